@@ -30,6 +30,7 @@ func NewBalancesCmd(io commands.IO) *commands.Command {
 		newBalancesAddCmd(cfg, io),
 		newBalancesRemoveCmd(cfg, io),
 		newBalancesExportCmd(cfg, io),
+		newBalancesImportCmd(cfg, io),
 	)
 
 	return cmd
@@ -0,0 +1,412 @@
+package balances
+
+import (
+	"context"
+	"encoding/csv"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gnolang/contribs/gnogenesis/internal/common"
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+var (
+	errNoImportFile       = errors.New("no import file specified")
+	errUnknownFormat      = errors.New("unknown import file format")
+	errDuplicateAccount   = errors.New("duplicate account")
+	errInvalidImportDenom = errors.New("denom not allowed")
+)
+
+const (
+	formatCSV  = "csv"
+	formatJSON = "json"
+)
+
+type balancesImportCfg struct {
+	rootCfg *balancesCfg
+
+	file          string
+	format        string
+	denom         string
+	allowedDenoms string
+	sumDuplicates bool
+}
+
+// newBalancesImportCmd creates the genesis balances import subcommand
+func newBalancesImportCmd(rootCfg *balancesCfg, io commands.IO) *commands.Command {
+	cfg := &balancesImportCfg{
+		rootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "import",
+			ShortUsage: "balances import [flags]",
+			ShortHelp:  "imports account balances from a CSV or JSON file",
+			LongHelp: `Imports account balances, like the ones of an airdrop, from a CSV or JSON file into the genesis.json.
+
+The CSV file has an <address>,<amount> record per account, optionally preceded by a header; lines starting with # are ignored.
+The JSON file is an array of {"address": <address>, "amount": <amount>} objects.
+
+The amount is either a list of coins, like "10ugnot", or a number in the default denom.
+The file is read as a stream, so that it can be large; the balances it sets take precedence over the ones in the genesis.json.`,
+		},
+		cfg,
+		func(ctx context.Context, _ []string) error {
+			return execBalancesImport(ctx, cfg, io)
+		},
+	)
+}
+
+func (c *balancesImportCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.file,
+		"file",
+		"",
+		"the path to the CSV or JSON file containing the account balances",
+	)
+
+	fs.StringVar(
+		&c.format,
+		"format",
+		"",
+		"the format of the file (csv or json); by default, it's set from the file extension",
+	)
+
+	fs.StringVar(
+		&c.denom,
+		"denom",
+		ugnot.Denom,
+		"the denom of the amounts given as a number",
+	)
+
+	fs.StringVar(
+		&c.allowedDenoms,
+		"allowed-denoms",
+		ugnot.Denom,
+		"the comma-separated list of the denoms allowed in the balances",
+	)
+
+	fs.BoolVar(
+		&c.sumDuplicates,
+		"sum-duplicates",
+		false,
+		"sum the amounts of the accounts listed several times, instead of failing",
+	)
+}
+
+// importEntry is an account balance read from an import file.
+type importEntry struct {
+	pos     string // the position in the file, for errors
+	address string
+	amount  string
+}
+
+// importStats summarizes a balances import.
+type importStats struct {
+	entries    int
+	duplicates int
+	added      int
+	updated    int
+	total      std.Coins
+}
+
+func execBalancesImport(ctx context.Context, cfg *balancesImportCfg, io commands.IO) error {
+	// Load the genesis
+	genesis, loadErr := types.GenesisDocFromFile(cfg.rootCfg.GenesisPath)
+	if loadErr != nil {
+		return fmt.Errorf("%w, %w", common.ErrUnableToLoadGenesis, loadErr)
+	}
+
+	if cfg.file == "" {
+		return errNoImportFile
+	}
+
+	format := cfg.format
+	if format == "" {
+		format = strings.TrimPrefix(strings.ToLower(filepath.Ext(cfg.file)), ".")
+	}
+
+	file, err := os.Open(cfg.file)
+	if err != nil {
+		return fmt.Errorf("unable to open import file, %w", err)
+	}
+	defer file.Close()
+
+	var next func() (importEntry, error)
+	switch format {
+	case formatCSV:
+		next = newCSVEntryReader(file)
+	case formatJSON:
+		next = newJSONEntryReader(file)
+	default:
+		return fmt.Errorf("%w: %q", errUnknownFormat, format)
+	}
+
+	balances, stats, err := importBalances(ctx, cfg, next)
+	if err != nil {
+		return err
+	}
+
+	// Initialize genesis app state if it is not initialized already
+	if genesis.AppState == nil {
+		genesis.AppState = gnoland.GnoGenesisState{}
+	}
+
+	// Construct the initial genesis balance sheet
+	state := genesis.AppState.(gnoland.GnoGenesisState)
+	genesisBalances, err := mapGenesisBalancesFromState(state)
+	if err != nil {
+		return err
+	}
+
+	for address := range balances {
+		if _, ok := genesisBalances[address]; ok {
+			stats.updated++
+		} else {
+			stats.added++
+		}
+	}
+
+	// Merge the two balance sheets, with the import
+	// having precedence over the genesis balances
+	balances.LeftMerge(genesisBalances)
+
+	state.Balances = balances.List()
+	genesis.AppState = state
+
+	// Save the updated genesis
+	if err := genesis.SaveAs(cfg.rootCfg.GenesisPath); err != nil {
+		return fmt.Errorf("unable to save genesis.json, %w", err)
+	}
+
+	io.Printfln("%d entries imported from %s", stats.entries, cfg.file)
+	io.Printfln("  accounts added:    %d", stats.added)
+	io.Printfln("  accounts updated:  %d", stats.updated)
+	io.Printfln("  duplicates summed: %d", stats.duplicates)
+	io.Printfln("  total amount:      %s", stats.total)
+
+	io.Println()
+
+	io.Printfln(
+		"%d balances saved",
+		len(balances),
+	)
+
+	return nil
+}
+
+// importBalances reads the balances of the entries returned by next.
+func importBalances(
+	ctx context.Context,
+	cfg *balancesImportCfg,
+	next func() (importEntry, error),
+) (gnoland.Balances, importStats, error) {
+	allowedDenoms := strings.Split(cfg.allowedDenoms, ",")
+	for i, denom := range allowedDenoms {
+		allowedDenoms[i] = strings.TrimSpace(denom)
+	}
+
+	var (
+		stats    importStats
+		balances = gnoland.NewBalances()
+		seen     = make(map[crypto.Address]string) // position of the first entry
+	)
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil, stats, errBalanceParsingAborted
+		default:
+		}
+
+		entry, err := next()
+		if errors.Is(err, io.EOF) {
+			return balances, stats, nil
+		}
+		if err != nil {
+			return nil, stats, fmt.Errorf("unable to read import file, %w", err)
+		}
+
+		balance, err := parseImportEntry(entry, cfg.denom, allowedDenoms)
+		if err != nil {
+			return nil, stats, fmt.Errorf("%s: %w", entry.pos, err)
+		}
+
+		stats.entries++
+		stats.total = stats.total.Add(balance.Amount)
+
+		if pos, ok := seen[balance.Address]; ok {
+			if !cfg.sumDuplicates {
+				return nil, stats, fmt.Errorf(
+					"%s: %w %s, first listed at %s",
+					entry.pos, errDuplicateAccount, balance.Address, pos,
+				)
+			}
+
+			stats.duplicates++
+			balance.Amount = balance.Amount.Add(balances[balance.Address].Amount)
+		} else {
+			seen[balance.Address] = entry.pos
+		}
+
+		balances[balance.Address] = balance
+	}
+}
+
+// parseImportEntry parses the balance of an import entry, with denom as the
+// denom of an amount given as a number, and validates its denoms.
+func parseImportEntry(entry importEntry, denom string, allowedDenoms []string) (gnoland.Balance, error) {
+	var (
+		balance gnoland.Balance
+		err     error
+	)
+
+	balance.Address, err = crypto.AddressFromBech32(strings.TrimSpace(entry.address))
+	if err != nil {
+		return balance, fmt.Errorf("%w %q, %w", errInvalidAddress, entry.address, err)
+	}
+
+	amount := strings.TrimSpace(entry.amount)
+	if amount != "" && strings.Trim(amount, "0123456789") == "" {
+		amount += denom
+	}
+
+	balance.Amount, err = std.ParseCoins(amount)
+	if err != nil {
+		return balance, fmt.Errorf("invalid amount %q, %w", entry.amount, err)
+	}
+	if balance.Amount.Empty() {
+		return balance, fmt.Errorf("invalid amount %q, no coins", entry.amount)
+	}
+
+	for _, coin := range balance.Amount {
+		if !slices.Contains(allowedDenoms, coin.Denom) {
+			return balance, fmt.Errorf("%w: %q", errInvalidImportDenom, coin.Denom)
+		}
+	}
+
+	return balance, nil
+}
+
+// newCSVEntryReader returns a function reading the next entry of a CSV
+// import file, which returns io.EOF once all the entries are read.
+func newCSVEntryReader(r io.Reader) func() (importEntry, error) {
+	reader := csv.NewReader(r)
+	reader.Comment = '#'
+	reader.FieldsPerRecord = -1 // checked below, for a clearer error
+	reader.TrimLeadingSpace = true
+	reader.ReuseRecord = true
+
+	first := true
+	return func() (importEntry, error) {
+		for {
+			record, err := reader.Read()
+			if err != nil {
+				return importEntry{}, err
+			}
+
+			line, _ := reader.FieldPos(0)
+			pos := fmt.Sprintf("line %d", line)
+
+			// Skip the header, if any
+			if first {
+				first = false
+				if strings.EqualFold(strings.TrimSpace(record[0]), "address") {
+					continue
+				}
+			}
+
+			if len(record) != 2 {
+				return importEntry{}, fmt.Errorf(
+					"%s: expected 2 fields (<address>,<amount>), got %d",
+					pos, len(record),
+				)
+			}
+
+			return importEntry{
+				pos:     pos,
+				address: record[0],
+				amount:  record[1],
+			}, nil
+		}
+	}
+}
+
+// jsonEntry is an entry of a JSON import file.
+type jsonEntry struct {
+	Address string     `json:"address"`
+	Amount  jsonAmount `json:"amount"`
+}
+
+// jsonAmount is an amount given as a string, or as a number.
+type jsonAmount string
+
+func (a *jsonAmount) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err == nil {
+		*a = jsonAmount(s)
+		return nil
+	}
+
+	var n json.Number
+	if err := json.Unmarshal(b, &n); err != nil {
+		return fmt.Errorf("amount %s is neither a string nor a number", b)
+	}
+
+	*a = jsonAmount(n)
+	return nil
+}
+
+// newJSONEntryReader returns a function reading the next entry of a JSON
+// import file, decoding its array one element at a time, which returns
+// io.EOF once all the entries are read.
+func newJSONEntryReader(r io.Reader) func() (importEntry, error) {
+	dec := json.NewDecoder(r)
+
+	index := 0
+	return func() (importEntry, error) {
+		if index == 0 {
+			tok, err := dec.Token()
+			if err != nil {
+				return importEntry{}, err
+			}
+			if tok != json.Delim('[') {
+				return importEntry{}, fmt.Errorf("expected an array of entries, got %v", tok)
+			}
+		}
+		index++
+
+		if !dec.More() {
+			if _, err := dec.Token(); err != nil { // ]
+				return importEntry{}, err
+			}
+			return importEntry{}, io.EOF
+		}
+
+		pos := fmt.Sprintf("entry %d", index)
+
+		var entry jsonEntry
+		if err := dec.Decode(&entry); err != nil {
+			return importEntry{}, fmt.Errorf("%s: %w", pos, err)
+		}
+
+		return importEntry{
+			pos:     pos,
+			address: entry.Address,
+			amount:  string(entry.Amount),
+		}, nil
+	}
+}
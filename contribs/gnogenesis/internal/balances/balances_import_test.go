@@ -0,0 +1,245 @@
+package balances
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/contribs/gnogenesis/internal/common"
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenesis_Balances_Import(t *testing.T) {
+	t.Parallel()
+
+	// setup saves a genesis with the given balances, and an import file
+	// with the given name and content, and returns their paths
+	setup := func(t *testing.T, balances []gnoland.Balance, name, content string) (string, string) {
+		t.Helper()
+
+		dir := t.TempDir()
+
+		genesis := common.DefaultGenesis()
+		genesis.AppState = gnoland.GnoGenesisState{Balances: balances}
+		genesisPath := filepath.Join(dir, "genesis.json")
+		require.NoError(t, genesis.SaveAs(genesisPath))
+
+		filePath := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(filePath, []byte(content), 0o644))
+
+		return genesisPath, filePath
+	}
+
+	// loadBalances loads the balances of the genesis at the given path
+	loadBalances := func(t *testing.T, genesisPath string) map[crypto.Address]std.Coins {
+		t.Helper()
+
+		genesis, err := types.GenesisDocFromFile(genesisPath)
+		require.NoError(t, err)
+
+		state, ok := genesis.AppState.(gnoland.GnoGenesisState)
+		require.True(t, ok)
+
+		balances := make(map[crypto.Address]std.Coins)
+		for _, balance := range state.Balances {
+			balances[balance.Address] = balance.Amount
+		}
+
+		return balances
+	}
+
+	run := func(genesisPath string, args ...string) (string, error) {
+		mockOut := new(strings.Builder)
+		io := commands.NewTestIO()
+		io.SetOut(commands.WriteNopCloser(mockOut))
+
+		cmd := NewBalancesCmd(io)
+		args = append([]string{"import", "--genesis-path", genesisPath}, args...)
+
+		err := cmd.ParseAndRun(context.Background(), args)
+		return mockOut.String(), err
+	}
+
+	keys := common.DummyKeys(t, 3)
+	addr := func(i int) crypto.Address { return keys[i].Address() }
+	coins := func(amount int64) std.Coins { return std.NewCoins(std.NewCoin(ugnot.Denom, amount)) }
+
+	t.Run("invalid genesis", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := run("dummy-path", "--file", "airdrop.csv")
+		assert.ErrorContains(t, err, common.ErrUnableToLoadGenesis.Error())
+	})
+
+	t.Run("no file", func(t *testing.T) {
+		t.Parallel()
+
+		genesisPath, _ := setup(t, nil, "airdrop.csv", "")
+
+		_, err := run(genesisPath)
+		assert.ErrorIs(t, err, errNoImportFile)
+	})
+
+	t.Run("unknown format", func(t *testing.T) {
+		t.Parallel()
+
+		genesisPath, filePath := setup(t, nil, "airdrop.txt", "")
+
+		_, err := run(genesisPath, "--file", filePath)
+		assert.ErrorIs(t, err, errUnknownFormat)
+	})
+
+	t.Run("csv", func(t *testing.T) {
+		t.Parallel()
+
+		content := fmt.Sprintf(`address,amount
+# the first accounts
+%s,100
+%s,"200ugnot"
+`, addr(0), addr(1))
+		genesisPath, filePath := setup(t, []gnoland.Balance{
+			{Address: addr(1), Amount: coins(1)},
+			{Address: addr(2), Amount: coins(2)},
+		}, "airdrop.csv", content)
+
+		out, err := run(genesisPath, "--file", filePath)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[crypto.Address]std.Coins{
+			addr(0): coins(100),
+			addr(1): coins(200), // the import has precedence
+			addr(2): coins(2),
+		}, loadBalances(t, genesisPath))
+
+		assert.Contains(t, out, "2 entries imported")
+		assert.Contains(t, out, "accounts added:    1")
+		assert.Contains(t, out, "accounts updated:  1")
+		assert.Contains(t, out, "total amount:      300ugnot")
+		assert.Contains(t, out, "3 balances saved")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		t.Parallel()
+
+		content := fmt.Sprintf(`[
+	{"address": %q, "amount": 100},
+	{"address": %q, "amount": "200ugnot"}
+]`, addr(0), addr(1))
+		genesisPath, filePath := setup(t, nil, "airdrop.json", content)
+
+		_, err := run(genesisPath, "--file", filePath)
+		require.NoError(t, err)
+
+		assert.Equal(t, map[crypto.Address]std.Coins{
+			addr(0): coins(100),
+			addr(1): coins(200),
+		}, loadBalances(t, genesisPath))
+	})
+
+	t.Run("duplicates", func(t *testing.T) {
+		t.Parallel()
+
+		content := fmt.Sprintf("%s,100\n%s,50\n%s,10\n", addr(0), addr(1), addr(0))
+
+		t.Run("rejected", func(t *testing.T) {
+			t.Parallel()
+
+			genesisPath, filePath := setup(t, nil, "airdrop.csv", content)
+
+			_, err := run(genesisPath, "--file", filePath)
+			assert.ErrorIs(t, err, errDuplicateAccount)
+			assert.ErrorContains(t, err, "line 3")
+			assert.ErrorContains(t, err, "first listed at line 1")
+		})
+
+		t.Run("summed", func(t *testing.T) {
+			t.Parallel()
+
+			genesisPath, filePath := setup(t, nil, "airdrop.csv", content)
+
+			out, err := run(genesisPath, "--file", filePath, "--sum-duplicates")
+			require.NoError(t, err)
+
+			assert.Equal(t, map[crypto.Address]std.Coins{
+				addr(0): coins(110),
+				addr(1): coins(50),
+			}, loadBalances(t, genesisPath))
+			assert.Contains(t, out, "duplicates summed: 1")
+		})
+	})
+
+	t.Run("invalid entries", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			name    string
+			content string
+			args    []string
+			errStr  string
+		}{
+			{
+				name:    "invalid address",
+				content: "g1invalid,100\n",
+				errStr:  errInvalidAddress.Error(),
+			},
+			{
+				name:    "zero amount",
+				content: addr(0).String() + ",0\n",
+				errStr:  "invalid amount",
+			},
+			{
+				name:    "denom not allowed",
+				content: addr(0).String() + ",100foo\n",
+				errStr:  errInvalidImportDenom.Error(),
+			},
+			{
+				name:    "other denom",
+				content: addr(0).String() + ",100\n",
+				args:    []string{"--denom", "foo"},
+				errStr:  errInvalidImportDenom.Error(),
+			},
+			{
+				name:    "missing amount",
+				content: addr(0).String() + "\n",
+				errStr:  "expected 2 fields",
+			},
+		}
+
+		for _, testCase := range testTable {
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				genesisPath, filePath := setup(t, nil, "airdrop.csv", testCase.content)
+
+				_, err := run(genesisPath, append([]string{"--file", filePath}, testCase.args...)...)
+				assert.ErrorContains(t, err, testCase.errStr)
+				assert.ErrorContains(t, err, "line 1")
+			})
+		}
+	})
+
+	t.Run("allowed denoms", func(t *testing.T) {
+		t.Parallel()
+
+		content := addr(0).String() + ",100\n" + addr(1).String() + ",\"5foo,10ugnot\"\n"
+		genesisPath, filePath := setup(t, nil, "airdrop.csv", content)
+
+		_, err := run(genesisPath, "--file", filePath, "--allowed-denoms", "ugnot,foo")
+		require.NoError(t, err)
+
+		assert.Equal(t, map[crypto.Address]std.Coins{
+			addr(0): coins(100),
+			addr(1): std.NewCoins(std.NewCoin("foo", 5), std.NewCoin(ugnot.Denom, 10)),
+		}, loadBalances(t, genesisPath))
+	})
+}
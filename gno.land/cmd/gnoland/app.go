@@ -0,0 +1,151 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"path/filepath"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/gno.land/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/bft/abci/server"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/events"
+	"go.uber.org/zap/zapcore"
+)
+
+type appCfg struct {
+	dataDir                    string
+	skipFailingGenesisTxs      bool
+	skipGenesisSigVerification bool
+
+	logLevel  string
+	logFormat string
+}
+
+func newAppCmd(io commands.IO) *commands.Command {
+	cfg := &appCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "app",
+			ShortUsage: "app [flags]",
+			ShortHelp:  "serves the Gnoland application to an out-of-process node",
+			LongHelp: `Serves the Gnoland application at the proxy_app address of the node
+configuration, over its abci transport (socket or grpc). The node is then
+started separately with "gnoland start -remote-app", so that the application
+can be restarted or upgraded independently of the consensus engine.
+
+The application events are not forwarded to the node.`,
+		},
+		cfg,
+		func(ctx context.Context, _ []string) error {
+			return execApp(ctx, cfg, io)
+		},
+	)
+}
+
+func (c *appCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.dataDir,
+		"data-dir",
+		defaultNodeDir,
+		"the path to the node's data directory",
+	)
+
+	fs.BoolVar(
+		&c.skipFailingGenesisTxs,
+		"skip-failing-genesis-txs",
+		false,
+		"don't panic when replaying invalid genesis txs",
+	)
+
+	fs.BoolVar(
+		&c.skipGenesisSigVerification,
+		"skip-genesis-sig-verification",
+		false,
+		"don't panic when replaying invalidly signed genesis txs",
+	)
+
+	fs.StringVar(
+		&c.logLevel,
+		"log-level",
+		zapcore.DebugLevel.String(),
+		"log level for the gnoland application",
+	)
+
+	fs.StringVar(
+		&c.logFormat,
+		"log-format",
+		log.ConsoleFormat.String(),
+		"log format for the gnoland application",
+	)
+}
+
+func execApp(ctx context.Context, c *appCfg, io commands.IO) error {
+	nodeDir, err := filepath.Abs(c.dataDir)
+	if err != nil {
+		return fmt.Errorf("unable to get absolute path for data directory, %w", err)
+	}
+
+	zapLogger, err := log.InitializeZapLogger(io.Out(), c.logLevel, c.logFormat)
+	if err != nil {
+		return fmt.Errorf("unable to initialize zap logger, %w", err)
+	}
+
+	defer func() {
+		// Sync the logger before exiting
+		_ = zapLogger.Sync()
+	}()
+
+	logger := log.ZapLoggerToSlog(zapLogger)
+
+	cfg, err := config.LoadConfig(nodeDir)
+	if err != nil {
+		return fmt.Errorf("%s, %w", tryConfigInit, err)
+	}
+
+	if cfg.ABCI == config.LocalABCI {
+		return fmt.Errorf("abci transport %q can't serve an out-of-process node", cfg.ABCI)
+	}
+
+	app, err := gnoland.NewApp(
+		nodeDir,
+		gnoland.GenesisAppConfig{
+			SkipFailingTxs:      c.skipFailingGenesisTxs,
+			SkipSigVerification: c.skipGenesisSigVerification,
+		},
+		cfg.Application,
+		events.NewEventSwitch(),
+		logger,
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create the Gnoland app, %w", err)
+	}
+
+	srv, err := server.NewServer(cfg.ProxyApp, cfg.ABCI, app)
+	if err != nil {
+		return fmt.Errorf("unable to create the ABCI server, %w", err)
+	}
+	srv.SetLogger(logger.With("module", "abci-server"))
+
+	if err := srv.Start(); err != nil {
+		return fmt.Errorf("unable to start the ABCI server, %w", err)
+	}
+
+	io.Printfln("Serving the Gnoland application at %s (%s)", cfg.ProxyApp, cfg.ABCI)
+
+	// Wait for the exit signal
+	<-ctx.Done()
+
+	if err := srv.Stop(); err != nil {
+		return fmt.Errorf("unable to gracefully stop the ABCI server, %w", err)
+	}
+
+	if err := app.Close(); err != nil {
+		return fmt.Errorf("unable to gracefully close the Gnoland application: %w", err)
+	}
+
+	return nil
+}
@@ -0,0 +1,300 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	signer "github.com/gnolang/gno/tm2/pkg/bft/privval/signer/local"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+)
+
+const (
+	// bootstrapTimeout is the timeout of the request fetching the bootstrap
+	// document.
+	bootstrapTimeout = time.Minute
+
+	// maxBootstrapBytes is the maximum size of a bootstrap document, which
+	// embeds the genesis.
+	maxBootstrapBytes = 256 << 20
+)
+
+var (
+	errBootstrapPubKeyMissing = errors.New("--bootstrap-url requires --bootstrap-pubkey, the key signing the bootstrap document")
+	errBootstrapNotHTTPS      = errors.New("the bootstrap URL must be an https URL")
+	errBootstrapSignature     = errors.New("invalid bootstrap document signature")
+)
+
+// bootstrapInfo is what a node needs to join a chain: its genesis, and the
+// seed nodes to find its peers.
+type bootstrapInfo struct {
+	ChainID string          `json:"chain_id"`
+	Seeds   []string        `json:"seeds"`   // "id@host:port"
+	Genesis json.RawMessage `json:"genesis"` // genesis.json
+}
+
+// signedBootstrap is the bootstrap document served at the bootstrap URL. The
+// signature is the one of the JSON encoding of the bootstrap info, so that
+// verifying it doesn't depend on re-encoding the info.
+type signedBootstrap struct {
+	Info      []byte `json:"info"`
+	Signature []byte `json:"signature"`
+}
+
+// fetchBootstrap fetches the bootstrap document at rawURL, and returns its
+// info if it's signed by pubKey.
+func fetchBootstrap(ctx context.Context, client *http.Client, rawURL string, pubKey crypto.PubKey) (*bootstrapInfo, error) {
+	u, err := url.Parse(rawURL)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bootstrap URL, %w", err)
+	}
+	if u.Scheme != "https" {
+		return nil, errBootstrapNotHTTPS
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, bootstrapTimeout)
+	defer cancel()
+
+	req, err := http.NewRequestWithContext(ctx, http.MethodGet, u.String(), nil)
+	if err != nil {
+		return nil, err
+	}
+	resp, err := client.Do(req)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch the bootstrap document, %w", err)
+	}
+	defer resp.Body.Close()
+	if resp.StatusCode != http.StatusOK {
+		return nil, fmt.Errorf("unable to fetch the bootstrap document: %s", resp.Status)
+	}
+	body, err := io.ReadAll(io.LimitReader(resp.Body, maxBootstrapBytes+1))
+	if err != nil {
+		return nil, fmt.Errorf("unable to read the bootstrap document, %w", err)
+	}
+	if len(body) > maxBootstrapBytes {
+		return nil, fmt.Errorf("bootstrap document is larger than %d bytes", maxBootstrapBytes)
+	}
+
+	return verifyBootstrap(body, pubKey)
+}
+
+// verifyBootstrap decodes the signed bootstrap document doc, and returns its
+// info if it's signed by pubKey.
+func verifyBootstrap(doc []byte, pubKey crypto.PubKey) (*bootstrapInfo, error) {
+	var signed signedBootstrap
+	if err := json.Unmarshal(doc, &signed); err != nil {
+		return nil, fmt.Errorf("unable to decode the bootstrap document, %w", err)
+	}
+	if !pubKey.VerifyBytes(signed.Info, signed.Signature) {
+		return nil, errBootstrapSignature
+	}
+
+	var info bootstrapInfo
+	if err := json.Unmarshal(signed.Info, &info); err != nil {
+		return nil, fmt.Errorf("unable to decode the bootstrap info, %w", err)
+	}
+	genesis, err := bft.GenesisDocFromJSON(info.Genesis)
+	if err != nil {
+		return nil, fmt.Errorf("invalid bootstrap genesis, %w", err)
+	}
+	if genesis.ChainID != info.ChainID {
+		return nil, fmt.Errorf("bootstrap genesis is for chain %q, not %q", genesis.ChainID, info.ChainID)
+	}
+	return &info, nil
+}
+
+// applyBootstrap writes the genesis of info to genesisPath, unless it's
+// already there, and adds its seeds to the ones of cfg, and of its file at
+// configPath, so that reloading it doesn't change them.
+func applyBootstrap(info *bootstrapInfo, genesisPath, configPath string, cfg *config.Config) error {
+	if osm.FileExists(genesisPath) {
+		existing, err := os.ReadFile(genesisPath)
+		if err != nil {
+			return fmt.Errorf("unable to read the genesis.json, %w", err)
+		}
+		if !bytes.Equal(existing, info.Genesis) {
+			return fmt.Errorf("the genesis.json at %q differs from the bootstrap one", genesisPath)
+		}
+	} else {
+		if err := os.MkdirAll(filepath.Dir(genesisPath), 0o755); err != nil {
+			return fmt.Errorf("unable to create the genesis.json directory, %w", err)
+		}
+		if err := os.WriteFile(genesisPath, info.Genesis, 0o644); err != nil {
+			return fmt.Errorf("unable to write the genesis.json, %w", err)
+		}
+	}
+
+	seeds := mergeSeeds(cfg.P2P.Seeds, info.Seeds)
+	if seeds == cfg.P2P.Seeds {
+		return nil
+	}
+	cfg.P2P.Seeds = seeds
+
+	fileCfg, err := config.LoadConfigFile(configPath)
+	if err != nil {
+		return fmt.Errorf("unable to load the config, %w", err)
+	}
+	fileCfg.P2P.Seeds = mergeSeeds(fileCfg.P2P.Seeds, info.Seeds)
+	if err := config.WriteConfigFile(configPath, fileCfg); err != nil {
+		return fmt.Errorf("unable to save the config, %w", err)
+	}
+	return nil
+}
+
+// mergeSeeds returns the comma separated list of seeds, with the new ones
+// added.
+func mergeSeeds(seeds string, newSeeds []string) string {
+	var merged []string
+	if seeds != "" {
+		merged = strings.Split(seeds, ",")
+	}
+	for _, seed := range newSeeds {
+		if !slices.Contains(merged, seed) {
+			merged = append(merged, seed)
+		}
+	}
+	return strings.Join(merged, ",")
+}
+
+type bootstrapSignCfg struct {
+	dataDir     string
+	genesisFile string
+	seeds       string
+	output      string
+}
+
+// newBootstrapCmd creates the bootstrap command
+func newBootstrapCmd(io commands.IO) *commands.Command {
+	cmd := commands.NewCommand(
+		commands.Metadata{
+			Name:       "bootstrap",
+			ShortUsage: "bootstrap <subcommand> [flags]",
+			ShortHelp:  "manages the bootstrap document of a chain",
+			LongHelp: "Manages the bootstrap document of a chain, served over HTTPS so that nodes can join it " +
+				"with `gnoland start --bootstrap-url <url> --bootstrap-pubkey <key>`",
+		},
+		commands.NewEmptyConfig(),
+		commands.HelpExec,
+	)
+
+	cmd.AddSubCommands(newBootstrapSignCmd(io))
+
+	return cmd
+}
+
+func newBootstrapSignCmd(io commands.IO) *commands.Command {
+	cfg := &bootstrapSignCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "sign",
+			ShortUsage: "bootstrap sign [flags]",
+			ShortHelp:  "writes a bootstrap document signed with the validator key",
+			LongHelp: "Writes a bootstrap document, made of the genesis.json and the seed nodes of the chain, " +
+				"signed with the validator key of the secrets directory. Its public key, shown by " +
+				"`gnoland secrets get validator_key.pub_key`, is the --bootstrap-pubkey of the joining nodes",
+		},
+		cfg,
+		func(_ context.Context, _ []string) error {
+			return execBootstrapSign(cfg, io)
+		},
+	)
+}
+
+func (c *bootstrapSignCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.dataDir,
+		"data-dir",
+		constructSecretsPath(defaultNodeDir),
+		"the secrets directory, with the validator key signing the document",
+	)
+
+	fs.StringVar(
+		&c.genesisFile,
+		"genesis",
+		"genesis.json",
+		"the path to the genesis.json",
+	)
+
+	fs.StringVar(
+		&c.seeds,
+		"seeds",
+		"",
+		"comma separated list of seed nodes, as id@host:port",
+	)
+
+	fs.StringVar(
+		&c.output,
+		"output",
+		"",
+		"the output file of the document; the standard output if empty",
+	)
+}
+
+func execBootstrapSign(c *bootstrapSignCfg, io commands.IO) error {
+	key, err := signer.LoadFileKey(filepath.Join(c.dataDir, defaultValidatorKeyName))
+	if err != nil {
+		return fmt.Errorf("unable to load the validator key, %w", err)
+	}
+
+	genesis, err := os.ReadFile(c.genesisFile)
+	if err != nil {
+		return fmt.Errorf("unable to read the genesis.json, %w", err)
+	}
+	genesisDoc, err := bft.GenesisDocFromJSON(genesis)
+	if err != nil {
+		return fmt.Errorf("invalid genesis.json, %w", err)
+	}
+
+	info := bootstrapInfo{
+		ChainID: genesisDoc.ChainID,
+		Seeds:   []string{},
+		Genesis: genesis,
+	}
+	if c.seeds != "" {
+		info.Seeds = strings.Split(c.seeds, ",")
+	}
+
+	doc, err := signBootstrap(info, key.PrivKey)
+	if err != nil {
+		return err
+	}
+
+	if c.output == "" {
+		io.Println(string(doc))
+		return nil
+	}
+	if err := os.WriteFile(c.output, doc, 0o644); err != nil {
+		return fmt.Errorf("unable to write the bootstrap document, %w", err)
+	}
+	io.Printfln("Bootstrap document of chain %q written to %s", info.ChainID, c.output)
+	return nil
+}
+
+// signBootstrap returns the bootstrap document of info, signed with privKey.
+func signBootstrap(info bootstrapInfo, privKey crypto.PrivKey) ([]byte, error) {
+	infoBytes, err := json.Marshal(info)
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode the bootstrap info, %w", err)
+	}
+	sig, err := privKey.Sign(infoBytes)
+	if err != nil {
+		return nil, fmt.Errorf("unable to sign the bootstrap info, %w", err)
+	}
+	return json.Marshal(signedBootstrap{Info: infoBytes, Signature: sig})
+}
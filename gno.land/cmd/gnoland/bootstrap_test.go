@@ -0,0 +1,186 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	signer "github.com/gnolang/gno/tm2/pkg/bft/privval/signer/local"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func testBootstrapGenesis(t *testing.T, chainID string) []byte {
+	t.Helper()
+
+	genesis := &bft.GenesisDoc{
+		GenesisTime: time.Date(2026, 1, 1, 0, 0, 0, 0, time.UTC),
+		ChainID:     chainID,
+	}
+	bz, err := amino.MarshalJSON(genesis)
+	require.NoError(t, err)
+	return bz
+}
+
+// serveBootstrap serves doc over HTTPS, returning its URL and a client
+// trusting the server.
+func serveBootstrap(t *testing.T, doc []byte) (string, *http.Client) {
+	t.Helper()
+
+	srv := httptest.NewTLSServer(http.HandlerFunc(func(w http.ResponseWriter, _ *http.Request) {
+		w.Write(doc)
+	}))
+	t.Cleanup(srv.Close)
+	return srv.URL, srv.Client()
+}
+
+func TestBootstrap_FetchAndApply(t *testing.T) {
+	t.Parallel()
+
+	key := ed25519.GenPrivKey()
+	info := bootstrapInfo{
+		ChainID: "test-chain",
+		Seeds:   []string{"g1seed@1.2.3.4:26656"},
+		Genesis: testBootstrapGenesis(t, "test-chain"),
+	}
+	doc, err := signBootstrap(info, key)
+	require.NoError(t, err)
+	url, client := serveBootstrap(t, doc)
+
+	fetched, err := fetchBootstrap(context.Background(), client, url, key.PubKey())
+	require.NoError(t, err)
+	assert.Equal(t, info.ChainID, fetched.ChainID)
+	assert.Equal(t, info.Seeds, fetched.Seeds)
+
+	// The genesis is written, and the seeds added to the configured ones.
+	var (
+		dir         = t.TempDir()
+		genesisPath = filepath.Join(dir, "config", "genesis.json")
+		configPath  = filepath.Join(dir, "config.toml")
+	)
+	cfg := config.DefaultConfig()
+	cfg.P2P.Seeds = "g1other@5.6.7.8:26656"
+	require.NoError(t, config.WriteConfigFile(configPath, cfg))
+	require.NoError(t, applyBootstrap(fetched, genesisPath, configPath, cfg))
+	assert.Equal(t, "g1other@5.6.7.8:26656,g1seed@1.2.3.4:26656", cfg.P2P.Seeds)
+	genesis, err := bft.GenesisDocFromFile(genesisPath)
+	require.NoError(t, err)
+	assert.Equal(t, "test-chain", genesis.ChainID)
+
+	// The seeds are saved, so that reloading the config keeps them.
+	fileCfg, err := config.LoadConfigFile(configPath)
+	require.NoError(t, err)
+	assert.Equal(t, cfg.P2P.Seeds, fileCfg.P2P.Seeds)
+
+	// Applying it again is a no-op.
+	require.NoError(t, applyBootstrap(fetched, genesisPath, configPath, cfg))
+	assert.Equal(t, "g1other@5.6.7.8:26656,g1seed@1.2.3.4:26656", cfg.P2P.Seeds)
+
+	// A different genesis isn't overwritten.
+	require.NoError(t, os.WriteFile(genesisPath, testBootstrapGenesis(t, "other-chain"), 0o644))
+	assert.ErrorContains(t, applyBootstrap(fetched, genesisPath, configPath, cfg), "differs from the bootstrap one")
+}
+
+func TestBootstrap_Invalid(t *testing.T) {
+	t.Parallel()
+
+	key := ed25519.GenPrivKey()
+	info := bootstrapInfo{
+		ChainID: "test-chain",
+		Genesis: testBootstrapGenesis(t, "test-chain"),
+	}
+	doc, err := signBootstrap(info, key)
+	require.NoError(t, err)
+
+	t.Run("wrong key", func(t *testing.T) {
+		t.Parallel()
+
+		url, client := serveBootstrap(t, doc)
+		_, err := fetchBootstrap(context.Background(), client, url, ed25519.GenPrivKey().PubKey())
+		assert.ErrorIs(t, err, errBootstrapSignature)
+	})
+
+	t.Run("tampered info", func(t *testing.T) {
+		t.Parallel()
+
+		tampered := bytes.Replace(doc, []byte(`"info":"`), []byte(`"info":"AA`), 1)
+		_, err := verifyBootstrap(tampered, key.PubKey())
+		assert.Error(t, err)
+	})
+
+	t.Run("chain ID mismatch", func(t *testing.T) {
+		t.Parallel()
+
+		other := info
+		other.ChainID = "other-chain"
+		doc, err := signBootstrap(other, key)
+		require.NoError(t, err)
+		_, err = verifyBootstrap(doc, key.PubKey())
+		assert.ErrorContains(t, err, `bootstrap genesis is for chain "test-chain", not "other-chain"`)
+	})
+
+	t.Run("not https", func(t *testing.T) {
+		t.Parallel()
+
+		_, err := fetchBootstrap(context.Background(), http.DefaultClient, "http://127.0.0.1/bootstrap.json", key.PubKey())
+		assert.ErrorIs(t, err, errBootstrapNotHTTPS)
+	})
+
+	t.Run("not found", func(t *testing.T) {
+		t.Parallel()
+
+		srv := httptest.NewTLSServer(http.NotFoundHandler())
+		t.Cleanup(srv.Close)
+		_, err := fetchBootstrap(context.Background(), srv.Client(), srv.URL, key.PubKey())
+		assert.ErrorContains(t, err, "404 Not Found")
+	})
+}
+
+func TestBootstrap_Sign(t *testing.T) {
+	t.Parallel()
+
+	var (
+		dir         = t.TempDir()
+		genesisPath = filepath.Join(dir, "genesis.json")
+		outputPath  = filepath.Join(dir, "bootstrap.json")
+	)
+
+	key, err := signer.GeneratePersistedFileKey(filepath.Join(dir, defaultValidatorKeyName))
+	require.NoError(t, err)
+	require.NoError(t, os.WriteFile(genesisPath, testBootstrapGenesis(t, "test-chain"), 0o644))
+
+	cmd := newRootCmd(commands.NewTestIO())
+	require.NoError(t, cmd.ParseAndRun(context.Background(), []string{
+		"bootstrap", "sign",
+		"--data-dir", dir,
+		"--genesis", genesisPath,
+		"--seeds", "g1a@1.1.1.1:26656,g1b@2.2.2.2:26656",
+		"--output", outputPath,
+	}))
+
+	doc, err := os.ReadFile(outputPath)
+	require.NoError(t, err)
+	info, err := verifyBootstrap(doc, key.PubKey)
+	require.NoError(t, err)
+	assert.Equal(t, "test-chain", info.ChainID)
+	assert.Equal(t, []string{"g1a@1.1.1.1:26656", "g1b@2.2.2.2:26656"}, info.Seeds)
+}
+
+func TestStart_BootstrapPubKeyMissing(t *testing.T) {
+	t.Parallel()
+
+	c := &startCfg{bootstrapURL: "https://example.com/bootstrap.json"}
+	dir := t.TempDir()
+	err := c.bootstrap(context.Background(), filepath.Join(dir, "genesis.json"), filepath.Join(dir, "config.toml"), config.DefaultConfig())
+	assert.ErrorIs(t, err, errBootstrapPubKeyMissing)
+}
@@ -273,6 +273,14 @@ func TestConfig_Get_Base(t *testing.T) {
 			},
 			true,
 		},
+		{
+			"log level fetched",
+			"log_level",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.LogLevel, unmarshalJSONCommon[string](t, value))
+			},
+			false,
+		},
 	}
 
 	verifyGetTestTableCommon(t, testTable)
@@ -754,10 +762,26 @@ func TestConfig_Get_RPC(t *testing.T) {
 			false,
 		},
 		{
-			"unsafe value",
-			"rpc.unsafe",
+			"operator listen address",
+			"rpc.operator_laddr",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.RPC.OperatorListenAddress, unmarshalJSONCommon[string](t, value))
+			},
+			false,
+		},
+		{
+			"operator auth token",
+			"rpc.operator_auth_token",
+			func(loadedCfg *config.Config, value []byte) {
+				assert.Equal(t, loadedCfg.RPC.OperatorAuthToken, unmarshalJSONCommon[string](t, value))
+			},
+			false,
+		},
+		{
+			"trace txs",
+			"rpc.trace_txs",
 			func(loadedCfg *config.Config, value []byte) {
-				assert.Equal(t, loadedCfg.RPC.Unsafe, unmarshalJSONCommon[bool](t, value))
+				assert.Equal(t, loadedCfg.RPC.TraceTxs, unmarshalJSONCommon[bool](t, value))
 			},
 			false,
 		},
@@ -236,6 +236,16 @@ func TestConfig_Set_Base(t *testing.T) {
 				assert.Equal(t, value, loadedCfg.ProfListenAddress)
 			},
 		},
+		{
+			"log level updated",
+			[]string{
+				"log_level",
+				"warn",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.LogLevel)
+			},
+		},
 	}
 
 	verifySetTestTableCommon(t, testTable)
@@ -647,16 +657,36 @@ func TestConfig_Set_RPC(t *testing.T) {
 			},
 		},
 		{
-			"unsafe value updated",
+			"operator listen address updated",
+			[]string{
+				"rpc.operator_laddr",
+				"unix:///tmp/operator.sock",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.RPC.OperatorListenAddress)
+			},
+		},
+		{
+			"operator auth token updated",
 			[]string{
-				"rpc.unsafe",
+				"rpc.operator_auth_token",
+				"secret",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, loadedCfg.RPC.OperatorAuthToken)
+			},
+		},
+		{
+			"trace txs updated",
+			[]string{
+				"rpc.trace_txs",
 				"true",
 			},
 			func(loadedCfg *config.Config, value string) {
 				boolVal, err := strconv.ParseBool(value)
 				require.NoError(t, err)
 
-				assert.Equal(t, boolVal, loadedCfg.RPC.Unsafe)
+				assert.Equal(t, boolVal, loadedCfg.RPC.TraceTxs)
 			},
 		},
 		{
@@ -833,6 +863,16 @@ func TestConfig_Set_Application(t *testing.T) {
 				assert.Equal(t, types.PruneStrategy(value), loadedCfg.Application.PruneStrategy)
 			},
 		},
+		{
+			"snapshot interval updated",
+			[]string{
+				"application.snapshot_interval",
+				"1000",
+			},
+			func(loadedCfg *config.Config, value string) {
+				assert.Equal(t, value, fmt.Sprint(loadedCfg.Application.SnapshotInterval))
+			},
+		},
 	}
 
 	verifySetTestTableCommon(t, testTable)
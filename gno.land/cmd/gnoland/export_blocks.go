@@ -0,0 +1,288 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	goio "io"
+	"os"
+	"path/filepath"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/node"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+const (
+	exportFormatJSON  = "json"
+	exportFormatProto = "proto"
+)
+
+var (
+	errInvalidExportFormat = errors.New("invalid export format")
+	errResumeWithoutOutput = errors.New("-resume requires -output")
+)
+
+type exportBlocksCfg struct {
+	dataDir string
+	from    int64
+	to      int64
+	format  string
+	output  string
+	results bool
+	resume  bool
+}
+
+// exportedBlock is a record of the export of gnoland export-blocks.
+type exportedBlock struct {
+	Height  int64             `json:"height"`
+	Block   *types.Block      `json:"block"`
+	Results *sm.ABCIResponses `json:"results,omitempty"`
+}
+
+func newExportBlocksCmd(io commands.IO) *commands.Command {
+	cfg := &exportBlocksCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "export-blocks",
+			ShortUsage: "export-blocks [flags]",
+			ShortHelp:  "exports the blocks of the node, with their results",
+			LongHelp: `Exports the blocks of the node in the height range [-from, -to], with
+the results of their transactions, for offline analysis. The node must be
+stopped, as its databases are opened directly.
+
+With -format json, each block is written as a JSON object on its own line.
+With -format proto, each block is written as a length-prefixed amino binary
+message, which is protobuf compatible.
+
+With -resume, the export continues after the last block already written to
+-output, so that it can be interrupted and restarted, or run periodically.`,
+		},
+		cfg,
+		func(_ context.Context, _ []string) error {
+			return execExportBlocks(cfg, io)
+		},
+	)
+}
+
+func (c *exportBlocksCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.dataDir,
+		"data-dir",
+		defaultNodeDir,
+		"the path to the node's data directory",
+	)
+
+	fs.Int64Var(
+		&c.from,
+		"from",
+		1,
+		"the height of the first block to export",
+	)
+
+	fs.Int64Var(
+		&c.to,
+		"to",
+		0,
+		"the height of the last block to export (0 for the latest)",
+	)
+
+	fs.StringVar(
+		&c.format,
+		"format",
+		exportFormatJSON,
+		"the format of the export (json or proto)",
+	)
+
+	fs.StringVar(
+		&c.output,
+		"output",
+		"",
+		"the file to write the export to (stdout if empty)",
+	)
+
+	fs.BoolVar(
+		&c.results,
+		"results",
+		true,
+		"export the results of the transactions of the blocks",
+	)
+
+	fs.BoolVar(
+		&c.resume,
+		"resume",
+		false,
+		"continue after the last block written to -output",
+	)
+}
+
+func execExportBlocks(c *exportBlocksCfg, io commands.IO) error {
+	if c.format != exportFormatJSON && c.format != exportFormatProto {
+		return fmt.Errorf("%w: %q", errInvalidExportFormat, c.format)
+	}
+	if c.resume && c.output == "" {
+		return errResumeWithoutOutput
+	}
+
+	nodeDir, err := filepath.Abs(c.dataDir)
+	if err != nil {
+		return fmt.Errorf("unable to get absolute path for data directory, %w", err)
+	}
+
+	cfg, err := config.LoadConfig(nodeDir)
+	if err != nil {
+		return fmt.Errorf("%s, %w", tryConfigInit, err)
+	}
+
+	// Open the output, and find where to resume from
+	from := c.from
+	w := io.Out()
+	if c.output != "" {
+		f, err := os.OpenFile(c.output, os.O_RDWR|os.O_CREATE, 0o644)
+		if err != nil {
+			return fmt.Errorf("unable to open the output, %w", err)
+		}
+		defer f.Close()
+
+		var offset int64
+		if c.resume {
+			var last int64
+			offset, last, err = scanExport(f, c.format)
+			if err != nil {
+				return fmt.Errorf("unable to resume from the output, %w", err)
+			}
+			from = max(from, last+1)
+		}
+
+		// Drop what follows the last complete record, if anything
+		if err := f.Truncate(offset); err != nil {
+			return fmt.Errorf("unable to truncate the output, %w", err)
+		}
+		if _, err := f.Seek(offset, goio.SeekStart); err != nil {
+			return fmt.Errorf("unable to seek the output, %w", err)
+		}
+		w = f
+	}
+
+	blockStoreDB, err := node.DefaultDBProvider(&node.DBContext{ID: "blockstore", Config: cfg})
+	if err != nil {
+		return fmt.Errorf("unable to open the block store, %w", err)
+	}
+	defer blockStoreDB.Close()
+
+	stateDB, err := node.DefaultDBProvider(&node.DBContext{ID: "state", Config: cfg})
+	if err != nil {
+		return fmt.Errorf("unable to open the state store, %w", err)
+	}
+	defer stateDB.Close()
+
+	// In archival mode, the old blocks and results are in the cold store
+	if cfg.Archive.Enabled() {
+		if blockStoreDB, err = node.NewArchiveDB(cfg, "blockstore", blockStoreDB); err != nil {
+			return fmt.Errorf("unable to open the block cold store, %w", err)
+		}
+		if stateDB, err = node.NewArchiveDB(cfg, "state", stateDB); err != nil {
+			return fmt.Errorf("unable to open the state cold store, %w", err)
+		}
+	}
+
+	bw := bufio.NewWriter(w)
+	blockStore := store.NewBlockStore(blockStoreDB)
+
+	var exported int
+	err = blockStore.IterateBlocks(from, c.to, func(_ *types.BlockMeta, block *types.Block) error {
+		record := exportedBlock{
+			Height: block.Height,
+			Block:  block,
+		}
+		if c.results {
+			results, err := sm.LoadABCIResponses(stateDB, block.Height)
+			if err != nil {
+				return fmt.Errorf("unable to load the results of block %d, %w", block.Height, err)
+			}
+			record.Results = results
+		}
+
+		if err := writeExportRecord(bw, c.format, record); err != nil {
+			return fmt.Errorf("unable to write block %d, %w", block.Height, err)
+		}
+		exported++
+
+		return nil
+	})
+	// Flush what was exported, even upon an error, so it can be resumed
+	if flushErr := bw.Flush(); err == nil && flushErr != nil {
+		err = fmt.Errorf("unable to write the output, %w", flushErr)
+	}
+	if err != nil {
+		return err
+	}
+
+	io.ErrPrintfln("Exported %d blocks", exported)
+
+	return nil
+}
+
+// writeExportRecord writes record to w in the given format.
+func writeExportRecord(w goio.Writer, format string, record exportedBlock) error {
+	if format == exportFormatProto {
+		_, err := amino.MarshalSizedWriter(w, record)
+		return err
+	}
+
+	bz, err := amino.MarshalJSON(record)
+	if err != nil {
+		return err
+	}
+	bz = append(bz, '\n')
+	_, err = w.Write(bz)
+
+	return err
+}
+
+// scanExport reads the records of an export in the given format from r, and
+// returns the offset following the last complete record, with its height
+// (0 if there is none).
+func scanExport(r goio.Reader, format string) (offset, height int64, err error) {
+	br := bufio.NewReader(r)
+
+	for {
+		var record exportedBlock
+
+		if format == exportFormatProto {
+			n, err := amino.UnmarshalSizedReader(br, &record, 0)
+			if err != nil {
+				if errors.Is(err, goio.EOF) || errors.Is(err, goio.ErrUnexpectedEOF) {
+					// The rest of the export is a partial record
+					return offset, height, nil
+				}
+				return 0, 0, fmt.Errorf("invalid record at offset %d, %w", offset, err)
+			}
+			offset += n
+			height = record.Height
+
+			continue
+		}
+
+		line, err := br.ReadBytes('\n')
+		if err != nil {
+			if errors.Is(err, goio.EOF) {
+				// The rest of the export is a partial record
+				return offset, height, nil
+			}
+			return 0, 0, err
+		}
+		if err := amino.UnmarshalJSON(line, &record); err != nil {
+			return 0, 0, fmt.Errorf("invalid record at offset %d, %w", offset, err)
+		}
+		offset += int64(len(line))
+		height = record.Height
+	}
+}
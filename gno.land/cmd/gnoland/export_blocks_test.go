@@ -0,0 +1,159 @@
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"context"
+	"errors"
+	goio "io"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
+	"github.com/gnolang/gno/tm2/pkg/bft/node"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+// saveTestBlocks saves the blocks up to the given height in the databases of
+// the node in nodeDir, with a result for each of their transactions.
+func saveTestBlocks(t *testing.T, nodeDir string, height int64) {
+	t.Helper()
+
+	cfg, err := config.LoadOrMakeConfigWithOptions(nodeDir)
+	require.NoError(t, err)
+
+	blockStoreDB, err := node.DefaultDBProvider(&node.DBContext{ID: "blockstore", Config: cfg})
+	require.NoError(t, err)
+	defer blockStoreDB.Close()
+
+	stateDB, err := node.DefaultDBProvider(&node.DBContext{ID: "state", Config: cfg})
+	require.NoError(t, err)
+	defer stateDB.Close()
+
+	blockStore := store.NewBlockStore(blockStoreDB)
+	for h := blockStore.Height() + 1; h <= height; h++ {
+		block := types.MakeBlock(h, []types.Tx{[]byte{byte(h)}}, new(types.Commit))
+		seenCommit := types.NewCommit(types.BlockID{}, []*types.CommitSig{{Height: h, Timestamp: time.Now()}})
+		blockStore.SaveBlock(block, block.MakePartSet(types.BlockPartSizeBytes), seenCommit)
+
+		sm.SaveABCIResponses(stateDB, h, &sm.ABCIResponses{
+			DeliverTxs: []abci.ResponseDeliverTx{{
+				ResponseBase: abci.ResponseBase{Data: []byte{byte(h)}},
+			}},
+		})
+	}
+}
+
+func runExportBlocks(t *testing.T, args ...string) error {
+	t.Helper()
+
+	cmd := newRootCmd(commands.NewTestIO())
+
+	return cmd.ParseAndRun(context.Background(), append([]string{"export-blocks"}, args...))
+}
+
+func exportedHeights(t *testing.T, path, format string) []int64 {
+	t.Helper()
+
+	bz, err := os.ReadFile(path)
+	require.NoError(t, err)
+
+	var (
+		heights []int64
+		r       = bufio.NewReader(bytes.NewReader(bz))
+	)
+	for {
+		if _, err := r.Peek(1); errors.Is(err, goio.EOF) {
+			return heights
+		}
+
+		var record exportedBlock
+		if format == exportFormatProto {
+			_, err = amino.UnmarshalSizedReader(r, &record, 0)
+		} else {
+			var line []byte
+			line, err = r.ReadBytes('\n')
+			require.NoError(t, err)
+			err = amino.UnmarshalJSON(line, &record)
+		}
+		require.NoError(t, err)
+
+		require.Equal(t, record.Height, record.Block.Height)
+		require.Len(t, record.Results.DeliverTxs, 1)
+		assert.Equal(t, []byte{byte(record.Height)}, record.Results.DeliverTxs[0].Data)
+
+		heights = append(heights, record.Height)
+	}
+}
+
+func TestExportBlocks_InvalidFlags(t *testing.T) {
+	t.Parallel()
+
+	err := runExportBlocks(t, "-format", "xml")
+	assert.ErrorIs(t, err, errInvalidExportFormat)
+
+	err = runExportBlocks(t, "-resume")
+	assert.ErrorIs(t, err, errResumeWithoutOutput)
+
+	err = runExportBlocks(t, "-data-dir", t.TempDir())
+	assert.ErrorContains(t, err, tryConfigInit)
+}
+
+func TestExportBlocks(t *testing.T) {
+	t.Parallel()
+
+	for _, format := range []string{exportFormatJSON, exportFormatProto} {
+		t.Run(format, func(t *testing.T) {
+			t.Parallel()
+
+			var (
+				nodeDir = t.TempDir()
+				output  = filepath.Join(t.TempDir(), "blocks")
+			)
+			saveTestBlocks(t, nodeDir, 5)
+
+			// Export a range
+			require.NoError(t, runExportBlocks(t,
+				"-data-dir", nodeDir, "-format", format, "-output", output,
+				"-from", "2", "-to", "3",
+			))
+			assert.Equal(t, []int64{2, 3}, exportedHeights(t, output, format))
+
+			// Resume up to the latest height
+			require.NoError(t, runExportBlocks(t,
+				"-data-dir", nodeDir, "-format", format, "-output", output, "-resume",
+			))
+			assert.Equal(t, []int64{2, 3, 4, 5}, exportedHeights(t, output, format))
+
+			// Resume after an interrupted write, and new blocks
+			f, err := os.OpenFile(output, os.O_APPEND|os.O_WRONLY, 0)
+			require.NoError(t, err)
+			_, err = f.Write([]byte{0x7f, '{'})
+			require.NoError(t, err)
+			require.NoError(t, f.Close())
+			saveTestBlocks(t, nodeDir, 7)
+
+			require.NoError(t, runExportBlocks(t,
+				"-data-dir", nodeDir, "-format", format, "-output", output, "-resume",
+			))
+			assert.Equal(t, []int64{2, 3, 4, 5, 6, 7}, exportedHeights(t, output, format))
+
+			// Without -resume, the output is overwritten
+			require.NoError(t, runExportBlocks(t,
+				"-data-dir", nodeDir, "-format", format, "-output", output,
+				"-from", "7",
+			))
+			assert.Equal(t, []int64{7}, exportedHeights(t, output, format))
+		})
+	}
+}
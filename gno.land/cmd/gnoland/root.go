@@ -36,8 +36,11 @@ func newRootCmd(io commands.IO) *commands.Command {
 
 	cmd.AddSubCommands(
 		newStartCmd(io),
+		newAppCmd(io),
 		newSecretsCmd(io),
 		newConfigCmd(io),
+		newExportBlocksCmd(io),
+		newBootstrapCmd(io),
 	)
 
 	return cmd
@@ -5,8 +5,12 @@ import (
 	"errors"
 	"flag"
 	"fmt"
+	"net/http"
+	"os"
+	"os/signal"
 	"path/filepath"
 	"strings"
+	"syscall"
 	"time"
 
 	"github.com/gnolang/gno/gno.land/pkg/gnoland"
@@ -25,6 +29,7 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/telemetry"
+	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
 )
 
@@ -54,6 +59,12 @@ type startCfg struct {
 	chainID                    string
 	dataDir                    string
 	lazyInit                   bool
+	remoteApp                  bool
+	bootstrapURL               string
+	bootstrapPubKey            string
+
+	// httpClient fetches the bootstrap document; http.DefaultClient if nil.
+	httpClient *http.Client
 
 	logLevel  string
 	logFormat string
@@ -163,6 +174,27 @@ func (c *startCfg) RegisterFlags(fs *flag.FlagSet) {
 		false,
 		"flag indicating if lazy init is enabled. Generates the node secrets, configuration, and genesis.json",
 	)
+
+	fs.BoolVar(
+		&c.remoteApp,
+		"remote-app",
+		false,
+		"connect to the application served by `gnoland app` at proxy_app, instead of running it in-process",
+	)
+
+	fs.StringVar(
+		&c.bootstrapURL,
+		"bootstrap-url",
+		"",
+		"https URL of the signed bootstrap document of the chain to join, providing its genesis.json and seed nodes",
+	)
+
+	fs.StringVar(
+		&c.bootstrapPubKey,
+		"bootstrap-pubkey",
+		"",
+		"public key (bech32) signing the bootstrap document",
+	)
 }
 
 func execStart(ctx context.Context, c *startCfg, io commands.IO) error {
@@ -178,12 +210,15 @@ func execStart(ctx context.Context, c *startCfg, io commands.IO) error {
 		return fmt.Errorf("unable to get absolute path for the genesis.json, %w", err)
 	}
 
-	// Initialize the logger
-	zapLogger, err := log.InitializeZapLogger(io.Out(), c.logLevel, c.logFormat)
+	// Initialize the logger, with a level which can be changed
+	// by reloading the config
+	logLevel, err := zap.ParseAtomicLevel(c.logLevel)
 	if err != nil {
-		return fmt.Errorf("unable to initialize zap logger, %w", err)
+		return fmt.Errorf("unable to parse log level, %w", err)
 	}
 
+	zapLogger := log.GetZapLoggerFn(log.Format(strings.ToLower(c.logFormat)))(io.Out(), logLevel)
+
 	defer func() {
 		// Sync the logger before exiting
 		_ = zapLogger.Sync()
@@ -204,6 +239,18 @@ func execStart(ctx context.Context, c *startCfg, io commands.IO) error {
 		return fmt.Errorf("%s, %w", tryConfigInit, err)
 	}
 
+	// The log level of the config overrides the one of the flags
+	if err := setLogLevel(logLevel, cfg.LogLevel); err != nil {
+		return err
+	}
+
+	// Fetch the genesis.json and the seeds of the chain to join, if any
+	if c.bootstrapURL != "" {
+		if err := c.bootstrap(ctx, genesisPath, constructConfigPath(nodeDir), cfg); err != nil {
+			return err
+		}
+	}
+
 	// Check if the genesis.json exists
 	if !osm.FileExists(genesisPath) {
 		if !c.lazyInit {
@@ -236,18 +283,24 @@ func execStart(ctx context.Context, c *startCfg, io commands.IO) error {
 	evsw := events.NewEventSwitch()
 
 	// Create application and node
-	cfg.LocalApp, err = gnoland.NewApp(
-		nodeDir,
-		gnoland.GenesisAppConfig{
-			SkipFailingTxs:      c.skipFailingGenesisTxs,
-			SkipSigVerification: c.skipGenesisSigVerification,
-		},
-		cfg.Application,
-		evsw,
-		logger,
-	)
-	if err != nil {
-		return fmt.Errorf("unable to create the Gnoland app, %w", err)
+	if c.remoteApp {
+		if cfg.ABCI == config.LocalABCI {
+			return fmt.Errorf("abci transport %q can't connect to a remote application", cfg.ABCI)
+		}
+	} else {
+		cfg.LocalApp, err = gnoland.NewApp(
+			nodeDir,
+			gnoland.GenesisAppConfig{
+				SkipFailingTxs:      c.skipFailingGenesisTxs,
+				SkipSigVerification: c.skipGenesisSigVerification,
+			},
+			cfg.Application,
+			evsw,
+			logger,
+		)
+		if err != nil {
+			return fmt.Errorf("unable to create the Gnoland app, %w", err)
+		}
 	}
 
 	// Create a default node, with the given setup
@@ -256,13 +309,30 @@ func execStart(ctx context.Context, c *startCfg, io commands.IO) error {
 		return fmt.Errorf("unable to create the Gnoland node, %w", err)
 	}
 
+	gnoNode.OnReload(func(newConfig *config.Config) error {
+		return setLogLevel(logLevel, newConfig.LogLevel)
+	})
+
 	// Start the node (async)
 	if err := gnoNode.Start(); err != nil {
 		return fmt.Errorf("unable to start the Gnoland node, %w", err)
 	}
 
+	// Reload the config on SIGHUP
+	reloadCh := make(chan os.Signal, 1)
+	signal.Notify(reloadCh, syscall.SIGHUP)
+	defer signal.Stop(reloadCh)
+
 	// Wait for the exit signal
-	<-ctx.Done()
+	for ctx.Err() == nil {
+		select {
+		case <-ctx.Done():
+		case <-reloadCh:
+			if _, err := gnoNode.ReloadConfigFile(); err != nil {
+				logger.Error("Unable to reload the config", "err", err)
+			}
+		}
+	}
 
 	if !gnoNode.IsRunning() {
 		return nil
@@ -274,6 +344,9 @@ func execStart(ctx context.Context, c *startCfg, io commands.IO) error {
 	}
 
 	// Gracefully stop the app
+	if cfg.LocalApp == nil {
+		return nil
+	}
 	if err = cfg.LocalApp.Close(); err != nil {
 		return fmt.Errorf("unable to gracefully close the Gnoland application: %w", err)
 	}
@@ -281,8 +354,53 @@ func execStart(ctx context.Context, c *startCfg, io commands.IO) error {
 	return nil
 }
 
+// bootstrap fetches the bootstrap document at the bootstrap URL, and applies
+// it to the node's genesis.json and configuration
+func (c *startCfg) bootstrap(ctx context.Context, genesisPath, configPath string, cfg *config.Config) error {
+	if c.bootstrapPubKey == "" {
+		return errBootstrapPubKeyMissing
+	}
+
+	pubKey, err := crypto.PubKeyFromBech32(c.bootstrapPubKey)
+	if err != nil {
+		return fmt.Errorf("invalid bootstrap public key, %w", err)
+	}
+
+	client := c.httpClient
+	if client == nil {
+		client = http.DefaultClient
+	}
+
+	info, err := fetchBootstrap(ctx, client, c.bootstrapURL, pubKey)
+	if err != nil {
+		return err
+	}
+
+	if err := applyBootstrap(info, genesisPath, configPath, cfg); err != nil {
+		return fmt.Errorf("unable to apply the bootstrap document, %w", err)
+	}
+
+	return nil
+}
+
 // lazyInitNodeDir initializes new secrets, and a default configuration
 // in the given node directory, if not present
+// setLogLevel sets the log level to the given one, if any
+func setLogLevel(logLevel zap.AtomicLevel, level string) error {
+	if level == "" {
+		return nil
+	}
+
+	l, err := zapcore.ParseLevel(level)
+	if err != nil {
+		return fmt.Errorf("unable to parse log level, %w", err)
+	}
+
+	logLevel.SetLevel(l)
+
+	return nil
+}
+
 func lazyInitNodeDir(io commands.IO, nodeDir string) error {
 	var (
 		configPath  = constructConfigPath(nodeDir)
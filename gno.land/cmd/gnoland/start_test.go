@@ -10,6 +10,7 @@ import (
 	"testing"
 	"time"
 
+	"github.com/gnolang/gno/tm2/pkg/bft/config"
 	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/stretchr/testify/assert"
@@ -199,3 +200,84 @@ func TestStart_Lazy(t *testing.T) {
 		})
 	}
 }
+
+func TestStart_RemoteApp(t *testing.T) {
+	// Running a full node is cpu consuming
+	// Do run this one in parallel
+	// t.Parallel()
+
+	const maxTestDeadline = time.Minute
+
+	for _, transport := range []string{config.SocketABCI, config.GRPCABCI} {
+		t.Run(transport, func(t *testing.T) {
+			// Use short paths to avoid > 120 char socket paths.
+			sockDir, err := os.MkdirTemp("/tmp", "socktest-*")
+			require.NoError(t, err)
+			t.Cleanup(func() { os.RemoveAll(sockDir) })
+
+			var (
+				nodeDir     = t.TempDir()
+				genesisFile = filepath.Join(nodeDir, "test_genesis.json")
+				rpcAddr     = fmt.Sprintf("unix://%s", filepath.Join(sockDir, "rpc.sock"))
+				appAddr     = fmt.Sprintf("unix://%s", filepath.Join(sockDir, "app.sock"))
+			)
+
+			// Prepare the config
+			prepareNodeRPC(t, nodeDir, rpcAddr)
+
+			newIO := func() commands.IO {
+				io := commands.NewTestIO()
+				io.SetOut(commands.WriteNopCloser(new(bytes.Buffer)))
+				io.SetErr(commands.WriteNopCloser(new(bytes.Buffer)))
+				return io
+			}
+
+			path := constructConfigPath(nodeDir)
+			for key, value := range map[string]string{"proxy_app": appAddr, "abci": transport} {
+				args := []string{"config", "set", "--config-path", path, key, value}
+				require.NoError(t, newRootCmd(newIO()).ParseAndRun(context.Background(), args))
+			}
+
+			deadline := time.Now().Add(maxTestDeadline)
+			ctx, cancelFn := context.WithDeadline(context.Background(), deadline)
+			defer cancelFn()
+
+			g, gCtx := errgroup.WithContext(ctx)
+
+			// Start the application, then the node connecting to it
+			g.Go(func() error {
+				return newRootCmd(newIO()).ParseAndRun(gCtx, []string{
+					"app",
+					"--skip-failing-genesis-txs",
+					"--data-dir", nodeDir,
+				})
+			})
+
+			g.Go(func() error {
+				defer cancelFn()
+				return newRootCmd(newIO()).ParseAndRun(gCtx, []string{
+					"start",
+					"--lazy",
+					"--remote-app",
+					"--data-dir", nodeDir,
+					"--genesis", genesisFile,
+				})
+			})
+
+			cli, err := client.NewHTTPClient(rpcAddr)
+			require.NoError(t, err)
+
+			// Check the genesis packages were loaded by the remote application
+			require.EventuallyWithT(t, func(c *assert.CollectT) {
+				qres, qerr := cli.ABCIQuery(gCtx, "vm/qpaths", []byte("gno.land"))
+				require.NoError(c, qerr)
+				require.NoError(c, qres.Response.Error)
+				paths := strings.Split(string(qres.Response.Data), "\n")
+				require.Greater(c, len(paths), 1, "query qpaths: no package has been loaded")
+			}, time.Until(deadline), time.Millisecond*500, "rpc: unable to call rpc vm/qpaths")
+
+			cancelFn() // stop the node and the application
+			require.NoError(t, g.Wait())
+		})
+	}
+}
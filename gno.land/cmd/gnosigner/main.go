@@ -0,0 +1,165 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"net"
+	"net/http"
+	"os"
+	"os/signal"
+	"strings"
+	"syscall"
+	"time"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnosigner"
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+
+	// The messages of gno.land, to decode the transactions.
+	_ "github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	_ "github.com/gnolang/gno/tm2/pkg/sdk/bank"
+)
+
+type signerCfg struct {
+	home                  string
+	listen                string
+	chainID               string
+	allowedOrigins        string
+	insecurePasswordStdin bool
+}
+
+var defaultSignerOptions = signerCfg{
+	home:    gnoenv.HomeDir(),
+	listen:  "127.0.0.1:8765",
+	chainID: "dev",
+}
+
+func main() {
+	stdio := commands.NewDefaultIO()
+	cmd := newSignerCmd(stdio)
+	cmd.Execute(context.Background(), os.Args[1:])
+}
+
+func newSignerCmd(io commands.IO) *commands.Command {
+	var cfg signerCfg
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "gnosigner",
+			ShortUsage: "gnosigner [flags] <key-name or address>",
+			ShortHelp:  "signs the transactions of web dapps with a gnokey key",
+			LongHelp: "Serves, on a local WebSocket, a protocol by which web dapps request " +
+				"the address of the key and the signatures of transactions. " +
+				"Each connection and each transaction must be approved in the terminal.",
+		},
+		&cfg,
+		func(ctx context.Context, args []string) error {
+			return execSigner(ctx, &cfg, args, io)
+		},
+	)
+}
+
+func (c *signerCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.home,
+		"home",
+		defaultSignerOptions.home,
+		"home directory of the keybase",
+	)
+
+	fs.StringVar(
+		&c.listen,
+		"listen",
+		defaultSignerOptions.listen,
+		"local address of the WebSocket listener",
+	)
+
+	fs.StringVar(
+		&c.chainID,
+		"chainid",
+		defaultSignerOptions.chainID,
+		"the ID of the chain of the transactions",
+	)
+
+	fs.StringVar(
+		&c.allowedOrigins,
+		"allowed-origins",
+		defaultSignerOptions.allowedOrigins,
+		"comma-separated list of the origins of the dapps which can connect, like https://gno.land, or * for all (required)",
+	)
+
+	fs.BoolVar(
+		&c.insecurePasswordStdin,
+		"insecure-password-stdin",
+		defaultSignerOptions.insecurePasswordStdin,
+		"WARNING! take password from stdin",
+	)
+}
+
+func execSigner(ctx context.Context, cfg *signerCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+	if cfg.allowedOrigins == "" {
+		return errors.New("no allowed origins, set -allowed-origins to the origins of the dapps")
+	}
+	if err := checkLoopback(cfg.listen); err != nil {
+		return err
+	}
+
+	kb, err := keys.NewKeyBaseFromDir(cfg.home)
+	if err != nil {
+		return fmt.Errorf("unable to load keybase, %w", err)
+	}
+	signerConfig := gnosigner.Config{
+		KeyName:               args[0],
+		ChainID:               cfg.chainID,
+		AllowedOrigins:        strings.Split(cfg.allowedOrigins, ","),
+		InsecurePasswordStdin: cfg.insecurePasswordStdin,
+	}
+	signer, err := gnosigner.NewSigner(signerConfig, kb, io)
+	if err != nil {
+		return err
+	}
+
+	ln, err := net.Listen("tcp", cfg.listen)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s, %w", cfg.listen, err)
+	}
+	server := &http.Server{
+		Handler:           signer,
+		ReadHeaderTimeout: 10 * time.Second,
+	}
+
+	ctx, stop := signal.NotifyContext(ctx, os.Interrupt, syscall.SIGTERM)
+	defer stop()
+	go func() {
+		<-ctx.Done()
+		server.Close()
+	}()
+
+	io.ErrPrintfln("Serving signature requests for %s on ws://%s", args[0], ln.Addr())
+	if err := server.Serve(ln); !errors.Is(err, http.ErrServerClosed) {
+		return err
+	}
+	return nil
+}
+
+// checkLoopback returns an error if the address isn't a loopback address, so
+// that the signer can't be reached from the network.
+func checkLoopback(addr string) error {
+	host, _, err := net.SplitHostPort(addr)
+	if err != nil {
+		return fmt.Errorf("invalid listen address %q, %w", addr, err)
+	}
+	if host == "localhost" {
+		return nil
+	}
+	if ip := net.ParseIP(host); ip == nil || !ip.IsLoopback() {
+		return fmt.Errorf("refusing to listen on %q, which isn't a loopback address", addr)
+	}
+	return nil
+}
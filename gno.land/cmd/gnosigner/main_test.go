@@ -0,0 +1,40 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckLoopback(t *testing.T) {
+	t.Parallel()
+
+	for _, addr := range []string{"127.0.0.1:8765", "[::1]:8765", "localhost:0"} {
+		assert.NoError(t, checkLoopback(addr), addr)
+	}
+	for _, addr := range []string{"0.0.0.0:8765", ":8765", "192.168.1.2:8765", "example.com:80", "127.0.0.1"} {
+		assert.Error(t, checkLoopback(addr), addr)
+	}
+}
+
+func TestSignerCmd(t *testing.T) {
+	t.Parallel()
+
+	run := func(args ...string) error {
+		return newSignerCmd(commands.NewTestIO()).ParseAndRun(context.Background(), args)
+	}
+	require.ErrorIs(t, run(), flag.ErrHelp)
+
+	err := run("-home", t.TempDir(), "-listen", "127.0.0.1:0", "alice")
+	require.ErrorContains(t, err, "no allowed origins")
+
+	err = run("-home", t.TempDir(), "-allowed-origins", "*", "-listen", ":8765", "alice")
+	require.ErrorContains(t, err, "isn't a loopback address")
+
+	err = run("-home", t.TempDir(), "-allowed-origins", "https://gno.land", "-listen", "127.0.0.1:0", "alice")
+	require.ErrorContains(t, err, "unable to get key from keybase")
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/hex"
 	"flag"
 	"fmt"
 	"maps"
@@ -13,6 +14,7 @@ import (
 
 	"github.com/gnolang/gno/gno.land/pkg/gnoweb"
 	"github.com/gnolang/gno/gno.land/pkg/log"
+	rpchttp "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/client/http"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"go.uber.org/zap"
 	"go.uber.org/zap/zapcore"
@@ -46,10 +48,12 @@ type webCfg struct {
 	chainid          string
 	remote           string
 	remoteTimeout    time.Duration
+	remoteIdleConns  int
 	remoteHelp       string
 	bind             string
 	faucetURL        string
 	aliases          string
+	maxRenderSize    int
 	noDefaultAliases bool
 	noCache          bool
 	timeout          time.Duration
@@ -58,14 +62,19 @@ type webCfg struct {
 	html             bool
 	noStrict         bool
 	verbose          bool
+	verify           bool
+	verifyHeight     int64
+	verifyHash       string
 }
 
 var defaultWebOptions = webCfg{
-	chainid:       "dev",
-	remote:        "127.0.0.1:26657",
-	bind:          ":8888",
-	remoteTimeout: time.Minute,
-	timeout:       time.Minute,
+	chainid:         "dev",
+	remote:          "127.0.0.1:26657",
+	bind:            ":8888",
+	remoteTimeout:   time.Minute,
+	remoteIdleConns: rpchttp.DefaultMaxIdleConns,
+	timeout:         time.Minute,
+	maxRenderSize:   gnoweb.DefaultMaxRenderSize,
 }
 
 func main() {
@@ -107,6 +116,13 @@ func (c *webCfg) RegisterFlags(fs *flag.FlagSet) {
 		"defined how much time a request to the node should live before timeout",
 	)
 
+	fs.IntVar(
+		&c.remoteIdleConns,
+		"remote-max-idle-conns",
+		defaultWebOptions.remoteIdleConns,
+		"maximum number of idle connections kept open to the node, reused by the requests",
+	)
+
 	fs.StringVar(
 		&c.remoteHelp,
 		"help-remote",
@@ -156,6 +172,13 @@ func (c *webCfg) RegisterFlags(fs *flag.FlagSet) {
 		"The faucet URL will redirect the user when they access `/faucet`.",
 	)
 
+	fs.IntVar(
+		&c.maxRenderSize,
+		"max-render-size",
+		defaultWebOptions.maxRenderSize,
+		"maximum size in bytes of a realm render converted to HTML, larger renders are truncated (0 for no limit)",
+	)
+
 	fs.BoolVar(
 		&c.json,
 		"json",
@@ -198,6 +221,27 @@ func (c *webCfg) RegisterFlags(fs *flag.FlagSet) {
 		"verbose logging mode",
 	)
 
+	fs.BoolVar(
+		&c.verify,
+		"verify",
+		defaultWebOptions.verify,
+		"verify the responses of the node with an embedded light client, and mark pages with the height their content was proven at",
+	)
+
+	fs.Int64Var(
+		&c.verifyHeight,
+		"verify-trust-height",
+		defaultWebOptions.verifyHeight,
+		"height of the header first trusted by the light client (0 for the latest one)",
+	)
+
+	fs.StringVar(
+		&c.verifyHash,
+		"verify-trust-hash",
+		defaultWebOptions.verifyHash,
+		"hex-encoded hash of the header first trusted by the light client; if empty, the header of the node is trusted on first use",
+	)
+
 	fs.DurationVar(
 		&c.timeout,
 		"timeout",
@@ -227,6 +271,7 @@ func setupWeb(cfg *webCfg, _ []string, io commands.IO) (func() error, error) {
 	appcfg.ChainID = cfg.chainid
 	appcfg.NodeRemote = cfg.remote
 	appcfg.NodeRequestTimeout = cfg.remoteTimeout
+	appcfg.NodeMaxIdleConns = cfg.remoteIdleConns
 	appcfg.RemoteHelp = cfg.remoteHelp
 	if appcfg.RemoteHelp == "" {
 		appcfg.RemoteHelp = appcfg.NodeRemote
@@ -234,6 +279,16 @@ func setupWeb(cfg *webCfg, _ []string, io commands.IO) (func() error, error) {
 	appcfg.Analytics = cfg.analytics
 	appcfg.UnsafeHTML = cfg.html
 	appcfg.FaucetURL = cfg.faucetURL
+	appcfg.MaxRenderSize = cfg.maxRenderSize
+	appcfg.Verify = cfg.verify
+	appcfg.VerifyTrustHeight = cfg.verifyHeight
+	if cfg.verifyHash != "" {
+		hash, err := hex.DecodeString(cfg.verifyHash)
+		if err != nil {
+			return nil, fmt.Errorf("invalid trusted header hash: %w", err)
+		}
+		appcfg.VerifyTrustHash = hash
+	}
 
 	if cfg.noDefaultAliases {
 		appcfg.Aliases = map[string]gnoweb.AliasTarget{}
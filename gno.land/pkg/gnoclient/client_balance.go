@@ -0,0 +1,144 @@
+package gnoclient
+
+import (
+	"context"
+	"time"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+var ErrInvalidBalanceMinimum = errors.New("invalid balance minimum provided")
+
+// BalanceWatchCfg contains the configuration of a balance watch.
+type BalanceWatchCfg struct {
+	Address      crypto.Address // Watched account address
+	Minimum      std.Coins      // Minimum balance of each denom
+	FromHeight   int64          // First block inspected, the next block if 0
+	PollInterval time.Duration  // Interval between latest height queries, 1s if 0
+}
+
+// BalanceAlert is sent when the balance of the watched account falls under
+// the minimum of one of its denoms.
+type BalanceAlert struct {
+	Height  int64          // Height of the block the balance changed in
+	Address crypto.Address // Watched account address
+	Balance std.Coins      // Resulting balance of the account
+	Below   std.Coins      // Minimums the balance falls under
+}
+
+// WatchBalance calls alert each time the balance of cfg.Address falls under
+// one of the minimums of cfg.Minimum, until ctx is done.
+// The balance is tracked through the balance change events emitted by the
+// bank module in the block results, instead of querying the account at each
+// block. The chain only emits them for transfers: the gas fees are reflected
+// in the balance of the next transfer. The minimums are checked by the
+// client, and an alert is only sent when a denom goes under its minimum, not
+// for each change while it stays under.
+func (c *Client) WatchBalance(ctx context.Context, cfg BalanceWatchCfg, alert func(BalanceAlert)) error {
+	if err := c.validateRPCClient(); err != nil {
+		return ErrMissingRPCClient
+	}
+
+	if len(cfg.Minimum) == 0 || !cfg.Minimum.IsValid() {
+		return ErrInvalidBalanceMinimum
+	}
+
+	watcher := balanceWatcher{cfg: cfg}
+	return c.WatchBlocks(ctx, BlockWatchCfg{
+		FromHeight:   cfg.FromHeight,
+		PollInterval: cfg.PollInterval,
+	}, func(res *ctypes.ResultBlockResults) error {
+		for _, a := range watcher.inspect(res) {
+			alert(a)
+		}
+		return nil
+	})
+}
+
+// balanceWatcher tracks the denoms of the watched account under their minimum.
+type balanceWatcher struct {
+	cfg   BalanceWatchCfg
+	below std.Coins // minimums the balance was under after its last change
+}
+
+// inspect returns the alerts of the balance changes of the block results.
+func (w *balanceWatcher) inspect(res *ctypes.ResultBlockResults) []BalanceAlert {
+	if res == nil || res.Results == nil {
+		return nil
+	}
+
+	var events []abci.Event
+	events = append(events, res.Results.BeginBlock.Events...)
+	for _, tx := range res.Results.DeliverTxs {
+		events = append(events, tx.Events...)
+	}
+	events = append(events, res.Results.EndBlock.Events...)
+
+	var alerts []BalanceAlert
+	for _, ev := range events {
+		balance, ok := w.balanceChange(ev)
+		if !ok {
+			continue
+		}
+
+		var below, fell std.Coins
+		for _, minimum := range w.cfg.Minimum {
+			if balance.AmountOf(minimum.Denom) >= minimum.Amount {
+				continue
+			}
+
+			below = append(below, minimum)
+			if w.below.AmountOf(minimum.Denom) == 0 {
+				fell = append(fell, minimum)
+			}
+		}
+
+		w.below = below
+		if len(fell) > 0 {
+			alerts = append(alerts, BalanceAlert{
+				Height:  res.Height,
+				Address: w.cfg.Address,
+				Balance: balance,
+				Below:   below,
+			})
+		}
+	}
+
+	return alerts
+}
+
+// balanceChange returns the resulting balance of ev, if it is a balance
+// change of the watched account.
+func (w *balanceWatcher) balanceChange(ev abci.Event) (std.Coins, bool) {
+	ae, ok := ev.(sdk.AttributeEvent)
+	if !ok || ae.Module != bank.ModuleName || ae.Type != bank.EventTypeBalanceChange {
+		return nil, false
+	}
+
+	var addr, balance string
+	for _, attr := range ae.Attributes {
+		switch attr.Key {
+		case bank.AttributeKeyAddress:
+			addr = attr.Value
+		case bank.AttributeKeyBalance:
+			balance = attr.Value
+		}
+	}
+
+	if addr != w.cfg.Address.String() {
+		return nil, false
+	}
+
+	coins, err := std.ParseCoins(balance)
+	if err != nil {
+		return nil, false
+	}
+
+	return coins, true
+}
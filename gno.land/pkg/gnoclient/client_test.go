@@ -4,6 +4,7 @@ import (
 	"context"
 	"errors"
 	"testing"
+	"time"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	abciErrors "github.com/gnolang/gno/tm2/pkg/bft/abci/example/errors"
@@ -13,10 +14,13 @@ import (
 	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
 )
@@ -1566,3 +1570,145 @@ func TestClient_EstimateGas(t *testing.T) {
 		assert.Equal(t, gasUsed, estimate)
 	})
 }
+
+func TestWatchBalance(t *testing.T) {
+	t.Parallel()
+
+	watched := crypto.AddressFromPreimage([]byte("watched"))
+	other := crypto.AddressFromPreimage([]byte("other"))
+
+	balanceChange := func(addr crypto.Address, balance string) abci.Event {
+		return sdk.NewEvent(bank.EventTypeBalanceChange, bank.ModuleName,
+			sdk.NewIndexedAttribute(bank.AttributeKeyAddress, addr.String()),
+			sdk.NewAttribute(bank.AttributeKeyBalance, balance),
+		)
+	}
+	blocks := map[int64]*state.ABCIResponses{
+		1: {DeliverTxs: []abci.ResponseDeliverTx{
+			{ResponseBase: abci.ResponseBase{Events: []abci.Event{balanceChange(watched, "100ugnot")}}},
+		}},
+		2: {DeliverTxs: []abci.ResponseDeliverTx{
+			// Goes under the minimum, then stays under it.
+			{ResponseBase: abci.ResponseBase{Events: []abci.Event{balanceChange(watched, "40ugnot")}}},
+			{ResponseBase: abci.ResponseBase{Events: []abci.Event{balanceChange(watched, "30ugnot")}}},
+		}},
+		3: {
+			DeliverTxs: []abci.ResponseDeliverTx{
+				{ResponseBase: abci.ResponseBase{Events: []abci.Event{balanceChange(other, "10ugnot")}}},
+				{ResponseBase: abci.ResponseBase{Events: []abci.Event{balanceChange(watched, "60ugnot")}}},
+			},
+			// Emptied by a scheduled send.
+			EndBlock: abci.ResponseEndBlock{
+				Events: []abci.Event{balanceChange(watched, "")},
+			},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	client := &Client{
+		RPCClient: &mockRPCClient{
+			status: func(ctx context.Context, heightGte *int64) (*ctypes.ResultStatus, error) {
+				return &ctypes.ResultStatus{
+					SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 3},
+				}, nil
+			},
+			blockResults: func(_ context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+				if *height == 3 {
+					cancel()
+				}
+				return &ctypes.ResultBlockResults{Height: *height, Results: blocks[*height]}, nil
+			},
+		},
+	}
+
+	var alerts []BalanceAlert
+	err := client.WatchBalance(ctx, BalanceWatchCfg{
+		Address:      watched,
+		Minimum:      std.NewCoins(std.NewCoin("ugnot", 50)),
+		FromHeight:   1,
+		PollInterval: time.Millisecond,
+	}, func(alert BalanceAlert) {
+		alerts = append(alerts, alert)
+	})
+	require.ErrorIs(t, err, context.Canceled)
+
+	assert.Equal(t, []BalanceAlert{
+		{
+			Height:  2,
+			Address: watched,
+			Balance: std.NewCoins(std.NewCoin("ugnot", 40)),
+			Below:   std.NewCoins(std.NewCoin("ugnot", 50)),
+		},
+		{
+			Height:  3,
+			Address: watched,
+			Balance: nil,
+			Below:   std.NewCoins(std.NewCoin("ugnot", 50)),
+		},
+	}, alerts)
+}
+
+func TestWatchBalanceErrors(t *testing.T) {
+	t.Parallel()
+
+	alert := func(BalanceAlert) {}
+	cfg := BalanceWatchCfg{Minimum: std.NewCoins(std.NewCoin("ugnot", 50))}
+
+	err := (&Client{}).WatchBalance(context.Background(), cfg, alert)
+	assert.ErrorIs(t, err, ErrMissingRPCClient)
+
+	client := &Client{RPCClient: &mockRPCClient{}}
+	err = client.WatchBalance(context.Background(), BalanceWatchCfg{}, alert)
+	assert.ErrorIs(t, err, ErrInvalidBalanceMinimum)
+}
+
+func TestWatchBlocks(t *testing.T) {
+	t.Parallel()
+
+	client := &Client{
+		RPCClient: &mockRPCClient{
+			status: func(ctx context.Context, heightGte *int64) (*ctypes.ResultStatus, error) {
+				return &ctypes.ResultStatus{
+					SyncInfo: ctypes.SyncInfo{LatestBlockHeight: 5},
+				}, nil
+			},
+			blockResults: func(_ context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
+				return &ctypes.ResultBlockResults{Height: *height}, nil
+			},
+		},
+	}
+
+	var heights []int64
+	err := client.WatchBlocks(context.Background(), BlockWatchCfg{
+		FromHeight:   2,
+		ToHeight:     4,
+		PollInterval: time.Millisecond,
+	}, func(res *ctypes.ResultBlockResults) error {
+		heights = append(heights, res.Height)
+		return nil
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []int64{2, 3, 4}, heights)
+
+	// Errors of fn stop the watch.
+	errStop := errors.New("stop")
+	err = client.WatchBlocks(context.Background(), BlockWatchCfg{FromHeight: 1}, func(*ctypes.ResultBlockResults) error {
+		return errStop
+	})
+	assert.ErrorIs(t, err, errStop)
+}
+
+func TestWatchBlocksErrors(t *testing.T) {
+	t.Parallel()
+
+	fn := func(*ctypes.ResultBlockResults) error { return nil }
+
+	err := (&Client{}).WatchBlocks(context.Background(), BlockWatchCfg{}, fn)
+	assert.ErrorIs(t, err, ErrMissingRPCClient)
+
+	client := &Client{RPCClient: &mockRPCClient{}}
+	err = client.WatchBlocks(context.Background(), BlockWatchCfg{FromHeight: -1}, fn)
+	assert.ErrorIs(t, err, rpcclient.ErrInvalidWatchRange)
+}
@@ -0,0 +1,22 @@
+package gnoclient
+
+import (
+	"context"
+
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+)
+
+// BlockWatchCfg contains the configuration of a block watch.
+type BlockWatchCfg = rpcclient.BlockWatchCfg
+
+// WatchBlocks calls fn with the results of each block from cfg.FromHeight,
+// in order, as they are committed. It returns once the results of
+// cfg.ToHeight were handled, or when ctx is done or fn returns an error.
+func (c *Client) WatchBlocks(ctx context.Context, cfg BlockWatchCfg, fn func(*ctypes.ResultBlockResults) error) error {
+	if err := c.validateRPCClient(); err != nil {
+		return ErrMissingRPCClient
+	}
+
+	return rpcclient.WatchBlockResults(ctx, c.RPCClient, cfg, fn)
+}
@@ -118,6 +118,7 @@ type (
 	mockCommit               func(ctx context.Context, height *int64) (*ctypes.ResultCommit, error)
 	mockValidators           func(ctx context.Context, height *int64) (*ctypes.ResultValidators, error)
 	mockStatus               func(ctx context.Context, heightGte *int64) (*ctypes.ResultStatus, error)
+	mockMetadata             func(ctx context.Context) (*ctypes.ResultMetadata, error)
 	mockUnconfirmedTxs       func(ctx context.Context, limit int) (*ctypes.ResultUnconfirmedTxs, error)
 	mockNumUnconfirmedTxs    func(ctx context.Context) (*ctypes.ResultUnconfirmedTxs, error)
 	mockTx                   func(ctx context.Context, hash []byte) (*ctypes.ResultTx, error)
@@ -142,6 +143,7 @@ type mockRPCClient struct {
 	commit               mockCommit
 	validators           mockValidators
 	status               mockStatus
+	metadata             mockMetadata
 	unconfirmedTxs       mockUnconfirmedTxs
 	numUnconfirmedTxs    mockNumUnconfirmedTxs
 	tx                   mockTx
@@ -273,6 +275,13 @@ func (m *mockRPCClient) Status(ctx context.Context, heightGte *int64) (*ctypes.R
 	return nil, nil
 }
 
+func (m *mockRPCClient) Metadata(ctx context.Context) (*ctypes.ResultMetadata, error) {
+	if m.metadata != nil {
+		return m.metadata(ctx)
+	}
+	return nil, nil
+}
+
 func (m *mockRPCClient) UnconfirmedTxs(ctx context.Context, limit int) (*ctypes.ResultUnconfirmedTxs, error) {
 	if m.unconfirmedTxs != nil {
 		return m.unconfirmedTxs(ctx, limit)
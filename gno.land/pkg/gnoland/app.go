@@ -31,9 +31,26 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/store"
 	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
 	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/rootmulti"
 	"github.com/gnolang/gno/tm2/pkg/store/types"
 )
 
+// Names of the stores mounted in the application's multistore. Each store is
+// kept under its own prefix of the database and committed separately; the app
+// hash is the merkle root of their commit hashes, so a key of a single store can
+// be proven with a query to ".store/<name>/key".
+//
+// Chains whose state was committed before the split keep the legacy layout,
+// with only the main and base stores; see [loadAppStoreKeys].
+const (
+	StoreMain      = "main"       // baseapp state, params and gas price
+	StoreBase      = "base"       // VM objects, types and nodes; not merkleized
+	StoreAuth      = "auth"       // accounts
+	StoreBank      = "bank"       // bank module state
+	StoreVMCode    = "vm-code"    // VM mempackages
+	StoreVMObjects = "vm-objects" // VM escaped object hashes, prefixed by realm
+)
+
 // AppOptions contains the options to create the gno.land ABCI application.
 type AppOptions struct {
 	DB                         dbm.DB             // required
@@ -44,6 +61,9 @@ type AppOptions struct {
 	InitChainerConfig                             // options related to InitChainer
 	MinGasPrices               string             // optional
 	PruneStrategy              types.PruneStrategy
+	SnapshotDir                string // optional, required if SnapshotInterval > 0
+	SnapshotInterval           int64  // take a state snapshot every SnapshotInterval blocks, 0 to disable
+	SnapshotKeepRecent         int64  // number of snapshots to keep, 0 to keep all of them
 }
 
 // TestAppOptions provides a "ready" default [AppOptions] for use with
@@ -72,10 +92,82 @@ func (c AppOptions) validate() error {
 		return fmt.Errorf("no logger provided")
 	case c.EventSwitch == nil:
 		return fmt.Errorf("no event switch provided")
+	case c.SnapshotInterval > 0 && c.SnapshotDir == "":
+		return fmt.Errorf("no snapshot directory provided")
 	}
 	return nil
 }
 
+// storeMounter is implemented by [sdk.BaseApp] and [store.CommitMultiStore].
+type storeMounter interface {
+	MountStoreWithDB(key store.StoreKey, cons store.CommitStoreConstructor, db dbm.DB)
+}
+
+// appStoreKeys holds the capability keys of the application's stores.
+type appStoreKeys struct {
+	main, base, auth, bank, vmCode, vmObjects store.StoreKey
+
+	// legacyDB is set for the legacy layout, where the main and base stores
+	// are mounted directly on it, and hold the state of all the modules.
+	legacyDB dbm.DB
+}
+
+func newAppStoreKeys() appStoreKeys {
+	return appStoreKeys{
+		main:      store.NewStoreKey(StoreMain),
+		base:      store.NewStoreKey(StoreBase),
+		auth:      store.NewStoreKey(StoreAuth),
+		bank:      store.NewStoreKey(StoreBank),
+		vmCode:    store.NewStoreKey(StoreVMCode),
+		vmObjects: store.NewStoreKey(StoreVMObjects),
+	}
+}
+
+// newLegacyAppStoreKeys returns the keys of the layout used before the state
+// was split, where the modules share the main store.
+func newLegacyAppStoreKeys(db dbm.DB) appStoreKeys {
+	mainKey := store.NewStoreKey(StoreMain)
+	return appStoreKeys{
+		main:      mainKey,
+		base:      store.NewStoreKey(StoreBase),
+		auth:      mainKey,
+		bank:      mainKey,
+		vmCode:    mainKey,
+		vmObjects: mainKey,
+		legacyDB:  db,
+	}
+}
+
+// loadAppStoreKeys returns the keys of the stores of the state in db. A new
+// chain uses the split layout, while an existing chain whose state doesn't
+// have the auth store keeps the legacy layout, so that it restarts from the
+// same state and app hash. There's no migration between the two layouts.
+func loadAppStoreKeys(db dbm.DB) (appStoreKeys, error) {
+	names, err := rootmulti.LatestStoreNames(db)
+	if err != nil {
+		return appStoreKeys{}, fmt.Errorf("unable to read the stores of the state: %w", err)
+	}
+	if len(names) == 0 || slices.Contains(names, StoreAuth) {
+		return newAppStoreKeys(), nil
+	}
+	return newLegacyAppStoreKeys(db), nil
+}
+
+// mount mounts the stores on sm, each under its own prefix of the database.
+func (k appStoreKeys) mount(sm storeMounter) {
+	if k.legacyDB != nil {
+		sm.MountStoreWithDB(k.main, iavl.StoreConstructor, k.legacyDB)
+		sm.MountStoreWithDB(k.base, dbadapter.StoreConstructor, k.legacyDB)
+		return
+	}
+	sm.MountStoreWithDB(k.main, iavl.StoreConstructor, nil)
+	sm.MountStoreWithDB(k.base, dbadapter.StoreConstructor, nil)
+	sm.MountStoreWithDB(k.auth, iavl.StoreConstructor, nil)
+	sm.MountStoreWithDB(k.bank, iavl.StoreConstructor, nil)
+	sm.MountStoreWithDB(k.vmCode, iavl.StoreConstructor, nil)
+	sm.MountStoreWithDB(k.vmObjects, iavl.StoreConstructor, nil)
+}
+
 // NewAppWithOptions creates the gno.land application with specified options.
 func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	if err := cfg.validate(); err != nil {
@@ -83,8 +175,10 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	}
 
 	// Capabilities keys.
-	mainKey := store.NewStoreKey("main")
-	baseKey := store.NewStoreKey("base")
+	keys, err := loadAppStoreKeys(cfg.DB)
+	if err != nil {
+		return nil, err
+	}
 
 	//  set sdk app options
 	var appOpts []func(*sdk.BaseApp)
@@ -93,22 +187,26 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 	}
 
 	appOpts = append(appOpts, sdk.SetPruningOptions(cfg.PruneStrategy.Options()))
+	if cfg.SnapshotInterval > 0 {
+		appOpts = append(appOpts, sdk.SetSnapshotOptions(cfg.SnapshotDir, cfg.SnapshotInterval, cfg.SnapshotKeepRecent))
+	}
 
 	// Create BaseApp.
-	baseApp := sdk.NewBaseApp("gnoland", cfg.Logger, cfg.DB, baseKey, mainKey, appOpts...)
+	baseApp := sdk.NewBaseApp("gnoland", cfg.Logger, cfg.DB, keys.base, keys.main, appOpts...)
 	baseApp.SetAppVersion("dev")
 
 	// Set mounts for BaseApp's MultiStore.
-	baseApp.MountStoreWithDB(mainKey, iavl.StoreConstructor, cfg.DB)
-	baseApp.MountStoreWithDB(baseKey, dbadapter.StoreConstructor, cfg.DB)
+	keys.mount(baseApp)
 
 	// Construct keepers.
 
-	prmk := params.NewParamsKeeper(mainKey)
-	acck := auth.NewAccountKeeper(mainKey, prmk.ForModule(auth.ModuleName), ProtoGnoAccount)
-	bankk := bank.NewBankKeeper(acck, prmk.ForModule(bank.ModuleName))
-	gpk := auth.NewGasPriceKeeper(mainKey)
-	vmk := vm.NewVMKeeper(baseKey, mainKey, acck, bankk, prmk)
+	prmk := params.NewParamsKeeper(keys.main)
+	acck := auth.NewAccountKeeper(keys.auth, prmk.ForModule(auth.ModuleName), ProtoGnoAccount)
+	bankk := bank.NewBankKeeper(keys.bank, acck, prmk.ForModule(bank.ModuleName))
+	bankk.RegisterModuleAccount(auth.DefaultFeeCollectorName)
+	bankk.RegisterModuleAccount(vm.DefaultStorageFeeCollectorName)
+	gpk := auth.NewGasPriceKeeper(keys.main)
+	vmk := vm.NewVMKeeper(keys.base, keys.vmObjects, keys.vmCode, acck, bankk, prmk)
 	vmk.Output = cfg.VMOutput
 
 	prmk.Register(auth.ModuleName, acck)
@@ -175,6 +273,9 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 		}
 	})
 
+	// Describe the messages and modules in the ".app/metadata" query.
+	baseApp.SetMetadataHook(newMetadataHook(acck, bankk, vmk))
+
 	// Set up the event collector
 	c := newCollector[validatorUpdate](
 		cfg.EventSwitch,      // global event switch filled by the node
@@ -187,6 +288,7 @@ func NewAppWithOptions(cfg *AppOptions) (abci.Application, error) {
 			c,
 			acck,
 			gpk,
+			bankk,
 			vmk,
 			baseApp,
 		),
@@ -246,6 +348,9 @@ func NewApp(
 		MinGasPrices:               appCfg.MinGasPrices,
 		SkipGenesisSigVerification: genesisCfg.SkipSigVerification,
 		PruneStrategy:              appCfg.PruneStrategy,
+		SnapshotDir:                filepath.Join(dataRootDir, "snapshots"),
+		SnapshotInterval:           appCfg.SnapshotInterval,
+		SnapshotKeepRecent:         appCfg.SnapshotKeepRecent,
 	}
 	if genesisCfg.SkipFailingTxs {
 		cfg.GenesisTxResultHandler = NoopGenesisTxResultHandler
@@ -453,6 +558,7 @@ func EndBlocker(
 	collector *collector[validatorUpdate],
 	acck auth.AccountKeeperI,
 	gpk auth.GasPriceKeeperI,
+	bankk bank.BankKeeperI,
 	vmk vm.VMKeeperI,
 	app endBlockerApp,
 ) func(
@@ -468,6 +574,9 @@ func EndBlocker(
 		if acck != nil && gpk != nil {
 			auth.EndBlocker(ctx, gpk)
 		}
+		if bankk != nil {
+			bank.EndBlocker(ctx, bankk)
+		}
 
 		// Check if there was a valset change
 		if len(collector.getEvents()) == 0 {
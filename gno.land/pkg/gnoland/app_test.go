@@ -20,6 +20,7 @@ import (
 	bftCfg "github.com/gnolang/gno/tm2/pkg/bft/config"
 	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/merkle"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/events"
@@ -34,6 +35,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/store"
 	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
 	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/rootmulti"
 	"github.com/gnolang/gno/tm2/pkg/store/types"
 )
 
@@ -134,6 +136,18 @@ func TestNewAppWithOptions(t *testing.T) {
 		require.True(t, qres.IsOK())
 		assert.Equal(t, qres.Data, []byte(tc.expectedVal))
 	}
+
+	qres := bapp.Query(abci.RequestQuery{Path: ".app/metadata"})
+	require.True(t, qres.IsOK(), qres.Error)
+	var md abci.AppMetadata
+	require.NoError(t, amino.UnmarshalJSON(qres.Value, &md))
+	assert.Equal(t, "gnoland", md.Name)
+	assert.Equal(t, []string{"events", "simulate", "trace"}, md.Features)
+	assert.Contains(t, md.MsgTypes, abci.MsgTypeInfo{Route: "vm", Type: "exec", TypeURL: "/vm.m_call"})
+	require.Len(t, md.Modules, 3)
+	assert.Equal(t, vm.ModuleName, md.Modules[2].Name)
+	assert.Len(t, md.Modules[2].Hash, 64)
+	assert.Contains(t, md.Modules[2].Params, `"chain_domain":"gno.land"`)
 }
 
 func TestNewAppWithOptions_ErrNoDB(t *testing.T) {
@@ -143,6 +157,120 @@ func TestNewAppWithOptions_ErrNoDB(t *testing.T) {
 	assert.ErrorContains(t, err, "no db provided")
 }
 
+// Tests that each module's state is committed in its own store, and that its
+// keys can be proven against the app hash.
+func TestNewAppWithOptions_StoreProofs(t *testing.T) {
+	t.Parallel()
+
+	app, err := NewAppWithOptions(TestAppOptions(memdb.NewMemDB()))
+	require.NoError(t, err)
+	bapp := app.(*sdk.BaseApp)
+
+	addr := crypto.AddressFromPreimage([]byte("test1"))
+	appState := DefaultGenState()
+	appState.Balances = []Balance{
+		{
+			Address: addr,
+			Amount:  []std.Coin{{Amount: 1e15, Denom: "ugnot"}},
+		},
+	}
+	appState.Txs = []TxWithMetadata{
+		{
+			Tx: std.Tx{
+				Msgs: []std.Msg{vm.NewMsgAddPackage(addr, "gno.land/r/demo", []*std.MemFile{
+					{
+						Name: "demo.gno",
+						Body: "package demo; func Hello(cur realm) string { return `hello`; }",
+					},
+					{
+						Name: "gnomod.toml",
+						Body: gnolang.GenGnoModLatest("gno.land/r/demo"),
+					},
+				})},
+				Fee:        std.Fee{GasWanted: 1e6, GasFee: std.Coin{Amount: 1e6, Denom: "ugnot"}},
+				Signatures: []std.Signature{{}}, // one empty signature
+			},
+		},
+	}
+
+	resp := bapp.InitChain(abci.RequestInitChain{
+		Time:    time.Now(),
+		ChainID: "dev",
+		ConsensusParams: &abci.ConsensusParams{
+			Block: defaultBlockParams(),
+		},
+		Validators: []abci.ValidatorUpdate{},
+		AppState:   appState,
+	})
+	require.True(t, resp.IsOK(), "InitChain response: %v", resp)
+
+	bapp.Commit()
+	// Proofs can't be queried at height 1.
+	bapp.BeginBlock(abci.RequestBeginBlock{Header: &bft.Header{ChainID: "dev", Height: 2}})
+	bapp.EndBlock(abci.RequestEndBlock{})
+	cres := bapp.Commit()
+
+	prove := func(storeName string, key []byte) []byte {
+		t.Helper()
+
+		qres := bapp.Query(abci.RequestQuery{
+			Path:  ".store/" + storeName + "/key",
+			Data:  key,
+			Prove: true,
+		})
+		require.True(t, qres.IsOK(), "query %s: %v", storeName, qres.Error)
+		require.NotNil(t, qres.Value, "key not found in %s", storeName)
+
+		keyPath := merkle.KeyPath{}.
+			AppendKey([]byte(storeName), merkle.KeyEncodingURL).
+			AppendKey(key, merkle.KeyEncodingHex)
+		err := rootmulti.DefaultProofRuntime().
+			VerifyValue(qres.Proof, cres.Data, keyPath.String(), qres.Value)
+		require.NoError(t, err, "proof of %s", storeName)
+		return qres.Value
+	}
+
+	prove(StoreAuth, auth.AddressStoreKey(addr))
+	code := prove(StoreVMCode, []byte("pkg:gno.land/r/demo"))
+	assert.Contains(t, string(code), "func Hello")
+
+	// The package code isn't kept with the accounts, nor with the objects.
+	for _, storeName := range []string{StoreAuth, StoreVMObjects} {
+		qres := bapp.Query(abci.RequestQuery{
+			Path: ".store/" + storeName + "/key",
+			Data: []byte("pkg:gno.land/r/demo"),
+		})
+		require.True(t, qres.IsOK())
+		assert.Nil(t, qres.Value, "package code found in %s", storeName)
+	}
+}
+
+// Tests that a chain committed with the legacy layout, where the modules share
+// the main store, restarts from the same state and app hash.
+func TestNewAppWithOptions_LegacyStoreLayout(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewMemDB()
+	keys := newLegacyAppStoreKeys(db)
+	cms := store.NewCommitMultiStore(db)
+	keys.mount(cms)
+	require.NoError(t, cms.LoadLatestVersion())
+	cms.GetStore(keys.main).Set([]byte("key"), []byte("value"))
+	cid := cms.Commit()
+
+	app, err := NewAppWithOptions(TestAppOptions(db))
+	require.NoError(t, err)
+	bapp := app.(*sdk.BaseApp)
+	assert.Equal(t, cid, bapp.LastCommitID())
+
+	qres := bapp.Query(abci.RequestQuery{
+		Path: ".store/" + StoreMain + "/key",
+		Data: []byte("key"),
+	})
+	require.True(t, qres.IsOK(), "query: %v", qres.Error)
+	assert.Equal(t, []byte("value"), qres.Value)
+}
+
 func TestNewApp(t *testing.T) {
 	// NewApp should have good defaults and manage to run InitChain.
 	td := t.TempDir()
@@ -533,7 +661,7 @@ func TestEndBlocker(t *testing.T) {
 		c := newCollector[validatorUpdate](&mockEventSwitch{}, noFilter)
 
 		// Create the EndBlocker
-		eb := EndBlocker(c, nil, nil, nil, &mockEndBlockerApp{})
+		eb := EndBlocker(c, nil, nil, nil, nil, &mockEndBlockerApp{})
 
 		// Run the EndBlocker
 		res := eb(sdk.Context{}.WithConsensusParams(&abci.ConsensusParams{
@@ -577,7 +705,7 @@ func TestEndBlocker(t *testing.T) {
 		mockEventSwitch.FireEvent(chain.Event{})
 
 		// Create the EndBlocker
-		eb := EndBlocker(c, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
+		eb := EndBlocker(c, nil, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
 
 		// Run the EndBlocker
 		res := eb(sdk.Context{}.WithConsensusParams(&abci.ConsensusParams{
@@ -624,7 +752,7 @@ func TestEndBlocker(t *testing.T) {
 		mockEventSwitch.FireEvent(chain.Event{})
 
 		// Create the EndBlocker
-		eb := EndBlocker(c, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
+		eb := EndBlocker(c, nil, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
 
 		// Run the EndBlocker
 		res := eb(sdk.Context{}.WithConsensusParams(&abci.ConsensusParams{
@@ -696,7 +824,7 @@ func TestEndBlocker(t *testing.T) {
 		mockEventSwitch.FireEvent(txEvent)
 
 		// Create the EndBlocker
-		eb := EndBlocker(c, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
+		eb := EndBlocker(c, nil, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
 
 		// Run the EndBlocker
 		res := eb(sdk.Context{}.WithConsensusParams(&abci.ConsensusParams{
@@ -770,7 +898,7 @@ func TestEndBlocker(t *testing.T) {
 		c := newCollector[validatorUpdate](mockEventSwitch, validatorEventFilter)
 		mockEventSwitch.FireEvent(txEvent)
 
-		eb := EndBlocker(c, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
+		eb := EndBlocker(c, nil, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
 		res := eb(sdk.Context{}.WithConsensusParams(&abci.ConsensusParams{
 			Validator: &abci.ValidatorParams{
 				PubKeyTypeURLs: []string{"/tm.PubKeySecp256k1"},
@@ -835,7 +963,7 @@ func TestEndBlocker(t *testing.T) {
 
 		c := newCollector[validatorUpdate](mockEventSwitch, validatorEventFilter)
 		mockEventSwitch.FireEvent(txEvent)
-		eb := EndBlocker(c, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
+		eb := EndBlocker(c, nil, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
 		res := eb(sdk.Context{}.WithConsensusParams(&abci.ConsensusParams{
 			Validator: &abci.ValidatorParams{
 				PubKeyTypeURLs: []string{"/tm.PubKeySecp256k1"},
@@ -890,7 +1018,7 @@ func TestEndBlocker(t *testing.T) {
 
 		c := newCollector[validatorUpdate](mockEventSwitch, validatorEventFilter)
 		mockEventSwitch.FireEvent(txEvent)
-		eb := EndBlocker(c, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
+		eb := EndBlocker(c, nil, nil, nil, mockVMKeeper, &mockEndBlockerApp{})
 		res := eb(sdk.Context{}.WithConsensusParams(&abci.ConsensusParams{
 			Validator: &abci.ValidatorParams{
 				PubKeyTypeURLs: []string{"/tm.PubKeyEd25519"},
@@ -1051,22 +1179,20 @@ func newGasPriceTestApp(t *testing.T) abci.Application {
 	cfg.EventSwitch = events.NewEventSwitch()
 
 	// Capabilities keys.
-	mainKey := store.NewStoreKey("main")
-	baseKey := store.NewStoreKey("base")
+	keys := newAppStoreKeys()
 
-	baseApp := sdk.NewBaseApp("gnoland", cfg.Logger, cfg.DB, baseKey, mainKey)
+	baseApp := sdk.NewBaseApp("gnoland", cfg.Logger, cfg.DB, keys.base, keys.main)
 	baseApp.SetAppVersion("test")
 
 	// Set mounts for BaseApp's MultiStore.
-	baseApp.MountStoreWithDB(mainKey, iavl.StoreConstructor, cfg.DB)
-	baseApp.MountStoreWithDB(baseKey, dbadapter.StoreConstructor, cfg.DB)
+	keys.mount(baseApp)
 
 	// Construct keepers.
-	prmk := params.NewParamsKeeper(mainKey)
-	acck := auth.NewAccountKeeper(mainKey, prmk.ForModule(auth.ModuleName), ProtoGnoAccount)
-	gpk := auth.NewGasPriceKeeper(mainKey)
-	bankk := bank.NewBankKeeper(acck, prmk.ForModule(bank.ModuleName))
-	vmk := vm.NewVMKeeper(baseKey, mainKey, acck, bankk, prmk)
+	prmk := params.NewParamsKeeper(keys.main)
+	acck := auth.NewAccountKeeper(keys.auth, prmk.ForModule(auth.ModuleName), ProtoGnoAccount)
+	gpk := auth.NewGasPriceKeeper(keys.main)
+	bankk := bank.NewBankKeeper(keys.bank, acck, prmk.ForModule(bank.ModuleName))
+	vmk := vm.NewVMKeeper(keys.base, keys.vmObjects, keys.vmCode, acck, bankk, prmk)
 	prmk.Register(auth.ModuleName, acck)
 	prmk.Register(bank.ModuleName, bankk)
 	prmk.Register(vm.ModuleName, vmk)
@@ -1119,6 +1245,7 @@ func newGasPriceTestApp(t *testing.T) abci.Application {
 			c,
 			acck,
 			gpk,
+			bankk,
 			nil,
 			baseApp,
 		),
@@ -1300,14 +1427,8 @@ func TestPruneStrategyNothing(t *testing.T) {
 	)
 	require.NoError(t, err)
 
-	var (
-		mainKey = store.NewStoreKey("main")
-		baseKey = store.NewStoreKey("base")
-	)
-
 	cms := store.NewCommitMultiStore(db)
-	cms.MountStoreWithDB(mainKey, iavl.StoreConstructor, db)
-	cms.MountStoreWithDB(baseKey, dbadapter.StoreConstructor, db)
+	newAppStoreKeys().mount(cms)
 
 	// Make sure loading a past version doesn't fail
 	assert.NoError(t, cms.LoadVersion(1))
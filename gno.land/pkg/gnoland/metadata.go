@@ -0,0 +1,56 @@
+package gnoland
+
+import (
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/version"
+)
+
+// appMsgs are the messages which the transactions of gno.land can hold.
+var appMsgs = []std.Msg{
+	bank.MsgSend{},
+	bank.MsgSendAt{},
+	bank.MsgCancelSendAt{},
+	vm.MsgAddPackage{},
+	vm.MsgCall{},
+	vm.MsgRun{},
+}
+
+// newMetadataHook returns the hook completing the ".app/metadata" query with
+// the messages and the modules of gno.land.
+func newMetadataHook(acck auth.AccountKeeper, bankk bank.BankKeeper, vmk *vm.VMKeeper) sdk.MetadataHook {
+	return func(ctx sdk.Context, md *abci.AppMetadata) {
+		// Realms can emit events, returned in the tx results.
+		md.Features = append(md.Features, "events")
+
+		for _, msg := range appMsgs {
+			md.MsgTypes = append(md.MsgTypes, abci.MsgTypeInfo{
+				Route:   msg.Route(),
+				Type:    msg.Type(),
+				TypeURL: amino.GetTypeURL(msg),
+			})
+		}
+
+		md.Modules = append(md.Modules,
+			abci.ModuleInfo{
+				Name:   auth.ModuleName,
+				Params: string(amino.MustMarshalJSON(acck.GetParams(ctx))),
+			},
+			abci.ModuleInfo{
+				Name:   bank.ModuleName,
+				Params: string(amino.MustMarshalJSON(bankk.GetParams(ctx))),
+			},
+			abci.ModuleInfo{
+				Name:    vm.ModuleName,
+				Version: version.Version,
+				Hash:    vmk.QueryStdlibHash(ctx),
+				Params:  string(amino.MustMarshalJSON(vmk.GetParams(ctx))),
+			},
+		)
+	}
+}
@@ -153,6 +153,42 @@ func (m *mockBankKeeper) HasCoins(ctx sdk.Context, addr crypto.Address, amt std.
 	return true
 }
 
+func (m *mockBankKeeper) ScheduleSend(ctx sdk.Context, fromAddr crypto.Address, toAddr crypto.Address, amt std.Coins, height int64, unixTime int64) (uint64, error) {
+	return 0, nil
+}
+
+func (m *mockBankKeeper) CancelScheduledSend(ctx sdk.Context, fromAddr crypto.Address, id uint64) error {
+	return nil
+}
+
+func (m *mockBankKeeper) ExecuteDueSends(ctx sdk.Context) {}
+
+func (m *mockBankKeeper) SetAddressOwner(ctx sdk.Context, addr crypto.Address, owner string) {}
+
+func (m *mockBankKeeper) GetAddressOwner(ctx sdk.Context, addr crypto.Address) string {
+	return ""
+}
+
+func (m *mockBankKeeper) GetModuleAccount(name string) (bank.ModuleAccount, bool) {
+	return bank.ModuleAccount{}, false
+}
+
+func (m *mockBankKeeper) MintCoins(ctx sdk.Context, module string, amt std.Coins) error {
+	return nil
+}
+
+func (m *mockBankKeeper) BurnCoins(ctx sdk.Context, module string, amt std.Coins) error {
+	return nil
+}
+
+func (m *mockBankKeeper) SendCoinsFromModuleToAccount(ctx sdk.Context, module string, toAddr crypto.Address, amt std.Coins) error {
+	return nil
+}
+
+func (m *mockBankKeeper) SendCoinsFromAccountToModule(ctx sdk.Context, fromAddr crypto.Address, module string, amt std.Coins) error {
+	return nil
+}
+
 type mockAuthKeeper struct{}
 
 func (m *mockAuthKeeper) NewAccountWithAddress(ctx sdk.Context, addr crypto.Address) std.Account {
@@ -164,6 +200,9 @@ func (m *mockAuthKeeper) SetAccount(ctx sdk.Context, acc std.Account)
 func (m *mockAuthKeeper) IterateAccounts(ctx sdk.Context, process func(std.Account) bool) {}
 func (m *mockAuthKeeper) InitGenesis(ctx sdk.Context, data auth.GenesisState)             {}
 func (m *mockAuthKeeper) GetParams(ctx sdk.Context) auth.Params                           { return auth.Params{} }
+func (m *mockAuthKeeper) ListAccounts(ctx sdk.Context, start crypto.Address, limit, maxScan int, filter func(std.Account) bool) ([]std.Account, crypto.Address) {
+	return nil, crypto.Address{}
+}
 
 type mockParamsKeeper struct{}
 
@@ -0,0 +1,76 @@
+package gnosigner
+
+import "encoding/json"
+
+// The methods of the protocol.
+const (
+	// MethodGetAccount returns the address and the public key of the key of
+	// the signer, once the user approved the connection.
+	MethodGetAccount = "get_account"
+	// MethodSignTx returns a transaction signed by the key of the signer,
+	// once the user approved it.
+	MethodSignTx = "sign_tx"
+)
+
+// The error codes of the protocol: those of JSON-RPC 2.0, and those of the
+// wallets of the browsers (EIP-1193) for the decisions of the user.
+const (
+	CodeParseError     = -32700
+	CodeInvalidRequest = -32600
+	CodeMethodNotFound = -32601
+	CodeInvalidParams  = -32602
+	CodeInternalError  = -32603
+
+	// CodeUserRejected is returned when the user rejects a request.
+	CodeUserRejected = 4001
+	// CodeUnauthorized is returned when a transaction is to be signed before
+	// the user approved the connection with get_account.
+	CodeUnauthorized = 4100
+)
+
+// Request is a JSON-RPC 2.0 request sent by a dapp.
+type Request struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Method  string          `json:"method"`
+	Params  json.RawMessage `json:"params,omitempty"`
+}
+
+// Response is the JSON-RPC 2.0 response to a Request.
+type Response struct {
+	JSONRPC string          `json:"jsonrpc"`
+	ID      json.RawMessage `json:"id"`
+	Result  any             `json:"result,omitempty"`
+	Error   *Error          `json:"error,omitempty"`
+}
+
+// Error is the error of a Response.
+type Error struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+func (e *Error) Error() string {
+	return e.Message
+}
+
+// AccountResult is the result of get_account.
+type AccountResult struct {
+	Address string `json:"address"`
+	PubKey  string `json:"pub_key"` // bech32
+	ChainID string `json:"chain_id"`
+}
+
+// SignTxParams are the parameters of sign_tx. The transaction is encoded in
+// Amino JSON, like the documents of gnokey sign.
+type SignTxParams struct {
+	Tx            json.RawMessage `json:"tx"`
+	AccountNumber uint64          `json:"account_number,string"`
+	Sequence      uint64          `json:"sequence,string"`
+}
+
+// SignTxResult is the result of sign_tx: the transaction with the signature
+// of the signer, in Amino JSON, ready to be broadcast.
+type SignTxResult struct {
+	Tx json.RawMessage `json:"tx"`
+}
@@ -0,0 +1,256 @@
+// Package gnosigner implements a signer for the web dapps: it serves a
+// JSON-RPC protocol over a WebSocket, on which dapps request the address of
+// the user and the signatures of transactions, and it signs with a key of the
+// gnokey keybase once the user approved each request in the terminal.
+//
+// A dapp first requests get_account, which the user approves for the whole
+// connection; it can then request sign_tx, with an unsigned transaction in
+// Amino JSON, which the user approves after reviewing it:
+//
+//	-> {"jsonrpc": "2.0", "id": 1, "method": "get_account"}
+//	<- {"jsonrpc": "2.0", "id": 1, "result": {"address": "g1...", "pub_key": "gpub1...", "chain_id": "dev"}}
+//	-> {"jsonrpc": "2.0", "id": 2, "method": "sign_tx", "params": {"tx": {...}, "account_number": "1", "sequence": "0"}}
+//	<- {"jsonrpc": "2.0", "id": 2, "result": {"tx": {...}}}
+//
+// The chain ID is the one of the signer, not of the dapp, so that a dapp
+// can't obtain a signature valid on another chain.
+package gnosigner
+
+import (
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"slices"
+	"strings"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gorilla/websocket"
+)
+
+// maxMessageSize is the maximum size of a request.
+const maxMessageSize = 1 << 20
+
+// Config is the configuration of a Signer.
+type Config struct {
+	// KeyName is the name or the address of the key signing the
+	// transactions.
+	KeyName string
+	// ChainID is the ID of the chain of the transactions.
+	ChainID string
+	// AllowedOrigins are the origins of the dapps which can connect, like
+	// "https://gno.land", or "*" for all of them. None can if it's empty.
+	AllowedOrigins []string
+	// InsecurePasswordStdin reads the password of the key from the input,
+	// rather than from the terminal.
+	InsecurePasswordStdin bool
+}
+
+// Signer is the http.Handler serving the protocol on WebSocket connections.
+type Signer struct {
+	cfg      Config
+	io       commands.IO
+	kb       keys.Keybase
+	info     keys.Info
+	upgrader websocket.Upgrader
+
+	mu sync.Mutex // serializes the prompts
+}
+
+// NewSigner returns a Signer signing with the key of the keybase named in
+// the config, and prompting the user with io.
+func NewSigner(cfg Config, kb keys.Keybase, io commands.IO) (*Signer, error) {
+	info, err := kb.GetByNameOrAddress(cfg.KeyName)
+	if err != nil {
+		return nil, fmt.Errorf("unable to get key from keybase, %w", err)
+	}
+	if info.GetType() == keys.TypeOffline || info.GetType() == keys.TypeMulti {
+		return nil, fmt.Errorf("key %q can't sign", info.GetName())
+	}
+
+	s := &Signer{
+		cfg:  cfg,
+		io:   io,
+		kb:   kb,
+		info: info,
+	}
+	s.upgrader = websocket.Upgrader{
+		CheckOrigin: s.checkOrigin,
+	}
+	return s, nil
+}
+
+func (s *Signer) checkOrigin(r *http.Request) bool {
+	origins := s.cfg.AllowedOrigins
+	return slices.Contains(origins, "*") ||
+		slices.Contains(origins, r.Header.Get("Origin"))
+}
+
+// session is the state of a connection.
+type session struct {
+	origin   string
+	approved bool // whether the user approved the connection
+}
+
+// ServeHTTP upgrades the request to a WebSocket connection, and serves the
+// requests sent on it, one at a time.
+func (s *Signer) ServeHTTP(w http.ResponseWriter, r *http.Request) {
+	conn, err := s.upgrader.Upgrade(w, r, nil)
+	if err != nil {
+		return // the upgrader replied with the error.
+	}
+	defer conn.Close()
+	conn.SetReadLimit(maxMessageSize)
+
+	sess := &session{origin: r.Header.Get("Origin")}
+	if sess.origin == "" {
+		sess.origin = "an unknown origin"
+	}
+	for {
+		_, msg, err := conn.ReadMessage()
+		if err != nil {
+			return
+		}
+		if err := conn.WriteJSON(s.handle(sess, msg)); err != nil {
+			return
+		}
+	}
+}
+
+func (s *Signer) handle(sess *session, msg []byte) Response {
+	var req Request
+	if err := json.Unmarshal(msg, &req); err != nil {
+		return newErrorResponse(nil, CodeParseError, "parse error: %v", err)
+	}
+	if req.JSONRPC != "2.0" || req.Method == "" {
+		return newErrorResponse(req.ID, CodeInvalidRequest, "invalid request")
+	}
+
+	var (
+		result any
+		err    *Error
+	)
+	switch req.Method {
+	case MethodGetAccount:
+		result, err = s.getAccount(sess)
+	case MethodSignTx:
+		result, err = s.signTx(sess, req.Params)
+	default:
+		err = newError(CodeMethodNotFound, "method not found: %s", req.Method)
+	}
+	if err != nil {
+		return Response{JSONRPC: "2.0", ID: req.ID, Error: err}
+	}
+	return Response{JSONRPC: "2.0", ID: req.ID, Result: result}
+}
+
+func (s *Signer) getAccount(sess *session) (*AccountResult, *Error) {
+	if !sess.approved {
+		s.mu.Lock()
+		defer s.mu.Unlock()
+
+		s.io.ErrPrintfln("\n%s requests the address of %s (%s) on chain %q.",
+			sess.origin, s.info.GetName(), s.info.GetAddress(), s.cfg.ChainID)
+		ok, err := s.confirm("Allow this connection to request signatures?")
+		if err != nil {
+			return nil, newError(CodeInternalError, "unable to prompt the user, %v", err)
+		}
+		if !ok {
+			return nil, newError(CodeUserRejected, "user rejected the connection")
+		}
+		sess.approved = true
+	}
+
+	return &AccountResult{
+		Address: s.info.GetAddress().String(),
+		PubKey:  crypto.PubKeyToBech32(s.info.GetPubKey()),
+		ChainID: s.cfg.ChainID,
+	}, nil
+}
+
+func (s *Signer) signTx(sess *session, params json.RawMessage) (*SignTxResult, *Error) {
+	if !sess.approved {
+		return nil, newError(CodeUnauthorized, "connection not approved, request %s first", MethodGetAccount)
+	}
+
+	var p SignTxParams
+	if err := json.Unmarshal(params, &p); err != nil {
+		return nil, newError(CodeInvalidParams, "invalid params: %v", err)
+	}
+	var tx std.Tx
+	if err := amino.UnmarshalJSON(p.Tx, &tx); err != nil {
+		return nil, newError(CodeInvalidParams, "unable to unmarshal transaction, %v", err)
+	}
+	address := s.info.GetAddress()
+	signer := slices.Index(tx.GetSigners(), address)
+	if signer < 0 {
+		return nil, newError(CodeInvalidParams, "transaction isn't to be signed by %s", address)
+	}
+	signBytes, err := tx.GetSignBytes(s.cfg.ChainID, p.AccountNumber, p.Sequence)
+	if err != nil {
+		return nil, newError(CodeInvalidParams, "unable to get signature bytes, %v", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.io.ErrPrintfln("\n%s requests the signature of a transaction on chain %q, by %s (account %d, sequence %d):",
+		sess.origin, s.cfg.ChainID, address, p.AccountNumber, p.Sequence)
+	if doc, err := amino.MarshalJSONIndent(tx, "", "  "); err == nil {
+		s.io.ErrPrintln(string(doc))
+	}
+	ok, perr := s.confirm("Sign this transaction?")
+	if perr != nil {
+		return nil, newError(CodeInternalError, "unable to prompt the user, %v", perr)
+	}
+	if !ok {
+		return nil, newError(CodeUserRejected, "user rejected the transaction")
+	}
+
+	var password string
+	if s.info.GetType() != keys.TypeLedger {
+		password, err = s.io.GetPassword("Enter password to decrypt key", s.cfg.InsecurePasswordStdin)
+		if err != nil {
+			return nil, newError(CodeInternalError, "unable to get decryption key, %v", err)
+		}
+	}
+	sig, pub, err := s.kb.Sign(s.info.GetName(), password, signBytes)
+	if err != nil {
+		return nil, newError(CodeInternalError, "unable to sign transaction bytes, %v", err)
+	}
+
+	// The signatures are in the order of the signers.
+	if n := len(tx.GetSigners()); len(tx.Signatures) < n {
+		tx.Signatures = append(tx.Signatures, make([]std.Signature, n-len(tx.Signatures))...)
+	}
+	tx.Signatures[signer] = std.Signature{PubKey: pub, Signature: sig}
+
+	signed, err := amino.MarshalJSON(tx)
+	if err != nil {
+		return nil, newError(CodeInternalError, "unable to marshal transaction, %v", err)
+	}
+	return &SignTxResult{Tx: signed}, nil
+}
+
+// confirm asks the user to approve a request; unlike
+// commands.IO.GetConfirmation, only an explicit yes approves it.
+func (s *Signer) confirm(prompt string) (bool, error) {
+	answer, err := s.io.GetString(prompt + " [y/N]:")
+	if err != nil {
+		return false, err
+	}
+	answer = strings.ToLower(answer)
+	return answer == "y" || answer == "yes", nil
+}
+
+func newError(code int, format string, args ...any) *Error {
+	return &Error{Code: code, Message: fmt.Sprintf(format, args...)}
+}
+
+func newErrorResponse(id json.RawMessage, code int, format string, args ...any) Response {
+	return Response{JSONRPC: "2.0", ID: id, Error: newError(code, format, args...)}
+}
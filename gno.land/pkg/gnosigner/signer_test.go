@@ -0,0 +1,197 @@
+package gnosigner
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gorilla/websocket"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	testMnemonic = "equip will roof matter pink blind book anxiety banner elbow sun young"
+	testPassword = "password"
+)
+
+// newTestSigner returns the URL of a signer answering the prompts with the
+// given input, and the buffer of its prompts.
+func newTestSigner(t *testing.T, cfg Config, input string) (string, keys.Info, *bytes.Buffer) {
+	t.Helper()
+
+	kb := keys.NewInMemory()
+	info, err := kb.CreateAccount("alice", testMnemonic, "", testPassword, 0, 0)
+	require.NoError(t, err)
+
+	var prompts bytes.Buffer
+	io := commands.NewTestIO()
+	io.SetIn(strings.NewReader(input))
+	io.SetErr(commands.WriteNopCloser(&prompts))
+
+	cfg.KeyName = "alice"
+	cfg.ChainID = "test-chain"
+	cfg.InsecurePasswordStdin = true
+	signer, err := NewSigner(cfg, kb, io)
+	require.NoError(t, err)
+
+	srv := httptest.NewServer(signer)
+	t.Cleanup(srv.Close)
+	return "ws" + strings.TrimPrefix(srv.URL, "http"), info, &prompts
+}
+
+func dial(t *testing.T, url, origin string) *websocket.Conn {
+	t.Helper()
+
+	conn, _, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {origin}})
+	require.NoError(t, err)
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+// call sends a raw request, and returns the result or the error.
+func call(t *testing.T, conn *websocket.Conn, req string) (json.RawMessage, *Error) {
+	t.Helper()
+
+	require.NoError(t, conn.WriteMessage(websocket.TextMessage, []byte(req)))
+	var res struct {
+		ID     json.RawMessage `json:"id"`
+		Result json.RawMessage `json:"result"`
+		Error  *Error          `json:"error"`
+	}
+	require.NoError(t, conn.ReadJSON(&res))
+	return res.Result, res.Error
+}
+
+func signTxRequest(t *testing.T, tx std.Tx) string {
+	t.Helper()
+
+	params, err := json.Marshal(SignTxParams{
+		Tx:            amino.MustMarshalJSON(tx),
+		AccountNumber: 3,
+		Sequence:      7,
+	})
+	require.NoError(t, err)
+	return `{"jsonrpc": "2.0", "id": 2, "method": "sign_tx", "params": ` + string(params) + `}`
+}
+
+func TestSigner(t *testing.T) {
+	t.Parallel()
+
+	// The user approves the connection and the first transaction, and
+	// rejects the second.
+	url, info, prompts := newTestSigner(t, Config{AllowedOrigins: []string{"https://dapp.example"}}, "y\ny\n"+testPassword+"\nn\n")
+	conn := dial(t, url, "https://dapp.example")
+
+	tx := std.Tx{
+		Msgs: []std.Msg{bank.NewMsgSend(info.GetAddress(), crypto.AddressFromPreimage([]byte("bob")), std.MustParseCoins("10ugnot"))},
+		Fee:  std.NewFee(100000, std.MustParseCoin("1ugnot")),
+		Memo: "hello",
+	}
+
+	// A transaction can't be signed before the connection is approved.
+	_, rpcErr := call(t, conn, signTxRequest(t, tx))
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, CodeUnauthorized, rpcErr.Code)
+
+	res, rpcErr := call(t, conn, `{"jsonrpc": "2.0", "id": 1, "method": "get_account"}`)
+	require.Nil(t, rpcErr)
+	var account AccountResult
+	require.NoError(t, json.Unmarshal(res, &account))
+	assert.Equal(t, AccountResult{
+		Address: info.GetAddress().String(),
+		PubKey:  crypto.PubKeyToBech32(info.GetPubKey()),
+		ChainID: "test-chain",
+	}, account)
+	assert.Contains(t, prompts.String(), "https://dapp.example requests the address of alice")
+
+	// The connection is only approved once.
+	_, rpcErr = call(t, conn, `{"jsonrpc": "2.0", "id": 1, "method": "get_account"}`)
+	require.Nil(t, rpcErr)
+
+	res, rpcErr = call(t, conn, signTxRequest(t, tx))
+	require.Nil(t, rpcErr)
+	assert.Contains(t, prompts.String(), `on chain "test-chain", by `+info.GetAddress().String()+" (account 3, sequence 7)")
+	assert.Contains(t, prompts.String(), `"memo": "hello"`)
+
+	var result SignTxResult
+	require.NoError(t, json.Unmarshal(res, &result))
+	var signed std.Tx
+	require.NoError(t, amino.UnmarshalJSON(result.Tx, &signed))
+	require.Len(t, signed.Signatures, 1)
+	require.NoError(t, signed.ValidateBasic())
+	signBytes, err := tx.GetSignBytes("test-chain", 3, 7)
+	require.NoError(t, err)
+	sig := signed.Signatures[0]
+	assert.True(t, sig.PubKey.Equals(info.GetPubKey()))
+	assert.True(t, sig.PubKey.VerifyBytes(signBytes, sig.Signature))
+
+	_, rpcErr = call(t, conn, signTxRequest(t, tx))
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, CodeUserRejected, rpcErr.Code)
+}
+
+func TestSigner_Errors(t *testing.T) {
+	t.Parallel()
+
+	url, _, _ := newTestSigner(t, Config{AllowedOrigins: []string{"*"}}, "y\n")
+	conn := dial(t, url, "")
+
+	_, rpcErr := call(t, conn, `{"jsonrpc": "2.0", "id": 1, "method": "get_account"}`)
+	require.Nil(t, rpcErr)
+
+	other := std.Tx{
+		Msgs: []std.Msg{bank.NewMsgSend(crypto.AddressFromPreimage([]byte("bob")), crypto.AddressFromPreimage([]byte("alice")), std.MustParseCoins("10ugnot"))},
+		Fee:  std.NewFee(100000, std.MustParseCoin("1ugnot")),
+	}
+	for _, tc := range []struct {
+		req  string
+		code int
+	}{
+		{`{"jsonrpc": "2.0", "id": 1, `, CodeParseError},
+		{`{"id": 1, "method": "get_account"}`, CodeInvalidRequest},
+		{`{"jsonrpc": "2.0", "id": 1, "method": "send_tx"}`, CodeMethodNotFound},
+		{`{"jsonrpc": "2.0", "id": 1, "method": "sign_tx", "params": {"tx": "x"}}`, CodeInvalidParams},
+		{signTxRequest(t, other), CodeInvalidParams},
+	} {
+		_, rpcErr := call(t, conn, tc.req)
+		require.NotNil(t, rpcErr, tc.req)
+		assert.Equal(t, tc.code, rpcErr.Code, tc.req)
+	}
+}
+
+func TestSigner_RejectedConnection(t *testing.T) {
+	t.Parallel()
+
+	url, _, _ := newTestSigner(t, Config{AllowedOrigins: []string{"https://gno.land"}}, "no\n")
+
+	_, res, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {"https://evil.example"}})
+	require.Error(t, err)
+	assert.Equal(t, http.StatusForbidden, res.StatusCode)
+
+	conn := dial(t, url, "https://gno.land")
+	_, rpcErr := call(t, conn, `{"jsonrpc": "2.0", "id": 1, "method": "get_account"}`)
+	require.NotNil(t, rpcErr)
+	assert.Equal(t, CodeUserRejected, rpcErr.Code)
+}
+
+func TestSigner_NoAllowedOrigins(t *testing.T) {
+	t.Parallel()
+
+	url, _, _ := newTestSigner(t, Config{}, "")
+
+	for _, origin := range []string{"https://gno.land", ""} {
+		_, res, err := websocket.DefaultDialer.Dial(url, http.Header{"Origin": {origin}})
+		require.Error(t, err, origin)
+		assert.Equal(t, http.StatusForbidden, res.StatusCode, origin)
+	}
+}
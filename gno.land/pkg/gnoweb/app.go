@@ -30,6 +30,10 @@ var DefaultAliases = map[string]AliasTarget{
 	"/docs":       {"/u/docs", GnowebPath},
 }
 
+// DefaultMaxRenderSize is the default maximum size, in bytes, of the render
+// of a realm converted to HTML.
+const DefaultMaxRenderSize = 1 << 20 // 1MiB
+
 // AppConfig contains configuration for gnoweb.
 type AppConfig struct {
 	// UnsafeHTML, if enabled, allows to use HTML in the markdown.
@@ -40,6 +44,9 @@ type AppConfig struct {
 	NodeRemote string
 	// NodeRequestTimeout define how much time a request to the remote node should live before timeout.
 	NodeRequestTimeout time.Duration
+	// NodeMaxIdleConns is the maximum number of idle connections kept open to
+	// the remote node, shared by the requests. Zero means the default.
+	NodeMaxIdleConns int
 	// RemoteHelp is the remote of the gno.land node, as used in the help page.
 	RemoteHelp string
 	// AssetsPath is the base path to the gnoweb assets.
@@ -56,6 +63,20 @@ type AppConfig struct {
 	Aliases map[string]AliasTarget
 	// RenderConfig defines the default configuration for rendering realms and source files.
 	RenderConfig RenderConfig
+	// MaxRenderSize is the maximum size, in bytes, of the render of a realm
+	// converted to HTML; larger renders are truncated, with a link to their
+	// raw content. Zero means no limit.
+	MaxRenderSize int
+	// Verify enables the verification of the responses of the node, with an
+	// embedded light client following the chain; pages are then marked with
+	// the height at which their content was proven, if it could be.
+	Verify bool
+	// VerifyTrustHeight is the height of the header the light client trusts
+	// first. Zero means the latest header of the node.
+	VerifyTrustHeight int64
+	// VerifyTrustHash is the hash of the header the light client trusts
+	// first. If empty, the header given by the node is trusted on first use.
+	VerifyTrustHash []byte
 }
 
 // NewDefaultAppConfig returns a new default AppConfig. The default sets
@@ -71,6 +92,7 @@ func NewDefaultAppConfig() *AppConfig {
 		Domain:             "gno.land",
 		Aliases:            DefaultAliases,
 		RenderConfig:       NewDefaultRenderConfig(),
+		MaxRenderSize:      DefaultMaxRenderSize,
 	}
 }
 
@@ -82,6 +104,7 @@ func NewRouter(logger *slog.Logger, cfg *AppConfig) (http.Handler, error) {
 	// Initialize RPC Client.
 	rpcclient, err := client.NewHTTPClient(cfg.NodeRemote,
 		client.WithRequestTimeout(cfg.NodeRequestTimeout),
+		client.WithConnPool(cfg.NodeMaxIdleConns, 0),
 	)
 	if err != nil {
 		return nil, fmt.Errorf("unable to create HTTP client: %w", err)
@@ -97,6 +120,15 @@ func NewRouter(logger *slog.Logger, cfg *AppConfig) (http.Handler, error) {
 
 	// Setup client adapter
 	adpcli := NewRPCClientAdapter(logger, rpcclient, cfg.Domain)
+	if cfg.Verify {
+		light, err := NewLightClient(context.Background(), logger, rpcclient,
+			cfg.ChainID, cfg.VerifyTrustHeight, cfg.VerifyTrustHash)
+		if err != nil {
+			return nil, fmt.Errorf("unable to start light client: %w", err)
+		}
+
+		adpcli = NewVerifiedClientAdapter(logger, rpcclient, cfg.Domain, light)
+	}
 
 	// Setup StaticMetadata
 	chromaStylePath := path.Join(assetsBase, "_chroma", "style.css")
@@ -127,6 +159,8 @@ func NewRouter(logger *slog.Logger, cfg *AppConfig) (http.Handler, error) {
 		Meta:          staticMeta,
 		Renderer:      renderer,
 		Aliases:       cfg.Aliases,
+		MaxRenderSize: cfg.MaxRenderSize,
+		Verify:        cfg.Verify,
 	})
 	if err != nil {
 		return nil, fmt.Errorf("unable to create web handler: %w", err)
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"testing"
+	"time"
 
 	"github.com/rs/xid"
 
@@ -252,3 +253,58 @@ func TestHealthEndpoints(t *testing.T) {
 		})
 	})
 }
+
+func TestVerify(t *testing.T) {
+	logger := log.NewTestingLogger(t)
+	rootdir := gnoenv.RootDir()
+	genesis := integration.LoadDefaultGenesisTXsFile(t, "tendermint_test", rootdir)
+	config, _ := integration.TestingNodeConfig(t, rootdir, genesis...)
+	config.TMConfig.Consensus.CreateEmptyBlocks = true
+	node, remoteAddr := integration.TestingInMemoryNode(t, logger, config)
+	defer node.Stop()
+
+	// Proofs need the state of a block after the genesis one.
+	require.Eventually(t, func() bool {
+		return node.BlockStore().Height() >= 3
+	}, 30*time.Second, 100*time.Millisecond)
+
+	cfg := NewDefaultAppConfig()
+	cfg.NodeRemote = remoteAddr
+	cfg.Verify = true
+	router, err := NewRouter(logger, cfg)
+	require.NoError(t, err)
+
+	routes := []struct {
+		route     string
+		status    int
+		substring string
+	}{
+		// Sources and docs are proven.
+		{"/r/gnoland/blog/admin.gno", ok, "verified at height"},
+		{"/r/gnoland/blog/", ok, "verified at height"},
+		{"/r/gnoland/blog$help", ok, "verified at height"},
+		{"/r/not/found/", notFound, ""},
+		// Renders are computed by the node.
+		{"/r/gnoland/blog", ok, "unverified"},
+	}
+
+	for _, r := range routes {
+		t.Run(r.route, func(t *testing.T) {
+			request := httptest.NewRequest(http.MethodGet, r.route, nil)
+			response := httptest.NewRecorder()
+			router.ServeHTTP(response, request)
+			assert.Equal(t, r.status, response.Code)
+			assert.Contains(t, response.Body.String(), r.substring)
+		})
+	}
+
+	t.Run("untrusted header", func(t *testing.T) {
+		cfg := NewDefaultAppConfig()
+		cfg.NodeRemote = remoteAddr
+		cfg.Verify = true
+		cfg.VerifyTrustHeight = 2
+		cfg.VerifyTrustHash = []byte("not the hash")
+		_, err := NewRouter(logger, cfg)
+		require.ErrorIs(t, err, ErrLightUntrusted)
+	})
+}
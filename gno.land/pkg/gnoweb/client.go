@@ -22,6 +22,7 @@ var (
 	ErrClientBadRequest        = errors.New("bad request")
 	ErrClientTimeout           = errors.New("RPC node request timeout")
 	ErrClientResponse          = errors.New("RPC node response error")
+	ErrClientVerification      = errors.New("RPC node response verification failed")
 )
 
 type FileMeta struct {
@@ -48,6 +49,10 @@ type ClientAdapter interface {
 	// Doc retrieves the JSON doc suitable for printing from a
 	// specified package path.
 	Doc(ctx context.Context, path string) (*doc.JSONDocumentation, error)
+
+	// AddressOwner returns the package path an address is derived from,
+	// or an empty string if the address is a user account.
+	AddressOwner(ctx context.Context, addr string) (string, error)
 }
 
 type rpcClient struct {
@@ -171,6 +176,19 @@ func (c *rpcClient) Doc(ctx context.Context, pkgPath string) (*doc.JSONDocumenta
 	return jdoc, nil
 }
 
+// AddressOwner returns the package path an address is derived from, or an
+// empty string if the address is a user account.
+func (c *rpcClient) AddressOwner(ctx context.Context, addr string) (string, error) {
+	const qpath = "bank/qaddr-owner/"
+
+	res, err := c.query(ctx, qpath+addr, nil)
+	if err != nil {
+		return "", fmt.Errorf("unable to query address owner: %w", err)
+	}
+
+	return string(res), nil
+}
+
 // query sends a query to the RPC client and returns the response
 // data.
 func (c *rpcClient) query(ctx context.Context, qpath string, data []byte) ([]byte, error) {
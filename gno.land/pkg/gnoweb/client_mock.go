@@ -8,6 +8,7 @@ import (
 	"strings"
 
 	"github.com/gnolang/gno/gnovm/pkg/doc"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 )
 
 // MockPackage represents a mock package with files and function signatures for testing.
@@ -138,6 +139,21 @@ func (m *MockClient) Doc(ctx context.Context, path string) (*doc.JSONDocumentati
 	return &doc.JSONDocumentation{Funcs: pkg.Functions}, nil
 }
 
+// AddressOwner returns the path of the package whose derived address is addr.
+func (m *MockClient) AddressOwner(ctx context.Context, addr string) (string, error) {
+	if err := ctx.Err(); err != nil {
+		return "", fmt.Errorf("context error: %w", err)
+	}
+
+	for _, pkg := range m.Packages {
+		pkgPath := pkg.Domain + pkg.Path
+		if gno.DerivePkgBech32Addr(pkgPath).String() == addr {
+			return pkgPath, nil
+		}
+	}
+	return "", nil
+}
+
 // Helper: check if package has a Render(string) string function.
 func pkgHasRender(pkg *MockPackage) bool {
 	if len(pkg.Functions) == 0 {
@@ -0,0 +1,278 @@
+package gnoweb
+
+import (
+	"context"
+	"fmt"
+	"log/slog"
+	gopath "path"
+	"strings"
+	"sync"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoweb/components"
+	"github.com/gnolang/gno/gnovm/pkg/doc"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto/merkle"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store/rootmulti"
+)
+
+// CodeStoreName is the name of the store of the node holding the source
+// of the packages.
+const CodeStoreName = "vm-code"
+
+// Verification records whether the responses of the node used to build a
+// page could all be verified, and at which height.
+type Verification struct {
+	mu         sync.Mutex
+	header     *types.SignedHeader
+	proven     bool
+	unverified bool
+}
+
+type verificationKey struct{}
+
+// WithVerification returns a context recording the verification of the
+// responses of a verifying ClientAdapter in the returned Verification.
+// The responses of the context are all verified against the same header.
+func WithVerification(ctx context.Context) (context.Context, *Verification) {
+	v := &Verification{}
+	return context.WithValue(ctx, verificationKey{}, v), v
+}
+
+func verificationFrom(ctx context.Context) *Verification {
+	v, _ := ctx.Value(verificationKey{}).(*Verification)
+	return v
+}
+
+// Data returns the verification data of a page built with the responses
+// recorded in v. A page is verified only if all of them were proven.
+func (v *Verification) Data() components.VerificationData {
+	if v == nil {
+		return components.VerificationData{}
+	}
+
+	v.mu.Lock()
+	defer v.mu.Unlock()
+
+	data := components.VerificationData{Enabled: true}
+	if v.proven && !v.unverified {
+		data.Verified = true
+		data.Height = provenHeight(v.header)
+	}
+	return data
+}
+
+func (v *Verification) setUnverified() {
+	if v == nil {
+		return
+	}
+
+	v.mu.Lock()
+	v.unverified = true
+	v.mu.Unlock()
+}
+
+type verifiedClient struct {
+	*rpcClient
+	light *LightClient
+	prt   *merkle.ProofRuntime
+}
+
+var _ ClientAdapter = (*verifiedClient)(nil)
+
+// NewVerifiedClientAdapter creates a ClientAdapter verifying the Merkle
+// proofs of the responses of the node against the headers of the given
+// LightClient. Package sources and docs are proven; realm renders, path
+// listings and address owners are computed by the node, can't be proven,
+// and mark the Verification of their context as unverified.
+func NewVerifiedClientAdapter(logger *slog.Logger, cli *client.RPCClient, domain string, light *LightClient) ClientAdapter {
+	return &verifiedClient{
+		rpcClient: &rpcClient{
+			logger: logger,
+			domain: domain,
+			client: cli,
+		},
+		light: light,
+		prt:   rootmulti.DefaultProofRuntime(),
+	}
+}
+
+func (c *verifiedClient) Realm(ctx context.Context, path, args string) ([]byte, error) {
+	verificationFrom(ctx).setUnverified()
+	return c.rpcClient.Realm(ctx, path, args)
+}
+
+func (c *verifiedClient) File(ctx context.Context, path, fileName string) (out []byte, meta FileMeta, err error) {
+	fileName = strings.TrimSpace(fileName)
+	if fileName == "" {
+		return nil, meta, fmt.Errorf("empty filename given")
+	}
+
+	mpkg, err := c.memPackage(ctx, path)
+	if err != nil {
+		return nil, meta, err
+	}
+
+	file := mpkg.GetFile(fileName)
+	if file == nil {
+		return nil, meta, ErrClientFileNotFound
+	}
+
+	source := []byte(file.Body)
+	meta = FileMeta{
+		Lines:  strings.Count(file.Body, "\n"),
+		SizeKB: float64(len(source)) / 1024.0,
+	}
+	return source, meta, nil
+}
+
+func (c *verifiedClient) ListFiles(ctx context.Context, path string) ([]string, error) {
+	mpkg, err := c.memPackage(ctx, path)
+	if err != nil {
+		return nil, err
+	}
+
+	files := make([]string, len(mpkg.Files))
+	for i, file := range mpkg.Files {
+		files[i] = file.Name
+	}
+	return files, nil
+}
+
+func (c *verifiedClient) ListPaths(ctx context.Context, prefix string, limit int) ([]string, error) {
+	verificationFrom(ctx).setUnverified()
+	return c.rpcClient.ListPaths(ctx, prefix, limit)
+}
+
+// Doc builds the documentation of the package from its proven source, as
+// the node does.
+func (c *verifiedClient) Doc(ctx context.Context, pkgPath string) (*doc.JSONDocumentation, error) {
+	mpkg, err := c.memPackage(ctx, pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to query qdoc: %w", err)
+	}
+
+	d, err := doc.NewDocumentableFromMemPkg(mpkg, true, "", "")
+	if err != nil {
+		return nil, fmt.Errorf("unable to build doc: %w", err)
+	}
+	return d.WriteJSONDocumentation(nil)
+}
+
+func (c *verifiedClient) AddressOwner(ctx context.Context, addr string) (string, error) {
+	verificationFrom(ctx).setUnverified()
+	return c.rpcClient.AddressOwner(ctx, addr)
+}
+
+// memPackage queries the MemPackage at the given path from the code store
+// of the node, and verifies its Merkle proof.
+func (c *verifiedClient) memPackage(ctx context.Context, path string) (*std.MemPackage, error) {
+	pkgPath := gopath.Join(c.domain, strings.Trim(path, "/"))
+	key := gnolang.MemPackageStoreKey(pkgPath)
+
+	bz, err := c.proveKey(ctx, CodeStoreName, key)
+	if err != nil {
+		return nil, err
+	}
+
+	if bz == nil {
+		return nil, ErrClientPackageNotFound
+	}
+
+	mpkg := &std.MemPackage{}
+	if err := amino.Unmarshal(bz, mpkg); err != nil {
+		return nil, fmt.Errorf("%w: unable to decode package %q: %w", ErrClientVerification, pkgPath, err)
+	}
+	return mpkg, nil
+}
+
+// proveKey queries the value of key in the given store of the node, and
+// verifies its Merkle proof, or the proof of its absence if the returned
+// value is nil, against the app hash of the header of the Verification of
+// ctx, or of the latest header of the light client.
+func (c *verifiedClient) proveKey(ctx context.Context, storeName string, key []byte) ([]byte, error) {
+	v := verificationFrom(ctx)
+	header, err := c.header(ctx, v)
+	if err != nil {
+		return nil, err
+	}
+
+	// The app hash of a header is the one of the state of the previous
+	// block.
+	height := provenHeight(header)
+	qpath := ".store/" + storeName + "/key"
+	c.logger.Info("querying node", "path", qpath, "data", string(key), "height", height, "prove", true)
+
+	qres, err := c.client.ABCIQueryWithOptions(ctx, qpath, key, client.ABCIQueryOptions{
+		Height: height,
+		Prove:  true,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("%w: %s", ErrClientBadRequest, err.Error())
+	}
+
+	res := qres.Response
+	if res.Error != nil {
+		return nil, fmt.Errorf("%w: %w", ErrClientResponse, res.Error)
+	}
+
+	if res.Height != height || res.Proof == nil {
+		return nil, fmt.Errorf("%w: no proof for height %d", ErrClientVerification, height)
+	}
+
+	kp := merkle.KeyPath{}.
+		AppendKey([]byte(storeName), merkle.KeyEncodingURL).
+		AppendKey(key, merkle.KeyEncodingURL)
+	if res.Value == nil {
+		err = c.prt.VerifyAbsence(res.Proof, header.AppHash, kp.String())
+	} else {
+		err = c.prt.VerifyValue(res.Proof, header.AppHash, kp.String(), res.Value)
+	}
+	if err != nil {
+		c.logger.Error("invalid proof",
+			"path", qpath,
+			"data", string(key),
+			"height", height,
+			"error", err,
+		)
+		return nil, fmt.Errorf("%w: %w", ErrClientVerification, err)
+	}
+
+	if v != nil {
+		v.mu.Lock()
+		v.proven = true
+		v.mu.Unlock()
+	}
+	return res.Value, nil
+}
+
+// header returns the header the responses recorded in v are verified
+// against, which is the latest header of the light client when first used.
+func (c *verifiedClient) header(ctx context.Context, v *Verification) (*types.SignedHeader, error) {
+	if v != nil {
+		v.mu.Lock()
+		defer v.mu.Unlock()
+		if v.header != nil {
+			return v.header, nil
+		}
+	}
+
+	header, err := c.light.Latest(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrClientVerification, err)
+	}
+
+	if v != nil {
+		v.header = header
+	}
+	return header, nil
+}
+
+// provenHeight returns the height of the state whose app hash is in the
+// given header.
+func provenHeight(header *types.SignedHeader) int64 {
+	return header.Height - 1
+}
@@ -1,6 +1,9 @@
 package components
 
 import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
 	"fmt"
 	"io"
 	"time"
@@ -36,6 +39,65 @@ func (c *readerComponent) Render(w io.Writer) (err error) {
 	return err
 }
 
+// StreamComponent is a component written directly to the client, as it's
+// rendered, instead of being buffered with the page around it. See
+// RenderStream.
+type StreamComponent struct {
+	Component
+	placeholder string
+}
+
+// NewStreamComponent returns a StreamComponent rendering comp.
+func NewStreamComponent(comp Component) *StreamComponent {
+	var nonce [16]byte
+	rand.Read(nonce[:])
+	return &StreamComponent{
+		Component:   comp,
+		placeholder: "<!-- stream:" + hex.EncodeToString(nonce[:]) + " -->",
+	}
+}
+
+// Render writes the placeholder of the component, which RenderStream
+// replaces with its content.
+func (c *StreamComponent) Render(w io.Writer) error {
+	_, err := io.WriteString(w, c.placeholder)
+	return err
+}
+
+// RenderStream renders page to w, writing the content of stream, if any, in
+// place of its placeholder as it's rendered. The page before the placeholder
+// is flushed first, if w can be flushed, so that the client can start loading
+// the page.
+func RenderStream(w io.Writer, page Component, stream *StreamComponent) error {
+	if stream == nil {
+		return page.Render(w)
+	}
+
+	var buf bytes.Buffer
+	if err := page.Render(&buf); err != nil {
+		return err
+	}
+
+	before, after, found := bytes.Cut(buf.Bytes(), []byte(stream.placeholder))
+	if _, err := w.Write(before); err != nil || !found {
+		return err
+	}
+	if f, ok := w.(interface{ Flush() }); ok {
+		f.Flush()
+	}
+
+	// Still write the end of the page if the content fails to render, as
+	// the beginning is sent already.
+	streamErr := stream.Component.Render(w)
+	if _, err := w.Write(after); err != nil {
+		return err
+	}
+	if streamErr != nil {
+		return fmt.Errorf("unable to render stream: %w", streamErr)
+	}
+	return nil
+}
+
 func FormatRelativeTimeSince(t time.Time) string {
 	diff := time.Since(t)
 
@@ -18,14 +18,23 @@ type HeaderLinks struct {
 	Dev     []HeaderLink
 }
 
+// VerificationData describes whether the content of a page was verified
+// against the chain, when gnoweb runs in verify mode.
+type VerificationData struct {
+	Enabled  bool
+	Verified bool
+	Height   int64 // height of the state the content was proven in
+}
+
 type HeaderData struct {
-	RealmPath  string
-	RealmURL   weburl.GnoURL
-	Breadcrumb BreadcrumbData
-	Links      HeaderLinks
-	ChainId    string
-	Remote     string
-	Mode       ViewMode
+	RealmPath    string
+	RealmURL     weburl.GnoURL
+	Breadcrumb   BreadcrumbData
+	Links        HeaderLinks
+	ChainId      string
+	Remote       string
+	Mode         ViewMode
+	Verification VerificationData
 }
 
 func StaticHeaderGeneralLinks() []HeaderLink {
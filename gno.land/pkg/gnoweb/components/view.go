@@ -12,6 +12,10 @@ type ViewType string
 type View struct {
 	Type ViewType
 	Component
+
+	// Stream is the content of the view written directly to the client,
+	// if any; see RenderStream.
+	Stream *StreamComponent
 }
 
 func (v *View) String() string {
@@ -8,7 +8,16 @@ type DirData struct {
 	FileCounter int
 	FilesLinks  FilesLinks
 	Mode        ViewMode
-	Readme      Component
+	DirMeta
+}
+
+// DirMeta is the documentation of a package, shown at the top of its
+// directory view.
+type DirMeta struct {
+	Readme      Component // README.md, or the package documentation
+	ReadmeFile  string    // file of Readme, if any
+	Description string    // declared in gnomod.toml
+	Authors     []string  // declared in gnomod.toml
 }
 
 type DirLinkType int
@@ -46,7 +55,7 @@ func GetFullLinks(files []string, linkType DirLinkType, pkgPath string) FilesLin
 	return result
 }
 
-func DirectoryView(pkgPath string, files []string, fileCounter int, linkType DirLinkType, mode ViewMode, readme ...Component) *View {
+func DirectoryView(pkgPath string, files []string, fileCounter int, linkType DirLinkType, mode ViewMode, meta ...DirMeta) *View {
 	viewData := DirData{
 		PkgPath:     pkgPath,
 		Files:       files,
@@ -54,8 +63,8 @@ func DirectoryView(pkgPath string, files []string, fileCounter int, linkType Dir
 		FileCounter: fileCounter,
 		Mode:        mode,
 	}
-	if len(readme) > 0 {
-		viewData.Readme = readme[0]
+	if len(meta) > 0 {
+		viewData.DirMeta = meta[0]
 	}
 	return NewTemplateView(DirectoryViewType, "renderDir", viewData)
 }
@@ -10,13 +10,23 @@ type RealmTOCData struct {
 	Items []*markdown.TocItem
 }
 
+// RealmTruncatedData describes a realm render truncated to its first
+// RenderedSize bytes.
+type RealmTruncatedData struct {
+	Size         int
+	RenderedSize int
+	RawURL       string
+}
+
 type RealmData struct {
 	ComponentContent Component
 	TocItems         *RealmTOCData
+	Truncated        *RealmTruncatedData
 }
 
 type ArticleData struct {
 	ComponentContent Component
+	ComponentFooter  Component
 	Classes          string
 }
 
@@ -25,6 +35,8 @@ type realmViewParams struct {
 	ComponentTOC Component
 }
 
+// RealmView returns the view of a realm. If its content is a StreamComponent,
+// it's the stream of the view.
 func RealmView(data RealmData) *View {
 	viewData := realmViewParams{
 		Article: ArticleData{
@@ -33,6 +45,11 @@ func RealmView(data RealmData) *View {
 		},
 		ComponentTOC: NewTemplateComponent("ui/toc_realm", data.TocItems),
 	}
+	if data.Truncated != nil {
+		viewData.Article.ComponentFooter = NewTemplateComponent("ui/render_truncated", data.Truncated)
+	}
 
-	return NewTemplateView(RealmViewType, "renderRealm", viewData)
+	view := NewTemplateView(RealmViewType, "renderRealm", viewData)
+	view.Stream, _ = data.ComponentContent.(*StreamComponent)
+	return view
 }
@@ -346,3 +346,17 @@ func TestUserView(t *testing.T) {
 
 	assert.NoError(t, view.Render(io.Discard))
 }
+
+func TestRenderStream(t *testing.T) {
+	stream := NewStreamComponent(NewReaderComponent(strings.NewReader("<p>content</p>")))
+	view := RealmView(RealmData{
+		TocItems:         &RealmTOCData{},
+		ComponentContent: stream,
+	})
+	assert.Same(t, stream, view.Stream)
+
+	var buf strings.Builder
+	assert.NoError(t, RenderStream(&buf, view, view.Stream))
+	assert.Contains(t, buf.String(), "<p>content</p>")
+	assert.NotContains(t, buf.String(), stream.placeholder)
+}
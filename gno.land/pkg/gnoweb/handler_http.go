@@ -8,19 +8,25 @@ import (
 	"go/token"
 	"log/slog"
 	"net/http"
+	"net/url"
 	"path"
 	"slices"
 	"sort"
 	"strings"
 	"time"
+	"unicode/utf8"
 
 	"github.com/gnolang/gno/gno.land/pkg/gnoweb/components"
 	"github.com/gnolang/gno/gno.land/pkg/gnoweb/weburl"
 	"github.com/gnolang/gno/gnovm/pkg/doc"
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
 	"github.com/gnolang/gno/tm2/pkg/bech32"
 )
 
-const ReadmeFileName = "README.md"
+const (
+	ReadmeFileName = "README.md"
+	GnomodFileName = "gnomod.toml"
+)
 
 // StaticMetadata holds static configuration for a web handler.
 type StaticMetadata struct {
@@ -51,6 +57,12 @@ type HTTPHandlerConfig struct {
 	Renderer      Renderer
 	Aliases       map[string]AliasTarget
 	Timeout       time.Duration
+	// MaxRenderSize is the maximum size, in bytes, of the render of a realm
+	// converted to HTML; larger renders are truncated. Zero means no limit.
+	MaxRenderSize int
+	// Verify marks the pages with the verification of their content, as
+	// recorded by a ClientAdapter created with NewVerifiedClientAdapter.
+	Verify bool
 }
 
 // validate checks if the HTTPHandlerConfig is valid.
@@ -74,6 +86,9 @@ type HTTPHandler struct {
 	Client   ClientAdapter
 	Renderer Renderer
 	Aliases  map[string]AliasTarget
+
+	MaxRenderSize int
+	Verify        bool
 }
 
 // NewHTTPHandler creates a new HTTPHandler.
@@ -88,6 +103,9 @@ func NewHTTPHandler(logger *slog.Logger, cfg *HTTPHandlerConfig) (*HTTPHandler,
 		Renderer: cfg.Renderer,
 		Aliases:  cfg.Aliases,
 		Logger:   logger,
+
+		MaxRenderSize: cfg.MaxRenderSize,
+		Verify:        cfg.Verify,
 	}, nil
 }
 
@@ -142,12 +160,26 @@ func (h *HTTPHandler) Get(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
+	// Record the verification of the responses of the node for the page.
+	var verification *Verification
+	if h.Verify {
+		var ctx context.Context
+		ctx, verification = WithVerification(r.Context())
+		r = r.WithContext(ctx)
+	}
+
 	// Handle download request outside of component rendering flow.
 	if gnourl.WebQuery.Has("download") {
 		h.ServeSourceDownload(r.Context(), gnourl, w, r)
 		return
 	}
 
+	// Handle raw render request outside of component rendering flow.
+	if gnourl.WebQuery.Has("raw") {
+		h.ServeRealmRaw(r.Context(), gnourl, w, r)
+		return
+	}
+
 	// Set the header mode based on the URL type and context
 	switch {
 	case r.RequestURI == "/": // is home path
@@ -162,10 +194,12 @@ func (h *HTTPHandler) Get(w http.ResponseWriter, r *http.Request) {
 
 	var status int
 	status, indexData.BodyView = h.prepareIndexBodyView(r, &indexData)
+	indexData.HeaderData.Verification = verification.Data()
 
-	// Render the final page with the rendered body
+	// Render the final page with the rendered body, streaming its content
 	w.WriteHeader(status)
-	if err := components.IndexLayout(indexData).Render(w); err != nil {
+	index := components.IndexLayout(indexData)
+	if err := components.RenderStream(w, index, indexData.BodyView.Stream); err != nil {
 		h.Logger.Error("failed to render index component", "error", err)
 	}
 }
@@ -194,13 +228,33 @@ func (h *HTTPHandler) Post(w http.ResponseWriter, r *http.Request) {
 		return
 	}
 
-	// Use form data as query
-	gnourl.Query = r.PostForm
+	if gnourl.WebQuery.Has("help") && gnourl.WebQuery.Get("func") != "" {
+		// Forms calling a function fill its arguments on the help page.
+		// Only keys which can name an argument are kept, so that a form
+		// can't set the other options of the page.
+		for key, vals := range r.PostForm {
+			if isFuncArgKey(key) {
+				gnourl.WebQuery[key] = vals
+			}
+		}
+	} else {
+		// Use form data as query
+		gnourl.Query = r.PostForm
+	}
 
 	// Redirect to the new URL
 	http.Redirect(w, r, gnourl.EncodeWebURL(), http.StatusSeeOther)
 }
 
+// webQueryKeys are the keys of the web query reserved by gnoweb.
+var webQueryKeys = []string{"help", "func", "source", "file", "download", "raw"}
+
+// isFuncArgKey returns true if key can be the name of a function argument
+// in the web query of a help page.
+func isFuncArgKey(key string) bool {
+	return token.IsIdentifier(key) && !slices.Contains(webQueryKeys, key)
+}
+
 // prepareIndexBodyView prepares the data and main view for the index page.
 func (h *HTTPHandler) prepareIndexBodyView(r *http.Request, indexData *components.IndexData) (int, *components.View) {
 	ctx := r.Context()
@@ -298,23 +352,56 @@ func (h *HTTPHandler) GetRealmView(ctx context.Context, gnourl *weburl.GnoURL, i
 		return GetClientErrorStatusPage(gnourl, err)
 	}
 
-	var content bytes.Buffer
-	meta, err := h.Renderer.RenderRealm(&content, gnourl, raw)
-	if err != nil {
-		h.Logger.Error("unable to render realm", "error", err, "path", gnourl.EncodeURL())
-		return GetClientErrorStatusPage(gnourl, err)
+	var truncated *components.RealmTruncatedData
+	if size := len(raw); h.MaxRenderSize > 0 && size > h.MaxRenderSize {
+		raw = truncateRender(raw, h.MaxRenderSize)
+
+		rawURL := *gnourl
+		rawURL.WebQuery = url.Values{"raw": {""}}
+		truncated = &components.RealmTruncatedData{
+			Size:         size,
+			RenderedSize: len(raw),
+			RawURL:       rawURL.EncodeWebURL(),
+		}
 	}
 
+	// The realm is rendered while it's written to the client, instead of
+	// being buffered, which is why it can't fail with an error page.
+	meta, content := h.Renderer.ParseRealm(gnourl, raw)
 	return http.StatusOK, components.RealmView(components.RealmData{
 		TocItems: &components.RealmTOCData{
 			Items: meta.Items,
 		},
-		// NOTE: `RenderRealm` should ensure that HTML content is
+		// NOTE: `ParseRealm` should ensure that HTML content is
 		// sanitized before rendering
-		ComponentContent: components.NewReaderComponent(&content),
+		ComponentContent: components.NewStreamComponent(content),
+		Truncated:        truncated,
 	})
 }
 
+// truncateRender truncates a realm render to at most maxSize bytes, at the
+// end of its last complete line if there is one.
+func truncateRender(raw []byte, maxSize int) []byte {
+	if len(raw) <= maxSize {
+		return raw
+	}
+
+	raw = raw[:maxSize]
+	if i := bytes.LastIndexByte(raw, '\n'); i >= 0 {
+		return raw[:i+1]
+	}
+
+	// Don't cut a multi-byte character.
+	i := len(raw) - 1
+	for i > 0 && !utf8.RuneStart(raw[i]) {
+		i--
+	}
+	if i >= 0 && !utf8.FullRune(raw[i:]) {
+		raw = raw[:i]
+	}
+	return raw
+}
+
 // buildContributions returns the sorted list of contributions (packages and realms) for a user.
 func (h *HTTPHandler) buildContributions(ctx context.Context, username string) ([]components.UserContribution, int, error) {
 	prefix := "@" + username
@@ -392,16 +479,30 @@ func (h *HTTPHandler) GetUserView(ctx context.Context, gnourl *weburl.GnoURL) (i
 	pkgCount := len(contribs)
 	pureCount := pkgCount - realmCount
 
+	// Realm addresses hold the funds of their realm, not of a user.
+	var owner, bio string
+	if _, _, err := bech32.Decode(username); err == nil {
+		owner, err = h.Client.AddressOwner(ctx, username)
+		if err != nil {
+			h.Logger.Debug("unable to fetch address owner", "username", username, "error", err)
+		}
+	}
+
 	// TODO: Check username from r/sys/users in addition to bech32 address test (username + gno address to be used)
 	// Try to decode the bech32 address
 	username = CreateUsernameFromBech32(username)
 
 	//TODO: get from user r/profile and use placeholder if not set
 	handlename := "Gnome " + username
+	if owner != "" {
+		handlename = "Realm " + username
+		bio = "This address is derived from " + owner + " and holds its funds; it isn't a user account."
+	}
 
 	data := components.UserData{
 		Username:      username,
 		Handlename:    handlename,
+		Bio:           bio,
 		Contributions: contribs,
 		PackageCount:  pkgCount,
 		RealmCount:    realmCount,
@@ -622,18 +723,68 @@ func (h *HTTPHandler) GetDirectoryView(ctx context.Context, gnourl *weburl.GnoUR
 		indexData.Mode = components.ViewModePackage
 	}
 
-	// Get README.md file if it exists
-	readmeComp, _ := h.renderReadme(ctx, gnourl, pkgPath)
 	return http.StatusOK, components.DirectoryView(
 		pkgPath,
 		files,
 		len(files),
 		components.DirLinkTypeSource,
 		indexData.Mode,
-		readmeComp,
+		h.getDirMeta(ctx, gnourl, pkgPath, files),
 	)
 }
 
+// getDirMeta returns the documentation shown at the top of the directory view
+// of a package: its README.md or, without one, its package documentation, and
+// the description and authors declared in its gnomod.toml.
+func (h *HTTPHandler) getDirMeta(ctx context.Context, gnourl *weburl.GnoURL, pkgPath string, files []string) components.DirMeta {
+	var meta components.DirMeta
+	if slices.Contains(files, ReadmeFileName) {
+		if readme, _ := h.renderReadme(ctx, gnourl, pkgPath); readme != nil {
+			meta.Readme = readme
+			meta.ReadmeFile = ReadmeFileName
+		}
+	}
+	if meta.Readme == nil {
+		meta.Readme = h.renderPackageDoc(ctx, gnourl, pkgPath)
+	}
+
+	if slices.Contains(files, GnomodFileName) {
+		file, _, err := h.Client.File(ctx, pkgPath, GnomodFileName)
+		if err != nil {
+			h.Logger.Warn("fetch gnomod.toml", "path", pkgPath, "error", err)
+			return meta
+		}
+		mod, err := gnomod.ParseBytes(GnomodFileName, file)
+		if err != nil {
+			h.Logger.Warn("parse gnomod.toml", "path", pkgPath, "error", err)
+			return meta
+		}
+		meta.Description = mod.Description
+		meta.Authors = mod.Authors
+	}
+	return meta
+}
+
+// renderPackageDoc renders the documentation comment of the package, such as
+// the one of its doc.gno file.
+func (h *HTTPHandler) renderPackageDoc(ctx context.Context, gnourl *weburl.GnoURL, pkgPath string) components.Component {
+	jdoc, err := h.Client.Doc(ctx, pkgPath)
+	if err != nil {
+		h.Logger.Warn("fetch package doc", "path", pkgPath, "error", err)
+		return nil
+	}
+	if strings.TrimSpace(jdoc.PackageDoc) == "" {
+		return nil
+	}
+
+	var buf bytes.Buffer
+	if _, err := h.Renderer.RenderRealm(&buf, gnourl, []byte(jdoc.PackageDoc)); err != nil {
+		h.Logger.Error("render package doc", "error", err)
+		return nil
+	}
+	return components.NewReaderComponent(&buf)
+}
+
 // ServeSourceDownload handles downloading a source file as plain text.
 func (h *HTTPHandler) ServeSourceDownload(ctx context.Context, gnourl *weburl.GnoURL, w http.ResponseWriter, r *http.Request) {
 	pkgPath := gnourl.Path
@@ -666,6 +817,22 @@ func (h *HTTPHandler) ServeSourceDownload(ctx context.Context, gnourl *weburl.Gn
 	w.Write(source) // write raw file
 }
 
+// ServeRealmRaw handles serving the render of a realm as plain text.
+func (h *HTTPHandler) ServeRealmRaw(ctx context.Context, gnourl *weburl.GnoURL, w http.ResponseWriter, r *http.Request) {
+	raw, err := h.Client.Realm(ctx, gnourl.Path, gnourl.EncodeArgs())
+	if err != nil {
+		h.Logger.Warn("unable to fetch realm", "error", err, "path", gnourl.EncodeURL())
+		status, _ := GetClientErrorStatusPage(gnourl, err)
+		http.Error(w, http.StatusText(status), status)
+		return
+	}
+
+	// Send the raw render, without converting it to HTML
+	w.Header().Set("Content-Type", "text/plain; charset=utf-8")
+	w.WriteHeader(http.StatusOK)
+	w.Write(raw)
+}
+
 func GetClientErrorStatusPage(_ *weburl.GnoURL, err error) (int, *components.View) {
 	if err == nil {
 		return http.StatusOK, nil
@@ -676,6 +843,8 @@ func GetClientErrorStatusPage(_ *weburl.GnoURL, err error) (int, *components.Vie
 		return http.StatusRequestTimeout, components.StatusErrorComponent(err.Error())
 	case errors.Is(err, ErrClientPackageNotFound):
 		return http.StatusNotFound, components.StatusErrorComponent(err.Error())
+	case errors.Is(err, ErrClientVerification):
+		return http.StatusBadGateway, components.StatusErrorComponent("unable to verify the response of the node")
 	case errors.Is(err, ErrClientBadRequest):
 		return http.StatusInternalServerError, components.StatusErrorComponent("bad request")
 	case errors.Is(err, ErrClientResponse):
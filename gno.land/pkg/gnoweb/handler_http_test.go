@@ -15,6 +15,7 @@ import (
 	"time"
 
 	"github.com/gnolang/gno/gno.land/pkg/gnoweb"
+	"github.com/gnolang/gno/gno.land/pkg/gnoweb/components"
 	md "github.com/gnolang/gno/gno.land/pkg/gnoweb/markdown"
 	"github.com/gnolang/gno/gno.land/pkg/gnoweb/weburl"
 	"github.com/gnolang/gno/gnovm/pkg/doc"
@@ -39,6 +40,7 @@ type stubClient struct {
 	docFunc       func(ctx context.Context, path string) (*doc.JSONDocumentation, error)
 	listFilesFunc func(ctx context.Context, path string) ([]string, error)
 	listPathsFunc func(ctx context.Context, prefix string, limit int) ([]string, error)
+	ownerFunc     func(ctx context.Context, addr string) (string, error)
 }
 
 func (s *stubClient) Realm(ctx context.Context, path, args string) ([]byte, error) {
@@ -76,6 +78,13 @@ func (s *stubClient) ListPaths(ctx context.Context, prefix string, limit int) ([
 	return nil, errors.New("stubClient: ListPaths not implemented")
 }
 
+func (s *stubClient) AddressOwner(ctx context.Context, addr string) (string, error) {
+	if s.ownerFunc != nil {
+		return s.ownerFunc(ctx, addr)
+	}
+	return "", errors.New("stubClient: AddressOwner not implemented")
+}
+
 type rawRenderer struct{}
 
 func (rawRenderer) RenderRealm(w io.Writer, u *weburl.GnoURL, src []byte) (md.Toc, error) {
@@ -83,6 +92,10 @@ func (rawRenderer) RenderRealm(w io.Writer, u *weburl.GnoURL, src []byte) (md.To
 	return md.Toc{}, err
 }
 
+func (rawRenderer) ParseRealm(u *weburl.GnoURL, src []byte) (md.Toc, components.Component) {
+	return md.Toc{}, components.NewReaderComponent(bytes.NewReader(src))
+}
+
 func (rawRenderer) RenderSource(w io.Writer, name string, src []byte) error {
 	_, err := w.Write(src)
 	return err
@@ -346,6 +359,99 @@ func TestHTTPHandler_DirectoryViewPurePackage(t *testing.T) {
 	assert.Contains(t, rr.Body.String(), "/p/pkg/")
 }
 
+func TestHTTPHandler_DirectoryViewPackageDoc(t *testing.T) {
+	t.Parallel()
+
+	const gnomod = `module = "gno.land/p/pkg"
+gno = "0.9"
+description = "A package for testing."
+authors = ["Alice", "Bob"]
+`
+	cases := []struct {
+		name     string
+		files    map[string]string
+		doc      string
+		docErr   error
+		contains []string
+		excludes []string
+	}{
+		{
+			name: "readme and gnomod",
+			files: map[string]string{
+				"README.md":   "# Readme content",
+				"gnomod.toml": gnomod,
+				"pkg.gno":     "package pkg",
+			},
+			doc:      "Package pkg documentation.",
+			contains: []string{"A package for testing.", "By Alice, Bob", "Readme content", "$source&amp;file=README.md"},
+			excludes: []string{"Package pkg documentation."},
+		},
+		{
+			name: "doc comment without readme",
+			files: map[string]string{
+				"doc.gno": "// Package pkg documentation.\npackage pkg",
+			},
+			doc:      "Package pkg documentation.",
+			contains: []string{"Documentation", "Package pkg documentation."},
+			excludes: []string{"By Alice"},
+		},
+		{
+			name:     "doc error",
+			files:    map[string]string{"pkg.gno": "package pkg"},
+			docErr:   errors.New("doc error"),
+			contains: []string{"pkg.gno"},
+			excludes: []string{`href="#ico-readme"`},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			client := &stubClient{
+				listFilesFunc: func(ctx context.Context, path string) ([]string, error) {
+					var files []string
+					for name := range tc.files {
+						files = append(files, name)
+					}
+					return files, nil
+				},
+				fileFunc: func(ctx context.Context, path, filename string) ([]byte, gnoweb.FileMeta, error) {
+					body, ok := tc.files[filename]
+					if !ok {
+						return nil, gnoweb.FileMeta{}, gnoweb.ErrClientFileNotFound
+					}
+					return []byte(body), gnoweb.FileMeta{}, nil
+				},
+				docFunc: func(ctx context.Context, path string) (*doc.JSONDocumentation, error) {
+					if tc.docErr != nil {
+						return nil, tc.docErr
+					}
+					return &doc.JSONDocumentation{PackageDoc: tc.doc}, nil
+				},
+			}
+			handler, err := gnoweb.NewHTTPHandler(
+				slog.New(slog.NewTextHandler(&testingLogger{t}, nil)),
+				newTestHandlerConfig(t, client),
+			)
+			require.NoError(t, err)
+
+			req := httptest.NewRequest(http.MethodGet, "/p/pkg/", nil)
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusOK, rr.Code)
+			body := rr.Body.String()
+			for _, s := range tc.contains {
+				assert.Contains(t, body, s)
+			}
+			for _, s := range tc.excludes {
+				assert.NotContains(t, body, s)
+			}
+		})
+	}
+}
+
 // TestHTTPHandler_DirectoryViewErrorTotal covers the case where neither Sources nor QueryPaths return anything:
 func TestHTTPHandler_DirectoryViewErrorTotal(t *testing.T) {
 	t.Parallel()
@@ -430,6 +536,41 @@ func TestHTTPHandler_ServeHTTPMethodNotAllowed(t *testing.T) {
 	assert.Contains(t, rr.Body.String(), "method not allowed")
 }
 
+// TestHTTPHandler_Post verifies form submissions redirect to the render or
+// help page with the submitted values.
+func TestHTTPHandler_Post(t *testing.T) {
+	t.Parallel()
+
+	minimalMock := gnoweb.NewMockClient(&gnoweb.MockPackage{Path: "/", Files: map[string]string{}})
+	cfg := newTestHandlerConfig(t, minimalMock)
+	logger := slog.New(slog.NewTextHandler(&testingLogger{t}, &slog.HandlerOptions{Level: slog.LevelDebug}))
+	handler, err := gnoweb.NewHTTPHandler(logger, cfg)
+	require.NoError(t, err)
+
+	cases := []struct {
+		target   string
+		form     string
+		location string
+	}{
+		{"/r/ex:submit", "name=foo+bar&func=Other", "/r/ex:submit?func=Other&name=foo+bar"},
+		{"/r/ex$help&func=Transfer", "name=foo+bar&func=Other", "/r/ex$func=Transfer&help&name=foo+bar"},
+		// Keys which can't name an argument are dropped on help pages.
+		{"/r/ex$help&func=Transfer", "name=foo&source=1&a%3Cb=x&download", "/r/ex$func=Transfer&help&name=foo"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.target, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodPost, tc.target, strings.NewReader(tc.form))
+			req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+			rr := httptest.NewRecorder()
+			handler.ServeHTTP(rr, req)
+
+			assert.Equal(t, http.StatusSeeOther, rr.Code)
+			assert.Equal(t, tc.location, rr.Header().Get("Location"))
+		})
+	}
+}
+
 // TestHTTPHandler_DirectoryViewNoFiles covers the case where Sources returns
 // no error but the list is empty (len(files)==0).
 func TestHTTPHandler_DirectoryViewNoFiles(t *testing.T) {
@@ -621,6 +762,43 @@ func TestHTTPHandler_GetUserView(t *testing.T) {
 	assert.Contains(t, body, "testuser")
 }
 
+func TestHTTPHandler_GetUserView_RealmAddress(t *testing.T) {
+	t.Parallel()
+
+	const addr = "g1manfred47kzduec920z88wfr64ylksmdcedlf5"
+	client := &stubClient{
+		listPathsFunc: func(ctx context.Context, prefix string, limit int) ([]string, error) {
+			return nil, nil
+		},
+		ownerFunc: func(ctx context.Context, a string) (string, error) {
+			if a != addr {
+				return "", nil
+			}
+			return "gno.land/r/demo/escrow", nil
+		},
+	}
+
+	cfg := newTestHandlerConfig(t, client)
+
+	handler, err := gnoweb.NewHTTPHandler(
+		slog.New(slog.NewTextHandler(&testingLogger{t}, nil)),
+		cfg,
+	)
+	require.NoError(t, err)
+
+	req := httptest.NewRequest(http.MethodGet, "/u/"+addr, nil)
+	rr := httptest.NewRecorder()
+	handler.ServeHTTP(rr, req)
+
+	assert.Equal(t, http.StatusOK, rr.Code)
+	body := rr.Body.String()
+
+	// The address is shown as the account of its realm, not as a user.
+	assert.Contains(t, body, "Realm g1ma...dlf5")
+	assert.NotContains(t, body, "Gnome")
+	assert.Contains(t, body, "gno.land/r/demo/escrow")
+}
+
 func TestHTTPHandler_GetUserView_QueryPathsError(t *testing.T) {
 	t.Parallel()
 
@@ -1045,3 +1223,50 @@ func TestHTTPHandler_DownloadWithContext(t *testing.T) {
 	assert.True(t, contextReceived)
 	assert.Contains(t, rr.Body.String(), content)
 }
+
+func TestHTTPHandler_GetRealmView_Truncated(t *testing.T) {
+	t.Parallel()
+
+	render := strings.Repeat("line of the render\n", 100)
+	client := &stubClient{
+		realmFunc: func(ctx context.Context, path, args string) ([]byte, error) {
+			return []byte(render), nil
+		},
+	}
+
+	cfg := newTestHandlerConfig(t, client)
+	cfg.MaxRenderSize = 100
+	handler, err := gnoweb.NewHTTPHandler(
+		slog.New(slog.NewTextHandler(&testingLogger{t}, nil)),
+		cfg,
+	)
+	require.NoError(t, err)
+
+	t.Run("truncated", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/r/test/path:arg", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		body := rr.Body.String()
+		// Only the complete lines within the limit are rendered.
+		assert.Equal(t, 5, strings.Count(body, "line of the render"))
+		assert.Contains(t, body, "only its first 95 bytes are shown")
+		assert.Contains(t, body, `href="/r/test/path:arg$raw"`)
+		assert.True(t, strings.HasSuffix(strings.TrimSpace(body), "</html>"))
+	})
+
+	t.Run("raw", func(t *testing.T) {
+		t.Parallel()
+
+		req := httptest.NewRequest(http.MethodGet, "/r/test/path:arg$raw", nil)
+		rr := httptest.NewRecorder()
+		handler.ServeHTTP(rr, req)
+
+		assert.Equal(t, http.StatusOK, rr.Code)
+		assert.Equal(t, "text/plain; charset=utf-8", rr.Header().Get("Content-Type"))
+		assert.Equal(t, render, rr.Body.String())
+	})
+}
@@ -0,0 +1,165 @@
+package gnoweb
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"log/slog"
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+// DefaultLightRefreshInterval is the default minimum interval between two
+// updates of the header trusted by a LightClient.
+const DefaultLightRefreshInterval = time.Second
+
+var ErrLightUntrusted = errors.New("untrusted header")
+
+// LightClient is a minimal light client of the chain of a node. Starting
+// from a trusted header, it follows the latest headers of the chain and
+// only accepts those committed by more than 2/3 of the voting power of the
+// validators it already trusts. The app hashes of its headers are then used
+// to verify the Merkle proofs of the responses of the node.
+//
+// XXX: the validator set may only change between two updates if more than
+// 2/3 of the trusted set signed the new header; bisection isn't supported.
+type LightClient struct {
+	logger  *slog.Logger
+	client  *client.RPCClient
+	chainID string
+
+	// RefreshInterval is the minimum interval between two updates of the
+	// trusted header, so that a burst of requests doesn't fetch a new
+	// header each.
+	RefreshInterval time.Duration
+
+	mu      sync.Mutex
+	trusted *types.SignedHeader
+	vals    *types.ValidatorSet
+	updated time.Time
+}
+
+// NewLightClient creates a LightClient trusting the header of the chain at
+// the given height, or the latest one if height is zero. If hash is not
+// empty, the header must have this hash; otherwise the header is trusted on
+// first use, and its hash logged so that it can be pinned.
+func NewLightClient(ctx context.Context, logger *slog.Logger, cli *client.RPCClient, chainID string, height int64, hash []byte) (*LightClient, error) {
+	lc := &LightClient{
+		logger:          logger,
+		client:          cli,
+		chainID:         chainID,
+		RefreshInterval: DefaultLightRefreshInterval,
+	}
+
+	var h *int64
+	if height > 0 {
+		h = &height
+	}
+
+	sh, vals, err := lc.fetch(ctx, h)
+	if err != nil {
+		return nil, err
+	}
+
+	if len(hash) > 0 && !bytes.Equal(sh.Hash(), hash) {
+		return nil, fmt.Errorf("%w: hash of header %d is %X, expected %X",
+			ErrLightUntrusted, sh.Height, sh.Hash(), hash)
+	}
+
+	if err := sh.ValidateBasic(chainID); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLightUntrusted, err)
+	}
+
+	if err := vals.VerifyCommit(chainID, sh.Commit.BlockID, sh.Height, sh.Commit); err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrLightUntrusted, err)
+	}
+
+	if len(hash) == 0 {
+		logger.Warn("no trusted header hash given, trusting the header of the node",
+			"height", sh.Height,
+			"hash", fmt.Sprintf("%X", sh.Hash()),
+		)
+	}
+
+	lc.trusted, lc.vals = sh, vals
+	lc.updated = time.Now()
+	return lc, nil
+}
+
+// Latest returns the latest verified header, after following the chain up
+// to the latest header of the node if the trusted one is older than the
+// refresh interval.
+func (lc *LightClient) Latest(ctx context.Context) (*types.SignedHeader, error) {
+	lc.mu.Lock()
+	defer lc.mu.Unlock()
+
+	if time.Since(lc.updated) < lc.RefreshInterval {
+		return lc.trusted, nil
+	}
+
+	sh, vals, err := lc.fetch(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// The node may lag behind the headers it gave before; keep them.
+	if sh.Height > lc.trusted.Height {
+		if err := lc.verify(sh, vals); err != nil {
+			lc.logger.Error("unable to verify header",
+				"height", sh.Height,
+				"error", err,
+			)
+			return nil, fmt.Errorf("%w: %w", ErrLightUntrusted, err)
+		}
+
+		lc.trusted, lc.vals = sh, vals
+	}
+
+	lc.updated = time.Now()
+	return lc.trusted, nil
+}
+
+// verify checks that sh, committed by vals, was also committed by more than
+// 2/3 of the voting power of the trusted validators.
+func (lc *LightClient) verify(sh *types.SignedHeader, vals *types.ValidatorSet) error {
+	if err := sh.ValidateBasic(lc.chainID); err != nil {
+		return err
+	}
+
+	if bytes.Equal(sh.ValidatorsHash, lc.vals.Hash()) {
+		return lc.vals.VerifyCommit(lc.chainID, sh.Commit.BlockID, sh.Height, sh.Commit)
+	}
+
+	return lc.vals.VerifyFutureCommit(vals, lc.chainID, sh.Commit.BlockID, sh.Height, sh.Commit)
+}
+
+// fetch returns the signed header at the given height, or the latest one if
+// height is nil, with the validator set of its hash.
+func (lc *LightClient) fetch(ctx context.Context, height *int64) (*types.SignedHeader, *types.ValidatorSet, error) {
+	cres, err := lc.client.Commit(ctx, height)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to fetch commit: %w", err)
+	}
+
+	if cres.Header == nil || cres.Commit == nil {
+		return nil, nil, fmt.Errorf("%w: incomplete signed header", ErrLightUntrusted)
+	}
+
+	vheight := cres.Height
+	vres, err := lc.client.Validators(ctx, &vheight)
+	if err != nil {
+		return nil, nil, fmt.Errorf("unable to fetch validators: %w", err)
+	}
+
+	vals := types.NewValidatorSet(vres.Validators)
+	if !bytes.Equal(vals.Hash(), cres.ValidatorsHash) {
+		return nil, nil, fmt.Errorf("%w: validators of height %d don't match the header",
+			ErrLightUntrusted, vheight)
+	}
+
+	return &cres.SignedHeader, vals, nil
+}
@@ -74,6 +74,9 @@ func (e *GnoExtension) Extend(m goldmark.Markdown) {
 	// Add link extension
 	ExtLinks.Extend(m)
 
+	// Add package path autolinks extension
+	ExtPkgLinks.Extend(m)
+
 	// Add form / inputs extension
 	ExtForms.Extend(m)
 
@@ -4,6 +4,8 @@ import (
 	"bytes"
 	"errors"
 	"fmt"
+	"go/token"
+	"net/url"
 	"strings"
 
 	"github.com/yuin/goldmark"
@@ -36,6 +38,8 @@ var (
 	ErrFormInputMissingName       = errors.New(tagGnoInput + " must have a 'name' attribute")
 	ErrFormInvalidInputType       = errors.New("invalid input type")
 	ErrFormInputNameAlreadyUsed   = errors.New("input name already used")
+	ErrFormInvalidExec            = errors.New(tagGnoForm + " 'exec' must be an exported function name")
+	ErrFormExecWithPath           = errors.New(tagGnoForm + " cannot have both 'exec' and 'path' attributes")
 )
 
 // Whitelist of allowed input types
@@ -147,6 +151,7 @@ type FormNode struct {
 	Elements     []FormElement
 	ElementsName map[string]bool
 	RenderPath   string // Path to render after form submission
+	Exec         string // Function whose call help is shown after form submission
 	RealmName    string
 }
 
@@ -162,6 +167,7 @@ func (n *FormNode) Kind() ast.NodeKind { return KindForm }
 func (n *FormNode) Dump(source []byte, level int) {
 	kv := map[string]string{
 		"path": n.RenderPath,
+		"exec": n.Exec,
 		"name": n.RealmName,
 	}
 
@@ -261,6 +267,16 @@ func (p *formParser) Open(parent ast.Node, reader text.Reader, pc parser.Context
 	}
 
 	fn.RenderPath, _ = ExtractAttr(tok.Attr, "path")
+	if exec, ok := ExtractAttr(tok.Attr, "exec"); ok {
+		fn.Exec = strings.TrimSpace(exec)
+		switch {
+		case !token.IsIdentifier(fn.Exec) || !token.IsExported(fn.Exec):
+			fn.Error = ErrFormInvalidExec
+		case fn.RenderPath != "":
+			fn.Error = ErrFormExecWithPath
+		}
+	}
+
 	if gnourl, ok := getUrlFromContext(pc); ok {
 		fn.RealmName = gnourl.Path // Use full path instead of just namespace
 	}
@@ -483,7 +499,12 @@ func (r *formRenderer) render(w util.BufWriter, source []byte, node ast.Node, en
 
 	// Form action must include the full path
 	formAction := n.RealmName // start with /r/docs/markdown
-	if n.RenderPath != "" {
+	switch {
+	case n.Exec != "":
+		// Submitting the form fills the arguments of the function on the help
+		// page, which generates the matching `gnokey maketx call` command.
+		formAction += "$help&func=" + url.QueryEscape(n.Exec)
+	case n.RenderPath != "":
 		formAction += ":" + strings.TrimPrefix(n.RenderPath, "/")
 	}
 
@@ -491,7 +512,11 @@ func (r *formRenderer) render(w util.BufWriter, source []byte, node ast.Node, en
 	fmt.Fprintf(w, `<form class="gno-form" method="post" action="%s" autocomplete="off" spellcheck="false">`+"\n", HTMLEscapeString(formAction))
 	fmt.Fprintln(w, `<div class="gno-form_header">`)
 	fmt.Fprintf(w, `<span><span class="font-bold">%s</span> Form</span>`+"\n", HTMLEscapeString(n.RealmName))
-	fmt.Fprintf(w, `<span class="tooltip" data-tooltip="Processed securely by %s"><svg class="w-3 h-3"><use href="#ico-info"></use></svg></span>`+"\n", HTMLEscapeString(n.RealmName))
+	if n.Exec != "" {
+		fmt.Fprintf(w, `<a href="%s" class="tooltip" data-tooltip="Call %s with gnokey"><svg class="w-3 h-3"><use href="#ico-tx-link"></use></svg></a>`+"\n", HTMLEscapeString(formAction), HTMLEscapeString(n.Exec))
+	} else {
+		fmt.Fprintf(w, `<span class="tooltip" data-tooltip="Processed securely by %s"><svg class="w-3 h-3"><use href="#ico-info"></use></svg></span>`+"\n", HTMLEscapeString(n.RealmName))
+	}
 	fmt.Fprintln(w, `</div>`)
 
 	// Render all form elements in order of appearance
@@ -639,7 +664,11 @@ func (r *formRenderer) render(w util.BufWriter, source []byte, node ast.Node, en
 	}
 
 	// Display submit button only if there is at least one input or textarea
-	if len(n.Elements) > 0 {
+	switch {
+	case len(n.Elements) == 0:
+	case n.Exec != "":
+		fmt.Fprintf(w, `<input type="submit" value="Call %s" />`+"\n", HTMLEscapeString(n.Exec))
+	default:
 		fmt.Fprintf(w, `<input type="submit" value="Submit to %s Realm" />`+"\n", HTMLEscapeString(n.RealmName))
 	}
 
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"fmt"
 	"net/url"
+	"strings"
 
 	"github.com/gnolang/gno/gno.land/pkg/gnoweb/weburl"
 	"github.com/yuin/goldmark"
@@ -37,6 +38,15 @@ const (
 	classLinkUser     = "link-user"
 )
 
+// allowedLinkSchemes is the allowlist of URL schemes links can use. Links
+// with any other scheme, such as "javascript:", are rendered as invalid.
+var allowedLinkSchemes = map[string]bool{
+	"":       true, // relative
+	"http":   true,
+	"https":  true,
+	"mailto": true,
+}
+
 // GnoLinkType represents the type of a link
 type GnoLinkType int
 
@@ -115,7 +125,7 @@ func (t *linkTransformer) Transform(doc *ast.Document, reader text.Reader, pc pa
 
 		// Parse destination URL and check for validity.
 		dest, err := url.Parse(string(link.Destination))
-		if err != nil {
+		if err != nil || !allowedLinkSchemes[strings.ToLower(dest.Scheme)] {
 			gnoLink.LinkType = GnoLinkTypeInvalid
 			return ast.WalkContinue, nil
 		}
@@ -177,13 +187,13 @@ type attr struct {
 	value string
 }
 
-// writeHTMLTag writes an HTML attribute.
+// writeHTMLTag writes an HTML tag with its attributes, escaping their values.
 // XXX: We probably want this as a general helper for futur extension.
 func writeHTMLTag(w util.BufWriter, tag string, attrs []attr) {
 	w.WriteString("<" + tag)
 	for _, a := range attrs {
 		w.WriteByte(' ') // write space separator
-		fmt.Fprintf(w, "%s=%q", a.name, a.value)
+		fmt.Fprintf(w, "%s=\"%s\"", a.name, HTMLEscapeString(a.value))
 	}
 	w.WriteByte('>')
 }
@@ -240,7 +250,7 @@ func (r *linkRenderer) renderGnoLink(w util.BufWriter, source []byte, node ast.N
 			attrs = append(attrs, attr{"rel", "noopener nofollow ugc"})
 		}
 		if n.Title != nil {
-			attrs = append(attrs, attr{"title", string(n.Title)})
+			attrs = append(attrs, attr{"title", string(util.UnescapePunctuations(n.Title))})
 		}
 
 		// Write opening tag <a>.
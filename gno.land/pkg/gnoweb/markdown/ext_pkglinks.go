@@ -0,0 +1,118 @@
+package markdown
+
+import (
+	"regexp"
+
+	"github.com/yuin/goldmark"
+	"github.com/yuin/goldmark/ast"
+	"github.com/yuin/goldmark/parser"
+	"github.com/yuin/goldmark/text"
+	"github.com/yuin/goldmark/util"
+)
+
+// reDomainPkgPath matches package paths with their domain, such as
+// "gno.land/r/demo/boards", capturing the domain and the path.
+var reDomainPkgPath = regexp.MustCompile(`([a-z0-9][a-z0-9.-]*(?::[0-9]+)?)(/[pr](?:/[a-z0-9_]+)+)`)
+
+// pkgLinkTransformer turns package paths of the current chain written as
+// plain text, such as "gno.land/r/demo/boards", into links to their page.
+type pkgLinkTransformer struct{}
+
+// Transform walks the text nodes of the document, and splits them around
+// the package paths they contain.
+func (t *pkgLinkTransformer) Transform(doc *ast.Document, reader text.Reader, pc parser.Context) {
+	orig, ok := getUrlFromContext(pc)
+	if !ok || orig.Domain == "" {
+		return
+	}
+
+	source := reader.Source()
+
+	// Collect text nodes first, as linkifying modifies the tree.
+	var texts []*ast.Text
+	ast.Walk(doc, func(node ast.Node, entering bool) (ast.WalkStatus, error) {
+		if !entering {
+			return ast.WalkContinue, nil
+		}
+
+		switch n := node.(type) {
+		case *ast.Link, *ast.AutoLink, *ast.Image, *ast.CodeSpan:
+			return ast.WalkSkipChildren, nil
+		case *ast.Text:
+			texts = append(texts, n)
+		}
+
+		return ast.WalkContinue, nil
+	})
+
+	for _, txt := range texts {
+		linkifyPkgPaths(txt, source, orig.Domain)
+	}
+}
+
+// linkifyPkgPaths inserts a link before txt for each package path of domain
+// it contains, and shrinks txt to the text following the last one.
+func linkifyPkgPaths(txt *ast.Text, source []byte, domain string) {
+	seg := txt.Segment
+	value := seg.Value(source)
+	parent := txt.Parent()
+
+	pos := 0 // start of the text not yet inserted, relative to seg
+	for _, match := range reDomainPkgPath.FindAllSubmatchIndex(value, -1) {
+		start, end := match[0], match[1]
+		if string(value[match[2]:match[3]]) != domain {
+			continue
+		}
+		if !isPkgPathBoundary(source, seg.Start+start-1) || !isPkgPathBoundary(source, seg.Start+end) {
+			continue
+		}
+
+		if start > pos {
+			before := ast.NewTextSegment(text.NewSegment(seg.Start+pos, seg.Start+start))
+			parent.InsertBefore(parent, txt, before)
+		}
+
+		link := ast.NewLink()
+		link.Destination = value[match[4]:match[5]]
+		link.AppendChild(link, ast.NewTextSegment(text.NewSegment(seg.Start+start, seg.Start+end)))
+		parent.InsertBefore(parent, txt, link)
+
+		pos = end
+	}
+
+	txt.Segment = text.NewSegment(seg.Start+pos, seg.Stop)
+}
+
+// isPkgPathBoundary returns true if the character of source at pos can
+// precede or follow a package path.
+func isPkgPathBoundary(source []byte, pos int) bool {
+	if pos < 0 || pos >= len(source) {
+		return true
+	}
+
+	switch c := source[pos]; {
+	case util.IsSpace(c), c == '(', c == ')', c == ',', c == ';', c == '!', c == '?',
+		c == '*', c == '"', c == '\'':
+		return true
+	case c == '.', c == ':':
+		// Allow ending a sentence with a package path.
+		return pos+1 >= len(source) || util.IsSpace(source[pos+1])
+	default:
+		return false
+	}
+}
+
+// pkgLinkExtension is a Goldmark extension that autolinks package paths.
+type pkgLinkExtension struct{}
+
+// ExtPkgLinks instance for extending markdown with package path autolinks
+var ExtPkgLinks = &pkgLinkExtension{}
+
+// Extend adds the package path autolinks to the provided Goldmark markdown
+// processor. It runs before the link extension, so the created links are
+// rendered like any other link.
+func (e *pkgLinkExtension) Extend(m goldmark.Markdown) {
+	m.Parser().AddOptions(parser.WithASTTransformers(
+		util.Prioritized(&pkgLinkTransformer{}, 600),
+	))
+}
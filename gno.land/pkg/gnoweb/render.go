@@ -10,10 +10,12 @@ import (
 	"github.com/alecthomas/chroma/v2"
 	chromahtml "github.com/alecthomas/chroma/v2/formatters/html"
 	"github.com/alecthomas/chroma/v2/lexers"
+	"github.com/gnolang/gno/gno.land/pkg/gnoweb/components"
 	md "github.com/gnolang/gno/gno.land/pkg/gnoweb/markdown"
 	"github.com/gnolang/gno/gno.land/pkg/gnoweb/weburl"
 	"github.com/yuin/goldmark"
 	markdown "github.com/yuin/goldmark-highlighting/v2"
+	"github.com/yuin/goldmark/ast"
 	"github.com/yuin/goldmark/parser"
 	"github.com/yuin/goldmark/text"
 )
@@ -21,6 +23,10 @@ import (
 // Renderer defines the interface for rendering realms and source files.
 type Renderer interface {
 	RenderRealm(w io.Writer, u *weburl.GnoURL, src []byte) (md.Toc, error)
+	// ParseRealm parses a realm, and returns its table of contents and a
+	// component rendering it to HTML; unlike RenderRealm, the table of
+	// contents is known before the realm is rendered.
+	ParseRealm(u *weburl.GnoURL, src []byte) (md.Toc, components.Component)
 	RenderSource(w io.Writer, name string, src []byte) error
 }
 
@@ -49,20 +55,44 @@ func NewHTMLRenderer(logger *slog.Logger, cfg RenderConfig) *HTMLRenderer {
 
 // RenderRealm renders a realm to HTML and returns a table of contents.
 func (r *HTMLRenderer) RenderRealm(w io.Writer, u *weburl.GnoURL, src []byte) (md.Toc, error) {
+	toc, content := r.ParseRealm(u, src)
+	if err := content.Render(w); err != nil {
+		return md.Toc{}, err
+	}
+
+	return toc, nil
+}
+
+// ParseRealm parses a realm and returns its table of contents, and a
+// component rendering it to HTML.
+func (r *HTMLRenderer) ParseRealm(u *weburl.GnoURL, src []byte) (md.Toc, components.Component) {
 	ctx := md.NewGnoParserContext(u)
 
 	// Use Goldmark for Markdown parsing
 	doc := r.gm.Parser().Parse(text.NewReader(src), parser.WithContext(ctx))
-	if err := r.gm.Renderer().Render(w, src, doc); err != nil {
-		return md.Toc{}, fmt.Errorf("unable to render markdown at path %q: %w", u.Path, err)
-	}
 
 	toc, err := md.TocInspect(doc, src, md.TocOptions{MaxDepth: 6, MinDepth: 2})
 	if err != nil {
 		r.logger.Warn("unable to inspect for TOC elements", "error", err)
 	}
 
-	return toc, nil
+	return toc, &realmComponent{r: r, path: u.Path, src: src, doc: doc}
+}
+
+// realmComponent renders a parsed realm to HTML.
+type realmComponent struct {
+	r    *HTMLRenderer
+	path string
+	src  []byte
+	doc  ast.Node
+}
+
+func (c *realmComponent) Render(w io.Writer) error {
+	if err := c.r.gm.Renderer().Render(w, c.src, c.doc); err != nil {
+		return fmt.Errorf("unable to render markdown at path %q: %w", c.path, err)
+	}
+
+	return nil
 }
 
 // RenderSource renders a source file into HTML with syntax highlighting based on its extension.
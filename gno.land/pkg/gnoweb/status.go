@@ -11,6 +11,7 @@ import (
 
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	rpchttp "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/client/http"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 )
 
@@ -38,7 +39,9 @@ func handlerStatusJSON(logger *slog.Logger, cli *client.RPCClient) http.Handler
 				Error     *string `json:"error,omitempty"`
 				Height    *int64  `json:"height,omitempty"`
 				// processed txs
-				// active connections
+
+				// RPC is the connection pool to the node.
+				RPC *rpchttp.PoolStats `json:"rpc,omitempty"`
 
 				Version *string `json:"version,omitempty"`
 				// Uptime    *float64 `json:"uptime-seconds,omitempty"`
@@ -73,6 +76,9 @@ func handlerStatusJSON(logger *slog.Logger, cli *client.RPCClient) http.Handler
 			ret.Gnoland.Version = &version
 			ret.Gnoland.Height = &res.Height
 		}
+		if stats, ok := cli.PoolStats(); ok {
+			ret.Gnoland.RPC = &stats
+		}
 
 		out, _ := json.MarshalIndent(ret, "", "  ")
 		w.Header().Set("Content-Type", "application/json")
@@ -51,6 +51,9 @@ func TestNodeProcess(t *testing.T) {
 
 	// Prepare a minimal node configuration for testing
 	cfg := TestingMinimalNodeConfig(gnoRootDir)
+	// The node process doesn't get WALDisabled, which isn't marshaled: keep
+	// its WAL out of the repository.
+	cfg.TMConfig.SetRootDir(t.TempDir())
 
 	var stdio bytes.Buffer
 	defer func() {
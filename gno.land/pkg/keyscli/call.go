@@ -5,7 +5,6 @@ import (
 	"flag"
 
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
-	"github.com/gnolang/gno/tm2/pkg/amino"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
@@ -154,8 +153,7 @@ func execMakeCall(cfg *MakeCallCfg, args []string, io commands.IO) error {
 		if err != nil {
 			return err
 		}
-	} else {
-		io.Println(string(amino.MustMarshalJSON(tx)))
+		return nil
 	}
-	return nil
+	return client.PrintTx(cfg.RootCfg, tx, io)
 }
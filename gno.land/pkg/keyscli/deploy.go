@@ -0,0 +1,353 @@
+package keyscli
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os/exec"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/client"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+const (
+	// defaultEstimateMaxGas is the gas limit of the simulation estimating the
+	// gas of a transaction, when the chain has no block gas limit.
+	defaultEstimateMaxGas = 3_000_000_000
+
+	// estimateGasMargin is the percentage of gas added to the estimation.
+	estimateGasMargin = 10
+)
+
+type MakeDeployCfg struct {
+	RootCfg    *client.MakeTxCfg
+	PkgPath    string
+	PkgDir     string
+	MaxDeposit string
+	GnoBin     string
+	SkipTests  bool
+	DryRun     bool
+	JSON       bool
+}
+
+// deploySummary is the summary of a deployment, printed by -json.
+type deploySummary struct {
+	PkgPath      string          `json:"pkg_path"`
+	Creator      string          `json:"creator"`
+	Files        int             `json:"files"`
+	Size         int             `json:"size"`
+	GasWanted    int64           `json:"gas_wanted"`
+	GasEstimated int64           `json:"gas_estimated,omitempty"`
+	GasFee       string          `json:"gas_fee"`
+	MaxDeposit   string          `json:"max_deposit,omitempty"`
+	DryRun       bool            `json:"dry_run"`
+	Tx           json.RawMessage `json:"tx,omitempty"`      // the unsigned tx, unless broadcast.
+	TxHash       string          `json:"tx_hash,omitempty"` // once broadcast.
+	Height       int64           `json:"height,omitempty"`
+	GasUsed      int64           `json:"gas_used,omitempty"`
+}
+
+func NewMakeDeployCmd(rootCfg *client.MakeTxCfg, io commands.IO) *commands.Command {
+	cfg := &MakeDeployCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "deploy",
+			ShortUsage: "deploy [flags] <key-name>",
+			ShortHelp:  "checks and uploads a new package",
+			LongHelp: `Uploads the package in -pkgdir, like addpkg, after checking it.
+
+The package is first linted and tested with "gno lint" and "gno test" (unless
+-skip-tests is set), so the gno binary must be installed. If -gas-wanted isn't
+set, the gas of the transaction is estimated by simulating it on the chain at
+-remote, with a margin. A summary of the deployment is printed to stderr.
+
+As with the other maketx commands, the transaction is only printed unless
+-broadcast is set. With -dry-run, the checks and the gas estimation are run
+and the summary is printed, but the transaction isn't.
+
+With -json, the summary is printed to stdout as a JSON object instead,
+including the unsigned transaction, or the hash, height and gas used of the
+broadcast transaction; the output of the checks still goes to stderr.`,
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return ExecMakeDeploy(cfg, args, io)
+		},
+	)
+}
+
+func (c *MakeDeployCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.PkgPath,
+		"pkgpath",
+		"",
+		"package path (default: the module of gnomod.toml)",
+	)
+
+	fs.StringVar(
+		&c.PkgDir,
+		"pkgdir",
+		"",
+		"path to package files (required)",
+	)
+
+	fs.StringVar(
+		&c.MaxDeposit,
+		"max-deposit",
+		"",
+		"max storage deposit",
+	)
+
+	fs.StringVar(
+		&c.GnoBin,
+		"gno-bin",
+		"gno",
+		"gno binary running the checks",
+	)
+
+	fs.BoolVar(
+		&c.SkipTests,
+		"skip-tests",
+		false,
+		"do not run the package tests",
+	)
+
+	fs.BoolVar(
+		&c.DryRun,
+		"dry-run",
+		false,
+		"run the checks and estimate the gas, without printing or broadcasting the transaction",
+	)
+
+	fs.BoolVar(
+		&c.JSON,
+		"json",
+		false,
+		"print the summary to stdout as JSON",
+	)
+}
+
+// ExecMakeDeploy runs the deploy command, with the name or address of the key
+// as args, for the commands wrapping it like gno deploy.
+func ExecMakeDeploy(cfg *MakeDeployCfg, args []string, io commands.IO) error {
+	if cfg.PkgDir == "" {
+		return errors.New("pkgdir not specified")
+	}
+	if cfg.RootCfg.GasWanted < 0 {
+		return errors.New("gas-wanted must not be negative")
+	}
+	if cfg.RootCfg.GasFee == "" {
+		return errors.New("gas-fee not specified")
+	}
+	if cfg.DryRun && cfg.RootCfg.Broadcast {
+		return errors.New("cannot use -dry-run with -broadcast")
+	}
+
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	gasfee, err := std.ParseCoin(cfg.RootCfg.GasFee)
+	if err != nil {
+		return errors.Wrap(err, "parsing gas fee")
+	}
+	deposit, err := std.ParseCoins(cfg.MaxDeposit)
+	if err != nil {
+		return errors.Wrap(err, "parsing max deposit")
+	}
+
+	pkgPath := cfg.PkgPath
+	if pkgPath == "" {
+		mod, err := gnomod.ParseDir(cfg.PkgDir)
+		if err != nil {
+			return errors.Wrap(err, "reading package path")
+		}
+		pkgPath = mod.Module
+	}
+
+	// Run the checks of the gno command, as a developer would before
+	// deploying.
+	if err := runGno(cfg.GnoBin, io, "lint", cfg.PkgDir); err != nil {
+		return errors.Wrap(err, "lint failed")
+	}
+	if !cfg.SkipTests {
+		if err := runGno(cfg.GnoBin, io, "test", cfg.PkgDir); err != nil {
+			return errors.Wrap(err, "tests failed")
+		}
+	}
+
+	// read account pubkey.
+	nameOrBech32 := args[0]
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.RootCfg.Home)
+	if err != nil {
+		return err
+	}
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return err
+	}
+
+	memPkg, err := gno.ReadMemPackage(cfg.PkgDir, pkgPath, gno.MPUserAll)
+	if err != nil {
+		return errors.Wrap(err, "reading package")
+	}
+	if memPkg.IsEmpty() {
+		return fmt.Errorf("found an empty package %q", pkgPath)
+	}
+
+	// construct msg & tx.
+	msg := vm.MsgAddPackage{
+		Creator:    info.GetAddress(),
+		Package:    memPkg,
+		MaxDeposit: deposit,
+	}
+	if err := msg.ValidateBasic(); err != nil {
+		return err
+	}
+	tx := std.Tx{
+		Msgs: []std.Msg{msg},
+		Fee:  std.NewFee(cfg.RootCfg.GasWanted, gasfee),
+		Memo: cfg.RootCfg.Memo,
+	}
+
+	var estimated int64
+	if tx.Fee.GasWanted == 0 {
+		estimated, tx.Fee.GasWanted, err = estimateGas(cfg.RootCfg.RootCfg.Remote, tx, info)
+		if err != nil {
+			return err
+		}
+	}
+
+	size := 0
+	for _, file := range memPkg.Files {
+		size += len(file.Body)
+	}
+	summary := deploySummary{
+		PkgPath:      pkgPath,
+		Creator:      info.GetAddress().String(),
+		Files:        len(memPkg.Files),
+		Size:         size,
+		GasWanted:    tx.Fee.GasWanted,
+		GasEstimated: estimated,
+		GasFee:       gasfee.String(),
+		DryRun:       cfg.DryRun,
+	}
+	if !deposit.IsZero() {
+		summary.MaxDeposit = deposit.String()
+	}
+
+	switch {
+	case cfg.JSON && cfg.RootCfg.Broadcast:
+		cfg.RootCfg.RootCfg.OnTxSuccess = func(_ std.Tx, res *ctypes.ResultBroadcastTxCommit) {
+			summary.TxHash = base64.StdEncoding.EncodeToString(res.Hash)
+			summary.Height = res.Height
+			summary.GasUsed = res.DeliverTx.GasUsed
+		}
+		if err := client.ExecSignAndBroadcast(cfg.RootCfg, args, tx, io); err != nil {
+			return err
+		}
+		return printDeploySummary(summary, io)
+	case cfg.JSON:
+		if !cfg.DryRun {
+			summary.Tx = amino.MustMarshalJSON(tx)
+		}
+		return printDeploySummary(summary, io)
+	}
+
+	io.ErrPrintfln("package:     %s", summary.PkgPath)
+	io.ErrPrintfln("creator:     %s", summary.Creator)
+	io.ErrPrintfln("files:       %d (%d bytes)", summary.Files, summary.Size)
+	if estimated > 0 {
+		io.ErrPrintfln("gas wanted:  %d (estimated %d)", tx.Fee.GasWanted, estimated)
+	} else {
+		io.ErrPrintfln("gas wanted:  %d", tx.Fee.GasWanted)
+	}
+	io.ErrPrintfln("gas fee:     %s", gasfee)
+	if !deposit.IsZero() {
+		io.ErrPrintfln("max deposit: %s", deposit)
+	}
+
+	switch {
+	case cfg.DryRun:
+		return nil
+	case cfg.RootCfg.Broadcast:
+		cfg.RootCfg.RootCfg.OnTxSuccess = func(tx std.Tx, res *ctypes.ResultBroadcastTxCommit) {
+			PrintTxInfo(tx, res, io)
+		}
+		return client.ExecSignAndBroadcast(cfg.RootCfg, args, tx, io)
+	default:
+		return client.PrintTx(cfg.RootCfg, tx, io)
+	}
+}
+
+// printDeploySummary prints the summary as JSON to the output of io.
+func printDeploySummary(summary deploySummary, io commands.IO) error {
+	bz, err := json.Marshal(summary)
+	if err != nil {
+		return errors.Wrap(err, "marshaling summary")
+	}
+	io.Println(string(bz))
+	return nil
+}
+
+// runGno runs the gno command with args, printing its output to the error
+// output of io.
+func runGno(gnoBin string, io commands.IO, args ...string) error {
+	cmd := exec.Command(gnoBin, args...)
+	cmd.Stdout = io.Err()
+	cmd.Stderr = io.Err()
+	return cmd.Run()
+}
+
+// estimateGas simulates tx on the chain at remote, and returns the gas
+// it used along with the gas to want, which adds a margin to it. The
+// simulation doesn't verify signatures, so the public key of the signer is
+// enough.
+func estimateGas(remote string, tx std.Tx, info keys.Info) (estimated, wanted int64, err error) {
+	cli, err := rpcclient.NewHTTPClient(remote)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "new http client")
+	}
+	defer cli.Close()
+
+	maxGas := int64(defaultEstimateMaxGas)
+	params, err := cli.ConsensusParams(context.Background(), nil)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "query consensus params")
+	}
+	if block := params.ConsensusParams.Block; block != nil && block.MaxGas > 0 {
+		maxGas = block.MaxGas
+	}
+
+	tx.Fee.GasWanted = maxGas
+	tx.Signatures = []std.Signature{{PubKey: info.GetPubKey()}}
+	bz, err := amino.Marshal(tx)
+	if err != nil {
+		return 0, 0, errors.Wrap(err, "marshaling tx")
+	}
+	res, err := client.SimulateTx(cli, bz)
+	if err != nil {
+		return 0, 0, err
+	}
+	if res.DeliverTx.IsErr() {
+		return 0, 0, errors.Wrapf(res.DeliverTx.Error, "simulating transaction: log:%s", res.DeliverTx.Log)
+	}
+
+	estimated = res.DeliverTx.GasUsed
+	wanted = min(estimated+estimated*estimateGasMargin/100, maxGas)
+	return estimated, wanted, nil
+}
@@ -0,0 +1,679 @@
+package keyscli
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"reflect"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"gopkg.in/yaml.v3"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/client"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+const (
+	// How often the balance of an address is queried by a wait step.
+	execPollInterval = time.Second
+
+	// How long a wait step waits for a balance, by default.
+	defaultWaitTimeout = time.Minute
+)
+
+type ExecCfg struct {
+	RootCfg *client.BaseCfg
+
+	State   string
+	Restart bool
+}
+
+func NewExecCmd(rootCfg *client.BaseCfg, io commands.IO) *commands.Command {
+	cfg := &ExecCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "exec",
+			ShortUsage: "exec [flags] <playbook>",
+			ShortHelp:  "executes the transactions of a YAML or JSON playbook",
+			LongHelp: `Executes the steps of a playbook: creating keys, waiting for the balance of
+an address, sending coins, adding packages, calling realms and querying the
+chain. Steps run in the order of the playbook, after the steps they need, named
+in their "needs" list or referenced in their fields, like ${deploy.height} or
+${alice.address}; ${vars.name} and ${env.NAME} are replaced by the variables of
+the playbook and of the environment.
+
+The outputs of the completed steps are saved to a state file: running the
+playbook again skips them, and resumes after the last completed step.`,
+		},
+		cfg,
+		func(ctx context.Context, args []string) error {
+			return execExec(ctx, cfg, args, io)
+		},
+	)
+}
+
+func (c *ExecCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.State,
+		"state",
+		"",
+		"file saving the outputs of the completed steps (default: <playbook>.state.json)",
+	)
+
+	fs.BoolVar(
+		&c.Restart,
+		"restart",
+		false,
+		"ignore the state file, and execute all the steps again",
+	)
+}
+
+// playbook is a sequence of steps, executed by gnokey exec.
+type playbook struct {
+	ChainID   string            `yaml:"chainid"`
+	GasFee    string            `yaml:"gas-fee"`
+	GasWanted int64             `yaml:"gas-wanted"` // estimated if 0.
+	Vars      map[string]string `yaml:"vars"`
+	Steps     []*playbookStep   `yaml:"steps"`
+}
+
+// playbookStep is a step of a playbook, with exactly one action.
+type playbookStep struct {
+	ID    string   `yaml:"id"`
+	Needs []string `yaml:"needs"`
+
+	// Gas of the transaction, overriding the one of the playbook.
+	GasFee    string `yaml:"gas-fee"`
+	GasWanted int64  `yaml:"gas-wanted"`
+
+	Key    *keyAction    `yaml:"key"`
+	Wait   *waitAction   `yaml:"wait"`
+	Send   *sendAction   `yaml:"send"`
+	AddPkg *addPkgAction `yaml:"addpkg"`
+	Call   *callAction   `yaml:"call"`
+	Query  *queryAction  `yaml:"query"`
+}
+
+// keyAction creates a key, unless it exists. Without mnemonic, a new one is
+// generated. Outputs: name, address.
+type keyAction struct {
+	Name     string `yaml:"name"`
+	Mnemonic string `yaml:"mnemonic"`
+}
+
+// waitAction waits until the balance of an address, like one funded by a
+// faucet, holds at least the given coins. Outputs: balance.
+type waitAction struct {
+	Address string        `yaml:"address"` // key name or address.
+	Balance string        `yaml:"balance"`
+	Timeout time.Duration `yaml:"timeout"`
+}
+
+// The transaction actions output the data, height, hash and gas-used of the
+// transaction.
+
+type sendAction struct {
+	From   string `yaml:"from"`
+	To     string `yaml:"to"` // key name or address.
+	Amount string `yaml:"amount"`
+}
+
+type addPkgAction struct {
+	From       string `yaml:"from"`
+	PkgPath    string `yaml:"pkgpath"`
+	Dir        string `yaml:"dir"` // relative to the playbook.
+	Send       string `yaml:"send"`
+	MaxDeposit string `yaml:"max-deposit"`
+}
+
+type callAction struct {
+	From       string   `yaml:"from"`
+	PkgPath    string   `yaml:"pkgpath"`
+	Func       string   `yaml:"func"`
+	Args       []string `yaml:"args"`
+	Send       string   `yaml:"send"`
+	MaxDeposit string   `yaml:"max-deposit"`
+}
+
+// queryAction queries the chain, like gnokey query. Outputs: data.
+type queryAction struct {
+	Path string `yaml:"path"`
+	Data string `yaml:"data"`
+}
+
+// action returns the action of the step, or nil if it doesn't have exactly
+// one.
+func (s *playbookStep) action() any {
+	var actions []any
+	for _, a := range []any{s.Key, s.Wait, s.Send, s.AddPkg, s.Call, s.Query} {
+		if !reflect.ValueOf(a).IsNil() {
+			actions = append(actions, a)
+		}
+	}
+	if len(actions) != 1 {
+		return nil
+	}
+	return actions[0]
+}
+
+// playbookState holds the outputs of the completed steps, by step id.
+type playbookState struct {
+	Outputs map[string]map[string]string `json:"outputs"`
+}
+
+// refPattern matches the references to the outputs of the steps and to the
+// variables, like ${deploy.height} or ${vars.name}.
+var refPattern = regexp.MustCompile(`\$\{([A-Za-z0-9_-]+)\.([A-Za-z0-9_-]+)\}`)
+
+// The scopes of the references which aren't steps.
+const (
+	varsScope = "vars"
+	envScope  = "env"
+)
+
+func parsePlaybook(bz []byte) (*playbook, error) {
+	var pb playbook
+	// JSON is a subset of YAML.
+	dec := yaml.NewDecoder(strings.NewReader(string(bz)))
+	dec.KnownFields(true)
+	if err := dec.Decode(&pb); err != nil {
+		return nil, errors.Wrap(err, "parsing playbook")
+	}
+	return &pb, nil
+}
+
+// order validates the steps of the playbook, and returns them in execution
+// order: each step runs after its dependencies, and otherwise in the order of
+// the playbook.
+func (pb *playbook) order() ([]*playbookStep, error) {
+	if len(pb.Steps) == 0 {
+		return nil, errors.New("playbook has no steps")
+	}
+
+	deps := make(map[string][]string, len(pb.Steps))
+	for i, step := range pb.Steps {
+		switch {
+		case step.ID == "":
+			return nil, fmt.Errorf("step %d has no id", i+1)
+		case step.ID == varsScope || step.ID == envScope:
+			return nil, fmt.Errorf("step id %q is reserved", step.ID)
+		case deps[step.ID] != nil:
+			return nil, fmt.Errorf("duplicate step id %q", step.ID)
+		case step.action() == nil:
+			return nil, fmt.Errorf("step %q must have exactly one of key, wait, send, addpkg, call or query", step.ID)
+		}
+		deps[step.ID] = append([]string{}, step.Needs...)
+		walkStrings(step.action(), func(s *string) {
+			for _, m := range refPattern.FindAllStringSubmatch(*s, -1) {
+				if m[1] == varsScope || m[1] == envScope {
+					continue
+				}
+				deps[step.ID] = append(deps[step.ID], m[1])
+			}
+		})
+	}
+	for _, step := range pb.Steps {
+		for _, dep := range deps[step.ID] {
+			if _, ok := deps[dep]; !ok {
+				return nil, fmt.Errorf("step %q depends on unknown step %q", step.ID, dep)
+			}
+		}
+	}
+
+	var (
+		order []*playbookStep
+		done  = make(map[string]bool, len(pb.Steps))
+	)
+	for len(order) < len(pb.Steps) {
+		next := slices.IndexFunc(pb.Steps, func(step *playbookStep) bool {
+			if done[step.ID] {
+				return false
+			}
+			for _, dep := range deps[step.ID] {
+				if !done[dep] {
+					return false
+				}
+			}
+			return true
+		})
+		if next < 0 {
+			return nil, errors.New("playbook steps have circular dependencies")
+		}
+		order = append(order, pb.Steps[next])
+		done[pb.Steps[next].ID] = true
+	}
+	return order, nil
+}
+
+// walkStrings calls fn with each of the string fields of the action, and with
+// the elements of its string slices.
+func walkStrings(action any, fn func(*string)) {
+	v := reflect.ValueOf(action).Elem()
+	for i := range v.NumField() {
+		switch f := v.Field(i); f.Kind() {
+		case reflect.String:
+			fn(f.Addr().Interface().(*string))
+		case reflect.Slice:
+			if f.Type().Elem().Kind() == reflect.String {
+				for j := range f.Len() {
+					fn(f.Index(j).Addr().Interface().(*string))
+				}
+			}
+		}
+	}
+}
+
+// expand replaces the references in the string fields of the action by their
+// values.
+func (pb *playbook) expand(action any, outputs map[string]map[string]string) error {
+	var err error
+	walkStrings(action, func(s *string) {
+		*s = refPattern.ReplaceAllStringFunc(*s, func(ref string) string {
+			m := refPattern.FindStringSubmatch(ref)
+			scope, name := m[1], m[2]
+			switch scope {
+			case varsScope:
+				if val, ok := pb.Vars[name]; ok {
+					return val
+				}
+			case envScope:
+				if val, ok := os.LookupEnv(name); ok {
+					return val
+				}
+			default:
+				if val, ok := outputs[scope][name]; ok {
+					return val
+				}
+			}
+			if err == nil {
+				err = fmt.Errorf("undefined reference %s", ref)
+			}
+			return ref
+		})
+	})
+	return err
+}
+
+func execExec(ctx context.Context, cfg *ExecCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	path := args[0]
+	bz, err := os.ReadFile(path)
+	if err != nil {
+		return errors.Wrap(err, "reading playbook")
+	}
+	pb, err := parsePlaybook(bz)
+	if err != nil {
+		return err
+	}
+	steps, err := pb.order()
+	if err != nil {
+		return err
+	}
+	if pb.ChainID == "" {
+		pb.ChainID = "dev"
+	}
+
+	statePath := cfg.State
+	if statePath == "" {
+		statePath = path + ".state.json"
+	}
+	state := playbookState{Outputs: map[string]map[string]string{}}
+	if !cfg.Restart {
+		if bz, err := os.ReadFile(statePath); err == nil {
+			if err := json.Unmarshal(bz, &state); err != nil {
+				return errors.Wrap(err, "reading state")
+			}
+		} else if !os.IsNotExist(err) {
+			return errors.Wrap(err, "reading state")
+		}
+	}
+
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	if err != nil {
+		return err
+	}
+	r := &playbookRunner{
+		cfg: cfg,
+		pb:  pb,
+		dir: filepath.Dir(path),
+		kb:  kb,
+		io:  io,
+	}
+
+	for _, step := range steps {
+		if _, ok := state.Outputs[step.ID]; ok {
+			io.Printfln("--- %s: done", step.ID)
+			continue
+		}
+		io.Printfln("--- %s", step.ID)
+
+		action := step.action()
+		if err := pb.expand(action, state.Outputs); err != nil {
+			return errors.Wrapf(err, "step %q", step.ID)
+		}
+		out, err := r.run(ctx, step, action)
+		if err != nil {
+			return errors.Wrapf(err, "step %q", step.ID)
+		}
+
+		state.Outputs[step.ID] = out
+		bz, err := json.MarshalIndent(state, "", "  ")
+		if err != nil {
+			return errors.Wrap(err, "marshaling state")
+		}
+		if err := os.WriteFile(statePath, bz, 0o644); err != nil {
+			return errors.Wrap(err, "writing state")
+		}
+	}
+	return nil
+}
+
+// playbookRunner executes the actions of the steps of a playbook.
+type playbookRunner struct {
+	cfg *ExecCfg
+	pb  *playbook
+	dir string
+	kb  keys.Keybase
+	io  commands.IO
+
+	// The password of the keys, asked once, when first needed.
+	password    string
+	hasPassword bool
+}
+
+func (r *playbookRunner) getPassword() (string, error) {
+	if !r.hasPassword {
+		prompt := "Enter password."
+		if r.cfg.RootCfg.Quiet {
+			prompt = ""
+		}
+		pass, err := r.io.GetPassword(prompt, r.cfg.RootCfg.InsecurePasswordStdin)
+		if err != nil {
+			return "", err
+		}
+		r.password, r.hasPassword = pass, true
+	}
+	return r.password, nil
+}
+
+func (r *playbookRunner) run(ctx context.Context, step *playbookStep, action any) (map[string]string, error) {
+	switch a := action.(type) {
+	case *keyAction:
+		return r.runKey(a)
+	case *waitAction:
+		return r.runWait(ctx, a)
+	case *queryAction:
+		return r.runQuery(a)
+	case *sendAction:
+		from, err := r.kb.GetByNameOrAddress(a.From)
+		if err != nil {
+			return nil, err
+		}
+		to, err := r.resolveAddress(a.To)
+		if err != nil {
+			return nil, err
+		}
+		amount, err := std.ParseCoins(a.Amount)
+		if err != nil {
+			return nil, errors.Wrap(err, "parsing amount")
+		}
+		msg := bank.MsgSend{
+			FromAddress: from.GetAddress(),
+			ToAddress:   to,
+			Amount:      amount,
+		}
+		return r.runTx(step, from, msg)
+	case *addPkgAction:
+		from, err := r.kb.GetByNameOrAddress(a.From)
+		if err != nil {
+			return nil, err
+		}
+		send, deposit, err := parseSendAndDeposit(a.Send, a.MaxDeposit)
+		if err != nil {
+			return nil, err
+		}
+		dir := a.Dir
+		if !filepath.IsAbs(dir) {
+			dir = filepath.Join(r.dir, dir)
+		}
+		memPkg, err := gno.ReadMemPackage(dir, a.PkgPath, gno.MPUserAll)
+		if err != nil {
+			return nil, errors.Wrap(err, "reading package")
+		}
+		if memPkg.IsEmpty() {
+			return nil, fmt.Errorf("found an empty package %q", a.PkgPath)
+		}
+		msg := vm.MsgAddPackage{
+			Creator:    from.GetAddress(),
+			Package:    memPkg,
+			Send:       send,
+			MaxDeposit: deposit,
+		}
+		return r.runTx(step, from, msg)
+	case *callAction:
+		from, err := r.kb.GetByNameOrAddress(a.From)
+		if err != nil {
+			return nil, err
+		}
+		send, deposit, err := parseSendAndDeposit(a.Send, a.MaxDeposit)
+		if err != nil {
+			return nil, err
+		}
+		msg := vm.MsgCall{
+			Caller:     from.GetAddress(),
+			Send:       send,
+			MaxDeposit: deposit,
+			PkgPath:    a.PkgPath,
+			Func:       a.Func,
+			Args:       a.Args,
+		}
+		return r.runTx(step, from, msg)
+	default:
+		panic(fmt.Sprintf("unexpected action %T", action))
+	}
+}
+
+func parseSendAndDeposit(sendStr, depositStr string) (send, deposit std.Coins, err error) {
+	send, err = std.ParseCoins(sendStr)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "parsing send coins")
+	}
+	deposit, err = std.ParseCoins(depositStr)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "parsing storage deposit coins")
+	}
+	return send, deposit, nil
+}
+
+// resolveAddress returns the address of a key name or address.
+func (r *playbookRunner) resolveAddress(nameOrBech32 string) (crypto.Address, error) {
+	if addr, err := crypto.AddressFromBech32(nameOrBech32); err == nil {
+		return addr, nil
+	}
+	info, err := r.kb.GetByName(nameOrBech32)
+	if err != nil {
+		return crypto.Address{}, err
+	}
+	return info.GetAddress(), nil
+}
+
+func (r *playbookRunner) runKey(a *keyAction) (map[string]string, error) {
+	if a.Name == "" {
+		return nil, errors.New("key name not specified")
+	}
+	info, err := r.kb.GetByName(a.Name)
+	if err != nil {
+		if ok, _ := r.kb.HasByName(a.Name); ok {
+			return nil, err
+		}
+
+		mnemonic := a.Mnemonic
+		if mnemonic == "" {
+			mnemonic, err = client.GenerateMnemonic(256)
+			if err != nil {
+				return nil, errors.Wrap(err, "generating mnemonic")
+			}
+		}
+		pass, err := r.getPassword()
+		if err != nil {
+			return nil, err
+		}
+		info, err = r.kb.CreateAccount(a.Name, mnemonic, "", pass, 0, 0)
+		if err != nil {
+			return nil, errors.Wrap(err, "creating key")
+		}
+		r.io.Printfln("created key %s", a.Name)
+	}
+
+	r.io.Printfln("ADDRESS:    %s", info.GetAddress())
+	return map[string]string{
+		"name":    a.Name,
+		"address": info.GetAddress().String(),
+	}, nil
+}
+
+func (r *playbookRunner) runWait(ctx context.Context, a *waitAction) (map[string]string, error) {
+	addr, err := r.resolveAddress(a.Address)
+	if err != nil {
+		return nil, err
+	}
+	want, err := std.ParseCoins(a.Balance)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing balance")
+	}
+	timeout := a.Timeout
+	if timeout == 0 {
+		timeout = defaultWaitTimeout
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	for {
+		qres, err := client.QueryHandler(&client.QueryCfg{
+			RootCfg: r.cfg.RootCfg,
+			Path:    "bank/balances/" + addr.String(),
+		})
+		if err != nil {
+			return nil, err
+		}
+		var balance std.Coins
+		if err := amino.UnmarshalJSON(qres.Response.Data, &balance); err != nil {
+			return nil, errors.Wrap(err, "unmarshaling balance")
+		}
+		if balance.IsAllGTE(want) {
+			r.io.Printfln("BALANCE:    %s", balance)
+			return map[string]string{"balance": balance.String()}, nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return nil, fmt.Errorf("balance of %s is %s after %s, waiting for %s", addr, balance, timeout, want)
+		case <-time.After(execPollInterval):
+		}
+	}
+}
+
+func (r *playbookRunner) runQuery(a *queryAction) (map[string]string, error) {
+	qres, err := client.QueryHandler(&client.QueryCfg{
+		RootCfg: r.cfg.RootCfg,
+		Path:    a.Path,
+		Data:    a.Data,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if qres.Response.Error != nil {
+		return nil, errors.Wrapf(qres.Response.Error, "query: log:%s", qres.Response.Log)
+	}
+
+	data := string(qres.Response.Data)
+	r.io.Printfln("DATA:       %s", data)
+	return map[string]string{"data": data}, nil
+}
+
+// runTx signs and broadcasts a transaction made of msg, estimating its gas
+// if it isn't set.
+func (r *playbookRunner) runTx(step *playbookStep, from keys.Info, msg std.Msg) (map[string]string, error) {
+	txCfg := &client.MakeTxCfg{
+		RootCfg:   r.cfg.RootCfg,
+		GasWanted: r.pb.GasWanted,
+		GasFee:    r.pb.GasFee,
+		Broadcast: true,
+		Simulate:  client.SimulateTest,
+		ChainID:   r.pb.ChainID,
+	}
+	if step.GasWanted != 0 {
+		txCfg.GasWanted = step.GasWanted
+	}
+	if step.GasFee != "" {
+		txCfg.GasFee = step.GasFee
+	}
+	if txCfg.GasFee == "" {
+		return nil, errors.New("gas-fee not specified")
+	}
+	gasfee, err := std.ParseCoin(txCfg.GasFee)
+	if err != nil {
+		return nil, errors.Wrap(err, "parsing gas fee coin")
+	}
+
+	tx := std.Tx{
+		Msgs: []std.Msg{msg},
+		Fee:  std.NewFee(txCfg.GasWanted, gasfee),
+	}
+	if tx.Fee.GasWanted == 0 {
+		_, tx.Fee.GasWanted, err = estimateGas(r.cfg.RootCfg.Remote, tx, from)
+		if err != nil {
+			return nil, errors.Wrap(err, "estimating gas")
+		}
+	}
+
+	pass, err := r.getPassword()
+	if err != nil {
+		return nil, err
+	}
+	res, err := client.SignAndBroadcastHandler(txCfg, from.GetName(), tx, pass)
+	if err != nil {
+		return nil, errors.Wrap(err, "broadcast tx")
+	}
+	if res.CheckTx.IsErr() {
+		return nil, errors.Wrapf(res.CheckTx.Error, "check transaction failed: log:%s", res.CheckTx.Log)
+	}
+	if res.DeliverTx.IsErr() {
+		return nil, errors.Wrapf(res.DeliverTx.Error, "deliver transaction failed: log:%s", res.DeliverTx.Log)
+	}
+
+	PrintTxInfo(tx, res, r.io)
+	return txOutputs(res), nil
+}
+
+func txOutputs(res *ctypes.ResultBroadcastTxCommit) map[string]string {
+	return map[string]string{
+		"data":     strings.TrimSpace(string(res.DeliverTx.Data)),
+		"height":   strconv.FormatInt(res.Height, 10),
+		"hash":     base64.StdEncoding.EncodeToString(res.Hash),
+		"gas-used": strconv.FormatInt(res.DeliverTx.GasUsed, 10),
+	}
+}
@@ -0,0 +1,101 @@
+package keyscli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestPlaybook_Order(t *testing.T) {
+	t.Parallel()
+
+	pb, err := parsePlaybook([]byte(`
+gas-fee: 1000000ugnot
+steps:
+  - id: call
+    call: {from: alice, pkgpath: gno.land/r/demo/foo, func: Bar, args: ["${deploy.height}"]}
+  - id: alice
+    key: {name: alice}
+  - id: deploy
+    needs: [alice]
+    addpkg: {from: alice, pkgpath: gno.land/r/demo/foo, dir: foo}
+  - id: query
+    query: {path: "vm/qrender", data: "gno.land/r/demo/foo:${vars.page}"}
+`))
+	require.NoError(t, err)
+
+	steps, err := pb.order()
+	require.NoError(t, err)
+	var ids []string
+	for _, step := range steps {
+		ids = append(ids, step.ID)
+	}
+	// call waits for deploy, which waits for alice.
+	assert.Equal(t, []string{"alice", "deploy", "call", "query"}, ids)
+
+	// JSON playbooks are parsed the same.
+	pb, err = parsePlaybook([]byte(`{"steps": [{"id": "alice", "key": {"name": "alice"}}]}`))
+	require.NoError(t, err)
+	assert.Equal(t, "alice", pb.Steps[0].Key.Name)
+}
+
+func TestPlaybook_OrderErrors(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name     string
+		playbook string
+		err      string
+	}{
+		{"no steps", `chainid: dev`, "playbook has no steps"},
+		{"no id", `steps: [{key: {name: a}}]`, "step 1 has no id"},
+		{"reserved id", `steps: [{id: vars, key: {name: a}}]`, `step id "vars" is reserved`},
+		{"duplicate id", `steps: [{id: a, key: {name: a}}, {id: a, key: {name: b}}]`, `duplicate step id "a"`},
+		{"no action", `steps: [{id: a}]`, `step "a" must have exactly one of`},
+		{"two actions", `steps: [{id: a, key: {name: a}, query: {path: p}}]`, `step "a" must have exactly one of`},
+		{"unknown need", `steps: [{id: a, needs: [b], key: {name: a}}]`, `step "a" depends on unknown step "b"`},
+		{"unknown ref", `steps: [{id: a, query: {path: "${b.data}"}}]`, `step "a" depends on unknown step "b"`},
+		{"cycle", `steps: [{id: a, needs: [b], key: {name: a}}, {id: b, query: {path: "${a.address}"}}]`, "circular dependencies"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			pb, err := parsePlaybook([]byte(tc.playbook))
+			require.NoError(t, err)
+			_, err = pb.order()
+			require.ErrorContains(t, err, tc.err)
+		})
+	}
+
+	// Unknown fields are rejected, to catch typos.
+	_, err := parsePlaybook([]byte(`steps: [{id: a, key: {nam: a}}]`))
+	require.ErrorContains(t, err, "field nam not found")
+}
+
+func TestPlaybook_Expand(t *testing.T) {
+	t.Setenv("GNOKEY_EXEC_TEST", "from-env")
+
+	pb := &playbook{Vars: map[string]string{"amount": "10ugnot"}}
+	outputs := map[string]map[string]string{
+		"alice": {"address": "g1alice"},
+	}
+
+	action := &callAction{
+		From: "${env.GNOKEY_EXEC_TEST}",
+		Args: []string{"${alice.address}", "${alice.address}:${vars.amount}"},
+		Send: "${vars.amount}",
+	}
+	require.NoError(t, pb.expand(action, outputs))
+	assert.Equal(t, &callAction{
+		From: "from-env",
+		Args: []string{"g1alice", "g1alice:10ugnot"},
+		Send: "10ugnot",
+	}, action)
+
+	err := pb.expand(&queryAction{Path: "${alice.balance}"}, outputs)
+	require.EqualError(t, err, "undefined reference ${alice.balance}")
+	err = pb.expand(&queryAction{Path: "${vars.missing}"}, outputs)
+	require.EqualError(t, err, "undefined reference ${vars.missing}")
+}
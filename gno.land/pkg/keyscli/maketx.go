@@ -38,6 +38,7 @@ func NewMakeTxCmd(rootCfg *client.BaseCfg, io commands.IO) *commands.Command {
 
 		// custom commands
 		NewMakeAddPkgCmd(cfg, io),
+		NewMakeDeployCmd(cfg, io),
 		NewMakeCallCmd(cfg, io),
 		NewMakeRunCmd(cfg, io),
 	)
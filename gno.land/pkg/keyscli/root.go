@@ -45,15 +45,19 @@ func NewRootCmd(io commands.IO, base client.BaseOptions) *commands.Command {
 		client.NewExportCmd(cfg, io),
 		client.NewImportCmd(cfg, io),
 		client.NewListCmd(cfg, io),
+		client.NewNetworkCmd(cfg, io),
 		client.NewSignCmd(cfg, io),
 		client.NewVerifyCmd(cfg, io),
 		client.NewQueryCmd(cfg, io),
 		client.NewBroadcastCmd(cfg, io),
 		client.NewMultisignCmd(cfg, io),
+		client.NewQueueCmd(cfg, io),
 		client.NewVersionCmd(cfg, io),
 
 		// Custom MakeTX command
 		NewMakeTxCmd(cfg, io),
+		NewWatchCmd(cfg, io),
+		NewExecCmd(cfg, io),
 	)
 
 	return cmd
@@ -9,7 +9,6 @@ import (
 
 	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
-	"github.com/gnolang/gno/tm2/pkg/amino"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
@@ -20,6 +19,7 @@ import (
 
 type MakeRunCfg struct {
 	RootCfg    *client.MakeTxCfg
+	Send       string
 	MaxDeposit string
 }
 
@@ -33,6 +33,13 @@ func NewMakeRunCmd(rootCfg *client.MakeTxCfg, cmdio commands.IO) *commands.Comma
 			Name:       "run",
 			ShortUsage: "run [flags] <key-name or address> <file or - or dir>",
 			ShortHelp:  "runs Gno code by invoking main() in a package",
+			LongHelp: `Runs the main() function of a Gno script, with the key as the caller, without
+deploying it: the script can call several realms in a single transaction, for
+instance to claim coins from some of them and transfer them. The coins of -send
+are sent to the script, which can spend them.
+
+If -gas-wanted isn't set, the gas of the transaction is estimated by simulating
+it on the chain at -remote, with a margin.`,
 		},
 		cfg,
 		func(_ context.Context, args []string) error {
@@ -42,6 +49,13 @@ func NewMakeRunCmd(rootCfg *client.MakeTxCfg, cmdio commands.IO) *commands.Comma
 }
 
 func (c *MakeRunCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Send,
+		"send",
+		"",
+		"send amount",
+	)
+
 	fs.StringVar(
 		&c.MaxDeposit,
 		"max-deposit",
@@ -54,8 +68,8 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 	if len(args) != 2 {
 		return flag.ErrHelp
 	}
-	if cfg.RootCfg.GasWanted == 0 {
-		return errors.New("gas-wanted not specified")
+	if cfg.RootCfg.GasWanted < 0 {
+		return errors.New("gas-wanted must not be negative")
 	}
 	if cfg.RootCfg.GasFee == "" {
 		return errors.New("gas-fee not specified")
@@ -75,7 +89,11 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 	}
 	caller := info.GetAddress()
 
-	// Parase deposit amount
+	// Parse send and deposit amounts
+	send, err := std.ParseCoins(cfg.Send)
+	if err != nil {
+		return errors.Wrap(err, "parsing send coins")
+	}
 	deposit, err := std.ParseCoins(cfg.MaxDeposit)
 	if err != nil {
 		return errors.Wrap(err, "parsing storage deposit coins")
@@ -106,7 +124,13 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 			return fmt.Errorf("could not read source path: %q, %w", sourcePath, err)
 		}
 		if info.IsDir() {
-			memPkg = gno.MustReadMemPackage(sourcePath, "", gno.MPUserProd)
+			// The path is only needed to read the files, as the VM keeper
+			// sets it.
+			runPath := "gno.land/e/" + caller.String() + "/run"
+			memPkg, err = gno.ReadMemPackage(sourcePath, runPath, gno.MPUserProd)
+			if err != nil {
+				return fmt.Errorf("could not read %q: %w", sourcePath, err)
+			}
 		} else { // is file
 			b, err := os.ReadFile(sourcePath)
 			if err != nil {
@@ -123,7 +147,7 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 
 	memPkg.Name = "main"
 	if memPkg.IsEmpty() {
-		panic(fmt.Sprintf("found an empty package %q", memPkg.Path))
+		return fmt.Errorf("found an empty package %q", sourcePath)
 	}
 
 	// Set to empty; this will be automatically set by the VM keeper.
@@ -132,6 +156,7 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 	// construct msg & tx and marshal.
 	msg := vm.MsgRun{
 		Caller:     caller,
+		Send:       send,
 		Package:    memPkg,
 		MaxDeposit: deposit,
 	}
@@ -142,6 +167,15 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 		Memo:       cfg.RootCfg.Memo,
 	}
 
+	if tx.Fee.GasWanted == 0 {
+		estimated, wanted, err := estimateGas(cfg.RootCfg.RootCfg.Remote, tx, info)
+		if err != nil {
+			return err
+		}
+		tx.Fee.GasWanted = wanted
+		cmdio.ErrPrintfln("gas wanted: %d (estimated %d)", wanted, estimated)
+	}
+
 	if cfg.RootCfg.Broadcast {
 		cfg.RootCfg.RootCfg.OnTxSuccess = func(tx std.Tx, res *ctypes.ResultBroadcastTxCommit) {
 			PrintTxInfo(tx, res, cmdio)
@@ -150,8 +184,7 @@ func execMakeRun(cfg *MakeRunCfg, args []string, cmdio commands.IO) error {
 		if err != nil {
 			return err
 		}
-	} else {
-		cmdio.Println(string(amino.MustMarshalJSON(tx)))
+		return nil
 	}
-	return nil
+	return client.PrintTx(cfg.RootCfg, tx, cmdio)
 }
@@ -0,0 +1,399 @@
+package keyscli
+
+import (
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/client"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// How often the node is asked for new blocks.
+const watchPollInterval = time.Second
+
+type WatchCfg struct {
+	RootCfg *client.BaseCfg
+
+	JSON   bool
+	Start  int64
+	Follow bool
+}
+
+func NewWatchCmd(rootCfg *client.BaseCfg, io commands.IO) *commands.Command {
+	cfg := &WatchCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "watch",
+			ShortUsage: "watch [flags] <key-name or address>",
+			ShortHelp:  "prints the transfers and realm calls involving an address",
+			LongHelp: "Connects to the remote node over WebSocket, and prints the activity " +
+				"involving the given address as new blocks are committed: the transfers " +
+				"reported by the bank events, including those made by realms and the " +
+				"scheduled sends, and the realm calls, runs, package additions and send " +
+				"schedulings of the transactions. A realm address matches the calls made " +
+				"to the realm. Transfers of failed transactions aren't reported.",
+		},
+		cfg,
+		func(ctx context.Context, args []string) error {
+			return execWatch(ctx, cfg, args, io)
+		},
+	)
+}
+
+func (c *WatchCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.BoolVar(
+		&c.JSON,
+		"json",
+		false,
+		"print one JSON object per line",
+	)
+
+	fs.Int64Var(
+		&c.Start,
+		"start",
+		0,
+		"height of the first block to watch (default: the next block)",
+	)
+
+	fs.BoolVar(
+		&c.Follow,
+		"follow",
+		true,
+		"keep watching new blocks; if false, exit after the latest block",
+	)
+}
+
+// watchEvent is a transfer or realm call involving the watched address.
+type watchEvent struct {
+	Height    int64  `json:"height"`
+	TxHash    string `json:"tx_hash"`
+	Type      string `json:"type"`      // "transfer", "schedule", "call", "run" or "addpkg".
+	Direction string `json:"direction"` // "in" or "out".
+	From      string `json:"from"`
+	To        string `json:"to,omitempty"`
+	Amount    string `json:"amount,omitempty"`
+	PkgPath   string `json:"pkg_path,omitempty"`
+	Func      string `json:"func,omitempty"`
+	At        int64  `json:"at_height,omitempty"` // execution height of a scheduled send.
+	AtTime    int64  `json:"at_time,omitempty"`   // execution unix time of a scheduled send.
+	Error     string `json:"error,omitempty"`
+}
+
+func (ev watchEvent) String() string {
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "HEIGHT %d", ev.Height)
+	if ev.TxHash != "" {
+		fmt.Fprintf(&sb, " TX %s", ev.TxHash)
+	}
+	fmt.Fprintf(&sb, " %s %s", strings.ToUpper(ev.Direction), ev.Type)
+	switch ev.Type {
+	case "transfer":
+		fmt.Fprintf(&sb, " %s from %s to %s", ev.Amount, ev.From, ev.To)
+	case "schedule":
+		fmt.Fprintf(&sb, " %s from %s to %s", ev.Amount, ev.From, ev.To)
+		if ev.At != 0 {
+			fmt.Fprintf(&sb, " at height %d", ev.At)
+		} else {
+			fmt.Fprintf(&sb, " at time %d", ev.AtTime)
+		}
+	case "call":
+		fmt.Fprintf(&sb, " %s.%s by %s", ev.PkgPath, ev.Func, ev.From)
+	case "addpkg":
+		fmt.Fprintf(&sb, " %s by %s", ev.PkgPath, ev.From)
+	case "run":
+		fmt.Fprintf(&sb, " by %s", ev.From)
+	}
+	if ev.Type != "transfer" && ev.Type != "schedule" && ev.Amount != "" {
+		fmt.Fprintf(&sb, " sending %s", ev.Amount)
+	}
+	if ev.Error != "" {
+		fmt.Fprintf(&sb, " FAILED: %s", ev.Error)
+	}
+	return sb.String()
+}
+
+func execWatch(ctx context.Context, cfg *WatchCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+	if cfg.Start < 0 {
+		return errors.New("start height must not be negative")
+	}
+
+	addr, err := crypto.AddressFromBech32(args[0])
+	if err != nil {
+		kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+		if err != nil {
+			return err
+		}
+		info, err := kb.GetByName(args[0])
+		if err != nil {
+			return err
+		}
+		addr = info.GetAddress()
+	}
+
+	remote := cfg.RootCfg.Remote
+	if remote == "" {
+		return errors.New("missing remote url")
+	}
+	wsURL, err := websocketURL(remote)
+	if err != nil {
+		return errors.Wrap(err, "parsing remote url")
+	}
+	cli, err := rpcclient.NewWSClient(wsURL)
+	if err != nil {
+		return errors.Wrap(err, "new ws client")
+	}
+	defer cli.Close()
+
+	wcfg := rpcclient.BlockWatchCfg{
+		FromHeight:   cfg.Start,
+		PollInterval: watchPollInterval,
+	}
+	if !cfg.Follow {
+		status, err := cli.Status(ctx, nil)
+		if err != nil {
+			return errors.Wrap(err, "getting status")
+		}
+		latest := status.SyncInfo.LatestBlockHeight
+		if latest == 0 || latest < cfg.Start {
+			return nil
+		}
+		wcfg.ToHeight = latest
+	}
+
+	w := addressWatcher{cli: cli, addr: addr}
+	return rpcclient.WatchBlockResults(ctx, cli, wcfg, func(res *ctypes.ResultBlockResults) error {
+		events, err := w.inspect(ctx, res)
+		if err != nil {
+			return err
+		}
+		for _, ev := range events {
+			if cfg.JSON {
+				bz, err := json.Marshal(ev)
+				if err != nil {
+					return err
+				}
+				io.Println(string(bz))
+			} else {
+				io.Println(ev.String())
+			}
+		}
+		return nil
+	})
+}
+
+// addressWatcher finds the activity of an address in the blocks.
+type addressWatcher struct {
+	cli  rpcclient.Client
+	addr crypto.Address
+}
+
+// inspect returns the events of the block of res involving the watched
+// address. The transfers are found in the bank events of the block results,
+// so that the transfers made by realms and the scheduled sends are reported
+// as well; the other activity is found in the messages of the transactions.
+func (w addressWatcher) inspect(ctx context.Context, res *ctypes.ResultBlockResults) ([]watchEvent, error) {
+	if res == nil || res.Results == nil {
+		return nil, nil
+	}
+
+	var events []watchEvent
+	add := func(evs []watchEvent, txHash, txErr string) {
+		for _, ev := range evs {
+			ev.Height = res.Height
+			ev.TxHash = txHash
+			ev.Error = txErr
+			events = append(events, ev)
+		}
+	}
+
+	for _, ev := range res.Results.BeginBlock.Events {
+		add(w.eventWatchEvents(ev), "", "")
+	}
+
+	if len(res.Results.DeliverTxs) > 0 {
+		block, err := w.cli.Block(ctx, &res.Height)
+		if err != nil {
+			return nil, errors.Wrapf(err, "getting block %d", res.Height)
+		}
+		for i, txBytes := range block.Block.Txs {
+			var txErr string
+			var txEvents []abci.Event
+			if i < len(res.Results.DeliverTxs) {
+				dtx := res.Results.DeliverTxs[i]
+				if dtx.IsErr() {
+					txErr = dtx.Error.Error()
+				}
+				txEvents = dtx.Events
+			}
+			hash := base64.StdEncoding.EncodeToString(types.Tx(txBytes).Hash())
+
+			var tx std.Tx
+			if err := amino.Unmarshal(txBytes, &tx); err == nil {
+				for _, msg := range tx.Msgs {
+					add(w.msgWatchEvents(msg), hash, txErr)
+				}
+			}
+			for _, ev := range txEvents {
+				add(w.eventWatchEvents(ev), hash, "")
+			}
+		}
+	}
+
+	for _, ev := range res.Results.EndBlock.Events {
+		add(w.eventWatchEvents(ev), "", "")
+	}
+	return events, nil
+}
+
+// eventWatchEvents returns the transfers of the bank event ev involving the
+// watched address, without their height and transaction hash.
+func (w addressWatcher) eventWatchEvents(ev abci.Event) []watchEvent {
+	ae, ok := ev.(sdk.AttributeEvent)
+	if !ok || ae.Module != bank.ModuleName {
+		return nil
+	}
+	attrs := map[string]string{}
+	for _, attr := range ae.Attributes {
+		attrs[attr.Key] = attr.Value
+	}
+
+	addr := w.addr.String()
+	wev := watchEvent{Type: "transfer", Amount: attrs[bank.AttributeKeyAmount]}
+	switch ae.Type {
+	case bank.EventTypeTransfer:
+		wev.From, wev.To = attrs[bank.AttributeKeySender], attrs[bank.AttributeKeyRecipient]
+		switch addr {
+		case wev.From:
+			wev.Direction = "out"
+		case wev.To:
+			wev.Direction = "in"
+		default:
+			return nil
+		}
+	case bank.EventTypeCoinSpent:
+		if attrs[bank.AttributeKeySpender] != addr {
+			return nil
+		}
+		wev.Direction, wev.From = "out", addr
+	case bank.EventTypeCoinReceived:
+		if attrs[bank.AttributeKeyReceiver] != addr {
+			return nil
+		}
+		wev.Direction, wev.To = "in", addr
+	default:
+		return nil
+	}
+	return []watchEvent{wev}
+}
+
+// msgWatchEvents returns the realm calls, package additions and scheduled
+// sends of msg involving the watched address, without their height,
+// transaction hash and error.
+func (w addressWatcher) msgWatchEvents(msg std.Msg) []watchEvent {
+	switch msg := msg.(type) {
+	case bank.MsgSendAt:
+		ev := watchEvent{
+			Type: "schedule", From: msg.FromAddress.String(), To: msg.ToAddress.String(),
+			Amount: msg.Amount.String(), At: msg.Height, AtTime: msg.Time,
+		}
+		switch w.addr {
+		case msg.FromAddress:
+			ev.Direction = "out"
+		case msg.ToAddress:
+			ev.Direction = "in"
+		default:
+			return nil
+		}
+		return []watchEvent{ev}
+	case vm.MsgCall:
+		ev := watchEvent{Type: "call", From: msg.Caller.String(), PkgPath: msg.PkgPath, Func: msg.Func}
+		if !msg.Send.IsZero() {
+			ev.Amount = msg.Send.String()
+		}
+		switch w.addr {
+		case msg.Caller:
+			ev.Direction = "out"
+		case gno.DerivePkgCryptoAddr(msg.PkgPath):
+			ev.Direction = "in"
+		default:
+			return nil
+		}
+		return []watchEvent{ev}
+	case vm.MsgRun:
+		if msg.Caller != w.addr {
+			return nil
+		}
+		ev := watchEvent{Type: "run", Direction: "out", From: msg.Caller.String()}
+		if !msg.Send.IsZero() {
+			ev.Amount = msg.Send.String()
+		}
+		return []watchEvent{ev}
+	case vm.MsgAddPackage:
+		if msg.Package == nil {
+			return nil
+		}
+		ev := watchEvent{Type: "addpkg", From: msg.Creator.String(), PkgPath: msg.Package.Path}
+		if !msg.Send.IsZero() {
+			ev.Amount = msg.Send.String()
+		}
+		switch w.addr {
+		case msg.Creator:
+			ev.Direction = "out"
+		case gno.DerivePkgCryptoAddr(msg.Package.Path):
+			ev.Direction = "in"
+		default:
+			return nil
+		}
+		return []watchEvent{ev}
+	}
+	return nil
+}
+
+// websocketURL returns the URL of the WebSocket endpoint of the node at
+// remote, which is in the form [<protocol>://]<host>:<port>.
+func websocketURL(remote string) (string, error) {
+	if !strings.Contains(remote, "://") {
+		remote = "tcp://" + remote
+	}
+	u, err := url.Parse(remote)
+	if err != nil {
+		return "", err
+	}
+	switch u.Scheme {
+	case "https", "wss":
+		u.Scheme = "wss"
+	case "http", "tcp", "ws":
+		u.Scheme = "ws"
+	default:
+		return "", fmt.Errorf("unsupported scheme %q", u.Scheme)
+	}
+	if u.Path == "" || u.Path == "/" {
+		u.Path = "/websocket"
+	}
+	return u.String(), nil
+}
@@ -0,0 +1,150 @@
+package keyscli
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestAddressWatcher_EventWatchEvents(t *testing.T) {
+	t.Parallel()
+
+	watched := crypto.AddressFromPreimage([]byte("watched"))
+	other := crypto.AddressFromPreimage([]byte("other"))
+	w := addressWatcher{addr: watched}
+
+	transfer := func(from, to crypto.Address) sdk.AttributeEvent {
+		return sdk.NewEvent(bank.EventTypeTransfer, bank.ModuleName,
+			sdk.NewIndexedAttribute(bank.AttributeKeySender, from.String()),
+			sdk.NewIndexedAttribute(bank.AttributeKeyRecipient, to.String()),
+			sdk.NewAttribute(bank.AttributeKeyAmount, "10ugnot"),
+		)
+	}
+
+	assert.Equal(t, []watchEvent{{
+		Type: "transfer", Direction: "out", From: watched.String(), To: other.String(), Amount: "10ugnot",
+	}}, w.eventWatchEvents(transfer(watched, other)))
+	assert.Equal(t, []watchEvent{{
+		Type: "transfer", Direction: "in", From: other.String(), To: watched.String(), Amount: "10ugnot",
+	}}, w.eventWatchEvents(transfer(other, watched)))
+	assert.Empty(t, w.eventWatchEvents(transfer(other, other)))
+
+	// Events of other modules are ignored.
+	ev := transfer(watched, other)
+	ev.Module = "vm"
+	assert.Empty(t, w.eventWatchEvents(ev))
+}
+
+func TestAddressWatcher_MsgWatchEvents(t *testing.T) {
+	t.Parallel()
+
+	watched := crypto.AddressFromPreimage([]byte("watched"))
+	other := crypto.AddressFromPreimage([]byte("other"))
+	send := std.NewCoins(std.NewCoin("ugnot", 10))
+
+	const pkgPath = "gno.land/r/demo/counter"
+	pkg := &std.MemPackage{Name: "counter", Path: pkgPath}
+
+	tests := []struct {
+		name string
+		addr crypto.Address
+		msg  std.Msg
+		want []watchEvent
+	}{
+		{
+			name: "scheduled send out",
+			addr: watched,
+			msg:  bank.MsgSendAt{FromAddress: watched, ToAddress: other, Amount: send, Height: 42},
+			want: []watchEvent{{
+				Type: "schedule", Direction: "out", From: watched.String(), To: other.String(),
+				Amount: "10ugnot", At: 42,
+			}},
+		},
+		{
+			name: "scheduled send in",
+			addr: watched,
+			msg:  bank.MsgSendAt{FromAddress: other, ToAddress: watched, Amount: send, Time: 1700000000},
+			want: []watchEvent{{
+				Type: "schedule", Direction: "in", From: other.String(), To: watched.String(),
+				Amount: "10ugnot", AtTime: 1700000000,
+			}},
+		},
+		{
+			name: "call by the address",
+			addr: watched,
+			msg:  vm.MsgCall{Caller: watched, Send: send, PkgPath: pkgPath, Func: "Incr"},
+			want: []watchEvent{{
+				Type: "call", Direction: "out", From: watched.String(), Amount: "10ugnot",
+				PkgPath: pkgPath, Func: "Incr",
+			}},
+		},
+		{
+			name: "call to the realm",
+			addr: gno.DerivePkgCryptoAddr(pkgPath),
+			msg:  vm.MsgCall{Caller: other, PkgPath: pkgPath, Func: "Incr"},
+			want: []watchEvent{{
+				Type: "call", Direction: "in", From: other.String(), PkgPath: pkgPath, Func: "Incr",
+			}},
+		},
+		{
+			name: "run",
+			addr: watched,
+			msg:  vm.MsgRun{Caller: watched, Package: pkg},
+			want: []watchEvent{{Type: "run", Direction: "out", From: watched.String()}},
+		},
+		{
+			name: "package added by the address",
+			addr: watched,
+			msg:  vm.MsgAddPackage{Creator: watched, Package: pkg},
+			want: []watchEvent{{Type: "addpkg", Direction: "out", From: watched.String(), PkgPath: pkgPath}},
+		},
+		{
+			name: "package added at the address",
+			addr: gno.DerivePkgCryptoAddr(pkgPath),
+			msg:  vm.MsgAddPackage{Creator: other, Package: pkg},
+			want: []watchEvent{{Type: "addpkg", Direction: "in", From: other.String(), PkgPath: pkgPath}},
+		},
+		{
+			name: "unrelated call",
+			addr: watched,
+			msg:  vm.MsgCall{Caller: other, PkgPath: pkgPath, Func: "Incr"},
+		},
+		{
+			name: "unrelated scheduled send",
+			addr: watched,
+			msg:  bank.MsgSendAt{FromAddress: other, ToAddress: other, Amount: send, Height: 42},
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			w := addressWatcher{addr: tc.addr}
+			assert.Equal(t, tc.want, w.msgWatchEvents(tc.msg))
+		})
+	}
+}
+
+func TestWatchEvent_String(t *testing.T) {
+	t.Parallel()
+
+	assert.Equal(t,
+		"HEIGHT 3 OUT schedule 10ugnot from a to b at height 42",
+		watchEvent{Height: 3, Type: "schedule", Direction: "out", From: "a", To: "b", Amount: "10ugnot", At: 42}.String(),
+	)
+	assert.Equal(t,
+		"HEIGHT 3 TX aGFzaA== OUT call gno.land/r/demo/counter.Incr by a sending 10ugnot FAILED: boom",
+		watchEvent{
+			Height: 3, TxHash: "aGFzaA==", Type: "call", Direction: "out", From: "a",
+			Amount: "10ugnot", PkgPath: "gno.land/r/demo/counter", Func: "Incr", Error: "boom",
+		}.String(),
+	)
+}
@@ -12,7 +12,7 @@ import (
 )
 
 // NewZapLoggerFn is the zap logger init declaration
-type NewZapLoggerFn func(w io.Writer, level zapcore.Level, opts ...zap.Option) *zap.Logger
+type NewZapLoggerFn func(w io.Writer, level zapcore.LevelEnabler, opts ...zap.Option) *zap.Logger
 
 // GetZapLoggerFn returns the appropriate init callback
 // for the zap logger, given the requested format
@@ -44,7 +44,7 @@ func InitializeZapLogger(io io.WriteCloser, logLevel, logFormat string) (*zap.Lo
 }
 
 // NewZapJSONLogger creates a zap logger with a JSON encoder for production use.
-func NewZapJSONLogger(w io.Writer, level zapcore.Level, opts ...zap.Option) *zap.Logger {
+func NewZapJSONLogger(w io.Writer, level zapcore.LevelEnabler, opts ...zap.Option) *zap.Logger {
 	// Build encoder config
 	jsonConfig := zap.NewProductionEncoderConfig()
 
@@ -54,7 +54,7 @@ func NewZapJSONLogger(w io.Writer, level zapcore.Level, opts ...zap.Option) *zap
 }
 
 // NewZapConsoleLogger creates a zap logger with a console encoder for development use.
-func NewZapConsoleLogger(w io.Writer, level zapcore.Level, opts ...zap.Option) *zap.Logger {
+func NewZapConsoleLogger(w io.Writer, level zapcore.LevelEnabler, opts ...zap.Option) *zap.Logger {
 	// Build encoder config
 	consoleConfig := zap.NewDevelopmentEncoderConfig()
 	consoleConfig.EncodeLevel = stableWidthCapitalColorLevelEncoder
@@ -66,7 +66,7 @@ func NewZapConsoleLogger(w io.Writer, level zapcore.Level, opts ...zap.Option) *
 }
 
 // NewZapTestingLogger creates a zap logger with a console encoder optimized for testing.
-func NewZapTestingLogger(w io.Writer, level zapcore.Level, opts ...zap.Option) *zap.Logger {
+func NewZapTestingLogger(w io.Writer, level zapcore.LevelEnabler, opts ...zap.Option) *zap.Logger {
 	// Build encoder config
 	consoleConfig := zap.NewDevelopmentEncoderConfig()
 	consoleConfig.TimeKey = ""
@@ -79,11 +79,12 @@ func NewZapTestingLogger(w io.Writer, level zapcore.Level, opts ...zap.Option) *
 }
 
 // NewZapLogger creates a new zap logger instance, for the given level, writer and zap encoder.
-func NewZapLogger(enc zapcore.Encoder, w io.Writer, level zapcore.Level, opts ...zap.Option) *zap.Logger {
+// The level can be a zap.AtomicLevel, to change it while the logger is in use.
+func NewZapLogger(enc zapcore.Encoder, w io.Writer, level zapcore.LevelEnabler, opts ...zap.Option) *zap.Logger {
 	ws := zapcore.AddSync(w)
 
 	// Create zap core
-	core := zapcore.NewCore(enc, ws, zap.NewAtomicLevelAt(level))
+	core := zapcore.NewCore(enc, ws, level)
 	return zap.New(core, opts...)
 }
 
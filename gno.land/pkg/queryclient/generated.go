@@ -0,0 +1,371 @@
+// Code generated by the genqueryclient tool (@/gno.land/pkg/queryclient/genqueryclient); DO NOT EDIT.
+// To regenerate it, run `go generate` from the queryclient directory.
+
+package queryclient
+
+import (
+	"context"
+	"strconv"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/gnovm/pkg/doc"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// VMRenderRequest is the request of the vm/qrender query.
+type VMRenderRequest struct {
+	PkgPath string // path of the realm
+	Path    string // argument of Render
+}
+
+// VMRenderResponse is the response of the vm/qrender query.
+type VMRenderResponse struct {
+	Output string // result of Render
+}
+
+// VMRender calls the Render function of the realm at PkgPath, with Path as its argument.
+func (c *Client) VMRender(ctx context.Context, req VMRenderRequest) (res VMRenderResponse, err error) {
+	path := "vm/qrender"
+	bz, err := c.query(ctx, path, []byte(req.PkgPath+":"+req.Path))
+	if err != nil {
+		return res, err
+	}
+	res.Output = string(bz)
+	return res, nil
+}
+
+// VMFuncsRequest is the request of the vm/qfuncs query.
+type VMFuncsRequest struct {
+	PkgPath string // path of the package
+}
+
+// VMFuncsResponse is the response of the vm/qfuncs query.
+type VMFuncsResponse = vm.FunctionSignatures
+
+// VMFuncs returns the signatures of the exported functions of the package at PkgPath.
+func (c *Client) VMFuncs(ctx context.Context, req VMFuncsRequest) (res VMFuncsResponse, err error) {
+	path := "vm/qfuncs"
+	bz, err := c.query(ctx, path, []byte(req.PkgPath))
+	if err != nil {
+		return res, err
+	}
+	err = amino.UnmarshalJSON(bz, &res)
+	return res, err
+}
+
+// VMEvalRequest is the request of the vm/qeval query.
+type VMEvalRequest struct {
+	PkgPath string // path of the package
+	Expr    string // expression to evaluate, e.g. a function call
+}
+
+// VMEvalResponse is the response of the vm/qeval query.
+type VMEvalResponse struct {
+	Result string // typed values of the result, one per line
+}
+
+// VMEval evaluates Expr in the package at PkgPath, in read-only mode.
+func (c *Client) VMEval(ctx context.Context, req VMEvalRequest) (res VMEvalResponse, err error) {
+	path := "vm/qeval"
+	bz, err := c.query(ctx, path, []byte(req.PkgPath+"."+req.Expr))
+	if err != nil {
+		return res, err
+	}
+	res.Result = string(bz)
+	return res, nil
+}
+
+// VMFileRequest is the request of the vm/qfile query.
+type VMFileRequest struct {
+	Path string // path of a file, or of a package
+}
+
+// VMFileResponse is the response of the vm/qfile query.
+type VMFileResponse struct {
+	Body string // body of the file, or names of the files
+}
+
+// VMFile returns the body of the file at Path, or the names of the files of the package at Path, one per line.
+func (c *Client) VMFile(ctx context.Context, req VMFileRequest) (res VMFileResponse, err error) {
+	path := "vm/qfile"
+	bz, err := c.query(ctx, path, []byte(req.Path))
+	if err != nil {
+		return res, err
+	}
+	res.Body = string(bz)
+	return res, nil
+}
+
+// VMDocRequest is the request of the vm/qdoc query.
+type VMDocRequest struct {
+	PkgPath string // path of the package
+}
+
+// VMDocResponse is the response of the vm/qdoc query.
+type VMDocResponse = doc.JSONDocumentation
+
+// VMDoc returns the documentation of the package at PkgPath.
+func (c *Client) VMDoc(ctx context.Context, req VMDocRequest) (res VMDocResponse, err error) {
+	path := "vm/qdoc"
+	bz, err := c.query(ctx, path, []byte(req.PkgPath))
+	if err != nil {
+		return res, err
+	}
+	err = amino.UnmarshalJSON(bz, &res)
+	return res, err
+}
+
+// VMABIRequest is the request of the vm/qabi query.
+type VMABIRequest struct {
+	PkgPath string // path of the package
+}
+
+// VMABIResponse is the response of the vm/qabi query.
+type VMABIResponse = vm.ABI
+
+// VMABI returns the ABI of the package at PkgPath: its exported functions, with their parameters and documentation.
+func (c *Client) VMABI(ctx context.Context, req VMABIRequest) (res VMABIResponse, err error) {
+	path := "vm/qabi"
+	bz, err := c.query(ctx, path, []byte(req.PkgPath))
+	if err != nil {
+		return res, err
+	}
+	err = amino.UnmarshalJSON(bz, &res)
+	return res, err
+}
+
+// VMPathsRequest is the request of the vm/qpaths query.
+type VMPathsRequest struct {
+	Target string // path prefix, or @username for the packages of a user
+	Limit  int    // maximum number of paths, 0 is the node default
+}
+
+// VMPathsResponse is the response of the vm/qpaths query.
+type VMPathsResponse struct {
+	Paths []string // paths of the packages
+}
+
+// VMPaths returns the paths of the packages matching Target.
+func (c *Client) VMPaths(ctx context.Context, req VMPathsRequest) (res VMPathsResponse, err error) {
+	path := "vm/qpaths" + limitQuery(req.Limit)
+	bz, err := c.query(ctx, path, []byte(req.Target))
+	if err != nil {
+		return res, err
+	}
+	res.Paths = lines(bz)
+	return res, nil
+}
+
+// VMStorageRequest is the request of the vm/qstorage query.
+type VMStorageRequest struct {
+	PkgPath string // path of the realm
+}
+
+// VMStorageResponse is the response of the vm/qstorage query.
+type VMStorageResponse struct {
+	Storage int64 // storage used by the realm, in bytes
+	Deposit int64 // deposit paid for the storage
+}
+
+// VMStorage returns the storage used by the realm at PkgPath, and the deposit paid for it.
+func (c *Client) VMStorage(ctx context.Context, req VMStorageRequest) (res VMStorageResponse, err error) {
+	path := "vm/qstorage"
+	bz, err := c.query(ctx, path, []byte(req.PkgPath))
+	if err != nil {
+		return res, err
+	}
+	err = decodeStorage(bz, &res)
+	return res, err
+}
+
+// VMDependentsRequest is the request of the vm/qdependents query.
+type VMDependentsRequest struct {
+	PkgPath string // path of the package
+	Limit   int    // maximum number of paths, 0 is the node default
+}
+
+// VMDependentsResponse is the response of the vm/qdependents query.
+type VMDependentsResponse struct {
+	Paths []string // paths of the importing packages
+}
+
+// VMDependents returns the paths of the packages importing the package at PkgPath.
+func (c *Client) VMDependents(ctx context.Context, req VMDependentsRequest) (res VMDependentsResponse, err error) {
+	path := "vm/qdependents" + limitQuery(req.Limit)
+	bz, err := c.query(ctx, path, []byte(req.PkgPath))
+	if err != nil {
+		return res, err
+	}
+	res.Paths = lines(bz)
+	return res, nil
+}
+
+// VMLimitsRequest is the request of the vm/qlimits query.
+type VMLimitsRequest struct{}
+
+// VMLimitsResponse is the response of the vm/qlimits query.
+type VMLimitsResponse = vm.Limits
+
+// VMLimits returns the limits on the size of the transactions and of the packages.
+func (c *Client) VMLimits(ctx context.Context, req VMLimitsRequest) (res VMLimitsResponse, err error) {
+	path := "vm/qlimits"
+	bz, err := c.query(ctx, path, nil)
+	if err != nil {
+		return res, err
+	}
+	err = amino.UnmarshalJSON(bz, &res)
+	return res, err
+}
+
+// VMGasTableRequest is the request of the vm/qgastable query.
+type VMGasTableRequest struct{}
+
+// VMGasTableResponse is the response of the vm/qgastable query.
+type VMGasTableResponse = vm.GasTable
+
+// VMGasTable returns the gas table of the VM, with its version, overrides and prices.
+func (c *Client) VMGasTable(ctx context.Context, req VMGasTableRequest) (res VMGasTableResponse, err error) {
+	path := "vm/qgastable"
+	bz, err := c.query(ctx, path, nil)
+	if err != nil {
+		return res, err
+	}
+	err = amino.UnmarshalJSON(bz, &res)
+	return res, err
+}
+
+// AuthAccountRequest is the request of the auth/accounts query.
+type AuthAccountRequest struct {
+	Address crypto.Address // address of the account
+}
+
+// AuthAccountResponse is the response of the auth/accounts query.
+type AuthAccountResponse = gnoland.GnoAccount
+
+// AuthAccount returns the account at Address, or an error if it doesn't exist.
+func (c *Client) AuthAccount(ctx context.Context, req AuthAccountRequest) (res AuthAccountResponse, err error) {
+	path := "auth/accounts" + "/" + req.Address.String()
+	bz, err := c.query(ctx, path, nil)
+	if err != nil {
+		return res, err
+	}
+	err = decodeAccount(bz, &res)
+	return res, err
+}
+
+// AuthAccountsRequest is the request of the auth/accounts query.
+type AuthAccountsRequest struct {
+	Query auth.AccountsQuery // filters and page of the accounts
+}
+
+// AuthAccountsResponse is the response of the auth/accounts query.
+type AuthAccountsResponse = auth.AccountsResult
+
+// AuthAccounts returns a page of the accounts matching the filters of Query.
+func (c *Client) AuthAccounts(ctx context.Context, req AuthAccountsRequest) (res AuthAccountsResponse, err error) {
+	path := "auth/accounts"
+	bz, err := c.query(ctx, path, amino.MustMarshalJSON(req.Query))
+	if err != nil {
+		return res, err
+	}
+	err = amino.UnmarshalJSON(bz, &res)
+	return res, err
+}
+
+// AuthGasPriceRequest is the request of the auth/gasprice query.
+type AuthGasPriceRequest struct{}
+
+// AuthGasPriceResponse is the response of the auth/gasprice query.
+type AuthGasPriceResponse = std.GasPrice
+
+// AuthGasPrice returns the gas price of the last block.
+func (c *Client) AuthGasPrice(ctx context.Context, req AuthGasPriceRequest) (res AuthGasPriceResponse, err error) {
+	path := "auth/gasprice"
+	bz, err := c.query(ctx, path, nil)
+	if err != nil {
+		return res, err
+	}
+	err = amino.UnmarshalJSON(bz, &res)
+	return res, err
+}
+
+// BankBalanceRequest is the request of the bank/balances query.
+type BankBalanceRequest struct {
+	Address crypto.Address // address of the account
+}
+
+// BankBalanceResponse is the response of the bank/balances query.
+type BankBalanceResponse = std.Coins
+
+// BankBalance returns the coins of the account at Address.
+func (c *Client) BankBalance(ctx context.Context, req BankBalanceRequest) (res BankBalanceResponse, err error) {
+	path := "bank/balances" + "/" + req.Address.String()
+	bz, err := c.query(ctx, path, nil)
+	if err != nil {
+		return res, err
+	}
+	err = amino.UnmarshalJSON(bz, &res)
+	return res, err
+}
+
+// BankScheduledSendRequest is the request of the bank/scheduled query.
+type BankScheduledSendRequest struct {
+	ID uint64 // ID of the scheduled send
+}
+
+// BankScheduledSendResponse is the response of the bank/scheduled query.
+type BankScheduledSendResponse = bank.ScheduledSend
+
+// BankScheduledSend returns the pending scheduled send with the given ID.
+func (c *Client) BankScheduledSend(ctx context.Context, req BankScheduledSendRequest) (res BankScheduledSendResponse, err error) {
+	path := "bank/scheduled" + "/" + strconv.FormatUint(req.ID, 10)
+	bz, err := c.query(ctx, path, nil)
+	if err != nil {
+		return res, err
+	}
+	err = amino.UnmarshalJSON(bz, &res)
+	return res, err
+}
+
+// BankAddressOwnerRequest is the request of the bank/qaddr-owner query.
+type BankAddressOwnerRequest struct {
+	Address crypto.Address // derived address
+}
+
+// BankAddressOwnerResponse is the response of the bank/qaddr-owner query.
+type BankAddressOwnerResponse struct {
+	Owner string // owner of the address, empty for the user accounts
+}
+
+// BankAddressOwner returns the owner of a derived address, like the path of the package of a realm address.
+func (c *Client) BankAddressOwner(ctx context.Context, req BankAddressOwnerRequest) (res BankAddressOwnerResponse, err error) {
+	path := "bank/qaddr-owner" + "/" + req.Address.String()
+	bz, err := c.query(ctx, path, nil)
+	if err != nil {
+		return res, err
+	}
+	res.Owner = string(bz)
+	return res, nil
+}
+
+// BankModulesRequest is the request of the bank/modules query.
+type BankModulesRequest struct{}
+
+// BankModulesResponse is the response of the bank/modules query.
+type BankModulesResponse = []bank.ModuleAccount
+
+// BankModules returns the module accounts, with their address and permissions.
+func (c *Client) BankModules(ctx context.Context, req BankModulesRequest) (res BankModulesResponse, err error) {
+	path := "bank/modules"
+	bz, err := c.query(ctx, path, nil)
+	if err != nil {
+		return res, err
+	}
+	err = amino.UnmarshalJSON(bz, &res)
+	return res, err
+}
@@ -0,0 +1,86 @@
+// Command genqueryclient generates the query methods of the queryclient
+// package, with their request and response types, from the query routes of
+// the vm, auth and bank modules described in ./routes.go.
+//
+// The file is generated following the template available in ./template.tmpl,
+// and written to the given path.
+package main
+
+import (
+	"bytes"
+	"fmt"
+	"go/format"
+	"os"
+	"strings"
+	"text/template"
+
+	_ "embed"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: genqueryclient <output.go>")
+		os.Exit(2)
+	}
+
+	src, err := generate()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(os.Args[1], src, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+//go:embed template.tmpl
+var templateText string
+
+var tpl = template.Must(template.New("").Parse(templateText))
+
+// tplRoute is a route, as passed to the template.
+type tplRoute struct {
+	route
+	Path string // query path, e.g. vm/qrender
+}
+
+// generate returns the formatted source of the query methods.
+func generate() ([]byte, error) {
+	data := make([]tplRoute, len(routes))
+	for i, r := range routes {
+		if err := r.validate(); err != nil {
+			return nil, err
+		}
+		data[i] = tplRoute{
+			route: r,
+			Path:  strings.ToLower(r.Module) + "/" + r.Route,
+		}
+	}
+
+	var buf bytes.Buffer
+	if err := tpl.Execute(&buf, data); err != nil {
+		return nil, fmt.Errorf("execute template: %w", err)
+	}
+	src, err := format.Source(buf.Bytes())
+	if err != nil {
+		return nil, fmt.Errorf("format generated code: %w", err)
+	}
+	return src, nil
+}
+
+func (r route) validate() error {
+	name := r.Module + r.Name
+	switch {
+	case r.Module == "" || r.Name == "" || r.Route == "" || r.Doc == "":
+		return fmt.Errorf("route %s: missing module, name, route or doc", name)
+	case (r.Alias == "") == (len(r.Response) == 0):
+		return fmt.Errorf("route %s: needs either a response alias or fields", name)
+	case (r.Decode == "text" || r.Decode == "lines") && len(r.Response) != 1:
+		return fmt.Errorf("route %s: %s decoder needs a single response field", name, r.Decode)
+	case r.Decode == "":
+		return fmt.Errorf("route %s: missing decoder", name)
+	}
+	return nil
+}
@@ -0,0 +1,33 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestGenerated(t *testing.T) {
+	t.Parallel()
+
+	src, err := generate()
+	require.NoError(t, err)
+
+	generated, err := os.ReadFile("../generated.go")
+	require.NoError(t, err)
+	assert.Equal(t, string(generated), string(src), "generated.go is outdated, run go generate")
+}
+
+func TestRoutes(t *testing.T) {
+	t.Parallel()
+
+	names := make(map[string]bool)
+	for _, r := range routes {
+		name := r.Module + r.Name
+		assert.False(t, names[name], "duplicate route %s", name)
+		names[name] = true
+
+		assert.NoError(t, r.validate())
+	}
+}
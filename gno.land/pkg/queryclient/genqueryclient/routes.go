@@ -0,0 +1,197 @@
+package main
+
+import (
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+)
+
+// route describes a query route, and the client method generated for it.
+type route struct {
+	Module string // module of the route, also the prefix of the generated names
+	Name   string // name of the method, without the module prefix
+	Route  string // query path of the route in the module, e.g. qrender
+	Doc    string // doc comment of the method, following its name
+
+	Request  []field // fields of the request struct
+	Response []field // fields of the response struct, if not an alias
+	Alias    string  // type aliased by the response, if any
+
+	// Go expressions, of the query path after the route (string) and of
+	// the request data ([]byte); they use the request as req.
+	PathSuffix string
+	Data       string
+
+	// Decoder of the response data: "amino" to decode amino JSON into the
+	// response, "text" or "lines" to set its only field to the data as a
+	// string or as lines, or the name of a func(bz []byte, res *Response) error.
+	Decode string
+}
+
+// field is a field of a request or a response struct.
+type field struct {
+	Name string
+	Type string
+	Doc  string
+}
+
+var routes = []route{
+	// vm
+	{
+		Module: "VM", Name: "Render", Route: vm.QueryRender,
+		Doc: "calls the Render function of the realm at PkgPath, with Path as its argument.",
+		Request: []field{
+			{"PkgPath", "string", "path of the realm"},
+			{"Path", "string", "argument of Render"},
+		},
+		Response: []field{{"Output", "string", "result of Render"}},
+		Data:     `[]byte(req.PkgPath + ":" + req.Path)`,
+		Decode:   "text",
+	},
+	{
+		Module: "VM", Name: "Funcs", Route: vm.QueryFuncs,
+		Doc:     "returns the signatures of the exported functions of the package at PkgPath.",
+		Request: []field{{"PkgPath", "string", "path of the package"}},
+		Alias:   "vm.FunctionSignatures",
+		Data:    `[]byte(req.PkgPath)`,
+		Decode:  "amino",
+	},
+	{
+		Module: "VM", Name: "Eval", Route: vm.QueryEval,
+		Doc: "evaluates Expr in the package at PkgPath, in read-only mode.",
+		Request: []field{
+			{"PkgPath", "string", "path of the package"},
+			{"Expr", "string", "expression to evaluate, e.g. a function call"},
+		},
+		Response: []field{{"Result", "string", "typed values of the result, one per line"}},
+		Data:     `[]byte(req.PkgPath + "." + req.Expr)`,
+		Decode:   "text",
+	},
+	{
+		Module: "VM", Name: "File", Route: vm.QueryFile,
+		Doc:      "returns the body of the file at Path, or the names of the files of the package at Path, one per line.",
+		Request:  []field{{"Path", "string", "path of a file, or of a package"}},
+		Response: []field{{"Body", "string", "body of the file, or names of the files"}},
+		Data:     `[]byte(req.Path)`,
+		Decode:   "text",
+	},
+	{
+		Module: "VM", Name: "Doc", Route: vm.QueryDoc,
+		Doc:     "returns the documentation of the package at PkgPath.",
+		Request: []field{{"PkgPath", "string", "path of the package"}},
+		Alias:   "doc.JSONDocumentation",
+		Data:    `[]byte(req.PkgPath)`,
+		Decode:  "amino",
+	},
+	{
+		Module: "VM", Name: "ABI", Route: vm.QueryABI,
+		Doc:     "returns the ABI of the package at PkgPath: its exported functions, with their parameters and documentation.",
+		Request: []field{{"PkgPath", "string", "path of the package"}},
+		Alias:   "vm.ABI",
+		Data:    `[]byte(req.PkgPath)`,
+		Decode:  "amino",
+	},
+	{
+		Module: "VM", Name: "Paths", Route: vm.QueryPaths,
+		Doc: "returns the paths of the packages matching Target.",
+		Request: []field{
+			{"Target", "string", "path prefix, or @username for the packages of a user"},
+			{"Limit", "int", "maximum number of paths, 0 is the node default"},
+		},
+		Response:   []field{{"Paths", "[]string", "paths of the packages"}},
+		PathSuffix: `limitQuery(req.Limit)`,
+		Data:       `[]byte(req.Target)`,
+		Decode:     "lines",
+	},
+	{
+		Module: "VM", Name: "Storage", Route: vm.QueryStorage,
+		Doc:     "returns the storage used by the realm at PkgPath, and the deposit paid for it.",
+		Request: []field{{"PkgPath", "string", "path of the realm"}},
+		Response: []field{
+			{"Storage", "int64", "storage used by the realm, in bytes"},
+			{"Deposit", "int64", "deposit paid for the storage"},
+		},
+		Data:   `[]byte(req.PkgPath)`,
+		Decode: "decodeStorage",
+	},
+	{
+		Module: "VM", Name: "Dependents", Route: vm.QueryDependents,
+		Doc: "returns the paths of the packages importing the package at PkgPath.",
+		Request: []field{
+			{"PkgPath", "string", "path of the package"},
+			{"Limit", "int", "maximum number of paths, 0 is the node default"},
+		},
+		Response:   []field{{"Paths", "[]string", "paths of the importing packages"}},
+		PathSuffix: `limitQuery(req.Limit)`,
+		Data:       `[]byte(req.PkgPath)`,
+		Decode:     "lines",
+	},
+	{
+		Module: "VM", Name: "Limits", Route: vm.QueryLimits,
+		Doc:    "returns the limits on the size of the transactions and of the packages.",
+		Alias:  "vm.Limits",
+		Decode: "amino",
+	},
+	{
+		Module: "VM", Name: "GasTable", Route: vm.QueryGasTable,
+		Doc:    "returns the gas table of the VM, with its version, overrides and prices.",
+		Alias:  "vm.GasTable",
+		Decode: "amino",
+	},
+
+	// auth
+	{
+		Module: "Auth", Name: "Account", Route: auth.QueryAccount,
+		Doc:        "returns the account at Address, or an error if it doesn't exist.",
+		Request:    []field{{"Address", "crypto.Address", "address of the account"}},
+		Alias:      "gnoland.GnoAccount",
+		PathSuffix: `"/" + req.Address.String()`,
+		Decode:     "decodeAccount",
+	},
+	{
+		Module: "Auth", Name: "Accounts", Route: auth.QueryAccount,
+		Doc:     "returns a page of the accounts matching the filters of Query.",
+		Request: []field{{"Query", "auth.AccountsQuery", "filters and page of the accounts"}},
+		Alias:   "auth.AccountsResult",
+		Data:    `amino.MustMarshalJSON(req.Query)`,
+		Decode:  "amino",
+	},
+	{
+		Module: "Auth", Name: "GasPrice", Route: auth.QueryGasPrice,
+		Doc:    "returns the gas price of the last block.",
+		Alias:  "std.GasPrice",
+		Decode: "amino",
+	},
+
+	// bank
+	{
+		Module: "Bank", Name: "Balance", Route: bank.QueryBalance,
+		Doc:        "returns the coins of the account at Address.",
+		Request:    []field{{"Address", "crypto.Address", "address of the account"}},
+		Alias:      "std.Coins",
+		PathSuffix: `"/" + req.Address.String()`,
+		Decode:     "amino",
+	},
+	{
+		Module: "Bank", Name: "ScheduledSend", Route: bank.QueryScheduledSend,
+		Doc:        "returns the pending scheduled send with the given ID.",
+		Request:    []field{{"ID", "uint64", "ID of the scheduled send"}},
+		Alias:      "bank.ScheduledSend",
+		PathSuffix: `"/" + strconv.FormatUint(req.ID, 10)`,
+		Decode:     "amino",
+	},
+	{
+		Module: "Bank", Name: "AddressOwner", Route: bank.QueryAddressOwner,
+		Doc:        "returns the owner of a derived address, like the path of the package of a realm address.",
+		Request:    []field{{"Address", "crypto.Address", "derived address"}},
+		Response:   []field{{"Owner", "string", "owner of the address, empty for the user accounts"}},
+		PathSuffix: `"/" + req.Address.String()`,
+		Decode:     "text",
+	},
+	{
+		Module: "Bank", Name: "Modules", Route: bank.QueryModules,
+		Doc:    "returns the module accounts, with their address and permissions.",
+		Alias:  "[]bank.ModuleAccount",
+		Decode: "amino",
+	},
+}
@@ -0,0 +1,83 @@
+package queryclient
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoland"
+	"github.com/gnolang/gno/gno.land/pkg/gnoland/ugnot"
+	"github.com/gnolang/gno/gno.land/pkg/integration"
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+func TestClient_Integration(t *testing.T) {
+	const pkgPath = "gno.land/r/tests/vm/deep/very/deep"
+
+	// Setup the node, with a realm
+	rootdir := gnoenv.RootDir()
+	config := integration.TestingMinimalNodeConfig(rootdir)
+	loader := integration.NewPkgsLoader()
+	examplesDir := filepath.Join(rootdir, "examples")
+	require.NoError(t, loader.LoadPackage(examplesDir, filepath.Join(examplesDir, pkgPath), ""))
+	privKey, err := integration.GeneratePrivKeyFromMnemonic(integration.DefaultAccount_Seed, "", 0, 0)
+	require.NoError(t, err)
+	meta, err := loader.GenerateTxs(privKey, std.NewFee(50000, std.MustParseCoin(ugnot.ValueString(1000000))), nil)
+	require.NoError(t, err)
+	state := config.Genesis.AppState.(gnoland.GnoGenesisState)
+	state.Txs = append(state.Txs, meta...)
+	config.Genesis.AppState = state
+
+	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
+	defer node.Stop()
+
+	rpcClient, err := rpcclient.NewHTTPClient(remoteAddr)
+	require.NoError(t, err)
+	client := New(rpcClient)
+	ctx := context.Background()
+
+	// vm
+	render, err := client.VMRender(ctx, VMRenderRequest{PkgPath: pkgPath, Path: "you"})
+	require.NoError(t, err)
+	assert.Equal(t, "hi you", render.Output)
+
+	funcs, err := client.VMFuncs(ctx, VMFuncsRequest{PkgPath: pkgPath})
+	require.NoError(t, err)
+	names := make([]string, len(funcs))
+	for i, f := range funcs {
+		names[i] = f.FuncName
+	}
+	assert.Contains(t, names, "Render")
+
+	eval, err := client.VMEval(ctx, VMEvalRequest{PkgPath: pkgPath, Expr: `Render("")`})
+	require.NoError(t, err)
+	assert.Equal(t, `("it works!" string)`, eval.Result)
+
+	paths, err := client.VMPaths(ctx, VMPathsRequest{Target: pkgPath})
+	require.NoError(t, err)
+	assert.Equal(t, []string{pkgPath}, paths.Paths)
+
+	_, err = client.VMLimits(ctx, VMLimitsRequest{})
+	require.NoError(t, err)
+
+	// auth
+	addr := crypto.MustAddressFromString(integration.DefaultAccount_Address)
+	acc, err := client.AuthAccount(ctx, AuthAccountRequest{Address: addr})
+	require.NoError(t, err)
+	assert.Equal(t, addr, acc.GetAddress())
+
+	_, err = client.AuthAccount(ctx, AuthAccountRequest{Address: crypto.AddressFromPreimage([]byte("unknown"))})
+	assert.ErrorIs(t, err, std.UnknownAddressError{})
+
+	// bank
+	coins, err := client.BankBalance(ctx, BankBalanceRequest{Address: addr})
+	require.NoError(t, err)
+	assert.True(t, coins.AmountOf(ugnot.Denom) > 0)
+}
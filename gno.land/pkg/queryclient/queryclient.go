@@ -0,0 +1,93 @@
+// Package queryclient is a typed client for the queries of the vm, auth and
+// bank modules of a gno.land node.
+//
+// Each query route has a method taking a request struct, which builds the
+// query path and data, and returning the decoded response. The methods and
+// their types are generated by genqueryclient from the query routes described
+// in genqueryclient/routes.go; run `go generate` after changing them.
+package queryclient
+
+//go:generate go run ./genqueryclient generated.go
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Querier is the part of the RPC client used to query the node, implemented
+// by [rpcclient.RPCClient] and [rpcclient.Local].
+type Querier interface {
+	ABCIQueryWithOptions(ctx context.Context, path string, data []byte, opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error)
+}
+
+// Client queries a gno.land node through an RPC client.
+type Client struct {
+	rpc    Querier
+	height int64
+}
+
+// New returns a client querying the latest state of the node, through rpc.
+func New(rpc Querier) *Client {
+	return &Client{rpc: rpc}
+}
+
+// AtHeight returns a copy of the client querying the state at the given
+// height; 0 is the latest height.
+func (c *Client) AtHeight(height int64) *Client {
+	return &Client{rpc: c.rpc, height: height}
+}
+
+// query runs the query with the given path and data, and returns the data of
+// the response, or its error.
+func (c *Client) query(ctx context.Context, path string, data []byte) ([]byte, error) {
+	qres, err := c.rpc.ABCIQueryWithOptions(ctx, path, data, rpcclient.ABCIQueryOptions{Height: c.height})
+	if err != nil {
+		return nil, fmt.Errorf("query %s: %w", path, err)
+	}
+	if qres.Response.Error != nil {
+		return nil, fmt.Errorf("query %s: %w", path, qres.Response.Error)
+	}
+	return qres.Response.Data, nil
+}
+
+// limitQuery returns the query string of the routes taking a limit.
+func limitQuery(limit int) string {
+	if limit <= 0 {
+		return ""
+	}
+	return "?limit=" + strconv.Itoa(limit)
+}
+
+// lines returns the lines of bz, or nil if it's empty.
+func lines(bz []byte) []string {
+	if len(bz) == 0 {
+		return nil
+	}
+	return strings.Split(string(bz), "\n")
+}
+
+// decodeStorage decodes the "storage: <storage>, deposit: <deposit>" data of
+// the vm/qstorage query.
+func decodeStorage(bz []byte, res *VMStorageResponse) error {
+	_, err := fmt.Sscanf(string(bz), "storage: %d, deposit: %d", &res.Storage, &res.Deposit)
+	if err != nil {
+		return fmt.Errorf("invalid storage %q: %w", bz, err)
+	}
+	return nil
+}
+
+// decodeAccount decodes the account of the auth/accounts query, which is null
+// for unknown addresses.
+func decodeAccount(bz []byte, res *AuthAccountResponse) error {
+	if len(bz) == 0 || string(bz) == "null" {
+		return std.ErrUnknownAddress("unknown address")
+	}
+	return amino.UnmarshalJSON(bz, res)
+}
@@ -0,0 +1,115 @@
+package queryclient
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// mockQuerier records the last query, and answers it with res.
+type mockQuerier struct {
+	path   string
+	data   []byte
+	height int64
+
+	res abci.ResponseQuery
+	err error
+}
+
+func (m *mockQuerier) ABCIQueryWithOptions(_ context.Context, path string, data []byte, opts rpcclient.ABCIQueryOptions) (*ctypes.ResultABCIQuery, error) {
+	m.path, m.data, m.height = path, data, opts.Height
+	if m.err != nil {
+		return nil, m.err
+	}
+	return &ctypes.ResultABCIQuery{Response: m.res}, nil
+}
+
+func TestClient_Request(t *testing.T) {
+	t.Parallel()
+
+	m := &mockQuerier{}
+	m.res.Data = []byte("# Hello")
+	c := New(m)
+
+	res, err := c.VMRender(context.Background(), VMRenderRequest{PkgPath: "gno.land/r/demo/home", Path: "page"})
+	require.NoError(t, err)
+	assert.Equal(t, "# Hello", res.Output)
+	assert.Equal(t, "vm/qrender", m.path)
+	assert.Equal(t, "gno.land/r/demo/home:page", string(m.data))
+	assert.Equal(t, int64(0), m.height)
+
+	// At a given height, with a path suffix
+	_, err = c.AtHeight(42).VMPaths(context.Background(), VMPathsRequest{Target: "@user", Limit: 10})
+	require.NoError(t, err)
+	assert.Equal(t, "vm/qpaths?limit=10", m.path)
+	assert.Equal(t, "@user", string(m.data))
+	assert.Equal(t, int64(42), m.height)
+
+	m.res.Data = []byte(`"10ugnot"`)
+	addr := crypto.AddressFromPreimage([]byte("addr"))
+	coins, err := c.BankBalance(context.Background(), BankBalanceRequest{Address: addr})
+	require.NoError(t, err)
+	assert.Equal(t, std.NewCoins(std.NewCoin("ugnot", 10)), coins)
+	assert.Equal(t, "bank/balances/"+addr.String(), m.path)
+	assert.Empty(t, m.data)
+}
+
+func TestClient_Response(t *testing.T) {
+	t.Parallel()
+
+	m := &mockQuerier{}
+	c := New(m)
+	ctx := context.Background()
+
+	m.res.Data = []byte("gno.land/r/a\ngno.land/r/b")
+	paths, err := c.VMDependents(ctx, VMDependentsRequest{PkgPath: "gno.land/p/c"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"gno.land/r/a", "gno.land/r/b"}, paths.Paths)
+
+	m.res.Data = nil
+	paths, err = c.VMDependents(ctx, VMDependentsRequest{PkgPath: "gno.land/p/c"})
+	require.NoError(t, err)
+	assert.Empty(t, paths.Paths)
+
+	m.res.Data = []byte("storage: 1234, deposit: 123400")
+	storage, err := c.VMStorage(ctx, VMStorageRequest{PkgPath: "gno.land/r/a"})
+	require.NoError(t, err)
+	assert.Equal(t, VMStorageResponse{Storage: 1234, Deposit: 123400}, storage)
+
+	m.res.Data = []byte(`{"max_tx_bytes":"1000","max_package_files":"2","max_package_bytes":"3","max_file_bytes":"4"}`)
+	limits, err := c.VMLimits(ctx, VMLimitsRequest{})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1000), limits.MaxTxBytes)
+	assert.Equal(t, int64(4), limits.MaxFileBytes)
+
+	m.res.Data = []byte("null")
+	_, err = c.AuthAccount(ctx, AuthAccountRequest{Address: crypto.AddressFromPreimage([]byte("addr"))})
+	assert.True(t, errors.Is(err, std.UnknownAddressError{}))
+}
+
+func TestClient_Error(t *testing.T) {
+	t.Parallel()
+
+	m := &mockQuerier{}
+	c := New(m)
+	ctx := context.Background()
+
+	m.err = errors.New("connection refused")
+	_, err := c.VMLimits(ctx, VMLimitsRequest{})
+	assert.ErrorIs(t, err, m.err)
+	assert.ErrorContains(t, err, "query vm/qlimits")
+
+	m.err = nil
+	m.res.Error = abci.ABCIErrorOrStringError(std.ErrUnknownRequest("unknown vm query endpoint"))
+	_, err = c.VMLimits(ctx, VMLimitsRequest{})
+	assert.True(t, errors.Is(err, std.UnknownRequestError{}))
+}
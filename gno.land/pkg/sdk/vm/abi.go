@@ -0,0 +1,161 @@
+package vm
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm/pkg/doc"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// ABI is the machine-readable public interface of a package: its exported
+// functions, with their parameters, results and documentation, so that client
+// code generators and wallets don't have to parse the Gno sources.
+type ABI struct {
+	PkgPath string    `json:"pkg_path"`
+	Funcs   []ABIFunc `json:"funcs"` // sorted by name
+}
+
+// ABIFunc is an exported function of a package.
+type ABIFunc struct {
+	Name string `json:"name"`
+	Doc  string `json:"doc"` // markdown
+	// Params are the parameters of the function, without the realm of the
+	// crossing functions, like the arguments of MsgCall.
+	Params  []ABIParam `json:"params"`
+	Results []ABIParam `json:"results"`
+	// Mutating is true for the crossing functions of realms, which are
+	// called with MsgCall and can modify the state of the realm; the other
+	// functions can only read it, and can be called with vm/qeval.
+	Mutating bool `json:"mutating"`
+}
+
+// ABIParam is a parameter or a result of an ABIFunc.
+type ABIParam struct {
+	Name     string `json:"name"`      // "_" if unnamed
+	Type     string `json:"type"`      // as declared, like "address"
+	BaseType string `json:"base_type"` // underlying type, like "string"
+}
+
+func (abi *ABI) JSON() string {
+	return string(amino.MustMarshalJSON(abi))
+}
+
+// abiKeyPrefix is the prefix of the keys of the ABIs of the packages added
+// with AddPackage, stored in the base store as "pkgabi:<pkgpath>".
+const abiKeyPrefix = "pkgabi:"
+
+func abiKey(pkgPath string) []byte {
+	return []byte(abiKeyPrefix + pkgPath)
+}
+
+// newABI returns the ABI of the package pv, whose sources are memPkg.
+func newABI(store gno.Store, memPkg *std.MemPackage, pv *gno.PackageValue) (*ABI, error) {
+	d, err := doc.NewDocumentableFromMemPkg(memPkg, false, "", "")
+	if err != nil {
+		return nil, err
+	}
+	jsonDoc, err := d.WriteJSONDocumentation(nil)
+	if err != nil {
+		return nil, err
+	}
+	docs := make(map[string]string, len(jsonDoc.Funcs))
+	for _, fn := range jsonDoc.Funcs {
+		if fn.Type == "" { // not a method
+			docs[fn.Name] = fn.Doc
+		}
+	}
+
+	realm := gno.IsRealmPath(memPkg.Path)
+	abi := &ABI{PkgPath: memPkg.Path, Funcs: []ABIFunc{}}
+	for _, tv := range pv.GetBlock(store).Values {
+		if tv.T == nil || tv.T.Kind() != gno.FuncKind {
+			continue
+		}
+		fv := tv.GetFunc()
+		fname := string(fv.Name)
+		if fv.IsMethod || fname == "" || strings.ToUpper(fname[:1]) != fname[:1] {
+			continue // must be an exported function
+		}
+		ft := fv.Type.(*gno.FuncType)
+		params := ft.Params
+		if ft.IsCrossing() {
+			params = params[1:]
+		}
+		abi.Funcs = append(abi.Funcs, ABIFunc{
+			Name:     fname,
+			Doc:      docs[fname],
+			Params:   newABIParams(params),
+			Results:  newABIParams(ft.Results),
+			Mutating: realm && ft.IsCrossing(),
+		})
+	}
+	slices.SortFunc(abi.Funcs, func(a, b ABIFunc) int {
+		return strings.Compare(a.Name, b.Name)
+	})
+	return abi, nil
+}
+
+func newABIParams(fields []gno.FieldType) []ABIParam {
+	params := make([]ABIParam, 0, len(fields))
+	for _, field := range fields {
+		name := string(field.Name)
+		if name == "" || name[0] == '.' { // unnamed results are renamed .res_N
+			name = "_"
+		}
+		params = append(params, ABIParam{
+			Name:     name,
+			Type:     abiTypeString(field.Type),
+			BaseType: abiTypeString(gno.BaseOf(field.Type)),
+		})
+	}
+	return params
+}
+
+// abiTypeString returns the string of t, with the declared types of the
+// universe block, like address, unqualified.
+func abiTypeString(t gno.Type) string {
+	return strings.ReplaceAll(t.String(), ".uverse.", "")
+}
+
+// storeABI stores the ABI of the package added from memPkg, for vm/qabi, and
+// charges the gas of writing it. The package is valid, so it isn't rejected
+// if its ABI can't be generated: the ABI is then not stored, and vm/qabi
+// tries to generate it again.
+func (vm *VMKeeper) storeABI(ctx sdk.Context, gnostore gno.Store, memPkg *std.MemPackage) {
+	pv := gnostore.GetPackage(memPkg.Path, false)
+	abi, err := newABI(gnostore, memPkg, pv)
+	if err != nil {
+		if logger := ctx.Logger(); logger != nil {
+			logger.Error("unable to generate the ABI", "pkgpath", memPkg.Path, "err", err)
+		}
+		return
+	}
+	ctx.GasStore(vm.baseKey).Set(abiKey(memPkg.Path), []byte(abi.JSON()))
+}
+
+// QueryABI returns the ABI of the package at pkgPath, as JSON. The ABI of the
+// packages added before their ABI was stored, like the standard libraries, is
+// generated on the fly.
+func (vm *VMKeeper) QueryABI(ctx sdk.Context, pkgPath string) (string, error) {
+	if bz := ctx.Store(vm.baseKey).Get(abiKey(pkgPath)); bz != nil {
+		return string(bz), nil
+	}
+
+	store := vm.newGnoTransactionStore(ctx) // throwaway (never committed)
+	memPkg := store.GetMemPackage(pkgPath)
+	pv := store.GetPackage(pkgPath, false)
+	if memPkg == nil || pv == nil {
+		return "", ErrInvalidPkgPath(fmt.Sprintf(
+			"package not found: %s", pkgPath))
+	}
+	abi, err := newABI(store, memPkg, pv)
+	if err != nil {
+		return "", err
+	}
+	return abi.JSON(), nil
+}
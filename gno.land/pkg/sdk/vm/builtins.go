@@ -4,9 +4,12 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
 )
 
 // ----------------------------------------
@@ -24,6 +27,18 @@ func NewSDKBanker(vmk *VMKeeper, ctx sdk.Context) *SDKBanker {
 	}
 }
 
+// CacheWrapCall implements gno.CallCacheWrapper, if the context of the banker
+// was made with withCallStore: the writes of a call crossing a realm
+// boundary, through the banker and the SDKParams sharing its context, are
+// then discarded if a panic leaves the call.
+func (bnk *SDKBanker) CacheWrapCall() (write, discard func()) {
+	cms, ok := bnk.ctx.MultiStore().(*callMultiStore)
+	if !ok {
+		return func() {}, func() {}
+	}
+	return cms.cacheWrapCall()
+}
+
 func (bnk *SDKBanker) GetCoins(b32addr crypto.Bech32Address) (dst std.Coins) {
 	addr := crypto.MustAddressFromString(string(b32addr))
 	coins := bnk.vmk.bank.GetCoins(bnk.ctx, addr)
@@ -45,7 +60,7 @@ func (bnk *SDKBanker) TotalCoin(denom string) int64 {
 
 func (bnk *SDKBanker) IssueCoin(b32addr crypto.Bech32Address, denom string, amount int64) {
 	addr := crypto.MustAddressFromString(string(b32addr))
-	_, err := bnk.vmk.bank.AddCoins(bnk.ctx, addr, std.Coins{std.Coin{Denom: denom, Amount: amount}})
+	_, err := bnk.vmk.bank.AddCoins(bank.WithJournalKind(bnk.ctx, bank.JournalKindMint), addr, std.Coins{std.Coin{Denom: denom, Amount: amount}})
 	if err != nil {
 		panic(err)
 	}
@@ -53,12 +68,90 @@ func (bnk *SDKBanker) IssueCoin(b32addr crypto.Bech32Address, denom string, amou
 
 func (bnk *SDKBanker) RemoveCoin(b32addr crypto.Bech32Address, denom string, amount int64) {
 	addr := crypto.MustAddressFromString(string(b32addr))
-	_, err := bnk.vmk.bank.SubtractCoins(bnk.ctx, addr, std.Coins{std.Coin{Denom: denom, Amount: amount}})
+	_, err := bnk.vmk.bank.SubtractCoins(bank.WithJournalKind(bnk.ctx, bank.JournalKindBurn), addr, std.Coins{std.Coin{Denom: denom, Amount: amount}})
 	if err != nil {
 		panic(err)
 	}
 }
 
+// allowanceKeyPrefix is the prefix of the keys of the allowances given with
+// Banker.Approve, stored as "allowance:<owner>:<spender>" in the iavl store,
+// so that they're part of the app hash like the balances they control.
+const allowanceKeyPrefix = "allowance:"
+
+func allowanceKey(owner, spender crypto.Bech32Address) []byte {
+	return []byte(allowanceKeyPrefix + string(owner) + ":" + string(spender))
+}
+
+func (bnk *SDKBanker) Approve(owner, spender crypto.Bech32Address, amt std.Coins) {
+	stor := bnk.ctx.GasStore(bnk.vmk.iavlKey)
+	if amt.IsZero() {
+		stor.Delete(allowanceKey(owner, spender))
+		return
+	}
+	stor.Set(allowanceKey(owner, spender), amino.MustMarshal(amt))
+}
+
+func (bnk *SDKBanker) Allowance(owner, spender crypto.Bech32Address) (allowance std.Coins) {
+	bz := bnk.ctx.GasStore(bnk.vmk.iavlKey).Get(allowanceKey(owner, spender))
+	if bz == nil {
+		return nil
+	}
+	amino.MustUnmarshal(bz, &allowance)
+	return allowance
+}
+
+// ----------------------------------------
+// callMultiStore
+
+// callMultiStore is the multistore of the context of the SDKBanker and the
+// SDKParams of a machine, which the calls crossing realm boundaries
+// cache-wrap: its stores are those of the current call.
+type callMultiStore struct {
+	stores []store.MultiStore // the last one is the current call's.
+}
+
+// withCallStore returns ctx with a callMultiStore, for the SDKBanker and the
+// SDKParams of a machine.
+func withCallStore(ctx sdk.Context) sdk.Context {
+	return ctx.WithMultiStore(&callMultiStore{
+		stores: []store.MultiStore{ctx.MultiStore()},
+	})
+}
+
+func (cms *callMultiStore) current() store.MultiStore {
+	return cms.stores[len(cms.stores)-1]
+}
+
+func (cms *callMultiStore) GetStore(key store.StoreKey) store.Store {
+	return cms.current().GetStore(key)
+}
+
+func (cms *callMultiStore) MultiCacheWrap() store.MultiStore {
+	return cms.current().MultiCacheWrap()
+}
+
+func (cms *callMultiStore) MultiWrite() {
+	panic("the writes of a callMultiStore are written by the calls")
+}
+
+func (cms *callMultiStore) cacheWrapCall() (write, discard func()) {
+	ms := cms.current().MultiCacheWrap()
+	cms.stores = append(cms.stores, ms)
+	depth := len(cms.stores)
+	discard = func() {
+		if len(cms.stores) != depth {
+			panic("unexpected call multistore")
+		}
+		cms.stores = cms.stores[:len(cms.stores)-1]
+	}
+	write = func() {
+		discard()
+		ms.MultiWrite()
+	}
+	return write, discard
+}
+
 // ----------------------------------------
 // SDKParams
 
@@ -66,6 +159,8 @@ func (bnk *SDKBanker) RemoveCoin(b32addr crypto.Bech32Address, denom string, amo
 // which is available as ExecContext.Params.
 // Access to SDKParams gives access to all parameters.
 // Users must write code to limit access as appropriate.
+// Its writes are cache-wrapped by the calls crossing realm boundaries with
+// those of the SDKBanker of the same context, see callMultiStore.
 
 type SDKParams struct {
 	pmk ParamsKeeperI
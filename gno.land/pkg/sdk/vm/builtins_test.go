@@ -3,6 +3,9 @@ package vm
 import (
 	"testing"
 
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
 	"github.com/stretchr/testify/require"
 )
 
@@ -65,3 +68,37 @@ func TestParamsKeeper(t *testing.T) {
 		})
 	}
 }
+
+func TestSDKBankerAllowance(t *testing.T) {
+	env := setupTestEnv()
+	bnk := NewSDKBanker(env.vmk, env.ctx)
+	owner := crypto.AddressFromPreimage([]byte("owner")).Bech32()
+	spender := crypto.AddressFromPreimage([]byte("spender")).Bech32()
+
+	require.Nil(t, bnk.Allowance(owner, spender))
+
+	amt := std.MustParseCoins("100ugnot,5foo")
+	bnk.Approve(owner, spender, amt)
+	require.Equal(t, amt, bnk.Allowance(owner, spender))
+	require.Nil(t, bnk.Allowance(spender, owner))
+
+	// Approving again replaces the allowance; an empty amount removes it.
+	bnk.Approve(owner, spender, std.MustParseCoins("10ugnot"))
+	require.Equal(t, std.MustParseCoins("10ugnot"), bnk.Allowance(owner, spender))
+	bnk.Approve(owner, spender, nil)
+	require.Nil(t, bnk.Allowance(owner, spender))
+	require.False(t, env.ctx.Store(env.vmk.iavlKey).Has(allowanceKey(owner, spender)))
+}
+
+func TestSDKBankerApproveGas(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx.WithGasMeter(store.NewInfiniteGasMeter())
+	bnk := NewSDKBanker(env.vmk, ctx)
+	owner := crypto.AddressFromPreimage([]byte("owner")).Bech32()
+	spender := crypto.AddressFromPreimage([]byte("spender")).Bech32()
+
+	// The allowance is written to the iavl store, and charged.
+	bnk.Approve(owner, spender, std.MustParseCoins("100ugnot"))
+	require.Positive(t, ctx.GasMeter().GasConsumed())
+	require.True(t, ctx.Store(env.vmk.iavlKey).Has(allowanceKey(owner, spender)))
+}
@@ -51,8 +51,8 @@ func _setupTestEnv(cacheStdlibs bool) testEnv {
 
 	prmk := pm.NewParamsKeeper(iavlCapKey)
 	acck := authm.NewAccountKeeper(iavlCapKey, prmk.ForModule(authm.ModuleName), std.ProtoBaseAccount)
-	bankk := bankm.NewBankKeeper(acck, prmk.ForModule(bankm.ModuleName))
-	vmk := NewVMKeeper(baseCapKey, iavlCapKey, acck, bankk, prmk)
+	bankk := bankm.NewBankKeeper(iavlCapKey, acck, prmk.ForModule(bankm.ModuleName))
+	vmk := NewVMKeeper(baseCapKey, iavlCapKey, iavlCapKey, acck, bankk, prmk)
 
 	prmk.Register(authm.ModuleName, acck)
 	prmk.Register(bankm.ModuleName, bankk)
@@ -24,6 +24,20 @@ func assertNoPlusPrefix(s string) {
 // NOTE: very important that there is no malleability.
 func convertArgToGno(arg string, argT gno.Type) (tv gno.TypedValue) {
 	tv.T = argT
+	if gno.IsAddressType(argT) {
+		// The empty string is the zero value of address; any other value
+		// must be a valid address in canonical form, so that addresses
+		// can be compared as strings.
+		if arg != "" {
+			if _, err := gno.ParseAddress(arg); err != nil {
+				panic(fmt.Sprintf(
+					"error parsing address %q: %v",
+					arg, err))
+			}
+		}
+		tv.SetString(gno.StringValue(arg))
+		return
+	}
 	switch bt := gno.BaseOf(argT).(type) {
 	case gno.PrimitiveType:
 		switch bt {
@@ -194,6 +208,27 @@ func convertArgToGno(arg string, argT gno.Type) (tv gno.TypedValue) {
 	}
 }
 
+// convertArgsToGno converts the arguments of a MsgCall to the types of params,
+// the parameters of the called function following the realm. A malformed
+// argument is returned as an InvalidArgumentError.
+func convertArgsToGno(args []string, params []gno.FieldType) (tvs []gno.TypedValue, err error) {
+	tvs = make([]gno.TypedValue, len(args))
+	for i, arg := range args {
+		func() {
+			defer func() {
+				if r := recover(); r != nil {
+					err = ErrInvalidArgument(fmt.Sprintf("argument %d (%s): %v", i, params[i].Name, r))
+				}
+			}()
+			tvs[i] = convertArgToGno(arg, params[i].Type)
+		}()
+		if err != nil {
+			return nil, err
+		}
+	}
+	return tvs, nil
+}
+
 func convertFloat(value string, precision int) float64 {
 	assertNoPlusPrefix(value)
 	dec, _, err := apd.NewFromString(value)
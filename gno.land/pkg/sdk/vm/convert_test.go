@@ -37,3 +37,31 @@ func TestConvertEmptyNumbers(t *testing.T) {
 		})
 	}
 }
+
+func TestConvertAddress(t *testing.T) {
+	const valid = "g1f4v282mwyhu29afke4vq5r2xzcm6z3ftnugcnv"
+	argT := gnolang.UverseNode().GetSlot(nil, "address", false).GetType()
+
+	tv := convertArgToGno(valid, argT)
+	assert.Equal(t, valid, tv.GetString())
+	tv = convertArgToGno("", argT)
+	assert.Equal(t, "", tv.GetString())
+
+	tests := []struct {
+		arg         string
+		expectedErr string
+	}{
+		{"g1f4v282mwyhu29afke4vq5r2xzcm6z3ftnugcnw", `error parsing address "g1f4v282mwyhu29afke4vq5r2xzcm6z3ftnugcnw": invalid checksum (expected (bech32=nugcnv, bech32m=nugcnvxqc5kw), got nugcnw)`},
+		{"G1F4V282MWYHU29AFKE4VQ5R2XZCM6Z3FTNUGCNV", `error parsing address "G1F4V282MWYHU29AFKE4VQ5R2XZCM6Z3FTNUGCNV": address is not in canonical form, expected ` + valid},
+		{"cosmos1f4v282mwyhu29afke4vq5r2xzcm6z3ftqq5mjg", `error parsing address "cosmos1f4v282mwyhu29afke4vq5r2xzcm6z3ftqq5mjg": invalid Bech32 prefix; expected g, got cosmos`},
+		{"g1qypqxpq9qcrssp837rp", `error parsing address "g1qypqxpq9qcrssp837rp": invalid address length; expected 20 bytes, got 8`},
+	}
+	for _, tt := range tests {
+		t.Run(tt.arg, func(t *testing.T) {
+			run := func() {
+				_ = convertArgToGno(tt.arg, argT)
+			}
+			assert.PanicsWithValue(t, tt.expectedErr, run)
+		})
+	}
+}
@@ -0,0 +1,144 @@
+package vm
+
+import (
+	"fmt"
+	"strings"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/stdlibs"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// deployers restrict who can add packages under some package paths, for
+// permissioned chains where only some accounts can deploy code, while anyone
+// can call it. Each rule is "<path prefix>=<deployer>", set by the vm param
+// deployers; a package path is restricted if it's under the prefix of one of
+// the rules, and can then only be added by the deployers of those rules.
+//
+// A deployer is either an address, or the path of a realm, which is then
+// asked with IsAuthorizedDeployer(addr address, pkgPath string) bool, so that
+// governance can manage the deployers of a prefix.
+type deployers []deployerRule
+
+type deployerRule struct {
+	prefix    string
+	address   crypto.Address // if set, the allowed deployer.
+	realmPath string         // otherwise, the realm allowing deployers.
+}
+
+// parseDeployers parses the rules of the deployers param.
+func parseDeployers(rules []string) (deployers, error) {
+	ds := make(deployers, 0, len(rules))
+	for _, rule := range rules {
+		prefix, deployer, ok := strings.Cut(rule, "=")
+		prefix = strings.TrimSuffix(prefix, "/")
+		if !ok || prefix == "" || deployer == "" {
+			return nil, fmt.Errorf("invalid deployer %q, must be <path prefix>=<address or realm path>", rule)
+		}
+		dr := deployerRule{prefix: prefix}
+		if gno.IsRealmPath(deployer) {
+			dr.realmPath = deployer
+		} else {
+			addr, err := crypto.AddressFromBech32(deployer)
+			if err != nil {
+				return nil, fmt.Errorf("invalid deployer %q: %w", rule, err)
+			}
+			dr.address = addr
+		}
+		ds = append(ds, dr)
+	}
+	return ds, nil
+}
+
+// match returns the rules whose prefix pkgPath is, or is under.
+func (ds deployers) match(pkgPath string) (rules []deployerRule) {
+	for _, dr := range ds {
+		if pkgPath == dr.prefix || strings.HasPrefix(pkgPath, dr.prefix+"/") {
+			rules = append(rules, dr)
+		}
+	}
+	return rules
+}
+
+// getDeployersParam returns the deployers set in the vm params.
+func (vm *VMKeeper) getDeployersParam(ctx sdk.Context) (deployers, error) {
+	var rules []string
+	vm.prmk.GetStrings(ctx, deployersParamPath, &rules)
+	ds, err := parseDeployers(rules)
+	if err != nil {
+		return nil, fmt.Errorf("invalid deployers param: %w", err)
+	}
+	return ds, nil
+}
+
+// checkDeployPermission returns an error if pkgPath is restricted by the
+// deployers param, and creator isn't one of its deployers.
+func (vm *VMKeeper) checkDeployPermission(ctx sdk.Context, creator crypto.Address, pkgPath string) error {
+	ds, err := vm.getDeployersParam(ctx)
+	if err != nil {
+		return err
+	}
+	rules := ds.match(pkgPath)
+	if len(rules) == 0 {
+		return nil
+	}
+	for _, dr := range rules {
+		if dr.realmPath == "" {
+			if dr.address == creator {
+				return nil
+			}
+		} else if vm.isAuthorizedDeployer(ctx, dr.realmPath, creator, pkgPath) {
+			return nil
+		}
+	}
+	return ErrUnauthorizedUser(fmt.Sprintf(
+		"%s is not an allowed deployer of %s", creator, pkgPath))
+}
+
+// isAuthorizedDeployer calls realmPath.IsAuthorizedDeployer for creator and
+// pkgPath. A realm which doesn't exist authorizes no one.
+func (vm *VMKeeper) isAuthorizedDeployer(ctx sdk.Context, realmPath string, creator crypto.Address, pkgPath string) bool {
+	store := vm.getGnoTransactionStore(ctx)
+	if store.GetPackage(realmPath, false) == nil {
+		return false
+	}
+
+	bctx := withCallStore(ctx)
+	msgCtx := stdlibs.ExecContext{
+		ChainID:         ctx.ChainID(),
+		ChainDomain:     vm.getChainDomainParam(ctx),
+		Height:          ctx.BlockHeight(),
+		Timestamp:       ctx.BlockTime().Unix(),
+		TimestampNano:   int64(ctx.BlockTime().Nanosecond()),
+		OriginCaller:    creator.Bech32(),
+		OriginSendSpent: new(std.Coins),
+		Banker:          NewSDKBanker(vm, bctx),
+		Params:          NewSDKParams(vm.prmk, bctx),
+		EventManager:    ctx.EventManager(),
+	}
+	m := gno.NewMachineWithOptions(
+		gno.MachineOptions{
+			PkgPath:  "",
+			Output:   vm.Output,
+			Store:    store,
+			Context:  msgCtx,
+			Alloc:    store.GetAllocator(),
+			GasMeter: ctx.GasMeter(),
+		})
+	defer m.Release()
+
+	mpv := gno.NewPackageNode("main", "main", nil).NewPackage(m.Alloc)
+	m.SetActivePackage(mpv)
+	m.RunDeclaration(gno.ImportD("deployers", realmPath))
+	ret := m.Eval(gno.Call(
+		gno.Sel(gno.Nx("deployers"), "IsAuthorizedDeployer"),
+		gno.Str(creator.String()),
+		gno.Str(pkgPath),
+	))
+	if len(ret) != 1 || ret[0].T.Kind() != gno.BoolKind {
+		panic("IsAuthorizedDeployer: invalid response, must be a bool")
+	}
+	return ret[0].GetBool()
+}
@@ -3,6 +3,7 @@ package vm
 import (
 	"strings"
 
+	"github.com/gnolang/gno/tm2/pkg/errcode"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"go.uber.org/multierr"
 )
@@ -23,6 +24,8 @@ type (
 	UnauthorizedUserError struct{ abciError }
 	InvalidPackageError   struct{ abciError }
 	InvalidFileError      struct{ abciError }
+	PackageTooLargeError  struct{ abciError }
+	InvalidArgumentError  struct{ abciError }
 	TypeCheckError        struct {
 		abciError
 		Errors []string `json:"errors"`
@@ -37,6 +40,8 @@ func (e InvalidFileError) Error() string      { return "file is not available" }
 func (e InvalidExprError) Error() string      { return "invalid expression" }
 func (e UnauthorizedUserError) Error() string { return "unauthorized user" }
 func (e InvalidPackageError) Error() string   { return "invalid package" }
+func (e PackageTooLargeError) Error() string  { return "package too large" }
+func (e InvalidArgumentError) Error() string  { return "invalid argument" }
 func (e TypeCheckError) Error() string {
 	var bld strings.Builder
 	bld.WriteString("invalid gno package; type check errors:\n")
@@ -44,6 +49,22 @@ func (e TypeCheckError) Error() string {
 	return bld.String()
 }
 
+// Codespace holds the codes of the vm errors, returned in ABCI responses.
+// NOTE: codes are stable; never change or reuse them.
+var Codespace = errcode.Register(errcode.NewCodespace("vm").WithErrors(
+	InvalidPkgPathError{}, 1,
+	NoRenderDeclError{}, 2,
+	PkgExistError{}, 3,
+	InvalidStmtError{}, 4,
+	InvalidExprError{}, 5,
+	UnauthorizedUserError{}, 6,
+	InvalidPackageError{}, 7,
+	InvalidFileError{}, 8,
+	PackageTooLargeError{}, 9,
+	TypeCheckError{}, 10,
+	InvalidArgumentError{}, 11,
+))
+
 func ErrPkgAlreadyExists(msg string) error {
 	return errors.Wrap(PkgExistError{}, msg)
 }
@@ -72,6 +93,14 @@ func ErrInvalidPackage(msg string) error {
 	return errors.Wrap(InvalidPackageError{}, msg)
 }
 
+func ErrPackageTooLarge(msg string) error {
+	return errors.Wrap(PackageTooLargeError{}, msg)
+}
+
+func ErrInvalidArgument(msg string) error {
+	return errors.Wrap(InvalidArgumentError{}, msg)
+}
+
 func ErrTypeCheck(err error) error {
 	var tce TypeCheckError
 	errs := multierr.Errors(err)
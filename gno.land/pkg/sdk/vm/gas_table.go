@@ -0,0 +1,49 @@
+package vm
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+)
+
+// GasTable is the gas table of the VM set by the params: the version of its
+// base prices, the overrides of the gas_prices param, and the resulting
+// prices of the opcodes, store operations and native functions.
+type GasTable struct {
+	Version   string   `json:"version"`
+	Overrides []string `json:"overrides"`
+	Prices    []string `json:"prices"` // "name=price", sorted by name.
+}
+
+// JSON returns the gas table as amino JSON, as returned by the vm/qgastable
+// query.
+func (gt GasTable) JSON() string {
+	return string(amino.MustMarshalJSON(gt))
+}
+
+// QueryGasTable returns the gas table set in the params, for the clients to
+// estimate the gas of their transactions, and the governance to tune it.
+func (vm *VMKeeper) QueryGasTable(ctx sdk.Context) (GasTable, error) {
+	var p Params
+	vm.prmk.GetString(ctx, gasTableVersionParamPath, &p.GasTableVersion)
+	vm.prmk.GetStrings(ctx, gasPricesParamPath, &p.GasPrices)
+	gt, err := p.GasTable()
+	if err != nil {
+		return GasTable{}, err
+	}
+	return GasTable{
+		Version:   gt.Version,
+		Overrides: p.GasPrices,
+		Prices:    gt.Prices(),
+	}, nil
+}
+
+// SetGasTable sets the version and the overrides of the gas table of the VM,
+// used from the next transaction. The overrides are of the form "name=price",
+// like the gas_prices param; it returns an error if they, or the version, are
+// invalid.
+func (vm *VMKeeper) SetGasTable(ctx sdk.Context, version string, overrides []string) error {
+	params := vm.GetParams(ctx)
+	params.GasTableVersion = version
+	params.GasPrices = overrides
+	return vm.SetParams(ctx, params)
+}
@@ -71,13 +71,18 @@ func (vh vmHandler) handleMsgRun(ctx sdk.Context, msg MsgRun) (res sdk.Result) {
 
 // query paths
 const (
-	QueryRender  = "qrender"
-	QueryFuncs   = "qfuncs"
-	QueryEval    = "qeval"
-	QueryFile    = "qfile"
-	QueryDoc     = "qdoc"
-	QueryPaths   = "qpaths"
-	QueryStorage = "qstorage"
+	QueryRender     = "qrender"
+	QueryFuncs      = "qfuncs"
+	QueryEval       = "qeval"
+	QueryFile       = "qfile"
+	QueryDoc        = "qdoc"
+	QueryABI        = "qabi"
+	QueryPaths      = "qpaths"
+	QueryStorage    = "qstorage"
+	QueryDependents = "qdependents"
+	QueryLimits     = "qlimits"
+	QueryGasTable   = "qgastable"
+	QueryStats      = "qstats"
 )
 
 func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
@@ -97,10 +102,20 @@ func (vh vmHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.Resp
 		res = vh.queryFile(ctx, req)
 	case QueryDoc:
 		res = vh.queryDoc(ctx, req)
+	case QueryABI:
+		res = vh.queryABI(ctx, req)
 	case QueryPaths:
 		res = vh.queryPaths(ctx, req)
 	case QueryStorage:
 		res = vh.queryStorage(ctx, req)
+	case QueryDependents:
+		res = vh.queryDependents(ctx, req)
+	case QueryLimits:
+		res = vh.queryLimits(ctx, req)
+	case QueryGasTable:
+		res = vh.queryGasTable(ctx, req)
+	case QueryStats:
+		res = vh.queryStats(ctx, req)
 	default:
 		return sdk.ABCIResponseQueryFromError(
 			std.ErrUnknownRequest(fmt.Sprintf(
@@ -182,6 +197,41 @@ func (vh vmHandler) queryPaths(ctx sdk.Context, req abci.RequestQuery) (res abci
 	return
 }
 
+// queryDependents returns the paths of the packages importing the package
+// whose path is the request data, one per line.
+func (vh vmHandler) queryDependents(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	const defaultLimit = 1_000
+	const maxLimit = 10_000
+
+	pkgPath := string(req.Data)
+
+	var query string
+	if i := strings.IndexByte(req.Path, '?'); i >= 0 {
+		query = req.Path[i+1:]
+	}
+
+	params, _ := url.ParseQuery(query)
+
+	// Get limit param, if any
+	limit := defaultLimit // default
+	if l := params.Get("limit"); len(l) > 0 {
+		var err error
+		if limit, err = strconv.Atoi(l); err != nil {
+			return sdk.ABCIResponseQueryFromError(fmt.Errorf("invalid limit argument"))
+		}
+
+		limit = min(limit, maxLimit) // cap to maxLimit
+	}
+
+	paths, err := vh.vm.QueryDependents(ctx, pkgPath, limit)
+	if err != nil {
+		return sdk.ABCIResponseQueryFromError(err)
+	}
+
+	res.Data = []byte(strings.Join(paths, "\n"))
+	return
+}
+
 // queryEval evaluates any expression in readonly mode and returns the results.
 func (vh vmHandler) queryEval(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
 	pkgPath, expr := parseQueryEvalData(string(req.Data))
@@ -243,6 +293,18 @@ func (vh vmHandler) queryDoc(ctx sdk.Context, req abci.RequestQuery) (res abci.R
 	return
 }
 
+// queryABI returns the ABI of a package, as JSON.
+func (vh vmHandler) queryABI(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	pkgPath := string(req.Data)
+	abi, err := vh.vm.QueryABI(ctx, pkgPath)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(err)
+		return
+	}
+	res.Data = []byte(abi)
+	return
+}
+
 // queryStorage returns the storage size and deposit for a realm
 func (vh vmHandler) queryStorage(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
 	pkgpath := string(req.Data)
@@ -255,6 +317,55 @@ func (vh vmHandler) queryStorage(ctx sdk.Context, req abci.RequestQuery) (res ab
 	return
 }
 
+// queryLimits returns the limits on the size of the transactions and of the
+// packages as JSON.
+func (vh vmHandler) queryLimits(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	res.Data = []byte(vh.vm.QueryLimits(ctx).JSON())
+	return
+}
+
+// queryGasTable returns the gas table of the VM as JSON.
+func (vh vmHandler) queryGasTable(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	gt, err := vh.vm.QueryGasTable(ctx)
+	if err != nil {
+		return sdk.ABCIResponseQueryFromError(err)
+	}
+	res.Data = []byte(gt.JSON())
+	return
+}
+
+// queryStats returns the call counters of the realm whose path is the
+// request data as JSON, with those of the last days (7 by default).
+func (vh vmHandler) queryStats(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	pkgPath := string(req.Data)
+
+	var query string
+	if i := strings.IndexByte(req.Path, '?'); i >= 0 {
+		query = req.Path[i+1:]
+	}
+
+	params, _ := url.ParseQuery(query)
+
+	// Get days param, if any
+	days := statsDaysDefault // default
+	if d := params.Get("days"); len(d) > 0 {
+		var err error
+		if days, err = strconv.Atoi(d); err != nil {
+			return sdk.ABCIResponseQueryFromError(fmt.Errorf("invalid days argument"))
+		}
+
+		days = min(days, statsDaysMax) // cap to statsDaysMax
+	}
+
+	stats, err := vh.vm.QueryStats(ctx, pkgPath, days)
+	if err != nil {
+		return sdk.ABCIResponseQueryFromError(err)
+	}
+
+	res.Data = []byte(stats.JSON())
+	return
+}
+
 // ----------------------------------------
 // misc
 
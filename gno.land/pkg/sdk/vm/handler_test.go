@@ -6,10 +6,12 @@ import (
 
 	"github.com/gnolang/gno/gnovm/pkg/doc"
 	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 func Test_parseQueryEvalData(t *testing.T) {
@@ -474,3 +476,186 @@ func Hello(msg string) (res string) { res = prefix+" "+msg; return }
 		})
 	}
 }
+
+func TestVmHandlerQuery_Dependents(t *testing.T) {
+	tt := []struct {
+		path               string
+		input              []byte
+		expectedResult     string
+		expectedErrorMatch string
+	}{
+		// valid queries
+		{path: "vm/qdependents", input: []byte(`gno.land/p/demo/lib`), expectedResult: "gno.land/r/demo/alpha\ngno.land/r/demo/beta"},
+		{path: "vm/qdependents?limit=1", input: []byte(`gno.land/p/demo/lib`), expectedResult: "gno.land/r/demo/alpha"},
+		{path: "vm/qdependents", input: []byte(`strings`), expectedResult: "gno.land/p/demo/lib"},
+		{path: "vm/qdependents", input: []byte(`gno.land/r/demo/alpha`), expectedResult: ""},
+		{path: "vm/qdependents", input: []byte(`gno.land/p/demo`), expectedResult: ""}, // not a prefix match
+		{path: "vm/qdependents?limit=x", input: []byte(`gno.land/p/demo/lib`), expectedErrorMatch: `invalid limit argument`},
+	}
+
+	for _, tc := range tt {
+		name := tc.path + ":" + string(tc.input)
+		t.Run(name, func(t *testing.T) {
+			env := setupTestEnv()
+			ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+			vmHandler := env.vmh
+
+			// Give "addr1" some gnots.
+			addr := crypto.AddressFromPreimage([]byte("addr1"))
+			acc := env.acck.NewAccountWithAddress(ctx, addr)
+			env.acck.SetAccount(ctx, acc)
+			env.bankk.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+			// Create test packages; the imports of test files aren't indexed.
+			pkgs := []struct {
+				path  string
+				files []*std.MemFile
+			}{
+				{"gno.land/p/demo/lib", []*std.MemFile{
+					{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest("gno.land/p/demo/lib")},
+					{Name: "lib.gno", Body: "package lib\n\nimport \"strings\"\n\nfunc Upper(s string) string { return strings.ToUpper(s) }\n"},
+				}},
+				{"gno.land/r/demo/beta", []*std.MemFile{
+					{Name: "beta.gno", Body: "package beta\n\nimport \"gno.land/p/demo/lib\"\n\nfunc Beta() string { return lib.Upper(\"beta\") }\n"},
+					{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest("gno.land/r/demo/beta")},
+				}},
+				{"gno.land/r/demo/alpha", []*std.MemFile{
+					{Name: "alpha.gno", Body: "package alpha\n\nimport \"gno.land/p/demo/lib\"\n\nfunc Alpha() string { return lib.Upper(\"alpha\") }\n"},
+					{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest("gno.land/r/demo/alpha")},
+				}},
+				{"gno.land/r/demo/gamma", []*std.MemFile{
+					{Name: "gamma.gno", Body: "package gamma\n"},
+					{Name: "gamma_test.gno", Body: "package gamma\n\nimport (\n\t\"testing\"\n\n\t\"gno.land/p/demo/lib\"\n)\n\nfunc TestGamma(t *testing.T) { lib.Upper(\"gamma\") }\n"},
+					{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest("gno.land/r/demo/gamma")},
+				}},
+			}
+			for _, pkg := range pkgs {
+				msg := NewMsgAddPackage(addr, pkg.path, pkg.files)
+				err := env.vmk.AddPackage(ctx, msg)
+				assert.NoError(t, err)
+			}
+
+			req := abci.RequestQuery{
+				Path: tc.path,
+				Data: tc.input,
+			}
+
+			res := vmHandler.Query(env.ctx, req)
+			if tc.expectedErrorMatch == "" {
+				assert.True(t, res.IsOK(), "should not have error")
+				assert.Equal(t, tc.expectedResult, string(res.Data))
+			} else {
+				assert.False(t, res.IsOK(), "should have an error")
+				errmsg := res.Error.Error()
+				assert.Regexp(t, tc.expectedErrorMatch, errmsg)
+			}
+		})
+	}
+}
+
+func TestVmHandlerQuery_ABI(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, std.MustParseCoins("10000000ugnot"))
+
+	const pkgPath = "gno.land/r/hello"
+	files := []*std.MemFile{
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(pkgPath)},
+		{Name: "hello.gno", Body: `package hello
+
+type myStruct struct{a int}
+
+func (ms myStruct) Foo() string { return "myStruct.Foo" }
+
+var greeting = "Hello"
+
+// SetGreeting sets the greeting.
+func SetGreeting(cur realm, g string) { greeting = g }
+
+// Hello greets name.
+func Hello(name address) (res string, _ int) { return greeting + " " + string(name), 1 }
+
+func helper() {}
+`},
+	}
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files))
+	require.NoError(t, err)
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	res := env.vmh.Query(env.ctx, abci.RequestQuery{Path: "vm/qabi", Data: []byte(pkgPath)})
+	require.True(t, res.IsOK(), "should not have error")
+	assert.JSONEq(t, `{
+		"pkg_path": "gno.land/r/hello",
+		"funcs": [
+			{
+				"name": "Hello",
+				"doc": "Hello greets name.\n",
+				"params": [{"name": "name", "type": "address", "base_type": "string"}],
+				"results": [
+					{"name": "res", "type": "string", "base_type": "string"},
+					{"name": "_", "type": "int", "base_type": "int"}
+				],
+				"mutating": false
+			},
+			{
+				"name": "SetGreeting",
+				"doc": "SetGreeting sets the greeting.\n",
+				"params": [{"name": "g", "type": "string", "base_type": "string"}],
+				"results": [],
+				"mutating": true
+			}
+		]
+	}`, string(res.Data))
+
+	// The ABI of the standard libraries is generated on the fly.
+	res = env.vmh.Query(env.ctx, abci.RequestQuery{Path: "vm/qabi", Data: []byte("strings")})
+	require.True(t, res.IsOK(), "should not have error")
+	var abi ABI
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &abi))
+	assert.Equal(t, "strings", abi.PkgPath)
+	assert.NotEmpty(t, abi.Funcs)
+
+	res = env.vmh.Query(env.ctx, abci.RequestQuery{Path: "vm/qabi", Data: []byte("gno.land/r/missing")})
+	assert.False(t, res.IsOK(), "should have an error")
+	assert.Regexp(t, "invalid package path", res.Error.Error())
+
+	// An ABI which can't be generated isn't stored, without failing.
+	ctx = env.vmk.MakeGnoTransactionStore(env.ctx)
+	broken := &std.MemPackage{
+		Name:  "broken",
+		Path:  "gno.land/r/broken",
+		Files: []*std.MemFile{{Name: "broken.gno", Body: "package broken\n\nfunc {"}},
+	}
+	env.vmk.storeABI(ctx, env.vmk.getGnoTransactionStore(ctx), broken)
+	assert.Nil(t, ctx.Store(env.vmk.baseKey).Get(abiKey(broken.Path)))
+}
+
+func TestVmHandlerQuery_Limits(t *testing.T) {
+	env := setupTestEnv()
+	env.prmk.SetInt64(env.ctx, "auth:p:max_tx_bytes", 1000)
+	env.prmk.SetInt64(env.ctx, "vm:p:max_file_bytes", 0)
+
+	res := env.vmh.Query(env.ctx, abci.RequestQuery{Path: "vm/qlimits"})
+	assert.True(t, res.IsOK(), "should not have error")
+	assert.JSONEq(t,
+		`{"max_tx_bytes":"1000","max_package_files":"500","max_package_bytes":"800000","max_file_bytes":"0"}`,
+		string(res.Data))
+}
+
+func TestVmHandlerQuery_Stats(t *testing.T) {
+	env := setupTestEnv()
+
+	res := env.vmh.Query(env.ctx, abci.RequestQuery{Path: "vm/qstats?days=1", Data: []byte("gno.land/r/test")})
+	assert.True(t, res.IsOK(), "should not have error")
+	assert.JSONEq(t,
+		`{"pkg_path":"gno.land/r/test","calls":"0","funcs":[],"days":[{"day":"0001-01-01","calls":"0","unique_callers":"0"}]}`,
+		string(res.Data))
+
+	res = env.vmh.Query(env.ctx, abci.RequestQuery{Path: "vm/qstats?days=x", Data: []byte("gno.land/r/test")})
+	assert.False(t, res.IsOK(), "should have an error")
+}
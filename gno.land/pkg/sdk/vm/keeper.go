@@ -5,6 +5,8 @@ package vm
 import (
 	"bytes"
 	"context"
+	"crypto/sha256"
+	"encoding/hex"
 	goerrors "errors"
 	"fmt"
 	"io"
@@ -24,6 +26,7 @@ import (
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/gnovm/pkg/packages"
 	"github.com/gnolang/gno/gnovm/stdlibs"
 	"github.com/gnolang/gno/gnovm/stdlibs/chain"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
@@ -32,6 +35,7 @@ import (
 	osm "github.com/gnolang/gno/tm2/pkg/os"
 	"github.com/gnolang/gno/tm2/pkg/overflow"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
 	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
@@ -69,8 +73,9 @@ type VMKeeper struct {
 	// Needs to be explicitly set, like in the case of gnodev.
 	Output io.Writer
 
-	baseKey store.StoreKey
-	iavlKey store.StoreKey
+	baseKey store.StoreKey // objects, types and nodes
+	iavlKey store.StoreKey // escaped object hashes
+	codeKey store.StoreKey // mempackages
 	acck    AccountKeeperI
 	bank    BankKeeperI
 	prmk    ParamsKeeperI
@@ -83,11 +88,14 @@ type VMKeeper struct {
 }
 
 // NewVMKeeper returns a new VMKeeper.
+// The package code is kept in the codeKey store, apart from the escaped object
+// hashes of the iavlKey store; both may be the same key.
 // NOTE: prmk must be the root ParamsKeeper such that
 // ExecContext.Params may set any module's parameter.
 func NewVMKeeper(
 	baseKey store.StoreKey,
 	iavlKey store.StoreKey,
+	codeKey store.StoreKey,
 	acck AccountKeeperI,
 	bank BankKeeperI,
 	prmk ParamsKeeperI,
@@ -95,6 +103,7 @@ func NewVMKeeper(
 	vmk := &VMKeeper{
 		baseKey:        baseKey,
 		iavlKey:        iavlKey,
+		codeKey:        codeKey,
 		acck:           acck,
 		bank:           bank,
 		prmk:           prmk,
@@ -120,6 +129,7 @@ func (vm *VMKeeper) Initialize(
 
 	alloc := gno.NewAllocator(maxAllocTx)
 	vm.gnoStore = gno.NewStore(alloc, baseStore, iavlStore)
+	vm.gnoStore.SetCodeStore(ms.GetStore(vm.codeKey))
 	vm.gnoStore.SetNativeResolver(stdlibs.NativeResolver)
 
 	if vm.gnoStore.NumMemPackages() > 0 {
@@ -289,7 +299,19 @@ func (vm *VMKeeper) newGnoTransactionStore(ctx sdk.Context) gno.TransactionStore
 	iavl := ctx.Store(vm.iavlKey)
 	gasMeter := ctx.GasMeter()
 
-	return vm.gnoStore.BeginTransaction(base, iavl, gasMeter)
+	ts := vm.gnoStore.BeginTransaction(base, iavl, gasMeter)
+	ts.SetCodeStore(ctx.Store(vm.codeKey))
+	gt, err := vm.getGasTableParam(ctx)
+	if err != nil {
+		// Params are validated when set, so this shouldn't happen; don't
+		// halt the chain over it.
+		if logger := ctx.Logger(); logger != nil {
+			logger.Error("using the default gas table", "err", err)
+		}
+		gt = gno.DefaultGasTable()
+	}
+	ts.SetGasTable(gt)
+	return ts
 }
 
 func (vm *VMKeeper) MakeGnoTransactionStore(ctx sdk.Context) sdk.Context {
@@ -357,17 +379,19 @@ func (vm *VMKeeper) checkNamespacePermission(ctx sdk.Context, creator crypto.Add
 	}
 
 	// Parse and run the files, construct *PV.
+	bctx := withCallStore(ctx)
 	msgCtx := stdlibs.ExecContext{
 		ChainID:         ctx.ChainID(),
 		ChainDomain:     chainDomain,
 		Height:          ctx.BlockHeight(),
 		Timestamp:       ctx.BlockTime().Unix(),
+		TimestampNano:   int64(ctx.BlockTime().Nanosecond()),
 		OriginCaller:    creator.Bech32(),
 		OriginSendSpent: new(std.Coins),
 		// XXX: should we remove the banker ?
-		Banker:      NewSDKBanker(vm, ctx),
-		Params:      NewSDKParams(vm.prmk, ctx),
-		EventLogger: ctx.EventLogger(),
+		Banker:       NewSDKBanker(vm, bctx),
+		Params:       NewSDKParams(vm.prmk, bctx),
+		EventManager: ctx.EventManager(),
 	}
 
 	m := gno.NewMachineWithOptions(
@@ -433,6 +457,9 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 	if creatorAcc == nil {
 		return std.ErrUnknownAddress(fmt.Sprintf("account %s does not exist, it must receive coins to be created", creator))
 	}
+	if err := vm.getLimitsParam(ctx).checkPackage(memPkg); err != nil {
+		return err
+	}
 	if err := gno.ValidateMemPackageAny(msg.Package); err != nil {
 		return ErrInvalidPkgPath(err.Error())
 	}
@@ -452,6 +479,9 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 	if _, ok := gno.IsGnoRunPath(pkgPath); ok {
 		return ErrInvalidPkgPath("reserved package name: " + pkgPath)
 	}
+	if err := vm.checkDeployPermission(ctx, creator, pkgPath); err != nil {
+		return err
+	}
 	opts := gno.TypeCheckOptions{
 		Getter:     gnostore,
 		TestGetter: vm.testStdlibCache.memPackageGetter(gnostore),
@@ -505,21 +535,26 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 	if err != nil {
 		return err
 	}
+	// Tell the funds of the package apart from those of user accounts.
+	vm.bank.SetAddressOwner(ctx, pkgAddr, pkgPath)
 
 	// Parse and run the files, construct *PV.
+	bctx := withCallStore(ctx)
 	msgCtx := stdlibs.ExecContext{
 		ChainID:         ctx.ChainID(),
 		ChainDomain:     chainDomain,
 		Height:          ctx.BlockHeight(),
 		Timestamp:       ctx.BlockTime().Unix(),
+		TimestampNano:   int64(ctx.BlockTime().Nanosecond()),
 		OriginCaller:    creator.Bech32(),
 		OriginSend:      send,
 		OriginSendSpent: new(std.Coins),
-		Banker:          NewSDKBanker(vm, ctx),
-		Params:          NewSDKParams(vm.prmk, ctx),
-		EventLogger:     ctx.EventLogger(),
+		Banker:          NewSDKBanker(vm, bctx),
+		Params:          NewSDKParams(vm.prmk, bctx),
+		EventManager:    ctx.EventManager(),
 	}
 	// Parse and run the files, construct *PV.
+	tracer, endCalls := newTracer(ctx)
 	m2 := gno.NewMachineWithOptions(
 		gno.MachineOptions{
 			PkgPath:  "",
@@ -528,8 +563,10 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 			Alloc:    gnostore.GetAllocator(),
 			Context:  msgCtx,
 			GasMeter: ctx.GasMeter(),
+			Tracer:   tracer,
 		})
 	defer m2.Release()
+	defer endCalls()
 	defer doRecover(m2, &err)
 	params := vm.GetParams(ctx)
 	m2.RunMemPackage(memPkg, true)
@@ -540,6 +577,12 @@ func (vm *VMKeeper) AddPackage(ctx sdk.Context, msg MsgAddPackage) (err error) {
 	if err != nil {
 		return err
 	}
+	// Index the imports of the package, for vm/qdependents.
+	if err := vm.indexDependents(ctx, memPkg); err != nil {
+		return err
+	}
+	// Store the ABI of the package, for vm/qabi.
+	vm.storeABI(ctx, gnostore, memPkg)
 	// Log the telemetry
 	logTelemetry(
 		m2.GasMeter.GasConsumed(),
@@ -599,9 +642,11 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 	if nargs := len(msg.Args) + 1; nargs != len(ft.Params) { // NOTE: nargs = `cur` + user's len(args)
 		panic(fmt.Sprintf("wrong number of arguments in call to %s: want %d got %d", fnc, len(ft.Params), nargs))
 	}
-	for i, arg := range msg.Args {
-		argType := ft.Params[i+1].Type
-		atv := convertArgToGno(arg, argType)
+	atvs, err := convertArgsToGno(msg.Args, ft.Params[1:])
+	if err != nil {
+		return "", err
+	}
+	for i, atv := range atvs {
 		cx.Args[i+1] = &gno.ConstExpr{
 			TypedValue: atv,
 		}
@@ -610,19 +655,22 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 	// NOTE: if this is too expensive,
 	// could it be safely partially memoized?
 	chainDomain := vm.getChainDomainParam(ctx)
+	bctx := withCallStore(ctx)
 	msgCtx := stdlibs.ExecContext{
 		ChainID:         ctx.ChainID(),
 		ChainDomain:     chainDomain,
 		Height:          ctx.BlockHeight(),
 		Timestamp:       ctx.BlockTime().Unix(),
+		TimestampNano:   int64(ctx.BlockTime().Nanosecond()),
 		OriginCaller:    caller.Bech32(),
 		OriginSend:      send,
 		OriginSendSpent: new(std.Coins),
-		Banker:          NewSDKBanker(vm, ctx),
-		Params:          NewSDKParams(vm.prmk, ctx),
-		EventLogger:     ctx.EventLogger(),
+		Banker:          NewSDKBanker(vm, bctx),
+		Params:          NewSDKParams(vm.prmk, bctx),
+		EventManager:    ctx.EventManager(),
 	}
 	// Construct machine and evaluate.
+	tracer, endCalls := newTracer(ctx)
 	m := gno.NewMachineWithOptions(
 		gno.MachineOptions{
 			PkgPath:  "",
@@ -631,8 +679,10 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 			Context:  msgCtx,
 			Alloc:    gnostore.GetAllocator(),
 			GasMeter: ctx.GasMeter(),
+			Tracer:   tracer,
 		})
 	defer m.Release()
+	defer endCalls()
 	m.SetActivePackage(mpv)
 	defer doRecover(m, &err)
 	rtvs := m.Eval(xn)
@@ -649,6 +699,7 @@ func (vm *VMKeeper) Call(ctx sdk.Context, msg MsgCall) (res string, err error) {
 	if err != nil {
 		return "", err
 	}
+	vm.recordCall(ctx, pkgPath, fnc, caller)
 	// Log the telemetry
 	logTelemetry(
 		m.GasMeter.GasConsumed(),
@@ -755,17 +806,19 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 	}
 
 	// Parse and run the files, construct *PV.
+	bctx := withCallStore(ctx)
 	msgCtx := stdlibs.ExecContext{
 		ChainID:         ctx.ChainID(),
 		ChainDomain:     chainDomain,
 		Height:          ctx.BlockHeight(),
 		Timestamp:       ctx.BlockTime().Unix(),
+		TimestampNano:   int64(ctx.BlockTime().Nanosecond()),
 		OriginCaller:    caller.Bech32(),
 		OriginSend:      send,
 		OriginSendSpent: new(std.Coins),
-		Banker:          NewSDKBanker(vm, ctx),
-		Params:          NewSDKParams(vm.prmk, ctx),
-		EventLogger:     ctx.EventLogger(),
+		Banker:          NewSDKBanker(vm, bctx),
+		Params:          NewSDKParams(vm.prmk, bctx),
+		EventManager:    ctx.EventManager(),
 	}
 
 	buf := new(bytes.Buffer)
@@ -778,6 +831,7 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 		if vm.Output != nil {
 			output = io.MultiWriter(buf, vm.Output)
 		}
+		tracer, endCalls := newTracer(ctx)
 		m := gno.NewMachineWithOptions(
 			gno.MachineOptions{
 				PkgPath:  "",
@@ -786,8 +840,10 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 				Alloc:    alloc,
 				Context:  msgCtx,
 				GasMeter: ctx.GasMeter(),
+				Tracer:   tracer,
 			})
 		defer m.Release()
+		defer endCalls()
 		defer doRecover(m, &err)
 
 		_, pv := m.RunMemPackage(memPkg, false)
@@ -798,6 +854,7 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 		return
 	}
 
+	tracer, endCalls := newTracer(ctx)
 	m2 := gno.NewMachineWithOptions(
 		gno.MachineOptions{
 			PkgPath:  "",
@@ -806,8 +863,10 @@ func (vm *VMKeeper) Run(ctx sdk.Context, msg MsgRun) (res string, err error) {
 			Alloc:    alloc,
 			Context:  msgCtx,
 			GasMeter: ctx.GasMeter(),
+			Tracer:   tracer,
 		})
 	defer m2.Release()
+	defer endCalls()
 	m2.SetActivePackage(pv)
 	defer doRecover(m2, &err)
 	m2.RunMain()
@@ -892,6 +951,51 @@ func joinIters[T any](seqs ...iter.Seq[T]) iter.Seq[T] {
 	}
 }
 
+// dependentsKeyPrefix is the prefix of the keys of the reverse import index,
+// stored in the base store: for each package imported by a package deployed
+// with AddPackage, there is a "pkgdeps:<imported>:<importer>" key. As package
+// paths can't contain a colon, the importers of a package are the keys
+// prefixed by "pkgdeps:<imported>:".
+const dependentsKeyPrefix = "pkgdeps:"
+
+func dependentsKey(pkgPath, importer string) []byte {
+	return []byte(dependentsKeyPrefix + pkgPath + ":" + importer)
+}
+
+// indexDependents adds the package to the reverse import index of the
+// packages imported by its source files. Its entries are charged like the
+// other writes to the store, so that imports don't grow the index for free.
+func (vm *VMKeeper) indexDependents(ctx sdk.Context, memPkg *std.MemPackage) error {
+	imports, err := packages.Imports(memPkg, nil)
+	if err != nil {
+		return ErrInvalidPackage(err.Error())
+	}
+	base := ctx.GasStore(vm.baseKey)
+	for _, imp := range imports[packages.FileKindPackageSource] {
+		base.Set(dependentsKey(imp.PkgPath, memPkg.Path), []byte{})
+	}
+	return nil
+}
+
+// QueryDependents returns the paths of the packages importing the package at
+// pkgPath, up to limit, in lexicographic order. Only the packages added
+// with AddPackage are indexed, and their test files are ignored.
+func (vm *VMKeeper) QueryDependents(ctx sdk.Context, pkgPath string, limit int) ([]string, error) {
+	if limit < 0 {
+		return nil, errors.New("cannot have negative limit value")
+	}
+
+	prefix := dependentsKey(pkgPath, "")
+	it := store.PrefixIterator(ctx.Store(vm.baseKey), prefix)
+	defer it.Close()
+
+	paths := []string{}
+	for ; it.Valid() && len(paths) < limit; it.Next() {
+		paths = append(paths, string(it.Key()[len(prefix):]))
+	}
+	return paths, nil
+}
+
 // like slices.Collect, but limits the slice size to the given limit.
 func collectWithLimit[T any](seq iter.Seq[T], limit int) []T {
 	s := []T{}
@@ -1014,17 +1118,19 @@ func (vm *VMKeeper) queryEvalInternal(ctx sdk.Context, pkgPath string, expr stri
 	}
 	// Construct new machine.
 	chainDomain := vm.getChainDomainParam(ctx)
+	bctx := withCallStore(ctx)
 	msgCtx := stdlibs.ExecContext{
-		ChainID:     ctx.ChainID(),
-		ChainDomain: chainDomain,
-		Height:      ctx.BlockHeight(),
-		Timestamp:   ctx.BlockTime().Unix(),
+		ChainID:       ctx.ChainID(),
+		ChainDomain:   chainDomain,
+		Height:        ctx.BlockHeight(),
+		Timestamp:     ctx.BlockTime().Unix(),
+		TimestampNano: int64(ctx.BlockTime().Nanosecond()),
 		// OrigCaller:    caller,
 		// OrigSend:      send,
 		// OrigSendSpent: nil,
-		Banker:      NewSDKBanker(vm, ctx), // safe as long as ctx is a fork to be discarded.
-		Params:      NewSDKParams(vm.prmk, ctx),
-		EventLogger: ctx.EventLogger(),
+		Banker:       NewSDKBanker(vm, bctx), // safe as long as ctx is a fork to be discarded.
+		Params:       NewSDKParams(vm.prmk, bctx),
+		EventManager: ctx.EventManager(),
 	}
 	m := gno.NewMachineWithOptions(
 		gno.MachineOptions{
@@ -1082,6 +1188,26 @@ func (vm *VMKeeper) QueryDoc(ctx sdk.Context, pkgPath string) (*doc.JSONDocument
 	return d.WriteJSONDocumentation(nil)
 }
 
+// QueryStdlibHash returns the hex-encoded SHA-256 hash of the sources of the
+// standard libraries loaded in the store, in their initialization order, so
+// that clients can tell which version of the standard libraries the chain runs.
+func (vm *VMKeeper) QueryStdlibHash(ctx sdk.Context) string {
+	store := vm.newGnoTransactionStore(ctx) // throwaway (never committed)
+
+	h := sha256.New()
+	for _, pkgPath := range stdlibs.InitOrder() {
+		memPkg := store.GetMemPackage(pkgPath)
+		if memPkg == nil {
+			continue
+		}
+		fmt.Fprintf(h, "%s\n", pkgPath)
+		for _, mfile := range memPkg.Files {
+			fmt.Fprintf(h, "%s\n%d\n%s", mfile.Name, len(mfile.Body), mfile.Body)
+		}
+	}
+	return hex.EncodeToString(h.Sum(nil))
+}
+
 // QueryStorage returns storage and deposit for a realm.
 func (vm *VMKeeper) QueryStorage(ctx sdk.Context, pkgPath string) (string, error) {
 	store := vm.newGnoTransactionStore(ctx) // throwaway (never committed)
@@ -1152,7 +1278,7 @@ func (vm *VMKeeper) processStorageDeposit(ctx sdk.Context, caller crypto.Address
 				FeeDelta:   d,
 				PkgPath:    rlmPath,
 			}
-			ctx.EventLogger().EmitEvent(evt)
+			ctx.EventManager().EmitEvent(evt)
 		} else {
 			// release storage used and return deposit
 			released := -diff
@@ -1188,7 +1314,7 @@ func (vm *VMKeeper) processStorageDeposit(ctx sdk.Context, caller crypto.Address
 				PkgPath:        rlmPath,
 				RefundWithheld: isRestricted,
 			}
-			ctx.EventLogger().EmitEvent(evt)
+			ctx.EventManager().EmitEvent(evt)
 		}
 		gnostore.SetPackageRealm(rlm)
 	}
@@ -1202,7 +1328,7 @@ func (vm *VMKeeper) lockStorageDeposit(ctx sdk.Context, caller crypto.Address, r
 	storageDepositAddr := gno.DeriveStorageDepositCryptoAddr(rlm.Path)
 
 	d := std.Coins{std.Coin{Denom: ugnot.Denom, Amount: requiredDeposit}}
-	err := vm.bank.SendCoinsUnrestricted(ctx, caller, storageDepositAddr, d)
+	err := vm.bank.SendCoinsUnrestricted(bank.WithJournalKind(ctx, bank.JournalKindEscrow), caller, storageDepositAddr, d)
 	if err != nil {
 		return fmt.Errorf("unable to transfer deposit %s, %w", rlm.Path, err)
 	}
@@ -1217,7 +1343,7 @@ func (vm *VMKeeper) refundStorageDeposit(ctx sdk.Context, refundReceiver crypto.
 	storageDepositAddr := gno.DeriveStorageDepositCryptoAddr(rlm.Path)
 	d := std.Coins{std.Coin{Denom: ugnot.Denom, Amount: depositUnlocked}}
 
-	err := vm.bank.SendCoinsUnrestricted(ctx, storageDepositAddr, refundReceiver, d)
+	err := vm.bank.SendCoinsUnrestricted(bank.WithJournalKind(ctx, bank.JournalKindRelease), storageDepositAddr, refundReceiver, d)
 	if err != nil {
 		return fmt.Errorf("unable to return deposit %s, %w", rlm.Path, err)
 	}
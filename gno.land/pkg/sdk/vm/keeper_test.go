@@ -9,6 +9,7 @@ import (
 	"runtime"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -20,6 +21,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
 	"github.com/gnolang/gno/tm2/pkg/store/types"
@@ -121,6 +123,126 @@ func Echo(cur realm) string {
 	assert.Nil(t, memFile)
 }
 
+func TestVMKeeperAddPackage_Limits(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, initialBalance)
+
+	env.prmk.SetInt64(ctx, "vm:p:max_package_files", 3)
+	env.prmk.SetInt64(ctx, "vm:p:max_package_bytes", 200)
+	env.prmk.SetInt64(ctx, "vm:p:max_file_bytes", 100)
+
+	const pkgPath = "gno.land/r/test"
+	gnomod := &std.MemFile{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(pkgPath)}
+	tests := []struct {
+		name  string
+		files []*std.MemFile
+		err   string
+	}{
+		{"too many files", []*std.MemFile{
+			{Name: "a.gno", Body: "package test\n"},
+			{Name: "b.gno", Body: "package test\n"},
+			{Name: "c.gno", Body: "package test\n"},
+			gnomod,
+		}, "package gno.land/r/test has 4 files, the maximum is 3"},
+		{"file too large", []*std.MemFile{
+			{Name: "a.gno", Body: "package test\n\n// " + strings.Repeat("a", 100) + "\n"},
+			gnomod,
+		}, "file a.gno of package gno.land/r/test is 118 bytes, the maximum is 100"},
+		{"package too large", []*std.MemFile{
+			{Name: "a.gno", Body: "package test\n\n// " + strings.Repeat("a", 80) + "\n"},
+			{Name: "b.gno", Body: "package test\n\n// " + strings.Repeat("b", 80) + "\n"},
+			gnomod,
+		}, fmt.Sprintf("package gno.land/r/test is %d bytes, the maximum is 200", len(gnomod.Body)+2*98)},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, tc.files))
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, PackageTooLargeError{}))
+			assert.Contains(t, fmt.Sprintf("%+v", err), tc.err)
+			assert.Nil(t, env.vmk.getGnoTransactionStore(ctx).GetPackage(pkgPath, false))
+		})
+	}
+
+	// Within the limits.
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, []*std.MemFile{
+		{Name: "a.gno", Body: "package test\n"},
+		gnomod,
+	}))
+	require.NoError(t, err)
+}
+
+func TestVMKeeperAddPackage_Deployers(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	// Give the accounts some gnots.
+	newAccount := func(name string) crypto.Address {
+		addr := crypto.AddressFromPreimage([]byte(name))
+		env.acck.SetAccount(ctx, env.acck.NewAccountWithAddress(ctx, addr))
+		env.bankk.SetCoins(ctx, addr, initialBalance)
+		return addr
+	}
+	admin, member, other := newAccount("admin"), newAccount("member"), newAccount("other")
+	addPackage := func(creator crypto.Address, pkgPath string) error {
+		return env.vmk.AddPackage(ctx, NewMsgAddPackage(creator, pkgPath, []*std.MemFile{
+			{Name: "a.gno", Body: "package " + path.Base(pkgPath) + "\n"},
+			{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(pkgPath)},
+		}))
+	}
+
+	// The governance realm authorizes member.
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(admin, "gno.land/r/gov", []*std.MemFile{
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest("gno.land/r/gov")},
+		{Name: "gov.gno", Body: `package gov
+
+func IsAuthorizedDeployer(addr address, pkgPath string) bool {
+	return addr == "` + member.String() + `"
+}`},
+	}))
+	require.NoError(t, err)
+
+	env.prmk.SetStrings(ctx, "vm:p:deployers", []string{
+		"gno.land/r/acme=" + admin.String(),
+		"gno.land/r/acme/=gno.land/r/gov",
+		"gno.land/p/acme=gno.land/r/missing",
+	})
+
+	tests := []struct {
+		name    string
+		creator crypto.Address
+		pkgPath string
+		allowed bool
+	}{
+		{"allowed address", admin, "gno.land/r/acme/alpha", true},
+		{"authorized by realm", member, "gno.land/r/acme/beta", true},
+		{"unauthorized", other, "gno.land/r/acme/gamma", false},
+		{"prefix itself", other, "gno.land/r/acme", false},
+		{"missing realm", admin, "gno.land/p/acme/delta", false},
+		{"unrestricted path", other, "gno.land/r/acmex", true},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			err := addPackage(tc.creator, tc.pkgPath)
+			if tc.allowed {
+				require.NoError(t, err)
+				return
+			}
+			require.Error(t, err)
+			assert.True(t, errors.Is(err, UnauthorizedUserError{}))
+			assert.Contains(t, fmt.Sprintf("%+v", err),
+				fmt.Sprintf("%s is not an allowed deployer of %s", tc.creator, tc.pkgPath))
+			assert.Nil(t, env.vmk.getGnoTransactionStore(ctx).GetPackage(tc.pkgPath, false))
+		})
+	}
+}
+
 func TestVMKeeperAddPackage_DraftPackage(t *testing.T) {
 	env := setupTestEnv()
 	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
@@ -637,6 +759,40 @@ func main() {
 	assert.Equal(t, "hello world!\n", res)
 }
 
+func TestVMKeeperRunBlockTime(t *testing.T) {
+	env := setupTestEnv()
+	blockTime := time.Date(2025, 3, 10, 12, 30, 15, 123456789, time.UTC)
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 7, Time: blockTime})
+	ctx = env.vmk.MakeGnoTransactionStore(ctx)
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+
+	const pkgPath = "gno.land/r/test"
+	files := []*std.MemFile{
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(pkgPath)},
+		{Name: "script.gno", Body: `
+package main
+
+import (
+	"chain/runtime"
+	"time"
+)
+
+func main() {
+	now := time.Now()
+	println(now.Format(time.RFC3339Nano), runtime.ChainHeight())
+	println(now.Add(36 * time.Hour).Sub(now), now.Equal(time.Now()))
+}
+`},
+	}
+
+	res, err := env.vmk.Run(ctx, NewMsgRun(addr, std.Coins{}, files))
+	require.NoError(t, err)
+	assert.Equal(t, "2025-03-10T12:30:15.123456789Z 7\n36h0m0s true\n", res)
+}
+
 // Call Run with stdlibs.
 func TestVMKeeperRunImportStdlibs(t *testing.T) {
 	env := setupTestEnv()
@@ -782,6 +938,53 @@ func Echo(cur realm, msg string) string { // XXX remove crossing call ?
 	)
 }
 
+func TestVMKeeperCallAddressArg(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	// Give "addr1" some gnots.
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, initialBalance)
+
+	// Create test package.
+	const pkgPath = "gno.land/r/test"
+	files := []*std.MemFile{
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(pkgPath)},
+		{
+			Name: "test.gno",
+			Body: `package test
+
+func Check(cur realm, to address) (string, int) {
+	if to == "" {
+		return "zero", 0
+	}
+	return to.String(), len(to.Bytes())
+}`,
+		},
+	}
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files))
+	require.NoError(t, err)
+
+	res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Check", []string{addr.String()}))
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("(\"%s\" string)\n(20 int)\n\n", addr), res)
+
+	res, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Check", []string{""}))
+	require.NoError(t, err)
+	assert.Equal(t, "(\"zero\" string)\n(0 int)\n\n", res)
+
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Check", []string{strings.ToUpper(addr.String())}))
+	require.Error(t, err)
+	assert.True(t, errors.Is(err, InvalidArgumentError{}), "unexpected error: %v", err)
+	assert.Contains(t, fmt.Sprintf("%+v", err), "argument 0 (to): error parsing address")
+	assert.Contains(t, fmt.Sprintf("%+v", err), "address is not in canonical form")
+
+	_, err = env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Check", []string{"g1notanaddress"}))
+	assert.True(t, errors.Is(err, InvalidArgumentError{}), "unexpected error: %v", err)
+}
+
 func TestVMKeeperReinitialize(t *testing.T) {
 	env := setupTestEnv()
 	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
@@ -1111,3 +1314,274 @@ func UpdateAll(cur realm) {
 	// All runs produced identical results - this is expected with the fix applied
 	t.Logf("SUCCESS: All %d runs produced identical results, confirming deterministic behavior", numRuns)
 }
+
+func TestVMKeeperCall_Tracer(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, initialBalance)
+
+	const pkgPath = "gno.land/r/test"
+	files := []*std.MemFile{
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(pkgPath)},
+		{Name: "test.gno", Body: `
+package test
+
+var count int
+
+func Outer(cur realm) int {
+	return Inner(cross) + helper()
+}
+
+func Inner(cur realm) int {
+	count++
+	return count
+}
+
+func helper() int {
+	return 1
+}`},
+	}
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, pkgPath, files))
+	require.NoError(t, err)
+
+	tracer := sdk.NewTracer()
+	ctx = ctx.WithTracer(tracer)
+	res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, "Outer", nil))
+	require.NoError(t, err)
+	assert.Equal(t, "(2 int)\n\n", res)
+
+	// the call to helper doesn't change realm, and is not traced.
+	calls := tracer.Calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, pkgPath+".Outer", calls[0].Name)
+	assert.Equal(t, pkgPath+".Inner", calls[1].Name)
+	for i, call := range calls {
+		assert.Equal(t, i, call.Depth)
+		assert.Equal(t, pkgPath, call.Realm)
+		assert.Greater(t, call.GasUsed, int64(0))
+	}
+	assert.Greater(t, calls[0].GasUsed, calls[1].GasUsed)
+	assert.Equal(t, 0, tracer.Depth())
+}
+
+func TestVMKeeperCall_Stats(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+	ctx = ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 1, Time: time.Date(2025, 3, 10, 12, 0, 0, 0, time.UTC)})
+
+	addr1 := crypto.AddressFromPreimage([]byte("addr1"))
+	addr2 := crypto.AddressFromPreimage([]byte("addr2"))
+	for _, addr := range []crypto.Address{addr1, addr2} {
+		acc := env.acck.NewAccountWithAddress(ctx, addr)
+		env.acck.SetAccount(ctx, acc)
+		env.bankk.SetCoins(ctx, addr, initialBalance)
+	}
+
+	const pkgPath = "gno.land/r/test"
+	files := []*std.MemFile{
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(pkgPath)},
+		{Name: "test.gno", Body: `
+package test
+
+var count int
+
+func Incr(cur realm) int {
+	count++
+	return count
+}
+
+func Fail(cur realm) {
+	panic("fail")
+}`},
+	}
+	err := env.vmk.AddPackage(ctx, NewMsgAddPackage(addr1, pkgPath, files))
+	require.NoError(t, err)
+
+	call := func(addr crypto.Address, fn string) {
+		t.Helper()
+		_, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, fn, nil))
+		if fn == "Fail" {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+
+	// The calls aren't counted by default.
+	call(addr1, "Incr")
+	stats, err := env.vmk.QueryStats(ctx, pkgPath, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(0), stats.Calls)
+
+	env.prmk.SetBool(ctx, "vm:p:call_stats", true)
+	call(addr1, "Incr")
+	call(addr1, "Incr")
+	call(addr2, "Incr")
+	call(addr2, "Fail") // failed calls aren't counted.
+
+	// The next day.
+	ctx = ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 2, Time: time.Date(2025, 3, 11, 1, 0, 0, 0, time.UTC)})
+	call(addr2, "Incr")
+
+	stats, err = env.vmk.QueryStats(ctx, pkgPath, 3)
+	require.NoError(t, err)
+	assert.Equal(t, CallStats{
+		PkgPath: pkgPath,
+		Calls:   4,
+		Funcs:   []FuncCallStats{{Name: "Incr", Calls: 4}},
+		Days: []DayCallStats{
+			{Day: "2025-03-11", Calls: 1, UniqueCallers: 1},
+			{Day: "2025-03-10", Calls: 3, UniqueCallers: 2},
+			{Day: "2025-03-09"},
+		},
+	}, stats)
+
+	// The day buckets older than statsDaysMax are pruned on the first call
+	// of a day, and the counters are metered.
+	ctx = ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 3, Time: time.Date(2025, 6, 9, 1, 0, 0, 0, time.UTC)})
+	base := ctx.Store(env.vmk.baseKey)
+	assert.True(t, base.Has(statsKey(pkgPath, "day", statsDay(time.Date(2025, 3, 10, 0, 0, 0, 0, time.UTC)), "calls")))
+	gasBefore := ctx.GasMeter().GasConsumed()
+	env.vmk.recordCall(ctx, pkgPath, "Incr", addr1)
+	assert.Greater(t, ctx.GasMeter().GasConsumed(), gasBefore)
+	it := base.Iterator(statsKey(pkgPath, "day", ""), statsKey(pkgPath, "day", statsDay(ctx.BlockTime())))
+	assert.False(t, it.Valid(), "old day buckets not pruned")
+	it.Close()
+	stats, err = env.vmk.QueryStats(ctx, pkgPath, 1)
+	require.NoError(t, err)
+	assert.Equal(t, uint64(5), stats.Calls)
+	assert.Equal(t, []DayCallStats{{Day: "2025-06-09", Calls: 1, UniqueCallers: 1}}, stats.Days)
+}
+
+func TestVMKeeperCall_TxObjectCache(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	acc := env.acck.NewAccountWithAddress(ctx, addr)
+	env.acck.SetAccount(ctx, acc)
+	env.bankk.SetCoins(ctx, addr, initialBalance)
+
+	// A boards-style realm, whose posts are read by another realm.
+	const boardsPath = "gno.land/r/test/boards"
+	boardsFiles := []*std.MemFile{
+		{Name: "boards.gno", Body: `
+package boards
+
+import "strconv"
+
+type Post struct {
+	ID    int
+	Title string
+	Body  string
+}
+
+var posts []*Post
+
+func init() {
+	for i := 0; i < 100; i++ {
+		posts = append(posts, &Post{ID: i, Title: "post " + strconv.Itoa(i), Body: "body"})
+	}
+}
+
+func AddPost(cur realm, title string) {
+	posts = append(posts, &Post{ID: len(posts), Title: title, Body: "body"})
+}
+
+func Titles() string {
+	s := ""
+	for _, p := range posts {
+		s += p.Title + "\n"
+	}
+	return s
+}`},
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(boardsPath)},
+	}
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, boardsPath, boardsFiles)))
+
+	const feedPath = "gno.land/r/test/feed"
+	feedFiles := []*std.MemFile{
+		{Name: "feed.gno", Body: `
+package feed
+
+import "gno.land/r/test/boards"
+
+func Feed(cur realm) int {
+	return len(boards.Titles())
+}`},
+		{Name: "gnomod.toml", Body: gnolang.GenGnoModLatest(feedPath)},
+	}
+	require.NoError(t, env.vmk.AddPackage(ctx, NewMsgAddPackage(addr, feedPath, feedFiles)))
+
+	// call returns the gas used by the message, out of the gas of the
+	// transaction, which the gno store also consumes.
+	call := func(ctx sdk.Context, pkgPath, fn string, args ...string) (res string, gas int64) {
+		t.Helper()
+		before := ctx.GasMeter().GasConsumed()
+		res, err := env.vmk.Call(ctx, NewMsgCall(addr, nil, pkgPath, fn, args))
+		require.NoError(t, err)
+		return res, ctx.GasMeter().GasConsumed() - before
+	}
+
+	env.vmk.CommitGnoTransactionStore(ctx)
+
+	// The messages of a transaction read, modify and read again the posts.
+	// The objects kept across the messages are charged like when they're
+	// loaded from the store, which is what happens when they're dropped.
+	run := func(keep bool) (res []string, gas []int64) {
+		ctx, _ := env.ctx.CacheContext()
+		ctx = env.vmk.MakeGnoTransactionStore(ctx.WithGasMeter(types.NewInfiniteGasMeter()))
+		for _, msg := range [][]string{
+			{feedPath, "Feed"},
+			{feedPath, "Feed"},
+			{boardsPath, "AddPost", "new post"},
+			{feedPath, "Feed"},
+			{feedPath, "Feed"},
+		} {
+			if !keep {
+				ctx.Value(vmkContextKeyStore).(gnolang.TransactionStore).ResetObjectCache()
+			}
+			r, g := call(ctx, msg[0], msg[1], msg[2:]...)
+			res = append(res, r)
+			gas = append(gas, g)
+		}
+		return res, gas
+	}
+	res1, gas1 := run(true)
+	res2, gas2 := run(false)
+	assert.Equal(t, res2, res1)
+	assert.Equal(t, gas2, gas1)
+	assert.Equal(t, res1[0], res1[1])
+	assert.NotEqual(t, res1[1], res1[3])
+	assert.Equal(t, res1[3], res1[4])
+}
+
+func TestVMKeeperIndexDependents_Gas(t *testing.T) {
+	env := setupTestEnv()
+
+	// The index entries are charged, one per import.
+	gas := func(imports ...string) int64 {
+		t.Helper()
+
+		body := "package lib\n"
+		for _, imp := range imports {
+			body += fmt.Sprintf("\nimport _ %q\n", imp)
+		}
+		memPkg := &std.MemPackage{
+			Name:  "lib",
+			Path:  "gno.land/p/demo/lib",
+			Files: []*std.MemFile{{Name: "lib.gno", Body: body}},
+		}
+		ctx := env.ctx.WithGasMeter(types.NewInfiniteGasMeter())
+		require.NoError(t, env.vmk.indexDependents(ctx, memPkg))
+		return ctx.GasMeter().GasConsumed()
+	}
+	assert.Zero(t, gas())
+	one := gas("strings")
+	assert.Positive(t, one)
+	assert.Equal(t, 2*one, gas("strings", "errors"))
+}
@@ -0,0 +1,88 @@
+package vm
+
+import (
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Limits are the limits on the size of the transactions, checked by the ante
+// handler, and on the size of the packages they add, checked by AddPackage.
+// They're set by the auth and vm params; 0 means unlimited.
+type Limits struct {
+	MaxTxBytes      int64 `json:"max_tx_bytes"`
+	MaxPackageFiles int64 `json:"max_package_files"`
+	MaxPackageBytes int64 `json:"max_package_bytes"`
+	MaxFileBytes    int64 `json:"max_file_bytes"`
+}
+
+// JSON returns the limits as amino JSON, as returned by the vm/qlimits query.
+func (l Limits) JSON() string {
+	return string(amino.MustMarshalJSON(l))
+}
+
+func (l Limits) validate() error {
+	if l.MaxPackageFiles < 0 {
+		return fmt.Errorf("invalid max package files: %d, 0 is unlimited", l.MaxPackageFiles)
+	}
+	if l.MaxPackageBytes < 0 {
+		return fmt.Errorf("invalid max package bytes: %d, 0 is unlimited", l.MaxPackageBytes)
+	}
+	if l.MaxFileBytes < 0 {
+		return fmt.Errorf("invalid max file bytes: %d, 0 is unlimited", l.MaxFileBytes)
+	}
+	return nil
+}
+
+// checkPackage returns an error if mpkg exceeds the package limits. The size
+// of a package is the sum of the sizes of its file bodies.
+func (l Limits) checkPackage(mpkg *std.MemPackage) error {
+	if l.MaxPackageFiles > 0 && int64(len(mpkg.Files)) > l.MaxPackageFiles {
+		return ErrPackageTooLarge(fmt.Sprintf(
+			"package %s has %d files, the maximum is %d",
+			mpkg.Path, len(mpkg.Files), l.MaxPackageFiles))
+	}
+	var total int64
+	for _, mfile := range mpkg.Files {
+		size := int64(len(mfile.Body))
+		if l.MaxFileBytes > 0 && size > l.MaxFileBytes {
+			return ErrPackageTooLarge(fmt.Sprintf(
+				"file %s of package %s is %d bytes, the maximum is %d",
+				mfile.Name, mpkg.Path, size, l.MaxFileBytes))
+		}
+		total += size
+	}
+	if l.MaxPackageBytes > 0 && total > l.MaxPackageBytes {
+		return ErrPackageTooLarge(fmt.Sprintf(
+			"package %s is %d bytes, the maximum is %d",
+			mpkg.Path, total, l.MaxPackageBytes))
+	}
+	return nil
+}
+
+// packageLimits returns the package limits of the params.
+func (p Params) packageLimits() Limits {
+	return Limits{
+		MaxPackageFiles: p.MaxPackageFiles,
+		MaxPackageBytes: p.MaxPackageBytes,
+		MaxFileBytes:    p.MaxFileBytes,
+	}
+}
+
+// getLimitsParam returns the limits set in the auth and vm params.
+func (vm *VMKeeper) getLimitsParam(ctx sdk.Context) Limits {
+	var l Limits
+	vm.prmk.GetInt64(ctx, maxTxBytesParamPath, &l.MaxTxBytes)
+	vm.prmk.GetInt64(ctx, maxPackageFilesParamPath, &l.MaxPackageFiles)
+	vm.prmk.GetInt64(ctx, maxPackageBytesParamPath, &l.MaxPackageBytes)
+	vm.prmk.GetInt64(ctx, maxFileBytesParamPath, &l.MaxFileBytes)
+	return l
+}
+
+// QueryLimits returns the limits on the size of the transactions and of the
+// packages, for the clients to check them before broadcasting.
+func (vm *VMKeeper) QueryLimits(ctx sdk.Context) Limits {
+	return vm.getLimitsParam(ctx)
+}
@@ -25,4 +25,7 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	TypeCheckError{}, "TypeCheckError",
 	UnauthorizedUserError{}, "UnauthorizedUserError",
 	InvalidPackageError{}, "InvalidPackageError",
+	PackageTooLargeError{}, "PackageTooLargeError",
+	InvalidFileError{}, "InvalidFileError",
+	InvalidArgumentError{}, "InvalidArgumentError",
 ))
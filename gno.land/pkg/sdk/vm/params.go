@@ -9,17 +9,26 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
 )
 
 const (
-	sysNamesPkgDefault             = "gno.land/r/sys/names"
-	chainDomainDefault             = "gno.land"
-	depositDefault                 = "600000000ugnot"
-	storagePriceDefault            = "100ugnot" // cost per byte (1 gnot per 10KB) 1B GNOT == 10TB
-	storageFeeCollectorNameDefault = "storage_fee_collector"
+	sysNamesPkgDefault  = "gno.land/r/sys/names"
+	chainDomainDefault  = "gno.land"
+	depositDefault      = "600000000ugnot"
+	storagePriceDefault = "100ugnot" // cost per byte (1 gnot per 10KB) 1B GNOT == 10TB
+
+	maxPackageFilesDefault int64 = 500
+	maxPackageBytesDefault int64 = 800_000
+	maxFileBytesDefault    int64 = 100_000
 )
 
+// DefaultStorageFeeCollectorName is the name of the module account collecting
+// the storage fees, by default.
+const DefaultStorageFeeCollectorName = "storage_fee_collector"
+
 var ASCIIDomain = regexp.MustCompile(`^(?:[A-Za-z0-9](?:[A-Za-z0-9-]{0,61}[A-Za-z0-9])?\.)+[A-Za-z]{2,}$`)
 
 // Params defines the parameters for the bank module.
@@ -29,6 +38,13 @@ type Params struct {
 	DefaultDeposit      string         `json:"default_deposit" yaml:"default_deposit"`
 	StoragePrice        string         `json:"storage_price" yaml:"storage_price"`
 	StorageFeeCollector crypto.Address `json:"storage_fee_collector" yaml:"storage_fee_collector"`
+	GasTableVersion     string         `json:"gas_table_version" yaml:"gas_table_version"`
+	GasPrices           []string       `json:"gas_prices" yaml:"gas_prices"`
+	MaxPackageFiles     int64          `json:"max_package_files" yaml:"max_package_files"`
+	MaxPackageBytes     int64          `json:"max_package_bytes" yaml:"max_package_bytes"`
+	MaxFileBytes        int64          `json:"max_file_bytes" yaml:"max_file_bytes"`
+	CallStats           bool           `json:"call_stats" yaml:"call_stats"`
+	Deployers           []string       `json:"deployers" yaml:"deployers"`
 }
 
 // NewParams creates a new Params object
@@ -44,8 +60,13 @@ func NewParams(namesPkgPath, chainDomain, defaultDeposit, storagePrice string, s
 
 // DefaultParams returns a default set of parameters.
 func DefaultParams() Params {
-	return NewParams(sysNamesPkgDefault, chainDomainDefault,
-		depositDefault, storagePriceDefault, crypto.AddressFromPreimage([]byte(storageFeeCollectorNameDefault)))
+	p := NewParams(sysNamesPkgDefault, chainDomainDefault,
+		depositDefault, storagePriceDefault, bank.ModuleAddress(DefaultStorageFeeCollectorName))
+	p.GasTableVersion = gno.GasTableVersionDefault
+	p.MaxPackageFiles = maxPackageFilesDefault
+	p.MaxPackageBytes = maxPackageBytesDefault
+	p.MaxFileBytes = maxFileBytesDefault
+	return p
 }
 
 // String implements the stringer interface.
@@ -57,6 +78,13 @@ func (p Params) String() string {
 	sb.WriteString(fmt.Sprintf("DefaultDeposit: %q\n", p.DefaultDeposit))
 	sb.WriteString(fmt.Sprintf("StoragePrice: %q\n", p.StoragePrice))
 	sb.WriteString(fmt.Sprintf("StorageFeeCollector: %q\n", p.StorageFeeCollector.String()))
+	sb.WriteString(fmt.Sprintf("GasTableVersion: %q\n", p.GasTableVersion))
+	sb.WriteString(fmt.Sprintf("GasPrices: %q\n", p.GasPrices))
+	sb.WriteString(fmt.Sprintf("MaxPackageFiles: %d\n", p.MaxPackageFiles))
+	sb.WriteString(fmt.Sprintf("MaxPackageBytes: %d\n", p.MaxPackageBytes))
+	sb.WriteString(fmt.Sprintf("MaxFileBytes: %d\n", p.MaxFileBytes))
+	sb.WriteString(fmt.Sprintf("CallStats: %t\n", p.CallStats))
+	sb.WriteString(fmt.Sprintf("Deployers: %q\n", p.Deployers))
 	return sb.String()
 }
 
@@ -78,9 +106,24 @@ func (p Params) Validate() error {
 	if p.StorageFeeCollector.IsZero() {
 		return fmt.Errorf("invalid storage fee collector, cannot be empty")
 	}
+	if _, err := p.GasTable(); err != nil {
+		return err
+	}
+	if err := p.packageLimits().validate(); err != nil {
+		return err
+	}
+	if _, err := parseDeployers(p.Deployers); err != nil {
+		return err
+	}
 	return nil
 }
 
+// GasTable returns the gas table of the VM, made of the prices of the
+// GasTableVersion and of the GasPrices overrides.
+func (p Params) GasTable() (*gno.GasTable, error) {
+	return gno.NewGasTable(p.GasTableVersion, p.GasPrices)
+}
+
 // Equals returns a boolean determining if two Params types are identical.
 func (p Params) Equals(p2 Params) bool {
 	return amino.DeepEqual(p, p2)
@@ -101,8 +144,16 @@ func (vm *VMKeeper) GetParams(ctx sdk.Context) Params {
 }
 
 const (
-	sysUsersPkgParamPath = "vm:p:sysnames_pkgpath"
-	chainDomainParamPath = "vm:p:chain_domain"
+	sysUsersPkgParamPath     = "vm:p:sysnames_pkgpath"
+	chainDomainParamPath     = "vm:p:chain_domain"
+	gasTableVersionParamPath = "vm:p:gas_table_version"
+	gasPricesParamPath       = "vm:p:gas_prices"
+	maxPackageFilesParamPath = "vm:p:max_package_files"
+	maxPackageBytesParamPath = "vm:p:max_package_bytes"
+	maxFileBytesParamPath    = "vm:p:max_file_bytes"
+	callStatsParamPath       = "vm:p:call_stats"
+	deployersParamPath       = "vm:p:deployers"
+	maxTxBytesParamPath      = "auth:p:max_tx_bytes"
 )
 
 func (vm *VMKeeper) getChainDomainParam(ctx sdk.Context) string {
@@ -117,6 +168,48 @@ func (vm *VMKeeper) getSysNamesPkgParam(ctx sdk.Context) string {
 	return sysNamesPkg
 }
 
+// getGasTableParam returns the gas table set in the params. Reading the
+// params doesn't consume gas, as it's done for every transaction.
+func (vm *VMKeeper) getGasTableParam(ctx sdk.Context) (*gno.GasTable, error) {
+	ctx = ctx.WithGasMeter(store.NewInfiniteGasMeter())
+	var p Params
+	vm.prmk.GetString(ctx, gasTableVersionParamPath, &p.GasTableVersion)
+	vm.prmk.GetStrings(ctx, gasPricesParamPath, &p.GasPrices)
+	gt, err := p.GasTable()
+	if err != nil {
+		return nil, fmt.Errorf("invalid gas table params: %w", err)
+	}
+	return gt, nil
+}
+
 func (vm *VMKeeper) WillSetParam(ctx sdk.Context, key string, value any) {
-	// XXX validate input?
+	// XXX validate other inputs?
+	var p Params
+	switch key {
+	case "p:max_package_files", "p:max_package_bytes", "p:max_file_bytes":
+		// Negative limits would reject every package.
+		if n, _ := value.(int64); n < 0 {
+			panic(fmt.Sprintf("invalid %s: %d, 0 is unlimited", strings.TrimPrefix(key, "p:"), n))
+		}
+		return
+	case "p:deployers":
+		// The packages under a prefix of an invalid rule couldn't be added.
+		rules, _ := value.([]string)
+		if _, err := parseDeployers(rules); err != nil {
+			panic(err)
+		}
+		return
+	case "p:gas_table_version":
+		p.GasTableVersion, _ = value.(string)
+		vm.prmk.GetStrings(ctx, gasPricesParamPath, &p.GasPrices)
+	case "p:gas_prices":
+		vm.prmk.GetString(ctx, gasTableVersionParamPath, &p.GasTableVersion)
+		p.GasPrices, _ = value.([]string)
+	default:
+		return
+	}
+	// The next transactions would fail with an invalid gas table.
+	if _, err := p.GasTable(); err != nil {
+		panic(err)
+	}
 }
@@ -4,7 +4,9 @@ import (
 	"fmt"
 	"testing"
 
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 )
 
 // TestParamsString verifies the output of the String method.
@@ -21,7 +23,14 @@ func TestParamsString(t *testing.T) {
 		fmt.Sprintf("ChainDomain: %q\n", p.ChainDomain) +
 		fmt.Sprintf("DefaultDeposit: %q\n", p.DefaultDeposit) +
 		fmt.Sprintf("StoragePrice: %q\n", p.StoragePrice) +
-		fmt.Sprintf("StorageFeeCollector: %q\n", p.StorageFeeCollector)
+		fmt.Sprintf("StorageFeeCollector: %q\n", p.StorageFeeCollector) +
+		fmt.Sprintf("GasTableVersion: %q\n", p.GasTableVersion) +
+		fmt.Sprintf("GasPrices: %q\n", p.GasPrices) +
+		fmt.Sprintf("MaxPackageFiles: %d\n", p.MaxPackageFiles) +
+		fmt.Sprintf("MaxPackageBytes: %d\n", p.MaxPackageBytes) +
+		fmt.Sprintf("MaxFileBytes: %d\n", p.MaxFileBytes) +
+		fmt.Sprintf("CallStats: %t\n", p.CallStats) +
+		fmt.Sprintf("Deployers: %q\n", p.Deployers)
 
 	// Assert: check if the result matches the expected string.
 	if result != expected {
@@ -131,3 +140,122 @@ func TestWillSetParam(t *testing.T) {
 		})
 	}
 }
+
+func TestGasTableParams(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.vmk.MakeGnoTransactionStore(env.ctx)
+	vmk := env.vmk
+	prmk := env.prmk
+
+	gt := vmk.getGnoTransactionStore(ctx).GetGasTable()
+	assert.Equal(t, gno.GasTableVersionDefault, gt.Version)
+	assert.Equal(t, gno.DefaultGasTable(), gt)
+
+	prmk.SetStrings(ctx, "vm:p:gas_prices", []string{"OpAdd=1000", "GetObjectPerByte=20", "crypto/sha256.sum256=500"})
+	ctx = vmk.MakeGnoTransactionStore(ctx)
+	gt = vmk.getGnoTransactionStore(ctx).GetGasTable()
+	for name, price := range map[string]int64{"OpAdd": 1000, "GetObjectPerByte": 20, "crypto/sha256.sum256": 500} {
+		actual, ok := gt.Get(name)
+		assert.True(t, ok, name)
+		assert.Equal(t, price, actual, name)
+	}
+	assert.Equal(t, []string{"OpAdd=1000", "GetObjectPerByte=20", "crypto/sha256.sum256=500"}, vmk.GetParams(ctx).GasPrices)
+
+	assert.PanicsWithError(t, `unknown gas price "OpUnknown"`, func() {
+		prmk.SetStrings(ctx, "vm:p:gas_prices", []string{"OpUnknown=1"})
+	})
+	assert.PanicsWithError(t, `unknown gas table version "v0"`, func() {
+		prmk.SetString(ctx, "vm:p:gas_table_version", "v0")
+	})
+}
+
+func TestVMKeeperGasTable(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+	vmk := env.vmk
+
+	gt, err := vmk.QueryGasTable(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, gno.GasTableVersionDefault, gt.Version)
+	assert.Empty(t, gt.Overrides)
+	assert.Equal(t, gno.DefaultGasTable().Prices(), gt.Prices)
+
+	require.NoError(t, vmk.SetGasTable(ctx, "v1", []string{"OpAdd=1000"}))
+	gt, err = vmk.QueryGasTable(ctx)
+	require.NoError(t, err)
+	assert.Equal(t, []string{"OpAdd=1000"}, gt.Overrides)
+	assert.Contains(t, gt.Prices, "OpAdd=1000")
+	assert.Contains(t, gt.JSON(), `"OpAdd=1000"`)
+
+	// The next transactions use the new table.
+	ctx = vmk.MakeGnoTransactionStore(ctx)
+	price, _ := vmk.getGnoTransactionStore(ctx).GetGasTable().Get("OpAdd")
+	assert.Equal(t, int64(1000), price)
+
+	assert.ErrorContains(t, vmk.SetGasTable(ctx, "v1", []string{"OpUnknown=1"}), `unknown gas price "OpUnknown"`)
+	assert.ErrorContains(t, vmk.SetGasTable(ctx, "v0", nil), `unknown gas table version "v0"`)
+	assert.Equal(t, []string{"OpAdd=1000"}, vmk.GetParams(ctx).GasPrices)
+}
+
+func TestGasTableParamsInvalid(t *testing.T) {
+	env := setupTestEnv()
+	ctx := env.ctx
+	vmk := env.vmk
+
+	// Bypass the validation of WillSetParam, as a faulty upgrade would.
+	env.prmk.SetRaw(ctx, "vm:p:gas_table_version", []byte(`"v0"`))
+	_, err := vmk.getGasTableParam(ctx)
+	assert.ErrorContains(t, err, `unknown gas table version "v0"`)
+
+	// Transactions fall back to the default gas table.
+	assert.NotPanics(t, func() {
+		ctx = vmk.MakeGnoTransactionStore(ctx)
+	})
+	assert.Equal(t, gno.DefaultGasTable(), vmk.getGnoTransactionStore(ctx).GetGasTable())
+}
+
+func TestParamsValidateGasTable(t *testing.T) {
+	p := DefaultParams()
+	assert.NoError(t, p.Validate())
+
+	p.GasPrices = []string{"OpAdd=-1"}
+	assert.ErrorContains(t, p.Validate(), "invalid gas price")
+
+	p.GasPrices = nil
+	p.GasTableVersion = "v0"
+	assert.ErrorContains(t, p.Validate(), "unknown gas table version")
+}
+
+func TestParamsValidateLimits(t *testing.T) {
+	p := DefaultParams()
+	p.MaxPackageFiles = 0 // unlimited
+	assert.NoError(t, p.Validate())
+
+	p.MaxPackageBytes = -1
+	assert.ErrorContains(t, p.Validate(), "invalid max package bytes: -1")
+
+	env := setupTestEnv()
+	assert.PanicsWithValue(t, "invalid max_file_bytes: -1, 0 is unlimited", func() {
+		env.prmk.SetInt64(env.ctx, "vm:p:max_file_bytes", -1)
+	})
+}
+
+func TestParamsValidateDeployers(t *testing.T) {
+	p := DefaultParams()
+	p.Deployers = []string{
+		"gno.land/r/acme=g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5",
+		"gno.land/p/acme=gno.land/r/acme/gov",
+	}
+	assert.NoError(t, p.Validate())
+
+	p.Deployers = []string{"gno.land/r/acme"}
+	assert.ErrorContains(t, p.Validate(), `invalid deployer "gno.land/r/acme"`)
+
+	p.Deployers = []string{"gno.land/r/acme=nope"}
+	assert.ErrorContains(t, p.Validate(), `invalid deployer "gno.land/r/acme=nope"`)
+
+	env := setupTestEnv()
+	assert.Panics(t, func() {
+		env.prmk.SetStrings(env.ctx, "vm:p:deployers", []string{"=gno.land/r/acme/gov"})
+	})
+}
@@ -0,0 +1,167 @@
+package vm
+
+import (
+	"encoding/binary"
+	"fmt"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+// The call counters of the realms are maintained when the vm:p:call_stats
+// param is set, so that a realm can display its usage without an indexer.
+// They're stored in the base store, under keys prefixed by
+// "pkgstats:<pkgpath>:" (package paths can't contain a colon):
+//
+//   - "calls": the number of successful calls of the realm;
+//   - "func:<name>": the number of successful calls of each of its functions;
+//   - "day:<day>:calls": the number of successful calls during a day;
+//   - "day:<day>:callers": the number of unique callers during a day;
+//   - "day:<day>:caller:<address>": a marker of the callers of a day.
+//
+// A day is the number of days since the Unix epoch of the block time, in UTC.
+// Only the buckets of the last statsDaysMax days are kept.
+const statsKeyPrefix = "pkgstats:"
+
+const (
+	statsDaysDefault = 7
+	statsDaysMax     = 90
+)
+
+// CallStats are the call counters of a realm, as returned by the vm/qstats
+// query.
+type CallStats struct {
+	PkgPath string          `json:"pkg_path"`
+	Calls   uint64          `json:"calls"`
+	Funcs   []FuncCallStats `json:"funcs"`
+	Days    []DayCallStats  `json:"days"`
+}
+
+// FuncCallStats are the call counters of a function of a realm.
+type FuncCallStats struct {
+	Name  string `json:"name"`
+	Calls uint64 `json:"calls"`
+}
+
+// DayCallStats are the call counters of a realm during a day (UTC).
+type DayCallStats struct {
+	Day           string `json:"day"` // YYYY-MM-DD
+	Calls         uint64 `json:"calls"`
+	UniqueCallers uint64 `json:"unique_callers"`
+}
+
+// JSON returns the stats as amino JSON.
+func (cs CallStats) JSON() string {
+	return string(amino.MustMarshalJSON(cs))
+}
+
+func statsKey(pkgPath string, parts ...string) []byte {
+	key := statsKeyPrefix + pkgPath
+	for _, part := range parts {
+		key += ":" + part
+	}
+	return []byte(key)
+}
+
+// statsDay returns the day bucket of t.
+func statsDay(t time.Time) string {
+	return fmt.Sprintf("%08d", t.Unix()/(24*60*60))
+}
+
+func incrStatsCounter(st store.Store, key []byte) (n uint64) {
+	if bz := st.Get(key); len(bz) == 8 {
+		n = binary.BigEndian.Uint64(bz)
+	}
+	n++
+	st.Set(key, binary.BigEndian.AppendUint64(nil, n))
+	return n
+}
+
+func getStatsCounter(st store.Store, key []byte) uint64 {
+	bz := st.Get(key)
+	if len(bz) != 8 {
+		return 0
+	}
+	return binary.BigEndian.Uint64(bz)
+}
+
+// recordCall increments the counters of the realm at pkgPath for a
+// successful call of fn by caller, if the vm:p:call_stats param is set. The
+// counters are metered like any other write of the call; the day buckets
+// older than statsDaysMax are pruned on the first call of each day.
+func (vm *VMKeeper) recordCall(ctx sdk.Context, pkgPath, fn string, caller crypto.Address) {
+	var enabled bool
+	vm.prmk.GetBool(ctx.WithGasMeter(store.NewInfiniteGasMeter()), callStatsParamPath, &enabled)
+	if !enabled {
+		return
+	}
+
+	base := ctx.GasStore(vm.baseKey)
+	now := ctx.BlockTime()
+	day := statsDay(now)
+	incrStatsCounter(base, statsKey(pkgPath, "calls"))
+	incrStatsCounter(base, statsKey(pkgPath, "func", fn))
+	if incrStatsCounter(base, statsKey(pkgPath, "day", day, "calls")) == 1 {
+		pruneStatsDays(base, pkgPath, statsDay(now.AddDate(0, 0, -statsDaysMax+1)))
+	}
+	if callerKey := statsKey(pkgPath, "day", day, "caller", caller.String()); !base.Has(callerKey) {
+		base.Set(callerKey, []byte{})
+		incrStatsCounter(base, statsKey(pkgPath, "day", day, "callers"))
+	}
+}
+
+// pruneStatsDays deletes the day buckets of the realm at pkgPath before the
+// given day, which can't be queried anymore. Days sort as their keys do.
+func pruneStatsDays(st store.Store, pkgPath, before string) {
+	var keys [][]byte
+	it := st.Iterator(statsKey(pkgPath, "day", ""), statsKey(pkgPath, "day", before))
+	for ; it.Valid(); it.Next() {
+		keys = append(keys, it.Key())
+	}
+	it.Close()
+	for _, key := range keys {
+		st.Delete(key)
+	}
+}
+
+// QueryStats returns the call counters of the realm at pkgPath, with those
+// of the given number of days up to the current block, the latest first.
+func (vm *VMKeeper) QueryStats(ctx sdk.Context, pkgPath string, days int) (CallStats, error) {
+	if days < 0 {
+		return CallStats{}, fmt.Errorf("cannot have negative days value")
+	}
+
+	base := ctx.Store(vm.baseKey)
+	stats := CallStats{
+		PkgPath: pkgPath,
+		Calls:   getStatsCounter(base, statsKey(pkgPath, "calls")),
+		Funcs:   []FuncCallStats{},
+		Days:    []DayCallStats{},
+	}
+
+	prefix := statsKey(pkgPath, "func", "")
+	it := store.PrefixIterator(base, prefix)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		stats.Funcs = append(stats.Funcs, FuncCallStats{
+			Name:  string(it.Key()[len(prefix):]),
+			Calls: binary.BigEndian.Uint64(it.Value()),
+		})
+	}
+
+	now := ctx.BlockTime().UTC()
+	for i := range days {
+		t := now.AddDate(0, 0, -i)
+		day := statsDay(t)
+		stats.Days = append(stats.Days, DayCallStats{
+			Day:           t.Format(time.DateOnly),
+			Calls:         getStatsCounter(base, statsKey(pkgPath, "day", day, "calls")),
+			UniqueCallers: getStatsCounter(base, statsKey(pkgPath, "day", day, "callers")),
+		})
+	}
+
+	return stats, nil
+}
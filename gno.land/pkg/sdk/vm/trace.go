@@ -0,0 +1,68 @@
+package vm
+
+import (
+	"fmt"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+)
+
+// vmTracer adds the realm calls made by a machine to the tracer of a traced
+// transaction: the calls crossing into a realm, and the method calls
+// switching to the realm storing their receiver.
+type vmTracer struct {
+	tracer *sdk.Tracer
+	frames []int // the indexes in m.Frames of the realm calls in progress
+}
+
+// newTracer returns the tracer of the realm calls made by a machine if the
+// transaction is traced, or nil. endCalls ends the calls left in progress by
+// a panic, and must be deferred.
+func newTracer(ctx sdk.Context) (tracer gno.Tracer, endCalls func()) {
+	t := ctx.Tracer()
+	if t == nil {
+		return nil, func() {}
+	}
+	depth := t.Depth()
+	return &vmTracer{tracer: t}, func() {
+		t.EndCalls(depth, ctx.GasMeter().GasConsumed())
+	}
+}
+
+// OnCall implements gno.Tracer.
+func (vt *vmTracer) OnCall(m *gno.Machine) {
+	index := len(m.Frames) - 1
+	vt.endCalls(m, index)
+
+	fr := &m.Frames[index]
+	if !fr.WithCross && fr.LastRealm == m.Realm {
+		return
+	}
+	realm := ""
+	if m.Realm != nil {
+		realm = m.Realm.Path
+	}
+	vt.tracer.BeginCall(funcName(fr), realm, m.GasMeter.GasConsumed())
+	vt.frames = append(vt.frames, index)
+}
+
+// OnReturn implements gno.Tracer.
+func (vt *vmTracer) OnReturn(m *gno.Machine, fr *gno.Frame) {
+	vt.endCalls(m, len(m.Frames))
+}
+
+// endCalls ends the realm calls of the frames from index, which have been
+// popped.
+func (vt *vmTracer) endCalls(m *gno.Machine, index int) {
+	for len(vt.frames) > 0 && vt.frames[len(vt.frames)-1] >= index {
+		vt.frames = vt.frames[:len(vt.frames)-1]
+		vt.tracer.EndCall(m.GasMeter.GasConsumed())
+	}
+}
+
+func funcName(fr *gno.Frame) string {
+	if fr.Receiver.IsDefined() {
+		return fmt.Sprintf("(%s).%s", fr.Receiver.T.String(), fr.Func.Name)
+	}
+	return fr.Func.PkgPath + "." + string(fr.Func.Name)
+}
@@ -21,6 +21,7 @@ type BankKeeperI interface {
 	SubtractCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) (std.Coins, error)
 	AddCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) (std.Coins, error)
 	RestrictedDenoms(ctx sdk.Context) []string
+	SetAddressOwner(ctx sdk.Context, addr crypto.Address, owner string)
 }
 
 // ParamsKeeperI is the limited interface only needed for VM.
@@ -0,0 +1,174 @@
+// Package benchmarks contains representative workloads for the GnoVM, and
+// the tooling to run them and compare their results between releases.
+//
+// Each workload is a main package defining a Bench(n int) function, which
+// runs n iterations of the workload. The package is loaded before timing
+// starts, so only the execution of Bench is measured.
+package benchmarks
+
+import (
+	"embed"
+	"fmt"
+	"io"
+	"path"
+	"runtime"
+	"strings"
+	"time"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/test"
+)
+
+//go:embed workloads/*.gno
+var workloadsFS embed.FS
+
+// Workload is a Gno program to benchmark.
+type Workload struct {
+	Name   string
+	Source string
+}
+
+// Workloads returns the workloads of the suite, ordered by name.
+func Workloads() []Workload {
+	entries, err := workloadsFS.ReadDir("workloads")
+	if err != nil {
+		panic(err)
+	}
+	wls := make([]Workload, 0, len(entries))
+	for _, entry := range entries {
+		bz, err := workloadsFS.ReadFile(path.Join("workloads", entry.Name()))
+		if err != nil {
+			panic(err)
+		}
+		wls = append(wls, Workload{
+			Name:   strings.TrimSuffix(entry.Name(), ".gno"),
+			Source: string(bz),
+		})
+	}
+	return wls
+}
+
+// Options configures how a workload is run.
+type Options struct {
+	// RootDir is the root of the gno repository, containing the standard
+	// libraries and the examples imported by the workloads.
+	RootDir string
+	// N is the number of iterations of the workload.
+	N int
+}
+
+// Result is the measure of a workload run.
+type Result struct {
+	Name        string  `json:"name"`
+	N           int     `json:"n"`
+	NsPerOp     float64 `json:"ns_per_op"`
+	CyclesPerOp int64   `json:"cycles_per_op"` // VM cycles, deterministic.
+	BytesPerOp  int64   `json:"bytes_per_op"`  // Go heap allocations.
+}
+
+func (r Result) String() string {
+	return fmt.Sprintf("%s\t%d\t%.0f ns/op\t%d cycles/op\t%d B/op",
+		r.Name, r.N, r.NsPerOp, r.CyclesPerOp, r.BytesPerOp)
+}
+
+// Run runs the workload w with the given options.
+func Run(w Workload, opts Options) (res Result, err error) {
+	if opts.N <= 0 {
+		return res, fmt.Errorf("invalid number of iterations: %d", opts.N)
+	}
+	fn, err := gno.ParseFile(w.Name+".gno", w.Source)
+	if err != nil {
+		return res, err
+	}
+	bench, err := gno.ParseExpr(fmt.Sprintf("Bench(%d)", opts.N))
+	if err != nil {
+		return res, err
+	}
+
+	_, store := test.ProdStore(opts.RootDir, io.Discard, nil)
+	m := gno.NewMachineWithOptions(gno.MachineOptions{
+		PkgPath: "main",
+		Output:  io.Discard,
+		Store:   store,
+		Context: test.Context("", "main", nil),
+	})
+	defer m.Release()
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("running workload %s: %v", w.Name, r)
+		}
+	}()
+	m.RunFiles(fn)
+
+	var before, after runtime.MemStats
+	runtime.GC()
+	runtime.ReadMemStats(&before)
+	cycles := m.Cycles
+	start := time.Now()
+	m.Eval(bench)
+	elapsed := time.Since(start)
+	runtime.ReadMemStats(&after)
+
+	n := int64(opts.N)
+	return Result{
+		Name:        w.Name,
+		N:           opts.N,
+		NsPerOp:     float64(elapsed.Nanoseconds()) / float64(n),
+		CyclesPerOp: (m.Cycles - cycles) / n,
+		BytesPerOp:  int64(after.TotalAlloc-before.TotalAlloc) / n,
+	}, nil
+}
+
+// Comparison is the relative change of a workload result from a baseline.
+// A delta of 0.1 is an increase of 10%.
+type Comparison struct {
+	Name        string
+	Base        Result
+	Current     Result
+	TimeDelta   float64
+	CyclesDelta float64
+}
+
+// Regressed returns true if the time or the cycles of the workload grew by
+// more than threshold.
+func (c Comparison) Regressed(threshold float64) bool {
+	return c.TimeDelta > threshold || c.CyclesRegressed(threshold)
+}
+
+// CyclesRegressed returns true if the cycles of the workload grew by more
+// than threshold. Unlike the time, the cycles don't depend on the machine,
+// so they can be compared with a baseline measured elsewhere.
+func (c Comparison) CyclesRegressed(threshold float64) bool {
+	return c.CyclesDelta > threshold
+}
+
+// Compare compares the current results with the base results of the same
+// workloads. Workloads missing from base are skipped.
+func Compare(base, current []Result) []Comparison {
+	baseByName := make(map[string]Result, len(base))
+	for _, r := range base {
+		baseByName[r.Name] = r
+	}
+	var cmps []Comparison
+	for _, cur := range current {
+		b, ok := baseByName[cur.Name]
+		if !ok {
+			continue
+		}
+		cmps = append(cmps, Comparison{
+			Name:        cur.Name,
+			Base:        b,
+			Current:     cur,
+			TimeDelta:   delta(b.NsPerOp, cur.NsPerOp),
+			CyclesDelta: delta(float64(b.CyclesPerOp), float64(cur.CyclesPerOp)),
+		})
+	}
+	return cmps
+}
+
+func delta(base, cur float64) float64 {
+	if base == 0 {
+		return 0
+	}
+	return (cur - base) / base
+}
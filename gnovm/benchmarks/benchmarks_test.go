@@ -0,0 +1,88 @@
+package benchmarks
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestWorkloads(t *testing.T) {
+	rootDir := gnoenv.RootDir()
+	wls := Workloads()
+	require.NotEmpty(t, wls)
+	for _, w := range wls {
+		t.Run(w.Name, func(t *testing.T) {
+			res, err := Run(w, Options{RootDir: rootDir, N: 2})
+			require.NoError(t, err)
+			assert.Equal(t, w.Name, res.Name)
+			assert.Positive(t, res.CyclesPerOp)
+
+			// VM cycles are deterministic.
+			res2, err := Run(w, Options{RootDir: rootDir, N: 2})
+			require.NoError(t, err)
+			assert.Equal(t, res.CyclesPerOp, res2.CyclesPerOp)
+		})
+	}
+}
+
+func TestBaseline(t *testing.T) {
+	bz, err := os.ReadFile("baseline.json")
+	require.NoError(t, err)
+	var base []Result
+	require.NoError(t, json.Unmarshal(bz, &base))
+
+	// Every workload is compared by the CI.
+	names := map[string]bool{}
+	for _, r := range base {
+		names[r.Name] = true
+	}
+	for _, w := range Workloads() {
+		assert.True(t, names[w.Name], "workload %s is missing from baseline.json", w.Name)
+	}
+}
+
+func TestRunError(t *testing.T) {
+	w := Workload{Name: "panic", Source: "package main\n\nfunc Bench(n int) { panic(\"boom\") }\n"}
+	_, err := Run(w, Options{RootDir: gnoenv.RootDir(), N: 1})
+	assert.ErrorContains(t, err, "boom")
+
+	_, err = Run(w, Options{RootDir: gnoenv.RootDir()})
+	assert.ErrorContains(t, err, "invalid number of iterations")
+}
+
+func TestCompare(t *testing.T) {
+	base := []Result{
+		{Name: "a", NsPerOp: 100, CyclesPerOp: 1000},
+		{Name: "b", NsPerOp: 100, CyclesPerOp: 1000},
+	}
+	current := []Result{
+		{Name: "a", NsPerOp: 150, CyclesPerOp: 1000},
+		{Name: "b", NsPerOp: 95, CyclesPerOp: 1010},
+		{Name: "c", NsPerOp: 100, CyclesPerOp: 1000},
+	}
+	cmps := Compare(base, current)
+	require.Len(t, cmps, 2)
+	assert.InDelta(t, 0.5, cmps[0].TimeDelta, 1e-9)
+	assert.True(t, cmps[0].Regressed(0.1))
+	assert.InDelta(t, 0.01, cmps[1].CyclesDelta, 1e-9)
+	assert.False(t, cmps[1].Regressed(0.1))
+}
+
+func BenchmarkWorkloads(b *testing.B) {
+	rootDir := gnoenv.RootDir()
+	for _, w := range Workloads() {
+		b.Run(w.Name, func(b *testing.B) {
+			res, err := Run(w, Options{RootDir: rootDir, N: b.N})
+			if err != nil {
+				b.Fatal(err)
+			}
+			// Exclude the loading of the workload.
+			b.ReportMetric(res.NsPerOp, "ns/op")
+			b.ReportMetric(float64(res.CyclesPerOp), "cycles/op")
+		})
+	}
+}
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"fmt"
+	"math"
+	"os"
+	"strings"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// storePrices maps the store codes measured with the benchmarkingstorage
+// tag to the store prices of the gas table. All of them are per byte, except
+// for the deletion of objects.
+var storePrices = map[string]string{
+	"StoreGetObject":       gno.GasGetObjectDesc,
+	"StoreSetObject":       gno.GasSetObjectDesc,
+	"StoreGetType":         gno.GasGetTypeDesc,
+	"StoreSetType":         gno.GasSetTypeDesc,
+	"StoreGetPackageRealm": gno.GasGetPackageRealmDesc,
+	"StoreSetPackageRealm": gno.GasSetPackageRealmDesc,
+	"StoreAddMemPackage":   gno.GasAddMemPackageDesc,
+	"StoreGetMemPackage":   gno.GasGetMemPackageDesc,
+	"StoreDeleteObject":    gno.GasDeleteObjectDesc,
+}
+
+// calibratedPrices returns the gas prices matching the measured stats, as
+// "name=price" overrides of the default gas table. factor is the gas charged
+// per nanosecond. Prices which don't differ from the default table are
+// skipped.
+func calibratedPrices(css []codeStats, factor float64) []string {
+	gt := gno.DefaultGasTable()
+
+	var prices []string
+	for _, cs := range css {
+		name, perByte := cs.codeName, false
+		if storeName, ok := storePrices[cs.codeName]; ok {
+			name, perByte = storeName, storeName != gno.GasDeleteObjectDesc
+		}
+		current, ok := gt.Get(name)
+		if !ok {
+			continue // not priced by the gas table
+		}
+
+		cost := float64(cs.avgTime)
+		if perByte {
+			if cs.avgSize == 0 {
+				continue
+			}
+			cost /= float64(cs.avgSize)
+		}
+		price := max(int64(math.Round(cost*factor)), 1)
+		if price != current {
+			prices = append(prices, fmt.Sprintf("%s=%d", name, price))
+		}
+	}
+	return prices
+}
+
+// calibrate writes the calibrated gas prices to filename, one per line, so
+// they can be proposed as the vm:p:gas_prices param.
+func calibrate(css []codeStats, filename string, factor float64) error {
+	prices := calibratedPrices(css, factor)
+	out := strings.Join(prices, "\n")
+	if len(prices) > 0 {
+		out += "\n"
+	}
+	if err := os.WriteFile(filename, []byte(out), 0o644); err != nil {
+		return err
+	}
+	fmt.Println("## Calibrated gas prices saved in:", filename)
+	return nil
+}
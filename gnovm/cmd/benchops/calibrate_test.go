@@ -0,0 +1,29 @@
+package main
+
+import (
+	"testing"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestCalibratedPrices(t *testing.T) {
+	css := []codeStats{
+		{codeName: "OpAdd", avgTime: gno.OpCPUAdd}, // unchanged
+		{codeName: "OpSub", avgTime: 40},           // changed
+		{codeName: "OpMul", avgTime: 0},            // minimum price
+		{codeName: "StoreGetObject", avgTime: 3200, avgSize: 100},
+		{codeName: "StoreDeleteObject", avgTime: 1000, avgSize: 100},
+		{codeName: "StoreSetType", avgTime: 1000, avgSize: 0}, // no size
+		{codeName: "FinalizeTx", avgTime: 1000},               // not priced
+	}
+	assert.Equal(t, []string{
+		"OpSub=40",
+		"OpMul=1",
+		"GetObjectPerByte=32",
+		"DeleteObjectFlat=1000",
+	}, calibratedPrices(css, 1))
+
+	assert.Equal(t, []string{"OpAdd=36", "OpSub=80", "OpMul=1", "GetObjectPerByte=64", "DeleteObjectFlat=2000"},
+		calibratedPrices(css, 2))
+}
@@ -13,6 +13,9 @@ var (
 	outFlag   = flag.String("out", "results.csv", "the out put file")
 	benchFlag = flag.String("bench", "./pkg/benchops/gno", "the path to the benchmark contract")
 	binFlag   = flag.String("bin", "", "interpret the existing benchmarking file.")
+
+	calibrateFlag = flag.String("calibrate", "", "write the gas prices calibrated from the results to this file.")
+	factorFlag    = flag.Float64("factor", 1, "gas per nanosecond used to calibrate the gas prices.")
 )
 
 // We dump the benchmark in bytes for speed and minimal overhead.
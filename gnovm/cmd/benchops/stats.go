@@ -108,11 +108,16 @@ func stats(binFile string) {
 	<-doneCh
 	close(doneCh)
 
-	calculateStats(crs)
+	css := calculateStats(crs)
+	if *calibrateFlag != "" {
+		if err := calibrate(css, *calibrateFlag, *factorFlag); err != nil {
+			panic("could not write calibrated gas prices: " + err.Error())
+		}
+	}
 	fmt.Println("done")
 }
 
-func calculateStats(crs []codeRecord) {
+func calculateStats(crs []codeRecord) []codeStats {
 	filename := *outFlag
 	out, err := os.Create(addSuffix(filename))
 	if err != nil {
@@ -139,14 +144,17 @@ func calculateStats(crs []codeRecord) {
 	}
 	slices.Sort(keys)
 
+	css := make([]codeStats, 0, len(keys))
 	for _, k := range keys {
 		cs := calculate(k, m[k])
 		csv := cs.codeName + "," + fmt.Sprint(cs.avgTime) + "," + fmt.Sprint(cs.avgSize) + "," + fmt.Sprint(cs.timeStdDev) + "," + fmt.Sprint(cs.count)
 		fmt.Fprintln(out, csv)
+		css = append(css, cs)
 	}
 
 	fmt.Println("## Benchmark results saved in:", filename)
 	fmt.Println("## Benchmark result stats saved in:", out.Name())
+	return css
 }
 
 func addSuffix(filename string) string {
@@ -0,0 +1,217 @@
+// Command determinism runs the filetests of gnovm/tests/files and prints a
+// digest of the result of each of them, including the hashes of the realm
+// objects they persist. Running it on different architectures, or with
+// different GOGC settings, and comparing the digests catches nondeterminism
+// of the VM before it reaches a chain.
+//
+// Usage:
+//
+//	determinism [-dir ../../tests/files] [-short] [-out digests.txt]
+//	determinism -compare a.txt b.txt [...]
+package main
+
+import (
+	"bufio"
+	"bytes"
+	"crypto/sha256"
+	"encoding/hex"
+	"flag"
+	"fmt"
+	"io"
+	"io/fs"
+	"log"
+	"os"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm/pkg/test"
+)
+
+var (
+	dirFlag     = flag.String("dir", "../../tests/files", "the directory of the filetests")
+	rootFlag    = flag.String("root", "../../..", "the root of the gno repository")
+	outFlag     = flag.String("out", "", "write the digests to this file instead of stdout")
+	shortFlag   = flag.Bool("short", false, "skip the _long filetests")
+	compareFlag = flag.Bool("compare", false, "compare the digest files given as arguments")
+)
+
+func main() {
+	flag.Parse()
+	if *compareFlag {
+		if flag.NArg() < 2 {
+			log.Fatal("-compare needs at least two digest files")
+		}
+		diffs, err := compare(flag.Args())
+		if err != nil {
+			log.Fatal(err)
+		}
+		for _, d := range diffs {
+			fmt.Println(d)
+		}
+		if len(diffs) > 0 {
+			log.Fatalf("%d filetests are not deterministic", len(diffs))
+		}
+		return
+	}
+
+	out := io.Writer(os.Stdout)
+	if *outFlag != "" {
+		f, err := os.Create(*outFlag)
+		if err != nil {
+			log.Fatal(err)
+		}
+		defer f.Close()
+		out = f
+	}
+	if err := run(out, *rootFlag, *dirFlag, *shortFlag); err != nil {
+		log.Fatal(err)
+	}
+}
+
+// run writes the digest of each filetest in dir to w, after a header with the
+// settings of the run.
+func run(w io.Writer, rootDir, dir string, short bool) error {
+	rootDir, err := filepath.Abs(rootDir)
+	if err != nil {
+		return err
+	}
+	newOpts := func() *test.TestOptions {
+		o := &test.TestOptions{
+			RootDir: rootDir,
+			Output:  io.Discard,
+			Error:   io.Discard,
+			// Sync makes RunFiletest return the file with the actual
+			// results, which is what gets hashed.
+			Sync: true,
+		}
+		o.BaseStore, o.TestStore = test.StoreWithOptions(
+			rootDir, o.WriterForStore(),
+			test.StoreOptions{WithExtern: true, WithExamples: true, Testing: true},
+		)
+		return o
+	}
+	sharedOpts := newOpts()
+
+	fmt.Fprintf(w, "# goos=%s goarch=%s gogc=%s go=%s\n",
+		runtime.GOOS, runtime.GOARCH, os.Getenv("GOGC"), runtime.Version())
+	fsys := os.DirFS(dir)
+	return fs.WalkDir(fsys, ".", func(path string, de fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case path == "extern":
+			return fs.SkipDir
+		case de.IsDir(),
+			strings.HasPrefix(path, "."),
+			!strings.HasSuffix(path, ".gno"),
+			strings.HasSuffix(path, "_known.gno"):
+			return nil
+		}
+		isLong := strings.HasSuffix(path, "_long.gno")
+		if isLong && short {
+			return nil
+		}
+
+		source, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		opts := sharedOpts
+		if isLong {
+			opts = newOpts()
+		}
+		fmt.Fprintf(w, "%s %s\n", digest(opts, path, source), path)
+		return nil
+	})
+}
+
+// digest runs the filetest and returns the hash of its results. The realm
+// operations are always recorded, so that the hashes of the persisted objects
+// are compared even for the filetests not checking them.
+func digest(opts *test.TestOptions, path string, source []byte) (res string) {
+	defer func() {
+		if r := recover(); r != nil {
+			res = "panic:" + hash(fmt.Sprint(r))
+		}
+	}()
+
+	if !bytes.Contains(source, []byte("\n// Realm:\n")) {
+		// Empty directives are ignored: the placeholder is replaced by the
+		// realm operations.
+		source = append(bytes.TrimRight(source, "\n"), "\n\n// Realm:\n// -\n"...)
+	}
+	updated, err := opts.RunFiletest(path, source, opts.TestStore)
+	switch {
+	case err != nil:
+		return "error:" + hash(err.Error())
+	case updated != "":
+		return hash(updated)
+	default:
+		return hash(string(source))
+	}
+}
+
+func hash(s string) string {
+	sum := sha256.Sum256([]byte(s))
+	return hex.EncodeToString(sum[:])
+}
+
+// compare returns the filetests whose digests differ between the given
+// digest files.
+func compare(files []string) ([]string, error) {
+	digests := make([]map[string]string, len(files))
+	for i, file := range files {
+		d, err := readDigests(file)
+		if err != nil {
+			return nil, err
+		}
+		digests[i] = d
+	}
+
+	var diffs []string
+	for path, want := range digests[0] {
+		for i, d := range digests[1:] {
+			got, ok := d[path]
+			if !ok {
+				diffs = append(diffs, fmt.Sprintf("%s: missing in %s", path, files[i+1]))
+			} else if got != want {
+				diffs = append(diffs, fmt.Sprintf("%s: %s differs from %s", path, files[i+1], files[0]))
+			}
+		}
+	}
+	for _, d := range digests[1:] {
+		for path := range d {
+			if _, ok := digests[0][path]; !ok {
+				diffs = append(diffs, fmt.Sprintf("%s: missing in %s", path, files[0]))
+			}
+		}
+	}
+	sort.Strings(diffs)
+	return diffs, nil
+}
+
+// readDigests reads a digest file written by run, indexed by filetest.
+func readDigests(file string) (map[string]string, error) {
+	f, err := os.Open(file)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	digests := make(map[string]string)
+	sc := bufio.NewScanner(f)
+	for sc.Scan() {
+		line := sc.Text()
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		sum, path, ok := strings.Cut(line, " ")
+		if !ok {
+			return nil, fmt.Errorf("%s: invalid line %q", file, line)
+		}
+		digests[path] = sum
+	}
+	return digests, sc.Err()
+}
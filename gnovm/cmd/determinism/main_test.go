@@ -0,0 +1,68 @@
+package main
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRun(t *testing.T) {
+	dir := t.TempDir()
+	const realm = `// PKGPATH: gno.land/r/test/counter
+package counter
+
+var counter int
+
+func main(cur realm) {
+	counter++
+	println(counter)
+}
+
+// Output:
+// 1
+`
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "counter.gno"), []byte(realm), 0o644))
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "fail.gno"), []byte("package main\n\nfunc main() {\n\tpanic(\"boom\")\n}\n"), 0o644))
+
+	var first, second bytes.Buffer
+	require.NoError(t, run(&first, "../../..", dir, false))
+	require.NoError(t, run(&second, "../../..", dir, false))
+
+	lines := strings.Split(strings.TrimSpace(first.String()), "\n")
+	require.Len(t, lines, 3)
+	assert.True(t, strings.HasPrefix(lines[0], "# goos="))
+	assert.True(t, strings.HasSuffix(lines[1], " counter.gno"))
+	assert.True(t, strings.HasSuffix(lines[2], " fail.gno"))
+	assert.Equal(t, first.String(), second.String())
+}
+
+func TestCompare(t *testing.T) {
+	dir := t.TempDir()
+	write := func(name, content string) string {
+		path := filepath.Join(dir, name)
+		require.NoError(t, os.WriteFile(path, []byte(content), 0o644))
+		return path
+	}
+	a := write("a.txt", "# goarch=amd64\naaa x.gno\nbbb y.gno\n")
+	b := write("b.txt", "# goarch=arm64\naaa x.gno\nbbb y.gno\n")
+	c := write("c.txt", "aaa x.gno\nccc y.gno\nddd z.gno\n")
+
+	diffs, err := compare([]string{a, b})
+	require.NoError(t, err)
+	assert.Empty(t, diffs)
+
+	diffs, err = compare([]string{a, b, c})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"y.gno: " + c + " differs from " + a,
+		"z.gno: missing in " + a,
+	}, diffs)
+
+	_, err = compare([]string{a, write("bad.txt", "nodigest\n")})
+	assert.ErrorContains(t, err, "invalid line")
+}
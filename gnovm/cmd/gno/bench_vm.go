@@ -0,0 +1,204 @@
+package main
+
+import (
+	"context"
+	"encoding/json"
+	"flag"
+	"fmt"
+	"os"
+	"regexp"
+	"text/tabwriter"
+
+	"github.com/gnolang/gno/gnovm/benchmarks"
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+type benchVMCmd struct {
+	rootDir    string
+	run        string
+	n          int
+	count      int
+	json       bool
+	baseline   string
+	threshold  float64
+	cyclesOnly bool
+}
+
+func newBenchVMCmd(io commands.IO) *commands.Command {
+	cfg := &benchVMCmd{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "bench-vm",
+			ShortUsage: "bench-vm [flags]",
+			ShortHelp:  "runs the GnoVM benchmark suite",
+			LongHelp: `Runs the workloads of the GnoVM benchmark suite, and prints for each of them
+the time, the VM cycles and the Go heap allocations per iteration.
+
+The results printed with -json can be given to -baseline in a later run, to
+compare the performance of two versions of the VM. The command fails if the
+time or the VM cycles of a workload grew by more than -threshold percent.
+VM cycles are deterministic, while the time depends on the machine and its load:
+use -count to reduce the noise, or -cycles-only to only compare the VM cycles.
+
+The CI compares the VM cycles with the baseline committed in
+gnovm/benchmarks/baseline.json, which is updated with
+"make run.bench.vm.baseline" in gnovm.`,
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execBenchVM(cfg, args, io)
+		},
+	)
+}
+
+func (c *benchVMCmd) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.rootDir,
+		"root-dir",
+		"",
+		"clone location of github.com/gnolang/gno (gno binary tries to guess it)",
+	)
+
+	fs.StringVar(
+		&c.run,
+		"run",
+		"",
+		"regular expression selecting the workloads to run",
+	)
+
+	fs.IntVar(
+		&c.n,
+		"n",
+		10,
+		"number of iterations of each workload",
+	)
+
+	fs.IntVar(
+		&c.count,
+		"count",
+		1,
+		"number of runs of each workload; the fastest run is reported",
+	)
+
+	fs.BoolVar(
+		&c.json,
+		"json",
+		false,
+		"print the results as JSON",
+	)
+
+	fs.StringVar(
+		&c.baseline,
+		"baseline",
+		"",
+		"JSON results of a previous run to compare with",
+	)
+
+	fs.Float64Var(
+		&c.threshold,
+		"threshold",
+		10,
+		"maximum increase, in percent, of the time or cycles of a workload from the baseline",
+	)
+
+	fs.BoolVar(
+		&c.cyclesOnly,
+		"cycles-only",
+		false,
+		"only fail if the VM cycles of a workload regressed, ignoring the time",
+	)
+}
+
+func execBenchVM(cfg *benchVMCmd, args []string, io commands.IO) error {
+	if len(args) != 0 {
+		return flag.ErrHelp
+	}
+	if cfg.count <= 0 {
+		return fmt.Errorf("invalid count: %d", cfg.count)
+	}
+	if cfg.rootDir == "" {
+		cfg.rootDir = gnoenv.RootDir()
+	}
+	re, err := regexp.Compile(cfg.run)
+	if err != nil {
+		return fmt.Errorf("invalid -run: %w", err)
+	}
+
+	var base []benchmarks.Result
+	if cfg.baseline != "" {
+		bz, err := os.ReadFile(cfg.baseline)
+		if err != nil {
+			return err
+		}
+		if err := json.Unmarshal(bz, &base); err != nil {
+			return fmt.Errorf("parsing baseline %s: %w", cfg.baseline, err)
+		}
+	}
+
+	results := []benchmarks.Result{}
+	for _, w := range benchmarks.Workloads() {
+		if !re.MatchString(w.Name) {
+			continue
+		}
+		var best benchmarks.Result
+		for i := range cfg.count {
+			res, err := benchmarks.Run(w, benchmarks.Options{RootDir: cfg.rootDir, N: cfg.n})
+			if err != nil {
+				return err
+			}
+			if i == 0 || res.NsPerOp < best.NsPerOp {
+				best = res
+			}
+		}
+		results = append(results, best)
+		if !cfg.json && base == nil {
+			io.Println(best.String())
+		}
+	}
+
+	if cfg.json {
+		bz, err := json.MarshalIndent(results, "", "  ")
+		if err != nil {
+			return err
+		}
+		io.Println(string(bz))
+	}
+	if base == nil {
+		return nil
+	}
+
+	threshold := cfg.threshold / 100
+	regressed := false
+	// Keep stdout parseable when printing JSON.
+	out := io.Out()
+	if cfg.json {
+		out = io.Err()
+	}
+	tw := tabwriter.NewWriter(out, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "workload\told ns/op\tnew ns/op\tdelta\told cycles/op\tnew cycles/op\tdelta\t")
+	for _, cmp := range benchmarks.Compare(base, results) {
+		regression := cmp.Regressed(threshold)
+		if cfg.cyclesOnly {
+			regression = cmp.CyclesRegressed(threshold)
+		}
+		status := ""
+		if regression {
+			status = "REGRESSION"
+			regressed = true
+		}
+		fmt.Fprintf(tw, "%s\t%.0f\t%.0f\t%+.1f%%\t%d\t%d\t%+.1f%%\t%s\n",
+			cmp.Name,
+			cmp.Base.NsPerOp, cmp.Current.NsPerOp, cmp.TimeDelta*100,
+			cmp.Base.CyclesPerOp, cmp.Current.CyclesPerOp, cmp.CyclesDelta*100,
+			status)
+	}
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+	if regressed {
+		return fmt.Errorf("performance regressed by more than %g%%", cfg.threshold)
+	}
+	return nil
+}
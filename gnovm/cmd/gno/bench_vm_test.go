@@ -0,0 +1,48 @@
+package main
+
+import (
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestBenchVMApp(t *testing.T) {
+	baseline := filepath.Join(t.TempDir(), "baseline.json")
+	err := os.WriteFile(baseline, []byte(`[{"name": "recursion", "n": 1, "ns_per_op": 1, "cycles_per_op": 1}]`), 0o644)
+	require.NoError(t, err)
+	// Only the time regressed.
+	fastBaseline := filepath.Join(t.TempDir(), "fast.json")
+	err = os.WriteFile(fastBaseline, []byte(`[{"name": "recursion", "n": 1, "ns_per_op": 1, "cycles_per_op": 1000000000}]`), 0o644)
+	require.NoError(t, err)
+
+	tc := []testMainCase{
+		{
+			args:        []string{"bench-vm", "extra"},
+			errShouldBe: "flag: help requested",
+		},
+		{
+			args:             []string{"bench-vm", "-run", "("},
+			errShouldContain: "invalid -run",
+		},
+		{
+			args:                []string{"bench-vm", "-run", "^recursion$", "-n", "1"},
+			stdoutShouldContain: "recursion\t1\t",
+		},
+		{
+			args:                []string{"bench-vm", "-run", "^recursion$", "-n", "1", "-json"},
+			stdoutShouldContain: `"name": "recursion"`,
+		},
+		{
+			args:                []string{"bench-vm", "-run", "^recursion$", "-n", "1", "-baseline", baseline},
+			stdoutShouldContain: "REGRESSION",
+			errShouldContain:    "performance regressed by more than 10%",
+		},
+		{
+			args:                []string{"bench-vm", "-run", "^recursion$", "-n", "1", "-baseline", fastBaseline, "-cycles-only"},
+			stdoutShouldContain: "recursion",
+		},
+	}
+	testMainCaseRun(t, tc)
+}
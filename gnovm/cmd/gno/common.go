@@ -19,22 +19,30 @@ import (
 type gnoCode string
 
 const (
-	gnoUnknownError    gnoCode = "gnoUnknownError"
-	gnoReadError       gnoCode = "gnoReadError"
-	gnoImportError     gnoCode = "gnoImportError"
-	gnoGnoModError     gnoCode = "gnoGnoModError"
-	gnoPreprocessError gnoCode = "gnoPreprocessError"
-	gnoParserError     gnoCode = "gnoParserError"
-	gnoTypeCheckError  gnoCode = "gnoTypeCheckError"
+	gnoUnknownError     gnoCode = "gnoUnknownError"
+	gnoReadError        gnoCode = "gnoReadError"
+	gnoImportError      gnoCode = "gnoImportError"
+	gnoGnoModError      gnoCode = "gnoGnoModError"
+	gnoPreprocessError  gnoCode = "gnoPreprocessError"
+	gnoParserError      gnoCode = "gnoParserError"
+	gnoTypeCheckError   gnoCode = "gnoTypeCheckError"
+	gnoUnsupportedError gnoCode = "gnoUnsupportedError"
+
+	// Codes of the issues found by the rules of gno lint.
+	gnoUnsupportedImport   gnoCode = "gnoUnsupportedImport"
+	gnoBlockTime           gnoCode = "gnoBlockTime"
+	gnoMapIteration        gnoCode = "gnoMapIteration"
+	gnoUnserializableParam gnoCode = "gnoUnserializableParam"
+	gnoNilMapWrite         gnoCode = "gnoNilMapWrite"
 
 	// TODO: add new gno codes here.
 )
 
 type gnoIssue struct {
-	Code       gnoCode
-	Msg        string
-	Confidence float64 // 1 is 100%
-	Location   string  // file:line, or equivalent
+	Code       gnoCode `json:"code"`
+	Msg        string  `json:"msg"`
+	Confidence float64 `json:"confidence"` // 1 is 100%
+	Location   string  `json:"location"`   // file:line, or equivalent
 	// TODO: consider writing fix suggestions
 }
 
@@ -105,12 +113,20 @@ func parsePkgPathDirective(body string, defaultPkgPath string) (string, error) {
 }
 
 func printError(w io.WriteCloser, dir, pkgPath string, err error) {
+	for _, issue := range issuesFromError(dir, pkgPath, err) {
+		fmt.Fprintln(w, issue)
+	}
+}
+
+// issuesFromError converts err, returned while processing the package pkgPath
+// in dir, to the issues to report.
+func issuesFromError(dir, pkgPath string, err error) []gnoIssue {
 	switch err := err.(type) {
 	case *gno.PreprocessError:
 		err2 := err.Unwrap()
 		// XXX probably no need for guessing, replace with exact issue.
-		fmt.Fprintln(w, guessIssueFromError(
-			dir, pkgPath, err2, gnoPreprocessError).String())
+		return []gnoIssue{guessIssueFromError(
+			dir, pkgPath, err2, gnoPreprocessError)}
 	case gno.ImportError:
 		// NOTE: gnovm/pkg/test.LoadImport will return a
 		// ImportNotFoundError with format "<loc>: unknown import path:
@@ -119,12 +135,12 @@ func printError(w io.WriteCloser, dir, pkgPath string, err error) {
 		// path: <path>"; but Go .Check ends up returning a types.Error
 		// instead, as seen in the hack in the next clause.  So
 		// test.LoadImport needs this and guessing isn't needed.
-		fmt.Fprintln(w, gnoIssue{
+		return []gnoIssue{{
 			Code:       gnoImportError,
 			Msg:        err.GetMsg(),
 			Confidence: 1,
 			Location:   err.GetLocation(),
-		})
+		}}
 	case types.Error:
 		loc := err.Fset.Position(err.Pos).String()
 		loc = guessFilePathLocRel(loc, pkgPath, dir)
@@ -135,50 +151,61 @@ func printError(w io.WriteCloser, dir, pkgPath string, err error) {
 			// on why this is necessary, and how to make it less hacky.
 			code = gnoImportError
 		}
-		fmt.Fprintln(w, gnoIssue{
+		return []gnoIssue{{
 			Code:       code,
 			Msg:        err.Msg,
 			Confidence: 1,
 			Location:   loc,
-		})
+		}}
 	case scanner.ErrorList:
+		issues := make([]gnoIssue, 0, len(err))
 		for _, err := range err {
 			loc := err.Pos.String()
 			loc = guessFilePathLocRel(loc, pkgPath, dir)
-			fmt.Fprintln(w, gnoIssue{
+			issues = append(issues, gnoIssue{
 				Code:       gnoParserError,
 				Msg:        err.Msg,
 				Confidence: 1,
 				Location:   loc,
 			})
 		}
+		return issues
 	case scanner.Error:
 		loc := err.Pos.String()
 		loc = guessFilePathLocRel(loc, pkgPath, dir)
-		fmt.Fprintln(w, gnoIssue{
+		return []gnoIssue{{
 			Code:       gnoParserError,
 			Msg:        err.Msg,
 			Confidence: 1,
 			Location:   loc,
-		})
+		}}
 	default: // error type
 		errors := multierr.Errors(err)
 		if len(errors) == 1 {
-			fmt.Fprintln(w, guessIssueFromError(
+			return []gnoIssue{guessIssueFromError(
 				dir,
 				pkgPath,
 				err,
 				gnoUnknownError,
-			).String())
-			return
+			)}
 		}
+		var issues []gnoIssue
 		for _, err := range errors {
-			printError(w, dir, pkgPath, err)
+			issues = append(issues, issuesFromError(dir, pkgPath, err)...)
 		}
+		return issues
 	}
 }
 
 func catchPanic(dir, pkgPath string, stderr io.WriteCloser, action func()) (didPanic bool) {
+	return catchPanicFunc(func(err error) {
+		printError(stderr, dir, pkgPath, err)
+	}, action)
+}
+
+// catchPanicFunc is like [catchPanic], but passes the recovered errors to
+// onError instead of printing them.
+func catchPanicFunc(onError func(err error), action func()) (didPanic bool) {
 	// If this gets out of hand (e.g. with nested catchPanic with need for
 	// selective catching) then pass in a bool instead.
 	// See also pkg/test/imports.go.
@@ -194,7 +221,7 @@ func catchPanic(dir, pkgPath string, stderr io.WriteCloser, action func()) (didP
 			}
 			didPanic = true
 			if err, ok := r.(error); ok {
-				printError(stderr, dir, pkgPath, err)
+				onError(err)
 			} else {
 				panic(r)
 			}
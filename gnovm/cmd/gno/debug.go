@@ -0,0 +1,185 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"net"
+	"path/filepath"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/test"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+type debugCmd struct {
+	rootDir string
+	expr    string
+	dap     bool
+	addr    string
+}
+
+func newDebugCmd(cio commands.IO) *commands.Command {
+	cfg := &debugCmd{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "debug",
+			ShortUsage: "debug [flags] <file> [<file>...]",
+			ShortHelp:  "run gno packages in the debugger",
+			LongHelp: `Runs the given files or package directories in the GnoVM debugger, stopping at
+breakpoints to step through the program and inspect its stack and variables.
+
+By default, the debugger is interactive: type 'help' at the 'dbg>' prompt for
+the list of commands.
+
+With -dap, the debugger speaks the Debug Adapter Protocol instead, for editors
+and other clients to drive it. The output of the program is sent to the client
+as output events. The program starts once the client sends its configurationDone
+request, stopping at the first statement if the launch request has stopOnEntry.
+
+With -addr, the debugger waits for a client to connect to the given TCP
+address, instead of using stdin and stdout.`,
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execDebug(cfg, args, cio)
+		},
+	)
+}
+
+func (c *debugCmd) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.rootDir,
+		"root-dir",
+		"",
+		"clone location of github.com/gnolang/gno (gno binary tries to guess it)",
+	)
+
+	fs.StringVar(
+		&c.expr,
+		"expr",
+		"main()",
+		"value of expression to evaluate. Defaults to executing function main() with no args",
+	)
+
+	fs.BoolVar(
+		&c.dap,
+		"dap",
+		false,
+		"use the Debug Adapter Protocol",
+	)
+
+	fs.StringVar(
+		&c.addr,
+		"addr",
+		"",
+		"wait for a debugger client to connect to the tcp address in the form [host]:port",
+	)
+}
+
+func execDebug(cfg *debugCmd, args []string, cio commands.IO) error {
+	if len(args) == 0 {
+		return flag.ErrHelp
+	}
+
+	if cfg.rootDir == "" {
+		cfg.rootDir = gnoenv.RootDir()
+	}
+
+	// Breakpoints are set on absolute paths by debugger clients.
+	paths := make([]string, len(args))
+	for i, arg := range args {
+		path, err := filepath.Abs(arg)
+		if err != nil {
+			return err
+		}
+		paths[i] = path
+	}
+	files, err := parseFiles(paths, cio.Err())
+	if err != nil {
+		return err
+	}
+	if len(files) == 0 {
+		return fmt.Errorf("no files to debug")
+	}
+
+	var (
+		in      io.Reader = cio.In()
+		out     io.Writer = cio.Out()
+		session *gno.DAPSession
+	)
+	if cfg.addr != "" && cfg.dap {
+		conn, err := acceptDebugClient(cfg.addr, cio)
+		if err != nil {
+			return err
+		}
+		defer conn.Close()
+		in, out = conn, conn
+	}
+	output := test.OutputWithError(cio.Out(), cio.Err())
+	if cfg.dap {
+		session = gno.NewDAPSession(in, out)
+		output = test.OutputWithError(session.Output("stdout"), session.Output("stderr"))
+		// The program can't read the protocol input.
+		in = strings.NewReader("")
+	}
+
+	_, testStore := test.ProdStore(cfg.rootDir, output, nil)
+	pkgPath := string(files[0].PkgName)
+	m := gno.NewMachineWithOptions(gno.MachineOptions{
+		PkgPath:       pkgPath,
+		Output:        output,
+		Input:         in,
+		Store:         testStore,
+		MaxAllocBytes: maxAllocRun,
+		Context:       test.Context("", pkgPath, nil),
+		Debug:         true,
+	})
+	defer m.Release()
+
+	switch {
+	case session != nil:
+		m.Debugger.EnableDAP(session)
+	case cfg.addr != "":
+		if err := m.Debugger.Serve(cfg.addr); err != nil {
+			return err
+		}
+	}
+
+	err = runDebug(m, files, cfg.expr)
+	if session != nil {
+		exitCode := 0
+		if err != nil {
+			fmt.Fprintln(session.Output("stderr"), err)
+			exitCode = 1
+		}
+		session.Exited(exitCode)
+	}
+	return err
+}
+
+// runDebug runs the files and evaluates expr, returning the panics of the
+// program as errors.
+func runDebug(m *gno.Machine, files []*gno.FileNode, expr string) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	m.RunFiles(files...)
+	return runExpr(m, expr)
+}
+
+func acceptDebugClient(addr string, cio commands.IO) (net.Conn, error) {
+	l, err := net.Listen("tcp", addr)
+	if err != nil {
+		return nil, err
+	}
+	defer l.Close()
+	cio.ErrPrintfln("Waiting for debugger client to connect at %s", l.Addr())
+	return l.Accept()
+}
@@ -0,0 +1,30 @@
+package main
+
+import "testing"
+
+func TestDebugApp(t *testing.T) {
+	tc := []testMainCase{
+		{
+			args:        []string{"debug"},
+			errShouldBe: "flag: help requested",
+		},
+		{
+			args:                []string{"debug", "../../tests/integ/debugger/sample.gno"},
+			stdoutShouldContain: "Welcome to the Gnovm debugger",
+		},
+		{
+			// Without a client, the program runs to completion.
+			args:                []string{"debug", "-dap", "../../tests/integ/debugger/sample.gno"},
+			stdoutShouldContain: `"event":"terminated"`,
+		},
+		{
+			args:             []string{"debug", "-dap", "-addr", "invalidhost:17538", "../../tests/integ/debugger/sample.gno"},
+			errShouldContain: "listen tcp",
+		},
+		{
+			args:             []string{"debug", "../../tests/integ/does_not_exist"},
+			errShouldContain: "no such file or directory",
+		},
+	}
+	testMainCaseRun(t, tc)
+}
@@ -0,0 +1,80 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+
+	"github.com/gnolang/gno/gno.land/pkg/keyscli"
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/client"
+)
+
+// deployCfg holds the flags of gno deploy, which are those of gnokey (like
+// -home and -remote), of gnokey maketx (like -gas-fee and -broadcast) and of
+// gnokey maketx deploy, along with the key signing the transaction.
+type deployCfg struct {
+	base   client.BaseCfg
+	maketx client.MakeTxCfg
+	deploy keyscli.MakeDeployCfg
+	key    string
+}
+
+func newDeployCmd(io commands.IO) *commands.Command {
+	cfg := &deployCfg{}
+	cfg.base.BaseOptions = client.DefaultBaseOptions
+	cfg.maketx.RootCfg = &cfg.base
+	cfg.deploy.RootCfg = &cfg.maketx
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "deploy",
+			ShortUsage: "deploy [flags] <pkgdir>",
+			ShortHelp:  "checks and uploads a package to a chain",
+			LongHelp: `Lints and tests the package in pkgdir, estimates the gas of adding it to the
+chain at -remote, prints a summary and uploads it with the key of -key.
+
+It runs "gnokey maketx deploy", and takes the same flags, with the package
+directory as argument: see "gnokey maketx deploy -h" for the details. Unless
+-broadcast is set, the transaction is only printed; with -dry-run, only the
+checks and the summary are run, and with -json, the summary is printed as
+JSON for CI scripts.`,
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execDeploy(cfg, args, io)
+		},
+	)
+}
+
+func (c *deployCfg) RegisterFlags(fs *flag.FlagSet) {
+	c.base.RegisterFlags(fs)
+	c.maketx.RegisterFlags(fs)
+	c.deploy.RegisterFlags(fs)
+
+	fs.StringVar(
+		&c.key,
+		"key",
+		"",
+		"name or address of the key signing the transaction (required)",
+	)
+}
+
+func execDeploy(cfg *deployCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+	if cfg.deploy.PkgDir != "" {
+		return errors.New("the package directory is given as argument, not with -pkgdir")
+	}
+	if cfg.key == "" {
+		return errors.New("key not specified")
+	}
+	if cfg.base.Home == "" {
+		cfg.base.Home = gnoenv.HomeDir()
+	}
+	cfg.deploy.PkgDir = args[0]
+
+	return keyscli.ExecMakeDeploy(&cfg.deploy, []string{cfg.key}, io)
+}
@@ -0,0 +1,26 @@
+package main
+
+import "testing"
+
+func TestDeployApp(t *testing.T) {
+	tc := []testMainCase{
+		{
+			args:        []string{"deploy"},
+			errShouldBe: "flag: help requested",
+		},
+		{
+			args:             []string{"deploy", "-key", "alice", "-pkgdir", "foo", "bar"},
+			errShouldContain: "not with -pkgdir",
+		},
+		{
+			args:        []string{"deploy", "../../tests/integ/minimalist_gnomod"},
+			errShouldBe: "key not specified",
+		},
+		{
+			// the checks of gnokey maketx deploy
+			args:        []string{"deploy", "-key", "alice", "../../tests/integ/minimalist_gnomod"},
+			errShouldBe: "gas-fee not specified",
+		},
+	}
+	testMainCaseRun(t, tc)
+}
@@ -7,19 +7,23 @@ import (
 	"io/fs"
 	"os"
 	"path/filepath"
+	"strings"
 
 	"github.com/gnolang/gno/gnovm/pkg/doc"
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
 	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload/rpcpkgfetcher"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 )
 
 type docCfg struct {
-	all        bool
-	src        bool
-	unexported bool
-	short      bool
-	rootDir    string
+	all             bool
+	src             bool
+	unexported      bool
+	short           bool
+	rootDir         string
+	remote          bool
+	remoteOverrides string
 }
 
 func newDocCmd(io commands.IO) *commands.Command {
@@ -29,7 +33,10 @@ func newDocCmd(io commands.IO) *commands.Command {
 			Name:       "doc",
 			ShortUsage: "doc [flags] <pkgsym>",
 			ShortHelp:  "show documentation for package or symbol",
-			LongHelp:   "get documentation for the specified package or symbol (type, function, method, or variable/constant)",
+			LongHelp: `get documentation for the specified package or symbol (type, function, method, or variable/constant).
+
+Packages which cannot be found locally and are given by their full path, such as
+gno.land/r/demo/boards, are fetched from the RPC endpoint of their chain.`,
 		},
 		c,
 		func(_ context.Context, args []string) error {
@@ -73,9 +80,48 @@ func (c *docCfg) RegisterFlags(fs *flag.FlagSet) {
 		"",
 		"clone location of github.com/gnolang/gno (gno binary tries to guess it)",
 	)
+
+	fs.BoolVar(
+		&c.remote,
+		"remote",
+		false,
+		"always fetch the package from its chain, even if it is available locally",
+	)
+
+	fs.StringVar(
+		&c.remoteOverrides,
+		remoteOverridesArgName,
+		"",
+		"chain-domain=rpc-url comma-separated list",
+	)
 }
 
 func execDoc(cfg *docCfg, args []string, io commands.IO) error {
+	fetcher := testPackageFetcher
+	if fetcher == nil {
+		remoteOverrides, err := parseRemoteOverrides(cfg.remoteOverrides)
+		if err != nil {
+			return fmt.Errorf("invalid %s flag: %w", remoteOverridesArgName, err)
+		}
+		fetcher = rpcpkgfetcher.New(remoteOverrides)
+	} else if len(cfg.remoteOverrides) != 0 {
+		return fmt.Errorf("can't use %s flag with a custom package fetcher", remoteOverridesArgName)
+	}
+	opts := &doc.WriteDocumentationOptions{
+		ShowAll:    cfg.all,
+		Source:     cfg.src,
+		Unexported: cfg.unexported,
+		Short:      false,
+	}
+
+	if cfg.remote {
+		res, err := doc.ResolveRemoteDocumentable(fetcher, args, cfg.unexported)
+		if err != nil {
+			return err
+		}
+		return res.WriteDocumentation(io.Out(), opts)
+	}
+
 	// guess opts.RootDir
 	if cfg.rootDir == "" {
 		cfg.rootDir = gnoenv.RootDir()
@@ -103,20 +149,29 @@ func execDoc(cfg *docCfg, args []string, io commands.IO) error {
 	dirs := []string{filepath.Join(cfg.rootDir, "gnovm/stdlibs")}
 	res, err := doc.ResolveDocumentable(dirs, modDirs, args, cfg.unexported)
 	if res == nil {
-		return err
+		if !isChainPkgPath(args) {
+			return err
+		}
+		// not available locally: try fetching it from its chain.
+		res, err = doc.ResolveRemoteDocumentable(fetcher, args, cfg.unexported)
+		if err != nil {
+			return err
+		}
 	}
 	if err != nil {
 		io.Printfln("warning: error parsing some candidate packages:\n%v", err)
 	}
-	return res.WriteDocumentation(
-		io.Out(),
-		&doc.WriteDocumentationOptions{
-			ShowAll:    cfg.all,
-			Source:     cfg.src,
-			Unexported: cfg.unexported,
-			Short:      false,
-		},
-	)
+	return res.WriteDocumentation(io.Out(), opts)
+}
+
+// isChainPkgPath reports whether the package in args is given by a full
+// package path whose first element is a chain domain, like gno.land/r/demo/boards.
+func isChainPkgPath(args []string) bool {
+	if len(args) == 0 {
+		return false
+	}
+	domain, _, ok := strings.Cut(args[0], "/")
+	return ok && strings.Contains(domain, ".") && !strings.HasPrefix(domain, ".")
 }
 
 func findGnomodExamples(dir string) ([]string, error) {
@@ -24,6 +24,22 @@ func TestGnoDoc(t *testing.T) {
 			args:             []string{"doc", "There.Are.Too.Many.Dots"},
 			errShouldContain: "invalid arguments",
 		},
+		{
+			args:                []string{"doc", "-remote", "gno.land/p/nt/avl.NewTree"},
+			stdoutShouldContain: "func NewTree() *Tree",
+		},
+		{
+			args:             []string{"doc", "-remote", "gno.land/r/demo/doesnotexist"},
+			errShouldContain: "is not available",
+		},
+		{
+			args:             []string{"doc", "gno.land/r/demo/doesnotexist"},
+			errShouldContain: "is not available",
+		},
+		{
+			args:             []string{"doc", "-remote", "avl"},
+			errShouldContain: "not a remote package path",
+		},
 	}
 	testMainCaseRun(t, tc)
 }
@@ -21,12 +21,14 @@ import (
 )
 
 type fmtCfg struct {
-	write   bool
-	quiet   bool
-	diff    bool
-	verbose bool
-	imports bool
-	include fmtIncludes
+	write     bool
+	list      bool
+	printDiff bool
+	quiet     bool
+	diff      bool
+	verbose   bool
+	imports   bool
+	include   fmtIncludes
 }
 
 func newFmtCmd(io commands.IO) *commands.Command {
@@ -36,7 +38,11 @@ func newFmtCmd(io commands.IO) *commands.Command {
 			Name:       "fmt",
 			ShortUsage: "gno fmt [flags] [path ...]",
 			ShortHelp:  "gnofmt (reformat) package sources",
-			LongHelp:   "The `gno fmt` tool processes, formats, and cleans up `gno` source files.",
+			LongHelp: `The "gno fmt" tool processes, formats, and cleans up gno source files.
+
+The paths can be .gno files, package directories, or patterns like ./...; only
+the .gno files are processed, so the .gen.go files generated from them are left
+untouched. By default, the formatted sources are printed to stdout.`,
 		},
 		cfg,
 		func(_ context.Context, args []string) error {
@@ -52,6 +58,20 @@ func (c *fmtCfg) RegisterFlags(fs *flag.FlagSet) {
 		"write result to (source) file instead of stdout",
 	)
 
+	fs.BoolVar(
+		&c.list,
+		"l",
+		false,
+		"list files whose formatting differs from gno fmt's, instead of printing them",
+	)
+
+	fs.BoolVar(
+		&c.printDiff,
+		"d",
+		false,
+		"display diffs instead of printing the formatted sources",
+	)
+
 	fs.BoolVar(
 		&c.verbose,
 		"v",
@@ -160,8 +180,11 @@ func fmtProcessSingleFile(cfg *fmtCfg, file string, processFile fmtProcessFileFu
 	if cfg.diff && fmtProcessDiff(file, out, io) {
 		return false
 	}
+	if (cfg.list || cfg.printDiff) && !fmtReportChanges(cfg, file, out, io) {
+		return false
+	}
 	if !cfg.write {
-		if !cfg.diff && !cfg.quiet {
+		if !cfg.diff && !cfg.list && !cfg.printDiff && !cfg.quiet {
 			io.Out().Write(out)
 		}
 		return true
@@ -191,6 +214,30 @@ func fmtProcessDiff(file string, data []byte, io commands.IO) bool {
 	return false
 }
 
+// fmtReportChanges prints the name of the file (-l), or the diff of its
+// formatting (-d), if the formatted data differs from the file.
+// Returns false if the file can't be read
+func fmtReportChanges(cfg *fmtCfg, file string, data []byte, io commands.IO) bool {
+	src, err := os.ReadFile(file)
+	if err != nil {
+		io.ErrPrintfln("unable to read %q: %v", file, err)
+		return false
+	}
+
+	if bytes.Equal(src, data) {
+		return true
+	}
+
+	if cfg.list {
+		io.Println(file)
+	}
+	if cfg.printDiff {
+		io.Out().Write(diff.Diff(file+".orig", src, file, data))
+	}
+
+	return true
+}
+
 func fmtFormatFileImports(cfg *fmtCfg, io commands.IO) (fmtProcessFileFunc, error) {
 	r := gnofmt.NewFSResolver()
 
@@ -0,0 +1,297 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/build"
+	"go/format"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/gnovm/pkg/packages"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+type importGoCmd struct {
+	verbose  bool
+	output   string
+	pkgPath  string
+	rewrites string
+	rootDir  string
+}
+
+func newImportGoCmd(io commands.IO) *commands.Command {
+	cmd := &importGoCmd{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "import-go",
+			ShortUsage: "import-go [flags] <pkg>",
+			ShortHelp:  "converts a Go package to Gno",
+			LongHelp: `Converts the Go package <pkg> to a Gno package, by writing its .go files
+as .gno files in the output directory. <pkg> is either a directory, or an
+import path resolved like the go command does, such as "golang.org/x/text/cases".
+
+Only a subset of Go can be converted: cgo, assembly and package unsafe
+aren't supported in Gno. The imports are kept when the package
+is part of the Gno standard libraries, and can be rewritten to Gno packages
+with -rewrite, such as "github.com/foo/avl=gno.land/p/foo/avl". Imports of
+sub-packages are rewritten along with the package.
+
+A diagnostic is printed for each unsupported construct found. The files are
+written anyway, so they can be ported by hand, but the command fails.`,
+		},
+		cmd,
+		func(_ context.Context, args []string) error {
+			return execImportGo(cmd, args, io)
+		},
+	)
+}
+
+func (c *importGoCmd) RegisterFlags(fs *flag.FlagSet) {
+	fs.BoolVar(&c.verbose, "v", false, "verbose output when converting")
+	fs.StringVar(&c.output, "output", ".", "output directory")
+	fs.StringVar(&c.pkgPath, "pkgpath", "", "package path of the Gno package, written to gnomod.toml if set")
+	fs.StringVar(&c.rewrites, "rewrite", "", "comma-separated list of import path rewrites, as old=new")
+	fs.StringVar(&c.rootDir, "root-dir", "", "clone location of github.com/gnolang/gno (gno tries to guess it)")
+}
+
+func execImportGo(cmd *importGoCmd, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	rewrites, err := parseImportRewrites(cmd.rewrites)
+	if err != nil {
+		return err
+	}
+	if cmd.pkgPath != "" && !gno.IsUserlib(cmd.pkgPath) {
+		return fmt.Errorf("%q is not a valid package path URL", cmd.pkgPath)
+	}
+	if cmd.rootDir == "" {
+		cmd.rootDir = gnoenv.RootDir()
+	}
+
+	// Load cgo files too, so they can be reported.
+	ctxt := build.Default
+	ctxt.CgoEnabled = true
+
+	bpkg, dir, err := importGoPackage(&ctxt, args[0])
+	if err != nil {
+		return fmt.Errorf("unable to load Go package: %w", err)
+	}
+
+	conv := &goConverter{
+		rootDir:  cmd.rootDir,
+		rewrites: rewrites,
+		fset:     token.NewFileSet(),
+	}
+	for _, fname := range bpkg.CgoFiles {
+		conv.report(filepath.Join(dir, fname), "cgo is not supported")
+	}
+	for _, fname := range slices.Concat(bpkg.SFiles, bpkg.CFiles, bpkg.HFiles) {
+		conv.report(filepath.Join(dir, fname), "non-Go source files are not supported")
+	}
+
+	if err := os.MkdirAll(cmd.output, 0o755); err != nil {
+		return fmt.Errorf("unable to create output directory: %w", err)
+	}
+
+	files := slices.Concat(bpkg.GoFiles, bpkg.TestGoFiles, bpkg.XTestGoFiles)
+	for _, fname := range files {
+		src := filepath.Join(dir, fname)
+		out, err := conv.convertFile(src)
+		if err != nil {
+			return err
+		}
+
+		dst := filepath.Join(cmd.output, strings.TrimSuffix(fname, ".go")+".gno")
+		if err := os.WriteFile(dst, out, 0o644); err != nil {
+			return fmt.Errorf("unable to write %s: %w", dst, err)
+		}
+		if cmd.verbose {
+			io.ErrPrintln(dst)
+		}
+	}
+
+	if cmd.pkgPath != "" {
+		modfile := new(gnomod.File)
+		modfile.Module = cmd.pkgPath
+		modfile.Gno = gno.GnoVerLatest
+		if err := modfile.WriteFile(filepath.Join(cmd.output, "gnomod.toml")); err != nil {
+			return fmt.Errorf("writing gnomod.toml: %w", err)
+		}
+	}
+
+	for _, issue := range conv.issues {
+		io.ErrPrintln(issue)
+	}
+	if len(conv.issues) > 0 {
+		return commands.ExitCodeError(1)
+	}
+
+	return nil
+}
+
+// importGoPackage loads the Go package at pkg, which is either a directory or
+// an import path. It returns the package along with its directory, kept as
+// given for directories so that the diagnostics use the same paths.
+func importGoPackage(ctxt *build.Context, pkg string) (*build.Package, string, error) {
+	if fi, err := os.Stat(pkg); err == nil && fi.IsDir() {
+		bpkg, err := ctxt.ImportDir(pkg, 0)
+		return bpkg, pkg, err
+	}
+	if build.IsLocalImport(pkg) {
+		return nil, "", fmt.Errorf("directory %s not found", pkg)
+	}
+
+	wd, err := os.Getwd()
+	if err != nil {
+		return nil, "", err
+	}
+	bpkg, err := ctxt.Import(pkg, wd, 0)
+	if err != nil {
+		return nil, "", err
+	}
+	return bpkg, bpkg.Dir, nil
+}
+
+// importRewrite replaces the import path prefix old by new.
+type importRewrite struct {
+	old, new string
+}
+
+func parseImportRewrites(s string) ([]importRewrite, error) {
+	if s == "" {
+		return nil, nil
+	}
+
+	var rewrites []importRewrite
+	for _, rw := range strings.Split(s, ",") {
+		old, new, ok := strings.Cut(strings.TrimSpace(rw), "=")
+		if !ok || old == "" || new == "" {
+			return nil, fmt.Errorf("invalid import rewrite %q, expected old=new", rw)
+		}
+		rewrites = append(rewrites, importRewrite{old: old, new: new})
+	}
+
+	return rewrites, nil
+}
+
+// goConverter converts Go files to Gno, collecting the issues
+// found along the way.
+type goConverter struct {
+	rootDir  string
+	rewrites []importRewrite
+	fset     *token.FileSet
+	issues   []gnoIssue
+}
+
+func (c *goConverter) report(location, msg string) {
+	c.issues = append(c.issues, gnoIssue{
+		Code:       gnoUnsupportedError,
+		Msg:        msg,
+		Confidence: 1,
+		Location:   location,
+	})
+}
+
+func (c *goConverter) reportAt(pos token.Pos, format string, args ...any) {
+	c.report(c.fset.Position(pos).String(), fmt.Sprintf(format, args...))
+}
+
+// convertFile returns the Gno source of the Go file at path.
+func (c *goConverter) convertFile(path string) ([]byte, error) {
+	f, err := parser.ParseFile(c.fset, path, nil, parser.ParseComments)
+	if err != nil {
+		return nil, fmt.Errorf("unable to parse %s: %w", path, err)
+	}
+
+	isTest := strings.HasSuffix(path, "_test.go")
+	for _, imp := range f.Imports {
+		c.convertImport(imp, isTest)
+	}
+
+	// Build constraints were already evaluated when loading the package.
+	for _, cg := range f.Comments {
+		cg.List = slices.DeleteFunc(cg.List, func(cm *ast.Comment) bool {
+			if strings.HasPrefix(cm.Text, "//go:build") || strings.HasPrefix(cm.Text, "// +build") {
+				return true
+			}
+			if strings.HasPrefix(cm.Text, "//go:") {
+				c.reportAt(cm.Pos(), "directive %s is not supported", strings.Fields(cm.Text)[0])
+			}
+			return false
+		})
+	}
+	f.Comments = slices.DeleteFunc(f.Comments, func(cg *ast.CommentGroup) bool {
+		return len(cg.List) == 0
+	})
+
+	var buf bytes.Buffer
+	if err := format.Node(&buf, c.fset, f); err != nil {
+		return nil, fmt.Errorf("unable to format %s: %w", path, err)
+	}
+
+	return buf.Bytes(), nil
+}
+
+// convertImport rewrites the import path of imp, or reports it if it isn't
+// available in Gno.
+func (c *goConverter) convertImport(imp *ast.ImportSpec, isTest bool) {
+	path, err := strconv.Unquote(imp.Path.Value)
+	if err != nil {
+		return
+	}
+
+	for _, rw := range c.rewrites {
+		if path == rw.old || strings.HasPrefix(path, rw.old+"/") {
+			imp.Path.Value = strconv.Quote(rw.new + strings.TrimPrefix(path, rw.old))
+			return
+		}
+	}
+
+	switch {
+	case path == "C":
+		// Reported with the cgo files.
+	case path == "unsafe":
+		c.reportAt(imp.Pos(), "package unsafe is not supported")
+	case gno.IsStdlib(path):
+		if !isStdlibAvailable(c.rootDir, path, isTest) {
+			c.reportAt(imp.Pos(), "package %q is not available in the Gno standard libraries", path)
+		}
+	default:
+		c.reportAt(imp.Pos(), "package %q must be rewritten to a Gno package path with -rewrite", path)
+	}
+}
+
+// isStdlibAvailable returns true if the standard library at path is
+// implemented in Gno, including the testing standard libraries for tests.
+func isStdlibAvailable(rootDir, path string, isTest bool) bool {
+	dirs := []string{packages.StdlibDir(rootDir, path)}
+	if isTest {
+		dirs = append(dirs, filepath.Join(rootDir, "gnovm", "tests", "stdlibs", filepath.FromSlash(path)))
+	}
+
+	for _, dir := range dirs {
+		entries, _ := os.ReadDir(dir)
+		if slices.ContainsFunc(entries, func(e os.DirEntry) bool {
+			return !e.IsDir() && strings.HasSuffix(e.Name(), ".gno")
+		}) {
+			return true
+		}
+	}
+
+	return false
+}
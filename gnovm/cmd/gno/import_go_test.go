@@ -0,0 +1,40 @@
+package main
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestImportGoApp(t *testing.T) {
+	tc := []testMainCase{
+		{
+			args:        []string{"import-go"},
+			errShouldBe: "flag: help requested",
+		}, {
+			args:             []string{"import-go", "-rewrite", "github.com/foo/avl", "."},
+			errShouldContain: `invalid import rewrite "github.com/foo/avl"`,
+		}, {
+			args:             []string{"import-go", "-pkgpath", "avl", "."},
+			errShouldContain: `"avl" is not a valid package path URL`,
+		},
+
+		// XXX: conversions are tested in `testdata/import_go/*.txtar`.
+	}
+	testMainCaseRun(t, tc)
+}
+
+func TestParseImportRewrites(t *testing.T) {
+	rewrites, err := parseImportRewrites("github.com/foo/avl=gno.land/p/foo/avl, github.com/bar=gno.land/p/bar")
+	require.NoError(t, err)
+	assert.Equal(t, []importRewrite{
+		{old: "github.com/foo/avl", new: "gno.land/p/foo/avl"},
+		{old: "github.com/bar", new: "gno.land/p/bar"},
+	}, rewrites)
+
+	for _, s := range []string{"github.com/foo/avl", "=gno.land/p/foo/avl", "github.com/foo/avl="} {
+		_, err := parseImportRewrites(s)
+		assert.Error(t, err, s)
+	}
+}
@@ -38,6 +38,14 @@ modification may be required in these cases.`,
 		F:       interrealm,
 		Version: "0.9",
 	},
+	{
+		Name: "realmapi",
+		Date: "2026-10-16",
+		Desc: `rewrites calls of the Realm API removed in the std renames, which are not
+simple renames: std.CurrentRealmPath() and the Addr method of realms.
+It runs before stdsplit, which moves the rewritten calls to the new packages.`,
+		F: realmapi,
+	},
 	{
 		Name: "stdsplit",
 		Date: "2025-08-13",
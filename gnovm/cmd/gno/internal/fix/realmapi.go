@@ -0,0 +1,107 @@
+package fix
+
+import (
+	"go/ast"
+	"slices"
+
+	"golang.org/x/tools/go/ast/astutil"
+)
+
+// realmFuncs lists the functions returning a Realm, by import path.
+var realmFuncs = map[string][]string{
+	"std":           {"CurrentRealm", "PrevRealm", "PreviousRealm"},
+	"chain/runtime": {"CurrentRealm", "PreviousRealm"},
+}
+
+// realmapi rewrites the calls of the Realm API removed with the std renames of
+// https://github.com/gnolang/gno/pull/3374 which cannot be converted with a
+// simple rename of the symbol:
+//
+//   - std.CurrentRealmPath() becomes std.CurrentRealm().PkgPath()
+//   - the Addr method of realms becomes Address
+//
+// As the fix works without type information, the Addr method is only renamed
+// when called directly on the result of a function returning a Realm,
+// such as std.PrevRealm().Addr().
+func realmapi(f *ast.File) (fixed bool) {
+	apply(
+		f,
+		func(c *astutil.Cursor, sc scopes) bool {
+			switch n := c.Node().(type) {
+			case *ast.ImportSpec:
+				unq := importPath(n)
+
+				switch {
+				case n.Name != nil:
+					sc.declare(n.Name, n)
+				case unq == "std":
+					sc.declare(ast.NewIdent("std"), n)
+				case unq == "chain/runtime":
+					sc.declare(ast.NewIdent("runtime"), n)
+				default:
+					return false
+				}
+			case *ast.CallExpr:
+				sel, ok := n.Fun.(*ast.SelectorExpr)
+				if !ok || len(n.Args) != 0 {
+					break
+				}
+
+				switch {
+				case sel.Sel.Name == "CurrentRealmPath" && importedPath(sc, sel) == "std":
+					c.Replace(&ast.CallExpr{
+						Fun: &ast.SelectorExpr{
+							X: &ast.CallExpr{
+								Fun: &ast.SelectorExpr{
+									X:   sel.X,
+									Sel: &ast.Ident{NamePos: sel.Sel.Pos(), Name: "CurrentRealm"},
+								},
+								Lparen: n.Lparen,
+								Rparen: n.Lparen,
+							},
+							Sel: &ast.Ident{NamePos: n.Rparen, Name: "PkgPath"},
+						},
+						Lparen: n.Rparen,
+						Rparen: n.Rparen,
+					})
+					fixed = true
+				case sel.Sel.Name == "Addr" && isRealmCall(sc, sel.X):
+					sel.Sel = &ast.Ident{NamePos: sel.Sel.Pos(), Name: "Address"}
+					fixed = true
+				}
+			}
+
+			return true
+		},
+		nil,
+	)
+
+	return
+}
+
+// importedPath returns the import path of the package of the qualified
+// identifier sel, or "" if sel is not a qualified identifier.
+func importedPath(sc scopes, sel *ast.SelectorExpr) string {
+	id, ok := sel.X.(*ast.Ident)
+	if !ok {
+		return ""
+	}
+	def, ok := sc.lookup(id.Name).(*ast.ImportSpec)
+	if !ok {
+		return ""
+	}
+	return importPath(def)
+}
+
+// isRealmCall reports whether x is a call to a function returning a Realm.
+func isRealmCall(sc scopes, x ast.Expr) bool {
+	call, ok := x.(*ast.CallExpr)
+	if !ok {
+		return false
+	}
+	sel, ok := call.Fun.(*ast.SelectorExpr)
+	if !ok {
+		return false
+	}
+	return slices.Contains(realmFuncs[importedPath(sc, sel)], sel.Sel.Name)
+}
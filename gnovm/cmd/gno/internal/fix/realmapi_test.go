@@ -0,0 +1,139 @@
+package fix
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestRealmapi(t *testing.T) {
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+		fixed    bool
+	}{
+		{
+			name: "CurrentRealmPath",
+			input: `package test
+
+import "std"
+
+func main() {
+	println(std.CurrentRealmPath())
+}
+`,
+			expected: `package test
+
+import "std"
+
+func main() {
+	println(std.CurrentRealm().PkgPath())
+}
+`,
+			fixed: true,
+		},
+		{
+			name: "Addr of realm calls",
+			input: `package test
+
+import (
+	"chain/runtime"
+	s "std"
+)
+
+func main() {
+	println(s.PrevRealm().Addr(), s.CurrentRealm().Addr())
+	println(runtime.PreviousRealm().Addr())
+}
+`,
+			expected: `package test
+
+import (
+	"chain/runtime"
+	s "std"
+)
+
+func main() {
+	println(s.PrevRealm().Address(), s.CurrentRealm().Address())
+	println(runtime.PreviousRealm().Address())
+}
+`,
+			fixed: true,
+		},
+		{
+			name: "untyped receivers are left unchanged",
+			input: `package test
+
+import "std"
+
+func main() {
+	r := std.CurrentRealm()
+	println(r.Addr(), foo().Addr())
+}
+`,
+			fixed: false,
+		},
+		{
+			name: "shadowed import",
+			input: `package test
+
+import "std"
+
+func main() {
+	std := foo{}
+	println(std.CurrentRealmPath(), std.CurrentRealm().Addr())
+}
+`,
+			fixed: false,
+		},
+		{
+			name: "other packages",
+			input: `package test
+
+import "gno.land/p/demo/std"
+
+func main() {
+	println(std.CurrentRealmPath())
+}
+`,
+			fixed: false,
+		},
+	}
+
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			fset, f := mustParse(tc.input)
+			assert.Equal(t, tc.fixed, realmapi(f))
+
+			expected := tc.expected
+			if !tc.fixed {
+				expected = tc.input
+			}
+			assert.Equal(t, expected, doFormat(fset, f))
+		})
+	}
+}
+
+func TestRealmapi_Stdsplit(t *testing.T) {
+	const src = `package test
+
+import "std"
+
+func main() {
+	println(std.CurrentRealmPath(), std.PrevRealm().Addr())
+}
+`
+	const want = `package test
+
+import "chain/runtime"
+
+func main() {
+	println(runtime.CurrentRealm().PkgPath(), runtime.PreviousRealm().Address())
+}
+`
+	fset, f := mustParse(src)
+	assert.True(t, realmapi(f))
+	assert.True(t, stdsplit(f))
+	assert.Equal(t, want, doFormat(fset, f))
+}
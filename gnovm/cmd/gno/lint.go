@@ -2,9 +2,13 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
 	goio "io"
 	"io/fs"
 	"path/filepath"
@@ -26,11 +30,12 @@ import (
 */
 
 type lintCmd struct {
-	verbose    bool
-	rootDir    string
-	autoGnomod bool
-	// min_confidence: minimum confidence of a problem to print it
-	// (default 0.8) auto-fix: apply suggested fixes automatically.
+	verbose       bool
+	rootDir       string
+	autoGnomod    bool
+	minConfidence float64
+	json          bool
+	// auto-fix: apply suggested fixes automatically.
 }
 
 func newLintCmd(io commands.IO) *commands.Command {
@@ -55,6 +60,8 @@ func (c *lintCmd) RegisterFlags(fs *flag.FlagSet) {
 	fs.BoolVar(&c.verbose, "v", false, "verbose output when lintning")
 	fs.StringVar(&c.rootDir, "root-dir", rootdir, "clone location of github.com/gnolang/gno (gno tries to guess it)")
 	fs.BoolVar(&c.autoGnomod, "auto-gnomod", true, "auto-generate gnomod.toml file if not already present")
+	fs.Float64Var(&c.minConfidence, "min-confidence", 0.8, "minimum confidence of an issue to report it, between 0 and 1")
+	fs.BoolVar(&c.json, "json", false, "print the issues to stdout as JSON objects, one per line")
 }
 
 func execLint(cmd *lintCmd, args []string, io commands.IO) error {
@@ -82,6 +89,7 @@ func execLint(cmd *lintCmd, args []string, io commands.IO) error {
 	}
 
 	hasError := false
+	lp := &lintPrinter{io: io, json: cmd.json, minConfidence: cmd.minConfidence}
 
 	prodbs, prodgs := test.StoreWithOptions(
 		cmd.rootDir, goio.Discard,
@@ -161,7 +169,7 @@ func execLint(cmd *lintCmd, args []string, io commands.IO) error {
 				Location:   fpath,
 				Msg:        err.Error(),
 			}
-			io.ErrPrintln(issue)
+			lp.printIssue(issue)
 			hasError = true
 			return commands.ExitCodeError(1)
 		}
@@ -172,7 +180,7 @@ func execLint(cmd *lintCmd, args []string, io commands.IO) error {
 		pkgPath, _ := determinePkgPath(mod, dir, cmd.rootDir)
 		mpkg, err := gno.ReadMemPackage(dir, pkgPath, gno.MPAnyAll)
 		if err != nil {
-			printError(io.Err(), dir, pkgPath, err)
+			lp.printError(dir, pkgPath, err)
 			hasError = true
 			continue
 		}
@@ -185,10 +193,20 @@ func execLint(cmd *lintCmd, args []string, io commands.IO) error {
 			continue
 		}
 
+		// Imports of standard libraries only available in tests fail to
+		// type check; report them more clearly.
+		if issues := lintImports(cmd.rootDir, dir, mpkg); len(issues) > 0 {
+			for _, issue := range issues {
+				lp.printIssue(issue)
+			}
+			hasError = true
+			continue
+		}
+
 		// Perform imports using the parent store.
 		abortOnError := true
 		if err := test.LoadImports(testgs, mpkg, abortOnError); err != nil {
-			printError(io.Err(), dir, pkgPath, err)
+			lp.printError(dir, pkgPath, err)
 			hasError = true
 			continue
 		}
@@ -237,7 +255,9 @@ func execLint(cmd *lintCmd, args []string, io commands.IO) error {
 		}
 
 		// Handle runtime errors
-		didPanic := catchPanic(dir, pkgPath, io.Err(), func() {
+		didPanic := catchPanicFunc(func(err error) {
+			lp.printError(dir, pkgPath, err)
+		}, func() {
 			// Memo process results here.
 			ppkg := cmdutil.ProcessedPackage{MPkg: mpkg, Dir: dir}
 
@@ -256,7 +276,7 @@ func execLint(cmd *lintCmd, args []string, io commands.IO) error {
 			if cmd.autoGnomod {
 				tcmode = gno.TCLatestRelaxed
 			}
-			errs := lintTypeCheck(io, dir, mpkg, gno.TypeCheckOptions{
+			tcfset, tcfiles, info, errs := lintTypeCheck(lp, dir, mpkg, gno.TypeCheckOptions{
 				Getter:     newProdGnoStore(),
 				TestGetter: newTestGnoStore(true),
 				Mode:       tcmode,
@@ -268,6 +288,13 @@ func execLint(cmd *lintCmd, args []string, io commands.IO) error {
 				return
 			}
 
+			// LINT STEP 3: Gno-specific rules.
+			for _, issue := range lintRules(dir, pkgPath, tcfset, tcfiles, info) {
+				if lp.printIssue(issue) {
+					hasError = true
+				}
+			}
+
 			// Construct machine for testing.
 			tm := test.Machine(newProdGnoStore(), goio.Discard, pkgPath, false)
 			defer tm.Release()
@@ -313,7 +340,7 @@ func execLint(cmd *lintCmd, args []string, io commands.IO) error {
 					pkgPath := fmt.Sprintf("%s_filetest%d", mpkg.Path, i)
 					pkgPath, err = parsePkgPathDirective(mfile.Body, pkgPath)
 					if err != nil {
-						io.ErrPrintln(err)
+						lp.printError(dir, pkgPath, err)
 						hasError = true
 						continue
 					}
@@ -359,30 +386,71 @@ func execLint(cmd *lintCmd, args []string, io commands.IO) error {
 	return nil
 }
 
-// Wrapper around TypeCheckMemPackage() to io.ErrPrintln(gnoIssue{}).
-// Prints and returns errors. Panics upon an unexpected error.
+// Wrapper around TypeCheckMemPackageInfo() to print the type checking errors.
+// Returns the type checked production files and their type information, for
+// the lint rules, and the errors. Panics upon an unexpected error.
 func lintTypeCheck(
 	// Args:
-	io commands.IO,
+	lp *lintPrinter,
 	dir string,
 	mpkg *std.MemPackage,
 	opts gno.TypeCheckOptions) (
 	// Results:
+	fset *token.FileSet,
+	files []*ast.File,
+	info *types.Info,
 	lerr error,
 ) {
-	// gno.TypeCheckMemPackage(mpkg, testStore).
-	_, tcErrs := gno.TypeCheckMemPackage(mpkg, opts)
+	info = &types.Info{
+		Types: make(map[ast.Expr]types.TypeAndValue),
+		Defs:  make(map[*ast.Ident]types.Object),
+		Uses:  make(map[*ast.Ident]types.Object),
+	}
+	// gno.TypeCheckMemPackageInfo(mpkg, testStore).
+	fset, files, tcErrs := gno.TypeCheckMemPackageInfo(mpkg, opts, info)
 
 	// Print errors, and return the first unexpected error.
 	errors := multierr.Errors(tcErrs)
 	for _, err := range errors {
-		printError(io.Err(), dir, mpkg.Path, err)
+		lp.printError(dir, mpkg.Path, err)
 	}
 
 	lerr = tcErrs
 	return
 }
 
+// lintPrinter prints the issues found by gno lint to stderr or, with -json,
+// to stdout as JSON objects, one per line. The issues with a confidence
+// below minConfidence are not printed.
+type lintPrinter struct {
+	io            commands.IO
+	json          bool
+	minConfidence float64
+}
+
+// printIssue prints issue, and reports whether it was.
+func (lp *lintPrinter) printIssue(issue gnoIssue) bool {
+	if issue.Confidence < lp.minConfidence {
+		return false
+	}
+	if !lp.json {
+		lp.io.ErrPrintln(issue)
+		return true
+	}
+	bz, err := json.Marshal(issue)
+	if err != nil {
+		panic(fmt.Errorf("unexpected error marshaling issue: %w", err))
+	}
+	lp.io.Println(string(bz))
+	return true
+}
+
+func (lp *lintPrinter) printError(dir, pkgPath string, err error) {
+	for _, issue := range issuesFromError(dir, pkgPath, err) {
+		lp.printIssue(issue)
+	}
+}
+
 func lintTargetName(pkg *packages.Package) string {
 	if pkg.ImportPath != "" {
 		return pkg.ImportPath
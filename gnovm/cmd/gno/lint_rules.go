@@ -0,0 +1,355 @@
+package main
+
+import (
+	"fmt"
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/packages"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+/*
+	Gno-specific lint rules.
+
+	They find code which type checks and preprocesses fine, but is likely to
+	misbehave on chain. The confidence of an issue reflects how likely it is
+	to be a real problem; by default, gno lint only reports those with a
+	confidence of at least 0.8 (see -min-confidence).
+*/
+
+// lintImports reports the imports of the production files of mpkg which are
+// standard libraries only available in tests, like "fmt" and "testing". The
+// imports which don't exist at all are reported by the type checker.
+func lintImports(rootDir, dir string, mpkg *std.MemPackage) []gnoIssue {
+	var issues []gnoIssue
+	fset := token.NewFileSet()
+	for _, mfile := range mpkg.Files {
+		if !strings.HasSuffix(mfile.Name, ".gno") || gno.IsTestFile(mfile.Name) {
+			continue
+		}
+		imports, err := packages.FileImports(mfile.Name, mfile.Body, fset)
+		if err != nil {
+			continue // reported by the type checker.
+		}
+		for _, imp := range imports {
+			if !gno.IsStdlib(imp.PkgPath) {
+				continue
+			}
+			rel := filepath.FromSlash(imp.PkgPath)
+			if dirExists(filepath.Join(rootDir, "gnovm", "stdlibs", rel)) {
+				continue
+			}
+			if !dirExists(filepath.Join(rootDir, "gnovm", "tests", "stdlibs", rel)) {
+				continue
+			}
+			issues = append(issues, gnoIssue{
+				Code:       gnoUnsupportedImport,
+				Msg:        fmt.Sprintf("%q is only available in tests", imp.PkgPath),
+				Confidence: 1,
+				Location:   guessFilePathLocRel(fset.Position(imp.Spec.Pos()).String(), mpkg.Path, dir),
+			})
+		}
+	}
+	return issues
+}
+
+// lintRules runs the rules requiring type information on files, the type
+// checked production files of the package pkgPath in dir.
+func lintRules(dir, pkgPath string, fset *token.FileSet, files []*ast.File, info *types.Info) []gnoIssue {
+	l := &linter{
+		dir:     dir,
+		pkgPath: pkgPath,
+		fset:    fset,
+		info:    info,
+	}
+	isRealm := gno.IsRealmPath(pkgPath)
+	for _, gof := range files {
+		if isRealm {
+			l.blockTime(gof)
+			l.unserializableParams(gof)
+		}
+		l.mapIteration(gof)
+	}
+	l.nilMapWrites(files)
+	slices.SortStableFunc(l.issues, func(a, b lintIssue) int {
+		return int(a.pos - b.pos)
+	})
+	issues := make([]gnoIssue, len(l.issues))
+	for i, li := range l.issues {
+		issues[i] = li.gnoIssue
+	}
+	return issues
+}
+
+type linter struct {
+	dir     string
+	pkgPath string
+	fset    *token.FileSet
+	info    *types.Info
+	issues  []lintIssue
+}
+
+// lintIssue is an issue found by a rule, at pos.
+type lintIssue struct {
+	gnoIssue
+	pos token.Pos
+}
+
+func (l *linter) report(pos token.Pos, code gnoCode, confidence float64, format string, args ...any) {
+	loc := guessFilePathLocRel(l.fset.Position(pos).String(), l.pkgPath, l.dir)
+	l.issues = append(l.issues, lintIssue{
+		gnoIssue: gnoIssue{
+			Code:       code,
+			Msg:        fmt.Sprintf(format, args...),
+			Confidence: confidence,
+			Location:   loc,
+		},
+		pos: pos,
+	})
+}
+
+// blockTime reports calls to time.Now in realms: it returns the time of the
+// block, which is chosen by its proposer and is the same for all of its
+// transactions, so it is neither precise nor unique.
+func (l *linter) blockTime(gof *ast.File) {
+	ast.Inspect(gof, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		sel, ok := ast.Unparen(call.Fun).(*ast.SelectorExpr)
+		if !ok {
+			return true
+		}
+		fn, ok := l.info.Uses[sel.Sel].(*types.Func)
+		if ok && fn.Pkg() != nil && fn.Pkg().Path() == "time" && fn.Name() == "Now" {
+			l.report(call.Pos(), gnoBlockTime, 0.5,
+				"time.Now returns the time of the block, which is set by its proposer and shared by all of its transactions")
+		}
+		return true
+	})
+}
+
+// mapIteration reports the ranges over maps which write package variables,
+// ie. the state of the realm: the order of iteration of a map depends on the
+// order in which its keys were inserted, so the resulting state may too.
+func (l *linter) mapIteration(gof *ast.File) {
+	ast.Inspect(gof, func(n ast.Node) bool {
+		rng, ok := n.(*ast.RangeStmt)
+		if !ok {
+			return true
+		}
+		if t := l.info.TypeOf(rng.X); t == nil {
+			return true
+		} else if _, ok := t.Underlying().(*types.Map); !ok {
+			return true
+		}
+		ast.Inspect(rng.Body, func(n ast.Node) bool {
+			var lhs []ast.Expr
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				if n.Tok == token.DEFINE {
+					return true
+				}
+				lhs = n.Lhs
+			case *ast.IncDecStmt:
+				lhs = []ast.Expr{n.X}
+			default:
+				return true
+			}
+			for _, x := range lhs {
+				if v := l.packageVar(x); v != nil {
+					l.report(x.Pos(), gnoMapIteration, 0.5,
+						"package variable %s is written while iterating over a map, whose order depends on the order of insertion of its keys", v.Name())
+				}
+			}
+			return true
+		})
+		return true
+	})
+}
+
+// packageVar returns the package variable of the package being linted which
+// is written by assigning to x, or nil.
+func (l *linter) packageVar(x ast.Expr) *types.Var {
+	for {
+		switch e := x.(type) {
+		case *ast.ParenExpr:
+			x = e.X
+		case *ast.SelectorExpr:
+			x = e.X
+		case *ast.IndexExpr:
+			x = e.X
+		case *ast.StarExpr:
+			x = e.X
+		case *ast.Ident:
+			v, ok := l.info.Uses[e].(*types.Var)
+			if !ok || v.Parent() == nil || v.Parent().Parent() != types.Universe {
+				return nil
+			}
+			return v
+		default:
+			return nil
+		}
+	}
+}
+
+// unserializableParams reports the parameters of exported realm functions
+// which can't be passed by a MsgCall, which only supports the primitive
+// types and byte arrays and slices.
+func (l *linter) unserializableParams(gof *ast.File) {
+	for _, decl := range gof.Decls {
+		fd, ok := decl.(*ast.FuncDecl)
+		if !ok || fd.Recv != nil || !fd.Name.IsExported() {
+			continue
+		}
+		for _, field := range fd.Type.Params.List {
+			t := l.info.TypeOf(field.Type)
+			if t == nil || isRealmType(t) || isMsgCallType(t) {
+				continue
+			}
+			for _, name := range field.Names {
+				l.report(name.Pos(), gnoUnserializableParam, 0.6,
+					"parameter %s of exported function %s has type %s, which can't be passed by a MsgCall", name.Name, fd.Name.Name, l.typeString(t))
+			}
+			if len(field.Names) == 0 {
+				l.report(field.Pos(), gnoUnserializableParam, 0.6,
+					"parameter of exported function %s has type %s, which can't be passed by a MsgCall", fd.Name.Name, l.typeString(t))
+			}
+		}
+	}
+}
+
+// typeString returns the string of t, with the types of other packages
+// qualified by their package name.
+func (l *linter) typeString(t types.Type) string {
+	return types.TypeString(t, func(pkg *types.Package) string {
+		if pkg.Path() == l.pkgPath {
+			return ""
+		}
+		return pkg.Name()
+	})
+}
+
+// isRealmType reports whether t is the type of the realm parameter of
+// crossing functions.
+func isRealmType(t types.Type) bool {
+	named, ok := types.Unalias(t).(*types.Named)
+	return ok && named.Obj().Name() == "realm" &&
+		named.Obj().Pkg() != nil && named.Obj().Pkg().Path() == "gnobuiltins/gno0p9"
+}
+
+// isMsgCallType reports whether a MsgCall can pass an argument of type t.
+// See convertArgToGno in gno.land/pkg/sdk/vm.
+func isMsgCallType(t types.Type) bool {
+	switch u := t.Underlying().(type) {
+	case *types.Basic:
+		return u.Info()&(types.IsBoolean|types.IsString|types.IsInteger|types.IsFloat) != 0 &&
+			u.Kind() != types.Uintptr && u.Kind() != types.UnsafePointer
+	case *types.Array:
+		return isByte(u.Elem())
+	case *types.Slice:
+		return isByte(u.Elem())
+	default:
+		return false
+	}
+}
+
+func isByte(t types.Type) bool {
+	b, ok := t.Underlying().(*types.Basic)
+	return ok && b.Kind() == types.Uint8
+}
+
+// nilMapWrites reports the package variables of map type declared without a
+// value and never assigned, but which are written to: writing to a nil map
+// panics, so they are missing an initialization, either in their declaration
+// or in init().
+func (l *linter) nilMapWrites(files []*ast.File) {
+	type mapVar struct {
+		ident             *ast.Ident
+		assigned, written bool
+	}
+	var (
+		vars  []*mapVar
+		byObj = map[types.Object]*mapVar{}
+	)
+	for _, gof := range files {
+		for _, decl := range gof.Decls {
+			gd, ok := decl.(*ast.GenDecl)
+			if !ok || gd.Tok != token.VAR {
+				continue
+			}
+			for _, spec := range gd.Specs {
+				vs := spec.(*ast.ValueSpec)
+				if len(vs.Values) > 0 {
+					continue
+				}
+				for _, name := range vs.Names {
+					obj := l.info.Defs[name]
+					if obj == nil || name.Name == "_" {
+						continue
+					}
+					if _, ok := obj.Type().Underlying().(*types.Map); !ok {
+						continue
+					}
+					mv := &mapVar{ident: name}
+					vars = append(vars, mv)
+					byObj[obj] = mv
+				}
+			}
+		}
+	}
+	if len(vars) == 0 {
+		return
+	}
+
+	// lookup returns the map variable x refers to, or nil.
+	lookup := func(x ast.Expr) *mapVar {
+		id, ok := ast.Unparen(x).(*ast.Ident)
+		if !ok {
+			return nil
+		}
+		return byObj[l.info.Uses[id]]
+	}
+	for _, gof := range files {
+		ast.Inspect(gof, func(n ast.Node) bool {
+			var lhs []ast.Expr
+			switch n := n.(type) {
+			case *ast.AssignStmt:
+				lhs = n.Lhs
+			case *ast.IncDecStmt:
+				lhs = []ast.Expr{n.X}
+			case *ast.UnaryExpr:
+				// The map may be initialized through a pointer.
+				if mv := lookup(n.X); n.Op == token.AND && mv != nil {
+					mv.assigned = true
+				}
+				return true
+			default:
+				return true
+			}
+			for _, x := range lhs {
+				if mv := lookup(x); mv != nil {
+					mv.assigned = true
+				} else if ix, ok := ast.Unparen(x).(*ast.IndexExpr); ok {
+					if mv := lookup(ix.X); mv != nil {
+						mv.written = true
+					}
+				}
+			}
+			return true
+		})
+	}
+	for _, mv := range vars {
+		if mv.written && !mv.assigned {
+			l.report(mv.ident.Pos(), gnoNilMapWrite, 0.9,
+				"map %s is written to but never initialized, and writing to a nil map panics; initialize it in its declaration or in init()", mv.ident.Name)
+		}
+	}
+}
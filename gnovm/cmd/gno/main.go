@@ -92,15 +92,19 @@ func newGnocliCmd(io commands.IO) (*commands.Command, *rootConfig) {
 	)
 
 	cmd.AddSubCommands(
+		newBenchVMCmd(io),
 		newBugCmd(io),
 		// build
 		newCleanCmd(io),
+		newDebugCmd(io),
+		newDeployCmd(io),
 		newDocCmd(io),
 		newEnvCmd(io),
 		newFixCmd(io),
 		newFmtCmd(io),
 		// generate
 		// get
+		newImportGoCmd(io),
 		// install
 		newListCmd(io),
 		newLintCmd(io),
@@ -110,6 +114,7 @@ func newGnocliCmd(io commands.IO) (*commands.Command, *rootConfig) {
 		newRunCmd(io),
 		// telemetry
 		newTestCmd(io),
+		newTestVMCmd(io),
 		newToolCmd(io),
 		// version -- show cmd/gno, golang versions
 		newGnoVersionCmd(io),
@@ -4,11 +4,17 @@ import (
 	"bytes"
 	"context"
 	"fmt"
+	"io/fs"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 	"testing"
 
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/gnovm/pkg/packages"
 	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload/examplespkgfetcher"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/stretchr/testify/require"
@@ -135,6 +141,7 @@ func testMainCaseRun(t *testing.T, tc []testMainCase) {
 			io.SetErr(commands.WriteNopCloser(mockErr))
 
 			testPackageFetcher = examplespkgfetcher.New("")
+			testDependentsFetcher = examplesDependents
 
 			cmd, _ := newGnocliCmd(io)
 			err := cmd.ParseAndRun(context.Background(), test.args)
@@ -156,3 +163,39 @@ func testMainCaseRun(t *testing.T, tc []testMainCase) {
 		})
 	}
 }
+
+// examplesDependents returns the paths of the example packages importing the
+// package at pkgPath, like on a chain where the examples are deployed.
+func examplesDependents(pkgPath string) ([]string, error) {
+	var res []string
+	examplesDir := filepath.Join(gnoenv.RootDir(), "examples")
+	err := filepath.WalkDir(examplesDir, func(path string, d fs.DirEntry, err error) error {
+		if err != nil || d.IsDir() || d.Name() != "gnomod.toml" {
+			return err
+		}
+
+		dir := filepath.Dir(path)
+		gm, err := gnomod.ParseDir(dir)
+		if err != nil {
+			return err
+		}
+		mpkg, err := gnolang.ReadMemPackage(dir, gm.Module, gnolang.MPAnyProd)
+		if err != nil {
+			return err
+		}
+		imports, err := packages.Imports(mpkg, nil)
+		if err != nil {
+			return err
+		}
+
+		for _, imp := range imports[packages.FileKindPackageSource] {
+			if imp.PkgPath == pkgPath {
+				res = append(res, gm.Module)
+				break
+			}
+		}
+		return nil
+	})
+	slices.Sort(res)
+	return res, err
+}
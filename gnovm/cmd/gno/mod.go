@@ -38,9 +38,10 @@ func newModCmd(io commands.IO) *commands.Command {
 		newModDownloadCmd(io),
 		// edit
 		newModGraphCmd(io),
+		newModImpact(io),
 		newModInitCmd(),
 		newModTidy(io),
-		// vendor
+		newModVendorCmd(io),
 		// verify
 		newModWhy(io),
 	)
@@ -109,6 +110,33 @@ func newModTidy(io commands.IO) *commands.Command {
 	)
 }
 
+func newModVendorCmd(io commands.IO) *commands.Command {
+	cfg := &modDownloadCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "vendor",
+			ShortUsage: "vendor [flags]",
+			ShortHelp:  "make vendored copy of dependencies",
+			LongHelp: `Vendor copies the remote packages the packages of the workspace depend
+on, such as gno.land/p/nt/avl, to its vendor directory, along with a
+vendor/modules.txt file listing them. Outside of a workspace, it's the vendor
+directory of the package in the current directory.
+
+When the vendor directory exists, gno test, gno lint, gno run and the other
+commands loading packages use its packages, and don't download any: they
+don't need access to a gno.land node, and use the same code even if the
+packages change on chain. Run gno mod vendor again to update them, or
+remove the vendor directory to download them again.
+`,
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execModVendor(cfg, args, io)
+		},
+	)
+}
+
 func newModWhy(io commands.IO) *commands.Command {
 	return commands.NewCommand(
 		commands.Metadata{
@@ -274,6 +302,62 @@ func execModDownload(cfg *modDownloadCfg, args []string, io commands.IO) error {
 	return nil
 }
 
+func execModVendor(cfg *modDownloadCfg, args []string, io commands.IO) error {
+	if len(args) > 0 {
+		return flag.ErrHelp
+	}
+
+	fetcher := testPackageFetcher
+	if fetcher == nil {
+		remoteOverrides, err := parseRemoteOverrides(cfg.remoteOverrides)
+		if err != nil {
+			return fmt.Errorf("invalid %s flag: %w", remoteOverridesArgName, err)
+		}
+		fetcher = rpcpkgfetcher.New(remoteOverrides)
+	} else if len(cfg.remoteOverrides) != 0 {
+		return fmt.Errorf("can't use %s flag with a custom package fetcher", remoteOverridesArgName)
+	}
+
+	root, err := packages.FindRootDir()
+	if err != nil {
+		return err
+	}
+
+	// Download the dependencies again, ignoring the current vendor directory.
+	loadCfg := packages.LoadConfig{
+		Fetcher:    fetcher,
+		Deps:       true,
+		Test:       true,
+		AllowEmpty: true,
+		NoVendor:   true,
+		Out:        io.Err(),
+	}
+	pkgs, err := packages.Load(loadCfg, "./...")
+	if err != nil {
+		return err
+	}
+
+	errCount := uint(0)
+	var remote packages.PkgList
+	for _, pkg := range pkgs {
+		for _, err := range pkg.Errors {
+			fmt.Fprintf(io.Err(), "%s: %v", pkg.ImportPath, err)
+			errCount++
+		}
+		if packages.IsRemotePackage(pkg) {
+			remote = append(remote, pkg)
+		}
+	}
+	if errCount != 0 {
+		return fmt.Errorf("%d build error(s)", errCount)
+	}
+
+	if len(remote) == 0 {
+		io.ErrPrintln("gno: no dependencies to vendor")
+	}
+	return packages.WriteVendor(root, remote)
+}
+
 func parseRemoteOverrides(arg string) (map[string]string, error) {
 	if arg == "" {
 		return map[string]string{}, nil
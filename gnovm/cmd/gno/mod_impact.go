@@ -0,0 +1,423 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm/pkg/doc"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/gnovm/pkg/packages"
+	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload/rpcpkgfetcher"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// testDependentsFetcher allows to override the lookup of the dependents of a
+// package during tests.
+var testDependentsFetcher func(pkgPath string) ([]string, error)
+
+type modImpactCfg struct {
+	remoteOverrides string
+}
+
+func newModImpact(io commands.IO) *commands.Command {
+	cfg := &modImpactCfg{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "impact",
+			ShortUsage: "impact [flags] [path]",
+			ShortHelp:  "report the impact of upgrading a package on its dependents",
+			LongHelp: `Reports the impact of upgrading a package on the packages depending on it.
+
+gno mod impact compares the exported API of the package in the given directory
+(the current one by default) with the one of the version deployed on its chain,
+and lists the exported symbols which were added, removed, or whose signature
+changed. Removed and changed symbols are breaking changes.
+
+It then queries the chain for the packages importing the deployed package, and
+reports the ones using symbols with breaking changes. Functions, types, constants
+and variables are matched by their qualified name; methods and struct fields
+are matched by name, so that they may be reported for a dependent using a
+member of the same name of another type.
+
+The command fails if a dependent uses a symbol with a breaking change.
+
+For example:
+
+	$ gno mod impact
+	gno.land/p/demo/todolist: 3 API change(s), 2 breaking
+	  removed  func (*TodoList) RemoveTask(string)
+	  added    func (*TodoList) Size() int
+	  changed  func ToggleTaskStatus(*Task) => func ToggleTaskStatus(*Task, bool)
+
+	1 dependent(s)
+	  gno.land/r/demo/todolist: broken, uses TodoList.RemoveTask, ToggleTaskStatus
+`,
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execModImpact(cfg, args, io)
+		},
+	)
+}
+
+func (c *modImpactCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.remoteOverrides,
+		remoteOverridesArgName,
+		"",
+		"chain-domain=rpc-url comma-separated list",
+	)
+}
+
+func execModImpact(cfg *modImpactCfg, args []string, io commands.IO) error {
+	if len(args) > 1 {
+		return flag.ErrHelp
+	}
+	dir := "."
+	if len(args) == 1 {
+		dir = args[0]
+	}
+
+	fetcher, fetchDependents := testPackageFetcher, testDependentsFetcher
+	if fetcher == nil {
+		remoteOverrides, err := parseRemoteOverrides(cfg.remoteOverrides)
+		if err != nil {
+			return fmt.Errorf("invalid %s flag: %w", remoteOverridesArgName, err)
+		}
+		fetcher = rpcpkgfetcher.New(remoteOverrides)
+		fetchDependents = func(pkgPath string) ([]string, error) {
+			return rpcpkgfetcher.FetchDependents(pkgPath, remoteOverrides)
+		}
+	} else if len(cfg.remoteOverrides) != 0 {
+		return fmt.Errorf("can't use %s flag with a custom package fetcher", remoteOverridesArgName)
+	}
+
+	gm, err := gnomod.ParseDir(dir)
+	if err != nil {
+		return err
+	}
+	pkgPath := gm.Module
+
+	local, err := gno.ReadMemPackage(dir, pkgPath, gno.MPUserProd)
+	if err != nil {
+		return err
+	}
+	files, err := fetcher.FetchPackage(pkgPath)
+	if err != nil {
+		return fmt.Errorf("fetch deployed package: %w", err)
+	}
+	deployed := &std.MemPackage{Name: local.Name, Path: pkgPath, Files: sourceFiles(files)}
+
+	oldAPI, err := packageAPI(deployed)
+	if err != nil {
+		return fmt.Errorf("deployed package: %w", err)
+	}
+	newAPI, err := packageAPI(local)
+	if err != nil {
+		return err
+	}
+	changes := diffAPI(oldAPI, newAPI)
+
+	breaking := 0
+	for _, change := range changes {
+		if change.breaking() {
+			breaking++
+		}
+	}
+	io.Printfln("%s: %d API change(s), %d breaking", pkgPath, len(changes), breaking)
+	for _, change := range changes {
+		io.Printfln("  %s", change)
+	}
+
+	dependents, err := fetchDependents(pkgPath)
+	if err != nil {
+		return fmt.Errorf("fetch dependents: %w", err)
+	}
+
+	io.Println()
+	io.Printfln("%d dependent(s)", len(dependents))
+
+	broken := 0
+	for _, dependent := range dependents {
+		files, err := fetcher.FetchPackage(dependent)
+		if err != nil {
+			return fmt.Errorf("fetch dependent: %w", err)
+		}
+		uses, err := packageUses(sourceFiles(files), pkgPath, local.Name)
+		if err != nil {
+			return fmt.Errorf("dependent %s: %w", dependent, err)
+		}
+
+		var used []string
+		for _, change := range changes {
+			if change.breaking() && uses.uses(change.old) {
+				used = append(used, change.old.name)
+			}
+		}
+		if len(used) == 0 {
+			io.Printfln("  %s: ok", dependent)
+			continue
+		}
+		broken++
+		io.Printfln("  %s: broken, uses %s", dependent, strings.Join(used, ", "))
+	}
+
+	if broken != 0 {
+		return errors.New("%d dependent(s) affected by breaking changes", broken)
+	}
+
+	return nil
+}
+
+// sourceFiles returns the package source files of files, leaving out the
+// test files, which can't break the package when upgrading its dependencies.
+func sourceFiles(files []*std.MemFile) []*std.MemFile {
+	var res []*std.MemFile
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name, ".gno") {
+			continue
+		}
+		if packages.GetFileKind(file.Name, file.Body, nil) != packages.FileKindPackageSource {
+			continue
+		}
+		res = append(res, file)
+	}
+	return res
+}
+
+// apiSymbol is an exported symbol of a package.
+type apiSymbol struct {
+	name   string // like NewTree, or Tree.Get for methods and struct fields
+	member bool   // a method or a struct field
+	decl   string // like func NewTree() *Tree, without the names of the parameters
+}
+
+// apiChange is a change of an exported symbol between two versions of a
+// package; old is nil for added symbols, and new for removed ones.
+type apiChange struct {
+	old, new *apiSymbol
+}
+
+// breaking returns whether the change can break the dependents of the package.
+func (c apiChange) breaking() bool {
+	return c.old != nil
+}
+
+func (c apiChange) String() string {
+	switch {
+	case c.old == nil:
+		return "added    " + c.new.decl
+	case c.new == nil:
+		return "removed  " + c.old.decl
+	default:
+		return "changed  " + c.old.decl + " => " + c.new.decl
+	}
+}
+
+// packageAPI returns the exported symbols of mpkg, by name.
+func packageAPI(mpkg *std.MemPackage) (map[string]*apiSymbol, error) {
+	d, err := doc.NewDocumentableFromMemPkg(mpkg, false, "", "")
+	if err != nil {
+		return nil, err
+	}
+	jdoc, err := d.WriteJSONDocumentation(nil)
+	if err != nil {
+		return nil, err
+	}
+
+	api := make(map[string]*apiSymbol)
+	add := func(sym *apiSymbol) {
+		sym.decl = strings.Join(strings.Fields(sym.decl), " ") // single line
+		api[sym.name] = sym
+	}
+
+	for _, decl := range jdoc.Values {
+		kind := "var"
+		if decl.Const {
+			kind = "const"
+		}
+		for _, value := range decl.Values {
+			add(&apiSymbol{name: value.Name, decl: kind + " " + value.Name + " " + value.Type})
+		}
+	}
+
+	for _, fn := range jdoc.Funcs {
+		sig := fn.Name + "(" + fieldTypes(fn.Params) + ")"
+		switch {
+		case len(fn.Results) == 1 && fn.Results[0].Name == "":
+			sig += " " + fn.Results[0].Type
+		case len(fn.Results) > 0:
+			sig += " (" + fieldTypes(fn.Results) + ")"
+		}
+
+		if fn.Type == "" {
+			add(&apiSymbol{name: fn.Name, decl: "func " + sig})
+			continue
+		}
+		add(&apiSymbol{
+			name:   fn.Type + "." + fn.Name,
+			member: true,
+			decl:   "func (" + receiverType(fn.Signature, fn.Type) + ") " + sig,
+		})
+	}
+
+	for _, typ := range jdoc.Types {
+		if typ.Kind != "struct" || typ.Alias {
+			decl := "type " + typ.Name + " "
+			if typ.Alias {
+				decl += "= "
+			}
+			add(&apiSymbol{name: typ.Name, decl: decl + typ.Type})
+			continue
+		}
+
+		add(&apiSymbol{name: typ.Name, decl: "type " + typ.Name + " struct"})
+		for _, field := range typ.Fields {
+			name := field.Name
+			if name == "" { // embedded
+				name = field.Type[strings.LastIndexAny(field.Type, "*.")+1:]
+			}
+			if !token.IsExported(name) {
+				continue
+			}
+			add(&apiSymbol{
+				name:   typ.Name + "." + name,
+				member: true,
+				decl:   "field " + typ.Name + "." + name + " " + field.Type,
+			})
+		}
+	}
+
+	return api, nil
+}
+
+// fieldTypes returns the comma-separated types of fields.
+func fieldTypes(fields []*doc.JSONField) string {
+	types := make([]string, len(fields))
+	for i, field := range fields {
+		types[i] = field.Type
+	}
+	return strings.Join(types, ", ")
+}
+
+// receiverType returns the receiver type of the method with the given
+// signature, like *Tree, or typ if it can't be found.
+func receiverType(signature, typ string) string {
+	recv, ok := strings.CutPrefix(signature, "func (")
+	if !ok {
+		return typ
+	}
+	recv, _, ok = strings.Cut(recv, ")")
+	if !ok {
+		return typ
+	}
+	fields := strings.Fields(recv)
+	if len(fields) == 0 {
+		return typ
+	}
+	return fields[len(fields)-1]
+}
+
+// diffAPI returns the changes from the old API to the new one, sorted by
+// symbol name.
+func diffAPI(oldAPI, newAPI map[string]*apiSymbol) []apiChange {
+	names := make([]string, 0, len(oldAPI)+len(newAPI))
+	for name := range oldAPI {
+		names = append(names, name)
+	}
+	for name := range newAPI {
+		if _, ok := oldAPI[name]; !ok {
+			names = append(names, name)
+		}
+	}
+	slices.Sort(names)
+
+	var changes []apiChange
+	for _, name := range names {
+		before, after := oldAPI[name], newAPI[name]
+		if before != nil && after != nil && before.decl == after.decl {
+			continue
+		}
+		changes = append(changes, apiChange{old: before, new: after})
+	}
+	return changes
+}
+
+// apiUses are the uses of the symbols of a package by a dependent.
+type apiUses struct {
+	names     map[string]bool // qualified by the package, like avl.NewTree
+	selectors map[string]bool // any selector or struct literal key, like tree.Get
+}
+
+// uses returns whether sym may be used by the dependent.
+func (u apiUses) uses(sym *apiSymbol) bool {
+	if !sym.member {
+		return u.names[sym.name]
+	}
+	_, member, _ := strings.Cut(sym.name, ".")
+	return u.selectors[member]
+}
+
+// packageUses returns the uses of the package at pkgPath, named pkgName, by
+// the files of a dependent. Only the files importing the package are taken
+// into account.
+func packageUses(files []*std.MemFile, pkgPath, pkgName string) (apiUses, error) {
+	uses := apiUses{
+		names:     make(map[string]bool),
+		selectors: make(map[string]bool),
+	}
+
+	fset := token.NewFileSet()
+	for _, file := range files {
+		f, err := parser.ParseFile(fset, file.Name, file.Body, 0)
+		if err != nil {
+			return uses, err
+		}
+
+		name := ""
+		for _, spec := range f.Imports {
+			if path, _ := strconv.Unquote(spec.Path.Value); path != pkgPath {
+				continue
+			}
+			name = pkgName
+			if spec.Name != nil {
+				name = spec.Name.Name
+			}
+		}
+		if name == "" || name == "_" {
+			continue
+		}
+
+		ast.Inspect(f, func(n ast.Node) bool {
+			switch n := n.(type) {
+			case *ast.SelectorExpr:
+				if x, ok := n.X.(*ast.Ident); ok && x.Name == name {
+					uses.names[n.Sel.Name] = true
+				}
+				uses.selectors[n.Sel.Name] = true
+			case *ast.CompositeLit:
+				for _, elt := range n.Elts {
+					if kv, ok := elt.(*ast.KeyValueExpr); ok {
+						if key, ok := kv.Key.(*ast.Ident); ok {
+							uses.selectors[key.Name] = true
+						}
+					}
+				}
+			}
+			return true
+		})
+	}
+
+	return uses, nil
+}
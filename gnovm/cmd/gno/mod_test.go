@@ -1,7 +1,19 @@
 package main
 
 import (
+	"bytes"
+	"context"
+	"fmt"
+	"os"
+	"path/filepath"
 	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 func TestModApp(t *testing.T) {
@@ -227,7 +239,139 @@ gno.land/p/nt/avl strings
 gno.land/p/nt/avl testing
 `,
 		},
+
+		// test `gno mod impact`
+		{
+			args:                 []string{"mod", "impact"},
+			testDir:              "../../tests/integ/mod_impact_compatible",
+			simulateExternalRepo: true,
+			stdoutShouldBe: `gno.land/p/demo/todolist: 1 API change(s), 0 breaking
+  added    func (*TodoList) Size() int
+
+1 dependent(s)
+  gno.land/r/demo/todolist: ok
+`,
+		},
+		{
+			args:                 []string{"mod", "impact"},
+			testDir:              "../../tests/integ/mod_impact_breaking",
+			simulateExternalRepo: true,
+			errShouldBe:          "1 dependent(s) affected by breaking changes",
+			stdoutShouldBe: `gno.land/p/demo/todolist: 3 API change(s), 2 breaking
+  removed  func (*TodoList) RemoveTask(string)
+  added    func (*TodoList) Size() int
+  changed  func ToggleTaskStatus(*Task) => func ToggleTaskStatus(*Task, bool)
+
+1 dependent(s)
+  gno.land/r/demo/todolist: broken, uses TodoList.RemoveTask, ToggleTaskStatus
+`,
+		},
+		{
+			args:                 []string{"mod", "impact", "--remote-overrides", "gno.land=http://localhost:26657"},
+			testDir:              "../../tests/integ/mod_impact_compatible",
+			simulateExternalRepo: true,
+			errShouldBe:          "can't use remote-overrides flag with a custom package fetcher",
+		},
 	}
 
 	testMainCaseRun(t, tc)
 }
+
+type memPackageFetcher map[string][]*std.MemFile
+
+func (f memPackageFetcher) FetchPackage(pkgPath string) ([]*std.MemFile, error) {
+	files, ok := f[pkgPath]
+	if !ok {
+		return nil, fmt.Errorf("package %q is not available", pkgPath)
+	}
+	return files, nil
+}
+
+func TestModVendor(t *testing.T) {
+	t.Setenv("GNOHOME", t.TempDir())
+
+	dir := t.TempDir()
+	for name, body := range map[string]string{
+		"gnowork.toml": ``,
+		"hello/gnomod.toml": `module = "gno.land/r/test/hello"
+gno = "0.9"
+`,
+		"hello/hello.gno": `package hello
+
+import "gno.land/p/remote/greet"
+
+func Hello() string { return greet.Greet("gnome") }
+`,
+		"hello/hello_test.gno": `package hello
+
+import "testing"
+
+func TestHello(t *testing.T) {
+	if got := Hello(); got != "hello, gnome!" {
+		t.Errorf("got %q", got)
+	}
+}
+`,
+		"main.gno": `package main
+
+import "gno.land/p/remote/greet"
+
+func main() { println(greet.Greet("world")) }
+`,
+	} {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(body), 0o644))
+	}
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	defer os.Chdir(wd)
+	defer func() { testPackageFetcher = nil }()
+
+	run := func(args ...string) (string, string, error) {
+		var stdout, stderr bytes.Buffer
+		io := commands.NewTestIO()
+		io.SetOut(commands.WriteNopCloser(&stdout))
+		io.SetErr(commands.WriteNopCloser(&stderr))
+		cmd, _ := newGnocliCmd(io)
+		err := cmd.ParseAndRun(context.Background(), args)
+		return stdout.String(), stderr.String(), err
+	}
+
+	// The remote packages, and their dependencies, are vendored.
+	testPackageFetcher = memPackageFetcher{
+		"gno.land/p/remote/greet": {
+			{Name: "gnomod.toml", Body: "module = \"gno.land/p/remote/greet\"\ngno = \"0.9\"\n"},
+			{Name: "greet.gno", Body: "package greet\n\nimport \"gno.land/p/remote/greet/punct\"\n\nfunc Greet(name string) string { return \"hello, \" + name + punct.Bang }\n"},
+		},
+		"gno.land/p/remote/greet/punct": {
+			{Name: "gnomod.toml", Body: "module = \"gno.land/p/remote/greet/punct\"\ngno = \"0.9\"\n"},
+			{Name: "punct.gno", Body: "package punct\n\nconst Bang = \"!\"\n"},
+		},
+	}
+	_, stderr, err := run("mod", "vendor")
+	require.NoError(t, err, stderr)
+	list, err := os.ReadFile(filepath.Join(dir, "vendor", "modules.txt"))
+	require.NoError(t, err)
+	assert.Contains(t, string(list), "gno.land/p/remote/greet\ngno.land/p/remote/greet/punct\n")
+	assert.FileExists(t, filepath.Join(dir, "vendor", "gno.land", "p", "remote", "greet", "greet.gno"))
+	assert.FileExists(t, filepath.Join(dir, "vendor", "gno.land", "p", "remote", "greet", "punct", "punct.gno"))
+
+	// Without access to the packages, they're used from the vendor directory.
+	t.Setenv("GNOHOME", t.TempDir())
+	testPackageFetcher = pkgdownload.NewNoopFetcher()
+	_, stderr, err = run("test", "./...")
+	require.NoError(t, err, stderr)
+	assert.NotContains(t, stderr, "downloading")
+	assert.NotContains(t, stderr, "vendor/gno.land") // not tested as workspace packages
+	stdout, stderr, err := run("run", "main.gno")
+	require.NoError(t, err, stderr)
+	assert.Equal(t, "hello, world!\n", stdout)
+
+	// A missing package isn't downloaded.
+	require.NoError(t, os.RemoveAll(filepath.Join(dir, "vendor", "gno.land", "p", "remote", "greet", "punct")))
+	_, stderr, err = run("test", "./...")
+	require.Error(t, err)
+	assert.Contains(t, stderr, "package gno.land/p/remote/greet/punct is not in the vendor directory, run gno mod vendor")
+}
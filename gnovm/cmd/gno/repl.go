@@ -11,15 +11,17 @@ import (
 
 	hcal "github.com/bendory/conway-hebrew-calendar"
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload/rpcpkgfetcher"
 	"github.com/gnolang/gno/gnovm/pkg/repl"
 	"github.com/gnolang/gno/tm2/pkg/colors"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 )
 
 type replCfg struct {
-	rootDir   string
-	init      string
-	skipUsage bool
+	rootDir         string
+	init            string
+	skipUsage       bool
+	remoteOverrides string
 }
 
 func newReplCmd() *commands.Command {
@@ -30,6 +32,13 @@ func newReplCmd() *commands.Command {
 			Name:       "repl",
 			ShortUsage: "repl [flags]",
 			ShortHelp:  "starts a GnoVM REPL",
+			LongHelp: `Starts an interactive GnoVM session.
+
+Declarations and statements are evaluated in a persistent package; the values
+of expressions other than function calls are printed. Imported packages which are not found in the
+standard library or the examples are fetched from the chain their path points
+to (see -remote-overrides). Commands start with '/' or ':', type help() for a
+list.`,
 		},
 		cfg,
 		func(_ context.Context, args []string) error {
@@ -59,6 +68,13 @@ func (c *replCfg) RegisterFlags(fs *flag.FlagSet) {
 		false,
 		"do not print welcome line",
 	)
+
+	fs.StringVar(
+		&c.remoteOverrides,
+		remoteOverridesArgName,
+		"",
+		"chain-domain=rpc-url comma-separated list",
+	)
 }
 
 const gnoHelp = `Usage:
@@ -66,8 +82,11 @@ const gnoHelp = `Usage:
    gno /history                         // print statement history
    gno /debug                           // activate the GnoVM debugger
    gno /reset                           // remove all previously inserted code
+   gno /type a                          // print the type of a
    gno println(a())                     // print the result of calling a()
+   gno a                                // print the value of a
    gno import "gno.land/p/nt/avl"     // import the p/nt/avl package
+   gno import "gno.land/r/gnoland/home" // import a package from the chain
    gno func a() string { return "a" }   // declare a new function named a
    gno func b() string {\               // multi-line with '\'
    ...    return "a"\
@@ -78,6 +97,7 @@ const gnoHelp = `Usage:
    ... }                             
    ... ;
    gno /exit                            // alternative to <Ctrl-D>
+   gno :reset                           // commands may also start with ':'
 
 Goto gno.land for more info.`
 
@@ -131,14 +151,24 @@ func execRepl(cfg *replCfg, args []string) error {
 }
 
 func runRepl(cfg *replCfg) error {
-	r := repl.NewRepl()
+	fetcher := testPackageFetcher
+	if fetcher == nil {
+		remoteOverrides, err := parseRemoteOverrides(cfg.remoteOverrides)
+		if err != nil {
+			return fmt.Errorf("invalid %s flag: %w", remoteOverridesArgName, err)
+		}
+		fetcher = rpcpkgfetcher.New(remoteOverrides)
+	} else if len(cfg.remoteOverrides) != 0 {
+		return fmt.Errorf("can't use %s flag with a custom package fetcher", remoteOverridesArgName)
+	}
+	r := repl.NewRepl(repl.WithPackageFetcher(fetcher))
 
+	bootRepl(r)
 	if cfg.init != "" {
 		handleInput(r, cfg.init)
 	}
 
 	r.Print(colors.Cyan("gno "))
-	handleInput(r, bootCode)
 
 	inEdit := false
 	code := ""
@@ -154,7 +184,7 @@ func runRepl(cfg *replCfg) error {
 	for liner.Scan() {
 		line := liner.Text()
 
-		if line == "/editor" {
+		if cmd, _ := parseCommand(line); cmd == "editor" {
 			line, inEdit = "", true
 			r.Println(colors.Gray("// enter a single ';' to quit and commit"))
 		}
@@ -184,20 +214,52 @@ func runRepl(cfg *replCfg) error {
 	return nil
 }
 
-// handleInput executes specific "/" commands, or evaluates input as Gno source code.
+// bootRepl declares help() in a new or reset REPL, keeping it out of the history.
+func bootRepl(r *repl.Repl) {
+	r.RunStatements(bootCode)
+	r.ClearHistory()
+}
+
+// parseCommand returns the name and argument of a command input, starting
+// with '/' or ':'. name is empty if input is not a command.
+func parseCommand(input string) (name, arg string) {
+	input = strings.TrimSpace(input)
+	if strings.HasPrefix(input, "//") ||
+		!strings.HasPrefix(input, "/") && !strings.HasPrefix(input, ":") {
+		return "", ""
+	}
+	name, arg, _ = strings.Cut(input[1:], " ")
+	return name, strings.TrimSpace(arg)
+}
+
+// handleInput executes specific "/" (or ":") commands, or evaluates input as Gno source code.
 func handleInput(r *repl.Repl, input string) {
-	switch strings.TrimSpace(input) {
-	case "/reset":
+	if strings.TrimSpace(input) == "" {
+		// Avoid to increase the repl execution counter if no input.
+		return
+	}
+	cmd, arg := parseCommand(input)
+	switch cmd {
+	case "":
+		r.RunStatements(input)
+	case "reset":
 		r.Reset()
-	case "/debug":
+		bootRepl(r)
+	case "debug":
 		r.Debug()
-	case "/history":
-		panic("not yet implemented")
-	case "/exit":
+	case "history":
+		for i, code := range r.History() {
+			r.Printfln("%d\t%s", i+1, strings.ReplaceAll(code, "\n", "\n\t"))
+		}
+	case "type":
+		if arg == "" {
+			r.Errorln("usage: /type <expression>")
+			return
+		}
+		r.PrintType(arg)
+	case "exit":
 		os.Exit(0)
-	case "":
-		// Avoid to increase the repl execution counter if no input.
 	default:
-		r.RunStatements(input)
+		r.Errorfln("unknown command %q, try \"help()\"", input)
 	}
 }
@@ -1,6 +1,10 @@
 package main
 
-import "testing"
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
 
 func TestReplApp(t *testing.T) {
 	tc := []testMainCase{
@@ -11,3 +15,22 @@ func TestReplApp(t *testing.T) {
 	}
 	testMainCaseRun(t, tc)
 }
+
+func TestParseCommand(t *testing.T) {
+	for _, tc := range []struct {
+		input, name, arg string
+	}{
+		{"/reset", "reset", ""},
+		{":reset", "reset", ""},
+		{"  /history  ", "history", ""},
+		{"/type a + b", "type", "a + b"},
+		{":type  x", "type", "x"},
+		{"// a comment", "", ""},
+		{"a / b", "", ""},
+		{"println(1)", "", ""},
+	} {
+		name, arg := parseCommand(tc.input)
+		assert.Equal(t, tc.name, name, tc.input)
+		assert.Equal(t, tc.arg, arg, tc.input)
+	}
+}
@@ -12,6 +12,7 @@ import (
 
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/packages"
 	"github.com/gnolang/gno/gnovm/pkg/test"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -91,10 +92,22 @@ func execRun(cfg *runCmd, args []string, cio commands.IO) error {
 	stdout := cio.Out()
 	stderr := cio.Err()
 
+	// the remote packages are only available if they're vendored.
+	var pkgs packages.PkgList
+	vendorDir, err := packages.FindVendorDir()
+	if err != nil {
+		return err
+	}
+	if vendorDir != "" {
+		if pkgs, err = packages.LoadVendor(vendorDir); err != nil {
+			return err
+		}
+	}
+
 	// init store and machine
 	output := test.OutputWithError(stdout, stderr)
 	_, testStore := test.ProdStore(
-		cfg.rootDir, output, nil)
+		cfg.rootDir, output, pkgs)
 
 	if len(args) == 0 {
 		args = []string{"."}
@@ -30,9 +30,27 @@ type testCmd struct {
 	timeout             time.Duration
 	updateGoldenTests   bool
 	printRuntimeMetrics bool
+	maxGas              int64
 	printEvents         bool
 	debug               bool
 	debugAddr           string
+	parallel            int
+	mutate              bool
+	cover               bool
+	coverProfile        string
+	bench               string
+	benchtime           test.DurationOrCount
+	benchmem            bool
+	fuzz                string
+	fuzztime            test.DurationOrCount
+
+	network               string
+	faucetKey             string
+	home                  string
+	insecurePasswordStdin bool
+	gasFee                string
+	gasWanted             int64
+	fund                  string
 }
 
 func newTestCmd(io commands.IO) *commands.Command {
@@ -50,9 +68,9 @@ file pattern "*_test.gno" or "*_filetest.gno".
 
 The <package> can be directory or file path (relative or absolute).
 
-- "*_test.gno" files work like "*_test.go" files, but they contain only test
-functions. Benchmark and fuzz functions aren't supported yet. Similarly, only
-tests that belong to the same package are supported for now (no "xxx_test").
+- "*_test.gno" files work like "*_test.go" files, and contain test, fuzz and
+benchmark functions. Only tests that belong to the same package are supported
+for now (no "xxx_test").
 
 The package path used to execute the "*_test.gno" file is fetched from the
 module name found in 'gno.mod', or else it is set to
@@ -89,6 +107,59 @@ of the GnoVM, acting as a "golden test":
 	stacktrace of the error.
 	- "Events:" can be used to verify the emitted events against a JSON.
 
+Packages are tested serially by default. With -p greater than 1, they are
+tested in parallel, each in its own worker process with an isolated test
+store; the -p flag bounds the number of workers. The output of each package is
+printed in the same order as when testing serially.
+
+With -mutate, each package whose tests pass is then mutation tested: its
+tests are run again against mutants, variations of the package with a single
+change made to one of its non-test files. The changes negate a comparison
+(== to !=, < to >=), move its boundary (< to <=), or remove an assignment, an
+increment or a call. A mutant is killed when its tests fail or time out; the
+mutants which survive are listed, as they show code whose behavior the tests
+don't check. Mutants which don't type-check are not tested. Each mutant is
+tested in its own worker process, and the -p flag bounds the number of mutants
+tested in parallel. Surviving mutants don't make 'gno test' fail.
+
+Tests are metered like transactions: the CPU cycles they use and the memory
+they allocate consume gas. With -max-gas, a test consuming more than the given
+amount of gas fails. With -print-runtime-metrics, the gas consumed by each test
+is printed.
+
+With -cover, the status line of each package reports the percentage of the
+statements of its non-test files run by its tests, including its filetests.
+With -coverprofile, which implies -cover, the statements of the packages and
+whether they were run are written to a file in the format of Go cover
+profiles; use 'go tool cover -html=<file>' to view it.
+
+With -bench, the benchmark functions matching the regular expression are run
+after the tests of their package pass, each for the time, or the number of
+iterations, set by -benchtime. Besides the time, they report the gas consumed
+by an iteration, and with -benchmem the memory allocated by the GnoVM. The
+packages are then tested serially, so that benchmarks don't run concurrently.
+
+Functions of the form "func FuzzXxx(f *testing.F)" are fuzz tests. Their seed
+corpus, the inputs added with f.Add and the files of their testdata/fuzz/FuzzXxx
+directory, is run with the tests. With -fuzz, which only tests a single
+package, the fuzz test matching the regular expression then runs generated
+inputs, mutations of the seed corpus, until one of them fails, or for the time
+or number of inputs set by -fuzztime. The failing input is written to its
+testdata/fuzz directory, so that it is replayed by the next runs.
+
+Filetests with an "INTEGRATION: true" directive are integration tests: they
+are skipped, unless -network is set to the RPC endpoint of a node, in which
+case only they are run against it, with real consensus. The packages they test,
+and the packages of the workspace they import, are first deployed under a
+namespace of the -faucet-key account unique to the run, like
+gno.land/r/<address>/gnotest<random>/foo for gno.land/r/foo, with their imports
+rewritten; their other imports must already be on the network. Each filetest then runs as the main
+package of a 'maketx run' transaction of a new test account, funded with -fund
+by the faucet account, and its Output or Error directive is checked against
+the result of the transaction; its other directives are ignored, and so is
+PKGPATH, except for SEND. Afterwards, the funds left on the test account are
+sent back to the faucet account. Deployed packages can't be removed.
+
 To speed up execution, imports of pure packages are processed separately from
 the execution of the tests. This makes testing faster, but means that the
 initialization of imported pure packages cannot be checked in filetests.
@@ -158,6 +229,13 @@ func (c *testCmd) RegisterFlags(fs *flag.FlagSet) {
 		"print runtime metrics (gas, memory, cpu cycles)",
 	)
 
+	fs.Int64Var(
+		&c.maxGas,
+		"max-gas",
+		0,
+		"gas budget of each test; 0 means unlimited",
+	)
+
 	fs.BoolVar(
 		&c.printEvents,
 		"print-events",
@@ -178,6 +256,117 @@ func (c *testCmd) RegisterFlags(fs *flag.FlagSet) {
 		"",
 		"enable interactive debugger using tcp address in the form [host]:port",
 	)
+
+	fs.IntVar(
+		&c.parallel,
+		"p",
+		1,
+		"number of packages that can be tested in parallel, in separate worker processes",
+	)
+
+	fs.BoolVar(
+		&c.mutate,
+		"mutate",
+		false,
+		"test the mutants of the packages, and report those surviving their tests",
+	)
+
+	fs.BoolVar(
+		&c.cover,
+		"cover",
+		false,
+		"report the statement coverage of the packages by their tests",
+	)
+
+	fs.StringVar(
+		&c.coverProfile,
+		"coverprofile",
+		"",
+		"write a cover profile of the packages to the file; implies -cover",
+	)
+
+	fs.StringVar(
+		&c.bench,
+		"bench",
+		"",
+		"run only those benchmarks matching a regular expression",
+	)
+
+	c.benchtime = test.DefaultBenchTime
+	fs.Var(
+		&c.benchtime,
+		"benchtime",
+		"run each benchmark for duration d, or N times if of the form Nx",
+	)
+
+	fs.BoolVar(
+		&c.benchmem,
+		"benchmem",
+		false,
+		"print the memory allocations of benchmarks",
+	)
+
+	fs.StringVar(
+		&c.fuzz,
+		"fuzz",
+		"",
+		"run the fuzz test matching a regular expression",
+	)
+
+	fs.Var(
+		&c.fuzztime,
+		"fuzztime",
+		"time to spend fuzzing, or number of inputs if of the form Nx; default is to run until failure",
+	)
+
+	fs.StringVar(
+		&c.network,
+		"network",
+		"",
+		"run the integration filetests against the node at this RPC endpoint",
+	)
+
+	fs.StringVar(
+		&c.faucetKey,
+		"faucet-key",
+		"",
+		"name or address of the key deploying the packages and funding the test accounts, with -network",
+	)
+
+	fs.StringVar(
+		&c.home,
+		"home",
+		gnoenv.HomeDir(),
+		"home directory of the keybase of -faucet-key",
+	)
+
+	fs.BoolVar(
+		&c.insecurePasswordStdin,
+		"insecure-password-stdin",
+		false,
+		"read the password of -faucet-key from stdin, without a prompt",
+	)
+
+	fs.StringVar(
+		&c.gasFee,
+		"gas-fee",
+		"1000000ugnot",
+		"gas fee of the transactions, with -network",
+	)
+
+	fs.Int64Var(
+		&c.gasWanted,
+		"gas-wanted",
+		50_000_000,
+		"gas requested for the transactions, with -network",
+	)
+
+	fs.StringVar(
+		&c.fund,
+		"fund",
+		"10000000ugnot",
+		"coins sent to each test account, with -network",
+	)
 }
 
 func execTest(cmd *testCmd, args []string, io commands.IO) error {
@@ -208,6 +397,33 @@ func execTest(cmd *testCmd, args []string, io commands.IO) error {
 		return nil
 	}
 
+	if cmd.network != "" {
+		return execTestNetwork(cmd, pkgs, io)
+	}
+
+	if cmd.fuzz != "" {
+		matched := 0
+		for _, pkg := range pkgs {
+			if len(pkg.Match) != 0 {
+				matched++
+			}
+		}
+		if matched > 1 {
+			return errors.New("cannot use -fuzz flag with multiple packages")
+		}
+	}
+
+	var coverOut goio.Writer
+	if cmd.coverProfile != "" {
+		cmd.cover = true
+		f, err := createCoverProfile(cmd.coverProfile)
+		if err != nil {
+			return err
+		}
+		defer f.Close()
+		coverOut = f
+	}
+
 	if cmd.timeout > 0 {
 		go func() {
 			time.Sleep(cmd.timeout)
@@ -215,6 +431,34 @@ func execTest(cmd *testCmd, args []string, io commands.IO) error {
 		}()
 	}
 
+	// When running as a worker of a parallel 'gno test', the parent process
+	// already reported the errors of the other packages.
+	isWorker := os.Getenv(testWorkerEnv) != ""
+
+	// Run each package in its own worker process when there is more than one
+	// to test. The interactive debugger needs the terminal, so it never runs
+	// in parallel, and benchmarks are timed one package at a time. With
+	// -mutate, the workers test the mutants instead.
+	var workers []*testWorker
+	if cmd.parallel > 1 && !isWorker && !cmd.debug && cmd.debugAddr == "" && !cmd.mutate && cmd.bench == "" {
+		ctx, cancel := context.WithCancel(context.Background())
+		defer cancel() // kills pending workers on -failfast.
+		// Each worker writes the cover profile of its package to this
+		// directory, to be appended to the profile in package order.
+		var coverDir string
+		if coverOut != nil {
+			coverDir, err = os.MkdirTemp("", "gno-test-cover")
+			if err != nil {
+				return err
+			}
+			defer os.RemoveAll(coverDir)
+		}
+		workers, err = startTestWorkers(ctx, cmd, pkgs, coverDir)
+		if err != nil {
+			return err
+		}
+	}
+
 	// Set up options to run tests.
 	stdout := goio.Discard
 	if cmd.verbose {
@@ -225,9 +469,15 @@ func execTest(cmd *testCmd, args []string, io commands.IO) error {
 	opts.Sync = cmd.updateGoldenTests
 	opts.Verbose = cmd.verbose
 	opts.Metrics = cmd.printRuntimeMetrics
+	opts.MaxGas = cmd.maxGas
 	opts.Events = cmd.printEvents
 	opts.Debug = cmd.debug
 	opts.FailfastFlag = cmd.failfast
+	opts.BenchFlag = cmd.bench
+	opts.Benchtime = cmd.benchtime
+	opts.Benchmem = cmd.benchmem
+	opts.FuzzFlag = cmd.fuzz
+	opts.Fuzztime = cmd.fuzztime
 	cache := make(gno.TypeCheckCache, 64)
 
 	// test.ProdStore() is suitable for type-checking prod (non-test) files.
@@ -236,11 +486,18 @@ func execTest(cmd *testCmd, args []string, io commands.IO) error {
 	buildErrCount := 0
 	testErrCount := 0
 	fail := func() error {
+		if isWorker {
+			// the parent process prints the summary.
+			return commands.ExitCodeError(1)
+		}
 		io.ErrPrintfln("FAIL")
 		return fmt.Errorf("FAIL: %d build errors, %d test errors", buildErrCount, testErrCount)
 	}
 
-	for _, pkg := range pkgs {
+	for i, pkg := range pkgs {
+		if isWorker && len(pkg.Match) == 0 {
+			continue
+		}
 		for _, err := range pkg.Errors {
 			io.ErrPrintfln("%s", err.Error())
 			buildErrCount++
@@ -254,104 +511,169 @@ func execTest(cmd *testCmd, args []string, io commands.IO) error {
 			continue
 		}
 
-		// Relativize and prepend dot to pkg dir if possible
-		// We ignore errors since it's a cosmetic thing
-		// XXX: use pkg import path instead of this when printing if possible
-		prettyDir := pkg.Dir
-		if filepath.IsAbs(pkg.Dir) {
-			cwd, err := os.Getwd()
-			if err == nil {
-				relDir, err := filepath.Rel(cwd, pkg.Dir)
-				if err == nil {
-					prettyDir = relDir
-					if prettyDir != "." && !strings.HasPrefix(prettyDir, "."+string(filepath.Separator)) {
-						prettyDir = "." + string(filepath.Separator) + prettyDir
-					}
-				}
-			}
-		}
-
-		if len(pkg.Files[packages.FileKindTest]) == 0 && len(pkg.Files[packages.FileKindXTest]) == 0 && len(pkg.Files[packages.FileKindFiletest]) == 0 {
+		prettyDir := prettyPkgDir(pkg)
+		if !hasTestFiles(pkg) {
 			io.ErrPrintfln("?       %s \t[no test files]", prettyDir)
 			continue
 		}
 
-		// Read and parse gnomod.toml directly.
-		fpath := filepath.Join(pkg.Dir, "gnomod.toml")
-		mod, err := gnomod.ParseFilepath(fpath)
-		if errors.Is(err, fs.ErrNotExist) {
-			if cmd.autoGnomod {
-				modulePath, _ := determinePkgPath(nil, pkg.Dir, cmd.rootDir)
-				modstr := gno.GenGnoModLatest(modulePath)
-				mod, err = gnomod.ParseBytes("gnomod.toml", []byte(modstr))
-				if err != nil {
-					panic(fmt.Errorf("unexpected panic parsing default gnomod.toml bytes: %w", err))
+		var ok bool
+		if workers != nil {
+			ok = workers[i].wait(io)
+			if coverOut != nil {
+				if err := appendCoverProfile(coverOut, workers[i].coverProfile); err != nil {
+					io.ErrPrintfln("unable to write cover profile: %v", err)
 				}
-				io.ErrPrintfln("auto-generated %q", fpath)
-				err = mod.WriteFile(fpath)
-				if err != nil {
-					panic(fmt.Errorf("unexpected panic writing to %q: %w", fpath, err))
-				}
-				// err == nil.
 			}
+		} else {
+			ok = testPkg(cmd, io, opts, cache, pkg, prettyDir, coverOut)
 		}
-
-		// Determine pkgPath from gno.mod.
-		pkgPath, ok := determinePkgPath(mod, pkg.Dir, cmd.rootDir)
 		if !ok {
-			io.ErrPrintfln("WARNING: unable to read package path from gno.mod or gno root directory; try creating a gno.mod file")
+			testErrCount++
+			if cmd.failfast {
+				return fail()
+			}
 		}
+	}
+	if testErrCount > 0 || buildErrCount > 0 {
+		return fail()
+	}
 
-		// Read MemPackage with all files.
-		mpkg := gno.MustReadMemPackage(pkg.Dir, pkgPath, gno.MPAnyAll)
-		var didPanic, didError bool
-		startedAt := time.Now()
-		didPanic = catchPanic(pkg.Dir, pkgPath, io.Err(), func() {
-			if mod == nil || !mod.Ignore {
-				errs := lintTypeCheck(io, pkg.Dir, mpkg, gno.TypeCheckOptions{
-					Getter:     opts.TestStore,
-					TestGetter: opts.TestStore,
-					Mode:       gno.TCLatestRelaxed,
-					Cache:      cache,
-				})
-				if errs != nil {
-					didError = true
-					// already printed in lintTypeCheck.
-					// io.ErrPrintln(errs)
-					return
-				}
-			} else if cmd.verbose {
-				io.ErrPrintfln("%s: module is ignore, skipping type check", pkgPath)
+	return nil
+}
+
+// testPkg runs the tests of pkg in the current process, and prints its
+// status line. With -cover, the blocks covered are written to coverOut, if
+// not nil. It returns false if the package failed.
+func testPkg(
+	cmd *testCmd,
+	io commands.IO,
+	opts *test.TestOptions,
+	cache gno.TypeCheckCache,
+	pkg *packages.Package,
+	prettyDir string,
+	coverOut goio.Writer,
+) bool {
+	// Read and parse gnomod.toml directly.
+	fpath := filepath.Join(pkg.Dir, "gnomod.toml")
+	mod, err := gnomod.ParseFilepath(fpath)
+	if errors.Is(err, fs.ErrNotExist) {
+		if cmd.autoGnomod {
+			modulePath, _ := determinePkgPath(nil, pkg.Dir, cmd.rootDir)
+			modstr := gno.GenGnoModLatest(modulePath)
+			mod, err = gnomod.ParseBytes("gnomod.toml", []byte(modstr))
+			if err != nil {
+				panic(fmt.Errorf("unexpected panic parsing default gnomod.toml bytes: %w", err))
+			}
+			io.ErrPrintfln("auto-generated %q", fpath)
+			err = mod.WriteFile(fpath)
+			if err != nil {
+				panic(fmt.Errorf("unexpected panic writing to %q: %w", fpath, err))
 			}
+			// err == nil.
+		}
+	}
 
-			///////////////////////////////////
-			// Run the tests found in the mpkg.
-			errs := test.Test(mpkg, prettyDir, opts)
-			if errs != nil {
+	// Determine pkgPath from gno.mod.
+	pkgPath, ok := determinePkgPath(mod, pkg.Dir, cmd.rootDir)
+	if !ok {
+		io.ErrPrintfln("WARNING: unable to read package path from gno.mod or gno root directory; try creating a gno.mod file")
+	}
+
+	// Read MemPackage with all files.
+	mpkg := gno.MustReadMemPackage(pkg.Dir, pkgPath, gno.MPAnyAll)
+	if cmd.cover {
+		opts.Coverage = gno.NewCoverage()
+		defer func() { opts.Coverage = nil }()
+	}
+
+	var didPanic, didError bool
+	startedAt := time.Now()
+	didPanic = catchPanic(pkg.Dir, pkgPath, io.Err(), func() {
+		// When testing a mutant, test it instead of mpkg.
+		if index := os.Getenv(testMutantEnv); index != "" {
+			mutant, err := loadMutant(opts, mpkg, index)
+			if err != nil {
 				didError = true
-				io.ErrPrintln(errs)
+				io.ErrPrintln(err)
 				return
 			}
-		})
+			mpkg = mutant
+		}
 
-		// Print status with duration.
-		duration := time.Since(startedAt)
-		dstr := fmtDuration(duration)
-		if didPanic || didError {
-			io.ErrPrintfln("FAIL    %s \t%s", prettyDir, dstr)
-			testErrCount++
-			if cmd.failfast {
-				return fail()
+		if mod == nil || !mod.Ignore {
+			_, _, _, errs := lintTypeCheck(&lintPrinter{io: io}, pkg.Dir, mpkg, gno.TypeCheckOptions{
+				Getter:     opts.TestStore,
+				TestGetter: opts.TestStore,
+				Mode:       gno.TCLatestRelaxed,
+				Cache:      cache,
+			})
+			if errs != nil {
+				didError = true
+				// already printed in lintTypeCheck.
+				// io.ErrPrintln(errs)
+				return
 			}
-		} else {
-			io.ErrPrintfln("ok      %s \t%s", prettyDir, dstr)
+		} else if cmd.verbose {
+			io.ErrPrintfln("%s: module is ignore, skipping type check", pkgPath)
+		}
+
+		///////////////////////////////////
+		// Run the tests found in the mpkg.
+		errs := test.Test(mpkg, prettyDir, opts)
+		if errs != nil {
+			didError = true
+			io.ErrPrintln(errs)
+			return
+		}
+	})
+
+	// Print status with duration.
+	duration := time.Since(startedAt)
+	dstr := fmtDuration(duration)
+	if cmd.cover {
+		// Written even if the tests failed, but only reported if they passed.
+		coverage := coverPkg(io, coverOut, mpkg, pkg.Dir, opts.Coverage)
+		if !didPanic && !didError && coverage != "" {
+			dstr += "\t" + coverage
 		}
 	}
-	if testErrCount > 0 || buildErrCount > 0 {
-		return fail()
+	if didPanic || didError {
+		io.ErrPrintfln("FAIL    %s \t%s", prettyDir, dstr)
+		return false
 	}
+	io.ErrPrintfln("ok      %s \t%s", prettyDir, dstr)
 
-	return nil
+	if cmd.mutate {
+		testMutants(cmd, io, opts, cache, mpkg, pkg.Dir, prettyDir, duration)
+	}
+	return true
+}
+
+// prettyPkgDir relativizes and prepends dot to pkg dir if possible.
+// We ignore errors since it's a cosmetic thing.
+// XXX: use pkg import path instead of this when printing if possible
+func prettyPkgDir(pkg *packages.Package) string {
+	prettyDir := pkg.Dir
+	if filepath.IsAbs(pkg.Dir) {
+		cwd, err := os.Getwd()
+		if err == nil {
+			relDir, err := filepath.Rel(cwd, pkg.Dir)
+			if err == nil {
+				prettyDir = relDir
+				if prettyDir != "." && !strings.HasPrefix(prettyDir, "."+string(filepath.Separator)) {
+					prettyDir = "." + string(filepath.Separator) + prettyDir
+				}
+			}
+		}
+	}
+	return prettyDir
+}
+
+func hasTestFiles(pkg *packages.Package) bool {
+	return len(pkg.Files[packages.FileKindTest]) != 0 ||
+		len(pkg.Files[packages.FileKindXTest]) != 0 ||
+		len(pkg.Files[packages.FileKindFiletest]) != 0
 }
 
 func determinePkgPath(mod *gnomod.File, dir, rootDir string) (string, bool) {
@@ -0,0 +1,73 @@
+package main
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	goio "io"
+	"io/fs"
+	"os"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/test"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// createCoverProfile creates the cover profile at path, and writes its header.
+func createCoverProfile(path string) (*os.File, error) {
+	f, err := os.Create(path)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := f.WriteString(test.CoverProfileHeader); err != nil {
+		f.Close()
+		return nil, err
+	}
+	return f, nil
+}
+
+// appendCoverProfile appends to w the blocks of the cover profile at path,
+// written by a test worker. A missing profile is ignored, as the worker may
+// have failed before creating it.
+func appendCoverProfile(w goio.Writer, path string) error {
+	f, err := os.Open(path)
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil
+	}
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	// Skip the header.
+	if _, err := r.ReadString('\n'); err != nil {
+		if errors.Is(err, goio.EOF) {
+			return nil
+		}
+		return err
+	}
+	_, err = goio.Copy(w, r)
+	return err
+}
+
+// coverPkg writes the blocks of mpkg covered by cov to coverOut, if not nil,
+// and returns the coverage summary printed after the status of the package.
+func coverPkg(io commands.IO, coverOut goio.Writer, mpkg *std.MemPackage, dir string, cov *gno.Coverage) string {
+	blocks, err := test.CoverBlocks(mpkg, cov)
+	if err != nil {
+		io.ErrPrintfln("unable to compute the coverage of %s: %v", mpkg.Path, err)
+		return ""
+	}
+	if coverOut != nil {
+		if err := test.WriteCoverProfile(coverOut, dir, blocks); err != nil {
+			io.ErrPrintfln("unable to write cover profile: %v", err)
+		}
+	}
+	percent := test.CoveredPercent(blocks)
+	if percent < 0 {
+		return "coverage: [no statements]"
+	}
+	return fmt.Sprintf("coverage: %.1f%% of statements", percent)
+}
@@ -0,0 +1,181 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/test"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// testMutantEnv is set in the environment of the worker processes started by
+// 'gno test -mutate'. It is the index of the mutation of the tested package,
+// in the order of [test.Mutations].
+const testMutantEnv = "GNO_TEST_MUTANT"
+
+// mutantStatus is the result of testing a mutant.
+type mutantStatus int
+
+const (
+	mutantInvalid  mutantStatus = iota // doesn't type check, not tested.
+	mutantKilled                       // the tests failed.
+	mutantTimedOut                     // the tests timed out, counted as killed.
+	mutantSurvived                     // the tests passed.
+)
+
+// loadMutant returns the mutant of mpkg tested by a mutant worker, and loads
+// it in opts.TestStore so that the filetests use it too.
+func loadMutant(opts *test.TestOptions, mpkg *std.MemPackage, index string) (*std.MemPackage, error) {
+	i, err := strconv.Atoi(index)
+	if err != nil {
+		return nil, fmt.Errorf("invalid %s: %w", testMutantEnv, err)
+	}
+	muts, err := test.Mutations(mpkg)
+	if err != nil {
+		return nil, err
+	}
+	if i < 0 || i >= len(muts) {
+		return nil, fmt.Errorf("invalid %s: %d, the package has %d mutations", testMutantEnv, i, len(muts))
+	}
+	mutant := muts[i].Apply(mpkg)
+	opts.LoadPackage(mutant)
+	return mutant, nil
+}
+
+// testMutants tests the mutants of mpkg, whose tests passed in the given
+// duration, and prints those which survived followed by a summary line.
+// Each mutant is tested in its own worker process, so that it can be stopped
+// when its tests don't terminate; cmd.parallel bounds the number of workers.
+func testMutants(
+	cmd *testCmd,
+	io commands.IO,
+	opts *test.TestOptions,
+	cache gno.TypeCheckCache,
+	mpkg *std.MemPackage,
+	dir, prettyDir string,
+	duration time.Duration,
+) {
+	muts, err := test.Mutations(mpkg)
+	if err != nil {
+		io.ErrPrintfln("unable to mutate %s: %v", prettyDir, err)
+		return
+	}
+	exe, err := os.Executable()
+	if err != nil {
+		io.ErrPrintfln("unable to test mutants: %v", err)
+		return
+	}
+
+	// A mutant can make the tests loop forever: stop them once they take
+	// much longer than the tests of mpkg.
+	timeout := 10*duration + 10*time.Second
+
+	statuses := make([]mutantStatus, len(muts))
+	errs := make([]error, len(muts))
+	sem := make(chan struct{}, max(cmd.parallel, 1))
+	done := make(chan struct{})
+	pending := 0
+	for i, mu := range muts {
+		// Don't test the mutants which wouldn't build.
+		_, tcErrs := gno.TypeCheckMemPackage(mu.Apply(mpkg), gno.TypeCheckOptions{
+			Getter:     opts.TestStore,
+			TestGetter: opts.TestStore,
+			Mode:       gno.TCLatestRelaxed,
+			Cache:      cache,
+		})
+		if tcErrs != nil {
+			statuses[i] = mutantInvalid
+			continue
+		}
+
+		pending++
+		sem <- struct{}{}
+		go func() {
+			defer func() {
+				<-sem
+				done <- struct{}{}
+			}()
+			ctx, cancel := context.WithTimeout(context.Background(), timeout)
+			defer cancel()
+
+			wcmd := exec.CommandContext(ctx, exe, cmd.mutantArgs(dir)...)
+			wcmd.Env = append(os.Environ(),
+				testWorkerEnv+"=1",
+				testMutantEnv+"="+strconv.Itoa(i))
+			err := wcmd.Run()
+
+			var exitErr *exec.ExitError
+			switch {
+			case err == nil:
+				statuses[i] = mutantSurvived
+			case ctx.Err() != nil:
+				statuses[i] = mutantTimedOut
+			case errors.As(err, &exitErr):
+				statuses[i] = mutantKilled
+			default:
+				statuses[i], errs[i] = mutantInvalid, err
+			}
+		}()
+	}
+	for range pending {
+		<-done
+	}
+
+	var killed, survived, invalid int
+	for i, mu := range muts {
+		name := prettyDir + string(filepath.Separator) + mu.String()
+		switch statuses[i] {
+		case mutantInvalid:
+			invalid++
+			if errs[i] != nil {
+				io.ErrPrintfln("unable to test mutant %s: %v", name, errs[i])
+			}
+		case mutantKilled, mutantTimedOut:
+			killed++
+			if cmd.verbose {
+				status := "KILLED"
+				if statuses[i] == mutantTimedOut {
+					status = "TIMEOUT"
+				}
+				io.ErrPrintfln("--- %s: %s", status, name)
+			}
+		case mutantSurvived:
+			survived++
+			io.ErrPrintfln("--- SURVIVED: %s", name)
+		}
+	}
+
+	score := "no mutants"
+	if tested := killed + survived; tested > 0 {
+		score = fmt.Sprintf("%d/%d mutants killed (%.1f%%)",
+			killed, tested, 100*float64(killed)/float64(tested))
+	}
+	if invalid > 0 {
+		score += fmt.Sprintf(", %d invalid", invalid)
+	}
+	io.ErrPrintfln("mutate  %s \t%s", prettyDir, score)
+}
+
+// mutantArgs returns the arguments of a worker process testing a mutant of the
+// package in dir.
+func (c *testCmd) mutantArgs(dir string) []string {
+	args := []string{
+		"test",
+		"-p", "1",
+		"-failfast",
+		"-root-dir", c.rootDir,
+		"-auto-gnomod=false",
+	}
+	if c.run != "" {
+		args = append(args, "-run", c.run)
+	}
+	return append(args, dir)
+}
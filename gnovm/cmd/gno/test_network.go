@@ -0,0 +1,500 @@
+package main
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"go/parser"
+	"go/token"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gnolang/gno/gno.land/pkg/gnoclient"
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+	"github.com/gnolang/gno/gnovm/pkg/packages"
+	"github.com/gnolang/gno/gnovm/pkg/test"
+	rpcclient "github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/bip39"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// networkTester runs the integration filetests of packages against a node,
+// with -network. The packages are deployed under a namespace of the faucet
+// account which is unique to the run, so that runs don't collide, and each
+// filetest is run as a transaction of its own test account, funded by the
+// faucet account, which gets the rest of the funds back after the test.
+type networkTester struct {
+	cmd     *testCmd
+	io      commands.IO
+	rpc     *rpcclient.RPCClient
+	chainID string
+	faucet  gnoclient.Client
+	addr    crypto.Address // of the faucet account.
+	txCfg   gnoclient.BaseTxCfg
+	fund    std.Coins
+	run     *regexp.Regexp
+
+	// ns is the namespace the packages are deployed under.
+	ns string
+	// paths maps the paths of the deployed packages to their paths on the
+	// network.
+	paths map[string]string
+}
+
+func execTestNetwork(cmd *testCmd, pkgs packages.PkgList, io commands.IO) error {
+	switch {
+	case cmd.mutate, cmd.cover, cmd.coverProfile != "", cmd.bench != "", cmd.fuzz != "":
+		return errors.New("cannot use -network with -mutate, -cover, -bench or -fuzz")
+	case cmd.updateGoldenTests:
+		return errors.New("cannot use -network with -update-golden-tests")
+	case cmd.debug, cmd.debugAddr != "":
+		return errors.New("cannot use -network with the debugger")
+	case cmd.faucetKey == "":
+		return errors.New("-network requires a -faucet-key to fund the test accounts")
+	}
+
+	nt, err := newNetworkTester(cmd, io)
+	if err != nil {
+		return err
+	}
+	defer nt.rpc.Close()
+
+	buildErrCount := 0
+	testErrCount := 0
+	fail := func() error {
+		io.ErrPrintfln("FAIL")
+		return fmt.Errorf("FAIL: %d build errors, %d test errors", buildErrCount, testErrCount)
+	}
+
+	// Select the packages with integration filetests, which are deployed
+	// with the packages of the workspace they import.
+	tested := make(map[string][]*std.MemFile)
+	for _, pkg := range pkgs {
+		for _, err := range pkg.Errors {
+			io.ErrPrintfln("%s", err.Error())
+			buildErrCount++
+		}
+		if len(pkg.Errors) != 0 || len(pkg.Match) == 0 {
+			continue
+		}
+		files, err := integrationFiletests(pkg)
+		if err != nil {
+			io.ErrPrintfln("%s", err.Error())
+			buildErrCount++
+			continue
+		}
+		if len(files) > 0 {
+			tested[pkg.ImportPath] = files
+		}
+	}
+	if buildErrCount > 0 {
+		return fail()
+	}
+	deployed := packagesToDeploy(pkgs, tested)
+	for _, pkg := range deployed {
+		nt.paths[pkg.ImportPath] = nt.networkPath(pkg.ImportPath)
+	}
+	for _, pkg := range deployed {
+		if err := nt.deploy(pkg); err != nil {
+			io.ErrPrintfln("%s: %v", pkg.ImportPath, err)
+			buildErrCount++
+			return fail()
+		}
+	}
+
+	for _, pkg := range pkgs {
+		if len(pkg.Errors) != 0 || len(pkg.Match) == 0 {
+			continue
+		}
+		prettyDir := prettyPkgDir(pkg)
+		files, ok := tested[pkg.ImportPath]
+		if !ok {
+			io.ErrPrintfln("?       %s \t[no integration test files]", prettyDir)
+			continue
+		}
+
+		startedAt := time.Now()
+		ok = true
+		for _, file := range files {
+			if nt.run != nil && !nt.run.MatchString(file.Name) {
+				continue
+			}
+			if !nt.testFile(prettyDir, file) {
+				ok = false
+				if cmd.failfast {
+					break
+				}
+			}
+		}
+
+		dstr := fmtDuration(time.Since(startedAt))
+		if !ok {
+			io.ErrPrintfln("FAIL    %s \t%s", prettyDir, dstr)
+			testErrCount++
+			if cmd.failfast {
+				return fail()
+			}
+			continue
+		}
+		io.ErrPrintfln("ok      %s \t%s", prettyDir, dstr)
+	}
+	if testErrCount > 0 {
+		return fail()
+	}
+	return nil
+}
+
+func newNetworkTester(cmd *testCmd, io commands.IO) (*networkTester, error) {
+	nt := &networkTester{
+		cmd:   cmd,
+		io:    io,
+		paths: make(map[string]string),
+		txCfg: gnoclient.BaseTxCfg{
+			GasFee:    cmd.gasFee,
+			GasWanted: cmd.gasWanted,
+		},
+	}
+	if _, err := std.ParseCoin(cmd.gasFee); err != nil {
+		return nil, fmt.Errorf("invalid -gas-fee: %w", err)
+	}
+	fund, err := std.ParseCoins(cmd.fund)
+	if err != nil {
+		return nil, fmt.Errorf("invalid -fund: %w", err)
+	}
+	nt.fund = fund
+	if cmd.run != "" {
+		if nt.run, err = regexp.Compile(cmd.run); err != nil {
+			return nil, fmt.Errorf("invalid -run regexp: %w", err)
+		}
+	}
+
+	nt.rpc, err = rpcclient.NewHTTPClient(cmd.network)
+	if err != nil {
+		return nil, fmt.Errorf("unable to connect to %s: %w", cmd.network, err)
+	}
+	status, err := nt.rpc.Status(context.Background(), nil)
+	if err != nil {
+		nt.rpc.Close()
+		return nil, fmt.Errorf("unable to get the status of %s: %w", cmd.network, err)
+	}
+	nt.chainID = status.NodeInfo.Network
+
+	kb, err := keys.NewKeyBaseFromDir(cmd.home)
+	if err != nil {
+		nt.rpc.Close()
+		return nil, err
+	}
+	info, err := kb.GetByNameOrAddress(cmd.faucetKey)
+	if err != nil {
+		nt.rpc.Close()
+		return nil, fmt.Errorf("unable to get the faucet key: %w", err)
+	}
+	pass, err := io.GetPassword("Enter password for the faucet key.", cmd.insecurePasswordStdin)
+	if err != nil {
+		nt.rpc.Close()
+		return nil, err
+	}
+	signer := gnoclient.SignerFromKeybase{
+		Keybase:  kb,
+		Account:  info.GetName(),
+		Password: pass,
+		ChainID:  nt.chainID,
+	}
+	if err := signer.Validate(); err != nil {
+		nt.rpc.Close()
+		return nil, fmt.Errorf("invalid faucet key: %w", err)
+	}
+	nt.faucet = gnoclient.Client{Signer: signer, RPCClient: nt.rpc}
+	nt.addr = info.GetAddress()
+
+	var suffix [4]byte
+	if _, err := rand.Read(suffix[:]); err != nil {
+		nt.rpc.Close()
+		return nil, err
+	}
+	nt.ns = nt.addr.String() + "/gnotest" + hex.EncodeToString(suffix[:])
+	return nt, nil
+}
+
+// networkPath returns the path of the package at pkgPath on the network: the
+// path in the namespace of the run, like gno.land/r/g1.../gnotest1a2b3c4d/foo
+// for gno.land/r/foo.
+func (nt *networkTester) networkPath(pkgPath string) string {
+	parts := strings.SplitN(pkgPath, "/", 3)
+	if len(parts) < 3 {
+		return pkgPath
+	}
+	return parts[0] + "/" + parts[1] + "/" + nt.ns + "/" + parts[2]
+}
+
+// deploy adds the package pkg to the network, at its path in the namespace of
+// the run.
+func (nt *networkTester) deploy(pkg *packages.Package) error {
+	path := nt.paths[pkg.ImportPath]
+	mpkg, err := gno.ReadMemPackage(pkg.Dir, path, gno.MPUserProd)
+	if err != nil {
+		return err
+	}
+	for _, file := range mpkg.Files {
+		switch {
+		case file.Name == "gnomod.toml":
+			mod, err := gnomod.ParseBytes(file.Name, []byte(file.Body))
+			if err != nil {
+				return err
+			}
+			mod.Module = path
+			file.Body = mod.WriteString()
+		case strings.HasSuffix(file.Name, ".gno"):
+			if file.Body, err = rewriteImports(file.Name, file.Body, nt.paths); err != nil {
+				return err
+			}
+		}
+	}
+
+	msg := vm.MsgAddPackage{
+		Creator: nt.addr,
+		Package: mpkg,
+	}
+	if _, err := nt.faucet.AddPackage(nt.txCfg, msg); err != nil {
+		return fmt.Errorf("unable to deploy the package to %s: %w", path, err)
+	}
+	if nt.cmd.verbose {
+		nt.io.ErrPrintfln("deployed %s as %s", pkg.ImportPath, path)
+	}
+	return nil
+}
+
+// testFile runs the integration filetest file as a transaction of a new test
+// account, and returns whether it passed.
+func (nt *networkTester) testFile(prettyDir string, file *std.MemFile) bool {
+	testName := prettyDir + "/" + file.Name
+	if nt.cmd.verbose {
+		nt.io.ErrPrintfln("=== RUN   %s", testName)
+	}
+	startedAt := time.Now()
+	err := nt.runFiletest(file)
+	dstr := fmtDuration(time.Since(startedAt))
+	if err != nil {
+		nt.io.ErrPrintfln("--- FAIL: %s (%s)", testName, dstr)
+		nt.io.ErrPrintln(err.Error())
+		return false
+	}
+	if nt.cmd.verbose {
+		nt.io.ErrPrintfln("--- PASS: %s (%s)", testName, dstr)
+	}
+	return true
+}
+
+func (nt *networkTester) runFiletest(file *std.MemFile) error {
+	dirs, err := test.ParseDirectives(strings.NewReader(file.Body))
+	if err != nil {
+		return fmt.Errorf("error parsing directives: %w", err)
+	}
+	send, err := std.ParseCoins(dirs.FirstDefault(test.DirectiveSend, ""))
+	if err != nil {
+		return err
+	}
+	body, err := rewriteImports(file.Name, file.Body, nt.paths)
+	if err != nil {
+		return err
+	}
+
+	// Create and fund the test account.
+	entropy, err := bip39.NewEntropy(256)
+	if err != nil {
+		return err
+	}
+	mnemonic, err := bip39.NewMnemonic(entropy)
+	if err != nil {
+		return err
+	}
+	signer, err := gnoclient.SignerFromBip39(mnemonic, nt.chainID, "", 0, 0)
+	if err != nil {
+		return err
+	}
+	info, err := signer.Info()
+	if err != nil {
+		return err
+	}
+	account := gnoclient.Client{Signer: signer, RPCClient: nt.rpc}
+	addr := info.GetAddress()
+	if _, err := nt.faucet.Send(nt.txCfg, bank.MsgSend{
+		FromAddress: nt.addr,
+		ToAddress:   addr,
+		Amount:      nt.fund.Add(send),
+	}); err != nil {
+		return fmt.Errorf("unable to fund the test account: %w", err)
+	}
+	// The refund may fail, for example on chains restricting the transfers
+	// of the coins, which doesn't make the test fail.
+	defer func() {
+		if err := nt.refund(account, addr); err != nil {
+			nt.io.ErrPrintfln("warning: %v", err)
+		}
+	}()
+
+	// The filetest is run as the main package of a MsgRun.
+	name := strings.TrimSuffix(file.Name, "_filetest.gno") + ".gno"
+	msg := vm.NewMsgRun(addr, send, []*std.MemFile{{Name: name, Body: body}})
+	res, runErr := account.Run(nt.txCfg, msg)
+	if res == nil && runErr != nil {
+		return runErr
+	}
+
+	if errDirective := dirs.First(test.DirectiveError); errDirective != nil {
+		want := strings.TrimSpace(errDirective.Content)
+		if runErr == nil {
+			return fmt.Errorf("expected error %q, but the transaction succeeded", want)
+		}
+		if log := txErrorLog(res); !strings.Contains(log, want) {
+			return fmt.Errorf("Error diff:\nexpected %q in:\n%s", want, log)
+		}
+		return nil
+	}
+	if runErr != nil {
+		return fmt.Errorf("unexpected error: %w", runErr)
+	}
+
+	output := strings.TrimRight(string(res.DeliverTx.Data), "\n")
+	want := strings.TrimRight(dirs.FirstDefault(test.DirectiveOutput, ""), "\n")
+	if output != want {
+		return fmt.Errorf("Output diff:\n-want:\n%s\n+got:\n%s", want, output)
+	}
+	return nil
+}
+
+// refund sends the funds left on the test account at addr back to the faucet,
+// but for the fee of the transaction.
+func (nt *networkTester) refund(account gnoclient.Client, addr crypto.Address) error {
+	acc, _, err := account.QueryAccount(addr)
+	if err != nil {
+		return fmt.Errorf("unable to refund the test account: %w", err)
+	}
+	fee := std.Coins{std.MustParseCoin(nt.txCfg.GasFee)}
+	if !acc.Coins.IsAllGT(fee) {
+		return nil
+	}
+	if _, err := account.Send(nt.txCfg, bank.MsgSend{
+		FromAddress: addr,
+		ToAddress:   nt.addr,
+		Amount:      acc.Coins.Sub(fee),
+	}); err != nil {
+		return fmt.Errorf("unable to refund the test account: %w", err)
+	}
+	return nil
+}
+
+// txErrorLog returns the error and the log of the failed transaction of res.
+func txErrorLog(res *ctypes.ResultBroadcastTxCommit) string {
+	r := res.DeliverTx.ResponseBase
+	if res.CheckTx.IsErr() {
+		r = res.CheckTx.ResponseBase
+	}
+	var sb strings.Builder
+	if r.Error != nil {
+		sb.WriteString(r.Error.Error())
+		sb.WriteString("\n")
+	}
+	sb.WriteString(r.Log)
+	return sb.String()
+}
+
+// integrationFiletests returns the filetests of pkg marked as integration
+// tests, sorted by name.
+func integrationFiletests(pkg *packages.Package) ([]*std.MemFile, error) {
+	var files []*std.MemFile
+	for _, name := range pkg.Files[packages.FileKindFiletest] {
+		body, err := os.ReadFile(filepath.Join(pkg.Dir, name))
+		if err != nil {
+			return nil, err
+		}
+		file := &std.MemFile{Name: name, Body: string(body)}
+		dirs, err := test.ParseDirectives(strings.NewReader(file.Body))
+		if err != nil {
+			return nil, fmt.Errorf("%s: error parsing directives: %w", name, err)
+		}
+		integration, err := dirs.Integration()
+		if err != nil {
+			return nil, fmt.Errorf("%s: %w", name, err)
+		}
+		if integration {
+			files = append(files, file)
+		}
+	}
+	slices.SortFunc(files, func(a, b *std.MemFile) int { return strings.Compare(a.Name, b.Name) })
+	return files, nil
+}
+
+// packagesToDeploy returns the packages of tested, and the packages of the
+// workspace they import, in the order in which they must be deployed. Other
+// imports, of the standard library or downloaded to the modcache, must already
+// be on the network.
+func packagesToDeploy(pkgs packages.PkgList, tested map[string][]*std.MemFile) []*packages.Package {
+	modCache := gnomod.ModCachePath() + string(filepath.Separator)
+	var deploy []*packages.Package
+	seen := make(map[string]bool)
+	var visit func(pkg *packages.Package, kinds ...packages.FileKind)
+	visit = func(pkg *packages.Package, kinds ...packages.FileKind) {
+		if seen[pkg.ImportPath] {
+			return
+		}
+		seen[pkg.ImportPath] = true
+		for _, imp := range pkg.ImportsSpecs.Merge(kinds...) {
+			dep := pkgs.Get(imp.PkgPath)
+			if dep != nil && !gno.IsStdlib(dep.ImportPath) && !strings.HasPrefix(dep.Dir, modCache) {
+				visit(dep, packages.FileKindPackageSource)
+			}
+		}
+		// After its dependencies.
+		deploy = append(deploy, pkg)
+	}
+	for _, pkg := range pkgs {
+		if _, ok := tested[pkg.ImportPath]; ok {
+			// The filetests may import other tested packages.
+			visit(pkg, packages.FileKindPackageSource, packages.FileKindFiletest)
+		}
+	}
+	return deploy
+}
+
+// rewriteImports returns the source of the file name with the imports of the
+// packages of paths replaced by their paths on the network.
+func rewriteImports(name, src string, paths map[string]string) (string, error) {
+	f, err := parser.ParseFile(token.NewFileSet(), name, src, parser.ImportsOnly)
+	if err != nil {
+		return "", err
+	}
+	var sb strings.Builder
+	last := 0
+	for _, spec := range f.Imports {
+		path, err := strconv.Unquote(spec.Path.Value)
+		if err != nil {
+			return "", err
+		}
+		npath, ok := paths[path]
+		if !ok {
+			continue
+		}
+		// Positions are 1-based offsets, with a file set of a single file.
+		start, end := int(spec.Path.Pos())-1, int(spec.Path.End())-1
+		sb.WriteString(src[last:start])
+		sb.WriteString(strconv.Quote(npath))
+		last = end
+	}
+	sb.WriteString(src[last:])
+	return sb.String(), nil
+}
@@ -0,0 +1,141 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gno.land/pkg/integration"
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/log"
+)
+
+var networkTestFiles = map[string]string{
+	"gnowork.toml": ``,
+	"counter/gnomod.toml": `module = "gno.land/r/test/counter"
+gno = "0.9"
+`,
+	"counter/counter.gno": `package counter
+
+import "gno.land/p/test/incr"
+
+var count int
+
+func Incr(cur realm) int {
+	count = incr.Incr(count)
+	return count
+}
+`,
+	"counter/incr_filetest.gno": `// INTEGRATION: true
+
+package main
+
+import "gno.land/r/test/counter"
+
+func main() {
+	println(counter.Incr(cross))
+	println(counter.Incr(cross))
+}
+
+// Output:
+// 1
+// 2
+`,
+	"counter/panic_filetest.gno": `// INTEGRATION: true
+
+package main
+
+func main() {
+	panic("boom")
+}
+
+// Error:
+// boom
+`,
+	"counter/unit_filetest.gno": `package main
+
+func main() {
+	panic("not an integration test")
+}
+`,
+	"incr/gnomod.toml": `module = "gno.land/p/test/incr"
+gno = "0.9"
+`,
+	"incr/incr.gno": `package incr
+
+func Incr(n int) int { return n + 1 }
+`,
+}
+
+func TestTestNetwork(t *testing.T) {
+	rootdir := gnoenv.RootDir()
+	config := integration.TestingMinimalNodeConfig(rootdir)
+	node, remoteAddr := integration.TestingInMemoryNode(t, log.NewNoopLogger(), config)
+	defer node.Stop()
+
+	home := t.TempDir()
+	kb, err := keys.NewKeyBaseFromDir(home)
+	require.NoError(t, err)
+	_, err = kb.CreateAccount(integration.DefaultAccount_Name, integration.DefaultAccount_Seed, "", "", 0, 0)
+	require.NoError(t, err)
+
+	dir := t.TempDir()
+	for name, body := range networkTestFiles {
+		path := filepath.Join(dir, filepath.FromSlash(name))
+		require.NoError(t, os.MkdirAll(filepath.Dir(path), 0o755))
+		require.NoError(t, os.WriteFile(path, []byte(body), 0o644))
+	}
+	wd, err := os.Getwd()
+	require.NoError(t, err)
+	require.NoError(t, os.Chdir(dir))
+	defer os.Chdir(wd)
+
+	run := func(args ...string) (string, error) {
+		var stderr bytes.Buffer
+		io := commands.NewTestIO()
+		io.SetIn(strings.NewReader("\n"))
+		io.SetOut(commands.WriteNopCloser(&bytes.Buffer{}))
+		io.SetErr(commands.WriteNopCloser(&stderr))
+		cmd, _ := newGnocliCmd(io)
+		args = append([]string{
+			"test", "-network", remoteAddr, "-faucet-key", integration.DefaultAccount_Name,
+			"-home", home, "-insecure-password-stdin",
+		}, args...)
+		err := cmd.ParseAndRun(context.Background(), args)
+		return stderr.String(), err
+	}
+
+	// The integration filetests of counter pass, and the other is not run;
+	// incr, which it imports, is deployed with it.
+	out, err := run("-v", "./counter", "./incr")
+	require.NoError(t, err, out)
+	assert.Regexp(t, `deployed gno.land/p/test/incr as gno.land/p/g1\w+/gnotest[0-9a-f]{8}/test/incr`, out)
+	assert.Regexp(t, `deployed gno.land/r/test/counter as gno.land/r/g1\w+/gnotest[0-9a-f]{8}/test/counter`, out)
+	assert.Contains(t, out, "--- PASS: ./counter/incr_filetest.gno")
+	assert.Contains(t, out, "--- PASS: ./counter/panic_filetest.gno")
+	assert.NotContains(t, out, "unit_filetest.gno")
+	assert.Contains(t, out, "?       ./incr \t[no integration test files]")
+	assert.Contains(t, out, "ok      ./counter \t")
+
+	// Each run deploys to a new namespace, with the state of a new realm.
+	out, err = run("-run", "incr", "./counter")
+	require.NoError(t, err, out)
+	assert.NotContains(t, out, "panic_filetest.gno")
+
+	// A failing integration filetest.
+	require.NoError(t, os.WriteFile(filepath.Join(dir, "counter", "incr_filetest.gno"),
+		[]byte(strings.Replace(networkTestFiles["counter/incr_filetest.gno"], "// 2", "// 3", 1)), 0o644))
+	out, err = run("./counter")
+	require.Error(t, err)
+	assert.Contains(t, out, "--- FAIL: ./counter/incr_filetest.gno")
+	assert.Contains(t, out, "Output diff:")
+	assert.Contains(t, out, "FAIL    ./counter \t")
+}
@@ -0,0 +1,224 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"path/filepath"
+	"runtime"
+	"sort"
+	"strings"
+	"text/tabwriter"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/gnovm/pkg/test"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+type testVMCmd struct {
+	rootDir  string
+	filter   string
+	parallel int
+	short    bool
+	update   bool
+	verbose  bool
+}
+
+func newTestVMCmd(io commands.IO) *commands.Command {
+	cfg := &testVMCmd{}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "test-vm",
+			ShortUsage: "test-vm [flags] [dir]",
+			ShortHelp:  "runs the GnoVM filetests",
+			LongHelp: `Runs the filetests of the GnoVM, in gnovm/tests/files or in the given
+directory, and prints a summary of the differences between their result and
+their golden directives, followed by the number of filetests passed, failed and
+skipped for each tag.
+
+Each filetest is tagged from its content: "realm" if it runs in a realm,
+"stdlib" if it imports a standard library, "error" if it expects an error,
+"long" if it is a _long.gno filetest, and "output", "realmops", "events",
+"preprocessed" or "storage" if it has the corresponding directive. Filetests
+in a subdirectory are also tagged with the name of the directory.
+
+-filter selects the filetests with a comma-separated list of terms, all of
+which must match: "tag:NAME" matches the filetests with the tag NAME, and any
+other term is a regular expression matched against the path of the filetest.
+A term prefixed with "!" excludes the filetests it matches. For example:
+
+	gno test-vm -filter 'tag:realm,!tag:long,^zrealm_crossrealm'
+
+The operations of the Realm directives are compared in a stable order: a
+change in the order in which objects are saved alone is not reported as a
+difference.`,
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execTestVM(cfg, args, io)
+		},
+	)
+}
+
+func (c *testVMCmd) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.rootDir,
+		"root-dir",
+		"",
+		"clone location of github.com/gnolang/gno (gno binary tries to guess it)",
+	)
+
+	fs.StringVar(
+		&c.filter,
+		"filter",
+		"",
+		"comma-separated tags (tag:NAME) and regular expressions selecting the filetests",
+	)
+
+	fs.IntVar(
+		&c.parallel,
+		"p",
+		runtime.GOMAXPROCS(0),
+		"number of filetests run in parallel",
+	)
+
+	fs.BoolVar(
+		&c.short,
+		"short",
+		false,
+		"skip the long filetests",
+	)
+
+	fs.BoolVar(
+		&c.update,
+		"update-golden-tests",
+		false,
+		"rewrite the Output, Realm and other golden directives which changed",
+	)
+
+	fs.BoolVar(
+		&c.verbose,
+		"v",
+		false,
+		"print the result of every filetest, not only the failures",
+	)
+}
+
+func execTestVM(cfg *testVMCmd, args []string, io commands.IO) error {
+	if len(args) > 1 {
+		return flag.ErrHelp
+	}
+	if cfg.parallel <= 0 {
+		return fmt.Errorf("invalid -p: %d", cfg.parallel)
+	}
+	if cfg.rootDir == "" {
+		cfg.rootDir = gnoenv.RootDir()
+	}
+	dir := filepath.Join(cfg.rootDir, "gnovm", "tests", "files")
+	if len(args) == 1 {
+		dir = args[0]
+	}
+	filter, err := test.ParseFiletestFilter(cfg.filter)
+	if err != nil {
+		return fmt.Errorf("invalid -filter: %w", err)
+	}
+
+	fm := &test.FiletestMatrix{
+		RootDir:  cfg.rootDir,
+		Dir:      dir,
+		Filter:   filter,
+		Parallel: cfg.parallel,
+		Short:    cfg.short,
+		Sync:     cfg.update,
+	}
+	results, err := fm.Run(func(r test.FiletestResult) {
+		printFiletestResult(io, r, cfg.verbose)
+	})
+	if err != nil {
+		return err
+	}
+
+	// Summary by tag.
+	type counts struct{ pass, fail, skip int }
+	var total counts
+	byTag := map[string]*counts{}
+	for _, r := range results {
+		c := &total
+		for i := -1; i < len(r.Tags); i++ {
+			if i >= 0 {
+				if byTag[r.Tags[i]] == nil {
+					byTag[r.Tags[i]] = &counts{}
+				}
+				c = byTag[r.Tags[i]]
+			}
+			switch {
+			case r.Skipped != "":
+				c.skip++
+			case r.Err != nil:
+				c.fail++
+			default:
+				c.pass++
+			}
+		}
+	}
+	tags := make([]string, 0, len(byTag))
+	for tag := range byTag {
+		tags = append(tags, tag)
+	}
+	sort.Strings(tags)
+	tw := tabwriter.NewWriter(io.Out(), 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "tag\tpass\tfail\tskip\t")
+	for _, tag := range tags {
+		c := byTag[tag]
+		fmt.Fprintf(tw, "%s\t%d\t%d\t%d\t\n", tag, c.pass, c.fail, c.skip)
+	}
+	fmt.Fprintf(tw, "total\t%d\t%d\t%d\t\n", total.pass, total.fail, total.skip)
+	if err := tw.Flush(); err != nil {
+		return err
+	}
+
+	if total.fail > 0 {
+		return fmt.Errorf("%d filetest(s) failed", total.fail)
+	}
+	return nil
+}
+
+func printFiletestResult(io commands.IO, r test.FiletestResult, verbose bool) {
+	tags := strings.Join(r.Tags, ",")
+	switch {
+	case r.Skipped != "":
+		if verbose {
+			io.Printfln("SKIP  %s [%s] (%s)", r.Path, tags, r.Skipped)
+		}
+		return
+	case r.Err == nil:
+		if r.Updated {
+			io.Printfln("UPDATED %s [%s]", r.Path, tags)
+		} else if verbose {
+			io.Printfln("PASS  %s [%s] (%s)", r.Path, tags, r.Duration.Round(1e6))
+		}
+		return
+	}
+
+	io.Printfln("FAIL  %s [%s] (%s)", r.Path, tags, r.Duration.Round(1e6))
+	mismatches := r.Mismatches()
+	if len(mismatches) == 0 {
+		// Not a mismatch of directives, like an unexpected panic.
+		io.Println(indent(r.Err.Error()))
+		return
+	}
+	for _, mm := range mismatches {
+		diff := mm.Diff()
+		if diff == "" {
+			io.Printfln("    %s: only the order of the operations changed", mm.Directive)
+			continue
+		}
+		io.Printfln("    %s diff:", mm.Directive)
+		io.Println(indent(strings.TrimRight(diff, "\n")))
+	}
+}
+
+func indent(s string) string {
+	return "        " + strings.ReplaceAll(s, "\n", "\n        ")
+}
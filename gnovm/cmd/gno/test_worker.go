@@ -0,0 +1,139 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"errors"
+	"fmt"
+	"os"
+	"os/exec"
+	"path/filepath"
+	"strconv"
+
+	"github.com/gnolang/gno/gnovm/pkg/packages"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+// testWorkerEnv is set in the environment of the worker processes started by
+// a parallel 'gno test'.
+const testWorkerEnv = "GNO_TEST_WORKER"
+
+// testWorker is a 'gno test' subprocess testing a single package. Its output
+// is buffered, so that it can be printed in package order once it's done.
+type testWorker struct {
+	cmd    *exec.Cmd
+	stdout bytes.Buffer
+	stderr bytes.Buffer
+	err    error
+	done   chan struct{}
+
+	coverProfile string // written by the worker, with -coverprofile.
+}
+
+// startTestWorkers starts a worker for each testable package of pkgs, running
+// at most cmd.parallel of them at a time. The returned slice is indexed like
+// pkgs; it is nil if there are less than two packages to test, in which case
+// they should be tested in the current process. With -coverprofile, the
+// workers write their cover profiles to coverDir.
+func startTestWorkers(ctx context.Context, cmd *testCmd, pkgs []*packages.Package, coverDir string) ([]*testWorker, error) {
+	exe, err := os.Executable()
+	if err != nil {
+		return nil, fmt.Errorf("unable to start test workers: %w", err)
+	}
+
+	workers := make([]*testWorker, len(pkgs))
+	var queue []*testWorker
+	for i, pkg := range pkgs {
+		if len(pkg.Errors) != 0 || len(pkg.Match) == 0 || !hasTestFiles(pkg) {
+			continue
+		}
+		w := &testWorker{done: make(chan struct{})}
+		if coverDir != "" {
+			w.coverProfile = filepath.Join(coverDir, strconv.Itoa(i)+".out")
+		}
+		w.cmd = exec.CommandContext(ctx, exe, cmd.workerArgs(pkg.Dir, w.coverProfile)...)
+		w.cmd.Env = append(os.Environ(), testWorkerEnv+"=1")
+		w.cmd.Stdout = &w.stdout
+		w.cmd.Stderr = &w.stderr
+		workers[i] = w
+		queue = append(queue, w)
+	}
+	if len(queue) < 2 {
+		return nil, nil
+	}
+
+	sem := make(chan struct{}, cmd.parallel)
+	go func() {
+		for _, w := range queue {
+			select {
+			case sem <- struct{}{}:
+			case <-ctx.Done():
+				w.err = ctx.Err()
+				close(w.done)
+				continue
+			}
+			go func() {
+				w.err = w.cmd.Run()
+				<-sem
+				close(w.done)
+			}()
+		}
+	}()
+
+	return workers, nil
+}
+
+// wait waits for the worker to be done, and copies its output to io.
+// It returns false if the package failed.
+func (w *testWorker) wait(io commands.IO) bool {
+	<-w.done
+	io.Out().Write(w.stdout.Bytes())
+	io.Err().Write(w.stderr.Bytes())
+
+	var exitErr *exec.ExitError
+	if w.err != nil && !errors.As(w.err, &exitErr) {
+		io.ErrPrintfln("unable to run test worker: %v", w.err)
+	}
+	return w.err == nil
+}
+
+// workerArgs returns the arguments of a worker process testing dir, writing
+// its cover profile to coverProfile if set.
+func (c *testCmd) workerArgs(dir, coverProfile string) []string {
+	args := []string{
+		"test",
+		"-p", "1",
+		"-root-dir", c.rootDir,
+		"-auto-gnomod=" + strconv.FormatBool(c.autoGnomod),
+	}
+	if c.verbose {
+		args = append(args, "-v")
+	}
+	if c.failfast {
+		args = append(args, "-failfast")
+	}
+	if c.updateGoldenTests {
+		args = append(args, "-update-golden-tests")
+	}
+	if c.run != "" {
+		args = append(args, "-run", c.run)
+	}
+	if c.timeout > 0 {
+		args = append(args, "-timeout", c.timeout.String())
+	}
+	if c.printRuntimeMetrics {
+		args = append(args, "-print-runtime-metrics")
+	}
+	if c.maxGas > 0 {
+		args = append(args, "-max-gas", strconv.FormatInt(c.maxGas, 10))
+	}
+	if c.printEvents {
+		args = append(args, "-print-events")
+	}
+	if coverProfile != "" {
+		args = append(args, "-coverprofile", coverProfile)
+	} else if c.cover {
+		args = append(args, "-cover")
+	}
+	return append(args, dir)
+}
@@ -2,6 +2,7 @@ package main
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"flag"
 	"fmt"
@@ -29,6 +30,8 @@ type transpileCfg struct {
 	gobuild     bool
 	goBinary    string
 	output      string
+	watch       bool
+	sourceMaps  bool
 }
 
 type transpileOptions struct {
@@ -40,6 +43,22 @@ type transpileOptions struct {
 	transpiled map[string]struct{}
 	// skipped packages (gno mod marks them as ignore)
 	skipped []string
+	// imports maps the absolute directory of each transpiled
+	// package to the directories of the packages it imports.
+	imports map[string]map[string]struct{}
+	// generated maps the absolute path of each generated .go file
+	// to its source, when building with -gobuild.
+	generated map[string]generatedFile
+}
+
+// generatedFile is a .go file generated by transpileFile, used to map the
+// positions of the go build errors back to the .gno source.
+type generatedFile struct {
+	srcPath string
+	// lineOffset is the line of the //line directive, after which the
+	// line numbers reported by go build start back at 1.
+	lineOffset int
+	sourceMap  *transpiler.SourceMap
 }
 
 func newTranspileOptions(cfg *transpileCfg, io commands.IO) *transpileOptions {
@@ -47,6 +66,8 @@ func newTranspileOptions(cfg *transpileCfg, io commands.IO) *transpileOptions {
 		cfg:        cfg,
 		io:         io,
 		transpiled: map[string]struct{}{},
+		imports:    map[string]map[string]struct{}{},
+		generated:  map[string]generatedFile{},
 	}
 }
 
@@ -63,6 +84,16 @@ func (p *transpileOptions) markAsTranspiled(pkg string) {
 	p.transpiled[pkg] = struct{}{}
 }
 
+func (p *transpileOptions) addImports(dir string, imports []string) {
+	dir = absPath(dir)
+	if p.imports[dir] == nil {
+		p.imports[dir] = map[string]struct{}{}
+	}
+	for _, imp := range imports {
+		p.imports[dir][absPath(imp)] = struct{}{}
+	}
+}
+
 func newTranspileCmd(io commands.IO) *commands.Command {
 	cfg := &transpileCfg{}
 
@@ -73,8 +104,8 @@ func newTranspileCmd(io commands.IO) *commands.Command {
 			ShortHelp:  "transpiles .gno files to .go",
 		},
 		cfg,
-		func(_ context.Context, args []string) error {
-			return execTranspile(cfg, args, io)
+		func(ctx context.Context, args []string) error {
+			return execTranspile(ctx, cfg, args, io)
 		},
 	)
 }
@@ -121,9 +152,23 @@ func (c *transpileCfg) RegisterFlags(fs *flag.FlagSet) {
 		".",
 		"output directory",
 	)
+
+	fs.BoolVar(
+		&c.watch,
+		"watch",
+		false,
+		"keep running, and transpile the .gno files again when they change",
+	)
+
+	fs.BoolVar(
+		&c.sourceMaps,
+		"source-maps",
+		false,
+		"write the source map of each generated .go file next to it, as <file>.go.map",
+	)
 }
 
-func execTranspile(cfg *transpileCfg, args []string, io commands.IO) error {
+func execTranspile(ctx context.Context, cfg *transpileCfg, args []string, io commands.IO) error {
 	if len(args) < 1 {
 		return flag.ErrHelp
 	}
@@ -140,11 +185,39 @@ func execTranspile(cfg *transpileCfg, args []string, io commands.IO) error {
 	}
 
 	opts := newTranspileOptions(cfg, io)
+	errlist, err := transpilePaths(paths, opts)
+	if err != nil {
+		return err
+	}
+
+	if errlist.Len() == 0 && cfg.gobuild {
+		errlist, err = goBuildPaths(paths, opts)
+		if err != nil {
+			return err
+		}
+	}
+
+	if cfg.watch {
+		printTranspileErrors(io, errlist)
+		return transpileWatch(ctx, paths, opts)
+	}
+
+	if errlist.Len() > 0 {
+		printTranspileErrors(io, errlist)
+		return fmt.Errorf("%d transpile error(s)", errlist.Len())
+	}
+	return nil
+}
+
+// transpilePaths transpiles the given packages and files. The errors found in
+// the .gno files are returned in the error list.
+func transpilePaths(paths []string, opts *transpileOptions) (scanner.ErrorList, error) {
+	io := opts.io
 	var errlist scanner.ErrorList
 	for _, path := range paths {
 		st, err := os.Stat(path)
 		if err != nil {
-			return err
+			return nil, err
 		}
 		if st.IsDir() {
 			err = transpilePkg(path, opts)
@@ -159,41 +232,46 @@ func execTranspile(cfg *transpileCfg, args []string, io commands.IO) error {
 			var fileErrlist scanner.ErrorList
 			if !errors.As(err, &fileErrlist) {
 				// Not an scanner.ErrorList: return immediately.
-				return fmt.Errorf("%s: transpile: %w", path, err)
+				return nil, fmt.Errorf("%s: transpile: %w", path, err)
 			}
 			errlist = append(errlist, fileErrlist...)
 		}
 	}
+	return errlist, nil
+}
 
-	if errlist.Len() == 0 && cfg.gobuild {
-		for _, pkgPath := range paths {
-			if slices.Contains(opts.skipped, pkgPath) {
-				continue
-			}
-			if cfg.output != "." {
-				if pkgPath, err = ResolvePath(cfg.output, pkgPath); err != nil {
-					return fmt.Errorf("resolve output path: %w", err)
-				}
+// goBuildPaths runs go build on the transpiled packages and files. The build
+// errors are returned in the error list.
+func goBuildPaths(paths []string, opts *transpileOptions) (scanner.ErrorList, error) {
+	cfg := opts.cfg
+	var errlist scanner.ErrorList
+	for _, pkgPath := range paths {
+		if slices.Contains(opts.skipped, pkgPath) {
+			continue
+		}
+		if cfg.output != "." {
+			var err error
+			if pkgPath, err = ResolvePath(cfg.output, pkgPath); err != nil {
+				return nil, fmt.Errorf("resolve output path: %w", err)
 			}
-			err := goBuildFileOrPkg(io, pkgPath, cfg)
-			if err != nil {
-				var fileErrlist scanner.ErrorList
-				if !errors.As(err, &fileErrlist) {
-					// Not an scanner.ErrorList: return immediately.
-					return fmt.Errorf("%s: build: %w", pkgPath, err)
-				}
-				errlist = append(errlist, fileErrlist...)
+		}
+		err := goBuildFileOrPkg(pkgPath, opts)
+		if err != nil {
+			var fileErrlist scanner.ErrorList
+			if !errors.As(err, &fileErrlist) {
+				// Not an scanner.ErrorList: return immediately.
+				return nil, fmt.Errorf("%s: build: %w", pkgPath, err)
 			}
+			errlist = append(errlist, fileErrlist...)
 		}
 	}
+	return errlist, nil
+}
 
-	if errlist.Len() > 0 {
-		for _, err := range errlist {
-			io.ErrPrintfln(err.Error())
-		}
-		return fmt.Errorf("%d transpile error(s)", errlist.Len())
+func printTranspileErrors(io commands.IO, errlist scanner.ErrorList) {
+	for _, err := range errlist {
+		io.ErrPrintfln(err.Error())
 	}
-	return nil
 }
 
 // transpilePkg transpiles all non-test files at the given location.
@@ -257,15 +335,9 @@ func transpileFile(srcPath string, opts *transpileOptions) error {
 	}
 
 	// resolve target path
-	var targetPath string
-	if flags.output != "." {
-		path, err := ResolvePath(flags.output, filepath.Dir(srcPath))
-		if err != nil {
-			return fmt.Errorf("resolve output path: %w", err)
-		}
-		targetPath = filepath.Join(path, targetFilename)
-	} else {
-		targetPath = filepath.Join(filepath.Dir(srcPath), targetFilename)
+	targetPath, err := transpiledPath(srcPath, targetFilename, flags)
+	if err != nil {
+		return err
 	}
 
 	// write .go file.
@@ -274,6 +346,33 @@ func transpileFile(srcPath string, opts *transpileOptions) error {
 		return fmt.Errorf("write .go file: %w", err)
 	}
 
+	var sm *transpiler.SourceMap
+	if flags.sourceMaps || flags.gobuild {
+		sm, err = transpileRes.SourceMap()
+		if err != nil {
+			return err
+		}
+	}
+	if flags.gobuild {
+		opts.generated[absPath(targetPath)] = generatedFile{
+			srcPath:    srcPath,
+			lineOffset: lineDirectiveLine(transpileRes.Translated),
+			sourceMap:  sm,
+		}
+	}
+
+	// write .go.map file.
+	if flags.sourceMaps {
+		bz, err := json.Marshal(sm)
+		if err != nil {
+			return fmt.Errorf("marshal source map: %w", err)
+		}
+		err = WriteDirFile(sourceMapPath(targetPath), append(bz, '\n'))
+		if err != nil {
+			return fmt.Errorf("write source map: %w", err)
+		}
+	}
+
 	// transpile imported packages, if `SkipImports` sets to false
 	if !flags.skipImports &&
 		!strings.HasSuffix(srcPath, "_filetest.gno") && !strings.HasSuffix(srcPath, "_test.gno") {
@@ -281,6 +380,7 @@ func transpileFile(srcPath string, opts *transpileOptions) error {
 		if err != nil {
 			return err
 		}
+		opts.addImports(filepath.Dir(srcPath), dirPaths)
 		for _, path := range dirPaths {
 			if err := transpilePkg(path, opts); err != nil {
 				return err
@@ -291,15 +391,87 @@ func transpileFile(srcPath string, opts *transpileOptions) error {
 	return nil
 }
 
-func goBuildFileOrPkg(io commands.IO, fileOrPkg string, cfg *transpileCfg) error {
-	verbose := cfg.verbose
-	goBinary := cfg.goBinary
+// transpiledPath returns the path of the .go file named targetFilename,
+// transpiled from srcPath.
+func transpiledPath(srcPath, targetFilename string, flags *transpileCfg) (string, error) {
+	if flags.output == "." {
+		return filepath.Join(filepath.Dir(srcPath), targetFilename), nil
+	}
+	path, err := ResolvePath(flags.output, filepath.Dir(srcPath))
+	if err != nil {
+		return "", fmt.Errorf("resolve output path: %w", err)
+	}
+	return filepath.Join(path, targetFilename), nil
+}
+
+// sourceMapPath returns the path of the source map of the transpiled file at
+// targetPath.
+func sourceMapPath(targetPath string) string {
+	return targetPath + ".map"
+}
+
+func goBuildFileOrPkg(fileOrPkg string, opts *transpileOptions) error {
+	verbose := opts.cfg.verbose
+	goBinary := opts.cfg.goBinary
 
 	if verbose {
-		io.ErrPrintfln("%s [build]", filepath.Clean(fileOrPkg))
+		opts.io.ErrPrintfln("%s [build]", filepath.Clean(fileOrPkg))
+	}
+
+	err := buildTranspiledPackage(fileOrPkg, goBinary)
+	var errlist scanner.ErrorList
+	if !errors.As(err, &errlist) {
+		return err
 	}
 
-	return buildTranspiledPackage(fileOrPkg, goBinary)
+	// go build reports the paths relative to the directory it runs in.
+	dir := "."
+	if info, statErr := os.Stat(fileOrPkg); statErr == nil && info.IsDir() {
+		dir = fileOrPkg
+	}
+	for _, e := range errlist {
+		e.Pos = opts.gnoPosition(e.Pos, dir)
+	}
+	return errlist
+}
+
+// gnoPosition returns the position in the .gno source of pos, reported by go
+// build running in dir. Thanks to the //line directive, go build reports the
+// name of the .gno file, but the lines of the generated code; those are
+// mapped back using the source map of the generated file. pos is returned
+// as is if it isn't in a file generated by this run.
+func (p *transpileOptions) gnoPosition(pos token.Position, dir string) token.Position {
+	if pos.Filename == "" {
+		return pos
+	}
+	name := pos.Filename
+	if !filepath.IsAbs(name) {
+		name = filepath.Join(dir, name)
+	}
+	targetFilename, _ := transpiler.TranspiledFilenameAndTags(name)
+	gen, ok := p.generated[absPath(filepath.Join(filepath.Dir(name), targetFilename))]
+	if !ok {
+		return pos
+	}
+
+	res := token.Position{Filename: gen.srcPath, Line: pos.Line, Column: pos.Column}
+	if gen.sourceMap != nil {
+		if gnoPos, ok := gen.sourceMap.Lookup(pos.Line+gen.lineOffset, pos.Column); ok {
+			res.Line, res.Column = gnoPos.Line, gnoPos.Column
+		}
+	}
+	return res
+}
+
+// lineDirectiveLine returns the line of the first //line directive in the
+// generated code, or 0 if there is none.
+func lineDirectiveLine(generated string) int {
+	for i, line := range strings.Split(generated, "\n") {
+		if strings.HasPrefix(line, "//line ") {
+			return i + 1
+		}
+	}
+	return 0
 }
 
 // getPathsFromImportSpec returns the directory paths where the code for each
@@ -0,0 +1,261 @@
+package main
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"go/scanner"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+
+	"github.com/gnolang/gno/gnovm/pkg/transpiler"
+)
+
+// transpileWatchDebounce is how long transpileWatch waits for the changes to
+// settle, as editors usually write a file in several operations.
+const transpileWatchDebounce = 100 * time.Millisecond
+
+// transpileWatcher re-transpiles the .gno files changed in the directories of
+// the packages and files given to 'gno tool transpile -watch'.
+type transpileWatcher struct {
+	paths []string // as given to transpilePaths
+	opts  *transpileOptions
+	fsw   *fsnotify.Watcher
+
+	// dirs maps the absolute path of each watched directory to the path
+	// used to transpile it, so that the output paths don't change.
+	dirs map[string]string
+	// pkgs is the set of watched directories whose non-test files are
+	// all transpiled; otherwise, only the files given as arguments are.
+	pkgs map[string]struct{}
+	// files is the set of absolute paths of the files given as arguments.
+	files map[string]struct{}
+}
+
+// transpileWatch watches the transpiled packages and files, including the
+// imported packages, and transpiles their .gno files again as they change.
+// With -gobuild, the changed packages and those depending on them are built
+// again as well. It returns once ctx is done.
+func transpileWatch(ctx context.Context, paths []string, opts *transpileOptions) error {
+	fsw, err := fsnotify.NewWatcher()
+	if err != nil {
+		return fmt.Errorf("unable to watch files: %w", err)
+	}
+	defer fsw.Close()
+
+	w := &transpileWatcher{
+		paths: paths,
+		opts:  opts,
+		fsw:   fsw,
+		dirs:  map[string]string{},
+		pkgs:  map[string]struct{}{},
+		files: map[string]struct{}{},
+	}
+	for _, path := range paths {
+		st, err := os.Stat(path)
+		if err != nil {
+			return err
+		}
+		if !st.IsDir() {
+			w.files[absPath(path)] = struct{}{}
+			if err := w.watch(filepath.Dir(path), false); err != nil {
+				return err
+			}
+		}
+	}
+	if err := w.watchTranspiled(); err != nil {
+		return err
+	}
+	opts.io.ErrPrintfln("watching %d directories for changes", len(w.dirs))
+
+	var (
+		changed  = map[string]struct{}{}
+		debounce <-chan time.Time
+	)
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case err := <-fsw.Errors:
+			return fmt.Errorf("watch error: %w", err)
+		case evt := <-fsw.Events:
+			if evt.Op&(fsnotify.Create|fsnotify.Write|fsnotify.Remove|fsnotify.Rename) == 0 ||
+				!w.isWatchedFile(evt.Name) {
+				continue
+			}
+			changed[evt.Name] = struct{}{}
+			debounce = time.After(transpileWatchDebounce)
+		case <-debounce:
+			files := make([]string, 0, len(changed))
+			for file := range changed {
+				files = append(files, file)
+			}
+			slices.Sort(files)
+			clear(changed)
+			debounce = nil
+
+			if err := w.update(files); err != nil {
+				return err
+			}
+		}
+	}
+}
+
+// watch adds dir to the watched directories; all of its non-test files are
+// transpiled if isPkg.
+func (w *transpileWatcher) watch(dir string, isPkg bool) error {
+	abs := absPath(dir)
+	if isPkg {
+		w.pkgs[abs] = struct{}{}
+	}
+	if _, ok := w.dirs[abs]; ok {
+		return nil
+	}
+	if err := w.fsw.Add(abs); err != nil {
+		return fmt.Errorf("unable to watch %s: %w", dir, err)
+	}
+	w.dirs[abs] = dir
+	return nil
+}
+
+// watchTranspiled watches the packages transpiled so far, which includes
+// those imported by the given packages and files.
+func (w *transpileWatcher) watchTranspiled() error {
+	for dir := range w.opts.transpiled {
+		if slices.Contains(w.opts.skipped, dir) {
+			continue
+		}
+		if err := w.watch(dir, true); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isWatchedFile reports whether the .gno file at the absolute path name is
+// transpiled by the watcher.
+func (w *transpileWatcher) isWatchedFile(name string) bool {
+	if !strings.HasSuffix(name, ".gno") {
+		return false
+	}
+	if _, ok := w.files[name]; ok {
+		return true
+	}
+	if _, ok := w.pkgs[filepath.Dir(name)]; !ok {
+		return false
+	}
+	return !strings.HasSuffix(name, "_test.gno") && !strings.HasSuffix(name, "_filetest.gno")
+}
+
+// update transpiles again the changed files, given by their absolute paths,
+// then builds the packages depending on them if -gobuild is set.
+func (w *transpileWatcher) update(files []string) error {
+	opts := w.opts
+	var (
+		errlist scanner.ErrorList
+		dirs    = map[string]struct{}{}
+	)
+	for _, file := range files {
+		dir := filepath.Dir(file)
+		dirs[dir] = struct{}{}
+		// Use the path of the directory as it was first transpiled.
+		srcPath := filepath.Join(w.dirs[dir], filepath.Base(file))
+
+		if _, err := os.Stat(file); errors.Is(err, os.ErrNotExist) {
+			// Removed or renamed: remove the transpiled file and its
+			// source map as well.
+			targetFilename, _ := transpiler.TranspiledFilenameAndTags(srcPath)
+			targetPath, err := transpiledPath(srcPath, targetFilename, opts.cfg)
+			if err != nil {
+				return err
+			}
+			for _, path := range []string{targetPath, sourceMapPath(targetPath)} {
+				if err := os.Remove(path); err != nil && !errors.Is(err, os.ErrNotExist) {
+					return err
+				}
+			}
+			if opts.cfg.verbose {
+				opts.io.ErrPrintfln("%s (removed)", srcPath)
+			}
+			continue
+		}
+
+		if opts.cfg.verbose {
+			opts.io.ErrPrintln(srcPath)
+		}
+		if err := transpileFile(srcPath, opts); err != nil {
+			var fileErrlist scanner.ErrorList
+			if !errors.As(err, &fileErrlist) {
+				// Not a scanner.ErrorList: report it, and keep watching.
+				opts.io.ErrPrintfln("%s: transpile: %v", srcPath, err)
+				continue
+			}
+			errlist = append(errlist, fileErrlist...)
+		}
+	}
+
+	// The changed files may import new packages.
+	if err := w.watchTranspiled(); err != nil {
+		return err
+	}
+
+	if errlist.Len() == 0 && opts.cfg.gobuild {
+		var paths []string
+		for _, path := range w.paths {
+			dir := absPath(path)
+			if _, ok := w.files[dir]; ok {
+				dir = filepath.Dir(dir)
+			}
+			if w.dependsOn(dir, dirs) {
+				paths = append(paths, path)
+			}
+		}
+		buildErrlist, err := goBuildPaths(paths, opts)
+		if err != nil {
+			opts.io.ErrPrintfln("%v", err)
+		}
+		errlist = append(errlist, buildErrlist...)
+	}
+
+	printTranspileErrors(opts.io, errlist)
+	opts.io.ErrPrintfln("transpiled %d changed file(s), %d error(s)", len(files), errlist.Len())
+	return nil
+}
+
+// dependsOn reports whether the package in the absolute directory dir is one
+// of dirs, or imports one of them, directly or not.
+func (w *transpileWatcher) dependsOn(dir string, dirs map[string]struct{}) bool {
+	seen := map[string]struct{}{}
+	var visit func(dir string) bool
+	visit = func(dir string) bool {
+		if _, ok := dirs[dir]; ok {
+			return true
+		}
+		if _, ok := seen[dir]; ok {
+			return false
+		}
+		seen[dir] = struct{}{}
+		for imp := range w.opts.imports[dir] {
+			if visit(imp) {
+				return true
+			}
+		}
+		return false
+	}
+	return visit(dir)
+}
+
+// absPath returns the absolute and clean form of path, or path itself if it
+// can't be determined.
+func absPath(path string) string {
+	abs, err := filepath.Abs(path)
+	if err != nil {
+		return filepath.Clean(path)
+	}
+	return abs
+}
@@ -0,0 +1,119 @@
+package main
+
+import (
+	"bufio"
+	"context"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+)
+
+func TestTranspileWatch(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	writeFile := func(name, content string) {
+		t.Helper()
+		require.NoError(t, os.WriteFile(filepath.Join(dir, name), []byte(content), 0o644))
+	}
+	// waitFor waits until the transpiled file of name contains substr, or
+	// doesn't exist if substr is empty.
+	waitFor := func(name, substr string) {
+		t.Helper()
+		path := filepath.Join(dir, name+".gen.go")
+		require.Eventually(t, func() bool {
+			bz, err := os.ReadFile(path)
+			if substr == "" {
+				return os.IsNotExist(err)
+			}
+			return err == nil && strings.Contains(string(bz), substr)
+		}, 5*time.Second, 10*time.Millisecond, "waiting for %s", path)
+	}
+	writeFile("foo.gno", "package foo\n\nfunc A() int { return 1 }\n")
+
+	cfg := &transpileCfg{
+		rootDir:     dir,
+		skipImports: true,
+		output:      ".",
+		watch:       true,
+	}
+	// Read stderr to know when the watcher is ready.
+	pr, pw := io.Pipe()
+	lines := make(chan string, 16)
+	go func() {
+		scanner := bufio.NewScanner(pr)
+		for scanner.Scan() {
+			lines <- scanner.Text()
+		}
+		close(lines)
+	}()
+	cio := commands.NewTestIO()
+	cio.SetErr(pw)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	done := make(chan error, 1)
+	go func() {
+		done <- execTranspile(ctx, cfg, []string{dir}, cio)
+		pw.Close()
+	}()
+
+	select {
+	case line := <-lines:
+		require.Equal(t, "watching 1 directories for changes", line)
+	case <-time.After(5 * time.Second):
+		t.Fatal("transpile -watch didn't start watching")
+	}
+	waitFor("foo.gno", "return 1")
+
+	// Changed, new and removed files are handled; test files are not.
+	writeFile("foo.gno", "package foo\n\nfunc A() int { return 2 }\n")
+	waitFor("foo.gno", "return 2")
+	writeFile("bar.gno", "package foo\n\nfunc B() int { return 3 }\n")
+	waitFor("bar.gno", "return 3")
+	require.NoError(t, os.Remove(filepath.Join(dir, "bar.gno")))
+	waitFor("bar.gno", "")
+	writeFile("foo_test.gno", "package foo\n")
+
+	cancel()
+	go func() {
+		for range lines {
+		}
+	}()
+	select {
+	case err := <-done:
+		require.NoError(t, err)
+	case <-time.After(5 * time.Second):
+		t.Fatal("transpile -watch didn't return after cancel")
+	}
+	assert.NoFileExists(t, filepath.Join(dir, "foo_test.gno.gen.go"))
+}
+
+func TestTranspileWatcher_dependsOn(t *testing.T) {
+	t.Parallel()
+
+	opts := newTranspileOptions(&transpileCfg{}, commands.NewTestIO())
+	opts.addImports("/a", []string{"/b"})
+	opts.addImports("/b", []string{"/c", "/a"})
+	w := &transpileWatcher{opts: opts}
+
+	changed := func(dirs ...string) map[string]struct{} {
+		m := map[string]struct{}{}
+		for _, dir := range dirs {
+			m[dir] = struct{}{}
+		}
+		return m
+	}
+	assert.True(t, w.dependsOn("/a", changed("/a")))
+	assert.True(t, w.dependsOn("/a", changed("/c")))
+	assert.True(t, w.dependsOn("/b", changed("/a")))
+	assert.False(t, w.dependsOn("/c", changed("/a", "/b")))
+	assert.False(t, w.dependsOn("/a", changed("/d")))
+}
@@ -13,8 +13,11 @@ import (
 	"log"
 	"os"
 	"path/filepath"
+	"slices"
 	"strings"
 
+	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload"
+	"github.com/gnolang/gno/tm2/pkg/std"
 	"go.uber.org/multierr"
 )
 
@@ -184,6 +187,41 @@ func ResolveDocumentable(dirs, modDirs, args []string, unexported bool) (*Docume
 	return resolveDocumentable(d, parsed, unexported)
 }
 
+// ResolveRemoteDocumentable returns a Documentable from the given arguments,
+// like ResolveDocumentable, but fetching the package from fetcher rather than
+// looking for it on disk. The package must be given by its full import path,
+// such as gno.land/r/demo/boards; this allows documenting packages which are
+// only available on-chain.
+func ResolveRemoteDocumentable(fetcher pkgdownload.PackageFetcher, args []string, unexported bool) (*Documentable, error) {
+	parsed, ok := parseArgs(args)
+	if !ok {
+		return nil, fmt.Errorf("commands/doc: invalid arguments: %v", args)
+	}
+	if parsed.pkgAmbiguous || !strings.Contains(parsed.pkg, "/") {
+		return nil, fmt.Errorf("commands/doc: not a remote package path: %q", parsed.pkg)
+	}
+
+	files, err := fetcher.FetchPackage(parsed.pkg)
+	if err != nil {
+		return nil, fmt.Errorf("commands/doc: fetch package %q: %w", parsed.pkg, err)
+	}
+	pd, err := newPkgDataFromMemPkg(&std.MemPackage{Path: parsed.pkg, Files: files}, unexported)
+	if err != nil {
+		return nil, err
+	}
+	pd.dir = bfsDir{importPath: parsed.pkg, dir: parsed.pkg}
+
+	if parsed.sym != "" && !slices.ContainsFunc(pd.symbols, parsed.symbolMatcher()) {
+		return nil, fmt.Errorf("commands/doc: could not resolve arguments: %+v", parsed)
+	}
+	return &Documentable{
+		bfsDir:     pd.dir,
+		pkgData:    pd,
+		symbol:     parsed.sym,
+		accessible: parsed.acc,
+	}, nil
+}
+
 func resolveDocumentable(dirs *bfsDirs, parsed docArgs, unexported bool) (*Documentable, error) {
 	var candidates []bfsDir
 
@@ -230,18 +268,7 @@ func resolveDocumentable(dirs *bfsDirs, parsed docArgs, unexported bool) (*Docum
 		accessible: parsed.acc,
 	}
 
-	var matchFunc func(s symbolData) bool
-	if parsed.acc == "" {
-		matchFunc = func(s symbolData) bool {
-			return (s.accessible == "" && symbolMatch(parsed.sym, s.symbol)) ||
-				(s.typ == symbolDataMethod && symbolMatch(parsed.sym, s.accessible))
-		}
-	} else {
-		matchFunc = func(s symbolData) bool {
-			return symbolMatch(parsed.sym, s.symbol) && symbolMatch(parsed.acc, s.accessible)
-		}
-	}
-
+	matchFunc := parsed.symbolMatcher()
 	var errs []error
 	for _, candidate := range candidates {
 		pd, err := newPkgData(candidate, unexported)
@@ -279,6 +306,20 @@ type docArgs struct {
 	pkgAmbiguous bool
 }
 
+// symbolMatcher returns a function reporting whether a symbol of a package
+// matches the requested sym and acc.
+func (parsed docArgs) symbolMatcher() func(s symbolData) bool {
+	if parsed.acc == "" {
+		return func(s symbolData) bool {
+			return (s.accessible == "" && symbolMatch(parsed.sym, s.symbol)) ||
+				(s.typ == symbolDataMethod && symbolMatch(parsed.sym, s.accessible))
+		}
+	}
+	return func(s symbolData) bool {
+		return symbolMatch(parsed.sym, s.symbol) && symbolMatch(parsed.acc, s.accessible)
+	}
+}
+
 func parseArgs(args []string) (docArgs, bool) {
 	switch len(args) {
 	case 0:
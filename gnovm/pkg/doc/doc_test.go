@@ -2,11 +2,13 @@ package doc
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"path/filepath"
 	"strings"
 	"testing"
 
+	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
 )
@@ -137,6 +139,56 @@ func stripFset(d *Documentable) *Documentable {
 	return d
 }
 
+type memFetcher map[string][]*std.MemFile
+
+func (f memFetcher) FetchPackage(pkgPath string) ([]*std.MemFile, error) {
+	files, ok := f[pkgPath]
+	if !ok {
+		return nil, fmt.Errorf("package %q is not available", pkgPath)
+	}
+	return files, nil
+}
+
+func TestResolveRemoteDocumentable(t *testing.T) {
+	fetcher := memFetcher{
+		"gno.land/r/demo/hello": {
+			{Name: "gnomod.toml", Body: `module = "gno.land/r/demo/hello"`},
+			{Name: "hello.gno", Body: "// Package hello greets.\npackage hello\n\n// Render greets path.\nfunc Render(path string) string { return \"hello \" + path }\n\ntype Greeter struct{}\n\n// Greet greets.\nfunc (Greeter) Greet() string { return \"hi\" }\n"},
+		},
+	}
+
+	tt := []struct {
+		name        string
+		args        []string
+		contains    []string
+		errContains string
+	}{
+		{"package", []string{"gno.land/r/demo/hello"}, []string{`package hello // import "gno.land/r/demo/hello"`, "Package hello greets.", "func Render"}, ""},
+		{"symbol", []string{"gno.land/r/demo/hello.Render"}, []string{"func Render(path string) string", "Render greets path."}, ""},
+		{"method", []string{"gno.land/r/demo/hello", "Greeter.Greet"}, []string{"func (Greeter) Greet() string"}, ""},
+		{"notFound", []string{"gno.land/r/demo/nope"}, nil, "is not available"},
+		{"symbolNotFound", []string{"gno.land/r/demo/hello.Nope"}, nil, "could not resolve arguments"},
+		{"notRemote", []string{"hello"}, nil, "not a remote package path"},
+	}
+
+	for _, tc := range tt {
+		t.Run(tc.name, func(t *testing.T) {
+			d, err := ResolveRemoteDocumentable(fetcher, tc.args, false)
+			if tc.errContains != "" {
+				assert.ErrorContains(t, err, tc.errContains)
+				return
+			}
+			require.NoError(t, err)
+
+			buf := &bytes.Buffer{}
+			require.NoError(t, d.WriteDocumentation(buf, nil))
+			for _, c := range tc.contains {
+				assert.Contains(t, buf.String(), c)
+			}
+		})
+	}
+}
+
 func TestDocument(t *testing.T) {
 	// the format itself can change if the design is to be changed,
 	// we want to make sure that given information is available when calling
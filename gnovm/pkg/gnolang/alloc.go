@@ -19,6 +19,7 @@ type Allocator struct {
 	// to calculate the corresponding gas usage.
 	// It increases monotonically.
 	peakBytes int64
+	allocs    int64                        // number of allocations
 	collect   func() (left int64, ok bool) // gc callback
 	gasMeter  store.GasMeter
 }
@@ -38,6 +39,7 @@ const (
 	_allocSliceValue       = 40
 	_allocFuncValue        = 312
 	_allocMapValue         = 144
+	_allocChanValue        = 144
 	_allocBoundMethodValue = 176
 	_allocBlock            = 472
 	_allocPackageValue     = 240
@@ -69,6 +71,8 @@ const (
 	allocFunc        = _allocBase + _allocPointer + _allocFuncValue
 	allocMap         = _allocBase + _allocPointer + _allocMapValue
 	allocMapItem     = _allocTypedValue * 3 // XXX
+	allocChan        = _allocBase + _allocPointer + _allocChanValue
+	allocChanItem    = _allocTypedValue
 	allocBoundMethod = _allocBase + _allocPointer + _allocBoundMethodValue
 	allocBlock       = _allocBase + _allocPointer + _allocBlock
 	allocBlockItem   = _allocTypedValue
@@ -112,11 +116,18 @@ func (alloc *Allocator) Status() (maxBytes int64, bytes int64) {
 	return alloc.maxBytes, alloc.bytes
 }
 
+// NumAllocs returns the number of allocations made, including those
+// since collected.
+func (alloc *Allocator) NumAllocs() int64 {
+	return alloc.allocs
+}
+
 func (alloc *Allocator) Reset() *Allocator {
 	if alloc == nil {
 		return nil
 	}
 	alloc.bytes = 0
+	alloc.allocs = 0
 	return alloc
 }
 
@@ -135,6 +146,7 @@ func (alloc *Allocator) Allocate(size int64) {
 		// this can happen for map items just prior to assignment.
 		return
 	}
+	alloc.allocs++
 	if alloc.bytes+size > alloc.maxBytes {
 		if left, ok := alloc.collect(); !ok {
 			panic("should not happen, allocation limit exceeded while gc.")
@@ -204,6 +216,10 @@ func (alloc *Allocator) AllocateMapItem() {
 	alloc.Allocate(allocMapItem)
 }
 
+func (alloc *Allocator) AllocateChan(capacity int64) {
+	alloc.Allocate(allocChan + allocChanItem*capacity)
+}
+
 func (alloc *Allocator) AllocateBoundMethod() {
 	alloc.Allocate(allocBoundMethod)
 }
@@ -358,6 +374,13 @@ func (alloc *Allocator) NewMap(size int) *MapValue {
 	return mv
 }
 
+func (alloc *Allocator) NewChan(capacity int) *ChanValue {
+	alloc.AllocateChan(int64(capacity))
+	return &ChanValue{
+		Cap: capacity,
+	}
+}
+
 // Only used for constructing the main package
 func (alloc *Allocator) NewPackageValue(pn *PackageNode) *PackageValue {
 	alloc.AllocatePackageValue()
@@ -440,6 +463,10 @@ func (mv *MapValue) GetShallowSize() int64 {
 	return allocMap + allocMapItem*int64(mv.GetLength())
 }
 
+func (cv *ChanValue) GetShallowSize() int64 {
+	return allocChan + allocChanItem*int64(cv.Cap)
+}
+
 func (bmv *BoundMethodValue) GetShallowSize() int64 {
 	// skip .uverse
 	if bmv.Func.PkgPath == ".uverse" {
@@ -0,0 +1,286 @@
+package gnolang
+
+import (
+	"go/ast"
+	"go/token"
+	"go/types"
+	"path"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"go.uber.org/multierr"
+)
+
+/*
+	Static analysis of a package, for use by external tools (auditing
+	platforms, IDEs...). AnalyzePackage runs the same type-checking pipeline as
+	TypeCheckMemPackage, and exposes what it learns about the package.
+*/
+
+// SymbolKind is the kind of a package-level declaration.
+type SymbolKind string
+
+const (
+	SymbolConst  SymbolKind = "const"
+	SymbolVar    SymbolKind = "var"
+	SymbolType   SymbolKind = "type"
+	SymbolFunc   SymbolKind = "func"
+	SymbolMethod SymbolKind = "method"
+)
+
+// Symbol is a package-level declaration, or a method of a declared type.
+type Symbol struct {
+	// ID uniquely identifies the symbol, and is used in [CallEdge].
+	// It is "<pkgpath>.<name>" for functions, types, variables and
+	// constants, and "(<pkgpath>.<type>).<name>" or
+	// "(*<pkgpath>.<type>).<name>" for methods.
+	ID       string
+	Name     string
+	Kind     SymbolKind
+	Recv     string // receiver type of methods, e.g. "*T".
+	Type     string // type of the symbol; underlying type of types.
+	Exported bool
+	Location Location // declaration.
+}
+
+// CallEdge is a static call from Caller to Callee, both symbol IDs.
+// Calls made at package initialization have a Caller of "<pkgpath>.init".
+// Calls of function values can't be resolved statically and are not
+// included; calls of interface methods have the interface method as Callee.
+type CallEdge struct {
+	Caller   string
+	Callee   string
+	Location Location // call expression.
+}
+
+// Diagnostic is an error found while analyzing a package.
+type Diagnostic struct {
+	Location Location // zero if unknown.
+	Msg      string
+}
+
+func (d Diagnostic) String() string {
+	if d.Location.File == "" {
+		return d.Msg
+	}
+	return d.Location.String() + ": " + d.Msg
+}
+
+// AnalysisResult is the result of [AnalyzePackage].
+type AnalysisResult struct {
+	PkgPath     string
+	Name        string
+	Symbols     []Symbol     // ordered by name; methods follow their type.
+	Calls       []CallEdge   // ordered by file, then position.
+	Diagnostics []Diagnostic // type-checking errors.
+}
+
+// AnalyzePackage type checks mpkg like [TypeCheckMemPackage], and returns the
+// symbols declared by its production files, the calls between them and to
+// imported packages, and the type-checking errors as diagnostics.
+//
+// An error is returned only if the package could not be type checked at
+// all, for example if one of its files cannot be parsed.
+func AnalyzePackage(mpkg *std.MemPackage, opts TypeCheckOptions) (*AnalysisResult, error) {
+	gimp := newGnoImporter(mpkg, opts)
+	gimp.info = &types.Info{
+		Defs: make(map[*ast.Ident]types.Object),
+		Uses: make(map[*ast.Ident]types.Object),
+	}
+	pkg, errs := gimp.typeCheckMemPackage(mpkg, nil)
+	if pkg == nil {
+		return nil, errs
+	}
+
+	an := &analyzer{
+		pkgPath: mpkg.Path,
+		pkg:     pkg,
+		info:    gimp.info,
+		fset:    gimp.fset,
+		decls:   make(map[token.Pos]ast.Node),
+		res: &AnalysisResult{
+			PkgPath: mpkg.Path,
+			Name:    mpkg.Name,
+		},
+	}
+	for _, gof := range gimp.files {
+		if an.isBuiltins(gof.Pos()) {
+			continue
+		}
+		an.analyzeFile(gof)
+	}
+	an.collectSymbols()
+	for _, err := range multierr.Errors(errs) {
+		an.res.Diagnostics = append(an.res.Diagnostics, an.diagnostic(err))
+	}
+	return an.res, nil
+}
+
+type analyzer struct {
+	pkgPath string
+	pkg     *types.Package
+	info    *types.Info
+	fset    *token.FileSet
+	decls   map[token.Pos]ast.Node // declaring node by name position.
+	res     *AnalysisResult
+}
+
+func (an *analyzer) analyzeFile(gof *ast.File) {
+	initID := an.pkgPath + ".init"
+	for _, decl := range gof.Decls {
+		switch decl := decl.(type) {
+		case *ast.FuncDecl:
+			an.decls[decl.Name.Pos()] = decl
+			caller := initID
+			if fn, ok := an.info.Defs[decl.Name].(*types.Func); ok && decl.Name.Name != "init" {
+				caller = fn.FullName()
+			}
+			if decl.Body != nil {
+				an.analyzeCalls(caller, decl.Body)
+			}
+		case *ast.GenDecl:
+			for _, spec := range decl.Specs {
+				switch spec := spec.(type) {
+				case *ast.TypeSpec:
+					an.decls[spec.Name.Pos()] = spec
+				case *ast.ValueSpec:
+					for _, name := range spec.Names {
+						an.decls[name.Pos()] = spec
+					}
+					for _, value := range spec.Values {
+						an.analyzeCalls(initID, value)
+					}
+				}
+			}
+		}
+	}
+}
+
+func (an *analyzer) analyzeCalls(caller string, node ast.Node) {
+	ast.Inspect(node, func(n ast.Node) bool {
+		call, ok := n.(*ast.CallExpr)
+		if !ok {
+			return true
+		}
+		var id *ast.Ident
+		switch fun := ast.Unparen(call.Fun).(type) {
+		case *ast.Ident:
+			id = fun
+		case *ast.SelectorExpr:
+			id = fun.Sel
+		case *ast.IndexExpr: // explicit instantiation.
+			id, _ = ast.Unparen(fun.X).(*ast.Ident)
+		}
+		if id == nil {
+			return true
+		}
+		// builtins, conversions and function values are skipped.
+		if fn, ok := an.info.Uses[id].(*types.Func); ok {
+			an.res.Calls = append(an.res.Calls, CallEdge{
+				Caller:   caller,
+				Callee:   fn.Origin().FullName(),
+				Location: an.location(call),
+			})
+		}
+		return true
+	})
+}
+
+func (an *analyzer) collectSymbols() {
+	qual := types.RelativeTo(an.pkg)
+	scope := an.pkg.Scope()
+	for _, name := range scope.Names() { // sorted.
+		obj := scope.Lookup(name)
+		if an.isBuiltins(obj.Pos()) {
+			continue
+		}
+		sym := Symbol{
+			ID:       an.pkgPath + "." + name,
+			Name:     name,
+			Type:     types.TypeString(obj.Type(), qual),
+			Exported: obj.Exported(),
+			Location: an.declLocation(obj),
+		}
+		switch obj := obj.(type) {
+		case *types.Const:
+			sym.Kind = SymbolConst
+		case *types.Var:
+			sym.Kind = SymbolVar
+		case *types.Func:
+			sym.Kind = SymbolFunc
+		case *types.TypeName:
+			sym.Kind = SymbolType
+			sym.Type = types.TypeString(obj.Type().Underlying(), qual)
+		default:
+			continue
+		}
+		an.res.Symbols = append(an.res.Symbols, sym)
+
+		tn, ok := obj.(*types.TypeName)
+		if !ok || tn.IsAlias() {
+			continue
+		}
+		named, ok := tn.Type().(*types.Named)
+		if !ok {
+			continue
+		}
+		methods := named.Method
+		numMethods := named.NumMethods()
+		if it, ok := named.Underlying().(*types.Interface); ok {
+			methods = it.ExplicitMethod
+			numMethods = it.NumExplicitMethods()
+		}
+		for i := range numMethods {
+			m := methods(i)
+			recv := types.TypeString(m.Type().(*types.Signature).Recv().Type(), qual)
+			an.res.Symbols = append(an.res.Symbols, Symbol{
+				ID:       m.FullName(),
+				Name:     m.Name(),
+				Kind:     SymbolMethod,
+				Recv:     recv,
+				Type:     types.TypeString(m.Type(), qual),
+				Exported: m.Exported(),
+				Location: an.declLocation(m),
+			})
+		}
+	}
+}
+
+func (an *analyzer) diagnostic(err error) Diagnostic {
+	if terr, ok := err.(types.Error); ok {
+		return Diagnostic{
+			Location: an.positionLocation(terr.Fset.Position(terr.Pos), Pos{}),
+			Msg:      terr.Msg,
+		}
+	}
+	return Diagnostic{Msg: err.Error()}
+}
+
+// isBuiltins returns true if pos is in the injected .gnobuiltins.gno file.
+func (an *analyzer) isBuiltins(pos token.Pos) bool {
+	return strings.HasSuffix(an.fset.Position(pos).Filename, ".gnobuiltins.gno")
+}
+
+func (an *analyzer) declLocation(obj types.Object) Location {
+	if decl, ok := an.decls[obj.Pos()]; ok {
+		return an.location(decl)
+	}
+	return an.positionLocation(an.fset.Position(obj.Pos()), Pos{})
+}
+
+func (an *analyzer) location(n ast.Node) Location {
+	end := an.fset.Position(n.End())
+	return an.positionLocation(an.fset.Position(n.Pos()), Pos{end.Line, end.Column})
+}
+
+func (an *analyzer) positionLocation(pos token.Position, end Pos) Location {
+	start := Pos{pos.Line, pos.Column}
+	if end == (Pos{}) {
+		end = start
+	}
+	return Location{
+		PkgPath: an.pkgPath,
+		File:    path.Base(pos.Filename),
+		Span:    Span{Pos: start, End: end},
+	}
+}
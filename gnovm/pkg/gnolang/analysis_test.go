@@ -0,0 +1,125 @@
+package gnolang
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestAnalyzePackage(t *testing.T) {
+	t.Parallel()
+
+	dep := &std.MemPackage{
+		Type: MPUserProd,
+		Name: "dep",
+		Path: "gno.land/p/demo/dep",
+		Files: []*std.MemFile{
+			{Name: "dep.gno", Body: "package dep\n\nfunc Hello() string { return \"hello\" }\n"},
+		},
+	}
+	mpkg := &std.MemPackage{
+		Type: MPUserProd,
+		Name: "hello",
+		Path: "gno.land/p/demo/hello",
+		Files: []*std.MemFile{
+			{
+				Name: "hello.gno",
+				Body: `package hello
+
+import "gno.land/p/demo/dep"
+
+const Greeting = "hi"
+
+var counter = newCounter()
+
+type Greeter interface{ Greet() string }
+
+type counter_ struct{ n int }
+
+func (c *counter_) Greet() string { return dep.Hello() }
+
+func newCounter() *counter_ { return &counter_{} }
+
+func Greet(g Greeter) string {
+	f := func() string { return g.Greet() }
+	return f() + Greeting
+}
+`,
+			},
+		},
+	}
+	getter := mockPackageGetter{dep}
+	res, err := AnalyzePackage(mpkg, TypeCheckOptions{
+		Getter:     getter,
+		TestGetter: getter,
+		Mode:       TCLatestRelaxed,
+	})
+	require.NoError(t, err)
+	assert.Empty(t, res.Diagnostics)
+	assert.Equal(t, "hello", res.Name)
+
+	type sym struct {
+		ID   string
+		Kind SymbolKind
+		Type string
+	}
+	var syms []sym
+	for _, s := range res.Symbols {
+		syms = append(syms, sym{s.ID, s.Kind, s.Type})
+	}
+	assert.Equal(t, []sym{
+		{"gno.land/p/demo/hello.Greet", SymbolFunc, "func(g Greeter) string"},
+		{"gno.land/p/demo/hello.Greeter", SymbolType, "interface{Greet() string}"},
+		{"(gno.land/p/demo/hello.Greeter).Greet", SymbolMethod, "func() string"},
+		{"gno.land/p/demo/hello.Greeting", SymbolConst, "untyped string"},
+		{"gno.land/p/demo/hello.counter", SymbolVar, "*counter_"},
+		{"gno.land/p/demo/hello.counter_", SymbolType, "struct{n int}"},
+		{"(*gno.land/p/demo/hello.counter_).Greet", SymbolMethod, "func() string"},
+		{"gno.land/p/demo/hello.newCounter", SymbolFunc, "func() *counter_"},
+	}, syms)
+	assert.Equal(t, "*counter_", res.Symbols[6].Recv)
+	assert.Equal(t, "gno.land/p/demo/hello/hello.gno:13:1-57", res.Symbols[6].Location.String())
+
+	type edge struct{ Caller, Callee string }
+	var edges []edge
+	for _, c := range res.Calls {
+		edges = append(edges, edge{c.Caller, c.Callee})
+	}
+	assert.Equal(t, []edge{
+		{"gno.land/p/demo/hello.init", "gno.land/p/demo/hello.newCounter"},
+		{"(*gno.land/p/demo/hello.counter_).Greet", "gno.land/p/demo/dep.Hello"},
+		{"gno.land/p/demo/hello.Greet", "(gno.land/p/demo/hello.Greeter).Greet"},
+	}, edges)
+}
+
+func TestAnalyzePackageDiagnostics(t *testing.T) {
+	t.Parallel()
+
+	mpkg := &std.MemPackage{
+		Type: MPUserProd,
+		Name: "hello",
+		Path: "gno.land/p/demo/hello",
+		Files: []*std.MemFile{
+			{Name: "hello.gno", Body: "package hello\n\nfunc A() int { return \"a\" }\n"},
+		},
+	}
+	res, err := AnalyzePackage(mpkg, TypeCheckOptions{
+		Getter:     mockPackageGetter{},
+		TestGetter: mockPackageGetter{},
+		Mode:       TCLatestRelaxed,
+	})
+	require.NoError(t, err)
+	require.Len(t, res.Diagnostics, 1)
+	assert.Contains(t, res.Diagnostics[0].String(), "gno.land/p/demo/hello/hello.gno:3:23")
+	assert.Len(t, res.Symbols, 1)
+
+	mpkg.Files[0].Body = "package hello!\n"
+	_, err = AnalyzePackage(mpkg, TypeCheckOptions{
+		Getter:     mockPackageGetter{},
+		TestGetter: mockPackageGetter{},
+		Mode:       TCLatestRelaxed,
+	})
+	assert.ErrorContains(t, err, "found '!'")
+}
@@ -0,0 +1,131 @@
+package gnolang
+
+// ----------------------------------------
+// ChanValue
+
+// ChanValue is the value of a channel. Channels only live during the
+// execution of a Machine, and can't be persisted in a realm.
+//
+// The goroutines blocked on a channel wait in its queues, in order, until
+// the operation of another goroutine completes theirs: a send hands its value
+// to the first receiver waiting, or to the buffer, and a receive takes the
+// first value of the buffer, or of the first sender waiting.
+type ChanValue struct {
+	Buffer []TypedValue // values sent and not yet received
+	Cap    int          // capacity of the buffer
+	Closed bool
+
+	recvq []chanWaiter // receivers waiting for a value
+	sendq []chanWaiter // senders waiting for a receiver, or for room in the buffer
+
+	lastGCCycle int64
+}
+
+// chanWait is the wait of a goroutine blocked on channel operations: a send,
+// a receive, or the cases of a select. It is done once one of them completes.
+type chanWait struct {
+	g     *goroutine
+	done  bool
+	index int        // index of the completed operation, for selects
+	value TypedValue // value received
+	ok    bool       // false if the channel was closed
+}
+
+// chanWaiter is a goroutine waiting in the queue of a channel.
+type chanWaiter struct {
+	wait  *chanWait
+	index int        // index of the operation, for selects
+	value TypedValue // value to send, for senders
+}
+
+func (w *chanWait) complete(index int, value TypedValue, ok bool) {
+	w.done = true
+	w.index = index
+	w.value = value
+	w.ok = ok
+}
+
+func (cv *ChanValue) GetLength() int {
+	return len(cv.Buffer)
+}
+
+func (cv *ChanValue) GetCapacity() int {
+	return cv.Cap
+}
+
+// trySend sends v without blocking, and returns whether it was sent. It must
+// not be called on a closed channel.
+func (cv *ChanValue) trySend(v TypedValue) bool {
+	if w, ok := popChanWaiter(&cv.recvq); ok {
+		w.wait.complete(w.index, v, true)
+		return true
+	}
+	if len(cv.Buffer) < cv.Cap {
+		cv.Buffer = append(cv.Buffer, v)
+		return true
+	}
+	return false
+}
+
+// tryRecv receives a value without blocking, and returns whether it was
+// received. The value is undefined and ok is false if the channel is closed.
+func (cv *ChanValue) tryRecv() (v TypedValue, ok, received bool) {
+	if w, found := popChanWaiter(&cv.sendq); found {
+		if cv.Cap == 0 {
+			v = w.value
+		} else {
+			// The buffer is full: take its first value, and make room
+			// for the one of the sender.
+			v = cv.Buffer[0]
+			copy(cv.Buffer, cv.Buffer[1:])
+			cv.Buffer[len(cv.Buffer)-1] = w.value
+		}
+		w.wait.complete(w.index, TypedValue{}, true)
+		return v, true, true
+	}
+	if len(cv.Buffer) > 0 {
+		v = cv.Buffer[0]
+		cv.Buffer[0] = TypedValue{}
+		cv.Buffer = cv.Buffer[1:]
+		return v, true, true
+	}
+	if cv.Closed {
+		return TypedValue{}, false, true
+	}
+	return TypedValue{}, false, false
+}
+
+// close closes the channel, completing the operations of the goroutines
+// waiting on it: the receivers receive a zero value, and the senders panic.
+func (cv *ChanValue) close() {
+	cv.Closed = true
+	for {
+		w, ok := popChanWaiter(&cv.recvq)
+		if !ok {
+			break
+		}
+		w.wait.complete(w.index, TypedValue{}, false)
+	}
+	for {
+		w, ok := popChanWaiter(&cv.sendq)
+		if !ok {
+			break
+		}
+		w.wait.complete(w.index, TypedValue{}, false)
+	}
+}
+
+// popChanWaiter pops the first waiter of q whose wait isn't done yet. The
+// waits of a select are done once one of its cases completes, but its other
+// cases are left in the queues of their channels until then.
+func popChanWaiter(q *[]chanWaiter) (chanWaiter, bool) {
+	for len(*q) > 0 {
+		w := (*q)[0]
+		(*q)[0] = chanWaiter{}
+		*q = (*q)[1:]
+		if !w.wait.done {
+			return w, true
+		}
+	}
+	return chanWaiter{}, false
+}
@@ -0,0 +1,53 @@
+package gnolang
+
+// Coverage counts the statements executed by the machines it is set on, see
+// [MachineOptions.Coverage]. It is used to report which statements of a
+// package its tests run.
+//
+// A Coverage may be shared by successive machines, but not by machines
+// running concurrently.
+type Coverage struct {
+	stmts map[Stmt]*coveredStmt
+}
+
+type coveredStmt struct {
+	loc   Location // zero if the statement isn't in a file.
+	count int
+}
+
+// NewCoverage returns an empty Coverage.
+func NewCoverage() *Coverage {
+	return &Coverage{stmts: make(map[Stmt]*coveredStmt)}
+}
+
+// hit records the execution of s, which is in the last block of m.
+func (c *Coverage) hit(m *Machine, s Stmt) {
+	cs := c.stmts[s]
+	if cs == nil {
+		cs = &coveredStmt{}
+		if span := s.GetSpan(); !span.IsZero() {
+			loc := m.LastBlock().GetSource(m.Store).GetLocation()
+			if loc.File != "" {
+				loc.Span = span
+				cs.loc = loc
+			}
+		}
+		c.stmts[s] = cs
+	}
+	cs.count++
+}
+
+// Counts returns the number of times the statements of the given file of the
+// package at pkgPath were executed, by the position they start at. Statements
+// which were never executed are not included.
+func (c *Coverage) Counts(pkgPath, file string) map[Pos]int {
+	counts := make(map[Pos]int)
+	for _, cs := range c.stmts {
+		if cs.loc.PkgPath == pkgPath && cs.loc.File == file {
+			// The same statement may have several nodes, if its package
+			// was loaded more than once.
+			counts[cs.loc.Pos] += cs.count
+		}
+	}
+	return counts
+}
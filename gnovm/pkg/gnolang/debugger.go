@@ -59,6 +59,7 @@ type Debugger struct {
 	nextDepth   int                         // function call depth at the 'next' command
 	getSrc      func(string, string) string // helper to access source from repl or others
 	rootDir     string
+	dap         *DAPSession // when set, debugger IO uses the Debug Adapter Protocol
 }
 
 // Enable makes the debugger d active, using in as input reader, out as output writer and f as a source helper.
@@ -135,15 +136,27 @@ func init() {
 
 // Debug is the debug callback invoked at each VM execution step. It implements the DebugState FSA.
 func (m *Machine) Debug() {
+	if m.Debugger.state == DebugAtRun {
+		// Locate the machine instruction about to be executed.
+		m.Debugger.prevLoc = m.Debugger.loc
+		debugUpdateLocation(m)
+	}
 loop:
 	for {
 		switch m.Debugger.state {
 		case DebugAtInit:
 			debugUpdateLocation(m)
+			m.Debugger.state = DebugAtCmd
+			if m.Debugger.dap != nil {
+				continue loop
+			}
 			fmt.Fprintln(m.Debugger.out, "Welcome to the Gnovm debugger. Type 'help' for list of commands.")
 			m.Debugger.scanner = bufio.NewScanner(m.Debugger.in)
-			m.Debugger.state = DebugAtCmd
 		case DebugAtCmd:
+			if m.Debugger.dap != nil {
+				dapCmd(m)
+				continue loop
+			}
 			if err := debugCmd(m); err != nil {
 				fmt.Fprintln(m.Debugger.out, "Command failed:", err)
 			}
@@ -154,12 +167,16 @@ loop:
 			switch m.Debugger.lastCmd {
 			case "si", "stepi":
 				m.Debugger.state = DebugAtCmd
-				debugLineInfo(m)
+				if m.Debugger.dap != nil {
+					m.Debugger.dap.stopped("step")
+				} else {
+					debugLineInfo(m)
+				}
 			case "s", "step":
-				if m.Debugger.loc != m.Debugger.prevLoc && m.Debugger.loc.File != "" {
+				if m.Debugger.loc != m.Debugger.prevLoc && m.Debugger.loc.File != "" && dapResumed(m) {
 					m.Debugger.state = DebugAtCmd
 					m.Debugger.prevLoc = m.Debugger.loc
-					debugList(m, "")
+					debugStop(m, "step")
 					continue loop
 				}
 			case "n", "next":
@@ -167,32 +184,32 @@ loop:
 					(m.Debugger.nextDepth == 0 || !sameLine(m.Debugger.loc, m.Debugger.nextLoc) && callDepth(m) <= m.Debugger.nextDepth) {
 					m.Debugger.state = DebugAtCmd
 					m.Debugger.prevLoc = m.Debugger.loc
-					debugList(m, "")
+					debugStop(m, "step")
 					continue loop
 				}
 			case "stepout", "so":
 				if callDepth(m) < m.Debugger.nextDepth {
 					m.Debugger.state = DebugAtCmd
 					m.Debugger.prevLoc = m.Debugger.loc
-					debugList(m, "")
+					debugStop(m, "step")
 					continue loop
 				}
 			default:
-				if atBreak(m) {
+				if dapResumed(m) && atBreak(m) {
 					m.Debugger.state = DebugAtCmd
 					m.Debugger.prevLoc = m.Debugger.loc
-					debugList(m, "")
+					debugStop(m, "breakpoint")
 					continue loop
 				}
 			}
 			break loop
 		case DebugAtExit:
+			if m.Debugger.dap != nil {
+				m.Debugger.dap.Exited(0)
+			}
 			os.Exit(0)
 		}
 	}
-	m.Debugger.prevLoc = m.Debugger.loc
-	debugUpdateLocation(m)
-
 	// Keep track of exact locations when performing calls.
 	op := m.Ops[len(m.Ops)-1]
 	switch op {
@@ -203,6 +220,16 @@ loop:
 	}
 }
 
+// debugStop reports that the program stopped at the current location, for
+// the given reason: "step" or "breakpoint".
+func debugStop(m *Machine, reason string) {
+	if m.Debugger.dap != nil {
+		m.Debugger.dap.stopped(reason)
+		return
+	}
+	debugList(m, "")
+}
+
 // callDepth returns the function call depth.
 func callDepth(m *Machine) int {
 	n := 0
@@ -304,9 +331,18 @@ func debugUpdateLocation(m *Machine) {
 
 	// The location computed from above points to the block start. Examine
 	// expressions and statements to have the exact line within the block.
+	// Only those of the current function call frame are considered, as the
+	// ones below belong to the callers.
+	var numExprs, numStmts int
+	for i := len(m.Frames) - 1; i >= 0; i-- {
+		if m.Frames[i].Func != nil {
+			numExprs, numStmts = m.Frames[i].NumExprs, m.Frames[i].NumStmts
+			break
+		}
+	}
 
 	nx := len(m.Exprs)
-	for i := nx - 1; i >= 0; i-- {
+	for i := nx - 1; i >= numExprs; i-- {
 		expr := m.Exprs[i]
 		if l := expr.GetLine(); l > 0 {
 			if col := expr.GetColumn(); col > 0 {
@@ -317,7 +353,7 @@ func debugUpdateLocation(m *Machine) {
 		}
 	}
 
-	if len(m.Stmts) > 0 {
+	if len(m.Stmts) > numStmts {
 		if stmt := m.PeekStmt1(); stmt != nil {
 			if l := stmt.GetLine(); l > 0 {
 				if col := stmt.GetColumn(); col > 0 {
@@ -739,6 +775,37 @@ func debugEvalExpr(m *Machine, node ast.Node) (tv TypedValue, err error) {
 // the current function call frame, or the global frame if not found.
 // Note: the commands 'up' and 'down' change the frame level to start from.
 func debugLookup(m *Machine, name string) (tv TypedValue, ok bool) {
+	sblocks := debugFrameBlocks(m)
+	if len(sblocks) == 0 {
+		return tv, false
+	}
+
+	// Search value in current frame level blocks, or main scope.
+	for _, b := range sblocks {
+		switch t := b.Source.(type) {
+		case *IfStmt:
+			for i, s := range ifBody(m, t).Source.GetBlockNames() {
+				if string(s) == name {
+					return b.Values[i], true
+				}
+			}
+		}
+		for i, s := range b.Source.GetBlockNames() {
+			if string(s) == name {
+				return b.Values[i], true
+			}
+		}
+	}
+	// Fallback: search a global value.
+	if v := sblocks[0].Source.GetSlot(m.Store, Name(name), true); v != nil {
+		return *v, true
+	}
+	return tv, false
+}
+
+// debugFrameBlocks returns the blocks of the function call frame at the
+// current frame level, innermost first, followed by the global block.
+func debugFrameBlocks(m *Machine) []*Block {
 	// Position to the right frame.
 	ncall := 0
 	var i int
@@ -757,7 +824,7 @@ func debugLookup(m *Machine, name string) (tv TypedValue, ok bool) {
 		}
 	}
 	if i < 0 {
-		return tv, false
+		return nil
 	}
 
 	// XXX The following logic isn't necessary and it isn't correct either.
@@ -791,7 +858,7 @@ func debugLookup(m *Machine, name string) (tv TypedValue, ok bool) {
 		}
 	}
 	if i < 0 {
-		return tv, false
+		return nil
 	}
 
 	// get SourceBlocks in the same frame level.
@@ -805,28 +872,7 @@ func debugLookup(m *Machine, name string) (tv TypedValue, ok bool) {
 	if i > 0 {
 		sblocks = append(sblocks, m.Blocks[0]) // Add global block
 	}
-
-	// Search value in current frame level blocks, or main scope.
-	for _, b := range sblocks {
-		switch t := b.Source.(type) {
-		case *IfStmt:
-			for i, s := range ifBody(m, t).Source.GetBlockNames() {
-				if string(s) == name {
-					return b.Values[i], true
-				}
-			}
-		}
-		for i, s := range b.Source.GetBlockNames() {
-			if string(s) == name {
-				return b.Values[i], true
-			}
-		}
-	}
-	// Fallback: search a global value.
-	if v := sblocks[0].Source.GetSlot(m.Store, Name(name), true); v != nil {
-		return *v, true
-	}
-	return tv, false
+	return sblocks
 }
 
 // ifBody returns the Then or Else body corresponding to the current location.
@@ -0,0 +1,699 @@
+package gnolang
+
+import (
+	"bufio"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/parser"
+	"io"
+	"os"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+)
+
+// DAPSession is a session of the debugger with a client speaking the Debug
+// Adapter Protocol (https://microsoft.github.io/debug-adapter-protocol/), such
+// as an editor. The debugged program runs in a single thread, with id 1.
+//
+// Requests are only read while the program is stopped: the program can not
+// be paused while it runs, breakpoints must be set beforehand.
+type DAPSession struct {
+	r  *bufio.Reader
+	w  io.Writer
+	mu sync.Mutex // protects w and seq: program output is written as events
+
+	seq         int
+	stopOnEntry bool
+	left        bool        // whether the program left the line it was resumed from
+	vars        []dapVarRef // variable references, valid while stopped
+	sources     []Location  // sources served by reference, by the source request
+}
+
+// dapVarRef is the target of a variable reference, either the scope of a
+// frame or a value with children.
+type dapVarRef struct {
+	frame   int
+	globals bool
+	tv      *TypedValue
+}
+
+// maxDAPChildren bounds the number of elements of arrays, slices and maps
+// returned at once.
+const maxDAPChildren = 1000
+
+// NewDAPSession returns a session reading the requests of the client from r,
+// and writing responses and events to w.
+func NewDAPSession(r io.Reader, w io.Writer) *DAPSession {
+	return &DAPSession{r: bufio.NewReader(r), w: w}
+}
+
+// EnableDAP makes the debugger d active, using the Debug Adapter Protocol
+// session s for debugger IO. The program starts to run once the client sends
+// the configurationDone request.
+func (d *Debugger) EnableDAP(s *DAPSession) {
+	d.dap = s
+	d.enabled = true
+	d.state = DebugAtInit
+	d.rootDir = gnoenv.RootDir()
+}
+
+// Output returns a writer sending what is written to the client as output
+// events of the given category, "stdout" or "stderr". It is used as the
+// output of the debugged program, so that it doesn't mix with the protocol.
+func (s *DAPSession) Output(category string) io.Writer {
+	return dapOutput{s: s, category: category}
+}
+
+type dapOutput struct {
+	s        *DAPSession
+	category string
+}
+
+func (o dapOutput) Write(p []byte) (int, error) {
+	err := o.s.event("output", map[string]any{"category": o.category, "output": string(p)})
+	if err != nil {
+		return 0, err
+	}
+	return len(p), nil
+}
+
+// Exited notifies the client that the program exited with the given code,
+// which ends the session.
+func (s *DAPSession) Exited(code int) {
+	s.event("exited", map[string]any{"exitCode": code})
+	s.event("terminated", nil)
+}
+
+// ----------------------------------------
+// Wire format.
+
+type dapRequest struct {
+	Seq       int             `json:"seq"`
+	Type      string          `json:"type"`
+	Command   string          `json:"command"`
+	Arguments json.RawMessage `json:"arguments,omitempty"`
+}
+
+type dapResponse struct {
+	Seq        int    `json:"seq"`
+	Type       string `json:"type"`
+	RequestSeq int    `json:"request_seq"`
+	Success    bool   `json:"success"`
+	Command    string `json:"command"`
+	Message    string `json:"message,omitempty"`
+	Body       any    `json:"body,omitempty"`
+}
+
+type dapEvent struct {
+	Seq   int    `json:"seq"`
+	Type  string `json:"type"`
+	Event string `json:"event"`
+	Body  any    `json:"body,omitempty"`
+}
+
+type dapSource struct {
+	Name            string `json:"name,omitempty"`
+	Path            string `json:"path,omitempty"`
+	SourceReference int    `json:"sourceReference,omitempty"`
+}
+
+type dapVariable struct {
+	Name               string `json:"name"`
+	Value              string `json:"value"`
+	Type               string `json:"type,omitempty"`
+	VariablesReference int    `json:"variablesReference"`
+	IndexedVariables   int    `json:"indexedVariables,omitempty"`
+}
+
+// read reads the next request of the client.
+func (s *DAPSession) read() (req dapRequest, err error) {
+	length := -1
+	for {
+		line, err := s.r.ReadString('\n')
+		if err != nil {
+			return req, err
+		}
+		line = strings.TrimSpace(line)
+		if line == "" {
+			break // end of the header
+		}
+		if v, ok := strings.CutPrefix(line, "Content-Length:"); ok {
+			if length, err = strconv.Atoi(strings.TrimSpace(v)); err != nil {
+				return req, fmt.Errorf("invalid DAP header %q: %w", line, err)
+			}
+		}
+	}
+	if length < 0 {
+		return req, errors.New("missing Content-Length DAP header")
+	}
+	bz := make([]byte, length)
+	if _, err := io.ReadFull(s.r, bz); err != nil {
+		return req, err
+	}
+	return req, json.Unmarshal(bz, &req)
+}
+
+// send writes a response or an event to the client, setting its sequence
+// number.
+func (s *DAPSession) send(setSeq func(int) any) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.seq++
+	bz, err := json.Marshal(setSeq(s.seq))
+	if err != nil {
+		return err
+	}
+	_, err = fmt.Fprintf(s.w, "Content-Length: %d\r\n\r\n%s", len(bz), bz)
+	return err
+}
+
+func (s *DAPSession) respond(req dapRequest, body any, err error) error {
+	return s.send(func(seq int) any {
+		res := dapResponse{Seq: seq, Type: "response", RequestSeq: req.Seq, Command: req.Command, Success: err == nil, Body: body}
+		if err != nil {
+			res.Message = err.Error()
+		}
+		return res
+	})
+}
+
+func (s *DAPSession) event(name string, body any) error {
+	return s.send(func(seq int) any {
+		return dapEvent{Seq: seq, Type: "event", Event: name, Body: body}
+	})
+}
+
+func (s *DAPSession) stopped(reason string) {
+	s.event("stopped", map[string]any{"reason": reason, "threadId": 1, "allThreadsStopped": true})
+}
+
+// ----------------------------------------
+// Requests.
+
+// dapCmd reads and processes a request of the client, while the program is
+// stopped. If the client closes the session, the program resumes.
+func dapCmd(m *Machine) {
+	s := m.Debugger.dap
+	req, err := s.read()
+	if err != nil {
+		debugDetach(m, "")
+		return
+	}
+	body, err := dapHandle(m, req)
+	s.respond(req, body, err)
+
+	switch req.Command {
+	case "initialize":
+		s.event("initialized", nil)
+	case "configurationDone":
+		if s.stopOnEntry {
+			s.stopped("entry")
+		}
+	}
+}
+
+func dapHandle(m *Machine, req dapRequest) (body any, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+	s := m.Debugger.dap
+
+	switch req.Command {
+	case "initialize":
+		return map[string]any{
+			"supportsConfigurationDoneRequest": true,
+			"supportsEvaluateForHovers":        true,
+			"supportTerminateDebuggee":         true,
+			"supportsTerminateRequest":         true,
+		}, nil
+	case "launch", "attach":
+		// The program was launched by the debugger itself.
+		var args struct {
+			StopOnEntry bool `json:"stopOnEntry"`
+		}
+		if len(req.Arguments) > 0 {
+			if err := json.Unmarshal(req.Arguments, &args); err != nil {
+				return nil, err
+			}
+		}
+		s.stopOnEntry = args.StopOnEntry
+		return nil, nil
+	case "setBreakpoints":
+		return dapSetBreakpoints(m, req.Arguments)
+	case "setExceptionBreakpoints":
+		return map[string]any{"breakpoints": []any{}}, nil
+	case "configurationDone":
+		if !s.stopOnEntry {
+			dapResume(m, "continue")
+		}
+		return nil, nil
+	case "threads":
+		return map[string]any{"threads": []any{map[string]any{"id": 1, "name": "main"}}}, nil
+	case "stackTrace":
+		return dapStackTrace(m), nil
+	case "scopes":
+		var args struct {
+			FrameID int `json:"frameId"`
+		}
+		if err := json.Unmarshal(req.Arguments, &args); err != nil {
+			return nil, err
+		}
+		return map[string]any{"scopes": []any{
+			map[string]any{"name": "Locals", "variablesReference": s.varRef(dapVarRef{frame: args.FrameID}), "presentationHint": "locals"},
+			map[string]any{"name": "Globals", "variablesReference": s.varRef(dapVarRef{frame: args.FrameID, globals: true})},
+		}}, nil
+	case "variables":
+		return dapVariables(m, req.Arguments)
+	case "evaluate":
+		return dapEvaluate(m, req.Arguments)
+	case "source":
+		return dapSourceContent(m, req.Arguments)
+	case "continue":
+		dapResume(m, "continue")
+		return map[string]any{"allThreadsContinued": true}, nil
+	case "next":
+		dapResume(m, "next")
+		return nil, nil
+	case "stepIn":
+		dapResume(m, "step")
+		return nil, nil
+	case "stepOut":
+		dapResume(m, "stepout")
+		return nil, nil
+	case "disconnect":
+		var args struct {
+			TerminateDebuggee bool `json:"terminateDebuggee"`
+		}
+		if len(req.Arguments) > 0 {
+			if err := json.Unmarshal(req.Arguments, &args); err != nil {
+				return nil, err
+			}
+		}
+		if args.TerminateDebuggee {
+			m.Debugger.state = DebugAtExit
+		} else {
+			// Resume the program without the debugger.
+			m.Debugger.enabled = false
+			m.Debugger.state = DebugAtRun
+		}
+		return nil, nil
+	case "terminate":
+		m.Debugger.state = DebugAtExit
+		return nil, nil
+	default:
+		return nil, fmt.Errorf("unsupported request: %s", req.Command)
+	}
+}
+
+// dapResume resumes the program with the debugger command cmd, invalidating
+// the references of the current stop.
+func dapResume(m *Machine, cmd string) {
+	m.Debugger.dap.vars = nil
+	m.Debugger.dap.left = false
+	m.Debugger.lastCmd = cmd
+	debugContinue(m, "")
+}
+
+// dapResumed returns whether the program left the line it was resumed from,
+// or true if the debugger doesn't use DAP. DAP clients expect the program to
+// stop once per line, while the VM executes several steps per line.
+func dapResumed(m *Machine) bool {
+	s := m.Debugger.dap
+	if s == nil {
+		return true
+	}
+	depth := callDepth(m)
+	// Native calls made from the line have no location of their own.
+	onLine := sameLine(m.Debugger.loc, m.Debugger.nextLoc) && depth >= m.Debugger.nextDepth
+	// Calls made from the line don't leave it: the program stops in their
+	// body, and not again when they return.
+	if !onLine && depth <= m.Debugger.nextDepth {
+		s.left = true
+	}
+	return s.left || !onLine
+}
+
+func dapSetBreakpoints(m *Machine, arguments json.RawMessage) (any, error) {
+	var args struct {
+		Source      dapSource `json:"source"`
+		Breakpoints []struct {
+			Line int `json:"line"`
+		} `json:"breakpoints"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, err
+	}
+	file := dapBreakpointLocation(m, args.Source.Path)
+
+	// The breakpoints of the source replace the previous ones.
+	breakpoints := m.Debugger.breakpoints[:0]
+	for _, b := range m.Debugger.breakpoints {
+		if b.File != file.File {
+			breakpoints = append(breakpoints, b)
+		}
+	}
+	res := make([]any, 0, len(args.Breakpoints))
+	for _, b := range args.Breakpoints {
+		loc := file
+		loc.Line = b.Line
+		breakpoints = append(breakpoints, loc)
+		res = append(res, map[string]any{"verified": true, "line": b.Line, "source": args.Source})
+	}
+	m.Debugger.breakpoints = breakpoints
+	return map[string]any{"breakpoints": res}, nil
+}
+
+// dapBreakpointLocation returns the location of the source file at path, as
+// named by the VM: files of the standard libraries and of the examples are
+// named relative to their package.
+func dapBreakpointLocation(m *Machine, path string) Location {
+	path = filepath.Clean(path)
+	if m.Debugger.rootDir != "" {
+		for _, dir := range []string{"gnovm/stdlibs", "examples"} {
+			prefix := filepath.Join(m.Debugger.rootDir, dir) + string(filepath.Separator)
+			if rel, ok := strings.CutPrefix(path, prefix); ok {
+				rel = filepath.ToSlash(rel)
+				return Location{PkgPath: filepath.ToSlash(filepath.Dir(rel)), File: filepath.Base(rel)}
+			}
+		}
+	}
+	return Location{File: path}
+}
+
+func dapStackTrace(m *Machine) any {
+	s := m.Debugger.dap
+	var frames []any
+	for i := 0; ; i++ {
+		ff := debugFrameFunc(m, i)
+		if ff == nil {
+			break
+		}
+		name := fmt.Sprintf("%v.%v", ff.PkgPath, ff.Name)
+		if ff.IsMethod {
+			name = fmt.Sprintf("%v.(%v).%v", ff.PkgPath, ff.Type.(*FuncType).Params[0].Type, ff.Name)
+		}
+		frames = append(frames, dapFrame(s, m, i, name, debugFrameLoc(m, i)))
+	}
+	if len(frames) == 0 {
+		// Executing package level declarations.
+		frames = append(frames, dapFrame(s, m, 0, string(m.Package.PkgPath), m.Debugger.loc))
+	}
+	return map[string]any{"stackFrames": frames, "totalFrames": len(frames)}
+}
+
+func dapFrame(s *DAPSession, m *Machine, id int, name string, loc Location) map[string]any {
+	frame := map[string]any{"id": id, "name": name, "line": loc.Line, "column": loc.Column}
+	if src := s.source(m, loc); src != nil {
+		frame["source"] = src
+	}
+	return frame
+}
+
+// source returns the DAP source of the file at loc. Files which are not on
+// disk, such as those of packages loaded from the store, are served by
+// reference.
+func (s *DAPSession) source(m *Machine, loc Location) *dapSource {
+	if loc.File == "" {
+		return nil
+	}
+	if filepath.IsAbs(loc.File) {
+		return &dapSource{Name: filepath.Base(loc.File), Path: loc.File}
+	}
+	if _, err := os.Stat(loc.File); err == nil {
+		if path, err := filepath.Abs(loc.File); err == nil {
+			return &dapSource{Name: filepath.Base(loc.File), Path: path}
+		}
+	}
+	if m.Debugger.rootDir != "" {
+		for _, dir := range []string{"gnovm/stdlibs", "examples"} {
+			path := filepath.Join(m.Debugger.rootDir, dir, filepath.FromSlash(loc.PkgPath), loc.File)
+			if _, err := os.Stat(path); err == nil {
+				return &dapSource{Name: loc.File, Path: path}
+			}
+		}
+	}
+	for i, src := range s.sources {
+		if src.PkgPath == loc.PkgPath && src.File == loc.File {
+			return &dapSource{Name: loc.File, SourceReference: i + 1}
+		}
+	}
+	s.sources = append(s.sources, Location{PkgPath: loc.PkgPath, File: loc.File})
+	return &dapSource{Name: loc.File, SourceReference: len(s.sources)}
+}
+
+func dapSourceContent(m *Machine, arguments json.RawMessage) (any, error) {
+	var args struct {
+		SourceReference int `json:"sourceReference"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, err
+	}
+	sources := m.Debugger.dap.sources
+	if args.SourceReference < 1 || args.SourceReference > len(sources) {
+		return nil, fmt.Errorf("invalid source reference: %d", args.SourceReference)
+	}
+	loc := sources[args.SourceReference-1]
+	src, err := fileContent(m.Store, loc.PkgPath, loc.File)
+	if err != nil && m.Debugger.getSrc != nil {
+		src, err = m.Debugger.getSrc(loc.PkgPath, loc.File), nil
+	}
+	if err != nil {
+		return nil, err
+	}
+	return map[string]any{"content": src}, nil
+}
+
+// varRef returns a new variable reference to ref.
+func (s *DAPSession) varRef(ref dapVarRef) int {
+	s.vars = append(s.vars, ref)
+	return len(s.vars)
+}
+
+// withFrame calls f with the debugger positioned at the given frame level.
+func withFrame(m *Machine, frame int, f func()) {
+	level := m.Debugger.frameLevel
+	defer func() { m.Debugger.frameLevel = level }()
+	m.Debugger.frameLevel = frame
+	f()
+}
+
+func dapVariables(m *Machine, arguments json.RawMessage) (any, error) {
+	var args struct {
+		VariablesReference int `json:"variablesReference"`
+		Start              int `json:"start"`
+		Count              int `json:"count"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, err
+	}
+	s := m.Debugger.dap
+	if args.VariablesReference < 1 || args.VariablesReference > len(s.vars) {
+		return nil, fmt.Errorf("invalid variables reference: %d", args.VariablesReference)
+	}
+	ref := s.vars[args.VariablesReference-1]
+
+	vars := []dapVariable{}
+	switch {
+	case ref.tv != nil:
+		vars = dapChildren(m, *ref.tv, args.Start, args.Count)
+	case ref.globals:
+		withFrame(m, ref.frame, func() { vars = dapGlobals(m) })
+	default:
+		withFrame(m, ref.frame, func() { vars = dapLocals(m) })
+	}
+	return map[string]any{"variables": vars}, nil
+}
+
+// dapLocals returns the variables of the function call frame at the current
+// frame level.
+func dapLocals(m *Machine) []dapVariable {
+	vars := []dapVariable{}
+	seen := map[Name]bool{}
+	add := func(names []Name, values []TypedValue) {
+		for i, name := range names {
+			if i >= len(values) || seen[name] || !dapVisible(name) || values[i].IsUndefined() {
+				continue
+			}
+			seen[name] = true // inner variables shadow outer ones
+			vars = append(vars, dapVar(m, string(name), values[i]))
+		}
+	}
+	for _, b := range debugFrameBlocks(m) {
+		switch b.Source.(type) {
+		case *FileNode, *PackageNode:
+			continue // globals
+		case *IfStmt:
+			add(ifBody(m, b.Source.(*IfStmt)).Source.GetBlockNames(), b.Values)
+		}
+		add(b.Source.GetBlockNames(), b.Values)
+	}
+	return vars
+}
+
+// dapGlobals returns the package level variables of the function at the
+// current frame level.
+func dapGlobals(m *Machine) []dapVariable {
+	pv := m.Package
+	if ff := debugFrameFunc(m, m.Debugger.frameLevel); ff != nil && ff.PkgPath != pv.PkgPath {
+		pv = m.Store.GetPackage(ff.PkgPath, false)
+	}
+	vars := []dapVariable{}
+	if pv == nil {
+		return vars
+	}
+	b := pv.GetBlock(m.Store)
+	for i, name := range b.Source.GetBlockNames() {
+		if i >= len(b.Values) || !dapVisible(name) {
+			continue
+		}
+		tv := b.Values[i]
+		if tv.IsUndefined() || tv.T.Kind() == TypeKind {
+			continue
+		}
+		if fv, ok := tv.V.(*FuncValue); ok && fv.Name == name {
+			continue // function declaration
+		}
+		vars = append(vars, dapVar(m, string(name), tv))
+	}
+	return vars
+}
+
+// dapVisible returns whether the value named name is shown to the user,
+// excluding the internal values of the VM.
+func dapVisible(name Name) bool {
+	return name != "" && name != blankIdentifier && !strings.HasPrefix(string(name), ".")
+}
+
+func dapEvaluate(m *Machine, arguments json.RawMessage) (any, error) {
+	var args struct {
+		Expression string `json:"expression"`
+		FrameID    int    `json:"frameId"`
+	}
+	if err := json.Unmarshal(arguments, &args); err != nil {
+		return nil, err
+	}
+	expr, err := parser.ParseExpr(args.Expression)
+	if err != nil {
+		return nil, err
+	}
+	var tv TypedValue
+	withFrame(m, args.FrameID, func() { tv, err = debugEvalExpr(m, expr) })
+	if err != nil {
+		return nil, err
+	}
+	v := dapVar(m, args.Expression, tv)
+	return map[string]any{
+		"result":             v.Value,
+		"type":               v.Type,
+		"variablesReference": v.VariablesReference,
+		"indexedVariables":   v.IndexedVariables,
+	}, nil
+}
+
+// dapVar returns the DAP variable of value tv. Composite values get a
+// reference to their children.
+func dapVar(m *Machine, name string, tv TypedValue) dapVariable {
+	fillValueTV(m.Store, &tv)
+	if hiv, ok := tv.V.(*HeapItemValue); ok {
+		// Variable captured by a closure.
+		tv = hiv.Value
+		fillValueTV(m.Store, &tv)
+	}
+	v := dapVariable{Name: name, Value: dapValueString(tv)}
+	if tv.T == nil {
+		return v
+	}
+	v.Type = tv.T.String()
+
+	switch cv := tv.V.(type) {
+	case PointerValue:
+		v.VariablesReference = m.Debugger.dap.varRef(dapVarRef{tv: &tv})
+	case *StructValue:
+		if len(cv.Fields) > 0 {
+			v.VariablesReference = m.Debugger.dap.varRef(dapVarRef{tv: &tv})
+		}
+	case *ArrayValue:
+		if n := cv.GetLength(); n > 0 {
+			v.VariablesReference = m.Debugger.dap.varRef(dapVarRef{tv: &tv})
+			v.IndexedVariables = n
+		}
+	case *SliceValue:
+		if cv.Length > 0 {
+			v.VariablesReference = m.Debugger.dap.varRef(dapVarRef{tv: &tv})
+			v.IndexedVariables = cv.Length
+		}
+	case *MapValue:
+		if cv.GetLength() > 0 {
+			v.VariablesReference = m.Debugger.dap.varRef(dapVarRef{tv: &tv})
+		}
+	}
+	return v
+}
+
+// dapValueString returns the representation of tv shown to the user, which
+// doesn't call the String or Error methods of the value.
+func dapValueString(tv TypedValue) (s string) {
+	defer func() {
+		if r := recover(); r != nil {
+			s = fmt.Sprintf("<error: %v>", r)
+		}
+	}()
+	if tv.T == nil {
+		return nilStr
+	}
+	if tv.T.Kind() == StringKind {
+		return strconv.Quote(tv.GetString())
+	}
+	s = tv.ProtectedSprint(newSeenValues(), false)
+	if len(s) > 256 {
+		s = s[:256] + "..."
+	}
+	return s
+}
+
+// dapChildren returns the children of the composite value tv: the elements
+// in [start, start+count) of arrays and slices, the fields of structs, the
+// entries of maps or the value pointed to.
+func dapChildren(m *Machine, tv TypedValue, start, count int) []dapVariable {
+	if count <= 0 || count > maxDAPChildren {
+		count = maxDAPChildren
+	}
+	vars := []dapVariable{}
+	switch cv := tv.V.(type) {
+	case PointerValue:
+		elem := cv.Deref()
+		v := dapVar(m, "*", elem)
+		if v.VariablesReference != 0 && v.IndexedVariables == 0 {
+			// Show the fields of the pointed struct or map directly.
+			return dapChildren(m, elem, start, count)
+		}
+		vars = append(vars, v)
+	case *StructValue:
+		st := baseOf(tv.T).(*StructType)
+		for i, f := range st.Fields {
+			vars = append(vars, dapVar(m, string(f.Name), cv.GetPointerToInt(m.Store, i).Deref()))
+		}
+	case *ArrayValue:
+		et := baseOf(tv.T).(*ArrayType).Elt
+		for i := start; i < cv.GetLength() && i < start+count; i++ {
+			vars = append(vars, dapVar(m, "["+strconv.Itoa(i)+"]", cv.GetPointerAtIndexInt2(m.Store, i, et).Deref()))
+		}
+	case *SliceValue:
+		et := baseOf(tv.T).(*SliceType).Elt
+		for i := start; i < cv.Length && i < start+count; i++ {
+			vars = append(vars, dapVar(m, "["+strconv.Itoa(i)+"]", cv.GetPointerAtIndexInt2(m.Store, i, et).Deref()))
+		}
+	case *MapValue:
+		for cur := cv.List.Head; cur != nil && len(vars) < count; cur = cur.Next {
+			vars = append(vars, dapVar(m, "["+dapValueString(cur.Key)+"]", cur.Value))
+		}
+	}
+	return vars
+}
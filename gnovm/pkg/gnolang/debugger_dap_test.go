@@ -0,0 +1,313 @@
+package gnolang_test
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
+	"github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/test"
+)
+
+// dapClient is a minimal Debug Adapter Protocol client.
+type dapClient struct {
+	t      *testing.T
+	r      *bufio.Reader
+	w      io.Writer
+	seq    int
+	output strings.Builder // output events
+}
+
+type dapMessage struct {
+	Type    string          `json:"type"`
+	Event   string          `json:"event"`
+	Command string          `json:"command"`
+	Success bool            `json:"success"`
+	Message string          `json:"message"`
+	Body    json.RawMessage `json:"body"`
+}
+
+// startDAP runs the main function of file in a debugger with a DAP session,
+// returning the client of the session.
+func startDAP(t *testing.T, file string) *dapClient {
+	t.Helper()
+
+	clientR, vmW := io.Pipe()
+	vmR, clientW := io.Pipe()
+	session := gnolang.NewDAPSession(vmR, vmW)
+
+	go func() {
+		defer vmW.Close()
+		output := test.OutputWithError(session.Output("stdout"), session.Output("stderr"))
+		_, testStore := test.TestStore(gnoenv.RootDir(), output, nil)
+		f := gnolang.MustReadFile(file)
+		m := gnolang.NewMachineWithOptions(gnolang.MachineOptions{
+			PkgPath: string(f.PkgName),
+			Output:  output,
+			Store:   testStore,
+			Context: test.Context(test.DefaultCaller, string(f.PkgName), nil),
+		})
+		defer m.Release()
+		m.Debugger.EnableDAP(session)
+		m.RunFiles(f)
+		ex, _ := gnolang.ParseExpr("main()")
+		m.Eval(ex)
+		session.Exited(0)
+	}()
+
+	c := &dapClient{t: t, r: bufio.NewReader(clientR), w: clientW}
+	t.Cleanup(func() { clientW.Close() })
+	return c
+}
+
+func (c *dapClient) send(command string, args any) {
+	c.t.Helper()
+
+	c.seq++
+	bz, err := json.Marshal(map[string]any{"seq": c.seq, "type": "request", "command": command, "arguments": args})
+	require.NoError(c.t, err)
+	_, err = fmt.Fprintf(c.w, "Content-Length: %d\r\n\r\n%s", len(bz), bz)
+	require.NoError(c.t, err)
+}
+
+func (c *dapClient) read() dapMessage {
+	c.t.Helper()
+
+	header, err := c.r.ReadString('\n')
+	require.NoError(c.t, err)
+	length, err := strconv.Atoi(strings.TrimSpace(strings.TrimPrefix(header, "Content-Length:")))
+	require.NoError(c.t, err)
+	_, err = c.r.ReadString('\n')
+	require.NoError(c.t, err)
+	bz := make([]byte, length)
+	_, err = io.ReadFull(c.r, bz)
+	require.NoError(c.t, err)
+
+	var msg dapMessage
+	require.NoError(c.t, json.Unmarshal(bz, &msg))
+	return msg
+}
+
+// expect reads messages until the response to command or the event named
+// name, and decodes its body into body.
+func (c *dapClient) expect(name string, body any) dapMessage {
+	c.t.Helper()
+
+	for {
+		msg := c.read()
+		if msg.Event == "output" {
+			var out struct{ Output string }
+			require.NoError(c.t, json.Unmarshal(msg.Body, &out))
+			c.output.WriteString(out.Output)
+			continue
+		}
+		if msg.Event != name && msg.Command != name {
+			continue
+		}
+		if body != nil {
+			require.NoError(c.t, json.Unmarshal(msg.Body, body))
+		}
+		return msg
+	}
+}
+
+// request sends a request and returns its response.
+func (c *dapClient) request(command string, args any, body any) dapMessage {
+	c.t.Helper()
+
+	c.send(command, args)
+	return c.expect(command, body)
+}
+
+type dapVariables struct {
+	Variables []struct {
+		Name               string
+		Value              string
+		Type               string
+		VariablesReference int
+		IndexedVariables   int
+	}
+}
+
+func (v dapVariables) get(t *testing.T, name string) (value string, ref int) {
+	t.Helper()
+
+	for _, v := range v.Variables {
+		if v.Name == name {
+			return v.Value, v.VariablesReference
+		}
+	}
+	t.Fatalf("no variable %s in %+v", name, v.Variables)
+	return "", 0
+}
+
+func (c *dapClient) scopes(frame int) (locals, globals int) {
+	c.t.Helper()
+
+	var scopes struct {
+		Scopes []struct {
+			Name               string
+			VariablesReference int
+		}
+	}
+	c.request("scopes", map[string]any{"frameId": frame}, &scopes)
+	require.Len(c.t, scopes.Scopes, 2)
+	return scopes.Scopes[0].VariablesReference, scopes.Scopes[1].VariablesReference
+}
+
+func (c *dapClient) variables(ref int) dapVariables {
+	c.t.Helper()
+
+	var vars dapVariables
+	res := c.request("variables", map[string]any{"variablesReference": ref}, &vars)
+	require.True(c.t, res.Success, res.Message)
+	return vars
+}
+
+func (c *dapClient) stoppedAt(reason string, line int) {
+	c.t.Helper()
+
+	var stopped struct{ Reason string }
+	c.expect("stopped", &stopped)
+	assert.Equal(c.t, reason, stopped.Reason)
+
+	var trace struct {
+		StackFrames []struct {
+			Name string
+			Line int
+		}
+	}
+	c.request("stackTrace", map[string]any{"threadId": 1}, &trace)
+	require.NotEmpty(c.t, trace.StackFrames)
+	assert.Equal(c.t, line, trace.StackFrames[0].Line)
+}
+
+func TestDebugDAP(t *testing.T) {
+	target, err := filepath.Abs(debugTarget)
+	require.NoError(t, err)
+	c := startDAP(t, target)
+
+	var caps map[string]any
+	res := c.request("initialize", map[string]any{"adapterID": "gno"}, &caps)
+	require.True(t, res.Success)
+	assert.Equal(t, true, caps["supportsConfigurationDoneRequest"])
+	c.expect("initialized", nil)
+
+	c.request("launch", map[string]any{}, nil)
+	var bps struct {
+		Breakpoints []struct {
+			Verified bool
+			Line     int
+		}
+	}
+	c.request("setBreakpoints", map[string]any{
+		"source":      map[string]any{"path": target},
+		"breakpoints": []any{map[string]any{"line": 7}, map[string]any{"line": 21}},
+	}, &bps)
+	require.Len(t, bps.Breakpoints, 2)
+	assert.True(t, bps.Breakpoints[0].Verified)
+	c.request("setExceptionBreakpoints", map[string]any{"filters": []string{}}, nil)
+	c.request("configurationDone", nil, nil)
+
+	// In f, called from g, called from main.
+	c.stoppedAt("breakpoint", 7)
+	assert.Contains(t, c.output.String(), "in main")
+	var trace struct {
+		StackFrames []struct {
+			ID     int
+			Name   string
+			Line   int
+			Source struct{ Path string }
+		}
+	}
+	c.request("stackTrace", map[string]any{"threadId": 1}, &trace)
+	require.Len(t, trace.StackFrames, 3)
+	assert.Equal(t, "main.f", trace.StackFrames[0].Name)
+	assert.Equal(t, target, trace.StackFrames[0].Source.Path)
+	assert.Equal(t, "main.g", trace.StackFrames[1].Name)
+	assert.Equal(t, 11, trace.StackFrames[1].Line)
+	assert.Equal(t, "main.main", trace.StackFrames[2].Name)
+
+	locals, globals := c.scopes(0)
+	vars := c.variables(locals)
+	value, _ := vars.get(t, "name")
+	assert.Equal(t, `"hello"`, value)
+	value, _ = vars.get(t, "i")
+	assert.Equal(t, "3", value)
+	value, _ = c.variables(globals).get(t, "global")
+	assert.Equal(t, `"test"`, value)
+
+	// Locals of the caller.
+	locals, _ = c.scopes(1)
+	value, _ = c.variables(locals).get(t, "s")
+	assert.Equal(t, `"hello"`, value)
+
+	var eval struct{ Result, Type string }
+	c.request("evaluate", map[string]any{"expression": "n", "frameId": 1}, &eval)
+	assert.Equal(t, "3", eval.Result)
+	assert.Equal(t, "int", eval.Type)
+	res = c.request("evaluate", map[string]any{"expression": "n", "frameId": 0}, nil)
+	assert.False(t, res.Success)
+	assert.Contains(t, res.Message, "could not find symbol value for n")
+
+	// In the method get of T.
+	c.request("continue", map[string]any{"threadId": 1}, nil)
+	c.stoppedAt("breakpoint", 21)
+	assert.Contains(t, c.output.String(), "hello 3")
+	locals, _ = c.scopes(0)
+	_, ref := c.variables(locals).get(t, "t")
+	require.NotZero(t, ref)
+	_, ref = c.variables(ref).get(t, "A")
+	require.NotZero(t, ref)
+	elems := c.variables(ref)
+	require.Len(t, elems.Variables, 3)
+	assert.Equal(t, "[2]", elems.Variables[2].Name)
+	assert.Equal(t, "3", elems.Variables[2].Value)
+
+	c.request("next", map[string]any{"threadId": 1}, nil)
+	c.stoppedAt("step", 22)
+	c.request("stepOut", map[string]any{"threadId": 1}, nil)
+	c.stoppedAt("step", 40)
+
+	res = c.request("pause", map[string]any{"threadId": 1}, nil)
+	assert.False(t, res.Success)
+	assert.Contains(t, res.Message, "unsupported request")
+
+	// Run to completion.
+	c.request("setBreakpoints", map[string]any{"source": map[string]any{"path": target}, "breakpoints": []any{}}, nil)
+	c.request("continue", map[string]any{"threadId": 1}, nil)
+	var exited struct{ ExitCode int }
+	c.expect("exited", &exited)
+	assert.Equal(t, 0, exited.ExitCode)
+	c.expect("terminated", nil)
+	assert.Contains(t, c.output.String(), "bye 4")
+}
+
+func TestDebugDAPStopOnEntry(t *testing.T) {
+	target, err := filepath.Abs(debugTarget)
+	require.NoError(t, err)
+	c := startDAP(t, target)
+
+	c.request("initialize", map[string]any{}, nil)
+	c.expect("initialized", nil)
+	c.request("launch", map[string]any{"stopOnEntry": true}, nil)
+	c.request("configurationDone", nil, nil)
+	// At the declaration of global, then of T.
+	c.stoppedAt("entry", 14)
+	c.request("stepIn", map[string]any{"threadId": 1}, nil)
+	c.stoppedAt("step", 16)
+
+	// The program resumes without the debugger.
+	c.request("disconnect", map[string]any{"terminateDebuggee": false}, nil)
+	c.expect("terminated", nil)
+	assert.Contains(t, c.output.String(), "bye 4")
+}
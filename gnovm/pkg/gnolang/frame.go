@@ -35,6 +35,10 @@ type Frame struct {
 	IsRevive      bool          // calling revive()
 	LastException *Exception    // previous m.exception
 
+	// cache of the call if it crossed into a realm, see enterRealm.
+	writeCall   func() // upon return.
+	discardCall func() // upon panic.
+
 	// test info
 	TestOverridden bool // bool if overridden by test SetContext.
 }
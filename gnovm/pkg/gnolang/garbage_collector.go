@@ -80,6 +80,28 @@ func (m *Machine) GarbageCollect() (left int64, ok bool) {
 		}
 	}
 
+	// Visit the blocks and frames of the other goroutines
+	if m.sched != nil {
+		for i, g := range m.sched.gs {
+			if i == m.sched.cur {
+				continue
+			}
+			for _, block := range g.blocks {
+				if block == nil {
+					continue
+				}
+				if stop := vis(block); stop {
+					return -1, false
+				}
+			}
+			for _, frame := range g.frames {
+				if stop := frame.Visit(m.Alloc, vis); stop {
+					return -1, false
+				}
+			}
+		}
+	}
+
 	// Visit package
 	stop := vis(m.Package)
 	if stop {
@@ -135,6 +157,14 @@ func GCVisitorFn(gcCycle int64, alloc *Allocator, visitCount *int64) Visitor {
 			debug.Printf("Visit, v: %v (type: %v)\n", v, reflect.TypeOf(v))
 		}
 
+		// Channels aren't objects, but may reference themselves.
+		if cv, ok := v.(*ChanValue); ok {
+			if cv.lastGCCycle == gcCycle {
+				return false
+			}
+			cv.lastGCCycle = gcCycle
+		}
+
 		if oo, isObject := v.(Object); isObject {
 			// Return if already measured.
 			if debug {
@@ -264,6 +294,25 @@ func (bmv *BoundMethodValue) VisitAssociated(vis Visitor) (stop bool) {
 	return
 }
 
+func (cv *ChanValue) VisitAssociated(vis Visitor) (stop bool) {
+	// Visit the values buffered, and the ones of the senders waiting.
+	for _, tv := range cv.Buffer {
+		if tv.V != nil {
+			if stop = vis(tv.V); stop {
+				return
+			}
+		}
+	}
+	for _, w := range cv.sendq {
+		if w.value.V != nil {
+			if stop = vis(w.value.V); stop {
+				return
+			}
+		}
+	}
+	return
+}
+
 func (mv *MapValue) VisitAssociated(vis Visitor) (stop bool) {
 	// visit mv.List.
 	for cur := mv.List.Head; cur != nil; cur = cur.Next {
@@ -0,0 +1,401 @@
+package gnolang
+
+import (
+	"fmt"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/overflow"
+)
+
+// GasTableVersionDefault is the version of the default gas table.
+const GasTableVersionDefault = "v1"
+
+// GasTable is a versioned pricing table of the VM: it sets the CPU cycles of
+// each opcode, the gas of the store operations, and the extra CPU cycles of
+// native function calls.
+//
+// A table is made of the prices of a base version, which are fixed, and of
+// overrides, so costs can be re-calibrated without changing the VM. Prices
+// are named after the opcode ("OpAdd"), the store operation
+// ("GetObjectPerByte"), or the native function ("crypto/sha256.sum256"). The
+// price per byte of the []byte and string arguments of a native function is
+// named after the function, with a "PerByte" suffix
+// ("crypto/sha256.sum256PerByte"), and so is the price per byte of the
+// opcodes copying data ("OpConvertPerByte").
+type GasTable struct {
+	Version       string
+	OpCPU         [256]int64
+	OpCPUPerByte  [256]int64
+	Store         GasConfig
+	Native        map[string]int64 // by "pkgpath.name"
+	NativePerByte map[string]int64 // by "pkgpath.name"
+}
+
+// gasTableVersions contains the base prices of each gas table version.
+var gasTableVersions = map[string]func() *GasTable{
+	"v1": gasTableV1,
+}
+
+func gasTableV1() *GasTable {
+	gt := &GasTable{
+		Version: "v1",
+		Store:   DefaultGasConfig(),
+		// Hashing costs in proportion to the size of the data.
+		// The additions, subtractions and shifts of math/uint256 only cost
+		// the native call.
+		// The operations of math/big and math/decimal cost in proportion to the size of their
+		// operands, which is bounded by big.MaxBits, so that the price per
+		// byte also covers the quadratic multiplications and divisions.
+		Native: map[string]int64{
+			"crypto/sha256.sum256":        100,
+			"crypto/sha3.sum256":          100,
+			"crypto/sha3.sum512":          100,
+			"crypto/keccak256.sum":        100,
+			"crypto/ripemd160.sum":        100,
+			"crypto/ed25519.verify":       25000,
+			"crypto/secp256k1.verifyHash": 30000,
+			"math/uint256.mul":            50,
+			"math/uint256.divMod":         400,
+			"math/uint256.mulDiv":         600,
+			"math/uint256.exp":            8000, // up to 256 squarings.
+			"math/uint256.parse":          100,
+			"math/uint256.format":         600,
+			"math/big.addAbs":             20,
+			"math/big.subAbs":             20,
+			"math/big.mulAbs":             50,
+			"math/big.mulMod":             400,
+			"math/big.quoRemAbs":          400,
+			"math/big.sqrtAbs":            600,
+			"math/big.lshAbs":             20,
+			"math/big.rshAbs":             20,
+			"math/big.parse":              100,
+			"math/big.format":             600,
+			"math/decimal.quoRound":       400,
+			"math/decimal.parse":          100,
+			"math/decimal.format":         600,
+		},
+		NativePerByte: map[string]int64{
+			"crypto/sha256.sum256":  2,
+			"crypto/sha3.sum256":    2,
+			"crypto/sha3.sum512":    4, // about twice the permutations per byte of sum256.
+			"crypto/keccak256.sum":  2,
+			"crypto/ripemd160.sum":  2,
+			"crypto/ed25519.verify": 2, // the message is hashed with SHA-512.
+			"math/uint256.parse":    10,
+			"math/big.addAbs":       1,
+			"math/big.subAbs":       1,
+			"math/big.mulAbs":       4,
+			"math/big.mulMod":       8,
+			"math/big.quoRemAbs":    4,
+			"math/big.sqrtAbs":      8,
+			"math/big.lshAbs":       1,
+			"math/big.rshAbs":       1,
+			"math/big.parse":        10,
+			"math/big.format":       10,
+			"math/decimal.quoRound": 4,
+			"math/decimal.parse":    10,
+			"math/decimal.format":   10,
+		},
+	}
+	// Converting between strings and []byte or []rune copies the string.
+	gt.OpCPUPerByte = [256]int64{
+		OpConvert: OpCPUConvertPerByte,
+	}
+	gt.OpCPU = [256]int64{
+		OpHalt:                OpCPUHalt,
+		OpNoop:                OpCPUNoop,
+		OpExec:                OpCPUExec,
+		OpPrecall:             OpCPUPrecall,
+		OpEnterCrossing:       OpCPUEnterCrossing,
+		OpCall:                OpCPUCall,
+		OpCallNativeBody:      OpCPUCallNativeBody,
+		OpReturn:              OpCPUReturn,
+		OpReturnAfterCopy:     OpCPUReturnAfterCopy,
+		OpReturnFromBlock:     OpCPUReturnFromBlock,
+		OpReturnToBlock:       OpCPUReturnToBlock,
+		OpDefer:               OpCPUDefer,
+		OpPanic1:              OpCPUPanic1,
+		OpPanic2:              OpCPUPanic2,
+		OpCallDeferNativeBody: OpCPUCallDeferNativeBody,
+		OpGo:                  OpCPUGo,
+		OpSelect:              OpCPUSelect,
+		OpSwitchClause:        OpCPUSwitchClause,
+		OpSwitchClauseCase:    OpCPUSwitchClauseCase,
+		OpTypeSwitch:          OpCPUTypeSwitch,
+		OpIfCond:              OpCPUIfCond,
+		OpPopValue:            OpCPUPopValue,
+		OpPopResults:          OpCPUPopResults,
+		OpPopBlock:            OpCPUPopBlock,
+		OpPopFrameAndReset:    OpCPUPopFrameAndReset,
+		OpUpos:                OpCPUUpos,
+		OpUneg:                OpCPUUneg,
+		OpUnot:                OpCPUUnot,
+		OpUxor:                OpCPUUxor,
+		OpUrecv:               OpCPUUrecv,
+		OpLor:                 OpCPULor,
+		OpLand:                OpCPULand,
+		OpEql:                 OpCPUEql,
+		OpNeq:                 OpCPUNeq,
+		OpLss:                 OpCPULss,
+		OpLeq:                 OpCPULeq,
+		OpGtr:                 OpCPUGtr,
+		OpGeq:                 OpCPUGeq,
+		OpAdd:                 OpCPUAdd,
+		OpSub:                 OpCPUSub,
+		OpBor:                 OpCPUBor,
+		OpXor:                 OpCPUXor,
+		OpMul:                 OpCPUMul,
+		OpQuo:                 OpCPUQuo,
+		OpRem:                 OpCPURem,
+		OpShl:                 OpCPUShl,
+		OpShr:                 OpCPUShr,
+		OpBand:                OpCPUBand,
+		OpBandn:               OpCPUBandn,
+		OpEval:                OpCPUEval,
+		OpBinary1:             OpCPUBinary1,
+		OpIndex1:              OpCPUIndex1,
+		OpIndex2:              OpCPUIndex2,
+		OpSelector:            OpCPUSelector,
+		OpSlice:               OpCPUSlice,
+		OpStar:                OpCPUStar,
+		OpRef:                 OpCPURef,
+		OpTypeAssert1:         OpCPUTypeAssert1,
+		OpTypeAssert2:         OpCPUTypeAssert2,
+		OpStaticTypeOf:        OpCPUStaticTypeOf,
+		OpCompositeLit:        OpCPUCompositeLit,
+		OpArrayLit:            OpCPUArrayLit,
+		OpSliceLit:            OpCPUSliceLit,
+		OpSliceLit2:           OpCPUSliceLit2,
+		OpFuncLit:             OpCPUFuncLit,
+		OpMapLit:              OpCPUMapLit,
+		OpStructLit:           OpCPUStructLit,
+		OpConvert:             OpCPUConvert,
+		OpFieldType:           OpCPUFieldType,
+		OpArrayType:           OpCPUArrayType,
+		OpSliceType:           OpCPUSliceType,
+		OpChanType:            OpCPUChanType,
+		OpFuncType:            OpCPUFuncType,
+		OpMapType:             OpCPUMapType,
+		OpStructType:          OpCPUStructType,
+		OpInterfaceType:       OpCPUInterfaceType,
+		OpAssign:              OpCPUAssign,
+		OpAddAssign:           OpCPUAddAssign,
+		OpSubAssign:           OpCPUSubAssign,
+		OpMulAssign:           OpCPUMulAssign,
+		OpQuoAssign:           OpCPUQuoAssign,
+		OpRemAssign:           OpCPURemAssign,
+		OpBandAssign:          OpCPUBandAssign,
+		OpBandnAssign:         OpCPUBandnAssign,
+		OpBorAssign:           OpCPUBorAssign,
+		OpXorAssign:           OpCPUXorAssign,
+		OpShlAssign:           OpCPUShlAssign,
+		OpShrAssign:           OpCPUShrAssign,
+		OpDefine:              OpCPUDefine,
+		OpInc:                 OpCPUInc,
+		OpDec:                 OpCPUDec,
+		OpSend:                OpCPUSend,
+		OpValueDecl:           OpCPUValueDecl,
+		OpTypeDecl:            OpCPUTypeDecl,
+		OpBody:                OpCPUBody,
+		OpForLoop:             OpCPUForLoop,
+		OpRangeIter:           OpCPURangeIter,
+		OpRangeIterArrayPtr:   OpCPURangeIterArrayPtr,
+		OpRangeIterString:     OpCPURangeIterString,
+		OpRangeIterMap:        OpCPURangeIterMap,
+		OpRangeIterChan:       OpCPURangeIterChan,
+		OpReturnCallDefers:    OpCPUReturnCallDefers,
+	}
+	return gt
+}
+
+// DefaultGasTable returns the gas table of version GasTableVersionDefault,
+// without overrides.
+func DefaultGasTable() *GasTable {
+	return gasTableVersions[GasTableVersionDefault]()
+}
+
+// NewGasTable returns the gas table of the given version, with the given
+// overrides of the form "name=price". An empty version is the default one.
+func NewGasTable(version string, overrides []string) (*GasTable, error) {
+	if version == "" {
+		version = GasTableVersionDefault
+	}
+	newTable, ok := gasTableVersions[version]
+	if !ok {
+		return nil, fmt.Errorf("unknown gas table version %q", version)
+	}
+	gt := newTable()
+	for _, override := range overrides {
+		name, value, ok := strings.Cut(override, "=")
+		if !ok {
+			return nil, fmt.Errorf("invalid gas price %q, expected name=price", override)
+		}
+		price, err := strconv.ParseInt(strings.TrimSpace(value), 10, 64)
+		if err != nil || price < 0 {
+			return nil, fmt.Errorf("invalid gas price %q: must be a positive integer", override)
+		}
+		if err := gt.Set(strings.TrimSpace(name), price); err != nil {
+			return nil, err
+		}
+	}
+	return gt, nil
+}
+
+// opsByName maps the name of each opcode to its value.
+var opsByName = func() map[string]Op {
+	ops := make(map[string]Op, len(_Op_map))
+	for op, name := range _Op_map {
+		ops[name] = op
+	}
+	return ops
+}()
+
+// Set sets the price of the opcode, store operation or native function
+// with the given name.
+func (gt *GasTable) Set(name string, price int64) error {
+	if op, ok := opsByName[name]; ok {
+		gt.OpCPU[op] = price
+		return nil
+	}
+	if opName, ok := strings.CutSuffix(name, "PerByte"); ok {
+		if op, ok := opsByName[opName]; ok {
+			gt.OpCPUPerByte[op] = price
+			return nil
+		}
+	}
+	if ptr := gt.storePrice(name); ptr != nil {
+		*ptr = price
+		return nil
+	}
+	if pkgPath, fn, ok := strings.Cut(name, "."); ok && pkgPath != "" && fn != "" {
+		if native, ok := strings.CutSuffix(name, "PerByte"); ok {
+			gt.NativePerByte[native] = price
+		} else {
+			gt.Native[name] = price
+		}
+		return nil
+	}
+	return fmt.Errorf("unknown gas price %q", name)
+}
+
+// Get returns the price of the opcode, store operation or native function
+// with the given name.
+func (gt *GasTable) Get(name string) (int64, bool) {
+	if op, ok := opsByName[name]; ok {
+		return gt.OpCPU[op], true
+	}
+	if opName, ok := strings.CutSuffix(name, "PerByte"); ok {
+		if op, ok := opsByName[opName]; ok {
+			return gt.OpCPUPerByte[op], true
+		}
+	}
+	if ptr := gt.storePrice(name); ptr != nil {
+		return *ptr, true
+	}
+	if native, ok := strings.CutSuffix(name, "PerByte"); ok {
+		price, ok := gt.NativePerByte[native]
+		return price, ok
+	}
+	price, ok := gt.Native[name]
+	return price, ok
+}
+
+// storePriceNames are the names of the prices of the store operations.
+var storePriceNames = []string{
+	GasGetObjectDesc,
+	GasSetObjectDesc,
+	GasGetTypeDesc,
+	GasSetTypeDesc,
+	GasGetPackageRealmDesc,
+	GasSetPackageRealmDesc,
+	GasAddMemPackageDesc,
+	GasGetMemPackageDesc,
+	GasDeleteObjectDesc,
+}
+
+// Prices returns all the prices of the table, sorted by name, in the
+// "name=price" form of the overrides of NewGasTable. The per byte prices of
+// the opcodes are only listed if they are set.
+func (gt *GasTable) Prices() []string {
+	prices := make([]string, 0, len(opsByName)+len(storePriceNames)+len(gt.Native)+len(gt.NativePerByte))
+	add := func(name string, price int64) {
+		prices = append(prices, name+"="+strconv.FormatInt(price, 10))
+	}
+	for name, op := range opsByName {
+		add(name, gt.OpCPU[op])
+		if price := gt.OpCPUPerByte[op]; price != 0 {
+			add(name+"PerByte", price)
+		}
+	}
+	for _, name := range storePriceNames {
+		add(name, *gt.storePrice(name))
+	}
+	for name, price := range gt.Native {
+		add(name, price)
+	}
+	for name, price := range gt.NativePerByte {
+		add(name+"PerByte", price)
+	}
+	slices.Sort(prices)
+	return prices
+}
+
+func (gt *GasTable) storePrice(name string) *int64 {
+	switch name {
+	case GasGetObjectDesc:
+		return &gt.Store.GasGetObject
+	case GasSetObjectDesc:
+		return &gt.Store.GasSetObject
+	case GasGetTypeDesc:
+		return &gt.Store.GasGetType
+	case GasSetTypeDesc:
+		return &gt.Store.GasSetType
+	case GasGetPackageRealmDesc:
+		return &gt.Store.GasGetPackageRealm
+	case GasSetPackageRealmDesc:
+		return &gt.Store.GasSetPackageRealm
+	case GasAddMemPackageDesc:
+		return &gt.Store.GasAddMemPackage
+	case GasGetMemPackageDesc:
+		return &gt.Store.GasGetMemPackage
+	case GasDeleteObjectDesc:
+		return &gt.Store.GasDeleteObject
+	default:
+		return nil
+	}
+}
+
+// nativeCPU returns the extra CPU cycles of a call to the native function,
+// whose arguments are in the block b.
+func (gt *GasTable) nativeCPU(fv *FuncValue, b *Block) int64 {
+	if len(gt.Native) == 0 && len(gt.NativePerByte) == 0 {
+		return 0
+	}
+	name := fv.NativePkg + "." + string(fv.NativeName)
+	cycles := gt.Native[name]
+	if perByte := gt.NativePerByte[name]; perByte != 0 {
+		var size int64
+		for _, tv := range b.Values {
+			if isByteSliceOrString(tv.T) {
+				size += int64(tv.GetLength())
+			}
+		}
+		cycles = overflow.Addp(cycles, overflow.Mulp(perByte, size))
+	}
+	return cycles
+}
+
+func isByteSliceOrString(t Type) bool {
+	if t == nil {
+		return false
+	}
+	switch bt := baseOf(t).(type) {
+	case PrimitiveType:
+		return bt.Kind() == StringKind
+	case *SliceType:
+		return bt.Elt.Kind() == Uint8Kind
+	}
+	return false
+}
@@ -0,0 +1,148 @@
+package gnolang
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewGasTable(t *testing.T) {
+	t.Parallel()
+
+	gt := DefaultGasTable()
+	assert.Equal(t, GasTableVersionDefault, gt.Version)
+	assert.Equal(t, int64(OpCPUAdd), gt.OpCPU[OpAdd])
+	assert.Equal(t, int64(OpCPUCallNativeBody), gt.OpCPU[OpCallNativeBody])
+	assert.Equal(t, DefaultGasConfig(), gt.Store)
+	assert.Equal(t, int64(100), gt.Native["crypto/sha256.sum256"])
+	assert.Equal(t, int64(2), gt.NativePerByte["crypto/sha256.sum256"])
+
+	gt, err := NewGasTable("", []string{"OpAdd=1", " GetObjectPerByte = 2", "crypto/sha256.sum256=3", "crypto/sha256.sum256PerByte=4"})
+	require.NoError(t, err)
+	assert.Equal(t, int64(1), gt.OpCPU[OpAdd])
+	assert.Equal(t, int64(2), gt.Store.GasGetObject)
+	price, ok := gt.Get("crypto/sha256.sum256")
+	assert.True(t, ok)
+	assert.Equal(t, int64(3), price)
+	price, ok = gt.Get("crypto/sha256.sum256PerByte")
+	assert.True(t, ok)
+	assert.Equal(t, int64(4), price)
+	_, ok = gt.Get("crypto/sha256.unknown")
+	assert.False(t, ok)
+
+	assert.Equal(t, int64(OpCPUConvertPerByte), gt.OpCPUPerByte[OpConvert])
+	require.NoError(t, gt.Set("OpConvertPerByte", 5))
+	price, ok = gt.Get("OpConvertPerByte")
+	assert.True(t, ok)
+	assert.Equal(t, int64(5), price)
+
+	// Overrides don't change the base prices.
+	assert.Equal(t, int64(OpCPUAdd), DefaultGasTable().OpCPU[OpAdd])
+
+	for _, tc := range []struct {
+		version   string
+		overrides []string
+		err       string
+	}{
+		{"v0", nil, `unknown gas table version "v0"`},
+		{"v1", []string{"OpAdd"}, "expected name=price"},
+		{"v1", []string{"OpAdd=x"}, "must be a positive integer"},
+		{"v1", []string{"OpAdd=-1"}, "must be a positive integer"},
+		{"v1", []string{"OpUnknown=1"}, `unknown gas price "OpUnknown"`},
+		{"v1", []string{"sum256=1"}, `unknown gas price "sum256"`},
+	} {
+		_, err := NewGasTable(tc.version, tc.overrides)
+		assert.ErrorContains(t, err, tc.err)
+	}
+}
+
+func TestMachineGasTable(t *testing.T) {
+	t.Parallel()
+
+	run := func(gt *GasTable) int64 {
+		m := NewMachineWithOptions(MachineOptions{PkgPath: "test", GasTable: gt})
+		defer m.Release()
+		m.RunFiles(MustParseFile("main.go", `package test
+func add(a, b int) int {
+	return a + b
+}`))
+		cycles := m.Cycles
+		m.Eval(Call("add", "1", "2"))
+		return m.Cycles - cycles
+	}
+
+	base := run(nil)
+	gt, err := NewGasTable("", []string{"OpAdd=1000"})
+	require.NoError(t, err)
+	assert.Equal(t, base+1000-OpCPUAdd, run(gt))
+}
+
+func TestMachineGasTableConvert(t *testing.T) {
+	t.Parallel()
+
+	run := func(fn, arg string) int64 {
+		gt, err := NewGasTable("", []string{"OpConvertPerByte=100"})
+		require.NoError(t, err)
+		m := NewMachineWithOptions(MachineOptions{PkgPath: "test", GasTable: gt})
+		defer m.Release()
+		m.RunFiles(MustParseFile("main.go", `package test
+func bytes(s string) int { return len([]byte(s)) }
+func runes(s string) int { return len([]rune(s)) }
+func str(s string) int { return len(string([]byte(s))) }`))
+		cycles := m.Cycles
+		m.Eval(Call(fn, fmt.Sprintf("%q", arg)))
+		return m.Cycles - cycles
+	}
+
+	// Converting between strings and []byte or []rune costs per byte of the
+	// string.
+	for _, fn := range []string{"bytes", "runes"} {
+		assert.Equal(t, run(fn, "")+100*10, run(fn, "0123456789"), fn)
+		assert.Equal(t, run(fn, "")+100*6, run(fn, "héllo"), fn)
+	}
+	assert.Equal(t, run("str", "")+2*100*10, run("str", "0123456789"))
+}
+
+func TestGasTableNativeCPU(t *testing.T) {
+	t.Parallel()
+
+	gt := DefaultGasTable()
+	require.NoError(t, gt.Set("test.hash", 10))
+	require.NoError(t, gt.Set("test.hashPerByte", 3))
+
+	fv := &FuncValue{NativePkg: "test", NativeName: "hash"}
+	data := TypedValue{T: &SliceType{Elt: Uint8Type}}
+	data.V = &SliceValue{Base: &ArrayValue{Data: make([]byte, 5)}, Length: 5, Maxcap: 5}
+	str := TypedValue{T: StringType, V: StringValue("abc")}
+	n := TypedValue{T: IntType}
+	n.SetInt(100)
+
+	// []byte and string arguments are charged per byte.
+	b := &Block{Values: []TypedValue{data, str, n}}
+	assert.Equal(t, int64(10+3*(5+3)), gt.nativeCPU(fv, b))
+
+	// Other native functions aren't charged.
+	other := &FuncValue{NativePkg: "test", NativeName: "other"}
+	assert.Equal(t, int64(0), gt.nativeCPU(other, b))
+}
+
+func TestGasTablePrices(t *testing.T) {
+	t.Parallel()
+
+	gt, err := NewGasTable("", []string{"OpAdd=7", "test.hashPerByte=3"})
+	require.NoError(t, err)
+	prices := gt.Prices()
+	assert.Contains(t, prices, "OpAdd=7")
+	assert.Contains(t, prices, "OpConvertPerByte="+fmt.Sprint(OpCPUConvertPerByte))
+	assert.Contains(t, prices, "GetObjectPerByte=16")
+	assert.Contains(t, prices, "crypto/sha256.sum256=100")
+	assert.Contains(t, prices, "test.hashPerByte=3")
+	assert.IsIncreasing(t, prices)
+
+	// The prices are valid overrides, giving back the same table.
+	gt2, err := NewGasTable(gt.Version, prices)
+	require.NoError(t, err)
+	assert.Equal(t, gt, gt2)
+}
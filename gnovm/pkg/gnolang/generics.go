@@ -0,0 +1,999 @@
+package gnolang
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Generics are implemented by monomorphization during preprocessing.
+//
+// A generic *FuncDecl or *TypeDecl of a file is a template: it is never
+// preprocessed itself (see Transcribe), and its name is only predefined with
+// a *genericType static type. Each instantiation with distinct type
+// arguments preprocesses a copy of the template (an instance) where the type
+// parameters are bound to their type arguments as constant type values, like
+// any other declared type name. Instantiation replaces the generic
+// expression with a *ConstExpr of the instance *FuncValue, or with a
+// *constTypeExpr of the instance *DeclaredType, so that nothing generic
+// remains at runtime.
+//
+// The instances of a generic type also instantiate the generic methods
+// declared for it. An instance *DeclaredType is named after its type
+// arguments, e.g. "List[int]", and like the block nodes of instance
+// functions and methods it is deterministically recreated when the
+// preprocessor encounters the same instantiation again (e.g. upon restart).
+
+// genericInstance is an instance of a generic declaration.
+type genericInstance struct {
+	Args  []Type       // type arguments
+	Type  Type         // *DeclaredType of a type, or *FuncType of a function
+	Func  *FuncValue   // instance function value, if function
+	Decls []*FuncDecl  // instance function, or methods of a type
+	funcs []*FuncValue // function values of Decls
+
+	pending bool               // if the bodies of Decls are yet to be preprocessed.
+	uses    []*genericInstance // instances used by the instance, for persistence.
+}
+
+// isGenericDecl returns true if n is a generic function, type, or method of
+// a generic type.
+func isGenericDecl(n Node) bool {
+	switch n := n.(type) {
+	case *FuncDecl:
+		return n.IsGeneric()
+	case *TypeDecl:
+		return n.IsGeneric()
+	default:
+		return false
+	}
+}
+
+// isGenericInstance returns true if fv is an instance of a generic
+// function.
+func isGenericInstance(fv *FuncValue) bool {
+	fd, ok := fv.Source.(*FuncDecl)
+	return ok && fd.HasAttribute(ATTR_GENERIC_INSTANCE)
+}
+
+// predefineGeneric predefines the name of the generic declaration d, whose
+// static type is a *genericType. Generic methods are only declared with the
+// instances of their receiver type.
+func predefineGeneric(store Store, last BlockNode, d Decl) {
+	fn, ok := last.(*FileNode)
+	if !ok {
+		panic(fmt.Sprintf("generic declaration %s must be at the package level", d.GetDeclNames()))
+	}
+	pn := packageOf(last)
+	switch d := d.(type) {
+	case *TypeDecl:
+		if d.IsAlias {
+			panic(fmt.Sprintf("generic type alias %s not supported", d.Name))
+		}
+		if !isLocallyDefined(pn, d.Name) {
+			gt := &genericType{PkgPath: pn.PkgPath, Name: d.Name, Decl: d, File: fn}
+			pn.Define2(false, d.Name, gt, TypedValue{}, NameSource{&d.NameExpr, d, NSTypeDecl, -1})
+		}
+		d.Path = last.GetPathForName(store, d.Name)
+	case *FuncDecl:
+		if d.IsMethod {
+			if len(d.TypeParams) > 0 {
+				panic(fmt.Sprintf("method %s must have no type parameters", d.Name))
+			}
+			return
+		}
+		if d.Body == nil {
+			panic(fmt.Sprintf("generic function %s must have a body", d.Name))
+		}
+		if !isLocallyDefined(pn, d.Name) {
+			gt := &genericType{PkgPath: pn.PkgPath, Name: d.Name, Decl: d, File: fn}
+			pn.Define2(false, d.Name, gt, TypedValue{}, NameSource{&d.NameExpr, d, NSFuncDecl, -1})
+		}
+	}
+}
+
+func (gt *genericType) typeParams() FieldTypeExprs {
+	switch d := gt.Decl.(type) {
+	case *TypeDecl:
+		return d.TypeParams
+	case *FuncDecl:
+		return d.TypeParams
+	default:
+		panic("should not happen")
+	}
+}
+
+func (gt *genericType) kindString() string {
+	if _, ok := gt.Decl.(*TypeDecl); ok {
+		return "type"
+	}
+	return "function"
+}
+
+// assertGenericUse panics unless the name of generic declaration gt, as
+// transcribed with ftype, is instantiated (or, if a function, called).
+func assertGenericUse(gt *genericType, ftype TransField) {
+	switch ftype {
+	case TRANS_INDEX_X, TRANS_INDEXLIST_X:
+		return
+	case TRANS_CALL_FUNC:
+		if _, ok := gt.Decl.(*FuncDecl); ok {
+			return
+		}
+	}
+	panic(fmt.Sprintf("cannot use generic %s %s without instantiation",
+		gt.kindString(), gt.Name))
+}
+
+func assertTypeArgCount(gt *genericType, have int) {
+	want := len(gt.typeParams())
+	if have < want {
+		panic(fmt.Sprintf("not enough type arguments for %s %s: have %d, want %d",
+			gt.kindString(), gt.Name, have, want))
+	} else if have > want {
+		panic(fmt.Sprintf("too many type arguments for %s %s: have %d, want %d",
+			gt.kindString(), gt.Name, have, want))
+	}
+}
+
+// genericOf returns the generic declaration x refers to, if any.
+func genericOf(store Store, last BlockNode, x Expr) *genericType {
+	switch x.(type) {
+	case *NameExpr, *SelectorExpr:
+		gt, _ := evalStaticTypeOf(store, last, x).(*genericType)
+		return gt
+	default:
+		return nil
+	}
+}
+
+// instantiateExpr instantiates the generic declaration gt with the type
+// arguments of the index expression n, transcribed with ftype. A function
+// called with less type arguments than it has type parameters is left to be
+// inferred upon leaving the *CallExpr.
+func instantiateExpr(store Store, last BlockNode, ftype TransField, n Expr, gt *genericType, ixs Exprs) Expr {
+	targs := make([]Type, len(ixs))
+	for i, ix := range ixs {
+		targs[i] = evalStaticType(store, last, ix)
+	}
+	if _, ok := gt.Decl.(*FuncDecl); ok && ftype == TRANS_CALL_FUNC &&
+		len(targs) < len(gt.typeParams()) {
+		return n
+	}
+	assertTypeArgCount(gt, len(targs))
+	gi := instantiate(store, last, gt, targs)
+	if gi.Func != nil {
+		return toConstExpr(n, TypedValue{T: gi.Type, V: gi.Func})
+	}
+	// n may still be evaluated as a composite type.
+	n.SetAttribute(ATTR_TYPE_VALUE, gi.Type)
+	return toConstTypeExpr(last, n, gi.Type)
+}
+
+// instantiateCall instantiates the generic function called by n, if any,
+// inferring the type arguments not given from those of the call arguments.
+// Returns nil if n does not call a generic function.
+func instantiateCall(store Store, last BlockNode, n *CallExpr) Expr {
+	var gt *genericType
+	var ixs Exprs
+	switch fx := n.Func.(type) {
+	case *IndexExpr:
+		gt = genericOf(store, last, fx.X)
+		ixs = Exprs{fx.Index}
+	case *IndexListExpr:
+		gt = genericOf(store, last, fx.X)
+		ixs = fx.Indices
+	default:
+		gt = genericOf(store, last, fx)
+	}
+	if gt == nil {
+		return nil
+	}
+	fd, ok := gt.Decl.(*FuncDecl)
+	if !ok {
+		panic(fmt.Sprintf("cannot use generic type %s without instantiation", gt.Name))
+	}
+	targs := make([]Type, len(fd.TypeParams))
+	for i, ix := range ixs {
+		targs[i] = evalStaticType(store, last, ix)
+	}
+	inferTypeArgs(store, last, gt, targs, n)
+	gi := instantiate(store, last, gt, targs)
+	return toConstExpr(n.Func, TypedValue{T: gi.Type, V: gi.Func})
+}
+
+// genericKey returns the key of the instance with type arguments targs.
+func genericKey(targs []Type) string {
+	ids := make([]string, len(targs))
+	for i, t := range targs {
+		ids[i] = t.TypeID().String()
+	}
+	return strings.Join(ids, ",")
+}
+
+// instantiate returns the instance of gt with type arguments targs,
+// preprocessing it if it does not yet exist.
+func instantiate(store Store, last BlockNode, gt *genericType, targs []Type) *genericInstance {
+	key := genericKey(targs)
+	if gi, ok := gt.instances[key]; ok {
+		recordGenericUse(last, gi)
+		saveGenericInstance(store, gi)
+		return gi
+	}
+	if gt.instances == nil {
+		gt.instances = make(map[string]*genericInstance)
+	}
+	name := Name(fmt.Sprintf("%s[%s]", gt.Name, key))
+	gi := &genericInstance{Args: targs}
+	switch d := gt.Decl.(type) {
+	case *TypeDecl:
+		// register the declared type before its definition,
+		// for recursive references.
+		dt := &DeclaredType{PkgPath: gt.PkgPath, Name: name}
+		gi.Type = dt
+		gt.instances[key] = gi
+		recordGenericUse(last, gi)
+		// define the type parameters in a block of their own.
+		bs := new(BlockStmt)
+		bs.SetSpan(d.GetSpan())
+		bs.InitStaticBlock(bs, gt.File)
+		bs.SetAttribute(ATTR_GENERIC_INSTANCE, gi)
+		defineTypeArgs(store, bs, gt, d.TypeParams, targs)
+		tx := copyGeneric(d.Type).(Expr)
+		predefineGenericDeps(store, bs, gt, tx)
+		tx = Preprocess(store, bs, tx).(Expr)
+		t := evalStaticType(store, bs, tx)
+		dt.Base = baseOf(t)
+		dt.Seal()
+		instantiateMethods(store, gt, gi, dt)
+	case *FuncDecl:
+		fd := copyGeneric(d).(*FuncDecl)
+		fd.Name = name
+		fd.TypeParams = nil
+		gi.Decls = []*FuncDecl{fd}
+		gt.instances[key] = gi
+		recordGenericUse(last, gi)
+		initGenericFuncDecl(store, gt.File, gi, fd, key)
+		defineTypeArgs(store, fd, gt, d.TypeParams, targs)
+		predefineGenericDeps(store, fd, gt, &fd.Type)
+		fd.Type = *Preprocess(store, fd, &fd.Type).(*FuncTypeExpr)
+		ft := evalStaticType(store, fd, &fd.Type).(*FuncType)
+		gi.Type = ft
+		gi.Func = &FuncValue{
+			Type:     ft,
+			IsMethod: false,
+			Source:   fd,
+			Name:     name,
+			Parent:   nil, // set lazily.
+			FileName: gt.File.FileName,
+			PkgPath:  gt.PkgPath,
+			Crossing: ft.IsCrossing(),
+			body:     fd.Body,
+		}
+		gi.funcs = []*FuncValue{gi.Func}
+	default:
+		panic("should not happen")
+	}
+	gi.pending = true
+	gt.pending = append(gt.pending, gi)
+	if isPredefined(gt.File) {
+		preprocessGenericInstances(store, gt)
+	}
+	return gi
+}
+
+// instantiateMethods declares the generic methods of gt onto its instance
+// dt. Their bodies are preprocessed later with preprocessGenericInstances.
+func instantiateMethods(store Store, gt *genericType, gi *genericInstance, dt *DeclaredType) {
+	pn := packageOf(gt.File)
+	files := []*FileNode{gt.File}
+	if pn.FileSet != nil {
+		files = pn.FileSet.Files
+	}
+	for _, fn := range files {
+		for _, d := range fn.Decls {
+			md, ok := d.(*FuncDecl)
+			if !ok || !md.IsMethod || !md.IsGeneric() {
+				continue
+			}
+			rx := md.Recv.Type
+			sx, ptr := rx.(*StarExpr)
+			if ptr {
+				rx = sx.X
+			}
+			var bx Expr
+			var ixs Exprs
+			switch rx := rx.(type) {
+			case *IndexExpr:
+				bx, ixs = rx.X, Exprs{rx.Index}
+			case *IndexListExpr:
+				bx, ixs = rx.X, rx.Indices
+			}
+			if nx, ok := bx.(*NameExpr); !ok || nx.Name != gt.Name {
+				continue
+			}
+			if len(ixs) != len(gi.Args) {
+				panic(fmt.Sprintf("receiver of method %s must have %d type parameters, got %d",
+					md.Name, len(gi.Args), len(ixs)))
+			}
+			// the receiver type parameters are bound
+			// to the type arguments of the instance.
+			tparams := make(FieldTypeExprs, len(ixs))
+			for i, ix := range ixs {
+				nx, ok := ix.(*NameExpr)
+				if !ok {
+					panic(fmt.Sprintf("receiver type parameter %s must be an identifier", ix))
+				}
+				tparams[i] = FieldTypeExpr{NameExpr: *Nx(nx.Name)}
+			}
+			inst := copyGeneric(md).(*FuncDecl)
+			var rt Type = dt
+			if ptr {
+				rt = &PointerType{Elt: dt}
+			}
+			inst.Recv.Type = toConstTypeExpr(gt.File, md.Recv.Type, rt)
+			gi.Decls = append(gi.Decls, inst)
+			initGenericFuncDecl(store, fn, gi, inst, genericKey(gi.Args))
+			defineTypeArgs(store, inst, nil, tparams, gi.Args)
+			predefineGenericDeps(store, inst, gt, &inst.Type)
+			inst.Recv = *Preprocess(store, inst, &inst.Recv).(*FieldTypeExpr)
+			inst.Type = *Preprocess(store, inst, &inst.Type).(*FuncTypeExpr)
+			rft := evalStaticType(store, inst, &inst.Recv).(FieldType)
+			ft := evalStaticType(store, inst, &inst.Type).(*FuncType)
+			fv := &FuncValue{
+				Type:     ft.UnboundType(rft),
+				IsMethod: true,
+				Source:   inst,
+				Name:     inst.Name,
+				Parent:   nil, // set lazily
+				FileName: fn.FileName,
+				PkgPath:  gt.PkgPath,
+				Crossing: ft.IsCrossing(),
+				body:     inst.Body,
+			}
+			gi.funcs = append(gi.funcs, fv)
+			if !dt.TryDefineMethod(fv) {
+				panic(fmt.Sprintf("redeclaration of method %s.%s",
+					gt.Name, inst.Name))
+			}
+		}
+	}
+}
+
+// initGenericFuncDecl initializes the static block of the instance fd of a
+// generic function or method declared in file fn, with type arguments key.
+func initGenericFuncDecl(store Store, fn *FileNode, gi *genericInstance, fd *FuncDecl, key string) {
+	fd.SetAttribute(ATTR_GENERIC_INSTANCE, gi)
+	fd.SetAttribute(ATTR_PREDEFINED, true)
+	pn := packageOf(fn)
+	// NOTE: the file name of instance block node locations
+	// includes the type arguments, for unique locations.
+	setNodeLines(fd)
+	setNodeLocations(pn.PkgPath, fmt.Sprintf("%s[%s]", fn.FileName, key), fd)
+	initStaticBlocks(store, fn, fd)
+}
+
+// defineTypeArgs defines the type parameters tparams (of gt, if not nil) as
+// constant type names of their type arguments in bn, and checks that the
+// type arguments satisfy the constraints.
+func defineTypeArgs(store Store, bn BlockNode, gt *genericType, tparams FieldTypeExprs, targs []Type) {
+	for i, tp := range tparams {
+		if tp.Name == blankIdentifier {
+			continue
+		}
+		bn.Define2(true, tp.Name, targs[i], asValue(targs[i]), NameSource{})
+	}
+	if gt == nil {
+		return
+	}
+	// constraints may refer to any type parameter.
+	for i, tp := range tparams {
+		cx := copyGeneric(tp.Type).(Expr)
+		cx = Preprocess(store, bn, cx).(Expr)
+		ct := evalStaticType(store, bn, cx)
+		if err := satisfiesConstraint(targs[i], ct); err != nil {
+			panic(fmt.Sprintf("%s does not satisfy %s (%v)",
+				targs[i].String(), constraintString(tp.Type, ct), err))
+		}
+	}
+}
+
+// predefineGenericDeps predefines the declarations of the package of gt
+// that x (a part of an instance declared in bn) depends on, if they are not
+// yet predefined. This happens when a generic type is instantiated while its
+// package is being predefined.
+func predefineGenericDeps(store Store, bn BlockNode, gt *genericType, x Expr) {
+	pn := packageOf(gt.File)
+	if pn.FileSet == nil {
+		return
+	}
+	for {
+		un, _ := findUndefinedT(store, bn, x, nil, map[Name]struct{}{}, false, false)
+		if un == "" {
+			return
+		}
+		file, decl, ok := pn.FileSet.GetDeclForSafe(un)
+		if !ok {
+			return // let the preprocessor fail.
+		}
+		predefineRecursively(store, file, *decl)
+	}
+}
+
+// isPredefined returns true if all declarations of the package of file fn
+// have been predefined, so the bodies of instance functions can be
+// preprocessed.
+func isPredefined(fn *FileNode) bool {
+	files := []*FileNode{fn}
+	if pn := packageOf(fn); pn.FileSet != nil {
+		files = pn.FileSet.Files
+	}
+	for _, fn := range files {
+		for _, d := range fn.Decls {
+			if d.GetAttribute(ATTR_PREDEFINED) != true {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// preprocessGenericInstances preprocesses the bodies of the pending
+// instances of gt, which may in turn instantiate more.
+func preprocessGenericInstances(store Store, gt *genericType) {
+	for len(gt.pending) > 0 {
+		gi := gt.pending[0]
+		gt.pending = gt.pending[1:]
+		if !gi.pending {
+			continue
+		}
+		gi.pending = false
+		for i, fd := range gi.Decls {
+			Preprocess(store, fd.GetParentNode(nil), fd)
+			// the body may have been altered.
+			gi.funcs[i].UpdateBodyFromSource()
+		}
+		saveGenericInstance(store, gi)
+	}
+}
+
+// preprocessPendingGenerics preprocesses the pending instances of all
+// generic declarations of pn, once it is predefined.
+func preprocessPendingGenerics(store Store, pn *PackageNode) {
+	for _, t := range pn.Types {
+		if gt, ok := t.(*genericType); ok && gt.PkgPath == pn.PkgPath {
+			preprocessGenericInstances(store, gt)
+		}
+	}
+}
+
+// saveGenericInstance saves the block nodes of gi to store, if they are not
+// already. Like those of files, they are not persisted but recreated upon
+// restart (see PreprocessAllFilesAndSaveBlockNodes).
+func saveGenericInstance(store Store, gi *genericInstance) {
+	if store == nil || gi.pending || len(gi.Decls) == 0 {
+		return
+	}
+	if store.GetBlockNodeSafe(gi.Decls[0].GetLocation()) != nil {
+		return
+	}
+	for _, fd := range gi.Decls {
+		Transcribe(fd, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+			if stage != TRANS_ENTER {
+				return n, TRANS_CONTINUE
+			}
+			if bn, ok := n.(BlockNode); ok {
+				store.SetBlockNode(bn)
+			}
+			return n, TRANS_CONTINUE
+		})
+	}
+}
+
+// recordGenericUse records the use of gi from last, so that the types of
+// instances are persisted along with the package (or instance) using them.
+func recordGenericUse(last BlockNode, gi *genericInstance) {
+	for bn := last; bn != nil; bn = bn.GetParentNode(nil) {
+		if pgi, ok := bn.GetAttribute(ATTR_GENERIC_INSTANCE).(*genericInstance); ok {
+			if pgi != gi && !slices.Contains(pgi.uses, gi) {
+				pgi.uses = append(pgi.uses, gi)
+			}
+			return
+		}
+		if pn, ok := bn.(*PackageNode); ok {
+			gis, _ := pn.GetAttribute(ATTR_GENERIC_INSTANCES).([]*genericInstance)
+			if !slices.Contains(gis, gi) {
+				pn.SetAttribute(ATTR_GENERIC_INSTANCES, append(gis, gi))
+			}
+			return
+		}
+	}
+}
+
+// genericDeclaredTypes returns the instance types used by pn, and the types
+// declared within the instance functions and methods used by pn.
+func genericDeclaredTypes(pn *PackageNode) (dts []*DeclaredType) {
+	gis, _ := pn.GetAttribute(ATTR_GENERIC_INSTANCES).([]*genericInstance)
+	seen := map[*genericInstance]struct{}{}
+	for len(gis) > 0 {
+		gi := gis[0]
+		gis = gis[1:]
+		if _, ok := seen[gi]; ok {
+			continue
+		}
+		seen[gi] = struct{}{}
+		if dt, ok := gi.Type.(*DeclaredType); ok {
+			dts = append(dts, dt)
+		}
+		for _, fd := range gi.Decls {
+			dts = append(dts, declaredTypesIn(fd.GetParentNode(nil), fd)...)
+		}
+		gis = append(gis, gi.uses...)
+	}
+	return
+}
+
+// copyGeneric returns a copy of n, a part of a generic declaration, with
+// the spans and labels of the original nodes, which Node.Copy() does not
+// copy. Generic declarations are never preprocessed, so they have no other
+// attributes worth copying.
+func copyGeneric(n Node) Node {
+	var orig []Node
+	Transcribe(n, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage == TRANS_ENTER {
+			orig = append(orig, n)
+		}
+		return n, TRANS_CONTINUE
+	})
+	nc := n.Copy()
+	i := 0
+	Transcribe(nc, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage != TRANS_ENTER {
+			return n, TRANS_CONTINUE
+		}
+		o := orig[i]
+		i++
+		n.SetSpan(o.GetSpan())
+		n.SetLabel(o.GetLabel())
+		return n, TRANS_CONTINUE
+	})
+	return nc
+}
+
+// ----------------------------------------
+// Type argument inference
+
+// inferTypeArgs infers the missing (nil) type arguments targs of the
+// generic function gt called by n, from the types of the arguments of n.
+func inferTypeArgs(store Store, last BlockNode, gt *genericType, targs []Type, n *CallExpr) {
+	fd := gt.Decl.(*FuncDecl)
+	if !slices.Contains(targs, nil) {
+		return
+	}
+	inf := &inference{store: store, gt: gt, targs: targs}
+	for _, tp := range fd.TypeParams {
+		inf.names = append(inf.names, tp.Name)
+	}
+	params := fd.Type.Params
+	isVarg := len(params) > 0 && isVargTypeExpr(params[len(params)-1].Type)
+	paramOf := func(i int) Expr {
+		if isVarg && i >= len(params)-1 {
+			if n.Varg {
+				return params[len(params)-1].Type
+			}
+			return params[len(params)-1].Type.(*SliceTypeExpr).Elt
+		}
+		if i < len(params) {
+			return params[i].Type
+		}
+		return nil
+	}
+	// typed arguments first.
+	var untyped []int
+	for i, arg := range n.Args {
+		px := paramOf(i)
+		if px == nil {
+			break
+		}
+		at := evalStaticTypeOf(store, last, arg)
+		if at == nil || isUntyped(at) {
+			untyped = append(untyped, i)
+			continue
+		}
+		if tt, ok := at.(*tupleType); ok {
+			// f(g()) where g returns multiple values.
+			for j, et := range tt.Elts {
+				if px := paramOf(j); px != nil {
+					inf.unify(px, et)
+				}
+			}
+			break
+		}
+		inf.unify(px, at)
+	}
+	// then core types of constraints.
+	inf.unifyCoreTypes(fd)
+	// then untyped constants take their default type.
+	for _, i := range untyped {
+		at := evalStaticTypeOf(store, last, n.Args[i])
+		if at == nil {
+			continue // nil
+		}
+		if nx, ok := paramOf(i).(*NameExpr); ok {
+			if j := slices.Index(inf.names, nx.Name); j >= 0 {
+				if bt := inf.targs[j]; bt == nil || (isUntyped(bt) && untypedRank(at) > untypedRank(bt)) {
+					inf.targs[j] = at
+				}
+			}
+		}
+	}
+	for i, t := range inf.targs {
+		if t != nil && isUntyped(t) {
+			inf.targs[i] = defaultTypeOf(t)
+		}
+	}
+	inf.unifyCoreTypes(fd)
+	for i, t := range inf.targs {
+		if t == nil {
+			panic(fmt.Sprintf("in call to %s, cannot infer %s", gt.Name, inf.names[i]))
+		}
+	}
+}
+
+func isVargTypeExpr(x Expr) bool {
+	stx, ok := x.(*SliceTypeExpr)
+	return ok && stx.Vrd
+}
+
+func untypedRank(t Type) int {
+	switch t {
+	case UntypedBigintType:
+		return 1
+	case UntypedRuneType:
+		return 2
+	case UntypedBigdecType:
+		return 3
+	default:
+		return 0
+	}
+}
+
+type inference struct {
+	store Store
+	gt    *genericType
+	names []Name // type parameter names
+	targs []Type // type arguments, nil if not yet inferred
+}
+
+// unify infers type arguments by matching the (template) parameter type
+// expression x with type t.
+func (inf *inference) unify(x Expr, t Type) {
+	switch x := x.(type) {
+	case *NameExpr:
+		i := slices.Index(inf.names, x.Name)
+		if i < 0 {
+			return
+		}
+		if bt := inf.targs[i]; bt == nil {
+			inf.targs[i] = t
+		} else if bt.TypeID() != t.TypeID() {
+			panic(fmt.Sprintf("in call to %s, type %s does not match inferred type %s for %s",
+				inf.gt.Name, t.String(), bt.String(), x.Name))
+		}
+	case *StarExpr:
+		if pt, ok := baseOf(t).(*PointerType); ok {
+			inf.unify(x.X, pt.Elt)
+		}
+	case *SliceTypeExpr:
+		if st, ok := baseOf(t).(*SliceType); ok {
+			inf.unify(x.Elt, st.Elt)
+		}
+	case *ArrayTypeExpr:
+		if at, ok := baseOf(t).(*ArrayType); ok {
+			inf.unify(x.Elt, at.Elt)
+		}
+	case *MapTypeExpr:
+		if mt, ok := baseOf(t).(*MapType); ok {
+			inf.unify(x.Key, mt.Key)
+			inf.unify(x.Value, mt.Value)
+		}
+	case *ChanTypeExpr:
+		if ct, ok := baseOf(t).(*ChanType); ok {
+			inf.unify(x.Value, ct.Elt)
+		}
+	case *FuncTypeExpr:
+		if ft, ok := baseOf(t).(*FuncType); ok &&
+			len(ft.Params) == len(x.Params) && len(ft.Results) == len(x.Results) {
+			for i := range x.Params {
+				inf.unify(x.Params[i].Type, ft.Params[i].Type)
+			}
+			for i := range x.Results {
+				inf.unify(x.Results[i].Type, ft.Results[i].Type)
+			}
+		}
+	case *IndexExpr:
+		inf.unifyInstance(x.X, Exprs{x.Index}, t)
+	case *IndexListExpr:
+		inf.unifyInstance(x.X, x.Indices, t)
+	}
+}
+
+// unifyInstance matches the instance expression gx[ixs...] with t, if t
+// is an instance of the generic type gx refers to.
+func (inf *inference) unifyInstance(gx Expr, ixs Exprs, t Type) {
+	var gt2 *genericType
+	switch gx := gx.(type) {
+	case *NameExpr:
+		gt2, _ = inf.gt.File.GetStaticTypeOf(inf.store, gx.Name).(*genericType)
+	case *SelectorExpr:
+		px, ok := gx.X.(*NameExpr)
+		if !ok {
+			return
+		}
+		tv := inf.gt.File.GetSlot(inf.store, px.Name, true)
+		if tv == nil {
+			return
+		}
+		pv, ok := tv.V.(*PackageValue)
+		if !ok {
+			return
+		}
+		pn := pv.GetPackageNode(inf.store)
+		if idx, ok := pn.GetLocalIndex(gx.Sel); ok {
+			gt2, _ = pn.Types[idx].(*genericType)
+		}
+	}
+	if gt2 == nil {
+		return
+	}
+	for _, gi := range gt2.instances {
+		if gi.Type == t && len(gi.Args) == len(ixs) {
+			for i, ix := range ixs {
+				inf.unify(ix, gi.Args[i])
+			}
+			return
+		}
+	}
+}
+
+// unifyCoreTypes infers type arguments from the core type of the
+// constraints of inferred type parameters, e.g. E from S with constraint
+// ~[]E.
+func (inf *inference) unifyCoreTypes(fd *FuncDecl) {
+	for progress := true; progress; {
+		progress = false
+		for i, tp := range fd.TypeParams {
+			t := inf.targs[i]
+			if t == nil || isUntyped(t) {
+				continue
+			}
+			ux, ok := tp.Type.(*UnionTypeExpr)
+			if !ok || len(ux.Terms) != 1 {
+				continue
+			}
+			before := slices.Clone(inf.targs)
+			if ux.Tilde[0] {
+				inf.unify(ux.Terms[0], baseOf(t))
+			} else {
+				inf.unify(ux.Terms[0], t)
+			}
+			for j := range before {
+				if before[j] == nil && inf.targs[j] != nil {
+					progress = true
+				}
+			}
+		}
+	}
+}
+
+// ----------------------------------------
+// Constraints
+
+// evalConstraintType returns the interface type of a constraint interface
+// type expression x, whose elements may be type sets (unions, or embedded
+// non-interface types) besides methods and embedded interfaces. The
+// elements are expected to be preprocessed.
+func evalConstraintType(store Store, last BlockNode, x *InterfaceTypeExpr) *InterfaceType {
+	it := &InterfaceType{PkgPath: packageOf(last).PkgPath}
+	var terms []TypeTerm
+	hasTerms := false
+	intersect := func(ts []TypeTerm) {
+		if !hasTerms {
+			terms, hasTerms = ts, true
+			return
+		}
+		var res []TypeTerm
+		for _, t1 := range terms {
+			for _, t2 := range ts {
+				if t1.Type.TypeID() == t2.Type.TypeID() {
+					res = append(res, TypeTerm{Tilde: t1.Tilde && t2.Tilde, Type: t1.Type})
+				} else if t1.Tilde && baseOf(t2.Type).TypeID() == t1.Type.TypeID() {
+					res = append(res, t2)
+				} else if t2.Tilde && baseOf(t1.Type).TypeID() == t2.Type.TypeID() {
+					res = append(res, t1)
+				}
+			}
+		}
+		terms = res
+	}
+	for i := range x.Methods {
+		ftx := &x.Methods[i]
+		t := evalStaticType(store, last, ftx.Type)
+		if ftx.Name != "" {
+			it.Methods = append(it.Methods, FieldType{Name: ftx.Name, Type: t})
+			continue
+		}
+		if eit, ok := baseOf(t).(*InterfaceType); ok {
+			if len(eit.Terms) > 0 {
+				intersect(eit.Terms)
+				it.Methods = append(it.Methods, eit.Methods...)
+			} else {
+				ft := FieldType{Type: t}
+				fillEmbeddedName(&ft)
+				it.Methods = append(it.Methods, ft)
+			}
+		} else {
+			intersect([]TypeTerm{{Type: t}})
+		}
+	}
+	if hasTerms {
+		if len(terms) == 0 {
+			panic(fmt.Sprintf("empty type set for constraint %s", x.String()))
+		}
+		it.Terms = terms
+	}
+	return it
+}
+
+// evalUnionType returns the interface type of the type set x.
+func evalUnionType(store Store, last BlockNode, x *UnionTypeExpr) *InterfaceType {
+	it := &InterfaceType{PkgPath: packageOf(last).PkgPath}
+	for i, tx := range x.Terms {
+		t := evalStaticType(store, last, tx)
+		if x.Tilde[i] && baseOf(t) != t {
+			panic(fmt.Sprintf("invalid use of ~ (underlying type of %s is %s)",
+				t.String(), baseOf(t).String()))
+		}
+		if _, ok := baseOf(t).(*InterfaceType); ok {
+			panic(fmt.Sprintf("cannot use %s in union", t.String()))
+		}
+		it.Terms = append(it.Terms, TypeTerm{Tilde: x.Tilde[i], Type: t})
+	}
+	return it
+}
+
+// isConstraintTypeExpr returns true if x is a constraint interface with
+// type set elements.
+func isConstraintTypeExpr(store Store, last BlockNode, x *InterfaceTypeExpr) bool {
+	for _, ftx := range x.Methods {
+		if ftx.Name != "" {
+			continue
+		}
+		t := evalStaticType(store, last, ftx.Type)
+		switch bt := baseOf(t).(type) {
+		case nil:
+			// not yet defined.
+		case *InterfaceType:
+			if len(bt.Terms) > 0 {
+				return true
+			}
+		default:
+			return true
+		}
+	}
+	return false
+}
+
+// assertNotConstraint panics if x is a (preprocessed) constraint type
+// expression, which may only be used for type parameters.
+func assertNotConstraint(x Expr) {
+	var t Type
+	switch x := x.(type) {
+	case *constTypeExpr:
+		t = x.Type
+	case *ConstExpr:
+		if tv, ok := x.V.(TypeValue); ok {
+			t = tv.Type
+		}
+	}
+	if t == nil {
+		return
+	}
+	if t == gComparableType {
+		panic("cannot use type comparable outside a type constraint")
+	}
+	if it, ok := baseOf(t).(*InterfaceType); ok &&
+		(len(it.Terms) > 0 || requiresComparable(it)) {
+		panic(fmt.Sprintf("cannot use type %s outside a type constraint: interface contains type constraints",
+			t.String()))
+	}
+}
+
+// constraintString returns the constraint x of type ct, for error
+// messages.
+func constraintString(x Expr, ct Type) string {
+	switch x := x.(type) {
+	case *NameExpr:
+		return string(x.Name)
+	case *SelectorExpr:
+		if px, ok := x.X.(*NameExpr); ok {
+			return fmt.Sprintf("%s.%s", px.Name, x.Sel)
+		}
+	case *UnionTypeExpr:
+		if it, ok := ct.(*InterfaceType); ok {
+			ts := make([]string, len(it.Terms))
+			for i, term := range it.Terms {
+				ts[i] = term.String()
+			}
+			return strings.Join(ts, " | ")
+		}
+	}
+	return ct.String()
+}
+
+// satisfiesConstraint returns an error if t does not satisfy the constraint
+// type ct.
+func satisfiesConstraint(t Type, ct Type) error {
+	it, ok := baseOf(ct).(*InterfaceType)
+	if !ok {
+		return fmt.Errorf("%s is not an interface", ct.String())
+	}
+	if len(it.Terms) > 0 {
+		found := false
+		for _, term := range it.Terms {
+			if term.Tilde && baseOf(t).TypeID() == term.Type.TypeID() ||
+				t.TypeID() == term.Type.TypeID() {
+				found = true
+				break
+			}
+		}
+		if !found {
+			ts := make([]string, len(it.Terms))
+			for i, term := range it.Terms {
+				ts[i] = term.String()
+			}
+			return fmt.Errorf("%s missing in %s", t.String(), strings.Join(ts, " | "))
+		}
+	}
+	if requiresComparable(ct) && !isStrictlyComparable(t) {
+		return fmt.Errorf("%s is not comparable", t.String())
+	}
+	return it.VerifyImplementedBy(t)
+}
+
+// requiresComparable returns true if ct is or embeds comparable.
+func requiresComparable(ct Type) bool {
+	if ct == gComparableType {
+		return true
+	}
+	if it, ok := baseOf(ct).(*InterfaceType); ok {
+		for _, m := range it.Methods {
+			if m.Name == "comparable" && requiresComparable(m.Type) {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// isStrictlyComparable returns true if values of type t can be compared
+// with == without panicking.
+func isStrictlyComparable(t Type) bool {
+	switch bt := baseOf(t).(type) {
+	case nil:
+		return true // not yet defined.
+	case PrimitiveType, *PointerType, *ChanType:
+		return true
+	case *ArrayType:
+		return isStrictlyComparable(bt.Elt)
+	case *StructType:
+		for _, f := range bt.Fields {
+			if !isStrictlyComparable(f.Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return false
+	}
+}
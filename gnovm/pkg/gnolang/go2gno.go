@@ -357,7 +357,7 @@ func Go2Gno(fs *token.FileSet, gon ast.Node) (n Node) {
 		}
 	case *ast.InterfaceType:
 		return &InterfaceTypeExpr{
-			Methods: toFieldsFromList(fs, gon.Methods),
+			Methods: toInterfaceElems(fs, gon.Methods),
 		}
 	case *ast.ChanType:
 		var dir ChanDir
@@ -521,11 +521,12 @@ func Go2Gno(fs *token.FileSet, gon ast.Node) (n Node) {
 			body = Go2Gno(fs, gon.Body).(*BlockStmt).Body
 		}
 		return &FuncDecl{
-			IsMethod: isMethod,
-			Recv:     recv,
-			NameExpr: NameExpr{Name: name},
-			Type:     *type_,
-			Body:     body,
+			IsMethod:   isMethod,
+			Recv:       recv,
+			TypeParams: toTypeParams(fs, gon.Type.TypeParams),
+			NameExpr:   NameExpr{Name: name},
+			Type:       *type_,
+			Body:       body,
 		}
 	case *ast.GenDecl:
 		panicWithPos("unexpected *ast.GenDecl; use toDecls(fs,) instead")
@@ -547,12 +548,24 @@ func Go2Gno(fs *token.FileSet, gon ast.Node) (n Node) {
 	case *ast.EmptyStmt:
 		return &EmptyStmt{}
 	case *ast.IndexListExpr:
-		if len(gon.Indices) > 1 {
-			panicWithPos("invalid operation: more than one index")
+		return &IndexListExpr{
+			X:       toExpr(fs, gon.X),
+			Indices: toExprs(fs, gon.Indices),
 		}
-		panicWithPos("invalid operation: indexList is not permitted in Gno")
 	case *ast.GoStmt:
-		panicWithPos("goroutines are not permitted")
+		cx := toExpr(fs, gon.Call).(*CallExpr)
+		return &GoStmt{
+			Call: *cx,
+		}
+	case *ast.SendStmt:
+		return &SendStmt{
+			Chan:  toExpr(fs, gon.Chan),
+			Value: toExpr(fs, gon.Value),
+		}
+	case *ast.SelectStmt:
+		return &SelectStmt{
+			Cases: toSelectCases(fs, gon.Body.List),
+		}
 	default:
 		panicWithPos("unknown Go type %v: %s\n",
 			reflect.TypeOf(gon),
@@ -725,9 +738,10 @@ func toDecls(fs *token.FileSet, gd *ast.GenDecl) (ds Decls) {
 			tipe := toExpr(fs, s.Type)
 			alias := s.Assign != 0
 			td := &TypeDecl{
-				NameExpr: NameExpr{Name: name},
-				Type:     tipe,
-				IsAlias:  alias,
+				NameExpr:   NameExpr{Name: name},
+				TypeParams: toTypeParams(fs, s.TypeParams),
+				Type:       tipe,
+				IsAlias:    alias,
 			}
 			setSpan(fs, s, td)
 			ds = append(ds, td)
@@ -823,6 +837,83 @@ func toFieldsFromList(fs *token.FileSet, fl *ast.FieldList) (ftxs []FieldTypeExp
 	}
 }
 
+// toTypeParams converts a type parameter list, where each field type is a
+// constraint that may be a union of (possibly ~) terms.
+func toTypeParams(fs *token.FileSet, fl *ast.FieldList) (ftxs []FieldTypeExpr) {
+	if fl == nil || len(fl.List) == 0 {
+		return nil
+	}
+	for _, f := range fl.List {
+		for _, n := range f.Names {
+			ftx := FieldTypeExpr{
+				NameExpr: *Nx(toName(n)),
+				Type:     toConstraint(fs, f.Type),
+			}
+			setSpan(fs, f, &ftx)
+			ftxs = append(ftxs, ftx)
+		}
+	}
+	return
+}
+
+// toInterfaceElems converts the methods and embedded elements of an
+// interface type. Embedded unions and ~ terms become UnionTypeExprs.
+func toInterfaceElems(fs *token.FileSet, fl *ast.FieldList) (ftxs []FieldTypeExpr) {
+	ftxs = toFieldsFromList(fs, fl)
+	for i := range ftxs {
+		if ftxs[i].Name == "" {
+			ftxs[i].Type = toConstraint(fs, fl.List[i].Type)
+		}
+	}
+	return
+}
+
+// toConstraint converts a type constraint. Unions (A | B) and
+// underlying-type terms (~A) are collected into a UnionTypeExpr; any other
+// expression is converted as usual.
+func toConstraint(fs *token.FileSet, gox ast.Expr) Expr {
+	switch gx := gox.(type) {
+	case *ast.BinaryExpr:
+		if gx.Op != token.OR {
+			return toExpr(fs, gox)
+		}
+	case *ast.UnaryExpr:
+		if gx.Op != token.TILDE {
+			return toExpr(fs, gox)
+		}
+	case *ast.ParenExpr:
+		return toConstraint(fs, gx.X)
+	default:
+		return toExpr(fs, gox)
+	}
+	utx := &UnionTypeExpr{}
+	var collect func(ast.Expr)
+	collect = func(x ast.Expr) {
+		switch x := x.(type) {
+		case *ast.BinaryExpr:
+			if x.Op == token.OR {
+				collect(x.X)
+				collect(x.Y)
+				return
+			}
+		case *ast.UnaryExpr:
+			if x.Op == token.TILDE {
+				utx.Terms = append(utx.Terms, toExpr(fs, x.X))
+				utx.Tilde = append(utx.Tilde, true)
+				return
+			}
+		case *ast.ParenExpr:
+			collect(x.X)
+			return
+		}
+		utx.Terms = append(utx.Terms, toExpr(fs, x))
+		utx.Tilde = append(utx.Tilde, false)
+	}
+	collect(gox)
+	setSpan(fs, gox, utx)
+	return utx
+}
+
 func toFields(fs *token.FileSet, fields ...*ast.Field) (ftxs []FieldTypeExpr) {
 	if len(fields) == 0 {
 		return nil
@@ -871,30 +962,67 @@ func toKeyValueExprs(fs *token.FileSet, elts []ast.Expr) (kvxs KeyValueExprs) {
 	return
 }
 
-// NOTE: moves the default clause to last.
+// NOTE: clauses are kept in source order, so that a fallthrough
+// goes to the clause that follows it in the source, even when the
+// default clause is not the last one.
 func toClauses(fs *token.FileSet, csz []ast.Stmt) []SwitchClauseStmt {
 	res := make([]SwitchClauseStmt, 0, len(csz))
-	var dclause *SwitchClauseStmt
+	hasDefault := false
 	for _, cs := range csz {
 		clause := toSwitchClauseStmt(fs, cs.(*ast.CaseClause))
 		if len(clause.Cases) == 0 {
-			if dclause != nil {
+			if hasDefault {
 				panic("duplicate default clause")
 			}
-			dclause = &clause
-		} else {
-			res = append(res, clause)
+			hasDefault = true
 		}
+		res = append(res, clause)
 	}
-	if dclause != nil {
-		res = append(res, *dclause)
-	}
-	if len(res) != len(csz) {
-		panic("should not happen")
+	return res
+}
+
+func toSelectCases(fs *token.FileSet, csz []ast.Stmt) []SelectCaseStmt {
+	res := make([]SelectCaseStmt, 0, len(csz))
+	hasDefault := false
+	for _, cs := range csz {
+		cc := cs.(*ast.CommClause)
+		if cc.Comm == nil {
+			if hasDefault {
+				panic("multiple defaults in select")
+			}
+			hasDefault = true
+		}
+		scs := SelectCaseStmt{
+			Comm: toSimp(fs, cc.Comm),
+			Body: toStmts(fs, cc.Body),
+		}
+		if as, ok := scs.Comm.(*AssignStmt); ok && as.Op == ASSIGN {
+			// `case x, ok = <-ch:` becomes `case .recv_0, .recv_1 :=
+			// <-ch: x, ok = .recv_0, .recv_1`, so that only the
+			// case block is assigned to when a case is selected.
+			scs.Comm, scs.Body = toSelectRecvDefine(fs, cc.Comm, as, scs.Body)
+		}
+		setSpan(fs, cc, &scs)
+		res = append(res, scs)
 	}
 	return res
 }
 
+func toSelectRecvDefine(fs *token.FileSet, gos ast.Stmt, as *AssignStmt, body Body) (Stmt, Body) {
+	lhs := make(Exprs, len(as.Lhs))
+	rhs := make(Exprs, len(as.Lhs))
+	for i := range as.Lhs {
+		name := fmt.Sprintf(".recv_%d", i)
+		lhs[i] = setSpan(fs, gos, Nx(name)).(Expr)
+		rhs[i] = setSpan(fs, gos, Nx(name)).(Expr)
+	}
+	define := &AssignStmt{Lhs: lhs, Op: DEFINE, Rhs: as.Rhs}
+	setSpan(fs, gos, define)
+	assign := &AssignStmt{Lhs: as.Lhs, Op: ASSIGN, Rhs: rhs}
+	setSpan(fs, gos, assign)
+	return define, append(Body{assign}, body...)
+}
+
 func toSwitchClauseStmt(fs *token.FileSet, cc *ast.CaseClause) SwitchClauseStmt {
 	scs := SwitchClauseStmt{
 		Cases: toExprs(fs, cc.List),
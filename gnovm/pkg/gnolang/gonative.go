@@ -208,6 +208,17 @@ func gno2GoType(t Type) reflect.Type {
 	}
 }
 
+// Gno2GoType returns the Go type of the values Gno2GoValue converts values of
+// type t to. Unlike gno2GoType, it returns an error if t has no Go equivalent.
+func Gno2GoType(t Type) (rt reflect.Type, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("type %v has no Go equivalent: %v", t, r)
+		}
+	}()
+	return gno2GoType(t), nil
+}
+
 // rv must be addressable, or zero (invalid) (say if tv is referred to from a
 // gno.PointerValue). In the latter case, an addressable one will be
 // constructed and returned, otherwise returns rv.  if tv is undefined, rv must
@@ -0,0 +1,232 @@
+package gnolang
+
+import (
+	"fmt"
+	"slices"
+)
+
+// Goroutines are run by a deterministic cooperative scheduler: a single
+// goroutine runs at a time, until it blocks on a channel operation, exits, or
+// has run for goroutineTimeSlice ops, and then the next runnable goroutine is
+// resumed, in round-robin order. The scheduling only depends on the ops run,
+// so a program always runs the same way.
+//
+// The goroutines are scheduled within the outermost m.Run(): the goroutines
+// still running when it returns are discarded, like when main returns in Go.
+// Likewise, the goroutines started within a call crossing into a realm are
+// discarded when the call returns, before the realm is finalized, so that
+// they can't write its state afterwards.
+
+const (
+	// maxGoroutines is the maximum number of goroutines of a Machine,
+	// including the main one.
+	maxGoroutines = 1000
+	// goroutineTimeSlice is the number of ops run by a goroutine before
+	// yielding to the next runnable one.
+	goroutineTimeSlice = 1000
+)
+
+// goroutine holds the state of a goroutine while another one is running.
+type goroutine struct {
+	id         int
+	ops        []Op
+	values     []TypedValue
+	exprs      []Expr
+	stmts      []Stmt
+	blocks     []*Block
+	frames     []Frame
+	pkg        *PackageValue
+	realm      *Realm
+	exception  *Exception
+	numResults int
+
+	parent *goroutine // goroutine which started it
+	depth  int        // number of frames of the parent when started
+
+	wait *chanWait // if blocked on channel operations
+}
+
+func (g *goroutine) isRunnable() bool {
+	return g.wait == nil || g.wait.done
+}
+
+// scheduler holds the goroutines of a Machine, once it has started any.
+type scheduler struct {
+	gs     []*goroutine // gs[0] is the main goroutine
+	cur    int          // index of the running goroutine
+	nextID int
+	ticks  int  // ops run by the running goroutine since resumed
+	yield  bool // the running goroutine blocked, or exited
+}
+
+func (m *Machine) curGoroutine() *goroutine {
+	if m.sched == nil {
+		return nil
+	}
+	return m.sched.gs[m.sched.cur]
+}
+
+// spawnGoroutine starts a goroutine calling the func of the values, with the
+// rest of the values as arguments, as in cx.
+func (m *Machine) spawnGoroutine(cx *CallExpr, values []TypedValue) {
+	if m.sched == nil {
+		m.sched = &scheduler{
+			gs:     []*goroutine{{id: 1}},
+			nextID: 2,
+		}
+	}
+	if len(m.sched.gs) >= maxGoroutines {
+		m.Panic(typedString(fmt.Sprintf("too many goroutines (max %d)", maxGoroutines)))
+	}
+	g := &goroutine{
+		id: m.sched.nextID,
+		// Once the call returns, its results are popped, and the
+		// goroutine exits on OpHalt.
+		ops:    []Op{OpHalt, OpPopResults, OpPrecall},
+		values: values,
+		exprs:  []Expr{cx},
+		pkg:    m.Package,
+		realm:  m.Realm,
+		parent: m.curGoroutine(),
+		depth:  len(m.Frames),
+	}
+	m.sched.nextID++
+	m.sched.gs = append(m.sched.gs, g)
+}
+
+// blockGoroutine blocks the running goroutine with w, until it is done. A
+// nil w blocks it forever.
+func (m *Machine) blockGoroutine(w *chanWait) {
+	if m.runDepth > 1 {
+		panic("channel operation blocked during a nested execution")
+	}
+	if m.sched == nil {
+		panic("all goroutines are asleep - deadlock!")
+	}
+	if w == nil {
+		w = &chanWait{}
+	}
+	g := m.curGoroutine()
+	w.g = g
+	g.wait = w
+	m.sched.yield = true
+}
+
+// takeChanWait returns the wait of the running goroutine, if it just resumed
+// after blocking on channel operations.
+func (m *Machine) takeChanWait() *chanWait {
+	g := m.curGoroutine()
+	if g == nil || g.wait == nil {
+		return nil
+	}
+	w := g.wait
+	g.wait = nil
+	return w
+}
+
+// schedule is called after each op when goroutines were started, to resume
+// the next runnable goroutine if the running one yields.
+func (m *Machine) schedule() {
+	s := m.sched
+	s.ticks++
+	if !s.yield && s.ticks < goroutineTimeSlice {
+		return
+	}
+	s.yield = false
+	s.ticks = 0
+	n := len(s.gs)
+	for i := 1; i <= n; i++ {
+		next := (s.cur + i) % n
+		if s.gs[next].isRunnable() {
+			if next != s.cur {
+				m.switchGoroutine(next)
+			}
+			return
+		}
+	}
+	panic("all goroutines are asleep - deadlock!")
+}
+
+// exitGoroutine is called when a goroutine other than the main one returns,
+// to resume the next runnable goroutine.
+func (m *Machine) exitGoroutine() {
+	s := m.sched
+	s.gs = append(s.gs[:s.cur], s.gs[s.cur+1:]...)
+	s.cur--
+	// Resume the goroutine after the one that exited.
+	s.yield = true
+	s.ticks = 0
+	n := len(s.gs)
+	for i := 1; i <= n; i++ {
+		next := (s.cur + i) % n
+		if s.gs[next].isRunnable() {
+			m.loadGoroutine(next)
+			s.yield = false
+			return
+		}
+	}
+	panic("all goroutines are asleep - deadlock!")
+}
+
+// stopRealmGoroutines discards the goroutines started by the running one
+// within its call frame at index fi, and the ones they started, when the
+// call returns across a realm boundary.
+func (m *Machine) stopRealmGoroutines(fi int) {
+	s := m.sched
+	if s == nil {
+		return
+	}
+	cur := s.gs[s.cur]
+	var stopped []*goroutine
+	gs := s.gs[:0]
+	for _, g := range s.gs {
+		// Goroutines are started after their parent.
+		if (g.parent == cur && g.depth > fi) || slices.Contains(stopped, g.parent) {
+			if g.wait != nil {
+				// Skipped in the queues of the channels.
+				g.wait.done = true
+			}
+			stopped = append(stopped, g)
+			continue
+		}
+		if g == cur {
+			s.cur = len(gs)
+		}
+		gs = append(gs, g)
+	}
+	clear(s.gs[len(gs):])
+	s.gs = gs
+}
+
+// switchGoroutine saves the state of the running goroutine, and resumes the
+// goroutine at index i.
+func (m *Machine) switchGoroutine(i int) {
+	g := m.curGoroutine()
+	g.ops, g.values, g.exprs, g.stmts = m.Ops, m.Values, m.Exprs, m.Stmts
+	g.blocks, g.frames = m.Blocks, m.Frames
+	g.pkg, g.realm, g.exception, g.numResults = m.Package, m.Realm, m.Exception, m.NumResults
+	m.loadGoroutine(i)
+}
+
+func (m *Machine) loadGoroutine(i int) {
+	m.sched.cur = i
+	g := m.sched.gs[i]
+	m.Ops, m.Values, m.Exprs, m.Stmts = g.ops, g.values, g.exprs, g.stmts
+	m.Blocks, m.Frames = g.blocks, g.frames
+	m.Package, m.Realm, m.Exception, m.NumResults = g.pkg, g.realm, g.exception, g.numResults
+	g.ops, g.values, g.exprs, g.stmts, g.blocks, g.frames = nil, nil, nil, nil, nil, nil
+	g.pkg, g.realm, g.exception = nil, nil, nil
+}
+
+// stopGoroutines discards the goroutines other than the main one, and
+// resumes it, once the outermost m.Run() returns.
+func (m *Machine) stopGoroutines() {
+	if m.sched == nil {
+		return
+	}
+	if m.sched.cur != 0 {
+		// A goroutine panicked: restore the state of the main one.
+		m.loadGoroutine(0)
+	}
+	m.sched = nil
+}
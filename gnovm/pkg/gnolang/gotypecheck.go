@@ -52,6 +52,7 @@ type Realm = realm
 type address string
 func (a address) String() string { return string(a) }
 func (a address) IsValid() bool { return false } // shim
+func (a address) Bytes() []byte { return nil } // shim
 type Address = address
 
 type gnocoins []gnocoin
@@ -174,6 +175,33 @@ type TypeCheckOptions struct {
 func TypeCheckMemPackage(mpkg *std.MemPackage, opts TypeCheckOptions) (
 	pkg *types.Package, errs error,
 ) {
+	gimp := newGnoImporter(mpkg, opts)
+	pkg, errs = gimp.typeCheckMemPackage(mpkg, nil)
+	return
+}
+
+// TypeCheckMemPackageInfo is like [TypeCheckMemPackage], but also fills info
+// with the type information of the production files of mpkg, and returns
+// their Go syntax trees with the file set they were parsed in, for static
+// analysis. The builtins injected for type checking are not included.
+//
+// files is nil if mpkg could not be parsed.
+func TypeCheckMemPackageInfo(mpkg *std.MemPackage, opts TypeCheckOptions, info *types.Info) (
+	fset *token.FileSet, files []*ast.File, errs error,
+) {
+	gimp := newGnoImporter(mpkg, opts)
+	gimp.info = info
+	_, errs = gimp.typeCheckMemPackage(mpkg, nil)
+	for _, gof := range gimp.files {
+		if strings.HasSuffix(gimp.fset.Position(gof.Pos()).Filename, ".gnobuiltins.gno") {
+			continue
+		}
+		files = append(files, gof)
+	}
+	return gimp.fset, files, errs
+}
+
+func newGnoImporter(mpkg *std.MemPackage, opts TypeCheckOptions) *gnoImporter {
 	var gimp *gnoImporter
 	gimp = &gnoImporter{
 		pkgPath:   mpkg.Path,
@@ -191,9 +219,7 @@ func TypeCheckMemPackage(mpkg *std.MemPackage, opts TypeCheckOptions) (
 		errors: nil,
 	}
 	gimp.cfg.Importer = gimp
-
-	pkg, errs = gimp.typeCheckMemPackage(mpkg, nil)
-	return
+	return gimp
 }
 
 type gnoImporterResult struct {
@@ -218,6 +244,13 @@ type gnoImporter struct {
 	cfg       *types.Config
 	errors    []error  // there may be many for a single import
 	stack     []string // stack of pkgpaths for cyclic import detection
+
+	// If info is set, it is filled with the type information of the
+	// production files of the package being type checked, which are
+	// retained in files; see AnalyzePackage.
+	info  *types.Info
+	fset  *token.FileSet
+	files []*ast.File
 }
 
 // Unused, but satisfies the Importer interface.
@@ -477,7 +510,12 @@ func (gimp *gnoImporter) typeCheckMemPackage(mpkg *std.MemPackage, wtests *bool)
 	// Preserve gimp.testing, sub-imports are under the same context.
 	// gimp.testing = false <-- incorrect!
 	pgofs := filterTests(gofset, gofs) // prod gofs.
-	pkg, _ = gimp.cfg.Check(mpkg.Path, gofset, pgofs, nil)
+	var info *types.Info
+	if wtests == nil { // not an import.
+		info = gimp.info
+		gimp.fset, gimp.files = gofset, pgofs
+	}
+	pkg, _ = gimp.cfg.Check(mpkg.Path, gofset, pgofs, info)
 	// Fail early: there's no point checking the others.
 	if len(gimp.errors) != numErrs {
 		errs = multierr.Combine(gimp.errors[numErrs:]...)
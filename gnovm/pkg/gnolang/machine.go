@@ -39,13 +39,19 @@ type Machine struct {
 	Stage         Stage         // pre for static eval, add for package init, run otherwise
 	ReviveEnabled bool          // true if revive() enabled (only in testing mode for now)
 
+	sched    *scheduler // goroutines, once any was started
+	runDepth int        // number of nested m.Run() calls
+
 	Debugger Debugger
+	Tracer   Tracer    // if set, notified of the calls made
+	Coverage *Coverage // if set, counts the statements executed
 
 	// Configuration
 	Output   io.Writer
 	Store    Store
 	Context  any
 	GasMeter store.GasMeter
+	GasTable *GasTable
 }
 
 // NewMachine initializes a new gno virtual machine, acting as a shorthand
@@ -77,8 +83,26 @@ type MachineOptions struct {
 	Alloc         *Allocator // or see MaxAllocBytes.
 	MaxAllocBytes int64      // or 0 for no limit.
 	GasMeter      store.GasMeter
+	GasTable      *GasTable // default Store.GetGasTable()
 	ReviveEnabled bool
-	SkipPackage   bool // don't get/set package or realm.
+	SkipPackage   bool      // don't get/set package or realm.
+	Tracer        Tracer    // notified of the calls made, if set.
+	Coverage      *Coverage // counts the statements executed, if set.
+}
+
+// Tracer is notified of the function calls made by a Machine, to trace its
+// execution.
+//
+// Frames may be popped without notice when they are unwound by a panic, or by
+// a recover; a Tracer should consider the calls it was notified of at a depth
+// greater or equal to the depth of a new call, or of a return, as ended.
+type Tracer interface {
+	// OnCall is called when a call frame is pushed, as the last of
+	// m.Frames, once m.Package and m.Realm are set for the callee.
+	OnCall(m *Machine)
+	// OnReturn is called when the call frame fr is popped from m.Frames,
+	// before m.Package and m.Realm are restored for the caller.
+	OnReturn(m *Machine, fr *Frame)
 }
 
 const (
@@ -124,6 +148,10 @@ func NewMachineWithOptions(opts MachineOptions) *Machine {
 	} else if store.GetAllocator() == nil {
 		store.SetAllocator(alloc)
 	}
+	gasTable := opts.GasTable
+	if gasTable == nil {
+		gasTable = store.GetGasTable()
+	}
 	// Get machine from pool.
 	mm := machinePool.Get().(*Machine)
 	mm.Alloc = alloc
@@ -135,10 +163,13 @@ func NewMachineWithOptions(opts MachineOptions) *Machine {
 	mm.Store = store
 	mm.Context = opts.Context
 	mm.GasMeter = vmGasMeter
+	mm.GasTable = gasTable
 	mm.Debugger.enabled = opts.Debug
 	mm.Debugger.in = opts.Input
 	mm.Debugger.out = output
 	mm.ReviveEnabled = opts.ReviveEnabled
+	mm.Tracer = opts.Tracer
+	mm.Coverage = opts.Coverage
 	// Maybe get/set package and realm.
 	if !opts.SkipPackage && opts.PkgPath != "" {
 		pv := (*PackageValue)(nil)
@@ -597,6 +628,10 @@ func (m *Machine) runFileDecls(withOverrides bool, fns ...*FileNode) []TypedValu
 		pv.AddFileBlock(fn.FileName, fb)
 	}
 
+	// Check the realm doesn't write to objects of other realms through its
+	// package-level variables.
+	assertNoExternalRealmWrites(m.Store, pn)
+
 	// Get new values across all files in package.
 	updates := pn.PrepareNewValues(m.Alloc, pv)
 
@@ -727,6 +762,13 @@ func (m *Machine) saveNewPackageValuesAndTypes() (throwaway *Realm) {
 			}
 		}
 	}
+	// save types declared in functions, which persisted values may
+	// also have.
+	if pn, ok := pv.GetBlock(m.Store).GetSource(m.Store).(*PackageNode); ok {
+		for _, dt := range localDeclaredTypes(pn) {
+			m.Store.SetType(dt)
+		}
+	}
 	return
 }
 
@@ -780,6 +822,12 @@ func (m *Machine) RunMainMaybeCrossing() {
 	m.runFunc(StageRun, "main", true)
 }
 
+// This is used for the functions called after main by
+// realm filetests, which may likewise be crossing.
+func (m *Machine) RunFuncMaybeCrossing(fn Name) {
+	m.runFunc(StageRun, fn, true)
+}
+
 // Evaluate throwaway expression in new block scope.
 // If x is a function call, it may return any number of
 // results including 0.  Otherwise it returns 1.
@@ -965,6 +1013,11 @@ func (m *Machine) runDeclaration(d Decl) {
 		m.PushOp(OpExec)
 		m.Run(StageAdd)
 	case *TypeDecl:
+		if d.IsGeneric() {
+			// nothing to do.
+			// instances are declared when preprocessed.
+			return
+		}
 		m.PushOp(OpHalt)
 		m.PushStmt(d)
 		m.PushOp(OpExec)
@@ -1083,6 +1136,7 @@ const (
 	OpDefine      Op = 0x8C // X... := Y...
 	OpInc         Op = 0x8D // X++
 	OpDec         Op = 0x8E // X--
+	OpSend        Op = 0x8F // X <- Y
 
 	/* Decl operators */
 	OpValueDecl Op = 0x90 // var/const ...
@@ -1097,6 +1151,7 @@ const (
 	OpRangeIterMap      Op = 0xD5
 	OpRangeIterArrayPtr Op = 0xD6
 	OpReturnCallDefers  Op = 0xD7 // XXX rename to OpCallDefers
+	OpRangeIterChan     Op = 0xD8
 	OpVoid              Op = 0xFF // For profiling simple operation
 )
 
@@ -1126,8 +1181,8 @@ const (
 	OpCPUCallNativeBody      = 424
 	OpCPUDefer               = 64
 	OpCPUCallDeferNativeBody = 33
-	OpCPUGo                  = 1 // Not yet implemented
-	OpCPUSelect              = 1 // Not yet implemented
+	OpCPUGo                  = 244
+	OpCPUSelect              = 172
 	OpCPUSwitchClause        = 38
 	OpCPUSwitchClauseCase    = 143
 	OpCPUTypeSwitch          = 171
@@ -1148,7 +1203,7 @@ const (
 	OpCPUUneg  = 25
 	OpCPUUnot  = 6
 	OpCPUUxor  = 14
-	OpCPUUrecv = 1 // Not yet implemented
+	OpCPUUrecv = 54
 	OpCPULor   = 26
 	OpCPULand  = 24
 	OpCPUEql   = 160
@@ -1182,15 +1237,16 @@ const (
 	OpCPUTypeAssert2 = 25
 	// TODO: OpCPUStaticTypeOf is an arbitrary number.
 	// A good way to benchmark this is yet to be determined.
-	OpCPUStaticTypeOf = 100
-	OpCPUCompositeLit = 50
-	OpCPUArrayLit     = 137
-	OpCPUSliceLit     = 183
-	OpCPUSliceLit2    = 467
-	OpCPUMapLit       = 475
-	OpCPUStructLit    = 179
-	OpCPUFuncLit      = 61
-	OpCPUConvert      = 16
+	OpCPUStaticTypeOf   = 100
+	OpCPUCompositeLit   = 50
+	OpCPUArrayLit       = 137
+	OpCPUSliceLit       = 183
+	OpCPUSliceLit2      = 467
+	OpCPUMapLit         = 475
+	OpCPUStructLit      = 179
+	OpCPUFuncLit        = 61
+	OpCPUConvert        = 16
+	OpCPUConvertPerByte = 1 // between strings and []byte or []rune
 
 	/* Type operators */
 	OpCPUFieldType     = 59
@@ -1219,6 +1275,7 @@ const (
 	OpCPUDefine      = 111
 	OpCPUInc         = 76
 	OpCPUDec         = 46
+	OpCPUSend        = 62
 
 	/* Decl operators */
 	OpCPUValueDecl = 113
@@ -1233,6 +1290,7 @@ const (
 	OpCPURangeIterMap      = 48
 	OpCPURangeIterArrayPtr = 46
 	OpCPUReturnCallDefers  = 78
+	OpCPURangeIterChan     = 41
 )
 
 //----------------------------------------
@@ -1246,6 +1304,12 @@ func (m *Machine) Run(st Stage) {
 			bm.FinishRun()
 		}()
 	}
+	defer func() {
+		// Runs after the recovery below, which resumes the run.
+		if m.runDepth == 0 {
+			m.stopGoroutines()
+		}
+	}()
 	defer func() {
 		r := recover()
 
@@ -1262,6 +1326,10 @@ func (m *Machine) Run(st Stage) {
 			}
 		}
 	}()
+	m.runDepth++
+	defer func() {
+		m.runDepth--
+	}()
 
 	for {
 		if m.Debugger.enabled {
@@ -1277,317 +1345,229 @@ func (m *Machine) Run(st Stage) {
 				bm.StartOpCode(byte(op))
 			}
 		}
+		// Charge the price of the op before running it.
+		m.incrCPU(m.GasTable.OpCPU[op])
 		// TODO: this can be optimized manually, even into tiers.
 		switch op {
 		/* Control operators */
 		case OpHalt:
-			m.incrCPU(OpCPUHalt)
 			if bm.OpsEnabled {
 				bm.StopOpCode()
 			}
+			if m.sched != nil && m.sched.cur != 0 && m.runDepth == 1 {
+				// A goroutine returned.
+				m.exitGoroutine()
+				continue
+			}
 			return
 		case OpNoop:
-			m.incrCPU(OpCPUNoop)
 			continue
 		case OpExec:
-			m.incrCPU(OpCPUExec)
 			m.doOpExec(op)
 		case OpPrecall:
-			m.incrCPU(OpCPUPrecall)
 			m.doOpPrecall()
 		case OpEnterCrossing:
-			m.incrCPU(OpCPUEnterCrossing)
 			m.doOpEnterCrossing()
 		case OpCall:
-			m.incrCPU(OpCPUCall)
 			m.doOpCall()
 		case OpCallNativeBody:
-			m.incrCPU(OpCPUCallNativeBody)
 			m.doOpCallNativeBody()
 		case OpReturn:
-			m.incrCPU(OpCPUReturn)
 			m.doOpReturn()
 		case OpReturnAfterCopy:
-			m.incrCPU(OpCPUReturnAfterCopy)
 			m.doOpReturnAfterCopy()
 		case OpReturnFromBlock:
-			m.incrCPU(OpCPUReturnFromBlock)
 			m.doOpReturnFromBlock()
 		case OpReturnToBlock:
-			m.incrCPU(OpCPUReturnToBlock)
 			m.doOpReturnToBlock()
 		case OpDefer:
-			m.incrCPU(OpCPUDefer)
 			m.doOpDefer()
 		case OpPanic1:
 			panic("deprecated")
 		case OpPanic2:
-			m.incrCPU(OpCPUPanic2)
 			m.doOpPanic2()
 		case OpCallDeferNativeBody:
-			m.incrCPU(OpCPUCallDeferNativeBody)
 			m.doOpCallDeferNativeBody()
 		case OpGo:
-			m.incrCPU(OpCPUGo)
-			panic("not yet implemented")
+			m.doOpGo()
 		case OpSelect:
-			m.incrCPU(OpCPUSelect)
-			panic("not yet implemented")
+			m.doOpSelect()
 		case OpSwitchClause:
-			m.incrCPU(OpCPUSwitchClause)
 			m.doOpSwitchClause()
 		case OpSwitchClauseCase:
-			m.incrCPU(OpCPUSwitchClauseCase)
 			m.doOpSwitchClauseCase()
 		case OpTypeSwitch:
-			m.incrCPU(OpCPUTypeSwitch)
 			m.doOpTypeSwitch()
 		case OpIfCond:
-			m.incrCPU(OpCPUIfCond)
 			m.doOpIfCond()
 		case OpPopValue:
-			m.incrCPU(OpCPUPopValue)
 			m.PopValue()
 		case OpPopResults:
-			m.incrCPU(OpCPUPopResults)
 			m.PopResults()
 		case OpPopBlock:
-			m.incrCPU(OpCPUPopBlock)
 			m.PopBlock()
 		case OpPopFrameAndReset:
-			m.incrCPU(OpCPUPopFrameAndReset)
 			m.PopFrameAndReset()
 		/* Unary operators */
 		case OpUpos:
-			m.incrCPU(OpCPUUpos)
 			m.doOpUpos()
 		case OpUneg:
-			m.incrCPU(OpCPUUneg)
 			m.doOpUneg()
 		case OpUnot:
-			m.incrCPU(OpCPUUnot)
 			m.doOpUnot()
 		case OpUxor:
-			m.incrCPU(OpCPUUxor)
 			m.doOpUxor()
 		case OpUrecv:
-			m.incrCPU(OpCPUUrecv)
 			m.doOpUrecv()
 		/* Binary operators */
 		case OpLor:
-			m.incrCPU(OpCPULor)
 			m.doOpLor()
 		case OpLand:
-			m.incrCPU(OpCPULand)
 			m.doOpLand()
 		case OpEql:
-			m.incrCPU(OpCPUEql)
 			m.doOpEql()
 		case OpNeq:
-			m.incrCPU(OpCPUNeq)
 			m.doOpNeq()
 		case OpLss:
-			m.incrCPU(OpCPULss)
 			m.doOpLss()
 		case OpLeq:
-			m.incrCPU(OpCPULeq)
 			m.doOpLeq()
 		case OpGtr:
-			m.incrCPU(OpCPUGtr)
 			m.doOpGtr()
 		case OpGeq:
-			m.incrCPU(OpCPUGeq)
 			m.doOpGeq()
 		case OpAdd:
-			m.incrCPU(OpCPUAdd)
 			m.doOpAdd()
 		case OpSub:
-			m.incrCPU(OpCPUSub)
 			m.doOpSub()
 		case OpBor:
-			m.incrCPU(OpCPUBor)
 			m.doOpBor()
 		case OpXor:
-			m.incrCPU(OpCPUXor)
 			m.doOpXor()
 		case OpMul:
-			m.incrCPU(OpCPUMul)
 			m.doOpMul()
 		case OpQuo:
-			m.incrCPU(OpCPUQuo)
 			m.doOpQuo()
 		case OpRem:
-			m.incrCPU(OpCPURem)
 			m.doOpRem()
 		case OpShl:
-			m.incrCPU(OpCPUShl)
 			m.doOpShl()
 		case OpShr:
-			m.incrCPU(OpCPUShr)
 			m.doOpShr()
 		case OpBand:
-			m.incrCPU(OpCPUBand)
 			m.doOpBand()
 		case OpBandn:
-			m.incrCPU(OpCPUBandn)
 			m.doOpBandn()
 		/* Expression operators */
 		case OpEval:
-			m.incrCPU(OpCPUEval)
 			m.doOpEval()
 		case OpBinary1:
-			m.incrCPU(OpCPUBinary1)
 			m.doOpBinary1()
 		case OpIndex1:
-			m.incrCPU(OpCPUIndex1)
 			m.doOpIndex1()
 		case OpIndex2:
-			m.incrCPU(OpCPUIndex2)
 			m.doOpIndex2()
 		case OpSelector:
-			m.incrCPU(OpCPUSelector)
 			m.doOpSelector()
 		case OpSlice:
-			m.incrCPU(OpCPUSlice)
 			m.doOpSlice()
 		case OpStar:
-			m.incrCPU(OpCPUStar)
 			m.doOpStar()
 		case OpRef:
-			m.incrCPU(OpCPURef)
 			m.doOpRef()
 		case OpTypeAssert1:
-			m.incrCPU(OpCPUTypeAssert1)
 			m.doOpTypeAssert1()
 		case OpTypeAssert2:
-			m.incrCPU(OpCPUTypeAssert2)
 			m.doOpTypeAssert2()
 		case OpStaticTypeOf:
-			m.incrCPU(OpCPUStaticTypeOf)
 			m.doOpStaticTypeOf()
 		case OpCompositeLit:
-			m.incrCPU(OpCPUCompositeLit)
 			m.doOpCompositeLit()
 		case OpArrayLit:
-			m.incrCPU(OpCPUArrayLit)
 			m.doOpArrayLit()
 		case OpSliceLit:
-			m.incrCPU(OpCPUSliceLit)
 			m.doOpSliceLit()
 		case OpSliceLit2:
-			m.incrCPU(OpCPUSliceLit2)
 			m.doOpSliceLit2()
 		case OpFuncLit:
-			m.incrCPU(OpCPUFuncLit)
 			m.doOpFuncLit()
 		case OpMapLit:
-			m.incrCPU(OpCPUMapLit)
 			m.doOpMapLit()
 		case OpStructLit:
-			m.incrCPU(OpCPUStructLit)
 			m.doOpStructLit()
 		case OpConvert:
-			m.incrCPU(OpCPUConvert)
 			m.doOpConvert()
 		/* Type operators */
 		case OpFieldType:
-			m.incrCPU(OpCPUFieldType)
 			m.doOpFieldType()
 		case OpArrayType:
-			m.incrCPU(OpCPUArrayType)
 			m.doOpArrayType()
 		case OpSliceType:
-			m.incrCPU(OpCPUSliceType)
 			m.doOpSliceType()
 		case OpChanType:
-			m.incrCPU(OpCPUChanType)
 			m.doOpChanType()
 		case OpFuncType:
-			m.incrCPU(OpCPUFuncType)
 			m.doOpFuncType()
 		case OpMapType:
-			m.incrCPU(OpCPUMapType)
 			m.doOpMapType()
 		case OpStructType:
-			m.incrCPU(OpCPUStructType)
 			m.doOpStructType()
 		case OpInterfaceType:
-			m.incrCPU(OpCPUInterfaceType)
 			m.doOpInterfaceType()
 		/* Statement operators */
 		case OpAssign:
-			m.incrCPU(OpCPUAssign)
 			m.doOpAssign()
 		case OpAddAssign:
-			m.incrCPU(OpCPUAddAssign)
 			m.doOpAddAssign()
 		case OpSubAssign:
-			m.incrCPU(OpCPUSubAssign)
 			m.doOpSubAssign()
 		case OpMulAssign:
-			m.incrCPU(OpCPUMulAssign)
 			m.doOpMulAssign()
 		case OpQuoAssign:
-			m.incrCPU(OpCPUQuoAssign)
 			m.doOpQuoAssign()
 		case OpRemAssign:
-			m.incrCPU(OpCPURemAssign)
 			m.doOpRemAssign()
 		case OpBandAssign:
-			m.incrCPU(OpCPUBandAssign)
 			m.doOpBandAssign()
 		case OpBandnAssign:
-			m.incrCPU(OpCPUBandnAssign)
 			m.doOpBandnAssign()
 		case OpBorAssign:
-			m.incrCPU(OpCPUBorAssign)
 			m.doOpBorAssign()
 		case OpXorAssign:
-			m.incrCPU(OpCPUXorAssign)
 			m.doOpXorAssign()
 		case OpShlAssign:
-			m.incrCPU(OpCPUShlAssign)
 			m.doOpShlAssign()
 		case OpShrAssign:
-			m.incrCPU(OpCPUShrAssign)
 			m.doOpShrAssign()
 		case OpDefine:
-			m.incrCPU(OpCPUDefine)
 			m.doOpDefine()
 		case OpInc:
-			m.incrCPU(OpCPUInc)
 			m.doOpInc()
 		case OpDec:
-			m.incrCPU(OpCPUDec)
 			m.doOpDec()
+		case OpSend:
+			m.doOpSend()
 		/* Decl operators */
 		case OpValueDecl:
-			m.incrCPU(OpCPUValueDecl)
 			m.doOpValueDecl()
 		case OpTypeDecl:
-			m.incrCPU(OpCPUTypeDecl)
 			m.doOpTypeDecl()
 		/* Loop (sticky) operators */
 		case OpBody:
-			m.incrCPU(OpCPUBody)
 			m.doOpExec(op)
 		case OpForLoop:
-			m.incrCPU(OpCPUForLoop)
 			m.doOpExec(op)
 		case OpRangeIter:
-			m.incrCPU(OpCPURangeIter)
 			m.doOpExec(op)
 		case OpRangeIterArrayPtr:
-			m.incrCPU(OpCPURangeIterArrayPtr)
 			m.doOpExec(op)
 		case OpRangeIterString:
-			m.incrCPU(OpCPURangeIterString)
 			m.doOpExec(op)
 		case OpRangeIterMap:
-			m.incrCPU(OpCPURangeIterMap)
+			m.doOpExec(op)
+		case OpRangeIterChan:
 			m.doOpExec(op)
 		case OpReturnCallDefers:
-			m.incrCPU(OpCPUReturnCallDefers)
 			m.doOpReturnCallDefers()
 		default:
 			panic(fmt.Sprintf("unexpected opcode %s", op.String()))
@@ -1597,6 +1577,9 @@ func (m *Machine) Run(st Stage) {
 				bm.StopOpCode()
 			}
 		}
+		if m.sched != nil && m.runDepth == 1 {
+			m.schedule()
+		}
 	}
 }
 
@@ -1848,6 +1831,10 @@ func (m *Machine) PushFrameBasic(s Stmt) {
 // ensure the counts are consistent, otherwise we mask
 // bugs with frame pops.
 func (m *Machine) PushFrameCall(cx *CallExpr, fv *FuncValue, recv TypedValue, isDefer bool) {
+	if m.Tracer != nil {
+		// once the package and realm are switched.
+		defer m.Tracer.OnCall(m)
+	}
 	withCross := cx.IsWithCross()
 	numValues := 0
 	if isDefer {
@@ -1925,7 +1912,7 @@ func (m *Machine) PushFrameCall(cx *CallExpr, fv *FuncValue, recv TypedValue, is
 				mrpath,
 			))
 		}
-		m.Realm = pv.GetRealm()
+		m.enterRealm(pv.GetRealm())
 		return
 	}
 
@@ -1974,7 +1961,7 @@ func (m *Machine) PushFrameCall(cx *CallExpr, fv *FuncValue, recv TypedValue, is
 				recvPkgOID := ObjectIDFromPkgID(recvOID.PkgID)
 				objpv := m.Store.GetObject(recvPkgOID).(*PackageValue)
 				rlm = objpv.GetRealm()
-				m.Realm = rlm
+				m.enterRealm(rlm)
 				// DO NOT set DidCrossing here. Make
 				// DidCrossing only happen upon explicit
 				// cross(fn)(...) calls and subsequent calls to
@@ -1993,6 +1980,67 @@ func (m *Machine) PushFrameCall(cx *CallExpr, fv *FuncValue, recv TypedValue, is
 	}
 }
 
+// enterRealm switches to rlm upon a call crossing a realm boundary, and
+// cache-wraps the store and the context for the call, so that a panic
+// leaving it discards its writes; see doOpReturnCallDefers.
+func (m *Machine) enterRealm(rlm *Realm) {
+	if rlm != nil && rlm.hasPendingMarks() {
+		m.finalizeReenteredRealm(rlm)
+	}
+	m.Realm = rlm
+	if rlm == nil {
+		return
+	}
+	write, discard := m.Store.CacheWrapCall()
+	if cw, ok := m.Context.(CallCacheWrapper); ok {
+		cwrite, cdiscard := cw.CacheWrapCall()
+		swrite, sdiscard := write, discard
+		write = func() { cwrite(); swrite() }
+		discard = func() { cdiscard(); sdiscard() }
+	}
+	fr := m.LastFrame()
+	fr.writeCall, fr.discardCall = write, discard
+}
+
+// finalizeReenteredRealm finalizes the pending writes of rlm, by the frames
+// still running in it, upon a call entering it again. They're written to the
+// stores of when the call left rlm, so that they're not discarded with the
+// writes of the calls since, if a panic leaves them.
+func (m *Machine) finalizeReenteredRealm(rlm *Realm) {
+	n := 0
+	if m.Realm != rlm {
+		for i := len(m.Frames) - 2; i >= 0; i-- {
+			fr := &m.Frames[i]
+			if fr.discardCall == nil {
+				continue
+			}
+			n++
+			if fr.LastRealm == rlm {
+				break
+			}
+			if i == 0 {
+				// rlm wasn't left by a call.
+				n = 0
+			}
+		}
+	}
+	m.Store.WithCallerStores(n, func() {
+		rlm.FinalizeRealmTransaction(m.Store)
+	})
+}
+
+// discardCalls discards the caches of the calls crossing realm boundaries of
+// the frames from index i, see enterRealm.
+func (m *Machine) discardCalls(i int) {
+	for j := len(m.Frames) - 1; j >= i; j-- {
+		fr := &m.Frames[j]
+		if fr.discardCall != nil {
+			fr.discardCall()
+			fr.writeCall, fr.discardCall = nil, nil
+		}
+	}
+}
+
 func (m *Machine) PopFrame() Frame {
 	numFrames := len(m.Frames)
 	f := m.Frames[numFrames-1]
@@ -2000,6 +2048,9 @@ func (m *Machine) PopFrame() Frame {
 		m.Printf("-F %#v\n", f)
 	}
 	m.Frames = m.Frames[:numFrames-1]
+	if m.Tracer != nil && f.IsCall() {
+		m.Tracer.OnReturn(m, &f)
+	}
 
 	return f
 }
@@ -2022,8 +2073,6 @@ func (m *Machine) GotoJump(depthFrames, depthBlocks int) {
 		m.Exprs = m.Exprs[:fr.NumExprs]
 		m.Stmts = m.Stmts[:fr.NumStmts]
 		m.Blocks = m.Blocks[:fr.NumBlocks]
-		// pop stmts
-		m.Stmts = m.Stmts[:len(m.Stmts)-depthFrames]
 	}
 
 	if depthBlocks >= len(m.Blocks) {
@@ -2171,6 +2220,7 @@ func (m *Machine) PopUntilLastReviveFrame() *Frame {
 	for i := len(m.Frames) - 1; i >= 0; i-- {
 		fr := &m.Frames[i]
 		if fr.IsRevive {
+			m.discardCalls(i + 1)
 			m.Frames = m.Frames[:i+1]
 			return fr
 		}
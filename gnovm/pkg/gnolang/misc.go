@@ -1,6 +1,7 @@
 package gnolang
 
 import (
+	"errors"
 	"fmt"
 	"slices"
 	"strings"
@@ -180,6 +181,28 @@ func isUverseName(n Name) bool {
 //----------------------------------------
 // other
 
+// ParseAddress decodes the bech32 string of an address. It returns an error
+// unless s is the canonical, lowercase encoding of a 20 bytes address with the
+// chain's prefix. The empty string, the zero value of the address type, is not
+// a valid address.
+func ParseAddress(s string) (crypto.Address, error) {
+	if s == "" {
+		return crypto.Address{}, errors.New("empty address")
+	}
+	bz, err := crypto.GetFromBech32(s, crypto.Bech32AddrPrefix)
+	if err != nil {
+		return crypto.Address{}, err
+	}
+	if len(bz) != crypto.AddressSize {
+		return crypto.Address{}, fmt.Errorf("invalid address length; expected %d bytes, got %d", crypto.AddressSize, len(bz))
+	}
+	addr := crypto.AddressFromBytes(bz)
+	if addr.String() != s {
+		return crypto.Address{}, fmt.Errorf("address is not in canonical form, expected %s", addr.String())
+	}
+	return addr, nil
+}
+
 // For keeping record of package & realm coins.
 // If you need the bech32 address it is faster to call DerivePkgBech32Addr().
 func DerivePkgCryptoAddr(pkgPath string) crypto.Address {
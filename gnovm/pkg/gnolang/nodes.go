@@ -139,8 +139,10 @@ const (
 	ATTR_LAST_BLOCK_STMT       GnoAttribute = "ATTR_LAST_BLOCK_STMT"
 	ATTR_PACKAGE_REF           GnoAttribute = "ATTR_PACKAGE_REF"
 	ATTR_PACKAGE_DECL          GnoAttribute = "ATTR_PACKAGE_DECL"
-	ATTR_PACKAGE_PATH          GnoAttribute = "ATTR_PACKAGE_PATH" // if name expr refers to package.
-	ATTR_FIX_FROM              GnoAttribute = "ATTR_FIX_FROM"     // gno fix this version.
+	ATTR_PACKAGE_PATH          GnoAttribute = "ATTR_PACKAGE_PATH"      // if name expr refers to package.
+	ATTR_FIX_FROM              GnoAttribute = "ATTR_FIX_FROM"          // gno fix this version.
+	ATTR_GENERIC_INSTANCE      GnoAttribute = "ATTR_GENERIC_INSTANCE"  // *genericInstance of instance node.
+	ATTR_GENERIC_INSTANCES     GnoAttribute = "ATTR_GENERIC_INSTANCES" // []*genericInstance used by package.
 )
 
 // Embedded in each Node.
@@ -225,6 +227,7 @@ func (*BasicLitExpr) assertNode()      {}
 func (*BinaryExpr) assertNode()        {}
 func (*CallExpr) assertNode()          {}
 func (*IndexExpr) assertNode()         {}
+func (*IndexListExpr) assertNode()     {}
 func (*SelectorExpr) assertNode()      {}
 func (*SliceExpr) assertNode()         {}
 func (*StarExpr) assertNode()          {}
@@ -239,6 +242,7 @@ func (*FieldTypeExpr) assertNode()     {}
 func (*ArrayTypeExpr) assertNode()     {}
 func (*SliceTypeExpr) assertNode()     {}
 func (*InterfaceTypeExpr) assertNode() {}
+func (*UnionTypeExpr) assertNode()     {}
 func (*ChanTypeExpr) assertNode()      {}
 func (*FuncTypeExpr) assertNode()      {}
 func (*MapTypeExpr) assertNode()       {}
@@ -341,6 +345,7 @@ func (*BasicLitExpr) assertExpr()     {}
 func (*BinaryExpr) assertExpr()       {}
 func (*CallExpr) assertExpr()         {}
 func (*IndexExpr) assertExpr()        {}
+func (*IndexListExpr) assertExpr()    {}
 func (*SelectorExpr) assertExpr()     {}
 func (*SliceExpr) assertExpr()        {}
 func (*StarExpr) assertExpr()         {}
@@ -358,6 +363,7 @@ var (
 	_ Expr = &BinaryExpr{}
 	_ Expr = &CallExpr{}
 	_ Expr = &IndexExpr{}
+	_ Expr = &IndexListExpr{}
 	_ Expr = &SelectorExpr{}
 	_ Expr = &SliceExpr{}
 	_ Expr = &StarExpr{}
@@ -466,6 +472,14 @@ type IndexExpr struct { // X[Index]
 	HasOK bool // if true, is form: `value, ok := <X>[<Key>]
 }
 
+// Only used for the instantiation of generic functions and types
+// with more than one type argument; X[Index] is an *IndexExpr.
+type IndexListExpr struct { // X[Indices...]
+	Attributes
+	X       Expr  // generic function or type
+	Indices Exprs // type arguments
+}
+
 type SelectorExpr struct { // X.Sel
 	Attributes
 	X    Expr      // expression
@@ -507,8 +521,9 @@ type TypeAssertExpr struct { // X.(Type)
 // (referencing) are represented with RefExpr nodes.
 type UnaryExpr struct { // (Op X)
 	Attributes
-	X  Expr // operand
-	Op Word // operator
+	X     Expr // operand
+	Op    Word // operator
+	HasOK bool // if true, is form: `value, ok := <-<X>`.
 }
 
 // MyType{<key>:<value>} struct, array, slice, and map
@@ -617,6 +632,7 @@ func (x *FieldTypeExpr) assertTypeExpr()     {}
 func (x *ArrayTypeExpr) assertTypeExpr()     {}
 func (x *SliceTypeExpr) assertTypeExpr()     {}
 func (x *InterfaceTypeExpr) assertTypeExpr() {}
+func (x *UnionTypeExpr) assertTypeExpr()     {}
 func (x *ChanTypeExpr) assertTypeExpr()      {}
 func (x *FuncTypeExpr) assertTypeExpr()      {}
 func (x *MapTypeExpr) assertTypeExpr()       {}
@@ -627,6 +643,7 @@ func (x *FieldTypeExpr) assertExpr()     {}
 func (x *ArrayTypeExpr) assertExpr()     {}
 func (x *SliceTypeExpr) assertExpr()     {}
 func (x *InterfaceTypeExpr) assertExpr() {}
+func (x *UnionTypeExpr) assertExpr()     {}
 func (x *ChanTypeExpr) assertExpr()      {}
 func (x *FuncTypeExpr) assertExpr()      {}
 func (x *MapTypeExpr) assertExpr()       {}
@@ -638,6 +655,7 @@ var (
 	_ TypeExpr = &ArrayTypeExpr{}
 	_ TypeExpr = &SliceTypeExpr{}
 	_ TypeExpr = &InterfaceTypeExpr{}
+	_ TypeExpr = &UnionTypeExpr{}
 	_ TypeExpr = &ChanTypeExpr{}
 	_ TypeExpr = &FuncTypeExpr{}
 	_ TypeExpr = &MapTypeExpr{}
@@ -707,6 +725,14 @@ type InterfaceTypeExpr struct {
 	Generic Name           // for uverse generics
 }
 
+// A type set element of a constraint interface, e.g. `~int | string`.
+// A single `~T` term is also represented by a *UnionTypeExpr.
+type UnionTypeExpr struct {
+	Attributes
+	Terms Exprs  // term types
+	Tilde []bool // whether each term is of the form ~T
+}
+
 type ChanDir int
 
 const (
@@ -930,6 +956,7 @@ type RangeStmt struct {
 	IsMap      bool // if X is map type
 	IsString   bool // if X is string type
 	IsArrayPtr bool // if X is array-pointer type
+	IsChan     bool // if X is chan type
 }
 
 type ReturnStmt struct {
@@ -969,6 +996,17 @@ type SwitchStmt struct {
 	VarName      Name               // type-switched value; or ""
 }
 
+// DefaultClause returns the index of the default clause,
+// or -1 if the switch has none.
+func (ss *SwitchStmt) DefaultClause() int {
+	for i := range ss.Clauses {
+		if len(ss.Clauses[i].Cases) == 0 {
+			return i
+		}
+	}
+	return -1
+}
+
 type SwitchClauseStmt struct {
 	Attributes
 	StaticBlock
@@ -1090,10 +1128,11 @@ type FuncDecl struct {
 	Attributes
 	StaticBlock
 	NameExpr
-	IsMethod bool
-	Recv     FieldTypeExpr // receiver (if method); or empty (if function)
-	Type     FuncTypeExpr  // function signature: parameters and results
-	Body                   // function body; or empty for external (non-Go) function
+	IsMethod   bool
+	Recv       FieldTypeExpr  // receiver (if method); or empty (if function)
+	TypeParams FieldTypeExprs // type parameters (if generic function)
+	Type       FuncTypeExpr   // function signature: parameters and results
+	Body                      // function body; or empty for external (non-Go) function
 
 	unboundType *FuncTypeExpr // memoized
 }
@@ -1110,6 +1149,26 @@ func (x *FuncDecl) GetDeclNames() []Name {
 	}
 }
 
+// IsGeneric returns true if x is a generic function, or a method of a
+// generic type. Generic declarations are only templates for their
+// instances, and are never preprocessed themselves.
+func (x *FuncDecl) IsGeneric() bool {
+	if len(x.TypeParams) > 0 {
+		return true
+	}
+	if x.IsMethod {
+		rx := x.Recv.Type
+		if sx, ok := rx.(*StarExpr); ok {
+			rx = sx.X
+		}
+		switch rx.(type) {
+		case *IndexExpr, *IndexListExpr:
+			return true
+		}
+	}
+	return false
+}
+
 func (x *FuncDecl) GetFuncTypeExpr() *FuncTypeExpr {
 	return &x.Type
 }
@@ -1179,8 +1238,14 @@ func (x *ValueDecl) GetDeclNames() []Name {
 type TypeDecl struct {
 	Attributes
 	NameExpr
-	Type    Expr // Name, SelectorExpr, StarExpr, or XxxTypes
-	IsAlias bool // type alias since Go 1.9
+	TypeParams FieldTypeExprs // type parameters (if generic type)
+	Type       Expr           // Name, SelectorExpr, StarExpr, or XxxTypes
+	IsAlias    bool           // type alias since Go 1.9
+}
+
+// IsGeneric returns true if x declares a generic type.
+func (x *TypeDecl) IsGeneric() bool {
+	return len(x.TypeParams) > 0
 }
 
 func (x *TypeDecl) GetDeclNames() []Name {
@@ -53,6 +53,13 @@ func (x *IndexExpr) Copy() Node {
 	}
 }
 
+func (x *IndexListExpr) Copy() Node {
+	return &IndexListExpr{
+		X:       x.X.Copy().(Expr),
+		Indices: copyExprs(x.Indices),
+	}
+}
+
 func (x *SelectorExpr) Copy() Node {
 	return &SelectorExpr{
 		X:   x.X.Copy().(Expr),
@@ -144,6 +151,13 @@ func (x *InterfaceTypeExpr) Copy() Node {
 	}
 }
 
+func (x *UnionTypeExpr) Copy() Node {
+	return &UnionTypeExpr{
+		Terms: copyExprs(x.Terms),
+		Tilde: append([]bool(nil), x.Tilde...),
+	}
+}
+
 func (x *ChanTypeExpr) Copy() Node {
 	return &ChanTypeExpr{
 		Dir:   x.Dir,
@@ -305,10 +319,11 @@ func (x *SwitchClauseStmt) Copy() Node {
 
 func (x *FuncDecl) Copy() Node {
 	funcDecl := &FuncDecl{
-		NameExpr: *(x.NameExpr.Copy().(*NameExpr)),
-		IsMethod: x.IsMethod,
-		Type:     *(x.Type.Copy().(*FuncTypeExpr)),
-		Body:     copyStmts(x.Body),
+		NameExpr:   *(x.NameExpr.Copy().(*NameExpr)),
+		IsMethod:   x.IsMethod,
+		TypeParams: copyFTs(x.TypeParams),
+		Type:       *(x.Type.Copy().(*FuncTypeExpr)),
+		Body:       copyStmts(x.Body),
 	}
 	if x.IsMethod {
 		funcDecl.Recv = *(x.Recv.Copy().(*FieldTypeExpr))
@@ -334,9 +349,10 @@ func (x *ValueDecl) Copy() Node {
 
 func (x *TypeDecl) Copy() Node {
 	return &TypeDecl{
-		NameExpr: *(x.NameExpr.Copy().(*NameExpr)),
-		Type:     x.Type.Copy().(Expr),
-		IsAlias:  x.IsAlias,
+		NameExpr:   *(x.NameExpr.Copy().(*NameExpr)),
+		TypeParams: copyFTs(x.TypeParams),
+		Type:       x.Type.Copy().(Expr),
+		IsAlias:    x.IsAlias,
 	}
 }
 
@@ -374,6 +390,10 @@ func copyExpr(x Expr) Expr {
 }
 
 func copyExprs(xs []Expr) []Expr {
+	if xs == nil {
+		// preserve nil, e.g. for *ValueDecl.Values.
+		return nil
+	}
 	res := make([]Expr, len(xs))
 	for i, x := range xs {
 		res[i] = x.Copy().(Expr)
@@ -405,6 +425,10 @@ func copyStmt(x Stmt) Stmt {
 }
 
 func copyStmts(ss []Stmt) []Stmt {
+	if ss == nil {
+		// preserve nil, e.g. for *FuncDecl.Body.
+		return nil
+	}
 	res := make([]Stmt, len(ss))
 	for i, s := range ss {
 		res[i] = s.Copy().(Stmt)
@@ -136,6 +136,10 @@ func (x IndexExpr) String() string {
 	return fmt.Sprintf("%s[%s]", x.X, x.Index)
 }
 
+func (x IndexListExpr) String() string {
+	return fmt.Sprintf("%s[%s]", x.X, x.Indices)
+}
+
 func (x SelectorExpr) String() string {
 	return fmt.Sprintf("%s.%s", x.X, x.Sel)
 }
@@ -227,12 +231,26 @@ func (x InterfaceTypeExpr) String() string {
 	return fmt.Sprintf("interface { %v }", x.Methods)
 }
 
+func (x UnionTypeExpr) String() string {
+	str := ""
+	for i, t := range x.Terms {
+		if i > 0 {
+			str += " | "
+		}
+		if x.Tilde[i] {
+			str += "~"
+		}
+		str += t.String()
+	}
+	return str
+}
+
 func (x ChanTypeExpr) String() string {
 	switch x.Dir {
 	case SEND:
-		return fmt.Sprintf("<-chan %s", x.Value)
-	case RECV:
 		return fmt.Sprintf("chan<- %s", x.Value)
+	case RECV:
+		return fmt.Sprintf("<-chan %s", x.Value)
 	case SEND | RECV:
 		return fmt.Sprintf("chan %s", x.Value)
 	default:
@@ -420,8 +438,12 @@ func (x FuncDecl) String() string {
 	if x.IsMethod {
 		recv = "(" + x.Recv.String() + ") "
 	}
-	return fmt.Sprintf("func %s%s%s { %s }",
-		recv, x.Name, x.Type.String()[4:], x.Body.String())
+	tparams := ""
+	if len(x.TypeParams) > 0 {
+		tparams = "[" + x.TypeParams.String() + "]"
+	}
+	return fmt.Sprintf("func %s%s%s%s { %s }",
+		recv, x.Name, tparams, x.Type.String()[4:], x.Body.String())
 }
 
 func (x ImportDecl) String() string {
@@ -449,10 +471,14 @@ func (x ValueDecl) String() string {
 }
 
 func (x TypeDecl) String() string {
+	tparams := ""
+	if len(x.TypeParams) > 0 {
+		tparams = "[" + x.TypeParams.String() + "]"
+	}
 	if x.IsAlias {
-		return fmt.Sprintf("type %s = %s", x.Name, x.Type.String())
+		return fmt.Sprintf("type %s%s = %s", x.Name, tparams, x.Type.String())
 	}
-	return fmt.Sprintf("type %s %s", x.Name, x.Type.String())
+	return fmt.Sprintf("type %s%s %s", x.Name, tparams, x.Type.String())
 }
 
 func (x FileNode) String() string {
@@ -455,6 +455,8 @@ func isEql(store Store, lv, rv *TypedValue) bool {
 			}
 		}
 		return lv.V == rv.V
+	case ChanKind:
+		return lv.V == rv.V
 	case PointerKind:
 		if lv.T != rv.T &&
 			lv.T.Elem() != DataByteType &&
@@ -213,16 +213,19 @@ func (m *Machine) doOpCall() {
 }
 
 func (m *Machine) doOpCallNativeBody() {
-	m.LastFrame().Func.nativeBody(m)
+	fv := m.LastFrame().Func
+	m.incrCPU(m.GasTable.nativeCPU(fv, m.LastBlock()))
+	fv.nativeBody(m)
 }
 
 func (m *Machine) doOpCallDeferNativeBody() {
 	fv := m.PopValue().V.(*FuncValue)
+	m.incrCPU(m.GasTable.nativeCPU(fv, m.LastBlock()))
 	fv.nativeBody(m)
 }
 
 // Used by return and panic operation handlers.
-// Must finalize for returns, and must abort for panics.
+// Must finalize for returns, and must roll back for panics.
 func (m *Machine) isRealmBoundary(cfr *Frame) bool {
 	crlm := m.Realm
 	if crlm != nil {
@@ -266,8 +269,14 @@ func (m *Machine) isRealmBoundary(cfr *Frame) bool {
 // NOTE: resource intensive
 func (m *Machine) maybeFinalize(cfr *Frame) {
 	if m.isRealmBoundary(cfr) {
+		m.stopRealmGoroutines(len(m.Frames) - 1)
 		m.Realm.FinalizeRealmTransaction(m.Store)
 	}
+	// Write the cache of the call to the caller's.
+	if cfr.writeCall != nil {
+		cfr.writeCall()
+		cfr.writeCall, cfr.discardCall = nil, nil
+	}
 }
 
 // Assumes that result values are pushed onto the Values stack.
@@ -352,14 +361,25 @@ func (m *Machine) doOpReturnCallDefers() {
 		// If still in panic state pop this frame so doOpPanic2() will
 		// try doOpReturnCallDefers() in the previous frame.
 		if m.Exception != nil {
-			// If crossing a realm boundary find the revive frame
-			// for transaction revival.
+			// If crossing a realm boundary discard the writes of
+			// the call, and find the revive frame for transaction
+			// revival.
 			if m.isRealmBoundary(cfr) {
-				cfr := m.PopUntilLastReviveFrame()
-				if cfr == nil {
-					// or abort the transaction.
+				if m.NumFrames() == 1 {
+					// Nothing left to recover, abort the transaction.
+					m.discardCalls(0)
 					panic(m.makeUnhandledPanicError())
 				}
+				m.Realm.Rollback(m.Store)
+				if m.PopUntilLastReviveFrame() == nil {
+					// Or let the caller recover, back in its realm.
+					m.discardCalls(len(m.Frames) - 1)
+					pv, rlm := cfr.LastPackage, cfr.LastRealm
+					m.PopFrame()
+					m.Package, m.Realm = pv, rlm
+					m.PushOp(OpPanic2)
+					return
+				}
 				m.PopFrameAndReturn()
 				// assign exception as return of revive().
 				resx := m.PeekValue(1)
@@ -533,6 +553,20 @@ func (m *Machine) doOpDefer() {
 	m.PopValue() // pop func
 }
 
+func (m *Machine) doOpGo() {
+	gs := m.PopStmt().(*GoStmt)
+	numArgs := gs.Call.NumArgs
+	// The func and args are copied to the values stack of the new
+	// goroutine, which makes the call like any other.
+	vals := make([]TypedValue, numArgs+1)
+	m.PopCopyValues(vals)
+	if vals[0].V == nil {
+		m.pushPanic(typedString("go of nil func value"))
+		return
+	}
+	m.spawnGoroutine(&gs.Call, vals)
+}
+
 // Build exception string just as go, separated by \n\t.
 // TODO: deprecate UnhandledPanicError and just use the Exception.
 // (use a field to mark transaction abort)
@@ -558,6 +592,7 @@ func (m *Machine) doOpPanic2() {
 		// If we can't find a call frame, we're in a corrupted state.
 		// This can happen during init functions with realm calls.
 		// Return the original exception as an unhandled panic.
+		m.discardCalls(0)
 		panic(m.makeUnhandledPanicError())
 	}
 	m.PushOp(OpReturnCallDefers)
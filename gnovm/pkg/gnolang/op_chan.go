@@ -0,0 +1,229 @@
+package gnolang
+
+// NOTE: the channel operations which can't complete block the running
+// goroutine, and push their op back: the values they operate on are left on
+// the stack, and once the goroutine resumes, the op runs again, and completes
+// with the wait of the goroutine.
+
+func (m *Machine) doOpSend() {
+	xv := m.PeekValue(2) // chan
+	vv := m.PeekValue(1) // value
+	if w := m.takeChanWait(); w != nil {
+		// resumed.
+		if !w.ok {
+			m.pushPanic(typedString("send on closed channel"))
+			return
+		}
+		m.PopValue()
+		m.PopValue()
+		return
+	}
+	cv, _ := xv.V.(*ChanValue)
+	if cv == nil {
+		// a send on a nil channel blocks forever.
+		m.blockGoroutine(nil)
+		m.PushOp(OpSend)
+		return
+	}
+	if cv.Closed {
+		m.pushPanic(typedString("send on closed channel"))
+		return
+	}
+	v := vv.Copy(m.Alloc)
+	if cv.trySend(v) {
+		m.PopValue()
+		m.PopValue()
+		return
+	}
+	w := &chanWait{}
+	cv.sendq = append(cv.sendq, chanWaiter{wait: w, value: v})
+	m.blockGoroutine(w)
+	m.PushOp(OpSend)
+}
+
+// recvChan receives a value from xv, a channel, and returns whether it was
+// received. Otherwise, the running goroutine was blocked.
+func (m *Machine) recvChan(xv *TypedValue) (v TypedValue, ok, done bool) {
+	if w := m.takeChanWait(); w != nil {
+		// resumed.
+		v, ok = w.value, w.ok
+	} else {
+		cv, _ := xv.V.(*ChanValue)
+		if cv == nil {
+			// a receive from a nil channel blocks forever.
+			m.blockGoroutine(nil)
+			return
+		}
+		v, ok, done = cv.tryRecv()
+		if !done {
+			w := &chanWait{}
+			cv.recvq = append(cv.recvq, chanWaiter{wait: w})
+			m.blockGoroutine(w)
+			return
+		}
+	}
+	if !ok {
+		// the channel is closed.
+		v = defaultTypedValue(m.Alloc, baseOf(xv.T).(*ChanType).Elt)
+	}
+	return v, ok, true
+}
+
+// selectRecvExpr returns the receive expression of the comm of a select
+// case, either `<-ch` or `x, ok := <-ch`.
+func selectRecvExpr(s Stmt) *UnaryExpr {
+	switch s := s.(type) {
+	case *ExprStmt:
+		return s.X.(*UnaryExpr)
+	case *AssignStmt:
+		return s.Rhs[0].(*UnaryExpr)
+	default:
+		panic("unexpected select case statement")
+	}
+}
+
+// numSelectValues returns the number of values on the stack for the cases of
+// ss: the channel and the value of a send, and the channel of a receive.
+func numSelectValues(ss *SelectStmt) (n int) {
+	for _, cs := range ss.Cases {
+		switch cs.Comm.(type) {
+		case nil:
+		case *SendStmt:
+			n += 2
+		default:
+			n++
+		}
+	}
+	return n
+}
+
+func (m *Machine) doOpSelect() {
+	ss := m.PeekStmt1().(*SelectStmt)
+	nvals := numSelectValues(ss)
+	vals := m.PeekValues(nvals)
+	idx := -1        // index of the case selected
+	var v TypedValue // value received
+	var ok bool
+	if w := m.takeChanWait(); w != nil {
+		// resumed.
+		idx, v, ok = w.index, w.value, w.ok
+	} else {
+		// select the first case ready, in order.
+		dflt := -1
+		j := 0 // index of the values of the case
+	CASES:
+		for i, cs := range ss.Cases {
+			switch cs.Comm.(type) {
+			case nil:
+				dflt = i
+			case *SendStmt:
+				cv, _ := vals[j].V.(*ChanValue)
+				if cv != nil {
+					if cv.Closed {
+						m.pushPanic(typedString("send on closed channel"))
+						return
+					}
+					if cv.trySend(vals[j+1].Copy(m.Alloc)) {
+						idx, ok = i, true
+						break CASES
+					}
+				}
+				j += 2
+			default:
+				cv, _ := vals[j].V.(*ChanValue)
+				if cv != nil {
+					var done bool
+					v, ok, done = cv.tryRecv()
+					if done {
+						idx = i
+						break CASES
+					}
+				}
+				j++
+			}
+		}
+		if idx == -1 && dflt != -1 {
+			idx = dflt
+		}
+		if idx == -1 {
+			// wait on all the cases.
+			w := &chanWait{}
+			j = 0
+			for i, cs := range ss.Cases {
+				switch cs.Comm.(type) {
+				case nil:
+				case *SendStmt:
+					if cv, _ := vals[j].V.(*ChanValue); cv != nil {
+						cv.sendq = append(cv.sendq, chanWaiter{
+							wait:  w,
+							index: i,
+							value: vals[j+1].Copy(m.Alloc),
+						})
+					}
+					j += 2
+				default:
+					if cv, _ := vals[j].V.(*ChanValue); cv != nil {
+						cv.recvq = append(cv.recvq, chanWaiter{
+							wait:  w,
+							index: i,
+						})
+					}
+					j++
+				}
+			}
+			m.blockGoroutine(w)
+			m.PushOp(OpSelect)
+			return
+		}
+	}
+	cs := &ss.Cases[idx]
+	var as *AssignStmt
+	switch comm := cs.Comm.(type) {
+	case nil:
+	case *SendStmt:
+		if !ok {
+			m.pushPanic(typedString("send on closed channel"))
+			return
+		}
+	default:
+		if !ok {
+			// the channel is closed.
+			xv := &vals[selectValueIndex(ss, idx)]
+			v = defaultTypedValue(m.Alloc, baseOf(xv.T).(*ChanType).Elt)
+		}
+		as, _ = comm.(*AssignStmt)
+	}
+	m.PopValues(nvals)
+	// exec the case body in its block, as for switch clauses.
+	m.PushFrameBasic(ss)
+	m.PopStmt() // pop select stmt
+	m.PushOp(OpPopFrameAndReset)
+	b := m.Alloc.NewBlock(cs, m.LastBlock())
+	m.PushBlock(b)
+	m.PushOp(OpPopBlock)
+	if as != nil {
+		// define the received value, and ok.
+		rvs := [2]TypedValue{v, typedBool(ok)}
+		for i, lx := range as.Lhs {
+			nx := lx.(*NameExpr)
+			if nx.Name == blankIdentifier {
+				continue
+			}
+			ptr := b.GetPointerToMaybeHeapDefine(m.Store, nx)
+			ptr.TV.Assign(m.Alloc, rvs[i], false)
+		}
+	}
+	b.bodyStmt = bodyStmt{
+		Body:          cs.Body,
+		BodyLen:       len(cs.Body),
+		NextBodyIndex: -2,
+	}
+	m.PushOp(OpBody)
+	m.PushStmt(b.GetBodyStmt())
+}
+
+// selectValueIndex returns the index of the first value on the stack of the
+// case at index idx.
+func selectValueIndex(ss *SelectStmt, idx int) int {
+	return numSelectValues(&SelectStmt{Cases: ss.Cases[:idx]})
+}
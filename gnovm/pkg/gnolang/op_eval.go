@@ -320,6 +320,13 @@ func (m *Machine) doOpEval() {
 		// nodes may be more persistent than values in a tx.
 		// (currently all nodes are cached, but we don't want to cache
 		// all packages too).
+		if fv, ok := tv.V.(*FuncValue); ok && isGenericInstance(fv) {
+			// likewise, the parent of instances of generic
+			// functions is a file block of the current tx.
+			fv = fv.Copy(m.Alloc)
+			fv.Parent = nil // set lazily.
+			tv.V = fv
+		}
 		m.PushValue(tv)
 	case *constTypeExpr:
 		m.PopExpr()
@@ -428,12 +428,83 @@ func (m *Machine) doOpExec(op Op) {
 				panic("should not happen")
 			}
 		}
+	case OpRangeIterChan:
+		bs := s.(*bodyStmt)
+		xv := m.PeekValue(1)
+		switch bs.NextBodyIndex {
+		case -2: // init.
+			// initialize bs.
+			bs.NumOps = len(m.Ops)
+			bs.NumValues = len(m.Values)
+			bs.NumExprs = len(m.Exprs)
+			bs.NumStmts = len(m.Stmts)
+			bs.NextBodyIndex++
+			fallthrough
+		case -1: // receive and assign element.
+			ev, ok, done := m.recvChan(xv)
+			if !done {
+				// blocked: receive again once resumed.
+				return
+			}
+			if !ok { // the channel is closed.
+				m.PopFrameAndReset()
+				return
+			}
+			if bs.Key != nil {
+				switch bs.Op {
+				case ASSIGN:
+					m.PopAsPointer(bs.Key).Assign2(m.Alloc, m.Store, m.Realm, ev, false)
+				case DEFINE:
+					knx := bs.Key.(*NameExpr)
+					ptr := m.LastBlock().GetPointerToMaybeHeapDefine(m.Store, knx)
+					ptr.TV.Assign(m.Alloc, ev, false)
+				default:
+					panic("should not happen")
+				}
+			}
+			bs.NextBodyIndex++
+			fallthrough
+		default:
+			// NOTE: duplicated for OpRangeIter,
+			// with slight modification to receive next.
+			if bs.NextBodyIndex < bs.BodyLen {
+				next := bs.Body[bs.NextBodyIndex]
+				bs.NextBodyIndex++
+				// continue onto exec stmt.
+				bs.Active = next
+				s = next // switch on bs.Active
+				goto EXEC_SWITCH
+			} else if bs.NextBodyIndex == bs.BodyLen {
+				// set up next assign if needed.
+				switch bs.Op {
+				case ASSIGN:
+					if bs.Key != nil {
+						m.PushForPointer(bs.Key)
+					}
+				case DEFINE:
+					// do nothing
+				case ILLEGAL:
+					// do nothing, no assignment
+				default:
+					panic("should not happen")
+				}
+				bs.ListIndex++
+				bs.NextBodyIndex = -1
+				bs.Active = nil
+				return // redo doOpExec:*bodyStmt
+			} else {
+				panic("should not happen")
+			}
+		}
 	}
 
 EXEC_SWITCH:
 	if debug {
 		debug.Printf("EXEC: %v\n", s)
 	}
+	if m.Coverage != nil {
+		m.Coverage.hit(m, s)
+	}
 	switch cs := s.(type) {
 	case *AssignStmt:
 		switch cs.Op {
@@ -590,6 +661,8 @@ EXEC_SWITCH:
 		// TODO: replace with "cs.Op".
 		if cs.IsMap {
 			m.PushOp(OpRangeIterMap)
+		} else if cs.IsChan {
+			m.PushOp(OpRangeIterChan)
 		} else if cs.IsString {
 			m.PushOp(OpRangeIterString)
 		} else if cs.IsArrayPtr {
@@ -626,7 +699,7 @@ EXEC_SWITCH:
 			for {
 				fr := m.LastFrame()
 				switch fr.Source.(type) {
-				case *ForStmt, *RangeStmt, *SwitchStmt:
+				case *ForStmt, *RangeStmt, *SwitchStmt, *SelectStmt:
 					if cs.Label != "" && cs.Label != fr.Label {
 						m.PopFrame()
 					} else {
@@ -679,19 +752,13 @@ EXEC_SWITCH:
 			}
 
 			b := m.LastBlock()
+			// drop the names of the clause being left, so that the
+			// next clause gets fresh slots for its own names; values
+			// captured by closures live on in their heap items.
+			b.Values = b.Values[:ss.GetNumNames():ss.GetNumNames()]
 			// compute next switch clause from BodyIndex (assigned in preprocess)
 			nextClause := cs.BodyIndex + 1
-			// expand block size
-			cl := &ss.Clauses[nextClause]
-			b.ExpandWith(m.Alloc, cl)
-			// exec clause body
-			b.bodyStmt = bodyStmt{
-				Body:          cl.Body,
-				BodyLen:       len(cl.Body),
-				NextBodyIndex: -2,
-			}
-			m.PushOp(OpBody)
-			m.PushStmt(b.GetBodyStmt())
+			m.execSwitchClause(&ss.Clauses[nextClause])
 		default:
 			panic("unknown branch op")
 		}
@@ -726,6 +793,51 @@ EXEC_SWITCH:
 		// evaluate func
 		m.PushExpr(cs.Call.Func)
 		m.PushOp(OpEval)
+	case *GoStmt:
+		m.PushOp(OpGo)
+		// evaluate args
+		args := cs.Call.Args
+		for i := len(args) - 1; 0 <= i; i-- {
+			m.PushExpr(args[i])
+			m.PushOp(OpEval)
+		}
+		// evaluate func
+		m.PushExpr(cs.Call.Func)
+		m.PushOp(OpEval)
+	case *SendStmt:
+		m.PopStmt()
+		m.PushOp(OpSend)
+		// evaluate value
+		m.PushExpr(cs.Value)
+		m.PushOp(OpEval)
+		// evaluate chan
+		m.PushExpr(cs.Chan)
+		m.PushOp(OpEval)
+	case *SelectStmt:
+		m.PushOp(OpSelect)
+		if len(cs.Cases) == 0 {
+			break
+		}
+		// The channels and the values to send are evaluated in a
+		// block of the first case: they can't refer to the names
+		// defined by the cases, so it stands for all their blocks.
+		b := m.Alloc.NewBlock(&cs.Cases[0], m.LastBlock())
+		m.PushOp(OpPopBlock)
+		for i := len(cs.Cases) - 1; 0 <= i; i-- {
+			switch cc := cs.Cases[i].Comm.(type) {
+			case nil:
+				// default case
+			case *SendStmt:
+				m.PushExpr(cc.Value)
+				m.PushOp(OpEval)
+				m.PushExpr(cc.Chan)
+				m.PushOp(OpEval)
+			default:
+				m.PushExpr(selectRecvExpr(cc).X)
+				m.PushOp(OpEval)
+			}
+		}
+		m.PushBlock(b)
 	case *SwitchStmt:
 		m.PushFrameBasic(cs)
 		m.PushOp(OpPopFrameAndReset)
@@ -816,71 +928,79 @@ func (m *Machine) doOpTypeSwitch() {
 	for i := range ss.Clauses {
 		match := false
 		cs := &ss.Clauses[i]
-		if len(cs.Cases) > 0 {
-			// see if any clause cases match.
-			for _, cx := range cs.Cases {
-				if debug {
-					if !isConstType(cx) {
-						panic(fmt.Sprintf(
-							"should not happen, expected const type expr for case(s) but got %s",
-							reflect.TypeOf(cx)))
-					}
-				}
-				ct := cx.(*constTypeExpr).Type
-				if ct == nil {
-					if xv.IsUndefined() {
-						// match nil type with undefined
-						match = true
-					}
-				} else if ct.Kind() == InterfaceKind {
-					gnot := ct
-					if baseOf(gnot).(*InterfaceType).IsImplementedBy(xv.T) {
-						// match
-						match = true
-					}
-				} else {
-					ctid := TypeID("")
-					if ct != nil {
-						ctid = ct.TypeID()
-					}
-					if xtid == ctid {
-						// match
-						match = true
-					}
+		// see if any clause cases match.
+		// the default clause has none, and is
+		// only run once all other clauses failed.
+		for _, cx := range cs.Cases {
+			if debug {
+				if !isConstType(cx) {
+					panic(fmt.Sprintf(
+						"should not happen, expected const type expr for case(s) but got %s",
+						reflect.TypeOf(cx)))
 				}
 			}
-		} else { // default
-			match = true
-		}
-		if match { // did match
-			if len(cs.Body) != 0 {
-				b := m.LastBlock()
-				// remember size (from init)
-				size := len(b.Values)
-				// expand block size
-				b.ExpandWith(m.Alloc, cs)
-				// define if varname
-				if ss.VarName != "" {
-					// NOTE: assumes the var is first after size.
-					vp := NewValuePath(
-						VPBlock, 1, uint16(size), ss.VarName)
-					// NOTE: GetPointerToMaybeHeapDefine not needed,
-					// because this type is in new type switch clause block.
-					ptr := b.GetPointerTo(m.Store, vp)
-					ptr.TV.Assign(m.Alloc, *xv, false)
+			ct := cx.(*constTypeExpr).Type
+			if ct == nil {
+				if xv.IsUndefined() {
+					// match nil type with undefined
+					match = true
+				}
+			} else if ct.Kind() == InterfaceKind {
+				gnot := ct
+				if baseOf(gnot).(*InterfaceType).IsImplementedBy(xv.T) {
+					// match
+					match = true
 				}
-				// exec clause body
-				b.bodyStmt = bodyStmt{
-					Body:          cs.Body,
-					BodyLen:       len(cs.Body),
-					NextBodyIndex: -2,
+			} else {
+				ctid := TypeID("")
+				if ct != nil {
+					ctid = ct.TypeID()
+				}
+				if xtid == ctid {
+					// match
+					match = true
 				}
-				m.PushOp(OpBody)
-				m.PushStmt(b.GetBodyStmt())
 			}
+		}
+		if match { // did match
+			m.execTypeSwitchClause(ss, cs, xv)
 			return // done!
 		}
 	}
+	if dc := ss.DefaultClause(); dc >= 0 {
+		m.execTypeSwitchClause(ss, &ss.Clauses[dc], xv)
+	}
+}
+
+// execTypeSwitchClause runs the body of the type switch clause cs,
+// defining the type-switched variable to xv if any.
+func (m *Machine) execTypeSwitchClause(ss *SwitchStmt, cs *SwitchClauseStmt, xv *TypedValue) {
+	if len(cs.Body) == 0 {
+		return
+	}
+	b := m.LastBlock()
+	// remember size (from init)
+	size := len(b.Values)
+	// expand block size
+	b.ExpandWith(m.Alloc, cs)
+	// define if varname
+	if ss.VarName != "" {
+		// NOTE: assumes the var is first after size.
+		vp := NewValuePath(
+			VPBlock, 1, uint16(size), ss.VarName)
+		// NOTE: GetPointerToMaybeHeapDefine not needed,
+		// because this type is in new type switch clause block.
+		ptr := b.GetPointerTo(m.Store, vp)
+		ptr.TV.Assign(m.Alloc, *xv, false)
+	}
+	// exec clause body
+	b.bodyStmt = bodyStmt{
+		Body:          cs.Body,
+		BodyLen:       len(cs.Body),
+		NextBodyIndex: -2,
+	}
+	m.PushOp(OpBody)
+	m.PushStmt(b.GetBodyStmt())
 }
 
 func (m *Machine) doOpSwitchClause() {
@@ -890,31 +1010,22 @@ func (m *Machine) doOpSwitchClause() {
 	cliv := m.PeekValue(3) // switch clause index (reuse)
 	idx := cliv.GetInt()
 	if int(idx) >= len(ss.Clauses) {
-		// no clauses matched: do nothing.
+		// no clauses matched: run the default clause, if any.
 		m.PopStmt()  // pop switch stmt
 		m.PopValue() // pop switch tag value
 		m.PopValue() // pop clause case index
 		m.PopValue() // pop clause index
+		if dc := ss.DefaultClause(); dc >= 0 {
+			m.execSwitchClause(&ss.Clauses[dc])
+		}
 		// done!
 	} else {
 		cl := &ss.Clauses[idx]
 		if len(cl.Cases) == 0 {
-			// default clause
-			m.PopStmt()  // pop switch stmt
-			m.PopValue() // pop switch tag value
-			m.PopValue() // clause case index no longer needed
-			m.PopValue() // clause index no longer needed
-			// expand block size
-			b := m.LastBlock()
-			b.ExpandWith(m.Alloc, cl)
-			// exec clause body
-			b.bodyStmt = bodyStmt{
-				Body:          cl.Body,
-				BodyLen:       len(cl.Body),
-				NextBodyIndex: -2,
-			}
-			m.PushOp(OpBody)
-			m.PushStmt(b.GetBodyStmt())
+			// default clause: it only runs once all
+			// other clauses failed to match.
+			m.PushOp(OpSwitchClause)
+			cliv.SetInt(idx + 1)
 		} else {
 			// try to match switch clause case(s).
 			m.PushOp(OpSwitchClauseCase)
@@ -925,6 +1036,22 @@ func (m *Machine) doOpSwitchClause() {
 	}
 }
 
+// execSwitchClause runs the body of the switch clause cl,
+// in the block of the switch statement.
+func (m *Machine) execSwitchClause(cl *SwitchClauseStmt) {
+	// expand block size
+	b := m.LastBlock()
+	b.ExpandWith(m.Alloc, cl)
+	// exec clause body
+	b.bodyStmt = bodyStmt{
+		Body:          cl.Body,
+		BodyLen:       len(cl.Body),
+		NextBodyIndex: -2,
+	}
+	m.PushOp(OpBody)
+	m.PushStmt(b.GetBodyStmt())
+}
+
 func (m *Machine) doOpSwitchClauseCase() {
 	cv := m.PopValue()     // switch case value
 	tv := m.PeekValue(1)   // switch tag value
@@ -942,19 +1069,7 @@ func (m *Machine) doOpSwitchClauseCase() {
 		m.PopValue()                    // pop switch tag value
 		m.PopValue()                    // pop clause case index
 		m.PopValue()                    // pop clause index
-		// expand block size
-		clidx := cliv.GetInt()
-		cl := &ss.Clauses[clidx]
-		b := m.LastBlock()
-		b.ExpandWith(m.Alloc, cl)
-		// exec clause body
-		b.bodyStmt = bodyStmt{
-			Body:          cl.Body,
-			BodyLen:       len(cl.Body),
-			NextBodyIndex: -2,
-		}
-		m.PushOp(OpBody)
-		m.PushStmt(b.GetBodyStmt())
+		m.execSwitchClause(&ss.Clauses[cliv.GetInt()])
 	} else {
 		// try next case or clause.
 		ss := m.PeekStmt1().(*SwitchStmt) // peek switch stmt
@@ -2,6 +2,8 @@ package gnolang
 
 import (
 	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/overflow"
 )
 
 // OpBinary1 defined in op_binary.go
@@ -151,9 +153,12 @@ func (m *Machine) doOpStar() {
 		} else {
 			ro := m.IsReadonly(xv)
 			pvtv := (*pv.TV).WithReadonly(ro)
-			if xpt, ok := baseOf(xv.T).(*PointerType); ok {
+			if xpt, ok := baseOf(xv.T).(*PointerType); ok &&
+				xpt.Elt.Kind() != InterfaceKind {
 				// e.g. type Foo; type Bar;
 				// *((*Foo)(&Bar{})) should be Bar, not Foo.
+				// The value of an interface variable keeps its
+				// dynamic type.
 				pvtv.T = xpt.Elem()
 			}
 			m.PushValue(pvtv)
@@ -169,15 +174,22 @@ func (m *Machine) doOpStar() {
 	}
 }
 
-// XXX this is wrong, for var i interface{}; &i is *interface{}.
 func (m *Machine) doOpRef() {
 	rx := m.PopExpr().(*RefExpr)
 	xv, ro := m.PopAsPointer2(rx.X)
+	m.Alloc.AllocatePointer()
+	if pt, ok := rx.GetAttribute(ATTR_TYPEOF_VALUE).(*PointerType); ok && pt.Elt.Kind() == InterfaceKind {
+		// Pointer to an interface variable, see the preprocessor.
+		m.PushValue(TypedValue{
+			T: pt,
+			V: xv,
+		}.WithReadonly(ro))
+		return
+	}
 	elt := xv.TV.T
 	if elt == DataByteType {
 		elt = xv.TV.V.(DataByteValue).ElemType
 	}
-	m.Alloc.AllocatePointer()
 	m.PushValue(TypedValue{
 		T: m.Alloc.NewType(&PointerType{Elt: elt}),
 		V: xv,
@@ -716,6 +728,21 @@ func (m *Machine) doOpConvert() {
 	}
 	// END conversion checks
 
+	// Converting between strings and []byte or []rune copies the string,
+	// which costs in proportion to its length.
+	var size int
+	copying := xv.T != nil && isStringSliceConversion(xv.T.Kind(), t.Kind())
+	if copying && xv.T.Kind() == StringKind {
+		size = xv.GetLength()
+	}
+
 	ConvertTo(m.Alloc, m.Store, &xv, t, false)
+
+	if copying {
+		if xv.T.Kind() == StringKind {
+			size = xv.GetLength()
+		}
+		m.incrCPU(overflow.Mulp(m.GasTable.OpCPUPerByte[OpConvert], int64(size)))
+	}
 	m.PushValue(xv)
 }
@@ -122,5 +122,20 @@ func (m *Machine) doOpUxor() {
 }
 
 func (m *Machine) doOpUrecv() {
-	panic("not yet implemented")
+	ux := m.PeekExpr(1).(*UnaryExpr)
+	if debug {
+		debug.Printf("doOpUrecv(%v)\n", ux)
+	}
+	xv := m.PeekValue(1)
+	v, ok, done := m.recvChan(xv)
+	if !done {
+		// blocked: receive again once resumed.
+		m.PushOp(OpUrecv)
+		return
+	}
+	m.PopExpr()
+	*xv = v
+	if ux.HasOK {
+		m.PushValue(untypedBool(ok))
+	}
 }
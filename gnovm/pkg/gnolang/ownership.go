@@ -121,6 +121,8 @@ type Object interface {
 	GetIsNewDeleted() bool
 	SetIsNewDeleted(bool)
 	GetIsTransient() bool
+	GetIsPinned() bool
+	SetIsPinned(bool)
 
 	GetLastGCCycle() int64
 	SetLastGCCycle(int64)
@@ -152,6 +154,9 @@ type ObjectInfo struct {
 
 	LastObjectSize int64 //
 
+	// Object must remain attached to its realm, see Realm.Pin.
+	IsPinned bool `json:",omitempty"`
+
 	// MemRefCount int // consider for optimizations.
 	// Object has been modified and needs to be saved
 	isDirty bool
@@ -182,6 +187,7 @@ func (oi *ObjectInfo) Copy() ObjectInfo {
 		RefCount:       oi.RefCount,
 		IsEscaped:      oi.IsEscaped,
 		LastObjectSize: oi.LastObjectSize,
+		IsPinned:       oi.IsPinned,
 		isDirty:        oi.isDirty,
 		isDeleted:      oi.isDeleted,
 		isNewReal:      oi.isNewReal,
@@ -350,6 +356,14 @@ func (oi *ObjectInfo) SetIsNewDeleted(x bool) {
 	oi.isNewDeleted = x
 }
 
+func (oi *ObjectInfo) GetIsPinned() bool {
+	return oi.IsPinned
+}
+
+func (oi *ObjectInfo) SetIsPinned(x bool) {
+	oi.IsPinned = x
+}
+
 func (oi *ObjectInfo) GetLastGCCycle() int64 {
 	return oi.lastGCCycle
 }
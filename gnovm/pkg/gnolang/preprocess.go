@@ -163,6 +163,9 @@ func PredefineFileSet(store Store, pn *PackageNode, fset *FileSet) {
 			}
 		}
 	}
+	// Preprocess generic instances
+	// deferred until all were predefined.
+	preprocessPendingGenerics(store, pn)
 }
 
 // Initialize static blocks, and also reserves all names.
@@ -244,9 +247,13 @@ func initStaticBlocks(store Store, ctx BlockNode, nn Node) {
 				last2 := skipFile(last)
 				nx := &n.NameExpr
 				nx.Type = NameExprTypeDefine
-				last2.Reserve(true, nx, n, NSTypeDecl, -1)
+				// generic types are not constant type values
+				// (see predefineGeneric).
+				last2.Reserve(!n.IsGeneric(), nx, n, NSTypeDecl, -1)
 			case *FuncDecl:
-				if n.IsMethod {
+				if n.HasAttribute(ATTR_GENERIC_INSTANCE) {
+					// instances are not named in the package block.
+				} else if n.IsMethod {
 					if n.Recv.Name == "" || n.Recv.Name == blankIdentifier {
 						// create a hidden var with leading dot.
 						// NOTE: document somewhere.
@@ -667,6 +674,14 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 					n.IsMap = true
 				case StringKind:
 					n.IsString = true
+				case ChanKind:
+					if baseOf(xt).(*ChanType).Dir == SEND {
+						panic(fmt.Sprintf("invalid operation: range %s receive from send-only channel %s", n.X, xt))
+					}
+					if n.Value != nil {
+						panic(fmt.Sprintf("range over %s permits only one iteration variable", n.X))
+					}
+					n.IsChan = true
 				case PointerKind:
 					if xt.Elem().Kind() != ArrayKind {
 						panic("range iteration over pointer requires array elem type")
@@ -687,6 +702,12 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 							vn := n.Value.(*NameExpr).Name
 							last.Define(vn, anyValue(vt))
 						}
+					} else if xt.Kind() == ChanKind {
+						if n.Key != nil {
+							et := baseOf(xt).(*ChanType).Elt
+							kn := n.Key.(*NameExpr).Name
+							last.Define(kn, anyValue(et))
+						}
 					} else if xt.Kind() == StringKind {
 						if n.Key != nil {
 							it := IntType
@@ -959,6 +980,9 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 							n.Decls[i] = d
 						}
 					}
+					// Preprocess generic instances
+					// deferred until all were predefined.
+					preprocessPendingGenerics(store, ctxpn)
 				}
 
 			// TRANS_BLOCK -----------------------
@@ -1146,6 +1170,10 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 					nt := evalStaticTypeOf(store, last, n)
 					if nt == nil {
 						// this is fine, e.g. for TRANS_ASSIGN_LHS (define) etc.
+					} else if gt, ok := nt.(*genericType); ok {
+						// generic names must be instantiated,
+						// see *IndexExpr and *CallExpr.
+						assertGenericUse(gt, ftype)
 					} else if nt.Kind() == PackageKind {
 						// If name refers to a package, and this is not in
 						// the context of a selector, fail. Packages cannot
@@ -1328,6 +1356,11 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 				}
 			// TRANS_LEAVE -----------------------
 			case *CallExpr:
+				// Instantiate generic function, inferring
+				// any missing type arguments.
+				if fx := instantiateCall(store, last, n); fx != nil {
+					n.Func = fx
+				}
 				// Func type evaluation.
 				nft := evalStaticTypeOf(store, last, n.Func)
 				switch bnft := baseOf(nft).(type) {
@@ -1856,6 +1889,10 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 			// TRANS_LEAVE -----------------------
 			case *IndexExpr:
 				dt := evalStaticTypeOf(store, last, n.X)
+				if gt, ok := dt.(*genericType); ok {
+					// Instantiate generic function or type.
+					return instantiateExpr(store, last, ftype, n, gt, Exprs{n.Index}), TRANS_CONTINUE
+				}
 				if dt.Kind() == PointerKind {
 					// if a is a pointer to an array,
 					// a[low : high : max] is shorthand
@@ -1878,6 +1915,16 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 						dt.String()))
 				}
 
+			// TRANS_LEAVE -----------------------
+			case *IndexListExpr:
+				gt, ok := evalStaticTypeOf(store, last, n.X).(*genericType)
+				if !ok {
+					// only generics take multiple indices.
+					panic("invalid operation: more than one index")
+				}
+				// Instantiate generic function or type.
+				return instantiateExpr(store, last, ftype, n, gt, n.Indices), TRANS_CONTINUE
+
 			// TRANS_LEAVE -----------------------
 			case *SliceExpr:
 				// Replace const L/H/M with int *ConstExpr,
@@ -2006,6 +2053,15 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 				// NOTE: For simplicity we just
 				// use the *CompositeLitExpr.
 			// TRANS_LEAVE -----------------------
+			case *RefExpr:
+				// The value of an interface variable carries its
+				// dynamic type, so the type of a pointer to it
+				// can't be derived at runtime; keep it for OpRef.
+				xt := evalStaticTypeOf(store, last, n.X)
+				if xt != nil && xt.Kind() == InterfaceKind {
+					n.SetAttribute(ATTR_TYPEOF_VALUE, &PointerType{Elt: xt})
+				}
+			// TRANS_LEAVE -----------------------
 			case *StarExpr:
 				xt := evalStaticTypeOf(store, last, n.X)
 				if xt == nil {
@@ -2131,6 +2187,10 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 					// packages may contain constant vars,
 					// so check and evaluate if so.
 					tt := pn.GetStaticTypeOfAt(store, n.Path)
+					if gt, ok := tt.(*genericType); ok {
+						assertGenericUse(gt, ftype)
+						return n, TRANS_CONTINUE
+					}
 
 					// Produce a constant expression for both typed and untyped constants.
 					if isUntyped(tt) || pn.GetIsConstAt(store, n.Path) {
@@ -2141,6 +2201,11 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 				case *TypeType:
 					// unbound method
 					xt := evalStaticType(store, last, n.X)
+					// promoted, interface and (*T).ValMethod method
+					// expressions are desugared into a func literal.
+					if fx := methodExprFuncLit(store, last, n, xt); fx != nil {
+						return Preprocess(store, last, fx), TRANS_CONTINUE
+					}
 					switch ct := xt.(type) {
 					case *PointerType:
 						dt := ct.Elt.(*DeclaredType)
@@ -2162,6 +2227,12 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 			case *FieldTypeExpr:
 				// Replace const Tag with default *ConstExpr.
 				convertIfConst(store, last, n, n.Tag)
+				// Constraints may only be embedded in interfaces.
+				if len(ns) == 0 {
+					// e.g. receiver, see tryPredefine.
+				} else if _, ok := ns[len(ns)-1].(*InterfaceTypeExpr); !ok {
+					assertNotConstraint(n.Type)
+				}
 
 			// TRANS_LEAVE -----------------------
 			case *ArrayTypeExpr:
@@ -2184,8 +2255,18 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 
 			// TRANS_LEAVE -----------------------
 			case *InterfaceTypeExpr:
+				if isConstraintTypeExpr(store, last, n) {
+					// type sets are only known to the preprocessor.
+					it := evalConstraintType(store, last, n)
+					return toConstTypeExpr(last, n, it), TRANS_CONTINUE
+				}
 				evalStaticType(store, last, n)
 
+			// TRANS_LEAVE -----------------------
+			case *UnionTypeExpr:
+				it := evalUnionType(store, last, n)
+				return toConstTypeExpr(last, n, it), TRANS_CONTINUE
+
 			// TRANS_LEAVE -----------------------
 			case *ChanTypeExpr:
 				evalStaticType(store, last, n)
@@ -2342,7 +2423,7 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 					} else {
 						// Make sure that the label exists, either for a switch or a
 						// BranchStmt.
-						if !isSwitchLabel(ns, n.Label) {
+						if !isSwitchLabel(ns, n.Label) && !isSelectLabel(ns, n.Label) {
 							findBranchLabel(last, n.Label)
 						}
 					}
@@ -2350,13 +2431,14 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 					if n.Label == "" {
 						findContinuableNode(last, store)
 					} else {
-						if isSwitchLabel(ns, n.Label) {
+						if isSwitchLabel(ns, n.Label) || isSelectLabel(ns, n.Label) {
 							panic(fmt.Sprintf("invalid continue label %q\n", n.Label))
 						}
 						findBranchLabel(last, n.Label)
 					}
 				case GOTO:
-					_, blockDepth, frameDepth, index := findGotoLabel(last, n.Label)
+					bn, blockDepth, frameDepth, index := findGotoLabel(last, n.Label)
+					checkGotoDecls(bn, n, index)
 					n.BlockDepth = blockDepth
 					n.FrameDepth = frameDepth
 					n.BodyIndex = index
@@ -2485,8 +2567,16 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 
 			// TRANS_LEAVE -----------------------
 			case *SendStmt:
-				// Value consts become default *ConstExprs.
-				checkOrConvertType(store, last, n, &n.Value, nil, false)
+				xt := evalStaticTypeOf(store, last, n.Chan)
+				ct, ok := baseOf(xt).(*ChanType)
+				if !ok {
+					panic(fmt.Sprintf("invalid operation: cannot send to non-channel %s (variable of type %s)", n.Chan, xt))
+				}
+				if ct.Dir == RECV {
+					panic(fmt.Sprintf("invalid operation: cannot send to receive-only channel %s (variable of type %s)", n.Chan, xt))
+				}
+				// Value consts become *ConstExprs of the elem type.
+				checkOrConvertType(store, last, n, &n.Value, ct.Elt, false)
 
 			// TRANS_LEAVE -----------------------
 			case *SelectCaseStmt:
@@ -2522,6 +2612,7 @@ func preprocess1(store Store, ctx BlockNode, n Node) Node {
 			// TRANS_LEAVE -----------------------
 			case *ValueDecl:
 				assertValidAssignRhs(store, last, n)
+				assertNotConstraint(n.Type)
 
 				// evaluate value if const expr.
 				if n.Const {
@@ -2772,6 +2863,7 @@ func parseAssignFromExprList(
 // - a, b, c := f()
 // - a, b := n.(T)
 // - a, b := n[i], where n is a map
+// - a, b := <-ch
 func parseMultipleAssignFromOneExpr(
 	store Store,
 	bn BlockNode,
@@ -2810,6 +2902,20 @@ func parseMultipleAssignFromOneExpr(
 		}
 		tuple = &tupleType{Elts: []Type{mt.Value, BoolType}}
 		expr.HasOK = true
+	case *UnaryExpr:
+		// Receive case:
+		// var a, b = <-ch
+		// a, b := <-ch
+		if expr.Op != ARROW {
+			panic(fmt.Sprintf("unexpected value expression %s", expr))
+		}
+		dt := evalStaticTypeOf(store, bn, expr.X)
+		ct, ok := baseOf(dt).(*ChanType)
+		if !ok {
+			panic(fmt.Sprintf("invalid receive expression on %T", dt))
+		}
+		tuple = &tupleType{Elts: []Type{ct.Elt, BoolType}}
+		expr.HasOK = true
 	default:
 		panic(fmt.Sprintf("unexpected value expression type %T", expr))
 	}
@@ -3484,6 +3590,18 @@ func isSwitchLabel(ns []Node, label Name) bool {
 	return false
 }
 
+func isSelectLabel(ns []Node, label Name) bool {
+	if label == "" {
+		return false
+	}
+	for _, n := range ns {
+		if ss, ok := n.(*SelectStmt); ok && ss.GetLabel() == label {
+			return true
+		}
+	}
+	return false
+}
+
 // Idempotent.
 // Also makes sure the stack doesn't reach MaxUint8 in length.
 func pushInitBlock(bn BlockNode, last *BlockNode, stack *[]BlockNode) {
@@ -3907,6 +4025,8 @@ func findBreakableNode(last BlockNode, store Store) {
 			return
 		case *SwitchClauseStmt:
 			return
+		case *SelectCaseStmt:
+			return
 		}
 
 		last = last.GetParentNode(store)
@@ -3968,6 +4088,33 @@ func findBranchLabel(last BlockNode, label Name) (
 	}
 }
 
+// checkGotoDecls panics if the goto statement n, jumping to the statement
+// at bodyIdx of bn, would skip over a variable declaration of that body;
+// the spec forbids a goto from bringing new variables into scope.
+func checkGotoDecls(bn BlockNode, n *BranchStmt, bodyIdx int) {
+	for _, stmt := range bn.GetBody()[:bodyIdx] {
+		if stmt.GetPos().Compare(n.GetPos()) <= 0 {
+			continue
+		}
+		declared := false
+		switch s := stmt.(type) {
+		case *AssignStmt:
+			declared = s.Op == DEFINE
+		case *DeclStmt:
+			for _, d := range s.Body {
+				if vd, ok := d.(*ValueDecl); ok && !vd.Const {
+					declared = true
+				}
+			}
+		}
+		if declared {
+			panic(fmt.Sprintf(
+				"goto %s jumps over variable declaration at line %d",
+				n.Label, stmt.GetLine()))
+		}
+	}
+}
+
 func findGotoLabel(last BlockNode, label Name) (
 	bn BlockNode, blockDepth uint8, frameDepth uint8, bodyIdx int,
 ) {
@@ -3991,7 +4138,7 @@ func findGotoLabel(last BlockNode, label Name) (
 					"cannot find GOTO label %q within current function",
 					label))
 			}
-		case *ForStmt, *RangeStmt, *SwitchClauseStmt:
+		case *ForStmt, *RangeStmt, *SelectCaseStmt, *SwitchClauseStmt:
 			body := cbn.GetBody()
 			_, bodyIdx = body.GetLabeledStmt(label)
 			if bodyIdx != -1 {
@@ -4002,7 +4149,7 @@ func findGotoLabel(last BlockNode, label Name) (
 				frameDepth += 1
 				blockDepth = 0 // reset
 			}
-		case *IfCaseStmt, *SelectCaseStmt, *BlockStmt:
+		case *IfCaseStmt, *BlockStmt:
 			body := cbn.GetBody()
 			_, bodyIdx = body.GetLabeledStmt(label)
 			if bodyIdx != -1 {
@@ -4532,6 +4679,24 @@ func findUndefinedAny(store Store, last BlockNode, x Expr, stack []Name, definin
 		if un != "" {
 			return
 		}
+	case *IndexListExpr:
+		un, directR = findUndefinedV(store, last, cx.X, stack, defining, direct, nil)
+		if un != "" {
+			return
+		}
+		for i := range cx.Indices {
+			un, directR = findUndefinedV(store, last, cx.Indices[i], stack, defining, direct, nil)
+			if un != "" {
+				return
+			}
+		}
+	case *UnionTypeExpr:
+		for i := range cx.Terms {
+			un, directR = findUndefinedT(store, last, cx.Terms[i], stack, defining, isalias, direct)
+			if un != "" {
+				return
+			}
+		}
 	case *constTypeExpr:
 		return
 	case *ConstExpr:
@@ -4677,6 +4842,11 @@ func predefineRecursively2(store Store, last BlockNode, d Decl, stack []Name, de
 			break // predefine successfully performed.
 		}
 	}
+	if isGenericDecl(d) {
+		// generic declarations are only preprocessed
+		// as instances (see instantiate).
+		return true
+	}
 	switch cd := d.(type) {
 	case *FuncDecl:
 		// We cannot Preprocess the body of *FuncDecl as it may
@@ -4717,6 +4887,11 @@ func tryPredefine(store Store, pkg *PackageNode, last BlockNode, d Decl, stack [
 		}
 	}()
 
+	if isGenericDecl(d) {
+		predefineGeneric(store, last, d)
+		return "", false, false
+	}
+
 	// NOTE: These happen upon enter from the top,
 	// so value paths cannot be used here.
 	switch d := d.(type) {
@@ -4878,6 +5053,16 @@ func tryPredefine(store Store, pkg *PackageNode, last BlockNode, d Decl, stack [
 				tx.Path = pn.GetPathForName(store, tx.Sel)
 				ptr := pv.GetBlock(store).GetPointerTo(store, tx.Path)
 				t = ptr.TV.GetType()
+			case *IndexExpr, *IndexListExpr:
+				// instance of a generic type.
+				un, directR = findUndefinedAny(
+					store, last, tx, stack, defining, d.IsAlias, direct, true, nil)
+				if un != "" {
+					untype = true
+					return
+				}
+				d.Type = Preprocess(store, last, tx).(Expr)
+				t = evalStaticType(store, last, d.Type)
 			default:
 				panic(fmt.Sprintf(
 					"unexpected type declaration type %v",
@@ -5207,6 +5392,85 @@ func elideCompositeElements(last BlockNode, clx *CompositeLitExpr, clt Type) {
 	}
 }
 
+// methodExprFuncLit returns a func literal equivalent to the method
+// expression n (of the form T.Method or (*T).Method), when the method is not
+// declared directly on T with a matching receiver: that is, when it is
+// promoted from an embedded field, when T is an interface type, or when T is
+// a pointer and the method has a value receiver.
+//
+//	T.Method -> func(.rcv T, .arg0 A0, ...) (R0, ...) { return .rcv.Method(.arg0, ...) }
+//
+// It returns nil if n is a plain unbound method, which is resolved with
+// *DeclaredType.GetUnboundPathForName().
+func methodExprFuncLit(store Store, last BlockNode, n *SelectorExpr, xt Type) *FuncLitExpr {
+	// find direct methods.
+	dt, _ := xt.(*DeclaredType)
+	isPtr := false
+	if pt, ok := xt.(*PointerType); ok {
+		dt, _ = pt.Elt.(*DeclaredType)
+		isPtr = true
+	}
+	if dt != nil {
+		for _, mv := range dt.Methods {
+			if fv := mv.GetFunc(); fv.Name == n.Sel {
+				ft := fv.GetType(store)
+				if !isPtr || ft.HasPointerReceiver() || ft.BoundType().IsCrossing() {
+					return nil
+				}
+				// (*T).ValMethod
+				return methodExprFuncLit1(last, n, xt, ft.BoundType())
+			}
+		}
+	}
+	// find promoted or interface methods.
+	tr, _, _, ft, _ := findEmbeddedFieldType(packageOf(last).PkgPath, xt, n.Sel, nil)
+	if tr == nil {
+		return nil // let GetUnboundPathForName() report the error.
+	}
+	bft, ok := ft.(*FuncType)
+	if !ok || bft.IsCrossing() {
+		return nil
+	}
+	return methodExprFuncLit1(last, n, xt, bft)
+}
+
+func methodExprFuncLit1(last BlockNode, n *SelectorExpr, xt Type, bft *FuncType) *FuncLitExpr {
+	typeExpr := func(t Type) Expr {
+		tx := Nx(".methodexpr")
+		tx.SetSpan(n.GetSpan())
+		return toConstTypeExpr(last, tx, t)
+	}
+	params := FieldTypeExprs{
+		{NameExpr: *Nx(".rcv"), Type: typeExpr(xt)},
+	}
+	args := make(Exprs, len(bft.Params))
+	for i, p := range bft.Params {
+		name := Name(fmt.Sprintf(".arg%d", i))
+		params = append(params, FieldTypeExpr{NameExpr: *Nx(name), Type: typeExpr(p.Type)})
+		args[i] = Nx(name)
+	}
+	results := make(FieldTypeExprs, len(bft.Results))
+	for i, r := range bft.Results {
+		results[i] = FieldTypeExpr{Type: typeExpr(r.Type)}
+	}
+	call := &CallExpr{
+		Func: &SelectorExpr{X: Nx(".rcv"), Sel: n.Sel},
+		Args: args,
+		Varg: bft.HasVarg(),
+	}
+	var body []Stmt
+	if len(results) == 0 {
+		body = []Stmt{&ExprStmt{X: call}}
+	} else {
+		body = []Stmt{&ReturnStmt{Results: Exprs{call}}}
+	}
+	fx := Fn(params, results, body)
+	fx.SetSpan(n.GetSpan())
+	setNodeLines(fx)
+	setNodeLocations(packageOf(last).PkgPath, fileNameOf(last), fx)
+	return fx
+}
+
 // if *vx is composite lit type, fill in elided type.
 // if composite type is pointer type, replace composite
 // expression with ref expr.
@@ -5255,6 +5519,10 @@ func countNumArgs(store Store, last BlockNode, n *CallExpr) (numArgs int) {
 // to determine the order of var decl execution
 // (which may include functions which may refer to package vars).
 func findDependentNames(n Node, dst map[Name]struct{}) {
+	if isGenericDecl(n) {
+		// generic declarations are never run, only their instances.
+		return
+	}
 	switch cn := n.(type) {
 	case *NameExpr:
 		dst[cn.Name] = struct{}{}
@@ -5341,6 +5609,11 @@ func findDependentNames(n Node, dst map[Name]struct{}) {
 	case *IndexExpr:
 		findDependentNames(cn.X, dst)
 		findDependentNames(cn.Index, dst)
+	case *IndexListExpr:
+		findDependentNames(cn.X, dst)
+		for i := range cn.Indices {
+			findDependentNames(cn.Indices[i], dst)
+		}
 	case *FuncLitExpr:
 		findDependentNames(&cn.Type, dst)
 		for _, n := range cn.GetExternNames() {
@@ -132,6 +132,7 @@ type Realm struct {
 	newCreated []Object
 	newDeleted []Object
 	newEscaped []Object
+	newPinned  []Object
 
 	created []Object // about to become real.
 	updated []Object // real objects that were modified.
@@ -339,6 +340,49 @@ func (rlm *Realm) MarkNewEscaped(oo Object) {
 	rlm.newEscaped = append(rlm.newEscaped, oo)
 }
 
+//----------------------------------------
+// pinning
+
+// Pin declares that oo must remain attached to the realm. The realm
+// transaction fails if oo isn't attached at its end, or if it becomes
+// detached in a later transaction, until it is unpinned.
+// The caller must ensure oo isn't an object of another realm.
+func (rlm *Realm) Pin(oo Object) {
+	if oo.GetIsPinned() {
+		return // already pinned.
+	}
+	oo.SetIsPinned(true)
+	if oo.GetIsReal() {
+		rlm.MarkDirty(oo)
+	}
+	rlm.newPinned = append(rlm.newPinned, oo)
+}
+
+// Unpin removes the pin of oo, which may then be detached from the realm.
+func (rlm *Realm) Unpin(oo Object) {
+	if !oo.GetIsPinned() {
+		return // not pinned.
+	}
+	oo.SetIsPinned(false)
+	if oo.GetIsReal() {
+		rlm.MarkDirty(oo)
+	}
+}
+
+// processNewPinnedMarks fails if an object pinned in this transaction
+// isn't attached to the realm. Pinned objects which were already real are
+// checked when they get deleted, see decRefDeletedDescendants.
+// Must run *after* processNewDeletedMarks().
+func (rlm *Realm) processNewPinnedMarks() {
+	for _, oo := range rlm.newPinned {
+		if oo.GetIsPinned() && oo.GetRefCount() == 0 {
+			panic(fmt.Sprintf(
+				"pinned object of type %T is not attached to realm %s",
+				oo, rlm.Path))
+		}
+	}
+}
+
 //----------------------------------------
 // transactions
 
@@ -373,6 +417,8 @@ func (rlm *Realm) FinalizeRealmTransaction(store Store) {
 	// decrement recursively for deleted descendants.
 	rlm.processNewDeletedMarks(store)
 	// at this point, all ref-counts are final.
+	// fail if a newly pinned object isn't attached.
+	rlm.processNewPinnedMarks()
 	// demote any escaped if ref-count is 1.
 	rlm.processNewEscapedMarks(store, 0)
 	// given created and updated objects,
@@ -398,6 +444,57 @@ func (rlm *Realm) FinalizeRealmTransaction(store Store) {
 	rlm.sumDiff = 0
 }
 
+// Rollback discards the pending writes of the realm, i.e. the updates
+// since it was last finalized, when a panic leaves it: real objects are
+// restored in place from the store, and new objects become unreal again.
+func (rlm *Realm) Rollback(store Store) {
+	if bm.OpsEnabled {
+		bm.PauseOpCode()
+		defer bm.ResumeOpCode()
+	}
+
+	// log realm boundaries in opslog.
+	store.LogRollbackRealm(rlm.Path)
+	for _, lst := range [][]Object{
+		rlm.newCreated,
+		rlm.newEscaped,
+		rlm.newDeleted,
+		rlm.newPinned,
+		rlm.updated,
+	} {
+		for _, oo := range lst {
+			if oo.GetIsReal() {
+				// restoring clears the marks, restore only once.
+				if oo.GetIsDirty() || oo.GetIsNewEscaped() || oo.GetIsNewDeleted() {
+					store.RestoreObject(oo)
+				}
+				continue
+			}
+			// unreal objects only get attached to real ones.
+			oo.SetIsNewReal(false)
+			oo.SetIsNewEscaped(false)
+			oo.SetIsPinned(false)
+			oo.SetOwner(nil)
+			oo.GetObjectInfo().RefCount = 0
+		}
+	}
+	rlm.newCreated = nil
+	rlm.newEscaped = nil
+	rlm.newDeleted = nil
+	rlm.newPinned = nil
+	rlm.updated = nil
+}
+
+// hasPendingMarks returns true if the realm has updates that were not
+// finalized yet.
+func (rlm *Realm) hasPendingMarks() bool {
+	return len(rlm.newCreated) != 0 ||
+		len(rlm.newEscaped) != 0 ||
+		len(rlm.newDeleted) != 0 ||
+		len(rlm.newPinned) != 0 ||
+		len(rlm.updated) != 0
+}
+
 //----------------------------------------
 // processNewCreatedMarks
 
@@ -551,6 +648,11 @@ func (rlm *Realm) decRefDeletedDescendants(store Store, oo Object) {
 	if oo.GetIsDeleted() {
 		return
 	}
+	if oo.GetIsPinned() {
+		panic(fmt.Sprintf(
+			"pinned object %s would be detached from realm %s",
+			oo.GetObjectID(), rlm.Path))
+	}
 	oo.SetIsNewDeleted(false)
 	oo.SetIsNewReal(false)
 	oo.SetIsNewEscaped(false)
@@ -870,6 +972,7 @@ func (rlm *Realm) clearMarks() {
 	rlm.newCreated = nil
 	rlm.newEscaped = nil
 	rlm.newDeleted = nil
+	rlm.newPinned = nil
 	rlm.created = nil
 	rlm.updated = nil
 	rlm.deleted = nil
@@ -906,6 +1009,8 @@ func getChildObjects(val Value, more []Value) []Value {
 		return more
 	case DataByteValue:
 		panic("cannot get children from data byte objects")
+	case *ChanValue:
+		panic("cannot persist channel values")
 	case PointerValue:
 		if cv.Base == nil {
 			panic("should not happen")
@@ -1053,6 +1158,20 @@ func copyFieldsWithRefs(fields []FieldType) []FieldType {
 	return fieldsCpy
 }
 
+func copyTermsWithRefs(terms []TypeTerm) []TypeTerm {
+	if terms == nil {
+		return nil
+	}
+	termsCpy := make([]TypeTerm, len(terms))
+	for i, term := range terms {
+		termsCpy[i] = TypeTerm{
+			Tilde: term.Tilde,
+			Type:  refOrCopyType(term.Type),
+		}
+	}
+	return termsCpy
+}
+
 // Copies type but with references to dependant types;
 // the result is suitable for persistence bytes serialization.
 func copyTypeWithRefs(typ Type) Type {
@@ -1098,6 +1217,7 @@ func copyTypeWithRefs(typ Type) Type {
 			PkgPath: ct.PkgPath,
 			Methods: copyFieldsWithRefs(ct.Methods),
 			Generic: ct.Generic,
+			Terms:   copyTermsWithRefs(ct.Terms),
 		}
 	case *TypeType:
 		return &TypeType{}
@@ -1107,10 +1227,11 @@ func copyTypeWithRefs(typ Type) Type {
 			return RefType{ID: ct.TypeID()}
 		}
 		dt := &DeclaredType{
-			PkgPath: ct.PkgPath,
-			Name:    ct.Name,
-			Base:    copyTypeWithRefs(ct.Base),
-			Methods: copyMethods(ct.Methods),
+			PkgPath:   ct.PkgPath,
+			Name:      ct.Name,
+			ParentLoc: ct.ParentLoc,
+			Base:      copyTypeWithRefs(ct.Base),
+			Methods:   copyMethods(ct.Methods),
 		}
 		return dt
 	case *PackageType:
@@ -1161,6 +1282,8 @@ func copyValueWithRefs(val Value) Value {
 		return cv
 	case DataByteValue:
 		panic("cannot copy data byte value with references")
+	case *ChanValue:
+		panic("cannot persist channel values")
 	case PointerValue:
 		if cv.Base == nil {
 			panic("should not happen")
@@ -1352,6 +1475,9 @@ func fillType(store Store, typ Type) Type {
 		for i, mthd := range ct.Methods {
 			ct.Methods[i].Type = fillType(store, mthd.Type)
 		}
+		for i, term := range ct.Terms {
+			ct.Terms[i].Type = fillType(store, term.Type)
+		}
 		return ct
 	case *TypeType:
 		return ct // nothing to do
@@ -4,6 +4,7 @@ import (
 	"fmt"
 	"io"
 	"iter"
+	"maps"
 	"reflect"
 	"slices"
 	"strconv"
@@ -47,6 +48,7 @@ type Store interface {
 	SetPackageRealm(*Realm)
 	GetObject(oid ObjectID) Object
 	GetObjectSafe(oid ObjectID) Object
+	RestoreObject(Object)   // discards the updates of a real object not saved yet
 	SetObject(Object) int64 // returns size difference of the object
 	GetStagingPackage() *PackageValue
 	SetStagingPackage(pv *PackageValue)
@@ -64,6 +66,7 @@ type Store interface {
 	// UNSTABLE
 	GetAllocator() *Allocator
 	SetAllocator(alloc *Allocator)
+	SetCodeStore(codeStore store.Store) // for mempackages; defaults to the iavl store
 	NumMemPackages() int64
 	// Upon restart, all packages will be re-preprocessed; This
 	// loads BlockNodes and Types onto the store for persistence
@@ -74,11 +77,19 @@ type Store interface {
 	FindPathsByPrefix(prefix string) iter.Seq[string]
 	IterMemPackage() <-chan *std.MemPackage
 	ClearObjectCache() // run before processing a message
+	ResetObjectCache() // run before processing a transaction
 	GarbageCollectObjectCache(gcCycle int64)
 	SetNativeResolver(NativeResolver)                     // for native functions
 	GetNative(pkgPath string, name Name) func(m *Machine) // for native functions
+	GetGasTable() *GasTable
+	SetGasTable(*GasTable) // for gas prices of store operations and machines
 	SetLogStoreOps(dst io.Writer)
 	LogFinalizeRealm(rlmpath string) // to mark finalization of realm boundaries
+	LogRollbackRealm(rlmpath string) // to mark realm boundaries left by a panic
+
+	CacheWrapCall() (write, discard func()) // for the calls crossing realm boundaries
+	WithCallerStores(n int, fn func())      // runs fn with the stores before the n last calls
+
 	Print()
 }
 
@@ -138,9 +149,11 @@ type defaultStore struct {
 	// underlying stores used to keep data
 	baseStore store.Store // for objects, types, nodes
 	iavlStore store.Store // for escaped object hashes
+	codeStore store.Store // for mempackages
 
 	// transaction-scoped
 	cacheObjects map[ObjectID]Object            // this is a real cache, reset with every transaction.
+	txObjects    map[ObjectID]Object            // the objects of the transaction, kept across messages; nil if not a transaction store.
 	cacheTypes   txlog.Map[TypeID, Type]        // this re-uses the parent store's.
 	cacheNodes   txlog.Map[Location, BlockNode] // until BlockNode persistence is implemented, this is an actual store.
 	alloc        *Allocator                     // for accounting for cached items
@@ -158,17 +171,21 @@ type defaultStore struct {
 	current []string  // for detecting import cycles.
 
 	// gas
-	gasMeter  store.GasMeter
-	gasConfig GasConfig
+	gasMeter store.GasMeter
+	gasTable *GasTable
 
 	// realm storage changes on message level.
 	realmStorageDiffs map[string]int64 // maps realm path to size diff
+
+	// the writes of the current call crossing a realm boundary, if any.
+	call *callCache
 }
 
 func NewStore(alloc *Allocator, baseStore, iavlStore store.Store) *defaultStore {
 	ds := &defaultStore{
 		baseStore: baseStore,
 		iavlStore: iavlStore,
+		codeStore: iavlStore,
 		alloc:     alloc,
 
 		// cacheObjects is set; objects in the store will be copied over for any transaction.
@@ -182,27 +199,34 @@ func NewStore(alloc *Allocator, baseStore, iavlStore store.Store) *defaultStore
 		// store configuration
 		pkgGetter:      nil,
 		nativeResolver: nil,
-		gasConfig:      DefaultGasConfig(),
+		gasTable:       DefaultGasTable(),
 	}
 	InitStoreCaches(ds)
 	return ds
 }
 
 // If nil baseStore and iavlStore, the baseStores are re-used.
+// A non-nil iavlStore is also used as the code store, unless
+// SetCodeStore is called on the returned store.
 func (ds *defaultStore) BeginTransaction(baseStore, iavlStore store.Store, gasMeter store.GasMeter) TransactionStore {
 	if baseStore == nil {
 		baseStore = ds.baseStore
 	}
+	codeStore := ds.codeStore
 	if iavlStore == nil {
 		iavlStore = ds.iavlStore
+	} else {
+		codeStore = iavlStore
 	}
 	ds2 := &defaultStore{
 		// underlying stores
 		baseStore: baseStore,
 		iavlStore: iavlStore,
+		codeStore: codeStore,
 
 		// transaction-scoped
 		cacheObjects: make(map[ObjectID]Object),
+		txObjects:    make(map[ObjectID]Object),
 		cacheTypes:   txlog.Wrap(ds.cacheTypes),
 		cacheNodes:   txlog.Wrap(ds.cacheNodes),
 		alloc:        ds.alloc.Fork().Reset(),
@@ -212,8 +236,8 @@ func (ds *defaultStore) BeginTransaction(baseStore, iavlStore store.Store, gasMe
 		nativeResolver: ds.nativeResolver,
 
 		// gas meter
-		gasMeter:  gasMeter,
-		gasConfig: ds.gasConfig,
+		gasMeter: gasMeter,
+		gasTable: ds.gasTable,
 
 		// transient
 		current: nil,
@@ -243,6 +267,17 @@ func (t transactionStore) Write() {
 // 	panic("Go2GnoType may not be called in a transaction store")
 // }
 
+func (ds *defaultStore) GetGasTable() *GasTable {
+	return ds.gasTable
+}
+
+// SetGasTable sets the gas table of the store, and of the machines using it.
+// It can be called on a transaction store, so each transaction uses the
+// prices in effect when it starts.
+func (ds *defaultStore) SetGasTable(gt *GasTable) {
+	ds.gasTable = gt
+}
+
 func (transactionStore) SetNativeResolver(ns NativeResolver) {
 	panic("SetNativeResolver may not be called in a transaction store")
 }
@@ -259,7 +294,11 @@ func CopyFromCachedStore(destStore, cachedStore Store, cachedBase, cachedIavl st
 	}
 	iter = cachedIavl.Iterator(nil, nil)
 	for ; iter.Valid(); iter.Next() {
-		ds.iavlStore.Set(iter.Key(), iter.Value())
+		if isBackendPackagePathKey(iter.Key()) {
+			ds.codeStore.Set(iter.Key(), iter.Value())
+		} else {
+			ds.iavlStore.Set(iter.Key(), iter.Value())
+		}
 	}
 
 	for k, v := range ss.cacheTypes.Iterate() {
@@ -278,6 +317,12 @@ func (ds *defaultStore) SetAllocator(alloc *Allocator) {
 	ds.alloc = alloc
 }
 
+// SetCodeStore sets the store holding the mempackages, so that package code
+// can be kept apart from the escaped object hashes in the iavl store.
+func (ds *defaultStore) SetCodeStore(codeStore store.Store) {
+	ds.codeStore = codeStore
+}
+
 // Used by cmd/gno (e.g. lint) to inject target package as MPTest.
 func (ds *defaultStore) GetPackageGetter() (pg PackageGetter) {
 	return ds.pkgGetter
@@ -342,6 +387,9 @@ func (ds *defaultStore) GetPackage(pkgPath string, isImport bool) *PackageValue
 					ds.SetType(t)
 				}
 			}
+			for _, dt := range localDeclaredTypes(pn) {
+				ds.SetType(dt)
+			}
 			return pv
 		}
 	}
@@ -349,6 +397,45 @@ func (ds *defaultStore) GetPackage(pkgPath string, isImport bool) *PackageValue
 	return nil
 }
 
+// localDeclaredTypes returns the types declared in the function bodies of the
+// preprocessed package pn. Unlike the package-level types, these are not in
+// the package block.
+func localDeclaredTypes(pn *PackageNode) (dts []*DeclaredType) {
+	for _, fn := range pn.FileSet.Files {
+		dts = append(dts, declaredTypesIn(pn, fn)...)
+	}
+	// instances of generic types, and types declared in the instance
+	// functions and methods used by the package.
+	for _, dt := range genericDeclaredTypes(pn) {
+		if !slices.Contains(dts, dt) {
+			dts = append(dts, dt)
+		}
+	}
+	return
+}
+
+// declaredTypesIn returns the types declared locally within n, a child of
+// last.
+func declaredTypesIn(last BlockNode, n Node) (dts []*DeclaredType) {
+	TranscribeB(last, n, func(ns []Node, stack []BlockNode, last BlockNode, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+		if stage != TRANS_LEAVE {
+			return n, TRANS_CONTINUE
+		}
+		td, ok := n.(*TypeDecl)
+		if !ok || td.IsAlias || td.Name == blankIdentifier {
+			return n, TRANS_CONTINUE
+		}
+		if _, ok := last.(*FileNode); ok {
+			return n, TRANS_CONTINUE // package-level
+		}
+		if dt, ok := last.GetSlot(nil, td.Name, true).GetType().(*DeclaredType); ok {
+			dts = append(dts, dt)
+		}
+		return n, TRANS_CONTINUE
+	})
+	return
+}
+
 // Used to set throwaway packages.
 // NOTE: To check whether a mem package has been run, use GetMemPackage()
 // instead of implementing HasCachePackage().
@@ -375,7 +462,7 @@ func (ds *defaultStore) GetPackageRealm(pkgPath string) (rlm *Realm) {
 	if bz == nil {
 		return nil
 	}
-	gas := overflow.Mulp(ds.gasConfig.GasGetPackageRealm, store.Gas(len(bz)))
+	gas := overflow.Mulp(ds.gasTable.Store.GasGetPackageRealm, store.Gas(len(bz)))
 	ds.consumeGas(gas, GasGetPackageRealmDesc)
 	amino.MustUnmarshal(bz, &rlm)
 	size = len(bz)
@@ -405,10 +492,13 @@ func (ds *defaultStore) SetPackageRealm(rlm *Realm) {
 	oid := ObjectIDFromPkgPath(rlm.Path)
 	key := backendRealmKey(oid)
 	bz := amino.MustMarshal(rlm)
-	gas := overflow.Mulp(ds.gasConfig.GasSetPackageRealm, store.Gas(len(bz)))
+	gas := overflow.Mulp(ds.gasTable.Store.GasSetPackageRealm, store.Gas(len(bz)))
 	ds.consumeGas(gas, GasSetPackageRealmDesc)
 	ds.baseStore.Set([]byte(key), bz)
 	size = len(bz)
+	if ds.call != nil {
+		ds.call.addRealm(rlm)
+	}
 }
 
 // NOTE: it can be use to retrieve a package by ObjectID, but
@@ -437,6 +527,10 @@ func (ds *defaultStore) GetObjectSafe(oid ObjectID) Object {
 	if oo, exists := ds.cacheObjects[oid]; exists {
 		return oo
 	}
+	// check the objects of the previous messages of the transaction.
+	if oo := ds.restoreTxObject(oid); oo != nil {
+		return oo
+	}
 	// check baseStore.
 	if ds.baseStore != nil {
 		if oo := ds.loadObjectSafe(oid); oo != nil {
@@ -469,7 +563,7 @@ func (ds *defaultStore) loadObjectSafe(oid ObjectID) Object {
 		hash := hashbz[:HashSize]
 		bz := hashbz[HashSize:]
 		var oo Object
-		gas := overflow.Mulp(ds.gasConfig.GasGetObject, store.Gas(len(bz)))
+		gas := overflow.Mulp(ds.gasTable.Store.GasGetObject, store.Gas(len(bz)))
 		ds.consumeGas(gas, GasGetObjectDesc)
 		amino.MustUnmarshal(bz, &oo)
 		if debug {
@@ -495,6 +589,7 @@ func (ds *defaultStore) loadObjectSafe(oid ObjectID) Object {
 		}
 
 		ds.cacheObjects[oid] = oo
+		ds.setTxObject(oid, oo)
 		oo.GetObjectInfo().LastObjectSize = int64(size)
 		_ = fillTypesOfValue(ds, oo)
 		return oo
@@ -502,6 +597,258 @@ func (ds *defaultStore) loadObjectSafe(oid ObjectID) Object {
 	return nil
 }
 
+// RestoreObject restores the real object oo in place to its state in the
+// store, discarding its updates since it was last saved. Unlike
+// GetObject, it keeps oo as the cached object, as it may be referenced.
+func (ds *defaultStore) RestoreObject(oo Object) {
+	if bm.OpsEnabled {
+		bm.PauseOpCode()
+		defer bm.ResumeOpCode()
+	}
+
+	oid := oo.GetObjectID()
+	key := backendObjectKey(oid)
+	hashbz := ds.baseStore.Get([]byte(key))
+	if hashbz == nil {
+		panic(fmt.Sprintf("unexpected object with id %s", oid.String()))
+	}
+	hash := hashbz[:HashSize]
+	bz := hashbz[HashSize:]
+	var so Object
+	gas := overflow.Mulp(ds.gasTable.Store.GasGetObject, store.Gas(len(bz)))
+	ds.consumeGas(gas, GasGetObjectDesc)
+	amino.MustUnmarshal(bz, &so)
+	ds.alloc.Allocate(so.GetShallowSize())
+	AllocExpanded(ds.alloc, so)
+	so.SetHash(ValueHash{NewHashlet(hash)})
+	so.GetObjectInfo().LastObjectSize = int64(len(hashbz))
+	_ = fillTypesOfValue(ds, so)
+	restoreObject(oo, so)
+}
+
+// restoreObject copies the stored object so into oo, keeping the in-memory
+// state of oo which isn't persisted.
+func restoreObject(oo, so Object) {
+	switch cv := oo.(type) {
+	case *ArrayValue:
+		*cv = *so.(*ArrayValue)
+	case *StructValue:
+		*cv = *so.(*StructValue)
+	case *FuncValue:
+		body, nativeBody := cv.body, cv.nativeBody
+		*cv = *so.(*FuncValue)
+		cv.body, cv.nativeBody = body, nativeBody
+	case *BoundMethodValue:
+		*cv = *so.(*BoundMethodValue)
+	case *MapValue:
+		*cv = *so.(*MapValue)
+	case *PackageValue:
+		rlm, fBlocksMap := cv.Realm, cv.fBlocksMap
+		*cv = *so.(*PackageValue)
+		cv.Realm, cv.fBlocksMap = rlm, fBlocksMap
+	case *Block:
+		bodyStmt := cv.bodyStmt
+		*cv = *so.(*Block)
+		cv.bodyStmt = bodyStmt
+	case *HeapItemValue:
+		*cv = *so.(*HeapItemValue)
+	default:
+		panic(fmt.Sprintf("unexpected object type %v", reflect.TypeOf(oo)))
+	}
+}
+
+// CallCacheWrapper is implemented by the state which is cache-wrapped by the
+// calls crossing realm boundaries, like the objects of the Store and the
+// coins of the banker of the machine context, so that a panic leaving such a
+// call discards its writes; see Machine.enterRealm.
+type CallCacheWrapper interface {
+	// CacheWrapCall cache-wraps the state for a call crossing a realm
+	// boundary. The returned write function writes the cache to the state
+	// of the caller when the call returns, and discard drops it when a
+	// panic leaves the call.
+	CacheWrapCall() (write, discard func())
+}
+
+// callCache holds the writes of a call crossing a realm boundary: the base
+// and iavl stores are cache-wrapped for the call, and the objects and the
+// realms it saved are recorded, so that they can be restored from the stores
+// of the caller if a panic leaves the call.
+type callCache struct {
+	parent    *callCache  // of the caller, if it's in a call too.
+	baseStore store.Store // of the caller.
+	iavlStore store.Store // of the caller.
+
+	objects []Object // saved or deleted, in order.
+	oids    map[ObjectID]struct{}
+	realms  []*Realm // saved, in order.
+
+	realmStorageDiffs map[string]int64 // of the caller.
+}
+
+func (cc *callCache) addObject(oo Object) {
+	oid := oo.GetObjectID()
+	if _, exists := cc.oids[oid]; exists {
+		return
+	}
+	cc.oids[oid] = struct{}{}
+	cc.objects = append(cc.objects, oo)
+}
+
+func (cc *callCache) addRealm(rlm *Realm) {
+	if !slices.Contains(cc.realms, rlm) {
+		cc.realms = append(cc.realms, rlm)
+	}
+}
+
+// CacheWrapCall implements CallCacheWrapper.
+func (ds *defaultStore) CacheWrapCall() (write, discard func()) {
+	cc := &callCache{
+		parent:            ds.call,
+		baseStore:         ds.baseStore,
+		iavlStore:         ds.iavlStore,
+		oids:              make(map[ObjectID]struct{}),
+		realmStorageDiffs: maps.Clone(ds.realmStorageDiffs),
+	}
+	if ds.baseStore != nil {
+		ds.baseStore = ds.baseStore.CacheWrap()
+	}
+	if ds.iavlStore != nil {
+		ds.iavlStore = ds.iavlStore.CacheWrap()
+	}
+	ds.call = cc
+	baseStore, iavlStore := ds.baseStore, ds.iavlStore
+
+	write = func() {
+		ds.endCall(cc)
+		if baseStore != nil {
+			baseStore.Write()
+		}
+		if iavlStore != nil {
+			iavlStore.Write()
+		}
+		// the caller's call is now responsible for the writes.
+		if cc.parent != nil {
+			for _, oo := range cc.objects {
+				cc.parent.addObject(oo)
+			}
+			for _, rlm := range cc.realms {
+				cc.parent.addRealm(rlm)
+			}
+		}
+	}
+	discard = func() {
+		ds.endCall(cc)
+		ds.realmStorageDiffs = cc.realmStorageDiffs
+		ds.restoreCall(cc)
+	}
+	return write, discard
+}
+
+// WithCallerStores runs fn with the stores of the caller of the nth last call
+// crossing a realm boundary, i.e. before the n last calls were cache-wrapped,
+// so that the writes of fn are kept if a panic leaves these calls.
+func (ds *defaultStore) WithCallerStores(n int, fn func()) {
+	if n == 0 {
+		fn()
+		return
+	}
+	call, baseStore, iavlStore := ds.call, ds.baseStore, ds.iavlStore
+	cc := ds.call
+	for range n - 1 {
+		cc = cc.parent
+	}
+	ds.call, ds.baseStore, ds.iavlStore = cc.parent, cc.baseStore, cc.iavlStore
+	diffs := maps.Clone(ds.realmStorageDiffs)
+	defer func() {
+		ds.call, ds.baseStore, ds.iavlStore = call, baseStore, iavlStore
+		// the storage diffs of fn are kept too.
+		for path, diff := range ds.realmStorageDiffs {
+			if diff -= diffs[path]; diff != 0 {
+				for cc, i := call, 0; i < n; cc, i = cc.parent, i+1 {
+					cc.realmStorageDiffs[path] += diff
+				}
+			}
+		}
+	}()
+	fn()
+}
+
+// endCall goes back to the stores of the caller of the call of cc.
+func (ds *defaultStore) endCall(cc *callCache) {
+	if ds.call != cc {
+		panic("unexpected call cache")
+	}
+	ds.call = cc.parent
+	ds.baseStore, ds.iavlStore = cc.baseStore, cc.iavlStore
+}
+
+// restoreCall restores the objects and the realms saved by the discarded
+// call of cc to their state in the stores of the caller: the objects which
+// existed are restored in place, as they may be referenced, and those
+// created by the call become unreal again.
+func (ds *defaultStore) restoreCall(cc *callCache) {
+	if bm.OpsEnabled {
+		bm.PauseOpCode()
+		defer bm.ResumeOpCode()
+	}
+
+	for _, oo := range cc.objects {
+		oid := oo.GetObjectID()
+		if ds.baseStore != nil && ds.baseStore.Has([]byte(backendObjectKey(oid))) {
+			ds.RestoreObject(oo)
+			ds.cacheObjects[oid] = oo
+			ds.setTxObject(oid, oo)
+			continue
+		}
+		delete(ds.cacheObjects, oid)
+		delete(ds.txObjects, oid)
+		*oo.GetObjectInfo() = ObjectInfo{}
+	}
+	for _, rlm := range cc.realms {
+		if srlm := ds.GetPackageRealm(rlm.Path); srlm != nil {
+			rlm.Time = srlm.Time
+			rlm.Deposit = srlm.Deposit
+			rlm.Storage = srlm.Storage
+		}
+	}
+}
+
+// restoreTxObject returns the object oid if it was loaded or saved in a
+// previous message of the transaction, moving it back to the object cache, so
+// that it's not decoded from the store again. Its gas and memory are charged
+// like when it's loaded, so that the gas of a message doesn't depend on the
+// previous ones. It returns nil if the object isn't known.
+func (ds *defaultStore) restoreTxObject(oid ObjectID) Object {
+	oo, exists := ds.txObjects[oid]
+	if !exists {
+		return nil
+	}
+
+	size := oo.GetObjectInfo().LastObjectSize
+	gas := overflow.Mulp(ds.gasTable.Store.GasGetObject, store.Gas(size-HashSize))
+	ds.consumeGas(gas, GasGetObjectDesc)
+	ds.alloc.Allocate(oo.GetShallowSize())
+	AllocExpanded(ds.alloc, oo)
+	_ = fillTypesOfValue(ds, oo)
+	if pv, ok := oo.(*PackageValue); ok {
+		// The realm info (id counter, deposit) may have been updated since,
+		// so it's loaded again.
+		pv.Realm = nil
+		ds.SetStagingPackage(pv)
+		ds.fillPackage(pv)
+	}
+	ds.cacheObjects[oid] = oo
+	return oo
+}
+
+// setTxObject keeps the object oo, loaded or saved, for the next messages of
+// the transaction, if ds is a transaction store.
+func (ds *defaultStore) setTxObject(oid ObjectID, oo Object) {
+	if ds.txObjects != nil {
+		ds.txObjects[oid] = oo
+	}
+}
+
 func (ds *defaultStore) fillPackage(pv *PackageValue) {
 	pv.GetBlock(ds) // preload
 	if pv.IsRealm() && pv.Realm == nil {
@@ -625,10 +972,14 @@ func (ds *defaultStore) SetObject(oo Object) int64 {
 	o2 := copyValueWithRefs(oo)
 	// marshal to binary.
 	bz := amino.MustMarshalAny(o2)
-	gas := overflow.Mulp(ds.gasConfig.GasSetObject, store.Gas(len(bz)))
+	gas := overflow.Mulp(ds.gasTable.Store.GasSetObject, store.Gas(len(bz)))
 	ds.consumeGas(gas, GasSetObjectDesc)
 	// set hash.
-	hash := HashBytes(bz) // XXX objectHash(bz)???
+	// The hash is computed from the amino binary encoding of the object
+	// with its children replaced by RefValues, which is deterministic: the
+	// objecthash package reproduces it for clients, and its tests check
+	// that it matches.
+	hash := HashBytes(bz)
 	if len(hash) != HashSize {
 		panic("should not happen")
 	}
@@ -695,6 +1046,10 @@ func (ds *defaultStore) SetObject(oo Object) int64 {
 		}
 	}
 	ds.cacheObjects[oid] = oo
+	ds.setTxObject(oid, oo)
+	if ds.call != nil {
+		ds.call.addObject(oo)
+	}
 	// if escaped, add hash to iavl.
 	if oo.GetIsEscaped() && ds.iavlStore != nil {
 		var key, value []byte
@@ -730,11 +1085,15 @@ func (ds *defaultStore) DelObject(oo Object) int64 {
 			bm.StopStore(0)
 		}()
 	}
-	ds.consumeGas(ds.gasConfig.GasDeleteObject, GasDeleteObjectDesc)
+	ds.consumeGas(ds.gasTable.Store.GasDeleteObject, GasDeleteObjectDesc)
 	oid := oo.GetObjectID()
 	size := oo.GetObjectInfo().LastObjectSize
 	// delete from cache.
 	delete(ds.cacheObjects, oid)
+	delete(ds.txObjects, oid)
+	if ds.call != nil {
+		ds.call.addObject(oo)
+	}
 	// delete from backend.
 	if ds.baseStore != nil {
 		key := backendObjectKey(oid)
@@ -774,7 +1133,7 @@ func (ds *defaultStore) GetTypeSafe(tid TypeID) Type {
 		key := backendTypeKey(tid)
 		bz := ds.baseStore.Get([]byte(key))
 		if bz != nil {
-			gas := overflow.Mulp(ds.gasConfig.GasGetType, store.Gas(len(bz)))
+			gas := overflow.Mulp(ds.gasTable.Store.GasGetType, store.Gas(len(bz)))
 			ds.consumeGas(gas, GasGetTypeDesc)
 			var tt Type
 			amino.MustUnmarshal(bz, &tt)
@@ -833,7 +1192,7 @@ func (ds *defaultStore) SetType(tt Type) {
 		key := backendTypeKey(tid)
 		tcopy := copyTypeWithRefs(tt)
 		bz := amino.MustMarshalAny(tcopy)
-		gas := overflow.Mulp(ds.gasConfig.GasSetType, store.Gas(len(bz)))
+		gas := overflow.Mulp(ds.gasTable.Store.GasSetType, store.Gas(len(bz)))
 		ds.consumeGas(gas, GasSetTypeDesc)
 		ds.baseStore.Set([]byte(key), bz)
 		size = len(bz)
@@ -977,11 +1336,11 @@ func (ds *defaultStore) AddMemPackage(mpkg *std.MemPackage, mptype MemPackageTyp
 	ctr := ds.incGetPackageIndexCounter()
 	idxkey := []byte(backendPackageIndexKey(ctr))
 	bz := amino.MustMarshal(mpkg)
-	gas := overflow.Mulp(ds.gasConfig.GasAddMemPackage, store.Gas(len(bz)))
+	gas := overflow.Mulp(ds.gasTable.Store.GasAddMemPackage, store.Gas(len(bz)))
 	ds.consumeGas(gas, GasAddMemPackageDesc)
 	ds.baseStore.Set(idxkey, []byte(mpkg.Path))
 	pathkey := []byte(backendPackagePathKey(mpkg.Path))
-	ds.iavlStore.Set(pathkey, bz)
+	ds.codeStore.Set(pathkey, bz)
 	size = len(bz)
 }
 
@@ -1006,7 +1365,7 @@ func (ds *defaultStore) getMemPackage(path string, isRetry bool) *std.MemPackage
 		}()
 	}
 	pathkey := []byte(backendPackagePathKey(path))
-	bz := ds.iavlStore.Get(pathkey)
+	bz := ds.codeStore.Get(pathkey)
 	if bz == nil {
 		// If this is the first try, attempt using GetPackage to retrieve the
 		// package, first. GetPackage can leverage pkgGetter, which in most
@@ -1020,7 +1379,7 @@ func (ds *defaultStore) getMemPackage(path string, isRetry bool) *std.MemPackage
 		}
 		return nil
 	}
-	gas := overflow.Mulp(ds.gasConfig.GasGetMemPackage, store.Gas(len(bz)))
+	gas := overflow.Mulp(ds.gasTable.Store.GasGetMemPackage, store.Gas(len(bz)))
 	ds.consumeGas(gas, GasGetMemPackageDesc)
 
 	var mpkg *std.MemPackage
@@ -1054,7 +1413,7 @@ func (ds *defaultStore) FindPathsByPrefix(prefix string) iter.Seq[string] {
 	}
 
 	return func(yield func(string) bool) {
-		iter := ds.iavlStore.Iterator(startKey, endKey)
+		iter := ds.codeStore.Iterator(startKey, endKey)
 		defer iter.Close()
 
 		for ; iter.Valid(); iter.Next() {
@@ -1107,14 +1466,40 @@ func (ds *defaultStore) RealmStorageDiffs() map[string]int64 {
 }
 
 // Unstable.
-// This function is used to clear the object cache every transaction.
-// It also sets a new allocator.
+// This function is used to clear the object cache every message.
+// It also sets a new allocator. In a transaction store, the objects of the
+// previous messages are still kept, and restored from memory when they're
+// read again.
 func (ds *defaultStore) ClearObjectCache() {
 	ds.alloc.Reset()
 	ds.cacheObjects = make(map[ObjectID]Object) // new cache.
 	ds.realmStorageDiffs = make(map[string]int64)
 	ds.opslog = nil // new ops log.
 	ds.SetCachePackage(Uverse())
+	// The kept objects are replaced by copies referencing their children by
+	// ID, like loaded objects, so that each object read by the next messages
+	// goes through GetObject and is charged like a load. Those modified
+	// without being saved, which only happens if a message didn't complete,
+	// are dropped, to be loaded from the store again.
+	for oid, oo := range ds.txObjects {
+		if oo.GetIsDirty() || oo.GetIsDeleted() {
+			delete(ds.txObjects, oid)
+			continue
+		}
+		co := copyValueWithRefs(oo).(Object)
+		co.GetObjectInfo().lastGCCycle = 0
+		ds.txObjects[oid] = co
+	}
+}
+
+// ResetObjectCache is like ClearObjectCache, but also drops the objects kept
+// for the transaction, so that they are loaded from the store again, like in a
+// new transaction.
+func (ds *defaultStore) ResetObjectCache() {
+	if ds.txObjects != nil {
+		ds.txObjects = make(map[ObjectID]Object)
+	}
+	ds.ClearObjectCache()
 }
 
 func (ds *defaultStore) GarbageCollectObjectCache(gcCycle int64) {
@@ -1125,6 +1510,8 @@ func (ds *defaultStore) GarbageCollectObjectCache(gcCycle int64) {
 		}
 		if obj.GetLastGCCycle() < gcCycle {
 			delete(ds.cacheObjects, objId)
+			// the object is loaded from the store again when it's read.
+			delete(ds.txObjects, objId)
 		}
 	}
 }
@@ -1155,6 +1542,12 @@ func (ds *defaultStore) LogFinalizeRealm(rlmpath string) {
 	}
 }
 
+func (ds *defaultStore) LogRollbackRealm(rlmpath string) {
+	if ds.opslog != nil {
+		fmt.Fprintf(ds.opslog, "rollbackrealm[%q]\n", rlmpath)
+	}
+}
+
 // for debugging
 func (ds *defaultStore) Print() {
 	fmt.Println(colors.Yellow("//----------------------------------------"))
@@ -1164,6 +1557,9 @@ func (ds *defaultStore) Print() {
 	fmt.Println(colors.Green("defaultStore:iavlStore..."))
 	utils.Print(ds.iavlStore)
 	fmt.Println(colors.Yellow("//----------------------------------------"))
+	fmt.Println(colors.Green("defaultStore:codeStore..."))
+	utils.Print(ds.codeStore)
+	fmt.Println(colors.Yellow("//----------------------------------------"))
 	fmt.Println(colors.Green("defaultStore:cacheTypes..."))
 	ds.cacheTypes.Iterate()(func(tid TypeID, typ Type) bool {
 		fmt.Printf("- %v: %v\n", tid,
@@ -1208,6 +1604,13 @@ func backendPackageIndexKey(index uint64) string {
 	return fmt.Sprintf("pkgidx:%020d", index)
 }
 
+// MemPackageStoreKey returns the key of the code store at which the
+// amino-encoded MemPackage of the given path is saved. Clients use it to
+// query the package with a Merkle proof of its content.
+func MemPackageStoreKey(path string) []byte {
+	return []byte(backendPackagePathKey(path))
+}
+
 // We need to prefix stdlibs path with `_` to maitain them lexicographically
 // ordered with domain path
 func backendPackagePathKey(path string) string {
@@ -1222,6 +1625,10 @@ func backendPackageStdlibPath(path string) string { return "pkg:_/" + path }
 
 func backendPackageGlobalPath(path string) string { return "pkg:" + path }
 
+func isBackendPackagePathKey(key []byte) bool {
+	return strings.HasPrefix(string(key), "pkg:")
+}
+
 func decodeBackendPackagePathKey(key string) string {
 	path := strings.TrimPrefix(key, "pkg:")
 	return strings.TrimPrefix(path, "_/")
@@ -58,6 +58,55 @@ func TestTransactionStore(t *testing.T) {
 	assert.Equal(t, txSt.GetType("gno.vm/t/hello.A"), helloA)
 }
 
+func TestTransactionStore_txObjects(t *testing.T) {
+	db := memdb.NewMemDB()
+	tm2Store := dbadapter.StoreConstructor(db, storetypes.StoreOptions{})
+
+	st := NewStore(nil, tm2Store, tm2Store)
+	wrappedTm2Store := tm2Store.CacheWrap()
+	txSt := st.BeginTransaction(wrappedTm2Store, wrappedTm2Store, nil)
+	m := NewMachineWithOptions(MachineOptions{
+		PkgPath: "gno.vm/t/hello",
+		Store:   txSt,
+		Output:  io.Discard,
+	})
+	_, pv := m.RunMemPackage(&std.MemPackage{
+		Type: MPUserProd,
+		Name: "hello",
+		Path: "gno.vm/t/hello",
+		Files: []*std.MemFile{
+			{Name: "hello.gno", Body: "package hello; var A = []int{1, 2, 3}"},
+		},
+	}, true)
+	oid := pv.GetObjectID()
+
+	// The objects of the previous messages are kept, referencing their
+	// children by ID like loaded objects.
+	txSt.ClearObjectCache()
+	pv1 := txSt.GetObject(oid).(*PackageValue)
+	assert.NotSame(t, pv, pv1)
+	assert.Same(t, pv1, txSt.GetObject(oid))
+
+	// Those modified and not saved are loaded from the store again.
+	pv1.SetIsDirty(true, 0)
+	pv1.PkgName = "modified"
+	txSt.ClearObjectCache()
+	assert.Equal(t, Name("hello"), txSt.GetObject(oid).(*PackageValue).PkgName)
+
+	// Or in a new transaction.
+	pv2 := txSt.GetObject(oid)
+	txSt.ResetObjectCache()
+	assert.NotSame(t, pv2, txSt.GetObject(oid))
+
+	// Stores which are not transaction stores don't keep them.
+	txSt.Write()
+	wrappedTm2Store.Write()
+	st.ClearObjectCache()
+	pv3 := st.GetObject(oid)
+	st.ClearObjectCache()
+	assert.NotSame(t, pv3, st.GetObject(oid))
+}
+
 func TestTransactionStore_blockedMethods(t *testing.T) {
 	// These methods should panic as they modify store settings, which should
 	// only be changed in the root store.
@@ -39,11 +39,12 @@ func _() {
 	_ = x[HeapItemKind-28]
 	_ = x[TupleKind-29]
 	_ = x[RefTypeKind-30]
+	_ = x[GenericKind-31]
 }
 
-const _Kind_name = "InvalidKindBoolKindStringKindIntKindInt8KindInt16KindInt32KindInt64KindUintKindUint8KindUint16KindUint32KindUint64KindFloat32KindFloat64KindBigintKindBigdecKindArrayKindSliceKindPointerKindStructKindPackageKindInterfaceKindChanKindFuncKindMapKindTypeKindBlockKindHeapItemKindTupleKindRefTypeKind"
+const _Kind_name = "InvalidKindBoolKindStringKindIntKindInt8KindInt16KindInt32KindInt64KindUintKindUint8KindUint16KindUint32KindUint64KindFloat32KindFloat64KindBigintKindBigdecKindArrayKindSliceKindPointerKindStructKindPackageKindInterfaceKindChanKindFuncKindMapKindTypeKindBlockKindHeapItemKindTupleKindRefTypeKindGenericKind"
 
-var _Kind_index = [...]uint16{0, 11, 19, 29, 36, 44, 53, 62, 71, 79, 88, 98, 108, 118, 129, 140, 150, 160, 169, 178, 189, 199, 210, 223, 231, 239, 246, 254, 263, 275, 284, 295}
+var _Kind_index = [...]uint16{0, 11, 19, 29, 36, 44, 53, 62, 71, 79, 88, 98, 108, 118, 129, 140, 150, 160, 169, 178, 189, 199, 210, 223, 231, 239, 246, 254, 263, 275, 284, 295, 306}
 
 func (i Kind) String() string {
 	if i >= Kind(len(_Kind_index)-1) {
@@ -148,6 +149,7 @@ func _() {
 	_ = x[OpDefine-140]
 	_ = x[OpInc-141]
 	_ = x[OpDec-142]
+	_ = x[OpSend-143]
 	_ = x[OpValueDecl-144]
 	_ = x[OpTypeDecl-145]
 	_ = x[OpSticky-208]
@@ -158,10 +160,11 @@ func _() {
 	_ = x[OpRangeIterMap-213]
 	_ = x[OpRangeIterArrayPtr-214]
 	_ = x[OpReturnCallDefers-215]
+	_ = x[OpRangeIterChan-216]
 	_ = x[OpVoid-255]
 }
 
-const _Op_name = "OpInvalidOpHaltOpNoopOpExecOpPrecallOpEnterCrossingOpCallOpCallNativeBodyOpDeferOpCallDeferNativeBodyOpGoOpSelectOpSwitchClauseOpSwitchClauseCaseOpTypeSwitchOpIfCondOpPopValueOpPopResultsOpPopBlockOpPopFrameAndResetOpPanic1OpPanic2OpReturnOpReturnAfterCopyOpReturnFromBlockOpReturnToBlockOpUposOpUnegOpUnotOpUxorOpUrecvOpLorOpLandOpEqlOpNeqOpLssOpLeqOpGtrOpGeqOpAddOpSubOpBorOpXorOpMulOpQuoOpRemOpShlOpShrOpBandOpBandnOpEvalOpBinary1OpIndex1OpIndex2OpSelectorOpSliceOpStarOpRefOpTypeAssert1OpTypeAssert2OpStaticTypeOfOpCompositeLitOpArrayLitOpSliceLitOpSliceLit2OpMapLitOpStructLitOpFuncLitOpConvertOpFieldTypeOpArrayTypeOpSliceTypeOpPointerTypeOpInterfaceTypeOpChanTypeOpFuncTypeOpMapTypeOpStructTypeOpAssignOpAddAssignOpSubAssignOpMulAssignOpQuoAssignOpRemAssignOpBandAssignOpBandnAssignOpBorAssignOpXorAssignOpShlAssignOpShrAssignOpDefineOpIncOpDecOpValueDeclOpTypeDeclOpStickyOpBodyOpForLoopOpRangeIterOpRangeIterStringOpRangeIterMapOpRangeIterArrayPtrOpReturnCallDefersOpVoid"
+const _Op_name = "OpInvalidOpHaltOpNoopOpExecOpPrecallOpEnterCrossingOpCallOpCallNativeBodyOpDeferOpCallDeferNativeBodyOpGoOpSelectOpSwitchClauseOpSwitchClauseCaseOpTypeSwitchOpIfCondOpPopValueOpPopResultsOpPopBlockOpPopFrameAndResetOpPanic1OpPanic2OpReturnOpReturnAfterCopyOpReturnFromBlockOpReturnToBlockOpUposOpUnegOpUnotOpUxorOpUrecvOpLorOpLandOpEqlOpNeqOpLssOpLeqOpGtrOpGeqOpAddOpSubOpBorOpXorOpMulOpQuoOpRemOpShlOpShrOpBandOpBandnOpEvalOpBinary1OpIndex1OpIndex2OpSelectorOpSliceOpStarOpRefOpTypeAssert1OpTypeAssert2OpStaticTypeOfOpCompositeLitOpArrayLitOpSliceLitOpSliceLit2OpMapLitOpStructLitOpFuncLitOpConvertOpFieldTypeOpArrayTypeOpSliceTypeOpPointerTypeOpInterfaceTypeOpChanTypeOpFuncTypeOpMapTypeOpStructTypeOpAssignOpAddAssignOpSubAssignOpMulAssignOpQuoAssignOpRemAssignOpBandAssignOpBandnAssignOpBorAssignOpXorAssignOpShlAssignOpShrAssignOpDefineOpIncOpDecOpSendOpValueDeclOpTypeDeclOpStickyOpBodyOpForLoopOpRangeIterOpRangeIterStringOpRangeIterMapOpRangeIterArrayPtrOpReturnCallDefersOpRangeIterChanOpVoid"
 
 var _Op_map = map[Op]string{
 	0:   _Op_name[0:9],
@@ -257,17 +260,19 @@ var _Op_map = map[Op]string{
 	140: _Op_name[833:841],
 	141: _Op_name[841:846],
 	142: _Op_name[846:851],
-	144: _Op_name[851:862],
-	145: _Op_name[862:872],
-	208: _Op_name[872:880],
-	209: _Op_name[880:886],
-	210: _Op_name[886:895],
-	211: _Op_name[895:906],
-	212: _Op_name[906:923],
-	213: _Op_name[923:937],
-	214: _Op_name[937:956],
-	215: _Op_name[956:974],
-	255: _Op_name[974:980],
+	143: _Op_name[851:857],
+	144: _Op_name[857:868],
+	145: _Op_name[868:878],
+	208: _Op_name[878:886],
+	209: _Op_name[886:892],
+	210: _Op_name[892:901],
+	211: _Op_name[901:912],
+	212: _Op_name[912:929],
+	213: _Op_name[929:943],
+	214: _Op_name[943:962],
+	215: _Op_name[962:980],
+	216: _Op_name[980:995],
+	255: _Op_name[995:1001],
 }
 
 func (i Op) String() string {
@@ -306,83 +311,86 @@ func _() {
 	_ = x[TRANS_CALL_ARG-4]
 	_ = x[TRANS_INDEX_X-5]
 	_ = x[TRANS_INDEX_INDEX-6]
-	_ = x[TRANS_SELECTOR_X-7]
-	_ = x[TRANS_SLICE_X-8]
-	_ = x[TRANS_SLICE_LOW-9]
-	_ = x[TRANS_SLICE_HIGH-10]
-	_ = x[TRANS_SLICE_MAX-11]
-	_ = x[TRANS_STAR_X-12]
-	_ = x[TRANS_REF_X-13]
-	_ = x[TRANS_TYPEASSERT_X-14]
-	_ = x[TRANS_TYPEASSERT_TYPE-15]
-	_ = x[TRANS_UNARY_X-16]
-	_ = x[TRANS_COMPOSITE_TYPE-17]
-	_ = x[TRANS_COMPOSITE_KEY-18]
-	_ = x[TRANS_COMPOSITE_VALUE-19]
-	_ = x[TRANS_FUNCLIT_TYPE-20]
-	_ = x[TRANS_FUNCLIT_HEAP_CAPTURE-21]
-	_ = x[TRANS_FUNCLIT_BODY-22]
-	_ = x[TRANS_FIELDTYPE_NAME-23]
-	_ = x[TRANS_FIELDTYPE_TYPE-24]
-	_ = x[TRANS_FIELDTYPE_TAG-25]
-	_ = x[TRANS_ARRAYTYPE_LEN-26]
-	_ = x[TRANS_ARRAYTYPE_ELT-27]
-	_ = x[TRANS_SLICETYPE_ELT-28]
-	_ = x[TRANS_INTERFACETYPE_METHOD-29]
-	_ = x[TRANS_CHANTYPE_VALUE-30]
-	_ = x[TRANS_FUNCTYPE_PARAM-31]
-	_ = x[TRANS_FUNCTYPE_RESULT-32]
-	_ = x[TRANS_MAPTYPE_KEY-33]
-	_ = x[TRANS_MAPTYPE_VALUE-34]
-	_ = x[TRANS_STRUCTTYPE_FIELD-35]
-	_ = x[TRANS_ASSIGN_LHS-36]
-	_ = x[TRANS_ASSIGN_RHS-37]
-	_ = x[TRANS_BLOCK_BODY-38]
-	_ = x[TRANS_DECL_BODY-39]
-	_ = x[TRANS_DEFER_CALL-40]
-	_ = x[TRANS_EXPR_X-41]
-	_ = x[TRANS_FOR_INIT-42]
-	_ = x[TRANS_FOR_COND-43]
-	_ = x[TRANS_FOR_POST-44]
-	_ = x[TRANS_FOR_BODY-45]
-	_ = x[TRANS_GO_CALL-46]
-	_ = x[TRANS_IF_INIT-47]
-	_ = x[TRANS_IF_COND-48]
-	_ = x[TRANS_IF_BODY-49]
-	_ = x[TRANS_IF_ELSE-50]
-	_ = x[TRANS_IF_CASE_BODY-51]
-	_ = x[TRANS_INCDEC_X-52]
-	_ = x[TRANS_RANGE_X-53]
-	_ = x[TRANS_RANGE_KEY-54]
-	_ = x[TRANS_RANGE_VALUE-55]
-	_ = x[TRANS_RANGE_BODY-56]
-	_ = x[TRANS_RETURN_RESULT-57]
-	_ = x[TRANS_SELECT_CASE-58]
-	_ = x[TRANS_SELECTCASE_COMM-59]
-	_ = x[TRANS_SELECTCASE_BODY-60]
-	_ = x[TRANS_SEND_CHAN-61]
-	_ = x[TRANS_SEND_VALUE-62]
-	_ = x[TRANS_SWITCH_INIT-63]
-	_ = x[TRANS_SWITCH_X-64]
-	_ = x[TRANS_SWITCH_CASE-65]
-	_ = x[TRANS_SWITCHCASE_CASE-66]
-	_ = x[TRANS_SWITCHCASE_BODY-67]
-	_ = x[TRANS_FUNC_RECV-68]
-	_ = x[TRANS_FUNC_TYPE-69]
-	_ = x[TRANS_FUNC_BODY-70]
-	_ = x[TRANS_IMPORT_PATH-71]
-	_ = x[TRANS_CONST_TYPE-72]
-	_ = x[TRANS_CONST_VALUE-73]
-	_ = x[TRANS_VAR_NAME-74]
-	_ = x[TRANS_VAR_TYPE-75]
-	_ = x[TRANS_VAR_VALUE-76]
-	_ = x[TRANS_TYPE_TYPE-77]
-	_ = x[TRANS_FILE_BODY-78]
+	_ = x[TRANS_INDEXLIST_X-7]
+	_ = x[TRANS_INDEXLIST_INDEX-8]
+	_ = x[TRANS_SELECTOR_X-9]
+	_ = x[TRANS_SLICE_X-10]
+	_ = x[TRANS_SLICE_LOW-11]
+	_ = x[TRANS_SLICE_HIGH-12]
+	_ = x[TRANS_SLICE_MAX-13]
+	_ = x[TRANS_STAR_X-14]
+	_ = x[TRANS_REF_X-15]
+	_ = x[TRANS_TYPEASSERT_X-16]
+	_ = x[TRANS_TYPEASSERT_TYPE-17]
+	_ = x[TRANS_UNARY_X-18]
+	_ = x[TRANS_COMPOSITE_TYPE-19]
+	_ = x[TRANS_COMPOSITE_KEY-20]
+	_ = x[TRANS_COMPOSITE_VALUE-21]
+	_ = x[TRANS_FUNCLIT_TYPE-22]
+	_ = x[TRANS_FUNCLIT_HEAP_CAPTURE-23]
+	_ = x[TRANS_FUNCLIT_BODY-24]
+	_ = x[TRANS_FIELDTYPE_NAME-25]
+	_ = x[TRANS_FIELDTYPE_TYPE-26]
+	_ = x[TRANS_FIELDTYPE_TAG-27]
+	_ = x[TRANS_ARRAYTYPE_LEN-28]
+	_ = x[TRANS_ARRAYTYPE_ELT-29]
+	_ = x[TRANS_SLICETYPE_ELT-30]
+	_ = x[TRANS_INTERFACETYPE_METHOD-31]
+	_ = x[TRANS_UNIONTYPE_TERM-32]
+	_ = x[TRANS_CHANTYPE_VALUE-33]
+	_ = x[TRANS_FUNCTYPE_PARAM-34]
+	_ = x[TRANS_FUNCTYPE_RESULT-35]
+	_ = x[TRANS_MAPTYPE_KEY-36]
+	_ = x[TRANS_MAPTYPE_VALUE-37]
+	_ = x[TRANS_STRUCTTYPE_FIELD-38]
+	_ = x[TRANS_ASSIGN_LHS-39]
+	_ = x[TRANS_ASSIGN_RHS-40]
+	_ = x[TRANS_BLOCK_BODY-41]
+	_ = x[TRANS_DECL_BODY-42]
+	_ = x[TRANS_DEFER_CALL-43]
+	_ = x[TRANS_EXPR_X-44]
+	_ = x[TRANS_FOR_INIT-45]
+	_ = x[TRANS_FOR_COND-46]
+	_ = x[TRANS_FOR_POST-47]
+	_ = x[TRANS_FOR_BODY-48]
+	_ = x[TRANS_GO_CALL-49]
+	_ = x[TRANS_IF_INIT-50]
+	_ = x[TRANS_IF_COND-51]
+	_ = x[TRANS_IF_BODY-52]
+	_ = x[TRANS_IF_ELSE-53]
+	_ = x[TRANS_IF_CASE_BODY-54]
+	_ = x[TRANS_INCDEC_X-55]
+	_ = x[TRANS_RANGE_X-56]
+	_ = x[TRANS_RANGE_KEY-57]
+	_ = x[TRANS_RANGE_VALUE-58]
+	_ = x[TRANS_RANGE_BODY-59]
+	_ = x[TRANS_RETURN_RESULT-60]
+	_ = x[TRANS_SELECT_CASE-61]
+	_ = x[TRANS_SELECTCASE_COMM-62]
+	_ = x[TRANS_SELECTCASE_BODY-63]
+	_ = x[TRANS_SEND_CHAN-64]
+	_ = x[TRANS_SEND_VALUE-65]
+	_ = x[TRANS_SWITCH_INIT-66]
+	_ = x[TRANS_SWITCH_X-67]
+	_ = x[TRANS_SWITCH_CASE-68]
+	_ = x[TRANS_SWITCHCASE_CASE-69]
+	_ = x[TRANS_SWITCHCASE_BODY-70]
+	_ = x[TRANS_FUNC_RECV-71]
+	_ = x[TRANS_FUNC_TYPE-72]
+	_ = x[TRANS_FUNC_BODY-73]
+	_ = x[TRANS_IMPORT_PATH-74]
+	_ = x[TRANS_CONST_TYPE-75]
+	_ = x[TRANS_CONST_VALUE-76]
+	_ = x[TRANS_VAR_NAME-77]
+	_ = x[TRANS_VAR_TYPE-78]
+	_ = x[TRANS_VAR_VALUE-79]
+	_ = x[TRANS_TYPE_TYPE-80]
+	_ = x[TRANS_FILE_BODY-81]
 }
 
-const _TransField_name = "TRANS_ROOTTRANS_BINARY_LEFTTRANS_BINARY_RIGHTTRANS_CALL_FUNCTRANS_CALL_ARGTRANS_INDEX_XTRANS_INDEX_INDEXTRANS_SELECTOR_XTRANS_SLICE_XTRANS_SLICE_LOWTRANS_SLICE_HIGHTRANS_SLICE_MAXTRANS_STAR_XTRANS_REF_XTRANS_TYPEASSERT_XTRANS_TYPEASSERT_TYPETRANS_UNARY_XTRANS_COMPOSITE_TYPETRANS_COMPOSITE_KEYTRANS_COMPOSITE_VALUETRANS_FUNCLIT_TYPETRANS_FUNCLIT_HEAP_CAPTURETRANS_FUNCLIT_BODYTRANS_FIELDTYPE_NAMETRANS_FIELDTYPE_TYPETRANS_FIELDTYPE_TAGTRANS_ARRAYTYPE_LENTRANS_ARRAYTYPE_ELTTRANS_SLICETYPE_ELTTRANS_INTERFACETYPE_METHODTRANS_CHANTYPE_VALUETRANS_FUNCTYPE_PARAMTRANS_FUNCTYPE_RESULTTRANS_MAPTYPE_KEYTRANS_MAPTYPE_VALUETRANS_STRUCTTYPE_FIELDTRANS_ASSIGN_LHSTRANS_ASSIGN_RHSTRANS_BLOCK_BODYTRANS_DECL_BODYTRANS_DEFER_CALLTRANS_EXPR_XTRANS_FOR_INITTRANS_FOR_CONDTRANS_FOR_POSTTRANS_FOR_BODYTRANS_GO_CALLTRANS_IF_INITTRANS_IF_CONDTRANS_IF_BODYTRANS_IF_ELSETRANS_IF_CASE_BODYTRANS_INCDEC_XTRANS_RANGE_XTRANS_RANGE_KEYTRANS_RANGE_VALUETRANS_RANGE_BODYTRANS_RETURN_RESULTTRANS_SELECT_CASETRANS_SELECTCASE_COMMTRANS_SELECTCASE_BODYTRANS_SEND_CHANTRANS_SEND_VALUETRANS_SWITCH_INITTRANS_SWITCH_XTRANS_SWITCH_CASETRANS_SWITCHCASE_CASETRANS_SWITCHCASE_BODYTRANS_FUNC_RECVTRANS_FUNC_TYPETRANS_FUNC_BODYTRANS_IMPORT_PATHTRANS_CONST_TYPETRANS_CONST_VALUETRANS_VAR_NAMETRANS_VAR_TYPETRANS_VAR_VALUETRANS_TYPE_TYPETRANS_FILE_BODY"
+const _TransField_name = "TRANS_ROOTTRANS_BINARY_LEFTTRANS_BINARY_RIGHTTRANS_CALL_FUNCTRANS_CALL_ARGTRANS_INDEX_XTRANS_INDEX_INDEXTRANS_INDEXLIST_XTRANS_INDEXLIST_INDEXTRANS_SELECTOR_XTRANS_SLICE_XTRANS_SLICE_LOWTRANS_SLICE_HIGHTRANS_SLICE_MAXTRANS_STAR_XTRANS_REF_XTRANS_TYPEASSERT_XTRANS_TYPEASSERT_TYPETRANS_UNARY_XTRANS_COMPOSITE_TYPETRANS_COMPOSITE_KEYTRANS_COMPOSITE_VALUETRANS_FUNCLIT_TYPETRANS_FUNCLIT_HEAP_CAPTURETRANS_FUNCLIT_BODYTRANS_FIELDTYPE_NAMETRANS_FIELDTYPE_TYPETRANS_FIELDTYPE_TAGTRANS_ARRAYTYPE_LENTRANS_ARRAYTYPE_ELTTRANS_SLICETYPE_ELTTRANS_INTERFACETYPE_METHODTRANS_UNIONTYPE_TERMTRANS_CHANTYPE_VALUETRANS_FUNCTYPE_PARAMTRANS_FUNCTYPE_RESULTTRANS_MAPTYPE_KEYTRANS_MAPTYPE_VALUETRANS_STRUCTTYPE_FIELDTRANS_ASSIGN_LHSTRANS_ASSIGN_RHSTRANS_BLOCK_BODYTRANS_DECL_BODYTRANS_DEFER_CALLTRANS_EXPR_XTRANS_FOR_INITTRANS_FOR_CONDTRANS_FOR_POSTTRANS_FOR_BODYTRANS_GO_CALLTRANS_IF_INITTRANS_IF_CONDTRANS_IF_BODYTRANS_IF_ELSETRANS_IF_CASE_BODYTRANS_INCDEC_XTRANS_RANGE_XTRANS_RANGE_KEYTRANS_RANGE_VALUETRANS_RANGE_BODYTRANS_RETURN_RESULTTRANS_SELECT_CASETRANS_SELECTCASE_COMMTRANS_SELECTCASE_BODYTRANS_SEND_CHANTRANS_SEND_VALUETRANS_SWITCH_INITTRANS_SWITCH_XTRANS_SWITCH_CASETRANS_SWITCHCASE_CASETRANS_SWITCHCASE_BODYTRANS_FUNC_RECVTRANS_FUNC_TYPETRANS_FUNC_BODYTRANS_IMPORT_PATHTRANS_CONST_TYPETRANS_CONST_VALUETRANS_VAR_NAMETRANS_VAR_TYPETRANS_VAR_VALUETRANS_TYPE_TYPETRANS_FILE_BODY"
 
-var _TransField_index = [...]uint16{0, 10, 27, 45, 60, 74, 87, 104, 120, 133, 148, 164, 179, 191, 202, 220, 241, 254, 274, 293, 314, 332, 358, 376, 396, 416, 435, 454, 473, 492, 518, 538, 558, 579, 596, 615, 637, 653, 669, 685, 700, 716, 728, 742, 756, 770, 784, 797, 810, 823, 836, 849, 867, 881, 894, 909, 926, 942, 961, 978, 999, 1020, 1035, 1051, 1068, 1082, 1099, 1120, 1141, 1156, 1171, 1186, 1203, 1219, 1236, 1250, 1264, 1279, 1294, 1309}
+var _TransField_index = [...]uint16{0, 10, 27, 45, 60, 74, 87, 104, 121, 142, 158, 171, 186, 202, 217, 229, 240, 258, 279, 292, 312, 331, 352, 370, 396, 414, 434, 454, 473, 492, 511, 530, 556, 576, 596, 616, 637, 654, 673, 695, 711, 727, 743, 758, 774, 786, 800, 814, 828, 842, 855, 868, 881, 894, 907, 925, 939, 952, 967, 984, 1000, 1019, 1036, 1057, 1078, 1093, 1109, 1126, 1140, 1157, 1178, 1199, 1214, 1229, 1244, 1261, 1277, 1294, 1308, 1322, 1337, 1352, 1367}
 
 func (i TransField) String() string {
 	if i >= TransField(len(_TransField_index)-1) {
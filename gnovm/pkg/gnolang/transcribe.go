@@ -32,6 +32,8 @@ const (
 	TRANS_CALL_ARG
 	TRANS_INDEX_X
 	TRANS_INDEX_INDEX
+	TRANS_INDEXLIST_X
+	TRANS_INDEXLIST_INDEX
 	TRANS_SELECTOR_X
 	TRANS_SLICE_X
 	TRANS_SLICE_LOW
@@ -55,6 +57,7 @@ const (
 	TRANS_ARRAYTYPE_ELT
 	TRANS_SLICETYPE_ELT
 	TRANS_INTERFACETYPE_METHOD
+	TRANS_UNIONTYPE_TERM
 	TRANS_CHANTYPE_VALUE
 	TRANS_FUNCTYPE_PARAM
 	TRANS_FUNCTYPE_RESULT
@@ -142,6 +145,13 @@ func transcribe(t Transform, ns []Node, ftype TransField, index int, n Node, nc
 		return
 	}
 
+	// generic declarations of a file are templates for their
+	// instances, which are transcribed separately; so only the
+	// generic declaration itself is visited, with TRANS_ENTER.
+	if ftype == TRANS_FILE_BODY && isGenericDecl(nn) {
+		return
+	}
+
 	// push nn to node stack.
 	nns := append(ns, nn)
 
@@ -178,6 +188,17 @@ func transcribe(t Transform, ns []Node, ftype TransField, index int, n Node, nc
 		if stopOrSkip(nc, c) {
 			return
 		}
+	case *IndexListExpr:
+		cnn.X = transcribe(t, nns, TRANS_INDEXLIST_X, 0, cnn.X, &c).(Expr)
+		if stopOrSkip(nc, c) {
+			return
+		}
+		for idx := range cnn.Indices {
+			cnn.Indices[idx] = transcribe(t, nns, TRANS_INDEXLIST_INDEX, idx, cnn.Indices[idx], &c).(Expr)
+			if stopOrSkip(nc, c) {
+				return
+			}
+		}
 	case *SelectorExpr:
 		cnn.X = transcribe(t, nns, TRANS_SELECTOR_X, 0, cnn.X, &c).(Expr)
 		if stopOrSkip(nc, c) {
@@ -315,6 +336,13 @@ func transcribe(t Transform, ns []Node, ftype TransField, index int, n Node, nc
 				return
 			}
 		}
+	case *UnionTypeExpr:
+		for idx := range cnn.Terms {
+			cnn.Terms[idx] = transcribe(t, nns, TRANS_UNIONTYPE_TERM, idx, cnn.Terms[idx], &c).(Expr)
+			if stopOrSkip(nc, c) {
+				return
+			}
+		}
 	case *ChanTypeExpr:
 		cnn.Value = transcribe(t, nns, TRANS_CHANTYPE_VALUE, 0, cnn.Value, &c).(Expr)
 		if stopOrSkip(nc, c) {
@@ -537,9 +565,11 @@ func transcribe(t Transform, ns []Node, ftype TransField, index int, n Node, nc
 		} else {
 			cnn = cnn2.(*SelectCaseStmt)
 		}
-		cnn.Comm = transcribe(t, nns, TRANS_SELECTCASE_COMM, 0, cnn.Comm, &c).(Stmt)
-		if stopOrSkip(nc, c) {
-			return
+		if cnn.Comm != nil {
+			cnn.Comm = transcribe(t, nns, TRANS_SELECTCASE_COMM, 0, cnn.Comm, &c).(Stmt)
+			if stopOrSkip(nc, c) {
+				return
+			}
 		}
 		// iterate over Body; its length can change if a statement is decomposed.
 		for idx := 0; idx < len(cnn.Body); idx++ {
@@ -32,10 +32,11 @@ var (
 	}
 	// TODO: star, addressable
 	unaryChecker = map[Word]func(t Type) bool{
-		ADD: isNumeric,
-		SUB: isNumeric,
-		XOR: isIntNum,
-		NOT: isBoolean,
+		ADD:   isNumeric,
+		SUB:   isNumeric,
+		XOR:   isIntNum,
+		NOT:   isBoolean,
+		ARROW: isRecvChan,
 	}
 	IncDecStmtChecker = map[Word]func(t Type) bool{
 		INC: isNumeric,
@@ -108,6 +109,16 @@ func isBoolean(t Type) bool {
 	}
 }
 
+// isRecvChan returns true if t is a channel which can be received from.
+func isRecvChan(t Type) bool {
+	switch t := baseOf(t).(type) {
+	case *ChanType:
+		return t.Dir != SEND
+	default:
+		return false
+	}
+}
+
 // rune can be numeric and string
 func isNumeric(t Type) bool {
 	switch t := baseOf(t).(type) {
@@ -173,13 +184,14 @@ func assertComparable2(dt Type) {
 	case *StructType:
 		for _, f := range cdt.Fields {
 			switch cft := baseOf(f.Type).(type) {
-			case PrimitiveType, *PointerType, *InterfaceType, *ArrayType, *StructType:
+			case PrimitiveType, *PointerType, *InterfaceType, *ArrayType, *StructType, *ChanType:
 				assertComparable2(cft)
 			default:
 				panic(fmt.Sprintf("%v is not comparable", dt))
 			}
 		}
 	case *PointerType: // &a == &b
+	case *ChanType:
 	case *InterfaceType:
 	case *SliceType, *FuncType, *MapType:
 	default:
@@ -378,8 +390,8 @@ func checkValDefineMismatch(n Node) {
 	}
 
 	// Special case for single value.
-	// If the value is a call expression, type assertion, or index expression,
-	// it can be assigned to multiple variables.
+	// If the value is a call expression, type assertion, index expression,
+	// or receive expression, it can be assigned to multiple variables.
 	if numValues == 1 {
 		switch values[0].(type) {
 		case *CallExpr:
@@ -394,6 +406,14 @@ func checkValDefineMismatch(n Node) {
 				panic(fmt.Sprintf("assignment mismatch: %d variable(s) but %d value(s)", numNames, numValues))
 			}
 			return
+		case *UnaryExpr:
+			if values[0].(*UnaryExpr).Op != ARROW {
+				break
+			}
+			if numNames != 2 {
+				panic(fmt.Sprintf("assignment mismatch: %d variable(s) but %d value(s)", numNames, numValues))
+			}
+			return
 		}
 	}
 
@@ -631,7 +651,18 @@ func checkAssignableTo(n Node, xt, dt Type, autoNative bool) (err error) {
 		panic("should not happen")
 	case *DeclaredType:
 		panic("should not happen")
-	case *FuncType, *StructType, *PackageType, *ChanType, *TypeType:
+	case *ChanType:
+		if ct, ok := xt.(*ChanType); ok {
+			if ct.TypeID() == cdt.TypeID() {
+				return nil // ok
+			}
+			// a bidirectional channel is assignable to a
+			// directional channel of the same elem type.
+			if ct.Dir == SEND|RECV && ct.Elt.TypeID() == cdt.Elt.TypeID() {
+				return nil // ok
+			}
+		}
+	case *FuncType, *StructType, *PackageType, *TypeType:
 		if xt.TypeID() == cdt.TypeID() {
 			return nil // ok
 		}
@@ -866,6 +897,10 @@ func (x *RangeStmt) AssertCompatible(store Store, last BlockNode) {
 		if vt != nil {
 			assertAssignableTo(x, cxt.Elt, vt, false)
 		}
+	case *ChanType:
+		if !isBlankIdentifier(x.Key) {
+			assertAssignableTo(x, cxt.Elt, kt, false)
+		}
 	case PrimitiveType:
 		if cxt.Kind() == StringKind {
 			if kt != nil && kt.Kind() != IntKind {
@@ -963,6 +998,26 @@ func (x *AssignStmt) AssertCompatible(store Store, last BlockNode) {
 					}
 				}
 				cx.HasOK = true
+			case *UnaryExpr: // must be a receive when len(Lhs) > len(Rhs)
+				if cx.Op != ARROW || len(x.Lhs) != 2 {
+					panic(fmt.Sprintf("RHS should not be %v when len(Lhs) > len(Rhs)", cx))
+				}
+				if x.Op == ASSIGN {
+					assertValidAssignLhs(store, last, x.Lhs[0])
+					if !isBlankIdentifier(x.Lhs[0]) {
+						lt := evalStaticTypeOf(store, last, x.Lhs[0])
+						rt := evalStaticTypeOf(store, last, cx)
+						assertAssignableTo(x, rt, lt, false)
+					}
+					assertValidAssignLhs(store, last, x.Lhs[1])
+					if !isBlankIdentifier(x.Lhs[1]) {
+						dt := evalStaticTypeOf(store, last, x.Lhs[1])
+						if dt != nil && dt.Kind() != BoolKind { // typed, not bool
+							panic(fmt.Sprintf("want bool type got %v", dt))
+						}
+					}
+				}
+				cx.HasOK = true
 			default:
 				panic(fmt.Sprintf("RHS should not be %v when len(Lhs) > len(Rhs)", cx))
 			}
@@ -1085,6 +1140,230 @@ func assertValidAssignRhs(store Store, last BlockNode, n Node) {
 	}
 }
 
+// assertNoExternalRealmWrites ensures that a realm doesn't modify an object
+// of another realm through one of its package-level variables whose value is
+// a reference to it, e.g. `var p = &otherrealm.Value` followed by
+// `p.Field = x`. Such objects are readonly for the declaring realm, so the
+// write would fail at runtime; report it when the package is added instead.
+// Reading through such a variable is valid, as is modifying the variable
+// itself; as it may then reference a local object, the writes through a
+// variable which is reassigned are left to the runtime checks.
+//
+// It is called once all the files of pn are preprocessed, as the writes may
+// precede the variable declaration.
+func assertNoExternalRealmWrites(store Store, pn *PackageNode) {
+	if !IsRealmPath(pn.PkgPath) || pn.FileSet == nil {
+		return
+	}
+
+	type externalRef struct {
+		path string
+		sel  Name
+	}
+	refs := map[Name]externalRef{}
+	for _, fn := range pn.FileSet.Files {
+		for _, decl := range fn.Decls {
+			vd, ok := decl.(*ValueDecl)
+			if !ok || vd.Const {
+				continue
+			}
+			for i, vx := range vd.Values {
+				path, sel, ok := findExternalRealmRef(store, fn, pn.PkgPath, vx, false)
+				if !ok {
+					continue
+				}
+				if len(vd.NameExprs) == len(vd.Values) {
+					refs[vd.NameExprs[i].Name] = externalRef{path, sel}
+				} else {
+					for _, nx := range vd.NameExprs {
+						refs[nx.Name] = externalRef{path, sel}
+					}
+				}
+			}
+		}
+	}
+	if len(refs) == 0 {
+		return
+	}
+
+	// A variable may be reassigned to a local object before it's written
+	// through, which can't be told apart statically: only report the writes
+	// through the variables which always reference the external object, i.e.
+	// which are never assigned to nor have their address taken.
+	type write struct {
+		name Name
+		loc  Location
+	}
+	var writes []write
+	reassigned := map[Name]bool{}
+	for _, fn := range pn.FileSet.Files {
+		Transcribe(fn, func(ns []Node, ftype TransField, index int, n Node, stage TransStage) (Node, TransCtrl) {
+			if stage != TRANS_ENTER {
+				return n, TRANS_CONTINUE
+			}
+			var lhs Exprs
+			switch n := n.(type) {
+			case *AssignStmt:
+				if n.Op == DEFINE {
+					return n, TRANS_CONTINUE
+				}
+				lhs = n.Lhs
+			case *IncDecStmt:
+				lhs = Exprs{n.X}
+			case *RangeStmt:
+				if n.Op == DEFINE {
+					return n, TRANS_CONTINUE
+				}
+				lhs = Exprs{n.Key, n.Value}
+			case *RefExpr:
+				// e.g. &p, which may be used to reassign p.
+				nx, _ := sharedAssignRoot(store, lastBlockNode(ns), n.X)
+				if nx != nil && !isShadowed(ns, nx.Name) {
+					reassigned[nx.Name] = true
+				}
+				return n, TRANS_CONTINUE
+			default:
+				return n, TRANS_CONTINUE
+			}
+			last := lastBlockNode(ns)
+			for _, lx := range lhs {
+				if lx == nil {
+					continue
+				}
+				nx, shared := sharedAssignRoot(store, last, lx)
+				if nx == nil {
+					continue
+				}
+				if _, ok := refs[nx.Name]; !ok || isShadowed(ns, nx.Name) {
+					continue
+				}
+				if shared {
+					loc := Location3(pn.PkgPath, fn.FileName, n.GetSpan())
+					writes = append(writes, write{nx.Name, loc})
+				} else {
+					reassigned[nx.Name] = true
+				}
+			}
+			return n, TRANS_CONTINUE
+		})
+	}
+	for _, w := range writes {
+		if reassigned[w.name] {
+			continue
+		}
+		ref := refs[w.name]
+		panic(fmt.Sprintf(
+			"%s: realm variable %s references object %s.%s of external realm %s, "+
+				"which is readonly: use a copy or an accessor function instead",
+			w.loc.String(), w.name, ref.path, ref.sel, ref.path))
+	}
+}
+
+// sharedAssignRoot returns the name at the root of the assignable x, and
+// whether assigning to x modifies an object referenced by the value of that
+// name, rather than the value itself.
+func sharedAssignRoot(store Store, last BlockNode, x Expr) (nx *NameExpr, shared bool) {
+	for {
+		switch lx := x.(type) {
+		case *NameExpr:
+			return lx, shared
+		case *SelectorExpr:
+			if _, ok := baseOf(evalStaticTypeOf(store, last, lx.X)).(*PointerType); ok {
+				shared = true
+			}
+			x = lx.X
+		case *IndexExpr:
+			switch baseOf(evalStaticTypeOf(store, last, lx.X)).(type) {
+			case *SliceType, *MapType, *PointerType:
+				shared = true
+			}
+			x = lx.X
+		case *StarExpr:
+			shared = true
+			x = lx.X
+		default:
+			return nil, false
+		}
+	}
+}
+
+// lastBlockNode returns the innermost block node of the transcribe stack ns.
+func lastBlockNode(ns []Node) BlockNode {
+	for i := len(ns) - 1; i >= 0; i-- {
+		if bn, ok := ns[i].(BlockNode); ok {
+			return bn
+		}
+	}
+	panic("should not happen")
+}
+
+// isShadowed returns whether name is declared by a block of ns below the
+// file level.
+func isShadowed(ns []Node, name Name) bool {
+	for _, n := range ns {
+		switch bn := n.(type) {
+		case *FileNode, *PackageNode:
+		case BlockNode:
+			if _, ok := bn.GetLocalIndex(name); ok {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// findExternalRealmRef reports whether x is itself a reference to an object
+// of a realm package other than pkgPath: a selector of that package, either
+// with its address taken (ref) or of a reference type. Composite literals
+// are not inspected, as their other elements may be local objects: writes
+// through their elements are left to the runtime checks. Neither are
+// function calls, as they may return fresh objects.
+func findExternalRealmRef(store Store, last BlockNode, pkgPath string, x Expr, ref bool) (path string, sel Name, ok bool) {
+	switch x := x.(type) {
+	case *RefExpr:
+		return findExternalRealmRef(store, last, pkgPath, x.X, true)
+	case *SelectorExpr:
+		if _, isPkg := evalStaticTypeOf(store, last, x.X).(*PackageType); !isPkg {
+			// e.g. &ext.Value.Field
+			if ref {
+				return findExternalRealmRef(store, last, pkgPath, x.X, true)
+			}
+			return
+		}
+		// Package names are constant once preprocessed.
+		cx, isConst := x.X.(*ConstExpr)
+		if !isConst {
+			return
+		}
+		var extPath string
+		switch pv := cx.V.(type) {
+		case *PackageValue:
+			extPath = pv.PkgPath
+		case RefValue:
+			extPath = pv.PkgPath
+		}
+		if extPath == "" || extPath == pkgPath || !IsRealmPath(extPath) {
+			return
+		}
+		if !ref {
+			switch baseOf(evalStaticTypeOf(store, last, x)).(type) {
+			case *PointerType, *SliceType, *MapType:
+			default:
+				return
+			}
+		}
+		return extPath, x.Sel, true
+	case *IndexExpr:
+		if ref {
+			return findExternalRealmRef(store, last, pkgPath, x.X, true)
+		}
+	case *SliceExpr:
+		// slicing always shares the underlying array.
+		return findExternalRealmRef(store, last, pkgPath, x.X, true)
+	}
+	return
+}
+
 func kindString(xt Type) string {
 	if xt != nil {
 		return xt.Kind().String()
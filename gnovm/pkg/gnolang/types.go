@@ -81,6 +81,7 @@ func (blockType) assertType()      {}
 func (heapItemType) assertType()   {}
 func (*tupleType) assertType()     {}
 func (RefType) assertType()        {}
+func (*genericType) assertType()   {}
 
 // IsImmutable
 func (PrimitiveType) IsImmutable() bool    { return true }
@@ -100,6 +101,7 @@ func (blockType) IsImmutable() bool        { return false }
 func (heapItemType) IsImmutable() bool     { return false }
 func (*tupleType) IsImmutable() bool       { panic("should not happen") }
 func (RefType) IsImmutable() bool          { panic("should not happen") }
+func (*genericType) IsImmutable() bool     { panic("should not happen") }
 
 // ----------------------------------------
 // Primitive types
@@ -906,15 +908,30 @@ func (pt *PackageType) IsNamed() bool {
 type InterfaceType struct {
 	PkgPath string
 	Methods []FieldType
-	Generic Name // for uverse "generics"
+	Generic Name       // for uverse "generics"
+	Terms   []TypeTerm // type set of a constraint, e.g. ~int | string
 
 	typeid TypeID
 }
 
+// A term of the type set of a constraint interface.
+// Constraint interfaces may only be used as type parameter constraints.
+type TypeTerm struct {
+	Tilde bool // if true, any type whose underlying type is Type.
+	Type  Type
+}
+
+func (tt TypeTerm) String() string {
+	if tt.Tilde {
+		return "~" + tt.Type.String()
+	}
+	return tt.Type.String()
+}
+
 // General empty interface.
 
 func (it *InterfaceType) IsEmptyInterface() bool {
-	return len(it.Methods) == 0
+	return len(it.Methods) == 0 && len(it.Terms) == 0
 }
 
 func (it *InterfaceType) Kind() Kind {
@@ -935,7 +952,19 @@ func (it *InterfaceType) TypeID() TypeID {
 		ms := FieldTypeList(it.Methods)
 		// XXX pre-sort.
 		sort.Sort(ms)
-		it.typeid = typeid("interface{" + ms.TypeIDForPackage(it.PkgPath).String() + "}")
+		ts := ""
+		for i, tt := range it.Terms {
+			if i == 0 {
+				ts += ";"
+			} else {
+				ts += "|"
+			}
+			if tt.Tilde {
+				ts += "~"
+			}
+			ts += tt.Type.TypeID().String()
+		}
+		it.typeid = typeid("interface{" + ms.TypeIDForPackage(it.PkgPath).String() + ts + "}")
 	}
 	return it.typeid
 }
@@ -955,6 +984,16 @@ func (it *InterfaceType) String() string {
 		return fmt.Sprintf("<%s>{%s}",
 			it.Generic,
 			FieldTypeList(it.Methods).String())
+	} else if len(it.Terms) > 0 {
+		ts := make([]string, len(it.Terms))
+		for i, tt := range it.Terms {
+			ts[i] = tt.String()
+		}
+		if len(it.Methods) == 0 {
+			return fmt.Sprintf("interface {%s}", strings.Join(ts, " | "))
+		}
+		return fmt.Sprintf("interface {%s; %s}",
+			FieldTypeList(it.Methods).String(), strings.Join(ts, " | "))
 	} else {
 		return fmt.Sprintf("interface {%s}",
 			FieldTypeList(it.Methods).String())
@@ -1083,9 +1122,9 @@ func (ct *ChanType) TypeID() TypeID {
 		case SEND | RECV:
 			ct.typeid = typeidf("chan{%s}", ct.Elt.TypeID().String())
 		case SEND:
-			ct.typeid = typeidf("<-chan{%s}", ct.Elt.TypeID().String())
-		case RECV:
 			ct.typeid = typeidf("chan<-{%s}", ct.Elt.TypeID().String())
+		case RECV:
+			ct.typeid = typeidf("<-chan{%s}", ct.Elt.TypeID().String())
 		default:
 			panic("should not happen")
 		}
@@ -1098,9 +1137,9 @@ func (ct *ChanType) String() string {
 	case SEND | RECV:
 		return "chan " + ct.Elt.String()
 	case SEND:
-		return "<-chan " + ct.Elt.String()
-	case RECV:
 		return "chan<- " + ct.Elt.String()
+	case RECV:
+		return "<-chan " + ct.Elt.String()
 	default:
 		panic("should not happen")
 	}
@@ -1877,6 +1916,47 @@ func (tt *tupleType) IsNamed() bool {
 	panic("typleType has no property called named")
 }
 
+// ----------------------------------------
+// genericType
+
+// genericType is the static type of the name of a generic function or
+// type declaration. Generic declarations have no value at runtime; only
+// their instances do, so a genericType is only used by the preprocessor
+// and is never persisted.
+type genericType struct {
+	PkgPath string
+	Name    Name
+	Decl    Decl      // generic *FuncDecl or *TypeDecl
+	File    *FileNode // file of Decl
+
+	instances map[string]*genericInstance // by type argument TypeIDs.
+	pending   []*genericInstance          // in order of instantiation.
+}
+
+func (gt *genericType) Kind() Kind {
+	return GenericKind
+}
+
+func (gt *genericType) TypeID() TypeID {
+	return typeidf("%s.%s[...]", gt.PkgPath, gt.Name)
+}
+
+func (gt *genericType) String() string {
+	return fmt.Sprintf("%s.%s[...]", gt.PkgPath, gt.Name)
+}
+
+func (gt *genericType) Elem() Type {
+	panic("genericType has no elem type")
+}
+
+func (gt *genericType) GetPkgPath() string {
+	return gt.PkgPath
+}
+
+func (gt *genericType) IsNamed() bool {
+	return true
+}
+
 // ----------------------------------------
 // RefType
 
@@ -1947,6 +2027,7 @@ const (
 	HeapItemKind // not in go.
 	TupleKind    // not in go.
 	RefTypeKind  // not in go.
+	GenericKind  // not in go.
 )
 
 // This is generally slower than switching on baseOf(t).
@@ -2023,6 +2104,8 @@ func KindOf(t Type) Kind {
 		return TupleKind
 	case RefType:
 		return RefTypeKind
+	case *genericType:
+		return GenericKind
 	default:
 		panic(fmt.Sprintf("unexpected type %#v", t))
 	}
@@ -2193,6 +2276,11 @@ func IsImplementedBy(it Type, ot Type) bool {
 	}
 }
 
+// ImplementsError returns true if t implements the error interface.
+func ImplementsError(t Type) bool {
+	return IsImplementedBy(gErrorType, t)
+}
+
 // Given a map of generic type names, match the tmpl type which
 // might include generics with the spec type which is concrete
 // with no generics, and update the lookup map or panic if error.
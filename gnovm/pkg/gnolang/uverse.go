@@ -8,7 +8,6 @@ import (
 	"io"
 
 	bm "github.com/gnolang/gno/gnovm/pkg/benchops"
-	"github.com/gnolang/gno/tm2/pkg/crypto"
 )
 
 // ----------------------------------------
@@ -60,6 +59,17 @@ var gStringerType = &DeclaredType{
 	sealed: true,
 }
 
+// gComparableType is the constraint satisfied by strictly comparable
+// types, see satisfiesConstraint.
+var gComparableType = &DeclaredType{
+	PkgPath: uversePkgPath,
+	Name:    "comparable",
+	Base: &InterfaceType{
+		PkgPath: uversePkgPath,
+	},
+	sealed: true,
+}
+
 var gAddressType = &DeclaredType{
 	PkgPath: uversePkgPath,
 	Name:    "address",
@@ -68,6 +78,11 @@ var gAddressType = &DeclaredType{
 	// methods defined in makeUverseNode()
 }
 
+// IsAddressType reports whether t is the builtin address type.
+func IsAddressType(t Type) bool {
+	return t != nil && t.TypeID() == gAddressType.TypeID()
+}
+
 var gCoinType = &DeclaredType{
 	PkgPath: uversePkgPath,
 	Name:    "gnocoin",
@@ -289,6 +304,7 @@ func makeUverseNode() {
 	def("uint64", asValue(Uint64Type))
 	def("error", asValue(gErrorType))
 	def("any", asValue(&InterfaceType{}))
+	def("comparable", asValue(gComparableType))
 
 	// Values
 	def("true", untypedBool(true))
@@ -313,7 +329,7 @@ func makeUverseNode() {
 				// arg1 PointerValue is not a pointer,
 				// so the modification here is only local.
 				newArrayValue := m.Alloc.NewDataArray(len(arg1String))
-				copy(newArrayValue.Data, []byte(arg1String))
+				copy(newArrayValue.Data, arg1String)
 				arg1.TV = &TypedValue{
 					T: m.Alloc.NewType(&SliceType{ // TODO: reuse
 						Elt: Uint8Type,
@@ -555,6 +571,28 @@ func makeUverseNode() {
 			m.PushValue(res0)
 		},
 	)
+	defNative("close",
+		Flds( // params
+			"c", AnyT(),
+		),
+		nil, // results
+		func(m *Machine) {
+			arg0 := m.LastBlock().GetParams1(m.Store)
+			if _, ok := baseOf(arg0.TV.T).(*ChanType); !ok {
+				panic(fmt.Sprintf(
+					"invalid operation: close of non-channel type %s",
+					arg0.TV.T.String()))
+			}
+			cv, _ := arg0.TV.V.(*ChanValue)
+			if cv == nil {
+				m.Panic(typedString("close of nil channel"))
+			}
+			if cv.Closed {
+				m.Panic(typedString("close of closed channel"))
+			}
+			cv.close()
+		},
+	)
 	defNative("copy",
 		Flds( // params
 			"dst", GenT("X", nil),
@@ -591,11 +629,15 @@ func makeUverseNode() {
 						return
 					}
 					dstv := dst.TV.V.(*SliceValue)
-					// TODO: consider an optimization if dstv.Data != nil.
-					for i := range minl {
-						dstev := dstv.GetPointerAtIndexInt2(m.Store, i, bdt.Elt)
-						srcev := src.TV.GetPointerAtIndexInt(m.Store, i)
-						dstev.Assign2(m.Alloc, m.Store, m.Realm, srcev.Deref(), false)
+					if dstb := dstv.GetBase(m.Store); dstb.Data != nil {
+						// Like assigning each byte, which doesn't update the realm.
+						copy(dstb.Data[dstv.Offset:dstv.Offset+minl], src.TV.GetString())
+					} else {
+						for i := range minl {
+							dstev := dstv.GetPointerAtIndexInt2(m.Store, i, bdt.Elt)
+							srcev := src.TV.GetPointerAtIndexInt(m.Store, i)
+							dstev.Assign2(m.Alloc, m.Store, m.Realm, srcev.Deref(), false)
+						}
 					}
 					res0 := TypedValue{
 						T: IntType,
@@ -803,8 +845,23 @@ func makeUverseNode() {
 				}
 			case *ChanType:
 				switch vargsl {
-				case 0, 1:
-					panic("not yet implemented")
+				case 0:
+					m.PushValue(TypedValue{
+						T: tt,
+						V: m.Alloc.NewChan(0),
+					})
+					return
+				case 1:
+					lv := vargs.TV.GetPointerAtIndexInt(m.Store, 0).Deref()
+					li := int(lv.ConvertGetInt())
+					if li < 0 {
+						m.Panic(typedString(`makechan: size out of range`))
+					}
+					m.PushValue(TypedValue{
+						T: tt,
+						V: m.Alloc.NewChan(li),
+					})
+					return
 				default:
 					panic("make() of chan type takes 1 or 2 arguments")
 				}
@@ -912,14 +969,22 @@ func makeUverseNode() {
 		),
 		func(m *Machine) {
 			arg0 := m.LastBlock().GetParams1(nil)
-			b32addr := arg0.TV.GetString()
-			addr, err := crypto.AddressFromBech32(b32addr)
-			if err != nil {
-				m.PushValue(typedBool(false))
-				return
+			_, err := ParseAddress(arg0.TV.GetString())
+			m.PushValue(typedBool(err == nil))
+		},
+	)
+	defNativeMethod("address", "Bytes",
+		nil, // params
+		Flds( // results
+			"", "[]byte",
+		),
+		func(m *Machine) {
+			arg0 := m.LastBlock().GetParams1(nil)
+			res0 := TypedValue{T: gByteSliceType}
+			if addr, err := ParseAddress(arg0.TV.GetString()); err == nil {
+				res0.V = m.Alloc.NewSliceFromData(addr.Bytes())
 			}
-			_ = addr
-			m.PushValue(typedBool(len(addr) == 20))
+			m.PushValue(res0)
 		},
 	)
 	def("gnocoin", asValue(gCoinType))
@@ -1057,11 +1122,11 @@ func makeUverseNode() {
 	//
 	// XXX This is only enabled in testing mode (for now), and test
 	// developers should be aware that behavior will change to be like
-	// above; currently it doesn't cache-wrap the fn function so residual
-	// state mutations remain even after revive(), but they will be
-	// "magically" rolled back upon panic in the future. The fn function
-	// must *always* panic in the end in order to prevent state mutations
-	// after a non-aborting transaction.
+	// above; currently it doesn't cache-wrap the fn function: only the
+	// calls fn crossed into realms with are discarded (see enterRealm),
+	// and residual state mutations of the realm calling revive() remain
+	// even after revive(). The fn function must *always* panic in the end
+	// in order to prevent state mutations after a non-aborting transaction.
 	defNative("revive",
 		Flds( // params
 			"fn", FuncT(nil, nil),
@@ -1107,12 +1172,6 @@ func copyListToData(dst []byte, tvs []TypedValue) {
 	}
 }
 
-func copyListToRunes(dst []rune, tvs []TypedValue) {
-	for i := range tvs {
-		dst[i] = tvs[i].GetInt32()
-	}
-}
-
 // uversePrint is used for the print and println functions.
 // println passes newline = true.
 // xv contains the variadic argument passed to the function.
@@ -4,6 +4,8 @@ import (
 	"fmt"
 	"math"
 	"math/big"
+	"strings"
+	"unicode/utf8"
 
 	"github.com/cockroachdb/apd/v3"
 	"github.com/gnolang/gno/gnovm/pkg/gnolang/internal/softfloat"
@@ -1029,15 +1031,23 @@ func ConvertTo(alloc *Allocator, store Store, tv *TypedValue, t Type, isConst bo
 		case *SliceType:
 			switch cbt.Elt.Kind() {
 			case Uint8Kind:
-				tv.V = alloc.NewSliceFromData([]byte(tv.GetString()))
+				// The array is allocated before copying the string, so that
+				// its capacity is exactly its length.
+				str := tv.GetString()
+				av := alloc.NewDataArray(len(str))
+				copy(av.Data, str)
+				tv.V = alloc.NewSlice(av, 0, len(str), len(str))
 				tv.T = t // after tv.GetString()
 			case Int32Kind:
-				runes := []TypedValue{}
 				str := tv.GetString()
+				n := utf8.RuneCountInString(str)
+				av := alloc.NewListArray(n)
+				i := 0
 				for _, r := range str {
-					runes = append(runes, typedRune(r))
+					av.List[i] = typedRune(r)
+					i++
 				}
-				tv.V = alloc.NewSliceFromList(runes)
+				tv.V = alloc.NewSlice(av, 0, n, n)
 				tv.T = t // after tv.GetString()
 			default:
 				panic(fmt.Sprintf(
@@ -1066,26 +1076,26 @@ func ConvertTo(alloc *Allocator, store Store, tv *TypedValue, t Type, isConst bo
 				svl := sv.Length
 				svb := sv.GetBase(store)
 				if svb.Data == nil {
+					// The string is built directly from the list, without
+					// an intermediate []byte or []rune.
+					var sb strings.Builder
 					switch tk {
 					case Uint8Kind:
-						data := make([]byte, svl)
-						copyListToData(
-							data[:svl],
-							svb.List[svo:svo+svl])
-						strv := alloc.NewString(string(data))
-						tv.T = t
-						tv.V = strv
+						sb.Grow(svl)
+						for _, elt := range svb.List[svo : svo+svl] {
+							sb.WriteByte(elt.GetUint8())
+						}
 					case Int32Kind:
-						runes := make([]rune, svl)
-						copyListToRunes(
-							runes[:svl],
-							svb.List[svo:svo+svl])
-						strv := alloc.NewString(string(runes))
-						tv.T = t
-						tv.V = strv
+						sb.Grow(svl)
+						for _, elt := range svb.List[svo : svo+svl] {
+							sb.WriteRune(elt.GetInt32())
+						}
 					default:
 						panic("should not happen")
 					}
+					strv := alloc.NewString(sb.String())
+					tv.T = t
+					tv.V = strv
 				} else {
 					data := svb.Data[svo : svo+svl]
 					strv := alloc.NewString(string(data))
@@ -1490,3 +1500,11 @@ func toBigInt(d *apd.Decimal) *big.Int {
 	}
 	return bi
 }
+
+// isStringSliceConversion returns whether converting a value of kind from to
+// a value of kind to converts between a string and a []byte or []rune, which
+// copies the string.
+func isStringSliceConversion(from, to Kind) bool {
+	return (from == StringKind && to == SliceKind) ||
+		(from == SliceKind && to == StringKind)
+}
@@ -60,6 +60,11 @@ func (sv *StructValue) DeepFill(store Store) Value {
 	return sv
 }
 
+// Channels can't be persisted, so their values are always filled.
+func (cv *ChanValue) DeepFill(store Store) Value {
+	return cv
+}
+
 // XXX implement these too
 func (fv *FuncValue) DeepFill(store Store) Value         { panic("not yet implemented") }
 func (mv *MapValue) DeepFill(store Store) Value          { panic("not yet implemented") }
@@ -237,6 +237,25 @@ func (mv *MapValue) ProtectedString(seen *seenValues) string {
 	return "map{" + strings.Join(ss, ",") + "}"
 }
 
+func (cv *ChanValue) String() string {
+	return cv.ProtectedString(newSeenValues())
+}
+
+func (cv *ChanValue) ProtectedString(seen *seenValues) string {
+	if i := seen.IndexOf(cv); i != -1 {
+		return fmt.Sprintf("ref@%d", i)
+	}
+
+	seen.Put(cv)
+	defer seen.Pop()
+
+	ss := make([]string, 0, len(cv.Buffer))
+	for _, tv := range cv.Buffer {
+		ss = append(ss, tv.ProtectedString(seen))
+	}
+	return fmt.Sprintf("chan(%d){%s}", cv.Cap, strings.Join(ss, ","))
+}
+
 func (tv TypeValue) String() string {
 	return fmt.Sprintf("typeval{%s}",
 		tv.Type.String())
@@ -409,8 +428,6 @@ func (tv *TypedValue) ProtectedSprint(seen *seenValues, considerDeclaredType boo
 		panic("should not happen")
 	case *PackageType:
 		return tv.V.(*PackageValue).String()
-	case *ChanType:
-		panic("not yet implemented")
 	case *TypeType:
 		return tv.V.(TypeValue).String()
 	default:
@@ -423,7 +440,7 @@ func (tv *TypedValue) ProtectedSprint(seen *seenValues, considerDeclaredType boo
 		if tv.IsReadonly() {
 			roPre, roPost = "readonly(", ")"
 		}
-		// *ArrayType, *SliceType, *StructType, *MapType
+		// *ArrayType, *SliceType, *StructType, *MapType, *ChanType
 		if ps, ok := tv.V.(protectedStringer); ok {
 			return roPre + ps.ProtectedString(seen) + roPost
 		} else if s, ok := tv.V.(fmt.Stringer); ok {
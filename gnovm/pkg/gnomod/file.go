@@ -17,6 +17,13 @@ type File struct {
 	// It is intended to be set by the `gno` cli when initializing or upgrading a module.
 	Gno string `toml:"gno" json:"gno"`
 
+	// Description is a short description of the module, shown along with its
+	// documentation, for instance by gnoweb.
+	Description string `toml:"description,omitempty" json:"description,omitempty"`
+
+	// Authors is the list of the authors of the module, like "Name <email>".
+	Authors []string `toml:"authors,omitempty" json:"authors,omitempty"`
+
 	// Ignore indicate that the module will be ignored by the gno toolchain but still usable in development environments.
 	Ignore bool `toml:"ignore,omitempty" json:"ignore,omitempty"`
 
@@ -78,6 +78,8 @@ func TestMarshalTomlHelper(t *testing.T) {
 				file.Ignore = true
 				file.Draft = true
 				file.Private = true
+				file.Description = "A test realm."
+				file.Authors = []string{"Alice <alice@example.com>", "Bob"}
 				file.Replace = []Replace{
 					{Old: "gno.land/r/test", New: "gno.land/r/test/v2"},
 					{Old: "gno.land/r/test/v3", New: "../.."},
@@ -87,7 +89,7 @@ func TestMarshalTomlHelper(t *testing.T) {
 				file.AddPkg.Height = 42
 				return &file
 			}(),
-			expected: "module = \"gno.land/r/test\"\ngno = \"0.9\"\nignore = true\ndraft = true\nprivate = true\n\n[[replace]]\n  old = \"gno.land/r/test\"\n  new = \"gno.land/r/test/v2\"\n\n[[replace]]\n  old = \"gno.land/r/test/v3\"\n  new = \"../..\"\n\n[addpkg]\n  creator = \"addr1\"\n  height = 42\n",
+			expected: "module = \"gno.land/r/test\"\ngno = \"0.9\"\ndescription = \"A test realm.\"\nauthors = [\"Alice <alice@example.com>\", \"Bob\"]\nignore = true\ndraft = true\nprivate = true\n\n[[replace]]\n  old = \"gno.land/r/test\"\n  new = \"gno.land/r/test/v2\"\n\n[[replace]]\n  old = \"gno.land/r/test/v3\"\n  new = \"../..\"\n\n[addpkg]\n  creator = \"addr1\"\n  height = 42\n",
 		},
 		{
 			name:     "empty",
@@ -0,0 +1,194 @@
+package objecthash
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"regexp"
+	"slices"
+	"strings"
+	"unicode/utf8"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+)
+
+// ErrNonIntegerNumber is returned by Canonicalize for a number which isn't an
+// integer, as such numbers have several encodings.
+var ErrNonIntegerNumber = errors.New("non-integer number")
+
+// integerRe matches the JSON numbers which are integers.
+var integerRe = regexp.MustCompile(`^-?(0|[1-9][0-9]*)$`)
+
+// CanonicalJSON returns the canonical JSON encoding of oo, in its stored form
+// like the objects returned by DecodeObject: its amino JSON encoding, with
+// the type URL of the object as "@type", in the canonical form of
+// Canonicalize. It's meant for the clients inspecting and comparing objects:
+// the hash of an object is the one of its binary encoding, see HashObject.
+func CanonicalJSON(oo gno.Object) ([]byte, error) {
+	bz, err := amino.MarshalJSONAny(oo)
+	if err != nil {
+		return nil, err
+	}
+	return Canonicalize(bz)
+}
+
+// Canonicalize returns the canonical form of the JSON value bz, so that
+// equal values have the same encoding:
+//
+//   - no whitespace;
+//   - the keys of the objects are sorted by their bytes, and unique;
+//   - the strings are escaped with the short escapes (\" \\ \b \f \n \r \t),
+//     the other control characters with \u00XX in lower case, and the other
+//     characters are written as UTF-8;
+//   - the numbers must be integers, and are written in decimal, without
+//     exponent, fraction or leading zeros, and -0 as 0. Amino writes the
+//     64-bit integers as strings, which are kept as they are.
+func Canonicalize(bz []byte) ([]byte, error) {
+	dec := json.NewDecoder(bytes.NewReader(bz))
+	dec.UseNumber()
+	v, err := decodeValue(dec)
+	if err != nil {
+		return nil, err
+	}
+	if _, err := dec.Token(); err != io.EOF {
+		return nil, errors.New("trailing data after JSON value")
+	}
+
+	var buf bytes.Buffer
+	if err := writeCanonical(&buf, v); err != nil {
+		return nil, err
+	}
+	return buf.Bytes(), nil
+}
+
+// decodeValue reads the next value of dec, like dec.Decode with an any, but
+// returns an error for the duplicate keys of objects instead of dropping
+// all but the last value.
+func decodeValue(dec *json.Decoder) (any, error) {
+	tok, err := dec.Token()
+	if err != nil {
+		return nil, err
+	}
+	switch tok {
+	case json.Delim('['):
+		arr := []any{}
+		for dec.More() {
+			elem, err := decodeValue(dec)
+			if err != nil {
+				return nil, err
+			}
+			arr = append(arr, elem)
+		}
+		_, err := dec.Token() // ]
+		return arr, err
+	case json.Delim('{'):
+		obj := map[string]any{}
+		for dec.More() {
+			tok, err := dec.Token()
+			if err != nil {
+				return nil, err
+			}
+			key := tok.(string)
+			if _, ok := obj[key]; ok {
+				return nil, fmt.Errorf("duplicate key %q", key)
+			}
+			if obj[key], err = decodeValue(dec); err != nil {
+				return nil, err
+			}
+		}
+		_, err := dec.Token() // }
+		return obj, err
+	}
+	return tok, nil
+}
+
+func writeCanonical(buf *bytes.Buffer, v any) error {
+	switch v := v.(type) {
+	case nil:
+		buf.WriteString("null")
+	case bool:
+		if v {
+			buf.WriteString("true")
+		} else {
+			buf.WriteString("false")
+		}
+	case json.Number:
+		s := v.String()
+		if !integerRe.MatchString(s) {
+			return fmt.Errorf("%w: %s", ErrNonIntegerNumber, s)
+		}
+		if s == "-0" {
+			s = "0"
+		}
+		buf.WriteString(s)
+	case string:
+		writeString(buf, v)
+	case []any:
+		buf.WriteByte('[')
+		for i, elem := range v {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			if err := writeCanonical(buf, elem); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte(']')
+	case map[string]any:
+		keys := make([]string, 0, len(v))
+		for key := range v {
+			keys = append(keys, key)
+		}
+		slices.Sort(keys)
+		buf.WriteByte('{')
+		for i, key := range keys {
+			if i > 0 {
+				buf.WriteByte(',')
+			}
+			writeString(buf, key)
+			buf.WriteByte(':')
+			if err := writeCanonical(buf, v[key]); err != nil {
+				return err
+			}
+		}
+		buf.WriteByte('}')
+	default:
+		panic(fmt.Sprintf("unexpected JSON value %T", v))
+	}
+	return nil
+}
+
+func writeString(buf *bytes.Buffer, s string) {
+	const hex = "0123456789abcdef"
+	buf.WriteByte('"')
+	for _, r := range strings.ToValidUTF8(s, string(utf8.RuneError)) {
+		switch r {
+		case '"':
+			buf.WriteString(`\"`)
+		case '\\':
+			buf.WriteString(`\\`)
+		case '\b':
+			buf.WriteString(`\b`)
+		case '\f':
+			buf.WriteString(`\f`)
+		case '\n':
+			buf.WriteString(`\n`)
+		case '\r':
+			buf.WriteString(`\r`)
+		case '\t':
+			buf.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				buf.WriteString(`\u00`)
+				buf.WriteByte(hex[r>>4])
+				buf.WriteByte(hex[r&0xf])
+			} else {
+				buf.WriteRune(r)
+			}
+		}
+	}
+	buf.WriteByte('"')
+}
@@ -0,0 +1,78 @@
+package objecthash
+
+import (
+	"encoding/json"
+	"testing"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCanonicalize(t *testing.T) {
+	tests := []struct {
+		name, in, out, err string
+	}{
+		{"sorted keys", `{"b":1,"a":{"d":[],"c":null}}`, `{"a":{"c":null,"d":[]},"b":1}`, ""},
+		{"whitespace", " [ 1 , true ,\n\"x\" ] ", `[1,true,"x"]`, ""},
+		{"unescaped html", `"a<b&"`, `"a<b&"`, ""},
+		{"short escapes", `"\"\\\u0008\u000c\u000a\u000d\u0009\/"`, `"\"\\\b\f\n\r\t/"`, ""},
+		{"control escapes", `"\u0001\u001F"`, `"\u0001\u001f"`, ""},
+		{"unicode", `"é😀"`, `"é😀"`, ""},
+		{"negative zero", `-0`, `0`, ""},
+		{"large integer", `123456789012345678901234567890`, `123456789012345678901234567890`, ""},
+		{"fraction", `1.0`, "", "non-integer number: 1.0"},
+		{"exponent", `[1e3]`, "", "non-integer number: 1e3"},
+		{"duplicate key", `{"a":{"b":1,"b":2}}`, "", `duplicate key "b"`},
+		{"trailing data", `{} {}`, "", "trailing data after JSON value"},
+		{"invalid", `{"a":}`, "", "missing value after object key"},
+	}
+	for _, tc := range tests {
+		t.Run(tc.name, func(t *testing.T) {
+			out, err := Canonicalize([]byte(tc.in))
+			if tc.err != "" {
+				assert.EqualError(t, err, tc.err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.out, string(out))
+		})
+	}
+}
+
+func TestCanonicalJSON(t *testing.T) {
+	fs := make([]gno.TypedValue, 2)
+	fs[0] = gno.TypedValue{T: gno.StringType, V: gno.StringValue("a<b")}
+	fs[1] = gno.TypedValue{T: gno.IntType}
+	fs[1].SetInt(-1)
+	sv := &gno.StructValue{Fields: fs}
+	sv.SetObjectID(gno.ObjectID{PkgID: gno.PkgIDFromPkgPath("gno.land/r/test/json"), NewTime: 3})
+
+	js, err := CanonicalJSON(sv)
+	require.NoError(t, err)
+	assert.Contains(t, string(js), `"V":{"@type":"/gno.StringValue","value":"a<b"}`)
+	assert.Contains(t, string(js), `"N":"//////////8="`)
+	assert.Regexp(t, `^\{"@type":"/gno.StructValue","Fields":\[`, string(js))
+}
+
+func FuzzCanonicalize(f *testing.F) {
+	f.Add([]byte(`{"b":1,"a":[true,null,"<"]}`))
+	f.Add([]byte(`-0`))
+	f.Add([]byte(`"\ud800"`))
+
+	f.Fuzz(func(t *testing.T, bz []byte) {
+		out, err := Canonicalize(bz)
+		if err != nil {
+			return
+		}
+		// The canonical form is valid JSON, with the same value, and is its
+		// own canonical form.
+		var v1, v2 any
+		require.NoError(t, json.Unmarshal(bz, &v1))
+		require.NoError(t, json.Unmarshal(out, &v2))
+		assert.Equal(t, v1, v2)
+		out2, err := Canonicalize(out)
+		require.NoError(t, err)
+		assert.Equal(t, out, out2)
+	})
+}
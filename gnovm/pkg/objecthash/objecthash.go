@@ -0,0 +1,88 @@
+// Package objecthash reproduces the encoding and the hash of the realm
+// objects as the gno store persists them, so that clients can recompute and
+// verify the hashes of the objects of a realm, like those of the RefValues
+// in the realm ops of the filetests, without running the VM.
+//
+// An object is stored in the base store under the key "oid:<object id>",
+// as its hash followed by its encoding. The encoding is the amino binary
+// encoding of the object as an Any, i.e. prefixed with its type URL, in its
+// stored form: its child objects are replaced by RefValues, which carry the
+// hash of the children it owns, and the declared types by RefTypes. Escaped
+// objects are referenced by ID only: their hashes are kept in the iavl store,
+// under their object ID. Amino's binary encoding is deterministic, so an
+// object has a single encoding.
+//
+// The hash of an object is the first Size bytes of the SHA-256 of its
+// encoding. The encoding includes the ObjectInfo of the object, with the
+// LastObjectSize of its previous save, which is zero for a new object.
+//
+// For the clients which inspect objects rather than hash them, CanonicalJSON
+// gives the JSON encoding of an object in a canonical form, with sorted keys
+// and a fixed encoding of the strings and numbers. It's not part of the
+// consensus: the hash remains the one of the binary encoding.
+package objecthash
+
+import (
+	"crypto/sha256"
+	"errors"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+)
+
+// Size is the size of an object hash.
+const Size = gno.HashSize
+
+// ErrShortValue is returned by ParseStored for a value too short to hold a
+// hash.
+var ErrShortValue = errors.New("stored object shorter than its hash")
+
+// Key returns the key of the object oid in the base store.
+func Key(oid gno.ObjectID) []byte {
+	return []byte("oid:" + oid.String())
+}
+
+// HashObject returns the hash of an object from its encoding.
+func HashObject(bz []byte) (hash [Size]byte) {
+	sum := sha256.Sum256(bz)
+	copy(hash[:], sum[:Size])
+	return hash
+}
+
+// EncodeObject returns the encoding of oo, which must be in its stored form,
+// like the objects returned by DecodeObject.
+func EncodeObject(oo gno.Object) ([]byte, error) {
+	return amino.MarshalAny(oo)
+}
+
+// DecodeObject decodes an object from its encoding, in its stored form.
+func DecodeObject(bz []byte) (gno.Object, error) {
+	var oo gno.Object
+	if err := amino.Unmarshal(bz, &oo); err != nil {
+		return nil, err
+	}
+	return oo, nil
+}
+
+// ParseStored splits the value of an object in the base store into its hash
+// and its encoding.
+func ParseStored(value []byte) (hash [Size]byte, bz []byte, err error) {
+	if len(value) < Size {
+		return hash, nil, ErrShortValue
+	}
+	copy(hash[:], value[:Size])
+	return hash, value[Size:], nil
+}
+
+// VerifyStored checks that the hash of an object in the base store is the
+// hash of its encoding, and returns the decoded object.
+func VerifyStored(value []byte) (gno.Object, error) {
+	hash, bz, err := ParseStored(value)
+	if err != nil {
+		return nil, err
+	}
+	if HashObject(bz) != hash {
+		return nil, errors.New("object hash mismatch")
+	}
+	return DecodeObject(bz)
+}
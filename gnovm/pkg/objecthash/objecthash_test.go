@@ -0,0 +1,148 @@
+package objecthash
+
+import (
+	"bytes"
+	"io"
+	"math"
+	"testing"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	storetypes "github.com/gnolang/gno/tm2/pkg/store/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func newStore() (gno.Store, store.Store) {
+	db := memdb.NewMemDB()
+	base := dbadapter.StoreConstructor(db, storetypes.StoreOptions{})
+	return gno.NewStore(nil, base, base), base
+}
+
+func TestStoredObjects(t *testing.T) {
+	st, base := newStore()
+	m := gno.NewMachineWithOptions(gno.MachineOptions{
+		PkgPath: "gno.land/r/test/hash",
+		Store:   st,
+		Output:  io.Discard,
+	})
+	m.RunMemPackage(&std.MemPackage{
+		Type: gno.MPUserProd,
+		Name: "hash",
+		Path: "gno.land/r/test/hash",
+		Files: []*std.MemFile{
+			{Name: "hash.gno", Body: `package hash
+
+type Node struct {
+	Name  string
+	Left  *Node
+	Right *Node
+}
+
+var Root = &Node{Name: "root", Left: &Node{Name: "left"}, Right: &Node{Name: "right"}}
+var Counts = map[string]int{"a": 1, "b": 2}`},
+		},
+	}, true)
+
+	// Every stored object matches its hash, and has a single encoding.
+	hashes := map[gno.ObjectID][Size]byte{}
+	objects := map[gno.ObjectID]gno.Object{}
+	it := store.PrefixIterator(base, []byte("oid:"))
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		if bytes.Contains(it.Key(), []byte("#")) {
+			continue // the realm info, e.g. "oid:<pkgid>:1#realm".
+		}
+		oo, err := VerifyStored(it.Value())
+		require.NoError(t, err, "%s", it.Key())
+		assert.Equal(t, Key(oo.GetObjectID()), it.Key())
+		hash, bz, err := ParseStored(it.Value())
+		require.NoError(t, err)
+		bz2, err := EncodeObject(oo)
+		require.NoError(t, err)
+		assert.Equal(t, bz, bz2)
+		hashes[oo.GetObjectID()] = hash
+		objects[oo.GetObjectID()] = oo
+	}
+	require.NotEmpty(t, objects)
+
+	// The RefValues to the owned children carry their hashes.
+	var refs int
+	for _, oo := range objects {
+		sv, ok := oo.(*gno.StructValue)
+		if !ok {
+			continue
+		}
+		for _, ftv := range sv.Fields {
+			pv, ok := ftv.V.(gno.PointerValue)
+			if !ok {
+				continue
+			}
+			ref := pv.Base.(gno.RefValue)
+			hiv, ok := objects[ref.ObjectID].(*gno.HeapItemValue)
+			require.True(t, ok)
+			child := hiv.Value.V.(gno.RefValue)
+			assert.Equal(t, hashes[child.ObjectID], [Size]byte(child.Hash.Hashlet))
+			refs++
+		}
+	}
+	assert.Equal(t, 2, refs)
+
+	_, _, err := ParseStored([]byte("short"))
+	assert.ErrorIs(t, err, ErrShortValue)
+	value := append(make([]byte, Size), []byte("not an object")...)
+	_, err = VerifyStored(value)
+	assert.Error(t, err)
+}
+
+func FuzzHashObject(f *testing.F) {
+	f.Add("", int64(0), false, uint64(1))
+	f.Add("hello", int64(-1), true, uint64(42))
+	f.Add("\x00\xff", int64(1)<<62, false, uint64(1)<<63)
+
+	f.Fuzz(func(t *testing.T, s string, n int64, b bool, ntime uint64) {
+		// A zero object ID can't be stored, and the decoding of object IDs
+		// is limited to an int64 time.
+		ntime = max(ntime&math.MaxInt64, 1)
+		st, base := newStore()
+		fs := make([]gno.TypedValue, 3)
+		fs[0] = gno.TypedValue{T: gno.StringType, V: gno.StringValue(s)}
+		fs[1] = gno.TypedValue{T: gno.IntType}
+		fs[1].SetInt(n)
+		fs[2] = gno.TypedValue{T: gno.BoolType}
+		fs[2].SetBool(b)
+		sv := &gno.StructValue{Fields: fs}
+		oid := gno.ObjectID{PkgID: gno.PkgIDFromPkgPath("gno.land/r/test/fuzz"), NewTime: ntime}
+		sv.SetObjectID(oid)
+
+		// The encoding of the object is the one SetObject stores, and hashes.
+		bz, err := EncodeObject(sv)
+		require.NoError(t, err)
+		js, err := CanonicalJSON(sv)
+		require.NoError(t, err)
+		st.SetObject(sv)
+		hash, stored, err := ParseStored(base.Get(Key(oid)))
+		require.NoError(t, err)
+		assert.Equal(t, bz, stored)
+		assert.Equal(t, HashObject(bz), hash)
+		assert.Equal(t, hash, [Size]byte(sv.GetHash().Hashlet))
+
+		// It's decoded to an object with the same encoding.
+		oo, err := DecodeObject(bz)
+		require.NoError(t, err)
+		bz2, err := EncodeObject(oo)
+		require.NoError(t, err)
+		assert.Equal(t, bz, bz2)
+
+		// And to the same canonical JSON, which is in its canonical form.
+		js2, err := CanonicalJSON(oo)
+		require.NoError(t, err)
+		assert.Equal(t, js, js2)
+		js3, err := Canonicalize(js)
+		require.NoError(t, err)
+		assert.Equal(t, js, js3)
+	})
+}
@@ -26,6 +26,7 @@ type LoadConfig struct {
 	Test                bool                       // load test dependencies
 	GnoRoot             string                     // used to override GNOROOT
 	ExtraWorkspaceRoots []string                   // extra workspaces root used to find dependencies
+	NoVendor            bool                       // don't load dependencies from the vendor directory
 }
 
 func (conf *LoadConfig) applyDefaults() error {
@@ -61,7 +62,7 @@ func Load(conf LoadConfig, patterns ...string) (PkgList, error) {
 		panic(fmt.Errorf("context root should be absolute at this point, got %q", loaderCtx.Root))
 	}
 
-	expanded, err := expandPatterns(conf.GnoRoot, loaderCtx, conf.Out, patterns...)
+	expanded, err := expandPatterns(conf.GnoRoot, loaderCtx, conf.NoVendor, conf.Out, patterns...)
 	if err != nil {
 		return nil, err
 	}
@@ -83,6 +84,11 @@ func Load(conf LoadConfig, patterns ...string) (PkgList, error) {
 
 	localDeps := discoverPkgsForLocalDeps(conf, loaderCtx)
 
+	vendor := ""
+	if !conf.NoVendor {
+		vendor = vendorDir(loaderCtx.Root)
+	}
+
 	// mark all pattern packages for visit
 	toVisit := []*Package(pkgs)
 
@@ -144,6 +150,20 @@ func Load(conf LoadConfig, patterns ...string) (PkgList, error) {
 				continue
 			}
 
+			// load package from the vendor directory, never downloading it
+			if vendor != "" {
+				dir := vendorPackageDir(vendor, imp.PkgPath)
+				if !isDir(dir) {
+					pkg.Errors = append(pkg.Errors, &Error{
+						Pos: filepath.Join(filepath.FromSlash(pkg.Dir), conf.Fset.Position(imp.Spec.Pos()).String()),
+						Msg: fmt.Sprintf("package %s is not in the vendor directory, run gno mod vendor", imp.PkgPath),
+					})
+					continue
+				}
+				markDepForVisit(loadSinglePkg(conf.Out, nil, dir, conf.Fset))
+				continue
+			}
+
 			// attempt to download package
 			dir := PackageDir(imp.PkgPath)
 			markDepForVisit(loadSinglePkg(conf.Out, conf.Fetcher, dir, conf.Fset))
@@ -160,6 +180,17 @@ type loaderContext struct {
 	IsWorkspace bool
 }
 
+// FindRootDir returns the root directory of the workspace of the current
+// directory, or the current directory if it's a package outside of a
+// workspace.
+func FindRootDir() (string, error) {
+	loaderCtx, err := findLoaderContext()
+	if err != nil {
+		return "", err
+	}
+	return loaderCtx.Root, nil
+}
+
 func findLoaderContext() (*loaderContext, error) {
 	wd, err := os.Getwd()
 	if err != nil {
@@ -264,6 +295,10 @@ func discoverPkgsForLocalDeps(conf LoadConfig, loaderCtx *loaderContext) map[str
 				if dir == root {
 					return nil
 				}
+				if dir == filepath.Join(root, VendorDirName) {
+					// vendored packages are only used when not found elsewhere
+					return fs.SkipDir
+				}
 				subwork := filepath.Join(dir, "gnowork.toml")
 				_, err := os.Stat(subwork)
 				switch {
@@ -20,7 +20,7 @@ type pkgMatch struct {
 	Match []string
 }
 
-func expandPatterns(gnoRoot string, loaderCtx *loaderContext, out io.Writer, patterns ...string) ([]*pkgMatch, error) {
+func expandPatterns(gnoRoot string, loaderCtx *loaderContext, noVendor bool, out io.Writer, patterns ...string) ([]*pkgMatch, error) {
 	pkgMatches := []*pkgMatch(nil)
 
 	addPkgDir := func(dir string, match *string) {
@@ -120,6 +120,12 @@ func expandPatterns(gnoRoot string, loaderCtx *loaderContext, out io.Writer, pat
 				dir = StdlibDir(gnoRoot, pat)
 			} else {
 				dir = PackageDir(pat)
+				if vendor := vendorDir(loaderCtx.Root); vendor != "" && !noVendor {
+					// use the vendored package, if any
+					if vdir := vendorPackageDir(vendor, pat); isDir(vdir) {
+						dir = vdir
+					}
+				}
 			}
 			addPkgDir(dir, &match)
 
@@ -173,6 +179,9 @@ func expandRecursive(workspaceRoot string, pattern string) ([]string, error) {
 			if dir == workspaceRoot {
 				return nil
 			}
+			if dir == filepath.Join(workspaceRoot, VendorDirName) {
+				return fs.SkipDir
+			}
 			subwork := filepath.Join(dir, "gnowork.toml")
 			_, err := os.Stat(subwork)
 			switch {
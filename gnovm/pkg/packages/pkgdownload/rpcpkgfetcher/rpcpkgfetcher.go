@@ -57,6 +57,34 @@ func (gpf *gnoPackageFetcher) FetchPackage(pkgPath string) ([]*std.MemFile, erro
 	return res, nil
 }
 
+// FetchDependents returns the paths of the packages importing the package at
+// pkgPath on its chain, as indexed by the vm/qdependents query.
+func FetchDependents(pkgPath string, remoteOverrides map[string]string) ([]string, error) {
+	rpcURL, err := rpcURLFromPkgPath(pkgPath, remoteOverrides)
+	if err != nil {
+		return nil, fmt.Errorf("get rpc url for pkg path %q: %w", pkgPath, err)
+	}
+
+	client, err := client.NewHTTPClient(rpcURL)
+	if err != nil {
+		return nil, fmt.Errorf("failed to instantiate tm2 client with remote %q: %w", rpcURL, err)
+	}
+	defer client.Close()
+
+	qres, err := client.ABCIQuery(context.Background(), "vm/qdependents", []byte(pkgPath))
+	if err != nil {
+		return nil, fmt.Errorf("query qdependents: %w", err)
+	}
+	if qres.Response.Error != nil {
+		return nil, fmt.Errorf("qdependents failed: %w\n%s", qres.Response.Error, qres.Response.Log)
+	}
+
+	if len(qres.Response.Data) == 0 {
+		return nil, nil
+	}
+	return strings.Split(string(qres.Response.Data), "\n"), nil
+}
+
 func rpcURLFromPkgPath(pkgPath string, remoteOverrides map[string]string) (string, error) {
 	parts := strings.Split(pkgPath, "/")
 	if len(parts) < 2 {
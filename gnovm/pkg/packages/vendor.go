@@ -0,0 +1,157 @@
+package packages
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"go/token"
+	"io"
+	"os"
+	"path/filepath"
+	"slices"
+	"strings"
+
+	"github.com/gnolang/gno/gnovm/pkg/gnomod"
+)
+
+// VendorDirName is the name of the directory of a workspace, or of a package
+// outside of a workspace, where `gno mod vendor` copies the remote packages it
+// depends on. When it exists, they are loaded from it, and never downloaded.
+const VendorDirName = "vendor"
+
+// vendorListName is the file of the vendor directory listing its packages.
+const vendorListName = "modules.txt"
+
+const vendorListHeader = "# Packages copied by gno mod vendor, one per line; DO NOT EDIT.\n"
+
+// vendorDir returns the vendor directory of the workspace or package at root,
+// or "" if it doesn't have one.
+func vendorDir(root string) string {
+	dir := filepath.Join(root, VendorDirName)
+	if _, err := os.Stat(filepath.Join(dir, vendorListName)); err != nil {
+		return ""
+	}
+	return dir
+}
+
+// FindVendorDir returns the vendor directory of the workspace, or package,
+// of the current directory, or "" if it doesn't have one.
+func FindVendorDir() (string, error) {
+	loaderCtx, err := findLoaderContext()
+	switch {
+	case errors.Is(err, ErrGnoContextNotFound):
+		return "", nil
+	case err != nil:
+		return "", err
+	}
+	return vendorDir(loaderCtx.Root), nil
+}
+
+// ReadVendorList returns the paths of the packages of the vendor directory dir.
+func ReadVendorList(dir string) ([]string, error) {
+	f, err := os.Open(filepath.Join(dir, vendorListName))
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	var pkgPaths []string
+	scanner := bufio.NewScanner(f)
+	for scanner.Scan() {
+		line := strings.TrimSpace(scanner.Text())
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		pkgPaths = append(pkgPaths, line)
+	}
+	return pkgPaths, scanner.Err()
+}
+
+// LoadVendor loads the packages of the vendor directory dir, without their
+// dependencies, which are also in it.
+func LoadVendor(dir string) (PkgList, error) {
+	pkgPaths, err := ReadVendorList(dir)
+	if err != nil {
+		return nil, err
+	}
+
+	fset := token.NewFileSet()
+	pkgs := make(PkgList, 0, len(pkgPaths))
+	for _, pkgPath := range pkgPaths {
+		pkg := loadSinglePkg(io.Discard, nil, vendorPackageDir(dir, pkgPath), fset)
+		if len(pkg.Errors) != 0 {
+			return nil, fmt.Errorf("vendored package %s: %w", pkgPath, pkg.Errors[0])
+		}
+		pkgs = append(pkgs, pkg)
+	}
+	return pkgs, nil
+}
+
+// WriteVendor replaces the vendor directory of the workspace or package at
+// root with a copy of the given packages, which must have been downloaded to
+// the modcache.
+func WriteVendor(root string, pkgs PkgList) error {
+	dir := filepath.Join(root, VendorDirName)
+	if err := os.RemoveAll(dir); err != nil {
+		return fmt.Errorf("remove vendor dir: %w", err)
+	}
+
+	pkgPaths := make([]string, 0, len(pkgs))
+	for _, pkg := range pkgs {
+		if err := copyPackageFiles(pkg.Dir, vendorPackageDir(dir, pkg.ImportPath)); err != nil {
+			return fmt.Errorf("vendor %s: %w", pkg.ImportPath, err)
+		}
+		pkgPaths = append(pkgPaths, pkg.ImportPath)
+	}
+	slices.Sort(pkgPaths)
+
+	var sb strings.Builder
+	sb.WriteString(vendorListHeader)
+	for _, pkgPath := range pkgPaths {
+		sb.WriteString(pkgPath)
+		sb.WriteByte('\n')
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return err
+	}
+	return os.WriteFile(filepath.Join(dir, vendorListName), []byte(sb.String()), 0o644)
+}
+
+// IsRemotePackage returns true if the package was downloaded to the modcache.
+func IsRemotePackage(pkg *Package) bool {
+	return strings.HasPrefix(filepath.Clean(pkg.Dir), gnomod.ModCachePath()+string(filepath.Separator))
+}
+
+func vendorPackageDir(dir, pkgPath string) string {
+	return filepath.Join(dir, filepath.FromSlash(pkgPath))
+}
+
+// copyPackageFiles copies the files of the package in src to dst; the
+// sub-directories are other packages.
+func copyPackageFiles(src, dst string) error {
+	entries, err := os.ReadDir(src)
+	if err != nil {
+		return err
+	}
+	if err := os.MkdirAll(dst, 0o755); err != nil {
+		return err
+	}
+	for _, entry := range entries {
+		if !entry.Type().IsRegular() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(src, entry.Name()))
+		if err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(dst, entry.Name()), data, 0o644); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func isDir(path string) bool {
+	info, err := os.Stat(path)
+	return err == nil && info.IsDir()
+}
@@ -5,10 +5,13 @@ import (
 	"fmt"
 	"io"
 	"os"
+	"strings"
 
 	"github.com/gnolang/gno/gnovm/pkg/gnoenv"
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/packages/pkgdownload"
 	"github.com/gnolang/gno/gnovm/pkg/test"
+	"github.com/gnolang/gno/tm2/pkg/std"
 )
 
 type ReplOption func(*Repl)
@@ -29,6 +32,17 @@ func WithIO(input io.Reader, output, errput io.Writer) ReplOption {
 	}
 }
 
+// WithPackageFetcher allows importing packages which are not available
+// locally, by fetching their sources with the given fetcher (typically from
+// a chain, over RPC). Fetched packages are run from their sources in the
+// REPL's store; realms are thus initialized afresh, without their on-chain
+// state.
+func WithPackageFetcher(f pkgdownload.PackageFetcher) ReplOption {
+	return func(r *Repl) {
+		r.fetcher = f
+	}
+}
+
 type Repl struct {
 	m *gno.Machine
 
@@ -38,7 +52,8 @@ type Repl struct {
 	fn *gno.FileNode // contains .bs and func/type/import decls
 	fb *gno.Block    // file block for .fn
 
-	rec any // last exception recovered
+	rec     any      // last exception recovered
+	history []string // successfully evaluated inputs
 
 	// rw joins stdout and stderr to give an unified output and group with stdin.
 	rw *bufio.ReadWriter
@@ -49,7 +64,12 @@ type Repl struct {
 	errput  io.Writer // repl printing of errors
 	input   io.Reader
 	store   gno.Store
+	fetcher pkgdownload.PackageFetcher
 	debug   bool
+
+	// baseStore is the store given with WithStore, if any;
+	// otherwise a new store is created on each Reset.
+	baseStore gno.Store
 }
 
 // NewRepl creates a Repl struct. It is able to process input source code and eventually run it.
@@ -61,7 +81,26 @@ func NewRepl(opts ...ReplOption) *Repl {
 	r.input = os.Stdin
 	r.output = os.Stdout
 	r.errput = os.Stderr
-	_, r.store = test.TestStore(gnoenv.RootDir(), test.OutputWithError(r.output, r.errput), nil)
+	for _, opt := range opts {
+		opt(r)
+	}
+
+	r.baseStore = r.store
+	if r.baseStore != nil {
+		r.wrapPackageGetter(r.baseStore)
+	}
+	r.init()
+
+	return r
+}
+
+// init sets up a new store (unless one was provided), package and machine.
+func (r *Repl) init() {
+	r.store = r.baseStore
+	if r.store == nil {
+		_, r.store = test.TestStore(gnoenv.RootDir(), test.OutputWithError(r.output, r.errput), nil)
+		r.wrapPackageGetter(r.store)
+	}
 
 	var nilAllocator = (*gno.Allocator)(nil)
 	r.pn = gno.NewPackageNode("repl", r.pkgPath, &gno.FileSet{})
@@ -72,9 +111,6 @@ func NewRepl(opts ...ReplOption) *Repl {
 		Decls:    nil,
 	}
 	r.fb = gno.NewBlock(nilAllocator, r.fn, r.pv.GetBlock(r.store))
-	for _, opt := range opts {
-		opt(r)
-	}
 
 	// register package node and value.
 	r.store.SetBlockNode(r.pn)
@@ -98,8 +134,68 @@ func NewRepl(opts ...ReplOption) *Repl {
 
 	// set blocks.
 	// r.m.PushBlock(r.fb)
+}
 
-	return r
+// wrapPackageGetter makes store fall back to r.fetcher for packages that
+// its package getter cannot find.
+func (r *Repl) wrapPackageGetter(store gno.Store) {
+	if r.fetcher == nil {
+		return
+	}
+	getter := store.GetPackageGetter()
+	store.SetPackageGetter(func(pkgPath string, store gno.Store) (*gno.PackageNode, *gno.PackageValue) {
+		if getter != nil {
+			if pn, pv := getter(pkgPath, store); pn != nil {
+				return pn, pv
+			}
+		}
+		if !gno.IsUserlib(pkgPath) {
+			return nil, nil
+		}
+		mpkg, err := r.fetchPackage(pkgPath)
+		if err != nil {
+			panic(err)
+		}
+		m := gno.NewMachineWithOptions(gno.MachineOptions{
+			PkgPath:       pkgPath,
+			Output:        r.output,
+			Store:         store,
+			Context:       test.Context("", pkgPath, std.Coins{}),
+			ReviveEnabled: true,
+			SkipPackage:   true,
+		})
+		return m.RunMemPackage(mpkg, true)
+	})
+}
+
+// fetchPackage retrieves the production files of pkgPath using r.fetcher.
+func (r *Repl) fetchPackage(pkgPath string) (*std.MemPackage, error) {
+	files, err := r.fetcher.FetchPackage(pkgPath)
+	if err != nil {
+		return nil, fmt.Errorf("unable to fetch package %q: %w", pkgPath, err)
+	}
+	mpkg := &std.MemPackage{
+		Type: gno.MPUserProd,
+		Path: pkgPath,
+	}
+	for _, file := range files {
+		if !strings.HasSuffix(file.Name, ".gno") || gno.IsTestFile(file.Name) {
+			continue
+		}
+		if mpkg.Name == "" {
+			name, err := gno.PackageNameFromFileBody(file.Name, file.Body)
+			if err != nil {
+				return nil, err
+			}
+			mpkg.Name = string(name)
+		}
+		mpkg.Files = append(mpkg.Files, file)
+	}
+	if len(mpkg.Files) == 0 {
+		return nil, fmt.Errorf("package %q has no gno files", pkgPath)
+	}
+	mpkg.Sort()
+	return mpkg, nil
 }
 
 func (r *Repl) Print(args ...any) {
@@ -130,32 +226,45 @@ func (r *Repl) Errorln(args ...any) {
 	fmt.Fprintln(r.errput, args...)
 }
 
-func (r *Repl) RunStatements(code string) {
-	if os.Getenv("DEBUG_PANIC") != "1" {
-		defer func() {
-			if rec := recover(); rec != nil {
-				r.rec = rec
-				switch rec := rec.(type) {
-				case *gno.PreprocessError:
-					err := rec.Unwrap()
-					match := gno.ReErrorLine.Match(err.Error())
-					if match == nil {
-						r.Errorln(err.Error())
-					} else {
-						r.Errorln(match.Get("MSG"))
-					}
-				case error:
-					err := rec
-					match := gno.ReErrorLine.Match(err.Error())
-					if match == nil {
-						r.Errorln(err.Error())
-					} else {
-						r.Errorln(match.Get("MSG"))
-					}
-				}
-			}
-		}()
+// catchPanic reports errors raised while evaluating code, unless
+// DEBUG_PANIC is set. It must be deferred directly.
+func (r *Repl) catchPanic() {
+	if os.Getenv("DEBUG_PANIC") == "1" {
+		return
+	}
+	rec := recover()
+	if rec == nil {
+		return
 	}
+	r.rec = rec
+	r.rw.Flush()
+	switch rec := rec.(type) {
+	case *gno.PreprocessError:
+		err := rec.Unwrap()
+		match := gno.ReErrorLine.Match(err.Error())
+		if match == nil {
+			r.Errorln(err.Error())
+		} else {
+			r.Errorln(match.Get("MSG"))
+		}
+	case error:
+		err := rec
+		match := gno.ReErrorLine.Match(err.Error())
+		if match == nil {
+			r.Errorln(err.Error())
+		} else {
+			r.Errorln(match.Get("MSG"))
+		}
+	default:
+		r.Errorln(fmt.Sprint(rec))
+	}
+}
+
+// RunStatements evaluates code, which may be a list of declarations or of
+// statements. Expressions which are not function calls have their values
+// printed.
+func (r *Repl) RunStatements(code string) {
+	defer r.catchPanic()
 
 	if r.debug {
 		// Activate debugger for this statement only.
@@ -173,6 +282,13 @@ func (r *Repl) RunStatements(code string) {
 		}
 		// e.g. var a = 1; or b := 2
 		for _, stmt := range stmts {
+			if es, ok := stmt.(*gno.ExprStmt); ok {
+				if _, isCall := es.X.(*gno.CallExpr); !isCall {
+					// e.g. a or a+1
+					r.printValues(r.eval(es.X))
+					continue
+				}
+			}
 			r.m.RunStatement(gno.StageRun, stmt)
 			r.rw.Flush()
 		}
@@ -183,11 +299,74 @@ func (r *Repl) RunStatements(code string) {
 			r.rw.Flush()
 		}
 	}
+	r.history = append(r.history, code)
+}
+
+// eval evaluates x in the REPL's package block. Unlike [gno.Machine.Eval],
+// x is not isolated in its own function, so that errors are reported with
+// the REPL's location.
+func (r *Repl) eval(x gno.Expr) []gno.TypedValue {
+	last := r.m.LastBlock().GetSource(r.store)
+	x = gno.Preprocess(r.store, last, x).(gno.Expr)
+	start := len(r.m.Values)
+	r.m.PushOp(gno.OpHalt)
+	r.m.PushExpr(x)
+	r.m.PushOp(gno.OpEval)
+	r.m.Run(gno.StageRun)
+	res := r.m.ReapValues(start)
+	for i := range res {
+		switch res[i].T {
+		case gno.UntypedBoolType, gno.UntypedRuneType, gno.UntypedBigintType,
+			gno.UntypedBigdecType, gno.UntypedStringType:
+			// e.g. 1 is an int, like in var a = 1.
+			gno.ConvertUntypedTo(&res[i], nil)
+		}
+	}
+	return res
+}
+
+func (r *Repl) printValues(tvs []gno.TypedValue) {
+	for i := range tvs {
+		r.Println(tvs[i].Sprint(r.m))
+	}
+}
+
+// PrintType evaluates the expression code and prints the type of its
+// value(s).
+func (r *Repl) PrintType(code string) {
+	defer r.catchPanic()
+
+	x, err := gno.ParseExpr(code)
+	if err != nil {
+		r.Errorln(err.Error())
+		return
+	}
+	for _, tv := range r.eval(x) {
+		if tv.T == nil {
+			r.Println("nil")
+		} else {
+			r.Println(tv.T.String())
+		}
+	}
+}
+
+// History returns the inputs which were successfully evaluated since the
+// last Reset.
+func (r *Repl) History() []string {
+	return r.history
+}
+
+// ClearHistory forgets the inputs evaluated so far, without resetting the
+// REPL's state.
+func (r *Repl) ClearHistory() {
+	r.history = nil
 }
 
 // Reset will reset the actual repl state, restarting the internal VM.
 func (r *Repl) Reset() {
-	panic("not yet implemented")
+	r.history = nil
+	r.rec = nil
+	r.init()
 }
 
 // Debug activates the GnoVM debugger for the next evaluation.
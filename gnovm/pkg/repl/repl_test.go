@@ -2,10 +2,12 @@ package repl
 
 import (
 	"bytes"
+	"fmt"
 	"os"
 	"strings"
 	"testing"
 
+	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/stretchr/testify/require"
 )
 
@@ -117,6 +119,27 @@ var fixtures = []struct {
 			},
 		},
 	},
+	{
+		Name: "Print expressions",
+		CodeSteps: []step{
+			{
+				Line:   "a := 40",
+				Result: "",
+			},
+			{
+				Line:   "a + 2",
+				Result: "42",
+			},
+			{
+				Line:   "type S struct{ A int; B string }",
+				Result: "",
+			},
+			{
+				Line:   "s := S{1, \"x\"}; s; s.B",
+				Result: "(struct{(1 int),(\"x\" string)} repl.S)\nx",
+			},
+		},
+	},
 	{
 		Name: "Add wrong code",
 		CodeSteps: []step{
@@ -253,6 +276,88 @@ func TestRepl(t *testing.T) {
 	}
 }
 
+func TestReplPrintType(t *testing.T) {
+	outbuf := new(bytes.Buffer)
+	errbuf := new(bytes.Buffer)
+	r := NewRepl(WithIO(os.Stdin, outbuf, errbuf))
+	r.RunStatements("type T struct{}; var x any = T{}; y := []string{\"a\"}")
+	require.Empty(t, errbuf.String())
+
+	for _, tc := range []struct{ expr, typ string }{
+		{"1", "int"},
+		{"\"a\" + \"b\"", "string"},
+		{"x", "repl.T"},
+		{"y", "[]string"},
+		{"&y", "*[]string"},
+		{"nil", "nil"},
+	} {
+		outbuf.Reset()
+		r.PrintType(tc.expr)
+		require.Empty(t, errbuf.String(), tc.expr)
+		require.Equal(t, tc.typ+"\n", outbuf.String(), tc.expr)
+	}
+
+	r.PrintType("z")
+	require.Contains(t, errbuf.String(), "name z not declared")
+}
+
+func TestReplReset(t *testing.T) {
+	outbuf := new(bytes.Buffer)
+	errbuf := new(bytes.Buffer)
+	r := NewRepl(WithIO(os.Stdin, outbuf, errbuf))
+	r.RunStatements("type T struct{ n int }")
+	r.RunStatements("a := T{1}")
+	r.RunStatements("b") // fails, not in history.
+	require.Equal(t, []string{"type T struct{ n int }", "a := T{1}"}, r.History())
+
+	r.Reset()
+	errbuf.Reset()
+	require.Empty(t, r.History())
+	r.RunStatements("println(a)")
+	require.Contains(t, errbuf.String(), "name a not declared")
+
+	errbuf.Reset()
+	r.RunStatements("type T struct{ s string }")
+	r.RunStatements("println(T{\"ok\"}.s)")
+	require.Empty(t, errbuf.String())
+	require.Equal(t, "ok\n", outbuf.String())
+}
+
+type memFetcher map[string][]*std.MemFile
+
+func (f memFetcher) FetchPackage(pkgPath string) ([]*std.MemFile, error) {
+	files, ok := f[pkgPath]
+	if !ok {
+		return nil, fmt.Errorf("package %q is not available", pkgPath)
+	}
+	return files, nil
+}
+
+func TestReplPackageFetcher(t *testing.T) {
+	fetcher := memFetcher{
+		"gno.land/p/demo/greet": {
+			{Name: "gnomod.toml", Body: `module = "gno.land/p/demo/greet"`},
+			{Name: "greet.gno", Body: "package greet\n\nimport \"gno.land/p/demo/suffix\"\n\nfunc Hello(name string) string { return \"hello \" + name + suffix.Suffix }\n"},
+			{Name: "greet_test.gno", Body: "package greet\n\nimport \"testing\"\n\nfunc TestHello(t *testing.T) {}\n"},
+		},
+		"gno.land/p/demo/suffix": {
+			{Name: "suffix.gno", Body: "package suffix\n\nconst Suffix = \"!\"\n"},
+		},
+	}
+
+	outbuf := new(bytes.Buffer)
+	errbuf := new(bytes.Buffer)
+	r := NewRepl(WithIO(os.Stdin, outbuf, errbuf), WithPackageFetcher(fetcher))
+	r.RunStatements(`import "gno.land/p/demo/greet"`)
+	require.Empty(t, errbuf.String())
+	r.RunStatements(`s := greet.Hello("gno"); s`)
+	require.Empty(t, errbuf.String())
+	require.Equal(t, "hello gno!\n", outbuf.String())
+
+	r.RunStatements(`import "gno.land/p/demo/missing"`)
+	require.Contains(t, errbuf.String(), `package "gno.land/p/demo/missing" is not available`)
+}
+
 func stripTrailingNL(s string) string {
 	if strings.HasSuffix(s, "\n") {
 		return s[:len(s)-1]
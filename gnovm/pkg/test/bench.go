@@ -0,0 +1,232 @@
+package test
+
+import (
+	"encoding/json"
+	"fmt"
+	"io"
+	"math"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	storetypes "github.com/gnolang/gno/tm2/pkg/store/types"
+	"go.uber.org/multierr"
+)
+
+// DurationOrCount is how long, or how many times, each benchmark or fuzz test
+// is run. It is a [flag.Value], set from either a duration like "1s", or a
+// number of iterations like "100x", as with 'go test -benchtime' and
+// 'go test -fuzztime'.
+type DurationOrCount struct {
+	D time.Duration
+	N int // if not zero, the number of iterations, and D is ignored.
+}
+
+// DefaultBenchTime is the default benchtime, running each benchmark for at
+// least a second.
+var DefaultBenchTime = DurationOrCount{D: time.Second}
+
+func (bt DurationOrCount) String() string {
+	if bt.N > 0 {
+		return fmt.Sprintf("%dx", bt.N)
+	}
+	return bt.D.String()
+}
+
+func (bt *DurationOrCount) Set(s string) error {
+	if strings.HasSuffix(s, "x") {
+		n, err := strconv.ParseInt(s[:len(s)-1], 10, 0)
+		if err != nil || n <= 0 {
+			return fmt.Errorf("invalid count %q", s)
+		}
+		*bt = DurationOrCount{N: int(n)}
+		return nil
+	}
+	d, err := time.ParseDuration(s)
+	if err != nil || d <= 0 {
+		return fmt.Errorf("invalid duration %q", s)
+	}
+	*bt = DurationOrCount{D: d}
+	return nil
+}
+
+// benchReport is a mirror of the JSON returned by Gno's
+// stdlibs/testing.RunBenchmark.
+type benchReport struct {
+	Failed  bool
+	Results []benchResult
+}
+
+type benchResult struct {
+	Name       string
+	Failed     bool
+	Skipped    bool
+	HasSub     bool // not measured, as it has sub-benchmarks.
+	Output     string
+	N          int
+	T          int64 // total time, in nanoseconds.
+	Bytes      int64 // processed by an iteration, set by B.SetBytes.
+	MemBytes   int64
+	MemAllocs  int64
+	Gas        int64
+	ShowAllocs bool
+	Extra      map[string]float64
+}
+
+// runBenchmarks runs the benchmarks of the package pv, which were loaded
+// from files, matching opts.BenchFlag.
+func (opts *TestOptions) runBenchmarks(
+	mpkg *std.MemPackage,
+	files *gno.FileSet,
+	tgs gno.TransactionStore,
+	pv *gno.PackageValue,
+) (errs error) {
+	for _, bf := range loadBenchmarkFuncs(mpkg.Name, files) {
+		// Each benchmark has its own allocator and gas meter, so that
+		// it measures what it allocates and consumes.
+		m := gno.NewMachineWithOptions(gno.MachineOptions{
+			Store:         tgs,
+			Output:        opts.WriterForStore(),
+			Context:       Context("", mpkg.Path, nil),
+			Alloc:         gno.NewAllocator(math.MaxInt64),
+			GasMeter:      storetypes.NewInfiniteGasMeter(),
+			ReviveEnabled: true,
+		})
+		m.SetActivePackage(pv)
+
+		if m.Eval(gno.Nx(bf.Name))[0].GetFunc().IsCrossing() {
+			fmt.Fprintf(opts.Error, "--- FAIL: %s\ncrossing benchmarks are not supported\n", bf.Name)
+			errs = multierr.Append(errs, fmt.Errorf("failed: %q", bf.Name))
+			continue
+		}
+
+		testingpv := m.Store.GetPackage("testing", false)
+		testingtv := gno.TypedValue{T: &gno.PackageType{}, V: testingpv}
+		testingcx := &gno.ConstExpr{TypedValue: testingtv}
+
+		eval := m.Eval(gno.Call(
+			gno.Sel(testingcx, "RunBenchmark"),                      // Call testing.RunBenchmark
+			gno.Str(opts.BenchFlag),                                 // bench flag
+			gno.Num(strconv.FormatInt(int64(opts.Benchtime.D), 10)), // benchtime, as a duration
+			gno.Num(strconv.Itoa(opts.Benchtime.N)),                 // benchtime, as a count
+			&gno.CompositeLitExpr{ // testing.InternalBenchmark
+				Type: gno.Sel(testingcx, "InternalBenchmark"),
+				Elts: gno.KeyValueExprs{
+					{Key: gno.X("Name"), Value: gno.Str(bf.Name)},
+					{Key: gno.X("F"), Value: gno.Nx(bf.Name)},
+				},
+			},
+		))
+
+		var rep benchReport
+		if err := json.Unmarshal([]byte(eval[0].GetString()), &rep); err != nil {
+			errs = multierr.Append(errs, err)
+			fmt.Fprintf(opts.Error, "--- FAIL: %s [internal gno testing error]\n", bf.Name)
+			continue
+		}
+		for _, r := range rep.Results {
+			opts.printBenchResult(r)
+		}
+		if rep.Failed {
+			errs = multierr.Append(errs, fmt.Errorf("failed: %q", bf.Name))
+			if opts.FailfastFlag {
+				return errs
+			}
+		}
+	}
+	return errs
+}
+
+// printBenchResult prints r to opts.Error, in the format of 'go test -bench',
+// with the gas consumed by an iteration.
+func (opts *TestOptions) printBenchResult(r benchResult) {
+	switch {
+	case r.Failed:
+		fmt.Fprintf(opts.Error, "--- FAIL: %s\n%s", r.Name, r.Output)
+		return
+	case r.Skipped:
+		if opts.Verbose {
+			fmt.Fprintf(opts.Error, "--- SKIP: %s\n%s", r.Name, r.Output)
+		}
+		return
+	case r.HasSub || r.N <= 0:
+		// Not measured.
+	default:
+		var sb strings.Builder
+		fmt.Fprintf(&sb, "%s\t%8d\t", r.Name, r.N)
+		prettyPrint(&sb, float64(r.T)/float64(r.N), "ns/op")
+		sb.WriteByte('\t')
+		prettyPrint(&sb, float64(r.Gas)/float64(r.N), "gas/op")
+		if r.Bytes > 0 && r.T > 0 {
+			mbs := float64(r.Bytes) * float64(r.N) / 1e6 / (float64(r.T) / 1e9)
+			fmt.Fprintf(&sb, "\t%7.2f MB/s", mbs)
+		}
+		units := make([]string, 0, len(r.Extra))
+		for unit := range r.Extra {
+			units = append(units, unit)
+		}
+		sort.Strings(units)
+		for _, unit := range units {
+			sb.WriteByte('\t')
+			prettyPrint(&sb, r.Extra[unit], unit)
+		}
+		if opts.Benchmem || r.ShowAllocs {
+			fmt.Fprintf(&sb, "\t%8d B/op\t%8d allocs/op",
+				r.MemBytes/int64(r.N), r.MemAllocs/int64(r.N))
+		}
+		fmt.Fprintln(opts.Error, sb.String())
+	}
+	if r.Output != "" {
+		fmt.Fprintf(opts.Error, "--- BENCH: %s\n%s", r.Name, r.Output)
+	}
+}
+
+// prettyPrint writes x and its unit to w, like Go's benchmarks do: with ten
+// places before the decimal point, and four significant figures for small
+// numbers.
+func prettyPrint(w io.Writer, x float64, unit string) {
+	var format string
+	switch y := math.Abs(x); {
+	case y == 0 || y >= 999.95:
+		format = "%10.0f %s"
+	case y >= 99.995:
+		format = "%12.1f %s"
+	case y >= 9.9995:
+		format = "%13.2f %s"
+	case y >= 0.99995:
+		format = "%14.3f %s"
+	case y >= 0.099995:
+		format = "%15.4f %s"
+	case y >= 0.0099995:
+		format = "%16.5f %s"
+	case y >= 0.00099995:
+		format = "%17.6f %s"
+	default:
+		format = "%18.7f %s"
+	}
+	fmt.Fprintf(w, format, x, unit)
+}
+
+func loadBenchmarkFuncs(pkgName string, tfiles *gno.FileSet) (rt []testFunc) {
+	for _, tf := range tfiles.Files {
+		for _, d := range tf.Decls {
+			if fd, ok := d.(*gno.FuncDecl); ok {
+				if fd.IsMethod {
+					continue
+				}
+				fname := string(fd.Name)
+				if strings.HasPrefix(fname, "Benchmark") {
+					rt = append(rt, testFunc{
+						Package:  pkgName,
+						Name:     fname,
+						Filename: tf.FileName,
+					})
+				}
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,138 @@
+package test
+
+import (
+	"bufio"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"io"
+	"path/filepath"
+	"strings"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// CoverProfileHeader is the first line of a cover profile, followed by the
+// blocks written by [WriteCoverProfile].
+const CoverProfileHeader = "mode: set\n"
+
+// CoverBlock is a statement of a production file of a package, and the number
+// of times its tests ran it. The block of a statement with a body, like an if
+// or a for statement, ends at the opening brace of its body, and includes its
+// init and post statements.
+type CoverBlock struct {
+	File      string // name of the file
+	StartLine int
+	StartCol  int
+	EndLine   int
+	EndCol    int
+	Count     int
+}
+
+// CoverBlocks returns the statements of the production files of mpkg, in the
+// order of the files and of their source, with the number of times cov
+// recorded them being run.
+func CoverBlocks(mpkg *std.MemPackage, cov *gno.Coverage) ([]CoverBlock, error) {
+	var blocks []CoverBlock
+	for _, mfile := range mpkg.Files {
+		if !strings.HasSuffix(mfile.Name, ".gno") ||
+			gno.MPFProd.FilterGno(mfile, gno.Name(mpkg.Name)) {
+			continue
+		}
+		fblocks, err := fileCoverBlocks(mfile)
+		if err != nil {
+			return nil, err
+		}
+		counts := cov.Counts(mpkg.Path, mfile.Name)
+		for i, b := range fblocks {
+			fblocks[i].Count = counts[gno.Pos{Line: b.StartLine, Column: b.StartCol}]
+		}
+		blocks = append(blocks, fblocks...)
+	}
+	return blocks, nil
+}
+
+func fileCoverBlocks(mfile *std.MemFile) ([]CoverBlock, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, mfile.Name, mfile.Body, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	var blocks []CoverBlock
+	header := make(map[ast.Stmt]bool) // in the block of their statement.
+	ast.Inspect(f, func(n ast.Node) bool {
+		stmt, ok := n.(ast.Stmt)
+		if !ok || header[stmt] {
+			return true
+		}
+		end := stmt.End()
+		switch stmt := stmt.(type) {
+		case *ast.BlockStmt, *ast.CaseClause, *ast.CommClause,
+			*ast.LabeledStmt, *ast.EmptyStmt:
+			// Not run by themselves: their statements are.
+			return true
+		case *ast.IfStmt:
+			end = stmt.Body.Lbrace + 1
+			header[stmt.Init] = true
+		case *ast.ForStmt:
+			end = stmt.Body.Lbrace + 1
+			header[stmt.Init] = true
+			header[stmt.Post] = true
+		case *ast.RangeStmt:
+			end = stmt.Body.Lbrace + 1
+		case *ast.SwitchStmt:
+			end = stmt.Body.Lbrace + 1
+			header[stmt.Init] = true
+		case *ast.TypeSwitchStmt:
+			end = stmt.Body.Lbrace + 1
+			header[stmt.Init] = true
+			header[stmt.Assign] = true
+		case *ast.SelectStmt:
+			end = stmt.Body.Lbrace + 1
+		}
+		start, endp := fset.Position(stmt.Pos()), fset.Position(end)
+		blocks = append(blocks, CoverBlock{
+			File:      mfile.Name,
+			StartLine: start.Line,
+			StartCol:  start.Column,
+			EndLine:   endp.Line,
+			EndCol:    endp.Column,
+		})
+		return true
+	})
+	return blocks, nil
+}
+
+// CoveredPercent returns the percentage of blocks which were run, or -1 if
+// there are none.
+func CoveredPercent(blocks []CoverBlock) float64 {
+	if len(blocks) == 0 {
+		return -1
+	}
+	covered := 0
+	for _, b := range blocks {
+		if b.Count > 0 {
+			covered++
+		}
+	}
+	return 100 * float64(covered) / float64(len(blocks))
+}
+
+// WriteCoverProfile writes blocks to w in the format of the cover profiles of
+// Go, for the package in dir. The file names are joined to dir, so that tools
+// like 'go tool cover' can find them.
+func WriteCoverProfile(w io.Writer, dir string, blocks []CoverBlock) error {
+	bw := bufio.NewWriter(w)
+	for _, b := range blocks {
+		count := 0
+		if b.Count > 0 {
+			count = 1
+		}
+		fmt.Fprintf(bw, "%s:%d.%d,%d.%d 1 %d\n", filepath.Join(dir, b.File),
+			b.StartLine, b.StartCol, b.EndLine, b.EndCol, count)
+	}
+	return bw.Flush()
+}
@@ -61,6 +61,13 @@ func (opts *TestOptions) runFiletest(fname string, source []byte, tgs gno.Store,
 		return "", fmt.Errorf("could not parse MAXALLOC directive: %w", err)
 	}
 
+	var calls []string
+	if callsRaw := dirs.FirstDefault(DirectiveCalls, ""); callsRaw != "" {
+		for _, call := range strings.Split(callsRaw, ",") {
+			calls = append(calls, strings.TrimSpace(call))
+		}
+	}
+
 	var opslog io.Writer
 	if dirs.First(DirectiveRealm) != nil {
 		opslog = new(bytes.Buffer)
@@ -75,11 +82,12 @@ func (opts *TestOptions) runFiletest(fname string, source []byte, tgs gno.Store,
 		MaxAllocBytes: maxAlloc,
 		Debug:         opts.Debug,
 		ReviveEnabled: true,
+		Coverage:      opts.Coverage,
 	})
 	defer m.Release()
 
 	// RUN THE FILETEST /////////////////////////////////////
-	result := opts.runTest(m, pkgPath, fname, source, opslog, tcheck)
+	result := opts.runTest(m, pkgPath, fname, source, opslog, calls, tcheck)
 
 	// updated tells whether the directives have been updated, and as such
 	// a new generated filetest should be returned.
@@ -98,19 +106,16 @@ func (opts *TestOptions) runFiletest(fname string, source []byte, tgs gno.Store,
 				dir.Content = actual
 				updated = true
 			} else {
+				mismatch := &DirectiveMismatchError{
+					Directive: dir.Name,
+					Expected:  content,
+					Actual:    actual,
+				}
 				if dir.Name == DirectiveError {
-					returnErr = multierr.Append(
-						returnErr,
-						fmt.Errorf("%s diff:\n%s\nstacktrace:\n%s\nstack:\n%v",
-							dir.Name, unifiedDiff(content, actual),
-							result.GnoStacktrace, string(result.GoPanicStack)),
-					)
-				} else {
-					returnErr = multierr.Append(
-						returnErr,
-						fmt.Errorf("%s diff:\n%s", dir.Name, unifiedDiff(content, actual)),
-					)
+					mismatch.gnoStacktrace = result.GnoStacktrace
+					mismatch.goPanicStack = result.GoPanicStack
 				}
+				returnErr = multierr.Append(returnErr, mismatch)
 			}
 		}
 	}
@@ -167,7 +172,7 @@ func (opts *TestOptions) runFiletest(fname string, source []byte, tgs gno.Store,
 			res := opslog.(*bytes.Buffer).String()
 			match(dir, res)
 		case DirectiveEvents:
-			events := m.Context.(*teststdlibs.TestExecContext).EventLogger.Events()
+			events := m.Context.(*teststdlibs.TestExecContext).EventManager.Events()
 			evtjson, err := json.MarshalIndent(events, "", "  ")
 			if err != nil {
 				panic(err)
@@ -208,6 +213,27 @@ func (opts *TestOptions) runFiletest(fname string, source []byte, tgs gno.Store,
 	return "", returnErr
 }
 
+// A DirectiveMismatchError is returned by [TestOptions.RunFiletest] for each
+// directive whose golden content doesn't match the result of the filetest.
+type DirectiveMismatchError struct {
+	Directive string // name of the directive, like "Output".
+	Expected  string // golden content of the directive.
+	Actual    string // content generated by the filetest.
+
+	// Set for DirectiveError.
+	gnoStacktrace string
+	goPanicStack  []byte
+}
+
+func (e *DirectiveMismatchError) Error() string {
+	if e.Directive == DirectiveError {
+		return fmt.Sprintf("%s diff:\n%s\nstacktrace:\n%s\nstack:\n%v",
+			e.Directive, unifiedDiff(e.Expected, e.Actual),
+			e.gnoStacktrace, string(e.goPanicStack))
+	}
+	return fmt.Sprintf("%s diff:\n%s", e.Directive, unifiedDiff(e.Expected, e.Actual))
+}
+
 // returns a sorted string representation of realm diffs map
 func realmDiffsString(m map[string]int64) string {
 	keys := make([]string, 0, len(m))
@@ -259,7 +285,7 @@ type runResult struct {
 	GoPanicStack []byte
 }
 
-func (opts *TestOptions) runTest(m *gno.Machine, pkgPath, fname string, content []byte, opslog io.Writer, tcheck bool) (rr runResult) {
+func (opts *TestOptions) runTest(m *gno.Machine, pkgPath, fname string, content []byte, opslog io.Writer, calls []string, tcheck bool) (rr runResult) {
 	pkgName := gno.Name(pkgPath[strings.LastIndexByte(pkgPath, '/')+1:])
 	tcError := ""
 	fname = filepath.Base(fname)
@@ -320,6 +346,9 @@ func (opts *TestOptions) runTest(m *gno.Machine, pkgPath, fname string, content
 
 	// Use last element after / (works also if slash is missing).
 	if !gno.IsRealmPath(pkgPath) { // Simple case - pure package.
+		if len(calls) > 0 {
+			panic("CALLS directive is only available for realm filetests")
+		}
 		// Determine package type based on path
 		mptype := gno.MPUserProd
 		if strings.HasSuffix(pkgPath, "_test") {
@@ -405,6 +434,15 @@ func (opts *TestOptions) runTest(m *gno.Machine, pkgPath, fname string, content
 		// Clear store.opslog from init function(s).
 		m.Store.SetLogStoreOps(opslog) // resets.
 		m.RunMainMaybeCrossing()
+
+		// Run each call in its own transaction, with the objects of the
+		// realm reloaded from the store, like they are on-chain.
+		for _, call := range calls {
+			m.Store.ResetObjectCache()
+			m.Store.SetLogStoreOps(opslog)
+			m.SetActivePackage(m.Store.GetPackage(pkgPath, false))
+			m.RunFuncMaybeCrossing(gno.Name(call))
+		}
 	}
 	return runResult{
 		Output:         opts.filetestBuffer.String(),
@@ -422,6 +460,13 @@ const (
 	DirectivePkgPath  = "PKGPATH"
 	DirectiveMaxAlloc = "MAXALLOC"
 	DirectiveSend     = "SEND"
+	// DirectiveCalls lists the functions of a realm filetest which are called
+	// after main, each in a new transaction reloading the realm from the store.
+	DirectiveCalls = "CALLS"
+	// DirectiveIntegration marks a filetest as an integration test, when set
+	// to true: it is skipped by [Test], and only run against a node, as a
+	// transaction, by 'gno test -network'.
+	DirectiveIntegration = "INTEGRATION"
 
 	// These are used to match the result of the filetest against known golden
 	// values.
@@ -439,6 +484,8 @@ var allDirectives = []string{
 	DirectivePkgPath,
 	DirectiveMaxAlloc,
 	DirectiveSend,
+	DirectiveCalls,
+	DirectiveIntegration,
 	DirectiveOutput,
 	DirectiveError,
 	DirectiveRealm,
@@ -477,6 +524,20 @@ func (d Directives) FirstDefault(name, defaultValue string) string {
 	return v.Content
 }
 
+// Integration returns whether the filetest is marked as an integration test
+// by [DirectiveIntegration].
+func (d Directives) Integration() (bool, error) {
+	v := d.First(DirectiveIntegration)
+	if v == nil {
+		return false, nil
+	}
+	ok, err := strconv.ParseBool(strings.TrimSpace(v.Content))
+	if err != nil {
+		return false, fmt.Errorf("could not parse INTEGRATION directive: %w", err)
+	}
+	return ok, nil
+}
+
 // FileTest re-generates the filetest from the given directives; the inverse of ParseDirectives.
 func (d Directives) FileTest() string {
 	var bld strings.Builder
@@ -0,0 +1,286 @@
+package test
+
+import (
+	"crypto/sha256"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strconv"
+	"strings"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"go.uber.org/multierr"
+)
+
+// fuzzReport is a mirror of Gno's stdlibs/testing.fuzzReport.
+type fuzzReport struct {
+	Failed  bool
+	Skipped bool
+	Crasher string
+}
+
+// runFuzzTargets runs the fuzz tests of the package pv, which were loaded
+// from files: their seed corpus, the values added with F.Add and the files of
+// testdata/fuzz/<FuzzTestName> in fsDir, is always run, like tests are, while
+// the fuzz test matching opts.FuzzFlag, if fuzz is set, is then fuzzed. The
+// input which made it fail, if any, is written to its corpus directory.
+func (opts *TestOptions) runFuzzTargets(
+	mpkg *std.MemPackage,
+	files *gno.FileSet,
+	tgs gno.TransactionStore,
+	pv *gno.PackageValue,
+	fsDir string,
+	fuzz bool,
+) (errs error) {
+	fuzzFuncs := loadFuzzFuncs(mpkg.Name, files)
+
+	var fuzzRe *regexp.Regexp
+	if fuzz && opts.FuzzFlag != "" {
+		var err error
+		if fuzzRe, err = regexp.Compile(opts.FuzzFlag); err != nil {
+			return fmt.Errorf("invalid -fuzz regexp: %w", err)
+		}
+		var matched []string
+		for _, ff := range fuzzFuncs {
+			if fuzzRe.MatchString(ff.Name) {
+				matched = append(matched, ff.Name)
+			}
+		}
+		if len(matched) > 1 {
+			return fmt.Errorf("will not fuzz, -fuzz matches more than one fuzz test: %v", matched)
+		}
+	}
+
+	for _, ff := range fuzzFuncs {
+		// Like Go, don't fuzz if the seed corpus of a fuzz test failed.
+		fuzzThis := fuzzRe != nil && errs == nil && fuzzRe.MatchString(ff.Name)
+		err := opts.runFuzzTarget(mpkg, tgs, pv, fsDir, ff, fuzzThis)
+		if err != nil {
+			errs = multierr.Append(errs, err)
+			if opts.FailfastFlag {
+				return errs
+			}
+		}
+	}
+	return errs
+}
+
+func (opts *TestOptions) runFuzzTarget(
+	mpkg *std.MemPackage,
+	tgs gno.TransactionStore,
+	pv *gno.PackageValue,
+	fsDir string,
+	ff testFunc,
+	fuzz bool,
+) error {
+	m := Machine(tgs, opts.WriterForStore(), mpkg.Path, opts.Debug)
+	m.Coverage = opts.Coverage
+	m.SetActivePackage(pv)
+
+	fuzzfv := m.Eval(gno.Nx(ff.Name))[0]
+	if fuzzfv.GetFunc().IsCrossing() {
+		fmt.Fprintf(opts.Error, "--- FAIL: %s\ncrossing fuzz tests are not supported\n", ff.Name)
+		return fmt.Errorf("failed: %q", ff.Name)
+	}
+
+	// The functions running fuzz tests are unexported, so they are
+	// evaluated in the testing package, like the call of the fuzz target.
+	testingpv := m.Store.GetPackage("testing", false)
+	testingtv := gno.TypedValue{T: &gno.PackageType{}, V: testingpv}
+	testingcx := &gno.ConstExpr{TypedValue: testingtv}
+	m.SetActivePackage(testingpv)
+	evalTesting := func(x gno.Expr) *gno.ConstExpr {
+		return gno.NewConstExpr(x, m.Eval(x)[0])
+	}
+
+	// Run the fuzz test, which adds the seeds and sets the fuzz target.
+	setup := m.Eval(gno.Call(
+		evalTesting(gno.Nx("fuzzSetup")),
+		gno.Str(opts.RunFlag),
+		&gno.CompositeLitExpr{
+			Type: gno.Sel(testingcx, "InternalFuzzTarget"),
+			Elts: gno.KeyValueExprs{
+				{Key: gno.X("Name"), Value: gno.Str(ff.Name)},
+				{Key: gno.X("Fn"), Value: gno.NewConstExpr(gno.Nx(ff.Name), fuzzfv)},
+			},
+		},
+	))[0]
+	if setup.V == nil {
+		// Not matching -run.
+		return nil
+	}
+	fx := gno.NewConstExpr(gno.Nx("f"), setup)
+
+	// Without reflection, the call of the fuzz target with its arguments
+	// is made here, from its type.
+	var call, zero gno.Expr = gno.Nx("nil"), gno.Nx("nil")
+	if target := m.Eval(gno.Call(evalTesting(gno.Nx("fuzzTarget")), fx))[0]; target.T != nil {
+		callSrc, zeroSrc, err := fuzzCallSource(target.T)
+		if err != nil {
+			fmt.Fprintf(opts.Error, "--- FAIL: %s\n%v\n", ff.Name, err)
+			return fmt.Errorf("failed: %q", ff.Name)
+		}
+		call, zero = opts.fuzzCallFunc(tgs, ff.Name, callSrc), evalTesting(gno.MustParseExpr(zeroSrc))
+	}
+
+	corpusDir := filepath.Join(fsDir, "testdata", "fuzz", ff.Name)
+	corpus, err := readCorpus(corpusDir)
+	if err != nil {
+		return err
+	}
+	corpusX := evalTesting(gno.MustParseExpr(corpus))
+
+	eval := m.Eval(gno.Call(
+		evalTesting(gno.Nx("runFuzz")),
+		fx,
+		call,
+		zero,
+		corpusX,
+		gno.Str(opts.RunFlag),
+		gno.Nx(strconv.FormatBool(opts.Verbose)),
+		gno.Nx(strconv.FormatBool(fuzz)),
+		gno.Num(strconv.FormatInt(int64(opts.Fuzztime.D), 10)),
+		gno.Num(strconv.Itoa(opts.Fuzztime.N)),
+	))
+
+	var rep fuzzReport
+	if err := json.Unmarshal([]byte(eval[0].GetString()), &rep); err != nil {
+		fmt.Fprintf(opts.Error, "--- FAIL: %s [internal gno testing error]\n", ff.Name)
+		return err
+	}
+	if rep.Crasher != "" {
+		name := fmt.Sprintf("%x", sha256.Sum256([]byte(rep.Crasher)))[:16]
+		if err := os.MkdirAll(corpusDir, 0o755); err != nil {
+			return err
+		}
+		if err := os.WriteFile(filepath.Join(corpusDir, name), []byte(rep.Crasher), 0o644); err != nil {
+			return err
+		}
+		fmt.Fprintf(opts.Error, "    Failing input written to testdata/fuzz/%s/%s\n", ff.Name, name)
+		fmt.Fprintf(opts.Error, "    To re-run:\n    gno test -run=%s/%s\n", ff.Name, name)
+	}
+	if rep.Failed {
+		return fmt.Errorf("failed: %q", ff.Name)
+	}
+	return nil
+}
+
+// fuzzCallFunc declares the testing.fuzzCall function of src, "func call(...)",
+// in a throwaway package for the fuzz test name, and returns it.
+func (opts *TestOptions) fuzzCallFunc(tgs gno.TransactionStore, name, src string) *gno.ConstExpr {
+	m := Machine(tgs, opts.WriterForStore(), "fuzzcall/"+name, false)
+	pn := gno.NewPackageNode("fuzzcall", "fuzzcall/"+name, &gno.FileSet{})
+	pv := pn.NewPackage(m.Alloc)
+	m.Store.SetBlockNode(pn)
+	m.Store.SetCachePackage(pv)
+	m.SetActivePackage(pv)
+	m.RunFiles(gno.MustParseFile("fuzzcall.gno", "package fuzzcall\n\nimport \"testing\"\n\n"+src))
+	return gno.NewConstExpr(gno.Nx("call"), m.Eval(gno.Nx("call"))[0])
+}
+
+// fuzzArgTypes are the types of the arguments of fuzz targets, with the
+// expressions of their zero values.
+var fuzzArgTypes = map[gno.Type]string{
+	gno.StringType:  `""`,
+	gno.BoolType:    `false`,
+	gno.IntType:     `0`,
+	gno.Int8Type:    `0`,
+	gno.Int16Type:   `0`,
+	gno.Int32Type:   `0`,
+	gno.Int64Type:   `0`,
+	gno.UintType:    `0`,
+	gno.Uint8Type:   `0`,
+	gno.Uint16Type:  `0`,
+	gno.Uint32Type:  `0`,
+	gno.Uint64Type:  `0`,
+	gno.Float32Type: `0`,
+	gno.Float64Type: `0`,
+}
+
+// fuzzCallSource returns the source of the declaration of a testing.fuzzCall
+// calling a fuzz target of type typ, and of the zero values of its arguments,
+// as a []any.
+func fuzzCallSource(typ gno.Type) (call, zero string, err error) {
+	ft, ok := gno.BaseOf(typ).(*gno.FuncType)
+	if !ok || len(ft.Params) < 1 || len(ft.Results) > 0 || ft.HasVarg() || !isTestingT(ft.Params[0].Type) {
+		return "", "", fmt.Errorf("fuzz target must be a func(*testing.T, ...) with no return values, got %s", typ)
+	}
+
+	types := []string{"*testing.T"}
+	var args, zeros []string
+	for i, p := range ft.Params[1:] {
+		var name, z string
+		if st, ok := p.Type.(*gno.SliceType); ok && st.Elt == gno.Uint8Type {
+			name, z = "[]byte", "[]byte{}"
+		} else if z, ok = fuzzArgTypes[p.Type]; ok {
+			name = p.Type.String()
+			z = name + "(" + z + ")"
+		} else {
+			return "", "", fmt.Errorf("fuzz target has unsupported argument type %s", p.Type)
+		}
+		types = append(types, name)
+		args = append(args, fmt.Sprintf(", args[%d].(%s)", i, name))
+		zeros = append(zeros, z)
+	}
+	call = fmt.Sprintf("func call(fn any, t *testing.T, args []any) { fn.(func(%s))(t%s) }",
+		strings.Join(types, ", "), strings.Join(args, ""))
+	zero = "[]any{" + strings.Join(zeros, ", ") + "}"
+	return call, zero, nil
+}
+
+func isTestingT(typ gno.Type) bool {
+	pt, ok := typ.(*gno.PointerType)
+	if !ok {
+		return false
+	}
+	dt, ok := pt.Elt.(*gno.DeclaredType)
+	return ok && dt.PkgPath == "testing" && dt.Name == "T"
+}
+
+// readCorpus returns the source of a []testing.corpusEntry, with the files of
+// the corpus directory dir, if it exists, in order of their names.
+func readCorpus(dir string) (string, error) {
+	entries, err := os.ReadDir(dir)
+	if err != nil && !errors.Is(err, os.ErrNotExist) {
+		return "", err
+	}
+	var sb strings.Builder
+	sb.WriteString("[]corpusEntry{")
+	for _, entry := range entries {
+		if entry.IsDir() {
+			continue
+		}
+		data, err := os.ReadFile(filepath.Join(dir, entry.Name()))
+		if err != nil {
+			return "", err
+		}
+		fmt.Fprintf(&sb, "{Name: %s, Data: %s},", strconv.Quote(entry.Name()), strconv.Quote(string(data)))
+	}
+	sb.WriteString("}")
+	return sb.String(), nil
+}
+
+func loadFuzzFuncs(pkgName string, tfiles *gno.FileSet) (rt []testFunc) {
+	for _, tf := range tfiles.Files {
+		for _, d := range tf.Decls {
+			if fd, ok := d.(*gno.FuncDecl); ok {
+				if fd.IsMethod {
+					continue
+				}
+				fname := string(fd.Name)
+				if strings.HasPrefix(fname, "Fuzz") {
+					rt = append(rt, testFunc{
+						Package:  pkgName,
+						Name:     fname,
+						Filename: tf.FileName,
+					})
+				}
+			}
+		}
+	}
+	return
+}
@@ -0,0 +1,377 @@
+package test
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"regexp"
+	"slices"
+	"sort"
+	"strings"
+	"sync"
+	"time"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/packages"
+	"go.uber.org/multierr"
+)
+
+// Tags of the filetests, as computed by [FiletestTags]. Filetests in a
+// subdirectory are also tagged with the name of the directory.
+const (
+	TagRealm        = "realm"        // the filetest runs in a realm package.
+	TagStdlib       = "stdlib"       // the filetest imports a standard library.
+	TagError        = "error"        // the filetest expects an error.
+	TagOutput       = "output"       // the filetest has an Output directive.
+	TagRealmOps     = "realmops"     // the filetest has a Realm directive.
+	TagEvents       = "events"       // the filetest has an Events directive.
+	TagPreprocessed = "preprocessed" // the filetest has a Preprocessed directive.
+	TagStorage      = "storage"      // the filetest has a Storage directive.
+	TagLong         = "long"         // the filetest is skipped with -short.
+)
+
+// FiletestTags returns the sorted tags of the filetest at path, relative to
+// the directory of the filetests, with the given source.
+func FiletestTags(path string, source []byte) ([]string, error) {
+	dirs, err := ParseDirectives(bytes.NewReader(source))
+	if err != nil {
+		return nil, fmt.Errorf("error parsing directives: %w", err)
+	}
+	imports, err := packages.FileImports(path, string(source), nil)
+	if err != nil {
+		return nil, err
+	}
+
+	var tags []string
+	if dir := filepath.Dir(path); dir != "." {
+		tags = append(tags, filepath.ToSlash(dir))
+	}
+	if gno.IsRealmPath(dirs.FirstDefault(DirectivePkgPath, "main")) {
+		tags = append(tags, TagRealm)
+	}
+	for _, imp := range imports {
+		if gno.IsStdlib(imp.PkgPath) {
+			tags = append(tags, TagStdlib)
+			break
+		}
+	}
+	if dirs.First(DirectiveError) != nil || dirs.First(DirectiveTypeCheckError) != nil {
+		tags = append(tags, TagError)
+	}
+	for name, tag := range map[string]string{
+		DirectiveOutput:       TagOutput,
+		DirectiveRealm:        TagRealmOps,
+		DirectiveEvents:       TagEvents,
+		DirectivePreprocessed: TagPreprocessed,
+		DirectiveStorage:      TagStorage,
+	} {
+		if dirs.First(name) != nil {
+			tags = append(tags, tag)
+		}
+	}
+	if strings.HasSuffix(path, "_long.gno") {
+		tags = append(tags, TagLong)
+	}
+	sort.Strings(tags)
+	return tags, nil
+}
+
+// A FiletestFilter selects filetests by tag and by path.
+type FiletestFilter struct {
+	terms []filterTerm
+}
+
+type filterTerm struct {
+	negate bool
+	tag    string
+	re     *regexp.Regexp
+}
+
+// ParseFiletestFilter parses a comma-separated list of terms, all of which
+// must match a filetest for it to be selected. A term "tag:NAME" matches the
+// filetests having the tag NAME, and any other term is a regular expression
+// matched against the path of the filetest. A term prefixed with "!" matches
+// the filetests which the rest of the term doesn't match.
+//
+// An empty filter selects all the filetests.
+func ParseFiletestFilter(expr string) (*FiletestFilter, error) {
+	f := &FiletestFilter{}
+	for _, s := range strings.Split(expr, ",") {
+		s = strings.TrimSpace(s)
+		if s == "" {
+			continue
+		}
+		var term filterTerm
+		if strings.HasPrefix(s, "!") {
+			term.negate, s = true, s[1:]
+		}
+		if tag, ok := strings.CutPrefix(s, "tag:"); ok {
+			if tag == "" {
+				return nil, errors.New("empty tag in filter")
+			}
+			term.tag = tag
+		} else {
+			re, err := regexp.Compile(s)
+			if err != nil {
+				return nil, fmt.Errorf("invalid filter %q: %w", s, err)
+			}
+			term.re = re
+		}
+		f.terms = append(f.terms, term)
+	}
+	return f, nil
+}
+
+// Match reports whether the filetest at path, with the given tags, is
+// selected by the filter.
+func (f *FiletestFilter) Match(path string, tags []string) bool {
+	if f == nil {
+		return true
+	}
+	for _, term := range f.terms {
+		var ok bool
+		if term.re != nil {
+			ok = term.re.MatchString(path)
+		} else {
+			ok = slices.Contains(tags, term.tag)
+		}
+		if ok == term.negate {
+			return false
+		}
+	}
+	return true
+}
+
+// A FiletestMatrix runs a directory of filetests, like gnovm/tests/files,
+// with each filetest selected by its tags or its path.
+type FiletestMatrix struct {
+	RootDir  string          // clone location of github.com/gnolang/gno.
+	Dir      string          // directory of the filetests.
+	Filter   *FiletestFilter // selects the filetests to run; nil runs them all.
+	Parallel int             // number of filetests run concurrently, at least 1.
+	Short    bool            // skip the filetests tagged TagLong.
+	Sync     bool            // update the golden directives which changed.
+}
+
+// A FiletestResult is the result of a filetest run by a [FiletestMatrix].
+type FiletestResult struct {
+	Path     string   // path of the filetest, relative to the directory.
+	Tags     []string // tags of the filetest.
+	Skipped  string   // why the filetest was skipped, if it was.
+	Err      error    // set if the filetest failed.
+	Updated  bool     // set if the golden directives were updated.
+	Duration time.Duration
+}
+
+// Mismatches returns the directives of a failed filetest whose golden content
+// didn't match the result.
+func (r FiletestResult) Mismatches() []*DirectiveMismatchError {
+	var res []*DirectiveMismatchError
+	for _, err := range multierr.Errors(r.Err) {
+		var mm *DirectiveMismatchError
+		if errors.As(err, &mm) {
+			res = append(res, mm)
+		}
+	}
+	return res
+}
+
+type matrixEntry struct {
+	path   string
+	mode   fs.FileMode
+	source []byte
+	result FiletestResult
+}
+
+// Run runs the selected filetests, calling report with the result of each of
+// them in the order of their paths, and returns the results.
+func (fm *FiletestMatrix) Run(report func(FiletestResult)) ([]FiletestResult, error) {
+	entries, err := fm.collect()
+	if err != nil {
+		return nil, err
+	}
+
+	// Filetests are distributed to workers, each with its own store; their
+	// results are reported in order as soon as the previous ones are done.
+	var (
+		mu       sync.Mutex
+		wg       sync.WaitGroup
+		done     = make([]bool, len(entries))
+		next     int
+		errs     error
+		indexes  = make(chan int)
+		parallel = max(fm.Parallel, 1)
+	)
+	finish := func(i int) {
+		mu.Lock()
+		defer mu.Unlock()
+		done[i] = true
+		for ; next < len(entries) && done[next]; next++ {
+			if report != nil {
+				report(entries[next].result)
+			}
+		}
+	}
+	for range parallel {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			var opts *TestOptions
+			for i := range indexes {
+				e := &entries[i]
+				if e.result.Skipped == "" {
+					if opts == nil {
+						opts = fm.newOptions()
+					}
+					if err := fm.run(opts, e); err != nil {
+						mu.Lock()
+						errs = multierr.Append(errs, err)
+						mu.Unlock()
+					}
+				}
+				finish(i)
+			}
+		}()
+	}
+	for i := range entries {
+		indexes <- i
+	}
+	close(indexes)
+	wg.Wait()
+
+	results := make([]FiletestResult, len(entries))
+	for i, e := range entries {
+		results[i] = e.result
+	}
+	return results, errs
+}
+
+// collect returns the filetests of the directory selected by the filter.
+func (fm *FiletestMatrix) collect() ([]matrixEntry, error) {
+	var entries []matrixEntry
+	fsys := os.DirFS(fm.Dir)
+	err := fs.WalkDir(fsys, ".", func(path string, de fs.DirEntry, err error) error {
+		switch {
+		case err != nil:
+			return err
+		case path == "extern":
+			return fs.SkipDir
+		case de.IsDir(), !strings.HasSuffix(path, ".gno"):
+			return nil
+		}
+		source, err := fs.ReadFile(fsys, path)
+		if err != nil {
+			return err
+		}
+		tags, err := FiletestTags(path, source)
+		if err != nil {
+			return fmt.Errorf("%s: %w", path, err)
+		}
+		if !fm.Filter.Match(path, tags) {
+			return nil
+		}
+		e := matrixEntry{
+			path:   path,
+			mode:   de.Type(),
+			source: source,
+			result: FiletestResult{Path: path, Tags: tags},
+		}
+		switch {
+		case strings.HasPrefix(filepath.Base(path), "."):
+			e.result.Skipped = "hidden"
+		case strings.HasSuffix(path, "_known.gno"):
+			e.result.Skipped = "known issue"
+		case fm.Short && slices.Contains(tags, TagLong):
+			e.result.Skipped = "long"
+		}
+		entries = append(entries, e)
+		return nil
+	})
+	return entries, err
+}
+
+func (fm *FiletestMatrix) newOptions() *TestOptions {
+	opts := &TestOptions{
+		RootDir: fm.RootDir,
+		Output:  io.Discard,
+		Error:   io.Discard,
+		Sync:    fm.Sync,
+	}
+	opts.BaseStore, opts.TestStore = StoreWithOptions(
+		fm.RootDir, opts.WriterForStore(),
+		StoreOptions{WithExtern: true, WithExamples: true, Testing: true},
+	)
+	return opts
+}
+
+// run runs the filetest e, and returns an error only if its golden file could
+// not be updated.
+func (fm *FiletestMatrix) run(opts *TestOptions, e *matrixEntry) error {
+	start := time.Now()
+	changed, err := opts.RunFiletest(e.path, e.source, opts.TestStore)
+	e.result.Duration = time.Since(start)
+	e.result.Err = err
+	if changed == "" {
+		return nil
+	}
+	e.result.Updated = true
+	if err := os.WriteFile(filepath.Join(fm.Dir, e.path), []byte(changed), e.mode); err != nil {
+		return fmt.Errorf("could not fix golden file: %w", err)
+	}
+	return nil
+}
+
+// Diff returns a unified diff of the expected and actual content of the
+// directive. For the Realm directive, the operations on the objects are
+// sorted within each realm finalization: Diff returns "" if only their order
+// changed.
+func (e *DirectiveMismatchError) Diff() string {
+	if e.Directive == DirectiveRealm {
+		return unifiedDiff(sortRealmOps(e.Expected), sortRealmOps(e.Actual))
+	}
+	return unifiedDiff(e.Expected, e.Actual)
+}
+
+// sortRealmOps sorts the object operations of the realm opslog ops, like
+// "u[oid](size)=" and its JSON, by their first line. Other lines, like
+// "finalizerealm[path]", keep their position.
+func sortRealmOps(ops string) string {
+	var (
+		res     []string
+		section []string
+	)
+	flush := func() {
+		sort.SliceStable(section, func(i, j int) bool {
+			return strings.SplitN(section[i], "\n", 2)[0] < strings.SplitN(section[j], "\n", 2)[0]
+		})
+		res = append(res, section...)
+		section = nil
+	}
+	for _, line := range strings.Split(ops, "\n") {
+		switch {
+		case reObjectOp.MatchString(line):
+			section = append(section, line)
+		case len(section) > 0 && !reRealmOp.MatchString(line):
+			// Continuation of the current operation, like its JSON.
+			section[len(section)-1] += "\n" + line
+		default:
+			flush()
+			res = append(res, line)
+		}
+	}
+	flush()
+	return strings.Join(res, "\n")
+}
+
+var (
+	// reRealmOp matches the lines of the realm opslog starting an
+	// operation, like "finalizerealm[path]".
+	reRealmOp = regexp.MustCompile(`^[a-z]+\[`)
+	// reObjectOp matches the lines starting an operation on an object,
+	// like "c[oid](size)=".
+	reObjectOp = regexp.MustCompile(`^[cud]\[`)
+)
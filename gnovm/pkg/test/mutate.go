@@ -0,0 +1,190 @@
+package test
+
+import (
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"slices"
+	"strings"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// MutationOperator is a kind of change made by a [Mutation].
+type MutationOperator string
+
+const (
+	// NegateConditional replaces a comparison by its opposite, e.g. == by !=.
+	NegateConditional MutationOperator = "negate conditional"
+	// OffByOne moves the boundary of a comparison, e.g. < to <=.
+	OffByOne MutationOperator = "off by one"
+	// RemoveStatement removes an assignment, an increment or a call.
+	RemoveStatement MutationOperator = "remove statement"
+)
+
+// Mutation is a small change to a production file of a package, made to check
+// that its tests detect it. The package with the change applied is called a
+// mutant; a mutant whose tests still pass shows code which isn't tested, or
+// only partly so.
+type Mutation struct {
+	File     string // name of the mutated file
+	Line     int
+	Column   int
+	Operator MutationOperator
+	Change   string // e.g. "== to !=", or the removed statement
+
+	// the source in [start, end) is replaced by repl.
+	start, end int
+	repl       string
+}
+
+func (mu Mutation) String() string {
+	return fmt.Sprintf("%s:%d:%d: %s: %s", mu.File, mu.Line, mu.Column, mu.Operator, mu.Change)
+}
+
+var (
+	negatedOps = map[token.Token]token.Token{
+		token.EQL: token.NEQ,
+		token.NEQ: token.EQL,
+		token.LSS: token.GEQ,
+		token.GEQ: token.LSS,
+		token.GTR: token.LEQ,
+		token.LEQ: token.GTR,
+	}
+	boundaryOps = map[token.Token]token.Token{
+		token.LSS: token.LEQ,
+		token.LEQ: token.LSS,
+		token.GTR: token.GEQ,
+		token.GEQ: token.GTR,
+	}
+)
+
+// Mutations returns the mutations of the production files of mpkg, in the
+// order of the files and of their source.
+func Mutations(mpkg *std.MemPackage) ([]Mutation, error) {
+	var muts []Mutation
+	for _, mfile := range mpkg.Files {
+		if !strings.HasSuffix(mfile.Name, ".gno") ||
+			gno.MPFProd.FilterGno(mfile, gno.Name(mpkg.Name)) {
+			continue
+		}
+		fmuts, err := fileMutations(mfile)
+		if err != nil {
+			return nil, err
+		}
+		muts = append(muts, fmuts...)
+	}
+	return muts, nil
+}
+
+func fileMutations(mfile *std.MemFile) ([]Mutation, error) {
+	fset := token.NewFileSet()
+	f, err := parser.ParseFile(fset, mfile.Name, mfile.Body, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, err
+	}
+
+	var muts []Mutation
+	add := func(pos, end token.Pos, op MutationOperator, change, repl string) {
+		p := fset.Position(pos)
+		muts = append(muts, Mutation{
+			File:     mfile.Name,
+			Line:     p.Line,
+			Column:   p.Column,
+			Operator: op,
+			Change:   change,
+			start:    p.Offset,
+			end:      fset.Position(end).Offset,
+			repl:     repl,
+		})
+	}
+	removeStmts := func(stmts []ast.Stmt) {
+		for _, stmt := range stmts {
+			switch stmt := stmt.(type) {
+			case *ast.AssignStmt:
+				if stmt.Tok == token.DEFINE {
+					continue // removing it leaves undefined variables.
+				}
+			case *ast.ExprStmt, *ast.IncDecStmt:
+			default:
+				continue
+			}
+			src := mfile.Body[fset.Position(stmt.Pos()).Offset:fset.Position(stmt.End()).Offset]
+			add(stmt.Pos(), stmt.End(), RemoveStatement, shortSource(src), "")
+		}
+	}
+
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n := n.(type) {
+		case *ast.BinaryExpr:
+			if op, ok := negatedOps[n.Op]; ok {
+				add(n.OpPos, n.OpPos+token.Pos(len(n.Op.String())), NegateConditional,
+					n.Op.String()+" to "+op.String(), op.String())
+			}
+			if op, ok := boundaryOps[n.Op]; ok {
+				add(n.OpPos, n.OpPos+token.Pos(len(n.Op.String())), OffByOne,
+					n.Op.String()+" to "+op.String(), op.String())
+			}
+		case *ast.BlockStmt:
+			removeStmts(n.List)
+		case *ast.CaseClause:
+			removeStmts(n.Body)
+		case *ast.CommClause:
+			removeStmts(n.Body)
+		}
+		return true
+	})
+
+	// The statements of a block are removed when visiting the block, before
+	// the expressions they contain are visited.
+	slices.SortStableFunc(muts, func(a, b Mutation) int {
+		return a.start - b.start
+	})
+	return muts, nil
+}
+
+// shortSource returns the first line of src, shortened to be printed.
+func shortSource(src string) string {
+	const maxLen = 40
+	if i := strings.IndexByte(src, '\n'); i >= 0 {
+		src = src[:i] + " ..."
+	}
+	if len(src) > maxLen {
+		src = src[:maxLen] + "..."
+	}
+	return src
+}
+
+// Apply returns a copy of mpkg, with the mutation applied.
+func (mu Mutation) Apply(mpkg *std.MemPackage) *std.MemPackage {
+	mutant := *mpkg
+	mutant.Files = make([]*std.MemFile, len(mpkg.Files))
+	for i, mfile := range mpkg.Files {
+		if mfile.Name == mu.File {
+			mfile = &std.MemFile{
+				Name: mfile.Name,
+				Body: mfile.Body[:mu.start] + mu.repl + mfile.Body[mu.end:],
+			}
+		}
+		mutant.Files[i] = mfile
+	}
+	return &mutant
+}
+
+// LoadPackage runs the production files of mpkg in opts.TestStore. The tests
+// importing mpkg.Path, including the filetests, then use them instead of the
+// files of its directory; this is how the mutants of a package are tested.
+func (opts *TestOptions) LoadPackage(mpkg *std.MemPackage) {
+	m := gno.NewMachineWithOptions(gno.MachineOptions{
+		PkgPath:       mpkg.Path,
+		Output:        opts.WriterForStore(),
+		Store:         opts.TestStore,
+		Context:       Context("", mpkg.Path, nil),
+		ReviveEnabled: true,
+		SkipPackage:   true,
+	})
+	defer m.Release()
+	m.RunMemPackage(gno.MPFProd.FilterMemPackage(mpkg), true)
+}
@@ -61,7 +61,7 @@ func Context(caller crypto.Bech32Address, pkgPath string, send std.Coins) *runti
 		OriginSendSpent: new(std.Coins),
 		Banker:          banker,
 		Params:          newTestParams(),
-		EventLogger:     sdk.NewEventLogger(),
+		EventManager:    sdk.NewEventManager(),
 	}
 	return &runtime.TestExecContext{
 		ExecContext: ctx,
@@ -143,8 +143,25 @@ type TestOptions struct {
 	Verbose bool
 	// Uses Error to print runtime metrics for tests.
 	Metrics bool
+	// Gas budget of each test; a test running out of it fails. Zero means
+	// unlimited.
+	MaxGas int64
 	// Uses Error to print the events emitted.
 	Events bool
+	// Counts the statements executed by the tests, if set.
+	Coverage *gno.Coverage
+	// Flag to filter benchmarks to run; none are run if empty.
+	BenchFlag string
+	// How long, or how many times, to run each benchmark.
+	Benchtime DurationOrCount
+	// Uses Error to print the memory allocations of all benchmarks.
+	Benchmem bool
+	// Flag to select the fuzz test to fuzz; none is fuzzed if empty, but
+	// their seed corpus is run like tests.
+	FuzzFlag string
+	// How long, or how many times, to run the fuzz target; zero means until
+	// it fails.
+	Fuzztime DurationOrCount
 
 	filetestBuffer bytes.Buffer
 	outWriter      proxyWriter
@@ -247,6 +264,7 @@ func Test(mpkg *std.MemPackage, fsDir string, opts *TestOptions) error {
 		// new packages by default, which we don't want.  Instead we
 		// will run the mempackage ourselves in the next line.
 		SkipPackage: true,
+		Coverage:    opts.Coverage,
 	})
 	// Filter out xxx_test *_test.gno and *_filetest.gno and run.
 	// If testing with only filetests, there will be no files.
@@ -270,7 +288,7 @@ func Test(mpkg *std.MemPackage, fsDir string, opts *TestOptions) error {
 	if len(tset.Files)+len(itset.Files) > 0 {
 		// Run test files in pkg.
 		if len(tset.Files) > 0 {
-			err := opts.runTestFiles(mpkg, tset, tgs)
+			err := opts.runTestFiles(mpkg, tset, tgs, fsDir)
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			}
@@ -291,7 +309,7 @@ func Test(mpkg *std.MemPackage, fsDir string, opts *TestOptions) error {
 				Files: itfiles,
 			}
 
-			err := opts.runTestFiles(itmpkg, itset, tgs)
+			err := opts.runTestFiles(itmpkg, itset, tgs, fsDir)
 			if err != nil {
 				errs = multierr.Append(errs, err)
 			}
@@ -311,6 +329,22 @@ func Test(mpkg *std.MemPackage, fsDir string, opts *TestOptions) error {
 				continue
 			}
 
+			// Integration filetests only run against a node.
+			if dirs, err := ParseDirectives(strings.NewReader(testFile.Body)); err == nil {
+				integration, err := dirs.Integration()
+				if err != nil {
+					fmt.Fprintf(opts.Error, "--- FAIL: %s\n%v\n", testName, err)
+					errs = multierr.Append(errs, fmt.Errorf("%s failed", testName))
+					continue
+				}
+				if integration {
+					if opts.Verbose {
+						fmt.Fprintf(opts.Error, "--- SKIP: %s (integration test, run with -network)\n", testName)
+					}
+					continue
+				}
+			}
+
 			startedAt := time.Now()
 			if opts.Verbose {
 				fmt.Fprintf(opts.Error, "=== RUN   %s\n", testName)
@@ -352,6 +386,7 @@ func (opts *TestOptions) runTestFiles(
 	mpkg *std.MemPackage,
 	files *gno.FileSet,
 	tgs gno.TransactionStore,
+	fsDir string,
 ) (errs error) {
 	var m *gno.Machine
 	defer func() {
@@ -370,7 +405,7 @@ func (opts *TestOptions) runTestFiles(
 	tests := loadTestFuncs(mpkg.Name, files)
 
 	var alloc *gno.Allocator
-	if opts.Metrics {
+	if opts.Metrics || opts.MaxGas > 0 {
 		alloc = gno.NewAllocator(math.MaxInt64)
 	}
 	// reset store ops, if any - we only need them for some filetests.
@@ -379,6 +414,7 @@ func (opts *TestOptions) runTestFiles(
 	// Check if we already have the package - it may have been eagerly loaded.
 	m = Machine(tgs, opts.WriterForStore(), mpkg.Path, opts.Debug)
 	m.Alloc = alloc
+	m.Coverage = opts.Coverage
 	if tgs.GetMemPackage(mpkg.Path) == nil {
 		m.RunMemPackage(mpkg, false)
 	} else {
@@ -400,7 +436,14 @@ func (opts *TestOptions) runTestFiles(
 		// - Wrap here.
 		m = Machine(tgs, opts.WriterForStore(), mpkg.Path, opts.Debug)
 		m.Alloc = alloc.Reset()
+		m.Coverage = opts.Coverage
 		m.SetActivePackage(pv)
+		if gasMeter := opts.testGasMeter(); gasMeter != nil {
+			// Like in a transaction, both the CPU cycles and the
+			// allocations of the test consume gas.
+			m.GasMeter = gasMeter
+			m.Alloc.SetGasMeter(gasMeter)
+		}
 
 		testingpv := m.Store.GetPackage("testing", false)
 		testingtv := gno.TypedValue{T: &gno.PackageType{}, V: testingpv}
@@ -463,7 +506,7 @@ func (opts *TestOptions) runTestFiles(
 			m.Debugger.Enable(os.Stdin, os.Stdout, fileContent)
 		}
 
-		eval := m.Eval(gno.Call(
+		eval, oog := evalWithGas(m, gno.Call(
 			runTestCX,                                     // Call testing.RunTest
 			gno.Str(opts.RunFlag),                         // run flag
 			gno.Nx(strconv.FormatBool(opts.Verbose)),      // is verbose?
@@ -479,9 +522,19 @@ func (opts *TestOptions) runTestFiles(
 				},
 			},
 		))
+		if oog != nil {
+			err := fmt.Errorf("failed: %q: %w", tf.Name, oog)
+			errs = multierr.Append(errs, err)
+			fmt.Fprintf(opts.Error, "--- FAIL: %s (%v, gas used: %d)\n",
+				tf.Name, oog, m.GasMeter.GasConsumed())
+			if opts.FailfastFlag {
+				return errs
+			}
+			continue
+		}
 
 		if opts.Events {
-			events := m.Context.(*runtime.TestExecContext).EventLogger.Events()
+			events := m.Context.(*runtime.TestExecContext).EventManager.Events()
 			if events != nil {
 				res, err := json.Marshal(events)
 				if err != nil {
@@ -527,13 +580,27 @@ func (opts *TestOptions) runTestFiles(
 					float64(allocs)/float64(maxAllocs)*100,
 				)
 			}
-			fmt.Fprintf(opts.Error, "---       runtime: cycle=%s allocs=%s\n",
+			fmt.Fprintf(opts.Error, "---       runtime: cycle=%s gas=%s allocs=%s\n",
 				prettySize(m.Cycles),
+				prettySize(m.GasMeter.GasConsumed()),
 				allocsVal,
 			)
 		}
 	}
 
+	// Like Go, run the seed corpus of fuzz tests with the tests, and only
+	// fuzz and run the benchmarks if they pass.
+	fuzz := opts.FuzzFlag != "" && errs == nil
+	if err := opts.runFuzzTargets(mpkg, files, tgs, pv, fsDir, fuzz); err != nil {
+		errs = multierr.Append(errs, err)
+		if opts.FailfastFlag {
+			return errs
+		}
+	}
+	if opts.BenchFlag != "" && errs == nil {
+		errs = opts.runBenchmarks(mpkg, files, tgs, pv)
+	}
+
 	return errs
 }
 
@@ -621,6 +688,34 @@ func shouldRun(filter filterMatch, path string) bool {
 }
 
 // Adapted from https://yourbasic.org/golang/formatting-byte-size-to-human-readable-format/
+// testGasMeter returns the gas meter of a test, or nil if the tests are not
+// metered.
+func (opts *TestOptions) testGasMeter() storetypes.GasMeter {
+	switch {
+	case opts.MaxGas > 0:
+		return storetypes.NewGasMeter(opts.MaxGas)
+	case opts.Metrics:
+		return storetypes.NewInfiniteGasMeter()
+	default:
+		return nil
+	}
+}
+
+// evalWithGas evaluates x with m, returning the out of gas error if m runs
+// out of gas while evaluating it.
+func evalWithGas(m *gno.Machine, x gno.Expr) (res []gno.TypedValue, oog *storetypes.OutOfGasError) {
+	defer func() {
+		if r := recover(); r != nil {
+			e, ok := r.(storetypes.OutOfGasError)
+			if !ok {
+				panic(r)
+			}
+			oog = &e
+		}
+	}()
+	return m.Eval(x), nil
+}
+
 func prettySize(nb int64) string {
 	const unit = 1000
 	if nb < unit {
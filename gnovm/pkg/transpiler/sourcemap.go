@@ -0,0 +1,140 @@
+package transpiler
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"go/ast"
+	"go/parser"
+	"go/token"
+	"path/filepath"
+	"reflect"
+	"sort"
+)
+
+// SourceMap maps the positions of the generated Go code to those of the Gno
+// source it was transpiled from, for tools like debuggers or coverage to
+// report them against the .gno file.
+type SourceMap struct {
+	Version int    `json:"version"`
+	Source  string `json:"source"` // base name of the .gno file.
+	// Mappings are ordered by generated position.
+	Mappings []Mapping `json:"mappings"`
+}
+
+// SourceMapVersion is the version of the format of [SourceMap].
+const SourceMapVersion = 1
+
+// Mapping maps a position of the generated code to one of the Gno source.
+// Lines and columns start at 1, and columns count bytes, like in
+// [token.Position].
+type Mapping struct {
+	GenLine, GenColumn int
+	Line, Column       int
+}
+
+// MarshalJSON encodes the mapping as [genLine, genColumn, line, column],
+// to keep source maps compact.
+func (m Mapping) MarshalJSON() ([]byte, error) {
+	return json.Marshal([4]int{m.GenLine, m.GenColumn, m.Line, m.Column})
+}
+
+// UnmarshalJSON implements [json.Unmarshaler].
+func (m *Mapping) UnmarshalJSON(b []byte) error {
+	var a [4]int
+	if err := json.Unmarshal(b, &a); err != nil {
+		return err
+	}
+	*m = Mapping{GenLine: a[0], GenColumn: a[1], Line: a[2], Column: a[3]}
+	return nil
+}
+
+// Lookup returns the position in the Gno source of the given line and column
+// of the generated code: that of the closest mapping at or before it, on the
+// same line. ok is false if there is none, for instance in the file header.
+func (sm *SourceMap) Lookup(line, column int) (pos token.Position, ok bool) {
+	// First mapping after (line, column).
+	i := sort.Search(len(sm.Mappings), func(i int) bool {
+		m := sm.Mappings[i]
+		return m.GenLine > line || m.GenLine == line && m.GenColumn > column
+	})
+	if i == 0 || sm.Mappings[i-1].GenLine != line {
+		return token.Position{}, false
+	}
+	m := sm.Mappings[i-1]
+	return token.Position{Filename: sm.Source, Line: m.Line, Column: m.Column}, true
+}
+
+// SourceMap returns the source map of the transpiled file.
+func (r *Result) SourceMap() (*SourceMap, error) {
+	if r.fset == nil {
+		return nil, errors.New("source map: result was not returned by Transpile")
+	}
+
+	genFset := token.NewFileSet()
+	gen, err := parser.ParseFile(genFset, "", r.Translated, parser.SkipObjectResolution)
+	if err != nil {
+		return nil, fmt.Errorf("source map: parse generated code: %w", err)
+	}
+
+	// The generated code is the transformed AST, as printed by go/format: the
+	// nodes of both trees match one-to-one, and those of the transformed AST
+	// still have the positions of the Gno source, unless added by the
+	// transpiler.
+	src, dst := inspectNodes(r.File), inspectNodes(gen)
+	if len(src) != len(dst) {
+		return nil, fmt.Errorf("source map: generated code has %d nodes, expected %d", len(dst), len(src))
+	}
+	sm := &SourceMap{
+		Version: SourceMapVersion,
+		Source:  filepath.Base(r.filename),
+	}
+	for i, n := range src {
+		if reflect.TypeOf(n) != reflect.TypeOf(dst[i]) {
+			return nil, fmt.Errorf("source map: generated code has %T, expected %T", dst[i], n)
+		}
+		if !n.Pos().IsValid() || !dst[i].Pos().IsValid() {
+			continue
+		}
+		// Ignore //line directives, which would otherwise change the
+		// positions of the generated code.
+		srcPos := r.fset.PositionFor(n.Pos(), false)
+		genPos := genFset.PositionFor(dst[i].Pos(), false)
+		sm.Mappings = append(sm.Mappings, Mapping{
+			GenLine: genPos.Line, GenColumn: genPos.Column,
+			Line: srcPos.Line, Column: srcPos.Column,
+		})
+	}
+
+	// Order by generated position, keeping the outermost node for each.
+	sort.SliceStable(sm.Mappings, func(i, j int) bool {
+		a, b := sm.Mappings[i], sm.Mappings[j]
+		return a.GenLine < b.GenLine || a.GenLine == b.GenLine && a.GenColumn < b.GenColumn
+	})
+	mappings := sm.Mappings[:0]
+	for i, m := range sm.Mappings {
+		if i > 0 && m.GenLine == sm.Mappings[i-1].GenLine && m.GenColumn == sm.Mappings[i-1].GenColumn {
+			continue
+		}
+		mappings = append(mappings, m)
+	}
+	sm.Mappings = mappings
+	return sm, nil
+}
+
+// inspectNodes returns the nodes of f in depth-first order, except comments,
+// which the parser may attach differently once printed.
+func inspectNodes(f *ast.File) []ast.Node {
+	var nodes []ast.Node
+	ast.Inspect(f, func(n ast.Node) bool {
+		switch n.(type) {
+		case nil:
+			return false
+		case *ast.CommentGroup, *ast.Comment:
+			return false
+		}
+		nodes = append(nodes, n)
+		return true
+	})
+	return nodes
+}
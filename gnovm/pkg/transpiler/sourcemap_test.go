@@ -0,0 +1,75 @@
+package transpiler
+
+import (
+	"encoding/json"
+	"go/token"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSourceMap(t *testing.T) {
+	t.Parallel()
+
+	const source = `package foo
+
+// comments are moved around by go/format.
+func hello(
+	name string) string {
+		s := "hello, "+name
+	return s
+}
+
+func   main() {  println(hello("world")) }
+`
+	res, err := Transpile(source, "gno", "dir/foo.gno")
+	require.NoError(t, err)
+	sm, err := res.SourceMap()
+	require.NoError(t, err)
+	assert.Equal(t, "foo.gno", sm.Source)
+
+	// genPos returns the position of the first occurrence of substr in the
+	// generated code.
+	genPos := func(substr string) (line, column int) {
+		t.Helper()
+		idx := strings.Index(res.Translated, substr)
+		require.NotEqual(t, -1, idx, "%q not found in generated code", substr)
+		before := res.Translated[:idx]
+		line = strings.Count(before, "\n") + 1
+		column = idx - strings.LastIndex(before, "\n")
+		return
+	}
+	for _, tc := range []struct {
+		gen       string
+		line, col int
+	}{
+		{"package foo", 1, 1},
+		{"func hello", 4, 1},
+		{`"hello, "`, 6, 8},
+		{"+ name", 6, 8}, // binary expression, at its left operand.
+		{"name\n", 6, 18},
+		{"return s", 7, 2},
+		{"func main", 10, 1},
+		{`hello("world")`, 10, 26},
+	} {
+		line, col := genPos(tc.gen)
+		pos, ok := sm.Lookup(line, col)
+		if assert.True(t, ok, "lookup %q", tc.gen) {
+			assert.Equal(t, token.Position{Filename: "foo.gno", Line: tc.line, Column: tc.col}, pos, "lookup %q", tc.gen)
+		}
+	}
+
+	// The header isn't mapped.
+	_, ok := sm.Lookup(1, 1)
+	assert.False(t, ok)
+
+	// JSON round-trip.
+	bz, err := json.Marshal(sm)
+	require.NoError(t, err)
+	assert.Contains(t, string(bz), `"mappings":[[`)
+	var sm2 SourceMap
+	require.NoError(t, json.Unmarshal(bz, &sm2))
+	assert.Equal(t, sm, &sm2)
+}
@@ -51,6 +51,10 @@ type Result struct {
 	Imports    []*ast.ImportSpec
 	Translated string
 	File       *ast.File
+
+	// used to compute the source map.
+	fset     *token.FileSet
+	filename string
 }
 
 // TODO: func TranspileFile: supports caching.
@@ -139,6 +143,8 @@ func Transpile(source, tags, filename string) (*Result, error) {
 		Imports:    f.Imports,
 		Translated: out.String(),
 		File:       transformed,
+		fset:       fset,
+		filename:   filename,
 	}
 	return res, nil
 }
@@ -203,8 +209,13 @@ func (ctx *transpileCtx) transformFile(fset *token.FileSet, f *ast.File) (*ast.F
 			switch node := c.Node().(type) {
 			case *ast.FuncDecl:
 				// is function declaration without body?
-				// -> delete (native binding)
+				// -> delete (native binding), or stub it if the Go
+				// function can't be called without a Gno machine.
 				if node.Body == nil {
+					if nat := ctx.findNative(ctx.stdlibPath, node); nat != nil && nat.HasTypedValue() {
+						node.Body = nativeStubBody(node.Name.Name)
+						return true
+					}
 					c.Delete()
 					return false // don't attempt to traverse children
 				}
@@ -346,6 +357,33 @@ func() (__revive_recover any) {
 	return node.(*ast.File), errs.Err()
 }
 
+// findNative returns the native binding of the function declaration fd, in
+// the standard library at pkgPath.
+func (ctx *transpileCtx) findNative(pkgPath string, fd *ast.FuncDecl) *stdlibs.NativeFunc {
+	if pkgPath == "" || fd.Recv != nil {
+		return nil
+	}
+	return stdlibs.FindNative(pkgPath, gno.Name(fd.Name.Name))
+}
+
+// nativeStubBody returns the body of the stub of the native binding name,
+// which panics when called. The stub is generated for native bindings having
+// gno.TypedValue parameters or results, so that the generated code compiles
+// without the Gno machine the Go function needs.
+func nativeStubBody(name string) *ast.BlockStmt {
+	return &ast.BlockStmt{
+		List: []ast.Stmt{
+			&ast.ExprStmt{X: &ast.CallExpr{
+				Fun: ast.NewIdent("panic"),
+				Args: []ast.Expr{&ast.BasicLit{
+					Kind:  token.STRING,
+					Value: strconv.Quote("native binding " + name + " is only available in the GnoVM"),
+				}},
+			}},
+		},
+	}
+}
+
 func convertBuiltinType(ide ast.Expr, fset *token.FileSet, f *ast.File) ast.Expr {
 	id, ok := ide.(*ast.Ident)
 	if !ok {
@@ -387,7 +425,7 @@ func (ctx *transpileCtx) transformCallExpr(c *astutil.Cursor, ce *ast.CallExpr)
 			break
 		}
 		nat := stdlibs.FindNative(ip, gno.Name(fe.Sel.Name))
-		if nat != nil && nat.HasMachineParam() {
+		if nat != nil && nat.HasMachineParam() && !nat.HasTypedValue() {
 			// Because it's an import, the symbol is always exported, so no need for the
 			// X_ prefix we add below.
 			ce.Args = append([]ast.Expr{ast.NewIdent("nil")}, ce.Args...)
@@ -400,7 +438,7 @@ func (ctx *transpileCtx) transformCallExpr(c *astutil.Cursor, ce *ast.CallExpr)
 		// defined scope. However, because native bindings have a narrowly defined and
 		// controlled scope (standard libraries) this will work for our usecase.
 		nat := stdlibs.FindNative(ctx.stdlibPath, gno.Name(fe.Name))
-		if ctx.stdlibPath != "" && nat != nil {
+		if ctx.stdlibPath != "" && nat != nil && !nat.HasTypedValue() {
 			if nat.HasMachineParam() {
 				ce.Args = append([]ast.Expr{ast.NewIdent("nil")}, ce.Args...)
 			}
@@ -408,6 +408,70 @@ func testfunc() {
 }
 `,
 		},
+		{
+			name:     "natbind-typedvalue",
+			filename: filepath.Join(gnoenv.RootDir(), "gnovm/stdlibs/errors/wrap.gno"),
+			source: `
+package errors
+
+func isComparable(v any) bool
+
+func comparable(err error) bool {
+	return isComparable(err)
+}
+`,
+			expectedOutput: `
+// Code generated by github.com/gnolang/gno. DO NOT EDIT.
+
+//line wrap.gno:1:1
+package errors
+
+func isComparable(v any) bool { panic("native binding isComparable is only available in the GnoVM") }
+
+func comparable(err error) bool {
+	return isComparable(err)
+}
+`,
+		},
+		{
+			name: "use-natbind-typedvalue",
+			source: `
+package foo
+
+import "chain/runtime"
+
+var x = &struct{}{}
+
+func pin() {
+	runtime.Pin(x)
+	runtime.ChainID()
+}
+`,
+			expectedOutput: `
+// Code generated by github.com/gnolang/gno. DO NOT EDIT.
+
+//line foo.gno:1:1
+package foo
+
+import "github.com/gnolang/gno/gnovm/stdlibs/chain/runtime"
+
+var x = &struct{}{}
+
+func pin() {
+	runtime.Pin(x)
+	runtime.ChainID(nil)
+}
+`,
+			expectedImports: []*ast.ImportSpec{
+				{
+					Path: &ast.BasicLit{
+						ValuePos: 21,
+						Kind:     9,
+						Value:    `"github.com/gnolang/gno/gnovm/stdlibs/chain/runtime"`,
+					},
+				},
+			},
+		},
 	}
 	for _, c := range cases {
 		c := c // scopelint
@@ -430,6 +494,10 @@ func testfunc() {
 			expectedOutput := strings.TrimPrefix(c.expectedOutput, "\n")
 			assert.Equal(t, expectedOutput, res.Translated, "wrong output")
 			assert.Equal(t, c.expectedImports, res.Imports, "wrong imports")
+
+			// The generated code can always be mapped back to the source.
+			_, err = res.SourceMap()
+			require.NoError(t, err)
 		})
 	}
 }
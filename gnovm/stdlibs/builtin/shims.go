@@ -14,6 +14,7 @@ type Address string
 
 func (a Address) String() string { return string(a) }
 func (a Address) IsValid() bool  { return false }
+func (a Address) Bytes() []byte  { return nil }
 
 type Gnocoins []Gnocoin
 
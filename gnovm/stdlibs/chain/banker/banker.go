@@ -20,6 +20,10 @@ type BankerInterface interface {
 	TotalCoin(denom string) int64
 	IssueCoin(addr crypto.Bech32Address, denom string, amount int64)
 	RemoveCoin(addr crypto.Bech32Address, denom string, amount int64)
+	// Approve sets the amount that spender may send from owner; an empty
+	// amount removes the allowance.
+	Approve(owner, spender crypto.Bech32Address, amt std.Coins)
+	Allowance(owner, spender crypto.Bech32Address) std.Coins
 }
 
 const (
@@ -78,6 +82,48 @@ func X_bankerRemoveCoin(m *gno.Machine, bt uint8, addr string, denom string, amo
 	execctx.GetContext(m).Banker.RemoveCoin(crypto.Bech32Address(addr), denom, amount)
 }
 
+func X_bankerApprove(m *gno.Machine, bt uint8, owner, spender string, denoms []string, amounts []int64) {
+	// bt is btRealmSend or btRealmIssue, and owner the banker's realm
+	// (checked in gno)
+	amt := CompactCoins(denoms, amounts)
+	if !amt.IsValid() {
+		m.PanicString(fmt.Sprintf("invalid allowance %q", amt))
+		return
+	}
+	execctx.GetContext(m).Banker.Approve(crypto.Bech32Address(owner), crypto.Bech32Address(spender), amt)
+}
+
+func X_bankerAllowance(m *gno.Machine, bt uint8, owner, spender string) (denoms []string, amounts []int64) {
+	allowance := execctx.GetContext(m).Banker.Allowance(crypto.Bech32Address(owner), crypto.Bech32Address(spender))
+	return ExpandCoins(allowance)
+}
+
+func X_bankerTransferFrom(m *gno.Machine, bt uint8, spenderS, fromS, toS string, denoms []string, amounts []int64) {
+	// bt is btRealmSend or btRealmIssue, and spender the banker's realm
+	// (checked in gno)
+
+	ctx := execctx.GetContext(m)
+	amt := CompactCoins(denoms, amounts)
+	spender, from, to := crypto.Bech32Address(spenderS), crypto.Bech32Address(fromS), crypto.Bech32Address(toS)
+
+	if !amt.IsValid() {
+		m.PanicString(fmt.Sprintf("invalid amount %q", amt))
+		return
+	}
+	allowance := ctx.Banker.Allowance(from, spender)
+	if !allowance.IsAllGTE(amt) {
+		m.PanicString(
+			fmt.Sprintf(
+				`cannot transfer "%v" from %s, allowance is "%v"`,
+				amt, from, allowance),
+		)
+		return
+	}
+	// The allowance is only spent once the coins are sent.
+	ctx.Banker.SendCoins(from, to, amt)
+	ctx.Banker.Approve(from, spender, allowance.Sub(amt))
+}
+
 func ExpandCoins(c std.Coins) (denoms []string, amounts []int64) {
 	denoms = make([]string, len(c))
 	amounts = make([]int64, len(c))
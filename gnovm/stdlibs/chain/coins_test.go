@@ -0,0 +1,193 @@
+package chain_test
+
+import (
+	"bytes"
+	"fmt"
+	"path/filepath"
+	"strconv"
+	"strings"
+	"testing"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/pkg/test"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// TestCoinsDifferential checks that the Coins API of the chain package
+// behaves like the Go implementation used by the bank module, in
+// tm2/pkg/std.
+func TestCoinsDifferential(t *testing.T) {
+	pairs := [][2]string{
+		{"", ""},
+		{"1ugnot", ""},
+		{"", "2ugnot"},
+		{"2ugnot", "1ugnot"},
+		{"1ugnot", "2ugnot"},
+		{"1ugnot", "1ugnot"},
+		{"10atom,5ugnot", "3ugnot"},
+		{"10atom,5ugnot", "10atom,5ugnot"},
+		{"10atom,5ugnot", "1bar,1ugnot"},
+		{"3bar,10foo", "1bar,2baz,3foo"},
+		{"9223372036854775807ugnot", "1ugnot"},
+		{"1/gno.land/r/demo/foo:bar", "1/gno.land/r/demo/foo:bar,1ugnot"},
+	}
+	parses := []string{
+		"",
+		"1ugnot",
+		" 1ugnot ",
+		"1 ugnot",
+		"5ugnot,10atom",
+		"1ugnot,1ugnot",
+		"0ugnot",
+		"-1ugnot",
+		"1UGNOT",
+		"1ug",
+		"1u",
+		"1/gno.land/r/demo/foo:bar",
+		"ugnot",
+		"1ugnot,",
+		"99999999999999999999ugnot",
+		"1a-b",
+	}
+
+	var want strings.Builder
+	for _, p := range pairs {
+		a, b := std.MustParseCoins(p[0]), std.MustParseCoins(p[1])
+		fmt.Fprintln(&want,
+			goTry(func() string { return a.Add(b).String() }),
+			goTry(func() string { return a.Sub(b).String() }),
+			a.IsAllGT(b), a.IsAllGTE(b), a.IsAllLT(b), a.IsAllLTE(b),
+			a.IsAnyGT(b), a.IsAnyGTE(b), goIsEqual(a, b), a.DenomsSubsetOf(b),
+			a.IsZero(), a.IsAllPositive(), a.Empty(),
+		)
+	}
+	for _, s := range parses {
+		coins, err := std.ParseCoins(s)
+		if err != nil {
+			fmt.Fprintln(&want, "error")
+		} else {
+			fmt.Fprintln(&want, coins.String(), coins.IsValid())
+		}
+	}
+
+	quote := func(ss []string) string {
+		q := make([]string, len(ss))
+		for i, s := range ss {
+			q[i] = strconv.Quote(s)
+		}
+		return strings.Join(q, ", ")
+	}
+	pairStrs := make([]string, 0, len(pairs)*2)
+	for _, p := range pairs {
+		pairStrs = append(pairStrs, p[0], p[1])
+	}
+	program := `package main
+
+import "chain"
+
+func try(f func() string) (s string) {
+	defer func() {
+		if r := recover(); r != nil {
+			s = "panic"
+		}
+	}()
+	return f()
+}
+
+func main() {
+	pairs := []string{` + quote(pairStrs) + `}
+	for i := 0; i < len(pairs); i += 2 {
+		a, b := chain.MustParseCoins(pairs[i]), chain.MustParseCoins(pairs[i+1])
+		println(
+			try(func() string { return a.Add(b).String() }),
+			try(func() string { return a.Sub(b).String() }),
+			a.IsAllGT(b), a.IsAllGTE(b), a.IsAllLT(b), a.IsAllLTE(b),
+			a.IsAnyGT(b), a.IsAnyGTE(b), a.IsEqual(b), a.DenomsSubsetOf(b),
+			a.IsZero(), a.IsAllPositive(), a.Empty(),
+		)
+	}
+	for _, s := range []string{` + quote(parses) + `} {
+		coins, err := chain.ParseCoins(s)
+		if err != nil {
+			println("error")
+		} else {
+			println(coins.String(), coins.IsValid())
+		}
+	}
+}
+`
+
+	assert.Equal(t, want.String(), runGno(t, program))
+}
+
+func TestValidateDenomDifferential(t *testing.T) {
+	denoms := []string{
+		"ugnot", "atom", "foo", "fo", "f", "",
+		"UGNOT", "uGnot", "1foo", "_foo", "/foo", "f00",
+		"foo_bar", "foo.bar", "foo:bar", "foo/bar", "foo-bar", "foo bar",
+		"/gno.land/r/demo/foo:bar", "ibc/27394FB092D2ECCD56123C74F36E4C1F926001CEADA9CA97EA622B25F41E5EB2",
+	}
+
+	var want strings.Builder
+	for _, d := range denoms {
+		fmt.Fprintln(&want, std.ValidateDenom(d) == nil)
+	}
+
+	q := make([]string, len(denoms))
+	for i, d := range denoms {
+		q[i] = strconv.Quote(d)
+	}
+	program := `package main
+
+import "chain"
+
+func main() {
+	for _, d := range []string{` + strings.Join(q, ", ") + `} {
+		println(chain.ValidateDenom(d) == nil)
+	}
+}
+`
+
+	assert.Equal(t, want.String(), runGno(t, program))
+}
+
+func goTry(f func() string) (s string) {
+	defer func() {
+		if r := recover(); r != nil {
+			s = "panic"
+		}
+	}()
+	return f()
+}
+
+// goIsEqual is std.Coins.IsEqual, except that sets with different denoms
+// are unequal rather than making it panic, as in Gno.
+func goIsEqual(a, b std.Coins) (eq bool) {
+	defer func() { recover() }()
+	return a.IsEqual(b)
+}
+
+func runGno(t *testing.T, program string) string {
+	t.Helper()
+
+	rootDir, err := filepath.Abs("../../..")
+	require.NoError(t, err)
+
+	var buf bytes.Buffer
+	_, store := test.StoreWithOptions(rootDir, &buf, test.StoreOptions{})
+	m := gno.NewMachineWithOptions(gno.MachineOptions{
+		PkgPath: "gno.land/p/demo/main",
+		Store:   store,
+		Output:  &buf,
+	})
+	m.RunMemPackage(&std.MemPackage{
+		Type:  gno.MPUserProd,
+		Name:  "main",
+		Path:  "gno.land/p/demo/main",
+		Files: []*std.MemFile{{Name: "main.gno", Body: program}},
+	}, false)
+	m.RunMain()
+	return buf.String()
+}
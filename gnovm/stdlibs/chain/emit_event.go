@@ -29,7 +29,7 @@ func X_emit(m *gno.Machine, typ string, attrs []string) {
 		PkgPath:    pkgPath,
 	}
 
-	ctx.EventLogger.EmitEvent(evt)
+	ctx.EventManager.EmitEvent(evt)
 }
 
 // currentPkgPath retrieves the current package's pkgPath.
@@ -123,8 +123,8 @@ func TestEmit(t *testing.T) {
 
 	for _, tt := range tests {
 		t.Run(tt.name, func(t *testing.T) {
-			elgs := sdk.NewEventLogger()
-			m.Context = execctx.ExecContext{EventLogger: elgs}
+			elgs := sdk.NewEventManager()
+			m.Context = execctx.ExecContext{EventManager: elgs}
 
 			if tt.expectPanic {
 				assert.Panics(t, func() {
@@ -161,8 +161,8 @@ func TestEmit_MultipleEvents(t *testing.T) {
 	pushFuncFrame(m, "main")
 	pushFuncFrame(m, "Emit")
 
-	elgs := sdk.NewEventLogger()
-	m.Context = execctx.ExecContext{EventLogger: elgs}
+	elgs := sdk.NewEventManager()
+	m.Context = execctx.ExecContext{EventManager: elgs}
 
 	attrs1 := []string{"key1", "value1", "key2", "value2"}
 	attrs2 := []string{"key3", "value3", "key4", "value4"}
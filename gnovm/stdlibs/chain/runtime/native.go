@@ -33,6 +33,42 @@ func ChainHeight(m *gno.Machine) int64 {
 	return execctx.GetContext(m).Height
 }
 
+func X_pin(m *gno.Machine, obj gno.TypedValue) {
+	if oo := pinnableObject(m, obj); oo != nil {
+		m.Realm.Pin(oo)
+	}
+}
+
+func X_unpin(m *gno.Machine, obj gno.TypedValue) {
+	if oo := pinnableObject(m, obj); oo != nil {
+		m.Realm.Unpin(oo)
+	}
+}
+
+// pinnableObject returns the object of the current realm referenced by obj,
+// or panics and returns nil if there is none.
+func pinnableObject(m *gno.Machine, obj gno.TypedValue) gno.Object {
+	if m.Realm == nil {
+		m.Panic(typedString("cannot pin objects outside of a realm"))
+		return nil
+	}
+
+	var oo gno.Object
+	switch obj.V.(type) {
+	case gno.PointerValue, *gno.SliceValue, *gno.MapValue:
+		oo = obj.GetFirstObject(m.Store)
+	}
+	if oo == nil {
+		m.Panic(typedString("cannot pin " + obj.String() + ", expected a pointer, slice or map"))
+		return nil
+	}
+	if oo.GetIsReal() && oo.GetObjectID().PkgID != m.Realm.ID {
+		m.Panic(typedString("cannot pin an object of another realm"))
+		return nil
+	}
+	return oo
+}
+
 func X_originCaller(m *gno.Machine) string {
 	return string(execctx.GetContext(m).OriginCaller)
 }
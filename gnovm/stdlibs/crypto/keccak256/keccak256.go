@@ -0,0 +1,11 @@
+package keccak256
+
+import "golang.org/x/crypto/sha3"
+
+func X_sum(data []byte) [32]byte {
+	var sum [32]byte
+	h := sha3.NewLegacyKeccak256()
+	h.Write(data)
+	h.Sum(sum[:0])
+	return sum
+}
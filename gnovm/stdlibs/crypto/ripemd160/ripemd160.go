@@ -0,0 +1,11 @@
+package ripemd160
+
+import "golang.org/x/crypto/ripemd160" //nolint:staticcheck
+
+func X_sum(data []byte) [20]byte {
+	var sum [20]byte
+	h := ripemd160.New()
+	h.Write(data)
+	h.Sum(sum[:0])
+	return sum
+}
@@ -0,0 +1,24 @@
+package secp256k1
+
+import (
+	"github.com/decred/dcrd/dcrec/secp256k1/v4"
+	"github.com/decred/dcrd/dcrec/secp256k1/v4/ecdsa"
+)
+
+func X_verifyHash(publicKey []byte, hash []byte, signature []byte) bool {
+	if len(hash) != 32 || len(signature) != 64 {
+		return false
+	}
+	pub, err := secp256k1.ParsePubKey(publicKey)
+	if err != nil {
+		return false
+	}
+	var r, s secp256k1.ModNScalar
+	if r.SetByteSlice(signature[:32]) || s.SetByteSlice(signature[32:]) {
+		return false // overflow
+	}
+	if s.IsOverHalfOrder() {
+		return false // malleable
+	}
+	return ecdsa.NewSignature(&r, &s).Verify(hash, pub)
+}
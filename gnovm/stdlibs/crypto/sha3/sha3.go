@@ -0,0 +1,11 @@
+package sha3
+
+import "golang.org/x/crypto/sha3"
+
+func X_sum256(data []byte) [32]byte {
+	return sha3.Sum256(data)
+}
+
+func X_sum512(data []byte) [64]byte {
+	return sha3.Sum512(data)
+}
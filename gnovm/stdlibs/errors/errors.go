@@ -0,0 +1,62 @@
+package errors
+
+import (
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+func X_isComparable(v gno.TypedValue) bool {
+	return v.T == nil || isComparableType(v.T)
+}
+
+func isComparableType(t gno.Type) bool {
+	switch bt := gno.BaseOf(t).(type) {
+	case *gno.SliceType, *gno.FuncType, *gno.MapType:
+		return false
+	case *gno.ArrayType:
+		return isComparableType(bt.Elt)
+	case *gno.StructType:
+		for _, f := range bt.Fields {
+			if !isComparableType(f.Type) {
+				return false
+			}
+		}
+		return true
+	default:
+		return true
+	}
+}
+
+func X_checkAsTarget(m *gno.Machine, target gno.TypedValue) {
+	pt, ok := gno.BaseOf(target.T).(*gno.PointerType)
+	if !ok || target.V == nil {
+		m.Panic(typedString("errors: target must be a non-nil pointer"))
+		return
+	}
+	if pt.Elt.Kind() != gno.InterfaceKind && !gno.ImplementsError(pt.Elt) {
+		m.Panic(typedString("errors: *target must be interface or implement error"))
+	}
+}
+
+func X_assignTarget(m *gno.Machine, err, target gno.TypedValue) bool {
+	et := gno.BaseOf(target.T).(*gno.PointerType).Elt
+	if et.Kind() == gno.InterfaceKind {
+		if !gno.IsImplementedBy(et, err.T) {
+			return false
+		}
+	} else if err.T.TypeID() != et.TypeID() {
+		return false
+	}
+
+	if m.IsReadonly(&target) {
+		m.Panic(typedString("errors: cannot assign to readonly target of another realm"))
+		return false
+	}
+	target.V.(gno.PointerValue).Assign2(m.Alloc, m.Store, m.Realm, err, false)
+	return true
+}
+
+func typedString(s string) gno.TypedValue {
+	tv := gno.TypedValue{T: gno.StringType}
+	tv.SetString(gno.StringValue(s))
+	return tv
+}
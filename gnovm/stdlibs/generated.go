@@ -12,9 +12,19 @@ import (
 	libs_chain_params "github.com/gnolang/gno/gnovm/stdlibs/chain/params"
 	libs_chain_runtime "github.com/gnolang/gno/gnovm/stdlibs/chain/runtime"
 	libs_crypto_ed25519 "github.com/gnolang/gno/gnovm/stdlibs/crypto/ed25519"
+	libs_crypto_keccak256 "github.com/gnolang/gno/gnovm/stdlibs/crypto/keccak256"
+	libs_crypto_ripemd160 "github.com/gnolang/gno/gnovm/stdlibs/crypto/ripemd160"
+	libs_crypto_secp256k1 "github.com/gnolang/gno/gnovm/stdlibs/crypto/secp256k1"
 	libs_crypto_sha256 "github.com/gnolang/gno/gnovm/stdlibs/crypto/sha256"
+	libs_crypto_sha3 "github.com/gnolang/gno/gnovm/stdlibs/crypto/sha3"
+	libs_errors "github.com/gnolang/gno/gnovm/stdlibs/errors"
 	libs_math "github.com/gnolang/gno/gnovm/stdlibs/math"
+	libs_math_big "github.com/gnolang/gno/gnovm/stdlibs/math/big"
+	libs_math_decimal "github.com/gnolang/gno/gnovm/stdlibs/math/decimal"
+	libs_math_uint256 "github.com/gnolang/gno/gnovm/stdlibs/math/uint256"
+	libs_reflect "github.com/gnolang/gno/gnovm/stdlibs/reflect"
 	libs_runtime "github.com/gnolang/gno/gnovm/stdlibs/runtime"
+	libs_sort "github.com/gnolang/gno/gnovm/stdlibs/sort"
 	libs_sys_params "github.com/gnolang/gno/gnovm/stdlibs/sys/params"
 	libs_time "github.com/gnolang/gno/gnovm/stdlibs/time"
 )
@@ -27,6 +37,7 @@ type NativeFunc struct {
 	params     []gno.FieldTypeExpr
 	results    []gno.FieldTypeExpr
 	hasMachine bool
+	typedValue bool
 	f          func(m *gno.Machine)
 }
 
@@ -37,6 +48,13 @@ func (n *NativeFunc) HasMachineParam() bool {
 	return n.hasMachine
 }
 
+// HasTypedValue returns whether any of the parameters or results of the Go
+// version of this function is a gno.TypedValue, which Go code can't provide
+// or use without a Gno machine.
+func (n *NativeFunc) HasTypedValue() bool {
+	return n.typedValue
+}
+
 var nativeFuncs = [...]NativeFunc{
 	{
 		"chain",
@@ -48,6 +66,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("string")},
 		},
 		false,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -78,6 +97,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("string")},
 		},
 		false,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -107,6 +127,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -140,6 +161,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r1"), Type: gno.X("[]int64")},
 		},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -184,6 +206,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -231,6 +254,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("int64")},
 		},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -269,6 +293,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -311,6 +336,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -342,12 +368,168 @@ var nativeFuncs = [...]NativeFunc{
 				p0, p1, p2, p3)
 		},
 	},
+	{
+		"chain/banker",
+		"bankerApprove",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("uint8")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("string")},
+			{NameExpr: *gno.Nx("p2"), Type: gno.X("string")},
+			{NameExpr: *gno.Nx("p3"), Type: gno.X("[]string")},
+			{NameExpr: *gno.Nx("p4"), Type: gno.X("[]int64")},
+		},
+		[]gno.FieldTypeExpr{},
+		true,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  uint8
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  string
+				rp1 = reflect.ValueOf(&p1).Elem()
+				p2  string
+				rp2 = reflect.ValueOf(&p2).Elem()
+				p3  []string
+				rp3 = reflect.ValueOf(&p3).Elem()
+				p4  []int64
+				rp4 = reflect.ValueOf(&p4).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+			tv2 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 2, "")).TV
+			tv2.DeepFill(m.Store)
+			gno.Gno2GoValue(tv2, rp2)
+			tv3 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 3, "")).TV
+			tv3.DeepFill(m.Store)
+			gno.Gno2GoValue(tv3, rp3)
+			tv4 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 4, "")).TV
+			tv4.DeepFill(m.Store)
+			gno.Gno2GoValue(tv4, rp4)
+
+			libs_chain_banker.X_bankerApprove(
+				m,
+				p0, p1, p2, p3, p4)
+		},
+	},
+	{
+		"chain/banker",
+		"bankerAllowance",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("uint8")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("string")},
+			{NameExpr: *gno.Nx("p2"), Type: gno.X("string")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[]string")},
+			{NameExpr: *gno.Nx("r1"), Type: gno.X("[]int64")},
+		},
+		true,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  uint8
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  string
+				rp1 = reflect.ValueOf(&p1).Elem()
+				p2  string
+				rp2 = reflect.ValueOf(&p2).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+			tv2 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 2, "")).TV
+			tv2.DeepFill(m.Store)
+			gno.Gno2GoValue(tv2, rp2)
+
+			r0, r1 := libs_chain_banker.X_bankerAllowance(
+				m,
+				p0, p1, p2)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r1).Elem(),
+			))
+		},
+	},
+	{
+		"chain/banker",
+		"bankerTransferFrom",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("uint8")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("string")},
+			{NameExpr: *gno.Nx("p2"), Type: gno.X("string")},
+			{NameExpr: *gno.Nx("p3"), Type: gno.X("string")},
+			{NameExpr: *gno.Nx("p4"), Type: gno.X("[]string")},
+			{NameExpr: *gno.Nx("p5"), Type: gno.X("[]int64")},
+		},
+		[]gno.FieldTypeExpr{},
+		true,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  uint8
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  string
+				rp1 = reflect.ValueOf(&p1).Elem()
+				p2  string
+				rp2 = reflect.ValueOf(&p2).Elem()
+				p3  string
+				rp3 = reflect.ValueOf(&p3).Elem()
+				p4  []string
+				rp4 = reflect.ValueOf(&p4).Elem()
+				p5  []int64
+				rp5 = reflect.ValueOf(&p5).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+			tv2 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 2, "")).TV
+			tv2.DeepFill(m.Store)
+			gno.Gno2GoValue(tv2, rp2)
+			tv3 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 3, "")).TV
+			tv3.DeepFill(m.Store)
+			gno.Gno2GoValue(tv3, rp3)
+			tv4 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 4, "")).TV
+			tv4.DeepFill(m.Store)
+			gno.Gno2GoValue(tv4, rp4)
+			tv5 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 5, "")).TV
+			tv5.DeepFill(m.Store)
+			gno.Gno2GoValue(tv5, rp5)
+
+			libs_chain_banker.X_bankerTransferFrom(
+				m,
+				p0, p1, p2, p3, p4, p5)
+		},
+	},
 	{
 		"chain/banker",
 		"assertCallerIsRealm",
 		[]gno.FieldTypeExpr{},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			libs_chain_banker.X_assertCallerIsRealm(
 				m,
@@ -363,6 +545,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r1"), Type: gno.X("[]int64")},
 		},
 		true,
+		false,
 		func(m *gno.Machine) {
 			r0, r1 := libs_chain_banker.X_originSend(
 				m,
@@ -389,6 +572,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -419,6 +603,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -449,6 +634,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -479,6 +665,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -509,6 +696,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -539,6 +727,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -570,6 +759,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -602,6 +792,7 @@ var nativeFuncs = [...]NativeFunc{
 		[]gno.FieldTypeExpr{},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			libs_chain_runtime.AssertOriginCall(
 				m,
@@ -616,6 +807,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("string")},
 		},
 		true,
+		false,
 		func(m *gno.Machine) {
 			r0 := libs_chain_runtime.ChainID(
 				m,
@@ -636,6 +828,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("string")},
 		},
 		true,
+		false,
 		func(m *gno.Machine) {
 			r0 := libs_chain_runtime.ChainDomain(
 				m,
@@ -656,6 +849,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("int64")},
 		},
 		true,
+		false,
 		func(m *gno.Machine) {
 			r0 := libs_chain_runtime.ChainHeight(
 				m,
@@ -668,6 +862,42 @@ var nativeFuncs = [...]NativeFunc{
 			))
 		},
 	},
+	{
+		"chain/runtime",
+		"pin",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			libs_chain_runtime.X_pin(
+				m,
+				p0)
+		},
+	},
+	{
+		"chain/runtime",
+		"unpin",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			libs_chain_runtime.X_unpin(
+				m,
+				p0)
+		},
+	},
 	{
 		"chain/runtime",
 		"originCaller",
@@ -676,6 +906,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("string")},
 		},
 		true,
+		false,
 		func(m *gno.Machine) {
 			r0 := libs_chain_runtime.X_originCaller(
 				m,
@@ -699,6 +930,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r1"), Type: gno.X("string")},
 		},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -738,6 +970,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("bool")},
 		},
 		false,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -769,8 +1002,8 @@ var nativeFuncs = [...]NativeFunc{
 		},
 	},
 	{
-		"crypto/sha256",
-		"sum256",
+		"crypto/keccak256",
+		"sum",
 		[]gno.FieldTypeExpr{
 			{NameExpr: *gno.Nx("p0"), Type: gno.X("[]byte")},
 		},
@@ -778,6 +1011,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("[32]byte")},
 		},
 		false,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -789,7 +1023,7 @@ var nativeFuncs = [...]NativeFunc{
 			tv0.DeepFill(m.Store)
 			gno.Gno2GoValue(tv0, rp0)
 
-			r0 := libs_crypto_sha256.X_sum256(p0)
+			r0 := libs_crypto_keccak256.X_sum(p0)
 
 			m.PushValue(gno.Go2GnoValue(
 				m.Alloc,
@@ -799,19 +1033,20 @@ var nativeFuncs = [...]NativeFunc{
 		},
 	},
 	{
-		"math",
-		"Float32bits",
+		"crypto/ripemd160",
+		"sum",
 		[]gno.FieldTypeExpr{
-			{NameExpr: *gno.Nx("p0"), Type: gno.X("float32")},
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[]byte")},
 		},
 		[]gno.FieldTypeExpr{
-			{NameExpr: *gno.Nx("r0"), Type: gno.X("uint32")},
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[20]byte")},
 		},
 		false,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
-				p0  float32
+				p0  []byte
 				rp0 = reflect.ValueOf(&p0).Elem()
 			)
 
@@ -819,7 +1054,7 @@ var nativeFuncs = [...]NativeFunc{
 			tv0.DeepFill(m.Store)
 			gno.Gno2GoValue(tv0, rp0)
 
-			r0 := libs_math.Float32bits(p0)
+			r0 := libs_crypto_ripemd160.X_sum(p0)
 
 			m.PushValue(gno.Go2GnoValue(
 				m.Alloc,
@@ -829,27 +1064,40 @@ var nativeFuncs = [...]NativeFunc{
 		},
 	},
 	{
-		"math",
-		"Float32frombits",
+		"crypto/secp256k1",
+		"verifyHash",
 		[]gno.FieldTypeExpr{
-			{NameExpr: *gno.Nx("p0"), Type: gno.X("uint32")},
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("p2"), Type: gno.X("[]byte")},
 		},
 		[]gno.FieldTypeExpr{
-			{NameExpr: *gno.Nx("r0"), Type: gno.X("float32")},
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("bool")},
 		},
 		false,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
-				p0  uint32
+				p0  []byte
 				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  []byte
+				rp1 = reflect.ValueOf(&p1).Elem()
+				p2  []byte
+				rp2 = reflect.ValueOf(&p2).Elem()
 			)
 
 			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
 			tv0.DeepFill(m.Store)
 			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+			tv2 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 2, "")).TV
+			tv2.DeepFill(m.Store)
+			gno.Gno2GoValue(tv2, rp2)
 
-			r0 := libs_math.Float32frombits(p0)
+			r0 := libs_crypto_secp256k1.X_verifyHash(p0, p1, p2)
 
 			m.PushValue(gno.Go2GnoValue(
 				m.Alloc,
@@ -859,19 +1107,20 @@ var nativeFuncs = [...]NativeFunc{
 		},
 	},
 	{
-		"math",
-		"Float64bits",
+		"crypto/sha256",
+		"sum256",
 		[]gno.FieldTypeExpr{
-			{NameExpr: *gno.Nx("p0"), Type: gno.X("float64")},
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[]byte")},
 		},
 		[]gno.FieldTypeExpr{
-			{NameExpr: *gno.Nx("r0"), Type: gno.X("uint64")},
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[32]byte")},
 		},
 		false,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
-				p0  float64
+				p0  []byte
 				rp0 = reflect.ValueOf(&p0).Elem()
 			)
 
@@ -879,7 +1128,7 @@ var nativeFuncs = [...]NativeFunc{
 			tv0.DeepFill(m.Store)
 			gno.Gno2GoValue(tv0, rp0)
 
-			r0 := libs_math.Float64bits(p0)
+			r0 := libs_crypto_sha256.X_sum256(p0)
 
 			m.PushValue(gno.Go2GnoValue(
 				m.Alloc,
@@ -889,19 +1138,20 @@ var nativeFuncs = [...]NativeFunc{
 		},
 	},
 	{
-		"math",
-		"Float64frombits",
+		"crypto/sha3",
+		"sum256",
 		[]gno.FieldTypeExpr{
-			{NameExpr: *gno.Nx("p0"), Type: gno.X("uint64")},
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[]byte")},
 		},
 		[]gno.FieldTypeExpr{
-			{NameExpr: *gno.Nx("r0"), Type: gno.X("float64")},
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[32]byte")},
 		},
 		false,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
-				p0  uint64
+				p0  []byte
 				rp0 = reflect.ValueOf(&p0).Elem()
 			)
 
@@ -909,7 +1159,7 @@ var nativeFuncs = [...]NativeFunc{
 			tv0.DeepFill(m.Store)
 			gno.Gno2GoValue(tv0, rp0)
 
-			r0 := libs_math.Float64frombits(p0)
+			r0 := libs_crypto_sha3.X_sum256(p0)
 
 			m.PushValue(gno.Go2GnoValue(
 				m.Alloc,
@@ -919,11 +1169,2165 @@ var nativeFuncs = [...]NativeFunc{
 		},
 	},
 	{
-		"runtime",
-		"GC",
-		[]gno.FieldTypeExpr{},
+		"crypto/sha3",
+		"sum512",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[]byte")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[64]byte")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  []byte
+				rp0 = reflect.ValueOf(&p0).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+
+			r0 := libs_crypto_sha3.X_sum512(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"errors",
+		"isComparable",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("bool")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_errors.X_isComparable(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"errors",
+		"checkAsTarget",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			libs_errors.X_checkAsTarget(
+				m,
+				p0)
+		},
+	},
+	{
+		"errors",
+		"assignTarget",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("error")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("bool")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0 = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1 = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV)
+			)
+
+			r0 := libs_errors.X_assignTarget(
+				m,
+				p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math",
+		"Float32bits",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("float32")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("uint32")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  float32
+				rp0 = reflect.ValueOf(&p0).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+
+			r0 := libs_math.Float32bits(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math",
+		"Float32frombits",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("uint32")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("float32")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  uint32
+				rp0 = reflect.ValueOf(&p0).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+
+			r0 := libs_math.Float32frombits(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math",
+		"Float64bits",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("float64")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("uint64")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  float64
+				rp0 = reflect.ValueOf(&p0).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+
+			r0 := libs_math.Float64bits(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math",
+		"Float64frombits",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("uint64")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("float64")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  uint64
+				rp0 = reflect.ValueOf(&p0).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+
+			r0 := libs_math.Float64frombits(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math/big",
+		"addAbs",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("[]byte")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[]byte")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  []byte
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  []byte
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0 := libs_math_big.X_addAbs(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math/big",
+		"subAbs",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("[]byte")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[]byte")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  []byte
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  []byte
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0 := libs_math_big.X_subAbs(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math/big",
+		"mulAbs",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("[]byte")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[]byte")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  []byte
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  []byte
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0 := libs_math_big.X_mulAbs(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math/big",
+		"mulMod",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("p2"), Type: gno.X("[]byte")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[]byte")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  []byte
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  []byte
+				rp1 = reflect.ValueOf(&p1).Elem()
+				p2  []byte
+				rp2 = reflect.ValueOf(&p2).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+			tv2 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 2, "")).TV
+			tv2.DeepFill(m.Store)
+			gno.Gno2GoValue(tv2, rp2)
+
+			r0 := libs_math_big.X_mulMod(p0, p1, p2)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math/big",
+		"quoRemAbs",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("[]byte")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("r1"), Type: gno.X("[]byte")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  []byte
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  []byte
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0, r1 := libs_math_big.X_quoRemAbs(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r1).Elem(),
+			))
+		},
+	},
+	{
+		"math/big",
+		"sqrtAbs",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[]byte")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[]byte")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  []byte
+				rp0 = reflect.ValueOf(&p0).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+
+			r0 := libs_math_big.X_sqrtAbs(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math/big",
+		"lshAbs",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("uint")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[]byte")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  []byte
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  uint
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0 := libs_math_big.X_lshAbs(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math/big",
+		"rshAbs",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("uint")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[]byte")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  []byte
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  uint
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0 := libs_math_big.X_rshAbs(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math/big",
+		"parse",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("string")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("int")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("bool")},
+			{NameExpr: *gno.Nx("r1"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("r2"), Type: gno.X("bool")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  string
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  int
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0, r1, r2 := libs_math_big.X_parse(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r1).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r2).Elem(),
+			))
+		},
+	},
+	{
+		"math/big",
+		"format",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("int")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("string")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  []byte
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  int
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0 := libs_math_big.X_format(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math/decimal",
+		"quoRound",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("bool")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("p2"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("p3"), Type: gno.X("int")},
+			{NameExpr: *gno.Nx("p4"), Type: gno.X("uint8")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[]byte")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  bool
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  []byte
+				rp1 = reflect.ValueOf(&p1).Elem()
+				p2  []byte
+				rp2 = reflect.ValueOf(&p2).Elem()
+				p3  int
+				rp3 = reflect.ValueOf(&p3).Elem()
+				p4  uint8
+				rp4 = reflect.ValueOf(&p4).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+			tv2 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 2, "")).TV
+			tv2.DeepFill(m.Store)
+			gno.Gno2GoValue(tv2, rp2)
+			tv3 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 3, "")).TV
+			tv3.DeepFill(m.Store)
+			gno.Gno2GoValue(tv3, rp3)
+			tv4 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 4, "")).TV
+			tv4.DeepFill(m.Store)
+			gno.Gno2GoValue(tv4, rp4)
+
+			r0 := libs_math_decimal.X_quoRound(p0, p1, p2, p3, p4)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math/decimal",
+		"parse",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("string")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("bool")},
+			{NameExpr: *gno.Nx("r1"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("r2"), Type: gno.X("int")},
+			{NameExpr: *gno.Nx("r3"), Type: gno.X("bool")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  string
+				rp0 = reflect.ValueOf(&p0).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+
+			r0, r1, r2, r3 := libs_math_decimal.X_parse(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r1).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r2).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r3).Elem(),
+			))
+		},
+	},
+	{
+		"math/decimal",
+		"format",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("bool")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("[]byte")},
+			{NameExpr: *gno.Nx("p2"), Type: gno.X("int")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("string")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  bool
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  []byte
+				rp1 = reflect.ValueOf(&p1).Elem()
+				p2  int
+				rp2 = reflect.ValueOf(&p2).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+			tv2 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 2, "")).TV
+			tv2.DeepFill(m.Store)
+			gno.Gno2GoValue(tv2, rp2)
+
+			r0 := libs_math_decimal.X_format(p0, p1, p2)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math/uint256",
+		"add",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("[4]uint64")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("r1"), Type: gno.X("bool")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  [4]uint64
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  [4]uint64
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0, r1 := libs_math_uint256.X_add(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r1).Elem(),
+			))
+		},
+	},
+	{
+		"math/uint256",
+		"sub",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("[4]uint64")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("r1"), Type: gno.X("bool")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  [4]uint64
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  [4]uint64
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0, r1 := libs_math_uint256.X_sub(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r1).Elem(),
+			))
+		},
+	},
+	{
+		"math/uint256",
+		"mul",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("[4]uint64")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("r1"), Type: gno.X("bool")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  [4]uint64
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  [4]uint64
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0, r1 := libs_math_uint256.X_mul(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r1).Elem(),
+			))
+		},
+	},
+	{
+		"math/uint256",
+		"divMod",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("[4]uint64")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("r1"), Type: gno.X("[4]uint64")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  [4]uint64
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  [4]uint64
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0, r1 := libs_math_uint256.X_divMod(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r1).Elem(),
+			))
+		},
+	},
+	{
+		"math/uint256",
+		"mulDiv",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("p2"), Type: gno.X("[4]uint64")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("r1"), Type: gno.X("bool")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  [4]uint64
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  [4]uint64
+				rp1 = reflect.ValueOf(&p1).Elem()
+				p2  [4]uint64
+				rp2 = reflect.ValueOf(&p2).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+			tv2 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 2, "")).TV
+			tv2.DeepFill(m.Store)
+			gno.Gno2GoValue(tv2, rp2)
+
+			r0, r1 := libs_math_uint256.X_mulDiv(p0, p1, p2)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r1).Elem(),
+			))
+		},
+	},
+	{
+		"math/uint256",
+		"exp",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("[4]uint64")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[4]uint64")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  [4]uint64
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  [4]uint64
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0 := libs_math_uint256.X_exp(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math/uint256",
+		"lsh",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("uint")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[4]uint64")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  [4]uint64
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  uint
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0 := libs_math_uint256.X_lsh(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math/uint256",
+		"rsh",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("uint")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[4]uint64")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  [4]uint64
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  uint
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0 := libs_math_uint256.X_rsh(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"math/uint256",
+		"parse",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("string")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("int")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("r1"), Type: gno.X("bool")},
+			{NameExpr: *gno.Nx("r2"), Type: gno.X("bool")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  string
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  int
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0, r1, r2 := libs_math_uint256.X_parse(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r1).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r2).Elem(),
+			))
+		},
+	},
+	{
+		"math/uint256",
+		"format",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("[4]uint64")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("int")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("string")},
+		},
+		false,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  [4]uint64
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  int
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0 := libs_math_uint256.X_format(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"typeOf",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("any")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_typeOf(
+				m,
+				p0)
+
+			m.PushValue(r0)
+		},
+	},
+	{
+		"reflect",
+		"typeKind",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("int")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_typeKind(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"typeName",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("string")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_typeName(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"typePkgPath",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("string")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_typePkgPath(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"typeString",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("string")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_typeString(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"typeElem",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("any")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_typeElem(
+				m,
+				p0)
+
+			m.PushValue(r0)
+		},
+	},
+	{
+		"reflect",
+		"typeKey",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("any")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_typeKey(
+				m,
+				p0)
+
+			m.PushValue(r0)
+		},
+	},
+	{
+		"reflect",
+		"typeLen",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("int")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_typeLen(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"typeNumField",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("int")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_typeNumField(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"typeField",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("int")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("string")},
+			{NameExpr: *gno.Nx("r1"), Type: gno.X("string")},
+			{NameExpr: *gno.Nx("r2"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("r3"), Type: gno.X("string")},
+			{NameExpr: *gno.Nx("r4"), Type: gno.X("bool")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1  int
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0, r1, r2, r3, r4 := libs_reflect.X_typeField(
+				m,
+				p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r1).Elem(),
+			))
+			m.PushValue(r2)
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r3).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r4).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"typeImplements",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("bool")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0 = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1 = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV)
+			)
+
+			r0 := libs_reflect.X_typeImplements(p0, p1)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"valueOf",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("any")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_valueOf(
+				m,
+				p0)
+
+			m.PushValue(r0)
+		},
+	},
+	{
+		"reflect",
+		"valueType",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("any")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_valueType(p0)
+
+			m.PushValue(r0)
+		},
+	},
+	{
+		"reflect",
+		"load",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("any")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_load(
+				m,
+				p0)
+
+			m.PushValue(r0)
+		},
+	},
+	{
+		"reflect",
+		"loadBool",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("bool")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_loadBool(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"loadInt",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("int64")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_loadInt(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"loadUint",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("uint64")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_loadUint(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"loadFloat",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("float64")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_loadFloat(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"loadString",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("string")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_loadString(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"isNil",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("bool")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_isNil(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"isZero",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("bool")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_isZero(
+				m,
+				p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"valueLen",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("int")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_valueLen(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"valueCap",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("int")},
+		},
+		false,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_valueCap(p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"reflect",
+		"index",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("int")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("any")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1  int
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0 := libs_reflect.X_index(
+				m,
+				p0, p1)
+
+			m.PushValue(r0)
+		},
+	},
+	{
+		"reflect",
+		"field",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("int")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("any")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1  int
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			r0 := libs_reflect.X_field(
+				m,
+				p0, p1)
+
+			m.PushValue(r0)
+		},
+	},
+	{
+		"reflect",
+		"mapKeys",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("[]any")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_mapKeys(
+				m,
+				p0)
+
+			m.PushValue(r0)
+		},
+	},
+	{
+		"reflect",
+		"mapIndex",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("any")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0 = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1 = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV)
+			)
+
+			r0 := libs_reflect.X_mapIndex(
+				m,
+				p0, p1)
+
+			m.PushValue(r0)
+		},
+	},
+	{
+		"reflect",
+		"store",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0 = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1 = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV)
+			)
+
+			libs_reflect.X_store(
+				m,
+				p0, p1)
+		},
+	},
+	{
+		"reflect",
+		"storeBool",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("bool")},
+		},
+		[]gno.FieldTypeExpr{},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1  bool
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			libs_reflect.X_storeBool(
+				m,
+				p0, p1)
+		},
+	},
+	{
+		"reflect",
+		"storeInt",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("int64")},
+		},
+		[]gno.FieldTypeExpr{},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1  int64
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			libs_reflect.X_storeInt(
+				m,
+				p0, p1)
+		},
+	},
+	{
+		"reflect",
+		"storeUint",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("uint64")},
+		},
+		[]gno.FieldTypeExpr{},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1  uint64
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			libs_reflect.X_storeUint(
+				m,
+				p0, p1)
+		},
+	},
+	{
+		"reflect",
+		"storeFloat",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("float64")},
+		},
+		[]gno.FieldTypeExpr{},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1  float64
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			libs_reflect.X_storeFloat(
+				m,
+				p0, p1)
+		},
+	},
+	{
+		"reflect",
+		"storeString",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("string")},
+		},
+		[]gno.FieldTypeExpr{},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1  string
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			libs_reflect.X_storeString(
+				m,
+				p0, p1)
+		},
+	},
+	{
+		"reflect",
+		"newValue",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("any")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_newValue(
+				m,
+				p0)
+
+			m.PushValue(r0)
+		},
+	},
+	{
+		"reflect",
+		"makeSlice",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("int")},
+			{NameExpr: *gno.Nx("p2"), Type: gno.X("int")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("any")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1  int
+				rp1 = reflect.ValueOf(&p1).Elem()
+				p2  int
+				rp2 = reflect.ValueOf(&p2).Elem()
+			)
+
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+			tv2 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 2, "")).TV
+			tv2.DeepFill(m.Store)
+			gno.Gno2GoValue(tv2, rp2)
+
+			r0 := libs_reflect.X_makeSlice(
+				m,
+				p0, p1, p2)
+
+			m.PushValue(r0)
+		},
+	},
+	{
+		"reflect",
+		"makeMap",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("any")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_reflect.X_makeMap(
+				m,
+				p0)
+
+			m.PushValue(r0)
+		},
+	},
+	{
+		"reflect",
+		"appendValue",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("any")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0 = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1 = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV)
+			)
+
+			r0 := libs_reflect.X_appendValue(
+				m,
+				p0, p1)
+
+			m.PushValue(r0)
+		},
+	},
+	{
+		"reflect",
+		"setMapIndex",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p2"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0 = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1 = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV)
+				p2 = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 2, "")).TV)
+			)
+
+			libs_reflect.X_setMapIndex(
+				m,
+				p0, p1, p2)
+		},
+	},
+	{
+		"runtime",
+		"GC",
+		[]gno.FieldTypeExpr{},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			libs_runtime.GC(
 				m,
@@ -938,6 +3342,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("string")},
 		},
 		true,
+		false,
 		func(m *gno.Machine) {
 			r0 := libs_runtime.MemStats(
 				m,
@@ -950,6 +3355,65 @@ var nativeFuncs = [...]NativeFunc{
 			))
 		},
 	},
+	{
+		"sort",
+		"sliceLen",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+		},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("int")},
+		},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+
+			r0 := libs_sort.X_sliceLen(
+				m,
+				p0)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+		},
+	},
+	{
+		"sort",
+		"sliceSwap",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("any")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("int")},
+			{NameExpr: *gno.Nx("p2"), Type: gno.X("int")},
+		},
+		[]gno.FieldTypeExpr{},
+		true,
+		true,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  = *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
+				p1  int
+				rp1 = reflect.ValueOf(&p1).Elem()
+				p2  int
+				rp2 = reflect.ValueOf(&p2).Elem()
+			)
+
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+			tv2 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 2, "")).TV
+			tv2.DeepFill(m.Store)
+			gno.Gno2GoValue(tv2, rp2)
+
+			libs_sort.X_sliceSwap(
+				m,
+				p0, p1, p2)
+		},
+	},
 	{
 		"sys/params",
 		"setSysParamString",
@@ -961,6 +3425,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -1003,6 +3468,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -1045,6 +3511,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -1087,6 +3554,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -1129,6 +3597,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -1171,6 +3640,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -1214,6 +3684,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -1260,6 +3731,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r2"), Type: gno.X("int64")},
 		},
 		true,
+		false,
 		func(m *gno.Machine) {
 			r0, r1, r2 := libs_time.X_now(
 				m,
@@ -1293,6 +3765,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r1"), Type: gno.X("bool")},
 		},
 		false,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -1330,6 +3803,7 @@ var initOrder = [...]string{
 	"strings",
 	"bufio",
 	"math/overflow",
+	"sort",
 	"math/bits",
 	"math",
 	"strconv",
@@ -1344,26 +3818,34 @@ var initOrder = [...]string{
 	"crypto/chacha20",
 	"crypto/chacha20/rand",
 	"crypto/ed25519",
+	"crypto/keccak256",
+	"crypto/ripemd160",
 	"crypto/sha256",
+	"crypto/secp256k1",
+	"crypto/sha3",
 	"crypto/subtle",
 	"encoding",
 	"encoding/base32",
 	"encoding/base64",
 	"encoding/csv",
 	"encoding/hex",
+	"reflect",
+	"unicode/utf16",
+	"encoding/json",
 	"hash",
 	"hash/adler32",
 	"html",
+	"math/big",
+	"math/decimal",
 	"math/rand",
+	"math/uint256",
 	"path",
-	"sort",
 	"net/url",
 	"regexp/syntax",
 	"regexp",
 	"runtime",
 	"sys/params",
 	"time",
-	"unicode/utf16",
 }
 
 // InitOrder returns the initialization order of the standard libraries.
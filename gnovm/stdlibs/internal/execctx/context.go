@@ -1,6 +1,8 @@
 package execctx
 
 import (
+	"slices"
+
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
@@ -13,6 +15,10 @@ type BankerInterface interface {
 	TotalCoin(denom string) int64
 	IssueCoin(addr crypto.Bech32Address, denom string, amount int64)
 	RemoveCoin(addr crypto.Bech32Address, denom string, amount int64)
+	// Approve sets the amount that spender may send from owner; an empty
+	// amount removes the allowance.
+	Approve(owner, spender crypto.Bech32Address, amt std.Coins)
+	Allowance(owner, spender crypto.Bech32Address) std.Coins
 }
 
 type ParamsInterface interface {
@@ -30,13 +36,13 @@ type ExecContext struct {
 	ChainDomain     string
 	Height          int64
 	Timestamp       int64 // seconds
-	TimestampNano   int64 // nanoseconds, only used for testing.
+	TimestampNano   int64 // nanoseconds within the second
 	OriginCaller    crypto.Bech32Address
 	OriginSend      std.Coins
 	OriginSendSpent *std.Coins // mutable
 	Banker          BankerInterface
 	Params          ParamsInterface
-	EventLogger     *sdk.EventLogger
+	EventManager    *sdk.EventManager
 }
 
 // GetContext returns the execution context.
@@ -48,6 +54,50 @@ func (e ExecContext) GetExecContext() ExecContext {
 
 var _ ExecContexter = ExecContext{}
 
+var _ gno.CallCacheWrapper = ExecContext{}
+
+// CacheWrapCall implements [gno.CallCacheWrapper], so that a panic leaving a
+// call crossing a realm boundary discards its updates of the context: those
+// of the Banker and the Params if they implement it too, the coins of
+// OriginSend it spent, and the events it emitted.
+func (e ExecContext) CacheWrapCall() (write, discard func()) {
+	var writes, discards []func()
+	for _, x := range []any{e.Banker, e.Params} {
+		if cw, ok := x.(gno.CallCacheWrapper); ok {
+			w, d := cw.CacheWrapCall()
+			writes, discards = append(writes, w), append(discards, d)
+		}
+	}
+	var spent std.Coins
+	if e.OriginSendSpent != nil {
+		spent = slices.Clone(*e.OriginSendSpent)
+	}
+	var numEvents int
+	if e.EventManager != nil {
+		numEvents = len(e.EventManager.Events())
+	}
+
+	write = func() {
+		for i := len(writes) - 1; i >= 0; i-- {
+			writes[i]()
+		}
+	}
+	discard = func() {
+		for i := len(discards) - 1; i >= 0; i-- {
+			discards[i]()
+		}
+		if e.OriginSendSpent != nil {
+			*e.OriginSendSpent = spent
+		}
+		if e.EventManager != nil {
+			events := slices.Clone(e.EventManager.Events()[:numEvents])
+			*e.EventManager = *sdk.NewEventManager()
+			e.EventManager.EmitEvents(events)
+		}
+	}
+	return write, discard
+}
+
 // ExecContexter is a type capable of returning the parent [ExecContext]. When
 // using these standard libraries, m.Context should always implement this
 // interface. This can be obtained by embedding [ExecContext].
@@ -0,0 +1,58 @@
+package big
+
+import "math/big"
+
+// The magnitudes are passed as big-endian bytes, without leading zeros.
+
+func X_addAbs(x, y []byte) []byte {
+	return new(big.Int).Add(toBig(x), toBig(y)).Bytes()
+}
+
+// X_subAbs returns x-y, with x >= y.
+func X_subAbs(x, y []byte) []byte {
+	return new(big.Int).Sub(toBig(x), toBig(y)).Bytes()
+}
+
+func X_mulAbs(x, y []byte) []byte {
+	return new(big.Int).Mul(toBig(x), toBig(y)).Bytes()
+}
+
+// X_mulMod returns x*y mod m, with m > 0.
+func X_mulMod(x, y, m []byte) []byte {
+	p := new(big.Int).Mul(toBig(x), toBig(y))
+	return p.Mod(p, toBig(m)).Bytes()
+}
+
+// X_quoRemAbs returns x/y and x%y, with y > 0.
+func X_quoRemAbs(x, y []byte) (q, r []byte) {
+	bq, br := new(big.Int).QuoRem(toBig(x), toBig(y), new(big.Int))
+	return bq.Bytes(), br.Bytes()
+}
+
+func X_sqrtAbs(x []byte) []byte {
+	return new(big.Int).Sqrt(toBig(x)).Bytes()
+}
+
+func X_lshAbs(x []byte, n uint) []byte {
+	return new(big.Int).Lsh(toBig(x), n).Bytes()
+}
+
+func X_rshAbs(x []byte, n uint) []byte {
+	return new(big.Int).Rsh(toBig(x), n).Bytes()
+}
+
+func X_parse(s string, base int) (neg bool, abs []byte, ok bool) {
+	b, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		return false, nil, false
+	}
+	return b.Sign() < 0, b.Bytes(), true
+}
+
+func X_format(abs []byte, base int) string {
+	return toBig(abs).Text(base)
+}
+
+func toBig(abs []byte) *big.Int {
+	return new(big.Int).SetBytes(abs)
+}
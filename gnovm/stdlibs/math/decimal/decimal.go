@@ -0,0 +1,116 @@
+package decimal
+
+import "math/big"
+
+// The coefficients are passed as their absolute value, in big-endian bytes
+// without leading zeros, and their sign.
+
+// The rounding modes, as declared in decimal.gno.
+const (
+	toNearestEven uint8 = iota
+	toNearestAway
+	toZero
+	awayFromZero
+	toNegativeInf
+	toPositiveInf
+)
+
+var (
+	one = big.NewInt(1)
+	ten = big.NewInt(10)
+)
+
+// X_quoRound returns |x|*10^exp / |y|, rounded with the given mode for a
+// result of sign neg.
+func X_quoRound(neg bool, x, y []byte, exp int, mode uint8) []byte {
+	bx, by := new(big.Int).SetBytes(x), new(big.Int).SetBytes(y)
+	if exp >= 0 {
+		bx.Mul(bx, pow10(exp))
+	} else {
+		by.Mul(by, pow10(-exp))
+	}
+	q, r := new(big.Int).QuoRem(bx, by, new(big.Int))
+	if r.Sign() != 0 && roundAway(mode, neg, q, r, by) {
+		q.Add(q, one)
+	}
+	return q.Bytes()
+}
+
+// roundAway returns whether the quotient q, with a non-zero remainder r for
+// the divisor y, must be rounded away from zero.
+func roundAway(mode uint8, neg bool, q, r, y *big.Int) bool {
+	switch mode {
+	case toNearestEven:
+		c := new(big.Int).Lsh(r, 1).Cmp(y)
+		return c > 0 || c == 0 && q.Bit(0) == 1
+	case toNearestAway:
+		return new(big.Int).Lsh(r, 1).Cmp(y) >= 0
+	case toZero:
+		return false
+	case awayFromZero:
+		return true
+	case toNegativeInf:
+		return neg
+	case toPositiveInf:
+		return !neg
+	default:
+		panic("invalid rounding mode")
+	}
+}
+
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(ten, big.NewInt(int64(n)), nil)
+}
+
+// X_parse parses a decimal number made of an optional sign, digits, and
+// optionally a point followed by digits.
+func X_parse(s string) (neg bool, abs []byte, scale int, ok bool) {
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		neg = s[0] == '-'
+		s = s[1:]
+	}
+	digits := make([]byte, 0, len(s))
+	point := -1
+	for i := 0; i < len(s); i++ {
+		switch c := s[i]; {
+		case c >= '0' && c <= '9':
+			digits = append(digits, c)
+		case c == '.' && point < 0 && i > 0 && i < len(s)-1:
+			point = i
+		default:
+			return false, nil, 0, false
+		}
+	}
+	if len(digits) == 0 {
+		return false, nil, 0, false
+	}
+	if point >= 0 {
+		scale = len(s) - point - 1
+	}
+	b, _ := new(big.Int).SetString(string(digits), 10)
+	return neg && b.Sign() != 0, b.Bytes(), scale, true
+}
+
+// X_format returns the decimal representation of the coefficient with the
+// given scale, with all of its fractional digits.
+func X_format(neg bool, abs []byte, scale int) string {
+	digits := new(big.Int).SetBytes(abs).Text(10)
+	if len(digits) <= scale {
+		digits = zeros(scale-len(digits)+1) + digits
+	}
+	if scale > 0 {
+		digits = digits[:len(digits)-scale] + "." + digits[len(digits)-scale:]
+	}
+	if neg {
+		digits = "-" + digits
+	}
+	return digits
+}
+
+func zeros(n int) string {
+	b := make([]byte, n)
+	for i := range b {
+		b[i] = '0'
+	}
+	return string(b)
+}
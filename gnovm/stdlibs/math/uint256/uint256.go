@@ -0,0 +1,139 @@
+package uint256
+
+import (
+	"encoding/binary"
+	"math/big"
+	"math/bits"
+)
+
+// The integers are passed as [4]uint64, least significant word first.
+
+func X_add(x, y [4]uint64) (z [4]uint64, carry bool) {
+	var c uint64
+	z[0], c = bits.Add64(x[0], y[0], 0)
+	z[1], c = bits.Add64(x[1], y[1], c)
+	z[2], c = bits.Add64(x[2], y[2], c)
+	z[3], c = bits.Add64(x[3], y[3], c)
+	return z, c != 0
+}
+
+func X_sub(x, y [4]uint64) (z [4]uint64, borrow bool) {
+	var b uint64
+	z[0], b = bits.Sub64(x[0], y[0], 0)
+	z[1], b = bits.Sub64(x[1], y[1], b)
+	z[2], b = bits.Sub64(x[2], y[2], b)
+	z[3], b = bits.Sub64(x[3], y[3], b)
+	return z, b != 0
+}
+
+func X_mul(x, y [4]uint64) (z [4]uint64, overflow bool) {
+	p := umul(x, y)
+	copy(z[:], p[:4])
+	return z, p[4]|p[5]|p[6]|p[7] != 0
+}
+
+// umul returns the full 512-bit product of x and y.
+func umul(x, y [4]uint64) (p [8]uint64) {
+	for i := range 4 {
+		var carry uint64
+		for j := range 4 {
+			// x[i]*y[j] + p[i+j] + carry always fits in 128 bits.
+			hi, lo := bits.Mul64(x[i], y[j])
+			var c uint64
+			lo, c = bits.Add64(lo, p[i+j], 0)
+			hi += c
+			lo, c = bits.Add64(lo, carry, 0)
+			hi += c
+			p[i+j], carry = lo, hi
+		}
+		p[i+4] = carry
+	}
+	return p
+}
+
+func X_divMod(x, y [4]uint64) (q, r [4]uint64) {
+	bq, br := new(big.Int).QuoRem(toBig(x), toBig(y), new(big.Int))
+	q, _ = fromBig(bq)
+	r, _ = fromBig(br)
+	return q, r
+}
+
+func X_mulDiv(x, y, d [4]uint64) (z [4]uint64, overflow bool) {
+	p := new(big.Int).Mul(toBig(x), toBig(y))
+	return fromBig(p.Quo(p, toBig(d)))
+}
+
+// modulus is 2^256.
+var modulus = new(big.Int).Lsh(big.NewInt(1), 256)
+
+func X_exp(x, y [4]uint64) [4]uint64 {
+	z, _ := fromBig(new(big.Int).Exp(toBig(x), toBig(y), modulus))
+	return z
+}
+
+func X_lsh(x [4]uint64, n uint) (z [4]uint64) {
+	if n >= 256 {
+		return z
+	}
+	words, n := int(n/64), n%64
+	for i := 3; i >= words; i-- {
+		z[i] = x[i-words] << n
+		if n != 0 && i-words > 0 {
+			z[i] |= x[i-words-1] >> (64 - n)
+		}
+	}
+	return z
+}
+
+func X_rsh(x [4]uint64, n uint) (z [4]uint64) {
+	if n >= 256 {
+		return z
+	}
+	words, n := int(n/64), n%64
+	for i := 0; i < 4-words; i++ {
+		z[i] = x[i+words] >> n
+		if n != 0 && i+words < 3 {
+			z[i] |= x[i+words+1] << (64 - n)
+		}
+	}
+	return z
+}
+
+func X_parse(s string, base int) (z [4]uint64, valid, overflow bool) {
+	// big.Int accepts a sign, which isn't valid for unsigned integers.
+	if s == "" || s[0] == '+' || s[0] == '-' {
+		return z, false, false
+	}
+	b, ok := new(big.Int).SetString(s, base)
+	if !ok {
+		return z, false, false
+	}
+	z, overflow = fromBig(b)
+	return z, true, overflow
+}
+
+func X_format(x [4]uint64, base int) string {
+	return toBig(x).Text(base)
+}
+
+func toBig(x [4]uint64) *big.Int {
+	var buf [32]byte
+	for i, w := range x {
+		binary.BigEndian.PutUint64(buf[24-8*i:], w)
+	}
+	return new(big.Int).SetBytes(buf[:])
+}
+
+// fromBig returns b modulo 2^256, and whether it's larger than 2^256-1.
+func fromBig(b *big.Int) (z [4]uint64, overflow bool) {
+	overflow = b.BitLen() > 256
+	if overflow {
+		b = new(big.Int).Mod(b, modulus)
+	}
+	var buf [32]byte
+	b.FillBytes(buf[:])
+	for i := range z {
+		z[i] = binary.BigEndian.Uint64(buf[24-8*i:])
+	}
+	return z, overflow
+}
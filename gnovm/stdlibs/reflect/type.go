@@ -0,0 +1,145 @@
+package reflect
+
+import (
+	goreflect "reflect"
+	"unicode"
+	"unicode/utf8"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// A Type is passed around in Gno as a nil *T, so that comparing two Types
+// compares the identity of the types they describe.
+
+// typeValue returns the nil *T describing t.
+func typeValue(m *gno.Machine, t gno.Type) gno.TypedValue {
+	return gno.TypedValue{T: m.Alloc.NewType(&gno.PointerType{Elt: t})}
+}
+
+// typeFrom returns the type T described by, or pointed to by, the *T x.
+func typeFrom(x gno.TypedValue) gno.Type {
+	return gno.BaseOf(x.T).(*gno.PointerType).Elt
+}
+
+// kindOf returns the Go reflect.Kind of t.
+func kindOf(t gno.Type) goreflect.Kind {
+	switch gno.BaseOf(t).Kind() {
+	case gno.BoolKind:
+		return goreflect.Bool
+	case gno.StringKind:
+		return goreflect.String
+	case gno.IntKind:
+		return goreflect.Int
+	case gno.Int8Kind:
+		return goreflect.Int8
+	case gno.Int16Kind:
+		return goreflect.Int16
+	case gno.Int32Kind:
+		return goreflect.Int32
+	case gno.Int64Kind:
+		return goreflect.Int64
+	case gno.UintKind:
+		return goreflect.Uint
+	case gno.Uint8Kind:
+		return goreflect.Uint8
+	case gno.Uint16Kind:
+		return goreflect.Uint16
+	case gno.Uint32Kind:
+		return goreflect.Uint32
+	case gno.Uint64Kind:
+		return goreflect.Uint64
+	case gno.Float32Kind:
+		return goreflect.Float32
+	case gno.Float64Kind:
+		return goreflect.Float64
+	case gno.ArrayKind:
+		return goreflect.Array
+	case gno.SliceKind:
+		return goreflect.Slice
+	case gno.PointerKind:
+		return goreflect.Pointer
+	case gno.StructKind:
+		return goreflect.Struct
+	case gno.InterfaceKind:
+		return goreflect.Interface
+	case gno.ChanKind:
+		return goreflect.Chan
+	case gno.FuncKind:
+		return goreflect.Func
+	case gno.MapKind:
+		return goreflect.Map
+	default:
+		return goreflect.Invalid
+	}
+}
+
+func X_typeOf(m *gno.Machine, v gno.TypedValue) gno.TypedValue {
+	if v.IsUndefined() {
+		return gno.TypedValue{}
+	}
+	return typeValue(m, v.T)
+}
+
+func X_typeKind(t gno.TypedValue) int {
+	return int(kindOf(typeFrom(t)))
+}
+
+func X_typeName(t gno.TypedValue) string {
+	switch ct := typeFrom(t).(type) {
+	case *gno.DeclaredType:
+		return string(ct.Name)
+	case gno.PrimitiveType:
+		return ct.String()
+	default:
+		return ""
+	}
+}
+
+func X_typePkgPath(t gno.TypedValue) string {
+	dt, ok := typeFrom(t).(*gno.DeclaredType)
+	// Builtin types, like error, are declared in the ".uverse" package.
+	if !ok || dt.PkgPath == ".uverse" {
+		return ""
+	}
+	return dt.PkgPath
+}
+
+func X_typeString(t gno.TypedValue) string {
+	return typeFrom(t).String()
+}
+
+func X_typeElem(m *gno.Machine, t gno.TypedValue) gno.TypedValue {
+	return typeValue(m, gno.BaseOf(typeFrom(t)).Elem())
+}
+
+func X_typeKey(m *gno.Machine, t gno.TypedValue) gno.TypedValue {
+	return typeValue(m, gno.BaseOf(typeFrom(t)).(*gno.MapType).Key)
+}
+
+func X_typeLen(t gno.TypedValue) int {
+	return gno.BaseOf(typeFrom(t)).(*gno.ArrayType).Len
+}
+
+func X_typeNumField(t gno.TypedValue) int {
+	return len(gno.BaseOf(typeFrom(t)).(*gno.StructType).Fields)
+}
+
+func X_typeField(m *gno.Machine, t gno.TypedValue, i int) (name, pkgPath string, typ gno.TypedValue, tag string, embedded bool) {
+	st := gno.BaseOf(typeFrom(t)).(*gno.StructType)
+	ft := st.Fields[i]
+	name = string(ft.Name)
+	if !isExported(name) {
+		pkgPath = st.PkgPath
+	}
+	return name, pkgPath, typeValue(m, ft.Type), string(ft.Tag), ft.Embedded
+}
+
+func X_typeImplements(t, u gno.TypedValue) bool {
+	it, ok := gno.BaseOf(typeFrom(u)).(*gno.InterfaceType)
+	return ok && it.IsImplementedBy(typeFrom(t))
+}
+
+func isExported(name string) bool {
+	r, _ := utf8.DecodeRuneInString(name)
+	return unicode.IsUpper(r)
+}
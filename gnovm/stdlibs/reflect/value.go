@@ -0,0 +1,470 @@
+package reflect
+
+import (
+	"fmt"
+	"math"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+// A Value is backed in Gno by a *T pointing to the value it holds. Values
+// which are not addressable point to a copy.
+
+// pointerTo returns a *T of type t pointing to pv.
+func pointerTo(m *gno.Machine, t gno.Type, pv gno.PointerValue) gno.TypedValue {
+	return gno.TypedValue{
+		T: m.Alloc.NewType(&gno.PointerType{Elt: t}),
+		V: pv,
+	}
+}
+
+// newPointer returns a *T of type t pointing to a new copy of tv.
+func newPointer(m *gno.Machine, t gno.Type, tv gno.TypedValue) gno.TypedValue {
+	m.Alloc.AllocatePointer()
+	hi := m.Alloc.NewHeapItem(tv.Copy(m.Alloc))
+	return pointerTo(m, t, gno.PointerValue{
+		TV:    &hi.Value,
+		Base:  hi,
+		Index: 0,
+	})
+}
+
+// deref returns the value the *T p points to.
+func deref(p gno.TypedValue) gno.TypedValue {
+	return p.V.(gno.PointerValue).Deref()
+}
+
+// assignable returns the value tv of type t converted to type to. If it is not
+// assignable, it panics and returns false.
+func assignable(m *gno.Machine, tv gno.TypedValue, t, to gno.Type) (gno.TypedValue, bool) {
+	switch bto := gno.BaseOf(to).(type) {
+	case *gno.InterfaceType:
+		// Interfaces hold the concrete value, if any.
+		if tv.IsUndefined() || bto.IsImplementedBy(tv.T) {
+			return tv, true
+		}
+	default:
+		_, dt := t.(*gno.DeclaredType)
+		_, dto := to.(*gno.DeclaredType)
+		if t.TypeID() == to.TypeID() ||
+			(!dt || !dto) && gno.BaseOf(t).TypeID() == bto.TypeID() {
+			tv.T = to
+			return tv, true
+		}
+	}
+	m.Panic(typedString(fmt.Sprintf(
+		"reflect: value of type %s is not assignable to type %s", t, to)))
+	return tv, false
+}
+
+// writable returns whether the value tv can be modified by the current realm,
+// and panics if it can't.
+func writable(m *gno.Machine, tv *gno.TypedValue) bool {
+	if m.IsReadonly(tv) {
+		m.Panic(typedString("reflect: cannot modify a value of another realm"))
+		return false
+	}
+	return true
+}
+
+func X_valueOf(m *gno.Machine, v gno.TypedValue) gno.TypedValue {
+	if v.IsUndefined() {
+		return gno.TypedValue{}
+	}
+	return newPointer(m, v.T, v)
+}
+
+func X_valueType(p gno.TypedValue) gno.TypedValue {
+	return gno.TypedValue{T: p.T}
+}
+
+func X_load(m *gno.Machine, p gno.TypedValue) gno.TypedValue {
+	return deref(p).Copy(m.Alloc)
+}
+
+func X_loadBool(p gno.TypedValue) bool {
+	tv := deref(p)
+	return tv.GetBool()
+}
+
+func X_loadInt(p gno.TypedValue) int64 {
+	tv := deref(p)
+	return getInt(&tv)
+}
+
+func X_loadUint(p gno.TypedValue) uint64 {
+	tv := deref(p)
+	return getUint(&tv)
+}
+
+func X_loadFloat(p gno.TypedValue) float64 {
+	tv := deref(p)
+	return getFloat(&tv)
+}
+
+func X_loadString(p gno.TypedValue) string {
+	tv := deref(p)
+	return tv.GetString()
+}
+
+func getInt(tv *gno.TypedValue) int64 {
+	switch gno.BaseOf(tv.T).Kind() {
+	case gno.IntKind:
+		return tv.GetInt()
+	case gno.Int8Kind:
+		return int64(tv.GetInt8())
+	case gno.Int16Kind:
+		return int64(tv.GetInt16())
+	case gno.Int32Kind:
+		return int64(tv.GetInt32())
+	default:
+		return tv.GetInt64()
+	}
+}
+
+func getUint(tv *gno.TypedValue) uint64 {
+	switch gno.BaseOf(tv.T).Kind() {
+	case gno.UintKind:
+		return tv.GetUint()
+	case gno.Uint8Kind:
+		return uint64(tv.GetUint8())
+	case gno.Uint16Kind:
+		return uint64(tv.GetUint16())
+	case gno.Uint32Kind:
+		return uint64(tv.GetUint32())
+	default:
+		return tv.GetUint64()
+	}
+}
+
+func getFloat(tv *gno.TypedValue) float64 {
+	if gno.BaseOf(tv.T).Kind() == gno.Float32Kind {
+		return float64(math.Float32frombits(tv.GetFloat32()))
+	}
+	return math.Float64frombits(tv.GetFloat64())
+}
+
+func X_isNil(p gno.TypedValue) bool {
+	tv := deref(p)
+	return isNil(typeFrom(p), &tv)
+}
+
+func isNil(t gno.Type, tv *gno.TypedValue) bool {
+	if t.Kind() == gno.InterfaceKind {
+		return tv.IsUndefined()
+	}
+	if pv, ok := tv.V.(gno.PointerValue); ok {
+		return pv.TV == nil
+	}
+	return tv.V == nil
+}
+
+func X_isZero(m *gno.Machine, p gno.TypedValue) bool {
+	tv := deref(p)
+	return isZero(m.Store, typeFrom(p), &tv)
+}
+
+func isZero(store gno.Store, t gno.Type, tv *gno.TypedValue) bool {
+	switch bt := gno.BaseOf(t).(type) {
+	case gno.PrimitiveType:
+		switch bt.Kind() {
+		case gno.BoolKind:
+			return !tv.GetBool()
+		case gno.StringKind:
+			return tv.GetString() == ""
+		case gno.Float32Kind:
+			return tv.GetFloat32() == 0
+		case gno.Float64Kind:
+			return tv.GetFloat64() == 0
+		case gno.UintKind, gno.Uint8Kind, gno.Uint16Kind, gno.Uint32Kind, gno.Uint64Kind:
+			return getUint(tv) == 0
+		default:
+			return getInt(tv) == 0
+		}
+	case *gno.ArrayType:
+		av := tv.V.(*gno.ArrayValue)
+		for i := range bt.Len {
+			etv := av.GetPointerAtIndexInt2(store, i, bt.Elt).Deref()
+			if !isZero(store, bt.Elt, &etv) {
+				return false
+			}
+		}
+		return true
+	case *gno.StructType:
+		sv := tv.V.(*gno.StructValue)
+		for i, ft := range bt.Fields {
+			if !isZero(store, ft.Type, sv.GetPointerToInt(store, i).TV) {
+				return false
+			}
+		}
+		return true
+	default:
+		return isNil(t, tv)
+	}
+}
+
+func X_valueLen(p gno.TypedValue) int {
+	tv := deref(p)
+	return tv.GetLength()
+}
+
+func X_valueCap(p gno.TypedValue) int {
+	tv := deref(p)
+	return tv.GetCapacity()
+}
+
+func X_index(m *gno.Machine, p gno.TypedValue, i int) gno.TypedValue {
+	t := typeFrom(p)
+	tv := deref(p)
+	if gno.BaseOf(t).Kind() == gno.StringKind {
+		b := gno.TypedValue{T: gno.Uint8Type}
+		b.SetUint8(tv.GetString()[i])
+		return newPointer(m, gno.Uint8Type, b)
+	}
+	return pointerTo(m, gno.BaseOf(t).Elem(), tv.GetPointerAtIndexInt(m.Store, i))
+}
+
+func X_field(m *gno.Machine, p gno.TypedValue, i int) gno.TypedValue {
+	st := gno.BaseOf(typeFrom(p)).(*gno.StructType)
+	sv := deref(p).V.(*gno.StructValue)
+	return pointerTo(m, st.Fields[i].Type, sv.GetPointerToInt(m.Store, i))
+}
+
+var gSliceOfAny = &gno.SliceType{
+	Elt: &gno.InterfaceType{},
+}
+
+func X_mapKeys(m *gno.Machine, p gno.TypedValue) gno.TypedValue {
+	kt := gno.BaseOf(typeFrom(p)).(*gno.MapType).Key
+	keys := gno.TypedValue{T: gSliceOfAny}
+	mv, ok := deref(p).V.(*gno.MapValue)
+	if !ok || mv.GetLength() == 0 {
+		return keys
+	}
+	// Map keys are kept in insertion order, which is deterministic.
+	ks := make([]gno.TypedValue, 0, mv.GetLength())
+	for el := mv.List.Head; el != nil; el = el.Next {
+		ks = append(ks, newPointer(m, kt, el.Key))
+	}
+	keys.V = m.Alloc.NewSliceFromList(ks)
+	return keys
+}
+
+func X_mapIndex(m *gno.Machine, p, k gno.TypedValue) gno.TypedValue {
+	mt := gno.BaseOf(typeFrom(p)).(*gno.MapType)
+	mv, ok := deref(p).V.(*gno.MapValue)
+	if !ok {
+		return gno.TypedValue{}
+	}
+	key, ok := assignable(m, deref(k), typeFrom(k), mt.Key)
+	if !ok {
+		return gno.TypedValue{}
+	}
+	val, ok := mv.GetValueForKey(m.Store, &key)
+	if !ok {
+		return gno.TypedValue{}
+	}
+	return newPointer(m, mt.Value, val)
+}
+
+func X_store(m *gno.Machine, p, x gno.TypedValue) {
+	tv, ok := assignable(m, deref(x), typeFrom(x), typeFrom(p))
+	if !ok || !writable(m, &p) {
+		return
+	}
+	p.V.(gno.PointerValue).Assign2(m.Alloc, m.Store, m.Realm, tv, false)
+}
+
+func X_storeBool(m *gno.Machine, p gno.TypedValue, x bool) {
+	tv := gno.TypedValue{T: typeFrom(p)}
+	tv.SetBool(x)
+	if !writable(m, &p) {
+		return
+	}
+	p.V.(gno.PointerValue).Assign2(m.Alloc, m.Store, m.Realm, tv, false)
+}
+
+func X_storeInt(m *gno.Machine, p gno.TypedValue, x int64) {
+	tv := gno.TypedValue{T: typeFrom(p)}
+	switch gno.BaseOf(tv.T).Kind() {
+	case gno.IntKind:
+		tv.SetInt(x)
+	case gno.Int8Kind:
+		tv.SetInt8(int8(x))
+	case gno.Int16Kind:
+		tv.SetInt16(int16(x))
+	case gno.Int32Kind:
+		tv.SetInt32(int32(x))
+	default:
+		tv.SetInt64(x)
+	}
+	if !writable(m, &p) {
+		return
+	}
+	p.V.(gno.PointerValue).Assign2(m.Alloc, m.Store, m.Realm, tv, false)
+}
+
+func X_storeUint(m *gno.Machine, p gno.TypedValue, x uint64) {
+	tv := gno.TypedValue{T: typeFrom(p)}
+	switch gno.BaseOf(tv.T).Kind() {
+	case gno.UintKind:
+		tv.SetUint(x)
+	case gno.Uint8Kind:
+		tv.SetUint8(uint8(x))
+	case gno.Uint16Kind:
+		tv.SetUint16(uint16(x))
+	case gno.Uint32Kind:
+		tv.SetUint32(uint32(x))
+	default:
+		tv.SetUint64(x)
+	}
+	if !writable(m, &p) {
+		return
+	}
+	p.V.(gno.PointerValue).Assign2(m.Alloc, m.Store, m.Realm, tv, false)
+}
+
+func X_storeFloat(m *gno.Machine, p gno.TypedValue, x float64) {
+	tv := gno.TypedValue{T: typeFrom(p)}
+	if gno.BaseOf(tv.T).Kind() == gno.Float32Kind {
+		tv.SetFloat32(math.Float32bits(float32(x)))
+	} else {
+		tv.SetFloat64(math.Float64bits(x))
+	}
+	if !writable(m, &p) {
+		return
+	}
+	p.V.(gno.PointerValue).Assign2(m.Alloc, m.Store, m.Realm, tv, false)
+}
+
+func X_storeString(m *gno.Machine, p gno.TypedValue, x string) {
+	tv := gno.TypedValue{T: typeFrom(p)}
+	tv.SetString(m.Alloc.NewString(x))
+	if !writable(m, &p) {
+		return
+	}
+	p.V.(gno.PointerValue).Assign2(m.Alloc, m.Store, m.Realm, tv, false)
+}
+
+func X_newValue(m *gno.Machine, t gno.TypedValue) gno.TypedValue {
+	tt := typeFrom(t)
+	return newPointer(m, tt, gno.DefaultTypedValue(m.Alloc, tt))
+}
+
+func X_makeSlice(m *gno.Machine, t gno.TypedValue, n, c int) gno.TypedValue {
+	tt := typeFrom(t)
+	av := newArray(m, gno.BaseOf(tt).Elem(), c)
+	return newPointer(m, tt, gno.TypedValue{
+		T: tt,
+		V: m.Alloc.NewSlice(av, 0, n, c),
+	})
+}
+
+// newArray returns an array of n zero values of type et.
+func newArray(m *gno.Machine, et gno.Type, n int) *gno.ArrayValue {
+	if et.Kind() == gno.Uint8Kind {
+		return m.Alloc.NewDataArray(n)
+	}
+	av := m.Alloc.NewListArray(n)
+	if et.Kind() != gno.InterfaceKind {
+		for i := range n {
+			av.List[i] = gno.DefaultTypedValue(m.Alloc, et)
+		}
+	}
+	return av
+}
+
+func X_makeMap(m *gno.Machine, t gno.TypedValue) gno.TypedValue {
+	tt := typeFrom(t)
+	return newPointer(m, tt, gno.TypedValue{
+		T: tt,
+		V: m.Alloc.NewMap(0),
+	})
+}
+
+func X_appendValue(m *gno.Machine, p, x gno.TypedValue) gno.TypedValue {
+	st := typeFrom(p)
+	et := gno.BaseOf(st).Elem()
+	xv, ok := assignable(m, deref(x), typeFrom(x), et)
+	if !ok {
+		return gno.TypedValue{}
+	}
+	stv := deref(p)
+	var (
+		sv     *gno.SliceValue
+		base   *gno.ArrayValue
+		offset int
+	)
+	if stv.V != nil {
+		sv = stv.V.(*gno.SliceValue)
+		base, offset = sv.GetBase(m.Store), sv.Offset
+	}
+	n, c := stv.GetLength(), stv.GetCapacity()
+	if n < c {
+		// Like append, write past the length within the capacity.
+		if !writable(m, &stv) {
+			return gno.TypedValue{}
+		}
+	} else {
+		c = max(2*c, 1)
+		av := newArray(m, et, c)
+		if n == 0 {
+			// Nothing to copy.
+		} else if av.Data != nil {
+			copy(av.Data, base.Data[offset:offset+n])
+		} else {
+			for i := range n {
+				av.List[i] = base.GetPointerAtIndexInt2(m.Store, offset+i, et).Deref().Copy(m.Alloc)
+			}
+		}
+		base, offset = av, 0
+	}
+	base.GetPointerAtIndexInt2(m.Store, offset+n, et).Assign2(m.Alloc, m.Store, m.Realm, xv, false)
+	return newPointer(m, st, gno.TypedValue{
+		T: st,
+		V: m.Alloc.NewSlice(base, offset, n+1, c),
+	})
+}
+
+func X_setMapIndex(m *gno.Machine, p, k, v gno.TypedValue) {
+	mt := gno.BaseOf(typeFrom(p)).(*gno.MapType)
+	mtv := deref(p)
+	key, ok := assignable(m, deref(k), typeFrom(k), mt.Key)
+	if !ok {
+		return
+	}
+	if v.IsUndefined() {
+		mv, ok := mtv.V.(*gno.MapValue)
+		if !ok {
+			return
+		}
+		val, ok := mv.GetValueForKey(m.Store, &key)
+		if !ok {
+			return
+		}
+		if !writable(m, &mtv) {
+			return
+		}
+		mv.DeleteForKey(m.Store, &key)
+		if m.Realm != nil {
+			m.Realm.DidUpdate(mv, key.GetFirstObject(m.Store), nil)
+			m.Realm.DidUpdate(mv, val.GetFirstObject(m.Store), nil)
+		}
+		return
+	}
+	if mtv.V == nil {
+		m.Panic(typedString("assignment to entry in nil map"))
+		return
+	}
+	val, ok := assignable(m, deref(v), typeFrom(v), mt.Value)
+	if !ok || !writable(m, &mtv) {
+		return
+	}
+	mtv.GetPointerAtIndex(m.Realm, m.Alloc, m.Store, &key).Assign2(m.Alloc, m.Store, m.Realm, val, false)
+}
+
+func typedString(s string) gno.TypedValue {
+	tv := gno.TypedValue{T: gno.StringType}
+	tv.SetString(gno.StringValue(s))
+	return tv
+}
@@ -0,0 +1,216 @@
+package stdlibs
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+	"sync"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+var (
+	registeredMu      sync.RWMutex
+	registeredNatives []*NativeFunc
+
+	machineType    = reflect.TypeOf((*gno.Machine)(nil))
+	typedValueType = reflect.TypeOf(gno.TypedValue{})
+)
+
+// RegisterNative registers fn as the native implementation of the function
+// name, declared without a body in the Gno package pkgPath. It allows
+// embedders of the GnoVM to add their own native bindings, next to the ones
+// generated for the standard libraries.
+//
+// fn must be a non-variadic Go function, optionally taking a *gno.Machine as
+// its first parameter. Its other parameters and its results must be
+// gno.TypedValue, which is passed as-is, or of a type the GnoVM converts
+// to and from Go: booleans, strings, numbers, and arrays, slices and pointers
+// of them. When the function is first called, RegisterNative's binding checks
+// these types against the ones of the Gno declaration, and panics if they
+// don't match.
+//
+// RegisterNative must be called before the packages using the binding are
+// preprocessed, usually in an init function.
+func RegisterNative(pkgPath string, name gno.Name, fn any) error {
+	if pkgPath == "" || name == "" {
+		return errors.New("native binding must have a package path and a name")
+	}
+	fv := reflect.ValueOf(fn)
+	if fv.Kind() != reflect.Func || fv.IsNil() {
+		return fmt.Errorf("native binding %s.%s: expected a function, got %T", pkgPath, name, fn)
+	}
+	ft := fv.Type()
+	if ft.IsVariadic() {
+		return fmt.Errorf("native binding %s.%s: variadic functions are not supported", pkgPath, name)
+	}
+
+	nf := &NativeFunc{
+		gnoPkg:  pkgPath,
+		gnoFunc: name,
+	}
+	var params, results []reflect.Type
+	for i := range ft.NumIn() {
+		pt := ft.In(i)
+		if i == 0 && pt == machineType {
+			nf.hasMachine = true
+			continue
+		}
+		if err := checkNativeGoType(pt); err != nil {
+			return fmt.Errorf("native binding %s.%s: parameter %d: %w", pkgPath, name, i, err)
+		}
+		params = append(params, pt)
+	}
+	for i := range ft.NumOut() {
+		rt := ft.Out(i)
+		if err := checkNativeGoType(rt); err != nil {
+			return fmt.Errorf("native binding %s.%s: result %d: %w", pkgPath, name, i, err)
+		}
+		results = append(results, rt)
+	}
+	var paramsTV, resultsTV bool
+	nf.params, paramsTV = nativeFieldTypes("p", params)
+	nf.results, resultsTV = nativeFieldTypes("r", results)
+	nf.typedValue = paramsTV || resultsTV
+	nf.f = (&registeredNative{
+		fn:      fv,
+		params:  params,
+		results: results,
+		machine: nf.hasMachine,
+	}).call
+
+	registeredMu.Lock()
+	defer registeredMu.Unlock()
+	if findNative(pkgPath, name) != nil {
+		return fmt.Errorf("native binding %s.%s is already defined", pkgPath, name)
+	}
+	registeredNatives = append(registeredNatives, nf)
+	return nil
+}
+
+// checkNativeGoType returns an error if values of rt can't be converted
+// between Go and Gno by the native binding.
+func checkNativeGoType(rt reflect.Type) error {
+	if rt == typedValueType {
+		return nil
+	}
+	if rt.Name() != "" && rt.PkgPath() != "" {
+		return fmt.Errorf("named type %v is not supported", rt)
+	}
+	switch rt.Kind() {
+	case reflect.Bool, reflect.String,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64:
+		return nil
+	case reflect.Array, reflect.Slice, reflect.Ptr:
+		if rt.Elem() == typedValueType {
+			return fmt.Errorf("type %v is not supported", rt)
+		}
+		return checkNativeGoType(rt.Elem())
+	default:
+		return fmt.Errorf("type %v is not supported", rt)
+	}
+}
+
+// nativeFieldTypes returns the Gno field types of the Go types, and whether
+// any of them is a gno.TypedValue.
+func nativeFieldTypes(prefix string, types []reflect.Type) (fields []gno.FieldTypeExpr, typedValue bool) {
+	fields = make([]gno.FieldTypeExpr, len(types))
+	for i, t := range types {
+		fields[i].NameExpr = *gno.Nx(fmt.Sprintf("%s%d", prefix, i))
+		if t == typedValueType {
+			fields[i].Type = gno.AnyT()
+			typedValue = true
+		} else {
+			fields[i].Type = gno.X(t.String())
+		}
+	}
+	return fields, typedValue
+}
+
+// registeredNative is a native binding added with RegisterNative, calling its
+// Go function through reflection.
+type registeredNative struct {
+	fn      reflect.Value
+	params  []reflect.Type
+	results []reflect.Type
+	machine bool
+
+	// checked holds the type IDs of the Gno function types found to match
+	// the Go function.
+	checked sync.Map // gno.TypeID -> struct{}
+}
+
+func (rn *registeredNative) call(m *gno.Machine) {
+	fv := m.LastFrame().Func
+	ft := fv.GetType(m.Store)
+	if _, ok := rn.checked.Load(ft.TypeID()); !ok {
+		if err := rn.checkType(ft); err != nil {
+			panic(fmt.Sprintf("native binding %s.%s: %v", fv.PkgPath, fv.Name, err))
+		}
+		rn.checked.Store(ft.TypeID(), struct{}{})
+	}
+
+	in := make([]reflect.Value, 0, len(rn.params)+1)
+	if rn.machine {
+		in = append(in, reflect.ValueOf(m))
+	}
+	b := m.LastBlock()
+	for i, pt := range rn.params {
+		tv := b.GetPointerTo(nil, gno.NewValuePathBlock(1, uint16(i), "")).TV
+		if pt == typedValueType {
+			in = append(in, reflect.ValueOf(*tv))
+			continue
+		}
+		rv := reflect.New(pt).Elem()
+		tv.DeepFill(m.Store)
+		gno.Gno2GoValue(tv, rv)
+		in = append(in, rv)
+	}
+
+	out := rn.fn.Call(in)
+	for i, rv := range out {
+		if rn.results[i] == typedValueType {
+			m.PushValue(rv.Interface().(gno.TypedValue))
+			continue
+		}
+		// Copy to an addressable value, like the generated bindings do.
+		r := reflect.New(rv.Type()).Elem()
+		r.Set(rv)
+		m.PushValue(gno.Go2GnoValue(m.Alloc, m.Store, r))
+	}
+}
+
+// checkType returns an error if the parameters and results of the Gno
+// function type ft don't match the Go function.
+func (rn *registeredNative) checkType(ft *gno.FuncType) error {
+	if len(ft.Params) != len(rn.params) || len(ft.Results) != len(rn.results) {
+		return fmt.Errorf("Gno function %v has %d parameters and %d results, Go function has %d and %d",
+			ft, len(ft.Params), len(ft.Results), len(rn.params), len(rn.results))
+	}
+	check := func(kind string, i int, t gno.Type, goType reflect.Type) error {
+		if goType == typedValueType {
+			return nil
+		}
+		rt, err := gno.Gno2GoType(t)
+		if err != nil {
+			return fmt.Errorf("%s %d: %w", kind, i, err)
+		}
+		if rt != goType {
+			return fmt.Errorf("%s %d: Gno type %v does not match Go type %v", kind, i, t, goType)
+		}
+		return nil
+	}
+	for i, p := range ft.Params {
+		if err := check("parameter", i, p.Type, rn.params[i]); err != nil {
+			return err
+		}
+	}
+	for i, r := range ft.Results {
+		if err := check("result", i, r.Type, rn.results[i]); err != nil {
+			return err
+		}
+	}
+	return nil
+}
@@ -0,0 +1,104 @@
+package stdlibs
+
+import (
+	"fmt"
+	"strings"
+	"testing"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	stypes "github.com/gnolang/gno/tm2/pkg/store/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegisterNative_errors(t *testing.T) {
+	tests := []struct {
+		name    string
+		pkgPath string
+		fn      any
+		err     string
+	}{
+		{"not a function", "gno.land/p/native", 1, "expected a function"},
+		{"variadic", "gno.land/p/native", func(...int) {}, "variadic functions are not supported"},
+		{"unsupported param", "gno.land/p/native", func(map[string]int) {}, "parameter 0: type map[string]int is not supported"},
+		{"unsupported result", "gno.land/p/native", func() error { return nil }, "result 0: type error is not supported"},
+		{"named type", "gno.land/p/native", func(strings.Builder) {}, "named type strings.Builder is not supported"},
+		{"machine not first", "gno.land/p/native", func(int, *gno.Machine) {}, "parameter 1"},
+		{"already generated", "math", func(float32) uint32 { return 0 }, "math.Float32bits is already defined"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			name := gno.Name("Float32bits")
+			err := RegisterNative(tt.pkgPath, name, tt.fn)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+		})
+	}
+}
+
+func TestRegisterNative(t *testing.T) {
+	const pkgPath = "gno.land/p/nativetest"
+	require.NoError(t, RegisterNative(pkgPath, "add", func(a, b int) int { return a + b }))
+	require.NoError(t, RegisterNative(pkgPath, "join", func(m *gno.Machine, xs []string) (string, int) {
+		require.NotNil(t, m)
+		return strings.Join(xs, ","), len(xs)
+	}))
+	require.NoError(t, RegisterNative(pkgPath, "mismatch", func(a int64) int { return int(a) }))
+	err := RegisterNative(pkgPath, "add", func(a, b int) int { return 0 })
+	require.ErrorContains(t, err, "already defined")
+
+	assert.True(t, HasNativePkg(pkgPath))
+	nf := FindNative(pkgPath, "join")
+	require.NotNil(t, nf)
+	assert.True(t, nf.HasMachineParam())
+	assert.False(t, nf.HasTypedValue())
+
+	db := memdb.NewMemDB()
+	baseStore := dbadapter.StoreConstructor(db, stypes.StoreOptions{})
+	iavlStore := iavl.StoreConstructor(db, stypes.StoreOptions{})
+	store := gno.NewStore(nil, baseStore, iavlStore)
+	store.SetNativeResolver(NativeResolver)
+	m := gno.NewMachine(pkgPath, store)
+	defer m.Release()
+	m.RunMemPackage(&std.MemPackage{
+		Type: gno.MPUserProd,
+		Name: "nativetest",
+		Path: pkgPath,
+		Files: []*std.MemFile{
+			{Name: "a.gno", Body: `package nativetest
+
+func add(a, b int) int
+func join(xs []string) (string, int)
+func mismatch(a int) int
+
+func Sum() int { return add(40, 2) }
+
+func Join() string {
+	s, n := join([]string{"a", "b", "c"})
+	return s + ":" + string(rune('0'+n))
+}
+
+func Mismatch() int { return mismatch(1) }
+`},
+		},
+	}, true)
+
+	res := m.Eval(gno.Call(gno.X("Sum")))
+	require.Len(t, res, 1)
+	assert.Equal(t, int64(42), res[0].GetInt())
+
+	res = m.Eval(gno.Call(gno.X("Join")))
+	require.Len(t, res, 1)
+	assert.Equal(t, "a,b,c:3", res[0].GetString())
+
+	perr := func() (p string) {
+		defer func() { p = fmt.Sprint(recover()) }()
+		m.Eval(gno.Call(gno.X("Mismatch")))
+		return ""
+	}()
+	assert.Contains(t, perr, "parameter 0: Gno type int does not match Go type int64")
+}
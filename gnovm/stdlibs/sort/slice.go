@@ -0,0 +1,43 @@
+package sort
+
+import (
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+)
+
+func X_sliceLen(m *gno.Machine, x gno.TypedValue) int {
+	if _, ok := gno.BaseOf(x.T).(*gno.SliceType); !ok {
+		m.Panic(typedString("sort: expected a slice, got " + x.String()))
+		return 0
+	}
+	return x.GetLength()
+}
+
+func X_sliceSwap(m *gno.Machine, x gno.TypedValue, i, j int) {
+	sv, ok := x.V.(*gno.SliceValue)
+	if !ok || i < 0 || j < 0 || i >= sv.Length || j >= sv.Length {
+		m.Panic(typedString("sort: slice index out of range"))
+		return
+	}
+	if i == j {
+		return
+	}
+	if m.IsReadonly(&x) {
+		m.Panic(typedString("sort: cannot modify a slice of another realm"))
+		return
+	}
+	base := sv.GetBase(m.Store)
+	i, j = sv.Offset+i, sv.Offset+j
+	if base.Data != nil {
+		base.Data[i], base.Data[j] = base.Data[j], base.Data[i]
+	} else {
+		base.List[i], base.List[j] = base.List[j], base.List[i]
+	}
+	// The elements are the same, only their order changed.
+	m.Realm.DidUpdate(base, nil, nil)
+}
+
+func typedString(s string) gno.TypedValue {
+	tv := gno.TypedValue{T: gno.StringType}
+	tv.SetString(gno.StringValue(s))
+	return tv
+}
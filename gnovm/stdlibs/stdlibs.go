@@ -30,13 +30,25 @@ func GetContext(m *gno.Machine) ExecContext {
 }
 
 // FindNative returns the NativeFunc associated with the given pkgPath+name
-// combination. If there is none, FindNative returns nil.
+// combination, generated or added with [RegisterNative]. If there is none,
+// FindNative returns nil.
 func FindNative(pkgPath string, name gno.Name) *NativeFunc {
+	registeredMu.RLock()
+	defer registeredMu.RUnlock()
+	return findNative(pkgPath, name)
+}
+
+func findNative(pkgPath string, name gno.Name) *NativeFunc {
 	for i, nf := range nativeFuncs {
 		if nf.gnoPkg == pkgPath && name == nf.gnoFunc {
 			return &nativeFuncs[i]
 		}
 	}
+	for _, nf := range registeredNatives {
+		if nf.gnoPkg == pkgPath && name == nf.gnoFunc {
+			return nf
+		}
+	}
 	return nil
 }
 
@@ -57,5 +69,12 @@ func HasNativePkg(pkgPath string) bool {
 			return true
 		}
 	}
+	registeredMu.RLock()
+	defer registeredMu.RUnlock()
+	for _, nf := range registeredNatives {
+		if nf.gnoPkg == pkgPath {
+			return true
+		}
+	}
 	return false
 }
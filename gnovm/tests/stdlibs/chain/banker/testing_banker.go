@@ -0,0 +1,38 @@
+package banker
+
+import (
+	"fmt"
+
+	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	"github.com/gnolang/gno/gnovm/stdlibs/chain/banker"
+	"github.com/gnolang/gno/gnovm/tests/stdlibs/chain/runtime"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
+// btOriginSend mirrors the banker type of the same name in stdlibs.
+const btOriginSend uint8 = 1
+
+// X_bankerSendCoins fails the sends scripted to fail with testing.FailSends,
+// and those exceeding the balance of the sender, with a Gno panic, so that
+// tests can recover them and cover how realms handle failed transfers.
+func X_bankerSendCoins(m *gno.Machine, bt uint8, fromS, toS string, denoms []string, amounts []int64) {
+	ctx := m.Context.(*runtime.TestExecContext)
+	if tb, ok := ctx.Banker.(*runtime.TestBanker); ok {
+		from := crypto.Bech32Address(fromS)
+		if reason, ok := tb.SendFailure(from); ok {
+			m.PanicString(reason)
+			return
+		}
+		amt := banker.CompactCoins(denoms, amounts)
+		if bt == btOriginSend && !ctx.OriginSend.IsAllGTE((*ctx.OriginSendSpent).Add(amt)) {
+			// Exceeds the limit of the origin send, reported by stdlibs.
+			banker.X_bankerSendCoins(m, bt, fromS, toS, denoms, amounts)
+			return
+		}
+		if coins := tb.GetCoins(from); !coins.IsAllGTE(amt) {
+			m.PanicString(fmt.Sprintf("insufficient account funds; %s < %s", coins, amt))
+			return
+		}
+	}
+	banker.X_bankerSendCoins(m, bt, fromS, toS, denoms, amounts)
+}
@@ -2,6 +2,7 @@ package runtime
 
 import (
 	"fmt"
+	"maps"
 	"strings"
 
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
@@ -166,9 +167,20 @@ func X_getRealm(m *gno.Machine, height int) (addr string, pkgPath string) {
 // TestBanker is a banker that can be used as a mock banker in test contexts.
 type TestBanker struct {
 	CoinTable map[crypto.Bech32Address]tm2std.Coins
+
+	// SendFailures holds the reasons with which the sends from an address
+	// fail, as scripted by testing.FailSends.
+	SendFailures map[crypto.Bech32Address]string
+
+	// Allowances holds the allowances given with Banker.Approve, by owner
+	// and spender.
+	Allowances map[[2]crypto.Bech32Address]tm2std.Coins
 }
 
-var _ stdlibs.BankerInterface = &TestBanker{}
+var (
+	_ stdlibs.BankerInterface = &TestBanker{}
+	_ gno.CallCacheWrapper    = &TestBanker{}
+)
 
 // GetCoins implements the Banker interface.
 func (tb *TestBanker) GetCoins(addr crypto.Bech32Address) (dst tm2std.Coins) {
@@ -198,6 +210,26 @@ func (tb *TestBanker) SendCoins(from, to crypto.Bech32Address, amt tm2std.Coins)
 	tb.CoinTable[to] = tsum
 }
 
+// SendFailure returns the reason with which the sends from addr are scripted
+// to fail, if any.
+func (tb *TestBanker) SendFailure(addr crypto.Bech32Address) (reason string, ok bool) {
+	reason, ok = tb.SendFailures[addr]
+	return
+}
+
+// FailSends makes the sends from addr fail with reason. An empty reason makes
+// them succeed again.
+func (tb *TestBanker) FailSends(addr crypto.Bech32Address, reason string) {
+	if reason == "" {
+		delete(tb.SendFailures, addr)
+		return
+	}
+	if tb.SendFailures == nil {
+		tb.SendFailures = make(map[crypto.Bech32Address]string)
+	}
+	tb.SendFailures[addr] = reason
+}
+
 // TotalCoin implements the Banker interface.
 func (tb *TestBanker) TotalCoin(denom string) int64 {
 	panic("not yet implemented")
@@ -217,6 +249,36 @@ func (tb *TestBanker) RemoveCoin(addr crypto.Bech32Address, denom string, amt in
 	tb.CoinTable[addr] = rest
 }
 
+// Approve implements the Banker interface.
+func (tb *TestBanker) Approve(owner, spender crypto.Bech32Address, amt tm2std.Coins) {
+	key := [2]crypto.Bech32Address{owner, spender}
+	if amt.IsZero() {
+		delete(tb.Allowances, key)
+		return
+	}
+	if tb.Allowances == nil {
+		tb.Allowances = make(map[[2]crypto.Bech32Address]tm2std.Coins)
+	}
+	tb.Allowances[key] = amt
+}
+
+// Allowance implements the Banker interface.
+func (tb *TestBanker) Allowance(owner, spender crypto.Bech32Address) tm2std.Coins {
+	return tb.Allowances[[2]crypto.Bech32Address{owner, spender}]
+}
+
+// CacheWrapCall implements [gno.CallCacheWrapper], so that the coins sent and
+// the allowances given by a call crossing a realm boundary are restored if a
+// panic leaves it.
+func (tb *TestBanker) CacheWrapCall() (write, discard func()) {
+	coins, allowances := maps.Clone(tb.CoinTable), maps.Clone(tb.Allowances)
+	write = func() {}
+	discard = func() {
+		tb.CoinTable, tb.Allowances = coins, allowances
+	}
+	return write, discard
+}
+
 func X_testIssueCoins(m *gno.Machine, addr string, denom []string, amt []int64) {
 	ctx := m.Context.(*TestExecContext)
 	banker := ctx.Banker
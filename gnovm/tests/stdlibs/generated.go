@@ -7,6 +7,7 @@ import (
 	"reflect"
 
 	gno "github.com/gnolang/gno/gnovm/pkg/gnolang"
+	testlibs_chain_banker "github.com/gnolang/gno/gnovm/tests/stdlibs/chain/banker"
 	testlibs_chain_runtime "github.com/gnolang/gno/gnovm/tests/stdlibs/chain/runtime"
 	testlibs_fmt "github.com/gnolang/gno/gnovm/tests/stdlibs/fmt"
 	testlibs_os "github.com/gnolang/gno/gnovm/tests/stdlibs/os"
@@ -22,6 +23,7 @@ type NativeFunc struct {
 	params     []gno.FieldTypeExpr
 	results    []gno.FieldTypeExpr
 	hasMachine bool
+	typedValue bool
 	f          func(m *gno.Machine)
 }
 
@@ -32,13 +34,70 @@ func (n *NativeFunc) HasMachineParam() bool {
 	return n.hasMachine
 }
 
+// HasTypedValue returns whether any of the parameters or results of the Go
+// version of this function is a gno.TypedValue, which Go code can't provide
+// or use without a Gno machine.
+func (n *NativeFunc) HasTypedValue() bool {
+	return n.typedValue
+}
+
 var nativeFuncs = [...]NativeFunc{
+	{
+		"chain/banker",
+		"bankerSendCoins",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("uint8")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("string")},
+			{NameExpr: *gno.Nx("p2"), Type: gno.X("string")},
+			{NameExpr: *gno.Nx("p3"), Type: gno.X("[]string")},
+			{NameExpr: *gno.Nx("p4"), Type: gno.X("[]int64")},
+		},
+		[]gno.FieldTypeExpr{},
+		true,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  uint8
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  string
+				rp1 = reflect.ValueOf(&p1).Elem()
+				p2  string
+				rp2 = reflect.ValueOf(&p2).Elem()
+				p3  []string
+				rp3 = reflect.ValueOf(&p3).Elem()
+				p4  []int64
+				rp4 = reflect.ValueOf(&p4).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+			tv2 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 2, "")).TV
+			tv2.DeepFill(m.Store)
+			gno.Gno2GoValue(tv2, rp2)
+			tv3 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 3, "")).TV
+			tv3.DeepFill(m.Store)
+			gno.Gno2GoValue(tv3, rp3)
+			tv4 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 4, "")).TV
+			tv4.DeepFill(m.Store)
+			gno.Gno2GoValue(tv4, rp4)
+
+			testlibs_chain_banker.X_bankerSendCoins(
+				m,
+				p0, p1, p2, p3, p4)
+		},
+	},
 	{
 		"chain/runtime",
 		"AssertOriginCall",
 		[]gno.FieldTypeExpr{},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			testlibs_chain_runtime.AssertOriginCall(
 				m,
@@ -56,6 +115,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r1"), Type: gno.X("string")},
 		},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -93,6 +153,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("string")},
 		},
 		false,
+		true,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
@@ -120,6 +181,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r4"), Type: gno.X("int")},
 		},
 		false,
+		true,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
@@ -159,6 +221,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("uint64")},
 		},
 		true,
+		true,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
@@ -184,6 +247,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("any")},
 		},
 		false,
+		true,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
@@ -204,6 +268,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r1"), Type: gno.X("[]any")},
 		},
 		false,
+		true,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
@@ -225,6 +290,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("any")},
 		},
 		true,
+		true,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -256,6 +322,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r1"), Type: gno.X("any")},
 		},
 		false,
+		true,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -289,6 +356,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r1"), Type: gno.X("bool")},
 		},
 		false,
+		true,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			p0 := *(b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV)
@@ -314,6 +382,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("int")},
 		},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -349,6 +418,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -365,6 +435,39 @@ var nativeFuncs = [...]NativeFunc{
 				p0)
 		},
 	},
+	{
+		"testing",
+		"vmStats",
+		[]gno.FieldTypeExpr{},
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("r0"), Type: gno.X("int64")},
+			{NameExpr: *gno.Nx("r1"), Type: gno.X("int64")},
+			{NameExpr: *gno.Nx("r2"), Type: gno.X("int64")},
+		},
+		true,
+		false,
+		func(m *gno.Machine) {
+			r0, r1, r2 := testlibs_testing.X_vmStats(
+				m,
+			)
+
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r0).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r1).Elem(),
+			))
+			m.PushValue(gno.Go2GnoValue(
+				m.Alloc,
+				m.Store,
+				reflect.ValueOf(&r2).Elem(),
+			))
+		},
+	},
 	{
 		"testing",
 		"getContext",
@@ -381,6 +484,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r8"), Type: gno.X("int64")},
 		},
 		true,
+		false,
 		func(m *gno.Machine) {
 			r0, r1, r2, r3, r4, r5, r6, r7, r8 := testlibs_testing.X_getContext(
 				m,
@@ -451,6 +555,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -527,6 +632,7 @@ var nativeFuncs = [...]NativeFunc{
 		},
 		[]gno.FieldTypeExpr{},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -553,6 +659,37 @@ var nativeFuncs = [...]NativeFunc{
 				p0, p1, p2)
 		},
 	},
+	{
+		"testing",
+		"testFailSends",
+		[]gno.FieldTypeExpr{
+			{NameExpr: *gno.Nx("p0"), Type: gno.X("string")},
+			{NameExpr: *gno.Nx("p1"), Type: gno.X("string")},
+		},
+		[]gno.FieldTypeExpr{},
+		true,
+		false,
+		func(m *gno.Machine) {
+			b := m.LastBlock()
+			var (
+				p0  string
+				rp0 = reflect.ValueOf(&p0).Elem()
+				p1  string
+				rp1 = reflect.ValueOf(&p1).Elem()
+			)
+
+			tv0 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 0, "")).TV
+			tv0.DeepFill(m.Store)
+			gno.Gno2GoValue(tv0, rp0)
+			tv1 := b.GetPointerTo(nil, gno.NewValuePathBlock(1, 1, "")).TV
+			tv1.DeepFill(m.Store)
+			gno.Gno2GoValue(tv1, rp1)
+
+			testlibs_testing.X_testFailSends(
+				m,
+				p0, p1)
+		},
+	},
 	{
 		"testing",
 		"newRealm",
@@ -564,6 +701,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("runtime.Realm")},
 		},
 		true,
+		true,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -597,6 +735,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("bool")},
 		},
 		true,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -627,6 +766,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("int64")},
 		},
 		false,
+		false,
 		func(m *gno.Machine) {
 			r0 := testlibs_testing.X_unixNano()
 
@@ -646,6 +786,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r1"), Type: gno.X("string")},
 		},
 		true,
+		true,
 		func(m *gno.Machine) {
 			r0, r1 := testlibs_testing.X_recoverWithStacktrace(
 				m,
@@ -671,6 +812,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r1"), Type: gno.X("string")},
 		},
 		false,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -711,6 +853,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("bool")},
 		},
 		false,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -741,6 +884,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("bool")},
 		},
 		false,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -771,6 +915,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("rune")},
 		},
 		false,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -801,6 +946,7 @@ var nativeFuncs = [...]NativeFunc{
 			{NameExpr: *gno.Nx("r0"), Type: gno.X("bool")},
 		},
 		false,
+		false,
 		func(m *gno.Machine) {
 			b := m.LastBlock()
 			var (
@@ -94,6 +94,16 @@ func X_testIssueCoins(m *gno.Machine, addr string, denom []string, amt []int64)
 	}
 }
 
+func X_testFailSends(m *gno.Machine, addr string, reason string) {
+	ctx := m.Context.(*runtime.TestExecContext)
+	tb, ok := ctx.Banker.(*runtime.TestBanker)
+	if !ok {
+		m.PanicString("FailSends requires the test banker")
+		return
+	}
+	tb.FailSends(crypto.Bech32Address(addr), reason)
+}
+
 func X_newRealm(m *gno.Machine, addr, pkgPath string) gno.TypedValue {
 	return gno.TypedValue{
 		// testing imports chain/runtime, so this type is always available.
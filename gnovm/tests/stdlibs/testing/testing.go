@@ -26,3 +26,14 @@ func X_recoverWithStacktrace(m *gnolang.Machine) (gnolang.TypedValue, string) {
 	}
 	return exception.Value, exception.Stacktrace.String()
 }
+
+func X_vmStats(m *gnolang.Machine) (allocBytes, allocs, gas int64) {
+	if m.Alloc != nil {
+		_, allocBytes = m.Alloc.Status()
+		allocs = m.Alloc.NumAllocs()
+	}
+	if m.GasMeter != nil {
+		gas = m.GasMeter.GasConsumed()
+	}
+	return
+}
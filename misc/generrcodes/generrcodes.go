@@ -0,0 +1,150 @@
+// Command generrcodes checks the error codes of the modules of gno.land, and
+// generates their reference, for client SDKs to branch on them.
+//
+// The codes are registered with pkg/errcode, which already rejects colliding
+// codes when the modules are loaded; generrcodes also checks that all the
+// errors of their amino packages, returned in ABCI responses, have a code.
+package main
+
+import (
+	"bytes"
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"reflect"
+	"strings"
+
+	"github.com/gnolang/gno/gno.land/pkg/sdk/vm"
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/errcode"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// packages are the amino packages whose errors must have a code.
+var packages = []*amino.Package{
+	std.Package,
+	sdk.Package,
+	bank.Package,
+	vm.Package,
+}
+
+type genCfg struct {
+	out   string
+	check bool
+}
+
+func (c *genCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(&c.out, "out", "../../docs/resources/error-codes.md", "file to write the reference of the error codes to")
+	fs.BoolVar(&c.check, "check", false, "only check that the reference is up to date")
+}
+
+func main() {
+	cfg := &genCfg{}
+	cmd := commands.NewCommand(
+		commands.Metadata{
+			LongHelp: "Checks and generates the reference of the error codes returned in ABCI responses",
+		},
+		cfg,
+		func(_ context.Context, _ []string) error {
+			return execGen(cfg)
+		},
+	)
+
+	cmd.Execute(context.Background(), os.Args[1:])
+}
+
+func execGen(cfg *genCfg) error {
+	if err := checkCodes(packages); err != nil {
+		return err
+	}
+	ref := reference(packages)
+
+	if cfg.check {
+		existing, err := os.ReadFile(cfg.out)
+		if err != nil {
+			return err
+		}
+		if !bytes.Equal(existing, ref) {
+			return fmt.Errorf("%s is out of date; run generrcodes", cfg.out)
+		}
+		return nil
+	}
+	return os.WriteFile(cfg.out, ref, 0o644)
+}
+
+var errorType = reflect.TypeOf((*abci.Error)(nil)).Elem()
+
+// abciErrors returns the types of pkgs which are ABCI errors.
+func abciErrors(pkgs []*amino.Package) (types []reflect.Type) {
+	for _, pkg := range pkgs {
+		for _, rt := range pkg.ReflectTypes() {
+			if rt.Implements(errorType) {
+				types = append(types, rt)
+			}
+		}
+	}
+	return types
+}
+
+// checkCodes returns an error listing the ABCI errors of pkgs without a code,
+// and the errors with a code which aren't registered in pkgs, and so can't be
+// encoded in ABCI responses.
+func checkCodes(pkgs []*amino.Package) error {
+	var problems []string
+	registered := make(map[reflect.Type]bool)
+	for _, rt := range abciErrors(pkgs) {
+		registered[rt] = true
+		err := reflect.Zero(rt).Interface().(error)
+		if _, ok := errcode.Lookup(err); !ok {
+			problems = append(problems, rt.String()+" has no code")
+		}
+	}
+	for _, e := range errcode.Entries() {
+		if !registered[e.Type] {
+			problems = append(problems, fmt.Sprintf("%s (%s) is not registered in an amino package", e.Type, e.Code))
+		}
+	}
+	if len(problems) > 0 {
+		return fmt.Errorf("inconsistent error codes:\n%s", strings.Join(problems, "\n"))
+	}
+	return nil
+}
+
+// reference returns the markdown reference of the registered error codes,
+// with the amino type URLs of the errors of pkgs.
+func reference(pkgs []*amino.Package) []byte {
+	typeURLs := make(map[reflect.Type]string)
+	for _, pkg := range pkgs {
+		for _, rt := range abciErrors([]*amino.Package{pkg}) {
+			typeURLs[rt] = pkg.TypeURLForType(rt)
+		}
+	}
+
+	var buf bytes.Buffer
+	buf.WriteString(`# Error codes
+
+<!-- Code generated by misc/generrcodes; DO NOT EDIT. -->
+
+Failed transactions and queries return an error in their ABCI response,
+along with its codespace and code, which identify its type. Unlike messages,
+codes are stable: clients can rely on them to handle errors.
+
+Errors of a type without a code, such as the errors of Gno code, have the
+codespace ` + "`undefined`" + ` and the code 1.
+
+| Codespace | Code | Type | Message |
+|-----------|------|------|---------|
+`)
+	entries := errcode.Entries()
+	for _, e := range entries {
+		// The first line, for errors with details.
+		msg, _, _ := strings.Cut(reflect.Zero(e.Type).Interface().(error).Error(), "\n")
+		fmt.Fprintf(&buf, "| `%s` | %d | `%s` | %s |\n", e.Codespace, e.Code.Code, typeURLs[e.Type], msg)
+	}
+	return buf.Bytes()
+}
@@ -0,0 +1,19 @@
+package main
+
+import (
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCheckCodes(t *testing.T) {
+	require.NoError(t, checkCodes(packages))
+}
+
+func TestReferenceUpToDate(t *testing.T) {
+	existing, err := os.ReadFile("../../docs/resources/error-codes.md")
+	require.NoError(t, err)
+	assert.Equal(t, string(existing), string(reference(packages)), "run `make` in misc/generrcodes")
+}
@@ -31,6 +31,22 @@ type mappingType struct {
 	IsTypedValue bool
 }
 
+// HasTypedValue returns whether any of the parameters or results of the Go
+// function is a gno.TypedValue.
+func (m *mapping) HasTypedValue() bool {
+	for _, p := range m.Params {
+		if p.IsTypedValue {
+			return true
+		}
+	}
+	for _, r := range m.Results {
+		if r.IsTypedValue {
+			return true
+		}
+	}
+	return false
+}
+
 func (mt mappingType) GoQualifiedName() string {
 	return types.ExprString(mt.Type)
 }
@@ -97,6 +97,10 @@ func Unmarshal(bz []byte, ptr any) error {
 	return gcdc.Unmarshal(bz, ptr)
 }
 
+func UnmarshalLimited(bz []byte, ptr any, limits DecodeLimits) error {
+	return gcdc.UnmarshalLimited(bz, ptr, limits)
+}
+
 func MustUnmarshal(bz []byte, ptr any) {
 	gcdc.MustUnmarshal(bz, ptr)
 }
@@ -516,6 +520,14 @@ func (cdc *Codec) UnmarshalSized(bz []byte, ptr any) error {
 // If maxSize is 0, there is no limit (not recommended).
 func (cdc *Codec) UnmarshalSizedReader(r io.Reader, ptr any,
 	maxSize int64,
+) (n int64, err error) {
+	return cdc.unmarshalSizedReader(r, ptr, maxSize, DecodeLimits{})
+}
+
+// Like UnmarshalSizedReader, but also limits the decoding of the object with
+// limits, unless they are zero.
+func (cdc *Codec) unmarshalSizedReader(r io.Reader, ptr any,
+	maxSize int64, limits DecodeLimits,
 ) (n int64, err error) {
 	if maxSize < 0 {
 		panic("maxSize cannot be negative.")
@@ -573,7 +585,11 @@ func (cdc *Codec) UnmarshalSizedReader(r io.Reader, ptr any,
 	n += l
 
 	// Decode.
-	err = cdc.Unmarshal(bz, ptr)
+	if limits == (DecodeLimits{}) {
+		err = cdc.Unmarshal(bz, ptr)
+	} else {
+		err = cdc.UnmarshalLimited(bz, ptr, limits)
+	}
 	return n, err
 }
 
@@ -621,11 +637,23 @@ func (cdc *Codec) Unmarshal(bz []byte, ptr any) error {
 		// Else, fall back to using reflection for native primitive types.
 	}
 
-	return cdc.unmarshalReflect(bz, ptr)
+	return cdc.unmarshalReflect(bz, ptr, nil)
+}
+
+// UnmarshalLimited is like Unmarshal, but returns an error wrapping
+// ErrDecodeLimit if decoding bz exceeds limits. It should be used to decode
+// untrusted bytes.
+// UnmarshalLimited will panic if ptr is a nil-pointer.
+func (cdc *Codec) UnmarshalLimited(bz []byte, ptr any, limits DecodeLimits) error {
+	cdc.doAutoseal()
+
+	// The protobuf bindings don't track the decoding, so reflection is
+	// always used.
+	return cdc.unmarshalReflect(bz, ptr, newDecodeState(limits))
 }
 
 // Use reflection.
-func (cdc *Codec) unmarshalReflect(bz []byte, ptr any) error {
+func (cdc *Codec) unmarshalReflect(bz []byte, ptr any, ds *decodeState) error {
 	rv := reflect.ValueOf(ptr)
 	if rv.Kind() != reflect.Ptr {
 		return ErrNoPointer
@@ -675,7 +703,7 @@ func (cdc *Codec) unmarshalReflect(bz []byte, ptr any) error {
 	}
 
 	// Decode contents into rv.
-	n, err := cdc.decodeReflectBinary(bz, info, rv, FieldOptions{BinFieldNum: 1}, bare, 0)
+	n, err := cdc.decodeReflectBinary(bz, info, rv, FieldOptions{BinFieldNum: 1}, bare, 0, ds)
 	if err != nil {
 		return fmt.Errorf(
 			"unmarshal to %v failed after %d bytes (%w): %X",
@@ -750,7 +778,7 @@ func (cdc *Codec) UnmarshalAny(bz []byte, ptr any) (err error) {
 		return err
 	}
 
-	_, err = cdc.decodeReflectBinaryInterface(bz, iinfo, rv, FieldOptions{}, true)
+	_, err = cdc.decodeReflectBinaryInterface(bz, iinfo, rv, FieldOptions{}, true, nil)
 	return
 }
 
@@ -763,7 +791,7 @@ func (cdc *Codec) UnmarshalAny2(typeURL string, value []byte, ptr any) (err erro
 		return ErrNoPointer
 	}
 	rv = rv.Elem()
-	_, err = cdc.decodeReflectBinaryAny(typeURL, value, rv, FieldOptions{})
+	_, err = cdc.decodeReflectBinaryAny(typeURL, value, rv, FieldOptions{}, nil)
 	return
 }
 
@@ -26,7 +26,7 @@ const bdOptionByte = 0x01
 //
 // CONTRACT: rv.CanAddr() is true.
 func (cdc *Codec) decodeReflectBinary(bz []byte, info *TypeInfo,
-	rv reflect.Value, fopts FieldOptions, bare bool, options uint64,
+	rv reflect.Value, fopts FieldOptions, bare bool, options uint64, ds *decodeState,
 ) (n int, err error) {
 	if !rv.CanAddr() {
 		panic("rv not addressable")
@@ -43,7 +43,17 @@ func (cdc *Codec) decodeReflectBinary(bz []byte, info *TypeInfo,
 	}
 	var _n int
 
+	if err = ds.enter(); err != nil {
+		return
+	}
+	defer ds.leave()
+
 	// Dereference-and-construct if pointer.
+	if rv.Kind() == reflect.Ptr && rv.IsNil() {
+		if err = ds.allocateType(rv.Type().Elem()); err != nil {
+			return
+		}
+	}
 	rv = maybeDerefAndConstruct(rv)
 
 	// Handle the most special case, "well known".
@@ -64,7 +74,7 @@ func (cdc *Codec) decodeReflectBinary(bz []byte, info *TypeInfo,
 		if err != nil {
 			return
 		}
-		_n, err = cdc.decodeReflectBinary(bz, rinfo, rrv, fopts, bare, options)
+		_n, err = cdc.decodeReflectBinary(bz, rinfo, rrv, fopts, bare, options, ds)
 		if slide(&bz, &n, _n) && err != nil {
 			return
 		}
@@ -83,17 +93,17 @@ func (cdc *Codec) decodeReflectBinary(bz []byte, info *TypeInfo,
 	// Complex
 
 	case reflect.Interface:
-		_n, err = cdc.decodeReflectBinaryInterface(bz, info, rv, fopts, bare)
+		_n, err = cdc.decodeReflectBinaryInterface(bz, info, rv, fopts, bare, ds)
 		n += _n
 		return
 
 	case reflect.Array:
 		ert := info.Type.Elem()
 		if ert.Kind() == reflect.Uint8 {
-			_n, err = cdc.decodeReflectBinaryByteArray(bz, info, rv, fopts)
+			_n, err = cdc.decodeReflectBinaryByteArray(bz, info, rv, fopts, ds)
 			n += _n
 		} else {
-			_n, err = cdc.decodeReflectBinaryArray(bz, info, rv, fopts, bare)
+			_n, err = cdc.decodeReflectBinaryArray(bz, info, rv, fopts, bare, ds)
 			n += _n
 		}
 		return
@@ -101,16 +111,16 @@ func (cdc *Codec) decodeReflectBinary(bz []byte, info *TypeInfo,
 	case reflect.Slice:
 		ert := info.Type.Elem()
 		if ert.Kind() == reflect.Uint8 {
-			_n, err = cdc.decodeReflectBinaryByteSlice(bz, info, rv, fopts)
+			_n, err = cdc.decodeReflectBinaryByteSlice(bz, info, rv, fopts, ds)
 			n += _n
 		} else {
-			_n, err = cdc.decodeReflectBinarySlice(bz, info, rv, fopts, bare)
+			_n, err = cdc.decodeReflectBinarySlice(bz, info, rv, fopts, bare, ds)
 			n += _n
 		}
 		return
 
 	case reflect.Struct:
-		_n, err = cdc.decodeReflectBinaryStruct(bz, info, rv, fopts, bare)
+		_n, err = cdc.decodeReflectBinaryStruct(bz, info, rv, fopts, bare, ds)
 		n += _n
 		return
 
@@ -294,6 +304,7 @@ func (cdc *Codec) decodeReflectBinary(bz []byte, info *TypeInfo,
 			return
 		}
 		rv.SetString(str)
+		err = ds.allocate(len(str))
 		return
 
 	default:
@@ -304,7 +315,7 @@ func (cdc *Codec) decodeReflectBinary(bz []byte, info *TypeInfo,
 // CONTRACT: rv.CanAddr() is true.
 // CONTRACT: rv.Kind() == reflect.Interface.
 func (cdc *Codec) decodeReflectBinaryInterface(bz []byte, iinfo *TypeInfo, rv reflect.Value,
-	fopts FieldOptions, bare bool,
+	fopts FieldOptions, bare bool, ds *decodeState,
 ) (n int, err error) {
 	if !rv.CanAddr() {
 		panic("rv not addressable")
@@ -324,7 +335,7 @@ func (cdc *Codec) decodeReflectBinaryInterface(bz []byte, iinfo *TypeInfo, rv re
 	}
 
 	// Strip if needed.
-	bz, err = decodeMaybeBare(bz, &n, bare)
+	bz, err = decodeMaybeBare(bz, &n, bare, ds)
 	if err != nil {
 		return
 	}
@@ -375,6 +386,9 @@ func (cdc *Codec) decodeReflectBinaryInterface(bz []byte, iinfo *TypeInfo, rv re
 		if slide(&bz, nil, _n) && err != nil {
 			return
 		}
+		if err = ds.allocate(len(value)); err != nil {
+			return
+		}
 		// Earlier, we set bz to the byteslice read from
 		// buf.  Ensure that all of bz was consumed.
 		if len(bz) > 0 {
@@ -388,7 +402,7 @@ func (cdc *Codec) decodeReflectBinaryInterface(bz []byte, iinfo *TypeInfo, rv re
 	}
 
 	// Decode typeURL and value to rv.
-	_n, err = cdc.decodeReflectBinaryAny(typeURL, value, rv, fopts)
+	_n, err = cdc.decodeReflectBinaryAny(typeURL, value, rv, fopts, ds)
 	if slide(&value, &n, _n) && err != nil {
 		return
 	}
@@ -399,7 +413,7 @@ func (cdc *Codec) decodeReflectBinaryInterface(bz []byte, iinfo *TypeInfo, rv re
 // Returns the number of bytes read from value.
 // CONTRACT: rv.CanAddr() is true.
 // CONTRACT: rv.Kind() == reflect.Interface.
-func (cdc *Codec) decodeReflectBinaryAny(typeURL string, value []byte, rv reflect.Value, fopts FieldOptions) (n int, err error) {
+func (cdc *Codec) decodeReflectBinaryAny(typeURL string, value []byte, rv reflect.Value, fopts FieldOptions, ds *decodeState) (n int, err error) {
 	// Invalid typeURL value is invalid.
 	if !IsASCIIText(typeURL) {
 		err = fmt.Errorf("invalid type_url string bytes %X", typeURL)
@@ -415,6 +429,9 @@ func (cdc *Codec) decodeReflectBinaryAny(typeURL string, value []byte, rv reflec
 	}
 
 	// Construct the concrete type value.
+	if err = ds.allocateType(cinfo.Type); err != nil {
+		return
+	}
 	crv, irvSet := constructConcreteType(cinfo)
 
 	// Special case when value is default empty value.
@@ -467,7 +484,7 @@ func (cdc *Codec) decodeReflectBinaryAny(typeURL string, value []byte, rv reflec
 	// Decode into the concrete type.
 	// Here is where we consume the value bytes, which are necessarily length
 	// prefixed, due to the type of field 2, so bareValue is false.
-	_n, err := cdc.decodeReflectBinary(value, cinfo, crv, fopts, bareValue, 0)
+	_n, err := cdc.decodeReflectBinary(value, cinfo, crv, fopts, bareValue, 0, ds)
 	if slide(&value, &n, _n) && err != nil {
 		rv.Set(irvSet) // Helps with debugging
 		return
@@ -490,7 +507,7 @@ func (cdc *Codec) decodeReflectBinaryAny(typeURL string, value []byte, rv reflec
 
 // CONTRACT: rv.CanAddr() is true.
 func (cdc *Codec) decodeReflectBinaryByteArray(bz []byte, info *TypeInfo, rv reflect.Value,
-	fopts FieldOptions,
+	fopts FieldOptions, ds *decodeState,
 ) (n int, err error) {
 	if !rv.CanAddr() {
 		panic("rv not addressable")
@@ -515,6 +532,9 @@ func (cdc *Codec) decodeReflectBinaryByteArray(bz []byte, info *TypeInfo, rv ref
 	if slide(&bz, &n, _n) && err != nil {
 		return
 	}
+	if err = ds.allocate(len(byteslice)); err != nil {
+		return
+	}
 	if len(byteslice) != length {
 		err = fmt.Errorf("mismatched byte array length: Expected %v, got %v",
 			length, len(byteslice))
@@ -529,7 +549,7 @@ func (cdc *Codec) decodeReflectBinaryByteArray(bz []byte, info *TypeInfo, rv ref
 // CONTRACT: rv.CanAddr() is true.
 // NOTE: Keep the code structure similar to decodeReflectBinarySlice.
 func (cdc *Codec) decodeReflectBinaryArray(bz []byte, info *TypeInfo, rv reflect.Value,
-	fopts FieldOptions, bare bool,
+	fopts FieldOptions, bare bool, ds *decodeState,
 ) (n int, err error) {
 	if !rv.CanAddr() {
 		panic("rv not addressable")
@@ -551,7 +571,7 @@ func (cdc *Codec) decodeReflectBinaryArray(bz []byte, info *TypeInfo, rv reflect
 	}
 
 	// Bare if needed.
-	bz, err = decodeMaybeBare(bz, &n, bare)
+	bz, err = decodeMaybeBare(bz, &n, bare, ds)
 	if err != nil {
 		return
 	}
@@ -570,7 +590,7 @@ func (cdc *Codec) decodeReflectBinaryArray(bz []byte, info *TypeInfo, rv reflect
 		for i := range length {
 			erv := rv.Index(i)
 			var _n int
-			_n, err = cdc.decodeReflectBinary(bz, einfo, erv, fopts, false, newoptions)
+			_n, err = cdc.decodeReflectBinary(bz, einfo, erv, fopts, false, newoptions, ds)
 			if slide(&bz, &n, _n) && err != nil {
 				err = fmt.Errorf("error reading array contents: %w", err)
 				return
@@ -646,7 +666,7 @@ func (cdc *Codec) decodeReflectBinaryArray(bz []byte, info *TypeInfo, rv reflect
 				// Read field value of implicit struct.
 				efopts := fopts
 				efopts.BinFieldNum = 0 // dontcare
-				_n, err = cdc.decodeReflectBinary(ibz, einfo, erv, efopts, false, 0)
+				_n, err = cdc.decodeReflectBinary(ibz, einfo, erv, efopts, false, 0, ds)
 				if slide(&ibz, &n, _n) && err != nil {
 					err = fmt.Errorf("error reading array contents: %w", err)
 					return
@@ -660,7 +680,7 @@ func (cdc *Codec) decodeReflectBinaryArray(bz []byte, info *TypeInfo, rv reflect
 				// General case
 				efopts := fopts
 				efopts.BinFieldNum = 1
-				_n, err = cdc.decodeReflectBinary(bz, einfo, erv, efopts, false, 0)
+				_n, err = cdc.decodeReflectBinary(bz, einfo, erv, efopts, false, 0, ds)
 				if slide(&bz, &n, _n) && err != nil {
 					err = fmt.Errorf("error reading array contents: %w", err)
 					return
@@ -687,7 +707,7 @@ func (cdc *Codec) decodeReflectBinaryArray(bz []byte, info *TypeInfo, rv reflect
 
 // CONTRACT: rv.CanAddr() is true.
 func (cdc *Codec) decodeReflectBinaryByteSlice(bz []byte, info *TypeInfo, rv reflect.Value,
-	fopts FieldOptions,
+	fopts FieldOptions, ds *decodeState,
 ) (n int, err error) {
 	if !rv.CanAddr() {
 		panic("rv not addressable")
@@ -717,6 +737,9 @@ func (cdc *Codec) decodeReflectBinaryByteSlice(bz []byte, info *TypeInfo, rv ref
 	if slide(&bz, &n, _n) && err != nil {
 		return
 	}
+	if err = ds.allocate(len(byteslice)); err != nil {
+		return
+	}
 	if len(byteslice) == 0 {
 		// Special case when length is 0.
 		// NOTE: We prefer nil slices.
@@ -730,7 +753,7 @@ func (cdc *Codec) decodeReflectBinaryByteSlice(bz []byte, info *TypeInfo, rv ref
 // CONTRACT: rv.CanAddr() is true.
 // NOTE: Keep the code structure similar to decodeReflectBinaryArray.
 func (cdc *Codec) decodeReflectBinarySlice(bz []byte, info *TypeInfo, rv reflect.Value,
-	fopts FieldOptions, bare bool,
+	fopts FieldOptions, bare bool, ds *decodeState,
 ) (n int, err error) {
 	if !rv.CanAddr() {
 		panic("rv not addressable")
@@ -756,7 +779,7 @@ func (cdc *Codec) decodeReflectBinarySlice(bz []byte, info *TypeInfo, rv reflect
 	srv := reflect.Zero(esrt)
 
 	// Strip if needed.
-	bz, err = decodeMaybeBare(bz, &n, bare)
+	bz, err = decodeMaybeBare(bz, &n, bare, ds)
 	if err != nil {
 		return
 	}
@@ -772,9 +795,12 @@ func (cdc *Codec) decodeReflectBinarySlice(bz []byte, info *TypeInfo, rv reflect
 	typ3 := einfo.GetTyp3(fopts)
 	if typ3 != Typ3ByteLength || (newoptions&beOptionByte > 0) {
 		// Read elems in packed form.
-		for len(bz) != 0 {
+		for i := 0; len(bz) != 0; i++ {
+			if err = ds.listElement(i, ert); err != nil {
+				return
+			}
 			erv, _n := reflect.New(ert).Elem(), int(0)
-			_n, err = cdc.decodeReflectBinary(bz, einfo, erv, fopts, false, newoptions)
+			_n, err = cdc.decodeReflectBinary(bz, einfo, erv, fopts, false, newoptions, ds)
 			if slide(&bz, &n, _n) && err != nil {
 				err = fmt.Errorf("error reading array contents: %w", err)
 				return
@@ -789,7 +815,7 @@ func (cdc *Codec) decodeReflectBinarySlice(bz []byte, info *TypeInfo, rv reflect
 			einfo.Elem.ReprType.GetTyp3(fopts) != Typ3ByteLength
 
 		// Read elements in unpacked form.
-		for len(bz) != 0 {
+		for i := 0; len(bz) != 0; i++ {
 			// Read field key (number and type).
 			var (
 				typ  Typ3
@@ -800,6 +826,9 @@ func (cdc *Codec) decodeReflectBinarySlice(bz []byte, info *TypeInfo, rv reflect
 			if fnum > fopts.BinFieldNum {
 				break // before sliding...
 			}
+			if err = ds.listElement(i, ert); err != nil {
+				return
+			}
 			if slide(&bz, &n, _n) && err != nil {
 				return
 			}
@@ -850,7 +879,7 @@ func (cdc *Codec) decodeReflectBinarySlice(bz []byte, info *TypeInfo, rv reflect
 				// Read field value of implicit struct.
 				efopts := fopts
 				efopts.BinFieldNum = 0 // dontcare
-				_n, err = cdc.decodeReflectBinary(ibz, einfo, erv, efopts, false, 0)
+				_n, err = cdc.decodeReflectBinary(ibz, einfo, erv, efopts, false, 0, ds)
 				if slide(&ibz, &n, _n) && err != nil {
 					err = fmt.Errorf("error reading slice contents: %w", err)
 					return
@@ -864,7 +893,7 @@ func (cdc *Codec) decodeReflectBinarySlice(bz []byte, info *TypeInfo, rv reflect
 				// General case
 				efopts := fopts
 				efopts.BinFieldNum = 1
-				_n, err = cdc.decodeReflectBinary(bz, einfo, erv, efopts, false, 0)
+				_n, err = cdc.decodeReflectBinary(bz, einfo, erv, efopts, false, 0, ds)
 				if slide(&bz, &n, _n) && err != nil {
 					err = fmt.Errorf("error reading slice contents: %w", err)
 					return
@@ -879,7 +908,7 @@ func (cdc *Codec) decodeReflectBinarySlice(bz []byte, info *TypeInfo, rv reflect
 
 // CONTRACT: rv.CanAddr() is true.
 func (cdc *Codec) decodeReflectBinaryStruct(bz []byte, info *TypeInfo, rv reflect.Value,
-	_ FieldOptions, bare bool,
+	_ FieldOptions, bare bool, ds *decodeState,
 ) (n int, err error) {
 	if !rv.CanAddr() {
 		panic("rv not addressable")
@@ -896,7 +925,7 @@ func (cdc *Codec) decodeReflectBinaryStruct(bz []byte, info *TypeInfo, rv reflec
 	// It's already implied, either by struct-key or list-element-type-byte.
 
 	// Strip if needed.
-	bz, err = decodeMaybeBare(bz, &n, bare)
+	bz, err = decodeMaybeBare(bz, &n, bare, ds)
 	if err != nil {
 		return
 	}
@@ -927,7 +956,7 @@ func (cdc *Codec) decodeReflectBinaryStruct(bz []byte, info *TypeInfo, rv reflec
 			}
 			// This is a list that was encoded unpacked, e.g.
 			// with repeated field entries for each list item.
-			_n, err = cdc.decodeReflectBinary(bz, finfo, frv, field.FieldOptions, true, 0)
+			_n, err = cdc.decodeReflectBinary(bz, finfo, frv, field.FieldOptions, true, 0, ds)
 			if slide(&bz, &n, _n) && err != nil {
 				return
 			}
@@ -969,7 +998,7 @@ func (cdc *Codec) decodeReflectBinaryStruct(bz []byte, info *TypeInfo, rv reflec
 				return
 			}
 			// Decode field into frv.
-			_n, err = cdc.decodeReflectBinary(bz, finfo, frv, field.FieldOptions, false, 0)
+			_n, err = cdc.decodeReflectBinary(bz, finfo, frv, field.FieldOptions, false, 0, ds)
 			if slide(&bz, &n, _n) && err != nil {
 				return
 			}
@@ -1054,7 +1083,7 @@ func decodeFieldNumberAndTyp3(bz []byte) (num uint32, typ Typ3, n int, err error
 // ----------------------------------------
 // Misc.
 
-func decodeMaybeBare(bz []byte, n *int, bare bool) ([]byte, error) {
+func decodeMaybeBare(bz []byte, n *int, bare bool, ds *decodeState) ([]byte, error) {
 	if bare {
 		return bz, nil
 	} else {
@@ -1070,6 +1099,9 @@ func decodeMaybeBare(bz []byte, n *int, bare bool) ([]byte, error) {
 		}
 		// This is a trick for debuggability -- we slide on &n more later.
 		*n += UvarintSize(uint64(len(buf)))
+		if err = ds.allocate(len(buf)); err != nil {
+			return bz, err
+		}
 		bz = buf
 		return bz, nil
 	}
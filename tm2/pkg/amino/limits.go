@@ -0,0 +1,92 @@
+package amino
+
+import (
+	"errors"
+	"fmt"
+	"reflect"
+)
+
+// ErrDecodeLimit is wrapped by the errors returned when decoding exceeds its
+// DecodeLimits.
+var ErrDecodeLimit = errors.New("decode limit exceeded")
+
+// DecodeLimits bounds the resources used to decode binary bytes, so that
+// untrusted bytes, like those of a transaction or of a peer message, can't
+// make the decoder recurse without bound or allocate much more memory than
+// their own size. A zero limit is no limit.
+type DecodeLimits struct {
+	// MaxDepth is the maximum nesting depth of the decoded values.
+	MaxDepth int
+	// MaxListLength is the maximum number of elements of a decoded list.
+	MaxListLength int
+	// MaxAlloc is the maximum number of bytes allocated by the decoding,
+	// counting the decoded values and the intermediate buffers.
+	MaxAlloc int64
+}
+
+// decodeState tracks the resources used by a decoding, against its limits.
+// A nil *decodeState has no limits.
+type decodeState struct {
+	limits DecodeLimits
+	depth  int
+	alloc  int64
+}
+
+func newDecodeState(limits DecodeLimits) *decodeState {
+	if limits == (DecodeLimits{}) {
+		return nil
+	}
+	return &decodeState{limits: limits}
+}
+
+// enter is called when decoding a value nested in the current one.
+func (ds *decodeState) enter() error {
+	if ds == nil {
+		return nil
+	}
+	ds.depth++
+	if ds.limits.MaxDepth > 0 && ds.depth > ds.limits.MaxDepth {
+		return fmt.Errorf("%w: nesting depth greater than %d", ErrDecodeLimit, ds.limits.MaxDepth)
+	}
+	return nil
+}
+
+// leave is called when the value entered last is decoded.
+func (ds *decodeState) leave() {
+	if ds == nil {
+		return
+	}
+	ds.depth--
+}
+
+// allocate records the allocation of size bytes.
+func (ds *decodeState) allocate(size int) error {
+	if ds == nil {
+		return nil
+	}
+	ds.alloc += int64(size)
+	if ds.limits.MaxAlloc > 0 && ds.alloc > ds.limits.MaxAlloc {
+		return fmt.Errorf("%w: allocation greater than %d bytes", ErrDecodeLimit, ds.limits.MaxAlloc)
+	}
+	return nil
+}
+
+// allocateType records the allocation of a value of type rt.
+func (ds *decodeState) allocateType(rt reflect.Type) error {
+	if ds == nil {
+		return nil
+	}
+	return ds.allocate(int(rt.Size()))
+}
+
+// listElement records the decoding of the i-th element of a list of elements
+// of type ert.
+func (ds *decodeState) listElement(i int, ert reflect.Type) error {
+	if ds == nil {
+		return nil
+	}
+	if ds.limits.MaxListLength > 0 && i >= ds.limits.MaxListLength {
+		return fmt.Errorf("%w: list longer than %d elements", ErrDecodeLimit, ds.limits.MaxListLength)
+	}
+	return ds.allocateType(ert)
+}
@@ -0,0 +1,156 @@
+package amino_test
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"reflect"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	amino "github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/amino/tests"
+)
+
+type limitsNested struct {
+	Value int64
+	Next  *limitsNested
+}
+
+type limitsList struct {
+	Values []int64
+	Names  []string
+}
+
+func TestUnmarshalLimitedDepth(t *testing.T) {
+	t.Parallel()
+
+	cdc := amino.NewCodec()
+	var nested *limitsNested
+	for i := range 50 {
+		nested = &limitsNested{Value: int64(i), Next: nested}
+	}
+	bz, err := cdc.Marshal(nested)
+	require.NoError(t, err)
+
+	var got limitsNested
+	err = cdc.UnmarshalLimited(bz, &got, amino.DecodeLimits{MaxDepth: 20})
+	require.ErrorIs(t, err, amino.ErrDecodeLimit)
+
+	got = limitsNested{}
+	require.NoError(t, cdc.UnmarshalLimited(bz, &got, amino.DecodeLimits{MaxDepth: 200}))
+	assert.Equal(t, *nested, got)
+}
+
+func TestUnmarshalLimitedListLength(t *testing.T) {
+	t.Parallel()
+
+	cdc := amino.NewCodec()
+	bz, err := cdc.Marshal(limitsList{Values: make([]int64, 100)})
+	require.NoError(t, err)
+
+	var got limitsList
+	err = cdc.UnmarshalLimited(bz, &got, amino.DecodeLimits{MaxListLength: 99})
+	require.ErrorIs(t, err, amino.ErrDecodeLimit)
+
+	got = limitsList{}
+	require.NoError(t, cdc.UnmarshalLimited(bz, &got, amino.DecodeLimits{MaxListLength: 100}))
+	assert.Len(t, got.Values, 100)
+}
+
+func TestUnmarshalLimitedAlloc(t *testing.T) {
+	t.Parallel()
+
+	cdc := amino.NewCodec()
+	names := make([]string, 100)
+	for i := range names {
+		names[i] = strings.Repeat("a", 100)
+	}
+	bz, err := cdc.Marshal(limitsList{Names: names})
+	require.NoError(t, err)
+
+	var got limitsList
+	err = cdc.UnmarshalLimited(bz, &got, amino.DecodeLimits{MaxAlloc: 10_000})
+	require.ErrorIs(t, err, amino.ErrDecodeLimit)
+
+	got = limitsList{}
+	require.NoError(t, cdc.UnmarshalLimited(bz, &got, amino.DecodeLimits{MaxAlloc: 100_000}))
+	assert.Equal(t, names, got.Names)
+}
+
+func TestDecoder(t *testing.T) {
+	t.Parallel()
+
+	cdc := amino.NewCodec()
+	var buf bytes.Buffer
+	for i := range 3 {
+		_, err := cdc.MarshalSizedWriter(&buf, limitsList{Values: make([]int64, i*10)})
+		require.NoError(t, err)
+	}
+
+	dec := cdc.NewDecoder(&buf, 1000, amino.DecodeLimits{MaxListLength: 10})
+	var got limitsList
+	require.NoError(t, dec.Decode(&got))
+	assert.Len(t, got.Values, 0)
+	got = limitsList{}
+	require.NoError(t, dec.Decode(&got))
+	assert.Len(t, got.Values, 10)
+	got = limitsList{}
+	require.ErrorIs(t, dec.Decode(&got), amino.ErrDecodeLimit)
+	got = limitsList{}
+	require.ErrorIs(t, dec.Decode(&got), io.EOF)
+
+	// Objects larger than the maximum size aren't read.
+	buf.Reset()
+	_, err := cdc.MarshalSizedWriter(&buf, limitsList{Values: make([]int64, 10)})
+	require.NoError(t, err)
+	dec = cdc.NewDecoder(&buf, 5, amino.DecodeLimits{})
+	require.ErrorContains(t, dec.Decode(&got), "read overflow")
+}
+
+// FuzzUnmarshalLimited checks that decoding arbitrary bytes to the registered
+// test types within limits never panics, and only differs from decoding them
+// without limits by failing with ErrDecodeLimit.
+func FuzzUnmarshalLimited(f *testing.F) {
+	cdc := amino.NewCodec()
+	cdc.RegisterPackage(tests.Package)
+	limits := amino.DecodeLimits{MaxDepth: 8, MaxListLength: 16, MaxAlloc: 1 << 16}
+
+	// The UnmarshalAmino methods of the test types panic on unexpected
+	// representations, so these types are skipped.
+	var types []reflect.Type
+	for _, ptr := range tests.StructTypes {
+		rt := reflect.TypeOf(ptr)
+		if _, ok := rt.MethodByName("UnmarshalAmino"); !ok {
+			types = append(types, rt.Elem())
+		}
+	}
+
+	for i, rt := range types {
+		bz, err := cdc.Marshal(reflect.New(rt).Interface())
+		if err != nil {
+			continue
+		}
+		f.Add(byte(i), bz)
+	}
+
+	f.Fuzz(func(t *testing.T, typ byte, bz []byte) {
+		rt := types[int(typ)%len(types)]
+		limited := reflect.New(rt)
+		unlimited := reflect.New(rt)
+
+		lerr := cdc.UnmarshalLimited(bz, limited.Interface(), limits)
+		uerr := cdc.Unmarshal(bz, unlimited.Interface())
+		switch {
+		case lerr == nil:
+			require.NoError(t, uerr)
+			require.Equal(t, unlimited.Interface(), limited.Interface())
+		case errors.Is(lerr, amino.ErrDecodeLimit):
+		default:
+			require.Error(t, uerr, "limited: %v", lerr)
+		}
+	})
+}
@@ -0,0 +1,49 @@
+package amino
+
+import (
+	"io"
+)
+
+// Decoder decodes a stream of length-prefixed binary objects, as written by
+// MarshalSizedWriter, reading no more than needed from its reader. Each object
+// is limited in size and in the resources used to decode it, so that the
+// stream can be untrusted.
+//
+// The reader is read one byte at a time for the length prefixes, so it should
+// be buffered.
+type Decoder struct {
+	cdc     *Codec
+	r       io.Reader
+	maxSize int64
+	limits  DecodeLimits
+}
+
+// NewDecoder returns a Decoder of the global codec, reading objects of at
+// most maxSize bytes from r, each decoded within limits. If maxSize is 0, the
+// size of the objects is not limited (not recommended).
+func NewDecoder(r io.Reader, maxSize int64, limits DecodeLimits) *Decoder {
+	return gcdc.NewDecoder(r, maxSize, limits)
+}
+
+// NewDecoder returns a Decoder of cdc, reading objects of at most maxSize
+// bytes from r, each decoded within limits. If maxSize is 0, the size of the
+// objects is not limited (not recommended).
+func (cdc *Codec) NewDecoder(r io.Reader, maxSize int64, limits DecodeLimits) *Decoder {
+	if maxSize < 0 {
+		panic("maxSize cannot be negative.")
+	}
+	return &Decoder{
+		cdc:     cdc,
+		r:       r,
+		maxSize: maxSize,
+		limits:  limits,
+	}
+}
+
+// Decode reads the next object of the stream and decodes it into ptr. It
+// returns io.EOF if the stream ends before the object.
+// Decode will panic if ptr is a nil-pointer.
+func (dec *Decoder) Decode(ptr any) error {
+	_, err := dec.cdc.unmarshalSizedReader(dec.r, ptr, dec.maxSize, dec.limits)
+	return err
+}
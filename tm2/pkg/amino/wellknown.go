@@ -383,7 +383,7 @@ func decodeReflectBinaryWellKnown(bz []byte, info *TypeInfo, rv reflect.Value, f
 		panic("expected a concrete type to decode to")
 	}
 	// Strip if needed.
-	bz, err = decodeMaybeBare(bz, &n, bare)
+	bz, err = decodeMaybeBare(bz, &n, bare, nil)
 	if err != nil {
 		return false, n, err
 	}
@@ -29,6 +29,8 @@ type Client interface {
 	InitChainAsync(abci.RequestInitChain) *ReqRes
 	BeginBlockAsync(abci.RequestBeginBlock) *ReqRes
 	EndBlockAsync(abci.RequestEndBlock) *ReqRes
+	PrepareProposalAsync(abci.RequestPrepareProposal) *ReqRes
+	ProcessProposalAsync(abci.RequestProcessProposal) *ReqRes
 
 	FlushSync() error
 	EchoSync(msg string) (abci.ResponseEcho, error)
@@ -41,6 +43,8 @@ type Client interface {
 	InitChainSync(abci.RequestInitChain) (abci.ResponseInitChain, error)
 	BeginBlockSync(abci.RequestBeginBlock) (abci.ResponseBeginBlock, error)
 	EndBlockSync(abci.RequestEndBlock) (abci.ResponseEndBlock, error)
+	PrepareProposalSync(abci.RequestPrepareProposal) (abci.ResponsePrepareProposal, error)
+	ProcessProposalSync(abci.RequestProcessProposal) (abci.ResponseProcessProposal, error)
 }
 
 // ----------------------------------------
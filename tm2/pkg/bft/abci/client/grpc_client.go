@@ -0,0 +1,267 @@
+package abcicli
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"sync"
+	"time"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+	"github.com/gnolang/gno/tm2/pkg/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/credentials/insecure"
+)
+
+var _ Client = (*grpcClient)(nil)
+
+// grpcClient is the client to an application running in another process,
+// served by a server.GRPCServer. Each request is a unary call made before the
+// Async method returns, so requests reach the application in the order they
+// are made, as with the local client.
+//
+// If a call fails, the request and all the subsequent ones are answered with
+// a ResponseException, and Error() returns the failure.
+type grpcClient struct {
+	service.BaseService
+
+	addr        string
+	mustConnect bool
+	conn        *grpc.ClientConn
+
+	mtx   sync.Mutex
+	err   error
+	resCb Callback // Called on all requests, if set.
+}
+
+// NewGRPCClient creates a new client to the application listening at addr,
+// eg. "tcp://127.0.0.1:26658" or "unix:///tmp/app.sock". If mustConnect is
+// true, Start returns an error when the application is unreachable;
+// otherwise it retries until the application is up.
+func NewGRPCClient(addr string, mustConnect bool) *grpcClient {
+	cli := &grpcClient{
+		addr:        addr,
+		mustConnect: mustConnect,
+	}
+	cli.BaseService = *service.NewBaseService(nil, "grpcClient", cli)
+	return cli
+}
+
+func (cli *grpcClient) OnStart() error {
+	conn, err := grpc.NewClient(
+		grpcTarget(cli.addr),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+		grpc.WithDefaultCallOptions(
+			grpc.ForceCodec(abci.GRPCCodec),
+			grpc.MaxCallRecvMsgSize(abci.MaxMessageSize),
+			grpc.MaxCallSendMsgSize(abci.MaxMessageSize),
+		),
+	)
+	if err != nil {
+		return fmt.Errorf("invalid application address %s: %w", cli.addr, err)
+	}
+	cli.conn = conn
+
+	// The connection is established lazily: check the application answers.
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), dialRetryPeriod)
+		_, err := cli.call(ctx, abci.RequestEcho{Message: "hello"})
+		cancel()
+		if err == nil {
+			return nil
+		}
+		if cli.mustConnect {
+			conn.Close()
+			return fmt.Errorf("unable to connect to application at %s: %w", cli.addr, err)
+		}
+		cli.Logger.Error(fmt.Sprintf("abci.grpcClient failed to connect to %v.  Retrying...", cli.addr), "err", err)
+
+		select {
+		case <-cli.Quit():
+			conn.Close()
+			return errors.New("client stopped while connecting")
+		case <-time.After(dialRetryPeriod):
+		}
+	}
+}
+
+func (cli *grpcClient) OnStop() {
+	if cli.conn != nil {
+		cli.conn.Close()
+	}
+}
+
+func (cli *grpcClient) SetResponseCallback(resCb Callback) {
+	cli.mtx.Lock()
+	cli.resCb = resCb
+	cli.mtx.Unlock()
+}
+
+// Error returns the call failure, if any.
+func (cli *grpcClient) Error() error {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	return cli.err
+}
+
+// grpcTarget converts a "proto://addr" address to a gRPC target.
+func grpcTarget(protoAddr string) string {
+	proto, addr := osm.ProtocolAndAddress(protoAddr)
+	if proto == "unix" {
+		return "unix://" + addr
+	}
+	return addr
+}
+
+func (cli *grpcClient) call(ctx context.Context, req abci.Request) (abci.Response, error) {
+	var res abci.Response
+	if err := cli.conn.Invoke(ctx, abci.GRPCCallMethod, &req, &res); err != nil {
+		return nil, err
+	}
+	if !resMatchesReq(req, res) {
+		if _, ok := res.(abci.ResponseException); !ok {
+			return nil, fmt.Errorf("unexpected %T for %T", res, req)
+		}
+	}
+	return res, nil
+}
+
+// doRequest calls the application for req, and completes the returned
+// ReqRes with its response.
+func (cli *grpcClient) doRequest(req abci.Request) *ReqRes {
+	reqRes := NewReqRes(req)
+
+	// Hold the lock during the call, so that requests are made in order.
+	cli.mtx.Lock()
+	err := cli.err
+	var res abci.Response
+	if err == nil {
+		res, err = cli.call(context.Background(), req)
+		if err != nil {
+			cli.err = fmt.Errorf("error calling application: %w", err)
+			err = cli.err
+			cli.Logger.Error("Stopping abci.grpcClient", "err", err)
+		}
+	}
+	if err != nil {
+		res = exceptionResponse(err)
+	}
+	resCb := cli.resCb
+	cli.mtx.Unlock()
+
+	reqRes.SetResponse(res)
+	if resCb != nil {
+		resCb(req, res)
+	}
+	return reqRes
+}
+
+// ----------------------------------------
+
+func (cli *grpcClient) FlushAsync() *ReqRes {
+	// Requests aren't buffered.
+	return newLocalReqRes(abci.RequestFlush{}, abci.ResponseFlush{})
+}
+
+func (cli *grpcClient) EchoAsync(msg string) *ReqRes {
+	return cli.doRequest(abci.RequestEcho{Message: msg})
+}
+
+func (cli *grpcClient) InfoAsync(req abci.RequestInfo) *ReqRes {
+	return cli.doRequest(req)
+}
+
+func (cli *grpcClient) SetOptionAsync(req abci.RequestSetOption) *ReqRes {
+	return cli.doRequest(req)
+}
+
+func (cli *grpcClient) DeliverTxAsync(req abci.RequestDeliverTx) *ReqRes {
+	return cli.doRequest(req)
+}
+
+func (cli *grpcClient) CheckTxAsync(req abci.RequestCheckTx) *ReqRes {
+	return cli.doRequest(req)
+}
+
+func (cli *grpcClient) QueryAsync(req abci.RequestQuery) *ReqRes {
+	return cli.doRequest(req)
+}
+
+func (cli *grpcClient) CommitAsync() *ReqRes {
+	return cli.doRequest(abci.RequestCommit{})
+}
+
+func (cli *grpcClient) InitChainAsync(req abci.RequestInitChain) *ReqRes {
+	return cli.doRequest(req)
+}
+
+func (cli *grpcClient) BeginBlockAsync(req abci.RequestBeginBlock) *ReqRes {
+	return cli.doRequest(req)
+}
+
+func (cli *grpcClient) EndBlockAsync(req abci.RequestEndBlock) *ReqRes {
+	return cli.doRequest(req)
+}
+
+func (cli *grpcClient) PrepareProposalAsync(req abci.RequestPrepareProposal) *ReqRes {
+	return cli.doRequest(req)
+}
+
+func (cli *grpcClient) ProcessProposalAsync(req abci.RequestProcessProposal) *ReqRes {
+	return cli.doRequest(req)
+}
+
+//-------------------------------------------------------
+
+func (cli *grpcClient) FlushSync() error {
+	return nil
+}
+
+func (cli *grpcClient) EchoSync(msg string) (abci.ResponseEcho, error) {
+	return waitResponse[abci.ResponseEcho](cli.EchoAsync(msg))
+}
+
+func (cli *grpcClient) InfoSync(req abci.RequestInfo) (abci.ResponseInfo, error) {
+	return waitResponse[abci.ResponseInfo](cli.InfoAsync(req))
+}
+
+func (cli *grpcClient) SetOptionSync(req abci.RequestSetOption) (abci.ResponseSetOption, error) {
+	return waitResponse[abci.ResponseSetOption](cli.SetOptionAsync(req))
+}
+
+func (cli *grpcClient) DeliverTxSync(req abci.RequestDeliverTx) (abci.ResponseDeliverTx, error) {
+	return waitResponse[abci.ResponseDeliverTx](cli.DeliverTxAsync(req))
+}
+
+func (cli *grpcClient) CheckTxSync(req abci.RequestCheckTx) (abci.ResponseCheckTx, error) {
+	return waitResponse[abci.ResponseCheckTx](cli.CheckTxAsync(req))
+}
+
+func (cli *grpcClient) QuerySync(req abci.RequestQuery) (abci.ResponseQuery, error) {
+	return waitResponse[abci.ResponseQuery](cli.QueryAsync(req))
+}
+
+func (cli *grpcClient) CommitSync() (abci.ResponseCommit, error) {
+	return waitResponse[abci.ResponseCommit](cli.CommitAsync())
+}
+
+func (cli *grpcClient) InitChainSync(req abci.RequestInitChain) (abci.ResponseInitChain, error) {
+	return waitResponse[abci.ResponseInitChain](cli.InitChainAsync(req))
+}
+
+func (cli *grpcClient) BeginBlockSync(req abci.RequestBeginBlock) (abci.ResponseBeginBlock, error) {
+	return waitResponse[abci.ResponseBeginBlock](cli.BeginBlockAsync(req))
+}
+
+func (cli *grpcClient) EndBlockSync(req abci.RequestEndBlock) (abci.ResponseEndBlock, error) {
+	return waitResponse[abci.ResponseEndBlock](cli.EndBlockAsync(req))
+}
+
+func (cli *grpcClient) PrepareProposalSync(req abci.RequestPrepareProposal) (abci.ResponsePrepareProposal, error) {
+	return waitResponse[abci.ResponsePrepareProposal](cli.PrepareProposalAsync(req))
+}
+
+func (cli *grpcClient) ProcessProposalSync(req abci.RequestProcessProposal) (abci.ResponseProcessProposal, error) {
+	return waitResponse[abci.ResponseProcessProposal](cli.ProcessProposalAsync(req))
+}
@@ -0,0 +1,392 @@
+package abcicli
+
+import (
+	"bufio"
+	"container/list"
+	"errors"
+	"fmt"
+	"net"
+	"sync"
+	"time"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+	"github.com/gnolang/gno/tm2/pkg/service"
+)
+
+const (
+	reqQueueSize    = 256 // TODO make configurable
+	dialRetryPeriod = 3 * time.Second
+)
+
+var _ Client = (*socketClient)(nil)
+
+// socketClient is the client to an application running in another process,
+// served by a server.SocketServer. Requests are written in order to the
+// connection and the server answers them in the same order, so responses
+// are matched to the oldest request sent.
+//
+// If the connection fails, all the pending and subsequent requests are
+// answered with a ResponseException, and Error() returns the failure.
+type socketClient struct {
+	service.BaseService
+
+	addr        string
+	mustConnect bool
+	conn        net.Conn
+
+	reqQueue chan *ReqRes
+
+	mtx     sync.Mutex
+	err     error
+	reqSent *list.List // Requests sent and waiting for a response.
+	resCb   Callback   // Called on all requests, if set.
+}
+
+// NewSocketClient creates a new client to the application listening at addr,
+// eg. "tcp://127.0.0.1:26658" or "unix:///tmp/app.sock". If mustConnect is
+// true, Start returns an error when the application is unreachable;
+// otherwise it retries until the application is up.
+func NewSocketClient(addr string, mustConnect bool) *socketClient {
+	cli := &socketClient{
+		addr:        addr,
+		mustConnect: mustConnect,
+		reqQueue:    make(chan *ReqRes, reqQueueSize),
+		reqSent:     list.New(),
+	}
+	cli.BaseService = *service.NewBaseService(nil, "socketClient", cli)
+	return cli
+}
+
+func (cli *socketClient) OnStart() error {
+	var conn net.Conn
+	for {
+		var err error
+		conn, err = osm.Connect(cli.addr)
+		if err == nil {
+			break
+		}
+		if cli.mustConnect {
+			return fmt.Errorf("unable to connect to application at %s: %w", cli.addr, err)
+		}
+		cli.Logger.Error(fmt.Sprintf("abci.socketClient failed to connect to %v.  Retrying...", cli.addr), "err", err)
+
+		select {
+		case <-cli.Quit():
+			return errors.New("client stopped while connecting")
+		case <-time.After(dialRetryPeriod):
+		}
+	}
+	cli.conn = conn
+
+	go cli.sendRequestsRoutine(conn)
+	go cli.recvResponseRoutine(conn)
+
+	return nil
+}
+
+func (cli *socketClient) OnStop() {
+	if cli.conn != nil {
+		cli.conn.Close()
+	}
+	cli.stopForError(errors.New("client stopped"))
+}
+
+func (cli *socketClient) SetResponseCallback(resCb Callback) {
+	cli.mtx.Lock()
+	cli.resCb = resCb
+	cli.mtx.Unlock()
+}
+
+// Error returns the connection failure, if any.
+func (cli *socketClient) Error() error {
+	cli.mtx.Lock()
+	defer cli.mtx.Unlock()
+	return cli.err
+}
+
+// ----------------------------------------
+
+func (cli *socketClient) sendRequestsRoutine(conn net.Conn) {
+	w := bufio.NewWriter(conn)
+	for {
+		select {
+		case <-cli.Quit():
+			return
+		case reqRes := <-cli.reqQueue:
+			if err := abci.WriteMessage(w, reqRes.Request); err != nil {
+				cli.stopForError(fmt.Errorf("error writing request: %w", err))
+				return
+			}
+			// Flush once the queue is drained, so that requests sent
+			// together are written together.
+			if len(cli.reqQueue) == 0 {
+				if err := w.Flush(); err != nil {
+					cli.stopForError(fmt.Errorf("error flushing requests: %w", err))
+					return
+				}
+			}
+		}
+	}
+}
+
+func (cli *socketClient) recvResponseRoutine(conn net.Conn) {
+	r := bufio.NewReader(conn)
+	for {
+		res, err := abci.ReadResponse(r)
+		if err != nil {
+			cli.stopForError(fmt.Errorf("error reading response: %w", err))
+			return
+		}
+		if err := cli.didRecvResponse(res); err != nil {
+			cli.stopForError(err)
+			return
+		}
+	}
+}
+
+// didRecvResponse completes the oldest request sent with res.
+func (cli *socketClient) didRecvResponse(res abci.Response) error {
+	cli.mtx.Lock()
+	next := cli.reqSent.Front()
+	if next == nil {
+		cli.mtx.Unlock()
+		return fmt.Errorf("unexpected %T when nothing was requested", res)
+	}
+	reqRes := next.Value.(*ReqRes)
+	if _, ok := res.(abci.ResponseException); !ok && !resMatchesReq(reqRes.Request, res) {
+		cli.mtx.Unlock()
+		return fmt.Errorf("unexpected %T for %T", res, reqRes.Request)
+	}
+	cli.reqSent.Remove(next)
+	resCb := cli.resCb
+	cli.mtx.Unlock()
+
+	cli.completeRequest(reqRes, res, resCb)
+	return nil
+}
+
+// completeRequest sets the response of reqRes, then calls its callback and
+// the global callback, in this order, as the local client does.
+func (cli *socketClient) completeRequest(reqRes *ReqRes, res abci.Response, resCb Callback) {
+	reqRes.SetResponse(res)
+	if cb := reqRes.GetCallback(); cb != nil {
+		cb(res)
+	}
+	if resCb != nil {
+		resCb(reqRes.Request, res)
+	}
+}
+
+// stopForError records err, if it is the first failure, and answers all the
+// pending requests with a ResponseException.
+func (cli *socketClient) stopForError(err error) {
+	cli.mtx.Lock()
+	if cli.err == nil {
+		cli.err = err
+		cli.Logger.Error("Stopping abci.socketClient", "err", err)
+	}
+	pending := cli.reqSent
+	cli.reqSent = list.New()
+	resCb := cli.resCb
+	cli.mtx.Unlock()
+
+	for e := pending.Front(); e != nil; e = e.Next() {
+		cli.completeRequest(e.Value.(*ReqRes), exceptionResponse(err), resCb)
+	}
+
+	if cli.IsRunning() {
+		go cli.Stop()
+	}
+}
+
+func (cli *socketClient) queueRequest(req abci.Request) *ReqRes {
+	reqRes := NewReqRes(req)
+
+	cli.mtx.Lock()
+	if cli.err != nil {
+		err := cli.err
+		resCb := cli.resCb
+		cli.mtx.Unlock()
+		cli.completeRequest(reqRes, exceptionResponse(err), resCb)
+		return reqRes
+	}
+	// Track the request before it is sent, so the response can't arrive
+	// before it.
+	cli.reqSent.PushBack(reqRes)
+	cli.mtx.Unlock()
+
+	select {
+	case cli.reqQueue <- reqRes:
+	case <-cli.Quit():
+		// The request was answered when the client stopped.
+	}
+	return reqRes
+}
+
+func exceptionResponse(err error) abci.ResponseException {
+	return abci.ResponseException{
+		ResponseBase: abci.ResponseBase{
+			Error: abci.StringError(err.Error()),
+		},
+	}
+}
+
+// ----------------------------------------
+
+func (cli *socketClient) FlushAsync() *ReqRes {
+	return cli.queueRequest(abci.RequestFlush{})
+}
+
+func (cli *socketClient) EchoAsync(msg string) *ReqRes {
+	return cli.queueRequest(abci.RequestEcho{Message: msg})
+}
+
+func (cli *socketClient) InfoAsync(req abci.RequestInfo) *ReqRes {
+	return cli.queueRequest(req)
+}
+
+func (cli *socketClient) SetOptionAsync(req abci.RequestSetOption) *ReqRes {
+	return cli.queueRequest(req)
+}
+
+func (cli *socketClient) DeliverTxAsync(req abci.RequestDeliverTx) *ReqRes {
+	return cli.queueRequest(req)
+}
+
+func (cli *socketClient) CheckTxAsync(req abci.RequestCheckTx) *ReqRes {
+	return cli.queueRequest(req)
+}
+
+func (cli *socketClient) QueryAsync(req abci.RequestQuery) *ReqRes {
+	return cli.queueRequest(req)
+}
+
+func (cli *socketClient) CommitAsync() *ReqRes {
+	return cli.queueRequest(abci.RequestCommit{})
+}
+
+func (cli *socketClient) InitChainAsync(req abci.RequestInitChain) *ReqRes {
+	return cli.queueRequest(req)
+}
+
+func (cli *socketClient) BeginBlockAsync(req abci.RequestBeginBlock) *ReqRes {
+	return cli.queueRequest(req)
+}
+
+func (cli *socketClient) EndBlockAsync(req abci.RequestEndBlock) *ReqRes {
+	return cli.queueRequest(req)
+}
+
+func (cli *socketClient) PrepareProposalAsync(req abci.RequestPrepareProposal) *ReqRes {
+	return cli.queueRequest(req)
+}
+
+func (cli *socketClient) ProcessProposalAsync(req abci.RequestProcessProposal) *ReqRes {
+	return cli.queueRequest(req)
+}
+
+//-------------------------------------------------------
+
+// waitResponse waits for the response of reqRes, and returns it as a T.
+// A ResponseException is returned as an error.
+func waitResponse[T abci.Response](reqRes *ReqRes) (T, error) {
+	reqRes.Wait()
+
+	var zero T
+	switch res := reqRes.Response.(type) {
+	case T:
+		return res, nil
+	case abci.ResponseException:
+		return zero, res.Error
+	default:
+		return zero, fmt.Errorf("unexpected %T for %T", res, reqRes.Request)
+	}
+}
+
+func (cli *socketClient) FlushSync() error {
+	_, err := waitResponse[abci.ResponseFlush](cli.FlushAsync())
+	return err
+}
+
+func (cli *socketClient) EchoSync(msg string) (abci.ResponseEcho, error) {
+	return waitResponse[abci.ResponseEcho](cli.EchoAsync(msg))
+}
+
+func (cli *socketClient) InfoSync(req abci.RequestInfo) (abci.ResponseInfo, error) {
+	return waitResponse[abci.ResponseInfo](cli.InfoAsync(req))
+}
+
+func (cli *socketClient) SetOptionSync(req abci.RequestSetOption) (abci.ResponseSetOption, error) {
+	return waitResponse[abci.ResponseSetOption](cli.SetOptionAsync(req))
+}
+
+func (cli *socketClient) DeliverTxSync(req abci.RequestDeliverTx) (abci.ResponseDeliverTx, error) {
+	return waitResponse[abci.ResponseDeliverTx](cli.DeliverTxAsync(req))
+}
+
+func (cli *socketClient) CheckTxSync(req abci.RequestCheckTx) (abci.ResponseCheckTx, error) {
+	return waitResponse[abci.ResponseCheckTx](cli.CheckTxAsync(req))
+}
+
+func (cli *socketClient) QuerySync(req abci.RequestQuery) (abci.ResponseQuery, error) {
+	return waitResponse[abci.ResponseQuery](cli.QueryAsync(req))
+}
+
+func (cli *socketClient) CommitSync() (abci.ResponseCommit, error) {
+	return waitResponse[abci.ResponseCommit](cli.CommitAsync())
+}
+
+func (cli *socketClient) InitChainSync(req abci.RequestInitChain) (abci.ResponseInitChain, error) {
+	return waitResponse[abci.ResponseInitChain](cli.InitChainAsync(req))
+}
+
+func (cli *socketClient) BeginBlockSync(req abci.RequestBeginBlock) (abci.ResponseBeginBlock, error) {
+	return waitResponse[abci.ResponseBeginBlock](cli.BeginBlockAsync(req))
+}
+
+func (cli *socketClient) EndBlockSync(req abci.RequestEndBlock) (abci.ResponseEndBlock, error) {
+	return waitResponse[abci.ResponseEndBlock](cli.EndBlockAsync(req))
+}
+
+func (cli *socketClient) PrepareProposalSync(req abci.RequestPrepareProposal) (abci.ResponsePrepareProposal, error) {
+	return waitResponse[abci.ResponsePrepareProposal](cli.PrepareProposalAsync(req))
+}
+
+func (cli *socketClient) ProcessProposalSync(req abci.RequestProcessProposal) (abci.ResponseProcessProposal, error) {
+	return waitResponse[abci.ResponseProcessProposal](cli.ProcessProposalAsync(req))
+}
+
+//-------------------------------------------------------
+
+func resMatchesReq(req abci.Request, res abci.Response) (ok bool) {
+	switch req.(type) {
+	case abci.RequestEcho:
+		_, ok = res.(abci.ResponseEcho)
+	case abci.RequestFlush:
+		_, ok = res.(abci.ResponseFlush)
+	case abci.RequestInfo:
+		_, ok = res.(abci.ResponseInfo)
+	case abci.RequestSetOption:
+		_, ok = res.(abci.ResponseSetOption)
+	case abci.RequestDeliverTx:
+		_, ok = res.(abci.ResponseDeliverTx)
+	case abci.RequestCheckTx:
+		_, ok = res.(abci.ResponseCheckTx)
+	case abci.RequestCommit:
+		_, ok = res.(abci.ResponseCommit)
+	case abci.RequestQuery:
+		_, ok = res.(abci.ResponseQuery)
+	case abci.RequestInitChain:
+		_, ok = res.(abci.ResponseInitChain)
+	case abci.RequestBeginBlock:
+		_, ok = res.(abci.ResponseBeginBlock)
+	case abci.RequestEndBlock:
+		_, ok = res.(abci.ResponseEndBlock)
+	case abci.RequestPrepareProposal:
+		_, ok = res.(abci.ResponsePrepareProposal)
+	case abci.RequestProcessProposal:
+		_, ok = res.(abci.ResponseProcessProposal)
+	}
+	return ok
+}
@@ -149,6 +149,14 @@ func (app *PersistentKVStoreApplication) EndBlock(req abci.RequestEndBlock) abci
 	return abci.ResponseEndBlock{ValidatorUpdates: app.ValSetChanges}
 }
 
+func (app *PersistentKVStoreApplication) PrepareProposal(req abci.RequestPrepareProposal) abci.ResponsePrepareProposal {
+	return app.app.PrepareProposal(req)
+}
+
+func (app *PersistentKVStoreApplication) ProcessProposal(req abci.RequestProcessProposal) abci.ResponseProcessProposal {
+	return app.app.ProcessProposal(req)
+}
+
 // ---------------------------------------------
 // update validators
 
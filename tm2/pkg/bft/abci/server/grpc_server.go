@@ -0,0 +1,79 @@
+package server
+
+import (
+	"context"
+	"fmt"
+	"net"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+	"github.com/gnolang/gno/tm2/pkg/service"
+	"google.golang.org/grpc"
+)
+
+var _ abci.GRPCApplication = (*GRPCServer)(nil)
+
+// GRPCServer serves an application over gRPC.
+//
+// Each request is a unary call, answered once the application returns. The
+// application is called by a single request at a time, as with the socket
+// server.
+type GRPCServer struct {
+	service.BaseService
+
+	protoAddr string
+	listener  net.Listener
+	server    *grpc.Server
+
+	app appHandler
+}
+
+// NewGRPCServer creates a new server serving app at protoAddr, eg.
+// "tcp://127.0.0.1:26658" or "unix:///tmp/app.sock".
+func NewGRPCServer(protoAddr string, app abci.Application) *GRPCServer {
+	s := &GRPCServer{
+		protoAddr: protoAddr,
+		app:       appHandler{app: app},
+	}
+	s.BaseService = *service.NewBaseService(nil, "ABCIServer", s)
+	return s
+}
+
+func (s *GRPCServer) OnStart() error {
+	proto, addr := osm.ProtocolAndAddress(s.protoAddr)
+	ln, err := net.Listen(proto, addr)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s: %w", s.protoAddr, err)
+	}
+	s.listener = ln
+
+	s.server = grpc.NewServer(
+		grpc.ForceServerCodec(abci.GRPCCodec),
+		grpc.MaxRecvMsgSize(abci.MaxMessageSize),
+		grpc.MaxSendMsgSize(abci.MaxMessageSize),
+	)
+	s.server.RegisterService(&abci.GRPCServiceDesc, s)
+
+	go func() {
+		if err := s.server.Serve(ln); err != nil {
+			s.Logger.Error("Error serving gRPC", "err", err)
+		}
+	}()
+
+	return nil
+}
+
+func (s *GRPCServer) OnStop() {
+	s.server.Stop()
+}
+
+// Addr returns the address the server listens on. It is only available once
+// the server is started.
+func (s *GRPCServer) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+// Call implements abci.GRPCApplication.
+func (s *GRPCServer) Call(_ context.Context, req abci.Request) (abci.Response, error) {
+	return s.app.handleRequest(req), nil
+}
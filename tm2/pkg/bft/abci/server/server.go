@@ -0,0 +1,78 @@
+// Package server serves an abci.Application to a consensus engine running in
+// another process, which connects to it with abcicli.NewSocketClient or
+// abcicli.NewGRPCClient.
+package server
+
+import (
+	"fmt"
+	"sync"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/service"
+)
+
+const (
+	SocketTransport = "socket"
+	GRPCTransport   = "grpc"
+)
+
+// NewServer returns a new server serving app at protoAddr over the given
+// transport, socket or grpc.
+func NewServer(protoAddr, transport string, app abci.Application) (service.Service, error) {
+	switch transport {
+	case SocketTransport:
+		return NewSocketServer(protoAddr, app), nil
+	case GRPCTransport:
+		return NewGRPCServer(protoAddr, app), nil
+	default:
+		return nil, fmt.Errorf("unknown ABCI server transport %q", transport)
+	}
+}
+
+// appHandler calls the application for the requests of all the connections,
+// one at a time, as the local client does.
+type appHandler struct {
+	mtx sync.Mutex
+	app abci.Application
+}
+
+// handleRequest calls the application for req.
+func (h *appHandler) handleRequest(req abci.Request) abci.Response {
+	h.mtx.Lock()
+	defer h.mtx.Unlock()
+
+	switch req := req.(type) {
+	case abci.RequestEcho:
+		return abci.ResponseEcho{Message: req.Message}
+	case abci.RequestFlush:
+		return abci.ResponseFlush{}
+	case abci.RequestInfo:
+		return h.app.Info(req)
+	case abci.RequestSetOption:
+		return h.app.SetOption(req)
+	case abci.RequestDeliverTx:
+		return h.app.DeliverTx(req)
+	case abci.RequestCheckTx:
+		return h.app.CheckTx(req)
+	case abci.RequestCommit:
+		return h.app.Commit()
+	case abci.RequestQuery:
+		return h.app.Query(req)
+	case abci.RequestInitChain:
+		return h.app.InitChain(req)
+	case abci.RequestBeginBlock:
+		return h.app.BeginBlock(req)
+	case abci.RequestEndBlock:
+		return h.app.EndBlock(req)
+	case abci.RequestPrepareProposal:
+		return h.app.PrepareProposal(req)
+	case abci.RequestProcessProposal:
+		return h.app.ProcessProposal(req)
+	default:
+		return abci.ResponseException{
+			ResponseBase: abci.ResponseBase{
+				Error: abci.StringError(fmt.Sprintf("unknown request %T", req)),
+			},
+		}
+	}
+}
@@ -0,0 +1,166 @@
+package server
+
+import (
+	"fmt"
+	"net"
+	"path/filepath"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abcicli "github.com/gnolang/gno/tm2/pkg/bft/abci/client"
+	"github.com/gnolang/gno/tm2/pkg/bft/abci/example/kvstore"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/service"
+)
+
+type addrServer interface {
+	service.Service
+	Addr() net.Addr
+}
+
+// startServer serves app over transport on a random local port, and returns
+// a started client connected to it.
+func startServer(t *testing.T, transport string, app abci.Application) (addrServer, abcicli.Client) {
+	t.Helper()
+
+	srv, err := NewServer("tcp://127.0.0.1:0", transport, app)
+	require.NoError(t, err)
+	require.NoError(t, srv.Start())
+	t.Cleanup(func() { srv.Stop() })
+
+	s := srv.(addrServer)
+	addr := "tcp://" + s.Addr().String()
+
+	var cli abcicli.Client
+	switch transport {
+	case SocketTransport:
+		cli = abcicli.NewSocketClient(addr, true)
+	case GRPCTransport:
+		cli = abcicli.NewGRPCClient(addr, true)
+	}
+	require.NoError(t, cli.Start())
+	t.Cleanup(func() { cli.Stop() })
+
+	return s, cli
+}
+
+func TestServer_RoundTrip(t *testing.T) {
+	t.Parallel()
+
+	for _, transport := range []string{SocketTransport, GRPCTransport} {
+		t.Run(transport, func(t *testing.T) {
+			t.Parallel()
+
+			_, cli := startServer(t, transport, kvstore.NewKVStoreApplication())
+
+			resEcho, err := cli.EchoSync("hello")
+			require.NoError(t, err)
+			assert.Equal(t, "hello", resEcho.Message)
+
+			_, err = cli.BeginBlockSync(abci.RequestBeginBlock{
+				Header: &bft.Header{ChainID: "test", Height: 1},
+			})
+			require.NoError(t, err)
+
+			resDeliver, err := cli.DeliverTxSync(abci.RequestDeliverTx{Tx: []byte("key=value")})
+			require.NoError(t, err)
+			assert.Nil(t, resDeliver.Error)
+
+			resCommit, err := cli.CommitSync()
+			require.NoError(t, err)
+			assert.NotEmpty(t, resCommit.Data)
+
+			resQuery, err := cli.QuerySync(abci.RequestQuery{Path: "/store", Data: []byte("key")})
+			require.NoError(t, err)
+			assert.Equal(t, "value", string(resQuery.Value))
+
+			_, err = cli.InfoSync(abci.RequestInfo{})
+			require.NoError(t, err)
+
+			require.NoError(t, cli.FlushSync())
+			assert.NoError(t, cli.Error())
+		})
+	}
+}
+
+func TestServer_AsyncOrder(t *testing.T) {
+	t.Parallel()
+
+	const numTxs = 100
+
+	for _, transport := range []string{SocketTransport, GRPCTransport} {
+		t.Run(transport, func(t *testing.T) {
+			t.Parallel()
+
+			_, cli := startServer(t, transport, kvstore.NewKVStoreApplication())
+
+			var (
+				mu  sync.Mutex
+				txs []string
+			)
+			cli.SetResponseCallback(func(req abci.Request, res abci.Response) {
+				if req, ok := req.(abci.RequestDeliverTx); ok {
+					mu.Lock()
+					txs = append(txs, string(req.Tx))
+					mu.Unlock()
+				}
+			})
+
+			for i := 0; i < numTxs; i++ {
+				cli.DeliverTxAsync(abci.RequestDeliverTx{Tx: []byte(fmt.Sprintf("k%d=v", i))})
+			}
+			require.NoError(t, cli.FlushSync())
+
+			_, err := cli.CommitSync()
+			require.NoError(t, err)
+
+			mu.Lock()
+			defer mu.Unlock()
+			require.Len(t, txs, numTxs)
+			for i, tx := range txs {
+				assert.Equal(t, fmt.Sprintf("k%d=v", i), tx)
+			}
+		})
+	}
+}
+
+func TestServer_Stopped(t *testing.T) {
+	t.Parallel()
+
+	for _, transport := range []string{SocketTransport, GRPCTransport} {
+		t.Run(transport, func(t *testing.T) {
+			t.Parallel()
+
+			srv, cli := startServer(t, transport, kvstore.NewKVStoreApplication())
+			require.NoError(t, srv.Stop())
+
+			_, err := cli.InfoSync(abci.RequestInfo{})
+			require.Error(t, err)
+			assert.Error(t, cli.Error())
+
+			// Subsequent requests fail as well.
+			_, err = cli.EchoSync("hello")
+			assert.Error(t, err)
+		})
+	}
+}
+
+func TestClient_MustConnect(t *testing.T) {
+	t.Parallel()
+
+	addr := "unix://" + filepath.Join(t.TempDir(), "missing.sock")
+
+	assert.Error(t, abcicli.NewSocketClient(addr, true).Start())
+	assert.Error(t, abcicli.NewGRPCClient(addr, true).Start())
+}
+
+func TestNewServer_UnknownTransport(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewServer("tcp://127.0.0.1:0", "carrier-pigeon", abci.NewBaseApplication())
+	assert.Error(t, err)
+}
@@ -0,0 +1,151 @@
+package server
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"net"
+	"sync"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+	"github.com/gnolang/gno/tm2/pkg/service"
+)
+
+// SocketServer serves an application over TCP or UNIX sockets.
+//
+// The consensus engine opens one connection per ABCI connection (consensus,
+// mempool and query). Requests are answered in the order they are received
+// on each connection, and the application is called by a single connection
+// at a time, as with the local client. A panic of the application isn't
+// recovered: it stops the application process, and the consensus engine halts
+// on the closed connection.
+type SocketServer struct {
+	service.BaseService
+
+	protoAddr string
+	listener  net.Listener
+
+	connsMtx   sync.Mutex
+	conns      map[int]net.Conn
+	nextConnID int
+
+	app appHandler
+}
+
+// NewSocketServer creates a new server serving app at protoAddr, eg.
+// "tcp://127.0.0.1:26658" or "unix:///tmp/app.sock".
+func NewSocketServer(protoAddr string, app abci.Application) *SocketServer {
+	s := &SocketServer{
+		protoAddr: protoAddr,
+		app:       appHandler{app: app},
+		conns:     make(map[int]net.Conn),
+	}
+	s.BaseService = *service.NewBaseService(nil, "ABCIServer", s)
+	return s
+}
+
+func (s *SocketServer) OnStart() error {
+	proto, addr := osm.ProtocolAndAddress(s.protoAddr)
+	ln, err := net.Listen(proto, addr)
+	if err != nil {
+		return fmt.Errorf("unable to listen on %s: %w", s.protoAddr, err)
+	}
+	s.listener = ln
+
+	go s.acceptConnectionsRoutine()
+
+	return nil
+}
+
+func (s *SocketServer) OnStop() {
+	if err := s.listener.Close(); err != nil {
+		s.Logger.Error("Error closing listener", "err", err)
+	}
+
+	s.connsMtx.Lock()
+	defer s.connsMtx.Unlock()
+	for id, conn := range s.conns {
+		delete(s.conns, id)
+		if err := conn.Close(); err != nil {
+			s.Logger.Error("Error closing connection", "id", id, "err", err)
+		}
+	}
+}
+
+// Addr returns the address the server listens on. It is only available once
+// the server is started.
+func (s *SocketServer) Addr() net.Addr {
+	return s.listener.Addr()
+}
+
+func (s *SocketServer) addConn(conn net.Conn) int {
+	s.connsMtx.Lock()
+	defer s.connsMtx.Unlock()
+
+	connID := s.nextConnID
+	s.nextConnID++
+	s.conns[connID] = conn
+
+	return connID
+}
+
+func (s *SocketServer) rmConn(connID int) {
+	s.connsMtx.Lock()
+	defer s.connsMtx.Unlock()
+
+	if conn, ok := s.conns[connID]; ok {
+		delete(s.conns, connID)
+		conn.Close()
+	}
+}
+
+func (s *SocketServer) acceptConnectionsRoutine() {
+	for {
+		conn, err := s.listener.Accept()
+		if err != nil {
+			if !s.IsRunning() {
+				return // Ignore error from listener closing.
+			}
+			s.Logger.Error("Failed to accept connection", "err", err)
+			continue
+		}
+
+		connID := s.addConn(conn)
+		s.Logger.Info("Accepted a new connection", "id", connID)
+
+		go s.handleConnection(connID, conn)
+	}
+}
+
+// handleConnection answers the requests received on conn, until it is closed.
+func (s *SocketServer) handleConnection(connID int, conn net.Conn) {
+	defer s.rmConn(connID)
+
+	r := bufio.NewReader(conn)
+	w := bufio.NewWriter(conn)
+	for {
+		req, err := abci.ReadRequest(r)
+		if err != nil {
+			if err != io.EOF && s.IsRunning() {
+				s.Logger.Error("Error reading request", "id", connID, "err", err)
+			}
+			return
+		}
+
+		res := s.app.handleRequest(req)
+		if err := abci.WriteMessage(w, res); err != nil {
+			s.Logger.Error("Error writing response", "id", connID, "err", err)
+			return
+		}
+
+		// Flush when the client waits for the responses, ie. when no more
+		// requests are buffered.
+		if r.Buffered() == 0 {
+			if err := w.Flush(); err != nil {
+				s.Logger.Error("Error flushing responses", "id", connID, "err", err)
+				return
+			}
+		}
+	}
+}
@@ -21,6 +21,10 @@ type Application interface {
 	EndBlock(RequestEndBlock) ResponseEndBlock       // Signals the end of a block, returns changes to the validator set
 	Commit() ResponseCommit                          // Commit the state and return the application Merkle root hash
 
+	// Proposal Connection
+	PrepareProposal(RequestPrepareProposal) ResponsePrepareProposal // Select and order the txs of a block being proposed
+	ProcessProposal(RequestProcessProposal) ResponseProcessProposal // Accept or reject a received proposal block
+
 	// Cleanup
 	Close() error
 }
@@ -72,6 +76,16 @@ func (BaseApplication) EndBlock(req RequestEndBlock) ResponseEndBlock {
 	return ResponseEndBlock{}
 }
 
+// PrepareProposal returns the candidate txs unmodified.
+func (BaseApplication) PrepareProposal(req RequestPrepareProposal) ResponsePrepareProposal {
+	return ResponsePrepareProposal{Txs: req.Txs}
+}
+
+// ProcessProposal accepts every proposal.
+func (BaseApplication) ProcessProposal(req RequestProcessProposal) ResponseProcessProposal {
+	return ResponseProcessProposal{}
+}
+
 func (BaseApplication) Close() error {
 	return nil
 }
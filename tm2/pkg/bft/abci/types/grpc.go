@@ -0,0 +1,85 @@
+package abci
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"google.golang.org/grpc"
+)
+
+// GRPCCallMethod is the full name of the single gRPC method used to
+// exchange a Request for a Response with an application. Messages are amino
+// encoded with GRPCCodec, as they are over sockets.
+const GRPCCallMethod = "/tm2.abci.ABCIApplication/Call"
+
+// GRPCApplication is the server side of the gRPC ABCI service.
+type GRPCApplication interface {
+	Call(ctx context.Context, req Request) (Response, error)
+}
+
+// GRPCServiceDesc describes the gRPC ABCI service, to be registered on a
+// grpc.Server with a GRPCApplication.
+var GRPCServiceDesc = grpc.ServiceDesc{
+	ServiceName: "tm2.abci.ABCIApplication",
+	HandlerType: (*GRPCApplication)(nil),
+	Methods: []grpc.MethodDesc{
+		{
+			MethodName: "Call",
+			Handler:    grpcCallHandler,
+		},
+	},
+	Streams: []grpc.StreamDesc{},
+}
+
+func grpcCallHandler(srv any, ctx context.Context, dec func(any) error, interceptor grpc.UnaryServerInterceptor) (any, error) {
+	var req Request
+	if err := dec(&req); err != nil {
+		return nil, err
+	}
+
+	handler := func(ctx context.Context, req any) (any, error) {
+		res, err := srv.(GRPCApplication).Call(ctx, *req.(*Request))
+		if err != nil {
+			return nil, err
+		}
+		return &res, nil
+	}
+	if interceptor == nil {
+		return handler(ctx, &req)
+	}
+
+	info := &grpc.UnaryServerInfo{
+		Server:     srv,
+		FullMethod: GRPCCallMethod,
+	}
+	return interceptor(ctx, &req, info, handler)
+}
+
+// GRPCCodec encodes the *Request and *Response exchanged over gRPC with
+// amino.
+var GRPCCodec grpcCodec
+
+type grpcCodec struct{}
+
+func (grpcCodec) Name() string { return "amino" }
+
+func (grpcCodec) Marshal(v any) ([]byte, error) {
+	switch v := v.(type) {
+	case *Request:
+		return amino.MarshalAny(*v)
+	case *Response:
+		return amino.MarshalAny(*v)
+	default:
+		return nil, fmt.Errorf("unsupported gRPC message %T", v)
+	}
+}
+
+func (grpcCodec) Unmarshal(data []byte, v any) error {
+	switch v.(type) {
+	case *Request, *Response:
+		return amino.UnmarshalAny(data, v)
+	default:
+		return fmt.Errorf("unsupported gRPC message %T", v)
+	}
+}
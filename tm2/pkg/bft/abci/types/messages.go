@@ -0,0 +1,31 @@
+package abci
+
+import (
+	"io"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+)
+
+// MaxMessageSize is the maximum size of a request or response exchanged
+// with an application over a socket. It is large enough for the genesis
+// state sent with RequestInitChain.
+const MaxMessageSize = 1 << 30 // 1GB
+
+// WriteMessage writes a length-prefixed, amino encoded Request or Response
+// to w.
+func WriteMessage(w io.Writer, msg any) error {
+	_, err := amino.MarshalAnySizedWriter(w, msg)
+	return err
+}
+
+// ReadRequest reads a Request written by WriteMessage from r.
+func ReadRequest(r io.Reader) (req Request, err error) {
+	_, err = amino.UnmarshalSizedReader(r, &req, MaxMessageSize)
+	return
+}
+
+// ReadResponse reads a Response written by WriteMessage from r.
+func ReadResponse(r io.Reader) (res Response, err error) {
+	_, err = amino.UnmarshalSizedReader(r, &res, MaxMessageSize)
+	return
+}
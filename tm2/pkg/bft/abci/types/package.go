@@ -37,6 +37,8 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 		RequestDeliverTx{},
 		RequestEndBlock{},
 		RequestCommit{},
+		RequestPrepareProposal{},
+		RequestProcessProposal{},
 
 		// response types
 		ResponseBase{},
@@ -52,6 +54,8 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 		ResponseDeliverTx{},
 		ResponseEndBlock{},
 		ResponseCommit{},
+		ResponsePrepareProposal{},
+		ResponseProcessProposal{},
 
 		// error types
 		StringError(""),
@@ -72,5 +76,16 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 		// mocks
 		MockHeader{},
 
+		// tracing
+		TraceTxRequest{},
+		TxTrace{},
+		TraceCall{},
+		TraceStoreOp{},
+
+		// metadata
+		AppMetadata{},
+		MsgTypeInfo{},
+		ModuleInfo{},
+
 		// Params (abci/types/params.go)
 	))
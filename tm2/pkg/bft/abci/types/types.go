@@ -91,6 +91,29 @@ type RequestCommit struct {
 	RequestBase
 }
 
+// RequestPrepareProposal is sent to the proposer before it builds a block.
+// Txs are the candidate transactions reaped from the mempool, in mempool
+// order; the application may reorder or drop txs, or add txs of the mempool,
+// within MaxTxBytes and MaxGas (if not negative). Txs unknown to the mempool
+// have no known gas wanted, and can only be added when MaxGas is negative.
+type RequestPrepareProposal struct {
+	RequestBase
+	Height          int64
+	Txs             [][]byte
+	MaxTxBytes      int64
+	MaxGas          int64
+	ProposerAddress crypto.Address
+}
+
+// RequestProcessProposal is sent to every validator when a complete proposal
+// block has been received, before the validator prevotes for it.
+type RequestProcessProposal struct {
+	RequestBase
+	Hash   []byte
+	Header Header
+	Txs    [][]byte
+}
+
 // ----------------------------------------
 // Response types
 
@@ -105,6 +128,11 @@ type ResponseBase struct {
 
 	Log  string // nondeterministic
 	Info string // nondeterministic
+
+	// The codespace and code of Error, set by the application from its
+	// registry of error codes, for clients to branch on; see pkg/errcode.
+	Codespace string
+	Code      uint32
 }
 
 func (ResponseBase) AssertResponse() {}
@@ -197,6 +225,20 @@ type ResponseCommit struct {
 	ResponseBase
 }
 
+// ResponsePrepareProposal contains the txs the proposer should include in
+// the block, in order. An error response makes consensus fall back to the
+// unmodified mempool txs.
+type ResponsePrepareProposal struct {
+	ResponseBase
+	Txs [][]byte
+}
+
+// ResponseProcessProposal rejects the proposal when Error is set, in which
+// case the validator prevotes nil.
+type ResponseProcessProposal struct {
+	ResponseBase
+}
+
 // ----------------------------------------
 // Interface types
 
@@ -294,3 +336,70 @@ type Violation struct {
 	TotalVotingPower int64
 }
 */
+
+// ----------------------------------------
+// Tracing
+
+// TraceTxRequest is the data of the ".app/trace" query, asking the
+// application to trace the last of Txs: the transactions are executed in
+// order, in a block with the given Header, on the state committed for the
+// previous height, which is left unchanged.
+type TraceTxRequest struct {
+	Header Header
+	Txs    [][]byte
+}
+
+// TxTrace is the trace of the execution of a transaction, returned by the
+// ".app/trace" query.
+type TxTrace struct {
+	Result   ResponseDeliverTx
+	Calls    []TraceCall    // in the order they were made
+	StoreOps []TraceStoreOp // in the order they were made
+}
+
+// TraceCall is a call made during the execution of a transaction, like the
+// execution of a message, or a call to a smart contract.
+type TraceCall struct {
+	Depth   int    // 0 for the messages of the transaction
+	Name    string // the called message or function
+	Realm   string // the realm the call is made in, if any
+	GasUsed int64  // including the gas used by the nested calls
+}
+
+// TraceStoreOp is an operation made on a store during the execution of a
+// transaction.
+type TraceStoreOp struct {
+	Store string
+	Op    string // get, has, set, delete, or iter
+	Key   []byte
+	Value []byte
+}
+
+// ----------------------------------------
+// Metadata
+
+// AppMetadata describes the application and what it supports, returned by
+// the ".app/metadata" query, so that clients can adapt to the chain without
+// trial and error.
+type AppMetadata struct {
+	Name     string
+	Version  string
+	Features []string      // like "simulate" or "events", sorted
+	MsgTypes []MsgTypeInfo // the messages the transactions can hold
+	Modules  []ModuleInfo
+}
+
+// MsgTypeInfo describes a message type accepted by the application.
+type MsgTypeInfo struct {
+	Route   string // the route of the handler of the message
+	Type    string
+	TypeURL string // the amino type URL, like "/vm.m_call"
+}
+
+// ModuleInfo describes a module of the application, like the VM.
+type ModuleInfo struct {
+	Name    string
+	Version string
+	Hash    string // identifies the code run by the module, if relevant
+	Params  string // the current params of the module, in amino JSON
+}
@@ -19,6 +19,9 @@ type Consensus interface {
 	DeliverTxAsync(abci.RequestDeliverTx) *abcicli.ReqRes
 	EndBlockSync(abci.RequestEndBlock) (abci.ResponseEndBlock, error)
 	CommitSync() (abci.ResponseCommit, error)
+
+	PrepareProposalSync(abci.RequestPrepareProposal) (abci.ResponsePrepareProposal, error)
+	ProcessProposalSync(abci.RequestProcessProposal) (abci.ResponseProcessProposal, error)
 }
 
 type Mempool interface {
@@ -82,6 +85,14 @@ func (app *consensus) CommitSync() (abci.ResponseCommit, error) {
 	return app.appConn.CommitSync()
 }
 
+func (app *consensus) PrepareProposalSync(req abci.RequestPrepareProposal) (abci.ResponsePrepareProposal, error) {
+	return app.appConn.PrepareProposalSync(req)
+}
+
+func (app *consensus) ProcessProposalSync(req abci.RequestProcessProposal) (abci.ResponseProcessProposal, error) {
+	return app.appConn.ProcessProposalSync(req)
+}
+
 //------------------------------------------------
 // Implements Mempool (subset of abcicli.Client)
 
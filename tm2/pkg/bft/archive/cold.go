@@ -0,0 +1,71 @@
+package archive
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	osm "github.com/gnolang/gno/tm2/pkg/os"
+)
+
+// ColdStore is a secondary store holding the values offloaded from a DB.
+// It can be slower than the DB, but must be durable: Put returns once the
+// value is persisted. A ColdStore can be backed by any blob storage, like the
+// local filesystem or an S3-compatible object store.
+type ColdStore interface {
+	// Get returns the value of key, or nil if it doesn't exist.
+	Get(key []byte) ([]byte, error)
+
+	// Put persists the value of key, overwriting any previous value.
+	Put(key, value []byte) error
+}
+
+// FileStore is a ColdStore saving each value in a file of a directory,
+// usually on a cheaper disk than the DB
+type FileStore struct {
+	dir string
+}
+
+var _ ColdStore = (*FileStore)(nil)
+
+// NewFileStore returns a FileStore saving the values in dir,
+// which is created if needed
+func NewFileStore(dir string) (*FileStore, error) {
+	if err := osm.EnsureDir(dir, 0o700); err != nil {
+		return nil, fmt.Errorf("unable to create cold store directory, %w", err)
+	}
+
+	return &FileStore{dir: dir}, nil
+}
+
+// path returns the path of the file holding the value of key. The files are
+// spread in 256 subdirectories, so none grows too large.
+func (s *FileStore) path(key []byte) string {
+	sum := sha256.Sum256(key)
+
+	return filepath.Join(s.dir, hex.EncodeToString(sum[:1]), hex.EncodeToString(key))
+}
+
+// Get returns the value of key, or nil if it doesn't exist
+func (s *FileStore) Get(key []byte) ([]byte, error) {
+	value, err := os.ReadFile(s.path(key))
+	if errors.Is(err, fs.ErrNotExist) {
+		return nil, nil
+	}
+
+	return value, err
+}
+
+// Put writes the value of key to its file, atomically
+func (s *FileStore) Put(key, value []byte) error {
+	path := s.path(key)
+	if err := osm.EnsureDir(filepath.Dir(path), 0o700); err != nil {
+		return err
+	}
+
+	return osm.WriteFileAtomic(path, value, 0o600)
+}
@@ -0,0 +1,50 @@
+package config
+
+import (
+	"path/filepath"
+
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+// -----------------------------------------------------------------------------
+// ArchiveConfig
+
+// ArchiveConfig defines the configuration options for the archival mode, in
+// which the blocks and their results older than RetainBlocks heights are moved
+// from the local databases to a cold store, where they remain queryable
+type ArchiveConfig struct {
+	RootDir       string `json:"home" toml:"home"`
+	ColdStorePath string `json:"cold_store_dir" toml:"cold_store_dir" comment:"Directory of the cold store, holding the blocks and results older than retain_blocks.\n The archival mode is disabled if empty. A relative path is relative to the node's root directory."`
+	RetainBlocks  int64  `json:"retain_blocks" toml:"retain_blocks" comment:"Number of the most recent blocks kept in the local databases in archival mode"`
+}
+
+// DefaultArchiveConfig returns a default configuration for the archival mode,
+// which is disabled
+func DefaultArchiveConfig() *ArchiveConfig {
+	return &ArchiveConfig{
+		ColdStorePath: "",
+		RetainBlocks:  100000,
+	}
+}
+
+// ColdStoreDir returns the full path to the cold store
+func (cfg *ArchiveConfig) ColdStoreDir() string {
+	if filepath.IsAbs(cfg.ColdStorePath) {
+		return cfg.ColdStorePath
+	}
+	return filepath.Join(cfg.RootDir, cfg.ColdStorePath)
+}
+
+// Enabled returns true if the archival mode is enabled.
+func (cfg *ArchiveConfig) Enabled() bool {
+	return cfg.ColdStorePath != ""
+}
+
+// ValidateBasic performs basic validation (checking param bounds, etc.) and
+// returns an error if any check fails.
+func (cfg *ArchiveConfig) ValidateBasic() error {
+	if cfg.RetainBlocks < 1 {
+		return errors.New("retain_blocks must be positive")
+	}
+	return nil
+}
@@ -0,0 +1,68 @@
+package archive
+
+import (
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+)
+
+// DB is a database whose values can be offloaded to a cold store.
+// Reads fall back to the cold store for the keys missing from the database,
+// so the offloaded values remain readable, with a higher latency.
+// Iterators only cover the values left in the database.
+type DB struct {
+	dbm.DB
+
+	cold ColdStore
+}
+
+// NewDB returns a DB offloading the values of hot to cold
+func NewDB(hot dbm.DB, cold ColdStore) *DB {
+	return &DB{
+		DB:   hot,
+		cold: cold,
+	}
+}
+
+// Get returns the value of key from the database, or from the cold store
+// if it was offloaded
+func (db *DB) Get(key []byte) ([]byte, error) {
+	value, err := db.DB.Get(key)
+	if err != nil || value != nil {
+		return value, err
+	}
+
+	return db.cold.Get(key)
+}
+
+// Has checks if key exists in the database or in the cold store
+func (db *DB) Has(key []byte) (bool, error) {
+	value, err := db.Get(key)
+
+	return value != nil, err
+}
+
+// Offload moves the values of keys from the database to the cold store.
+// The keys missing from the database are skipped. Values are deleted from
+// the database only once they are persisted in the cold store.
+func (db *DB) Offload(keys [][]byte) error {
+	batch := db.DB.NewBatch()
+	defer batch.Close()
+
+	for _, key := range keys {
+		value, err := db.DB.Get(key)
+		if err != nil {
+			return err
+		}
+		if value == nil {
+			continue
+		}
+
+		if err := db.cold.Put(key, value); err != nil {
+			return err
+		}
+		if err := batch.Delete(key); err != nil {
+			return err
+		}
+	}
+
+	return batch.WriteSync()
+}
@@ -0,0 +1,84 @@
+package archive
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+)
+
+func TestFileStore(t *testing.T) {
+	t.Parallel()
+
+	s, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	value, err := s.Get([]byte("H:1"))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	require.NoError(t, s.Put([]byte("H:1"), []byte("meta")))
+	require.NoError(t, s.Put([]byte("P:1:0"), []byte("part")))
+	require.NoError(t, s.Put([]byte("H:1"), []byte("meta2")))
+
+	value, err = s.Get([]byte("H:1"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("meta2"), value)
+
+	value, err = s.Get([]byte("P:1:0"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("part"), value)
+}
+
+func TestDB_Offload(t *testing.T) {
+	t.Parallel()
+
+	cold, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	hot := memdb.NewMemDB()
+	db := NewDB(hot, cold)
+	require.NoError(t, db.Set([]byte("a"), []byte("1")))
+	require.NoError(t, db.Set([]byte("b"), []byte("2")))
+	require.NoError(t, db.Set([]byte("c"), []byte("3")))
+
+	// Missing keys are skipped
+	require.NoError(t, db.Offload([][]byte{[]byte("a"), []byte("b"), []byte("missing")}))
+
+	// The offloaded values are deleted from the database...
+	for _, key := range []string{"a", "b"} {
+		ok, err := hot.Has([]byte(key))
+		require.NoError(t, err)
+		assert.False(t, ok, key)
+	}
+	value, err := hot.Get([]byte("c"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("3"), value)
+
+	// ...but remain readable
+	for key, want := range map[string]string{"a": "1", "b": "2", "c": "3"} {
+		value, err := db.Get([]byte(key))
+		require.NoError(t, err)
+		assert.Equal(t, []byte(want), value, key)
+
+		ok, err := db.Has([]byte(key))
+		require.NoError(t, err)
+		assert.True(t, ok, key)
+	}
+
+	value, err = db.Get([]byte("missing"))
+	require.NoError(t, err)
+	assert.Nil(t, value)
+
+	ok, err := db.Has([]byte("missing"))
+	require.NoError(t, err)
+	assert.False(t, ok)
+
+	// A value set again is read from the database
+	require.NoError(t, db.Set([]byte("a"), []byte("4")))
+	value, err = db.Get([]byte("a"))
+	require.NoError(t, err)
+	assert.Equal(t, []byte("4"), value)
+}
@@ -0,0 +1,175 @@
+package archive
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+	"sync/atomic"
+
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/events"
+	"github.com/gnolang/gno/tm2/pkg/service"
+)
+
+const archiveListenerID = "archive"
+
+// archiveHeightKey is the key of the height of the last offloaded block,
+// in the block store database
+var archiveHeightKey = []byte("archiveHeight")
+
+// BlockStore loads the metas of the committed blocks
+type BlockStore interface {
+	Height() int64
+	LoadBlockMeta(height int64) *types.BlockMeta
+}
+
+// Service offloads the blocks older than the most recent retain ones, with
+// their results, from the block store and state databases to their cold
+// stores, as new blocks are committed.
+// A failed offload is retried with the next block.
+type Service struct {
+	service.BaseService
+
+	cancelFn context.CancelFunc
+
+	blockDB    *DB
+	stateDB    *DB
+	blockStore BlockStore
+	evsw       events.EventSwitch
+	retain     int64
+
+	next   int64        // height of the next block to offload
+	latest atomic.Int64 // height of the last committed block
+	notify chan struct{}
+}
+
+// NewService returns a new archive service, offloading the blocks of
+// blockStore, saved in blockDB, and their results, saved in stateDB
+func NewService(
+	blockDB *DB,
+	stateDB *DB,
+	blockStore BlockStore,
+	evsw events.EventSwitch,
+	retain int64,
+) *Service {
+	s := &Service{
+		blockDB:    blockDB,
+		stateDB:    stateDB,
+		blockStore: blockStore,
+		evsw:       evsw,
+		retain:     retain,
+		notify:     make(chan struct{}, 1),
+	}
+	s.BaseService = *service.NewBaseService(nil, "ArchiveService", s)
+
+	return s
+}
+
+func (s *Service) OnStart() error {
+	last, err := loadArchiveHeight(s.blockDB)
+	if err != nil {
+		return err
+	}
+	s.next = last + 1
+
+	// Catch up with the blocks committed so far, then with every new one
+	s.setLatest(s.blockStore.Height())
+	s.evsw.AddListener(archiveListenerID, func(ev events.Event) {
+		if ev, ok := ev.(types.EventNewBlock); ok {
+			s.setLatest(ev.Block.Height)
+		}
+	})
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	s.cancelFn = cancelFn
+
+	go s.offloadBlocks(ctx)
+
+	return nil
+}
+
+func (s *Service) OnStop() {
+	s.evsw.RemoveListener(archiveListenerID)
+
+	s.cancelFn()
+}
+
+// setLatest records a newly committed height, and wakes up offloadBlocks
+func (s *Service) setLatest(height int64) {
+	for {
+		latest := s.latest.Load()
+		if height <= latest {
+			return
+		}
+		if s.latest.CompareAndSwap(latest, height) {
+			break
+		}
+	}
+
+	select {
+	case s.notify <- struct{}{}:
+	default: // already notified
+	}
+}
+
+// offloadBlocks offloads the blocks falling out of the retained ones,
+// as they come
+func (s *Service) offloadBlocks(ctx context.Context) {
+	for {
+		for s.next <= s.latest.Load()-s.retain {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err := s.offloadBlock(s.next); err != nil {
+				// Retried with the next block
+				s.Logger.Error("unable to offload block", "height", s.next, "err", err)
+
+				break
+			}
+
+			s.next++
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-s.notify:
+		}
+	}
+}
+
+// offloadBlock moves the block at height and its results to the cold stores
+func (s *Service) offloadBlock(height int64) error {
+	// Blocks before the initial height of the chain are missing
+	if meta := s.blockStore.LoadBlockMeta(height); meta != nil {
+		keys := store.BlockKeys(height, meta.BlockID.PartsHeader.Total)
+		if err := s.blockDB.Offload(keys); err != nil {
+			return fmt.Errorf("unable to offload block, %w", err)
+		}
+	}
+
+	if err := s.stateDB.Offload([][]byte{sm.CalcABCIResponsesKey(height)}); err != nil {
+		return fmt.Errorf("unable to offload block results, %w", err)
+	}
+
+	return s.blockDB.DB.SetSync(archiveHeightKey, []byte(strconv.FormatInt(height, 10)))
+}
+
+// loadArchiveHeight returns the height of the last offloaded block,
+// saved in the block store database
+func loadArchiveHeight(db *DB) (int64, error) {
+	bz, err := db.DB.Get(archiveHeightKey)
+	if err != nil || bz == nil {
+		return 0, err
+	}
+
+	height, err := strconv.ParseInt(string(bz), 10, 64)
+	if err != nil {
+		return 0, fmt.Errorf("invalid archive height %q, %w", bz, err)
+	}
+
+	return height, nil
+}
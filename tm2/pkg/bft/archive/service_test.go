@@ -0,0 +1,121 @@
+package archive
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/store"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	tmtime "github.com/gnolang/gno/tm2/pkg/bft/types/time"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/events"
+)
+
+// newTestDB returns a DB over a memory database and a cold store
+// in a temporary directory
+func newTestDB(t *testing.T) (*DB, *memdb.MemDB) {
+	t.Helper()
+
+	cold, err := NewFileStore(t.TempDir())
+	require.NoError(t, err)
+
+	hot := memdb.NewMemDB()
+
+	return NewDB(hot, cold), hot
+}
+
+// commitBlocks saves blocks up to height in the stores, as consensus does,
+// with one transaction each
+func commitBlocks(t *testing.T, bs *store.BlockStore, stateDB *DB, from, to int64) {
+	t.Helper()
+
+	for height := from; height <= to; height++ {
+		block := types.MakeBlock(height, types.Txs{types.Tx{byte(height)}}, new(types.Commit))
+		seenCommit := types.NewCommit(types.BlockID{}, []*types.CommitSig{{Height: height, Timestamp: tmtime.Now()}})
+		bs.SaveBlock(block, block.MakePartSet(2), seenCommit)
+
+		sm.SaveABCIResponses(stateDB, height, &sm.ABCIResponses{
+			DeliverTxs: []abci.ResponseDeliverTx{{GasUsed: height}},
+		})
+	}
+}
+
+func TestService(t *testing.T) {
+	t.Parallel()
+
+	const defaultTimeout = 5 * time.Second
+
+	var (
+		blockDB, hotBlockDB = newTestDB(t)
+		stateDB, hotStateDB = newTestDB(t)
+
+		bs   = store.NewBlockStore(blockDB)
+		evsw = events.NewEventSwitch()
+	)
+
+	archived := func() int64 {
+		height, err := loadArchiveHeight(blockDB)
+		require.NoError(t, err)
+
+		return height
+	}
+
+	// Blocks 1 to 10 are committed, and the last 3 are retained
+	commitBlocks(t, bs, stateDB, 1, 10)
+
+	s := NewService(blockDB, stateDB, bs, evsw, 3)
+	require.NoError(t, s.Start())
+
+	require.Eventually(t, func() bool {
+		return archived() == 7
+	}, defaultTimeout, 10*time.Millisecond)
+
+	// The offloaded blocks and results are deleted from the databases
+	for height := int64(1); height <= 10; height++ {
+		ok, err := hotBlockDB.Has(store.BlockKeys(height, 1)[0])
+		require.NoError(t, err)
+		assert.Equal(t, height > 7, ok, height)
+
+		ok, err = hotStateDB.Has(sm.CalcABCIResponsesKey(height))
+		require.NoError(t, err)
+		assert.Equal(t, height > 7, ok, height)
+	}
+
+	// ...but they remain loadable
+	for height := int64(1); height <= 10; height++ {
+		block := bs.LoadBlock(height)
+		require.NotNil(t, block, height)
+		assert.Equal(t, types.Tx{byte(height)}, block.Txs[0])
+		assert.NotNil(t, bs.LoadSeenCommit(height), height)
+
+		responses, err := sm.LoadABCIResponses(stateDB, height)
+		require.NoError(t, err)
+		assert.Equal(t, height, responses.DeliverTxs[0].GasUsed)
+	}
+
+	// New blocks push the older ones out
+	commitBlocks(t, bs, stateDB, 11, 12)
+	evsw.FireEvent(types.EventNewBlock{Block: bs.LoadBlock(12)})
+
+	require.Eventually(t, func() bool {
+		return archived() == 9
+	}, defaultTimeout, 10*time.Millisecond)
+
+	// A restarted service resumes after the last offloaded block
+	require.NoError(t, s.Stop())
+	commitBlocks(t, bs, stateDB, 13, 13)
+
+	s = NewService(blockDB, stateDB, bs, evsw, 3)
+	require.NoError(t, s.Start())
+	defer s.Stop()
+
+	require.Eventually(t, func() bool {
+		return archived() == 10
+	}, defaultTimeout, 10*time.Millisecond)
+	assert.NotNil(t, bs.LoadBlock(1))
+}
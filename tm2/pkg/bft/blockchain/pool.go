@@ -5,6 +5,7 @@ import (
 	"fmt"
 	"log/slog"
 	"math"
+	"slices"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -44,6 +45,9 @@ const (
 
 	// Maximum difference between current and new block's height.
 	maxDiffBetweenCurrentAndReceivedBlockHeight = 100
+
+	// Weight of the latest sample in a peer's block latency moving average.
+	peerLatencyAlpha = 0.2
 )
 
 var peerTimeout = 15 * time.Second // not const so we can override with tests
@@ -199,6 +203,27 @@ func (pool *BlockPool) PeekTwoBlocks() (first *types.Block, second *types.Block)
 	return
 }
 
+// PeekBlocks returns up to n consecutive blocks starting at pool.height,
+// stopping at the first block which hasn't been received yet.
+func (pool *BlockPool) PeekBlocks(n int) []*types.Block {
+	pool.mtx.Lock()
+	defer pool.mtx.Unlock()
+
+	var blocks []*types.Block
+	for h := pool.height; len(blocks) < n; h++ {
+		r := pool.requesters[h]
+		if r == nil {
+			break
+		}
+		block := r.getBlock()
+		if block == nil {
+			break
+		}
+		blocks = append(blocks, block)
+	}
+	return blocks
+}
+
 // PopRequest pops the first block at pool.height.
 // It must have been validated by 'second'.Commit from PeekTwoBlocks().
 func (pool *BlockPool) PopRequest() {
@@ -259,6 +284,7 @@ func (pool *BlockPool) AddBlock(peerID p2pTypes.ID, block *types.Block, blockSiz
 		peer := pool.peers[peerID]
 		if peer != nil {
 			peer.decrPending(blockSize)
+			peer.updateLatency(time.Since(requester.getRequestedAt()))
 		}
 	} else {
 		pool.Logger.Info("invalid peer", "peer", peerID, "blockHeight", block.Height)
@@ -284,6 +310,7 @@ func (pool *BlockPool) SetPeerHeight(peerID p2pTypes.ID, height int64) {
 	} else {
 		peer = newBPPeer(pool, peerID, height)
 		peer.setLogger(pool.Logger.With("peer", peerID))
+		peer.latency = pool.medianLatency()
 		pool.peers[peerID] = peer
 	}
 
@@ -335,12 +362,35 @@ func (pool *BlockPool) updateMaxPeerHeight() {
 	pool.maxPeerHeight = maxVal
 }
 
-// Pick an available peer with at least the given minHeight.
+// medianLatency returns the median block latency of the peers which have
+// delivered blocks, or zero if there are none.
+// CONTRACT: pool.mtx is held.
+func (pool *BlockPool) medianLatency() time.Duration {
+	var latencies []time.Duration
+	for _, peer := range pool.peers {
+		if peer.latency != 0 {
+			latencies = append(latencies, peer.latency)
+		}
+	}
+	if len(latencies) == 0 {
+		return 0
+	}
+	slices.Sort(latencies)
+	mid := len(latencies) / 2
+	if len(latencies)%2 == 0 {
+		return (latencies[mid-1] + latencies[mid]) / 2
+	}
+	return latencies[mid]
+}
+
+// Pick the available peer with at least the given minHeight which is expected
+// to deliver a block the soonest (see bpPeer.score).
 // If no peers are available, returns nil.
 func (pool *BlockPool) pickIncrAvailablePeer(minHeight int64) *bpPeer {
 	pool.mtx.Lock()
 	defer pool.mtx.Unlock()
 
+	var best *bpPeer
 	for _, peer := range pool.peers {
 		if peer.didTimeout {
 			pool.removePeer(peer.id)
@@ -352,10 +402,14 @@ func (pool *BlockPool) pickIncrAvailablePeer(minHeight int64) *bpPeer {
 		if peer.height < minHeight {
 			continue
 		}
-		peer.incrPending()
-		return peer
+		if best == nil || peer.score() < best.score() {
+			best = peer
+		}
 	}
-	return nil
+	if best != nil {
+		best.incrPending()
+	}
+	return best
 }
 
 func (pool *BlockPool) makeNextRequester() {
@@ -428,6 +482,12 @@ type bpPeer struct {
 	timeout    *time.Timer
 	didTimeout bool
 
+	// moving average of the time between a block request and its response.
+	// New peers start at the median latency of the other peers, so that they
+	// are neither favored over nor starved by the known ones; it is zero
+	// until a peer delivers a block if no latency is known yet.
+	latency time.Duration
+
 	logger *slog.Logger
 }
 
@@ -478,6 +538,23 @@ func (peer *bpPeer) decrPending(recvSize int) {
 	}
 }
 
+// updateLatency records the time it took the peer to respond to a block
+// request.
+func (peer *bpPeer) updateLatency(d time.Duration) {
+	if peer.latency == 0 {
+		peer.latency = d
+		return
+	}
+	peer.latency = time.Duration(peerLatencyAlpha*float64(d) + (1-peerLatencyAlpha)*float64(peer.latency))
+}
+
+// score returns the expected time for the peer to deliver one more block,
+// given its pending requests. Lower is better; peers with no known latency
+// score 0, so that they are tried out first.
+func (peer *bpPeer) score() time.Duration {
+	return peer.latency * time.Duration(peer.numPending+1)
+}
+
 func (peer *bpPeer) onTimeout() {
 	peer.pool.mtx.Lock()
 	defer peer.pool.mtx.Unlock()
@@ -497,9 +574,10 @@ type bpRequester struct {
 	gotBlockCh chan struct{}
 	redoCh     chan p2pTypes.ID // redo may send multitime, add peerId to identify repeat
 
-	mtx    sync.Mutex
-	peerID p2pTypes.ID
-	block  *types.Block
+	mtx         sync.Mutex
+	peerID      p2pTypes.ID
+	block       *types.Block
+	requestedAt time.Time
 }
 
 func newBPRequester(pool *BlockPool, height int64) *bpRequester {
@@ -544,6 +622,12 @@ func (bpr *bpRequester) getBlock() *types.Block {
 	return bpr.block
 }
 
+func (bpr *bpRequester) getRequestedAt() time.Time {
+	bpr.mtx.Lock()
+	defer bpr.mtx.Unlock()
+	return bpr.requestedAt
+}
+
 func (bpr *bpRequester) getPeerID() p2pTypes.ID {
 	bpr.mtx.Lock()
 	defer bpr.mtx.Unlock()
@@ -595,6 +679,7 @@ OUTER_LOOP:
 		}
 		bpr.mtx.Lock()
 		bpr.peerID = peer.id
+		bpr.requestedAt = time.Now()
 		bpr.mtx.Unlock()
 
 		// Send request and wait.
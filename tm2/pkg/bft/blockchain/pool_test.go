@@ -228,3 +228,53 @@ func TestBlockPoolRemovePeer(t *testing.T) {
 
 	assert.EqualValues(t, 0, pool.MaxPeerHeight())
 }
+
+func TestBlockPoolPicksFastestPeer(t *testing.T) {
+	t.Parallel()
+
+	pool := NewBlockPool(1, make(chan BlockRequest), make(chan peerError))
+	pool.SetLogger(log.NewTestingLogger(t))
+
+	pool.SetPeerHeight("slow", 100)
+	pool.SetPeerHeight("fast", 100)
+	pool.peers["slow"].latency = 250 * time.Millisecond
+	pool.peers["fast"].latency = 100 * time.Millisecond
+
+	// The fast peer is picked while its pending requests make it expected
+	// to deliver sooner than the slow one.
+	for range 2 {
+		peer := pool.pickIncrAvailablePeer(1)
+		require.NotNil(t, peer)
+		assert.EqualValues(t, "fast", peer.id)
+	}
+	peer := pool.pickIncrAvailablePeer(1)
+	require.NotNil(t, peer)
+	assert.EqualValues(t, "slow", peer.id)
+
+	// A new peer starts at the median latency, which makes it the best
+	// pick while the others have pending requests.
+	pool.SetPeerHeight("new", 100)
+	assert.Equal(t, 175*time.Millisecond, pool.peers["new"].latency)
+	peer = pool.pickIncrAvailablePeer(1)
+	require.NotNil(t, peer)
+	assert.EqualValues(t, "new", peer.id)
+
+	// Peers which are too short are never picked.
+	assert.Nil(t, pool.pickIncrAvailablePeer(101))
+}
+
+func TestBPPeerUpdateLatency(t *testing.T) {
+	t.Parallel()
+
+	peer := newBPPeer(nil, "peer", 10)
+	assert.Zero(t, peer.score())
+
+	peer.updateLatency(time.Second)
+	assert.Equal(t, time.Second, peer.latency)
+
+	peer.updateLatency(2 * time.Second)
+	assert.Equal(t, 1200*time.Millisecond, peer.latency)
+
+	peer.numPending = 2
+	assert.Equal(t, 3600*time.Millisecond, peer.score())
+}
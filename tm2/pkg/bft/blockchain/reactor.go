@@ -183,13 +183,13 @@ func (bcR *BlockchainReactor) Receive(chID byte, src p2p.PeerConn, msgBytes []by
 	msg, err := decodeMsg(msgBytes)
 	if err != nil {
 		bcR.Logger.Error("Error decoding message", "src", src, "chId", chID, "msg", msg, "err", err, "bytes", msgBytes)
-		bcR.Switch.StopPeerForError(src, err)
+		bcR.Switch.ReportMisbehavior(src, p2p.MisbehaviorMalformedMessage, err)
 		return
 	}
 
 	if err = msg.ValidateBasic(); err != nil {
 		bcR.Logger.Error("Peer sent us invalid msg", "peer", src, "msg", msg, "err", err)
-		bcR.Switch.StopPeerForError(src, err)
+		bcR.Switch.ReportMisbehavior(src, p2p.MisbehaviorMalformedMessage, err)
 		return
 	}
 
@@ -229,6 +229,24 @@ func (bcR *BlockchainReactor) poolRoutine() {
 
 	didProcessCh := make(chan struct{}, 1)
 
+	// verify the commits of the next blocks while applying the current one.
+	verifier := newBlockVerifier(chainID, bcR.pool, state.Validators)
+	go func() {
+		ticker := time.NewTicker(trySyncIntervalMS * time.Millisecond)
+		defer ticker.Stop()
+
+		for {
+			select {
+			case <-bcR.Quit():
+				return
+			case <-bcR.pool.Quit():
+				return
+			case <-ticker.C:
+				verifier.verifyAhead()
+			}
+		}
+	}()
+
 	go func() {
 		for {
 			select {
@@ -304,30 +322,26 @@ FOR_LOOP:
 				didProcessCh <- struct{}{}
 			}
 
-			firstParts := first.MakePartSet(types.BlockPartSizeBytes)
-			firstPartsHeader := firstParts.Header()
-			firstID := types.BlockID{Hash: first.Hash(), PartsHeader: firstPartsHeader}
 			// Finally, verify the first block using the second's commit
-			// NOTE: we can probably make this more efficient, but note that calling
-			// first.Hash() doesn't verify the tx contents, so MakePartSet() is
-			// currently necessary.
-			err := state.Validators.VerifyCommit(
-				chainID, firstID, first.Height, second.LastCommit)
-			if err != nil {
+			verified := verifier.verify(state.Validators, first, second)
+			firstParts, firstID := verified.parts, verified.id
+			if err := verified.err; err != nil {
 				bcR.Logger.Error("Error in validation", "err", err)
+				// The blocks of the removed peers are requested again.
+				verifier.forgetFrom(first.Height)
 				peerID := bcR.pool.RedoRequest(first.Height)
 				peer := bcR.Switch.Peers().Get(peerID)
 				if peer != nil {
 					// NOTE: we've already removed the peer's request, but we
 					// still need to clean up the rest.
-					bcR.Switch.StopPeerForError(peer, fmt.Errorf("BlockchainReactor validation error: %w", err))
+					bcR.Switch.ReportMisbehavior(peer, p2p.MisbehaviorInvalidBlock, fmt.Errorf("BlockchainReactor validation error: %w", err))
 				}
 				peerID2 := bcR.pool.RedoRequest(second.Height)
 				peer2 := bcR.Switch.Peers().Get(peerID2)
 				if peer2 != nil && peer2 != peer {
 					// NOTE: we've already removed the peer's request, but we
 					// still need to clean up the rest.
-					bcR.Switch.StopPeerForError(peer2, fmt.Errorf("BlockchainReactor validation error: %w", err))
+					bcR.Switch.ReportMisbehavior(peer2, p2p.MisbehaviorInvalidBlock, fmt.Errorf("BlockchainReactor validation error: %w", err))
 				}
 				continue FOR_LOOP
 			} else {
@@ -344,6 +358,7 @@ FOR_LOOP:
 					// TODO This is bad, are we zombie?
 					panic(fmt.Sprintf("Failed to process committed block (%d:%X): %v", first.Height, first.Hash(), err))
 				}
+				verifier.setValidators(state.Validators)
 				blocksSynced++
 
 				if blocksSynced%100 == 0 {
@@ -0,0 +1,156 @@
+package blockchain
+
+import (
+	"bytes"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
+
+// Number of blocks ahead of the one being applied that are verified in the
+// background.
+const verifyPipelineDepth = 16
+
+// verifiedBlock is the result of verifying first with the commit included in
+// second, against the validator set with hash valsHash.
+type verifiedBlock struct {
+	first    *types.Block
+	second   *types.Block
+	valsHash []byte
+
+	parts *types.PartSet
+	id    types.BlockID
+	err   error
+}
+
+func (vb *verifiedBlock) matches(first, second *types.Block, valsHash []byte) bool {
+	return vb != nil &&
+		vb.first == first &&
+		vb.second == second &&
+		bytes.Equal(vb.valsHash, valsHash)
+}
+
+// blockVerifier verifies the commits of the blocks waiting in the pool while
+// the previous ones are being applied, so that verification and execution are
+// pipelined.
+//
+// Verification is done against the latest known validator set; the result is
+// only used if the validator set didn't change by the time the block is
+// applied, and the block is verified again otherwise.
+//
+// Verifying a block memoizes values in the block and its commit, so the
+// blocks being applied are never verified concurrently.
+type blockVerifier struct {
+	chainID string
+	pool    *BlockPool
+
+	workMtx sync.Mutex // held while verifying a block.
+
+	mtx      sync.Mutex
+	vals     *types.ValidatorSet
+	applying int64 // height of the block being, or next to be, applied.
+	results  map[int64]*verifiedBlock
+}
+
+func newBlockVerifier(chainID string, pool *BlockPool, vals *types.ValidatorSet) *blockVerifier {
+	height, _, _ := pool.GetStatus()
+	return &blockVerifier{
+		chainID:  chainID,
+		pool:     pool,
+		vals:     vals.Copy(),
+		applying: height,
+		results:  make(map[int64]*verifiedBlock),
+	}
+}
+
+// setValidators updates the validator set used to verify the next blocks.
+func (bv *blockVerifier) setValidators(vals *types.ValidatorSet) {
+	bv.mtx.Lock()
+	defer bv.mtx.Unlock()
+
+	bv.vals = vals.Copy()
+}
+
+// verifyAhead verifies the blocks received by the pool which have not been
+// verified against the current validator set yet.
+func (bv *blockVerifier) verifyAhead() {
+	blocks := bv.pool.PeekBlocks(verifyPipelineDepth + 1)
+	for i := 0; i+1 < len(blocks); i++ {
+		bv.verifyAhead1(blocks[i], blocks[i+1])
+	}
+}
+
+func (bv *blockVerifier) verifyAhead1(first, second *types.Block) {
+	bv.workMtx.Lock()
+	defer bv.workMtx.Unlock()
+
+	bv.mtx.Lock()
+	vals := bv.vals
+	// the block being applied and the next one are accessed by the
+	// reactor.
+	skip := first.Height <= bv.applying+1 ||
+		bv.results[first.Height].matches(first, second, vals.Hash())
+	bv.mtx.Unlock()
+	if skip {
+		return
+	}
+
+	res := verifyBlock(bv.chainID, vals, first, second)
+
+	bv.mtx.Lock()
+	bv.results[first.Height] = res
+	bv.mtx.Unlock()
+}
+
+// verify verifies first with the commit included in second, reusing the
+// result of the background verification if possible. first is the next block
+// to be applied.
+func (bv *blockVerifier) verify(vals *types.ValidatorSet, first, second *types.Block) *verifiedBlock {
+	// wait for any background verification to be done.
+	bv.workMtx.Lock()
+	bv.mtx.Lock()
+	bv.applying = first.Height
+	res := bv.results[first.Height]
+	bv.forgetLocked(func(height int64) bool { return height <= first.Height })
+	bv.mtx.Unlock()
+	bv.workMtx.Unlock()
+
+	if res.matches(first, second, vals.Hash()) {
+		return res
+	}
+	return verifyBlock(bv.chainID, vals, first, second)
+}
+
+// forgetFrom drops the results of the blocks at height and above, which are
+// requested again from other peers.
+func (bv *blockVerifier) forgetFrom(height int64) {
+	bv.mtx.Lock()
+	defer bv.mtx.Unlock()
+
+	bv.forgetLocked(func(h int64) bool { return h >= height })
+}
+
+func (bv *blockVerifier) forgetLocked(match func(height int64) bool) {
+	for h := range bv.results {
+		if match(h) {
+			delete(bv.results, h)
+		}
+	}
+}
+
+func verifyBlock(chainID string, vals *types.ValidatorSet, first, second *types.Block) *verifiedBlock {
+	// NOTE: we can probably make this more efficient, but note that calling
+	// first.Hash() doesn't verify the tx contents, so MakePartSet() is
+	// currently necessary.
+	parts := first.MakePartSet(types.BlockPartSizeBytes)
+	id := types.BlockID{Hash: first.Hash(), PartsHeader: parts.Header()}
+	err := vals.VerifyCommit(chainID, id, first.Height, second.LastCommit)
+	return &verifiedBlock{
+		first:    first,
+		second:   second,
+		valsHash: vals.Hash(),
+		parts:    parts,
+		id:       id,
+		err:      err,
+	}
+}
@@ -0,0 +1,66 @@
+package blockchain
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	tmtime "github.com/gnolang/gno/tm2/pkg/bft/types/time"
+)
+
+func TestBlockVerifier(t *testing.T) {
+	t.Parallel()
+
+	val, _ := types.RandValidator(false, 30)
+	state, err := sm.MakeGenesisState(&types.GenesisDoc{
+		GenesisTime: tmtime.Now(),
+		ChainID:     "test-chain",
+		Validators:  []types.GenesisValidator{{PubKey: val.PubKey, Power: val.VotingPower}},
+	})
+	require.NoError(t, err)
+
+	pool := NewBlockPool(1, make(chan BlockRequest), make(chan peerError))
+	blocks := make([]*types.Block, 5)
+	lastCommit := types.NewCommit(types.BlockID{}, nil)
+	for i := range blocks {
+		h := int64(i + 1)
+		blocks[i] = makeBlock(h, state, lastCommit)
+		pool.requesters[h] = &bpRequester{height: h, block: blocks[i]}
+	}
+
+	bv := newBlockVerifier(state.ChainID, pool, state.Validators)
+	bv.verifyAhead()
+	// blocks 1 and 2 are next to be applied, and are left to the reactor.
+	assert.Nil(t, bv.results[1])
+	assert.Nil(t, bv.results[2])
+	require.NotNil(t, bv.results[3])
+	require.NotNil(t, bv.results[4])
+	assert.Nil(t, bv.results[5]) // needs block 6.
+
+	// The commits are empty, so verification fails.
+	res3 := bv.results[3]
+	assert.Error(t, res3.err)
+	assert.Equal(t, blocks[2].Hash(), []byte(res3.id.Hash))
+
+	// Background results are reused.
+	assert.Same(t, res3, bv.verify(state.Validators, blocks[2], blocks[3]))
+	assert.Nil(t, bv.results[3])
+
+	// ... but not if the validator set changed.
+	vals, _ := types.RandValidatorSet(2, 10)
+	res4 := bv.results[4]
+	res := bv.verify(vals, blocks[3], blocks[4])
+	assert.NotSame(t, res4, res)
+	assert.Equal(t, vals.Hash(), res.valsHash)
+	assert.Empty(t, bv.results)
+
+	// Results are dropped when the blocks are requested again.
+	bv.results[5] = res
+	bv.results[6] = res
+	bv.forgetFrom(6)
+	assert.Len(t, bv.results, 1)
+	assert.NotNil(t, bv.results[5])
+}
@@ -11,6 +11,7 @@ import (
 	"dario.cat/mergo"
 
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	archive "github.com/gnolang/gno/tm2/pkg/bft/archive/config"
 	cns "github.com/gnolang/gno/tm2/pkg/bft/consensus/config"
 	mem "github.com/gnolang/gno/tm2/pkg/bft/mempool/config"
 	rpc "github.com/gnolang/gno/tm2/pkg/bft/rpc/config"
@@ -30,11 +31,13 @@ var (
 	errInvalidABCIMechanism     = errors.New("invalid ABCI mechanism")
 	errInvalidProfListenAddress = errors.New("invalid profiling server listen address")
 	errInvalidNodeKeyPath       = errors.New("invalid p2p node key path")
+	errInvalidLogLevel          = errors.New("invalid log level")
 )
 
 const (
 	LocalABCI  = "local"
 	SocketABCI = "socket"
+	GRPCABCI   = "grpc"
 )
 
 // Regular expression for TCP or UNIX socket address
@@ -48,13 +51,14 @@ type Config struct {
 	BaseConfig `toml:",squash"`
 
 	// Options for services
-	RPC          *rpc.RPCConfig       `json:"rpc" toml:"rpc" comment:"##### rpc server configuration options #####"`
-	P2P          *p2p.P2PConfig       `json:"p2p" toml:"p2p" comment:"##### peer to peer configuration options #####"`
-	Mempool      *mem.MempoolConfig   `json:"mempool" toml:"mempool" comment:"##### mempool configuration options #####"`
-	Consensus    *cns.ConsensusConfig `json:"consensus" toml:"consensus" comment:"##### consensus configuration options #####"`
-	TxEventStore *eventstore.Config   `json:"tx_event_store" toml:"tx_event_store" comment:"##### event store #####"`
-	Telemetry    *telemetry.Config    `json:"telemetry" toml:"telemetry" comment:"##### node telemetry #####"`
-	Application  *sdk.AppConfig       `json:"application" toml:"application" comment:"##### app settings #####"`
+	RPC          *rpc.RPCConfig         `json:"rpc" toml:"rpc" comment:"##### rpc server configuration options #####"`
+	P2P          *p2p.P2PConfig         `json:"p2p" toml:"p2p" comment:"##### peer to peer configuration options #####"`
+	Mempool      *mem.MempoolConfig     `json:"mempool" toml:"mempool" comment:"##### mempool configuration options #####"`
+	Consensus    *cns.ConsensusConfig   `json:"consensus" toml:"consensus" comment:"##### consensus configuration options #####"`
+	TxEventStore *eventstore.Config     `json:"tx_event_store" toml:"tx_event_store" comment:"##### event store #####"`
+	Archive      *archive.ArchiveConfig `json:"archive" toml:"archive" comment:"##### archival mode #####"`
+	Telemetry    *telemetry.Config      `json:"telemetry" toml:"telemetry" comment:"##### node telemetry #####"`
+	Application  *sdk.AppConfig         `json:"application" toml:"application" comment:"##### app settings #####"`
 }
 
 // DefaultConfig returns a default configuration for a Tendermint node
@@ -66,6 +70,7 @@ func DefaultConfig() *Config {
 		Mempool:      mem.DefaultMempoolConfig(),
 		Consensus:    cns.DefaultConsensusConfig(),
 		TxEventStore: eventstore.DefaultEventStoreConfig(),
+		Archive:      archive.DefaultArchiveConfig(),
 		Telemetry:    telemetry.DefaultTelemetryConfig(),
 		Application:  sdk.DefaultAppConfig(),
 	}
@@ -183,6 +188,7 @@ func TestConfig() *Config {
 		Mempool:      mem.TestMempoolConfig(),
 		Consensus:    cns.TestConsensusConfig(),
 		TxEventStore: eventstore.DefaultEventStoreConfig(),
+		Archive:      archive.DefaultArchiveConfig(),
 		Telemetry:    telemetry.DefaultTelemetryConfig(),
 		Application:  sdk.DefaultAppConfig(),
 	}
@@ -195,6 +201,7 @@ func (cfg *Config) SetRootDir(root string) *Config {
 	cfg.P2P.RootDir = root
 	cfg.Mempool.RootDir = root
 	cfg.Consensus.RootDir = root
+	cfg.Archive.RootDir = root
 	cfg.Consensus.PrivValidator.RootDir = (filepath.Join(root, DefaultSecretsDir))
 
 	return cfg
@@ -241,6 +248,9 @@ func (cfg *Config) ValidateBasic() error {
 	if err := cfg.Consensus.ValidateBasic(); err != nil {
 		return errors.Wrap(err, "Error in [consensus] section")
 	}
+	if err := cfg.Archive.ValidateBasic(); err != nil {
+		return errors.Wrap(err, "Error in [archive] section")
+	}
 	if err := cfg.Application.ValidateBasic(); err != nil {
 		return errors.Wrap(err, "Error in [application] section")
 	}
@@ -275,6 +285,8 @@ type BaseConfig struct {
 	//	│   └── state.db (folder)
 	//	├── wal/
 	//	│   └── cs.wal (folder)
+	//	├── snapshots/ (optional)
+	//	│   └── <height>/
 	//	├── secrets/
 	//	│   ├── priv_validator_state.json
 	//	│   ├── node_key.json
@@ -319,11 +331,14 @@ type BaseConfig struct {
 	// A JSON file containing the private key to use for p2p authenticated encryption
 	NodeKey string `toml:"node_key_file" comment:"Path to the JSON file containing the private key to use for node authentication in the p2p protocol"`
 
-	// Mechanism to connect to the ABCI application: local | socket
-	ABCI string `toml:"abci" comment:"Mechanism to connect to the ABCI application: socket | grpc"`
+	// Mechanism to connect to the ABCI application: local | socket | grpc
+	ABCI string `toml:"abci" comment:"Mechanism to connect to the ABCI application: local | socket | grpc"`
 
 	// TCP or UNIX socket address for the profiling server to listen on
 	ProfListenAddress string `toml:"prof_laddr" comment:"TCP or UNIX socket address for the profiling server to listen on"`
+
+	// Level of the node logs, which can be changed by reloading the config
+	LogLevel string `toml:"log_level" comment:"Level of the node logs: debug | info | warn | error\n Empty keeps the level given on the command line. It can be changed\n without restarting the node, by reloading the config"`
 }
 
 // DefaultBaseConfig returns a default base configuration for a Tendermint node
@@ -408,7 +423,8 @@ func (cfg BaseConfig) ValidateBasic() error {
 
 	// Verify the correct ABCI mechanism is set
 	if cfg.ABCI != LocalABCI &&
-		cfg.ABCI != SocketABCI {
+		cfg.ABCI != SocketABCI &&
+		cfg.ABCI != GRPCABCI {
 		return errInvalidABCIMechanism
 	}
 
@@ -417,5 +433,21 @@ func (cfg BaseConfig) ValidateBasic() error {
 		return errInvalidProfListenAddress
 	}
 
+	// Verify the log level
+	if err := ValidateLogLevel(cfg.LogLevel); err != nil {
+		return err
+	}
+
 	return nil
 }
+
+// ValidateLogLevel validates the log level of the node. The empty level is
+// valid, and keeps the level given on the command line.
+func ValidateLogLevel(level string) error {
+	switch level {
+	case "", "debug", "info", "warn", "error":
+		return nil
+	default:
+		return errInvalidLogLevel
+	}
+}
@@ -158,6 +158,15 @@ func TestConfig_ValidateBaseConfig(t *testing.T) {
 
 		assert.ErrorIs(t, c.BaseConfig.ValidateBasic(), errInvalidProfListenAddress)
 	})
+
+	t.Run("invalid log level", func(t *testing.T) {
+		t.Parallel()
+
+		c := DefaultConfig()
+		c.LogLevel = "verbose"
+
+		assert.ErrorIs(t, c.BaseConfig.ValidateBasic(), errInvalidLogLevel)
+	})
 }
 
 func TestConfig_DBDir(t *testing.T) {
@@ -0,0 +1,89 @@
+package config
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"strings"
+)
+
+// Settings are the values of the settings of a config, formatted as strings,
+// by key. The keys are the dotted TOML paths of the settings, like
+// "mempool.size".
+type Settings map[string]string
+
+// Settings returns the settings of the config.
+func (cfg *Config) Settings() Settings {
+	s := Settings{}
+	flatten("", reflect.ValueOf(cfg), s)
+
+	return s
+}
+
+// Diff returns the sorted keys of the settings whose values differ between s
+// and other.
+func (s Settings) Diff(other Settings) []string {
+	var keys []string
+	for key, value := range s {
+		if v, ok := other[key]; !ok || v != value {
+			keys = append(keys, key)
+		}
+	}
+	for key := range other {
+		if _, ok := s[key]; !ok {
+			keys = append(keys, key)
+		}
+	}
+	sort.Strings(keys)
+
+	return keys
+}
+
+// flatten adds the settings of the struct v to values, by TOML path.
+func flatten(prefix string, v reflect.Value, values Settings) {
+	for v.Kind() == reflect.Pointer {
+		if v.IsNil() {
+			return
+		}
+		v = v.Elem()
+	}
+
+	t := v.Type()
+	for i := range t.NumField() {
+		field := t.Field(i)
+		if !field.IsExported() {
+			continue
+		}
+
+		name, opts, _ := strings.Cut(field.Tag.Get("toml"), ",")
+		if name == "-" {
+			continue
+		}
+
+		fv := v.Field(i)
+		if opts == "squash" {
+			flatten(prefix, fv, values)
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		key := prefix + name
+		if isSection(fv) {
+			flatten(key+".", fv, values)
+			continue
+		}
+		values[key] = fmt.Sprint(fv.Interface())
+	}
+}
+
+// isSection returns whether v is a struct, or a pointer to one, holding
+// nested settings.
+func isSection(v reflect.Value) bool {
+	t := v.Type()
+	if t.Kind() == reflect.Pointer {
+		t = t.Elem()
+	}
+	return t.Kind() == reflect.Struct
+}
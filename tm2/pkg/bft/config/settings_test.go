@@ -0,0 +1,26 @@
+package config
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestSettingsDiff(t *testing.T) {
+	t.Parallel()
+
+	a, b := DefaultConfig(), DefaultConfig()
+	assert.Empty(t, a.Settings().Diff(b.Settings()))
+
+	b.LogLevel = "info"
+	b.Mempool.Size = 10
+	b.Consensus.TimeoutCommit *= 2
+	b.Consensus.PrivValidator.RemoteSigner.ServerAddress = "tcp://127.0.0.1:26659"
+	b.LocalApp = nil // not a setting
+	assert.Equal(t, []string{
+		"consensus.priv_validator.remote_signer.server_address",
+		"consensus.timeout_commit",
+		"log_level",
+		"mempool.size",
+	}, a.Settings().Diff(b.Settings()))
+}
@@ -1,6 +1,7 @@
 package config
 
 import (
+	"errors"
 	"fmt"
 	"os"
 	"path/filepath"
@@ -12,6 +13,13 @@ import (
 // DefaultDirPerm is the default permissions used when creating directories.
 const DefaultDirPerm = 0o700
 
+// errRPCUnsafeRemoved is returned when loading a config that still sets
+// rpc.unsafe, which would otherwise be silently ignored.
+var errRPCUnsafeRemoved = errors.New(
+	"rpc.unsafe was removed: serve the unsafe RPC commands on " +
+		"rpc.operator_laddr, protected by rpc.operator_auth_token",
+)
+
 // LoadConfigFile loads the TOML node configuration from the specified path
 func LoadConfigFile(path string) (*Config, error) {
 	// Read the config file
@@ -20,6 +28,16 @@ func LoadConfigFile(path string) (*Config, error) {
 		return nil, readErr
 	}
 
+	// Reject the removed keys
+	tree, loadErr := toml.LoadBytes(content)
+	if loadErr != nil {
+		return nil, loadErr
+	}
+
+	if tree.Has("rpc.unsafe") {
+		return nil, errRPCUnsafeRemoved
+	}
+
 	// Parse the node config
 	var nodeConfig Config
 
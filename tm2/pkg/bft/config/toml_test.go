@@ -138,6 +138,22 @@ func TestTOML_LoadConfig(t *testing.T) {
 		assert.Nil(t, cfg)
 	})
 
+	t.Run("config sets the removed rpc.unsafe", func(t *testing.T) {
+		t.Parallel()
+
+		// Create config file
+		configFile, cleanup := testutils.NewTestFile(t)
+		t.Cleanup(cleanup)
+
+		_, writeErr := configFile.WriteString("[rpc]\nunsafe = false\n")
+		require.NoError(t, writeErr)
+
+		cfg, loadErr := LoadConfigFile(configFile.Name())
+
+		assert.ErrorIs(t, loadErr, errRPCUnsafeRemoved)
+		assert.Nil(t, cfg)
+	})
+
 	t.Run("valid config", func(t *testing.T) {
 		t.Parallel()
 
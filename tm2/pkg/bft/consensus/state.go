@@ -133,6 +133,9 @@ type ConsensusState struct {
 	// for tests where we want to limit the number of transitions the state makes
 	nSteps int
 
+	// timings of the rounds of the current height, for the telemetry
+	roundTelemetry roundTelemetry
+
 	// some functions can be overwritten for testing
 	decideProposal func(height int64, round int)
 	doPrevote      func(height int64, round int)
@@ -457,6 +460,8 @@ func (cs *ConsensusState) updateHeight(height int64) {
 }
 
 func (cs *ConsensusState) updateRoundStep(round int, step cstypes.RoundStepType) {
+	cs.logStepTelemetry(step)
+
 	cs.Round = round
 	cs.Step = step
 }
@@ -740,6 +745,8 @@ func (cs *ConsensusState) handleTimeout(ti timeoutInfo, rs cstypes.RoundState) {
 	cs.mtx.Lock()
 	defer cs.mtx.Unlock()
 
+	cs.logTimeoutTelemetry(ti)
+
 	switch ti.Step {
 	case cstypes.RoundStepNewHeight:
 		// NewRound event fired from enterNewRound.
@@ -1066,6 +1073,24 @@ func (cs *ConsensusState) defaultDoPrevote(height int64, round int) {
 		return
 	}
 
+	// Let the application accept or reject the proposal
+	if err := cs.blockExec.ProcessProposal(cs.ProposalBlock); err != nil {
+		if !goerrors.As(err, &sm.ProposalRejectedError{}) {
+			// The application couldn't be asked: this is a local fault,
+			// not a rejection of the proposal, so halt as on ApplyBlock.
+			logger.Error("enterPrevote: Error on ProcessProposal. Did the application crash? Please restart tendermint", "err", err)
+			if err := osm.Kill(); err != nil {
+				logger.Error("Failed to kill this process - please do so manually", "err", err)
+			}
+			return
+		}
+
+		// ProposalBlock was rejected by the app, prevote nil.
+		logger.Error("enterPrevote: ProposalBlock was rejected by the application", "err", err)
+		cs.signAddVote(types.PrevoteType, nil, types.PartSetHeader{})
+		return
+	}
+
 	// Prevote cs.ProposalBlock
 	// NOTE: the proposal signature is validated when it is received,
 	// and the proposal block parts are validated as they are received (against the merkle hash in the proposal)
@@ -1598,6 +1623,8 @@ func (cs *ConsensusState) addVote(vote *types.Vote, peerID p2pTypes.ID) (added b
 		return
 	}
 
+	cs.logVoteTelemetry(vote)
+
 	cs.evsw.FireEvent(types.EventVote{Vote: vote})
 
 	switch vote.Type {
@@ -0,0 +1,117 @@
+package consensus
+
+import (
+	"context"
+	"time"
+
+	cstypes "github.com/gnolang/gno/tm2/pkg/bft/consensus/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/telemetry"
+	"github.com/gnolang/gno/tm2/pkg/telemetry/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
+)
+
+// voteRoundKey identifies the votes of a type within a round
+type voteRoundKey struct {
+	round int
+	typ   types.SignedMsgType
+}
+
+// roundTelemetry tracks the timings of the consensus rounds of the
+// current height, to record the round, step and vote arrival metrics
+type roundTelemetry struct {
+	roundStart time.Time                  // start of the current round, zero once committed
+	stepStart  time.Time                  // start of the current step
+	firstVotes map[voteRoundKey]time.Time // arrival of the first vote, by round and type
+}
+
+// logStepTelemetry records the duration of the step ending with the
+// transition to the given step, and of the round it ends, if any
+func (cs *ConsensusState) logStepTelemetry(step cstypes.RoundStepType) {
+	if !telemetry.MetricsEnabled() || cs.replayMode {
+		return
+	}
+
+	var (
+		rt  = &cs.roundTelemetry
+		now = time.Now()
+	)
+
+	if !rt.stepStart.IsZero() {
+		metrics.StepDuration.Record(
+			context.Background(),
+			now.Sub(rt.stepStart).Milliseconds(),
+			metric.WithAttributes(attribute.String("step", cs.Step.String())),
+		)
+	}
+	rt.stepStart = now
+
+	switch step {
+	case cstypes.RoundStepNewHeight:
+		rt.firstVotes = make(map[voteRoundKey]time.Time)
+	case cstypes.RoundStepNewRound, cstypes.RoundStepCommit:
+		// A round ends either with a commit, or with the start of the next one
+		if !rt.roundStart.IsZero() {
+			metrics.RoundDuration.Record(context.Background(), now.Sub(rt.roundStart).Milliseconds())
+		}
+
+		rt.roundStart = time.Time{}
+		if step == cstypes.RoundStepNewRound {
+			rt.roundStart = now
+		}
+	}
+}
+
+// logVoteTelemetry records the delay between the arrival of the
+// given vote and of the first vote of the same type and round
+func (cs *ConsensusState) logVoteTelemetry(vote *types.Vote) {
+	if !telemetry.MetricsEnabled() || cs.replayMode {
+		return
+	}
+
+	rt := &cs.roundTelemetry
+	if rt.firstVotes == nil {
+		rt.firstVotes = make(map[voteRoundKey]time.Time)
+	}
+
+	var (
+		now = time.Now()
+		key = voteRoundKey{round: vote.Round, typ: vote.Type}
+	)
+
+	first, ok := rt.firstVotes[key]
+	if !ok {
+		first = now
+		rt.firstVotes[key] = now
+	}
+
+	voteType := "prevote"
+	if vote.Type == types.PrecommitType {
+		voteType = "precommit"
+	}
+
+	metrics.VoteSpread.Record(
+		context.Background(),
+		now.Sub(first).Milliseconds(),
+		metric.WithAttributes(attribute.String("type", voteType)),
+	)
+}
+
+// logTimeoutTelemetry counts the timeout triggering a step transition
+func (cs *ConsensusState) logTimeoutTelemetry(ti timeoutInfo) {
+	if !telemetry.MetricsEnabled() || cs.replayMode {
+		return
+	}
+
+	switch ti.Step {
+	case cstypes.RoundStepPropose, cstypes.RoundStepPrevoteWait, cstypes.RoundStepPrecommitWait:
+		metrics.Timeouts.Add(
+			context.Background(),
+			1,
+			metric.WithAttributes(attribute.String("step", ti.Step.String())),
+		)
+	default:
+		// The other timeouts schedule the start of the height and of its first round
+	}
+}
@@ -5,8 +5,10 @@ import (
 	"container/list"
 	"context"
 	"crypto/sha256"
+	"encoding/hex"
 	"fmt"
 	"log/slog"
+	"os"
 	"sync"
 	"sync/atomic"
 	"time"
@@ -22,8 +24,13 @@ import (
 	osm "github.com/gnolang/gno/tm2/pkg/os"
 	"github.com/gnolang/gno/tm2/pkg/telemetry"
 	"github.com/gnolang/gno/tm2/pkg/telemetry/metrics"
+	"go.opentelemetry.io/otel/attribute"
+	"go.opentelemetry.io/otel/metric"
 )
 
+// The WAL is compacted once it is at least this large.
+const walCompactMinSize = 1 << 20 // 1MB
+
 // --------------------------------------------------------------------------------
 
 // CListMempool is an ordered in-memory pool for transactions before they are
@@ -63,8 +70,10 @@ type CListMempool struct {
 	// This reduces the pressure on the proxyApp.
 	cache txCache
 
-	// A log of mempool txs
-	wal *auto.AutoFile
+	// A log of the txs accepted in the mempool, replayed on restart.
+	walMtx        sync.Mutex
+	wal           *auto.AutoFile
+	walRecovering bool // txs aren't logged while the WAL is recovered.
 
 	logger *slog.Logger
 }
@@ -142,6 +151,8 @@ func (mem *CListMempool) InitWAL() {
 func (mem *CListMempool) CloseWAL() {
 	mem.mtx.Lock()
 	defer mem.mtx.Unlock()
+	mem.walMtx.Lock()
+	defer mem.walMtx.Unlock()
 
 	if err := mem.wal.Close(); err != nil {
 		mem.logger.Error("Error closing WAL", "err", err)
@@ -149,6 +160,143 @@ func (mem *CListMempool) CloseWAL() {
 	mem.wal = nil
 }
 
+// RecoverWAL re-checks the txs of the WAL left by a previous run, and adds
+// the valid ones back to the mempool, from where they are gossiped to peers.
+// Txs which were committed or became invalid since are dropped.
+// It returns the number of recovered txs.
+//
+// The WAL is only rewritten once all the txs were re-checked, with the
+// recovered txs, so that a crash during the recovery loses none of them.
+// Entries which aren't hex encoded are taken as written by older versions,
+// which logged the raw txs: they are re-checked as is. Their txs which
+// contained a newline can't be told apart from the next entries, and are
+// dropped as invalid.
+//
+// *not thread safe*, must be called after InitWAL and before the mempool
+// is used.
+func (mem *CListMempool) RecoverWAL() (int, error) {
+	if mem.wal == nil {
+		return 0, errors.New("WAL not initialized")
+	}
+	bz, err := os.ReadFile(mem.wal.Path)
+	if err != nil {
+		return 0, errors.Wrap(err, "Error reading WAL")
+	}
+	var txs []types.Tx
+	for _, line := range bytes.Split(bz, []byte("\n")) {
+		if len(line) == 0 {
+			continue
+		}
+		tx, err := hex.DecodeString(string(line))
+		if err != nil {
+			// Raw tx of an older version, or torn write on crash, which
+			// the app rejects.
+			tx = line
+		}
+		txs = append(txs, tx)
+	}
+
+	mem.walMtx.Lock()
+	mem.walRecovering = true
+	mem.walMtx.Unlock()
+	defer func() {
+		mem.walMtx.Lock()
+		mem.walRecovering = false
+		mem.walMtx.Unlock()
+	}()
+
+	for _, tx := range txs {
+		if err := mem.CheckTx(tx, nil); err != nil {
+			mem.logger.Info("Dropping WAL transaction", "tx", txID(tx), "err", err)
+		}
+	}
+	if err := mem.FlushAppConn(); err != nil {
+		return 0, err
+	}
+
+	if err := mem.rewriteWAL(mem.mempoolTxs()); err != nil {
+		return 0, err
+	}
+	return mem.Size(), nil
+}
+
+// writeWAL appends tx to the WAL, if enabled.
+// Called from:
+//   - resCbFirstTime (lock not held) if tx is valid
+func (mem *CListMempool) writeWAL(tx types.Tx) {
+	mem.walMtx.Lock()
+	defer mem.walMtx.Unlock()
+
+	// While recovering, the WAL is rewritten afterwards.
+	if mem.wal == nil || mem.walRecovering {
+		return
+	}
+	line := make([]byte, hex.EncodedLen(len(tx))+1)
+	hex.Encode(line, tx)
+	line[len(line)-1] = '\n'
+	// TODO: Notify administrators when WAL fails
+	if _, err := mem.wal.Write(line); err != nil {
+		mem.logger.Error("Error writing to WAL", "err", err)
+	}
+}
+
+// rewriteWAL atomically replaces the contents of the WAL with txs.
+func (mem *CListMempool) rewriteWAL(txs []types.Tx) error {
+	mem.walMtx.Lock()
+	defer mem.walMtx.Unlock()
+
+	var buf bytes.Buffer
+	for _, tx := range txs {
+		buf.WriteString(hex.EncodeToString(tx))
+		buf.WriteByte('\n')
+	}
+	path := mem.wal.Path
+	if err := osm.WriteFileAtomic(path, buf.Bytes(), 0o600); err != nil {
+		return errors.Wrap(err, "Error rewriting WAL")
+	}
+	// The WAL file may still be open on the replaced file.
+	if err := mem.wal.Close(); err != nil {
+		mem.logger.Error("Error closing WAL", "err", err)
+	}
+	af, err := auto.OpenAutoFile(path)
+	if err != nil {
+		return errors.Wrap(err, "Error opening WAL file")
+	}
+	mem.wal = af
+	return nil
+}
+
+// compactWAL rewrites the WAL with the txs of the mempool, once the txs
+// removed from the mempool take most of it.
+// Called from:
+//   - Update (lock held)
+func (mem *CListMempool) compactWAL() {
+	if mem.wal == nil {
+		return
+	}
+	size, err := mem.wal.Size()
+	if err != nil {
+		mem.logger.Error("Error getting WAL size", "err", err)
+		return
+	}
+	live := int64(hex.EncodedLen(int(mem.TxsBytes())) + mem.Size())
+	if size < walCompactMinSize || size < 2*live {
+		return
+	}
+	if err := mem.rewriteWAL(mem.mempoolTxs()); err != nil {
+		mem.logger.Error("Error compacting WAL", "err", err)
+	}
+}
+
+// mempoolTxs returns the txs of the mempool, in order.
+func (mem *CListMempool) mempoolTxs() []types.Tx {
+	txs := make([]types.Tx, 0, mem.txs.Len())
+	for e := mem.txs.Front(); e != nil; e = e.Next() {
+		txs = append(txs, e.Value.(*mempoolTx).tx)
+	}
+	return txs
+}
+
 func (mem *CListMempool) Lock() {
 	mem.mtx.Lock()
 }
@@ -167,6 +315,16 @@ func (mem *CListMempool) MaxTxBytes() int64 {
 	return mem.maxTxBytes
 }
 
+// SetLimits changes the maximum number of transactions in the mempool, and
+// their maximum total size. The transactions already in the mempool are kept,
+// even if they go over the new limits.
+func (mem *CListMempool) SetLimits(size int, maxPendingTxsBytes int64) {
+	mem.mtx.Lock()
+	defer mem.mtx.Unlock()
+	mem.config.Size = size
+	mem.config.MaxPendingTxsBytes = maxPendingTxsBytes
+}
+
 func (mem *CListMempool) TxsBytes() int64 {
 	return atomic.LoadInt64(&mem.txsBytes)
 }
@@ -264,20 +422,6 @@ func (mem *CListMempool) CheckTxWithInfo(tx types.Tx, cb func(abci.Response), tx
 	}
 	// END CACHE
 
-	// WAL
-	if mem.wal != nil {
-		// TODO: Notify administrators when WAL fails
-		_, err := mem.wal.Write([]byte(tx))
-		if err != nil {
-			mem.logger.Error("Error writing to WAL", "err", err)
-		}
-		_, err = mem.wal.Write([]byte("\n"))
-		if err != nil {
-			mem.logger.Error("Error writing to WAL", "err", err)
-		}
-	}
-	// END WAL
-
 	// NOTE: proxyAppConn may error if tx buffer is full
 	if err = mem.proxyAppConn.Error(); err != nil {
 		return err
@@ -339,6 +483,10 @@ func (mem *CListMempool) addTx(memTx *mempoolTx) {
 
 	// Update the telemetry
 	mem.logTelemetry()
+
+	if telemetry.MetricsEnabled() {
+		metrics.MempoolAddedTxs.Add(context.Background(), 1)
+	}
 }
 
 // logTelemetry logs the mempool telemetry
@@ -354,10 +502,16 @@ func (mem *CListMempool) logTelemetry() {
 	metrics.NumCachedTxs.Record(context.Background(), int64(mem.cache.Len()))
 }
 
+// Reasons a transaction is removed from the mempool, for the telemetry
+const (
+	evictReasonCommitted   = "committed"
+	evictReasonInvalidated = "invalidated"
+)
+
 // Called from:
 //   - Update (lock held) if tx was committed
 //   - resCbRecheck (lock not held) if tx was invalidated
-func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromCache bool) {
+func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromCache bool, reason string) {
 	mem.txs.Remove(elem)
 	elem.DetachPrev()
 	mem.txsMap.Delete(txKey(tx))
@@ -369,6 +523,14 @@ func (mem *CListMempool) removeTx(tx types.Tx, elem *clist.CElement, removeFromC
 
 	// Update the telemetry
 	mem.logTelemetry()
+
+	if telemetry.MetricsEnabled() {
+		metrics.MempoolEvictedTxs.Add(
+			context.Background(),
+			1,
+			metric.WithAttributes(attribute.String("reason", reason)),
+		)
+	}
 }
 
 // callback, which is called after the app checked the tx for the first time.
@@ -386,6 +548,7 @@ func (mem *CListMempool) resCbFirstTime(tx []byte, peerID uint16, res abci.Respo
 			}
 			memTx.senders.Store(peerID, true)
 			mem.addTx(memTx)
+			mem.writeWAL(tx)
 			mem.logger.Info("Added good transaction",
 				"tx", txID(tx),
 				"res", res,
@@ -425,7 +588,7 @@ func (mem *CListMempool) resCbRecheck(req abci.Request, res abci.Response) {
 			// Tx became invalidated due to newly committed block.
 			mem.logger.Info("Tx is no longer valid", "tx", txID(tx), "res", res, "err", res.Error)
 			// NOTE: we remove tx from the cache because it might be good later
-			mem.removeTx(tx, mem.recheckCursor, true)
+			mem.removeTx(tx, mem.recheckCursor, true, evictReasonInvalidated)
 		}
 		if mem.recheckCursor == mem.recheckEnd {
 			mem.recheckCursor = nil
@@ -505,6 +668,30 @@ func (mem *CListMempool) ReapMaxBytesMaxGas(maxDataBytes, maxGas int64) types.Tx
 	return txs
 }
 
+func (mem *CListMempool) CheckMaxBytesMaxGas(txs types.Txs, maxDataBytes, maxGas int64) error {
+	var totalBytes int64
+	var totalGas int64
+	for _, tx := range txs {
+		totalBytes += int64(len(tx))
+		if maxDataBytes > -1 && totalBytes > maxDataBytes {
+			return ReapLimitError{Reason: fmt.Sprintf("more than %d bytes", maxDataBytes)}
+		}
+		// If maxGas is negative, skip this check.
+		if maxGas < 0 {
+			continue
+		}
+		e, ok := mem.txsMap.Load(txKey(tx))
+		if !ok {
+			return ReapLimitError{Reason: fmt.Sprintf("gas wanted of tx %X is unknown", tx.Hash())}
+		}
+		totalGas += e.(*clist.CElement).Value.(*mempoolTx).gasWanted
+		if totalGas > maxGas {
+			return ReapLimitError{Reason: fmt.Sprintf("more than %d gas wanted", maxGas)}
+		}
+	}
+	return nil
+}
+
 func (mem *CListMempool) ReapMaxTxs(maxVal int) types.Txs {
 	mem.mtx.Lock()
 	defer mem.mtx.Unlock()
@@ -564,9 +751,10 @@ func (mem *CListMempool) Update(
 		//   100
 		// https://github.com/tendermint/classic/issues/3322.
 		if e, ok := mem.txsMap.Load(txKey(tx)); ok {
-			mem.removeTx(tx, e.(*clist.CElement), false)
+			mem.removeTx(tx, e.(*clist.CElement), false, evictReasonCommitted)
 		}
 	}
+	mem.compactWAL()
 
 	// Either recheck non-committed txs to see if they became invalid
 	// or just notify there're some txs left.
@@ -600,13 +788,13 @@ func (mem *CListMempool) recheckTxs() {
 		memTx := e.Value.(*mempoolTx)
 		// check tx size
 		if int64(len(memTx.tx)) > mem.maxTxBytes {
-			mem.removeTx(memTx.tx, e, false)
+			mem.removeTx(memTx.tx, e, false, evictReasonInvalidated)
 			continue
 		}
 		// run precheck
 		if mem.preCheck != nil {
 			if err := mem.preCheck(memTx.tx); err != nil {
-				mem.removeTx(memTx.tx, e, false)
+				mem.removeTx(memTx.tx, e, false, evictReasonInvalidated)
 				continue
 			}
 		}
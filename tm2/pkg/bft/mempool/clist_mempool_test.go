@@ -143,6 +143,26 @@ func TestReapMaxBytesMaxGas(t *testing.T) {
 	}
 }
 
+func TestCheckMaxBytesMaxGas(t *testing.T) {
+	app := kvstore.NewKVStoreApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithApp(cc)
+	defer cleanup()
+
+	// each tx has 20 bytes and 1 gas
+	txs := checkTxs(t, mempool, 10, UnknownPeerID, false)
+
+	assert.NoError(t, mempool.CheckMaxBytesMaxGas(txs, 200, 10))
+	assert.NoError(t, mempool.CheckMaxBytesMaxGas(txs, -1, -1))
+	assert.Error(t, mempool.CheckMaxBytesMaxGas(txs, 199, 10))
+	assert.Error(t, mempool.CheckMaxBytesMaxGas(txs, 200, 9))
+
+	// The gas wanted of a tx which isn't in the mempool is unknown.
+	unknown := append(types.Txs{types.Tx("unknown")}, txs[1:]...)
+	assert.Error(t, mempool.CheckMaxBytesMaxGas(unknown, 200, 10))
+	assert.NoError(t, mempool.CheckMaxBytesMaxGas(unknown, 200, -1))
+}
+
 /* XXX test PreCheck filter.
    XXX this used to be a PostCheck filter test, so the code doesn't make much sense.
    TODO change numTxsToCreate to a slice of tx sizes.
@@ -400,7 +420,7 @@ func TestMempoolCloseWAL(t *testing.T) {
 	sum1 := checksumFile(t, walFilepath)
 
 	// 6. Sanity check to ensure that the written TX matches the expectation.
-	require.Equal(t, sum1, checksumIt([]byte("foo\n")), "foo with a newline should be written")
+	require.Equal(t, sum1, checksumIt([]byte("666f6f\n")), "foo in hex with a newline should be written")
 
 	// 7. Invoke CloseWAL() and ensure it discards the
 	// WAL thus any other write won't go through.
@@ -415,6 +435,105 @@ func TestMempoolCloseWAL(t *testing.T) {
 	require.Equal(t, 1, len(m3), "expecting the wal match in")
 }
 
+func TestMempoolRecoverWAL(t *testing.T) {
+	wcfg := cfg.TestMempoolConfig()
+	wcfg.RootDir = t.TempDir()
+	app := counter.NewCounterApplication(true)
+	app.SetOption(abci.RequestSetOption{Key: "serial", Value: "on"})
+	cc := proxy.NewLocalClientCreator(app)
+
+	// 1. Add some txs to the mempool.
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, wcfg)
+	defer cleanup()
+	mempool.InitWAL()
+	txs := make(types.Txs, 5)
+	for i := range txs {
+		txs[i] = make([]byte, 8)
+		binary.BigEndian.PutUint64(txs[i], uint64(i))
+		require.NoError(t, mempool.CheckTx(txs[i], nil))
+	}
+
+	// 2. Commit the first two txs in the app, and stop the node in the
+	// middle of writing a tx to the WAL.
+	for _, tx := range txs[:2] {
+		res := app.DeliverTx(abci.RequestDeliverTx{Tx: tx})
+		require.NoError(t, res.Error)
+	}
+	app.Commit()
+	_, err := mempool.wal.Write([]byte("0000"))
+	require.NoError(t, err)
+	walFilepath := mempool.wal.Path
+	mempool.CloseWAL()
+
+	// 3. Restart: only the txs still valid are recovered.
+	mempool, cleanup = newMempoolWithAppAndConfig(cc, wcfg)
+	defer cleanup()
+	mempool.InitWAL()
+	recovered, err := mempool.RecoverWAL()
+	require.NoError(t, err)
+	assert.Equal(t, 3, recovered)
+	assert.Equal(t, txs[2:], mempool.ReapMaxTxs(-1))
+
+	// 4. The WAL only contains the recovered txs.
+	bz, err := os.ReadFile(walFilepath)
+	require.NoError(t, err)
+	assert.Equal(t, "0000000000000002\n0000000000000003\n0000000000000004\n", string(bz))
+	mempool.CloseWAL()
+
+	// 5. The raw txs logged by older versions are migrated.
+	oldTxs := make(types.Txs, 2)
+	var oldWAL []byte
+	for i := range oldTxs {
+		oldTxs[i] = make([]byte, 8)
+		binary.BigEndian.PutUint64(oldTxs[i], uint64(5+i))
+		oldWAL = append(append(oldWAL, oldTxs[i]...), '\n')
+	}
+	require.NoError(t, os.WriteFile(walFilepath, oldWAL, 0o600))
+	mempool, cleanup = newMempoolWithAppAndConfig(cc, wcfg)
+	defer cleanup()
+	mempool.InitWAL()
+	recovered, err = mempool.RecoverWAL()
+	require.NoError(t, err)
+	assert.Equal(t, 2, recovered)
+	assert.Equal(t, oldTxs, mempool.ReapMaxTxs(-1))
+	bz, err = os.ReadFile(walFilepath)
+	require.NoError(t, err)
+	assert.Equal(t, "0000000000000005\n0000000000000006\n", string(bz))
+	mempool.CloseWAL()
+}
+
+func TestMempoolCompactWAL(t *testing.T) {
+	wcfg := cfg.TestMempoolConfig()
+	wcfg.RootDir = t.TempDir()
+	app := kvstore.NewKVStoreApplication()
+	cc := proxy.NewLocalClientCreator(app)
+	mempool, cleanup := newMempoolWithAppAndConfig(cc, wcfg)
+	defer cleanup()
+	mempool.InitWAL()
+	defer mempool.CloseWAL()
+
+	// Fill the WAL past the compaction threshold.
+	n := walCompactMinSize/(2*int(testMaxTxBytes)) + 1
+	txs := make(types.Txs, n+1)
+	for i := range txs {
+		txs[i] = random.RandBytes(int(testMaxTxBytes))
+		require.NoError(t, mempool.CheckTx(txs[i], nil))
+	}
+	size, err := mempool.wal.Size()
+	require.NoError(t, err)
+	require.GreaterOrEqual(t, size, int64(walCompactMinSize))
+
+	// Committing all but one tx compacts the WAL.
+	mempool.Lock()
+	err = mempool.Update(1, txs[:n], abciResponses(n, nil), nil, 0)
+	mempool.Unlock()
+	require.NoError(t, err)
+
+	bz, err := os.ReadFile(mempool.wal.Path)
+	require.NoError(t, err)
+	assert.Equal(t, fmt.Sprintf("%x\n", []byte(txs[n])), string(bz))
+}
+
 func TestMempoolMaxMsgSize(t *testing.T) {
 	app := kvstore.NewKVStoreApplication()
 	cc := proxy.NewLocalClientCreator(app)
@@ -495,7 +614,17 @@ func TestMempoolMaxPendingTxsBytes(t *testing.T) {
 		assert.IsType(t, MempoolIsFullError{}, err)
 	}
 
-	// 6. zero after tx is rechecked and removed due to not being valid anymore
+	// 6. the limit can be raised while running.
+	mempool.SetLimits(config.Size, 11)
+	err = mempool.CheckTx([]byte{0x05}, nil)
+	require.NoError(t, err)
+	mempool.SetLimits(1, 100)
+	err = mempool.CheckTx([]byte{0x06}, nil)
+	if assert.Error(t, err) {
+		assert.IsType(t, MempoolIsFullError{}, err)
+	}
+
+	// 7. zero after tx is rechecked and removed due to not being valid anymore
 	app2 := counter.NewCounterApplication(true)
 	cc = proxy.NewLocalClientCreator(app2)
 	mempool, cleanup = newMempoolWithApp(cc)
@@ -34,3 +34,12 @@ func (e MempoolIsFullError) Error() string {
 		e.numTxs, e.maxTxs,
 		e.txsBytes, e.maxTxsBytes)
 }
+
+// ReapLimitError means txs don't fit in the size or gas limits of a block
+type ReapLimitError struct {
+	Reason string
+}
+
+func (e ReapLimitError) Error() string {
+	return fmt.Sprintf("txs exceed the block limits: %s", e.Reason)
+}
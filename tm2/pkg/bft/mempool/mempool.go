@@ -27,6 +27,12 @@ type Mempool interface {
 	// transactions (~ all available transactions).
 	ReapMaxBytesMaxGas(maxDataBytes, maxGas int64) types.Txs
 
+	// CheckMaxBytesMaxGas returns an error if txs don't fit in maxDataBytes
+	// bytes and maxGas total gasWanted, accounted as ReapMaxBytesMaxGas
+	// does. The gasWanted of a tx which isn't in the mempool is unknown, so
+	// such a tx is rejected unless maxGas is negative.
+	CheckMaxBytesMaxGas(txs types.Txs, maxDataBytes, maxGas int64) error
+
 	// ReapMaxTxs reaps up to max transactions from the mempool.
 	// If max is negative, there is no cap on the size of all returned
 	// transactions (~ all available transactions).
@@ -74,6 +80,11 @@ type Mempool interface {
 	// CloseWAL closes and discards the underlying WAL file.
 	// Any further writes will not be relayed to disk.
 	CloseWAL()
+
+	// RecoverWAL re-checks the transactions of the WAL left by a previous
+	// run, and adds the valid ones back to the mempool. It returns the
+	// number of recovered transactions.
+	RecoverWAL() (int, error)
 }
 
 //--------------------------------------------------------------------------------
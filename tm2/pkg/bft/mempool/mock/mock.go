@@ -24,8 +24,9 @@ func (Mempool) CheckTxWithInfo(_ types.Tx, _ func(abci.Response),
 ) error {
 	return nil
 }
-func (Mempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return types.Txs{} }
-func (Mempool) ReapMaxTxs(n int) types.Txs              { return types.Txs{} }
+func (Mempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs           { return types.Txs{} }
+func (Mempool) CheckMaxBytesMaxGas(_ types.Txs, _, _ int64) error { return nil }
+func (Mempool) ReapMaxTxs(n int) types.Txs                        { return types.Txs{} }
 func (Mempool) Update(
 	_ int64,
 	_ types.Txs,
@@ -45,5 +46,6 @@ func (Mempool) TxsBytes() int64               { return 0 }
 func (Mempool) TxsFront() *clist.CElement    { return nil }
 func (Mempool) TxsWaitChan() <-chan struct{} { return nil }
 
-func (Mempool) InitWAL()  {}
-func (Mempool) CloseWAL() {}
+func (Mempool) InitWAL()                 {}
+func (Mempool) CloseWAL()                {}
+func (Mempool) RecoverWAL() (int, error) { return 0, nil }
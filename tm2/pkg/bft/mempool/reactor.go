@@ -156,7 +156,7 @@ func (memR *Reactor) Receive(chID byte, src p2p.PeerConn, msgBytes []byte) {
 	msg, err := memR.decodeMsg(msgBytes)
 	if err != nil {
 		memR.Logger.Error("Error decoding mempool message", "src", src, "chId", chID, "msg", msg, "err", err, "bytes", msgBytes)
-		memR.Switch.StopPeerForError(src, err)
+		memR.Switch.ReportMisbehavior(src, p2p.MisbehaviorMalformedMessage, err)
 		return
 	}
 	memR.Logger.Debug("Receive", "src", src, "chId", chID, "msg", msg)
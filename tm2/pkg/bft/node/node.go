@@ -8,6 +8,7 @@ import (
 	"log/slog"
 	"net"
 	"net/http"
+	"path/filepath"
 	"strings"
 	"sync"
 	"time"
@@ -15,7 +16,9 @@ import (
 	"github.com/rs/cors"
 
 	"github.com/gnolang/gno/tm2/pkg/bft/appconn"
+	"github.com/gnolang/gno/tm2/pkg/bft/archive"
 	"github.com/gnolang/gno/tm2/pkg/bft/privval"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/exec"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/file"
 	"github.com/gnolang/gno/tm2/pkg/p2p/conn"
 	"github.com/gnolang/gno/tm2/pkg/p2p/discovery"
@@ -33,6 +36,7 @@ import (
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
 	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/null"
+	storetypes "github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/store"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	tmtime "github.com/gnolang/gno/tm2/pkg/bft/types/time"
@@ -177,26 +181,69 @@ type Node struct {
 	proxyApp          appconn.AppConns     // connection to the application
 	rpcListeners      []net.Listener       // rpc servers
 	txEventStore      eventstore.TxEventStore
-	eventStoreService *eventstore.Service
+	eventStoreService service.Service
+	archiveService    *archive.Service // nil unless in archival mode
 	firstBlockSignal  <-chan struct{}
+	rpcLimits         *rpcserver.Limits // limits of the rpc servers
+
+	// config reloading
+	reloadMtx   sync.Mutex
+	settings    cfg.Settings // settings of the loaded config
+	reloadHooks []ReloadHook
 }
 
-func initDBs(config *cfg.Config, dbProvider DBProvider) (blockStore *store.BlockStore, stateDB dbm.DB, err error) {
+func initDBs(
+	config *cfg.Config,
+	dbProvider DBProvider,
+	evsw events.EventSwitch,
+) (blockStore *store.BlockStore, stateDB dbm.DB, archiveService *archive.Service, err error) {
 	var blockStoreDB dbm.DB
 	blockStoreDB, err = dbProvider(&DBContext{"blockstore", config})
 	if err != nil {
 		return
 	}
-	blockStore = store.NewBlockStore(blockStoreDB)
 
 	stateDB, err = dbProvider(&DBContext{"state", config})
 	if err != nil {
 		return
 	}
 
+	if !config.Archive.Enabled() {
+		blockStore = store.NewBlockStore(blockStoreDB)
+
+		return
+	}
+
+	// In archival mode, the old blocks and results are offloaded to the cold
+	// stores, one per database, from which they are still loaded
+	archiveBlockStoreDB, err := NewArchiveDB(config, "blockstore", blockStoreDB)
+	if err != nil {
+		return
+	}
+	archiveStateDB, err := NewArchiveDB(config, "state", stateDB)
+	if err != nil {
+		return
+	}
+
+	blockStore = store.NewBlockStore(archiveBlockStoreDB)
+	stateDB = archiveStateDB
+	archiveService = archive.NewService(archiveBlockStoreDB, archiveStateDB, blockStore, evsw, config.Archive.RetainBlocks)
+
 	return
 }
 
+// NewArchiveDB wraps the database with the given ID, like "blockstore", so that
+// its values can be offloaded to its cold store in archival mode, and the
+// offloaded values are loaded from it
+func NewArchiveDB(config *cfg.Config, id string, db dbm.DB) (*archive.DB, error) {
+	cold, err := archive.NewFileStore(filepath.Join(config.Archive.ColdStoreDir(), id))
+	if err != nil {
+		return nil, err
+	}
+
+	return archive.NewDB(db, cold), nil
+}
+
 func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger *slog.Logger) (appconn.AppConns, error) {
 	proxyApp := appconn.NewAppConns(clientCreator)
 	proxyApp.SetLogger(logger.With("module", "proxy"))
@@ -206,30 +253,79 @@ func createAndStartProxyAppConns(clientCreator proxy.ClientCreator, logger *slog
 	return proxyApp, nil
 }
 
+// EventStoreConstructor creates an event store from its configuration.
+type EventStoreConstructor func(cfg *storetypes.Config) (eventstore.TxEventStore, error)
+
+var (
+	eventStoresMu sync.RWMutex
+	eventStores   = map[string]EventStoreConstructor{}
+)
+
+// RegisterEventStore makes an event store available to the tx_event_store
+// configuration under eventStoreType, such as a plugin writing to an external
+// indexer. Event stores implementing eventstore.BlockEventStore receive every
+// committed block. It is meant to be called from init functions, and panics
+// if eventStoreType is already registered.
+func RegisterEventStore(eventStoreType string, constructor EventStoreConstructor) {
+	eventStoresMu.Lock()
+	defer eventStoresMu.Unlock()
+
+	switch eventStoreType {
+	case file.EventStoreType, exec.EventStoreType, null.EventStoreType:
+		panic(fmt.Sprintf("event store %q is built in", eventStoreType))
+	}
+	if _, ok := eventStores[eventStoreType]; ok {
+		panic(fmt.Sprintf("event store %q already registered", eventStoreType))
+	}
+	eventStores[eventStoreType] = constructor
+}
+
 func createAndStartEventStoreService(
 	cfg *cfg.Config,
 	evsw events.EventSwitch,
+	blockStore *store.BlockStore,
+	stateDB dbm.DB,
 	logger *slog.Logger,
-) (*eventstore.Service, eventstore.TxEventStore, error) {
+) (service.Service, eventstore.TxEventStore, error) {
 	var (
 		err          error
 		txEventStore eventstore.TxEventStore
 	)
 
+	eventStoresMu.RLock()
+	constructor, registered := eventStores[cfg.TxEventStore.EventStoreType]
+	eventStoresMu.RUnlock()
+
 	// Instantiate the event store based on the configuration
-	switch cfg.TxEventStore.EventStoreType {
-	case file.EventStoreType:
+	switch {
+	case cfg.TxEventStore.EventStoreType == file.EventStoreType:
 		// Transaction events should be logged to files
 		txEventStore, err = file.NewTxEventStore(cfg.TxEventStore)
 		if err != nil {
 			return nil, nil, fmt.Errorf("unable to create file tx event store, %w", err)
 		}
+	case cfg.TxEventStore.EventStoreType == exec.EventStoreType:
+		// Blocks should be given to an external program
+		txEventStore, err = exec.NewTxEventStore(cfg.TxEventStore)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to create exec event store, %w", err)
+		}
+	case registered:
+		txEventStore, err = constructor(cfg.TxEventStore)
+		if err != nil {
+			return nil, nil, fmt.Errorf("unable to create %s event store, %w", cfg.TxEventStore.EventStoreType, err)
+		}
 	default:
 		// Transaction event storing should be omitted
 		txEventStore = null.NewNullEventStore()
 	}
 
-	indexerService := eventstore.NewEventStoreService(txEventStore, evsw)
+	var indexerService service.Service
+	if blockEventStore, ok := txEventStore.(eventstore.BlockEventStore); ok {
+		indexerService = eventstore.NewBlockEventStoreService(blockEventStore, evsw, blockStore, stateDB)
+	} else {
+		indexerService = eventstore.NewEventStoreService(txEventStore, evsw)
+	}
 	indexerService.SetLogger(logger.With("module", "eventstore"))
 	if err := indexerService.Start(); err != nil {
 		return nil, nil, err
@@ -358,10 +454,13 @@ func NewNode(config *cfg.Config,
 	logger *slog.Logger,
 	options ...Option,
 ) (*Node, error) {
-	blockStore, stateDB, err := initDBs(config, dbProvider)
+	blockStore, stateDB, archiveService, err := initDBs(config, dbProvider, evsw)
 	if err != nil {
 		return nil, err
 	}
+	if archiveService != nil {
+		archiveService.SetLogger(logger.With("module", "archive"))
+	}
 
 	state, genDoc, err := LoadStateFromDBOrGenesisDocProvider(stateDB, genesisDocProvider)
 	if err != nil {
@@ -389,7 +488,7 @@ func NewNode(config *cfg.Config,
 	})
 
 	// Transaction event storing
-	eventStoreService, txEventStore, err := createAndStartEventStoreService(config, evsw, logger)
+	eventStoreService, txEventStore, err := createAndStartEventStoreService(config, evsw, blockStore, stateDB, logger)
 	if err != nil {
 		return nil, err
 	}
@@ -553,7 +652,10 @@ func NewNode(config *cfg.Config,
 		proxyApp:          proxyApp,
 		txEventStore:      txEventStore,
 		eventStoreService: eventStoreService,
+		archiveService:    archiveService,
 		firstBlockSignal:  cFirstBlock,
+		rpcLimits:         rpcserver.NewLimits(config.RPC.MaxOpenConnections, config.RPC.MaxBodyBytes),
+		settings:          config.Settings(),
 	}
 	node.BaseService = *service.NewBaseService(logger, "Node", node)
 
@@ -578,19 +680,45 @@ func (n *Node) OnStart() error {
 	// for instance, to set up Local clients (rpc/client) which work without
 	// a network connection.
 	n.configureRPC()
-	if n.config.RPC.Unsafe {
-		rpccore.AddUnsafeRoutes()
-	}
 	rpccore.Start()
 
-	// Start the RPC server before the P2P server
+	// Start the RPC servers before the P2P server
 	// so we can eg. receive txs for the first block
+	routes, allRoutes := rpccore.Routes, rpccore.AllRoutes()
+	if n.config.RPC.TraceTxs {
+		routes, allRoutes = rpccore.WithDebugRoutes(routes), rpccore.WithDebugRoutes(allRoutes)
+	}
+
 	if n.config.RPC.ListenAddress != "" {
-		listeners, err := n.startRPC()
+		listeners, err := n.startRPC(n.config.RPC.ListenAddress, routes, "")
 		if err != nil {
 			return err
 		}
 		n.rpcListeners = listeners
+		n.config.RPC.ListenAddress = rebuildListenAddresses(n.config.RPC.ListenAddress, listeners)
+	}
+
+	if n.config.RPC.OperatorListenAddress != "" {
+		listeners, err := n.startRPC(
+			n.config.RPC.OperatorListenAddress,
+			allRoutes,
+			n.config.RPC.OperatorAuthToken,
+		)
+		if err != nil {
+			for _, l := range n.rpcListeners {
+				l.Close()
+			}
+			return err
+		}
+		n.rpcListeners = append(n.rpcListeners, listeners...)
+		n.config.RPC.OperatorListenAddress = rebuildListenAddresses(n.config.RPC.OperatorListenAddress, listeners)
+	}
+
+	// Start offloading the old blocks to the cold store
+	if n.archiveService != nil {
+		if err := n.archiveService.Start(); err != nil {
+			return fmt.Errorf("unable to start archive service, %w", err)
+		}
 	}
 
 	// Start the transport.
@@ -617,6 +745,13 @@ func (n *Node) OnStart() error {
 
 	if n.config.Mempool.WalEnabled() {
 		n.mempool.InitWAL() // no need to have the mempool wal during tests
+		// Recover the txs pending before the restart, before starting the
+		// switch so they are gossiped to the peers.
+		recovered, err := n.mempool.RecoverWAL()
+		if err != nil {
+			return fmt.Errorf("unable to recover mempool WAL, %w", err)
+		}
+		n.Logger.Info("Recovered mempool WAL", "txs", recovered)
 	}
 
 	// Start the switch (the P2P server).
@@ -651,6 +786,9 @@ func (n *Node) OnStop() {
 	// Stop the non-reactor services
 	n.evsw.Stop()
 	n.eventStoreService.Stop()
+	if n.archiveService != nil {
+		n.archiveService.Stop()
+	}
 
 	// Stop the node p2p transport
 	if err := n.transport.Close(); err != nil {
@@ -699,9 +837,17 @@ func (n *Node) configureRPC() {
 	rpccore.SetLogger(n.Logger.With("module", "rpc"))
 	rpccore.SetEventSwitch(n.evsw)
 	rpccore.SetConfig(*n.config.RPC)
+	rpccore.SetConfigReloader(n.ReloadConfigFile)
 }
 
-func (n *Node) startRPC() (listeners []net.Listener, err error) {
+// startRPC starts an RPC server serving the given routes on each of the
+// comma-separated listen addresses. When authToken isn't empty, the requests
+// must carry it in their Authorization header.
+func (n *Node) startRPC(
+	listenAddress string,
+	routes map[string]*rpcserver.RPCFunc,
+	authToken string,
+) (listeners []net.Listener, err error) {
 	defer func() {
 		if err != nil {
 			// Close all the created listeners on any error, instead of
@@ -712,12 +858,13 @@ func (n *Node) startRPC() (listeners []net.Listener, err error) {
 		}
 	}()
 
-	listenAddrs := splitAndTrimEmpty(n.config.RPC.ListenAddress, ",", " ")
+	listenAddrs := splitAndTrimEmpty(listenAddress, ",", " ")
 
 	config := rpcserver.DefaultConfig()
 	config.MaxBodyBytes = n.config.RPC.MaxBodyBytes
 	config.MaxHeaderBytes = n.config.RPC.MaxHeaderBytes
 	config.MaxOpenConnections = n.config.RPC.MaxOpenConnections
+	config.Limits = n.rpcLimits
 	// If necessary adjust global WriteTimeout to ensure it's greater than
 	// TimeoutBroadcastTxCommit.
 	// See https://github.com/gnolang/gno/tm2/pkg/bft/issues/3435
@@ -726,13 +873,12 @@ func (n *Node) startRPC() (listeners []net.Listener, err error) {
 	}
 
 	// we may expose the rpc over both a unix and tcp socket
-	var rebuildAddresses bool
 	listeners = make([]net.Listener, 0, len(listenAddrs))
 	for _, listenAddr := range listenAddrs {
 		mux := http.NewServeMux()
 		rpcLogger := n.Logger.With("module", "rpc-server")
 		wmLogger := rpcLogger.With("protocol", "websocket")
-		wm := rpcserver.NewWebsocketManager(rpccore.Routes,
+		wm := rpcserver.NewWebsocketManager(routes,
 			rpcserver.OnDisconnect(func(remoteAddr string) {
 				// any cleanup...
 				// (we used to unsubscribe from all event subscriptions)
@@ -741,10 +887,7 @@ func (n *Node) startRPC() (listeners []net.Listener, err error) {
 		)
 		wm.SetLogger(wmLogger)
 		mux.HandleFunc("/websocket", wm.WebsocketHandler)
-		rpcserver.RegisterRPCFuncs(mux, rpccore.Routes, rpcLogger)
-		if strings.HasPrefix(listenAddr, "tcp://") && strings.HasSuffix(listenAddr, ":0") {
-			rebuildAddresses = true
-		}
+		rpcserver.RegisterRPCFuncs(mux, routes, rpcLogger)
 		listener, err := rpcserver.Listen(
 			listenAddr,
 			config,
@@ -762,6 +905,9 @@ func (n *Node) startRPC() (listeners []net.Listener, err error) {
 			})
 			rootHandler = corsMiddleware.Handler(mux)
 		}
+		if authToken != "" {
+			rootHandler = rpcserver.AuthTokenHandler(rootHandler, authToken)
+		}
 		if n.config.RPC.IsTLSEnabled() {
 			go rpcserver.StartHTTPAndTLSServer(
 				listener,
@@ -782,13 +928,23 @@ func (n *Node) startRPC() (listeners []net.Listener, err error) {
 
 		listeners = append(listeners, listener)
 	}
-	if rebuildAddresses {
-		n.config.RPC.ListenAddress = joinListenerAddresses(listeners)
-	}
 
 	return listeners, nil
 }
 
+// rebuildListenAddresses returns the addresses of the listeners if one of
+// the listen addresses lets the kernel pick the port, or the listen addresses
+// otherwise.
+func rebuildListenAddresses(listenAddress string, listeners []net.Listener) string {
+	for _, listenAddr := range splitAndTrimEmpty(listenAddress, ",", " ") {
+		if strings.HasPrefix(listenAddr, "tcp://") && strings.HasSuffix(listenAddr, ":0") {
+			return joinListenerAddresses(listeners)
+		}
+	}
+
+	return listenAddress
+}
+
 func joinListenerAddresses(ll []net.Listener) string {
 	sl := make([]string, len(ll))
 	for i, l := range ll {
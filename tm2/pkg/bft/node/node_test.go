@@ -1,9 +1,14 @@
 package node
 
 import (
+	"errors"
 	"fmt"
+	"io"
 	"net"
+	"net/http"
 	"os"
+	"path/filepath"
+	"strings"
 	"syscall"
 	"testing"
 	"time"
@@ -18,6 +23,9 @@ import (
 	sserver "github.com/gnolang/gno/tm2/pkg/bft/privval/signer/remote/server"
 	"github.com/gnolang/gno/tm2/pkg/bft/proxy"
 	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/file"
+	storetypes "github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/types"
 	"github.com/gnolang/gno/tm2/pkg/bft/types"
 	tmtime "github.com/gnolang/gno/tm2/pkg/bft/types/time"
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
@@ -25,6 +33,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 	"github.com/gnolang/gno/tm2/pkg/events"
 	"github.com/gnolang/gno/tm2/pkg/log"
+	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
 	"github.com/gnolang/gno/tm2/pkg/random"
 )
 
@@ -139,6 +148,80 @@ func TestNodeReady(t *testing.T) {
 	require.GreaterOrEqual(t, n.BlockStore().Height(), int64(1))
 }
 
+// testBlockEventStore is a block event store sending the blocks it is given
+type testBlockEventStore struct {
+	blocks chan eventstore.BlockResult
+}
+
+func (s *testBlockEventStore) Start() error                  { return nil }
+func (s *testBlockEventStore) Stop() error                   { return nil }
+func (s *testBlockEventStore) GetType() string               { return "test" }
+func (s *testBlockEventStore) Append(_ types.TxResult) error { return nil }
+func (s *testBlockEventStore) LastHeight() (int64, error)    { return 0, nil }
+
+func (s *testBlockEventStore) AppendBlock(block eventstore.BlockResult) error {
+	s.blocks <- block
+	return nil
+}
+
+func TestNodeRegisteredEventStore(t *testing.T) {
+	config, genesisFile := cfg.ResetTestRoot("node_node_test")
+	defer os.RemoveAll(config.RootDir)
+
+	store := &testBlockEventStore{blocks: make(chan eventstore.BlockResult, 16)}
+	eventStoreType := "test-" + random.RandStr(8)
+	RegisterEventStore(eventStoreType, func(_ *storetypes.Config) (eventstore.TxEventStore, error) {
+		return store, nil
+	})
+	assert.Panics(t, func() {
+		RegisterEventStore(eventStoreType, nil)
+	})
+	assert.Panics(t, func() {
+		RegisterEventStore(file.EventStoreType, nil)
+	})
+	config.TxEventStore.EventStoreType = eventStoreType
+
+	n, err := DefaultNewNode(config, genesisFile, events.NewEventSwitch(), log.NewTestingLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer n.Stop()
+
+	// The store is given the blocks in order, from the first one
+	for height := int64(1); height <= 2; height++ {
+		select {
+		case block := <-store.blocks:
+			assert.Equal(t, height, block.Block.Height)
+		case <-time.After(10 * time.Second):
+			t.Fatalf("timed out waiting for block %d", height)
+		}
+	}
+}
+
+func TestNodeArchive(t *testing.T) {
+	config, genesisFile := cfg.ResetTestRoot("node_archive_test")
+	defer os.RemoveAll(config.RootDir)
+
+	config.Archive.ColdStorePath = "cold"
+	config.Archive.RetainBlocks = 1
+
+	n, err := DefaultNewNode(config, genesisFile, events.NewEventSwitch(), log.NewTestingLogger(t))
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer n.Stop()
+
+	// Once block 2 is committed, block 1 is offloaded to the cold store,
+	// from which it is still loaded
+	coldBlockStoreDir := filepath.Join(config.RootDir, "cold", "blockstore")
+	require.Eventually(t, func() bool {
+		entries, err := os.ReadDir(coldBlockStoreDir)
+		return err == nil && len(entries) > 0
+	}, 10*time.Second, 10*time.Millisecond)
+
+	block := n.BlockStore().LoadBlock(1)
+	require.NotNil(t, block)
+	assert.Equal(t, int64(1), block.Height)
+}
+
 func TestNodeSetAppVersion(t *testing.T) {
 	config, genesisFile := cfg.ResetTestRoot("node_app_version_test")
 	defer os.RemoveAll(config.RootDir)
@@ -327,3 +410,139 @@ func state(nVals int, height int64) (sm.State, dbm.DB) {
 	}
 	return s, stateDB
 }
+
+func TestNodeOperatorRPC(t *testing.T) {
+	config, genesisFile := cfg.ResetTestRoot("node_operator_rpc_test")
+	defer os.RemoveAll(config.RootDir)
+
+	config.RPC.ListenAddress = "tcp://127.0.0.1:0"
+	config.RPC.OperatorListenAddress = "tcp://127.0.0.1:0"
+	config.RPC.OperatorAuthToken = "secret"
+
+	n, err := DefaultNewNode(config, genesisFile, events.NewEventSwitch(), log.NewNoopLogger())
+	require.NoError(t, err)
+	require.NoError(t, n.Start())
+	defer n.Stop()
+
+	call := func(laddr, method, token string) (int, string) {
+		t.Helper()
+
+		url := "http://" + strings.TrimPrefix(laddr, "tcp://")
+		body := fmt.Sprintf(`{"jsonrpc":"2.0","id":"1","method":%q,"params":{}}`, method)
+		req, err := http.NewRequest(http.MethodPost, url, strings.NewReader(body))
+		require.NoError(t, err)
+		if token != "" {
+			req.Header.Set("Authorization", "Bearer "+token)
+		}
+
+		resp, err := http.DefaultClient.Do(req)
+		require.NoError(t, err)
+		defer resp.Body.Close()
+
+		res, err := io.ReadAll(resp.Body)
+		require.NoError(t, err)
+
+		return resp.StatusCode, string(res)
+	}
+
+	publicAddr, operatorAddr := n.Config().RPC.ListenAddress, n.Config().RPC.OperatorListenAddress
+	require.NotEqual(t, publicAddr, operatorAddr)
+
+	// The public server serves the public routes only, without authentication
+	code, res := call(publicAddr, "health", "")
+	assert.Equal(t, http.StatusOK, code)
+	assert.NotContains(t, res, `"error"`)
+
+	_, res = call(publicAddr, "unsafe_flush_mempool", "")
+	assert.Contains(t, res, "Method not found")
+
+	// The operator server requires the token
+	code, _ = call(operatorAddr, "unsafe_flush_mempool", "")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	code, _ = call(operatorAddr, "unsafe_flush_mempool", "wrong")
+	assert.Equal(t, http.StatusUnauthorized, code)
+
+	// and serves both the public and the operator routes
+	for _, method := range []string{"health", "unsafe_flush_mempool"} {
+		code, res = call(operatorAddr, method, "secret")
+		assert.Equal(t, http.StatusOK, code)
+		assert.NotContains(t, res, `"error"`)
+	}
+}
+
+func TestNodeReloadConfig(t *testing.T) {
+	config, genesisFile := cfg.ResetTestRoot("node_reload_config_test")
+	defer os.RemoveAll(config.RootDir)
+
+	n, err := DefaultNewNode(config, genesisFile, events.NewEventSwitch(), log.NewNoopLogger())
+	require.NoError(t, err)
+
+	var hookLevel string
+	n.OnReload(func(newConfig *cfg.Config) error {
+		if newConfig.LogLevel == "warn" {
+			return errors.New("warn is not supported")
+		}
+		hookLevel = newConfig.LogLevel
+		return nil
+	})
+
+	// reload reloads a copy of the config, with the given changes
+	reload := func(change func(c *cfg.Config)) ([]string, error) {
+		t.Helper()
+
+		c := cfg.TestConfig().SetRootDir(config.RootDir)
+		change(c)
+
+		return n.ReloadConfig(c)
+	}
+
+	// Nothing changed
+	changed, err := reload(func(*cfg.Config) {})
+	require.NoError(t, err)
+	assert.Empty(t, changed)
+
+	// Reloadable settings
+	changed, err = reload(func(c *cfg.Config) {
+		c.LogLevel = "error"
+		c.RPC.MaxOpenConnections = 42
+		c.Mempool.Size = 1234
+		c.P2P.PersistentPeers = fmt.Sprintf("%s@127.0.0.1:26656", p2pTypes.GenerateNodeKey().ID())
+	})
+	require.NoError(t, err)
+	assert.Equal(t, []string{
+		"log_level",
+		"mempool.size",
+		"p2p.persistent_peers",
+		"rpc.max_open_connections",
+	}, changed)
+
+	assert.Equal(t, "error", hookLevel)
+	assert.Equal(t, 42, n.rpcLimits.MaxOpenConnections())
+	assert.Equal(t, 1234, config.Mempool.Size)
+
+	// Settings requiring a restart
+	_, err = reload(func(c *cfg.Config) {
+		c.Moniker = "other"
+		c.Consensus.TimeoutCommit = time.Hour
+	})
+	require.Error(t, err)
+	assert.Contains(t, err.Error(), "consensus-critical settings can't be reloaded: consensus.timeout_commit")
+	assert.Contains(t, err.Error(), "settings requiring a restart can't be reloaded: moniker")
+	assert.Equal(t, 1234, config.Mempool.Size)
+
+	// Invalid peers
+	_, err = reload(func(c *cfg.Config) {
+		c.P2P.PersistentPeers = "invalid"
+	})
+	assert.ErrorContains(t, err, "invalid persistent peer addresses")
+
+	// Rejected by a hook
+	_, err = reload(func(c *cfg.Config) {
+		c.LogLevel = "warn"
+	})
+	assert.ErrorContains(t, err, "warn is not supported")
+	assert.Equal(t, "error", config.LogLevel)
+	assert.Equal(t, 1234, config.Mempool.Size)
+	assert.Equal(t, 42, n.rpcLimits.MaxOpenConnections())
+}
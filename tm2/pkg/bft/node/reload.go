@@ -0,0 +1,131 @@
+package node
+
+import (
+	"errors"
+	"fmt"
+	"strings"
+
+	cfg "github.com/gnolang/gno/tm2/pkg/bft/config"
+	mempl "github.com/gnolang/gno/tm2/pkg/bft/mempool"
+	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
+)
+
+// reloadableSettings are the settings which can be changed without
+// restarting the node, by reloading its config.
+var reloadableSettings = map[string]bool{
+	"log_level":                     true,
+	"rpc.max_open_connections":      true,
+	"rpc.max_body_bytes":            true,
+	"mempool.size":                  true,
+	"mempool.max_pending_txs_bytes": true,
+	"p2p.persistent_peers":          true,
+}
+
+// ReloadHook applies the settings of a reloaded config which are handled
+// outside of the node, like the log level. It can reject the config by
+// returning an error.
+type ReloadHook func(newConfig *cfg.Config) error
+
+// OnReload registers a hook called by ReloadConfig with the new config,
+// before the node applies it.
+func (n *Node) OnReload(hook ReloadHook) {
+	n.reloadMtx.Lock()
+	defer n.reloadMtx.Unlock()
+
+	n.reloadHooks = append(n.reloadHooks, hook)
+}
+
+// ReloadConfig applies the reloadable settings of newConfig to the running
+// node: the log level, the RPC limits, the mempool caps and the persistent
+// peers. It returns the keys of the changed settings.
+//
+// Nothing is changed if the reloadable settings of newConfig are invalid, or
+// if it changes any other setting: those require a restart, and the consensus ones must stay the same
+// across the validators.
+func (n *Node) ReloadConfig(newConfig *cfg.Config) ([]string, error) {
+	n.reloadMtx.Lock()
+	defer n.reloadMtx.Unlock()
+
+	settings := newConfig.Settings()
+	changed := n.settings.Diff(settings)
+
+	var consensus, restart []string
+	for _, key := range changed {
+		switch {
+		case reloadableSettings[key]:
+		case strings.HasPrefix(key, "consensus."):
+			consensus = append(consensus, key)
+		default:
+			restart = append(restart, key)
+		}
+	}
+	var errs []error
+	if len(consensus) > 0 {
+		errs = append(errs, fmt.Errorf("consensus-critical settings can't be reloaded: %s", strings.Join(consensus, ", ")))
+	}
+	if len(restart) > 0 {
+		errs = append(errs, fmt.Errorf("settings requiring a restart can't be reloaded: %s", strings.Join(restart, ", ")))
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	// The other settings are the same as the running ones,
+	// so only the reloadable ones need to be validated
+	if err := cfg.ValidateLogLevel(newConfig.LogLevel); err != nil {
+		return nil, err
+	}
+	if err := newConfig.RPC.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid rpc config, %w", err)
+	}
+	if err := newConfig.Mempool.ValidateBasic(); err != nil {
+		return nil, fmt.Errorf("invalid mempool config, %w", err)
+	}
+	peerAddrs, errs := p2pTypes.NewNetAddressFromStrings(
+		splitAndTrimEmpty(newConfig.P2P.PersistentPeers, ",", " "),
+	)
+	if len(errs) > 0 {
+		return nil, fmt.Errorf("invalid persistent peer addresses, %w", errors.Join(errs...))
+	}
+
+	if len(changed) == 0 {
+		return nil, nil
+	}
+
+	for _, hook := range n.reloadHooks {
+		if err := hook(newConfig); err != nil {
+			return nil, err
+		}
+	}
+
+	// Apply the new settings.
+	n.config.LogLevel = newConfig.LogLevel
+
+	n.config.RPC.MaxOpenConnections = newConfig.RPC.MaxOpenConnections
+	n.config.RPC.MaxBodyBytes = newConfig.RPC.MaxBodyBytes
+	n.rpcLimits.Set(newConfig.RPC.MaxOpenConnections, newConfig.RPC.MaxBodyBytes)
+
+	if mempool, ok := n.mempool.(*mempl.CListMempool); ok {
+		// Also updates n.config.Mempool, shared with the mempool.
+		mempool.SetLimits(newConfig.Mempool.Size, newConfig.Mempool.MaxPendingTxsBytes)
+	}
+
+	n.config.P2P.PersistentPeers = newConfig.P2P.PersistentPeers
+	n.sw.SetPersistentPeers(peerAddrs)
+
+	n.settings = settings
+	n.Logger.Info("Reloaded config", "changed", changed)
+
+	return changed, nil
+}
+
+// ReloadConfigFile reloads the config file of the node's root directory, with
+// ReloadConfig.
+func (n *Node) ReloadConfigFile() ([]string, error) {
+	newConfig, err := cfg.LoadConfig(n.config.RootDir)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load config, %w", err)
+	}
+
+	return n.ReloadConfig(newConfig)
+}
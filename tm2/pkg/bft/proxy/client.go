@@ -1,6 +1,7 @@
 package proxy
 
 import (
+	"fmt"
 	"sync"
 
 	abcicli "github.com/gnolang/gno/tm2/pkg/bft/abci/client"
@@ -9,6 +10,13 @@ import (
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 )
 
+// ABCI transports of remote applications, as in the abci setting of the node
+// config.
+const (
+	socketTransport = "socket"
+	grpcTransport   = "grpc"
+)
+
 // NewABCIClient returns newly connected client
 type ClientCreator interface {
 	NewABCIClient() (abcicli.Client, error)
@@ -33,6 +41,36 @@ func (l *localClientCreator) NewABCIClient() (abcicli.Client, error) {
 	return abcicli.NewLocalClient(l.mtx, l.app), nil
 }
 
+//---------------------------------------------------------------
+// remote proxy opens new connections to an external app process
+
+type remoteClientCreator struct {
+	addr        string
+	transport   string
+	mustConnect bool
+}
+
+// NewRemoteClientCreator returns a ClientCreator connecting to the application
+// listening at addr over the given transport, socket or grpc.
+func NewRemoteClientCreator(addr, transport string, mustConnect bool) ClientCreator {
+	return &remoteClientCreator{
+		addr:        addr,
+		transport:   transport,
+		mustConnect: mustConnect,
+	}
+}
+
+func (r *remoteClientCreator) NewABCIClient() (abcicli.Client, error) {
+	switch r.transport {
+	case socketTransport:
+		return abcicli.NewSocketClient(r.addr, r.mustConnect), nil
+	case grpcTransport:
+		return abcicli.NewGRPCClient(r.addr, r.mustConnect), nil
+	default:
+		return nil, fmt.Errorf("unsupported ABCI transport %q", r.transport)
+	}
+}
+
 //-----------------------------------------------------------------
 // DefaultClientCreator
 
@@ -56,8 +94,11 @@ func DefaultClientCreator(local abci.Application, proxy string, transport, dbDir
 		case "mock://noop":
 			return NewLocalClientCreator(abci.NewBaseApplication())
 		default:
-			// socket transport applications
-			panic("proxy scheme not yet supported: " + proxy)
+			// socket and grpc transport applications
+			if transport != socketTransport && transport != grpcTransport {
+				panic("proxy scheme not yet supported: " + proxy)
+			}
+			return NewRemoteClientCreator(proxy, transport, true)
 		}
 	}
 }
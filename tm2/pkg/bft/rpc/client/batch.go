@@ -122,6 +122,21 @@ func (b *RPCBatch) Status() error {
 	return nil
 }
 
+func (b *RPCBatch) Metadata() error {
+	// Prepare the RPC request
+	request, err := newRequest(
+		metadataMethod,
+		map[string]any{},
+	)
+	if err != nil {
+		return fmt.Errorf("unable to create request, %w", err)
+	}
+
+	b.addRequest(request, &ctypes.ResultMetadata{})
+
+	return nil
+}
+
 func (b *RPCBatch) ABCIInfo() error {
 	// Prepare the RPC request
 	request, err := newRequest(
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"fmt"
+	"sync/atomic"
 	"time"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
@@ -20,6 +21,7 @@ const defaultTimeout = 60 * time.Second
 
 const (
 	statusMethod             = "status"
+	metadataMethod           = "metadata"
 	abciInfoMethod           = "abci_info"
 	abciQueryMethod          = "abci_query"
 	broadcastTxCommitMethod  = "broadcast_tx_commit"
@@ -39,12 +41,26 @@ const (
 	commitMethod             = "commit"
 	txMethod                 = "tx"
 	validatorsMethod         = "validators"
+	debugTraceTxMethod       = "debug_trace_tx"
 )
 
 // RPCClient encompasses common RPC client methods
 type RPCClient struct {
 	requestTimeout time.Duration
 
+	// WS reconnect parameters, disabled if maxBackoff is 0
+	minBackoff time.Duration
+	maxBackoff time.Duration
+	onGap      func(lastHeight int64)
+	noReplay   bool
+
+	// HTTP connection pool parameters, the defaults if zero
+	maxIdleConns    int
+	idleConnTimeout time.Duration
+
+	// lastHeight is the highest block height seen in the responses
+	lastHeight atomic.Int64
+
 	caller rpcclient.Client
 }
 
@@ -69,13 +85,28 @@ func NewRPCClient(caller rpcclient.Client, opts ...Option) *RPCClient {
 // Request batching is available for JSON RPC requests over HTTP, which conforms to
 // the JSON RPC specification (https://www.jsonrpc.org/specification#batch). See
 // the example for more details
+//
+// The HTTP clients of the same node share a pool of keep-alive connections,
+// configured with WithConnPool, whose stats are returned by PoolStats
 func NewHTTPClient(rpcURL string, opts ...Option) (*RPCClient, error) {
-	httpClient, err := http.NewClient(rpcURL)
+	c := NewRPCClient(nil, opts...)
+
+	var httpOpts []http.Option
+	if c.maxIdleConns > 0 {
+		httpOpts = append(httpOpts, http.WithMaxIdleConns(c.maxIdleConns))
+	}
+	if c.idleConnTimeout > 0 {
+		httpOpts = append(httpOpts, http.WithIdleConnTimeout(c.idleConnTimeout))
+	}
+
+	httpClient, err := http.NewClient(rpcURL, httpOpts...)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewRPCClient(httpClient, opts...), nil
+	c.caller = httpClient
+
+	return c, nil
 }
 
 // NewWSClient takes a remote endpoint in the form <protocol>://<host>:<port>,
@@ -85,13 +116,84 @@ func NewHTTPClient(rpcURL string, opts ...Option) (*RPCClient, error) {
 // Request batching is available for JSON RPC requests over WS, which conforms to
 // the JSON RPC specification (https://www.jsonrpc.org/specification#batch). See
 // the example for more details
+//
+// With WithReconnect, the WS client reconnects when the connection is lost,
+// and the gap handler set with WithGapHandler is called with the last seen
+// height, so the blocks committed in the meantime can be resynced. The
+// requests awaiting a response are sent again, except for the tx broadcasts
+// which may have reached the node, unless WithoutReplay is set.
 func NewWSClient(rpcURL string, opts ...Option) (*RPCClient, error) {
-	wsClient, err := ws.NewClient(rpcURL)
+	c := NewRPCClient(nil, opts...)
+
+	var wsOpts []ws.Option
+	if c.maxBackoff > 0 {
+		wsOpts = append(
+			wsOpts,
+			ws.WithReconnect(c.minBackoff, c.maxBackoff),
+			ws.WithReconnectHandler(c.handleReconnect),
+		)
+
+		if !c.noReplay {
+			wsOpts = append(wsOpts, ws.WithReplayFilter(isReplayable))
+		}
+	}
+
+	wsClient, err := ws.NewClient(rpcURL, wsOpts...)
 	if err != nil {
 		return nil, err
 	}
 
-	return NewRPCClient(wsClient, opts...), nil
+	c.caller = wsClient
+
+	return c, nil
+}
+
+// isReplayable returns true if the method can be called again without side
+// effects. The tx broadcasts aren't, as the tx may already be in the mempool
+func isReplayable(method string) bool {
+	switch method {
+	case broadcastTxCommitMethod, broadcastTxAsyncMethod, broadcastTxSyncMethod:
+		return false
+	default:
+		return true
+	}
+}
+
+// LastHeight returns the highest block height seen in the responses
+// of the Status, Block and BlockResults calls, or 0 if none
+func (c *RPCClient) LastHeight() int64 {
+	return c.lastHeight.Load()
+}
+
+// PoolStats returns the stats of the connection pool of the HTTP client,
+// shared with the other HTTP clients of the node, and false for the other
+// clients
+func (c *RPCClient) PoolStats() (http.PoolStats, bool) {
+	httpClient, ok := c.caller.(*http.Client)
+	if !ok {
+		return http.PoolStats{}, false
+	}
+
+	return httpClient.Stats(), true
+}
+
+// seeHeight records the given block height, if it is the highest seen
+func (c *RPCClient) seeHeight(height int64) {
+	for {
+		last := c.lastHeight.Load()
+		if height <= last || c.lastHeight.CompareAndSwap(last, height) {
+			return
+		}
+	}
+}
+
+// handleReconnect is called when the WS client reconnects. Blocks may have
+// been committed while the connection was lost, which the gap handler is
+// notified of with the last seen height
+func (c *RPCClient) handleReconnect() {
+	if c.onGap != nil {
+		c.onGap(c.LastHeight())
+	}
 }
 
 // Close attempts to gracefully close the RPC client
@@ -108,7 +210,7 @@ func (c *RPCClient) NewBatch() *RPCBatch {
 }
 
 func (c *RPCClient) Status(ctx context.Context, heightGte *int64) (*ctypes.ResultStatus, error) {
-	return sendRequestCommon[ctypes.ResultStatus](
+	res, err := sendRequestCommon[ctypes.ResultStatus](
 		ctx,
 		c.requestTimeout,
 		c.caller,
@@ -117,6 +219,23 @@ func (c *RPCClient) Status(ctx context.Context, heightGte *int64) (*ctypes.Resul
 			"heightGte": heightGte,
 		},
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.seeHeight(res.SyncInfo.LatestBlockHeight)
+
+	return res, nil
+}
+
+func (c *RPCClient) Metadata(ctx context.Context) (*ctypes.ResultMetadata, error) {
+	return sendRequestCommon[ctypes.ResultMetadata](
+		ctx,
+		c.requestTimeout,
+		c.caller,
+		metadataMethod,
+		map[string]any{},
+	)
 }
 
 func (c *RPCClient) ABCIInfo(ctx context.Context) (*ctypes.ResultABCIInfo, error) {
@@ -280,13 +399,22 @@ func (c *RPCClient) Block(ctx context.Context, height *int64) (*ctypes.ResultBlo
 		params["height"] = height
 	}
 
-	return sendRequestCommon[ctypes.ResultBlock](
+	res, err := sendRequestCommon[ctypes.ResultBlock](
 		ctx,
 		c.requestTimeout,
 		c.caller,
 		blockMethod,
 		params,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	if res.Block != nil {
+		c.seeHeight(res.Block.Height)
+	}
+
+	return res, nil
 }
 
 func (c *RPCClient) BlockResults(ctx context.Context, height *int64) (*ctypes.ResultBlockResults, error) {
@@ -295,13 +423,20 @@ func (c *RPCClient) BlockResults(ctx context.Context, height *int64) (*ctypes.Re
 		params["height"] = height
 	}
 
-	return sendRequestCommon[ctypes.ResultBlockResults](
+	res, err := sendRequestCommon[ctypes.ResultBlockResults](
 		ctx,
 		c.requestTimeout,
 		c.caller,
 		blockResultsMethod,
 		params,
 	)
+	if err != nil {
+		return nil, err
+	}
+
+	c.seeHeight(res.Height)
+
+	return res, nil
 }
 
 func (c *RPCClient) Commit(ctx context.Context, height *int64) (*ctypes.ResultCommit, error) {
@@ -331,6 +466,18 @@ func (c *RPCClient) Tx(ctx context.Context, hash []byte) (*ctypes.ResultTx, erro
 	)
 }
 
+func (c *RPCClient) DebugTraceTx(ctx context.Context, hash []byte) (*ctypes.ResultTraceTx, error) {
+	return sendRequestCommon[ctypes.ResultTraceTx](
+		ctx,
+		c.requestTimeout,
+		c.caller,
+		debugTraceTxMethod,
+		map[string]any{
+			"hash": hash,
+		},
+	)
+}
+
 func (c *RPCClient) Validators(ctx context.Context, height *int64) (*ctypes.ResultValidators, error) {
 	params := map[string]any{}
 	if height != nil {
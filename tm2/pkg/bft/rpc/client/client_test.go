@@ -143,6 +143,44 @@ func TestRPCClient_Status(t *testing.T) {
 	assert.Equal(t, expectedStatus, status)
 }
 
+func TestRPCClient_Metadata(t *testing.T) {
+	t.Parallel()
+
+	var (
+		expectedMetadata = &ctypes.ResultMetadata{
+			Network:  "dummy",
+			Features: []string{"tx_index"},
+			App: abci.AppMetadata{
+				Name:     "dummy",
+				Features: []string{"simulate"},
+				MsgTypes: []abci.MsgTypeInfo{{Route: "bank", Type: "send", TypeURL: "/bank.MsgSend"}},
+			},
+		}
+
+		verifyFn = func(t *testing.T, params map[string]any) {
+			t.Helper()
+
+			assert.Len(t, params, 0)
+		}
+
+		mockClient = generateMockRequestClient(
+			t,
+			metadataMethod,
+			verifyFn,
+			expectedMetadata,
+		)
+	)
+
+	// Create the client
+	c := NewRPCClient(mockClient)
+
+	// Get the metadata
+	metadata, err := c.Metadata(context.Background())
+	require.NoError(t, err)
+
+	assert.Equal(t, expectedMetadata, metadata)
+}
+
 func TestRPCClient_ABCIInfo(t *testing.T) {
 	t.Parallel()
 
@@ -6,12 +6,15 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
+	"time"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	cstypes "github.com/gnolang/gno/tm2/pkg/bft/consensus/types"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/client/ws"
 	types "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
 	bfttypes "github.com/gnolang/gno/tm2/pkg/bft/types"
 	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
@@ -453,3 +456,161 @@ func TestRPCClient_E2E_Endpoints(t *testing.T) {
 		})
 	}
 }
+
+func TestRPCClient_WSReconnect(t *testing.T) {
+	t.Parallel()
+
+	var (
+		upgrader = websocket.Upgrader{}
+		conns    atomic.Int64
+	)
+
+	// Create the server, which answers block results requests
+	// and drops the first connection after the first response
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+
+		defer c.Close()
+
+		first := conns.Add(1) == 1
+
+		for {
+			mt, message, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			var req types.RPCRequest
+			require.NoError(t, json.Unmarshal(message, &req))
+			require.Equal(t, blockResultsMethod, req.Method)
+
+			result, err := amino.MarshalJSON(&ctypes.ResultBlockResults{Height: 10})
+			require.NoError(t, err)
+
+			marshalledResponse, err := json.Marshal(types.RPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+				Result:  result,
+			})
+			require.NoError(t, err)
+
+			require.NoError(t, c.WriteMessage(mt, marshalledResponse))
+
+			if first {
+				return
+			}
+		}
+	}
+
+	s := createTestServer(t, http.HandlerFunc(handler))
+
+	gaps := make(chan int64, 1)
+	c, err := NewWSClient(
+		"ws"+strings.TrimPrefix(s.URL, "http"),
+		WithReconnect(10*time.Millisecond, 100*time.Millisecond),
+		WithGapHandler(func(lastHeight int64) {
+			gaps <- lastHeight
+		}),
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	res, err := c.BlockResults(context.Background(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(10), res.Height)
+	assert.Equal(t, int64(10), c.LastHeight())
+
+	// The gap handler is called with the last seen height once reconnected
+	select {
+	case lastHeight := <-gaps:
+		assert.Equal(t, int64(10), lastHeight)
+	case <-time.After(5 * time.Second):
+		t.Fatal("gap handler not called")
+	}
+
+	// Requests are served over the new connection
+	_, err = c.BlockResults(context.Background(), nil)
+	require.NoError(t, err)
+
+	assert.Equal(t, int64(2), conns.Load())
+}
+
+func TestRPCClient_WSReconnectBroadcast(t *testing.T) {
+	t.Parallel()
+
+	var (
+		upgrader   = websocket.Upgrader{}
+		broadcasts atomic.Int64
+	)
+
+	// Create the server, which drops the connections
+	// after receiving a request, without responding
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+
+		defer c.Close()
+
+		_, message, err := c.ReadMessage()
+		if err != nil {
+			return
+		}
+
+		var req types.RPCRequest
+		require.NoError(t, json.Unmarshal(message, &req))
+		require.Equal(t, broadcastTxSyncMethod, req.Method)
+
+		broadcasts.Add(1)
+	}
+
+	s := createTestServer(t, http.HandlerFunc(handler))
+
+	c, err := NewWSClient(
+		"ws"+strings.TrimPrefix(s.URL, "http"),
+		WithReconnect(10*time.Millisecond, 100*time.Millisecond),
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	// The tx may have reached the node, so it isn't broadcast again
+	_, err = c.BroadcastTxSync(context.Background(), []byte("tx"))
+	require.ErrorIs(t, err, ws.ErrConnectionLost)
+
+	assert.Equal(t, int64(1), broadcasts.Load())
+}
+
+func TestRPCClient_HTTPConnPool(t *testing.T) {
+	t.Parallel()
+
+	s := createTestServer(t, defaultHTTPHandler(t, healthMethod, &ctypes.ResultHealth{}))
+
+	// The clients of the node share their connections
+	for range 5 {
+		c, err := NewHTTPClient(s.URL, WithConnPool(4, time.Minute))
+		require.NoError(t, err)
+
+		_, err = c.Health(context.Background())
+		require.NoError(t, err)
+	}
+
+	c, err := NewHTTPClient(s.URL, WithConnPool(4, time.Minute))
+	require.NoError(t, err)
+
+	stats, ok := c.PoolStats()
+	require.True(t, ok)
+	assert.Equal(t, uint64(5), stats.Requests)
+	assert.Equal(t, uint64(0), stats.Failures)
+	assert.Equal(t, uint64(1), stats.Dials)
+
+	// The other clients have no pool
+	_, ok = NewRPCClient(nil).PoolStats()
+	assert.False(t, ok)
+}
@@ -44,7 +44,10 @@ func NewLocal() *Local {
 	}
 }
 
-var _ Client = (*Local)(nil)
+var (
+	_ Client      = (*Local)(nil)
+	_ DebugClient = (*Local)(nil)
+)
 
 // SetLogger allows to set a logger on the client.
 func (c *Local) SetLogger(l *slog.Logger) {
@@ -55,6 +58,10 @@ func (c *Local) Status(_ context.Context, heightGte *int64) (*ctypes.ResultStatu
 	return core.Status(c.ctx, heightGte)
 }
 
+func (c *Local) Metadata(_ context.Context) (*ctypes.ResultMetadata, error) {
+	return core.Metadata(c.ctx)
+}
+
 func (c *Local) ABCIInfo(_ context.Context) (*ctypes.ResultABCIInfo, error) {
 	return core.ABCIInfo(c.ctx)
 }
@@ -134,3 +141,7 @@ func (c *Local) Validators(_ context.Context, height *int64) (*ctypes.ResultVali
 func (c *Local) Tx(_ context.Context, hash []byte) (*ctypes.ResultTx, error) {
 	return core.Tx(c.ctx, hash)
 }
+
+func (c *Local) DebugTraceTx(_ context.Context, hash []byte) (*ctypes.ResultTraceTx, error) {
+	return core.DebugTraceTx(c.ctx, hash)
+}
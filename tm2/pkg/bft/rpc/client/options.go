@@ -10,3 +10,41 @@ func WithRequestTimeout(timeout time.Duration) Option {
 		client.requestTimeout = timeout
 	}
 }
+
+// WithReconnect makes the WS client reconnect when the connection is lost,
+// with an exponential backoff from minBackoff up to maxBackoff between
+// attempts. It has no effect on the HTTP client
+func WithReconnect(minBackoff, maxBackoff time.Duration) Option {
+	return func(client *RPCClient) {
+		client.minBackoff = minBackoff
+		client.maxBackoff = max(maxBackoff, minBackoff)
+	}
+}
+
+// WithoutReplay makes the WS client fail the requests awaiting a response
+// when the connection is lost, instead of sending the idempotent ones again
+// once reconnected
+func WithoutReplay() Option {
+	return func(client *RPCClient) {
+		client.noReplay = true
+	}
+}
+
+// WithGapHandler sets the callback called with the last seen block height
+// when the WS client reconnects, so the blocks committed after it can be
+// resynced
+func WithGapHandler(fn func(lastHeight int64)) Option {
+	return func(client *RPCClient) {
+		client.onGap = fn
+	}
+}
+
+// WithConnPool sets the maximum number of idle connections the HTTP client
+// keeps open to the node, and the time they are kept open. Zero keeps the
+// default. It has no effect on the WS client
+func WithConnPool(maxIdleConns int, idleConnTimeout time.Duration) Option {
+	return func(client *RPCClient) {
+		client.maxIdleConns = maxIdleConns
+		client.idleConnTimeout = idleConnTimeout
+	}
+}
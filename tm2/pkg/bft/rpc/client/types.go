@@ -69,6 +69,7 @@ type HistoryClient interface {
 // StatusClient provides access to general chain info.
 type StatusClient interface {
 	Status(ctx context.Context, heightGte *int64) (*ctypes.ResultStatus, error)
+	Metadata(ctx context.Context) (*ctypes.ResultMetadata, error)
 }
 
 // NetworkClient is general info about the network state. May not be needed
@@ -90,3 +91,9 @@ type MempoolClient interface {
 type TxClient interface {
 	Tx(ctx context.Context, hash []byte) (*ctypes.ResultTx, error)
 }
+
+// DebugClient re-executes committed transactions. Its routes are only served
+// by the nodes enabling them, so it is not part of Client.
+type DebugClient interface {
+	DebugTraceTx(ctx context.Context, hash []byte) (*ctypes.ResultTraceTx, error)
+}
@@ -0,0 +1,86 @@
+package client
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+)
+
+var ErrInvalidWatchRange = errors.New("invalid block watch range")
+
+const defaultWatchPollInterval = time.Second
+
+// BlockWatchCfg contains the configuration of a block watch.
+type BlockWatchCfg struct {
+	FromHeight   int64         // First block inspected, the next block if 0
+	ToHeight     int64         // Last block inspected, no limit if 0
+	PollInterval time.Duration // Interval between latest height queries, 1s if 0
+}
+
+// WatchBlockResults calls fn with the results of each block from
+// cfg.FromHeight, in order, as they are committed. It returns once the
+// results of cfg.ToHeight were handled, or when ctx is done or fn returns an
+// error.
+func WatchBlockResults(ctx context.Context, c Client, cfg BlockWatchCfg, fn func(*ctypes.ResultBlockResults) error) error {
+	if cfg.FromHeight < 0 || cfg.ToHeight < 0 {
+		return ErrInvalidWatchRange
+	}
+
+	if cfg.PollInterval <= 0 {
+		cfg.PollInterval = defaultWatchPollInterval
+	}
+
+	latestHeight := func() (int64, error) {
+		status, err := c.Status(ctx, nil)
+		if err != nil {
+			return 0, fmt.Errorf("status query failed: %w", err)
+		}
+		return status.SyncInfo.LatestBlockHeight, nil
+	}
+
+	height := cfg.FromHeight
+	if height == 0 {
+		latest, err := latestHeight()
+		if err != nil {
+			return err
+		}
+		height = latest + 1
+	}
+
+	ticker := time.NewTicker(cfg.PollInterval)
+	defer ticker.Stop()
+
+	for {
+		latest, err := latestHeight()
+		if err != nil {
+			return err
+		}
+		if cfg.ToHeight != 0 && latest > cfg.ToHeight {
+			latest = cfg.ToHeight
+		}
+
+		for ; height <= latest; height++ {
+			res, err := c.BlockResults(ctx, &height)
+			if err != nil {
+				return fmt.Errorf("block results query failed: %w", err)
+			}
+
+			if err := fn(res); err != nil {
+				return err
+			}
+		}
+
+		if cfg.ToHeight != 0 && height > cfg.ToHeight {
+			return nil
+		}
+
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-ticker.C:
+		}
+	}
+}
@@ -4,6 +4,7 @@ import (
 	"errors"
 	"net/http"
 	"path/filepath"
+	"strings"
 	"time"
 )
 
@@ -44,8 +45,21 @@ type RPCConfig struct {
 	// 0 - unlimited.
 	GRPCMaxOpenConnections int `json:"grpc_max_open_connections" toml:"grpc_max_open_connections" comment:"Maximum number of simultaneous connections.\n Does not include RPC (HTTP&WebSocket) connections. See max_open_connections\n If you want to accept a larger number than the default, make sure\n you increase your OS limits.\n 0 - unlimited.\n Should be < {ulimit -Sn} - {MaxNumInboundPeers} - {MaxNumOutboundPeers} - {N of wal, db and other open files}\n 1024 - 40 - 10 - 50 = 924 = ~900"`
 
-	// Activate unsafe RPC commands like /dial_persistent_peers and /unsafe_flush_mempool
-	Unsafe bool `json:"unsafe" toml:"unsafe" comment:"Activate unsafe RPC commands like /dial_seeds and /unsafe_flush_mempool"`
+	// TCP or UNIX socket address for the operator RPC server to listen on.
+	// The operator RPC server serves the public RPC commands, along with the
+	// commands controlling the node, like /dial_peers and /unsafe_flush_mempool.
+	// Empty disables the operator RPC server
+	OperatorListenAddress string `json:"operator_laddr" toml:"operator_laddr" comment:"TCP or UNIX socket address for the operator RPC server to listen on.\n The operator RPC server serves the public RPC commands, along with the\n commands controlling the node, like /dial_peers and /unsafe_flush_mempool.\n Empty disables the operator RPC server"`
+
+	// Token the requests to the operator RPC server must carry in their
+	// Authorization header, as "Bearer <token>".
+	// Required unless the operator RPC server only listens on UNIX sockets
+	OperatorAuthToken string `json:"operator_auth_token" toml:"operator_auth_token" comment:"Token the requests to the operator RPC server must carry in their\n Authorization header, as \"Bearer <token>\".\n Required unless the operator RPC server only listens on UNIX sockets"`
+
+	// Serve /debug_trace_tx, which re-executes a committed transaction to trace
+	// its calls, gas and store operations. Re-executing transactions is expensive,
+	// and requires the application to keep the state of the previous height
+	TraceTxs bool `json:"trace_txs" toml:"trace_txs" comment:"Serve /debug_trace_tx, which re-executes a committed transaction to trace\n its calls, gas and store operations. Re-executing transactions is expensive,\n and requires the application to keep the state of the previous height"`
 
 	// Maximum number of simultaneous connections (including WebSocket).
 	// Does not include gRPC connections. See grpc_max_open_connections
@@ -95,7 +109,11 @@ func DefaultRPCConfig() *RPCConfig {
 		GRPCListenAddress:      "",
 		GRPCMaxOpenConnections: 900,
 
-		Unsafe:             false,
+		OperatorListenAddress: "",
+		OperatorAuthToken:     "",
+
+		TraceTxs: false,
+
 		MaxOpenConnections: 900,
 
 		TimeoutBroadcastTxCommit: 10 * time.Second,
@@ -113,7 +131,6 @@ func TestRPCConfig() *RPCConfig {
 	cfg := DefaultRPCConfig()
 	cfg.ListenAddress = "tcp://0.0.0.0:26657"
 	cfg.GRPCListenAddress = "tcp://0.0.0.0:26658"
-	cfg.Unsafe = true
 	return cfg
 }
 
@@ -135,9 +152,26 @@ func (cfg *RPCConfig) ValidateBasic() error {
 	if cfg.MaxHeaderBytes < 0 {
 		return errors.New("max_header_bytes can't be negative")
 	}
+	if cfg.OperatorAuthToken == "" && hasTCPAddress(cfg.OperatorListenAddress) {
+		return errors.New("operator_auth_token is required when operator_laddr has a TCP address")
+	}
+	if cfg.OperatorListenAddress != "" && cfg.OperatorListenAddress == cfg.ListenAddress {
+		return errors.New("operator_laddr must be different from laddr")
+	}
 	return nil
 }
 
+// hasTCPAddress returns true if one of the comma-separated listen addresses
+// isn't a UNIX socket, which access is restricted by the file permissions.
+func hasTCPAddress(listenAddress string) bool {
+	for _, addr := range strings.Split(listenAddress, ",") {
+		if addr = strings.TrimSpace(addr); addr != "" && !strings.HasPrefix(addr, "unix://") {
+			return true
+		}
+	}
+	return false
+}
+
 // IsCorsEnabled returns true if cross-origin resource sharing is enabled.
 // XXX review.
 func (cfg *RPCConfig) IsCorsEnabled() bool {
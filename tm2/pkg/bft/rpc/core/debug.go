@@ -0,0 +1,105 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+)
+
+// DebugTraceTx re-executes a committed transaction, on the state of the
+// previous block, and returns its trace: the calls it made, with the gas
+// they used, and its operations on the stores of the application. The
+// transactions before it in its block are executed first; the state of the
+// node is left unchanged.
+//
+// It is only served when enabled with the rpc.trace_txs config option, as
+// re-executing transactions is expensive. Tracing transactions requires the
+// application to keep the state of the previous height, so it fails for the
+// heights pruned by the application. The result of the original execution is
+// returned along with the trace, as the application may not keep all of its
+// state versioned, so that the re-execution can differ.
+//
+// ```shell
+// curl 'localhost:26657/debug_trace_tx?hash=0x...'
+// ```
+//
+// ### Query Parameters
+//
+// | Parameter | Type   | Default | Required | Description                  |
+// |-----------+--------+---------+----------+------------------------------|
+// | hash      | []byte | nil     | true     | The hash of the transaction  |
+func DebugTraceTx(_ *rpctypes.Context, hash []byte) (*ctypes.ResultTraceTx, error) {
+	resultIndex, err := sm.LoadTxResultIndex(stateDB, hash)
+	if err != nil {
+		return nil, err
+	}
+
+	height, err := getHeight(blockStore.Height(), &resultIndex.BlockNum)
+	if err != nil {
+		return nil, err
+	}
+
+	block := blockStore.LoadBlock(height)
+	if block == nil || int(resultIndex.TxIndex) >= len(block.Txs) {
+		return nil, fmt.Errorf(
+			"unable to get block transaction for block %d, index %d",
+			resultIndex.BlockNum,
+			resultIndex.TxIndex,
+		)
+	}
+
+	blockResults, err := sm.LoadABCIResponses(stateDB, height)
+	if err != nil {
+		return nil, fmt.Errorf("unable to load block results, %w", err)
+	}
+	if int(resultIndex.TxIndex) >= len(blockResults.DeliverTxs) {
+		return nil, fmt.Errorf(
+			"unable to get deliver result for block %d, index %d",
+			resultIndex.BlockNum,
+			resultIndex.TxIndex,
+		)
+	}
+
+	txs := make([][]byte, resultIndex.TxIndex+1)
+	for i := range txs {
+		txs[i] = block.Txs[i]
+	}
+	data, err := amino.Marshal(abci.TraceTxRequest{
+		Header: &block.Header,
+		Txs:    txs,
+	})
+	if err != nil {
+		return nil, fmt.Errorf("unable to encode trace request, %w", err)
+	}
+
+	resQuery, err := proxyAppQuery.QuerySync(abci.RequestQuery{
+		Path:   ".app/trace",
+		Data:   data,
+		Height: height - 1,
+	})
+	if err != nil {
+		return nil, err
+	}
+	if resQuery.Error != nil {
+		return nil, errors.New(resQuery.Error.Error())
+	}
+
+	var trace abci.TxTrace
+	if err := amino.Unmarshal(resQuery.Value, &trace); err != nil {
+		return nil, fmt.Errorf("unable to decode trace, %w", err)
+	}
+
+	return &ctypes.ResultTraceTx{
+		Hash:     hash,
+		Height:   height,
+		Index:    resultIndex.TxIndex,
+		TxResult: blockResults.DeliverTxs[resultIndex.TxIndex],
+		Tx:       block.Txs[resultIndex.TxIndex],
+		Trace:    trace,
+	}, nil
+}
@@ -1,6 +1,7 @@
 package core
 
 import (
+	"errors"
 	"os"
 	"runtime/pprof"
 
@@ -16,6 +17,41 @@ func UnsafeFlushMempool(ctx *rpctypes.Context) (*ctypes.ResultUnsafeFlushMempool
 
 var profFile *os.File
 
+// ReloadConfig reloads the config file of the node, applying the settings
+// which can be changed without restarting it: the log level, the RPC limits,
+// the mempool caps and the persistent peers. The reload is rejected if the
+// file changes any other setting, like the consensus ones.
+//
+// ```shell
+// curl -H 'Authorization: Bearer <token>' 'localhost:26659/reload_config'
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+//
+//	{
+//	  "jsonrpc": "2.0",
+//	  "id": "",
+//	  "result": {
+//	    "changed": ["log_level", "mempool.size"]
+//	  }
+//	}
+//
+// ```
+func ReloadConfig(_ *rpctypes.Context) (*ctypes.ResultReloadConfig, error) {
+	if reloadConfig == nil {
+		return nil, errors.New("config reloading is not supported")
+	}
+
+	changed, err := reloadConfig()
+	if err != nil {
+		return nil, err
+	}
+
+	return &ctypes.ResultReloadConfig{Changed: changed}, nil
+}
+
 // UnsafeStartCPUProfiler starts a pprof profiler using the given filename.
 func UnsafeStartCPUProfiler(ctx *rpctypes.Context, filename string) (*ctypes.ResultUnsafeProfile, error) {
 	var err error
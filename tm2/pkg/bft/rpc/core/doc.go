@@ -83,13 +83,12 @@ Available endpoints:
 /abci_info
 /dump_consensus_state
 /genesis
+/metadata
 /net_info
 /num_unconfirmed_txs
 /status
 /health
 /unconfirmed_txs
-/unsafe_flush_mempool
-/unsafe_stop_cpu_profiler
 /validators
 
 Endpoints that require arguments:
@@ -100,13 +99,17 @@ Endpoints that require arguments:
 /broadcast_tx_commit?tx=_
 /broadcast_tx_sync?tx=_
 /commit?height=_
-/dial_seeds?seeds=_
-/dial_persistent_peers?persistent_peers=_
 /tx?hash=_&prove=_
-/unsafe_start_cpu_profiler?filename=_
-/unsafe_write_heap_profile?filename=_
 ```
 
+The operator endpoints (`/dial_peers`, `/peer_reputations`, `/reload_config`,
+`/unsafe_flush_mempool` and the profiler endpoints) are only served by the operator RPC server, enabled with
+`rpc.operator_laddr`. Requests to it must carry the configured
+`rpc.operator_auth_token` in their `Authorization: Bearer <token>` header.
+
+The debug endpoints (`/debug_trace_tx?hash=_`), which re-execute committed
+transactions, are only served when enabled with `rpc.trace_txs`.
+
 # Endpoints
 */
 package core
@@ -0,0 +1,91 @@
+package core
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
+	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+)
+
+// Get the capabilities and versions of the node and of its application, so
+// that clients can adapt to the chain without trial and error: the version of
+// the node, the features it serves, the consensus params, and the metadata of
+// the application, like the messages it accepts, the versions and params of
+// its modules, and whether it supports simulating transactions.
+//
+// The features of the node are "tx_index" when the transactions are indexed,
+// and "debug_trace_tx" when the debug routes are served.
+//
+// ```shell
+// curl 'localhost:26657/metadata'
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+//
+//	{
+//	  "jsonrpc": "2.0",
+//	  "id": "",
+//	  "result": {
+//	    "network": "dev",
+//	    "software": "tm2",
+//	    "version": "develop",
+//	    "version_set": [...],
+//	    "features": ["tx_index"],
+//	    "consensus_params": {...},
+//	    "app": {
+//	      "Name": "gnoland",
+//	      "Version": "dev",
+//	      "Features": ["events", "simulate", "trace"],
+//	      "MsgTypes": [{"Route": "vm", "Type": "exec", "TypeURL": "/vm.m_call"}, ...],
+//	      "Modules": [{"Name": "vm", "Version": "develop", "Hash": "...", "Params": "{...}"}, ...]
+//	    }
+//	  }
+//	}
+//
+// ```
+func Metadata(_ *rpctypes.Context) (*ctypes.ResultMetadata, error) {
+	nodeInfo := p2pTransport.NodeInfo()
+
+	var features []string
+	if nodeInfo.Other.TxIndex == "on" {
+		features = append(features, "tx_index")
+	}
+	if config.TraceTxs {
+		features = append(features, "debug_trace_tx")
+	}
+
+	height := consensusState.GetState().LastBlockHeight + 1
+	consensusParams, err := sm.LoadConsensusParams(stateDB, height)
+	if err != nil {
+		return nil, err
+	}
+
+	resQuery, err := proxyAppQuery.QuerySync(abci.RequestQuery{Path: ".app/metadata"})
+	if err != nil {
+		return nil, err
+	}
+	if resQuery.Error != nil {
+		return nil, errors.New(resQuery.Error.Error())
+	}
+
+	var app abci.AppMetadata
+	if err := amino.UnmarshalJSON(resQuery.Value, &app); err != nil {
+		return nil, fmt.Errorf("unable to decode app metadata, %w", err)
+	}
+
+	return &ctypes.ResultMetadata{
+		Network:         nodeInfo.Network,
+		Software:        nodeInfo.Software,
+		Version:         nodeInfo.Version,
+		VersionSet:      nodeInfo.VersionSet,
+		Features:        features,
+		ConsensusParams: consensusParams,
+		App:             app,
+	}, nil
+}
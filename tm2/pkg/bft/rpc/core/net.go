@@ -1,8 +1,12 @@
 package core
 
 import (
+	"errors"
+	"fmt"
+
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	rpctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
+	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
 )
 
 // Get network info.
@@ -178,6 +182,82 @@ func NetInfo(_ *rpctypes.Context) (*ctypes.ResultNetInfo, error) {
 	}, nil
 }
 
+// Dial the given peers, in the background.
+// Only available on the operator RPC server.
+//
+// ```shell
+//
+//	curl -H 'Authorization: Bearer <operator token>' \
+//		'localhost:26659/dial_peers?peers=["g1...@1.2.3.4:26656"]'
+//
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+//
+//	{
+//	  "jsonrpc": "2.0",
+//	  "id": "",
+//	  "result": {
+//	    "log": "Dialing peers in progress. See /net_info for details"
+//	  }
+//	}
+//
+// ```
+func DialPeers(_ *rpctypes.Context, peers []string) (*ctypes.ResultDialPeers, error) {
+	if len(peers) == 0 {
+		return &ctypes.ResultDialPeers{}, errors.New("no peers provided")
+	}
+
+	addrs, errs := p2pTypes.NewNetAddressFromStrings(peers)
+	if len(errs) != 0 {
+		return &ctypes.ResultDialPeers{}, fmt.Errorf("invalid peer addresses, %w", errors.Join(errs...))
+	}
+
+	p2pPeers.DialPeers(addrs...)
+
+	return &ctypes.ResultDialPeers{Log: "Dialing peers in progress. See /net_info for details"}, nil
+}
+
+// Get the reputation of the peers that misbehaved: sent malformed messages
+// or invalid blocks, or disconnected on error. Peers are banned for a while
+// when their score drops to 0, and recover their score over time.
+// Only available on the operator RPC server.
+//
+// ```shell
+//
+//	curl -H 'Authorization: Bearer <operator token>' 'localhost:26659/peer_reputations'
+//
+// ```
+//
+// > The above command returns JSON structured like this:
+//
+// ```json
+//
+//	{
+//	  "jsonrpc": "2.0",
+//	  "id": "",
+//	  "result": {
+//	    "peers": [
+//	      {
+//	        "id": "g1m6kzw5yl3nyxx8dcyfncl2ltp8g9cs0gz3zg9l",
+//	        "score": "30",
+//	        "invalid_blocks": "1",
+//	        "malformed_messages": "1",
+//	        "disconnects": "0",
+//	        "bans": "0",
+//	        "banned_until": "0001-01-01T00:00:00Z"
+//	      }
+//	    ]
+//	  }
+//	}
+//
+// ```
+func PeerReputations(_ *rpctypes.Context) (*ctypes.ResultPeerReputations, error) {
+	return &ctypes.ResultPeerReputations{Peers: p2pPeers.PeerReputations()}, nil
+}
+
 // Get genesis file.
 //
 // ```shell
@@ -44,6 +44,8 @@ type transport interface {
 
 type peers interface {
 	Peers() p2p.PeerSet
+	DialPeers(peerAddrs ...*p2pTypes.NetAddress)
+	PeerReputations() []p2p.PeerReputation
 }
 
 // ----------------------------------------------
@@ -68,6 +70,7 @@ var (
 	gTxDispatcher *txDispatcher
 	mempool       mempl.Mempool
 	getFastSync   func() bool // avoids dependency on consensus pkg
+	reloadConfig  func() ([]string, error)
 
 	logger *slog.Logger
 
@@ -114,6 +117,12 @@ func SetGetFastSync(v func() bool) {
 	getFastSync = v
 }
 
+// SetConfigReloader sets the function reloading the node config, returning
+// the keys of the changed settings.
+func SetConfigReloader(fn func() ([]string, error)) {
+	reloadConfig = fn
+}
+
 func SetLogger(l *slog.Logger) {
 	logger = l
 }
@@ -1,10 +1,13 @@
 package core
 
 import (
+	"maps"
+
 	rpc "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/server"
 )
 
-// TODO: better system than "unsafe" prefix
+// Routes are the routes of the public RPC server: queries, and transaction
+// broadcasting.
 // NOTE: Amino is registered in rpc/core/types/codec.go.
 var Routes = map[string]*rpc.RPCFunc{
 	// info API
@@ -21,6 +24,7 @@ var Routes = map[string]*rpc.RPCFunc{
 	"dump_consensus_state": rpc.NewRPCFunc(DumpConsensusState, ""),
 	"consensus_state":      rpc.NewRPCFunc(ConsensusState, ""),
 	"consensus_params":     rpc.NewRPCFunc(ConsensusParams, "height"),
+	"metadata":             rpc.NewRPCFunc(Metadata, ""),
 	"unconfirmed_txs":      rpc.NewRPCFunc(UnconfirmedTxs, "limit"),
 	"num_unconfirmed_txs":  rpc.NewRPCFunc(NumUnconfirmedTxs, ""),
 
@@ -34,12 +38,43 @@ var Routes = map[string]*rpc.RPCFunc{
 	"abci_info":  rpc.NewRPCFunc(ABCIInfo, ""),
 }
 
-func AddUnsafeRoutes() {
+// DebugRoutes are the routes re-executing transactions, which are expensive;
+// they are served by the public and operator RPC servers only when enabled
+// in the RPC config.
+var DebugRoutes = map[string]*rpc.RPCFunc{
+	"debug_trace_tx": rpc.NewRPCFunc(DebugTraceTx, "hash"),
+}
+
+// OperatorRoutes are the routes controlling the node, served only by the
+// operator RPC server, along with the public routes.
+var OperatorRoutes = map[string]*rpc.RPCFunc{
 	// control API
-	Routes["unsafe_flush_mempool"] = rpc.NewRPCFunc(UnsafeFlushMempool, "")
+	"dial_peers":           rpc.NewRPCFunc(DialPeers, "peers"),
+	"peer_reputations":     rpc.NewRPCFunc(PeerReputations, ""),
+	"reload_config":        rpc.NewRPCFunc(ReloadConfig, ""),
+	"unsafe_flush_mempool": rpc.NewRPCFunc(UnsafeFlushMempool, ""),
 
 	// profiler API
-	Routes["unsafe_start_cpu_profiler"] = rpc.NewRPCFunc(UnsafeStartCPUProfiler, "filename")
-	Routes["unsafe_stop_cpu_profiler"] = rpc.NewRPCFunc(UnsafeStopCPUProfiler, "")
-	Routes["unsafe_write_heap_profile"] = rpc.NewRPCFunc(UnsafeWriteHeapProfile, "filename")
+	"unsafe_start_cpu_profiler": rpc.NewRPCFunc(UnsafeStartCPUProfiler, "filename"),
+	"unsafe_stop_cpu_profiler":  rpc.NewRPCFunc(UnsafeStopCPUProfiler, ""),
+	"unsafe_write_heap_profile": rpc.NewRPCFunc(UnsafeWriteHeapProfile, "filename"),
+}
+
+// AllRoutes returns the routes of the operator RPC server, which are the
+// public routes along with the operator ones.
+func AllRoutes() map[string]*rpc.RPCFunc {
+	routes := make(map[string]*rpc.RPCFunc, len(Routes)+len(OperatorRoutes))
+	maps.Copy(routes, Routes)
+	maps.Copy(routes, OperatorRoutes)
+
+	return routes
+}
+
+// WithDebugRoutes returns the given routes along with the debug ones.
+func WithDebugRoutes(routes map[string]*rpc.RPCFunc) map[string]*rpc.RPCFunc {
+	all := make(map[string]*rpc.RPCFunc, len(routes)+len(DebugRoutes))
+	maps.Copy(all, routes)
+	maps.Copy(all, DebugRoutes)
+
+	return all
 }
@@ -12,6 +12,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/p2p"
 	p2pTypes "github.com/gnolang/gno/tm2/pkg/p2p/types"
+	"github.com/gnolang/gno/tm2/pkg/versionset"
 )
 
 // List of blocks
@@ -88,6 +89,17 @@ func (s *ResultStatus) TxIndexEnabled() bool {
 	return s.NodeInfo.Other.TxIndex == "on"
 }
 
+// Capabilities and versions of the node and its application
+type ResultMetadata struct {
+	Network         string                `json:"network"`
+	Software        string                `json:"software"`
+	Version         string                `json:"version"`
+	VersionSet      versionset.VersionSet `json:"version_set"`
+	Features        []string              `json:"features"` // of the node, like "tx_index"
+	ConsensusParams abci.ConsensusParams  `json:"consensus_params"`
+	App             abci.AppMetadata      `json:"app"`
+}
+
 // Info about peer connections
 type ResultNetInfo struct {
 	Listening bool     `json:"listening"`
@@ -106,6 +118,16 @@ type ResultDialPeers struct {
 	Log string `json:"log"`
 }
 
+// Reputation of the peers that misbehaved
+type ResultPeerReputations struct {
+	Peers []p2p.PeerReputation `json:"peers"`
+}
+
+// Settings changed by reloading the node config
+type ResultReloadConfig struct {
+	Changed []string `json:"changed"`
+}
+
 // A peer
 type Peer struct {
 	NodeInfo         p2pTypes.NodeInfo    `json:"node_info"`
@@ -171,6 +193,16 @@ type ResultTx struct {
 	Proof    types.TxProof          `json:"proof,omitempty"`
 }
 
+// Trace of the re-execution of a committed tx
+type ResultTraceTx struct {
+	Hash     []byte                 `json:"hash"`
+	Height   int64                  `json:"height"`
+	Index    uint32                 `json:"index"`
+	TxResult abci.ResponseDeliverTx `json:"tx_result"` // of the original execution
+	Tx       types.Tx               `json:"tx"`
+	Trace    abci.TxTrace           `json:"trace"`
+}
+
 // Result of searching for txs
 type ResultTxSearch struct {
 	Txs        []*ResultTx `json:"txs"`
@@ -21,6 +21,10 @@ const (
 
 	portHTTP  = "80"
 	portHTTPS = "443"
+
+	// maxDrainBytes is the maximum size of the body of an error response
+	// read to reuse its connection
+	maxDrainBytes = 64 << 10
 )
 
 var (
@@ -32,11 +36,15 @@ var (
 type Client struct {
 	rpcURL string // the remote RPC URL of the node
 
-	client *http.Client
+	poolConfig poolConfig
+	pool       *pool
 }
 
-// NewClient initializes and creates a new HTTP RPC client
-func NewClient(rpcURL string) (*Client, error) {
+// NewClient initializes and creates a new HTTP RPC client.
+// The clients of the same node share a pool of keep-alive connections
+// (see WithMaxIdleConns and WithIdleConnTimeout), so they can be created
+// per request without opening as many connections
+func NewClient(rpcURL string, opts ...Option) (*Client, error) {
 	// Parse the RPC URL
 	address, err := toClientAddress(rpcURL)
 	if err != nil {
@@ -45,16 +53,31 @@ func NewClient(rpcURL string) (*Client, error) {
 
 	c := &Client{
 		rpcURL: address,
-		client: defaultHTTPClient(rpcURL),
+		poolConfig: poolConfig{
+			maxIdleConns:    DefaultMaxIdleConns,
+			idleConnTimeout: DefaultIdleConnTimeout,
+		},
+	}
+
+	for _, opt := range opts {
+		opt(c)
 	}
 
+	c.pool = getPool(rpcURL, c.poolConfig)
+
 	return c, nil
 }
 
+// Stats returns the stats of the connection pool of the client, shared
+// with the other clients of the node
+func (c *Client) Stats() PoolStats {
+	return c.pool.stats()
+}
+
 // SendRequest sends a single RPC request to the server
 func (c *Client) SendRequest(ctx context.Context, request types.RPCRequest) (*types.RPCResponse, error) {
 	// Send the request
-	response, err := sendRequestCommon[types.RPCRequest, *types.RPCResponse](ctx, c.client, c.rpcURL, request)
+	response, err := sendRequestCommon[types.RPCRequest, *types.RPCResponse](ctx, c.pool, c.rpcURL, request)
 	if err != nil {
 		return nil, err
 	}
@@ -70,7 +93,7 @@ func (c *Client) SendRequest(ctx context.Context, request types.RPCRequest) (*ty
 // SendBatch sends a single RPC batch request to the server
 func (c *Client) SendBatch(ctx context.Context, requests types.RPCRequests) (types.RPCResponses, error) {
 	// Send the batch
-	responses, err := sendRequestCommon[types.RPCRequests, types.RPCResponses](ctx, c.client, c.rpcURL, requests)
+	responses, err := sendRequestCommon[types.RPCRequests, types.RPCResponses](ctx, c.pool, c.rpcURL, requests)
 	if err != nil {
 		return nil, err
 	}
@@ -90,7 +113,7 @@ func (c *Client) SendBatch(ctx context.Context, requests types.RPCRequests) (typ
 	return responses, nil
 }
 
-// Close has no effect on an HTTP client
+// Close has no effect on an HTTP client, as its connections are shared
 func (c *Client) Close() error {
 	return nil
 }
@@ -105,8 +128,26 @@ type (
 	}
 )
 
-// sendRequestCommon executes the common request sending
+// sendRequestCommon executes the common request sending, metering it
 func sendRequestCommon[T requestType, R responseType](
+	ctx context.Context,
+	p *pool,
+	rpcURL string,
+	request T,
+) (R, error) {
+	p.requests.Add(1)
+	p.inFlight.Add(1)
+	defer p.inFlight.Add(-1)
+
+	response, err := doRequest[T, R](ctx, p.client, rpcURL, request)
+	if err != nil {
+		p.failures.Add(1)
+	}
+
+	return response, err
+}
+
+func doRequest[T requestType, R responseType](
 	ctx context.Context,
 	client *http.Client,
 	rpcURL string,
@@ -140,6 +181,9 @@ func sendRequestCommon[T requestType, R responseType](
 
 	// Parse the response code
 	if !isOKStatus(httpResponse.StatusCode) {
+		// Drain the body, so that the connection can be reused
+		io.Copy(io.Discard, io.LimitReader(httpResponse.Body, maxDrainBytes)) //nolint: errcheck
+
 		return nil, fmt.Errorf("invalid status code received, %d", httpResponse.StatusCode)
 	}
 
@@ -158,22 +202,8 @@ func sendRequestCommon[T requestType, R responseType](
 	return response, nil
 }
 
-// DefaultHTTPClient is used to create an http client with some default parameters.
-// We overwrite the http.Client.Dial so we can do http over tcp or unix.
-// remoteAddr should be fully featured (eg. with tcp:// or unix://)
-func defaultHTTPClient(remoteAddr string) *http.Client {
-	return &http.Client{
-		Transport: &http.Transport{
-			// Set to true to prevent GZIP-bomb DoS attacks
-			DisableCompression: true,
-			DialContext: func(_ context.Context, _, _ string) (net.Conn, error) {
-				return makeHTTPDialer(remoteAddr)
-			},
-		},
-	}
-}
-
-func makeHTTPDialer(remoteAddr string) (net.Conn, error) {
+// makeHTTPDialer dials the node at remoteAddr
+func makeHTTPDialer(ctx context.Context, dialer *net.Dialer, remoteAddr string) (net.Conn, error) {
 	protocol, address := parseRemoteAddr(remoteAddr)
 
 	// net.Dial doesn't understand http/https, so change it to TCP
@@ -182,7 +212,7 @@ func makeHTTPDialer(remoteAddr string) (net.Conn, error) {
 		protocol = protoTCP
 	}
 
-	return net.Dial(protocol, address)
+	return dialer.DialContext(ctx, protocol, address)
 }
 
 // protocol - client's protocol (for example, "http", "https", "wss", "ws", "tcp")
@@ -3,6 +3,7 @@ package http
 import (
 	"context"
 	"encoding/json"
+	"net"
 	"net/http"
 	"net/http/httptest"
 	"testing"
@@ -76,7 +77,7 @@ func TestClient_makeHTTPDialer(t *testing.T) {
 	t.Run("http", func(t *testing.T) {
 		t.Parallel()
 
-		_, err := makeHTTPDialer("https://.")
+		_, err := makeHTTPDialer(context.Background(), &net.Dialer{}, "https://.")
 		require.Error(t, err)
 
 		assert.Contains(t, err.Error(), "dial tcp:", "should convert https to tcp")
@@ -85,7 +86,7 @@ func TestClient_makeHTTPDialer(t *testing.T) {
 	t.Run("udp", func(t *testing.T) {
 		t.Parallel()
 
-		_, err := makeHTTPDialer("udp://.")
+		_, err := makeHTTPDialer(context.Background(), &net.Dialer{}, "udp://.")
 		require.Error(t, err)
 
 		assert.Contains(t, err.Error(), "dial udp:", "udp protocol should remain the same")
@@ -274,3 +275,72 @@ func TestClient_SendBatchRequest(t *testing.T) {
 		assert.Nil(t, resp.Error)
 	}
 }
+
+func TestClient_ConnectionPool(t *testing.T) {
+	t.Parallel()
+
+	var (
+		handler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			var req types.RPCRequest
+			require.NoError(t, json.NewDecoder(r.Body).Decode(&req))
+
+			if req.Method == "fail" {
+				http.Error(w, "failed", http.StatusInternalServerError)
+
+				return
+			}
+
+			marshalledResponse, err := json.Marshal(types.RPCResponse{
+				JSONRPC: "2.0",
+				ID:      req.ID,
+			})
+			require.NoError(t, err)
+
+			_, err = w.Write(marshalledResponse)
+			require.NoError(t, err)
+		})
+
+		server = createTestServer(t, handler)
+	)
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelFn()
+
+	// Send the requests sequentially, each with a new client
+	var c *Client
+	for i := range 10 {
+		var err error
+
+		c, err = NewClient(server.URL)
+		require.NoError(t, err)
+
+		method := "ok"
+		if i%2 == 0 {
+			method = "fail" // errors don't prevent reusing the connection
+		}
+
+		_, err = c.SendRequest(ctx, types.RPCRequest{
+			JSONRPC: "2.0",
+			ID:      types.JSONRPCIntID(i),
+			Method:  method,
+		})
+		if method == "fail" {
+			require.Error(t, err)
+		} else {
+			require.NoError(t, err)
+		}
+	}
+
+	// The clients share a single connection
+	stats := c.Stats()
+	assert.Equal(t, uint64(10), stats.Requests)
+	assert.Equal(t, uint64(5), stats.Failures)
+	assert.Equal(t, int64(0), stats.InFlight)
+	assert.Equal(t, uint64(1), stats.Dials)
+	assert.Equal(t, int64(1), stats.OpenConns)
+
+	// A client with another configuration has its own pool
+	other, err := NewClient(server.URL, WithMaxIdleConns(1), WithIdleConnTimeout(time.Second))
+	require.NoError(t, err)
+	assert.Equal(t, PoolStats{}, other.Stats())
+}
@@ -0,0 +1,21 @@
+package http
+
+import "time"
+
+type Option func(*Client)
+
+// WithMaxIdleConns sets the maximum number of idle connections kept open to
+// the node, for the requests to reuse them. Zero means no limit
+func WithMaxIdleConns(n int) Option {
+	return func(c *Client) {
+		c.poolConfig.maxIdleConns = max(n, 0)
+	}
+}
+
+// WithIdleConnTimeout sets the time an idle connection is kept open before
+// being closed. Zero means no limit
+func WithIdleConnTimeout(timeout time.Duration) Option {
+	return func(c *Client) {
+		c.poolConfig.idleConnTimeout = max(timeout, 0)
+	}
+}
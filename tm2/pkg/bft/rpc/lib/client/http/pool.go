@@ -0,0 +1,143 @@
+package http
+
+import (
+	"context"
+	"net"
+	"net/http"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+const (
+	// DefaultMaxIdleConns is the default maximum number of idle connections
+	// kept open to a node
+	DefaultMaxIdleConns = 64
+
+	// DefaultIdleConnTimeout is the default time an idle connection is kept
+	// open before being closed
+	DefaultIdleConnTimeout = 90 * time.Second
+
+	dialTimeout         = 30 * time.Second
+	tlsHandshakeTimeout = 10 * time.Second
+)
+
+// PoolStats are the stats of the connection pool of the clients of a node
+type PoolStats struct {
+	Requests  uint64 `json:"requests"`   // requests sent
+	Failures  uint64 `json:"failures"`   // requests that failed
+	InFlight  int64  `json:"in_flight"`  // requests awaiting a response
+	Dials     uint64 `json:"dials"`      // connections opened
+	OpenConns int64  `json:"open_conns"` // connections currently open, idle or not
+}
+
+// poolConfig is the configuration of a connection pool
+type poolConfig struct {
+	maxIdleConns    int
+	idleConnTimeout time.Duration
+}
+
+// poolKey identifies the pool shared by the clients of a node
+type poolKey struct {
+	remoteAddr string
+	config     poolConfig
+}
+
+var (
+	poolsMu sync.Mutex
+	pools   = map[poolKey]*pool{}
+)
+
+// pool is a pool of keep-alive connections to a node, shared by all the
+// clients created for it with the same configuration, so that creating a
+// client doesn't open new connections. It meters the requests sent through it
+type pool struct {
+	client *http.Client
+
+	requests  atomic.Uint64
+	failures  atomic.Uint64
+	inFlight  atomic.Int64
+	dials     atomic.Uint64
+	openConns atomic.Int64
+}
+
+// getPool returns the pool of the node at remoteAddr with the given
+// configuration, creating it if needed
+func getPool(remoteAddr string, cfg poolConfig) *pool {
+	poolsMu.Lock()
+	defer poolsMu.Unlock()
+
+	key := poolKey{remoteAddr: remoteAddr, config: cfg}
+	if p, ok := pools[key]; ok {
+		return p
+	}
+
+	p := newPool(remoteAddr, cfg)
+	pools[key] = p
+
+	return p
+}
+
+// newPool creates a connection pool to the node at remoteAddr.
+// remoteAddr should be fully featured (eg. with tcp:// or unix://), as
+// the dialer always connects to it, so that http can be done over tcp or unix
+func newPool(remoteAddr string, cfg poolConfig) *pool {
+	p := &pool{}
+
+	dialer := &net.Dialer{
+		Timeout:   dialTimeout,
+		KeepAlive: 30 * time.Second,
+	}
+
+	p.client = &http.Client{
+		Transport: &http.Transport{
+			// Set to true to prevent GZIP-bomb DoS attacks
+			DisableCompression: true,
+			DialContext: func(ctx context.Context, _, _ string) (net.Conn, error) {
+				conn, err := makeHTTPDialer(ctx, dialer, remoteAddr)
+				if err != nil {
+					return nil, err
+				}
+
+				p.dials.Add(1)
+				p.openConns.Add(1)
+
+				return &meteredConn{Conn: conn, pool: p}, nil
+			},
+			// All the connections are to the same node
+			MaxIdleConns:        cfg.maxIdleConns,
+			MaxIdleConnsPerHost: cfg.maxIdleConns,
+			IdleConnTimeout:     cfg.idleConnTimeout,
+			TLSHandshakeTimeout: tlsHandshakeTimeout,
+		},
+	}
+
+	return p
+}
+
+// stats returns the stats of the pool
+func (p *pool) stats() PoolStats {
+	return PoolStats{
+		Requests:  p.requests.Load(),
+		Failures:  p.failures.Load(),
+		InFlight:  p.inFlight.Load(),
+		Dials:     p.dials.Load(),
+		OpenConns: p.openConns.Load(),
+	}
+}
+
+// meteredConn is a pool connection, counted until it's closed
+type meteredConn struct {
+	net.Conn
+
+	pool      *pool
+	closeOnce sync.Once
+}
+
+func (c *meteredConn) Close() error {
+	c.closeOnce.Do(func() {
+		c.pool.openConns.Add(-1)
+	})
+
+	return c.Conn.Close()
+}
@@ -7,6 +7,7 @@ import (
 	"hash/fnv"
 	"log/slog"
 	"sync"
+	"time"
 
 	types "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
 	"github.com/gnolang/gno/tm2/pkg/errors"
@@ -18,21 +19,41 @@ var (
 	ErrTimedOut                  = errors.New("context timed out")
 	ErrRequestResponseIDMismatch = errors.New("ws request / response ID mismatch")
 	ErrInvalidBatchResponse      = errors.New("invalid ws batch response size")
+	ErrConnectionLost            = errors.New("connection lost before the response")
 )
 
 type responseCh chan<- types.RPCResponses
 
+// pendingRequest is a request (or batch) awaiting its response
+type pendingRequest struct {
+	ch    responseCh
+	errCh chan<- error
+	item  any // the request (or batch), replayed on reconnect
+
+	// conn is the connection the request was written to,
+	// nil while it waits in the backlog
+	conn *websocket.Conn
+}
+
 // Client is a WebSocket client implementation
 type Client struct {
 	ctx           context.Context
 	cancelCauseFn context.CancelCauseFunc
 
-	conn *websocket.Conn
+	rpcURL  string
+	conn    *websocket.Conn
+	connMux sync.RWMutex
+
+	// reconnect parameters, disabled if maxBackoff is 0
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+	onReconnect func()
+	canReplay   func(method string) bool
 
 	logger  *slog.Logger
 	backlog chan any // Either a single RPC request, or a batch of RPC requests
 
-	requestMap    map[string]responseCh
+	requestMap    map[string]pendingRequest
 	requestMapMux sync.Mutex
 }
 
@@ -45,8 +66,9 @@ func NewClient(rpcURL string, opts ...Option) (*Client, error) {
 	}
 
 	c := &Client{
+		rpcURL:     rpcURL,
 		conn:       conn,
-		requestMap: make(map[string]responseCh),
+		requestMap: make(map[string]pendingRequest),
 		backlog:    make(chan any, 1),
 		logger:     log.NewNoopLogger(),
 	}
@@ -68,16 +90,19 @@ func NewClient(rpcURL string, opts ...Option) (*Client, error) {
 
 // SendRequest sends a single RPC request to the server
 func (c *Client) SendRequest(ctx context.Context, request types.RPCRequest) (*types.RPCResponse, error) {
-	// Create the response channel for the pipeline
+	// Create the response channels for the pipeline
 	responseCh := make(chan types.RPCResponses, 1)
+	errCh := make(chan error, 1)
 
 	// Generate a unique request ID hash
 	requestHash := generateIDHash(request.ID.String())
 
 	c.requestMapMux.Lock()
-	c.requestMap[requestHash] = responseCh
+	c.requestMap[requestHash] = pendingRequest{ch: responseCh, errCh: errCh, item: request}
 	c.requestMapMux.Unlock()
 
+	defer c.clearRequest(requestHash)
+
 	// Pipe the request to the backlog
 	select {
 	case <-ctx.Done():
@@ -93,6 +118,8 @@ func (c *Client) SendRequest(ctx context.Context, request types.RPCRequest) (*ty
 		return nil, ErrTimedOut
 	case <-c.ctx.Done():
 		return nil, context.Cause(c.ctx)
+	case err := <-errCh:
+		return nil, err
 	case response := <-responseCh:
 		// Make sure the ID matches
 		if response[0].ID != request.ID {
@@ -105,8 +132,9 @@ func (c *Client) SendRequest(ctx context.Context, request types.RPCRequest) (*ty
 
 // SendBatch sends a batch of RPC requests to the server
 func (c *Client) SendBatch(ctx context.Context, requests types.RPCRequests) (types.RPCResponses, error) {
-	// Create the response channel for the pipeline
+	// Create the response channels for the pipeline
 	responseCh := make(chan types.RPCResponses, 1)
+	errCh := make(chan error, 1)
 
 	// Generate a unique request ID hash
 	requestIDs := make([]string, 0, len(requests))
@@ -118,9 +146,11 @@ func (c *Client) SendBatch(ctx context.Context, requests types.RPCRequests) (typ
 	requestHash := generateIDHash(requestIDs...)
 
 	c.requestMapMux.Lock()
-	c.requestMap[requestHash] = responseCh
+	c.requestMap[requestHash] = pendingRequest{ch: responseCh, errCh: errCh, item: requests}
 	c.requestMapMux.Unlock()
 
+	defer c.clearRequest(requestHash)
+
 	// Pipe the request to the backlog
 	select {
 	case <-ctx.Done():
@@ -136,6 +166,8 @@ func (c *Client) SendBatch(ctx context.Context, requests types.RPCRequests) (typ
 		return nil, ErrTimedOut
 	case <-c.ctx.Done():
 		return nil, context.Cause(c.ctx)
+	case err := <-errCh:
+		return nil, err
 	case responses := <-responseCh:
 		// Make sure the length matches
 		if len(responses) != len(requests) {
@@ -153,6 +185,56 @@ func (c *Client) SendBatch(ctx context.Context, requests types.RPCRequests) (typ
 	}
 }
 
+// clearRequest removes the pending request with the given hash, if any,
+// so it is not replayed once the caller stopped waiting for it
+func (c *Client) clearRequest(requestHash string) {
+	c.requestMapMux.Lock()
+	defer c.requestMapMux.Unlock()
+
+	delete(c.requestMap, requestHash)
+}
+
+// itemHash returns the hash of the request (or batch) IDs
+func itemHash(item any) string {
+	switch item := item.(type) {
+	case types.RPCRequest:
+		return generateIDHash(item.ID.String())
+	case types.RPCRequests:
+		ids := make([]string, 0, len(item))
+
+		for _, request := range item {
+			ids = append(ids, request.ID.String())
+		}
+
+		return generateIDHash(ids...)
+	default:
+		return ""
+	}
+}
+
+// itemReplayable returns true if all the methods of the request (or batch)
+// are safe to send again
+func (c *Client) itemReplayable(item any) bool {
+	if c.canReplay == nil {
+		return false
+	}
+
+	switch item := item.(type) {
+	case types.RPCRequest:
+		return c.canReplay(item.Method)
+	case types.RPCRequests:
+		for _, request := range item {
+			if !c.canReplay(request.Method) {
+				return false
+			}
+		}
+
+		return true
+	default:
+		return false
+	}
+}
+
 // generateIDHash generates a unique hash from the given IDs
 func generateIDHash(ids ...string) string {
 	hash := fnv.New128()
@@ -174,7 +256,12 @@ func (c *Client) runWriteRoutine(ctx context.Context) {
 			return
 		case item := <-c.backlog:
 			// Write the JSON request to the server
-			if err := c.conn.WriteJSON(item); err != nil {
+			conn := c.getConn()
+			err := conn.WriteJSON(item)
+
+			c.markSent(item, conn)
+
+			if err != nil {
 				c.logger.Error("unable to send request", "err", err)
 
 				continue
@@ -197,8 +284,22 @@ func (c *Client) runReadRoutine(ctx context.Context) {
 		}
 
 		// Read the message from the active connection
-		_, data, err := c.conn.ReadMessage()
+		_, data, err := c.getConn().ReadMessage()
 		if err != nil {
+			if ctx.Err() != nil {
+				continue // the client was closed
+			}
+
+			if c.maxBackoff > 0 {
+				c.logger.Warn("connection lost, reconnecting", "err", err)
+
+				if err = c.reconnect(ctx); err != nil {
+					c.logger.Debug("reconnect aborted", "err", err)
+				}
+
+				continue
+			}
+
 			if websocket.IsUnexpectedCloseError(err, websocket.CloseNormalClosure) {
 				c.logger.Error("failed to read response", "err", err)
 
@@ -248,7 +349,7 @@ func (c *Client) runReadRoutine(ctx context.Context) {
 
 		// Grab the response channel
 		c.requestMapMux.Lock()
-		ch := c.requestMap[responseHash]
+		ch := c.requestMap[responseHash].ch
 		if ch == nil {
 			c.requestMapMux.Unlock()
 			c.logger.Error("response listener not set", "hash", responseHash, "responses", responses)
@@ -271,6 +372,110 @@ func (c *Client) runReadRoutine(ctx context.Context) {
 	}
 }
 
+// reconnect dials the server until a new connection is established,
+// waiting between attempts with an exponential backoff. Once connected,
+// the pending requests are sent again, and the reconnect handler is called
+func (c *Client) reconnect(ctx context.Context) error {
+	backoff := c.minBackoff
+
+	for attempt := 1; ; attempt++ {
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+
+		conn, _, err := websocket.DefaultDialer.DialContext(ctx, c.rpcURL, nil)
+		if err != nil {
+			c.logger.Warn("unable to reconnect", "attempt", attempt, "err", err)
+
+			backoff = min(backoff*2, c.maxBackoff)
+
+			continue
+		}
+
+		c.connMux.Lock()
+		old := c.conn
+		c.conn = conn
+		c.connMux.Unlock()
+
+		_ = old.Close()
+
+		c.logger.Info("reconnected", "attempts", attempt)
+
+		break
+	}
+
+	// The requests written to the previous connection are lost with it.
+	// The ones still in the backlog are written to the new connection
+	c.requestMapMux.Lock()
+	for hash, pending := range c.requestMap {
+		if pending.conn != nil {
+			c.resend(ctx, hash, pending)
+		}
+	}
+	c.requestMapMux.Unlock()
+
+	if c.onReconnect != nil {
+		c.onReconnect()
+	}
+
+	return nil
+}
+
+// markSent records that the request (or batch) was written to conn.
+// If the connection was replaced in the meantime, the request is resent
+func (c *Client) markSent(item any, conn *websocket.Conn) {
+	hash := itemHash(item)
+
+	c.requestMapMux.Lock()
+	defer c.requestMapMux.Unlock()
+
+	pending, ok := c.requestMap[hash]
+	if !ok {
+		return // the caller stopped waiting
+	}
+
+	pending.conn = conn
+	c.requestMap[hash] = pending
+
+	if conn != c.getConn() {
+		c.resend(c.ctx, hash, pending)
+	}
+}
+
+// resend sends the pending request (or batch) again over the new connection
+// if it can be replayed, or fails it with ErrConnectionLost otherwise, as the
+// server may have already processed it.
+// The request map lock must be held
+func (c *Client) resend(ctx context.Context, hash string, pending pendingRequest) {
+	if !c.itemReplayable(pending.item) {
+		delete(c.requestMap, hash)
+
+		pending.errCh <- ErrConnectionLost // buffered, never blocks
+
+		return
+	}
+
+	pending.conn = nil
+	c.requestMap[hash] = pending
+
+	go func() {
+		select {
+		case <-ctx.Done():
+		case c.backlog <- pending.item:
+		}
+	}()
+}
+
+// getConn returns the active connection
+func (c *Client) getConn() *websocket.Conn {
+	c.connMux.RLock()
+	defer c.connMux.RUnlock()
+
+	return c.conn
+}
+
 // Close closes the WS client
 func (c *Client) Close() error {
 	return c.closeWithCause(nil)
@@ -281,5 +486,5 @@ func (c *Client) Close() error {
 func (c *Client) closeWithCause(err error) error {
 	c.cancelCauseFn(err)
 
-	return c.conn.Close()
+	return c.getConn().Close()
 }
@@ -6,6 +6,7 @@ import (
 	"net/http"
 	"net/http/httptest"
 	"strings"
+	"sync/atomic"
 	"testing"
 	"time"
 
@@ -300,3 +301,183 @@ func TestClient_SendBatch(t *testing.T) {
 		assert.Equal(t, response.Error, resp[0].Error)
 	})
 }
+
+func TestClient_Reconnect(t *testing.T) {
+	t.Parallel()
+
+	var (
+		upgrader = websocket.Upgrader{}
+		conns    atomic.Int64
+
+		request = types.RPCRequest{
+			JSONRPC: "2.0",
+			ID:      types.JSONRPCStringID("id"),
+		}
+
+		response = types.RPCResponse{
+			JSONRPC: "2.0",
+			ID:      request.ID,
+		}
+	)
+
+	// Create the server, which drops the first connection
+	// after receiving the request, without responding
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+
+		defer c.Close()
+
+		first := conns.Add(1) == 1
+
+		for {
+			mt, message, err := c.ReadMessage()
+			if err != nil {
+				return
+			}
+
+			// Parse the message
+			var req types.RPCRequest
+			require.NoError(t, json.Unmarshal(message, &req))
+			require.Equal(t, request.ID.String(), req.ID.String())
+
+			if first {
+				return
+			}
+
+			marshalledResponse, err := json.Marshal(response)
+			require.NoError(t, err)
+
+			require.NoError(t, c.WriteMessage(mt, marshalledResponse))
+		}
+	}
+
+	s := createTestServer(t, http.HandlerFunc(handler))
+	url := "ws" + strings.TrimPrefix(s.URL, "http")
+
+	// Create the client
+	reconnected := make(chan struct{}, 1)
+	c, err := NewClient(
+		url,
+		WithReconnect(10*time.Millisecond, 100*time.Millisecond),
+		WithReplayFilter(func(string) bool { return true }),
+		WithReconnectHandler(func() {
+			reconnected <- struct{}{}
+		}),
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	// Send the request, which is replayed once reconnected
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelFn()
+
+	resp, err := c.SendRequest(ctx, request)
+	require.NoError(t, err)
+
+	assert.Equal(t, response.ID, resp.ID)
+	assert.Equal(t, int64(2), conns.Load())
+
+	select {
+	case <-reconnected:
+	case <-ctx.Done():
+		t.Fatal("reconnect handler not called")
+	}
+}
+
+func TestClient_ReconnectNoReplay(t *testing.T) {
+	t.Parallel()
+
+	var (
+		upgrader = websocket.Upgrader{}
+		requests atomic.Int64
+
+		request = types.RPCRequest{
+			JSONRPC: "2.0",
+			ID:      types.JSONRPCStringID("id"),
+			Method:  "broadcast",
+		}
+	)
+
+	// Create the server, which drops the connections
+	// after receiving a request, without responding
+	handler := func(w http.ResponseWriter, r *http.Request) {
+		c, err := upgrader.Upgrade(w, r, nil)
+		require.NoError(t, err)
+
+		defer c.Close()
+
+		if _, _, err := c.ReadMessage(); err == nil {
+			requests.Add(1)
+		}
+	}
+
+	s := createTestServer(t, http.HandlerFunc(handler))
+	url := "ws" + strings.TrimPrefix(s.URL, "http")
+
+	// Create the client, which only replays the "status" requests
+	c, err := NewClient(
+		url,
+		WithReconnect(10*time.Millisecond, 100*time.Millisecond),
+		WithReplayFilter(func(method string) bool { return method == "status" }),
+	)
+	require.NoError(t, err)
+
+	defer func() {
+		assert.NoError(t, c.Close())
+	}()
+
+	ctx, cancelFn := context.WithTimeout(context.Background(), time.Second*5)
+	defer cancelFn()
+
+	// The request fails instead of being sent again
+	_, err = c.SendRequest(ctx, request)
+	require.ErrorIs(t, err, ErrConnectionLost)
+
+	assert.Equal(t, int64(1), requests.Load())
+}
+
+func TestClient_MarkSent(t *testing.T) {
+	t.Parallel()
+
+	var (
+		oldConn = &websocket.Conn{}
+		newConn = &websocket.Conn{}
+
+		request = types.RPCRequest{
+			JSONRPC: "2.0",
+			ID:      types.JSONRPCStringID("id"),
+		}
+		hash = itemHash(request)
+	)
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	defer cancelFn()
+
+	c := &Client{
+		ctx:        ctx,
+		conn:       newConn,
+		backlog:    make(chan any, 1),
+		requestMap: map[string]pendingRequest{hash: {item: request}},
+		canReplay:  func(string) bool { return true },
+	}
+
+	// Written to the active connection, so it isn't resent
+	c.markSent(request, newConn)
+	assert.Equal(t, newConn, c.requestMap[hash].conn)
+	assert.Empty(t, c.backlog)
+
+	// Written to a connection replaced in the meantime, so it is resent once
+	c.markSent(request, oldConn)
+	assert.Nil(t, c.requestMap[hash].conn)
+
+	select {
+	case item := <-c.backlog:
+		assert.Equal(t, request, item)
+	case <-time.After(5 * time.Second):
+		t.Fatal("request not resent")
+	}
+}
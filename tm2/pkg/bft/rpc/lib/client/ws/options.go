@@ -2,6 +2,7 @@ package ws
 
 import (
 	"log/slog"
+	"time"
 )
 
 type Option func(*Client)
@@ -12,3 +13,34 @@ func WithLogger(logger *slog.Logger) Option {
 		c.logger = logger
 	}
 }
+
+// WithReconnect makes the client reconnect when the connection is lost,
+// instead of closing. Reconnect attempts are spaced with an exponential
+// backoff, from minBackoff up to maxBackoff. The requests awaiting a
+// response fail with ErrConnectionLost, unless they can be replayed
+// (see WithReplayFilter)
+func WithReconnect(minBackoff, maxBackoff time.Duration) Option {
+	return func(c *Client) {
+		c.minBackoff = max(minBackoff, time.Millisecond)
+		c.maxBackoff = max(maxBackoff, c.minBackoff)
+	}
+}
+
+// WithReconnectHandler sets the callback called each time the client
+// reconnects, which can be used to resync the state missed while the
+// connection was lost
+func WithReconnectHandler(fn func()) Option {
+	return func(c *Client) {
+		c.onReconnect = fn
+	}
+}
+
+// WithReplayFilter sets the methods whose requests are sent again once
+// reconnected, when they were awaiting a response. Only idempotent methods
+// should be replayed, as the server may have processed the request before
+// the connection was lost. A batch is replayed if all its methods are
+func WithReplayFilter(fn func(method string) bool) Option {
+	return func(c *Client) {
+		c.canReplay = fn
+	}
+}
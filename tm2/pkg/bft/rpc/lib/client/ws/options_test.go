@@ -6,7 +6,9 @@ import (
 	"net/http"
 	"strings"
 	"testing"
+	"time"
 
+	types "github.com/gnolang/gno/tm2/pkg/bft/rpc/lib/types"
 	"github.com/gorilla/websocket"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
@@ -36,3 +38,31 @@ func TestClient_WithLogger(t *testing.T) {
 
 	assert.Equal(t, logger, c.logger)
 }
+
+func TestClient_WithReconnect(t *testing.T) {
+	t.Parallel()
+
+	var c Client
+
+	WithReconnect(0, 0)(&c)
+
+	assert.Equal(t, time.Millisecond, c.minBackoff)
+	assert.Equal(t, time.Millisecond, c.maxBackoff)
+
+	WithReconnect(time.Second, 10*time.Second)(&c)
+
+	assert.Equal(t, time.Second, c.minBackoff)
+	assert.Equal(t, 10*time.Second, c.maxBackoff)
+}
+
+func TestClient_WithReplayFilter(t *testing.T) {
+	t.Parallel()
+
+	var c Client
+
+	WithReplayFilter(func(method string) bool { return method == "status" })(&c)
+
+	assert.True(t, c.itemReplayable(types.RPCRequest{Method: "status"}))
+	assert.False(t, c.itemReplayable(types.RPCRequest{Method: "broadcast"}))
+	assert.False(t, c.itemReplayable(types.RPCRequests{{Method: "status"}, {Method: "broadcast"}}))
+}
@@ -3,6 +3,7 @@ package rpcserver
 
 import (
 	"bufio"
+	"crypto/subtle"
 	"encoding/json"
 	"fmt"
 	"log/slog"
@@ -31,6 +32,9 @@ type Config struct {
 	MaxBodyBytes int64
 	// mirrors http.Server#MaxHeaderBytes
 	MaxHeaderBytes int
+	// Limits, when set, replace MaxOpenConnections and MaxBodyBytes with
+	// limits which can be changed while the server is running.
+	Limits *Limits
 }
 
 // DefaultConfig returns a default configuration.
@@ -50,7 +54,7 @@ func DefaultConfig() *Config {
 func StartHTTPServer(listener net.Listener, handler http.Handler, logger *slog.Logger, config *Config) error {
 	logger.Info(fmt.Sprintf("Starting RPC HTTP server on %s", listener.Addr()))
 	s := &http.Server{
-		Handler:           RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes}, logger),
+		Handler:           RecoverAndLogHandler(newMaxBytesHandler(handler, config), logger),
 		ReadTimeout:       config.ReadTimeout,
 		ReadHeaderTimeout: 60 * time.Second,
 		WriteTimeout:      config.WriteTimeout,
@@ -74,7 +78,7 @@ func StartHTTPAndTLSServer(
 	logger.Info(fmt.Sprintf("Starting RPC HTTPS server on %s (cert: %q, key: %q)",
 		listener.Addr(), certFile, keyFile))
 	s := &http.Server{
-		Handler:           RecoverAndLogHandler(maxBytesHandler{h: handler, n: config.MaxBodyBytes}, logger),
+		Handler:           RecoverAndLogHandler(newMaxBytesHandler(handler, config), logger),
 		ReadTimeout:       config.ReadTimeout,
 		ReadHeaderTimeout: 60 * time.Second,
 		WriteTimeout:      config.WriteTimeout,
@@ -202,13 +206,39 @@ func (w *ResponseWriterWrapper) Hijack() (net.Conn, *bufio.ReadWriter, error) {
 	return w.ResponseWriter.(http.Hijacker).Hijack()
 }
 
+// AuthTokenHandler wraps an HTTP handler, rejecting the requests which don't
+// carry the given token in their Authorization header, as "Bearer <token>".
+func AuthTokenHandler(handler http.Handler, token string) http.Handler {
+	expected := []byte("Bearer " + token)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		got := []byte(r.Header.Get("Authorization"))
+		if subtle.ConstantTimeCompare(got, expected) != 1 {
+			WriteRPCResponseHTTPError(w, http.StatusUnauthorized,
+				types.RPCInvalidRequestError(types.JSONRPCStringID(""), errors.New("invalid authorization token")))
+			return
+		}
+
+		handler.ServeHTTP(w, r)
+	})
+}
+
 type maxBytesHandler struct {
-	h http.Handler
-	n int64
+	h      http.Handler
+	n      int64
+	limits *Limits
+}
+
+func newMaxBytesHandler(h http.Handler, config *Config) maxBytesHandler {
+	return maxBytesHandler{h: h, n: config.MaxBodyBytes, limits: config.Limits}
 }
 
 func (h maxBytesHandler) ServeHTTP(w http.ResponseWriter, r *http.Request) {
-	r.Body = http.MaxBytesReader(w, r.Body, h.n)
+	n := h.n
+	if h.limits != nil {
+		n = h.limits.MaxBodyBytes()
+	}
+	r.Body = http.MaxBytesReader(w, r.Body, n)
 	h.h.ServeHTTP(w, r)
 }
 
@@ -227,7 +257,9 @@ func Listen(addr string, config *Config) (listener net.Listener, err error) {
 	if err != nil {
 		return nil, errors.New("failed to listen on %v: %v", addr, err)
 	}
-	if config.MaxOpenConnections > 0 {
+	if config.Limits != nil {
+		listener = config.Limits.listen(listener)
+	} else if config.MaxOpenConnections > 0 {
 		listener = netutil.LimitListener(listener, config.MaxOpenConnections)
 	}
 
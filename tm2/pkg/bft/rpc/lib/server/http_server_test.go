@@ -181,3 +181,42 @@ func TestRecoverAndLogHandler(t *testing.T) {
 		})
 	}
 }
+
+func TestAuthTokenHandler(t *testing.T) {
+	t.Parallel()
+
+	handler := AuthTokenHandler(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		fmt.Fprint(w, "ok")
+	}), "secret")
+
+	tests := []struct {
+		name          string
+		authorization string
+		expectedCode  int
+	}{
+		{"valid token", "Bearer secret", http.StatusOK},
+		{"missing token", "", http.StatusUnauthorized},
+		{"invalid token", "Bearer wrong", http.StatusUnauthorized},
+		{"missing scheme", "secret", http.StatusUnauthorized},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			req := httptest.NewRequest(http.MethodGet, "/", nil)
+			if tt.authorization != "" {
+				req.Header.Set("Authorization", tt.authorization)
+			}
+			resp := httptest.NewRecorder()
+
+			handler.ServeHTTP(resp, req)
+
+			assert.Equal(t, tt.expectedCode, resp.Code)
+			if tt.expectedCode == http.StatusOK {
+				assert.Equal(t, "ok", resp.Body.String())
+			} else {
+				assert.Contains(t, resp.Body.String(), "invalid authorization token")
+			}
+		})
+	}
+}
@@ -0,0 +1,136 @@
+package rpcserver
+
+import (
+	"net"
+	"sync"
+	"sync/atomic"
+)
+
+// Limits are the limits of RPC servers which can be changed while they're
+// running: the maximum number of simultaneous connections of each listener,
+// and the maximum size of the request bodies. When set in the Config, they
+// replace its MaxOpenConnections and MaxBodyBytes.
+type Limits struct {
+	maxOpenConnections atomic.Int64
+	maxBodyBytes       atomic.Int64
+
+	mu        sync.Mutex
+	listeners []*limitListener
+}
+
+// NewLimits returns limits with the given initial values. A maxOpenConnections
+// of 0 means unlimited.
+func NewLimits(maxOpenConnections int, maxBodyBytes int64) *Limits {
+	l := &Limits{}
+	l.maxOpenConnections.Store(int64(maxOpenConnections))
+	l.maxBodyBytes.Store(maxBodyBytes)
+	return l
+}
+
+// MaxOpenConnections returns the maximum number of simultaneous connections
+// of each listener.
+func (l *Limits) MaxOpenConnections() int {
+	return int(l.maxOpenConnections.Load())
+}
+
+// MaxBodyBytes returns the maximum size of the request bodies.
+func (l *Limits) MaxBodyBytes() int64 {
+	return l.maxBodyBytes.Load()
+}
+
+// Set changes the limits. The connections already open over the new maximum
+// are kept, but no new connection is accepted until enough of them are
+// closed.
+func (l *Limits) Set(maxOpenConnections int, maxBodyBytes int64) {
+	l.maxOpenConnections.Store(int64(maxOpenConnections))
+	l.maxBodyBytes.Store(maxBodyBytes)
+
+	// Wake up the listeners waiting for a connection to be closed.
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	for _, ll := range l.listeners {
+		ll.wake()
+	}
+}
+
+// listen returns a listener limiting the connections of ln.
+func (l *Limits) listen(ln net.Listener) net.Listener {
+	ll := &limitListener{Listener: ln, limits: l}
+	ll.cond = sync.NewCond(&ll.mu)
+
+	l.mu.Lock()
+	l.listeners = append(l.listeners, ll)
+	l.mu.Unlock()
+	return ll
+}
+
+// limitListener is like netutil.LimitListener, with a limit which can change.
+type limitListener struct {
+	net.Listener
+	limits *Limits
+
+	mu     sync.Mutex
+	cond   *sync.Cond
+	open   int
+	closed bool
+}
+
+func (l *limitListener) Accept() (net.Conn, error) {
+	l.mu.Lock()
+	for !l.closed {
+		maxOpen := l.limits.MaxOpenConnections()
+		if maxOpen <= 0 || l.open < maxOpen {
+			break
+		}
+		l.cond.Wait()
+	}
+	if l.closed {
+		l.mu.Unlock()
+		return nil, net.ErrClosed
+	}
+	l.open++
+	l.mu.Unlock()
+
+	c, err := l.Listener.Accept()
+	if err != nil {
+		l.release()
+		return nil, err
+	}
+	return &limitConn{Conn: c, release: sync.OnceFunc(l.release)}, nil
+}
+
+func (l *limitListener) Close() error {
+	err := l.Listener.Close()
+
+	l.mu.Lock()
+	l.closed = true
+	l.mu.Unlock()
+	l.cond.Broadcast()
+	return err
+}
+
+func (l *limitListener) release() {
+	l.mu.Lock()
+	l.open--
+	l.mu.Unlock()
+	l.cond.Broadcast()
+}
+
+func (l *limitListener) wake() {
+	// Holding the lock makes sure Accept isn't between checking the limit
+	// and waiting.
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	l.cond.Broadcast()
+}
+
+type limitConn struct {
+	net.Conn
+	release func()
+}
+
+func (c *limitConn) Close() error {
+	err := c.Conn.Close()
+	c.release()
+	return err
+}
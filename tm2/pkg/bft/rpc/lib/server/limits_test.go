@@ -0,0 +1,101 @@
+package rpcserver
+
+import (
+	"errors"
+	"io"
+	"net"
+	"net/http"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/log"
+)
+
+func TestLimits_MaxOpenConnections(t *testing.T) {
+	t.Parallel()
+
+	limits := NewLimits(1, 1000)
+	config := DefaultConfig()
+	config.Limits = limits
+	l, err := Listen("tcp://127.0.0.1:0", config)
+	require.NoError(t, err)
+	defer l.Close()
+
+	accepted := make(chan net.Conn, 2)
+	go func() {
+		for {
+			c, err := l.Accept()
+			if err != nil {
+				close(accepted)
+				return
+			}
+			accepted <- c
+		}
+	}()
+
+	dial := func() {
+		c, err := net.Dial("tcp", l.Addr().String())
+		require.NoError(t, err)
+		t.Cleanup(func() { c.Close() })
+	}
+
+	// The second connection waits for the first one to be closed.
+	dial()
+	first := <-accepted
+	dial()
+	select {
+	case <-accepted:
+		t.Fatal("accepted a connection over the limit")
+	case <-time.After(50 * time.Millisecond):
+	}
+
+	// Raising the limit accepts it.
+	limits.Set(2, 1000)
+	select {
+	case c := <-accepted:
+		c.Close()
+	case <-time.After(time.Second):
+		t.Fatal("connection not accepted after raising the limit")
+	}
+	first.Close()
+
+	// Closing the listener stops Accept.
+	l.Close()
+	_, ok := <-accepted
+	assert.False(t, ok)
+}
+
+func TestLimits_MaxBodyBytes(t *testing.T) {
+	t.Parallel()
+
+	limits := NewLimits(0, 10)
+	config := DefaultConfig()
+	config.Limits = limits
+	l, err := Listen("tcp://127.0.0.1:0", config)
+	require.NoError(t, err)
+	defer l.Close()
+
+	mux := http.NewServeMux()
+	mux.HandleFunc("/", func(w http.ResponseWriter, r *http.Request) {
+		var mbe *http.MaxBytesError
+		if _, err := io.ReadAll(r.Body); errors.As(err, &mbe) {
+			w.WriteHeader(http.StatusRequestEntityTooLarge)
+		}
+	})
+	go StartHTTPServer(l, mux, log.NewTestingLogger(t), config)
+
+	post := func() int {
+		res, err := http.Post("http://"+l.Addr().String(), "text/plain", strings.NewReader(strings.Repeat("a", 20)))
+		require.NoError(t, err)
+		res.Body.Close()
+		return res.StatusCode
+	}
+	assert.Equal(t, http.StatusRequestEntityTooLarge, post())
+
+	limits.Set(0, 100)
+	assert.Equal(t, http.StatusOK, post())
+}
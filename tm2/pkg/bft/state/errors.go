@@ -47,6 +47,11 @@ type (
 	NoTxResultForHashError struct {
 		Hash []byte
 	}
+
+	ProposalRejectedError struct {
+		Reason error
+		Log    string
+	}
 )
 
 func (e UnknownBlockError) Error() string {
@@ -69,6 +74,10 @@ func (e StateMismatchError) Error() string {
 	return fmt.Sprintf("State after replay does not match saved state. Got ----\n%v\nExpected ----\n%v\n", e.Got, e.Expected)
 }
 
+func (e ProposalRejectedError) Error() string {
+	return fmt.Sprintf("Proposal rejected by application: %v %s", e.Reason, e.Log)
+}
+
 func (e NoValSetForHeightError) Error() string {
 	return fmt.Sprintf("Could not find validator set for height #%d", e.Height)
 }
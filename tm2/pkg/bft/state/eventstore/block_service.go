@@ -0,0 +1,177 @@
+package eventstore
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/events"
+	"github.com/gnolang/gno/tm2/pkg/service"
+)
+
+const blockEventStoreListenerID = "block-event-store"
+
+// BlockStore loads the committed blocks
+type BlockStore interface {
+	LoadBlock(height int64) *types.Block
+}
+
+// BlockService connects the committed blocks to a block event store.
+// The blocks are loaded from the block and state stores, so the event store
+// catches up with the blocks committed since the last one it stored, on start
+// and after failing to store one, and a slow event store falls behind instead
+// of holding up consensus
+type BlockService struct {
+	service.BaseService
+
+	cancelFn context.CancelFunc
+
+	blockEventStore BlockEventStore
+	evsw            events.EventSwitch
+	blockStore      BlockStore
+	stateDB         dbm.DB
+
+	next   int64        // height of the next block to append
+	latest atomic.Int64 // height of the last committed block
+	notify chan struct{}
+}
+
+// NewBlockEventStoreService returns a new block service instance
+func NewBlockEventStoreService(
+	store BlockEventStore,
+	evsw events.EventSwitch,
+	blockStore BlockStore,
+	stateDB dbm.DB,
+) *BlockService {
+	bs := &BlockService{
+		blockEventStore: store,
+		evsw:            evsw,
+		blockStore:      blockStore,
+		stateDB:         stateDB,
+		notify:          make(chan struct{}, 1),
+	}
+	bs.BaseService = *service.NewBaseService(nil, "BlockEventStoreService", bs)
+
+	return bs
+}
+
+func (bs *BlockService) OnStart() error {
+	// Start the event store
+	if err := bs.blockEventStore.Start(); err != nil {
+		return fmt.Errorf("unable to start block event store, %w", err)
+	}
+
+	last, err := bs.blockEventStore.LastHeight()
+	if err != nil {
+		return fmt.Errorf("unable to get the last height of the block event store, %w", err)
+	}
+	bs.next = last + 1
+
+	// Catch up with the blocks committed so far, then with every new one
+	bs.setLatest(sm.LoadState(bs.stateDB).LastBlockHeight)
+	bs.evsw.AddListener(blockEventStoreListenerID, func(ev events.Event) {
+		if ev, ok := ev.(types.EventNewBlock); ok {
+			bs.setLatest(ev.Block.Height)
+		}
+	})
+
+	ctx, cancelFn := context.WithCancel(context.Background())
+	bs.cancelFn = cancelFn
+
+	go bs.appendBlocks(ctx)
+
+	return nil
+}
+
+func (bs *BlockService) OnStop() {
+	bs.evsw.RemoveListener(blockEventStoreListenerID)
+
+	// Close off any routines
+	bs.cancelFn()
+
+	// Attempt to gracefully stop the event store
+	if err := bs.blockEventStore.Stop(); err != nil {
+		bs.Logger.Error(
+			fmt.Sprintf("unable to gracefully stop block event store, %v", err),
+		)
+	}
+}
+
+// setLatest records a newly committed height, and wakes up appendBlocks
+func (bs *BlockService) setLatest(height int64) {
+	for {
+		latest := bs.latest.Load()
+		if height <= latest {
+			// Blocks replayed by the handshake were already committed
+			return
+		}
+		if bs.latest.CompareAndSwap(latest, height) {
+			break
+		}
+	}
+
+	select {
+	case bs.notify <- struct{}{}:
+	default: // already notified
+	}
+}
+
+// appendBlocks appends the committed blocks to the event store, as they come
+func (bs *BlockService) appendBlocks(ctx context.Context) {
+	for {
+		for bs.next <= bs.latest.Load() {
+			if ctx.Err() != nil {
+				return
+			}
+
+			if err := bs.appendBlock(bs.next); err != nil {
+				// Retried with the next block
+				bs.Logger.Error("unable to store block", "height", bs.next, "err", err)
+
+				break
+			}
+
+			bs.next++
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-bs.notify:
+		}
+	}
+}
+
+// appendBlock loads the block at height with its results,
+// and appends it to the event store
+func (bs *BlockService) appendBlock(height int64) error {
+	block := bs.blockStore.LoadBlock(height)
+	if block == nil {
+		return fmt.Errorf("block %d not found", height)
+	}
+
+	responses, err := sm.LoadABCIResponses(bs.stateDB, height)
+	if err != nil {
+		return err
+	}
+
+	txResults := make([]types.TxResult, len(block.Txs))
+	for i, tx := range block.Txs {
+		txResults[i] = types.TxResult{
+			Height:   height,
+			Index:    uint32(i),
+			Tx:       tx,
+			Response: responses.DeliverTxs[i],
+		}
+	}
+
+	return bs.blockEventStore.AppendBlock(BlockResult{
+		Block:            block,
+		ResultBeginBlock: responses.BeginBlock,
+		TxResults:        txResults,
+		ResultEndBlock:   responses.EndBlock,
+	})
+}
@@ -0,0 +1,118 @@
+package eventstore
+
+import (
+	"errors"
+	"sync"
+	"testing"
+	"time"
+
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	sm "github.com/gnolang/gno/tm2/pkg/bft/state"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/events"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+// commitBlocks saves blocks up to height in the stores, as consensus does,
+// with one transaction each
+func commitBlocks(t *testing.T, blocks mockBlockStore, stateDB *memdb.MemDB, from, to int64) {
+	t.Helper()
+
+	for height := from; height <= to; height++ {
+		blocks[height] = &types.Block{
+			Header: types.Header{Height: height},
+			Data:   types.Data{Txs: types.Txs{types.Tx{byte(height)}}},
+		}
+		sm.SaveABCIResponses(stateDB, height, &sm.ABCIResponses{
+			DeliverTxs: []abci.ResponseDeliverTx{{GasUsed: height}},
+		})
+	}
+	sm.SaveState(stateDB, sm.State{LastBlockHeight: to})
+}
+
+func TestBlockService(t *testing.T) {
+	t.Parallel()
+
+	const defaultTimeout = 5 * time.Second
+
+	var (
+		mu       sync.Mutex
+		received []BlockResult
+		fail     = true
+
+		blocks  = mockBlockStore{}
+		stateDB = memdb.NewMemDB()
+		evsw    = events.NewEventSwitch()
+
+		store = &mockBlockEventStore{
+			lastHeightFn: func() (int64, error) {
+				return 1, nil
+			},
+			appendBlockFn: func(block BlockResult) error {
+				mu.Lock()
+				defer mu.Unlock()
+
+				if block.Block.Height == 4 && fail {
+					fail = false
+
+					return errors.New("unavailable")
+				}
+				received = append(received, block)
+
+				return nil
+			},
+		}
+	)
+
+	heights := func() []int64 {
+		mu.Lock()
+		defer mu.Unlock()
+
+		hs := make([]int64, 0, len(received))
+		for _, block := range received {
+			hs = append(hs, block.Block.Height)
+		}
+
+		return hs
+	}
+
+	// Blocks 1 to 3 are committed, and the store has stored block 1
+	commitBlocks(t, blocks, stateDB, 1, 3)
+
+	s := NewBlockEventStoreService(store, evsw, blocks, stateDB)
+	require.NoError(t, s.Start())
+	defer s.Stop()
+
+	// The store catches up with blocks 2 and 3
+	require.Eventually(t, func() bool {
+		return len(heights()) == 2
+	}, defaultTimeout, 10*time.Millisecond)
+	assert.Equal(t, []int64{2, 3}, heights())
+
+	mu.Lock()
+	res := received[0].TxResults
+	mu.Unlock()
+	require.Len(t, res, 1)
+	assert.Equal(t, int64(2), res[0].Height)
+	assert.Equal(t, types.Tx{2}, res[0].Tx)
+	assert.Equal(t, int64(2), res[0].Response.GasUsed)
+
+	// Block 4 fails, and is stored again along with block 5
+	commitBlocks(t, blocks, stateDB, 4, 4)
+	evsw.FireEvent(types.EventNewBlock{Block: blocks[4]})
+
+	commitBlocks(t, blocks, stateDB, 5, 5)
+	evsw.FireEvent(types.EventNewBlock{Block: blocks[5]})
+
+	require.Eventually(t, func() bool {
+		return len(heights()) == 4
+	}, defaultTimeout, 10*time.Millisecond)
+	assert.Equal(t, []int64{2, 3, 4, 5}, heights())
+
+	// Replayed blocks are not stored twice
+	evsw.FireEvent(types.EventNewBlock{Block: blocks[3]})
+	time.Sleep(50 * time.Millisecond)
+	assert.Equal(t, []int64{2, 3, 4, 5}, heights())
+}
@@ -0,0 +1,254 @@
+// Package exec implements a block event store driving an external program,
+// such as an indexer writing to a database, over its standard input and
+// output.
+//
+// The protocol is line-based JSON. When started, the program writes its
+// last stored height, 0 if none:
+//
+//	{"last_height": 41}
+//
+// The node then writes each block committed after it on a line, as amino
+// JSON, with its transactions decoded when they are std.Tx:
+//
+//	{"block": {...}, "result_begin_block": {...}, "txs": [{"hash": "...", "tx": {...}, "response": {...}}], "result_end_block": {...}}
+//
+// The program acknowledges each block once stored, by writing its height, or
+// an error, in which case the block is sent again with the next one:
+//
+//	{"height": 42}
+//	{"height": 42, "error": "database unavailable"}
+//
+// The standard error of the program is the standard error of the node.
+package exec
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"io"
+	"os"
+	osexec "os/exec"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	storetypes "github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+const (
+	EventStoreType = "exec"
+	Command        = "command"
+	Args           = "args"
+)
+
+// stopTimeout is how long the program has to exit once its input is closed
+const stopTimeout = 5 * time.Second
+
+var (
+	errMissingCommand = errors.New("missing command param")
+	errInvalidArgs    = errors.New("invalid args param, expected a list of strings")
+	errInvalidType    = errors.New("invalid config for exec event store specified")
+	errBlocksOnly     = errors.New("exec event store only appends blocks")
+)
+
+var _ eventstore.BlockEventStore = (*TxEventStore)(nil)
+
+// TxEventStore is the implementation of a block event store
+// that drives an external program
+type TxEventStore struct {
+	command string
+	args    []string
+
+	cmd        *osexec.Cmd
+	stdin      io.WriteCloser
+	stdout     *bufio.Scanner
+	lastHeight int64
+}
+
+// NewTxEventStore creates a new exec block event store
+func NewTxEventStore(cfg *storetypes.Config) (*TxEventStore, error) {
+	// Parse config params
+	if EventStoreType != cfg.EventStoreType {
+		return nil, errInvalidType
+	}
+
+	command, ok := cfg.GetParam(Command).(string)
+	if !ok || command == "" {
+		return nil, errMissingCommand
+	}
+
+	var args []string
+	switch raw := cfg.GetParam(Args).(type) {
+	case nil:
+	case []string:
+		args = raw
+	case []any: // decoded from TOML or JSON
+		for _, arg := range raw {
+			s, ok := arg.(string)
+			if !ok {
+				return nil, errInvalidArgs
+			}
+			args = append(args, s)
+		}
+	default:
+		return nil, errInvalidArgs
+	}
+
+	return &TxEventStore{
+		command: command,
+		args:    args,
+	}, nil
+}
+
+// Start starts the program, and reads its last stored height
+func (t *TxEventStore) Start() error {
+	t.cmd = osexec.Command(t.command, t.args...)
+	t.cmd.Stderr = os.Stderr
+
+	stdin, err := t.cmd.StdinPipe()
+	if err != nil {
+		return err
+	}
+	stdout, err := t.cmd.StdoutPipe()
+	if err != nil {
+		return err
+	}
+	if err := t.cmd.Start(); err != nil {
+		return fmt.Errorf("unable to start %s, %w", t.command, err)
+	}
+
+	t.stdin = stdin
+	t.stdout = bufio.NewScanner(stdout)
+
+	var hello struct {
+		LastHeight int64 `json:"last_height"`
+	}
+	if err := t.readLine(&hello); err != nil {
+		t.Stop()
+
+		return fmt.Errorf("unable to read the last height, %w", err)
+	}
+	t.lastHeight = hello.LastHeight
+
+	return nil
+}
+
+// Stop closes the input of the program, and waits for it to exit
+func (t *TxEventStore) Stop() error {
+	if t.cmd == nil {
+		return nil
+	}
+
+	t.stdin.Close()
+
+	done := make(chan error, 1)
+	go func() { done <- t.cmd.Wait() }()
+
+	select {
+	case err := <-done:
+		return err
+	case <-time.After(stopTimeout):
+		t.cmd.Process.Kill()
+
+		return <-done
+	}
+}
+
+// GetType returns the exec event store type
+func (t *TxEventStore) GetType() string {
+	return EventStoreType
+}
+
+// LastHeight returns the last height stored by the program
+func (t *TxEventStore) LastHeight() (int64, error) {
+	return t.lastHeight, nil
+}
+
+// Append is not supported, as the program is given whole blocks
+func (t *TxEventStore) Append(_ types.TxResult) error {
+	return errBlocksOnly
+}
+
+// blockMessage is a block, as written to the program
+type blockMessage struct {
+	Block            *types.Block            `json:"block"`
+	ResultBeginBlock abci.ResponseBeginBlock `json:"result_begin_block"`
+	Txs              []txMessage             `json:"txs"`
+	ResultEndBlock   abci.ResponseEndBlock   `json:"result_end_block"`
+}
+
+// txMessage is a transaction of a block, decoded if it is a std.Tx
+type txMessage struct {
+	Hash     []byte                 `json:"hash"`
+	Tx       *std.Tx                `json:"tx,omitempty"`
+	Raw      []byte                 `json:"raw,omitempty"`
+	Response abci.ResponseDeliverTx `json:"response"`
+}
+
+// AppendBlock writes the block to the program,
+// and waits for it to be acknowledged
+func (t *TxEventStore) AppendBlock(block eventstore.BlockResult) error {
+	msg := blockMessage{
+		Block:            block.Block,
+		ResultBeginBlock: block.ResultBeginBlock,
+		Txs:              make([]txMessage, len(block.TxResults)),
+		ResultEndBlock:   block.ResultEndBlock,
+	}
+	for i, res := range block.TxResults {
+		txMsg := txMessage{
+			Hash:     res.Tx.Hash(),
+			Response: res.Response,
+		}
+
+		var tx std.Tx
+		if err := amino.Unmarshal(res.Tx, &tx); err == nil {
+			txMsg.Tx = &tx
+		} else {
+			txMsg.Raw = res.Tx
+		}
+		msg.Txs[i] = txMsg
+	}
+
+	raw, err := amino.MarshalJSON(msg)
+	if err != nil {
+		return fmt.Errorf("unable to marshal block, %w", err)
+	}
+	if _, err := t.stdin.Write(append(raw, '\n')); err != nil {
+		return fmt.Errorf("unable to write block, %w", err)
+	}
+
+	var ack struct {
+		Height int64  `json:"height"`
+		Error  string `json:"error"`
+	}
+	if err := t.readLine(&ack); err != nil {
+		return fmt.Errorf("unable to read acknowledgement, %w", err)
+	}
+	switch {
+	case ack.Error != "":
+		return fmt.Errorf("unable to store block %d, %s", block.Block.Height, ack.Error)
+	case ack.Height != block.Block.Height:
+		return fmt.Errorf("acknowledged height %d, expected %d", ack.Height, block.Block.Height)
+	}
+
+	t.lastHeight = ack.Height
+
+	return nil
+}
+
+// readLine decodes the next line written by the program into v
+func (t *TxEventStore) readLine(v any) error {
+	if !t.stdout.Scan() {
+		if err := t.stdout.Err(); err != nil {
+			return err
+		}
+
+		return io.ErrUnexpectedEOF
+	}
+
+	return json.Unmarshal(t.stdout.Bytes(), v)
+}
@@ -0,0 +1,169 @@
+package exec
+
+import (
+	"bufio"
+	"encoding/json"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/state/eventstore"
+	storetypes "github.com/gnolang/gno/tm2/pkg/bft/state/eventstore/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const (
+	helperEnv    = "EXEC_EVENT_STORE_HELPER"
+	helperOutEnv = "EXEC_EVENT_STORE_HELPER_OUT"
+)
+
+// TestHelperProcess is the program driven by the event store in the tests:
+// it starts at height 3, fails to store block 5, and writes the blocks it is
+// given to the file in helperOutEnv
+func TestHelperProcess(t *testing.T) {
+	if os.Getenv(helperEnv) == "" {
+		t.Skip("helper process")
+	}
+
+	out, err := os.Create(os.Getenv(helperOutEnv))
+	if err != nil {
+		os.Exit(1)
+	}
+	defer out.Close()
+
+	fmt.Println(`{"last_height": 3}`)
+
+	sc := bufio.NewScanner(os.Stdin)
+	sc.Buffer(nil, 1<<20)
+	for sc.Scan() {
+		var block struct {
+			Block struct {
+				Header struct {
+					Height string `json:"height"`
+				} `json:"header"`
+			} `json:"block"`
+		}
+		if err := json.Unmarshal(sc.Bytes(), &block); err != nil {
+			fmt.Printf("{\"error\": %q}\n", err.Error())
+			continue
+		}
+
+		height := block.Block.Header.Height
+		if height == "5" {
+			fmt.Printf("{\"height\": %s, \"error\": \"unavailable\"}\n", height)
+			continue
+		}
+		fmt.Fprintln(out, sc.Text())
+		fmt.Printf("{\"height\": %s}\n", height)
+	}
+	os.Exit(0)
+}
+
+func TestNewTxEventStore(t *testing.T) {
+	t.Parallel()
+
+	testTable := []struct {
+		name   string
+		params storetypes.EventStoreParams
+		args   []string
+		err    error
+	}{
+		{"missing command", storetypes.EventStoreParams{}, nil, errMissingCommand},
+		{"invalid args", storetypes.EventStoreParams{Command: "indexer", Args: "-db"}, nil, errInvalidArgs},
+		{"invalid arg", storetypes.EventStoreParams{Command: "indexer", Args: []any{"-db", 1}}, nil, errInvalidArgs},
+		{"no args", storetypes.EventStoreParams{Command: "indexer"}, nil, nil},
+		{"args", storetypes.EventStoreParams{Command: "indexer", Args: []any{"-db", "pg"}}, []string{"-db", "pg"}, nil},
+	}
+
+	for _, testCase := range testTable {
+		t.Run(testCase.name, func(t *testing.T) {
+			t.Parallel()
+
+			store, err := NewTxEventStore(&storetypes.Config{
+				EventStoreType: EventStoreType,
+				Params:         testCase.params,
+			})
+			if testCase.err != nil {
+				assert.ErrorIs(t, err, testCase.err)
+
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, "indexer", store.command)
+			assert.Equal(t, testCase.args, store.args)
+		})
+	}
+
+	_, err := NewTxEventStore(&storetypes.Config{EventStoreType: "file"})
+	assert.ErrorIs(t, err, errInvalidType)
+}
+
+func TestTxEventStore(t *testing.T) {
+	out := filepath.Join(t.TempDir(), "blocks.jsonl")
+	t.Setenv(helperEnv, "1")
+	t.Setenv(helperOutEnv, out)
+
+	store, err := NewTxEventStore(&storetypes.Config{
+		EventStoreType: EventStoreType,
+		Params: storetypes.EventStoreParams{
+			Command: os.Args[0],
+			Args:    []any{"-test.run=^TestHelperProcess$"},
+		},
+	})
+	require.NoError(t, err)
+	require.NoError(t, store.Start())
+
+	last, err := store.LastHeight()
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), last)
+
+	stdTx := amino.MustMarshal(std.Tx{Memo: "hello"})
+	block := func(height int64, txs ...types.Tx) eventstore.BlockResult {
+		res := eventstore.BlockResult{
+			Block: &types.Block{
+				Header: types.Header{Height: height},
+				Data:   types.Data{Txs: txs},
+			},
+		}
+		for i, tx := range txs {
+			res.TxResults = append(res.TxResults, types.TxResult{
+				Height:   height,
+				Index:    uint32(i),
+				Tx:       tx,
+				Response: abci.ResponseDeliverTx{GasUsed: 10},
+			})
+		}
+
+		return res
+	}
+
+	require.NoError(t, store.AppendBlock(block(4, stdTx, types.Tx("raw"))))
+	assert.ErrorContains(t, store.AppendBlock(block(5)), "unable to store block 5, unavailable")
+
+	last, err = store.LastHeight()
+	require.NoError(t, err)
+	assert.Equal(t, int64(4), last)
+
+	assert.ErrorIs(t, store.Append(types.TxResult{}), errBlocksOnly)
+	require.NoError(t, store.Stop())
+
+	raw, err := os.ReadFile(out)
+	require.NoError(t, err)
+
+	var msg blockMessage
+	require.NoError(t, amino.UnmarshalJSON(raw, &msg))
+	assert.Equal(t, int64(4), msg.Block.Height)
+	require.Len(t, msg.Txs, 2)
+	require.NotNil(t, msg.Txs[0].Tx)
+	assert.Equal(t, "hello", msg.Txs[0].Tx.Memo)
+	assert.Equal(t, types.Tx(stdTx).Hash(), msg.Txs[0].Hash)
+	assert.Nil(t, msg.Txs[1].Tx)
+	assert.Equal(t, []byte("raw"), msg.Txs[1].Raw)
+	assert.Equal(t, int64(10), msg.Txs[1].Response.GasUsed)
+}
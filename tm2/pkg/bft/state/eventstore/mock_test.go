@@ -87,3 +87,41 @@ func (m *mockEventSwitch) RemoveListener(listenerID string) {
 		m.removeListenerFn(listenerID)
 	}
 }
+
+// BlockEventStore //
+
+type (
+	lastHeightDelegate  func() (int64, error)
+	appendBlockDelegate func(BlockResult) error
+)
+
+type mockBlockEventStore struct {
+	mockEventStore
+
+	lastHeightFn  lastHeightDelegate
+	appendBlockFn appendBlockDelegate
+}
+
+func (m mockBlockEventStore) LastHeight() (int64, error) {
+	if m.lastHeightFn != nil {
+		return m.lastHeightFn()
+	}
+
+	return 0, nil
+}
+
+func (m mockBlockEventStore) AppendBlock(block BlockResult) error {
+	if m.appendBlockFn != nil {
+		return m.appendBlockFn(block)
+	}
+
+	return nil
+}
+
+// BlockStore //
+
+type mockBlockStore map[int64]*types.Block
+
+func (m mockBlockStore) LoadBlock(height int64) *types.Block {
+	return m[height]
+}
@@ -1,6 +1,9 @@
 package eventstore
 
-import "github.com/gnolang/gno/tm2/pkg/bft/types"
+import (
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/bft/types"
+)
 
 const (
 	StatusOn  = "on"
@@ -22,3 +25,27 @@ type TxEventStore interface {
 	// to the event store
 	Append(result types.TxResult) error
 }
+
+// BlockResult is a committed block, along with the results of its execution
+type BlockResult struct {
+	Block            *types.Block            `json:"block"`
+	ResultBeginBlock abci.ResponseBeginBlock `json:"result_begin_block"`
+	TxResults        []types.TxResult        `json:"tx_results"`
+	ResultEndBlock   abci.ResponseEndBlock   `json:"result_end_block"`
+}
+
+// BlockEventStore is an event store receiving every committed block, such as
+// a plugin driving an external indexer. It is given the blocks in order and
+// without gaps, starting after the last block it has stored, instead of the
+// transactions one by one: Append is not called
+type BlockEventStore interface {
+	TxEventStore
+
+	// LastHeight returns the height of the last block stored,
+	// or 0 if there is none
+	LastHeight() (int64, error)
+
+	// AppendBlock appends the next committed block
+	// to the event store
+	AppendBlock(block BlockResult) error
+}
@@ -68,7 +68,8 @@ func (blockExec *BlockExecutor) SetEventSwitch(evsw events.EventSwitch) {
 	blockExec.evsw = evsw
 }
 
-// CreateProposalBlock calls state.MakeBlock with txs from the mempool.
+// CreateProposalBlock calls state.MakeBlock with txs from the mempool,
+// as selected and ordered by the application's PrepareProposal.
 func (blockExec *BlockExecutor) CreateProposalBlock(
 	height int64,
 	state State, commit *types.Commit,
@@ -78,10 +79,79 @@ func (blockExec *BlockExecutor) CreateProposalBlock(
 	maxGas := state.ConsensusParams.Block.MaxGas
 
 	txs := blockExec.mempool.ReapMaxBytesMaxGas(maxDataBytes, maxGas)
+	txs = blockExec.prepareProposal(height, txs, maxDataBytes, maxGas, proposerAddr)
 
 	return state.MakeBlock(height, txs, commit, proposerAddr)
 }
 
+// prepareProposal lets the application filter and reorder the reaped txs.
+// If the application errors or returns txs which don't fit in maxDataBytes
+// and maxGas, accounted as the mempool reap does, the reaped txs are used
+// as-is so that the proposer can still make progress.
+func (blockExec *BlockExecutor) prepareProposal(
+	height int64,
+	txs types.Txs,
+	maxDataBytes, maxGas int64,
+	proposerAddr crypto.Address,
+) types.Txs {
+	reqTxs := make([][]byte, len(txs))
+	for i, tx := range txs {
+		reqTxs[i] = tx
+	}
+
+	res, err := blockExec.proxyApp.PrepareProposalSync(abci.RequestPrepareProposal{
+		Height:          height,
+		Txs:             reqTxs,
+		MaxTxBytes:      maxDataBytes,
+		MaxGas:          maxGas,
+		ProposerAddress: proposerAddr,
+	})
+	switch {
+	case err != nil:
+		blockExec.logger.Error("Error in proxyAppConn.PrepareProposal", "err", err)
+		return txs
+	case res.IsErr():
+		blockExec.logger.Error("Application rejected PrepareProposal", "err", res.Error, "log", res.Log)
+		return txs
+	}
+
+	prepared := make(types.Txs, len(res.Txs))
+	for i, tx := range res.Txs {
+		prepared[i] = tx
+	}
+
+	if err := blockExec.mempool.CheckMaxBytesMaxGas(prepared, maxDataBytes, maxGas); err != nil {
+		blockExec.logger.Error("Application returned invalid txs from PrepareProposal", "err", err)
+		return txs
+	}
+
+	return prepared
+}
+
+// ProcessProposal asks the application whether the given proposal block is
+// acceptable. It is called by validators before prevoting for a block which
+// has already passed state.ValidateBlock.
+func (blockExec *BlockExecutor) ProcessProposal(block *types.Block) error {
+	txs := make([][]byte, len(block.Txs))
+	for i, tx := range block.Txs {
+		txs[i] = tx
+	}
+
+	res, err := blockExec.proxyApp.ProcessProposalSync(abci.RequestProcessProposal{
+		Hash:   block.Hash(),
+		Header: block.Header.Copy(),
+		Txs:    txs,
+	})
+	if err != nil {
+		return ProxyAppConnError(err)
+	}
+	if res.IsErr() {
+		return ProposalRejectedError{Reason: res.Error, Log: res.Log}
+	}
+
+	return nil
+}
+
 // ApplyBlock validates the block against the state, executes it against the app,
 // fires the relevant events, commits the app, and saves the new state and responses.
 // It's the only function that needs to be called
@@ -1,6 +1,7 @@
 package state_test
 
 import (
+	"errors"
 	"testing"
 	"time"
 
@@ -355,3 +356,130 @@ func TestEndBlockValidatorUpdatesResultingInEmptySet(t *testing.T) {
 	assert.NotNil(t, err)
 	assert.NotEmpty(t, state.NextValidators.Validators)
 }
+
+// reapMempool is a mock mempool returning a fixed set of txs, which all
+// want 1 gas.
+type reapMempool struct {
+	mock.Mempool
+	txs types.Txs
+}
+
+func (m reapMempool) ReapMaxBytesMaxGas(_, _ int64) types.Txs { return m.txs }
+
+func (m reapMempool) CheckMaxBytesMaxGas(txs types.Txs, maxDataBytes, maxGas int64) error {
+	var size int64
+	for _, tx := range txs {
+		size += int64(len(tx))
+		if m.txs.Index(tx) < 0 {
+			return errors.New("unknown tx")
+		}
+	}
+	if size > maxDataBytes || (maxGas > -1 && int64(len(txs)) > maxGas) {
+		return errors.New("txs too large")
+	}
+	return nil
+}
+
+// proposalApp reverses the proposed txs and rejects any proposal containing
+// the "reject" tx.
+type proposalApp struct {
+	abci.BaseApplication
+}
+
+func (proposalApp) PrepareProposal(req abci.RequestPrepareProposal) abci.ResponsePrepareProposal {
+	txs := make([][]byte, 0, len(req.Txs))
+	for i := len(req.Txs) - 1; i >= 0; i-- {
+		if string(req.Txs[i]) == "drop" {
+			continue
+		}
+		txs = append(txs, req.Txs[i])
+	}
+	return abci.ResponsePrepareProposal{Txs: txs}
+}
+
+func (proposalApp) ProcessProposal(req abci.RequestProcessProposal) (res abci.ResponseProcessProposal) {
+	for _, tx := range req.Txs {
+		if string(tx) == "reject" {
+			res.Error = abci.StringError("rejected tx")
+		}
+	}
+	return
+}
+
+func TestCreateProposalBlock_PrepareProposal(t *testing.T) {
+	t.Parallel()
+
+	cc := proxy.NewLocalClientCreator(proposalApp{})
+	proxyApp := appconn.NewAppConns(cc)
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop()
+
+	state, stateDB, _ := makeState(1, 1)
+
+	mempool := reapMempool{txs: types.Txs{types.Tx("a"), types.Tx("drop"), types.Tx("b")}}
+	blockExec := sm.NewBlockExecutor(stateDB, log.NewTestingLogger(t), proxyApp.Consensus(), mempool)
+
+	block, _ := blockExec.CreateProposalBlock(1, state, new(types.Commit), state.Validators.GetProposer().Address)
+	assert.Equal(t, types.Txs{types.Tx("b"), types.Tx("a")}, block.Txs)
+}
+
+func TestCreateProposalBlock_PrepareProposalTooLarge(t *testing.T) {
+	t.Parallel()
+
+	// The prepared txs exceed MaxDataBytes: the reaped txs must be used
+	// unmodified.
+	cc := proxy.NewLocalClientCreator(proposalApp{})
+	proxyApp := appconn.NewAppConns(cc)
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop()
+
+	state, stateDB, _ := makeState(1, 1)
+	state.ConsensusParams.Block.MaxDataBytes = 1
+
+	mempool := reapMempool{txs: types.Txs{types.Tx("a"), types.Tx("b")}}
+	blockExec := sm.NewBlockExecutor(stateDB, log.NewTestingLogger(t), proxyApp.Consensus(), mempool)
+
+	block, _ := blockExec.CreateProposalBlock(1, state, new(types.Commit), state.Validators.GetProposer().Address)
+	assert.Equal(t, mempool.txs, block.Txs)
+}
+
+func TestCreateProposalBlock_PrepareProposalMaxGas(t *testing.T) {
+	t.Parallel()
+
+	// The prepared txs exceed MaxGas: the reaped txs must be used
+	// unmodified.
+	cc := proxy.NewLocalClientCreator(proposalApp{})
+	proxyApp := appconn.NewAppConns(cc)
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop()
+
+	state, stateDB, _ := makeState(1, 1)
+	state.ConsensusParams.Block.MaxGas = 1
+
+	mempool := reapMempool{txs: types.Txs{types.Tx("a"), types.Tx("b")}}
+	blockExec := sm.NewBlockExecutor(stateDB, log.NewTestingLogger(t), proxyApp.Consensus(), mempool)
+
+	block, _ := blockExec.CreateProposalBlock(1, state, new(types.Commit), state.Validators.GetProposer().Address)
+	assert.Equal(t, mempool.txs, block.Txs)
+}
+
+func TestProcessProposal(t *testing.T) {
+	t.Parallel()
+
+	cc := proxy.NewLocalClientCreator(proposalApp{})
+	proxyApp := appconn.NewAppConns(cc)
+	require.NoError(t, proxyApp.Start())
+	defer proxyApp.Stop()
+
+	state, stateDB, _ := makeState(1, 1)
+	blockExec := sm.NewBlockExecutor(stateDB, log.NewTestingLogger(t), proxyApp.Consensus(), mock.Mempool{})
+	proposer := state.Validators.GetProposer().Address
+
+	good, _ := state.MakeBlock(1, types.Txs{types.Tx("a")}, new(types.Commit), proposer)
+	assert.NoError(t, blockExec.ProcessProposal(good))
+
+	bad, _ := state.MakeBlock(1, types.Txs{types.Tx("a"), types.Tx("reject")}, new(types.Commit), proposer)
+	err := blockExec.ProcessProposal(bad)
+	require.Error(t, err)
+	assert.ErrorAs(t, err, &sm.ProposalRejectedError{})
+}
@@ -148,6 +148,31 @@ func (bs *BlockStore) LoadSeenCommit(height int64) *types.Commit {
 	return commit
 }
 
+// IterateBlocks calls fn with the meta and the block of each height from
+// start to end, both inclusive, in increasing order. A start below 1 means
+// the first height, and an end below 1 or above the height of the store
+// means the last one. Iteration stops at the first error returned by fn,
+// which is returned.
+func (bs *BlockStore) IterateBlocks(start, end int64, fn func(meta *types.BlockMeta, block *types.Block) error) error {
+	if start < 1 {
+		start = 1
+	}
+	if height := bs.Height(); end < 1 || end > height {
+		end = height
+	}
+	for height := start; height <= end; height++ {
+		meta := bs.LoadBlockMeta(height)
+		if meta == nil {
+			return fmt.Errorf("missing block at height %d", height)
+		}
+		block := bs.LoadBlock(height)
+		if err := fn(meta, block); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
 // SaveBlock persists the given block, blockParts, and seenCommit to the underlying db.
 // blockParts: Must be parts of the block
 // seenCommit: The +2/3 precommits that were seen which committed at height.
@@ -225,6 +250,17 @@ func calcSeenCommitKey(height int64) []byte {
 	return fmt.Appendf(nil, "SC:%v", height)
 }
 
+// BlockKeys returns the keys of the values saved for the block at height, made
+// of parts block parts: its meta, its parts, its commit and its seen commit.
+func BlockKeys(height int64, parts int) [][]byte {
+	keys := make([][]byte, 0, parts+3)
+	keys = append(keys, calcBlockMetaKey(height))
+	for i := range parts {
+		keys = append(keys, calcBlockPartKey(height, i))
+	}
+	return append(keys, calcBlockCommitKey(height), calcSeenCommitKey(height))
+}
+
 //-----------------------------------------------------------------------------
 
 var blockStoreKey = []byte("blockStore")
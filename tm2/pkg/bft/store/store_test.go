@@ -449,3 +449,58 @@ func newBlock(hdr types.Header, lastCommit *types.Commit) *types.Block {
 		LastCommit: lastCommit,
 	}
 }
+
+func TestIterateBlocks(t *testing.T) {
+	t.Parallel()
+
+	bs, _ := freshBlockStore()
+	for h := int64(1); h <= 5; h++ {
+		block := types.MakeBlock(h, makeTxs(h), new(types.Commit))
+		bs.SaveBlock(block, block.MakePartSet(2), makeTestCommit(h, tmtime.Now()))
+	}
+
+	collect := func(start, end int64) []int64 {
+		t.Helper()
+
+		var heights []int64
+		err := bs.IterateBlocks(start, end, func(meta *types.BlockMeta, block *types.Block) error {
+			require.Equal(t, meta.Header.Height, block.Height)
+			require.Equal(t, meta.BlockID.Hash, block.Hash())
+			heights = append(heights, block.Height)
+			return nil
+		})
+		require.NoError(t, err)
+		return heights
+	}
+
+	assert.Equal(t, []int64{1, 2, 3, 4, 5}, collect(0, 0))
+	assert.Equal(t, []int64{2, 3, 4}, collect(2, 4))
+	assert.Equal(t, []int64{4, 5}, collect(4, 10))
+	assert.Empty(t, collect(6, 0))
+
+	// Iteration stops at the first error.
+	errStop := errors.New("stop")
+	var heights []int64
+	err := bs.IterateBlocks(1, 0, func(_ *types.BlockMeta, block *types.Block) error {
+		heights = append(heights, block.Height)
+		if block.Height == 3 {
+			return errStop
+		}
+		return nil
+	})
+	assert.ErrorIs(t, err, errStop)
+	assert.Equal(t, []int64{1, 2, 3}, heights)
+}
+
+func TestBlockKeys(t *testing.T) {
+	t.Parallel()
+
+	keys := BlockKeys(3, 2)
+	assert.Equal(t, [][]byte{
+		calcBlockMetaKey(3),
+		calcBlockPartKey(3, 0),
+		calcBlockPartKey(3, 1),
+		calcBlockCommitKey(3),
+		calcSeenCommitKey(3),
+	}, keys)
+}
@@ -24,7 +24,7 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 
 		// Block types
 		Block{},
-		Header{},
+		&Header{}, // Decoded as a pointer, to implement abci.Header.
 		Data{},
 		// EvidenceData{},
 		Commit{},
@@ -3,6 +3,7 @@ package armor
 import (
 	"encoding/hex"
 	"fmt"
+	"strconv"
 
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/armor"
@@ -10,6 +11,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys/keyerror"
 	"github.com/gnolang/gno/tm2/pkg/crypto/xsalsa20symmetric"
 	"github.com/gnolang/gno/tm2/pkg/os"
+	"golang.org/x/crypto/argon2"
 )
 
 const (
@@ -17,8 +19,43 @@ const (
 	blockTypeKeyInfo        = "TENDERMINT KEY INFO"
 	blockTypePubKey         = "TENDERMINT PUBLIC KEY"
 	bcryptSecurityParameter = 12
+
+	kdfBcrypt   = "bcrypt"
+	kdfArgon2id = "argon2id"
+
+	maxArgon2Memory = 4 * 1024 * 1024 // 4GiB
 )
 
+// Argon2Params are the parameters of the argon2id key derivation used to
+// encrypt private keys.
+type Argon2Params struct {
+	Time    uint32 // number of iterations
+	Memory  uint32 // memory, in KiB
+	Threads uint8
+}
+
+// DefaultArgon2Params are the argon2id parameters recommended by RFC 9106
+// for memory-constrained environments.
+var DefaultArgon2Params = Argon2Params{
+	Time:    3,
+	Memory:  64 * 1024,
+	Threads: 4,
+}
+
+// Validate returns an error if the parameters can't be used.
+func (p Argon2Params) Validate() error {
+	if p.Time == 0 {
+		return fmt.Errorf("argon2 iterations must be positive")
+	}
+	if p.Threads == 0 {
+		return fmt.Errorf("argon2 threads must be positive")
+	}
+	if p.Memory < 8*uint32(p.Threads) {
+		return fmt.Errorf("argon2 memory must be at least 8KiB per thread")
+	}
+	return nil
+}
+
 // -----------------------------------------------------------------
 // add armor
 
@@ -72,23 +109,66 @@ func unarmorBytes(armorStr, blockType string) (bz []byte, err error) {
 // -----------------------------------------------------------------
 // encrypt/decrypt with armor
 
-// Encrypt and armor the private key.
+// Encrypt and armor the private key, deriving the encryption key with
+// DefaultArgon2Params.
 func EncryptArmorPrivKey(privKey crypto.PrivKey, passphrase string) string {
+	return EncryptArmorPrivKeyWithParams(privKey, passphrase, DefaultArgon2Params)
+}
+
+// Encrypt and armor the private key, deriving the encryption key with the
+// given argon2id parameters.
+func EncryptArmorPrivKeyWithParams(privKey crypto.PrivKey, passphrase string, params Argon2Params) string {
 	if passphrase == "" {
 		return ArmorPrivateKey(privKey)
 	}
-	saltBytes, encBytes := encryptPrivKey(privKey, passphrase)
+	if err := params.Validate(); err != nil {
+		panic(err)
+	}
+	saltBytes := crypto.CRandBytes(16)
+	key := argon2Key(saltBytes, passphrase, params)
+	encBytes := xsalsa20symmetric.EncryptSymmetric(privKey.Bytes(), key)
 	header := map[string]string{
-		"kdf":  "bcrypt",
-		"salt": fmt.Sprintf("%X", saltBytes),
+		"kdf":     kdfArgon2id,
+		"salt":    fmt.Sprintf("%X", saltBytes),
+		"time":    strconv.FormatUint(uint64(params.Time), 10),
+		"memory":  strconv.FormatUint(uint64(params.Memory), 10),
+		"threads": strconv.FormatUint(uint64(params.Threads), 10),
+	}
+	return armor.EncodeArmor(blockTypePrivKey, header, encBytes)
+}
+
+// NeedsUpgrade reports whether the armored private key should be encrypted
+// again, because it was encrypted with bcrypt or with argon2id parameters
+// weaker than params. Unencrypted keys never need it.
+func NeedsUpgrade(armorStr string, params Argon2Params) (bool, error) {
+	blockType, header, _, err := armor.DecodeArmor(armorStr)
+	if err != nil {
+		return false, err
+	}
+	if blockType != blockTypePrivKey {
+		return false, fmt.Errorf("unrecognized armor type: %v", blockType)
+	}
+	switch header["kdf"] {
+	case "":
+		return false, nil
+	case kdfBcrypt:
+		return true, nil
+	case kdfArgon2id:
+		current, err := parseArgon2Params(header)
+		if err != nil {
+			return false, err
+		}
+		return current.Time < params.Time || current.Memory < params.Memory, nil
+	default:
+		return false, fmt.Errorf("unrecognized KDF type: %v", header["kdf"])
 	}
-	armorStr := armor.EncodeArmor(blockTypePrivKey, header, encBytes)
-	return armorStr
 }
 
 // encrypt the given privKey with the passphrase using a randomly
-// generated salt and the xsalsa20 cipher. returns the salt and the
+// generated salt, bcrypt and the xsalsa20 cipher. returns the salt and the
 // encrypted priv key.
+// Keys are no longer encrypted this way, but those which were are still
+// decrypted.
 func encryptPrivKey(privKey crypto.PrivKey, passphrase string) (saltBytes []byte, encBytes []byte) {
 	saltBytes = crypto.CRandBytes(16)
 	key, err := bcrypt.GenerateFromPassword(saltBytes, []byte(passphrase), bcryptSecurityParameter)
@@ -114,9 +194,6 @@ func UnarmorDecryptPrivKey(armorStr string, passphrase string) (crypto.PrivKey,
 	if len(header) == 0 && passphrase == "" {
 		return crypto.PrivKeyFromBytes(encBytes)
 	}
-	if header["kdf"] != "bcrypt" {
-		return privKey, fmt.Errorf("unrecognized KDF type: %v", header["KDF"])
-	}
 	if header["salt"] == "" {
 		return privKey, fmt.Errorf("missing salt bytes")
 	}
@@ -124,16 +201,66 @@ func UnarmorDecryptPrivKey(armorStr string, passphrase string) (crypto.PrivKey,
 	if err != nil {
 		return privKey, fmt.Errorf("error decoding salt: %w", err)
 	}
-	privKey, err = decryptPrivKey(saltBytes, encBytes, passphrase)
+	var key []byte
+	switch header["kdf"] {
+	case kdfBcrypt:
+		key = bcryptKey(saltBytes, passphrase)
+	case kdfArgon2id:
+		params, err := parseArgon2Params(header)
+		if err != nil {
+			return privKey, err
+		}
+		key = argon2Key(saltBytes, passphrase, params)
+	default:
+		return privKey, fmt.Errorf("unrecognized KDF type: %v", header["kdf"])
+	}
+	privKey, err = decryptPrivKey(key, encBytes)
 	return privKey, err
 }
 
-func decryptPrivKey(saltBytes []byte, encBytes []byte, passphrase string) (privKey crypto.PrivKey, err error) {
+func bcryptKey(saltBytes []byte, passphrase string) []byte {
 	key, err := bcrypt.GenerateFromPassword(saltBytes, []byte(passphrase), bcryptSecurityParameter)
 	if err != nil {
 		os.Exit("Error generating bcrypt key from passphrase: " + err.Error())
 	}
-	key = crypto.Sha256(key) // Get 32 bytes
+	return crypto.Sha256(key) // Get 32 bytes
+}
+
+func argon2Key(saltBytes []byte, passphrase string, params Argon2Params) []byte {
+	return argon2.IDKey([]byte(passphrase), saltBytes, params.Time, params.Memory, params.Threads, 32)
+}
+
+// parseArgon2Params parses the argon2id parameters of an armor header.
+func parseArgon2Params(header map[string]string) (params Argon2Params, err error) {
+	time, err := strconv.ParseUint(header["time"], 10, 32)
+	if err != nil {
+		return params, fmt.Errorf("error decoding argon2 time: %w", err)
+	}
+	memory, err := strconv.ParseUint(header["memory"], 10, 32)
+	if err != nil {
+		return params, fmt.Errorf("error decoding argon2 memory: %w", err)
+	}
+	threads, err := strconv.ParseUint(header["threads"], 10, 8)
+	if err != nil {
+		return params, fmt.Errorf("error decoding argon2 threads: %w", err)
+	}
+	params = Argon2Params{
+		Time:    uint32(time),
+		Memory:  uint32(memory),
+		Threads: uint8(threads),
+	}
+	// Armors are read from files which may be crafted, so the parameters
+	// are bounded to avoid exhausting the memory.
+	if err := params.Validate(); err != nil {
+		return params, err
+	}
+	if params.Memory > maxArgon2Memory {
+		return params, fmt.Errorf("argon2 memory greater than %dKiB", maxArgon2Memory)
+	}
+	return params, nil
+}
+
+func decryptPrivKey(key []byte, encBytes []byte) (privKey crypto.PrivKey, err error) {
 	privKeyBytes, err := xsalsa20symmetric.DecryptSymmetric(encBytes, key)
 	if err != nil && err.Error() == "ciphertext decryption failed" {
 		return privKey, keyerror.NewErrWrongPassword()
@@ -31,3 +31,27 @@ func TestArmorUnarmor_PrivKey_EmptyPassBackward(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, priv.Equals(decrypted))
 }
+
+func TestArmorUnarmor_PrivKey_BcryptUpgrade(t *testing.T) {
+	t.Parallel()
+
+	// Keys encrypted with bcrypt, before argon2id was used, can still be
+	// decrypted, and need to be upgraded.
+	priv := secp256k1.GenPrivKey()
+	saltBytes, encBytes := encryptPrivKey(priv, "passphrase")
+	header := map[string]string{
+		"kdf":  "bcrypt",
+		"salt": fmt.Sprintf("%X", saltBytes),
+	}
+	armorStr := armor.EncodeArmor(blockTypePrivKey, header, encBytes)
+
+	_, err := UnarmorDecryptPrivKey(armorStr, "wrongpassphrase")
+	require.Error(t, err)
+	decrypted, err := UnarmorDecryptPrivKey(armorStr, "passphrase")
+	require.NoError(t, err)
+	require.True(t, priv.Equals(decrypted))
+
+	upgrade, err := NeedsUpgrade(armorStr, DefaultArgon2Params)
+	require.NoError(t, err)
+	require.True(t, upgrade)
+}
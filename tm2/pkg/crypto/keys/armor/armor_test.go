@@ -1,6 +1,7 @@
 package armor_test
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/require"
@@ -53,3 +54,38 @@ func TestArmorUnarmor_PubKey(t *testing.T) {
 	require.NoError(t, err)
 	require.True(t, pub.Equals(info.GetPubKey()))
 }
+
+func TestArmorUnarmor_PrivKey_Argon2Params(t *testing.T) {
+	t.Parallel()
+
+	priv := secp256k1.GenPrivKey()
+	weak := armor.Argon2Params{Time: 1, Memory: 1024, Threads: 1}
+	astr := armor.EncryptArmorPrivKeyWithParams(priv, "passphrase", weak)
+	_, err := armor.UnarmorDecryptPrivKey(astr, "wrongpassphrase")
+	require.Error(t, err)
+	decrypted, err := armor.UnarmorDecryptPrivKey(astr, "passphrase")
+	require.NoError(t, err)
+	require.True(t, priv.Equals(decrypted))
+
+	upgrade, err := armor.NeedsUpgrade(astr, weak)
+	require.NoError(t, err)
+	require.False(t, upgrade)
+	upgrade, err = armor.NeedsUpgrade(astr, armor.DefaultArgon2Params)
+	require.NoError(t, err)
+	require.True(t, upgrade)
+
+	// Unencrypted keys are never upgraded.
+	upgrade, err = armor.NeedsUpgrade(armor.EncryptArmorPrivKey(priv, ""), armor.DefaultArgon2Params)
+	require.NoError(t, err)
+	require.False(t, upgrade)
+}
+
+func TestUnarmorDecryptPrivKey_Argon2MaxMemory(t *testing.T) {
+	t.Parallel()
+
+	priv := secp256k1.GenPrivKey()
+	astr := armor.EncryptArmorPrivKeyWithParams(priv, "passphrase", armor.Argon2Params{Time: 1, Memory: 1024, Threads: 1})
+	astr = strings.Replace(astr, "memory: 1024", "memory: 1073741824", 1)
+	_, err := armor.UnarmorDecryptPrivKey(astr, "passphrase")
+	require.ErrorContains(t, err, "argon2 memory greater than")
+}
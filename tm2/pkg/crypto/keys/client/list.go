@@ -8,39 +8,62 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 )
 
+type ListCfg struct {
+	RootCfg *BaseCfg
+
+	QR bool
+}
+
 func NewListCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cfg := &ListCfg{
+		RootCfg: rootCfg,
+	}
+
 	return commands.NewCommand(
 		commands.Metadata{
 			Name:       "list",
-			ShortUsage: "list",
+			ShortUsage: "list [flags]",
 			ShortHelp:  "lists all keys in the keybase",
 		},
-		nil,
+		cfg,
 		func(_ context.Context, args []string) error {
-			return execList(rootCfg, args, io)
+			return execList(cfg, args, io)
 		},
 	)
 }
 
-func execList(cfg *BaseCfg, args []string, io commands.IO) error {
+func (c *ListCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.BoolVar(
+		&c.QR,
+		"qr",
+		false,
+		"print the address of each key as a QR code",
+	)
+}
+
+func execList(cfg *ListCfg, args []string, io commands.IO) error {
 	if len(args) != 0 {
 		return flag.ErrHelp
 	}
 
-	kb, err := keys.NewKeyBaseFromDir(cfg.Home)
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
 	if err != nil {
 		return err
 	}
 
 	infos, err := kb.List()
-	if err == nil {
-		printInfos(infos, io)
+	if err != nil {
+		return err
+	}
+	networks, err := kb.ListNetworks()
+	if err != nil {
+		return err
 	}
 
-	return err
+	return printInfos(infos, networks, cfg.QR, io)
 }
 
-func printInfos(infos []keys.Info, io commands.IO) {
+func printInfos(infos []keys.Info, networks []keys.Network, qr bool, io commands.IO) error {
 	for i, info := range infos {
 		keyname := info.GetName()
 		keytype := info.GetType()
@@ -49,5 +72,19 @@ func printInfos(infos []keys.Info, io commands.IO) {
 		keypath, _ := info.GetPath()
 		io.Printfln("%d. %s (%s) - addr: %v pub: %v, path: %v",
 			i, keyname, keytype, keyaddr, keypub, keypath)
+		for _, network := range networks {
+			bech32Addr, err := network.Address(keyaddr)
+			if err != nil {
+				io.Printfln("   %s: %v", network.Name, err)
+				continue
+			}
+			io.Printfln("   %s: %s", network.Name, bech32Addr)
+		}
+		if qr {
+			if err := printQR(io, keyaddr.String()); err != nil {
+				return err
+			}
+		}
 	}
+	return nil
 }
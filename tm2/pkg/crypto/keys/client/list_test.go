@@ -36,9 +36,11 @@ func Test_execList(t *testing.T) {
 	for _, tt := range testData {
 		t.Run(tt.name, func(t *testing.T) {
 			// Set current home
-			cfg := &BaseCfg{
-				BaseOptions: BaseOptions{
-					Home: tt.kbDir,
+			cfg := &ListCfg{
+				RootCfg: &BaseCfg{
+					BaseOptions: BaseOptions{
+						Home: tt.kbDir,
+					},
 				},
 			}
 
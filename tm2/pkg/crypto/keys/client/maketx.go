@@ -8,6 +8,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/amino"
 	types "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
 	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -24,6 +25,10 @@ type MakeTxCfg struct {
 	// Valid options are SimulateTest, SimulateSkip or SimulateOnly.
 	Simulate string
 	ChainID  string
+
+	// QR prints the unsigned tx as a QR code, for signing it on an
+	// air-gapped machine.
+	QR bool
 }
 
 // These are the valid options for MakeTxConfig.Simulate.
@@ -109,6 +114,24 @@ func (c *MakeTxCfg) RegisterFlags(fs *flag.FlagSet) {
 		"dev",
 		"chainid to sign for (only useful with --broadcast)",
 	)
+
+	fs.BoolVar(
+		&c.QR,
+		"qr",
+		false,
+		"print the unsigned tx as a QR code (ignored with --broadcast)",
+	)
+}
+
+// PrintTx prints the unsigned tx document, as JSON or as a QR code of the
+// JSON if cfg.QR is set.
+func PrintTx(cfg *MakeTxCfg, tx std.Tx, io commands.IO) error {
+	txJSON := string(amino.MustMarshalJSON(tx))
+	if cfg.QR {
+		return printQR(io, txJSON)
+	}
+	io.Println(txJSON)
+	return nil
 }
 
 func SignAndBroadcastHandler(
@@ -131,23 +154,14 @@ func SignAndBroadcastHandler(
 	}
 	accountAddr := info.GetAddress()
 
-	qopts := &QueryCfg{
-		RootCfg: baseopts,
-		Path:    fmt.Sprintf("auth/accounts/%s", accountAddr),
-	}
-	qres, err := QueryHandler(qopts)
-	if err != nil {
-		return nil, errors.Wrap(err, "query account")
-	}
-	var qret struct{ BaseAccount std.BaseAccount }
-	err = amino.UnmarshalJSON(qres.Response.Data, &qret)
+	account, err := queryBaseAccount(baseopts, accountAddr)
 	if err != nil {
 		return nil, err
 	}
 
 	// sign tx
-	accountNumber := qret.BaseAccount.AccountNumber
-	sequence := qret.BaseAccount.Sequence
+	accountNumber := account.AccountNumber
+	sequence := account.Sequence
 
 	sOpts := signOpts{
 		chainID:         txopts.ChainID,
@@ -183,6 +197,24 @@ func SignAndBroadcastHandler(
 	return BroadcastHandler(bopts)
 }
 
+// queryBaseAccount queries the account of addr, for its number and sequence.
+func queryBaseAccount(cfg *BaseCfg, addr crypto.Address) (std.BaseAccount, error) {
+	qopts := &QueryCfg{
+		RootCfg: cfg,
+		Path:    fmt.Sprintf("auth/accounts/%s", addr),
+	}
+	qres, err := QueryHandler(qopts)
+	if err != nil {
+		return std.BaseAccount{}, errors.Wrap(err, "query account")
+	}
+	var qret struct{ BaseAccount std.BaseAccount }
+	err = amino.UnmarshalJSON(qres.Response.Data, &qret)
+	if err != nil {
+		return std.BaseAccount{}, err
+	}
+	return qret.BaseAccount, nil
+}
+
 func ExecSignAndBroadcast(
 	cfg *MakeTxCfg,
 	args []string,
@@ -0,0 +1,173 @@
+package client
+
+import (
+	"context"
+	"flag"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+)
+
+func NewNetworkCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cmd := commands.NewCommand(
+		commands.Metadata{
+			Name:       "network",
+			ShortUsage: "network <subcommand> [flags] [<arg>...]",
+			ShortHelp:  "manages the networks the keys are used on",
+			LongHelp: "Manages the networks the keys of the keybase are used on. " +
+				"A key has the same address on every network, displayed with the " +
+				"bech32 prefix of each network; addresses with the prefix of any " +
+				"network are accepted in place of a key name.",
+		},
+		commands.NewEmptyConfig(),
+		commands.HelpExec,
+	)
+
+	cmd.AddSubCommands(
+		NewNetworkAddCmd(rootCfg, io),
+		NewNetworkListCmd(rootCfg, io),
+		NewNetworkDeleteCmd(rootCfg, io),
+	)
+
+	return cmd
+}
+
+type NetworkAddCfg struct {
+	RootCfg *BaseCfg
+
+	Prefix  string
+	ChainID string
+	Remote  string
+}
+
+func NewNetworkAddCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cfg := &NetworkAddCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "add",
+			ShortUsage: "network add [flags] <name>",
+			ShortHelp:  "adds or replaces a network",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execNetworkAdd(cfg, args, io)
+		},
+	)
+}
+
+func (c *NetworkAddCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Prefix,
+		"prefix",
+		"",
+		"bech32 prefix of the addresses (required)",
+	)
+
+	fs.StringVar(
+		&c.ChainID,
+		"chainid",
+		"",
+		"chain ID of the network",
+	)
+
+	fs.StringVar(
+		&c.Remote,
+		"rpc",
+		"",
+		"remote node URL of the network",
+	)
+}
+
+func execNetworkAdd(cfg *NetworkAddCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	if err != nil {
+		return err
+	}
+
+	network := keys.Network{
+		Name:    args[0],
+		Prefix:  cfg.Prefix,
+		ChainID: cfg.ChainID,
+		Remote:  cfg.Remote,
+	}
+	if err := kb.SetNetwork(network); err != nil {
+		return err
+	}
+
+	io.Printfln("Network %s added", network.Name)
+	return nil
+}
+
+func NewNetworkListCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "list",
+			ShortUsage: "network list",
+			ShortHelp:  "lists the networks",
+		},
+		nil,
+		func(_ context.Context, args []string) error {
+			return execNetworkList(rootCfg, args, io)
+		},
+	)
+}
+
+func execNetworkList(cfg *BaseCfg, args []string, io commands.IO) error {
+	if len(args) != 0 {
+		return flag.ErrHelp
+	}
+
+	kb, err := keys.NewKeyBaseFromDir(cfg.Home)
+	if err != nil {
+		return err
+	}
+
+	networks, err := kb.ListNetworks()
+	if err != nil {
+		return err
+	}
+	for i, network := range networks {
+		io.Printfln("%d. %s - prefix: %s chainid: %s remote: %s",
+			i, network.Name, network.Prefix, network.ChainID, network.Remote)
+	}
+	return nil
+}
+
+func NewNetworkDeleteCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "delete",
+			ShortUsage: "network delete <name>",
+			ShortHelp:  "deletes a network",
+		},
+		nil,
+		func(_ context.Context, args []string) error {
+			return execNetworkDelete(rootCfg, args, io)
+		},
+	)
+}
+
+func execNetworkDelete(cfg *BaseCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	kb, err := keys.NewKeyBaseFromDir(cfg.Home)
+	if err != nil {
+		return err
+	}
+
+	if err := kb.DeleteNetwork(args[0]); err != nil {
+		return err
+	}
+
+	io.Printfln("Network %s deleted", args[0])
+	return nil
+}
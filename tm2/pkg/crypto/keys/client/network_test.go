@@ -0,0 +1,65 @@
+package client
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_execNetwork(t *testing.T) {
+	t.Parallel()
+
+	kbHome, kbCleanUp := testutils.NewTestCaseDir(t)
+	defer kbCleanUp()
+
+	baseCfg := &BaseCfg{
+		BaseOptions: BaseOptions{
+			Home: kbHome,
+		},
+	}
+
+	kb, err := keys.NewKeyBaseFromDir(kbHome)
+	require.NoError(t, err)
+	info, err := kb.CreateAccount("key1", testMnemonic, "", "", 0, 0)
+	require.NoError(t, err)
+
+	// missing prefix.
+	addCfg := &NetworkAddCfg{RootCfg: baseCfg}
+	err = execNetworkAdd(addCfg, []string{"testnet"}, commands.NewTestIO())
+	require.Error(t, err)
+
+	addCfg.Prefix = "gt"
+	addCfg.ChainID = "test5"
+	err = execNetworkAdd(addCfg, []string{"testnet"}, commands.NewTestIO())
+	require.NoError(t, err)
+
+	testnet, err := kb.GetNetwork("testnet")
+	require.NoError(t, err)
+	assert.Equal(t, "test5", testnet.ChainID)
+
+	// networks are listed.
+	io := commands.NewTestIO()
+	out := new(bytes.Buffer)
+	io.SetOut(commands.WriteNopCloser(out))
+	require.NoError(t, execNetworkList(baseCfg, nil, io))
+	assert.Equal(t, "0. testnet - prefix: gt chainid: test5 remote: \n", out.String())
+
+	// keys are listed with their address on each network.
+	out.Reset()
+	require.NoError(t, execList(&ListCfg{RootCfg: baseCfg}, nil, io))
+	testnetAddr, err := testnet.Address(info.GetAddress())
+	require.NoError(t, err)
+	assert.Contains(t, out.String(), "   testnet: "+testnetAddr+"\n")
+
+	// deleting.
+	require.NoError(t, execNetworkDelete(baseCfg, []string{"testnet"}, commands.NewTestIO()))
+	require.Error(t, execNetworkDelete(baseCfg, []string{"testnet"}, commands.NewTestIO()))
+	out.Reset()
+	require.NoError(t, execList(&ListCfg{RootCfg: baseCfg}, nil, io))
+	assert.NotContains(t, out.String(), "testnet")
+}
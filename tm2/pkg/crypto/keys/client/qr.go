@@ -0,0 +1,54 @@
+package client
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"rsc.io/qr"
+)
+
+const (
+	// qrQuietZone is the number of light modules around the code, as
+	// required by the QR code specification for it to be scanned.
+	qrQuietZone = 4
+
+	// The code is drawn dark on light using ANSI colors, so that it scans
+	// whatever the colors of the terminal.
+	qrColorStart = "\x1b[30;107m" // black on bright white
+	qrColorEnd   = "\x1b[0m"
+)
+
+// printQR prints data as a QR code in the terminal, for air-gapped workflows
+// where it is scanned by another device. Each line of text holds two rows of
+// modules, drawn with half blocks.
+func printQR(io commands.IO, data string) error {
+	code, err := qr.Encode(data, qr.L)
+	if err != nil {
+		return fmt.Errorf("unable to encode %d bytes as a QR code: %w", len(data), err)
+	}
+
+	lo, hi := -qrQuietZone, code.Size+qrQuietZone
+	var sb strings.Builder
+	for y := lo; y < hi; y += 2 {
+		sb.Reset()
+		sb.WriteString(qrColorStart)
+		for x := lo; x < hi; x++ {
+			// Black returns false outside of the code, ie. in the quiet zone.
+			top, bottom := code.Black(x, y), y+1 < hi && code.Black(x, y+1)
+			switch {
+			case top && bottom:
+				sb.WriteString("█")
+			case top:
+				sb.WriteString("▀")
+			case bottom:
+				sb.WriteString("▄")
+			default:
+				sb.WriteString(" ")
+			}
+		}
+		sb.WriteString(qrColorEnd)
+		io.Println(sb.String())
+	}
+	return nil
+}
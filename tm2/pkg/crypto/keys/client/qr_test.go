@@ -0,0 +1,57 @@
+package client
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"rsc.io/qr"
+)
+
+func TestPrintQR(t *testing.T) {
+	t.Parallel()
+
+	t.Run("valid data", func(t *testing.T) {
+		t.Parallel()
+
+		const data = "g1jg8mtutu9khhfwc4nxmuhcpftf0pajdhfvsqf5"
+		io := commands.NewTestIO()
+		out := new(bytes.Buffer)
+		io.SetOut(commands.WriteNopCloser(out))
+		require.NoError(t, printQR(io, data))
+
+		code, err := qr.Encode(data, qr.L)
+		require.NoError(t, err)
+		size := code.Size + 2*qrQuietZone
+
+		// Decode the half blocks back into modules, and compare them
+		// with the encoded code, quiet zone included.
+		lines := strings.Split(strings.TrimSuffix(out.String(), "\n"), "\n")
+		require.Len(t, lines, (size+1)/2)
+		for i, line := range lines {
+			require.True(t, strings.HasPrefix(line, qrColorStart))
+			require.True(t, strings.HasSuffix(line, qrColorEnd))
+			line = strings.TrimSuffix(strings.TrimPrefix(line, qrColorStart), qrColorEnd)
+
+			runes := []rune(line)
+			require.Len(t, runes, size)
+			for j, r := range runes {
+				x, y := j-qrQuietZone, 2*i-qrQuietZone
+				top := r == '█' || r == '▀'
+				bottom := r == '█' || r == '▄'
+				assert.Equal(t, code.Black(x, y), top, "module (%d, %d)", x, y)
+				assert.Equal(t, code.Black(x, y+1), bottom, "module (%d, %d)", x, y+1)
+			}
+		}
+	})
+
+	t.Run("data too long", func(t *testing.T) {
+		t.Parallel()
+
+		err := printQR(commands.NewTestIO(), strings.Repeat("a", 8000))
+		assert.ErrorContains(t, err, "unable to encode 8000 bytes as a QR code")
+	})
+}
@@ -3,6 +3,7 @@ package client
 import (
 	"context"
 	"flag"
+	"strings"
 
 	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
 	ctypes "github.com/gnolang/gno/tm2/pkg/bft/rpc/core/types"
@@ -24,7 +25,7 @@ func NewQueryCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
 		RootCfg: rootCfg,
 	}
 
-	return commands.NewCommand(
+	cmd := commands.NewCommand(
 		commands.Metadata{
 			Name:       "query",
 			ShortUsage: "query [flags] <path>",
@@ -35,6 +36,13 @@ func NewQueryCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
 			return execQuery(cfg, args, io)
 		},
 	)
+
+	cmd.AddSubCommands(
+		NewQueryAccountsCmd(cfg, io),
+		NewQueryJournalCmd(cfg, io),
+	)
+
+	return cmd
 }
 
 func (c *QueryCfg) RegisterFlags(fs *flag.FlagSet) {
@@ -85,6 +93,20 @@ func execQuery(cfg *QueryCfg, args []string, io commands.IO) error {
 	io.Printf("height: %d\ndata: %s\n",
 		height,
 		string(resdata))
+
+	// The balances of derived addresses, like those of realms, are held by
+	// their owner rather than by a user.
+	if b32addr, ok := strings.CutPrefix(cfg.Path, "bank/balances/"); ok {
+		ocfg := *cfg
+		ocfg.Path = "bank/qaddr-owner/" + b32addr
+		ores, err := QueryHandler(&ocfg)
+		if err != nil {
+			return err
+		}
+		if owner := ores.Response.Data; ores.Response.Error == nil && len(owner) > 0 {
+			io.Printf("owner: %s\n", string(owner))
+		}
+	}
 	return nil
 }
 
@@ -0,0 +1,133 @@
+package client
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+type QueryAccountsCfg struct {
+	QueryCfg *QueryCfg
+
+	Start            string
+	Limit            int
+	Prefix           string
+	MinBalance       string
+	MinAccountNumber uint64
+	CreatedAfter     int64
+}
+
+func NewQueryAccountsCmd(queryCfg *QueryCfg, io commands.IO) *commands.Command {
+	cfg := &QueryAccountsCfg{
+		QueryCfg: queryCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "accounts",
+			ShortUsage: "query accounts [flags]",
+			ShortHelp:  "lists accounts page by page",
+			LongHelp: "Lists the accounts matching the given filters, in address order. " +
+				"When accounts remain to be listed, the address to pass as -start " +
+				"to fetch the next page is printed. As the number of accounts read " +
+				"per query is capped, a page may be incomplete before the last one.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execQueryAccounts(cfg, args, io)
+		},
+	)
+}
+
+func (c *QueryAccountsCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Start,
+		"start",
+		"",
+		"address of the first account of the page",
+	)
+
+	fs.IntVar(
+		&c.Limit,
+		"limit",
+		auth.DefaultAccountsLimit,
+		fmt.Sprintf("maximum number of accounts listed (max %d)", auth.MaxAccountsLimit),
+	)
+
+	fs.StringVar(
+		&c.Prefix,
+		"prefix",
+		"",
+		"only list accounts whose address starts with this prefix",
+	)
+
+	fs.StringVar(
+		&c.MinBalance,
+		"min-balance",
+		"",
+		"only list accounts holding at least these coins (ex. 1000ugnot)",
+	)
+
+	fs.Uint64Var(
+		&c.MinAccountNumber,
+		"min-account-number",
+		0,
+		"only list accounts with at least this account number (ie. created after it)",
+	)
+
+	fs.Int64Var(
+		&c.CreatedAfter,
+		"created-after-height",
+		0,
+		"only list accounts created after this height",
+	)
+}
+
+func execQueryAccounts(cfg *QueryAccountsCfg, args []string, io commands.IO) error {
+	if len(args) != 0 {
+		return flag.ErrHelp
+	}
+
+	minBalance, err := std.ParseCoins(cfg.MinBalance)
+	if err != nil {
+		return errors.Wrap(err, "parsing min balance")
+	}
+
+	data, err := amino.MarshalJSON(auth.AccountsQuery{
+		Start:              cfg.Start,
+		Limit:              cfg.Limit,
+		Prefix:             cfg.Prefix,
+		MinBalance:         minBalance,
+		MinAccountNumber:   cfg.MinAccountNumber,
+		CreatedAfterHeight: cfg.CreatedAfter,
+	})
+	if err != nil {
+		return errors.Wrap(err, "encoding query")
+	}
+
+	qcfg := *cfg.QueryCfg
+	qcfg.Path = "auth/" + auth.QueryAccount
+	qcfg.Data = string(data)
+
+	qres, err := QueryHandler(&qcfg)
+	if err != nil {
+		return err
+	}
+
+	if qres.Response.Error != nil {
+		io.Printf("Log: %s\n",
+			qres.Response.Log)
+		return qres.Response.Error
+	}
+
+	io.Printf("height: %d\ndata: %s\n",
+		qres.Response.Height,
+		string(qres.Response.Data))
+	return nil
+}
@@ -0,0 +1,189 @@
+package client
+
+import (
+	"context"
+	"encoding/csv"
+	"flag"
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+)
+
+type QueryJournalCfg struct {
+	QueryCfg *QueryCfg
+
+	FromHeight int64
+	ToHeight   int64
+	After      uint64
+	Limit      int
+	CSV        bool
+}
+
+func NewQueryJournalCmd(queryCfg *QueryCfg, io commands.IO) *commands.Command {
+	cfg := &QueryJournalCfg{
+		QueryCfg: queryCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "journal",
+			ShortUsage: "query journal [flags] <address>",
+			ShortHelp:  "lists the balance changes of an account",
+			LongHelp: "Lists the entries of the journal of an account, recording the changes " +
+				"of its balance, in order. The journal is only recorded when enabled by the " +
+				"bank journal param. With -csv, all the entries between -from-height and " +
+				"-to-height are exported as CSV, for account statements; otherwise a page of " +
+				"entries is printed, and the next one is fetched with the height and the ID " +
+				"of its last entry as -from-height and -after.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execQueryJournal(cfg, args, io)
+		},
+	)
+}
+
+func (c *QueryJournalCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.Int64Var(
+		&c.FromHeight,
+		"from-height",
+		0,
+		"height of the first entries",
+	)
+
+	fs.Int64Var(
+		&c.ToHeight,
+		"to-height",
+		0,
+		"height of the last entries; the latest if zero",
+	)
+
+	fs.Uint64Var(
+		&c.After,
+		"after",
+		0,
+		"only list the entries with a greater ID",
+	)
+
+	fs.IntVar(
+		&c.Limit,
+		"limit",
+		bank.DefaultJournalLimit,
+		fmt.Sprintf("maximum number of entries listed per query (max %d)", bank.MaxJournalLimit),
+	)
+
+	fs.BoolVar(
+		&c.CSV,
+		"csv",
+		false,
+		"export all the entries as CSV",
+	)
+}
+
+func execQueryJournal(cfg *QueryJournalCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	addr, err := crypto.AddressFromBech32(args[0])
+	if err != nil {
+		return errors.Wrap(err, "parsing address")
+	}
+
+	q := bank.JournalQuery{
+		FromHeight: cfg.FromHeight,
+		ToHeight:   cfg.ToHeight,
+		After:      cfg.After,
+		Limit:      cfg.Limit,
+	}
+
+	qcfg := *cfg.QueryCfg
+	qcfg.Path = fmt.Sprintf("bank/%s/%s", bank.QueryJournal, addr)
+
+	if !cfg.CSV {
+		data, err := amino.MarshalJSON(q)
+		if err != nil {
+			return errors.Wrap(err, "encoding query")
+		}
+		qcfg.Data = string(data)
+
+		qres, err := QueryHandler(&qcfg)
+		if err != nil {
+			return err
+		}
+		if qres.Response.Error != nil {
+			io.Printf("Log: %s\n",
+				qres.Response.Log)
+			return qres.Response.Error
+		}
+
+		io.Printf("height: %d\ndata: %s\n",
+			qres.Response.Height,
+			string(qres.Response.Data))
+		return nil
+	}
+
+	w := csv.NewWriter(io.Out())
+	w.Write([]string{ //nolint:errcheck
+		"id", "op", "height", "time", "kind", "account", "counterparty", "debit", "credit", "balance",
+	})
+	for {
+		data, err := amino.MarshalJSON(q)
+		if err != nil {
+			return errors.Wrap(err, "encoding query")
+		}
+		qcfg.Data = string(data)
+
+		qres, err := QueryHandler(&qcfg)
+		if err != nil {
+			return err
+		}
+		if qres.Response.Error != nil {
+			return qres.Response.Error
+		}
+		// Query the next pages at the same height, for a consistent export.
+		qcfg.Height = qres.Response.Height
+
+		var result bank.JournalResult
+		if err := amino.UnmarshalJSON(qres.Response.Data, &result); err != nil {
+			return errors.Wrap(err, "decoding journal")
+		}
+		for _, entry := range result.Entries {
+			w.Write(journalEntryRecord(entry)) //nolint:errcheck
+		}
+		if !result.More || len(result.Entries) == 0 {
+			break
+		}
+
+		last := result.Entries[len(result.Entries)-1]
+		q.FromHeight, q.After = last.Height, last.ID
+	}
+	w.Flush()
+	return w.Error()
+}
+
+// journalEntryRecord returns the CSV record of entry.
+func journalEntryRecord(entry bank.JournalEntry) []string {
+	var counterparty string
+	if !entry.Counterparty.IsZero() {
+		counterparty = entry.Counterparty.String()
+	}
+	return []string{
+		strconv.FormatUint(entry.ID, 10),
+		strconv.FormatUint(entry.Op, 10),
+		strconv.FormatInt(entry.Height, 10),
+		time.Unix(entry.Time, 0).UTC().Format(time.RFC3339),
+		entry.Kind,
+		entry.Account.String(),
+		counterparty,
+		entry.Debit.String(),
+		entry.Credit.String(),
+		entry.Balance.String(),
+	}
+}
@@ -0,0 +1,519 @@
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/base64"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"strconv"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bft/rpc/client"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+const outboxDir = "outbox"
+
+var errQueueConflict = errors.New("queued drafts conflict with the account")
+
+// Draft is a composed, unsigned transaction waiting in the outbox of a key.
+type Draft struct {
+	ID      uint64    `json:"id"`
+	ChainID string    `json:"chain_id"`
+	Added   time.Time `json:"added"`
+	// The account sequence the draft must be signed with, if pinned.
+	// Otherwise it gets the next sequence of the account when flushed.
+	Pinned   bool   `json:"pinned"`
+	Sequence uint64 `json:"sequence"`
+	Tx       std.Tx `json:"tx"`
+}
+
+// Outbox is the local queue of the drafts of a key, stored in the home
+// directory so that they can be signed and broadcast once the node can be
+// reached.
+type Outbox struct {
+	NextID uint64  `json:"next_id"`
+	Drafts []Draft `json:"drafts"`
+
+	path string
+}
+
+// outboxPath returns the path of the outbox of addr in the home directory.
+func outboxPath(home string, addr crypto.Address) string {
+	return filepath.Join(home, outboxDir, addr.String()+".json")
+}
+
+// loadOutbox loads the outbox of addr, which is empty if it doesn't exist.
+func loadOutbox(home string, addr crypto.Address) (*Outbox, error) {
+	ob := &Outbox{path: outboxPath(home, addr)}
+	bz, err := os.ReadFile(ob.path)
+	if os.IsNotExist(err) {
+		return ob, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("unable to read outbox, %w", err)
+	}
+	if err := amino.UnmarshalJSON(bz, ob); err != nil {
+		return nil, fmt.Errorf("unable to unmarshal outbox %s, %w", ob.path, err)
+	}
+	return ob, nil
+}
+
+// save writes the outbox to disk, replacing the previous one at once.
+func (ob *Outbox) save() error {
+	bz, err := amino.MarshalJSONIndent(ob, "", "  ")
+	if err != nil {
+		return fmt.Errorf("unable to marshal outbox, %w", err)
+	}
+	if err := os.MkdirAll(filepath.Dir(ob.path), 0o700); err != nil {
+		return fmt.Errorf("unable to create outbox directory, %w", err)
+	}
+	tmp := ob.path + ".tmp"
+	if err := os.WriteFile(tmp, bz, 0o600); err != nil {
+		return fmt.Errorf("unable to write outbox, %w", err)
+	}
+	return os.Rename(tmp, ob.path)
+}
+
+// Add queues tx as a new draft, and returns it. It fails if the same tx is
+// already queued for the same chain.
+func (ob *Outbox) Add(chainID string, tx std.Tx, sequence *uint64) (Draft, error) {
+	bz := amino.MustMarshal(tx)
+	for _, d := range ob.Drafts {
+		if d.ChainID == chainID && bytes.Equal(amino.MustMarshal(d.Tx), bz) {
+			return Draft{}, fmt.Errorf("tx already queued as draft #%d", d.ID)
+		}
+	}
+
+	ob.NextID++
+	d := Draft{
+		ID:      ob.NextID,
+		ChainID: chainID,
+		Added:   time.Now().UTC(),
+		Tx:      tx,
+	}
+	if sequence != nil {
+		d.Pinned = true
+		d.Sequence = *sequence
+	}
+	ob.Drafts = append(ob.Drafts, d)
+	return d, nil
+}
+
+// Remove removes the draft with the given id.
+func (ob *Outbox) Remove(id uint64) error {
+	for i, d := range ob.Drafts {
+		if d.ID == id {
+			ob.Drafts = append(ob.Drafts[:i], ob.Drafts[i+1:]...)
+			return nil
+		}
+	}
+	return fmt.Errorf("draft #%d not found", id)
+}
+
+// Conflicts returns the reasons why the drafts can't be broadcast in order
+// on the chain chainID, by an account at the given sequence: a draft
+// composed for another chain, or pinned to a sequence other than the one
+// it gets after the previous drafts, e.g. because a transaction was sent
+// in the meantime.
+func (ob *Outbox) Conflicts(chainID string, sequence uint64) []string {
+	var conflicts []string
+	for i, d := range ob.Drafts {
+		if d.ChainID != chainID {
+			conflicts = append(conflicts, fmt.Sprintf(
+				"draft #%d was composed for chain %q, the node is on %q",
+				d.ID, d.ChainID, chainID))
+		}
+		if seq := sequence + uint64(i); d.Pinned && d.Sequence != seq {
+			conflicts = append(conflicts, fmt.Sprintf(
+				"draft #%d is pinned to sequence %d, but would be signed with sequence %d",
+				d.ID, d.Sequence, seq))
+		}
+	}
+	return conflicts
+}
+
+func NewQueueCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cmd := commands.NewCommand(
+		commands.Metadata{
+			Name:       "queue",
+			ShortUsage: "queue <subcommand> [flags] [<arg>...]",
+			ShortHelp:  "manages the outbox of draft transactions of a key",
+			LongHelp: "Manages the outbox of a key: composed transactions are queued " +
+				"as drafts, without connecting to a node, and are later signed and " +
+				"broadcast in order with their account sequences by flush.",
+		},
+		commands.NewEmptyConfig(),
+		commands.HelpExec,
+	)
+
+	cmd.AddSubCommands(
+		NewQueueAddCmd(rootCfg, io),
+		NewQueueListCmd(rootCfg, io),
+		NewQueueRemoveCmd(rootCfg, io),
+		NewQueueFlushCmd(rootCfg, io),
+	)
+
+	return cmd
+}
+
+type QueueAddCfg struct {
+	RootCfg *BaseCfg
+
+	ChainID  string
+	Sequence int64
+}
+
+func NewQueueAddCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cfg := &QueueAddCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "add",
+			ShortUsage: "queue add [flags] <key-name or address> <tx-file>",
+			ShortHelp:  "queues an unsigned tx document as a draft",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execQueueAdd(cfg, args, io)
+		},
+	)
+}
+
+func (c *QueueAddCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.ChainID,
+		"chainid",
+		"dev",
+		"chainid to sign for",
+	)
+
+	fs.Int64Var(
+		&c.Sequence,
+		"sequence",
+		-1,
+		"account sequence the draft must be signed with; by default, the next one when flushed",
+	)
+}
+
+func execQueueAdd(cfg *QueueAddCfg, args []string, io commands.IO) error {
+	if len(args) != 2 {
+		return flag.ErrHelp
+	}
+
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home)
+	if err != nil {
+		return err
+	}
+	info, err := kb.GetByNameOrAddress(args[0])
+	if err != nil {
+		return err
+	}
+
+	txRaw, err := os.ReadFile(args[1])
+	if err != nil {
+		return fmt.Errorf("unable to read transaction file, %w", err)
+	}
+	if len(txRaw) == 0 {
+		return errInvalidTxFile
+	}
+	var tx std.Tx
+	if err := amino.UnmarshalJSON(txRaw, &tx); err != nil {
+		return fmt.Errorf("unable to unmarshal transaction, %w", err)
+	}
+
+	// The key signs alone, and pays the fee with its sequence.
+	if len(tx.Signatures) != 0 {
+		return errors.New("tx is already signed")
+	}
+	signers := tx.GetSigners()
+	if len(signers) != 1 || signers[0] != info.GetAddress() {
+		return fmt.Errorf("tx must be signed by %s only", info.GetAddress())
+	}
+
+	ob, err := loadOutbox(cfg.RootCfg.Home, info.GetAddress())
+	if err != nil {
+		return err
+	}
+	var sequence *uint64
+	if cfg.Sequence >= 0 {
+		seq := uint64(cfg.Sequence)
+		sequence = &seq
+	}
+	d, err := ob.Add(cfg.ChainID, tx, sequence)
+	if err != nil {
+		return err
+	}
+	if err := ob.save(); err != nil {
+		return err
+	}
+
+	io.Printfln("Queued draft #%d (%d in the outbox of %s)", d.ID, len(ob.Drafts), info.GetName())
+	return nil
+}
+
+func NewQueueListCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "list",
+			ShortUsage: "queue list <key-name or address>",
+			ShortHelp:  "lists the drafts of a key, in broadcast order",
+		},
+		nil,
+		func(_ context.Context, args []string) error {
+			return execQueueList(rootCfg, args, io)
+		},
+	)
+}
+
+func execQueueList(cfg *BaseCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+
+	ob, _, err := loadKeyOutbox(cfg, args[0])
+	if err != nil {
+		return err
+	}
+
+	for _, d := range ob.Drafts {
+		seq := "next"
+		if d.Pinned {
+			seq = strconv.FormatUint(d.Sequence, 10)
+		}
+		io.Printfln("#%d chainid: %s sequence: %s msgs: %d added: %s memo: %q",
+			d.ID, d.ChainID, seq, len(d.Tx.Msgs), d.Added.Format(time.RFC3339), d.Tx.Memo)
+	}
+	return nil
+}
+
+func NewQueueRemoveCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "remove",
+			ShortUsage: "queue remove <key-name or address> <draft-id>",
+			ShortHelp:  "removes a draft from the outbox of a key",
+		},
+		nil,
+		func(_ context.Context, args []string) error {
+			return execQueueRemove(rootCfg, args, io)
+		},
+	)
+}
+
+func execQueueRemove(cfg *BaseCfg, args []string, io commands.IO) error {
+	if len(args) != 2 {
+		return flag.ErrHelp
+	}
+
+	id, err := strconv.ParseUint(args[1], 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid draft id %q", args[1])
+	}
+
+	ob, _, err := loadKeyOutbox(cfg, args[0])
+	if err != nil {
+		return err
+	}
+	if err := ob.Remove(id); err != nil {
+		return err
+	}
+	if err := ob.save(); err != nil {
+		return err
+	}
+
+	io.Printfln("Draft #%d removed", id)
+	return nil
+}
+
+type QueueFlushCfg struct {
+	RootCfg *BaseCfg
+
+	// Valid options are SimulateTest or SimulateSkip.
+	Simulate string
+}
+
+func NewQueueFlushCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cfg := &QueueFlushCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "flush",
+			ShortUsage: "queue flush [flags] <key-name or address>",
+			ShortHelp:  "signs and broadcasts the drafts of a key in order",
+			LongHelp: "Signs the drafts of the key with the following sequences of its " +
+				"account, and broadcasts them in order. Nothing is broadcast if a " +
+				"draft conflicts with the account or the chain of the node. Each " +
+				"draft is removed from the outbox once committed; flushing stops at " +
+				"the first failing one.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execQueueFlush(cfg, args, io)
+		},
+	)
+}
+
+func (c *QueueFlushCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.StringVar(
+		&c.Simulate,
+		"simulate",
+		SimulateTest,
+		`select how to simulate the transactions; valid options are
+		- test: attempts simulating each transaction, and if successful performs broadcasting (default)
+		- skip: avoids performing transaction simulation`,
+	)
+}
+
+func execQueueFlush(cfg *QueueFlushCfg, args []string, io commands.IO) error {
+	if len(args) != 1 {
+		return flag.ErrHelp
+	}
+	switch cfg.Simulate {
+	case SimulateTest, SimulateSkip:
+	default:
+		return fmt.Errorf("invalid simulate option: %q", cfg.Simulate)
+	}
+
+	ob, kb, err := loadKeyOutbox(cfg.RootCfg, args[0])
+	if err != nil {
+		return err
+	}
+	if len(ob.Drafts) == 0 {
+		io.Println("Nothing to flush")
+		return nil
+	}
+
+	remote := cfg.RootCfg.Remote
+	if remote == "" {
+		return errors.New("missing remote url")
+	}
+	cli, err := client.NewHTTPClient(remote)
+	if err != nil {
+		return err
+	}
+	status, err := cli.Status(context.Background(), nil)
+	if err != nil {
+		return errors.Wrap(err, "query status")
+	}
+	info, err := kb.GetByNameOrAddress(args[0])
+	if err != nil {
+		return err
+	}
+	account, err := queryBaseAccount(cfg.RootCfg, info.GetAddress())
+	if err != nil {
+		return err
+	}
+
+	if conflicts := ob.Conflicts(status.NodeInfo.Network, account.Sequence); len(conflicts) != 0 {
+		for _, c := range conflicts {
+			io.ErrPrintln(c)
+		}
+		return errQueueConflict
+	}
+
+	var pass string
+	if cfg.RootCfg.Quiet {
+		pass, err = io.GetPassword("", cfg.RootCfg.InsecurePasswordStdin)
+	} else {
+		pass, err = io.GetPassword("Enter password.", cfg.RootCfg.InsecurePasswordStdin)
+	}
+	if err != nil {
+		return err
+	}
+
+	for len(ob.Drafts) != 0 {
+		d := ob.Drafts[0]
+		tx := d.Tx
+
+		sOpts := signOpts{
+			chainID:         d.ChainID,
+			accountSequence: account.Sequence,
+			accountNumber:   account.AccountNumber,
+		}
+		kOpts := keyOpts{
+			keyName:     args[0],
+			decryptPass: pass,
+		}
+		signature, err := generateSignature(&tx, kb, sOpts, kOpts)
+		if err != nil {
+			return fmt.Errorf("unable to sign draft #%d: %w", d.ID, err)
+		}
+		if err := addSignature(&tx, signature); err != nil {
+			return fmt.Errorf("unable to add signature: %w", err)
+		}
+
+		// Simulate first, so that a failing draft isn't committed.
+		if cfg.Simulate == SimulateTest {
+			sres, err := SimulateTx(cli, amino.MustMarshal(tx))
+			if err != nil {
+				return errors.Wrapf(err, "simulate draft #%d", d.ID)
+			}
+			if sres.DeliverTx.IsErr() {
+				return errors.Wrapf(sres.DeliverTx.Error, "simulate draft #%d failed: log:%s", d.ID, sres.DeliverTx.Log)
+			}
+		}
+
+		bres, err := BroadcastHandler(&BroadcastCfg{
+			RootCfg: cfg.RootCfg,
+			tx:      &tx,
+		})
+		if err != nil {
+			return errors.Wrapf(err, "broadcast draft #%d", d.ID)
+		}
+		if bres.CheckTx.IsErr() {
+			return errors.Wrapf(bres.CheckTx.Error, "check draft #%d failed: log:%s", d.ID, bres.CheckTx.Log)
+		}
+
+		// Committed, even if failed: its sequence is used.
+		ob.Drafts = ob.Drafts[1:]
+		if err := ob.save(); err != nil {
+			return err
+		}
+		account.Sequence++
+
+		io.Printfln("Draft #%d broadcast", d.ID)
+		io.Println("TX HASH:   ", base64.StdEncoding.EncodeToString(bres.Hash))
+		if bres.DeliverTx.IsErr() {
+			return errors.Wrapf(bres.DeliverTx.Error, "deliver draft #%d failed: log:%s", d.ID, bres.DeliverTx.Log)
+		}
+		if cfg.RootCfg.OnTxSuccess != nil {
+			cfg.RootCfg.OnTxSuccess(tx, bres)
+		} else {
+			io.Println("OK!")
+			io.Println("GAS WANTED:", bres.DeliverTx.GasWanted)
+			io.Println("GAS USED:  ", bres.DeliverTx.GasUsed)
+			io.Println("HEIGHT:    ", bres.Height)
+		}
+	}
+	return nil
+}
+
+// loadKeyOutbox loads the keybase, and the outbox of the given key.
+func loadKeyOutbox(cfg *BaseCfg, nameOrBech32 string) (*Outbox, keys.Keybase, error) {
+	kb, err := keys.NewKeyBaseFromDir(cfg.Home)
+	if err != nil {
+		return nil, nil, err
+	}
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return nil, nil, err
+	}
+	ob, err := loadOutbox(cfg.Home, info.GetAddress())
+	if err != nil {
+		return nil, nil, err
+	}
+	return ob, kb, nil
+}
@@ -0,0 +1,110 @@
+package client
+
+import (
+	"bytes"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/sdk/bank"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_execQueue(t *testing.T) {
+	t.Parallel()
+
+	kbHome := t.TempDir()
+	baseCfg := &BaseCfg{
+		BaseOptions: BaseOptions{
+			Home: kbHome,
+		},
+	}
+
+	kb, err := keys.NewKeyBaseFromDir(kbHome)
+	require.NoError(t, err)
+	info, err := kb.CreateAccount("key1", testMnemonic, "", "", 0, 0)
+	require.NoError(t, err)
+	other, err := kb.CreateAccount("key2", testMnemonic, "", "", 0, 1)
+	require.NoError(t, err)
+
+	writeTx := func(name string, from crypto.Address, amount int64) string {
+		tx := std.Tx{
+			Msgs: []std.Msg{
+				bank.MsgSend{
+					FromAddress: from,
+					ToAddress:   other.GetAddress(),
+					Amount:      std.NewCoins(std.NewCoin("ugnot", amount)),
+				},
+			},
+			Fee: std.NewFee(100000, std.NewCoin("ugnot", 1000)),
+		}
+		path := filepath.Join(t.TempDir(), name)
+		require.NoError(t, os.WriteFile(path, amino.MustMarshalJSON(tx), 0o644))
+		return path
+	}
+	tx1 := writeTx("tx1.json", info.GetAddress(), 1)
+	tx2 := writeTx("tx2.json", info.GetAddress(), 2)
+	tx3 := writeTx("tx3.json", info.GetAddress(), 3)
+
+	addCfg := &QueueAddCfg{RootCfg: baseCfg, ChainID: "dev", Sequence: -1}
+	require.NoError(t, execQueueAdd(addCfg, []string{"key1", tx1}, commands.NewTestIO()))
+	addCfg.Sequence = 4
+	require.NoError(t, execQueueAdd(addCfg, []string{"key1", tx2}, commands.NewTestIO()))
+	addCfg.Sequence = -1
+	require.NoError(t, execQueueAdd(addCfg, []string{"key1", tx3}, commands.NewTestIO()))
+
+	// the same tx can't be queued twice.
+	err = execQueueAdd(addCfg, []string{"key1", tx1}, commands.NewTestIO())
+	assert.ErrorContains(t, err, "already queued as draft #1")
+
+	// the key must be the only signer.
+	err = execQueueAdd(addCfg, []string{"key2", tx1}, commands.NewTestIO())
+	assert.ErrorContains(t, err, "must be signed by")
+
+	// drafts are listed in order, only in the outbox of their key.
+	io := commands.NewTestIO()
+	out := new(bytes.Buffer)
+	io.SetOut(commands.WriteNopCloser(out))
+	require.NoError(t, execQueueList(baseCfg, []string{"key1"}, io))
+	lines := bytes.Split(bytes.TrimSpace(out.Bytes()), []byte("\n"))
+	require.Len(t, lines, 3)
+	assert.Contains(t, string(lines[0]), "#1 chainid: dev sequence: next msgs: 1")
+	assert.Contains(t, string(lines[1]), "#2 chainid: dev sequence: 4 msgs: 1")
+	assert.Contains(t, string(lines[2]), "#3 chainid: dev sequence: next msgs: 1")
+	out.Reset()
+	require.NoError(t, execQueueList(baseCfg, []string{"key2"}, io))
+	assert.Empty(t, out.String())
+
+	ob, err := loadOutbox(kbHome, info.GetAddress())
+	require.NoError(t, err)
+
+	// draft #2 gets sequence 4 only if the account is at sequence 3.
+	assert.Empty(t, ob.Conflicts("dev", 3))
+	assert.Equal(t, []string{
+		"draft #2 is pinned to sequence 4, but would be signed with sequence 6",
+	}, ob.Conflicts("dev", 5))
+	assert.Len(t, ob.Conflicts("test", 3), 3)
+
+	// removing.
+	require.NoError(t, execQueueRemove(baseCfg, []string{"key1", "2"}, commands.NewTestIO()))
+	require.Error(t, execQueueRemove(baseCfg, []string{"key1", "2"}, commands.NewTestIO()))
+	ob, err = loadOutbox(kbHome, info.GetAddress())
+	require.NoError(t, err)
+	require.Len(t, ob.Drafts, 2)
+	assert.Equal(t, uint64(1), ob.Drafts[0].ID)
+	assert.Equal(t, uint64(3), ob.Drafts[1].ID)
+	assert.Empty(t, ob.Conflicts("dev", 5))
+
+	// ids aren't reused.
+	addCfg.Sequence = -1
+	require.NoError(t, execQueueAdd(addCfg, []string{"key1", tx2}, commands.NewTestIO()))
+	ob, err = loadOutbox(kbHome, info.GetAddress())
+	require.NoError(t, err)
+	assert.Equal(t, uint64(4), ob.Drafts[2].ID)
+}
@@ -43,13 +43,16 @@ func NewRootCmdWithBaseConfig(io commands.IO, base BaseOptions) *commands.Comman
 		NewExportCmd(cfg, io),
 		NewImportCmd(cfg, io),
 		NewListCmd(cfg, io),
+		NewNetworkCmd(cfg, io),
 		NewRotateCmd(cfg, io),
+		NewSecurityCmd(cfg, io),
 		NewSignCmd(cfg, io),
 		NewVerifyCmd(cfg, io),
 		NewQueryCmd(cfg, io),
 		NewBroadcastCmd(cfg, io),
 		NewMakeTxCmd(cfg, io),
 		NewMultisignCmd(cfg, io),
+		NewQueueCmd(cfg, io),
 	)
 
 	return cmd
@@ -0,0 +1,125 @@
+package client
+
+import (
+	"context"
+	"flag"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/armor"
+)
+
+func NewSecurityCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cmd := commands.NewCommand(
+		commands.Metadata{
+			Name:       "security",
+			ShortUsage: "security <subcommand> [flags] [<arg>...]",
+			ShortHelp:  "manages the encryption of the keys",
+		},
+		commands.NewEmptyConfig(),
+		commands.HelpExec,
+	)
+
+	cmd.AddSubCommands(
+		NewSecurityUpgradeCmd(rootCfg, io),
+	)
+
+	return cmd
+}
+
+type SecurityUpgradeCfg struct {
+	RootCfg *BaseCfg
+
+	Iterations uint
+	Memory     uint
+}
+
+func NewSecurityUpgradeCmd(rootCfg *BaseCfg, io commands.IO) *commands.Command {
+	cfg := &SecurityUpgradeCfg{
+		RootCfg: rootCfg,
+	}
+
+	return commands.NewCommand(
+		commands.Metadata{
+			Name:       "upgrade",
+			ShortUsage: "security upgrade [flags] [<key-name>...]",
+			ShortHelp:  "encrypts keys again with argon2id",
+			LongHelp: "Encrypts the private keys again with argon2id, if they were " +
+				"encrypted with bcrypt or with weaker argon2id parameters. " +
+				"Without arguments, all the local keys which need it are upgraded. " +
+				"Keys are also upgraded to the default parameters the first time " +
+				"they are unlocked.",
+		},
+		cfg,
+		func(_ context.Context, args []string) error {
+			return execSecurityUpgrade(cfg, args, io)
+		},
+	)
+}
+
+func (c *SecurityUpgradeCfg) RegisterFlags(fs *flag.FlagSet) {
+	fs.UintVar(
+		&c.Iterations,
+		"argon2-iterations",
+		uint(armor.DefaultArgon2Params.Time),
+		"number of iterations of argon2id",
+	)
+
+	fs.UintVar(
+		&c.Memory,
+		"argon2-memory",
+		uint(armor.DefaultArgon2Params.Memory),
+		"memory used by argon2id, in KiB",
+	)
+}
+
+func execSecurityUpgrade(cfg *SecurityUpgradeCfg, args []string, io commands.IO) error {
+	params := armor.DefaultArgon2Params
+	params.Time = uint32(cfg.Iterations)
+	params.Memory = uint32(cfg.Memory)
+	if err := params.Validate(); err != nil {
+		return err
+	}
+
+	kb, err := keys.NewKeyBaseFromDir(cfg.RootCfg.Home, keys.WithArgon2Params(params))
+	if err != nil {
+		return err
+	}
+
+	names := args
+	if len(names) == 0 {
+		infos, err := kb.List()
+		if err != nil {
+			return err
+		}
+		for _, info := range infos {
+			if info.GetType() == keys.TypeLocal {
+				names = append(names, info.GetName())
+			}
+		}
+	}
+
+	for _, name := range names {
+		upgrade, err := kb.NeedsUpgrade(name)
+		if err != nil {
+			return err
+		}
+		if !upgrade {
+			io.ErrPrintfln("%s is up to date", name)
+			continue
+		}
+
+		msg := fmt.Sprintf("Enter the password of %s:", name)
+		pass, err := io.GetPassword(msg, cfg.RootCfg.InsecurePasswordStdin)
+		if err != nil {
+			return err
+		}
+		if _, err := kb.Upgrade(name, pass); err != nil {
+			return fmt.Errorf("unable to upgrade %s: %w", name, err)
+		}
+		io.ErrPrintfln("%s upgraded", name)
+	}
+
+	return nil
+}
@@ -0,0 +1,75 @@
+package client
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/commands"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/armor"
+	"github.com/gnolang/gno/tm2/pkg/testutils"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_execSecurityUpgrade(t *testing.T) {
+	t.Parallel()
+
+	// make new test dir
+	kbHome, kbCleanUp := testutils.NewTestCaseDir(t)
+	defer kbCleanUp()
+
+	// initialize test options
+	cfg := &SecurityUpgradeCfg{
+		RootCfg: &BaseCfg{
+			BaseOptions: BaseOptions{
+				Home:                  kbHome,
+				InsecurePasswordStdin: true,
+			},
+		},
+		Iterations: uint(armor.DefaultArgon2Params.Time),
+		Memory:     uint(armor.DefaultArgon2Params.Memory),
+	}
+
+	io := commands.NewTestIO()
+
+	// Add test accounts with weak encryption to keybase.
+	weak := armor.Argon2Params{Time: 1, Memory: 1024, Threads: 1}
+	kb, err := keys.NewKeyBaseFromDir(kbHome, keys.WithArgon2Params(weak))
+	require.NoError(t, err)
+
+	mnemonic := "equip will roof matter pink blind book anxiety banner elbow sun young"
+	_, err = kb.CreateAccount("key1", mnemonic, "", "1234", 0, 0)
+	require.NoError(t, err)
+	_, err = kb.CreateAccount("key2", mnemonic, "", "5678", 0, 1)
+	require.NoError(t, err)
+
+	{
+		// test: Wrong password
+		io.SetIn(strings.NewReader("blah\n"))
+		err = execSecurityUpgrade(cfg, []string{"key1"}, io)
+		require.ErrorContains(t, err, "invalid account password")
+	}
+
+	{
+		// Upgrade all the keys
+		io.SetIn(strings.NewReader("1234\n5678\n"))
+		err = execSecurityUpgrade(cfg, nil, io)
+		require.NoError(t, err)
+	}
+
+	kb, err = keys.NewKeyBaseFromDir(kbHome)
+	require.NoError(t, err)
+	for _, name := range []string{"key1", "key2"} {
+		upgrade, err := kb.NeedsUpgrade(name)
+		require.NoError(t, err)
+		assert.False(t, upgrade)
+	}
+
+	{
+		// Keys which are up to date don't need their password
+		io.SetIn(strings.NewReader(""))
+		err = execSecurityUpgrade(cfg, nil, io)
+		require.NoError(t, err)
+	}
+}
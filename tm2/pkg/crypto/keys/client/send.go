@@ -4,7 +4,6 @@ import (
 	"context"
 	"flag"
 
-	"github.com/gnolang/gno/tm2/pkg/amino"
 	"github.com/gnolang/gno/tm2/pkg/commands"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys"
@@ -122,8 +121,7 @@ func execMakeSend(cfg *MakeSendCfg, args []string, io commands.IO) error {
 		if err != nil {
 			return err
 		}
-	} else {
-		io.Println(string(amino.MustMarshalJSON(tx)))
+		return nil
 	}
-	return nil
+	return PrintTx(cfg.RootCfg, tx, io)
 }
@@ -68,19 +68,37 @@ var (
 // dbKeybase combines encryption and storage implementation to provide
 // a full-featured key manager
 type dbKeybase struct {
-	db dbm.DB
+	db  dbm.DB
+	kdf armor.Argon2Params
+}
+
+// Option configures a keybase.
+type Option func(*dbKeybase)
+
+// WithArgon2Params sets the argon2id parameters used to encrypt the private
+// keys. Keys encrypted with weaker parameters, or with bcrypt, are encrypted
+// again when they are unlocked. It defaults to armor.DefaultArgon2Params.
+func WithArgon2Params(params armor.Argon2Params) Option {
+	return func(kb *dbKeybase) {
+		kb.kdf = params
+	}
 }
 
 // NewDBKeybase creates a new keybase instance using the passed DB for reading and writing keys.
-func NewDBKeybase(db dbm.DB) Keybase {
-	return dbKeybase{
-		db: db,
+func NewDBKeybase(db dbm.DB, opts ...Option) Keybase {
+	kb := dbKeybase{
+		db:  db,
+		kdf: armor.DefaultArgon2Params,
 	}
+	for _, opt := range opts {
+		opt(&kb)
+	}
+	return kb
 }
 
 // NewInMemory creates a transient keybase on top of in-memory storage
 // instance useful for testing purposes and on-the-fly key generation.
-func NewInMemory() Keybase { return dbKeybase{memdb.NewMemDB()} }
+func NewInMemory(opts ...Option) Keybase { return NewDBKeybase(memdb.NewMemDB(), opts...) }
 
 // CreateAccount converts a mnemonic to a private key and persists it, encrypted with the given password.
 // XXX Info could include the separately derived ed25519 key,
@@ -173,7 +191,7 @@ func (kb dbKeybase) List() ([]Info, error) {
 
 // HasByNameOrAddress checks if a key with the name or bech32 string address is in the keybase.
 func (kb dbKeybase) HasByNameOrAddress(nameOrBech32 string) (bool, error) {
-	address, err := crypto.AddressFromBech32(nameOrBech32)
+	address, err := kb.addressFromBech32(nameOrBech32)
 	if err != nil {
 		return kb.HasByName(nameOrBech32)
 	}
@@ -192,7 +210,7 @@ func (kb dbKeybase) HasByAddress(address crypto.Address) (bool, error) {
 
 // Get returns the public information about one key.
 func (kb dbKeybase) GetByNameOrAddress(nameOrBech32 string) (Info, error) {
-	addr, err := crypto.AddressFromBech32(nameOrBech32)
+	addr, err := kb.addressFromBech32(nameOrBech32)
 	if err != nil {
 		return kb.GetByName(nameOrBech32)
 	}
@@ -242,7 +260,7 @@ func (kb dbKeybase) Sign(nameOrBech32, passphrase string, msg []byte) (sig []byt
 			return
 		}
 
-		priv, err = armor.UnarmorDecryptPrivKey(info.PrivKeyArmor, passphrase)
+		priv, err = kb.unlock(info, passphrase)
 		if err != nil {
 			return nil, nil, err
 		}
@@ -306,7 +324,7 @@ func (kb dbKeybase) ExportPrivKey(nameOrBech32 string, passphrase string) (crypt
 			return nil, fmt.Errorf("%w: %s", errKeyNotAvailable, nameOrBech32)
 		}
 
-		priv, err = armor.UnarmorDecryptPrivKey(info.PrivKeyArmor, passphrase)
+		priv, err = kb.unlock(info, passphrase)
 		if err != nil {
 			return nil, err
 		}
@@ -367,6 +385,61 @@ func (kb dbKeybase) Rotate(nameOrBech32, oldpass string, getNewpass func() (stri
 	}
 }
 
+// NeedsUpgrade reports whether the private key of the local key must be
+// encrypted again, because it was encrypted with bcrypt or with weaker
+// argon2id parameters than those of the keybase.
+func (kb dbKeybase) NeedsUpgrade(nameOrBech32 string) (bool, error) {
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return false, err
+	}
+	linfo, ok := info.(localInfo)
+	if !ok {
+		return false, fmt.Errorf("locally stored key required. Received: %v", reflect.TypeOf(info).String())
+	}
+	if linfo.PrivKeyArmor == "" {
+		return false, nil
+	}
+	return armor.NeedsUpgrade(linfo.PrivKeyArmor, kb.kdf)
+}
+
+// Upgrade encrypts the private key of the local key again with the argon2id
+// parameters of the keybase, if it needs it. It returns whether the key was
+// upgraded.
+func (kb dbKeybase) Upgrade(nameOrBech32, passphrase string) (bool, error) {
+	upgrade, err := kb.NeedsUpgrade(nameOrBech32)
+	if err != nil || !upgrade {
+		return false, err
+	}
+	info, err := kb.GetByNameOrAddress(nameOrBech32)
+	if err != nil {
+		return false, err
+	}
+	priv, err := armor.UnarmorDecryptPrivKey(info.(localInfo).PrivKeyArmor, passphrase)
+	if err != nil {
+		return false, err
+	}
+	if _, err := kb.writeLocalKey(info.GetName(), priv, passphrase); err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
+// unlock decrypts the private key of the local key. On the first unlock
+// after the argon2id parameters of the keybase are raised, or of a key
+// encrypted with bcrypt, the key is transparently encrypted again.
+func (kb dbKeybase) unlock(info localInfo, passphrase string) (crypto.PrivKey, error) {
+	priv, err := armor.UnarmorDecryptPrivKey(info.PrivKeyArmor, passphrase)
+	if err != nil {
+		return nil, err
+	}
+	if upgrade, err := armor.NeedsUpgrade(info.PrivKeyArmor, kb.kdf); err == nil && upgrade {
+		// The key was unlocked: failing to upgrade it is not an error.
+		kb.writeLocalKey(info.GetName(), priv, passphrase)
+	}
+	return priv, nil
+}
+
 // CloseDB releases the lock and closes the storage backend.
 func (kb dbKeybase) CloseDB() {
 	kb.db.Close()
@@ -374,7 +447,7 @@ func (kb dbKeybase) CloseDB() {
 
 func (kb dbKeybase) writeLocalKey(name string, priv crypto.PrivKey, passphrase string) (Info, error) {
 	// encrypt private key using passphrase
-	privArmor := armor.EncryptArmorPrivKey(priv, passphrase)
+	privArmor := armor.EncryptArmorPrivKeyWithParams(priv, passphrase, kb.kdf)
 	// make Info
 	pub := priv.PubKey()
 	info := newLocalInfo(name, pub, privArmor)
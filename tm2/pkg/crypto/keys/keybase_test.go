@@ -2,6 +2,7 @@ package keys
 
 import (
 	"fmt"
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -9,7 +10,9 @@ import (
 
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/crypto/ed25519"
+	"github.com/gnolang/gno/tm2/pkg/crypto/keys/armor"
 	"github.com/gnolang/gno/tm2/pkg/crypto/keys/keyerror"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
 )
 
 func TestCreateAccountInvalidMnemonic(t *testing.T) {
@@ -451,3 +454,122 @@ func TestKeybase_ExportPrivKey(t *testing.T) {
 		assert.True(t, key.Equals(exportedKey))
 	})
 }
+
+func TestKeybase_Networks(t *testing.T) {
+	t.Parallel()
+
+	kb := NewInMemory()
+	mn := `lounge napkin all odor tilt dove win inject sleep jazz uncover traffic hint require cargo arm rocket round scan bread report squirrel step lake`
+	info, err := kb.CreateAccount("personal", mn, "", "1234", 0, 0)
+	require.NoError(t, err)
+	addr := info.GetAddress()
+
+	// invalid networks are rejected.
+	assert.Error(t, kb.SetNetwork(Network{Name: "", Prefix: "g"}))
+	assert.Error(t, kb.SetNetwork(Network{Name: "test", Prefix: ""}))
+	assert.Error(t, kb.SetNetwork(Network{Name: "test", Prefix: "G"}))
+	assert.Error(t, kb.SetNetwork(Network{Name: "a.b", Prefix: "g"}))
+	assert.Error(t, kb.SetNetwork(Network{Name: "test", Prefix: "g t"}))
+	assert.Error(t, kb.SetNetwork(Network{Name: "test", Prefix: "gé"}))
+	assert.Error(t, kb.SetNetwork(Network{Name: "test", Prefix: strings.Repeat("g", 84)}))
+	assert.NoError(t, kb.SetNetwork(Network{Name: "test", Prefix: strings.Repeat("g", 83)}))
+	require.NoError(t, kb.DeleteNetwork("test"))
+
+	// addresses of invalid networks can't be encoded.
+	_, err = Network{Name: "test", Prefix: "G"}.Address(addr)
+	assert.Error(t, err)
+
+	// addresses with an unknown prefix are not accepted.
+	testnet := Network{Name: "testnet", Prefix: "gt", ChainID: "test5"}
+	bech32Addr, err := testnet.Address(addr)
+	require.NoError(t, err)
+	_, err = kb.GetByNameOrAddress(bech32Addr)
+	require.Error(t, err)
+
+	require.NoError(t, kb.SetNetwork(testnet))
+	require.NoError(t, kb.SetNetwork(Network{Name: "mainnet", Prefix: "g", ChainID: "gnoland1"}))
+	got, err := kb.GetNetwork("testnet")
+	require.NoError(t, err)
+	assert.Equal(t, testnet, got)
+
+	// networks don't show up as keys.
+	infos, err := kb.List()
+	require.NoError(t, err)
+	assert.Len(t, infos, 1)
+
+	networks, err := kb.ListNetworks()
+	require.NoError(t, err)
+	require.Len(t, networks, 2)
+	assert.Equal(t, "mainnet", networks[0].Name)
+	mainnetAddr, err := networks[0].Address(addr)
+	require.NoError(t, err)
+	assert.Equal(t, addr.String(), mainnetAddr)
+	assert.Equal(t, "testnet", networks[1].Name)
+
+	// the key can be found with the address of any network.
+	assert.True(t, strings.HasPrefix(bech32Addr, "gt1"))
+	found, err := kb.GetByNameOrAddress(bech32Addr)
+	require.NoError(t, err)
+	assert.Equal(t, "personal", found.GetName())
+	has, err := kb.HasByNameOrAddress(bech32Addr)
+	require.NoError(t, err)
+	assert.True(t, has)
+
+	// replacing and deleting.
+	testnet.Remote = "https://rpc.test5.gno.land:443"
+	require.NoError(t, kb.SetNetwork(testnet))
+	got, err = kb.GetNetwork("testnet")
+	require.NoError(t, err)
+	assert.Equal(t, testnet.Remote, got.Remote)
+
+	require.NoError(t, kb.DeleteNetwork("testnet"))
+	assert.Error(t, kb.DeleteNetwork("testnet"))
+	_, err = kb.GetNetwork("testnet")
+	assert.Error(t, err)
+	_, err = kb.GetByNameOrAddress(bech32Addr)
+	assert.Error(t, err)
+}
+
+func TestKeybase_Upgrade(t *testing.T) {
+	t.Parallel()
+
+	db := memdb.NewMemDB()
+	weak := armor.Argon2Params{Time: 1, Memory: 1024, Threads: 1}
+	mn := `lounge napkin all odor tilt dove win inject sleep jazz uncover traffic hint require cargo arm rocket round scan bread report squirrel step lake`
+	pass := "1234"
+
+	_, err := NewDBKeybase(db, WithArgon2Params(weak)).CreateAccount("weak1", mn, "", pass, 0, 0)
+	require.NoError(t, err)
+	_, err = NewDBKeybase(db, WithArgon2Params(weak)).CreateAccount("weak2", mn, "", pass, 0, 1)
+	require.NoError(t, err)
+
+	kb := NewDBKeybase(db)
+	for _, name := range []string{"weak1", "weak2"} {
+		upgrade, err := kb.NeedsUpgrade(name)
+		require.NoError(t, err)
+		require.True(t, upgrade)
+	}
+
+	// The first unlock encrypts the key again.
+	_, _, err = kb.Sign("weak1", "wrong", []byte("msg"))
+	require.Error(t, err)
+	_, _, err = kb.Sign("weak1", pass, []byte("msg"))
+	require.NoError(t, err)
+	upgrade, err := kb.NeedsUpgrade("weak1")
+	require.NoError(t, err)
+	assert.False(t, upgrade)
+	_, _, err = kb.Sign("weak1", pass, []byte("msg"))
+	require.NoError(t, err)
+
+	// Upgrade encrypts the key explicitly.
+	_, err = kb.Upgrade("weak2", "wrong")
+	require.Error(t, err)
+	upgraded, err := kb.Upgrade("weak2", pass)
+	require.NoError(t, err)
+	assert.True(t, upgraded)
+	upgraded, err = kb.Upgrade("weak2", pass)
+	require.NoError(t, err)
+	assert.False(t, upgraded)
+	_, err = kb.ExportPrivKey("weak2", pass)
+	require.NoError(t, err)
+}
@@ -17,15 +17,16 @@ var _ Keybase = lazyKeybase{}
 type lazyKeybase struct {
 	name string
 	dir  string
+	opts []Option
 }
 
 // New creates a new instance of a lazy keybase.
-func NewLazyDBKeybase(name, dir string) Keybase {
+func NewLazyDBKeybase(name, dir string, opts ...Option) Keybase {
 	if err := os.EnsureDir(dir, 0o700); err != nil {
 		panic(fmt.Sprintf("failed to create Keybase directory: %s", err))
 	}
 
-	return lazyKeybase{name: name, dir: dir}
+	return lazyKeybase{name: name, dir: dir, opts: opts}
 }
 
 func (lkb lazyKeybase) List() ([]Info, error) {
@@ -35,7 +36,7 @@ func (lkb lazyKeybase) List() ([]Info, error) {
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).List()
+	return NewDBKeybase(db, lkb.opts...).List()
 }
 
 func (lkb lazyKeybase) HasByNameOrAddress(nameOrBech32 string) (bool, error) {
@@ -45,7 +46,7 @@ func (lkb lazyKeybase) HasByNameOrAddress(nameOrBech32 string) (bool, error) {
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).HasByNameOrAddress(nameOrBech32)
+	return NewDBKeybase(db, lkb.opts...).HasByNameOrAddress(nameOrBech32)
 }
 
 func (lkb lazyKeybase) HasByName(name string) (bool, error) {
@@ -55,7 +56,7 @@ func (lkb lazyKeybase) HasByName(name string) (bool, error) {
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).HasByName(name)
+	return NewDBKeybase(db, lkb.opts...).HasByName(name)
 }
 
 func (lkb lazyKeybase) HasByAddress(address crypto.Address) (bool, error) {
@@ -65,7 +66,7 @@ func (lkb lazyKeybase) HasByAddress(address crypto.Address) (bool, error) {
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).HasByAddress(address)
+	return NewDBKeybase(db, lkb.opts...).HasByAddress(address)
 }
 
 func (lkb lazyKeybase) GetByNameOrAddress(nameOrBech32 string) (Info, error) {
@@ -75,7 +76,7 @@ func (lkb lazyKeybase) GetByNameOrAddress(nameOrBech32 string) (Info, error) {
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).GetByNameOrAddress(nameOrBech32)
+	return NewDBKeybase(db, lkb.opts...).GetByNameOrAddress(nameOrBech32)
 }
 
 func (lkb lazyKeybase) GetByName(name string) (Info, error) {
@@ -85,7 +86,7 @@ func (lkb lazyKeybase) GetByName(name string) (Info, error) {
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).GetByName(name)
+	return NewDBKeybase(db, lkb.opts...).GetByName(name)
 }
 
 func (lkb lazyKeybase) GetByAddress(address crypto.Address) (Info, error) {
@@ -95,7 +96,7 @@ func (lkb lazyKeybase) GetByAddress(address crypto.Address) (Info, error) {
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).GetByAddress(address)
+	return NewDBKeybase(db, lkb.opts...).GetByAddress(address)
 }
 
 func (lkb lazyKeybase) Delete(name, passphrase string, skipPass bool) error {
@@ -105,7 +106,7 @@ func (lkb lazyKeybase) Delete(name, passphrase string, skipPass bool) error {
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).Delete(name, passphrase, skipPass)
+	return NewDBKeybase(db, lkb.opts...).Delete(name, passphrase, skipPass)
 }
 
 func (lkb lazyKeybase) Sign(name, passphrase string, msg []byte) ([]byte, crypto.PubKey, error) {
@@ -115,7 +116,7 @@ func (lkb lazyKeybase) Sign(name, passphrase string, msg []byte) ([]byte, crypto
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).Sign(name, passphrase, msg)
+	return NewDBKeybase(db, lkb.opts...).Sign(name, passphrase, msg)
 }
 
 func (lkb lazyKeybase) Verify(name string, msg, sig []byte) error {
@@ -125,7 +126,7 @@ func (lkb lazyKeybase) Verify(name string, msg, sig []byte) error {
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).Verify(name, msg, sig)
+	return NewDBKeybase(db, lkb.opts...).Verify(name, msg, sig)
 }
 
 func (lkb lazyKeybase) CreateAccount(name, mnemonic, bip39Passwd, encryptPasswd string, account uint32, index uint32) (Info, error) {
@@ -135,7 +136,7 @@ func (lkb lazyKeybase) CreateAccount(name, mnemonic, bip39Passwd, encryptPasswd
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).CreateAccount(name, mnemonic, bip39Passwd, encryptPasswd, account, index)
+	return NewDBKeybase(db, lkb.opts...).CreateAccount(name, mnemonic, bip39Passwd, encryptPasswd, account, index)
 }
 
 func (lkb lazyKeybase) CreateAccountBip44(name, mnemonic, bip39Passwd, encryptPasswd string, params hd.BIP44Params) (Info, error) {
@@ -145,7 +146,7 @@ func (lkb lazyKeybase) CreateAccountBip44(name, mnemonic, bip39Passwd, encryptPa
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).CreateAccountBip44(name, mnemonic, bip39Passwd, encryptPasswd, params)
+	return NewDBKeybase(db, lkb.opts...).CreateAccountBip44(name, mnemonic, bip39Passwd, encryptPasswd, params)
 }
 
 func (lkb lazyKeybase) CreateLedger(name string, algo SigningAlgo, hrp string, account, index uint32) (info Info, err error) {
@@ -155,7 +156,7 @@ func (lkb lazyKeybase) CreateLedger(name string, algo SigningAlgo, hrp string, a
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).CreateLedger(name, algo, hrp, account, index)
+	return NewDBKeybase(db, lkb.opts...).CreateLedger(name, algo, hrp, account, index)
 }
 
 func (lkb lazyKeybase) CreateOffline(name string, pubkey crypto.PubKey) (info Info, err error) {
@@ -165,7 +166,7 @@ func (lkb lazyKeybase) CreateOffline(name string, pubkey crypto.PubKey) (info In
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).CreateOffline(name, pubkey)
+	return NewDBKeybase(db, lkb.opts...).CreateOffline(name, pubkey)
 }
 
 func (lkb lazyKeybase) CreateMulti(name string, pubkey crypto.PubKey) (info Info, err error) {
@@ -175,7 +176,7 @@ func (lkb lazyKeybase) CreateMulti(name string, pubkey crypto.PubKey) (info Info
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).CreateMulti(name, pubkey)
+	return NewDBKeybase(db, lkb.opts...).CreateMulti(name, pubkey)
 }
 
 func (lkb lazyKeybase) Rotate(name, oldpass string, getNewpass func() (string, error)) error {
@@ -185,7 +186,27 @@ func (lkb lazyKeybase) Rotate(name, oldpass string, getNewpass func() (string, e
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).Rotate(name, oldpass, getNewpass)
+	return NewDBKeybase(db, lkb.opts...).Rotate(name, oldpass, getNewpass)
+}
+
+func (lkb lazyKeybase) NeedsUpgrade(name string) (bool, error) {
+	db, err := db.NewDB(lkb.name, dbBackend, lkb.dir)
+	if err != nil {
+		return false, err
+	}
+	defer db.Close()
+
+	return NewDBKeybase(db, lkb.opts...).NeedsUpgrade(name)
+}
+
+func (lkb lazyKeybase) Upgrade(name, passphrase string) (bool, error) {
+	db, err := db.NewDB(lkb.name, dbBackend, lkb.dir)
+	if err != nil {
+		return false, err
+	}
+	defer db.Close()
+
+	return NewDBKeybase(db, lkb.opts...).Upgrade(name, passphrase)
 }
 
 func (lkb lazyKeybase) ImportPrivKey(name string, key crypto.PrivKey, encryptPass string) error {
@@ -196,7 +217,7 @@ func (lkb lazyKeybase) ImportPrivKey(name string, key crypto.PrivKey, encryptPas
 
 	defer db.Close()
 
-	return NewDBKeybase(db).ImportPrivKey(name, key, encryptPass)
+	return NewDBKeybase(db, lkb.opts...).ImportPrivKey(name, key, encryptPass)
 }
 
 func (lkb lazyKeybase) ExportPrivKey(name string, passphrase string) (crypto.PrivKey, error) {
@@ -206,7 +227,47 @@ func (lkb lazyKeybase) ExportPrivKey(name string, passphrase string) (crypto.Pri
 	}
 	defer db.Close()
 
-	return NewDBKeybase(db).ExportPrivKey(name, passphrase)
+	return NewDBKeybase(db, lkb.opts...).ExportPrivKey(name, passphrase)
+}
+
+func (lkb lazyKeybase) SetNetwork(network Network) error {
+	db, err := db.NewDB(lkb.name, dbBackend, lkb.dir)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return NewDBKeybase(db, lkb.opts...).SetNetwork(network)
+}
+
+func (lkb lazyKeybase) GetNetwork(name string) (Network, error) {
+	db, err := db.NewDB(lkb.name, dbBackend, lkb.dir)
+	if err != nil {
+		return Network{}, err
+	}
+	defer db.Close()
+
+	return NewDBKeybase(db, lkb.opts...).GetNetwork(name)
+}
+
+func (lkb lazyKeybase) ListNetworks() ([]Network, error) {
+	db, err := db.NewDB(lkb.name, dbBackend, lkb.dir)
+	if err != nil {
+		return nil, err
+	}
+	defer db.Close()
+
+	return NewDBKeybase(db, lkb.opts...).ListNetworks()
+}
+
+func (lkb lazyKeybase) DeleteNetwork(name string) error {
+	db, err := db.NewDB(lkb.name, dbBackend, lkb.dir)
+	if err != nil {
+		return err
+	}
+	defer db.Close()
+
+	return NewDBKeybase(db, lkb.opts...).DeleteNetwork(name)
 }
 
 func (lkb lazyKeybase) CloseDB() {}
@@ -0,0 +1,153 @@
+package keys
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/bech32"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+const (
+	networkSuffix = "network"
+
+	// maxPrefixLen is the maximum length of a bech32 human-readable part.
+	maxPrefixLen = 83
+)
+
+// Network is a chain on which the keys of a keybase are used. The same key
+// has the same address on every network, but it is displayed with the bech32
+// prefix of each network.
+type Network struct {
+	Name    string `json:"name"`
+	Prefix  string `json:"prefix"`   // bech32 human-readable part of addresses.
+	ChainID string `json:"chain_id"` // optional.
+	Remote  string `json:"remote"`   // optional RPC endpoint.
+}
+
+// Validate checks that the network has a valid name and prefix.
+func (n Network) Validate() error {
+	if n.Name == "" {
+		return errors.New("missing network name")
+	}
+	if strings.ContainsAny(n.Name, " ./") {
+		return fmt.Errorf("invalid network name %q", n.Name)
+	}
+	return validatePrefix(n.Prefix)
+}
+
+// validatePrefix checks that prefix is a valid bech32 human-readable part:
+// 1 to 83 printable ASCII characters. Uppercase characters are rejected, as
+// addresses are encoded in lowercase.
+func validatePrefix(prefix string) error {
+	if prefix == "" {
+		return errors.New("missing network prefix")
+	}
+	if len(prefix) > maxPrefixLen {
+		return fmt.Errorf("invalid network prefix %q: longer than %d characters", prefix, maxPrefixLen)
+	}
+	for _, c := range prefix {
+		if c < 33 || c > 126 {
+			return fmt.Errorf("invalid network prefix %q: invalid character %q", prefix, c)
+		}
+		if c >= 'A' && c <= 'Z' {
+			return fmt.Errorf("invalid network prefix %q: must be lowercase", prefix)
+		}
+	}
+	return nil
+}
+
+// Address returns addr encoded with the network prefix.
+func (n Network) Address(addr crypto.Address) (string, error) {
+	if err := validatePrefix(n.Prefix); err != nil {
+		return "", err
+	}
+	return bech32.Encode(n.Prefix, addr[:])
+}
+
+// SetNetwork adds network to the keybase, or replaces the network with the
+// same name.
+func (kb dbKeybase) SetNetwork(network Network) error {
+	if err := network.Validate(); err != nil {
+		return err
+	}
+	kb.db.SetSync(networkKey(network.Name), amino.MustMarshal(network))
+	return nil
+}
+
+// GetNetwork returns the network with the given name.
+func (kb dbKeybase) GetNetwork(name string) (Network, error) {
+	var network Network
+	bz, err := kb.db.Get(networkKey(name))
+	if err != nil {
+		return network, fmt.Errorf("error while getting network %s from db: %w", name, err)
+	}
+	if len(bz) == 0 {
+		return network, fmt.Errorf("network %s not found", name)
+	}
+	err = amino.Unmarshal(bz, &network)
+	return network, err
+}
+
+// ListNetworks returns the networks of the keybase in alphabetical order.
+func (kb dbKeybase) ListNetworks() ([]Network, error) {
+	var res []Network
+	iter, err := kb.db.Iterator(nil, nil)
+	if err != nil {
+		return nil, err
+	}
+	defer iter.Close()
+	for ; iter.Valid(); iter.Next() {
+		if !strings.HasSuffix(string(iter.Key()), "."+networkSuffix) {
+			continue
+		}
+		var network Network
+		if err := amino.Unmarshal(iter.Value(), &network); err != nil {
+			return nil, err
+		}
+		res = append(res, network)
+	}
+	return res, nil
+}
+
+// DeleteNetwork removes the network with the given name from the keybase.
+func (kb dbKeybase) DeleteNetwork(name string) error {
+	has, err := kb.db.Has(networkKey(name))
+	if err != nil {
+		return err
+	}
+	if !has {
+		return fmt.Errorf("network %s not found", name)
+	}
+	kb.db.DeleteSync(networkKey(name))
+	return nil
+}
+
+// addressFromBech32 decodes an address with the default prefix, or the
+// prefix of any network of the keybase.
+func (kb dbKeybase) addressFromBech32(bech32str string) (crypto.Address, error) {
+	addr, err := crypto.AddressFromBech32(bech32str)
+	if err == nil {
+		return addr, nil
+	}
+	hrp, bz, err2 := bech32.DecodeAndConvert(bech32str)
+	if err2 != nil || len(bz) != crypto.AddressSize {
+		return addr, err
+	}
+	networks, err2 := kb.ListNetworks()
+	if err2 != nil {
+		return addr, err2
+	}
+	for _, network := range networks {
+		if network.Prefix == hrp {
+			return crypto.AddressFromBytes(bz), nil
+		}
+	}
+	return addr, err
+}
+
+func networkKey(name string) []byte {
+	return fmt.Appendf(nil, "%s.%s", name, networkSuffix)
+}
@@ -54,6 +54,22 @@ type Keybase interface {
 	// ExportPrivKey exports the private key from the keybase. It *only* works on locally-stored keys
 	ExportPrivKey(name string, decryptPass string) (crypto.PrivKey, error)
 
+	// NeedsUpgrade reports whether the encryption of a local key is weaker
+	// than the one of the keybase.
+	NeedsUpgrade(name string) (bool, error)
+	// Upgrade encrypts a local key again with the encryption of the
+	// keybase, if it needs it, and returns whether it did.
+	Upgrade(name, passphrase string) (bool, error)
+
+	// SetNetwork adds a network, or replaces the network with the same name.
+	// The addresses of the keys can then be given and displayed with the
+	// bech32 prefix of the network.
+	SetNetwork(network Network) error
+	GetNetwork(name string) (Network, error)
+	// ListNetworks returns the networks in alphabetical order.
+	ListNetworks() ([]Network, error)
+	DeleteNetwork(name string) error
+
 	// CloseDB closes the database.
 	CloseDB()
 }
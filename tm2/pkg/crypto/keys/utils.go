@@ -11,8 +11,8 @@ const (
 )
 
 // NewKeyBaseFromDir initializes a keybase at a particular dir.
-func NewKeyBaseFromDir(rootDir string) (Keybase, error) {
-	return NewLazyDBKeybase(defaultKeyDBName, filepath.Join(rootDir, defaultKeyDBDir)), nil
+func NewKeyBaseFromDir(rootDir string, opts ...Option) (Keybase, error) {
+	return NewLazyDBKeybase(defaultKeyDBName, filepath.Join(rootDir, defaultKeyDBDir), opts...), nil
 }
 
 func ValidateMultisigThreshold(k, nKeys int) error {
@@ -0,0 +1,236 @@
+// Package errcode maps the typed errors of the modules of an application,
+// which are returned in ABCI responses, to stable codespace and code pairs,
+// so that clients can branch on errors without matching their amino type
+// names or their messages.
+//
+// Each module registers its codespace, usually as a package variable next to
+// the declaration of its errors:
+//
+//	var Codespace = errcode.Register(errcode.NewCodespace("bank").WithErrors(
+//		NoInputsError{}, 1,
+//		NoOutputsError{}, 2,
+//	))
+//
+// Codes are part of the API of a chain: once released, they must not be
+// changed or reused, only added.
+package errcode
+
+import (
+	"fmt"
+	"reflect"
+	"sort"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+// Code identifies a registered error type.
+type Code struct {
+	Codespace string `json:"codespace"`
+	Code      uint32 `json:"code"`
+}
+
+// OK is the code of responses without errors.
+var OK = Code{}
+
+// Undefined is the code of errors whose type isn't registered.
+var Undefined = Code{Codespace: "undefined", Code: 1}
+
+func (c Code) String() string {
+	return fmt.Sprintf("%s:%d", c.Codespace, c.Code)
+}
+
+// Entry is an error type registered with its code.
+type Entry struct {
+	Code
+	Type reflect.Type
+}
+
+// Codespace is a set of codes for the errors of a module.
+type Codespace struct {
+	name    string
+	entries []Entry
+	err     error // of WithErrors, returned by Register.
+}
+
+// NewCodespace returns an empty codespace named name.
+func NewCodespace(name string) *Codespace {
+	return &Codespace{name: name}
+}
+
+// Name returns the name of the codespace.
+func (cs *Codespace) Name() string {
+	return cs.name
+}
+
+// Entries returns the errors of the codespace, in their order of declaration.
+func (cs *Codespace) Entries() []Entry {
+	return append([]Entry(nil), cs.entries...)
+}
+
+// WithErrors adds errors to the codespace, given as pairs of an error value,
+// of the registered type, and of its code, which can't be zero.
+func (cs *Codespace) WithErrors(errsAndCodes ...any) *Codespace {
+	if len(errsAndCodes)%2 != 0 {
+		cs.setErr(fmt.Errorf("codespace %q: errors must be given as pairs of an error and a code", cs.name))
+		return cs
+	}
+	for i := 0; i < len(errsAndCodes); i += 2 {
+		err, ok := errsAndCodes[i].(error)
+		if !ok {
+			cs.setErr(fmt.Errorf("codespace %q: %T is not an error", cs.name, errsAndCodes[i]))
+			continue
+		}
+		code, ok := errsAndCodes[i+1].(int)
+		if !ok || code <= 0 || uint64(code) > uint64(^uint32(0)) {
+			cs.setErr(fmt.Errorf("codespace %q: invalid code %v for %T", cs.name, errsAndCodes[i+1], err))
+			continue
+		}
+		cs.entries = append(cs.entries, Entry{
+			Code: Code{Codespace: cs.name, Code: uint32(code)},
+			Type: typeOf(err),
+		})
+	}
+	return cs
+}
+
+func (cs *Codespace) setErr(err error) {
+	if cs.err == nil {
+		cs.err = err
+	}
+}
+
+// Registry maps error types to their codes.
+type Registry struct {
+	mu         sync.RWMutex
+	codespaces map[string]*Codespace
+	byType     map[reflect.Type]Code
+}
+
+// NewRegistry returns an empty registry.
+func NewRegistry() *Registry {
+	return &Registry{
+		codespaces: make(map[string]*Codespace),
+		byType:     make(map[reflect.Type]Code),
+	}
+}
+
+// Register adds the errors of cs to r. It returns an error, and doesn't add
+// any of them, if they collide with the registered ones: if the codespace is
+// already registered, or if a code or an error type is registered twice.
+func (r *Registry) Register(cs *Codespace) error {
+	if cs.err != nil {
+		return cs.err
+	}
+	if cs.name == "" || cs.name == Undefined.Codespace {
+		return fmt.Errorf("invalid codespace name %q", cs.name)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if _, ok := r.codespaces[cs.name]; ok {
+		return fmt.Errorf("codespace %q is already registered", cs.name)
+	}
+	codes := make(map[uint32]reflect.Type, len(cs.entries))
+	types := make(map[reflect.Type]struct{}, len(cs.entries))
+	for _, e := range cs.entries {
+		if other, ok := codes[e.Code.Code]; ok {
+			return fmt.Errorf("code %s of %v is already registered for %v", e.Code, e.Type, other)
+		}
+		codes[e.Code.Code] = e.Type
+		if existing, ok := r.byType[e.Type]; ok {
+			return fmt.Errorf("error %v is already registered with code %s", e.Type, existing)
+		}
+		if _, ok := types[e.Type]; ok {
+			return fmt.Errorf("error %v is registered twice in codespace %q", e.Type, cs.name)
+		}
+		types[e.Type] = struct{}{}
+	}
+
+	r.codespaces[cs.name] = cs
+	for _, e := range cs.entries {
+		r.byType[e.Type] = e.Code
+	}
+	return nil
+}
+
+// Lookup returns the code of the type of the cause of err, as returned by
+// [errors.Cause], and whether it's registered.
+func (r *Registry) Lookup(err error) (Code, bool) {
+	if err == nil {
+		return OK, true
+	}
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	code, ok := r.byType[typeOf(errors.Cause(err))]
+	return code, ok
+}
+
+// Of returns the code of err: [OK] if err is nil, and [Undefined] if its type
+// isn't registered.
+func (r *Registry) Of(err error) Code {
+	code, ok := r.Lookup(err)
+	if !ok {
+		return Undefined
+	}
+	return code
+}
+
+// Entries returns all the registered errors, sorted by codespace and code.
+func (r *Registry) Entries() []Entry {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	var entries []Entry
+	for _, cs := range r.codespaces {
+		entries = append(entries, cs.entries...)
+	}
+	sort.Slice(entries, func(i, j int) bool {
+		if entries[i].Codespace != entries[j].Codespace {
+			return entries[i].Codespace < entries[j].Codespace
+		}
+		return entries[i].Code.Code < entries[j].Code.Code
+	})
+	return entries
+}
+
+// typeOf returns the type of err, dereferenced if it's a pointer, so that
+// errors are registered and looked up by value.
+func typeOf(err error) reflect.Type {
+	rt := reflect.TypeOf(err)
+	for rt != nil && rt.Kind() == reflect.Ptr {
+		rt = rt.Elem()
+	}
+	return rt
+}
+
+// ----------------------------------------
+// Default registry
+
+var defaultRegistry = NewRegistry()
+
+// Register adds the errors of cs to the default registry, and returns cs. It
+// panics if they collide with the registered ones.
+func Register(cs *Codespace) *Codespace {
+	if err := defaultRegistry.Register(cs); err != nil {
+		panic(err)
+	}
+	return cs
+}
+
+// Lookup returns the code of err in the default registry, and whether it's
+// registered.
+func Lookup(err error) (Code, bool) {
+	return defaultRegistry.Lookup(err)
+}
+
+// Of returns the code of err in the default registry.
+func Of(err error) Code {
+	return defaultRegistry.Of(err)
+}
+
+// Entries returns all the errors of the default registry, sorted by codespace
+// and code.
+func Entries() []Entry {
+	return defaultRegistry.Entries()
+}
@@ -0,0 +1,117 @@
+package errcode
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/errors"
+)
+
+type fooError struct{}
+
+func (fooError) Error() string { return "foo" }
+
+type barError struct{ msg string }
+
+func (e barError) Error() string { return e.msg }
+
+type bazError struct{}
+
+func (*bazError) Error() string { return "baz" }
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+	require.NoError(t, r.Register(NewCodespace("test").WithErrors(
+		fooError{}, 1,
+		barError{}, 2,
+		&bazError{}, 3,
+	)))
+
+	tests := []struct {
+		name string
+		err  error
+		want Code
+		ok   bool
+	}{
+		{"nil", nil, OK, true},
+		{"value", fooError{}, Code{"test", 1}, true},
+		{"value with fields", barError{msg: "bar"}, Code{"test", 2}, true},
+		{"pointer", &bazError{}, Code{"test", 3}, true},
+		{"pointer to value", &fooError{}, Code{"test", 1}, true},
+		{"wrapped", errors.Wrap(barError{}, "context"), Code{"test", 2}, true},
+		{"unregistered", errors.New("other"), Undefined, false},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			code, ok := r.Lookup(tt.err)
+			assert.Equal(t, tt.ok, ok)
+			assert.Equal(t, tt.want, r.Of(tt.err))
+			if ok {
+				assert.Equal(t, tt.want, code)
+			}
+		})
+	}
+
+	entries := r.Entries()
+	require.Len(t, entries, 3)
+	for i, e := range entries {
+		assert.Equal(t, "test", e.Codespace)
+		assert.Equal(t, uint32(i+1), e.Code.Code)
+	}
+}
+
+func TestRegistry_Entries(t *testing.T) {
+	t.Parallel()
+
+	r := NewRegistry()
+	require.NoError(t, r.Register(NewCodespace("b").WithErrors(barError{}, 2, fooError{}, 1)))
+	require.NoError(t, r.Register(NewCodespace("a").WithErrors(&bazError{}, 7)))
+
+	var codes []string
+	for _, e := range r.Entries() {
+		codes = append(codes, e.Code.String())
+	}
+	assert.Equal(t, []string{"a:7", "b:1", "b:2"}, codes)
+}
+
+func TestRegistry_Collisions(t *testing.T) {
+	t.Parallel()
+
+	tests := []struct {
+		name string
+		cs   *Codespace
+		err  string
+	}{
+		{"empty name", NewCodespace(""), `invalid codespace name ""`},
+		{"undefined name", NewCodespace("undefined"), `invalid codespace name "undefined"`},
+		{"same codespace", NewCodespace("test"), `codespace "test" is already registered`},
+		{"registered type", NewCodespace("other").WithErrors(fooError{}, 1), "already registered with code test:1"},
+		{"same code", NewCodespace("other").WithErrors(barError{}, 1, &bazError{}, 1), "code other:1"},
+		{"same type", NewCodespace("other").WithErrors(barError{}, 1, barError{}, 2), "registered twice"},
+		{"zero code", NewCodespace("other").WithErrors(barError{}, 0), "invalid code 0"},
+		{"not an error", NewCodespace("other").WithErrors("bar", 1), "string is not an error"},
+		{"odd arguments", NewCodespace("other").WithErrors(barError{}), "pairs"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			t.Parallel()
+
+			r := NewRegistry()
+			require.NoError(t, r.Register(NewCodespace("test").WithErrors(fooError{}, 1)))
+			err := r.Register(tt.cs)
+			require.Error(t, err)
+			assert.Contains(t, err.Error(), tt.err)
+
+			// Nothing of a rejected codespace is registered.
+			_, ok := r.Lookup(barError{})
+			assert.False(t, ok)
+			assert.Len(t, r.Entries(), 1)
+		})
+	}
+}
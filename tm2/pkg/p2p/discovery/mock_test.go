@@ -9,19 +9,21 @@ import (
 )
 
 type (
-	broadcastDelegate        func(byte, []byte)
-	peersDelegate            func() p2p.PeerSet
-	stopPeerForErrorDelegate func(p2p.PeerConn, error)
-	dialPeersDelegate        func(...*types.NetAddress)
-	subscribeDelegate        func(events.EventFilter) (<-chan events.Event, func())
+	broadcastDelegate         func(byte, []byte)
+	peersDelegate             func() p2p.PeerSet
+	stopPeerForErrorDelegate  func(p2p.PeerConn, error)
+	reportMisbehaviorDelegate func(p2p.PeerConn, p2p.Misbehavior, error)
+	dialPeersDelegate         func(...*types.NetAddress)
+	subscribeDelegate         func(events.EventFilter) (<-chan events.Event, func())
 )
 
 type mockSwitch struct {
-	broadcastFn        broadcastDelegate
-	peersFn            peersDelegate
-	stopPeerForErrorFn stopPeerForErrorDelegate
-	dialPeersFn        dialPeersDelegate
-	subscribeFn        subscribeDelegate
+	broadcastFn         broadcastDelegate
+	peersFn             peersDelegate
+	stopPeerForErrorFn  stopPeerForErrorDelegate
+	reportMisbehaviorFn reportMisbehaviorDelegate
+	dialPeersFn         dialPeersDelegate
+	subscribeFn         subscribeDelegate
 }
 
 func (m *mockSwitch) Broadcast(chID byte, data []byte) {
@@ -44,6 +46,12 @@ func (m *mockSwitch) StopPeerForError(peer p2p.PeerConn, err error) {
 	}
 }
 
+func (m *mockSwitch) ReportMisbehavior(peer p2p.PeerConn, misbehavior p2p.Misbehavior, err error) {
+	if m.reportMisbehaviorFn != nil {
+		m.reportMisbehaviorFn(peer, misbehavior, err)
+	}
+}
+
 func (m *mockSwitch) DialPeers(peerAddrs ...*types.NetAddress) {
 	if m.dialPeersFn != nil {
 		m.dialPeersFn(peerAddrs...)
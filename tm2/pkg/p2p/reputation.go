@@ -0,0 +1,207 @@
+package p2p
+
+import (
+	"cmp"
+	"slices"
+	"sync"
+	"time"
+
+	"github.com/gnolang/gno/tm2/pkg/p2p/types"
+)
+
+// Misbehavior is a kind of peer misbehavior,
+// lowering the reputation score of the peer
+type Misbehavior int
+
+const (
+	// MisbehaviorInvalidBlock is a block failing validation
+	MisbehaviorInvalidBlock Misbehavior = iota
+
+	// MisbehaviorMalformedMessage is a message that can't be decoded,
+	// or that is invalid
+	MisbehaviorMalformedMessage
+
+	// MisbehaviorDisconnect is a peer connection closed on error
+	MisbehaviorDisconnect
+)
+
+func (m Misbehavior) String() string {
+	switch m {
+	case MisbehaviorInvalidBlock:
+		return "invalid block"
+	case MisbehaviorMalformedMessage:
+		return "malformed message"
+	case MisbehaviorDisconnect:
+		return "disconnect"
+	default:
+		return "unknown misbehavior"
+	}
+}
+
+// penalty returns the score lost by a peer for the misbehavior
+func (m Misbehavior) penalty() int64 {
+	switch m {
+	case MisbehaviorInvalidBlock:
+		return 50
+	case MisbehaviorMalformedMessage:
+		return 20
+	default:
+		// Disconnects are expected once in a while (restarts, network issues),
+		// only a burst of them is a misbehavior
+		return 5
+	}
+}
+
+const (
+	// maxReputationScore is the score of a peer without misbehavior.
+	// A peer whose score drops to 0 is banned
+	maxReputationScore int64 = 100
+
+	// scoreRecoveryInterval is the time for a peer to recover a score point
+	scoreRecoveryInterval = 30 * time.Second
+
+	// baseBanDuration and maxBanDuration bound the ban of a peer,
+	// which doubles with each new ban
+	baseBanDuration = time.Minute
+	maxBanDuration  = 24 * time.Hour
+)
+
+// PeerReputation is the reputation of a peer that misbehaved
+type PeerReputation struct {
+	ID    types.ID `json:"id"`
+	Score int64    `json:"score"` // between 0 and 100, recovering over time
+
+	InvalidBlocks     uint64 `json:"invalid_blocks"`
+	MalformedMessages uint64 `json:"malformed_messages"`
+	Disconnects       uint64 `json:"disconnects"`
+
+	Bans        uint64    `json:"bans"`         // number of times the peer was banned
+	BannedUntil time.Time `json:"banned_until"` // end of the last ban
+}
+
+// reputationBook keeps the reputation of the peers that misbehaved
+type reputationBook struct {
+	mux   sync.Mutex
+	peers map[types.ID]*PeerReputation
+
+	updated map[types.ID]time.Time // last score update of the peers
+	now     func() time.Time
+}
+
+func newReputationBook() *reputationBook {
+	return &reputationBook{
+		peers:   make(map[types.ID]*PeerReputation),
+		updated: make(map[types.ID]time.Time),
+		now:     time.Now,
+	}
+}
+
+// report lowers the score of the peer for the misbehavior, and bans the peer
+// if the score drops to 0 and the peer is bannable.
+// Returns the end of the new ban, if any
+func (b *reputationBook) report(id types.ID, m Misbehavior, bannable bool) (time.Time, bool) {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	now := b.now()
+	b.prune(now)
+
+	rep, ok := b.peers[id]
+	if !ok {
+		rep = &PeerReputation{
+			ID:    id,
+			Score: maxReputationScore,
+		}
+
+		b.peers[id] = rep
+		b.updated[id] = now
+	}
+
+	b.recover(rep, now)
+
+	switch m {
+	case MisbehaviorInvalidBlock:
+		rep.InvalidBlocks++
+	case MisbehaviorMalformedMessage:
+		rep.MalformedMessages++
+	case MisbehaviorDisconnect:
+		rep.Disconnects++
+	}
+
+	rep.Score = max(rep.Score-m.penalty(), 0)
+
+	if rep.Score > 0 || !bannable {
+		return time.Time{}, false
+	}
+
+	// Ban the peer, for longer with each ban.
+	// Once the ban expires, the peer starts over with a full score
+	rep.BannedUntil = now.Add(calculateBackoff(uint(min(rep.Bans, 16)), baseBanDuration, maxBanDuration))
+	rep.Bans++
+	rep.Score = maxReputationScore
+	b.updated[id] = rep.BannedUntil
+
+	return rep.BannedUntil, true
+}
+
+// isBanned returns a flag indicating if the peer is currently banned
+func (b *reputationBook) isBanned(id types.ID) bool {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	rep, ok := b.peers[id]
+
+	return ok && b.now().Before(rep.BannedUntil)
+}
+
+// list returns the reputation of the peers that misbehaved, sorted by ID
+func (b *reputationBook) list() []PeerReputation {
+	b.mux.Lock()
+	defer b.mux.Unlock()
+
+	now := b.now()
+	reps := make([]PeerReputation, 0, len(b.peers))
+
+	for _, rep := range b.peers {
+		b.recover(rep, now)
+
+		reps = append(reps, *rep)
+	}
+
+	slices.SortFunc(reps, func(a, b PeerReputation) int {
+		return cmp.Compare(a.ID, b.ID)
+	})
+
+	return reps
+}
+
+// recover gives back to the peer the score points
+// recovered since its last update
+func (b *reputationBook) recover(rep *PeerReputation, now time.Time) {
+	updated := b.updated[rep.ID]
+	if !now.After(updated) {
+		// Banned peers only start recovering once the ban expires
+		return
+	}
+
+	points := int64(now.Sub(updated) / scoreRecoveryInterval)
+	if points == 0 {
+		return
+	}
+
+	rep.Score = min(rep.Score+points, maxReputationScore)
+	b.updated[rep.ID] = updated.Add(time.Duration(points) * scoreRecoveryInterval)
+}
+
+// prune drops the peers that fully recovered, and whose last ban
+// is old enough not to matter anymore
+func (b *reputationBook) prune(now time.Time) {
+	for id, rep := range b.peers {
+		b.recover(rep, now)
+
+		if rep.Score == maxReputationScore && now.Sub(rep.BannedUntil) > maxBanDuration {
+			delete(b.peers, id)
+			delete(b.updated, id)
+		}
+	}
+}
@@ -0,0 +1,133 @@
+package p2p
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/p2p/types"
+)
+
+// newTestReputationBook creates a reputation book with a manual clock
+func newTestReputationBook(t *testing.T) (*reputationBook, *time.Time) {
+	t.Helper()
+
+	var (
+		now  = time.Date(2024, 1, 1, 0, 0, 0, 0, time.UTC)
+		book = newReputationBook()
+	)
+
+	book.now = func() time.Time {
+		return now
+	}
+
+	return book, &now
+}
+
+func TestReputationBook_Report(t *testing.T) {
+	t.Parallel()
+
+	t.Run("score lowered", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			book, _ = newTestReputationBook(t)
+			id      = types.ID("peer")
+		)
+
+		_, banned := book.report(id, MisbehaviorMalformedMessage, true)
+		require.False(t, banned)
+
+		_, banned = book.report(id, MisbehaviorDisconnect, true)
+		require.False(t, banned)
+
+		reps := book.list()
+		require.Len(t, reps, 1)
+
+		assert.Equal(t, id, reps[0].ID)
+		assert.Equal(t, maxReputationScore-20-5, reps[0].Score)
+		assert.Equal(t, uint64(1), reps[0].MalformedMessages)
+		assert.Equal(t, uint64(1), reps[0].Disconnects)
+		assert.Zero(t, reps[0].Bans)
+		assert.False(t, book.isBanned(id))
+	})
+
+	t.Run("score recovered", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			book, now = newTestReputationBook(t)
+			id        = types.ID("peer")
+		)
+
+		book.report(id, MisbehaviorInvalidBlock, true)
+
+		*now = now.Add(10*scoreRecoveryInterval + scoreRecoveryInterval/2)
+		assert.Equal(t, maxReputationScore-50+10, book.list()[0].Score)
+
+		// The partial interval is not lost
+		*now = now.Add(scoreRecoveryInterval / 2)
+		assert.Equal(t, maxReputationScore-50+11, book.list()[0].Score)
+
+		// Recovered peers are eventually dropped
+		*now = now.Add(maxBanDuration)
+		book.report("other", MisbehaviorDisconnect, true)
+
+		reps := book.list()
+		require.Len(t, reps, 1)
+		assert.Equal(t, types.ID("other"), reps[0].ID)
+	})
+
+	t.Run("peer banned", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			book, now = newTestReputationBook(t)
+			id        = types.ID("peer")
+		)
+
+		_, banned := book.report(id, MisbehaviorInvalidBlock, true)
+		require.False(t, banned)
+
+		until, banned := book.report(id, MisbehaviorInvalidBlock, true)
+		require.True(t, banned)
+
+		assert.InDelta(t, baseBanDuration, until.Sub(*now), float64(baseBanDuration/10))
+		assert.True(t, book.isBanned(id))
+
+		rep := book.list()[0]
+		assert.Equal(t, uint64(1), rep.Bans)
+		assert.Equal(t, until, rep.BannedUntil)
+
+		// The ban expires
+		*now = until
+		assert.False(t, book.isBanned(id))
+
+		// The next ban is longer
+		for range 2 {
+			book.report(id, MisbehaviorInvalidBlock, true)
+		}
+
+		assert.True(t, book.isBanned(id))
+		assert.InDelta(t, 2*baseBanDuration, book.list()[0].BannedUntil.Sub(*now), float64(2*baseBanDuration/10))
+	})
+
+	t.Run("peer not bannable", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			book, _ = newTestReputationBook(t)
+			id      = types.ID("peer")
+		)
+
+		for range 3 {
+			_, banned := book.report(id, MisbehaviorInvalidBlock, false)
+			require.False(t, banned)
+		}
+
+		assert.False(t, book.isBanned(id))
+		assert.Zero(t, book.list()[0].Score)
+	})
+}
@@ -74,6 +74,8 @@ type MultiplexSwitch struct {
 	privatePeers    sync.Map // ID -> nothing; lookup table of peers who are not shared
 	transport       Transport
 
+	reputation *reputationBook // scores of the peers that misbehaved
+
 	dialQueue  *dial.Queue
 	dialNotify chan struct{}
 	events     *events.Events
@@ -90,6 +92,7 @@ func NewMultiplexSwitch(
 		reactors:         make(map[string]Reactor),
 		peers:            newSet(),
 		transport:        transport,
+		reputation:       newReputationBook(),
 		dialQueue:        dial.NewQueue(),
 		dialNotify:       make(chan struct{}, 1),
 		events:           events.New(),
@@ -99,9 +102,11 @@ func NewMultiplexSwitch(
 
 	// Set up the peer dial behavior
 	sw.peerBehavior = &reactorPeerBehavior{
-		chDescs:         make([]*conn.ChannelDescriptor, 0),
-		reactorsByCh:    make(map[byte]Reactor),
-		handlePeerErrFn: sw.StopPeerForError,
+		chDescs:      make([]*conn.ChannelDescriptor, 0),
+		reactorsByCh: make(map[byte]Reactor),
+		handlePeerErrFn: func(p PeerConn, err error) {
+			sw.ReportMisbehavior(p, MisbehaviorDisconnect, err)
+		},
 		isPersistentPeerFn: func(id types.ID) bool {
 			return sw.isPersistentPeer(id)
 		},
@@ -218,6 +223,36 @@ func (sw *MultiplexSwitch) StopPeerForError(peer PeerConn, err error) {
 	sw.DialPeers(peer.SocketAddr())
 }
 
+// ReportMisbehavior lowers the reputation score of the peer for the given
+// misbehavior, and stops the peer with the given reason.
+// A peer whose score drops to 0 is banned for a period doubling with each ban:
+// it is neither dialed nor accepted until the ban expires.
+// Persistent peers are never banned
+func (sw *MultiplexSwitch) ReportMisbehavior(peer PeerConn, misbehavior Misbehavior, err error) {
+	until, banned := sw.reputation.report(peer.ID(), misbehavior, !peer.IsPersistent())
+	if !banned {
+		sw.StopPeerForError(peer, err)
+
+		return
+	}
+
+	sw.Logger.Warn(
+		"Banning peer",
+		"peer", peer,
+		"misbehavior", misbehavior,
+		"until", until,
+		"err", err,
+	)
+
+	sw.stopAndRemovePeer(peer, err)
+}
+
+// PeerReputations returns the reputation
+// of the peers that misbehaved, sorted by ID
+func (sw *MultiplexSwitch) PeerReputations() []PeerReputation {
+	return sw.reputation.list()
+}
+
 func (sw *MultiplexSwitch) stopAndRemovePeer(peer PeerConn, err error) {
 	// Remove the peer from the transport
 	sw.transport.Remove(peer)
@@ -307,6 +342,16 @@ func (sw *MultiplexSwitch) runDialLoop(ctx context.Context) {
 				continue
 			}
 
+			// Check if the peer is banned
+			if sw.reputation.isBanned(peerAddr.ID) {
+				sw.Logger.Warn(
+					"ignoring dial request for banned peer",
+					"id", peerAddr.ID,
+				)
+
+				continue
+			}
+
 			// Create a dial context
 			dialCtx, cancelFn := context.WithTimeout(ctx, defaultDialTimeout)
 			defer cancelFn()
@@ -611,6 +656,25 @@ func (sw *MultiplexSwitch) dialItems(dialItems ...dial.Item) {
 	}
 }
 
+// SetPersistentPeers replaces the persistent peer set. The new persistent
+// peers are dialed by the redial loop, while the connections to the peers
+// which are no longer persistent are kept, but not redialed once closed.
+func (sw *MultiplexSwitch) SetPersistentPeers(peerAddrs []*types.NetAddress) {
+	keep := make(map[types.ID]struct{}, len(peerAddrs))
+	for _, addr := range peerAddrs {
+		keep[addr.ID] = struct{}{}
+		sw.persistentPeers.Store(addr.ID, addr)
+	}
+
+	sw.persistentPeers.Range(func(key, _ any) bool {
+		if _, ok := keep[key.(types.ID)]; !ok {
+			sw.persistentPeers.Delete(key)
+		}
+
+		return true
+	})
+}
+
 // isPersistentPeer returns a flag indicating if a peer
 // is present in the persistent peer set
 func (sw *MultiplexSwitch) isPersistentPeer(id types.ID) bool {
@@ -650,6 +714,17 @@ func (sw *MultiplexSwitch) runAcceptLoop(ctx context.Context) {
 			continue
 		}
 
+		// Ignore connection if the peer is banned
+		if sw.reputation.isBanned(p.ID()) {
+			sw.Logger.Info(
+				"Ignoring inbound connection: peer is banned",
+				"address", p.SocketAddr(),
+			)
+
+			sw.transport.Remove(p)
+			continue
+		}
+
 		// Ignore connection if we already have enough peers.
 		if in := sw.Peers().NumInbound(); in >= sw.maxInboundPeers {
 			sw.Logger.Info(
@@ -87,6 +87,22 @@ func TestMultiplexSwitch_Options(t *testing.T) {
 	})
 }
 
+func TestMultiplexSwitch_SetPersistentPeers(t *testing.T) {
+	t.Parallel()
+
+	var (
+		peers = generateNetAddr(t, 4)
+		sw    = NewMultiplexSwitch(nil, WithPersistentPeers(peers[:2]))
+	)
+
+	sw.SetPersistentPeers(peers[1:])
+
+	assert.False(t, sw.isPersistentPeer(peers[0].ID))
+	for _, p := range peers[1:] {
+		assert.True(t, sw.isPersistentPeer(p.ID))
+	}
+}
+
 func TestMultiplexSwitch_Broadcast(t *testing.T) {
 	t.Parallel()
 
@@ -237,6 +253,85 @@ func TestMultiplexSwitch_StopPeer(t *testing.T) {
 	})
 }
 
+func TestMultiplexSwitch_ReportMisbehavior(t *testing.T) {
+	t.Parallel()
+
+	t.Run("peer banned", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			p             = mock.GeneratePeers(t, 1)[0]
+			mockTransport = &mockTransport{}
+
+			sw = NewMultiplexSwitch(mockTransport)
+		)
+
+		// Create a new peer set
+		sw.peers = newSet()
+
+		// Save the single peer
+		sw.peers.Add(p)
+
+		// Report the peer once, and make sure it's stopped
+		sw.ReportMisbehavior(p, MisbehaviorInvalidBlock, errors.New("invalid block"))
+
+		assert.False(t, sw.peers.Has(p.ID()))
+		assert.False(t, sw.reputation.isBanned(p.ID()))
+
+		// Report the peer again, and make sure it's banned
+		sw.peers.Add(p)
+		sw.ReportMisbehavior(p, MisbehaviorInvalidBlock, errors.New("invalid block"))
+
+		assert.False(t, sw.peers.Has(p.ID()))
+		assert.True(t, sw.reputation.isBanned(p.ID()))
+
+		reps := sw.PeerReputations()
+		require.Len(t, reps, 1)
+
+		assert.Equal(t, p.ID(), reps[0].ID)
+		assert.Equal(t, uint64(2), reps[0].InvalidBlocks)
+		assert.Equal(t, uint64(1), reps[0].Bans)
+	})
+
+	t.Run("persistent peer", func(t *testing.T) {
+		t.Parallel()
+
+		var (
+			p             = mock.GeneratePeers(t, 1)[0]
+			mockTransport = &mockTransport{
+				netAddressFn: func() types.NetAddress {
+					return types.NetAddress{}
+				},
+			}
+
+			sw = NewMultiplexSwitch(mockTransport)
+		)
+
+		// Make sure the peer is persistent
+		p.IsPersistentFn = func() bool {
+			return true
+		}
+
+		p.IsOutboundFn = func() bool {
+			return false
+		}
+
+		// Create a new peer set
+		sw.peers = newSet()
+
+		// Report the peer until its score drops to 0
+		for range 2 {
+			sw.peers.Add(p)
+			sw.ReportMisbehavior(p, MisbehaviorInvalidBlock, errors.New("invalid block"))
+		}
+
+		// Make sure the peer is not banned, and redialed
+		assert.False(t, sw.peers.Has(p.ID()))
+		assert.False(t, sw.reputation.isBanned(p.ID()))
+		assert.True(t, sw.dialQueue.Has(p.SocketAddr()))
+	})
+}
+
 func TestMultiplexSwitch_DialLoop(t *testing.T) {
 	t.Parallel()
 
@@ -481,6 +576,55 @@ func TestMultiplexSwitch_AcceptLoop(t *testing.T) {
 		assert.True(t, peerRemoved)
 	})
 
+	t.Run("peer banned", func(t *testing.T) {
+		t.Parallel()
+
+		ctx, cancelFn := context.WithTimeout(
+			context.Background(),
+			5*time.Second,
+		)
+		defer cancelFn()
+
+		var (
+			ch = make(chan struct{}, 1)
+
+			peerRemoved bool
+
+			p = mock.GeneratePeers(t, 1)[0]
+
+			mockTransport = &mockTransport{
+				acceptFn: func(_ context.Context, _ PeerBehavior) (PeerConn, error) {
+					return p, nil
+				},
+				removeFn: func(removedPeer PeerConn) {
+					require.Equal(t, p.ID(), removedPeer.ID())
+
+					peerRemoved = true
+
+					ch <- struct{}{}
+				},
+			}
+
+			sw = NewMultiplexSwitch(mockTransport)
+		)
+
+		// Ban the peer
+		for range 2 {
+			sw.reputation.report(p.ID(), MisbehaviorInvalidBlock, true)
+		}
+
+		// Run the accept loop
+		go sw.runAcceptLoop(ctx)
+
+		select {
+		case <-ch:
+		case <-time.After(5 * time.Second):
+		}
+
+		assert.True(t, peerRemoved)
+		assert.False(t, sw.peers.Has(p.ID()))
+	})
+
 	t.Run("peer accepted", func(t *testing.T) {
 		t.Parallel()
 
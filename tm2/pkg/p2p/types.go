@@ -87,6 +87,10 @@ type Switch interface {
 	// StopPeerForError stops the peer with the given reason
 	StopPeerForError(peer PeerConn, err error)
 
+	// ReportMisbehavior lowers the reputation of the peer for the misbehavior,
+	// and stops the peer with the given reason, banning it if its reputation is too low
+	ReportMisbehavior(peer PeerConn, misbehavior Misbehavior, err error)
+
 	// DialPeers marks the given peers as ready for async dialing
 	DialPeers(peerAddrs ...*types.NetAddress)
 }
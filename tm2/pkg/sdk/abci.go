@@ -17,6 +17,16 @@ type BeginBlocker func(ctx Context, req abci.RequestBeginBlock) abci.ResponseBeg
 // e.g. BFT timestamps rather than block height for any periodic EndBlock logic
 type EndBlocker func(ctx Context, req abci.RequestEndBlock) abci.ResponseEndBlock
 
+// PrepareProposalHandler selects and orders the txs of a block being proposed.
+// It runs on the proposer only, against a throwaway cache of the last
+// committed state, and may drop txs that would fail or reorder the rest.
+type PrepareProposalHandler func(ctx Context, req abci.RequestPrepareProposal) abci.ResponsePrepareProposal
+
+// ProcessProposalHandler decides whether a received proposal block is
+// acceptable. It runs on every validator before prevoting and must be
+// deterministic: returning an error makes the validator prevote nil.
+type ProcessProposalHandler func(ctx Context, req abci.RequestProcessProposal) abci.ResponseProcessProposal
+
 // BeginTxHook is a BaseApp-specific hook, called to modify the context with any
 // additional application-specific information, before running the messages in a
 // transaction.
@@ -25,3 +35,8 @@ type BeginTxHook func(ctx Context) Context
 // EndTxHook is a BaseApp-specific hook, called after all the messages in a
 // transaction have terminated.
 type EndTxHook func(ctx Context, result Result)
+
+// MetadataHook is a BaseApp-specific hook, called to complete the metadata
+// returned by the ".app/metadata" query with application-specific information,
+// like its message types and modules.
+type MetadataHook func(ctx Context, md *abci.AppMetadata)
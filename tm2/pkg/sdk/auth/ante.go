@@ -102,6 +102,10 @@ func NewAnteHandler(ak AccountKeeper, bank BankKeeperI, sigGasConsumer Signature
 			return newCtx, abciResult(err), true
 		}
 
+		if res := ValidateTxSize(newCtx.TxBytes(), params); !res.IsOK() {
+			return newCtx, res, true
+		}
+
 		newCtx.GasMeter().ConsumeGas(params.TxSizeCostPerByte*store.Gas(len(newCtx.TxBytes())), "txSize")
 
 		if res := ValidateMemo(tx, params); !res.IsOK() {
@@ -194,6 +198,21 @@ func ValidateSigCount(tx std.Tx, params Params) sdk.Result {
 	return sdk.Result{}
 }
 
+// ValidateTxSize validates the size of the encoded tx. A MaxTxBytes of 0 means
+// that it's only limited by the size of the blocks.
+func ValidateTxSize(txBytes []byte, params Params) sdk.Result {
+	if params.MaxTxBytes > 0 && int64(len(txBytes)) > params.MaxTxBytes {
+		return abciResult(std.ErrTxTooLarge(
+			fmt.Sprintf(
+				"maximum number of bytes is %d but received %d bytes",
+				params.MaxTxBytes, len(txBytes),
+			),
+		))
+	}
+
+	return sdk.Result{}
+}
+
 // ValidateMemo validates the memo size.
 func ValidateMemo(tx std.Tx, params Params) sdk.Result {
 	memoLength := len(tx.GetMemo())
@@ -788,6 +788,37 @@ func TestAnteHandlerSigLimitExceeded(t *testing.T) {
 	checkInvalidTx(t, anteHandler, ctx, tx, false, std.TooManySignaturesError{})
 }
 
+func TestAnteHandlerTxSizeExceeded(t *testing.T) {
+	t.Parallel()
+
+	// setup
+	env := setupTestEnv()
+	anteHandler := NewAnteHandler(env.acck, env.bankk, DefaultSigVerificationGasConsumer, defaultAnteOptions())
+	params := DefaultParams()
+	params.MaxTxBytes = 100
+	ctx := env.ctx.WithValue(AuthParamsContextKey{}, params)
+
+	// keys and addresses
+	priv1, _, addr1 := tu.KeyTestPubAddr()
+
+	// set the accounts
+	acc1 := env.acck.NewAccountWithAddress(ctx, addr1)
+	acc1.SetCoins(tu.NewTestCoins())
+	env.acck.SetAccount(ctx, acc1)
+
+	msg := tu.NewTestMsg(addr1)
+	privs, accnums, seqs := []crypto.PrivKey{priv1}, []uint64{0}, []uint64{0}
+	tx := tu.NewTestTx(t, ctx.ChainID(), []std.Msg{msg}, privs, accnums, seqs, tu.NewTestFee())
+
+	// tx too large
+	checkInvalidTx(t, anteHandler, ctx.WithTxBytes(make([]byte, 101)), tx, false, std.TxTooLargeError{})
+	_, res, _ := anteHandler(ctx.WithTxBytes(make([]byte, 101)), tx, false)
+	assert.Contains(t, res.Log, "maximum number of bytes is 100 but received 101 bytes")
+
+	// tx at the limit
+	checkValidTx(t, anteHandler, ctx.WithTxBytes(make([]byte, 100)), tx, false)
+}
+
 func TestEnsureSufficientMempoolFees(t *testing.T) {
 	t.Parallel()
 
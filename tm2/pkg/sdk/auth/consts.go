@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"encoding/binary"
+
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 )
 
@@ -20,9 +22,26 @@ const (
 	GasPriceKey = "gasPrice"
 	// param key for global account number
 	GlobalAccountNumberKey = "globalAccountNumber"
+	// AccountNumberHeightKeyPrefix prefix for the first account number
+	// assigned at each height, indexing the account creation heights
+	AccountNumberHeightKeyPrefix = "/anh/"
+
+	// DefaultAccountsLimit is the default page size of account listings.
+	DefaultAccountsLimit = 100
+	// MaxAccountsLimit is the maximum page size of account listings.
+	MaxAccountsLimit = 1000
+	// MaxAccountsScanned is the maximum number of accounts read by a single
+	// account listing, matching the filters or not.
+	MaxAccountsScanned = 10 * MaxAccountsLimit
 )
 
 // AddressStoreKey turn an address to key used to get it from the account store
 func AddressStoreKey(addr crypto.Address) []byte {
 	return append([]byte(AddressStoreKeyPrefix), addr.Bytes()...)
 }
+
+// AccountNumberHeightKey returns the key of the first account number assigned
+// at height.
+func AccountNumberHeightKey(height int64) []byte {
+	return binary.BigEndian.AppendUint64([]byte(AccountNumberHeightKeyPrefix), uint64(height))
+}
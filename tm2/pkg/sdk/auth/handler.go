@@ -54,9 +54,13 @@ func (ah authHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.Re
 
 // queryAccount fetch an account for the supplied height.
 // Account address are passed as path component.
+// Without an address, accounts are listed as per queryAccounts.
 func (ah authHandler) queryAccount(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
 	// parse addr from path.
 	b32addr := thirdPart(req.Path)
+	if b32addr == "" {
+		return ah.queryAccounts(ctx, req)
+	}
 	addr, err := crypto.AddressFromBech32(b32addr)
 	if err != nil {
 		res = sdk.ABCIResponseQueryFromError(
@@ -79,6 +83,64 @@ func (ah authHandler) queryAccount(ctx sdk.Context, req abci.RequestQuery) (res
 	return
 }
 
+// queryAccounts lists a page of accounts matching the filters of the
+// JSON-encoded AccountsQuery passed as data.
+func (ah authHandler) queryAccounts(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	var q AccountsQuery
+	if len(req.Data) > 0 {
+		if err := amino.UnmarshalJSON(req.Data, &q); err != nil {
+			res = sdk.ABCIResponseQueryFromError(
+				std.ErrUnknownRequest(fmt.Sprintf("invalid accounts query: %s", err.Error())))
+			return
+		}
+	}
+
+	var start crypto.Address
+	if q.Start != "" {
+		var err error
+		start, err = crypto.AddressFromBech32(q.Start)
+		if err != nil {
+			res = sdk.ABCIResponseQueryFromError(
+				std.ErrInvalidAddress("invalid start address " + q.Start))
+			return
+		}
+	}
+
+	limit := q.Limit
+	switch {
+	case limit <= 0:
+		limit = DefaultAccountsLimit
+	case limit > MaxAccountsLimit:
+		limit = MaxAccountsLimit
+	}
+
+	result := AccountsResult{Accounts: []std.Account{}}
+	matching := true
+	if q.CreatedAfterHeight > 0 {
+		// No account created after the height matches nothing.
+		var accNumber uint64
+		accNumber, matching = ah.acck.FirstAccountNumberAfter(ctx, q.CreatedAfterHeight)
+		q.MinAccountNumber = max(q.MinAccountNumber, accNumber)
+	}
+	if matching {
+		var next crypto.Address
+		result.Accounts, next = ah.acck.ListAccounts(ctx, start, limit, MaxAccountsScanned, q.Match)
+		if !next.IsZero() {
+			result.Next = next.String()
+		}
+	}
+
+	bz, err := amino.MarshalJSONIndent(result, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
 // queryGasPrice fetch a gas price of the last block.
 func (ah authHandler) queryGasPrice(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
 	// get account from addr.
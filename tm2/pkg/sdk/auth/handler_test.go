@@ -58,6 +58,89 @@ func TestQueryAccount(t *testing.T) {
 	require.True(t, bytes.Equal(res.Data, bz))
 }
 
+func TestQueryAccounts(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.acck, env.gk)
+
+	// the last account is created at height 2
+	for i := range 3 {
+		ctx := env.ctx.WithBlockHeader(&bft.Header{Height: int64(1 + i/2), ChainID: "test-chain-id"})
+		_, _, addr := tu.KeyTestPubAddr()
+		acc := env.acck.NewAccountWithAddress(ctx, addr)
+		acc.SetCoins(std.NewCoins(std.NewCoin("foo", int64(10*i))))
+		env.acck.SetAccount(ctx, acc)
+	}
+
+	query := func(t *testing.T, data string) AccountsResult {
+		t.Helper()
+
+		res := h.Query(env.ctx, abci.RequestQuery{
+			Path: fmt.Sprintf("auth/%s", QueryAccount),
+			Data: []byte(data),
+		})
+		require.Nil(t, res.Error)
+
+		var result AccountsResult
+		require.NoError(t, amino.UnmarshalJSON(res.Data, &result))
+		return result
+	}
+
+	t.Run("all accounts", func(t *testing.T) {
+		t.Parallel()
+
+		result := query(t, "")
+		require.Len(t, result.Accounts, 3)
+		require.Empty(t, result.Next)
+	})
+
+	t.Run("paginated", func(t *testing.T) {
+		t.Parallel()
+
+		first := query(t, `{"limit": "2"}`)
+		require.Len(t, first.Accounts, 2)
+		require.NotEmpty(t, first.Next)
+
+		second := query(t, fmt.Sprintf(`{"limit": "2", "start": %q}`, first.Next))
+		require.Len(t, second.Accounts, 1)
+		require.Empty(t, second.Next)
+		require.Equal(t, first.Next, second.Accounts[0].GetAddress().String())
+	})
+
+	t.Run("min balance", func(t *testing.T) {
+		t.Parallel()
+
+		result := query(t, `{"min_balance": "10foo"}`)
+		require.Len(t, result.Accounts, 2)
+		for _, acc := range result.Accounts {
+			require.GreaterOrEqual(t, acc.GetCoins().AmountOf("foo"), int64(10))
+		}
+	})
+
+	t.Run("created after height", func(t *testing.T) {
+		t.Parallel()
+
+		result := query(t, `{"created_after_height": "1"}`)
+		require.Len(t, result.Accounts, 1)
+		require.Equal(t, int64(20), result.Accounts[0].GetCoins().AmountOf("foo"))
+
+		result = query(t, `{"created_after_height": "2"}`)
+		require.Empty(t, result.Accounts)
+		require.Empty(t, result.Next)
+	})
+
+	t.Run("invalid query", func(t *testing.T) {
+		t.Parallel()
+
+		res := h.Query(env.ctx, abci.RequestQuery{
+			Path: fmt.Sprintf("auth/%s", QueryAccount),
+			Data: []byte(`{"start": "invalid"}`),
+		})
+		require.NotNil(t, res.Error)
+	})
+}
+
 func TestQueryGasPrice(t *testing.T) {
 	t.Parallel()
 
@@ -2,6 +2,7 @@ package auth
 
 import (
 	"context"
+	"encoding/binary"
 	"fmt"
 	"log/slog"
 	"math/big"
@@ -123,6 +124,48 @@ func (ak AccountKeeper) IterateAccounts(ctx sdk.Context, process func(std.Accoun
 	}
 }
 
+// ListAccounts returns up to limit accounts for which filter returns true,
+// in address order, starting at the account with address start (inclusive).
+// A nil filter matches every account. At most maxScan accounts are read, so
+// that a selective filter doesn't make the listing go through the whole
+// store. If accounts remain to be read, next is the address of the first of
+// them, to be used as start of the next page; the page may then hold less
+// than limit accounts, or none.
+func (ak AccountKeeper) ListAccounts(
+	ctx sdk.Context,
+	start crypto.Address,
+	limit int,
+	maxScan int,
+	filter func(std.Account) bool,
+) (accs []std.Account, next crypto.Address) {
+	prefix := []byte(AddressStoreKeyPrefix)
+	from := prefix
+	if !start.IsZero() {
+		from = AddressStoreKey(start)
+	}
+
+	stor := ctx.GasStore(ak.key)
+	iter := stor.Iterator(from, store.PrefixEndBytes(prefix))
+	defer iter.Close()
+
+	accs = []std.Account{}
+	for scanned := 0; iter.Valid(); iter.Next() {
+		acc := ak.decodeAccount(iter.Value())
+		if scanned == maxScan {
+			return accs, acc.GetAddress()
+		}
+		scanned++
+		if filter != nil && !filter(acc) {
+			continue
+		}
+		if len(accs) == limit {
+			return accs, acc.GetAddress()
+		}
+		accs = append(accs, acc)
+	}
+	return accs, crypto.Address{}
+}
+
 // GetPubKey Returns the PubKey of the account at address
 func (ak AccountKeeper) GetPubKey(ctx sdk.Context, addr crypto.Address) (crypto.PubKey, error) {
 	acc := ak.GetAccount(ctx, addr)
@@ -158,9 +201,37 @@ func (ak AccountKeeper) GetNextAccountNumber(ctx sdk.Context) uint64 {
 	bz = amino.MustMarshal(accNumber + 1)
 	stor.Set([]byte(GlobalAccountNumberKey), bz)
 
+	// Index the first account number of the block, so that accounts can be
+	// listed by creation height. The lookup is charged on every account
+	// creation, and the write on the first one of each height.
+	// NOTE: this is consensus-breaking, as it changes the gas of account
+	// creations and adds the index to the app hash.
+	hkey := AccountNumberHeightKey(ctx.BlockHeight())
+	if !stor.Has(hkey) {
+		stor.Set(hkey, binary.BigEndian.AppendUint64(nil, accNumber))
+	}
+
 	return accNumber
 }
 
+// FirstAccountNumberAfter returns the account number of the first account
+// created after height, if any. As account numbers are assigned
+// sequentially, the accounts created after height are those with at least
+// this account number. The creation heights are only indexed since the
+// index was introduced: accounts created before that are considered created
+// at the first indexed height.
+func (ak AccountKeeper) FirstAccountNumberAfter(ctx sdk.Context, height int64) (uint64, bool) {
+	stor := ctx.GasStore(ak.key)
+	prefix := []byte(AccountNumberHeightKeyPrefix)
+	iter := stor.Iterator(AccountNumberHeightKey(height+1), store.PrefixEndBytes(prefix))
+	defer iter.Close()
+	if !iter.Valid() {
+		return 0, false
+	}
+
+	return binary.BigEndian.Uint64(iter.Value()), true
+}
+
 // -----------------------------------------------------------------------------
 // Misc.
 func (ak AccountKeeper) decodeAccount(bz []byte) (acc std.Account) {
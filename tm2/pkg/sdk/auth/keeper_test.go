@@ -6,8 +6,10 @@ import (
 
 	"github.com/stretchr/testify/require"
 
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
 )
 
 func TestAccountMapperGetSet(t *testing.T) {
@@ -176,3 +178,98 @@ func TestCalcBlockGasPrice(t *testing.T) {
 	newGasPrice = gk.calcBlockGasPrice(lastGasPrice, gasUsed, maxGas, params)
 	require.Equal(t, int64(100), newGasPrice.Price.Amount)
 }
+
+func TestAccountKeeperListAccounts(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+
+	addrs := make([]crypto.Address, 5)
+	for i := range addrs {
+		addrs[i] = crypto.AddressFromPreimage([]byte{byte(i)})
+		acc := env.acck.NewAccountWithAddress(env.ctx, addrs[i])
+		acc.SetCoins(std.NewCoins(std.NewCoin("foo", int64(i))))
+		env.acck.SetAccount(env.ctx, acc)
+	}
+	all := env.acck.GetAllAccounts(env.ctx)
+	require.Len(t, all, len(addrs))
+
+	// paginate over all accounts
+	var (
+		listed []std.Account
+		start  crypto.Address
+	)
+	for {
+		accs, next := env.acck.ListAccounts(env.ctx, start, 2, 10, nil)
+		require.LessOrEqual(t, len(accs), 2)
+		listed = append(listed, accs...)
+		if next.IsZero() {
+			break
+		}
+		start = next
+	}
+	require.Equal(t, all, listed)
+
+	// filter
+	rich := func(acc std.Account) bool {
+		return acc.GetCoins().AmountOf("foo") >= 3
+	}
+	accs, next := env.acck.ListAccounts(env.ctx, crypto.Address{}, 10, 10, rich)
+	require.Len(t, accs, 2)
+	require.True(t, next.IsZero())
+	for _, acc := range accs {
+		require.True(t, rich(acc))
+	}
+
+	// scan cap: the pages may be incomplete, but together list every
+	// matching account
+	listed, start = nil, crypto.Address{}
+	for {
+		accs, next := env.acck.ListAccounts(env.ctx, start, 10, 2, rich)
+		listed = append(listed, accs...)
+		if next.IsZero() {
+			break
+		}
+		require.NotEqual(t, start, next)
+		start = next
+	}
+	require.Len(t, listed, 2)
+}
+
+func TestAccountKeeperFirstAccountNumberAfter(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+
+	// two accounts at height 1, one at heights 3 and 5
+	heights := []int64{1, 1, 3, 5}
+	gas := make([]int64, len(heights))
+	for i, height := range heights {
+		ctx := env.ctx.WithBlockHeader(&bft.Header{Height: height, ChainID: "test-chain-id"}).
+			WithGasMeter(store.NewInfiniteGasMeter())
+		acc := env.acck.NewAccountWithAddress(ctx, crypto.AddressFromPreimage([]byte{byte(i)}))
+		require.Equal(t, uint64(i), acc.GetAccountNumber())
+		gas[i] = ctx.GasMeter().GasConsumed()
+		env.acck.SetAccount(ctx, acc)
+	}
+	// the index is charged when written, for the first account of a height
+	require.Greater(t, gas[0], gas[1])
+	require.Greater(t, gas[2], gas[1])
+
+	for _, tc := range []struct {
+		height    int64
+		accNumber uint64
+		found     bool
+	}{
+		{0, 0, true},
+		{1, 2, true},
+		{2, 2, true},
+		{3, 3, true},
+		{4, 3, true},
+		{5, 0, false},
+	} {
+		accNumber, found := env.acck.FirstAccountNumberAfter(env.ctx, tc.height)
+		require.Equal(t, tc.found, found, "height %d", tc.height)
+		require.Equal(t, tc.accNumber, accNumber, "height %d", tc.height)
+	}
+}
@@ -15,6 +15,7 @@ type AuthParamsContextKey struct{}
 // Default parameter values
 const (
 	DefaultMaxMemoBytes           int64 = 65536
+	DefaultMaxTxBytes             int64 = 1_000_000 // same as the default Block.MaxTxBytes
 	DefaultTxSigLimit             int64 = 7
 	DefaultTxSizeCostPerByte      int64 = 10
 	DefaultSigVerifyCostED25519   int64 = 590
@@ -29,6 +30,7 @@ const (
 // Params defines the parameters for the auth module.
 type Params struct {
 	MaxMemoBytes              int64            `json:"max_memo_bytes" yaml:"max_memo_bytes"`
+	MaxTxBytes                int64            `json:"max_tx_bytes" yaml:"max_tx_bytes"`
 	TxSigLimit                int64            `json:"tx_sig_limit" yaml:"tx_sig_limit"`
 	TxSizeCostPerByte         int64            `json:"tx_size_cost_per_byte" yaml:"tx_size_cost_per_byte"`
 	SigVerifyCostED25519      int64            `json:"sig_verify_cost_ed25519" yaml:"sig_verify_cost_ed25519"`
@@ -64,7 +66,7 @@ func (p Params) Equals(p2 Params) bool {
 
 // DefaultParams returns a default set of parameters.
 func DefaultParams() Params {
-	return NewParams(
+	p := NewParams(
 		DefaultMaxMemoBytes,
 		DefaultTxSigLimit,
 		DefaultTxSizeCostPerByte,
@@ -74,6 +76,8 @@ func DefaultParams() Params {
 		DefaultTargetGasRatio,
 		crypto.AddressFromPreimage([]byte(DefaultFeeCollectorName)),
 	)
+	p.MaxTxBytes = DefaultMaxTxBytes
+	return p
 }
 
 // String implements the stringer interface.
@@ -82,6 +86,7 @@ func (p Params) String() string {
 	sb := &builder // Pointer for use with fmt.Fprintf
 	sb.WriteString("Params: \n")
 	fmt.Fprintf(sb, "MaxMemoBytes: %d\n", p.MaxMemoBytes)
+	fmt.Fprintf(sb, "MaxTxBytes: %d\n", p.MaxTxBytes)
 	fmt.Fprintf(sb, "TxSigLimit: %d\n", p.TxSigLimit)
 	fmt.Fprintf(sb, "TxSizeCostPerByte: %d\n", p.TxSizeCostPerByte)
 	fmt.Fprintf(sb, "SigVerifyCostED25519: %d\n", p.SigVerifyCostED25519)
@@ -96,6 +101,9 @@ func (p Params) Validate() error {
 	if p.MaxMemoBytes <= 0 {
 		return fmt.Errorf("invalid max memo bytes: %d", p.MaxMemoBytes)
 	}
+	if p.MaxTxBytes < 0 {
+		return fmt.Errorf("invalid max tx bytes: %d, 0 is unlimited", p.MaxTxBytes)
+	}
 	if p.TxSigLimit <= 0 {
 		return fmt.Errorf("invalid tx signature limit: %d", p.TxSigLimit)
 	}
@@ -1,6 +1,8 @@
 package auth
 
 import (
+	"strings"
+
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -13,6 +15,7 @@ type AccountKeeperI interface {
 	GetAllAccounts(ctx sdk.Context) []std.Account
 	SetAccount(ctx sdk.Context, acc std.Account)
 	IterateAccounts(ctx sdk.Context, process func(std.Account) bool)
+	ListAccounts(ctx sdk.Context, start crypto.Address, limit, maxScan int, filter func(std.Account) bool) ([]std.Account, crypto.Address)
 	InitGenesis(ctx sdk.Context, data GenesisState)
 	GetParams(ctx sdk.Context) Params
 }
@@ -32,3 +35,52 @@ type GasPriceKeeperI interface {
 }
 
 var _ GasPriceKeeperI = GasPriceKeeper{}
+
+// AccountsQuery is the JSON-encoded data of the "auth/accounts" query when
+// no address is given, listing accounts page by page.
+//
+// Accounts can't be filtered by owner, as an account is only controlled by
+// the key of its address: query it directly with "auth/accounts/{ADDRESS}".
+type AccountsQuery struct {
+	// Start is the bech32 address of the first account of the page,
+	// as returned in AccountsResult.Next. Empty starts from the beginning.
+	Start string `json:"start"`
+	// Limit is the maximum number of accounts returned.
+	// Defaults to DefaultAccountsLimit, and is capped to MaxAccountsLimit.
+	Limit int `json:"limit"`
+
+	// Prefix only matches accounts whose bech32 address has this prefix.
+	Prefix string `json:"prefix"`
+	// MinBalance only matches accounts holding at least these coins.
+	MinBalance std.Coins `json:"min_balance"`
+	// MinAccountNumber only matches accounts with at least this account
+	// number. Account numbers are assigned sequentially on creation, so
+	// this selects accounts created after a given account.
+	MinAccountNumber uint64 `json:"min_account_number"`
+	// CreatedAfterHeight, if positive, only matches accounts created after
+	// this height. It's resolved to a MinAccountNumber by the handler, see
+	// AccountKeeper.FirstAccountNumberAfter.
+	CreatedAfterHeight int64 `json:"created_after_height"`
+}
+
+// AccountsResult is the response of a paginated "auth/accounts" query.
+type AccountsResult struct {
+	Accounts []std.Account `json:"accounts"`
+	// Next is the Start of the next page, empty if this is the last one.
+	// As the number of accounts read per query is capped, a page may hold
+	// less than Limit accounts even though Next isn't empty.
+	Next string `json:"next"`
+}
+
+// Match reports whether acc satisfies the filters of the query.
+func (q AccountsQuery) Match(acc std.Account) bool {
+	switch {
+	case q.Prefix != "" && !strings.HasPrefix(acc.GetAddress().String(), q.Prefix):
+		return false
+	case !q.MinBalance.IsZero() && !acc.GetCoins().IsAllGTE(q.MinBalance):
+		return false
+	case acc.GetAccountNumber() < q.MinAccountNumber:
+		return false
+	}
+	return true
+}
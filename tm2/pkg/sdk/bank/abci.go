@@ -0,0 +1,11 @@
+package bank
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+)
+
+// EndBlocker is called in the EndBlock(), it executes the scheduled sends
+// that are due at the current block height or time.
+func EndBlocker(ctx sdk.Context, bank BankKeeperI) {
+	bank.ExecuteDueSends(ctx)
+}
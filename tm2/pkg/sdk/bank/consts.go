@@ -1,5 +1,83 @@
 package bank
 
+import (
+	"encoding/binary"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+)
+
 const (
 	ModuleName = "bank"
+
+	// ScheduledSendKeyPrefix prefix for scheduled-send-by-id store
+	ScheduledSendKeyPrefix = "/bank/ss/"
+	// ScheduledSendHeightKeyPrefix prefix for the index of scheduled sends by
+	// execution height
+	ScheduledSendHeightKeyPrefix = "/bank/ssh/"
+	// ScheduledSendTimeKeyPrefix prefix for the index of scheduled sends by
+	// execution time
+	ScheduledSendTimeKeyPrefix = "/bank/sst/"
+	// key for the next scheduled send id
+	NextScheduledSendIDKey = "/bank/nextScheduledSendID"
+	// AddressOwnerKeyPrefix prefix for the owners of derived addresses
+	AddressOwnerKeyPrefix = "/bank/owner/"
+	// JournalKeyPrefix prefix for the journal entries, by account and height
+	JournalKeyPrefix = "/bank/jrnl/"
+	// key for the next journal entry id
+	NextJournalIDKey = "/bank/nextJournalID"
+
+	// MaxScheduledSendsPerBlock is the maximum number of scheduled sends
+	// executed by a block; the other due sends are carried over.
+	MaxScheduledSendsPerBlock = 100
+
+	// DefaultJournalLimit is the default page size of journal queries.
+	DefaultJournalLimit = 100
+	// MaxJournalLimit is the maximum page size of journal queries.
+	MaxJournalLimit = 1000
+)
+
+// Events emitted by the bank handler and keeper, and their attributes.
+// The addresses are indexed.
+const (
+	EventTypeTransfer      = "transfer"       // sender, recipient, amount
+	EventTypeCoinSpent     = "coin_spent"     // spender, amount
+	EventTypeCoinReceived  = "coin_received"  // receiver, amount
+	EventTypeBalanceChange = "balance_change" // address, balance
+	EventTypeMint          = "mint"           // minter, amount
+	EventTypeBurn          = "burn"           // burner, amount
+
+	AttributeKeySender    = "sender"
+	AttributeKeyRecipient = "recipient"
+	AttributeKeySpender   = "spender"
+	AttributeKeyReceiver  = "receiver"
+	AttributeKeyAmount    = "amount"
+	AttributeKeyAddress   = "address"
+	AttributeKeyBalance   = "balance"
+	AttributeKeyMinter    = "minter"
+	AttributeKeyBurner    = "burner"
 )
+
+// ScheduledSendKey returns the key used to store the scheduled send with the
+// given id.
+func ScheduledSendKey(id uint64) []byte {
+	return binary.BigEndian.AppendUint64([]byte(ScheduledSendKeyPrefix), id)
+}
+
+// ScheduledSendHeightKey returns the index key of the scheduled send with the
+// given id, executing at height.
+func ScheduledSendHeightKey(height int64, id uint64) []byte {
+	key := binary.BigEndian.AppendUint64([]byte(ScheduledSendHeightKeyPrefix), uint64(height))
+	return binary.BigEndian.AppendUint64(key, id)
+}
+
+// ScheduledSendTimeKey returns the index key of the scheduled send with the
+// given id, executing at unixTime.
+func ScheduledSendTimeKey(unixTime int64, id uint64) []byte {
+	key := binary.BigEndian.AppendUint64([]byte(ScheduledSendTimeKeyPrefix), uint64(unixTime))
+	return binary.BigEndian.AppendUint64(key, id)
+}
+
+// AddressOwnerKey returns the key used to store the owner of addr.
+func AddressOwnerKey(addr crypto.Address) []byte {
+	return append([]byte(AddressOwnerKeyPrefix), addr.Bytes()...)
+}
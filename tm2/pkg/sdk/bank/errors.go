@@ -1,6 +1,7 @@
 package bank
 
 import (
+	"github.com/gnolang/gno/tm2/pkg/errcode"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 )
 
@@ -35,3 +36,38 @@ func ErrNoOutputs() error {
 func ErrInputOutputMismatch() error {
 	return errors.Wrap(InputOutputMismatchError{}, "")
 }
+
+type UnknownScheduledSendError struct{ abciError }
+
+func (e UnknownScheduledSendError) Error() string { return "unknown scheduled send" }
+
+func ErrUnknownScheduledSend(id uint64) error {
+	return errors.Wrapf(UnknownScheduledSendError{}, "scheduled send %d does not exist", id)
+}
+
+type (
+	UnknownModuleAccountError struct{ abciError }
+	MissingPermissionError    struct{ abciError }
+)
+
+func (e UnknownModuleAccountError) Error() string { return "unknown module account" }
+func (e MissingPermissionError) Error() string    { return "module account is missing permission" }
+
+func ErrUnknownModuleAccount(name string) error {
+	return errors.Wrapf(UnknownModuleAccountError{}, "module account %q is not registered", name)
+}
+
+func ErrMissingPermission(name, perm string) error {
+	return errors.Wrapf(MissingPermissionError{}, "module account %q does not have the %s permission", name, perm)
+}
+
+// Codespace holds the codes of the bank errors, returned in ABCI responses.
+// NOTE: codes are stable; never change or reuse them.
+var Codespace = errcode.Register(errcode.NewCodespace("bank").WithErrors(
+	NoInputsError{}, 1,
+	NoOutputsError{}, 2,
+	InputOutputMismatchError{}, 3,
+	UnknownScheduledSendError{}, 4,
+	UnknownModuleAccountError{}, 5,
+	MissingPermissionError{}, 6,
+))
@@ -2,6 +2,7 @@ package bank
 
 import (
 	"fmt"
+	"strconv"
 	"strings"
 
 	"github.com/gnolang/gno/tm2/pkg/amino"
@@ -30,6 +31,12 @@ func (bh bankHandler) Process(ctx sdk.Context, msg std.Msg) sdk.Result {
 	case MsgMultiSend:
 		return bh.handleMsgMultiSend(ctx, msg)
 
+	case MsgSendAt:
+		return bh.handleMsgSendAt(ctx, msg)
+
+	case MsgCancelSendAt:
+		return bh.handleMsgCancelSendAt(ctx, msg)
+
 	default:
 		errMsg := fmt.Sprintf("unrecognized bank message type: %T", msg)
 		return abciResult(std.ErrUnknownRequest(errMsg))
@@ -47,20 +54,12 @@ func (bh bankHandler) handleMsgSend(ctx sdk.Context, msg MsgSend) sdk.Result {
 		}
 	*/
 
+	// SendCoins emits the transfer event.
 	err := bh.bank.SendCoins(ctx, msg.FromAddress, msg.ToAddress, msg.Amount)
 	if err != nil {
 		return abciResult(err)
 	}
 
-	/*
-		ctx.EventManager().EmitEvent(
-			sdk.NewEvent(
-				sdk.EventTypeMessage,
-				sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
-			),
-		)
-	*/
-
 	return sdk.Result{}
 }
 
@@ -83,14 +82,46 @@ func (bh bankHandler) handleMsgMultiSend(ctx sdk.Context, msg MsgMultiSend) sdk.
 		return abciResult(err)
 	}
 
-	/*
+	for _, in := range msg.Inputs {
 		ctx.EventManager().EmitEvent(
 			sdk.NewEvent(
-				sdk.EventTypeMessage,
-				sdk.NewAttribute(sdk.AttributeKeyModule, types.AttributeValueCategory),
+				EventTypeCoinSpent, ModuleName,
+				sdk.NewIndexedAttribute(AttributeKeySpender, in.Address.String()),
+				sdk.NewAttribute(AttributeKeyAmount, in.Coins.String()),
 			),
 		)
-	*/
+	}
+	for _, out := range msg.Outputs {
+		ctx.EventManager().EmitEvent(
+			sdk.NewEvent(
+				EventTypeCoinReceived, ModuleName,
+				sdk.NewIndexedAttribute(AttributeKeyReceiver, out.Address.String()),
+				sdk.NewAttribute(AttributeKeyAmount, out.Coins.String()),
+			),
+		)
+	}
+
+	return sdk.Result{}
+}
+
+// Handle MsgSendAt.
+func (bh bankHandler) handleMsgSendAt(ctx sdk.Context, msg MsgSendAt) sdk.Result {
+	id, err := bh.bank.ScheduleSend(ctx, msg.FromAddress, msg.ToAddress, msg.Amount, msg.Height, msg.Time)
+	if err != nil {
+		return abciResult(err)
+	}
+
+	res := sdk.Result{}
+	res.Data = []byte(strconv.FormatUint(id, 10))
+	return res
+}
+
+// Handle MsgCancelSendAt.
+func (bh bankHandler) handleMsgCancelSendAt(ctx sdk.Context, msg MsgCancelSendAt) sdk.Result {
+	err := bh.bank.CancelScheduledSend(ctx, msg.FromAddress, msg.ID)
+	if err != nil {
+		return abciResult(err)
+	}
 
 	return sdk.Result{}
 }
@@ -98,13 +129,27 @@ func (bh bankHandler) handleMsgMultiSend(ctx sdk.Context, msg MsgMultiSend) sdk.
 //----------------------------------------
 // Query
 
-// query balance path
-const QueryBalance = "balances"
+// query paths
+const (
+	QueryBalance       = "balances"
+	QueryScheduledSend = "scheduled"
+	QueryAddressOwner  = "qaddr-owner"
+	QueryModules       = "modules"
+	QueryJournal       = "journal"
+)
 
 func (bh bankHandler) Query(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
 	switch secondPart(req.Path) {
 	case QueryBalance:
 		return bh.queryBalance(ctx, req)
+	case QueryScheduledSend:
+		return bh.queryScheduledSend(ctx, req)
+	case QueryAddressOwner:
+		return bh.queryAddressOwner(ctx, req)
+	case QueryModules:
+		return bh.queryModules(ctx, req)
+	case QueryJournal:
+		return bh.queryJournal(ctx, req)
 	default:
 		res = sdk.ABCIResponseQueryFromError(
 			std.ErrUnknownRequest("unknown bank query endpoint"))
@@ -135,6 +180,101 @@ func (bh bankHandler) queryBalance(ctx sdk.Context, req abci.RequestQuery) (res
 	return
 }
 
+// queryScheduledSend fetch a pending scheduled send.
+// Scheduled send id is passed as path component.
+func (bh bankHandler) queryScheduledSend(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	// parse id from path.
+	idstr := thirdPart(req.Path)
+	id, err := strconv.ParseUint(idstr, 10, 64)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrUnknownRequest("invalid scheduled send id " + idstr))
+		return
+	}
+
+	ss, ok := bh.bank.GetScheduledSend(ctx, id)
+	if !ok {
+		res = sdk.ABCIResponseQueryFromError(ErrUnknownScheduledSend(id))
+		return
+	}
+
+	bz, err := amino.MarshalJSONIndent(ss, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
+// queryAddressOwner fetch the owner of a derived address, like the path of
+// the package of a realm address; the data is empty for user accounts.
+// Address is passed as path component.
+func (bh bankHandler) queryAddressOwner(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	// parse addr from path.
+	b32addr := thirdPart(req.Path)
+	addr, err := crypto.AddressFromBech32(b32addr)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress("invalid query address " + b32addr))
+		return
+	}
+
+	res.Data = []byte(bh.bank.GetAddressOwner(ctx, addr))
+	return
+}
+
+// queryModules fetch the registered module accounts, with their address and
+// permissions.
+func (bh bankHandler) queryModules(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	bz, err := amino.MarshalJSONIndent(bh.bank.ModuleAccounts(), "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
+// queryJournal fetch a page of the journal of an account, selected by the
+// JSON-encoded JournalQuery passed as data.
+// Address is passed as path component.
+func (bh bankHandler) queryJournal(ctx sdk.Context, req abci.RequestQuery) (res abci.ResponseQuery) {
+	// parse addr from path.
+	b32addr := thirdPart(req.Path)
+	addr, err := crypto.AddressFromBech32(b32addr)
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInvalidAddress("invalid query address " + b32addr))
+		return
+	}
+
+	var q JournalQuery
+	if len(req.Data) > 0 {
+		if err := amino.UnmarshalJSON(req.Data, &q); err != nil {
+			res = sdk.ABCIResponseQueryFromError(
+				std.ErrUnknownRequest(fmt.Sprintf("invalid journal query: %s", err.Error())))
+			return
+		}
+	}
+
+	var result JournalResult
+	result.Entries, result.More = bh.bank.GetJournal(ctx, addr, q)
+	bz, err := amino.MarshalJSONIndent(result, "", "  ")
+	if err != nil {
+		res = sdk.ABCIResponseQueryFromError(
+			std.ErrInternal(fmt.Sprintf("could not marshal result to JSON: %s", err.Error())))
+		return
+	}
+
+	res.Data = bz
+	return
+}
+
 //----------------------------------------
 // misc
 
@@ -54,6 +54,32 @@ func TestBalances(t *testing.T) {
 	require.True(t, coins.AmountOf("foo") == 10)
 }
 
+func TestAddressOwner(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.bankk)
+	_, _, addr := tu.KeyTestPubAddr()
+
+	req := abci.RequestQuery{
+		Path: fmt.Sprintf("bank/%s/%s", QueryAddressOwner, addr.String()),
+		Data: []byte{},
+	}
+
+	res := h.Query(env.ctx, req)
+	require.Nil(t, res.Error)
+	require.Empty(t, res.Data) // a user account
+
+	env.bankk.SetAddressOwner(env.ctx, addr, "gno.land/r/demo/escrow")
+	res = h.Query(env.ctx, req)
+	require.Nil(t, res.Error)
+	require.Equal(t, "gno.land/r/demo/escrow", string(res.Data))
+
+	req.Path = fmt.Sprintf("bank/%s/%s", QueryAddressOwner, "g1invalid")
+	res = h.Query(env.ctx, req)
+	require.Error(t, res.Error)
+}
+
 func TestQuerierRouteNotFound(t *testing.T) {
 	t.Parallel()
 
@@ -66,3 +92,134 @@ func TestQuerierRouteNotFound(t *testing.T) {
 	res := h.Query(env.ctx, req)
 	require.Error(t, res.Error)
 }
+
+func TestMsgSendAt(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 5})
+	h := NewHandler(env.bankk)
+	_, _, from := tu.KeyTestPubAddr()
+	_, _, to := tu.KeyTestPubAddr()
+	env.bankk.SetCoins(ctx, from, std.NewCoins(std.NewCoin("foo", 10)))
+
+	amt := std.NewCoins(std.NewCoin("foo", 4))
+	require.Error(t, NewMsgSendAt(from, to, amt, 0, 0).ValidateBasic())
+	require.Error(t, NewMsgSendAt(from, to, amt, 6, 100).ValidateBasic())
+	require.Error(t, NewMsgSendAt(from, to, amt, -1, 0).ValidateBasic())
+
+	msg := NewMsgSendAt(from, to, amt, 6, 0)
+	require.NoError(t, msg.ValidateBasic())
+	res := h.Process(ctx, msg)
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, "0", string(res.Data))
+
+	qres := h.Query(ctx, abci.RequestQuery{Path: fmt.Sprintf("bank/%s/0", QueryScheduledSend)})
+	require.Nil(t, qres.Error)
+	var ss ScheduledSend
+	require.NoError(t, amino.UnmarshalJSON(qres.Data, &ss))
+	require.Equal(t, to, ss.ToAddress)
+	require.True(t, ss.Amount.IsEqual(amt))
+
+	res = h.Process(ctx, NewMsgCancelSendAt(to, 0))
+	require.False(t, res.IsOK())
+	res = h.Process(ctx, NewMsgCancelSendAt(from, 0))
+	require.True(t, res.IsOK(), res.Log)
+	require.True(t, env.bankk.GetCoins(ctx, from).IsEqual(std.NewCoins(std.NewCoin("foo", 10))))
+
+	qres = h.Query(ctx, abci.RequestQuery{Path: fmt.Sprintf("bank/%s/0", QueryScheduledSend)})
+	require.Error(t, qres.Error)
+}
+
+func TestMsgSendEvents(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx.WithEventManager(sdk.NewEventManager())
+	h := NewHandler(env.bankk)
+	_, _, from := tu.KeyTestPubAddr()
+	_, _, to := tu.KeyTestPubAddr()
+	env.bankk.SetCoins(env.ctx, from, std.NewCoins(std.NewCoin("foo", 10)))
+
+	amt := std.NewCoins(std.NewCoin("foo", 4))
+	res := h.Process(ctx, NewMsgSend(from, to, amt))
+	require.True(t, res.IsOK(), res.Log)
+
+	events := ctx.EventManager().Events()
+	require.Len(t, events, 3)
+	require.Equal(t, sdk.NewEvent(EventTypeTransfer, ModuleName,
+		sdk.NewIndexedAttribute(AttributeKeySender, from.String()),
+		sdk.NewIndexedAttribute(AttributeKeyRecipient, to.String()),
+		sdk.NewAttribute(AttributeKeyAmount, amt.String()),
+	), events[0])
+	require.Equal(t, sdk.NewEvent(EventTypeBalanceChange, ModuleName,
+		sdk.NewIndexedAttribute(AttributeKeyAddress, from.String()),
+		sdk.NewAttribute(AttributeKeyBalance, "6foo"),
+	), events[1])
+	require.Equal(t, sdk.NewEvent(EventTypeBalanceChange, ModuleName,
+		sdk.NewIndexedAttribute(AttributeKeyAddress, to.String()),
+		sdk.NewAttribute(AttributeKeyBalance, "4foo"),
+	), events[2])
+}
+
+func TestQueryModules(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	h := NewHandler(env.bankk)
+	addr := env.bankk.RegisterModuleAccount("minter", PermissionMinter)
+
+	res := h.Query(env.ctx, abci.RequestQuery{Path: "bank/" + QueryModules})
+	require.Nil(t, res.Error)
+
+	var mas []ModuleAccount
+	require.NoError(t, amino.UnmarshalJSON(res.Data, &mas))
+	require.Equal(t, []ModuleAccount{{Name: "minter", Address: addr, Permissions: []string{PermissionMinter}}}, mas)
+}
+
+func TestQueryJournal(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 5})
+	h := NewHandler(env.bankk)
+	_, _, from := tu.KeyTestPubAddr()
+	_, _, to := tu.KeyTestPubAddr()
+
+	params := env.bankk.GetParams(ctx)
+	params.Journal = true
+	require.NoError(t, env.bankk.SetParams(ctx, params))
+	env.bankk.SetCoins(ctx, from, std.NewCoins(std.NewCoin("foo", 10)))
+	res := h.Process(ctx, NewMsgSend(from, to, std.NewCoins(std.NewCoin("foo", 4))))
+	require.True(t, res.IsOK(), res.Log)
+
+	qres := h.Query(ctx, abci.RequestQuery{
+		Path: fmt.Sprintf("bank/%s/%s", QueryJournal, from),
+		Data: []byte(`{"from_height":"5","limit":"1"}`),
+	})
+	require.Nil(t, qres.Error)
+	var result JournalResult
+	require.NoError(t, amino.UnmarshalJSON(qres.Data, &result))
+	require.True(t, result.More)
+	require.Len(t, result.Entries, 1)
+	require.Equal(t, JournalKindSet, result.Entries[0].Kind)
+
+	qres = h.Query(ctx, abci.RequestQuery{
+		Path: fmt.Sprintf("bank/%s/%s", QueryJournal, from),
+		Data: []byte(`{"from_height":"5","after":"1"}`),
+	})
+	require.Nil(t, qres.Error)
+	require.NoError(t, amino.UnmarshalJSON(qres.Data, &result))
+	require.False(t, result.More)
+	require.Len(t, result.Entries, 1)
+	require.Equal(t, JournalKindSend, result.Entries[0].Kind)
+	require.Equal(t, to, result.Entries[0].Counterparty)
+
+	qres = h.Query(ctx, abci.RequestQuery{Path: fmt.Sprintf("bank/%s/invalid", QueryJournal)})
+	require.Error(t, qres.Error)
+	qres = h.Query(ctx, abci.RequestQuery{
+		Path: fmt.Sprintf("bank/%s/%s", QueryJournal, from),
+		Data: []byte(`{"limit":`),
+	})
+	require.Error(t, qres.Error)
+}
@@ -0,0 +1,228 @@
+package bank
+
+import (
+	"encoding/binary"
+	"math"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Kinds of the operations recorded in the journal.
+const (
+	JournalKindSend    = "send"    // transfers between accounts
+	JournalKindFee     = "fee"     // gas fees, and the other unrestricted sends
+	JournalKindMint    = "mint"    // coins created
+	JournalKindBurn    = "burn"    // coins destroyed
+	JournalKindEscrow  = "escrow"  // coins held in escrow, like scheduled sends and storage deposits
+	JournalKindRelease = "release" // coins paid out of escrow
+	JournalKindRefund  = "refund"  // coins returned from escrow to their sender
+	JournalKindSet     = "set"     // balances set directly, like at genesis
+)
+
+// JournalEntry is an entry of the journal of an account, recording a change
+// of its balance. The journal is double-entry: an operation moving coins
+// between two accounts records a debit in the journal of the one, and a
+// credit in the journal of the other, both with the ID of the operation.
+type JournalEntry struct {
+	ID           uint64         `json:"id" yaml:"id"`                     // increasing with the height
+	Op           uint64         `json:"op" yaml:"op"`                     // the ID of the first entry of the operation
+	Height       int64          `json:"height" yaml:"height"`             // block height
+	Time         int64          `json:"time" yaml:"time"`                 // block time (unix seconds)
+	Kind         string         `json:"kind" yaml:"kind"`                 // JournalKind*
+	Account      crypto.Address `json:"account" yaml:"account"`           // owner of the journal
+	Counterparty crypto.Address `json:"counterparty" yaml:"counterparty"` // zero if none, like for mints
+	Debit        std.Coins      `json:"debit" yaml:"debit"`               // coins removed from the account
+	Credit       std.Coins      `json:"credit" yaml:"credit"`             // coins added to the account
+	Balance      std.Coins      `json:"balance" yaml:"balance"`           // balance after the operation
+}
+
+// JournalQuery is the JSON-encoded data of the "bank/journal/<address>"
+// query, selecting a page of the journal of the address.
+type JournalQuery struct {
+	// FromHeight and ToHeight are the first and last heights of the entries,
+	// included. A zero ToHeight means no limit.
+	FromHeight int64 `json:"from_height"`
+	ToHeight   int64 `json:"to_height"`
+	// After only returns the entries with a greater ID. To fetch the next
+	// page, pass the height and the ID of the last entry as FromHeight and
+	// After.
+	After uint64 `json:"after"`
+	// Limit is the maximum number of entries returned.
+	// Defaults to DefaultJournalLimit, and is capped to MaxJournalLimit.
+	Limit int `json:"limit"`
+}
+
+// JournalResult is the response of the "bank/journal/<address>" query.
+type JournalResult struct {
+	Entries []JournalEntry `json:"entries"`
+	// More is true if more entries match the query.
+	More bool `json:"more"`
+}
+
+// journalKindKey is the context key of the kind set with WithJournalKind.
+type journalKindKey struct{}
+
+// journalOpKey is the context key of the operation being recorded.
+type journalOpKey struct{}
+
+// journalOp is an operation moving coins from an account to another, either
+// being zero if none. Its ID is assigned when recording its first entry.
+type journalOp struct {
+	kind     string
+	from, to crypto.Address
+	id       uint64
+}
+
+// WithJournalKind returns a context recording the operations of the bank
+// with the given kind, for the modules calling the lower level methods of
+// the bank, like SendCoinsUnrestricted or AddCoins, to describe them.
+func WithJournalKind(ctx sdk.Context, kind string) sdk.Context {
+	return ctx.WithValue(journalKindKey{}, kind)
+}
+
+// withJournalOp returns a context recording the balance changes as an
+// operation of the given kind, from an account to another, unless the kind
+// was set with WithJournalKind.
+func withJournalOp(ctx sdk.Context, kind string, from, to crypto.Address) sdk.Context {
+	if k, ok := ctx.Value(journalKindKey{}).(string); ok {
+		kind = k
+	}
+	return ctx.WithValue(journalOpKey{}, &journalOp{kind: kind, from: from, to: to})
+}
+
+// IsJournalEnabled returns whether the balance changes are recorded in the
+// journal, as set by the journal param.
+func (bank BankKeeper) IsJournalEnabled(ctx sdk.Context) bool {
+	var enabled bool
+	bank.prmk.GetBool(ctx, "p:journal", &enabled)
+	return enabled
+}
+
+// recordJournal records the change of the balance of addr, from oldCoins to
+// newCoins, in its journal, if enabled. It is called by SetCoins, through
+// which all the balance changes go.
+func (bank BankKeeper) recordJournal(ctx sdk.Context, addr crypto.Address, oldCoins, newCoins std.Coins) {
+	if !bank.IsJournalEnabled(ctx) {
+		return
+	}
+	debit, credit := coinsDiff(oldCoins, newCoins)
+	if debit.IsZero() && credit.IsZero() {
+		return
+	}
+
+	op, _ := ctx.Value(journalOpKey{}).(*journalOp)
+	if op == nil {
+		kind := JournalKindSet
+		if k, ok := ctx.Value(journalKindKey{}).(string); ok {
+			kind = k
+		}
+		op = &journalOp{kind: kind}
+	}
+
+	entry := JournalEntry{
+		ID:      bank.getNextJournalID(ctx),
+		Height:  ctx.BlockHeight(),
+		Time:    ctx.BlockTime().Unix(),
+		Kind:    op.kind,
+		Account: addr,
+		Debit:   debit,
+		Credit:  credit,
+		Balance: newCoins,
+	}
+	if op.id == 0 {
+		op.id = entry.ID
+	}
+	entry.Op = op.id
+	switch addr {
+	case op.from:
+		entry.Counterparty = op.to
+	case op.to:
+		entry.Counterparty = op.from
+	}
+
+	stor := ctx.GasStore(bank.key)
+	stor.Set(JournalKey(addr, entry.Height, entry.ID), amino.MustMarshal(entry))
+}
+
+// GetJournal returns the entries of the journal of addr matching q, in
+// order, and whether more entries match it.
+func (bank BankKeeper) GetJournal(ctx sdk.Context, addr crypto.Address, q JournalQuery) ([]JournalEntry, bool) {
+	if q.After == math.MaxUint64 {
+		return nil, false
+	}
+	limit := q.Limit
+	switch {
+	case limit <= 0:
+		limit = DefaultJournalLimit
+	case limit > MaxJournalLimit:
+		limit = MaxJournalLimit
+	}
+	toHeight := q.ToHeight
+	if toHeight <= 0 || toHeight == math.MaxInt64 {
+		toHeight = math.MaxInt64 - 1
+	}
+
+	stor := ctx.Store(bank.key)
+	start := JournalKey(addr, max(q.FromHeight, 0), q.After+1)
+	end := JournalKey(addr, toHeight+1, 0)
+	iter := stor.Iterator(start, end)
+	defer iter.Close()
+
+	var entries []JournalEntry
+	for ; iter.Valid(); iter.Next() {
+		var entry JournalEntry
+		amino.MustUnmarshal(iter.Value(), &entry)
+		if entry.ID <= q.After {
+			continue
+		}
+		if len(entries) == limit {
+			return entries, true
+		}
+		entries = append(entries, entry)
+	}
+	return entries, false
+}
+
+func (bank BankKeeper) getNextJournalID(ctx sdk.Context) uint64 {
+	var id uint64
+	stor := ctx.GasStore(bank.key)
+	bz := stor.Get([]byte(NextJournalIDKey))
+	if bz != nil {
+		amino.MustUnmarshal(bz, &id)
+	}
+	id++ // IDs start at 1, so that After can be zero
+	stor.Set([]byte(NextJournalIDKey), amino.MustMarshal(id))
+	return id
+}
+
+// coinsDiff returns the coins removed from and added to oldCoins to get
+// newCoins.
+func coinsDiff(oldCoins, newCoins std.Coins) (removed, added std.Coins) {
+	amounts := map[string]int64{}
+	for _, c := range newCoins {
+		amounts[c.Denom] += c.Amount
+	}
+	for _, c := range oldCoins {
+		amounts[c.Denom] -= c.Amount
+	}
+	for denom, amount := range amounts {
+		switch {
+		case amount > 0:
+			added = append(added, std.NewCoin(denom, amount))
+		case amount < 0:
+			removed = append(removed, std.NewCoin(denom, -amount))
+		}
+	}
+	return removed.Sort(), added.Sort()
+}
+
+// JournalKey returns the key used to store the journal entry of addr with
+// the given id, recorded at height.
+func JournalKey(addr crypto.Address, height int64, id uint64) []byte {
+	key := append([]byte(JournalKeyPrefix), addr.Bytes()...)
+	key = binary.BigEndian.AppendUint64(key, uint64(height))
+	return binary.BigEndian.AppendUint64(key, id)
+}
@@ -9,6 +9,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/sdk/auth"
 	"github.com/gnolang/gno/tm2/pkg/sdk/params"
 	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
 )
 
 // bank.Keeper defines a module interface that facilitates the transfer of
@@ -24,6 +25,19 @@ type BankKeeperI interface {
 	SetCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) error
 	SendCoinsUnrestricted(ctx sdk.Context, fromAddr crypto.Address, toAddr crypto.Address, amt std.Coins) error
 
+	ScheduleSend(ctx sdk.Context, fromAddr crypto.Address, toAddr crypto.Address, amt std.Coins, height int64, unixTime int64) (uint64, error)
+	CancelScheduledSend(ctx sdk.Context, fromAddr crypto.Address, id uint64) error
+	ExecuteDueSends(ctx sdk.Context)
+
+	SetAddressOwner(ctx sdk.Context, addr crypto.Address, owner string)
+	GetAddressOwner(ctx sdk.Context, addr crypto.Address) string
+
+	GetModuleAccount(name string) (ModuleAccount, bool)
+	MintCoins(ctx sdk.Context, module string, amt std.Coins) error
+	BurnCoins(ctx sdk.Context, module string, amt std.Coins) error
+	SendCoinsFromModuleToAccount(ctx sdk.Context, module string, toAddr crypto.Address, amt std.Coins) error
+	SendCoinsFromAccountToModule(ctx sdk.Context, fromAddr crypto.Address, module string, amt std.Coins) error
+
 	InitGenesis(ctx sdk.Context, data GenesisState)
 	GetParams(ctx sdk.Context) Params
 }
@@ -35,17 +49,26 @@ var _ BankKeeperI = &BankKeeper{}
 type BankKeeper struct {
 	ViewKeeper
 
+	// The (unexposed) key used to access the scheduled sends store.
+	key  store.StoreKey
 	acck auth.AccountKeeper
 	// The keeper used to store parameters
 	prmk params.ParamsKeeperI
+	// The registered module accounts
+	modules *moduleAccounts
 }
 
 // NewBankKeeper returns a new BankKeeper.
-func NewBankKeeper(acck auth.AccountKeeper, pk params.ParamsKeeperI) BankKeeper {
+func NewBankKeeper(key store.StoreKey, acck auth.AccountKeeper, pk params.ParamsKeeperI) BankKeeper {
 	return BankKeeper{
 		ViewKeeper: NewViewKeeper(acck),
+		key:        key,
 		acck:       acck,
 		prmk:       pk,
+		modules: &moduleAccounts{
+			byName: map[string]ModuleAccount{},
+			byAddr: map[crypto.Address]string{},
+		},
 	}
 }
 
@@ -86,6 +109,10 @@ func (bank BankKeeper) InputOutputCoins(ctx sdk.Context, inputs []Input, outputs
 		return err
 	}
 
+	// The inputs and outputs aren't paired, so the entries have no
+	// counterparty.
+	ctx = withJournalOp(ctx, JournalKindSend, crypto.Address{}, crypto.Address{})
+
 	for _, in := range inputs {
 		if !bank.canSendCoins(ctx, in.Address, in.Coins) {
 			return std.RestrictedTransferError{}
@@ -94,6 +121,7 @@ func (bank BankKeeper) InputOutputCoins(ctx sdk.Context, inputs []Input, outputs
 		if err != nil {
 			return err
 		}
+		bank.emitBalanceChanges(ctx, in.Coins, in.Address)
 
 		/*
 			ctx.EventManager().EmitEvent(
@@ -110,6 +138,7 @@ func (bank BankKeeper) InputOutputCoins(ctx sdk.Context, inputs []Input, outputs
 		if err != nil {
 			return err
 		}
+		bank.emitBalanceChanges(ctx, out.Coins, out.Address)
 
 		/*
 			ctx.EventManager().EmitEvent(
@@ -142,7 +171,9 @@ func (bank BankKeeper) canSendCoins(ctx sdk.Context, addr crypto.Address, amt st
 	return true
 }
 
-// SendCoins moves coins from one account to another, restrction could be applied
+// SendCoins moves coins from one account to another, restrction could be applied.
+// It emits a transfer event, for the transfers of users and realms alike,
+// followed by the balance changes of both accounts.
 func (bank BankKeeper) SendCoins(ctx sdk.Context, fromAddr crypto.Address, toAddr crypto.Address, amt std.Coins) error {
 	// read restricted boolean value from param.IsRestrictedTransfer()
 	// canSendCoins is true until they have agreed to the waiver
@@ -150,12 +181,53 @@ func (bank BankKeeper) SendCoins(ctx sdk.Context, fromAddr crypto.Address, toAdd
 		return std.RestrictedTransferError{}
 	}
 
-	return bank.sendCoins(ctx, fromAddr, toAddr, amt)
+	if err := bank.sendCoins(withJournalOp(ctx, JournalKindSend, fromAddr, toAddr), fromAddr, toAddr, amt); err != nil {
+		return err
+	}
+
+	bank.emitTransfer(ctx, fromAddr, toAddr, amt)
+	bank.emitBalanceChanges(ctx, amt, fromAddr, toAddr)
+	return nil
+}
+
+func (bank BankKeeper) emitTransfer(ctx sdk.Context, fromAddr crypto.Address, toAddr crypto.Address, amt std.Coins) {
+	if em := ctx.EventManager(); em != nil && !amt.IsZero() {
+		em.EmitEvent(
+			sdk.NewEvent(
+				EventTypeTransfer, ModuleName,
+				sdk.NewIndexedAttribute(AttributeKeySender, fromAddr.String()),
+				sdk.NewIndexedAttribute(AttributeKeyRecipient, toAddr.String()),
+				sdk.NewAttribute(AttributeKeyAmount, amt.String()),
+			),
+		)
+	}
+}
+
+// emitBalanceChanges emits a balance change event with the resulting balance
+// of each of addrs, so that clients can track the balance of an account
+// without querying it. Only transfers emit them: the gas fees, paid with
+// SendCoinsUnrestricted, don't.
+func (bank BankKeeper) emitBalanceChanges(ctx sdk.Context, amt std.Coins, addrs ...crypto.Address) {
+	em := ctx.EventManager()
+	if em == nil || amt.IsZero() {
+		return
+	}
+	for _, addr := range addrs {
+		em.EmitEvent(
+			sdk.NewEvent(
+				EventTypeBalanceChange, ModuleName,
+				sdk.NewIndexedAttribute(AttributeKeyAddress, addr.String()),
+				sdk.NewAttribute(AttributeKeyBalance, bank.GetCoins(ctx, addr).String()),
+			),
+		)
+	}
 }
 
 // SendCoinsUnrestricted is used for paying gas.
+// It is recorded in the journal as a fee, unless another kind is set with
+// WithJournalKind.
 func (bank BankKeeper) SendCoinsUnrestricted(ctx sdk.Context, fromAddr crypto.Address, toAddr crypto.Address, amt std.Coins) error {
-	return bank.sendCoins(ctx, fromAddr, toAddr, amt)
+	return bank.sendCoins(withJournalOp(ctx, JournalKindFee, fromAddr, toAddr), fromAddr, toAddr, amt)
 }
 
 func (bank BankKeeper) sendCoins(
@@ -237,14 +309,19 @@ func (bank BankKeeper) AddCoins(ctx sdk.Context, addr crypto.Address, amt std.Co
 }
 
 // SetCoins sets the coins at the addr.
+// All the balance changes go through it, and are recorded in the journal
+// when enabled.
 func (bank BankKeeper) SetCoins(ctx sdk.Context, addr crypto.Address, amt std.Coins) error {
 	if !amt.IsValid() {
 		return std.ErrInvalidCoins(amt.String())
 	}
 
+	oldCoins := std.NewCoins()
 	acc := bank.acck.GetAccount(ctx, addr)
 	if acc == nil {
 		acc = bank.acck.NewAccountWithAddress(ctx, addr)
+	} else {
+		oldCoins = acc.GetCoins()
 	}
 
 	err := acc.SetCoins(amt)
@@ -253,6 +330,7 @@ func (bank BankKeeper) SetCoins(ctx sdk.Context, addr crypto.Address, amt std.Co
 	}
 
 	bank.acck.SetAccount(ctx, acc)
+	bank.recordJournal(ctx, addr, oldCoins, amt)
 	return nil
 }
 
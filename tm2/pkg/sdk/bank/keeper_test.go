@@ -1,10 +1,13 @@
 package bank
 
 import (
+	"math"
 	"testing"
+	"time"
 
 	"github.com/stretchr/testify/require"
 
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
 	"github.com/gnolang/gno/tm2/pkg/crypto"
 	"github.com/gnolang/gno/tm2/pkg/sdk"
 	"github.com/gnolang/gno/tm2/pkg/std"
@@ -137,6 +140,12 @@ func TestBankKeeper(t *testing.T) {
 	// negative values.
 	err = bankk.SendCoins(ctx, addr, addr2, sdk.Coins{sdk.Coin{Denom: "FOOCOIN", Amount: -5}})
 	require.Error(t, err)
+
+	// Only the transfers emit balance changes, not the gas fee payments.
+	ectx := ctx.WithEventManager(sdk.NewEventManager())
+	require.NoError(t, bankk.SendCoinsUnrestricted(ectx, addr, addr2, std.NewCoins(std.NewCoin("foocoin", 1))))
+	require.NoError(t, bankk.SetCoins(ectx, addr, std.NewCoins(std.NewCoin("foocoin", 1))))
+	require.Empty(t, ectx.EventManager().Events())
 }
 
 func TestViewKeeper(t *testing.T) {
@@ -186,3 +195,243 @@ func TestSetRestrictedDenoms(t *testing.T) {
 	params = bankk.GetParams(ctx)
 	require.Empty(t, params.RestrictedDenoms)
 }
+
+func TestScheduledSends(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 10, Time: time.Unix(1000, 0)})
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	addr2 := crypto.AddressFromPreimage([]byte("addr2"))
+	addr3 := crypto.AddressFromPreimage([]byte("addr3"))
+	env.bankk.SetCoins(ctx, addr, std.NewCoins(std.NewCoin("foocoin", 100)))
+
+	// Execution point must be in the future, and exactly one must be set.
+	_, err := env.bankk.ScheduleSend(ctx, addr, addr2, std.NewCoins(std.NewCoin("foocoin", 10)), 10, 0)
+	require.Error(t, err)
+	_, err = env.bankk.ScheduleSend(ctx, addr, addr2, std.NewCoins(std.NewCoin("foocoin", 10)), 0, 1000)
+	require.Error(t, err)
+	_, err = env.bankk.ScheduleSend(ctx, addr, addr2, std.NewCoins(std.NewCoin("foocoin", 10)), 11, 1001)
+	require.Error(t, err)
+	_, err = env.bankk.ScheduleSend(ctx, addr, addr2, std.NewCoins(std.NewCoin("foocoin", 101)), 11, 0)
+	require.Error(t, err)
+	require.True(t, env.bankk.GetCoins(ctx, addr).IsEqual(std.NewCoins(std.NewCoin("foocoin", 100))))
+
+	// Coins are escrowed when scheduling.
+	id1, err := env.bankk.ScheduleSend(ctx, addr, addr2, std.NewCoins(std.NewCoin("foocoin", 10)), 12, 0)
+	require.NoError(t, err)
+	id2, err := env.bankk.ScheduleSend(ctx, addr, addr3, std.NewCoins(std.NewCoin("foocoin", 20)), 0, 2000)
+	require.NoError(t, err)
+	id3, err := env.bankk.ScheduleSend(ctx, addr, addr3, std.NewCoins(std.NewCoin("foocoin", 30)), 20, 0)
+	require.NoError(t, err)
+	require.NotEqual(t, id1, id2)
+	require.True(t, env.bankk.GetCoins(ctx, addr).IsEqual(std.NewCoins(std.NewCoin("foocoin", 40))))
+
+	ss, ok := env.bankk.GetScheduledSend(ctx, id2)
+	require.True(t, ok)
+	require.Equal(t, addr3, ss.ToAddress)
+	require.Equal(t, int64(2000), ss.Time)
+
+	// Only the sender can cancel.
+	err = env.bankk.CancelScheduledSend(ctx, addr2, id3)
+	require.Error(t, err)
+	require.NoError(t, env.bankk.CancelScheduledSend(ctx, addr, id3))
+	require.True(t, env.bankk.GetCoins(ctx, addr).IsEqual(std.NewCoins(std.NewCoin("foocoin", 70))))
+	require.Error(t, env.bankk.CancelScheduledSend(ctx, addr, id3))
+
+	// Nothing is due yet.
+	env.bankk.ExecuteDueSends(ctx.WithBlockHeader(&bft.Header{Height: 11, Time: time.Unix(1500, 0)}))
+	require.True(t, env.bankk.GetCoins(ctx, addr2).IsZero())
+	require.True(t, env.bankk.GetCoins(ctx, addr3).IsZero())
+
+	// Height-scheduled send is due.
+	env.bankk.ExecuteDueSends(ctx.WithBlockHeader(&bft.Header{Height: 12, Time: time.Unix(1600, 0)}))
+	require.True(t, env.bankk.GetCoins(ctx, addr2).IsEqual(std.NewCoins(std.NewCoin("foocoin", 10))))
+	require.True(t, env.bankk.GetCoins(ctx, addr3).IsZero())
+	_, ok = env.bankk.GetScheduledSend(ctx, id1)
+	require.False(t, ok)
+	require.Error(t, env.bankk.CancelScheduledSend(ctx, addr, id1))
+
+	// Time-scheduled send is due, once.
+	env.bankk.ExecuteDueSends(ctx.WithBlockHeader(&bft.Header{Height: 13, Time: time.Unix(2500, 0)}))
+	env.bankk.ExecuteDueSends(ctx.WithBlockHeader(&bft.Header{Height: 14, Time: time.Unix(2600, 0)}))
+	require.True(t, env.bankk.GetCoins(ctx, addr2).IsEqual(std.NewCoins(std.NewCoin("foocoin", 10))))
+	require.True(t, env.bankk.GetCoins(ctx, addr3).IsEqual(std.NewCoins(std.NewCoin("foocoin", 20))))
+	require.True(t, env.bankk.GetCoins(ctx, addr).IsEqual(std.NewCoins(std.NewCoin("foocoin", 70))))
+}
+
+func TestScheduledSendsLimits(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 10, Time: time.Unix(1000, 0)})
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	addr2 := crypto.AddressFromPreimage([]byte("addr2"))
+	addr3 := crypto.AddressFromPreimage([]byte("addr3"))
+	env.bankk.SetCoins(ctx, addr, std.NewCoins(std.NewCoin("foocoin", 1000)))
+	env.bankk.SetCoins(ctx, addr3, std.NewCoins(std.NewCoin("foocoin", math.MaxInt64)))
+
+	// Sends due in excess of the limit are carried over to the next blocks.
+	for range MaxScheduledSendsPerBlock + 1 {
+		_, err := env.bankk.ScheduleSend(ctx, addr, addr2, std.NewCoins(std.NewCoin("foocoin", 1)), 11, 0)
+		require.NoError(t, err)
+	}
+	ectx := ctx.WithBlockHeader(&bft.Header{Height: 11, Time: time.Unix(1100, 0)}).WithEventManager(sdk.NewEventManager())
+	env.bankk.ExecuteDueSends(ectx)
+	require.Equal(t, int64(MaxScheduledSendsPerBlock), env.bankk.GetCoins(ctx, addr2).AmountOf("foocoin"))
+	// Each payout is reported as a transfer.
+	transfers := 0
+	for _, ev := range ectx.EventManager().Events() {
+		if ev.(sdk.AttributeEvent).Type == EventTypeTransfer {
+			transfers++
+		}
+	}
+	require.Equal(t, MaxScheduledSendsPerBlock, transfers)
+	env.bankk.ExecuteDueSends(ctx.WithBlockHeader(&bft.Header{Height: 12, Time: time.Unix(1200, 0)}))
+	require.Equal(t, int64(MaxScheduledSendsPerBlock+1), env.bankk.GetCoins(ctx, addr2).AmountOf("foocoin"))
+
+	// A send which can't be paid out is refunded.
+	id, err := env.bankk.ScheduleSend(ctx, addr, addr3, std.NewCoins(std.NewCoin("foocoin", 10)), 13, 0)
+	require.NoError(t, err)
+	before := env.bankk.GetCoins(ctx, addr).AmountOf("foocoin")
+	env.bankk.ExecuteDueSends(ctx.WithBlockHeader(&bft.Header{Height: 13, Time: time.Unix(1300, 0)}))
+	require.Equal(t, before+10, env.bankk.GetCoins(ctx, addr).AmountOf("foocoin"))
+	require.Equal(t, int64(math.MaxInt64), env.bankk.GetCoins(ctx, addr3).AmountOf("foocoin"))
+	_, ok := env.bankk.GetScheduledSend(ctx, id)
+	require.False(t, ok)
+}
+
+func TestModuleAccounts(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	ctx := env.ctx.WithEventManager(sdk.NewEventManager())
+	bankk := env.bankk
+
+	minter := bankk.RegisterModuleAccount("minter", PermissionMinter, PermissionBurner)
+	escrow := bankk.RegisterModuleAccount("escrow")
+	require.Equal(t, ModuleAddress("minter"), minter)
+	require.Panics(t, func() { bankk.RegisterModuleAccount("escrow") })
+	require.Panics(t, func() { bankk.RegisterModuleAccount("other", "superuser") })
+
+	mas := bankk.ModuleAccounts()
+	require.Len(t, mas, 2)
+	require.Equal(t, "escrow", mas[0].Name)
+	require.Equal(t, escrow, mas[0].Address)
+	require.Equal(t, "module:minter", bankk.GetAddressOwner(ctx, minter))
+
+	// Only the module accounts with the permission can mint and burn.
+	coins := std.NewCoins(std.NewCoin("foocoin", 100))
+	require.NoError(t, bankk.MintCoins(ctx, "minter", coins))
+	require.True(t, bankk.GetCoins(ctx, minter).IsEqual(coins))
+	err := bankk.MintCoins(ctx, "escrow", coins)
+	require.ErrorIs(t, err, MissingPermissionError{})
+	err = bankk.MintCoins(ctx, "unknown", coins)
+	require.ErrorIs(t, err, UnknownModuleAccountError{})
+
+	addr := crypto.AddressFromPreimage([]byte("addr1"))
+	require.NoError(t, bankk.SendCoinsFromModuleToAccount(ctx, "minter", addr, coins))
+	require.NoError(t, bankk.SendCoinsFromAccountToModule(ctx, addr, "escrow", std.NewCoins(std.NewCoin("foocoin", 40))))
+	require.Equal(t, int64(60), bankk.GetCoins(ctx, addr).AmountOf("foocoin"))
+	require.Equal(t, int64(40), bankk.GetCoins(ctx, escrow).AmountOf("foocoin"))
+	err = bankk.BurnCoins(ctx, "escrow", std.NewCoins(std.NewCoin("foocoin", 40)))
+	require.ErrorIs(t, err, MissingPermissionError{})
+
+	require.NoError(t, bankk.SendCoinsFromAccountToModule(ctx, addr, "minter", std.NewCoins(std.NewCoin("foocoin", 60))))
+	require.NoError(t, bankk.BurnCoins(ctx, "minter", std.NewCoins(std.NewCoin("foocoin", 50))))
+	require.Equal(t, int64(10), bankk.GetCoins(ctx, minter).AmountOf("foocoin"))
+	err = bankk.BurnCoins(ctx, "minter", std.NewCoins(std.NewCoin("foocoin", 11)))
+	require.Error(t, err)
+
+	// The supply changes are reported with the module account address.
+	var types []string
+	for _, ev := range ctx.EventManager().Events() {
+		aev := ev.(sdk.AttributeEvent)
+		if aev.Type == EventTypeMint || aev.Type == EventTypeBurn {
+			types = append(types, aev.Type)
+			require.Equal(t, minter.String(), aev.Attributes[0].Value)
+		}
+	}
+	require.Equal(t, []string{EventTypeMint, EventTypeBurn}, types)
+}
+
+func TestJournal(t *testing.T) {
+	t.Parallel()
+
+	env := setupTestEnv()
+	bankk := env.bankk
+	ctx := env.ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 1, Time: time.Unix(1000, 0)})
+
+	addr1 := crypto.AddressFromPreimage([]byte("addr1"))
+	addr2 := crypto.AddressFromPreimage([]byte("addr2"))
+	minter := bankk.RegisterModuleAccount("minter", PermissionMinter)
+
+	// Nothing is recorded while disabled.
+	require.NoError(t, bankk.SetCoins(ctx, addr1, std.NewCoins(std.NewCoin("foo", 100))))
+	entries, more := bankk.GetJournal(ctx, addr1, JournalQuery{})
+	require.Empty(t, entries)
+	require.False(t, more)
+
+	params := bankk.GetParams(ctx)
+	params.Journal = true
+	require.NoError(t, bankk.SetParams(ctx, params))
+	require.True(t, bankk.IsJournalEnabled(ctx))
+
+	// A send is recorded as a debit of the sender and a credit of the
+	// recipient, with the same operation.
+	require.NoError(t, bankk.SendCoins(ctx, addr1, addr2, std.NewCoins(std.NewCoin("foo", 30))))
+	ctx = ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 2, Time: time.Unix(1005, 0)})
+	require.NoError(t, bankk.SendCoinsUnrestricted(ctx, addr2, addr1, std.NewCoins(std.NewCoin("foo", 1))))
+	require.NoError(t, bankk.MintCoins(ctx, "minter", std.NewCoins(std.NewCoin("foo", 50))))
+	require.NoError(t, bankk.SendCoinsFromModuleToAccount(ctx, "minter", addr2, std.NewCoins(std.NewCoin("foo", 50))))
+	ctx = ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 3, Time: time.Unix(1010, 0)})
+	_, err := bankk.ScheduleSend(ctx, addr2, addr1, std.NewCoins(std.NewCoin("foo", 9)), 4, 0)
+	require.NoError(t, err)
+	_, err = bankk.AddCoins(WithJournalKind(ctx, JournalKindMint), addr1, std.NewCoins(std.NewCoin("bar", 7)))
+	require.NoError(t, err)
+	ctx = ctx.WithBlockHeader(&bft.Header{ChainID: "test-chain-id", Height: 4, Time: time.Unix(1015, 0)})
+	bankk.ExecuteDueSends(ctx)
+
+	foo := func(amt int64) std.Coins { return std.NewCoins(std.NewCoin("foo", amt)) }
+	var zero crypto.Address
+	entries, more = bankk.GetJournal(ctx, addr1, JournalQuery{})
+	require.False(t, more)
+	require.Equal(t, []JournalEntry{
+		{ID: 1, Op: 1, Height: 1, Time: 1000, Kind: JournalKindSend, Account: addr1, Counterparty: addr2, Debit: foo(30), Balance: foo(70)},
+		{ID: 4, Op: 3, Height: 2, Time: 1005, Kind: JournalKindFee, Account: addr1, Counterparty: addr2, Credit: foo(1), Balance: foo(71)},
+		{ID: 9, Op: 9, Height: 3, Time: 1010, Kind: JournalKindMint, Account: addr1, Counterparty: zero, Credit: std.NewCoins(std.NewCoin("bar", 7)), Balance: std.NewCoins(std.NewCoin("bar", 7), std.NewCoin("foo", 71))},
+		{ID: 10, Op: 10, Height: 4, Time: 1015, Kind: JournalKindRelease, Account: addr1, Counterparty: addr2, Credit: foo(9), Balance: std.NewCoins(std.NewCoin("bar", 7), std.NewCoin("foo", 80))},
+	}, entries)
+
+	entries, _ = bankk.GetJournal(ctx, addr2, JournalQuery{})
+	require.Equal(t, []JournalEntry{
+		{ID: 2, Op: 1, Height: 1, Time: 1000, Kind: JournalKindSend, Account: addr2, Counterparty: addr1, Credit: foo(30), Balance: foo(30)},
+		{ID: 3, Op: 3, Height: 2, Time: 1005, Kind: JournalKindFee, Account: addr2, Counterparty: addr1, Debit: foo(1), Balance: foo(29)},
+		{ID: 7, Op: 6, Height: 2, Time: 1005, Kind: JournalKindSend, Account: addr2, Counterparty: minter, Credit: foo(50), Balance: foo(79)},
+		{ID: 8, Op: 8, Height: 3, Time: 1010, Kind: JournalKindEscrow, Account: addr2, Counterparty: addr1, Debit: foo(9), Balance: foo(70)},
+	}, entries)
+
+	entries, _ = bankk.GetJournal(ctx, minter, JournalQuery{})
+	require.Equal(t, []JournalEntry{
+		{ID: 5, Op: 5, Height: 2, Time: 1005, Kind: JournalKindMint, Account: minter, Counterparty: zero, Credit: foo(50), Balance: foo(50)},
+		{ID: 6, Op: 6, Height: 2, Time: 1005, Kind: JournalKindSend, Account: minter, Counterparty: addr2, Debit: foo(50)},
+	}, entries)
+
+	// Height ranges and pages.
+	entries, more = bankk.GetJournal(ctx, addr1, JournalQuery{FromHeight: 2, ToHeight: 3})
+	require.False(t, more)
+	require.Len(t, entries, 2)
+	require.Equal(t, uint64(4), entries[0].ID)
+	require.Equal(t, uint64(9), entries[1].ID)
+
+	entries, more = bankk.GetJournal(ctx, addr1, JournalQuery{Limit: 3})
+	require.True(t, more)
+	require.Len(t, entries, 3)
+	last := entries[2]
+	entries, more = bankk.GetJournal(ctx, addr1, JournalQuery{FromHeight: last.Height, After: last.ID, Limit: 3})
+	require.False(t, more)
+	require.Len(t, entries, 1)
+	require.Equal(t, uint64(10), entries[0].ID)
+}
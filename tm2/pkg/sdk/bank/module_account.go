@@ -0,0 +1,175 @@
+package bank
+
+import (
+	"slices"
+	"sort"
+
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// Permissions of the module accounts, checked by the keeper before creating
+// or destroying coins on their behalf.
+const (
+	PermissionMinter = "minter" // can mint coins
+	PermissionBurner = "burner" // can burn coins
+)
+
+// ModuleOwnerPrefix prefixes the name of a module account when it's reported
+// as the owner of its address.
+const ModuleOwnerPrefix = "module:"
+
+// ModuleAccount is an account held by a native module, like the fee
+// collector, rather than by a user: its address is derived from its name, so
+// that nobody has its private key, and the keeper only lets its module create
+// or destroy coins with it if it was granted the matching permission.
+type ModuleAccount struct {
+	Name        string         `json:"name" yaml:"name"`
+	Address     crypto.Address `json:"address" yaml:"address"`
+	Permissions []string       `json:"permissions" yaml:"permissions"`
+}
+
+// HasPermission returns whether the module account was granted perm.
+func (ma ModuleAccount) HasPermission(perm string) bool {
+	return slices.Contains(ma.Permissions, perm)
+}
+
+// ModuleAddress returns the address of the module account with the given
+// name.
+func ModuleAddress(name string) crypto.Address {
+	return crypto.AddressFromPreimage([]byte(name))
+}
+
+// moduleAccounts are the module accounts registered in a keeper, by name.
+// They're shared by the copies of the keeper.
+type moduleAccounts struct {
+	byName map[string]ModuleAccount
+	byAddr map[crypto.Address]string
+}
+
+// RegisterModuleAccount registers the module account with the given name and
+// permissions, and returns its address. It's meant to be called when setting
+// up the application, before processing any block; it panics if the name is
+// already registered.
+func (bank BankKeeper) RegisterModuleAccount(name string, perms ...string) crypto.Address {
+	if name == "" {
+		panic("module account name cannot be empty")
+	}
+	if _, ok := bank.modules.byName[name]; ok {
+		panic("module account " + name + " already registered")
+	}
+	for _, perm := range perms {
+		if perm != PermissionMinter && perm != PermissionBurner {
+			panic("unknown module account permission " + perm)
+		}
+	}
+	addr := ModuleAddress(name)
+	bank.modules.byName[name] = ModuleAccount{
+		Name:        name,
+		Address:     addr,
+		Permissions: append([]string{}, perms...),
+	}
+	bank.modules.byAddr[addr] = name
+	return addr
+}
+
+// GetModuleAccount returns the module account with the given name.
+func (bank BankKeeper) GetModuleAccount(name string) (ModuleAccount, bool) {
+	ma, ok := bank.modules.byName[name]
+	return ma, ok
+}
+
+// ModuleAccounts returns the registered module accounts, sorted by name.
+func (bank BankKeeper) ModuleAccounts() []ModuleAccount {
+	mas := make([]ModuleAccount, 0, len(bank.modules.byName))
+	for _, ma := range bank.modules.byName {
+		mas = append(mas, ma)
+	}
+	sort.Slice(mas, func(i, j int) bool { return mas[i].Name < mas[j].Name })
+	return mas
+}
+
+// moduleAccount returns the module account with the given name, checking it
+// was granted perm, if any.
+func (bank BankKeeper) moduleAccount(name string, perm string) (ModuleAccount, error) {
+	ma, ok := bank.modules.byName[name]
+	if !ok {
+		return ModuleAccount{}, ErrUnknownModuleAccount(name)
+	}
+	if perm != "" && !ma.HasPermission(perm) {
+		return ModuleAccount{}, ErrMissingPermission(name, perm)
+	}
+	return ma, nil
+}
+
+// MintCoins creates amt and adds it to the coins of the module account, which
+// must have the minter permission.
+func (bank BankKeeper) MintCoins(ctx sdk.Context, module string, amt std.Coins) error {
+	ma, err := bank.moduleAccount(module, PermissionMinter)
+	if err != nil {
+		return err
+	}
+	if _, err := bank.AddCoins(withJournalOp(ctx, JournalKindMint, crypto.Address{}, ma.Address), ma.Address, amt); err != nil {
+		return err
+	}
+
+	bank.emitSupplyChange(ctx, EventTypeMint, AttributeKeyMinter, ma.Address, amt)
+	bank.emitBalanceChanges(ctx, amt, ma.Address)
+	return nil
+}
+
+// BurnCoins destroys amt from the coins of the module account, which must
+// have the burner permission.
+func (bank BankKeeper) BurnCoins(ctx sdk.Context, module string, amt std.Coins) error {
+	ma, err := bank.moduleAccount(module, PermissionBurner)
+	if err != nil {
+		return err
+	}
+	if _, err := bank.SubtractCoins(withJournalOp(ctx, JournalKindBurn, ma.Address, crypto.Address{}), ma.Address, amt); err != nil {
+		return err
+	}
+
+	bank.emitSupplyChange(ctx, EventTypeBurn, AttributeKeyBurner, ma.Address, amt)
+	bank.emitBalanceChanges(ctx, amt, ma.Address)
+	return nil
+}
+
+// SendCoinsFromModuleToAccount moves amt from the module account to toAddr.
+// The restricted denoms don't apply: the module is responsible for deciding
+// what it pays out.
+func (bank BankKeeper) SendCoinsFromModuleToAccount(ctx sdk.Context, module string, toAddr crypto.Address, amt std.Coins) error {
+	ma, err := bank.moduleAccount(module, "")
+	if err != nil {
+		return err
+	}
+	if err := bank.sendCoins(withJournalOp(ctx, JournalKindSend, ma.Address, toAddr), ma.Address, toAddr, amt); err != nil {
+		return err
+	}
+
+	bank.emitTransfer(ctx, ma.Address, toAddr, amt)
+	bank.emitBalanceChanges(ctx, amt, ma.Address, toAddr)
+	return nil
+}
+
+// SendCoinsFromAccountToModule moves amt from fromAddr to the module account,
+// like when escrowing coins. The restricted denoms apply to fromAddr.
+func (bank BankKeeper) SendCoinsFromAccountToModule(ctx sdk.Context, fromAddr crypto.Address, module string, amt std.Coins) error {
+	ma, err := bank.moduleAccount(module, "")
+	if err != nil {
+		return err
+	}
+	return bank.SendCoins(ctx, fromAddr, ma.Address, amt)
+}
+
+func (bank BankKeeper) emitSupplyChange(ctx sdk.Context, typ, key string, addr crypto.Address, amt std.Coins) {
+	if em := ctx.EventManager(); em != nil && !amt.IsZero() {
+		em.EmitEvent(
+			sdk.NewEvent(
+				typ, ModuleName,
+				sdk.NewIndexedAttribute(key, addr.String()),
+				sdk.NewAttribute(AttributeKeyAmount, amt.String()),
+			),
+		)
+	}
+}
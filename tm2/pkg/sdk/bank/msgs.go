@@ -104,6 +104,101 @@ func (msg MsgMultiSend) GetSigners() []crypto.Address {
 	return addrs
 }
 
+// MsgSendAt - transfer coins at a future block height or time. The coins are
+// held in escrow until the transfer is executed or cancelled.
+type MsgSendAt struct {
+	FromAddress crypto.Address `json:"from_address" yaml:"from_address"`
+	ToAddress   crypto.Address `json:"to_address" yaml:"to_address"`
+	Amount      std.Coins      `json:"amount" yaml:"amount"`
+	Height      int64          `json:"height" yaml:"height"` // execute at block height
+	Time        int64          `json:"time" yaml:"time"`     // execute at block time (unix seconds)
+}
+
+var _ std.Msg = MsgSendAt{}
+
+// NewMsgSendAt - construct a scheduled send msg. Exactly one of height and
+// unixTime must be non-zero.
+func NewMsgSendAt(fromAddr, toAddr crypto.Address, amount std.Coins, height, unixTime int64) MsgSendAt {
+	return MsgSendAt{
+		FromAddress: fromAddr,
+		ToAddress:   toAddr,
+		Amount:      amount,
+		Height:      height,
+		Time:        unixTime,
+	}
+}
+
+// Route Implements Msg.
+func (msg MsgSendAt) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgSendAt) Type() string { return "send_at" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgSendAt) ValidateBasic() error {
+	if err := msg.send().ValidateBasic(); err != nil {
+		return err
+	}
+	if msg.Height < 0 || msg.Time < 0 {
+		return std.ErrUnknownRequest("execution height and time must not be negative")
+	}
+	if (msg.Height == 0) == (msg.Time == 0) {
+		return std.ErrUnknownRequest("exactly one of height or time must be set")
+	}
+	return nil
+}
+
+func (msg MsgSendAt) send() MsgSend {
+	return NewMsgSend(msg.FromAddress, msg.ToAddress, msg.Amount)
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgSendAt) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgSendAt) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.FromAddress}
+}
+
+// MsgCancelSendAt - cancel a pending MsgSendAt, refunding the sender.
+type MsgCancelSendAt struct {
+	FromAddress crypto.Address `json:"from_address" yaml:"from_address"`
+	ID          uint64         `json:"id" yaml:"id"`
+}
+
+var _ std.Msg = MsgCancelSendAt{}
+
+// NewMsgCancelSendAt - construct a scheduled send cancellation msg.
+func NewMsgCancelSendAt(fromAddr crypto.Address, id uint64) MsgCancelSendAt {
+	return MsgCancelSendAt{FromAddress: fromAddr, ID: id}
+}
+
+// Route Implements Msg.
+func (msg MsgCancelSendAt) Route() string { return RouterKey }
+
+// Type Implements Msg.
+func (msg MsgCancelSendAt) Type() string { return "cancel_send_at" }
+
+// ValidateBasic Implements Msg.
+func (msg MsgCancelSendAt) ValidateBasic() error {
+	if msg.FromAddress.IsZero() {
+		return std.ErrInvalidAddress("missing sender address")
+	}
+	return nil
+}
+
+// GetSignBytes Implements Msg.
+func (msg MsgCancelSendAt) GetSignBytes() []byte {
+	return std.MustSortJSON(amino.MustMarshalJSON(msg))
+}
+
+// GetSigners Implements Msg.
+func (msg MsgCancelSendAt) GetSigners() []crypto.Address {
+	return []crypto.Address{msg.FromAddress}
+}
+
 // Input models transaction input
 type Input struct {
 	Address crypto.Address `json:"address" yaml:"address"`
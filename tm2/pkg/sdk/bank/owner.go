@@ -0,0 +1,25 @@
+package bank
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+)
+
+// SetAddressOwner records that addr isn't a user account, but is derived from
+// owner, like the address of a package is derived from its path. The funds
+// held by addr are then reported as held by owner.
+func (bank BankKeeper) SetAddressOwner(ctx sdk.Context, addr crypto.Address, owner string) {
+	stor := ctx.GasStore(bank.key)
+	stor.Set(AddressOwnerKey(addr), []byte(owner))
+}
+
+// GetAddressOwner returns the owner addr is derived from, or an empty string
+// if addr isn't a derived address. The owner of a module account is its name,
+// prefixed with ModuleOwnerPrefix.
+func (bank BankKeeper) GetAddressOwner(ctx sdk.Context, addr crypto.Address) string {
+	if name, ok := bank.modules.byAddr[addr]; ok {
+		return ModuleOwnerPrefix + name
+	}
+	stor := ctx.GasStore(bank.key)
+	return string(stor.Get(AddressOwnerKey(addr)))
+}
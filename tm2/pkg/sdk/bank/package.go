@@ -13,4 +13,12 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	NoOutputsError{}, "NoOutputsError",
 	InputOutputMismatchError{}, "InputOutputMismatchError",
 	MsgSend{}, "MsgSend",
+	UnknownScheduledSendError{}, "UnknownScheduledSendError",
+	MsgSendAt{}, "MsgSendAt",
+	MsgCancelSendAt{}, "MsgCancelSendAt",
+	ScheduledSend{}, "ScheduledSend",
+	UnknownModuleAccountError{}, "UnknownModuleAccountError",
+	MissingPermissionError{}, "MissingPermissionError",
+	ModuleAccount{}, "ModuleAccount",
+	JournalEntry{}, "JournalEntry",
 ))
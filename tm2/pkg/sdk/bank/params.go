@@ -13,6 +13,9 @@ type BankParamsContextKey struct{}
 // Params defines the parameters for the bank module.
 type Params struct {
 	RestrictedDenoms []string `json:"restricted_denoms" yaml:"restricted_denoms"`
+	// Journal enables recording the balance changes in the journal of the
+	// accounts, queried with "bank/journal/<address>".
+	Journal bool `json:"journal" yaml:"journal"`
 }
 
 // NewParams creates a new Params object
@@ -32,6 +35,7 @@ func (p Params) String() string {
 	var sb strings.Builder
 	sb.WriteString("Params: \n")
 	sb.WriteString(fmt.Sprintf("RestrictedDenom: %q\n", p.RestrictedDenoms))
+	sb.WriteString(fmt.Sprintf("Journal: %t\n", p.Journal))
 	return sb.String()
 }
 
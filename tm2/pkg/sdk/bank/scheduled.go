@@ -0,0 +1,200 @@
+package bank
+
+import (
+	"encoding/binary"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/crypto"
+	"github.com/gnolang/gno/tm2/pkg/sdk"
+	"github.com/gnolang/gno/tm2/pkg/std"
+)
+
+// ScheduledSend is a transfer whose coins are held in escrow by the bank
+// until it is executed by the EndBlocker, or cancelled by its sender.
+// Exactly one of Height and Time is set.
+type ScheduledSend struct {
+	ID          uint64         `json:"id" yaml:"id"`
+	FromAddress crypto.Address `json:"from_address" yaml:"from_address"`
+	ToAddress   crypto.Address `json:"to_address" yaml:"to_address"`
+	Amount      std.Coins      `json:"amount" yaml:"amount"`
+	Height      int64          `json:"height" yaml:"height"` // execute at block height
+	Time        int64          `json:"time" yaml:"time"`     // execute at block time (unix seconds)
+}
+
+// ScheduleSend removes amt from fromAddr and schedules its transfer to toAddr
+// at the given block height or unix time, whichever is non-zero.
+// It returns the id of the scheduled send.
+func (bank BankKeeper) ScheduleSend(
+	ctx sdk.Context,
+	fromAddr crypto.Address,
+	toAddr crypto.Address,
+	amt std.Coins,
+	height int64,
+	unixTime int64,
+) (uint64, error) {
+	switch {
+	case (height == 0) == (unixTime == 0):
+		return 0, std.ErrUnknownRequest("exactly one of height or time must be set")
+	case height != 0 && height <= ctx.BlockHeight():
+		return 0, std.ErrUnknownRequest(
+			fmt.Sprintf("execution height %d is not in the future", height))
+	case unixTime != 0 && unixTime <= ctx.BlockTime().Unix():
+		return 0, std.ErrUnknownRequest(
+			fmt.Sprintf("execution time %d is not in the future", unixTime))
+	}
+
+	if !bank.canSendCoins(ctx, fromAddr, amt) {
+		return 0, std.RestrictedTransferError{}
+	}
+	if _, err := bank.SubtractCoins(withJournalOp(ctx, JournalKindEscrow, fromAddr, toAddr), fromAddr, amt); err != nil {
+		return 0, err
+	}
+	bank.emitBalanceChanges(ctx, amt, fromAddr)
+
+	ss := ScheduledSend{
+		ID:          bank.getNextScheduledSendID(ctx),
+		FromAddress: fromAddr,
+		ToAddress:   toAddr,
+		Amount:      amt,
+		Height:      height,
+		Time:        unixTime,
+	}
+	bank.setScheduledSend(ctx, ss)
+
+	return ss.ID, nil
+}
+
+// CancelScheduledSend cancels a pending scheduled send and refunds its
+// sender. Only the original sender may cancel it.
+func (bank BankKeeper) CancelScheduledSend(ctx sdk.Context, fromAddr crypto.Address, id uint64) error {
+	ss, ok := bank.GetScheduledSend(ctx, id)
+	if !ok {
+		return ErrUnknownScheduledSend(id)
+	}
+	if ss.FromAddress != fromAddr {
+		return std.ErrUnauthorized(
+			fmt.Sprintf("scheduled send %d was not created by %s", id, fromAddr))
+	}
+
+	bank.removeScheduledSend(ctx, ss)
+	if _, err := bank.AddCoins(withJournalOp(ctx, JournalKindRefund, ss.ToAddress, ss.FromAddress), ss.FromAddress, ss.Amount); err != nil {
+		return err
+	}
+	bank.emitBalanceChanges(ctx, ss.Amount, ss.FromAddress)
+	return nil
+}
+
+// GetScheduledSend returns the pending scheduled send with the given id.
+func (bank BankKeeper) GetScheduledSend(ctx sdk.Context, id uint64) (ScheduledSend, bool) {
+	stor := ctx.GasStore(bank.key)
+	bz := stor.Get(ScheduledSendKey(id))
+	if bz == nil {
+		return ScheduledSend{}, false
+	}
+	var ss ScheduledSend
+	amino.MustUnmarshal(bz, &ss)
+	return ss, true
+}
+
+// ExecuteDueSends pays out the scheduled sends whose execution height or
+// time has been reached by the current block, up to
+// MaxScheduledSendsPerBlock of them; the others are carried over to the next
+// blocks. A send that can't be paid out is refunded to its sender, or kept
+// pending if the refund fails as well, so that its coins are never lost.
+func (bank BankKeeper) ExecuteDueSends(ctx sdk.Context) {
+	var ids []uint64
+	ids = bank.dueScheduledSends(ctx, ids, ScheduledSendHeightKeyPrefix, ctx.BlockHeight())
+	ids = bank.dueScheduledSends(ctx, ids, ScheduledSendTimeKeyPrefix, ctx.BlockTime().Unix())
+
+	for _, id := range ids {
+		ss, ok := bank.GetScheduledSend(ctx, id)
+		if !ok {
+			continue
+		}
+		releaseCtx := withJournalOp(ctx, JournalKindRelease, ss.FromAddress, ss.ToAddress)
+		if err := bank.payOut(releaseCtx, ss.ToAddress, ss.Amount); err != nil {
+			bank.Logger(ctx).Error("unable to execute scheduled send, refunding it",
+				"id", ss.ID, "err", err)
+			refundCtx := withJournalOp(ctx, JournalKindRefund, ss.ToAddress, ss.FromAddress)
+			if err := bank.payOut(refundCtx, ss.FromAddress, ss.Amount); err != nil {
+				bank.Logger(ctx).Error("unable to refund scheduled send, keeping it",
+					"id", ss.ID, "err", err)
+				continue
+			}
+			bank.emitBalanceChanges(ctx, ss.Amount, ss.FromAddress)
+		} else {
+			bank.emitTransfer(ctx, ss.FromAddress, ss.ToAddress, ss.Amount)
+			bank.emitBalanceChanges(ctx, ss.Amount, ss.ToAddress)
+		}
+		bank.removeScheduledSend(ctx, ss)
+	}
+}
+
+// payOut adds amt to the coins of addr, leaving the state untouched if it
+// fails, including by panicking on overflow.
+func (bank BankKeeper) payOut(ctx sdk.Context, addr crypto.Address, amt std.Coins) (err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	cctx, write := ctx.CacheContext()
+	if _, err := bank.AddCoins(cctx, addr, amt); err != nil {
+		return err
+	}
+	write()
+	ctx.EventManager().EmitEvents(cctx.EventManager().Events())
+	return nil
+}
+
+// dueScheduledSends appends to ids the ids indexed under prefix with an
+// execution point lower than or equal to until, until ids holds
+// MaxScheduledSendsPerBlock ids.
+func (bank BankKeeper) dueScheduledSends(ctx sdk.Context, ids []uint64, prefix string, until int64) []uint64 {
+	if until < 0 {
+		return ids
+	}
+	stor := ctx.GasStore(bank.key)
+	start := []byte(prefix)
+	end := binary.BigEndian.AppendUint64([]byte(prefix), uint64(until)+1)
+	iter := stor.Iterator(start, end)
+	defer iter.Close()
+
+	for ; iter.Valid() && len(ids) < MaxScheduledSendsPerBlock; iter.Next() {
+		key := iter.Key()
+		ids = append(ids, binary.BigEndian.Uint64(key[len(key)-8:]))
+	}
+	return ids
+}
+
+func (bank BankKeeper) setScheduledSend(ctx sdk.Context, ss ScheduledSend) {
+	stor := ctx.GasStore(bank.key)
+	stor.Set(ScheduledSendKey(ss.ID), amino.MustMarshal(ss))
+	stor.Set(ss.indexKey(), []byte{})
+}
+
+func (bank BankKeeper) removeScheduledSend(ctx sdk.Context, ss ScheduledSend) {
+	stor := ctx.GasStore(bank.key)
+	stor.Delete(ScheduledSendKey(ss.ID))
+	stor.Delete(ss.indexKey())
+}
+
+func (bank BankKeeper) getNextScheduledSendID(ctx sdk.Context) uint64 {
+	var id uint64
+	stor := ctx.GasStore(bank.key)
+	bz := stor.Get([]byte(NextScheduledSendIDKey))
+	if bz != nil {
+		amino.MustUnmarshal(bz, &id)
+	}
+	stor.Set([]byte(NextScheduledSendIDKey), amino.MustMarshal(id+1))
+	return id
+}
+
+func (ss ScheduledSend) indexKey() []byte {
+	if ss.Height != 0 {
+		return ScheduledSendHeightKey(ss.Height, ss.ID)
+	}
+	return ScheduledSendTimeKey(ss.Time, ss.ID)
+}
@@ -5,7 +5,9 @@ import (
 	"log/slog"
 	"os"
 	"runtime/debug"
+	"slices"
 	"sort"
+	"strconv"
 	"strings"
 	"syscall"
 
@@ -16,6 +18,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/errors"
 	"github.com/gnolang/gno/tm2/pkg/std"
 	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 )
 
 // Key to store the consensus params in the main store.
@@ -24,6 +27,13 @@ var (
 	mainLastHeaderKey      = []byte("last_header")
 )
 
+// txDecodeLimits bounds the decoding of transaction bytes, which are
+// untrusted. They are well above what any valid transaction needs.
+var txDecodeLimits = amino.DecodeLimits{
+	MaxDepth: 64,
+	MaxAlloc: 64 << 20, // 64MB
+}
+
 // BaseApp reflects the ABCI application implementation.
 type BaseApp struct {
 	// initialized on creation
@@ -42,9 +52,14 @@ type BaseApp struct {
 	beginBlocker BeginBlocker // logic to run before any txs
 	endBlocker   EndBlocker   // logic to run after all txs, and to determine valset changes
 
+	prepareProposal PrepareProposalHandler // tx selection when proposing a block
+	processProposal ProcessProposalHandler // acceptance check for received proposals
+
 	beginTxHook BeginTxHook // BaseApp-specific hook run before running transaction messages.
 	endTxHook   EndTxHook   // BaseApp-specific hook run after running transaction messages.
 
+	metadataHook MetadataHook // completes the metadata of the ".app/metadata" query
+
 	// --------------------
 	// Volatile state
 	// checkState is set on initialization and reset on Commit.
@@ -73,6 +88,9 @@ type BaseApp struct {
 
 	// application's version string
 	appVersion string
+
+	// takes periodic state snapshots, if enabled
+	snapshots *snapshots.Manager
 }
 
 var _ abci.Application = (*BaseApp)(nil)
@@ -386,6 +404,8 @@ func splitPath(requestPath string) (path []string) {
 // Query implements the ABCI interface. It delegates to CommitMultiStore if it
 // implements Queryable.
 func (app *BaseApp) Query(req abci.RequestQuery) (res abci.ResponseQuery) {
+	defer setErrorCode(&res.ResponseBase)
+
 	path := splitPath(req.Path)
 	if len(path) == 0 {
 		msg := "no query path provided"
@@ -415,7 +435,7 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) (res abc
 		case "simulate":
 			txBytes := req.Data
 			var tx Tx
-			err := amino.Unmarshal(txBytes, &tx)
+			err := amino.UnmarshalLimited(txBytes, &tx, txDecodeLimits)
 			if err != nil {
 				res.Error = ABCIError(std.ErrTxDecode(err.Error()))
 			} else {
@@ -431,11 +451,32 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) (res abc
 				res.Value = bytes
 			}
 
+			return res
+		case "trace":
+			res.Height = req.Height
+
+			var treq abci.TraceTxRequest
+			if err := amino.Unmarshal(req.Data, &treq); err != nil {
+				res.Error = ABCIError(std.ErrUnknownRequest(fmt.Sprintf("cannot decode trace request: %s", err)))
+				return res
+			}
+			trace, err := app.TraceTx(treq.Header, treq.Txs)
+			if err != nil {
+				res.Error = ABCIError(std.ErrInternal(err.Error()))
+				res.Log = err.Error()
+				return res
+			}
+			res.Value = amino.MustMarshal(trace)
+
 			return res
 		case "version":
 			res.Height = req.Height
 			res.Value = []byte(app.appVersion)
 			return res
+		case "snapshots":
+			return handleQuerySnapshots(app, path[2:])
+		case "metadata":
+			return handleQueryMetadata(app)
 		default:
 			res.Error = ABCIError(std.ErrUnknownRequest(fmt.Sprintf("Unknown query: %s", path)))
 			return
@@ -446,6 +487,71 @@ func handleQueryApp(app *BaseApp, path []string, req abci.RequestQuery) (res abc
 	}
 }
 
+// handleQuerySnapshots serves ".app/snapshots", listing the available state
+// snapshots, and ".app/snapshots/<height>/<chunk>", returning a chunk of the
+// compressed snapshot at height.
+func handleQuerySnapshots(app *BaseApp, path []string) (res abci.ResponseQuery) {
+	if app.snapshots == nil {
+		res.Error = ABCIError(std.ErrUnknownRequest("state snapshots are not enabled"))
+		return
+	}
+
+	switch len(path) {
+	case 0:
+		infos, err := app.snapshots.List()
+		if err != nil {
+			res.Error = ABCIError(std.ErrInternal(err.Error()))
+			return
+		}
+		res.Value = amino.MustMarshalJSON(infos)
+	case 2:
+		height, err1 := strconv.ParseInt(path[0], 10, 64)
+		chunk, err2 := strconv.ParseInt(path[1], 10, 64)
+		if err1 != nil || err2 != nil {
+			res.Error = ABCIError(std.ErrUnknownRequest(fmt.Sprintf("invalid snapshot chunk %q", strings.Join(path, "/"))))
+			return
+		}
+		bz, err := app.snapshots.LoadChunk(height, chunk)
+		if err != nil {
+			res.Error = ABCIError(std.ErrUnknownRequest(err.Error()))
+			return
+		}
+		res.Height = height
+		res.Value = bz
+	default:
+		res.Error = ABCIError(std.ErrUnknownRequest(fmt.Sprintf("Unknown query: %s", path)))
+	}
+	return
+}
+
+// handleQueryMetadata serves ".app/metadata", describing the application and
+// what it supports as an amino JSON [abci.AppMetadata].
+func handleQueryMetadata(app *BaseApp) (res abci.ResponseQuery) {
+	md := abci.AppMetadata{
+		Name:     app.name,
+		Version:  app.appVersion,
+		Features: []string{"simulate", "trace"},
+	}
+	if app.snapshots != nil {
+		md.Features = append(md.Features, "snapshots")
+	}
+
+	// the hook is not called before the chain is initialized, when the
+	// application has no state.
+	if app.metadataHook != nil && app.checkState != nil {
+		// cache wrap the commit-multistore, so that the hook can only read
+		// the last committed state.
+		ctx := NewContext(RunTxModeCheck, app.cms.MultiCacheWrap(), app.checkState.ctx.BlockHeader(), app.logger)
+		app.metadataHook(ctx, &md)
+	}
+	slices.Sort(md.Features)
+	md.Features = slices.Compact(md.Features)
+
+	res.Height = app.LastBlockHeight()
+	res.Value = amino.MustMarshalJSON(md)
+	return
+}
+
 func handleQueryStore(app *BaseApp, path []string, req abci.RequestQuery) (res abci.ResponseQuery) {
 	// "/store" prefix for store queries
 	queryable, ok := app.cms.(store.Queryable)
@@ -572,8 +678,10 @@ func (app *BaseApp) BeginBlock(req abci.RequestBeginBlock) (res abci.ResponseBeg
 //
 // NOTE:CheckTx does not run the actual Msg handler function(s).
 func (app *BaseApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx) {
+	defer setErrorCode(&res.ResponseBase)
+
 	var tx Tx
-	err := amino.Unmarshal(req.Tx, &tx)
+	err := amino.UnmarshalLimited(req.Tx, &tx, txDecodeLimits)
 	if err != nil {
 		res.Error = ABCIError(std.ErrTxDecode(err.Error()))
 		return
@@ -590,8 +698,10 @@ func (app *BaseApp) CheckTx(req abci.RequestCheckTx) (res abci.ResponseCheckTx)
 
 // DeliverTx implements the ABCI interface.
 func (app *BaseApp) DeliverTx(req abci.RequestDeliverTx) (res abci.ResponseDeliverTx) {
+	defer setErrorCode(&res.ResponseBase)
+
 	var tx Tx
-	err := amino.Unmarshal(req.Tx, &tx)
+	err := amino.UnmarshalLimited(req.Tx, &tx, txDecodeLimits)
 	if err != nil {
 		res.Error = ABCIError(std.ErrTxDecode(err.Error()))
 		return
@@ -642,8 +752,6 @@ func (app *BaseApp) getContextForTx(mode RunTxMode, txBytes []byte) (ctx Context
 
 // / runMsgs iterates through all the messages and executes them.
 func (app *BaseApp) runMsgs(ctx Context, msgs []Msg, mode RunTxMode) (result Result) {
-	ctx = ctx.WithEventLogger(NewEventLogger())
-
 	msgLogs := make([]string, 0, len(msgs))
 	msgInfos := make([]string, 0, len(msgs))
 	data := make([]byte, 0, len(msgs))
@@ -667,13 +775,23 @@ func (app *BaseApp) runMsgs(ctx Context, msgs []Msg, mode RunTxMode) (result Res
 
 		// run the message!
 		// skip actual execution for CheckTx mode
+		// each message gets its own event manager, so events are ordered by
+		// message, then in the order they were emitted.
+		msgCtx := ctx.WithEventManager(NewEventManager())
 		if mode != RunTxModeCheck {
-			msgResult = handler.Process(ctx, msg) // ctx event logger being updated in handler
+			if tracer := ctx.Tracer(); tracer != nil {
+				tracer.BeginCall(msgRoute+"/"+msg.Type(), "", ctx.GasMeter().GasConsumed())
+			}
+			msgResult = handler.Process(msgCtx, msg) // ctx event manager being updated in handler
+			if tracer := ctx.Tracer(); tracer != nil {
+				tracer.EndCall(ctx.GasMeter().GasConsumed())
+			}
 		}
 
 		// Each message result's Data must be length prefixed in order to separate
 		// each result.
 		data = append(data, msgResult.Data...)
+		events = append(events, msgCtx.EventManager().Events()...)
 		events = append(events, msgResult.Events...)
 		msgInfos = append(msgInfos, msgResult.Info)
 
@@ -692,10 +810,6 @@ func (app *BaseApp) runMsgs(ctx Context, msgs []Msg, mode RunTxMode) (result Res
 				i, true, msgResult.Log, events))
 	}
 
-	if err == nil {
-		events = append(events, ctx.EventLogger().Events()...)
-	}
-
 	result.Error = ABCIError(err)
 	result.Data = data
 	result.Events = events
@@ -826,12 +940,19 @@ func (app *BaseApp) runTx(ctx Context, tx Tx) (result Result) {
 		// benefits, but it'll be more difficult to get
 		// right.
 		anteCtx, msCache = app.cacheTxContext(ctx)
+		if tracer := ctx.Tracer(); tracer != nil {
+			tracer.BeginCall("ante", "", 0)
+		}
 		// Call AnteHandler.
 		// NOTE: It is the responsibility of the anteHandler
 		// to use something like passthroughGasMeter to
 		// account for ante handler gas usage, despite
 		// OutOfGasExceptions.
 		newCtx, result, abort := app.anteHandler(anteCtx, tx, mode == RunTxModeSimulate)
+		if tracer := ctx.Tracer(); tracer != nil {
+			// the ante handler sets the gas meter of the tx.
+			tracer.EndCall(newCtx.GasMeter().GasConsumed())
+		}
 		if newCtx.IsZero() {
 			panic("newCtx must not be zero")
 		}
@@ -867,7 +988,8 @@ func (app *BaseApp) runTx(ctx Context, tx Tx) (result Result) {
 		return result
 	}
 
-	if app.endTxHook != nil {
+	// Traced transactions are run on a sandboxed state; see TraceTx.
+	if app.endTxHook != nil && ctx.Tracer() == nil {
 		app.endTxHook(runMsgCtx, result)
 	}
 
@@ -884,13 +1006,51 @@ func (app *BaseApp) EndBlock(req abci.RequestEndBlock) (res abci.ResponseEndBloc
 	if app.endBlocker != nil {
 		// we need to load consensusParams to the end blocker Context
 		// end blocker use consensusParams to calculat the gas price changes.
-		ctx := app.deliverState.ctx.WithConsensusParams(app.consensusParams)
+		ctx := app.deliverState.ctx.WithConsensusParams(app.consensusParams).
+			WithEventManager(NewEventManager())
 		res = app.endBlocker(ctx, req)
+		// include the events emitted by the modules, such as the transfers
+		// of the scheduled sends.
+		if events := ctx.EventManager().Events(); len(events) > 0 {
+			res.Events = append(res.Events, events...)
+		}
 	}
 
 	return
 }
 
+// PrepareProposal implements the ABCI interface. Without a
+// PrepareProposalHandler the candidate txs are returned unmodified.
+func (app *BaseApp) PrepareProposal(req abci.RequestPrepareProposal) (res abci.ResponsePrepareProposal) {
+	if app.prepareProposal == nil || app.checkState == nil {
+		res.Txs = req.Txs
+		return
+	}
+
+	return app.prepareProposal(app.proposalContext(), req)
+}
+
+// ProcessProposal implements the ABCI interface. Without a
+// ProcessProposalHandler every proposal is accepted.
+func (app *BaseApp) ProcessProposal(req abci.RequestProcessProposal) (res abci.ResponseProcessProposal) {
+	if app.processProposal == nil || app.checkState == nil {
+		return
+	}
+
+	return app.processProposal(app.proposalContext(), req)
+}
+
+// proposalContext returns a context over a cache of the last committed
+// state, so that proposal handlers can inspect (and simulate against) state
+// without affecting CheckTx or DeliverTx. It doesn't branch from checkState,
+// which holds the txs of this node's mempool, so that all validators see the
+// same state; for the same reason, the node's min gas prices aren't set.
+func (app *BaseApp) proposalContext() Context {
+	ms := app.cms.MultiCacheWrap()
+	return NewContext(RunTxModeCheck, ms, app.checkState.ctx.BlockHeader(), app.logger).
+		WithConsensusParams(app.consensusParams)
+}
+
 // Commit implements the ABCI interface. It will commit all state that exists in
 // the deliver state's multi-store and includes the resulting commit ID in the
 // returned abci.ResponseCommit. Commit will set the check state based on the
@@ -935,6 +1095,11 @@ func (app *BaseApp) Commit() (res abci.ResponseCommit) {
 	headerBz := amino.MustMarshal(header)
 	baseStore.Set(mainLastHeaderKey, headerBz)
 
+	// Snapshot the state, including the header, if due at this height.
+	if app.snapshots != nil {
+		app.snapshots.Commit(commitID.Version, commitID.Hash)
+	}
+
 	// Reset the Check state to the latest committed.
 	//
 	// NOTE: This is safe because Tendermint holds a lock on the mempool for
@@ -972,6 +1137,11 @@ func (app *BaseApp) halt() {
 }
 
 func (app *BaseApp) Close() error {
+	if app.snapshots != nil {
+		// Interrupt the snapshot being taken, if any, before closing its DB.
+		app.snapshots.Close()
+	}
+
 	if app.db == nil {
 		return nil
 	}
@@ -7,6 +7,7 @@ import (
 	"log/slog"
 	"os"
 	"reflect"
+	"strconv"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
@@ -22,6 +23,7 @@ import (
 	"github.com/gnolang/gno/tm2/pkg/store"
 	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
 	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 )
 
 var (
@@ -166,6 +168,81 @@ func TestAppVersionSetterGetter(t *testing.T) {
 	require.Equal(t, versionString, string(res.Value))
 }
 
+func TestQueryMetadata(t *testing.T) {
+	t.Parallel()
+
+	app := newBaseApp(t.Name(), memdb.NewMemDB())
+	app.SetAppVersion("1.0.0")
+	app.SetMetadataHook(func(ctx Context, md *abci.AppMetadata) {
+		ctx.Store(mainKey).Get([]byte("key")) // the hook can read the state
+		md.Features = append(md.Features, "events", "simulate")
+		md.MsgTypes = append(md.MsgTypes, abci.MsgTypeInfo{Route: "test", Type: "test"})
+	})
+	require.NoError(t, app.LoadLatestVersion())
+	app.InitChain(abci.RequestInitChain{ChainID: "test-chain"})
+	app.Commit()
+
+	res := app.Query(abci.RequestQuery{Path: ".app/metadata"})
+	require.True(t, res.IsOK(), res.Error)
+	var md abci.AppMetadata
+	require.NoError(t, amino.UnmarshalJSON(res.Value, &md))
+	assert.Equal(t, t.Name(), md.Name)
+	assert.Equal(t, "1.0.0", md.Version)
+	assert.Equal(t, []string{"events", "simulate", "trace"}, md.Features)
+	assert.Equal(t, []abci.MsgTypeInfo{{Route: "test", Type: "test"}}, md.MsgTypes)
+}
+
+func TestSnapshots(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	app := setupBaseApp(t, SetPruningOptions(store.PruneNothing), SetSnapshotOptions(dir, 2, 1))
+	defer app.Close()
+
+	res := app.Query(abci.RequestQuery{Path: ".app/snapshots"})
+	require.True(t, res.IsOK(), res.Log)
+	require.Equal(t, "[]", string(res.Value))
+
+	var lastHash []byte
+	for height := int64(1); height <= 4; height++ {
+		header := &bft.Header{ChainID: "test-chain", Height: height}
+		app.BeginBlock(abci.RequestBeginBlock{Header: header})
+		app.deliverState.ctx.Store(mainKey).Set([]byte("height"), []byte(fmt.Sprint(height)))
+		lastHash = app.Commit().Data
+		app.snapshots.Wait()
+	}
+
+	res = app.Query(abci.RequestQuery{Path: ".app/snapshots"})
+	require.True(t, res.IsOK(), res.Log)
+	var infos []snapshots.Info
+	require.NoError(t, amino.UnmarshalJSON(res.Value, &infos))
+	require.Len(t, infos, 1)
+	assert.Equal(t, int64(4), infos[0].Height)
+	assert.Equal(t, lastHash, infos[0].Hash)
+
+	// Fetch the snapshot and restore it in a new app.
+	var buf bytes.Buffer
+	for chunk := range infos[0].Chunks {
+		res = app.Query(abci.RequestQuery{Path: fmt.Sprintf(".app/snapshots/4/%d", chunk)})
+		require.True(t, res.IsOK(), res.Log)
+		buf.Write(res.Value)
+	}
+	restored := setupBaseApp(t)
+	require.NoError(t, snapshots.Restore(restored.cms.(store.Snapshotter), infos[0], &buf))
+	require.NoError(t, restored.LoadLatestVersion())
+	assert.Equal(t, int64(4), restored.LastBlockHeight())
+	assert.Equal(t, lastHash, restored.LastCommitID().Hash)
+
+	for _, path := range []string{".app/snapshots/2/0", ".app/snapshots/4/1", ".app/snapshots/4", ".app/snapshots/x/0"} {
+		res = app.Query(abci.RequestQuery{Path: path})
+		assert.False(t, res.IsOK(), path)
+	}
+	res = restored.Query(abci.RequestQuery{Path: ".app/snapshots"})
+	assert.False(t, res.IsOK())
+	_, ok := res.Error.(std.UnknownRequestError)
+	assert.True(t, ok)
+}
+
 func TestLoadVersionInvalid(t *testing.T) {
 	t.Parallel()
 
@@ -319,6 +396,12 @@ func TestBaseAppOptionSeal(t *testing.T) {
 	require.Panics(t, func() {
 		app.SetEndTxHook(nil)
 	})
+	require.Panics(t, func() {
+		app.SetPrepareProposalHandler(nil)
+	})
+	require.Panics(t, func() {
+		app.SetProcessProposalHandler(nil)
+	})
 }
 
 func TestSetMinGasPrices(t *testing.T) {
@@ -867,6 +950,12 @@ func TestRunInvalidTransaction(t *testing.T) {
 		res := app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
 		_, ok := res.Error.(std.TxDecodeError)
 		require.True(t, ok)
+		assert.Equal(t, "std", res.Codespace)
+		assert.Equal(t, uint32(2), res.Code)
+
+		checkRes := app.CheckTx(abci.RequestCheckTx{Tx: txBytes})
+		assert.Equal(t, "std", checkRes.Codespace)
+		assert.Equal(t, uint32(2), checkRes.Code)
 	}
 }
 
@@ -1232,6 +1321,111 @@ func TestQuery(t *testing.T) {
 	require.Equal(t, value, res.Value)
 }
 
+func TestDeliverTxEvents(t *testing.T) {
+	t.Parallel()
+
+	anteOpt := func(bapp *BaseApp) {
+		bapp.SetAnteHandler(func(ctx Context, tx Tx, simulate bool) (newCtx Context, res Result, abort bool) {
+			return ctx, Result{}, false
+		})
+	}
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, newTestHandler(func(ctx Context, msg Msg) Result {
+			n := strconv.FormatInt(msg.(msgCounter).Counter, 10)
+			ctx.EventManager().EmitEvent(NewEvent("emitted", "test",
+				NewIndexedAttribute("n", n), NewAttribute("extra", "x")))
+			ctx.EventManager().EmitEvent(abci.EventString("string " + n))
+			return Result{ResponseBase: abci.ResponseBase{Events: []Event{NewEvent("result", "test", NewAttribute("n", n))}}}
+		}))
+	}
+
+	tx := newTxCounter(0, 0, 1)
+	header := &bft.Header{ChainID: "test-chain", Height: 1}
+
+	// events are ordered by message, then in the order they were emitted.
+	app := setupBaseApp(t, anteOpt, routerOpt)
+	app.InitChain(abci.RequestInitChain{ChainID: "test-chain"})
+	app.BeginBlock(abci.RequestBeginBlock{Header: header})
+	res := app.Deliver(tx)
+	require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+	assert.Equal(t, []Event{
+		NewEvent("emitted", "test", NewIndexedAttribute("n", "0"), NewAttribute("extra", "x")),
+		abci.EventString("string 0"),
+		NewEvent("result", "test", NewAttribute("n", "0")),
+		NewEvent("emitted", "test", NewIndexedAttribute("n", "1"), NewAttribute("extra", "x")),
+		abci.EventString("string 1"),
+		NewEvent("result", "test", NewAttribute("n", "1")),
+	}, res.Events)
+}
+
+func TestPrepareProcessProposal(t *testing.T) {
+	t.Parallel()
+
+	key, value := []byte("hello"), []byte("goodbye")
+	proposalOpt := func(bapp *BaseApp) {
+		bapp.SetPrepareProposalHandler(func(ctx Context, req abci.RequestPrepareProposal) (res abci.ResponsePrepareProposal) {
+			// Writes to the proposal context must not leak into app state.
+			ctx.Store(mainKey).Set(key, value)
+			for _, tx := range req.Txs {
+				if len(tx) > 1 {
+					res.Txs = append(res.Txs, tx)
+				}
+			}
+			return
+		})
+		bapp.SetProcessProposalHandler(func(ctx Context, req abci.RequestProcessProposal) (res abci.ResponseProcessProposal) {
+			for _, tx := range req.Txs {
+				if len(tx) <= 1 {
+					res.Error = ABCIError(std.ErrTxDecode("short tx"))
+				}
+			}
+			return
+		})
+	}
+
+	txs := [][]byte{[]byte("a"), []byte("bb"), []byte("ccc")}
+
+	// Without handlers, txs are passed through and all proposals accepted.
+	app := setupBaseApp(t)
+	app.InitChain(abci.RequestInitChain{ChainID: "test-chain"})
+	assert.Equal(t, txs, app.PrepareProposal(abci.RequestPrepareProposal{Txs: txs}).Txs)
+	assert.True(t, app.ProcessProposal(abci.RequestProcessProposal{Txs: txs}).IsOK())
+
+	// With handlers.
+	app = setupBaseApp(t, proposalOpt)
+	app.InitChain(abci.RequestInitChain{ChainID: "test-chain"})
+
+	res := app.PrepareProposal(abci.RequestPrepareProposal{Txs: txs})
+	assert.Equal(t, txs[1:], res.Txs)
+	assert.True(t, app.ProcessProposal(abci.RequestProcessProposal{Txs: res.Txs}).IsOK())
+	assert.True(t, app.ProcessProposal(abci.RequestProcessProposal{Txs: txs}).IsErr())
+
+	query := app.Query(abci.RequestQuery{Path: ".store/main/key", Data: key})
+	assert.Empty(t, query.Value)
+}
+
+func TestProposalContextIgnoresCheckState(t *testing.T) {
+	t.Parallel()
+
+	key, value := []byte("checked"), []byte("value")
+	proposalOpt := func(bapp *BaseApp) {
+		bapp.SetProcessProposalHandler(func(ctx Context, req abci.RequestProcessProposal) (res abci.ResponseProcessProposal) {
+			if ctx.Store(mainKey).Has(key) {
+				res.Error = ABCIError(std.ErrInternal("proposal sees the mempool state"))
+			}
+			return
+		})
+	}
+
+	app := setupBaseApp(t, proposalOpt)
+	app.InitChain(abci.RequestInitChain{ChainID: "test-chain"})
+
+	// Changes made by CheckTx are local to this node's mempool.
+	app.checkState.ctx.Store(mainKey).Set(key, value)
+
+	assert.True(t, app.ProcessProposal(abci.RequestProcessProposal{}).IsOK())
+}
+
 func TestGetMaximumBlockGas(t *testing.T) {
 	app := setupBaseApp(t)
 
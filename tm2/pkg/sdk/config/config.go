@@ -14,6 +14,7 @@ import (
 var (
 	ErrInvalidMinGasPrices  = errors.New("invalid min gas prices")
 	ErrInvalidPruneStrategy = errors.New("invalid prune strategy")
+	ErrInvalidSnapshots     = errors.New("invalid state snapshot settings")
 )
 
 // AppConfig defines the configuration options for the Application
@@ -23,13 +24,21 @@ type AppConfig struct {
 
 	// The enforced state pruning stategy for the app
 	PruneStrategy types.PruneStrategy `json:"prune_strategy" toml:"prune_strategy" comment:"State pruning strategy [everything, nothing, syncable]"`
+
+	// The number of blocks between automatic state snapshots, 0 to disable them
+	SnapshotInterval int64 `json:"snapshot_interval" toml:"snapshot_interval" comment:"Take a compressed state snapshot every this many blocks, 0 to disable snapshots.\n Requires a prune strategy other than everything"`
+
+	// The number of most recent snapshots to keep
+	SnapshotKeepRecent int64 `json:"snapshot_keep_recent" toml:"snapshot_keep_recent" comment:"Number of most recent state snapshots to keep, 0 to keep all of them"`
 }
 
 // DefaultAppConfig returns a default configuration for the application
 func DefaultAppConfig() *AppConfig {
 	return &AppConfig{
-		MinGasPrices:  "",
-		PruneStrategy: types.PruneSyncableStrategy,
+		MinGasPrices:       "",
+		PruneStrategy:      types.PruneSyncableStrategy,
+		SnapshotInterval:   0,
+		SnapshotKeepRecent: 2,
 	}
 }
 
@@ -50,5 +59,16 @@ func (cfg *AppConfig) ValidateBasic() error {
 		return fmt.Errorf("%w: %q", ErrInvalidPruneStrategy, cfg.PruneStrategy)
 	}
 
+	// Make sure the snapshot settings are valid. Snapshots are taken in
+	// the background, so the state they export must not be pruned right away.
+	switch {
+	case cfg.SnapshotInterval < 0:
+		return fmt.Errorf("%w: negative interval %d", ErrInvalidSnapshots, cfg.SnapshotInterval)
+	case cfg.SnapshotKeepRecent < 0:
+		return fmt.Errorf("%w: negative number of snapshots to keep %d", ErrInvalidSnapshots, cfg.SnapshotKeepRecent)
+	case cfg.SnapshotInterval > 0 && cfg.PruneStrategy == types.PruneEverythingStrategy:
+		return fmt.Errorf("%w: snapshots require a prune strategy other than %q", ErrInvalidSnapshots, cfg.PruneStrategy)
+	}
+
 	return nil
 }
@@ -65,6 +65,44 @@ func TestConfig_ValidateBasic(t *testing.T) {
 		assert.NoError(t, cfg.ValidateBasic())
 	})
 
+	t.Run("invalid snapshot settings", func(t *testing.T) {
+		t.Parallel()
+
+		testTable := []struct {
+			name           string
+			interval, keep int64
+			pruneStrategy  types.PruneStrategy
+		}{
+			{"negative interval", -1, 2, types.PruneSyncableStrategy},
+			{"negative keep recent", 100, -1, types.PruneSyncableStrategy},
+			{"pruning everything", 100, 2, types.PruneEverythingStrategy},
+		}
+
+		for _, testCase := range testTable {
+			t.Run(testCase.name, func(t *testing.T) {
+				t.Parallel()
+
+				cfg := DefaultAppConfig()
+				cfg.SnapshotInterval = testCase.interval
+				cfg.SnapshotKeepRecent = testCase.keep
+				cfg.PruneStrategy = testCase.pruneStrategy
+
+				assert.ErrorIs(t, cfg.ValidateBasic(), ErrInvalidSnapshots)
+			})
+		}
+	})
+
+	t.Run("valid snapshot settings", func(t *testing.T) {
+		t.Parallel()
+
+		cfg := DefaultAppConfig()
+		cfg.SnapshotInterval = 1000
+		cfg.SnapshotKeepRecent = 0
+		cfg.PruneStrategy = types.PruneNothingStrategy
+
+		assert.NoError(t, cfg.ValidateBasic())
+	})
+
 	t.Run("valid default config", func(t *testing.T) {
 		t.Parallel()
 
@@ -32,7 +32,8 @@ type Context struct {
 	blockGasMeter store.GasMeter
 	minGasPrices  []GasPrice
 	consParams    *abci.ConsensusParams
-	eventLogger   *EventLogger
+	eventManager  *EventManager
+	tracer        *Tracer
 }
 
 // Proposed rename, not done to avoid API breakage
@@ -52,7 +53,13 @@ func (c Context) GasMeter() store.GasMeter      { return c.gasMeter }
 func (c Context) BlockGasMeter() store.GasMeter { return c.blockGasMeter }
 func (c Context) IsCheckTx() bool               { return c.mode == RunTxModeCheck }
 func (c Context) MinGasPrices() []GasPrice      { return c.minGasPrices }
-func (c Context) EventLogger() *EventLogger     { return c.eventLogger }
+func (c Context) EventManager() *EventManager   { return c.eventManager }
+func (c Context) Tracer() *Tracer               { return c.tracer }
+
+// EventLogger returns the event manager of the context.
+//
+// Deprecated: use EventManager.
+func (c Context) EventLogger() *EventLogger { return c.eventManager }
 
 // clone the header before returning
 func (c Context) BlockHeader() abci.Header {
@@ -78,7 +85,7 @@ func NewContext(mode RunTxMode, ms store.MultiStore, header abci.Header, logger
 		logger:       logger,
 		gasMeter:     store.NewInfiniteGasMeter(),
 		minGasPrices: nil,
-		eventLogger:  NewEventLogger(),
+		eventManager: NewEventManager(),
 	}
 }
 
@@ -142,11 +149,24 @@ func (c Context) WithConsensusParams(params *abci.ConsensusParams) Context {
 	return c
 }
 
-func (c Context) WithEventLogger(em *EventLogger) Context {
-	c.eventLogger = em
+func (c Context) WithEventManager(em *EventManager) Context {
+	c.eventManager = em
+	return c
+}
+
+// WithTracer sets the tracer of the transaction; see [BaseApp.TraceTx].
+func (c Context) WithTracer(tracer *Tracer) Context {
+	c.tracer = tracer
 	return c
 }
 
+// WithEventLogger sets the event manager of the context.
+//
+// Deprecated: use WithEventManager.
+func (c Context) WithEventLogger(em *EventLogger) Context {
+	return c.WithEventManager(em)
+}
+
 // WithValue is shorthand for:
 //
 //	c.WithContext(context.WithValue(c.Context(), key, value))
@@ -180,12 +200,12 @@ func (c Context) Store(key store.StoreKey) store.Store {
 }
 
 // CacheContext returns a new Context with the multi-store cached and a new
-// EventLogger . The cached context is written to the context when writeCache
+// EventManager . The cached context is written to the context when writeCache
 // is called.
 // XXX remove?
 func (c Context) CacheContext() (cc Context, writeCache func()) {
 	cms := c.MultiStore().MultiCacheWrap()
-	cc = c.WithMultiStore(cms).WithEventLogger(NewEventLogger())
+	cc = c.WithMultiStore(cms).WithEventManager(NewEventManager())
 	return cc, cms.MultiWrite
 }
 
@@ -5,28 +5,41 @@ import (
 )
 
 // ----------------------------------------------------------------------------
-// EventLogger
+// EventManager
 // ----------------------------------------------------------------------------
 
-// EventLogger implements a simple wrapper around a slice of Event objects that
-// can be emitted from.
-type EventLogger struct {
+// EventManager collects the events emitted while processing a message.
+// Events are returned in the order they were emitted, which is deterministic
+// as long as message handlers are.
+type EventManager struct {
 	events []Event
 }
 
-func NewEventLogger() *EventLogger {
-	return &EventLogger{nil}
+func NewEventManager() *EventManager {
+	return &EventManager{nil}
 }
 
-func (em *EventLogger) Events() []Event { return em.events }
+func (em *EventManager) Events() []Event { return em.events }
+
+// EventLogger is the former name of EventManager.
+//
+// Deprecated: use EventManager.
+type EventLogger = EventManager
+
+// NewEventLogger returns a new EventManager.
+//
+// Deprecated: use NewEventManager.
+func NewEventLogger() *EventLogger { return NewEventManager() }
 
 // EmitEvent stores a single Event object.
-func (em *EventLogger) EmitEvent(event Event) {
+// The event must be a type registered with amino, so it can be encoded in
+// the results of the transaction.
+func (em *EventManager) EmitEvent(event Event) {
 	em.events = append(em.events, event)
 }
 
-// EmitEvents stores a series of Event objects.
-func (em *EventLogger) EmitEvents(events []Event) {
+// EmitEvents stores a series of Event objects, preserving their order.
+func (em *EventManager) EmitEvents(events []Event) {
 	em.events = append(em.events, events...)
 }
 
@@ -35,3 +48,42 @@ func (em *EventLogger) EmitEvents(events []Event) {
 // ----------------------------------------------------------------------------
 
 type Event = abci.Event
+
+// Attribute is a key/value pair of an AttributeEvent. Index marks the
+// attributes external indexers should index, so that transactions can be
+// queried by their value; like the rest of the event, it is part of the
+// results of the transaction, and so must be set deterministically.
+type Attribute struct {
+	Key   string `json:"key"`
+	Value string `json:"value"`
+	Index bool   `json:"index,omitempty"`
+}
+
+// NewAttribute returns a non-indexed attribute.
+func NewAttribute(key, value string) Attribute {
+	return Attribute{Key: key, Value: value}
+}
+
+// NewIndexedAttribute returns an indexed attribute.
+func NewIndexedAttribute(key, value string) Attribute {
+	return Attribute{Key: key, Value: value, Index: true}
+}
+
+// AttributeEvent is an event made of key/value attributes, for modules
+// which don't define a dedicated event type.
+type AttributeEvent struct {
+	Type       string      `json:"type"`
+	Module     string      `json:"module"`
+	Attributes []Attribute `json:"attrs"`
+}
+
+// NewEvent returns an AttributeEvent of the given type, emitted by module.
+func NewEvent(typ, module string, attrs ...Attribute) AttributeEvent {
+	return AttributeEvent{
+		Type:       typ,
+		Module:     module,
+		Attributes: attrs,
+	}
+}
+
+func (AttributeEvent) AssertABCIEvent() {}
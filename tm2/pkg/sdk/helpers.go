@@ -5,6 +5,7 @@ import (
 	"regexp"
 
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/errcode"
 )
 
 var isAlphaNumeric = regexp.MustCompile(`^[a-zA-Z0-9]+$`).MatchString
@@ -56,6 +57,17 @@ func ABCIError(err error) abci.Error {
 	return abci.ABCIErrorOrStringError(err)
 }
 
+// setErrorCode sets the codespace and code of the error of res, from the
+// registry of error codes; errors whose type isn't registered, like
+// abci.StringError, have the code errcode.Undefined.
+func setErrorCode(res *abci.ResponseBase) {
+	if res.Error == nil {
+		return
+	}
+	code := errcode.Of(res.Error)
+	res.Codespace, res.Code = code.Codespace, code.Code
+}
+
 func ABCIResultFromError(err error) (res Result) {
 	res.Error = ABCIError(err)
 	res.Log = fmt.Sprintf("%#v", err)
@@ -5,6 +5,7 @@ import (
 
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
 	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/snapshots"
 )
 
 // File for storing in-package BaseApp optional functions,
@@ -19,6 +20,23 @@ func SetPruningOptions(opts store.PruningOptions) func(*BaseApp) {
 	}
 }
 
+// SetSnapshotOptions returns an option that makes the app take a snapshot of
+// its state in dir every interval blocks, keeping the keepRecent most recent
+// ones. The snapshots are served by the ".app/snapshots" queries.
+func SetSnapshotOptions(dir string, interval, keepRecent int64) func(*BaseApp) {
+	return func(bap *BaseApp) {
+		snapshotter, ok := bap.cms.(store.Snapshotter)
+		if !ok {
+			panic("multistore doesn't support snapshots")
+		}
+		manager, err := snapshots.NewManager(dir, snapshotter, interval, keepRecent, bap.logger)
+		if err != nil {
+			panic(fmt.Sprintf("invalid snapshot options: %v", err))
+		}
+		bap.snapshots = manager
+	}
+}
+
 // SetMinGasPrices returns an option that sets the minimum gas prices on the app.
 func SetMinGasPrices(gasPricesStr string) func(*BaseApp) {
 	gasPrices, err := ParseGasPrices(gasPricesStr)
@@ -79,6 +97,20 @@ func (app *BaseApp) SetEndBlocker(endBlocker EndBlocker) {
 	app.endBlocker = endBlocker
 }
 
+func (app *BaseApp) SetPrepareProposalHandler(prepareProposal PrepareProposalHandler) {
+	if app.sealed {
+		panic("SetPrepareProposalHandler() on sealed BaseApp")
+	}
+	app.prepareProposal = prepareProposal
+}
+
+func (app *BaseApp) SetProcessProposalHandler(processProposal ProcessProposalHandler) {
+	if app.sealed {
+		panic("SetProcessProposalHandler() on sealed BaseApp")
+	}
+	app.processProposal = processProposal
+}
+
 func (app *BaseApp) SetAnteHandler(ah AnteHandler) {
 	if app.sealed {
 		panic("SetAnteHandler() on sealed BaseApp")
@@ -99,3 +131,10 @@ func (app *BaseApp) SetEndTxHook(endTx EndTxHook) {
 	}
 	app.endTxHook = endTx
 }
+
+func (app *BaseApp) SetMetadataHook(metadata MetadataHook) {
+	if app.sealed {
+		panic("SetMetadataHook() on sealed BaseApp")
+	}
+	app.metadataHook = metadata
+}
@@ -15,4 +15,6 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	).
 	WithTypes(
 		Result{},
+		Attribute{},
+		AttributeEvent{},
 	))
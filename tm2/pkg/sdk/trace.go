@@ -0,0 +1,165 @@
+package sdk
+
+import (
+	"errors"
+	"fmt"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	"github.com/gnolang/gno/tm2/pkg/std"
+	"github.com/gnolang/gno/tm2/pkg/store"
+	"github.com/gnolang/gno/tm2/pkg/store/trace"
+)
+
+// Tracer records the calls made by a transaction, with the gas they use, and
+// the operations it makes on the stores. It is set on the Context of the
+// transactions run by [BaseApp.TraceTx]; handlers trace their own calls, like
+// the calls to smart contracts, with BeginCall and EndCall.
+type Tracer struct {
+	calls    []abci.TraceCall
+	storeOps []abci.TraceStoreOp
+	open     []openCall // the calls in progress
+}
+
+type openCall struct {
+	index    int   // in calls
+	gasStart int64 // gas consumed when the call began
+}
+
+// NewTracer returns a new, empty Tracer.
+func NewTracer() *Tracer {
+	return &Tracer{}
+}
+
+// Depth returns the number of calls in progress.
+func (t *Tracer) Depth() int {
+	return len(t.open)
+}
+
+// BeginCall records a call to name, made in realm (if any), nested in the
+// calls in progress. gasConsumed is the gas consumed by the transaction when
+// the call begins.
+func (t *Tracer) BeginCall(name, realm string, gasConsumed int64) {
+	t.open = append(t.open, openCall{index: len(t.calls), gasStart: gasConsumed})
+	t.calls = append(t.calls, abci.TraceCall{
+		Depth: len(t.open) - 1,
+		Name:  name,
+		Realm: realm,
+	})
+}
+
+// EndCall ends the last call in progress, when gasConsumed gas is consumed by
+// the transaction.
+func (t *Tracer) EndCall(gasConsumed int64) {
+	t.EndCalls(len(t.open)-1, gasConsumed)
+}
+
+// EndCalls ends the calls in progress until depth calls remain, like when they
+// are unwound by a panic.
+func (t *Tracer) EndCalls(depth int, gasConsumed int64) {
+	for len(t.open) > max(depth, 0) {
+		oc := t.open[len(t.open)-1]
+		t.calls[oc.index].GasUsed = gasConsumed - oc.gasStart
+		t.open = t.open[:len(t.open)-1]
+	}
+}
+
+// RecordStoreOp records an operation made on a store; it is a
+// [trace.Recorder].
+func (t *Tracer) RecordStoreOp(store, op string, key, value []byte) {
+	// the stores may reuse the buffers.
+	t.storeOps = append(t.storeOps, abci.TraceStoreOp{
+		Store: store,
+		Op:    op,
+		Key:   append([]byte(nil), key...),
+		Value: append([]byte(nil), value...),
+	})
+}
+
+// Calls returns the calls recorded, in the order they were made.
+func (t *Tracer) Calls() []abci.TraceCall {
+	return t.calls
+}
+
+// StoreOps returns the store operations recorded, in the order they were made.
+func (t *Tracer) StoreOps() []abci.TraceStoreOp {
+	return t.storeOps
+}
+
+// TraceTx executes the given transactions in a block with the given header, on
+// the state committed for the previous height, and returns the trace of the
+// last one. The state is left unchanged, and the transactions are executed
+// with the current consensus params, and without the votes of the last
+// commit, which the application doesn't keep.
+//
+// The stores which don't keep their versions, like the base store, are read
+// at their latest state: the trace of a transaction reading values of them
+// which changed since may differ from its original execution.
+//
+// It is served by the ".app/trace" query, which data is an amino-encoded
+// [abci.TraceTxRequest]. Like ".app/simulate", it executes transactions
+// without charging for them.
+func (app *BaseApp) TraceTx(header abci.Header, txs [][]byte) (abci.TxTrace, error) {
+	if len(txs) == 0 {
+		return abci.TxTrace{}, errors.New("no transaction to trace")
+	}
+	if header == nil || header.GetHeight() <= 1 {
+		return abci.TxTrace{}, errors.New("cannot trace the transactions of the first block")
+	}
+
+	version := header.GetHeight() - 1
+	ms, err := app.cms.MultiImmutableCacheWrapWithVersion(version)
+	if err != nil {
+		return abci.TxTrace{}, fmt.Errorf(
+			"failed to load state at height %d; %w (latest height: %d)",
+			version, err, app.LastBlockHeight(),
+		)
+	}
+
+	var blockGasMeter store.GasMeter
+	if maxGas := app.getMaximumBlockGas(); maxGas > 0 {
+		blockGasMeter = store.NewGasMeter(maxGas)
+	} else {
+		blockGasMeter = store.NewInfiniteGasMeter()
+	}
+	ctx := NewContext(RunTxModeDeliver, ms, header, app.logger).
+		WithBlockGasMeter(blockGasMeter).
+		WithConsensusParams(app.consensusParams)
+
+	if app.beginBlocker != nil {
+		app.beginBlocker(ctx.WithEventManager(NewEventManager()), abci.RequestBeginBlock{Header: header})
+	}
+
+	// NOTE: every transaction run has a tracer, which stops the end tx hook
+	// from committing the application caches, like for the sandboxed state.
+	var (
+		tracer *Tracer
+		result Result
+	)
+	for i, txBytes := range txs {
+		tracer = NewTracer()
+		txCtx := ctx.WithTxBytes(txBytes).WithTracer(tracer)
+		if i == len(txs)-1 {
+			txCtx = txCtx.WithMultiStore(trace.NewMultiStore(ms, tracer.RecordStoreOp))
+		}
+
+		var tx Tx
+		if err := amino.UnmarshalLimited(txBytes, &tx, txDecodeLimits); err != nil {
+			result = Result{}
+			result.Error = ABCIError(std.ErrTxDecode(err.Error()))
+			continue
+		}
+		result = app.runTx(txCtx, tx)
+	}
+	tracer.EndCalls(0, result.GasUsed)
+
+	return abci.TxTrace{
+		Result: abci.ResponseDeliverTx{
+			ResponseBase: result.ResponseBase,
+			GasWanted:    result.GasWanted,
+			GasUsed:      result.GasUsed,
+		},
+		Calls:    tracer.Calls(),
+		StoreOps: tracer.StoreOps(),
+	}, nil
+}
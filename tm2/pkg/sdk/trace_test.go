@@ -0,0 +1,123 @@
+package sdk
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
+	bft "github.com/gnolang/gno/tm2/pkg/bft/types"
+	"github.com/gnolang/gno/tm2/pkg/store"
+)
+
+func TestTracer(t *testing.T) {
+	t.Parallel()
+
+	tr := NewTracer()
+	tr.BeginCall("a", "", 0)
+	tr.BeginCall("b", "gno.land/r/b", 10)
+	tr.BeginCall("c", "gno.land/r/c", 15)
+	tr.EndCall(20)
+	assert.Equal(t, 2, tr.Depth())
+	// b is unwound by a panic, before a is ended.
+	tr.EndCalls(1, 30)
+	tr.EndCall(35)
+	assert.Equal(t, 0, tr.Depth())
+
+	assert.Equal(t, []abci.TraceCall{
+		{Depth: 0, Name: "a", GasUsed: 35},
+		{Depth: 1, Name: "b", Realm: "gno.land/r/b", GasUsed: 20},
+		{Depth: 2, Name: "c", Realm: "gno.land/r/c", GasUsed: 5},
+	}, tr.Calls())
+}
+
+func TestTraceTx(t *testing.T) {
+	t.Parallel()
+
+	anteKey := []byte("ante-key")
+	anteOpt := func(bapp *BaseApp) { bapp.SetAnteHandler(anteHandlerTxTest(t, mainKey, anteKey)) }
+
+	deliverKey := []byte("deliver-key")
+	routerOpt := func(bapp *BaseApp) {
+		bapp.Router().AddRoute(routeMsgCounter, newMsgCounterHandler(t, mainKey, deliverKey))
+	}
+
+	// keep the state of the previous heights.
+	pruningOpt := SetPruningOptions(store.PruneNothing)
+
+	app := setupBaseApp(t, anteOpt, routerOpt, pruningOpt)
+	app.InitChain(abci.RequestInitChain{ChainID: "test-chain"})
+
+	// deliver one tx in the first block, and two in the second.
+	var (
+		headers []*bft.Header
+		blocks  [][][]byte
+	)
+	for blockN, counters := range [][]int64{{0}, {1, 2}} {
+		header := &bft.Header{ChainID: "test-chain", Height: int64(blockN) + 1}
+		app.BeginBlock(abci.RequestBeginBlock{Header: header})
+
+		var txs [][]byte
+		for _, counter := range counters {
+			txBytes, err := amino.Marshal(newTxCounter(counter, counter))
+			require.NoError(t, err)
+
+			res := app.DeliverTx(abci.RequestDeliverTx{Tx: txBytes})
+			require.True(t, res.IsOK(), fmt.Sprintf("%v", res))
+			txs = append(txs, txBytes)
+		}
+
+		app.EndBlock(abci.RequestEndBlock{})
+		app.Commit()
+		headers = append(headers, header)
+		blocks = append(blocks, txs)
+	}
+
+	traceQuery := func(header *bft.Header, txs [][]byte) abci.ResponseQuery {
+		return app.Query(abci.RequestQuery{
+			Path: ".app/trace",
+			Data: amino.MustMarshal(abci.TraceTxRequest{Header: header, Txs: txs}),
+		})
+	}
+
+	t.Run("trace", func(t *testing.T) {
+		// trace the second tx of the second block, which counters only match
+		// once the first tx is executed.
+		res := traceQuery(headers[1], blocks[1])
+		require.Nil(t, res.Error, res.Log)
+
+		var trace abci.TxTrace
+		require.NoError(t, amino.Unmarshal(res.Value, &trace))
+		require.True(t, trace.Result.IsOK(), "%v", trace.Result)
+		assert.Greater(t, trace.Result.GasUsed, int64(0))
+
+		require.Len(t, trace.Calls, 2)
+		assert.Equal(t, "ante", trace.Calls[0].Name)
+		assert.Equal(t, routeMsgCounter+"/counter1", trace.Calls[1].Name)
+		for _, call := range trace.Calls {
+			assert.Equal(t, 0, call.Depth)
+		}
+
+		assert.Contains(t, trace.StoreOps, abci.TraceStoreOp{
+			Store: mainKey.Name(),
+			Op:    "set",
+			Key:   deliverKey,
+			Value: []byte{6}, // varint of 3
+		})
+	})
+
+	t.Run("state unchanged", func(t *testing.T) {
+		res := app.Query(abci.RequestQuery{Path: ".store/main/key", Data: deliverKey})
+		require.Nil(t, res.Error)
+		assert.Equal(t, []byte{6}, res.Value)
+	})
+
+	t.Run("first block", func(t *testing.T) {
+		res := traceQuery(headers[0], blocks[0])
+		require.NotNil(t, res.Error)
+		assert.Contains(t, res.Log, "first block")
+	})
+}
@@ -1,6 +1,7 @@
 package std
 
 import (
+	"github.com/gnolang/gno/tm2/pkg/errcode"
 	"github.com/gnolang/gno/tm2/pkg/errors"
 )
 
@@ -27,6 +28,7 @@ type (
 	InvalidGasWantedError   struct{ abciError }
 	OutOfGasError           struct{ abciError }
 	MemoTooLargeError       struct{ abciError }
+	TxTooLargeError         struct{ abciError }
 	InsufficientFeeError    struct{ abciError }
 	TooManySignaturesError  struct{ abciError }
 	NoSignaturesError       struct{ abciError }
@@ -48,6 +50,7 @@ func (e InvalidCoinsError) Error() string       { return "invalid coins error" }
 func (e InvalidGasWantedError) Error() string   { return "invalid gas wanted" }
 func (e OutOfGasError) Error() string           { return "out of gas error" }
 func (e MemoTooLargeError) Error() string       { return "memo too large error" }
+func (e TxTooLargeError) Error() string         { return "tx too large error" }
 func (e InsufficientFeeError) Error() string    { return "insufficient fee error" }
 func (e TooManySignaturesError) Error() string  { return "too many signatures error" }
 func (e NoSignaturesError) Error() string       { return "no signatures error" }
@@ -56,6 +59,31 @@ func (e RestrictedTransferError) Error() string { return "restricted token trans
 
 // NOTE also update pkg/std/package.go registrations.
 
+// Codespace holds the codes of the std errors, returned in ABCI responses.
+// NOTE: codes are stable; never change or reuse them.
+var Codespace = errcode.Register(errcode.NewCodespace("std").WithErrors(
+	InternalError{}, 1,
+	TxDecodeError{}, 2,
+	InvalidSequenceError{}, 3,
+	UnauthorizedError{}, 4,
+	InsufficientFundsError{}, 5,
+	UnknownRequestError{}, 6,
+	InvalidAddressError{}, 7,
+	UnknownAddressError{}, 8,
+	InvalidPubKeyError{}, 9,
+	InsufficientCoinsError{}, 10,
+	InvalidCoinsError{}, 11,
+	InvalidGasWantedError{}, 12,
+	OutOfGasError{}, 13,
+	MemoTooLargeError{}, 14,
+	TxTooLargeError{}, 15,
+	InsufficientFeeError{}, 16,
+	TooManySignaturesError{}, 17,
+	NoSignaturesError{}, 18,
+	GasOverflowError{}, 19,
+	RestrictedTransferError{}, 20,
+))
+
 func ErrInternal(msg string) error {
 	return errors.Wrap(InternalError{}, msg)
 }
@@ -112,6 +140,10 @@ func ErrMemoTooLarge(msg string) error {
 	return errors.Wrap(MemoTooLargeError{}, msg)
 }
 
+func ErrTxTooLarge(msg string) error {
+	return errors.Wrap(TxTooLargeError{}, msg)
+}
+
 func ErrInsufficientFee(msg string) error {
 	return errors.Wrap(InsufficientFeeError{}, msg)
 }
@@ -36,6 +36,7 @@ var Package = amino.RegisterPackage(amino.NewPackage(
 	InvalidGasWantedError{}, "InvalidGasWantedError",
 	OutOfGasError{}, "OutOfGasError",
 	MemoTooLargeError{}, "MemoTooLargeError",
+	TxTooLargeError{}, "TxTooLargeError",
 	InsufficientFeeError{}, "InsufficientFeeError",
 	TooManySignaturesError{}, "TooManySignaturesError",
 	NoSignaturesError{}, "NoSignaturesError",
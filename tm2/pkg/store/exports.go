@@ -12,6 +12,7 @@ type (
 	CommitStore            = types.CommitStore
 	MultiStore             = types.MultiStore
 	CommitMultiStore       = types.CommitMultiStore
+	Snapshotter            = types.Snapshotter
 	CommitStoreConstructor = types.CommitStoreConstructor
 	KVPair                 = types.KVPair
 	Iterator               = types.Iterator
@@ -36,5 +37,6 @@ var (
 	DefaultGasConfig       = types.DefaultGasConfig
 	PrefixIterator         = types.PrefixIterator
 	ReversePrefixIterator  = types.ReversePrefixIterator
+	PrefixEndBytes         = types.PrefixEndBytes
 	NewStoreKey            = types.NewStoreKey
 )
@@ -76,6 +76,26 @@ func (st *Store) GetImmutable(version int64) (*Store, error) {
 	}, nil
 }
 
+// Export returns an exporter of the nodes of the tree at version. The caller
+// must close it when done.
+func (st *Store) Export(version int64) (*iavl.Exporter, error) {
+	tree, err := st.tree.GetImmutable(version)
+	if err != nil {
+		return nil, err
+	}
+	return tree.Export()
+}
+
+// Import returns an importer of the nodes exported by [Store.Export] as
+// version. The store must be empty.
+func (st *Store) Import(version int64) (*iavl.Importer, error) {
+	tree, ok := st.tree.(*iavl.MutableTree)
+	if !ok {
+		return nil, goerrors.New("cannot import into an immutable store")
+	}
+	return tree.Import(version)
+}
+
 // Implements Committer.
 func (st *Store) Commit() types.CommitID {
 	// Save a new version.
@@ -0,0 +1,242 @@
+package rootmulti
+
+import (
+	"bufio"
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	iavltree "github.com/gnolang/gno/tm2/pkg/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+// maxSnapshotItemSize bounds the size of a single item read by Restore.
+const maxSnapshotItemSize = 64 << 20
+
+// snapshotItem is a record of a snapshot. Each store starts with an item
+// holding only its name, followed by its key/value pairs or, for IAVL stores,
+// by the nodes of its tree in the order of [iavltree.Exporter].
+type snapshotItem struct {
+	Store   string
+	Key     []byte
+	Value   []byte
+	Version int64 // IAVL node version
+	Height  int64 // IAVL node height, 0 for leaves
+}
+
+var _ types.Snapshotter = (*multiStore)(nil)
+
+// Implements Snapshotter.
+// Stores are written in the order of their names, unversioned stores first.
+func (ms *multiStore) Snapshot(version int64, w io.Writer, ready func()) (err error) {
+	readyCalled := false
+	setReady := func() {
+		if ready != nil && !readyCalled {
+			readyCalled = true
+			ready()
+		}
+	}
+	// Never leave the caller waiting, even on errors.
+	defer setReady()
+
+	if version <= 0 || version > ms.lastCommitID.Version {
+		return fmt.Errorf("cannot snapshot version %d, latest version is %d", version, ms.lastCommitID.Version)
+	}
+
+	var unversioned, versioned []string
+	stores := make(map[string]types.CommitStore, len(ms.stores))
+	for key, store := range ms.stores {
+		stores[key.Name()] = store
+		if _, ok := store.(*iavl.Store); ok {
+			versioned = append(versioned, key.Name())
+		} else {
+			unversioned = append(unversioned, key.Name())
+		}
+	}
+	sort.Strings(unversioned)
+	sort.Strings(versioned)
+
+	if len(unversioned) > 0 && version != ms.lastCommitID.Version {
+		return fmt.Errorf("cannot snapshot version %d: stores %v are not versioned, and can only be exported at the latest version %d",
+			version, unversioned, ms.lastCommitID.Version)
+	}
+
+	bw := bufio.NewWriter(w)
+	write := func(item snapshotItem) error {
+		_, err := amino.MarshalSizedWriter(bw, item)
+		return err
+	}
+
+	for _, name := range unversioned {
+		if err := write(snapshotItem{Store: name}); err != nil {
+			return err
+		}
+		if err := writeStoreItems(stores[name], write); err != nil {
+			return fmt.Errorf("store %q: %w", name, err)
+		}
+	}
+	// Unversioned stores must be written out before new commits change them.
+	if err := bw.Flush(); err != nil {
+		return err
+	}
+	setReady()
+
+	for _, name := range versioned {
+		if err := write(snapshotItem{Store: name}); err != nil {
+			return err
+		}
+		if err := writeTreeNodes(stores[name].(*iavl.Store), version, write); err != nil {
+			return fmt.Errorf("store %q: %w", name, err)
+		}
+	}
+	return bw.Flush()
+}
+
+func writeStoreItems(store types.Store, write func(snapshotItem) error) error {
+	it := store.Iterator(nil, nil)
+	defer it.Close()
+	for ; it.Valid(); it.Next() {
+		if err := write(snapshotItem{Key: it.Key(), Value: it.Value()}); err != nil {
+			return err
+		}
+	}
+	return it.Error()
+}
+
+func writeTreeNodes(store *iavl.Store, version int64, write func(snapshotItem) error) error {
+	exporter, err := store.Export(version)
+	if err != nil {
+		return err
+	}
+	defer exporter.Close()
+	for {
+		node, err := exporter.Next()
+		if errors.Is(err, iavltree.ErrExportDone) {
+			return nil
+		}
+		if err != nil {
+			return err
+		}
+		item := snapshotItem{
+			Key:     node.Key,
+			Value:   node.Value,
+			Version: node.Version,
+			Height:  int64(node.Height),
+		}
+		if err := write(item); err != nil {
+			return err
+		}
+	}
+}
+
+// Implements Snapshotter.
+func (ms *multiStore) Restore(version int64, r io.Reader) (types.CommitID, error) {
+	if version <= 0 {
+		return types.CommitID{}, fmt.Errorf("cannot restore version %d", version)
+	}
+	if ms.lastCommitID.Version != 0 {
+		return types.CommitID{}, fmt.Errorf("cannot restore into stores at version %d", ms.lastCommitID.Version)
+	}
+
+	var (
+		br       = bufio.NewReader(r)
+		restored = make(map[string]bool, len(ms.stores))
+		store    types.CommitStore
+		importer *iavltree.Importer
+	)
+	defer func() {
+		if importer != nil {
+			importer.Close()
+		}
+	}()
+	commitImport := func() error {
+		if importer == nil {
+			return nil
+		}
+		imp := importer
+		importer = nil
+		return imp.Commit()
+	}
+
+	for {
+		var item snapshotItem
+		n, err := amino.UnmarshalSizedReader(br, &item, maxSnapshotItemSize)
+		if errors.Is(err, io.EOF) && n == 0 {
+			break
+		}
+		if err != nil {
+			return types.CommitID{}, fmt.Errorf("reading snapshot: %w", err)
+		}
+
+		if item.Store != "" {
+			if err := commitImport(); err != nil {
+				return types.CommitID{}, err
+			}
+			key, ok := ms.keysByName[item.Store]
+			if !ok {
+				return types.CommitID{}, fmt.Errorf("snapshot has unknown store %q", item.Store)
+			}
+			if restored[item.Store] {
+				return types.CommitID{}, fmt.Errorf("snapshot has store %q twice", item.Store)
+			}
+			restored[item.Store] = true
+			store = ms.stores[key]
+			if st, ok := store.(*iavl.Store); ok {
+				if importer, err = st.Import(version); err != nil {
+					return types.CommitID{}, fmt.Errorf("store %q: %w", item.Store, err)
+				}
+			}
+			continue
+		}
+
+		switch {
+		case store == nil:
+			return types.CommitID{}, errors.New("snapshot item does not belong to a store")
+		case importer != nil:
+			err = importer.Add(&iavltree.ExportNode{
+				Key:     item.Key,
+				Value:   item.Value,
+				Version: item.Version,
+				Height:  int8(item.Height),
+			})
+			if err != nil {
+				return types.CommitID{}, err
+			}
+		default:
+			store.Set(item.Key, item.Value)
+		}
+	}
+	if err := commitImport(); err != nil {
+		return types.CommitID{}, err
+	}
+
+	storeInfos := make([]storeInfo, 0, len(ms.stores))
+	for key, store := range ms.stores {
+		if !restored[key.Name()] {
+			return types.CommitID{}, fmt.Errorf("snapshot is missing store %q", key.Name())
+		}
+		si := storeInfo{Name: key.Name()}
+		si.Core.CommitID = store.LastCommitID()
+		storeInfos = append(storeInfos, si)
+	}
+	ci := commitInfo{
+		Version:    version,
+		StoreInfos: storeInfos,
+	}
+
+	batch := ms.db.NewBatch()
+	defer batch.Close()
+	setCommitInfo(batch, version, ci)
+	setLatestVersion(batch, version)
+	if err := batch.Write(); err != nil {
+		return types.CommitID{}, err
+	}
+
+	if err := ms.LoadVersion(version); err != nil {
+		return types.CommitID{}, err
+	}
+	return ms.lastCommitID, nil
+}
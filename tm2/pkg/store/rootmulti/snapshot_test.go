@@ -0,0 +1,108 @@
+package rootmulti
+
+import (
+	"bytes"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	dbm "github.com/gnolang/gno/tm2/pkg/db"
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+func newSnapshotMultiStore(t *testing.T, db dbm.DB) *multiStore {
+	t.Helper()
+
+	ms := newMultiStoreWithMounts(db)
+	ms.MountStoreWithDB(types.NewStoreKey("base"), dbadapter.StoreConstructor, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+	return ms
+}
+
+func TestSnapshotRestore(t *testing.T) {
+	t.Parallel()
+
+	src := newSnapshotMultiStore(t, memdb.NewMemDB())
+	for i := range 3 {
+		for j := range 50 {
+			k := fmt.Appendf(nil, "key-%d-%d", i, j)
+			src.getStoreByName("store1").Set(k, fmt.Appendf(nil, "value-%d", i))
+			src.getStoreByName("store2").Set(k, []byte("x"))
+		}
+		src.getStoreByName("store1").Delete([]byte("key-0-0"))
+		src.getStoreByName("base").Set(fmt.Appendf(nil, "base-%d", i), []byte("base"))
+		src.Commit()
+	}
+	// store3 is left empty.
+	commitID := src.LastCommitID()
+
+	var buf bytes.Buffer
+	ready := false
+	require.NoError(t, src.Snapshot(commitID.Version, &buf, func() { ready = true }))
+	assert.True(t, ready)
+
+	dst := newSnapshotMultiStore(t, memdb.NewMemDB())
+	restored, err := dst.Restore(commitID.Version, &buf)
+	require.NoError(t, err)
+	assert.Equal(t, commitID, restored)
+	assert.Equal(t, commitID, dst.LastCommitID())
+
+	assert.Nil(t, dst.getStoreByName("store1").Get([]byte("key-0-0")))
+	assert.Equal(t, []byte("value-2"), dst.getStoreByName("store1").Get([]byte("key-2-7")))
+	assert.Equal(t, []byte("base"), dst.getStoreByName("base").Get([]byte("base-1")))
+
+	// The restored stores can be reloaded, and keep committing like the source.
+	reloaded := newSnapshotMultiStore(t, dst.db)
+	assert.Equal(t, commitID, reloaded.LastCommitID())
+	for _, ms := range []*multiStore{src, reloaded} {
+		ms.getStoreByName("store2").Set([]byte("next"), []byte("block"))
+	}
+	assert.Equal(t, src.Commit(), reloaded.Commit())
+}
+
+func TestSnapshotErrors(t *testing.T) {
+	t.Parallel()
+
+	ms := newSnapshotMultiStore(t, memdb.NewMemDB())
+	ms.getStoreByName("store1").Set([]byte("k"), []byte("v"))
+	ms.Commit()
+	ms.getStoreByName("store1").Set([]byte("k"), []byte("v2"))
+	ms.Commit()
+
+	var buf bytes.Buffer
+	ready := false
+	err := ms.Snapshot(3, &buf, func() { ready = true })
+	assert.ErrorContains(t, err, "latest version is 2")
+	assert.True(t, ready, "ready must be called on errors")
+
+	// The base store is not versioned.
+	assert.ErrorContains(t, ms.Snapshot(1, &buf, nil), "not versioned")
+
+	require.NoError(t, ms.Snapshot(2, &buf, nil))
+	_, err = ms.Restore(2, bytes.NewReader(buf.Bytes()))
+	assert.ErrorContains(t, err, "cannot restore into stores at version 2")
+
+	// Missing stores.
+	partial := NewMultiStore(memdb.NewMemDB())
+	partial.MountStoreWithDB(types.NewStoreKey("store1"), iavl.StoreConstructor, nil)
+	require.NoError(t, partial.LoadLatestVersion())
+	_, err = partial.Restore(2, bytes.NewReader(buf.Bytes()))
+	assert.ErrorContains(t, err, `unknown store "base"`)
+
+	extra := newSnapshotMultiStore(t, memdb.NewMemDB())
+	extra.MountStoreWithDB(types.NewStoreKey("store4"), iavl.StoreConstructor, nil)
+	require.NoError(t, extra.LoadLatestVersion())
+	_, err = extra.Restore(2, bytes.NewReader(buf.Bytes()))
+	assert.ErrorContains(t, err, `missing store "store4"`)
+
+	// Truncated snapshot.
+	dst := newSnapshotMultiStore(t, memdb.NewMemDB())
+	_, err = dst.Restore(2, bytes.NewReader(buf.Bytes()[:buf.Len()-3]))
+	assert.Error(t, err)
+}
@@ -450,6 +450,24 @@ func getLatestVersion(db dbm.DB) int64 {
 	return latest
 }
 
+// LatestStoreNames returns the names of the stores committed in the latest
+// version of db, or nil if nothing was committed yet.
+func LatestStoreNames(db dbm.DB) ([]string, error) {
+	ver := getLatestVersion(db)
+	if ver == 0 {
+		return nil, nil
+	}
+	cInfo, err := getCommitInfo(db, ver)
+	if err != nil {
+		return nil, err
+	}
+	names := make([]string, 0, len(cInfo.StoreInfos))
+	for _, storeInfo := range cInfo.StoreInfos {
+		names = append(names, storeInfo.Name)
+	}
+	return names, nil
+}
+
 // Set the latest version.
 func setLatestVersion(batch dbm.Batch, version int64) {
 	latestBytes, _ := amino.MarshalSized(version)
@@ -0,0 +1,386 @@
+// Package snapshots takes periodic, compressed snapshots of the state of a
+// [types.Snapshotter], keeps the most recent ones on disk, and restores them.
+//
+// Each snapshot is kept in its own directory, named after its height, holding
+// the gzip-compressed output of [types.Snapshotter.Snapshot] and its [Info] as
+// JSON. Snapshots are served in chunks of [ChunkSize] bytes of the compressed
+// file, so that they can be fetched piece by piece over the network.
+package snapshots
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"crypto/sha256"
+	"errors"
+	"fmt"
+	"io"
+	"log/slog"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/gnolang/gno/tm2/pkg/amino"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+// ChunkSize is the size of the chunks in which snapshots are served.
+const ChunkSize = 4 << 20
+
+const (
+	snapshotFileName = "snapshot.gz"
+	infoFileName     = "info.json"
+	tmpDirPrefix     = ".tmp-"
+)
+
+var (
+	ErrNotFound     = errors.New("snapshot not found")
+	ErrInvalidChunk = errors.New("invalid snapshot chunk")
+	ErrHashMismatch = errors.New("restored state does not match the snapshot")
+)
+
+// Info describes a snapshot.
+type Info struct {
+	Height   int64  `json:"height"`
+	Hash     []byte `json:"hash"`     // app hash of the state at Height
+	Size     int64  `json:"size"`     // size of the compressed snapshot, in bytes
+	Chunks   int64  `json:"chunks"`   // number of chunks of ChunkSize bytes
+	Checksum []byte `json:"checksum"` // sha256 of the compressed snapshot
+}
+
+// Manager takes snapshots of a store every Interval heights, keeping the
+// KeepRecent most recent ones (or all of them, if KeepRecent is 0).
+type Manager struct {
+	dir        string
+	store      types.Snapshotter
+	interval   int64
+	keepRecent int64
+	logger     *slog.Logger
+
+	mu      sync.Mutex
+	running bool
+	ctx     context.Context
+	cancel  context.CancelFunc
+	wg      sync.WaitGroup
+}
+
+// NewManager returns a Manager keeping snapshots of store in dir. A zero
+// interval disables periodic snapshots; [Manager.Create] can still be used.
+func NewManager(dir string, store types.Snapshotter, interval, keepRecent int64, logger *slog.Logger) (*Manager, error) {
+	if interval < 0 || keepRecent < 0 {
+		return nil, fmt.Errorf("invalid snapshot interval %d or number of snapshots to keep %d", interval, keepRecent)
+	}
+	if err := os.MkdirAll(dir, 0o755); err != nil {
+		return nil, fmt.Errorf("unable to create snapshot directory: %w", err)
+	}
+
+	// Remove the leftovers of snapshots interrupted by a shutdown.
+	entries, err := os.ReadDir(dir)
+	if err != nil {
+		return nil, err
+	}
+	for _, entry := range entries {
+		if strings.HasPrefix(entry.Name(), tmpDirPrefix) {
+			if err := os.RemoveAll(filepath.Join(dir, entry.Name())); err != nil {
+				return nil, err
+			}
+		}
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	return &Manager{
+		dir:        dir,
+		store:      store,
+		interval:   interval,
+		keepRecent: keepRecent,
+		logger:     logger,
+		ctx:        ctx,
+		cancel:     cancel,
+	}, nil
+}
+
+// Commit must be called once the state at height was committed, with its app
+// hash. When height is a multiple of the interval, a snapshot is taken in the
+// background: Commit returns once the stores which are not versioned have been
+// written, so that the next height can be processed.
+//
+// If the previous snapshot is still being written, the new one is skipped.
+func (m *Manager) Commit(height int64, hash []byte) {
+	if m.interval == 0 || height%m.interval != 0 {
+		return
+	}
+
+	m.mu.Lock()
+	if m.running {
+		m.mu.Unlock()
+		m.logger.Warn("Skipping state snapshot, the previous one is still in progress", "height", height)
+		return
+	}
+	m.running = true
+	m.mu.Unlock()
+
+	ready := make(chan struct{})
+	m.wg.Add(1)
+	go func() {
+		defer m.wg.Done()
+		defer func() {
+			m.mu.Lock()
+			m.running = false
+			m.mu.Unlock()
+		}()
+
+		info, err := m.create(height, hash, func() { close(ready) })
+		if err != nil {
+			m.logger.Error("Unable to take state snapshot", "height", height, "err", err)
+			return
+		}
+		m.logger.Info("Took state snapshot", "height", height, "size", info.Size)
+	}()
+	<-ready
+}
+
+// Create takes a snapshot of the state at height, with the given app hash,
+// and waits for it to be written.
+func (m *Manager) Create(height int64, hash []byte) (Info, error) {
+	return m.create(height, hash, nil)
+}
+
+func (m *Manager) create(height int64, hash []byte, ready func()) (Info, error) {
+	tmpDir, err := os.MkdirTemp(m.dir, tmpDirPrefix)
+	if err != nil {
+		if ready != nil {
+			ready()
+		}
+		return Info{}, err
+	}
+	defer os.RemoveAll(tmpDir)
+
+	info, err := m.write(tmpDir, height, hash, ready)
+	if err != nil {
+		return Info{}, err
+	}
+
+	dir := m.snapshotDir(height)
+	if err := os.RemoveAll(dir); err != nil {
+		return Info{}, err
+	}
+	if err := os.Rename(tmpDir, dir); err != nil {
+		return Info{}, err
+	}
+
+	if err := m.prune(); err != nil {
+		m.logger.Error("Unable to prune state snapshots", "err", err)
+	}
+	return info, nil
+}
+
+func (m *Manager) write(dir string, height int64, hash []byte, ready func()) (Info, error) {
+	f, err := os.Create(filepath.Join(dir, snapshotFileName))
+	if err != nil {
+		if ready != nil {
+			ready()
+		}
+		return Info{}, err
+	}
+	defer f.Close()
+
+	checksum := sha256.New()
+	counter := &countingWriter{w: io.MultiWriter(f, checksum)}
+	zw := gzip.NewWriter(&contextWriter{ctx: m.ctx, w: counter})
+	if err := m.store.Snapshot(height, zw, ready); err != nil {
+		return Info{}, err
+	}
+	if err := zw.Close(); err != nil {
+		return Info{}, err
+	}
+	if err := f.Sync(); err != nil {
+		return Info{}, err
+	}
+
+	info := Info{
+		Height:   height,
+		Hash:     hash,
+		Size:     counter.n,
+		Chunks:   (counter.n + ChunkSize - 1) / ChunkSize,
+		Checksum: checksum.Sum(nil),
+	}
+	bz, err := amino.MarshalJSONIndent(info, "", "  ")
+	if err != nil {
+		return Info{}, err
+	}
+	if err := os.WriteFile(filepath.Join(dir, infoFileName), bz, 0o644); err != nil {
+		return Info{}, err
+	}
+	return info, nil
+}
+
+// prune removes the snapshots older than the keepRecent most recent ones.
+func (m *Manager) prune() error {
+	if m.keepRecent == 0 {
+		return nil
+	}
+	heights, err := m.heights()
+	if err != nil {
+		return err
+	}
+	for i := m.keepRecent; i < int64(len(heights)); i++ {
+		if err := os.RemoveAll(m.snapshotDir(heights[i])); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// heights returns the heights of the snapshots, most recent first.
+func (m *Manager) heights() ([]int64, error) {
+	entries, err := os.ReadDir(m.dir)
+	if err != nil {
+		return nil, err
+	}
+	var heights []int64
+	for _, entry := range entries {
+		height, err := strconv.ParseInt(entry.Name(), 10, 64)
+		if err != nil || !entry.IsDir() {
+			continue
+		}
+		heights = append(heights, height)
+	}
+	sort.Slice(heights, func(i, j int) bool { return heights[i] > heights[j] })
+	return heights, nil
+}
+
+func (m *Manager) snapshotDir(height int64) string {
+	return filepath.Join(m.dir, strconv.FormatInt(height, 10))
+}
+
+// List returns the available snapshots, most recent first.
+func (m *Manager) List() ([]Info, error) {
+	heights, err := m.heights()
+	if err != nil {
+		return nil, err
+	}
+	infos := make([]Info, 0, len(heights))
+	for _, height := range heights {
+		info, err := m.Get(height)
+		if err != nil {
+			// Being pruned.
+			continue
+		}
+		infos = append(infos, info)
+	}
+	return infos, nil
+}
+
+// Get returns the snapshot at height.
+func (m *Manager) Get(height int64) (Info, error) {
+	bz, err := os.ReadFile(filepath.Join(m.snapshotDir(height), infoFileName))
+	if errors.Is(err, os.ErrNotExist) {
+		return Info{}, fmt.Errorf("%w at height %d", ErrNotFound, height)
+	}
+	if err != nil {
+		return Info{}, err
+	}
+	var info Info
+	if err := amino.UnmarshalJSON(bz, &info); err != nil {
+		return Info{}, err
+	}
+	return info, nil
+}
+
+// Open returns a reader of the compressed snapshot at height.
+func (m *Manager) Open(height int64) (io.ReadCloser, Info, error) {
+	info, err := m.Get(height)
+	if err != nil {
+		return nil, Info{}, err
+	}
+	f, err := os.Open(filepath.Join(m.snapshotDir(height), snapshotFileName))
+	if err != nil {
+		return nil, Info{}, err
+	}
+	return f, info, nil
+}
+
+// LoadChunk returns the given chunk of the compressed snapshot at height.
+func (m *Manager) LoadChunk(height, chunk int64) ([]byte, error) {
+	f, info, err := m.Open(height)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+
+	if chunk < 0 || chunk >= info.Chunks {
+		return nil, fmt.Errorf("%w: %d, snapshot at height %d has %d chunks", ErrInvalidChunk, chunk, height, info.Chunks)
+	}
+	bz := make([]byte, min(ChunkSize, info.Size-chunk*ChunkSize))
+	if _, err := f.(io.ReaderAt).ReadAt(bz, chunk*ChunkSize); err != nil {
+		return nil, err
+	}
+	return bz, nil
+}
+
+// Wait waits for the snapshot being taken, if any.
+func (m *Manager) Wait() {
+	m.wg.Wait()
+}
+
+// Close interrupts the snapshot being taken, if any, and waits for it to stop.
+func (m *Manager) Close() {
+	m.cancel()
+	m.wg.Wait()
+}
+
+// Restore restores the compressed snapshot read from r into store, which must
+// be empty. The checksum and app hash of the snapshot are verified after the
+// state has been written: on error, the store must be discarded.
+func Restore(store types.Snapshotter, info Info, r io.Reader) error {
+	checksum := sha256.New()
+	zr, err := gzip.NewReader(io.TeeReader(r, checksum))
+	if err != nil {
+		return err
+	}
+	commitID, err := store.Restore(info.Height, zr)
+	if err != nil {
+		return err
+	}
+	// Read the rest of the stream, for all of it to go through the checksum.
+	if _, err := io.Copy(io.Discard, zr); err != nil {
+		return err
+	}
+	if err := zr.Close(); err != nil {
+		return err
+	}
+	if !bytes.Equal(checksum.Sum(nil), info.Checksum) {
+		return fmt.Errorf("%w: invalid checksum", ErrHashMismatch)
+	}
+	if !bytes.Equal(commitID.Hash, info.Hash) {
+		return fmt.Errorf("%w: app hash %X, expected %X", ErrHashMismatch, commitID.Hash, info.Hash)
+	}
+	return nil
+}
+
+type countingWriter struct {
+	w io.Writer
+	n int64
+}
+
+func (cw *countingWriter) Write(p []byte) (int, error) {
+	n, err := cw.w.Write(p)
+	cw.n += int64(n)
+	return n, err
+}
+
+// contextWriter fails writes once ctx is done.
+type contextWriter struct {
+	ctx context.Context
+	w   io.Writer
+}
+
+func (cw *contextWriter) Write(p []byte) (int, error) {
+	if err := cw.ctx.Err(); err != nil {
+		return 0, err
+	}
+	return cw.w.Write(p)
+}
@@ -0,0 +1,156 @@
+package snapshots
+
+import (
+	"bytes"
+	"fmt"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/log"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/iavl"
+	"github.com/gnolang/gno/tm2/pkg/store/rootmulti"
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+var (
+	mainKey = types.NewStoreKey("main")
+	baseKey = types.NewStoreKey("base")
+)
+
+type testStore interface {
+	types.CommitMultiStore
+	types.Snapshotter
+}
+
+func newTestStore(t *testing.T) testStore {
+	t.Helper()
+
+	ms := rootmulti.NewMultiStore(memdb.NewMemDB())
+	ms.SetStoreOptions(types.StoreOptions{PruningOptions: types.PruneNothing})
+	ms.MountStoreWithDB(mainKey, iavl.StoreConstructor, nil)
+	ms.MountStoreWithDB(baseKey, dbadapter.StoreConstructor, nil)
+	require.NoError(t, ms.LoadLatestVersion())
+	return ms
+}
+
+func commitHeight(ms testStore, height int64) types.CommitID {
+	for i := range 20 {
+		ms.GetStore(mainKey).Set(fmt.Appendf(nil, "key-%d", i), fmt.Appendf(nil, "value-%d-%d", height, i))
+	}
+	ms.GetStore(baseKey).Set(fmt.Appendf(nil, "height-%d", height), []byte("base"))
+	return ms.Commit()
+}
+
+func TestManager(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	ms := newTestStore(t)
+	m, err := NewManager(dir, ms, 2, 2, log.NewNoopLogger())
+	require.NoError(t, err)
+
+	hashes := map[int64][]byte{}
+	for height := int64(1); height <= 7; height++ {
+		cid := commitHeight(ms, height)
+		hashes[height] = cid.Hash
+		m.Commit(cid.Version, cid.Hash)
+		// Let each snapshot finish, as new ones are skipped while one is
+		// in progress.
+		m.Wait()
+	}
+	m.Close()
+
+	infos, err := m.List()
+	require.NoError(t, err)
+	require.Len(t, infos, 2)
+	assert.Equal(t, int64(6), infos[0].Height)
+	assert.Equal(t, int64(4), infos[1].Height)
+	assert.Equal(t, hashes[6], infos[0].Hash)
+	assert.NoDirExists(t, filepath.Join(dir, "2"))
+
+	_, err = m.Get(2)
+	assert.ErrorIs(t, err, ErrNotFound)
+
+	// Restore from the chunks, as a node fetching it over the network would.
+	info := infos[0]
+	var buf bytes.Buffer
+	for chunk := range info.Chunks {
+		bz, err := m.LoadChunk(info.Height, chunk)
+		require.NoError(t, err)
+		buf.Write(bz)
+	}
+	assert.Equal(t, info.Size, int64(buf.Len()))
+	_, err = m.LoadChunk(info.Height, info.Chunks)
+	assert.ErrorIs(t, err, ErrInvalidChunk)
+
+	restored := newTestStore(t)
+	require.NoError(t, Restore(restored, info, &buf))
+	assert.Equal(t, hashes[6], restored.LastCommitID().Hash)
+	assert.Equal(t, []byte("value-6-3"), restored.GetStore(mainKey).Get([]byte("key-3")))
+	// The base store is not versioned: it was exported as of height 6.
+	assert.NotNil(t, restored.GetStore(baseKey).Get([]byte("height-6")))
+	assert.Nil(t, restored.GetStore(baseKey).Get([]byte("height-7")))
+}
+
+func TestManager_Create(t *testing.T) {
+	t.Parallel()
+
+	dir := t.TempDir()
+	// Leftovers of an interrupted snapshot are removed.
+	require.NoError(t, os.Mkdir(filepath.Join(dir, tmpDirPrefix+"123"), 0o755))
+
+	ms := newTestStore(t)
+	m, err := NewManager(dir, ms, 0, 0, log.NewNoopLogger())
+	require.NoError(t, err)
+	defer m.Close()
+	assert.NoDirExists(t, filepath.Join(dir, tmpDirPrefix+"123"))
+
+	var cid types.CommitID
+	for height := int64(1); height <= 3; height++ {
+		cid = commitHeight(ms, height)
+		// Periodic snapshots are disabled.
+		m.Commit(cid.Version, cid.Hash)
+	}
+	infos, err := m.List()
+	require.NoError(t, err)
+	assert.Empty(t, infos)
+
+	info, err := m.Create(cid.Version, cid.Hash)
+	require.NoError(t, err)
+	assert.Equal(t, int64(3), info.Height)
+
+	t.Run("bad checksum", func(t *testing.T) {
+		t.Parallel()
+
+		r, info, err := m.Open(3)
+		require.NoError(t, err)
+		defer r.Close()
+		info.Checksum = []byte("invalid")
+		assert.ErrorIs(t, Restore(newTestStore(t), info, r), ErrHashMismatch)
+	})
+
+	t.Run("bad hash", func(t *testing.T) {
+		t.Parallel()
+
+		r, info, err := m.Open(3)
+		require.NoError(t, err)
+		defer r.Close()
+		info.Hash = []byte("invalid")
+		assert.ErrorIs(t, Restore(newTestStore(t), info, r), ErrHashMismatch)
+	})
+}
+
+func TestNewManager_Invalid(t *testing.T) {
+	t.Parallel()
+
+	_, err := NewManager(t.TempDir(), newTestStore(t), -1, 0, log.NewNoopLogger())
+	assert.Error(t, err)
+	_, err = NewManager(t.TempDir(), newTestStore(t), 10, -1, log.NewNoopLogger())
+	assert.Error(t, err)
+}
@@ -0,0 +1,168 @@
+// Package trace wraps stores to record the operations made on them, for
+// tracing the execution of transactions.
+package trace
+
+import (
+	"github.com/gnolang/gno/tm2/pkg/store/types"
+)
+
+// Operations recorded on traced stores.
+const (
+	OpGet    = "get"
+	OpHas    = "has"
+	OpSet    = "set"
+	OpDelete = "delete"
+	OpIter   = "iter" // a key/value pair read by an iterator
+)
+
+// Recorder receives the operations made on traced stores. For OpHas, value
+// is non-nil if the key exists.
+type Recorder func(store, op string, key, value []byte)
+
+var (
+	_ types.Store      = &Store{}
+	_ types.MultiStore = MultiStore{}
+)
+
+// Store records the operations made on an underlying Store. It implements the
+// Store interface.
+type Store struct {
+	name   string
+	parent types.Store
+	record Recorder
+}
+
+// New returns a Store recording the operations made on parent with the given
+// store name.
+func New(parent types.Store, name string, record Recorder) *Store {
+	return &Store{
+		name:   name,
+		parent: parent,
+		record: record,
+	}
+}
+
+// Implements Store.
+func (ts *Store) Get(key []byte) []byte {
+	value := ts.parent.Get(key)
+	ts.record(ts.name, OpGet, key, value)
+	return value
+}
+
+// Implements Store.
+func (ts *Store) Has(key []byte) bool {
+	has := ts.parent.Has(key)
+	var value []byte
+	if has {
+		value = []byte{}
+	}
+	ts.record(ts.name, OpHas, key, value)
+	return has
+}
+
+// Implements Store.
+func (ts *Store) Set(key, value []byte) {
+	ts.record(ts.name, OpSet, key, value)
+	ts.parent.Set(key, value)
+}
+
+// Implements Store.
+func (ts *Store) Delete(key []byte) {
+	ts.record(ts.name, OpDelete, key, nil)
+	ts.parent.Delete(key)
+}
+
+// Implements Store.
+func (ts *Store) Iterator(start, end []byte) types.Iterator {
+	return &iterator{store: ts, parent: ts.parent.Iterator(start, end)}
+}
+
+// Implements Store.
+func (ts *Store) ReverseIterator(start, end []byte) types.Iterator {
+	return &iterator{store: ts, parent: ts.parent.ReverseIterator(start, end)}
+}
+
+// CacheWrap cache-wraps the parent store, and keeps tracing the operations
+// on the cache.
+func (ts *Store) CacheWrap() types.Store {
+	return New(ts.parent.CacheWrap(), ts.name, ts.record)
+}
+
+// Implements Store.
+func (ts *Store) Write() {
+	ts.parent.Write()
+}
+
+type iterator struct {
+	store  *Store
+	parent types.Iterator
+}
+
+// Implements Iterator.
+func (ti *iterator) Domain() (start []byte, end []byte) {
+	return ti.parent.Domain()
+}
+
+// Implements Iterator.
+func (ti *iterator) Valid() bool {
+	return ti.parent.Valid()
+}
+
+// Implements Iterator.
+func (ti *iterator) Next() {
+	ti.parent.Next()
+}
+
+// Implements Iterator.
+func (ti *iterator) Key() []byte {
+	return ti.parent.Key()
+}
+
+// Value records the pair read by the iterator.
+func (ti *iterator) Value() []byte {
+	value := ti.parent.Value()
+	ti.store.record(ti.store.name, OpIter, ti.parent.Key(), value)
+	return value
+}
+
+// Implements Iterator.
+func (ti *iterator) Error() error {
+	return ti.parent.Error()
+}
+
+// Implements Iterator.
+func (ti *iterator) Close() error {
+	return ti.parent.Close()
+}
+
+// MultiStore records the operations made on the stores of an underlying
+// MultiStore, named after their keys.
+type MultiStore struct {
+	parent types.MultiStore
+	record Recorder
+}
+
+// NewMultiStore returns a MultiStore recording the operations made on the
+// stores of parent, and of its cache wraps.
+func NewMultiStore(parent types.MultiStore, record Recorder) MultiStore {
+	return MultiStore{
+		parent: parent,
+		record: record,
+	}
+}
+
+// Implements MultiStore.
+func (tms MultiStore) GetStore(key types.StoreKey) types.Store {
+	return New(tms.parent.GetStore(key), key.Name(), tms.record)
+}
+
+// MultiCacheWrap cache-wraps the parent multi-store, and keeps tracing the
+// operations on the cache.
+func (tms MultiStore) MultiCacheWrap() types.MultiStore {
+	return NewMultiStore(tms.parent.MultiCacheWrap(), tms.record)
+}
+
+// Implements MultiStore.
+func (tms MultiStore) MultiWrite() {
+	tms.parent.MultiWrite()
+}
@@ -0,0 +1,72 @@
+package trace_test
+
+import (
+	"testing"
+
+	"github.com/gnolang/gno/tm2/pkg/db/memdb"
+	"github.com/gnolang/gno/tm2/pkg/store/dbadapter"
+	"github.com/gnolang/gno/tm2/pkg/store/trace"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+type op struct {
+	store, op  string
+	key, value string
+}
+
+func recorder(ops *[]op) trace.Recorder {
+	return func(store, o string, key, value []byte) {
+		*ops = append(*ops, op{store, o, string(key), string(value)})
+	}
+}
+
+func TestTraceStore(t *testing.T) {
+	t.Parallel()
+
+	var ops []op
+	mem := dbadapter.Store{DB: memdb.NewMemDB()}
+	st := trace.New(mem, "main", recorder(&ops))
+
+	assert.Nil(t, st.Get([]byte("a")))
+	st.Set([]byte("a"), []byte("1"))
+	st.Set([]byte("b"), []byte("2"))
+	assert.True(t, st.Has([]byte("a")))
+	st.Delete([]byte("b"))
+
+	it := st.Iterator(nil, nil)
+	for ; it.Valid(); it.Next() {
+		it.Value()
+	}
+	require.NoError(t, it.Close())
+
+	assert.Equal(t, []op{
+		{"main", trace.OpGet, "a", ""},
+		{"main", trace.OpSet, "a", "1"},
+		{"main", trace.OpSet, "b", "2"},
+		{"main", trace.OpHas, "a", ""},
+		{"main", trace.OpDelete, "b", ""},
+		{"main", trace.OpIter, "a", "1"},
+	}, ops)
+}
+
+func TestTraceStore_CacheWrap(t *testing.T) {
+	t.Parallel()
+
+	var ops []op
+	mem := dbadapter.Store{DB: memdb.NewMemDB()}
+	st := trace.New(mem, "main", recorder(&ops))
+
+	cache := st.CacheWrap()
+	cache.Set([]byte("a"), []byte("1"))
+	assert.Equal(t, []byte("1"), cache.Get([]byte("a")))
+	// writing the cache to the parent is not an operation of the caller.
+	cache.Write()
+
+	assert.Equal(t, []op{
+		{"main", trace.OpSet, "a", "1"},
+		{"main", trace.OpGet, "a", "1"},
+	}, ops)
+	assert.Equal(t, []byte("1"), mem.Get([]byte("a")))
+}
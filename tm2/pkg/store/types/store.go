@@ -3,6 +3,7 @@ package types
 import (
 	"bytes"
 	"fmt"
+	"io"
 
 	abci "github.com/gnolang/gno/tm2/pkg/bft/abci/types"
 	dbm "github.com/gnolang/gno/tm2/pkg/db"
@@ -134,6 +135,21 @@ type CommitMultiStore interface {
 	MultiImmutableCacheWrapWithVersion(version int64) (MultiStore, error)
 }
 
+// Snapshotter is implemented by CommitMultiStores which can export their state
+// at a committed version, and restore it into empty stores.
+type Snapshotter interface {
+	// Snapshot writes the state of the stores at version into w. Stores
+	// which are not versioned can only be exported as they are now, so
+	// they are written first; ready, if not nil, is called after them.
+	// From then on, new versions may be committed while the rest of the
+	// snapshot is written.
+	Snapshot(version int64, w io.Writer, ready func()) error
+
+	// Restore loads a snapshot written by Snapshot into the stores, which
+	// must be empty, and commits it as version.
+	Restore(version int64, r io.Reader) (CommitID, error)
+}
+
 // CommitID contains the tree version number and its merkle root.
 type CommitID struct {
 	Version int64
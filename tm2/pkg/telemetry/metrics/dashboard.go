@@ -0,0 +1,263 @@
+package metrics
+
+//go:generate go run ./gendashboard ../../../../misc/telemetry/grafana/provisioning/dashboards/gno-node-metrics-reference.json
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+const (
+	dashboardTitle = "Gno Node Metrics (reference)"
+	dashboardUID   = "gno-node-metrics-reference"
+
+	// datasourceUID is the UID of the Prometheus datasource
+	// the OTEL collector exports the metrics to
+	datasourceUID = "prometheus"
+
+	// instanceSelector filters the series by the node selected
+	// in the dashboard, identified by its service instance ID
+	instanceSelector = `{exported_instance=~"${node}"}`
+
+	panelWidth  = 12
+	panelHeight = 8
+)
+
+// histogramQuantiles are the quantiles displayed for histograms
+var histogramQuantiles = []float64{0.5, 0.95, 0.99}
+
+// Dashboard returns a Grafana dashboard displaying every metric of the
+// registry, as exported to Prometheus by the OTEL collector.
+// The metrics are displayed in one row per group
+func Dashboard() ([]byte, error) {
+	var (
+		panels []any
+		groups []string
+
+		byGroup = make(map[string][]Desc)
+	)
+
+	for _, d := range Registry {
+		if _, ok := byGroup[d.Group]; !ok {
+			groups = append(groups, d.Group)
+		}
+
+		byGroup[d.Group] = append(byGroup[d.Group], d)
+	}
+
+	id, y := 1, 0
+	for _, group := range groups {
+		panels = append(panels, map[string]any{
+			"collapsed": false,
+			"gridPos":   gridPos(0, y, 24, 1),
+			"id":        id,
+			"panels":    []any{},
+			"title":     group,
+			"type":      "row",
+		})
+		id++
+		y++
+
+		descs := byGroup[group]
+		for i, d := range descs {
+			x := (i % 2) * panelWidth
+			panels = append(panels, metricPanel(id, d, x, y+(i/2)*panelHeight))
+			id++
+		}
+
+		y += (len(descs) + 1) / 2 * panelHeight
+	}
+
+	dashboard := map[string]any{
+		"annotations":   map[string]any{"list": []any{}},
+		"description":   "Metrics exported by the Gno node, generated from tm2/pkg/telemetry/metrics",
+		"editable":      true,
+		"graphTooltip":  1,
+		"links":         []any{},
+		"panels":        panels,
+		"refresh":       "5s",
+		"schemaVersion": 39,
+		"tags":          []string{"gno"},
+		"templating": map[string]any{
+			"list": []any{
+				map[string]any{
+					"allValue":   ".*",
+					"datasource": datasource(),
+					"definition": "label_values(exported_instance)",
+					"includeAll": true,
+					"label":      "Node",
+					"name":       "node",
+					"query": map[string]any{
+						"qryType": 1,
+						"query":   "label_values(exported_instance)",
+						"refId":   "PrometheusVariableQueryEditor-VariableQuery",
+					},
+					"refresh": 1,
+					"type":    "query",
+				},
+			},
+		},
+		"time":     map[string]any{"from": "now-1h", "to": "now"},
+		"timezone": "",
+		"title":    dashboardTitle,
+		"uid":      dashboardUID,
+		"version":  1,
+	}
+
+	raw, err := json.MarshalIndent(dashboard, "", "  ")
+	if err != nil {
+		return nil, fmt.Errorf("unable to marshal dashboard, %w", err)
+	}
+
+	return append(raw, '\n'), nil
+}
+
+// metricPanel returns the time series panel of the given metric
+func metricPanel(id int, d Desc, x, y int) map[string]any {
+	title := d.Description
+	if d.Kind == KindCounter {
+		title += " (per second)"
+	}
+
+	qs := queries(d)
+	targets := make([]any, 0, len(qs))
+	for i, q := range qs {
+		targets = append(targets, map[string]any{
+			"datasource":   datasource(),
+			"editorMode":   "code",
+			"expr":         q.expr,
+			"legendFormat": q.legend,
+			"range":        true,
+			"refId":        string(rune('A' + i)),
+		})
+	}
+
+	return map[string]any{
+		"datasource":  datasource(),
+		"description": d.PrometheusName(),
+		"fieldConfig": map[string]any{
+			"defaults": map[string]any{
+				"unit": grafanaUnit(d),
+			},
+			"overrides": []any{},
+		},
+		"gridPos": gridPos(x, y, panelWidth, panelHeight),
+		"id":      id,
+		"options": map[string]any{
+			"legend": map[string]any{
+				"displayMode": "list",
+				"placement":   "bottom",
+				"showLegend":  true,
+			},
+			"tooltip": map[string]any{
+				"mode": "multi",
+				"sort": "none",
+			},
+		},
+		"targets": targets,
+		"title":   capitalize(title),
+		"type":    "timeseries",
+	}
+}
+
+type query struct {
+	expr   string
+	legend string
+}
+
+// queries returns the PromQL queries displaying the given metric:
+// quantiles for histograms, rates for counters and raw values for gauges
+func queries(d Desc) []query {
+	var (
+		name   = d.PrometheusName()
+		legend = legendFormat(d.Labels)
+	)
+
+	switch d.Kind {
+	case KindHistogram:
+		qs := make([]query, 0, len(histogramQuantiles))
+		for _, q := range histogramQuantiles {
+			qs = append(qs, query{
+				expr: fmt.Sprintf(
+					"histogram_quantile(%g, %s)",
+					q, sumBy(append([]string{"le"}, d.Labels...), rate(name+"_bucket")),
+				),
+				legend: strings.TrimSpace(fmt.Sprintf("p%g %s", q*100, legend)),
+			})
+		}
+
+		return qs
+	case KindCounter:
+		return []query{{
+			expr:   sumBy(d.Labels, rate(name)),
+			legend: legend,
+		}}
+	default:
+		return []query{{
+			expr:   name + instanceSelector,
+			legend: "{{exported_instance}}",
+		}}
+	}
+}
+
+// rate returns the PromQL per-second rate of the given series
+func rate(series string) string {
+	return fmt.Sprintf("rate(%s%s[$__rate_interval])", series, instanceSelector)
+}
+
+// sumBy returns the PromQL sum of expr, keeping the given labels
+func sumBy(labels []string, expr string) string {
+	if len(labels) == 0 {
+		return fmt.Sprintf("sum(%s)", expr)
+	}
+
+	return fmt.Sprintf("sum by (%s) (%s)", strings.Join(labels, ", "), expr)
+}
+
+// legendFormat returns the legend of the series of a metric
+// recorded with the given labels
+func legendFormat(labels []string) string {
+	parts := make([]string, 0, len(labels))
+	for _, label := range labels {
+		parts = append(parts, "{{"+label+"}}")
+	}
+
+	return strings.Join(parts, " ")
+}
+
+// grafanaUnit returns the Grafana unit of the values displayed for the metric
+func grafanaUnit(d Desc) string {
+	if d.Kind == KindCounter {
+		return "cps"
+	}
+
+	switch d.Unit {
+	case "ms", "s":
+		return d.Unit
+	case "B":
+		return "bytes"
+	default:
+		return "short"
+	}
+}
+
+func gridPos(x, y, w, h int) map[string]int {
+	return map[string]int{"h": h, "w": w, "x": x, "y": y}
+}
+
+func datasource() map[string]string {
+	return map[string]string{"type": "prometheus", "uid": datasourceUID}
+}
+
+func capitalize(s string) string {
+	if s == "" {
+		return s
+	}
+
+	r := []rune(s)
+	r[0] = unicode.ToUpper(r[0])
+
+	return string(r)
+}
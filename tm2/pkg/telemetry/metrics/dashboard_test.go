@@ -0,0 +1,78 @@
+package metrics
+
+import (
+	"encoding/json"
+	"os"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRegistry(t *testing.T) {
+	t.Parallel()
+
+	names := make(map[string]bool)
+	for _, d := range Registry {
+		assert.False(t, names[d.Name], "duplicate metric %q", d.Name)
+		names[d.Name] = true
+
+		assert.NotEmpty(t, d.Description, d.Name)
+		assert.NotEmpty(t, d.Group, d.Name)
+		assert.Contains(t, []Kind{KindCounter, KindGauge, KindHistogram}, d.Kind, d.Name)
+	}
+}
+
+func TestDesc_PrometheusName(t *testing.T) {
+	t.Parallel()
+
+	for _, tc := range []struct {
+		desc     Desc
+		expected string
+	}{
+		{Desc{Name: "num_mempool_txs_hist", Kind: KindHistogram}, "num_mempool_txs_hist"},
+		{Desc{Name: "build_block_hist", Unit: "ms", Kind: KindHistogram}, "build_block_hist_milliseconds"},
+		{Desc{Name: "block_interval_hist", Unit: "s", Kind: KindHistogram}, "block_interval_hist_seconds"},
+		{Desc{Name: "block_size_hist", Unit: "B", Kind: KindHistogram}, "block_size_hist_B"},
+		{Desc{Name: "vm_exec_msg_counter", Kind: KindCounter}, "vm_exec_msg_counter_total"},
+		{Desc{Name: "inbound_peers_gauge", Kind: KindGauge}, "inbound_peers_gauge"},
+	} {
+		assert.Equal(t, tc.expected, tc.desc.PrometheusName())
+	}
+}
+
+func TestDashboard(t *testing.T) {
+	t.Parallel()
+
+	raw, err := Dashboard()
+	require.NoError(t, err)
+
+	var dashboard struct {
+		Panels []struct {
+			Type    string `json:"type"`
+			Title   string `json:"title"`
+			Targets []struct {
+				Expr string `json:"expr"`
+			} `json:"targets"`
+		} `json:"panels"`
+	}
+	require.NoError(t, json.Unmarshal(raw, &dashboard))
+
+	// Every metric of the registry has its panel
+	var metricPanels int
+	for _, p := range dashboard.Panels {
+		if p.Type == "row" {
+			continue
+		}
+
+		require.NotEmpty(t, p.Targets, p.Title)
+		metricPanels++
+	}
+	assert.Equal(t, len(Registry), metricPanels)
+
+	// The reference dashboard is up to date with the registry
+	// (regenerate it with go generate)
+	committed, err := os.ReadFile("../../../../misc/telemetry/grafana/provisioning/dashboards/gno-node-metrics-reference.json")
+	require.NoError(t, err)
+	assert.Equal(t, string(raw), string(committed))
+}
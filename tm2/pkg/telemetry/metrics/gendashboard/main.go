@@ -0,0 +1,28 @@
+// Command gendashboard writes the reference Grafana dashboard of the node
+// metrics, generated from the metrics registry, to the given file.
+package main
+
+import (
+	"fmt"
+	"os"
+
+	"github.com/gnolang/gno/tm2/pkg/telemetry/metrics"
+)
+
+func main() {
+	if len(os.Args) != 2 {
+		fmt.Fprintln(os.Stderr, "usage: gendashboard <output.json>")
+		os.Exit(2)
+	}
+
+	dashboard, err := metrics.Dashboard()
+	if err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+
+	if err := os.WriteFile(os.Args[1], dashboard, 0o644); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
@@ -15,28 +15,160 @@ import (
 	semconv "go.opentelemetry.io/otel/semconv/v1.4.0"
 )
 
-const (
-	buildBlockTimerKey = "build_block_hist"
-
-	inboundPeersKey  = "inbound_peers_gauge"
-	outboundPeersKey = "outbound_peers_gauge"
-
-	numMempoolTxsKey = "num_mempool_txs_hist"
-	numCachedTxsKey  = "num_cached_txs_hist"
-
-	vmExecMsgKey   = "vm_exec_msg_counter"
-	vmGasUsedKey   = "vm_gas_used_hist"
-	vmCPUCyclesKey = "vm_cpu_cycles_hist"
-
-	validatorCountKey       = "validator_count_hist"
-	validatorVotingPowerKey = "validator_vp_hist"
-	blockIntervalKey        = "block_interval_hist"
-	blockTxsKey             = "block_txs_hist"
-	blockSizeKey            = "block_size_hist"
-	gasPriceKey             = "block_gas_price_hist"
-
-	httpRequestTimeKey = "http_request_time_hist"
-	wsRequestTimeKey   = "ws_request_time_hist"
+var (
+	inboundPeersDesc = register(Desc{
+		Name:        "inbound_peers_gauge",
+		Description: "inbound peer count",
+		Kind:        KindGauge,
+		Group:       groupNetworking,
+	})
+	outboundPeersDesc = register(Desc{
+		Name:        "outbound_peers_gauge",
+		Description: "outbound peer count",
+		Kind:        KindGauge,
+		Group:       groupNetworking,
+	})
+
+	numMempoolTxsDesc = register(Desc{
+		Name:        "num_mempool_txs_hist",
+		Description: "valid mempool transaction count",
+		Kind:        KindHistogram,
+		Group:       groupMempool,
+	})
+	numCachedTxsDesc = register(Desc{
+		Name:        "num_cached_txs_hist",
+		Description: "cached mempool transaction count",
+		Kind:        KindHistogram,
+		Group:       groupMempool,
+	})
+	mempoolAddedTxsDesc = register(Desc{
+		Name:        "mempool_added_txs_counter",
+		Description: "transactions added to the mempool",
+		Kind:        KindCounter,
+		Group:       groupMempool,
+	})
+	mempoolEvictedTxsDesc = register(Desc{
+		Name:        "mempool_evicted_txs_counter",
+		Description: "transactions removed from the mempool",
+		Kind:        KindCounter,
+		Group:       groupMempool,
+		Labels:      []string{"reason"},
+	})
+
+	vmExecMsgDesc = register(Desc{
+		Name:        "vm_exec_msg_counter",
+		Description: "vm msg operation call frequency",
+		Kind:        KindCounter,
+		Group:       groupRuntime,
+		Labels:      []string{"operation"},
+	})
+	vmGasUsedDesc = register(Desc{
+		Name:        "vm_gas_used_hist",
+		Description: "VM gas used",
+		Kind:        KindHistogram,
+		Group:       groupRuntime,
+		Labels:      []string{"operation"},
+	})
+	vmCPUCyclesDesc = register(Desc{
+		Name:        "vm_cpu_cycles_hist",
+		Description: "VM CPU cycles",
+		Kind:        KindHistogram,
+		Group:       groupRuntime,
+		Labels:      []string{"operation"},
+	})
+
+	buildBlockTimerDesc = register(Desc{
+		Name:        "build_block_hist",
+		Description: "block build duration",
+		Unit:        "ms",
+		Kind:        KindHistogram,
+		Group:       groupConsensus,
+	})
+	validatorCountDesc = register(Desc{
+		Name:        "validator_count_hist",
+		Description: "size of the active validator set",
+		Kind:        KindHistogram,
+		Group:       groupConsensus,
+	})
+	validatorVotingPowerDesc = register(Desc{
+		Name:        "validator_vp_hist",
+		Description: "total voting power of the active validator set",
+		Kind:        KindHistogram,
+		Group:       groupConsensus,
+	})
+	blockIntervalDesc = register(Desc{
+		Name:        "block_interval_hist",
+		Description: "interval between 2 subsequent blocks",
+		Unit:        "s",
+		Kind:        KindHistogram,
+		Group:       groupConsensus,
+	})
+	blockTxsDesc = register(Desc{
+		Name:        "block_txs_hist",
+		Description: "number of transactions within the latest block",
+		Kind:        KindHistogram,
+		Group:       groupConsensus,
+	})
+	blockSizeDesc = register(Desc{
+		Name:        "block_size_hist",
+		Description: "size of the latest block in bytes",
+		Unit:        "B",
+		Kind:        KindHistogram,
+		Group:       groupConsensus,
+	})
+	gasPriceDesc = register(Desc{
+		Name:        "block_gas_price_hist",
+		Description: "block gas price",
+		Unit:        "token",
+		Kind:        KindHistogram,
+		Group:       groupConsensus,
+		Labels:      []string{"func"},
+	})
+	roundDurationDesc = register(Desc{
+		Name:        "consensus_round_duration_hist",
+		Description: "duration of a consensus round, until the commit or the next round",
+		Unit:        "ms",
+		Kind:        KindHistogram,
+		Group:       groupConsensus,
+	})
+	stepDurationDesc = register(Desc{
+		Name:        "consensus_step_duration_hist",
+		Description: "duration of a consensus round step",
+		Unit:        "ms",
+		Kind:        KindHistogram,
+		Group:       groupConsensus,
+		Labels:      []string{"step"},
+	})
+	voteSpreadDesc = register(Desc{
+		Name:        "consensus_vote_spread_hist",
+		Description: "delay between the arrival of a vote and of the first vote of the same type and round",
+		Unit:        "ms",
+		Kind:        KindHistogram,
+		Group:       groupConsensus,
+		Labels:      []string{"type"},
+	})
+	timeoutsDesc = register(Desc{
+		Name:        "consensus_timeouts_counter",
+		Description: "consensus timeouts triggering a step transition",
+		Kind:        KindCounter,
+		Group:       groupConsensus,
+		Labels:      []string{"step"},
+	})
+
+	httpRequestTimeDesc = register(Desc{
+		Name:        "http_request_time_hist",
+		Description: "http request response time",
+		Unit:        "ms",
+		Kind:        KindHistogram,
+		Group:       groupRPC,
+	})
+	wsRequestTimeDesc = register(Desc{
+		Name:        "ws_request_time_hist",
+		Description: "ws request response time",
+		Unit:        "ms",
+		Kind:        KindHistogram,
+		Group:       groupRPC,
+	})
 )
 
 var (
@@ -56,6 +188,13 @@ var (
 	// NumCachedTxs measures the number of transaction inside the mempool cache
 	NumCachedTxs metric.Int64Histogram
 
+	// MempoolAddedTxs counts the transactions added to the mempool
+	MempoolAddedTxs metric.Int64Counter
+
+	// MempoolEvictedTxs counts the transactions removed from the mempool,
+	// by reason (committed or invalidated)
+	MempoolEvictedTxs metric.Int64Counter
+
 	// Runtime //
 
 	// VMExecMsgFrequency measures the frequency of VM operations
@@ -90,6 +229,19 @@ var (
 	// BlockGasPriceAmount measures the block gas price of the last block
 	BlockGasPriceAmount metric.Int64Histogram
 
+	// RoundDuration measures the duration of the consensus rounds
+	RoundDuration metric.Int64Histogram
+
+	// StepDuration measures the duration of the consensus round steps, by step
+	StepDuration metric.Int64Histogram
+
+	// VoteSpread measures the delay between the arrival of a vote and of the
+	// first vote of the same type and round, by vote type
+	VoteSpread metric.Int64Histogram
+
+	// Timeouts counts the consensus timeouts triggering a step transition, by step
+	Timeouts metric.Int64Counter
+
 	// RPC //
 
 	// HTTPRequestTime measures the HTTP request response time
@@ -146,131 +298,102 @@ func Init(config config.Config) error {
 	otel.SetMeterProvider(provider)
 	meter := provider.Meter(config.MeterName)
 
-	if BuildBlockTimer, err = meter.Int64Histogram(
-		buildBlockTimerKey,
-		metric.WithDescription("block build duration"),
-		metric.WithUnit("ms"),
-	); err != nil {
+	if BuildBlockTimer, err = newInt64Histogram(meter, buildBlockTimerDesc); err != nil {
 		return fmt.Errorf("unable to create histogram, %w", err)
 	}
 
 	// Networking //
-	if InboundPeers, err = meter.Int64Gauge(
-		inboundPeersKey,
-		metric.WithDescription("inbound peer count"),
-	); err != nil {
-		return fmt.Errorf("unable to create histogram, %w", err)
+	if InboundPeers, err = newInt64Gauge(meter, inboundPeersDesc); err != nil {
+		return fmt.Errorf("unable to create gauge, %w", err)
 	}
+
 	// Initialize InboundPeers Gauge
 	InboundPeers.Record(ctx, 0)
 
-	if OutboundPeers, err = meter.Int64Gauge(
-		outboundPeersKey,
-		metric.WithDescription("outbound peer count"),
-	); err != nil {
-		return fmt.Errorf("unable to create histogram, %w", err)
+	if OutboundPeers, err = newInt64Gauge(meter, outboundPeersDesc); err != nil {
+		return fmt.Errorf("unable to create gauge, %w", err)
 	}
 
 	// Initialize OutboundPeers Gauge
 	OutboundPeers.Record(ctx, 0)
 
 	// Mempool //
-	if NumMempoolTxs, err = meter.Int64Histogram(
-		numMempoolTxsKey,
-		metric.WithDescription("valid mempool transaction count"),
-	); err != nil {
+	if NumMempoolTxs, err = newInt64Histogram(meter, numMempoolTxsDesc); err != nil {
 		return fmt.Errorf("unable to create histogram, %w", err)
 	}
 
-	if NumCachedTxs, err = meter.Int64Histogram(
-		numCachedTxsKey,
-		metric.WithDescription("cached mempool transaction count"),
-	); err != nil {
+	if NumCachedTxs, err = newInt64Histogram(meter, numCachedTxsDesc); err != nil {
 		return fmt.Errorf("unable to create histogram, %w", err)
 	}
 
+	if MempoolAddedTxs, err = newInt64Counter(meter, mempoolAddedTxsDesc); err != nil {
+		return fmt.Errorf("unable to create counter, %w", err)
+	}
+
+	if MempoolEvictedTxs, err = newInt64Counter(meter, mempoolEvictedTxsDesc); err != nil {
+		return fmt.Errorf("unable to create counter, %w", err)
+	}
+
 	// Runtime //
-	if VMExecMsgFrequency, err = meter.Int64Counter(
-		vmExecMsgKey,
-		metric.WithDescription("vm msg operation call frequency"),
-	); err != nil {
+	if VMExecMsgFrequency, err = newInt64Counter(meter, vmExecMsgDesc); err != nil {
 		return fmt.Errorf("unable to create counter, %w", err)
 	}
 
-	if VMGasUsed, err = meter.Int64Histogram(
-		vmGasUsedKey,
-		metric.WithDescription("VM gas used"),
-	); err != nil {
+	if VMGasUsed, err = newInt64Histogram(meter, vmGasUsedDesc); err != nil {
 		return fmt.Errorf("unable to create histogram, %w", err)
 	}
 
-	if VMCPUCycles, err = meter.Int64Histogram(
-		vmCPUCyclesKey,
-		metric.WithDescription("VM CPU cycles"),
-	); err != nil {
+	if VMCPUCycles, err = newInt64Histogram(meter, vmCPUCyclesDesc); err != nil {
 		return fmt.Errorf("unable to create histogram, %w", err)
 	}
 
 	// Consensus //
-	if ValidatorsCount, err = meter.Int64Histogram(
-		validatorCountKey,
-		metric.WithDescription("size of the active validator set"),
-	); err != nil {
+	if ValidatorsCount, err = newInt64Histogram(meter, validatorCountDesc); err != nil {
 		return fmt.Errorf("unable to create histogram, %w", err)
 	}
 
-	if ValidatorsVotingPower, err = meter.Int64Histogram(
-		validatorVotingPowerKey,
-		metric.WithDescription("total voting power of the active validator set"),
-	); err != nil {
+	if ValidatorsVotingPower, err = newInt64Histogram(meter, validatorVotingPowerDesc); err != nil {
 		return fmt.Errorf("unable to create histogram, %w", err)
 	}
 
-	if BlockInterval, err = meter.Int64Histogram(
-		blockIntervalKey,
-		metric.WithDescription("interval between 2 subsequent blocks"),
-		metric.WithUnit("s"),
-	); err != nil {
+	if BlockInterval, err = newInt64Histogram(meter, blockIntervalDesc); err != nil {
 		return fmt.Errorf("unable to create histogram, %w", err)
 	}
 
-	if BlockTxs, err = meter.Int64Histogram(
-		blockTxsKey,
-		metric.WithDescription("number of transactions within the latest block"),
-	); err != nil {
+	if BlockTxs, err = newInt64Histogram(meter, blockTxsDesc); err != nil {
 		return fmt.Errorf("unable to create histogram, %w", err)
 	}
 
-	if BlockSizeBytes, err = meter.Int64Histogram(
-		blockSizeKey,
-		metric.WithDescription("size of the latest block in bytes"),
-		metric.WithUnit("B"),
-	); err != nil {
+	if BlockSizeBytes, err = newInt64Histogram(meter, blockSizeDesc); err != nil {
 		return fmt.Errorf("unable to create histogram, %w", err)
 	}
 
-	if BlockGasPriceAmount, err = meter.Int64Histogram(
-		gasPriceKey,
-		metric.WithDescription("block gas price"),
-		metric.WithUnit("token"),
-	); err != nil {
+	if BlockGasPriceAmount, err = newInt64Histogram(meter, gasPriceDesc); err != nil {
 		return fmt.Errorf("unable to create histogram, %w", err)
 	}
-	// RPC //
 
-	if HTTPRequestTime, err = meter.Int64Histogram(
-		httpRequestTimeKey,
-		metric.WithDescription("http request response time"),
-		metric.WithUnit("ms"),
-	); err != nil {
+	if RoundDuration, err = newInt64Histogram(meter, roundDurationDesc); err != nil {
+		return fmt.Errorf("unable to create histogram, %w", err)
+	}
+
+	if StepDuration, err = newInt64Histogram(meter, stepDurationDesc); err != nil {
+		return fmt.Errorf("unable to create histogram, %w", err)
+	}
+
+	if VoteSpread, err = newInt64Histogram(meter, voteSpreadDesc); err != nil {
+		return fmt.Errorf("unable to create histogram, %w", err)
+	}
+
+	if Timeouts, err = newInt64Counter(meter, timeoutsDesc); err != nil {
+		return fmt.Errorf("unable to create counter, %w", err)
+	}
+
+	// RPC //
+	if HTTPRequestTime, err = newInt64Histogram(meter, httpRequestTimeDesc); err != nil {
 		return fmt.Errorf("unable to create histogram, %w", err)
 	}
 
-	if WSRequestTime, err = meter.Int64Histogram(
-		wsRequestTimeKey,
-		metric.WithDescription("ws request response time"),
-		metric.WithUnit("ms"),
-	); err != nil {
+	if WSRequestTime, err = newInt64Histogram(meter, wsRequestTimeDesc); err != nil {
 		return fmt.Errorf("unable to create histogram, %w", err)
 	}
 
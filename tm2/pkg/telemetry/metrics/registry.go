@@ -0,0 +1,91 @@
+package metrics
+
+import (
+	"go.opentelemetry.io/otel/metric"
+)
+
+// Kind is the kind of instrument a metric is recorded with
+type Kind string
+
+const (
+	KindCounter   Kind = "counter"
+	KindGauge     Kind = "gauge"
+	KindHistogram Kind = "histogram"
+)
+
+// Dashboard rows the metrics are grouped in
+const (
+	groupNetworking = "Networking"
+	groupMempool    = "Mempool"
+	groupRuntime    = "VM"
+	groupConsensus  = "Consensus"
+	groupRPC        = "JSON-RPC"
+)
+
+// Desc describes a metric exported by the node
+type Desc struct {
+	Name        string   // instrument name
+	Description string   // instrument description
+	Unit        string   // instrument unit, if any
+	Kind        Kind     // instrument kind
+	Group       string   // dashboard row of the metric
+	Labels      []string // attributes the metric is recorded with
+}
+
+// Registry lists the metrics exported by the node,
+// in the order they are declared
+var Registry []Desc
+
+// register adds the metric to the registry
+func register(d Desc) Desc {
+	Registry = append(Registry, d)
+
+	return d
+}
+
+// PrometheusName returns the name of the metric once converted
+// to Prometheus by the OTEL collector, which appends the unit
+// to the name, and the "_total" suffix to counters
+func (d Desc) PrometheusName() string {
+	name := d.Name
+
+	switch d.Unit {
+	case "":
+	case "ms":
+		name += "_milliseconds"
+	case "s":
+		name += "_seconds"
+	default:
+		name += "_" + d.Unit
+	}
+
+	if d.Kind == KindCounter {
+		name += "_total"
+	}
+
+	return name
+}
+
+func newInt64Histogram(meter metric.Meter, d Desc) (metric.Int64Histogram, error) {
+	return meter.Int64Histogram(
+		d.Name,
+		metric.WithDescription(d.Description),
+		metric.WithUnit(d.Unit),
+	)
+}
+
+func newInt64Counter(meter metric.Meter, d Desc) (metric.Int64Counter, error) {
+	return meter.Int64Counter(
+		d.Name,
+		metric.WithDescription(d.Description),
+		metric.WithUnit(d.Unit),
+	)
+}
+
+func newInt64Gauge(meter metric.Meter, d Desc) (metric.Int64Gauge, error) {
+	return meter.Int64Gauge(
+		d.Name,
+		metric.WithDescription(d.Description),
+		metric.WithUnit(d.Unit),
+	)
+}